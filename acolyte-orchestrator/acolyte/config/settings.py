@@ -39,6 +39,10 @@ class Settings(BaseSettings):
     # External services
     news_creator_url: str = "http://news-creator:11434"
     search_indexer_url: str = "http://search-indexer:9300"
+    # Sent as X-API-Key; search-indexer's plaintext :9300 listener requires
+    # it outside development. The _file variant wins when both are set.
+    search_indexer_api_key: str = ""
+    search_indexer_api_key_file: str = ""
 
     # DB pool
     db_pool_min_size: int = 2
@@ -144,6 +148,17 @@ class Settings(BaseSettings):
 
         return {"en": self.language_quota_en}
 
+    def resolve_search_indexer_api_key(self) -> str:
+        """Resolve the search-indexer API key, reading it from file if configured."""
+        if self.search_indexer_api_key_file:
+            try:
+                return Path(self.search_indexer_api_key_file).read_text().strip()
+            except OSError as exc:
+                raise RuntimeError(  # noqa: TRY003 — fail-fast startup config error, single call site
+                    f"Failed to read search_indexer_api_key_file={self.search_indexer_api_key_file!r}: {exc}"
+                ) from exc
+        return self.search_indexer_api_key
+
     def resolve_db_dsn(self) -> str:
         """Resolve DB DSN, replacing password from file if configured."""
         if self.acolyte_db_password_file:
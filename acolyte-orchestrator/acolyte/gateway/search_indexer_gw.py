@@ -51,6 +51,8 @@ class SearchIndexerGateway:
     def __init__(self, http_client: httpx.AsyncClient, settings: Settings, content_store: ContentStorePort) -> None:
         self._client = http_client
         self._base_url = settings.search_indexer_url
+        api_key = settings.resolve_search_indexer_api_key()
+        self._headers = {"X-API-Key": api_key} if api_key else {}
         self._content_store = content_store
 
     async def search_articles(
@@ -64,7 +66,7 @@ class SearchIndexerGateway:
         """Search articles via GET /v1/search.
 
         Stores content in ContentStore; returns metadata-only ArticleHit.
-        Authentication is established at the TLS transport layer (mTLS).
+        The configured API key is sent as ``X-API-Key``.
 
         When ``published_after`` or ``published_before`` is supplied, the
         corresponding ISO 8601 timestamp is forwarded so search-indexer can
@@ -79,6 +81,7 @@ class SearchIndexerGateway:
         resp = await self._client.get(
             f"{self._base_url}/v1/search",
             params=params,
+            headers=self._headers,
         )
         resp.raise_for_status()
         data = resp.json()
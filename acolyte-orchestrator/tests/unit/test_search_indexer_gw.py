@@ -91,6 +91,23 @@ async def test_search_articles_stores_content_in_content_store(
     assert body2 == "Another article about technology."
 
 
+@pytest.mark.asyncio
+async def test_search_articles_sends_api_key(content_store: MemoryContentStore) -> None:
+    """A configured API key is sent as X-API-Key; none is sent otherwise."""
+    seen: list[str | None] = []
+
+    def handler(request: httpx.Request) -> httpx.Response:
+        seen.append(request.headers.get("X-API-Key"))
+        return httpx.Response(200, json={"query": "AI", "hits": []})
+
+    for key in ("k-acolyte", ""):
+        settings = Settings(search_indexer_url="http://fake:9300", search_indexer_api_key=key)
+        async with httpx.AsyncClient(transport=httpx.MockTransport(handler)) as client:
+            await SearchIndexerGateway(client, settings, content_store).search_articles("AI")
+
+    assert seen == ["k-acolyte", None]
+
+
 @pytest.mark.asyncio
 async def test_search_articles_empty_response(settings: Settings, content_store: MemoryContentStore) -> None:
     def handler(request: httpx.Request) -> httpx.Response:
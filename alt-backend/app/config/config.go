@@ -27,6 +27,10 @@ type Config struct {
 	AdminMonitor  AdminMonitorConfig  `json:"admin_monitor"`
 	Sovereign     SovereignConfig     `json:"sovereign"`
 	Meilisearch   MeilisearchConfig   `json:"meilisearch"`
+	ShareLink     ShareLinkConfig     `json:"share_link"`
+	Security      SecurityConfig      `json:"security"`
+	FeatureFlags  FeatureFlagConfig   `json:"feature_flags"`
+	Timeline      TimelineConfig      `json:"timeline"`
 
 	// AppEnv drives fail-fast-in-production checks (e.g. Knowledge Sovereign
 	// wiring). "production" is the only value that turns missing-required-config
@@ -103,6 +107,33 @@ type ImageProxyConfig struct {
 	WebPQuality int    `json:"webp_quality" env:"IMAGE_PROXY_WEBP_QUALITY" default:"80"`
 }
 
+// ShareLinkConfig holds configuration for public article share links.
+// Share tokens are HMAC-signed with Secret; without one the share
+// endpoints are not registered.
+type ShareLinkConfig struct {
+	Secret     string        `json:"-" env:"SHARE_LINK_SECRET"`
+	SecretFile string        `json:"-" env:"SHARE_LINK_SECRET_FILE"`
+	BaseURL    string        `json:"base_url" env:"SHARE_LINK_BASE_URL" default:"https://curionoah.com/share"`
+	DefaultTTL time.Duration `json:"default_ttl" env:"SHARE_LINK_DEFAULT_TTL" default:"168h"`
+	MaxTTL     time.Duration `json:"max_ttl" env:"SHARE_LINK_MAX_TTL" default:"720h"`
+}
+
+// FeatureFlagConfig holds configuration for the database-backed feature
+// flag service. An empty Environment selects the flags stored for AppEnv.
+type FeatureFlagConfig struct {
+	Environment string        `json:"environment" env:"FEATURE_FLAG_ENVIRONMENT" default:""`
+	CacheTTL    time.Duration `json:"cache_ttl" env:"FEATURE_FLAG_CACHE_TTL" default:"30s"`
+}
+
+// TimelineConfig holds limits for the live timeline stream
+// (GET /v1/timeline/stream).
+type TimelineConfig struct {
+	MaxStreams        int           `json:"max_streams" env:"TIMELINE_MAX_STREAMS" default:"1000"`
+	MaxStreamsPerUser int           `json:"max_streams_per_user" env:"TIMELINE_MAX_STREAMS_PER_USER" default:"5"`
+	HeartbeatInterval time.Duration `json:"heartbeat_interval" env:"TIMELINE_HEARTBEAT_INTERVAL" default:"25s"`
+	ReplayBufferSize  int           `json:"replay_buffer_size" env:"TIMELINE_REPLAY_BUFFER_SIZE" default:"1024"`
+}
+
 // KnowledgeHomeConfig holds configuration for Knowledge Home feature flags.
 type KnowledgeHomeConfig struct {
 	EnableHomePage     bool   `json:"enable_home_page" env:"KNOWLEDGE_HOME_ENABLE_PAGE" default:"false"`
@@ -158,7 +189,48 @@ type ServerConfig struct {
 	WriteTimeout       time.Duration `json:"write_timeout" env:"SERVER_WRITE_TIMEOUT" default:"300s"`
 	IdleTimeout        time.Duration `json:"idle_timeout" env:"SERVER_IDLE_TIMEOUT" default:"120s"`
 	SSEInterval        time.Duration `json:"sse_interval" env:"SERVER_SSE_INTERVAL" default:"5s"`
-	CORSAllowedOrigins []string      `json:"cors_allowed_origins" env:"CORS_ALLOWED_ORIGINS" default:"http://localhost:3000,http://localhost:80,http://localhost:4173,https://curionoah.com"`
+	CORSAllowedOrigins []string      `json:"cors_allowed_origins" env:"CORS_ALLOWED_ORIGINS"` // /v1 API group; unset takes the APP_ENV default (applySecurityDefaults)
+}
+
+// SecurityConfig holds the per-route-group browser security policy applied
+// in rest/security_middleware.go. The /v1 API group takes its CORS origins
+// from ServerConfig.CORSAllowedOrigins; the admin and image proxy groups
+// fall back to them when unset. /v1/internal never answers CORS.
+type SecurityConfig struct {
+	AdminCORSAllowedOrigins      []string `json:"admin_cors_allowed_origins" env:"ADMIN_CORS_ALLOWED_ORIGINS"`
+	ImageProxyCORSAllowedOrigins []string `json:"image_proxy_cors_allowed_origins" env:"IMAGE_PROXY_CORS_ALLOWED_ORIGINS"`
+
+	// APIContentSecurityPolicy and APIFrameOptions apply to every group but
+	// the image proxy, which serves raw image bytes instead of JSON.
+	APIContentSecurityPolicy        string `json:"api_content_security_policy" env:"API_CONTENT_SECURITY_POLICY" default:"default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'self'; report-uri /security/csp-report"`
+	APIFrameOptions                 string `json:"api_frame_options" env:"API_FRAME_OPTIONS" default:"DENY"`
+	ImageProxyContentSecurityPolicy string `json:"image_proxy_content_security_policy" env:"IMAGE_PROXY_CONTENT_SECURITY_POLICY" default:"default-src 'none'; frame-ancestors 'none'; sandbox; report-uri /security/csp-report"`
+	ImageProxyFrameOptions          string `json:"image_proxy_frame_options" env:"IMAGE_PROXY_FRAME_OPTIONS" default:"DENY"`
+}
+
+// Default API CORS origins per APP_ENV. Production only admits the public
+// site; other environments also admit the local dev servers.
+var (
+	productionCORSAllowedOrigins  = []string{"https://curionoah.com"}
+	developmentCORSAllowedOrigins = []string{"http://localhost:3000", "http://localhost:80", "http://localhost:4173", "https://curionoah.com"}
+)
+
+// applySecurityDefaults fills origin lists left unset with the APP_ENV
+// default, then lets the admin and image proxy groups inherit the API list.
+func applySecurityDefaults(config *Config) {
+	if len(config.Server.CORSAllowedOrigins) == 0 {
+		defaults := developmentCORSAllowedOrigins
+		if config.AppEnv == "production" {
+			defaults = productionCORSAllowedOrigins
+		}
+		config.Server.CORSAllowedOrigins = append([]string(nil), defaults...)
+	}
+	if len(config.Security.AdminCORSAllowedOrigins) == 0 {
+		config.Security.AdminCORSAllowedOrigins = config.Server.CORSAllowedOrigins
+	}
+	if len(config.Security.ImageProxyCORSAllowedOrigins) == 0 {
+		config.Security.ImageProxyCORSAllowedOrigins = config.Server.CORSAllowedOrigins
+	}
 }
 
 type RateLimitConfig struct {
@@ -195,6 +267,9 @@ type DatabaseConfig struct {
 type CacheConfig struct {
 	FeedCacheExpiry   time.Duration `json:"feed_cache_expiry" env:"CACHE_FEED_EXPIRY" default:"300s"`
 	SearchCacheExpiry time.Duration `json:"search_cache_expiry" env:"CACHE_SEARCH_EXPIRY" default:"900s"`
+	// ETagRevalidateWindow is how long a remembered ETag may answer
+	// If-None-Match without re-running the handler. 0 disables the shortcut.
+	ETagRevalidateWindow time.Duration `json:"etag_revalidate_window" env:"CACHE_ETAG_REVALIDATE_WINDOW" default:"30s"`
 }
 
 type LoggingConfig struct {
@@ -217,6 +292,7 @@ func NewConfig() (*Config, error) {
 	if err := loadFromEnvironment(config); err != nil {
 		return nil, err
 	}
+	applySecurityDefaults(config)
 
 	if err := validateConfig(config); err != nil {
 		return nil, err
@@ -230,6 +306,14 @@ func NewConfig() (*Config, error) {
 		}
 	}
 
+	// Load share link secret from file if configured (Docker Secrets support)
+	if config.ShareLink.SecretFile != "" {
+		content, err := os.ReadFile(config.ShareLink.SecretFile)
+		if err == nil {
+			config.ShareLink.Secret = strings.TrimSpace(string(content))
+		}
+	}
+
 	// Load backend token secret from file if configured (Docker Secrets support)
 	if config.Auth.BackendTokenSecretFile != "" {
 		content, err := os.ReadFile(config.Auth.BackendTokenSecretFile)
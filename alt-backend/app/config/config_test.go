@@ -39,6 +39,17 @@ func TestNewConfig_WithDefaults(t *testing.T) {
 					Level:  "info",
 					Format: "json",
 				},
+				ShareLink: ShareLinkConfig{
+					BaseURL:    "https://curionoah.com/share",
+					DefaultTTL: 7 * 24 * time.Hour,
+					MaxTTL:     30 * 24 * time.Hour,
+				},
+				Timeline: TimelineConfig{
+					MaxStreams:        1000,
+					MaxStreamsPerUser: 5,
+					HeartbeatInterval: 25 * time.Second,
+					ReplayBufferSize:  1024,
+				},
 			},
 		},
 	}
@@ -76,6 +87,16 @@ func TestNewConfig_WithDefaults(t *testing.T) {
 			if config.RateLimit.ExternalAPIInterval != tt.expected.RateLimit.ExternalAPIInterval {
 				t.Errorf("RateLimit.ExternalAPIInterval = %v, want %v", config.RateLimit.ExternalAPIInterval, tt.expected.RateLimit.ExternalAPIInterval)
 			}
+
+			// Verify share link config
+			if config.ShareLink != tt.expected.ShareLink {
+				t.Errorf("ShareLink = %+v, want %+v", config.ShareLink, tt.expected.ShareLink)
+			}
+
+			// Verify timeline config
+			if config.Timeline != tt.expected.Timeline {
+				t.Errorf("Timeline = %+v, want %+v", config.Timeline, tt.expected.Timeline)
+			}
 		})
 	}
 }
@@ -181,6 +202,23 @@ func TestConfigValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "log level must be one of: debug, info, warn, error",
 		},
+		{
+			name: "timeline per-user streams above total",
+			envVars: map[string]string{
+				"TIMELINE_MAX_STREAMS":          "4",
+				"TIMELINE_MAX_STREAMS_PER_USER": "5",
+			},
+			wantErr: true,
+			errMsg:  "max streams per user must be <= max streams",
+		},
+		{
+			name: "timeline heartbeat too short",
+			envVars: map[string]string{
+				"TIMELINE_HEARTBEAT_INTERVAL": "100ms",
+			},
+			wantErr: true,
+			errMsg:  "heartbeat interval must be at least 1s",
+		},
 	}
 
 	for _, tt := range tests {
@@ -215,9 +253,11 @@ func clearTestEnv() {
 		"SERVER_PORT", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT", "SERVER_IDLE_TIMEOUT",
 		"DB_MAX_CONNECTIONS", "DB_CONNECTION_TIMEOUT",
 		"RATE_LIMIT_EXTERNAL_API_INTERVAL", "RATE_LIMIT_FEED_FETCH_LIMIT",
-		"CACHE_FEED_EXPIRY", "CACHE_SEARCH_EXPIRY",
+		"CACHE_FEED_EXPIRY", "CACHE_SEARCH_EXPIRY", "CACHE_ETAG_REVALIDATE_WINDOW",
 		"LOG_LEVEL", "LOG_FORMAT",
 		"PRE_PROCESSOR_ENABLED",
+		"SHARE_LINK_SECRET", "SHARE_LINK_BASE_URL", "SHARE_LINK_DEFAULT_TTL", "SHARE_LINK_MAX_TTL",
+		"TIMELINE_MAX_STREAMS", "TIMELINE_MAX_STREAMS_PER_USER", "TIMELINE_HEARTBEAT_INTERVAL", "TIMELINE_REPLAY_BUFFER_SIZE",
 	}
 
 	for _, env := range envVars {
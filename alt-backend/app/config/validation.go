@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -40,6 +41,18 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("knowledge home config validation failed: %w", err)
 	}
 
+	if err := validateShareLinkConfig(&config.ShareLink); err != nil {
+		return fmt.Errorf("share link config validation failed: %w", err)
+	}
+
+	if err := validateTimelineConfig(&config.Timeline); err != nil {
+		return fmt.Errorf("timeline config validation failed: %w", err)
+	}
+
+	if err := validateSecurityConfig(config.Server.CORSAllowedOrigins, &config.Security, config.AppEnv); err != nil {
+		return fmt.Errorf("security config validation failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -112,6 +125,10 @@ func validateCacheConfig(config *CacheConfig) error {
 		return fmt.Errorf("search cache expiry must be positive, got %v", config.SearchCacheExpiry)
 	}
 
+	if config.ETagRevalidateWindow < 0 {
+		return fmt.Errorf("etag revalidate window must not be negative, got %v", config.ETagRevalidateWindow)
+	}
+
 	return nil
 }
 
@@ -261,6 +278,72 @@ func validateKnowledgeHomeConfig(config *KnowledgeHomeConfig) error {
 	return nil
 }
 
+func validateShareLinkConfig(config *ShareLinkConfig) error {
+	if config.DefaultTTL <= 0 {
+		return fmt.Errorf("default TTL must be positive, got %v", config.DefaultTTL)
+	}
+	if config.MaxTTL < config.DefaultTTL {
+		return fmt.Errorf("max TTL must be >= default TTL (got max=%v, default=%v)", config.MaxTTL, config.DefaultTTL)
+	}
+	if strings.TrimSpace(config.BaseURL) == "" {
+		return fmt.Errorf("base URL must not be empty")
+	}
+	return nil
+}
+
+func validateTimelineConfig(config *TimelineConfig) error {
+	if config.MaxStreams <= 0 || config.MaxStreamsPerUser <= 0 {
+		return fmt.Errorf("stream limits must be positive (got max=%d, per user=%d)", config.MaxStreams, config.MaxStreamsPerUser)
+	}
+	if config.MaxStreamsPerUser > config.MaxStreams {
+		return fmt.Errorf("max streams per user must be <= max streams (got per user=%d, max=%d)", config.MaxStreamsPerUser, config.MaxStreams)
+	}
+	if config.HeartbeatInterval < time.Second {
+		return fmt.Errorf("heartbeat interval must be at least 1s, got %v", config.HeartbeatInterval)
+	}
+	if config.ReplayBufferSize <= 0 {
+		return fmt.Errorf("replay buffer size must be positive, got %d", config.ReplayBufferSize)
+	}
+	return nil
+}
+
+// validateSecurityConfig checks every route group's CORS origins and frame
+// options. Wildcard origins ("*" or "https://*.example.com") are refused in
+// production, where a typo in an env file would otherwise open the API to
+// every site.
+func validateSecurityConfig(apiOrigins []string, config *SecurityConfig, env string) error {
+	groups := []struct {
+		name    string
+		origins []string
+	}{
+		{"CORS_ALLOWED_ORIGINS", apiOrigins},
+		{"ADMIN_CORS_ALLOWED_ORIGINS", config.AdminCORSAllowedOrigins},
+		{"IMAGE_PROXY_CORS_ALLOWED_ORIGINS", config.ImageProxyCORSAllowedOrigins},
+	}
+	for _, g := range groups {
+		for _, origin := range g.origins {
+			if strings.Contains(origin, "*") {
+				if env == "production" {
+					return fmt.Errorf("%s must not contain wildcard origins in production, got %q", g.name, origin)
+				}
+				continue
+			}
+			u, err := url.Parse(origin)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+				return fmt.Errorf("%s entries must be scheme://host[:port] origins, got %q", g.name, origin)
+			}
+		}
+	}
+
+	if config.APIFrameOptions != "DENY" && config.APIFrameOptions != "SAMEORIGIN" {
+		return fmt.Errorf("API_FRAME_OPTIONS must be DENY or SAMEORIGIN, got %q", config.APIFrameOptions)
+	}
+	if config.ImageProxyFrameOptions != "DENY" && config.ImageProxyFrameOptions != "SAMEORIGIN" {
+		return fmt.Errorf("IMAGE_PROXY_FRAME_OPTIONS must be DENY or SAMEORIGIN, got %q", config.ImageProxyFrameOptions)
+	}
+	return nil
+}
+
 func validateCircuitBreakerConfig(config *CircuitBreakerConfig) error {
 	// Skip validation if circuit breaker is disabled
 	if !config.Enabled {
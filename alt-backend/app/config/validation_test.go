@@ -143,3 +143,80 @@ func TestValidateAuthConfig_ShortSecretInDevelopment(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSecurityConfig(t *testing.T) {
+	valid := SecurityConfig{
+		AdminCORSAllowedOrigins:      []string{"https://admin.example.com"},
+		ImageProxyCORSAllowedOrigins: []string{"https://example.com"},
+		APIFrameOptions:              "DENY",
+		ImageProxyFrameOptions:       "SAMEORIGIN",
+	}
+
+	tests := []struct {
+		name       string
+		apiOrigins []string
+		mutate     func(*SecurityConfig)
+		env        string
+		errMsg     string
+	}{
+		{name: "valid production config", apiOrigins: []string{"https://example.com"}, env: "production"},
+		{name: "wildcard allowed outside production", apiOrigins: []string{"*"}, env: "development"},
+		{name: "wildcard rejected in production", apiOrigins: []string{"*"}, env: "production", errMsg: "CORS_ALLOWED_ORIGINS must not contain wildcard origins in production"},
+		{
+			name:       "subdomain wildcard rejected in production",
+			apiOrigins: []string{"https://example.com"},
+			mutate:     func(c *SecurityConfig) { c.AdminCORSAllowedOrigins = []string{"https://*.example.com"} },
+			env:        "production",
+			errMsg:     "ADMIN_CORS_ALLOWED_ORIGINS must not contain wildcard origins",
+		},
+		{name: "origin with path rejected", apiOrigins: []string{"https://example.com/app"}, env: "development", errMsg: "must be scheme://host[:port] origins"},
+		{name: "bare host rejected", apiOrigins: []string{"example.com"}, env: "development", errMsg: "must be scheme://host[:port] origins"},
+		{
+			name:       "unknown frame options rejected",
+			apiOrigins: []string{"https://example.com"},
+			mutate:     func(c *SecurityConfig) { c.ImageProxyFrameOptions = "ALLOW-FROM https://example.com" },
+			env:        "development",
+			errMsg:     "IMAGE_PROXY_FRAME_OPTIONS must be DENY or SAMEORIGIN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			if tt.mutate != nil {
+				tt.mutate(&cfg)
+			}
+			err := validateSecurityConfig(tt.apiOrigins, &cfg, tt.env)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.errMsg) {
+				t.Fatalf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestApplySecurityDefaults_DependsOnAppEnv(t *testing.T) {
+	prod := &Config{AppEnv: "production"}
+	applySecurityDefaults(prod)
+	if len(prod.Server.CORSAllowedOrigins) != 1 || prod.Server.CORSAllowedOrigins[0] != "https://curionoah.com" {
+		t.Fatalf("production default origins = %v", prod.Server.CORSAllowedOrigins)
+	}
+	if len(prod.Security.AdminCORSAllowedOrigins) != 1 || len(prod.Security.ImageProxyCORSAllowedOrigins) != 1 {
+		t.Fatalf("admin and image proxy groups must inherit the API origins, got %v / %v",
+			prod.Security.AdminCORSAllowedOrigins, prod.Security.ImageProxyCORSAllowedOrigins)
+	}
+
+	dev := &Config{AppEnv: "development", Security: SecurityConfig{AdminCORSAllowedOrigins: []string{"http://localhost:5173"}}}
+	applySecurityDefaults(dev)
+	if len(dev.Server.CORSAllowedOrigins) != 4 {
+		t.Fatalf("development default origins = %v", dev.Server.CORSAllowedOrigins)
+	}
+	if dev.Security.AdminCORSAllowedOrigins[0] != "http://localhost:5173" {
+		t.Fatalf("explicit admin origins must be kept, got %v", dev.Security.AdminCORSAllowedOrigins)
+	}
+}
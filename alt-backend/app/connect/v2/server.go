@@ -80,6 +80,7 @@ func SetupConnectHandlers(mux *http.ServeMux, container *di.ApplicationComponent
 		PreProcessorClient:       container.PreProcessorConnectClient,
 		CreateSummaryVersion:     container.CreateSummaryVersionUsecase,
 		ImageProxy:               container.ImageProxyUsecase,
+		CacheVersions:            container.CacheVersions,
 	}, cfg, logger)
 	feedPath, feedServiceHandler := feedsv2connect.NewFeedServiceHandler(feedHandler, opts)
 	mux.Handle(feedPath, feedServiceHandler)
@@ -101,6 +102,7 @@ func SetupConnectHandlers(mux *http.ServeMux, container *di.ApplicationComponent
 		GetArticleSourceURL:     container.GetArticleSourceURLUsecase,
 		ImageProxy:              container.ImageProxyUsecase,
 		StreamArticleTags:       container.StreamArticleTagsUsecase,
+		CacheVersions:           container.CacheVersions,
 	}, cfg, logger)
 	articlePath, articleServiceHandler := articlesv2connect.NewArticleServiceHandler(articleHandler, opts)
 	mux.Handle(articlePath, articleServiceHandler)
@@ -248,6 +250,7 @@ func SetupConnectHandlers(mux *http.ServeMux, container *di.ApplicationComponent
 		internalhandler.WithKnowledgeVersionUsecases(container.CreateSummaryVersionUsecase, container.CreateTagSetVersionUsecase),
 		internalhandler.WithKnowledgeEventPort(container.SovereignClient),
 		internalhandler.WithRAGToolPorts(container.FetchTagCloudUsecase, container.FetchArticlesByTagUsecase),
+		internalhandler.WithReindexPort(gw),
 		internalhandler.WithRecapArticlesUsecase(container.RecapArticlesUsecase),
 	)
 	internalPath, internalServiceHandler := backendv1connect.NewBackendInternalServiceHandler(internalHandler, internalOpts)
@@ -69,6 +69,9 @@ type Handler struct {
 	fetchTagCloudPort      fetchTagCloudPort
 	fetchArticlesByTagPort fetchArticlesByTagPort
 
+	// RAG partial reindex (rag-orchestrator)
+	listArticlesForReindex internal_article_port.ListArticlesForReindexPort
+
 	// Recap article window (recap-worker paginated fetch).
 	recapArticlesUsecase recapArticlesUsecase
 
@@ -982,6 +985,9 @@ func toProtoArticle(a *internal_article_port.ArticleWithTags) *backendv1.Article
 		CreatedAt: timestamppb.New(a.CreatedAt),
 		UserId:    a.UserID,
 		Language:  a.Language,
+		FeedId:    a.FeedID,
+		FeedTitle: a.FeedTitle,
+		Author:    a.Author,
 	}
 }
 
@@ -1018,6 +1024,13 @@ func WithRAGToolPorts(
 	}
 }
 
+// WithReindexPort configures the port for the RAG partial reindex RPC.
+func WithReindexPort(p internal_article_port.ListArticlesForReindexPort) HandlerOption {
+	return func(h *Handler) {
+		h.listArticlesForReindex = p
+	}
+}
+
 // recapArticlesUsecase is the minimal interface for paginated article window
 // fetch. The concrete usecase lives at alt/usecase/recap_articles_usecase.
 type recapArticlesUsecase interface {
@@ -1196,3 +1209,71 @@ func (h *Handler) FetchArticlesByTag(ctx context.Context, req *connect.Request[b
 		Articles: result,
 	}), nil
 }
+
+// ListArticlesForReindex returns articles matching the rag-orchestrator
+// reindex filters, newest first.
+func (h *Handler) ListArticlesForReindex(ctx context.Context, req *connect.Request[backendv1.ListArticlesForReindexRequest]) (*connect.Response[backendv1.ListArticlesForReindexResponse], error) {
+	if h.listArticlesForReindex == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("not yet implemented"))
+	}
+
+	var filter internal_article_port.ReindexArticleFilter
+	if req.Msg.CreatedFrom != nil {
+		t := req.Msg.CreatedFrom.AsTime()
+		filter.CreatedFrom = &t
+	}
+	if req.Msg.CreatedTo != nil {
+		t := req.Msg.CreatedTo.AsTime()
+		filter.CreatedTo = &t
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("created_from must be before created_to"))
+	}
+	if req.Msg.FeedId != "" {
+		if _, err := uuid.Parse(req.Msg.FeedId); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("feed_id must be a UUID"))
+		}
+		filter.FeedID = req.Msg.FeedId
+	}
+	if req.Msg.UserId != "" {
+		if _, err := uuid.Parse(req.Msg.UserId); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user_id must be a UUID"))
+		}
+		filter.UserID = req.Msg.UserId
+	}
+
+	var lastCreatedAt *time.Time
+	if req.Msg.LastCreatedAt != nil {
+		t := req.Msg.LastCreatedAt.AsTime()
+		lastCreatedAt = &t
+	}
+
+	articles, nextCreatedAt, nextID, err := h.listArticlesForReindex.ListArticlesForReindex(ctx, filter, lastCreatedAt, req.Msg.LastId, clampLimit(int(req.Msg.Limit)))
+	if err != nil {
+		h.logger.Error("ListArticlesForReindex failed", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list articles for reindex"))
+	}
+
+	protoArticles := make([]*backendv1.ReindexArticle, len(articles))
+	for i, a := range articles {
+		protoArticles[i] = &backendv1.ReindexArticle{
+			Id:        a.ID,
+			Title:     a.Title,
+			Content:   a.Content,
+			Url:       a.URL,
+			FeedId:    a.FeedID,
+			UserId:    a.UserID,
+			CreatedAt: timestamppb.New(a.CreatedAt),
+		}
+	}
+
+	resp := &backendv1.ListArticlesForReindexResponse{
+		Articles: protoArticles,
+		NextId:   nextID,
+	}
+	if nextCreatedAt != nil {
+		resp.NextCreatedAt = timestamppb.New(*nextCreatedAt)
+	}
+
+	return connect.NewResponse(resp), nil
+}
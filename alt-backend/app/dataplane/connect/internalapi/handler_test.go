@@ -110,6 +110,32 @@ func TestListArticlesWithTags_PropagatesLanguage(t *testing.T) {
 	}
 }
 
+func TestListArticlesWithTags_PropagatesFeedMetadata(t *testing.T) {
+	h, mockList, _, _, _, _ := setupHandler(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	mockList.EXPECT().
+		ListArticlesWithTags(gomock.Any(), (*time.Time)(nil), "", 100).
+		Return([]*internal_article_port.ArticleWithTags{
+			{ID: "a1", Title: "T", Content: "c", Tags: []string{"go"}, CreatedAt: now, UserID: "u",
+				FeedID: "feed-1", FeedTitle: "Go Blog", Author: "Rob"},
+			{ID: "a2", Title: "T", Content: "c", Tags: []string{}, CreatedAt: now, UserID: "u"},
+		}, &now, "a2", nil)
+
+	resp, err := h.ListArticlesWithTags(ctx, connect.NewRequest(&backendv1.ListArticlesWithTagsRequest{Limit: 100}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := resp.Msg.Articles[0]
+	if first.FeedId != "feed-1" || first.FeedTitle != "Go Blog" || first.Author != "Rob" {
+		t.Errorf("expected feed metadata feed-1/Go Blog/Rob, got %q/%q/%q", first.FeedId, first.FeedTitle, first.Author)
+	}
+	if second := resp.Msg.Articles[1]; second.FeedTitle != "" || second.Author != "" {
+		t.Errorf("expected empty feed metadata, got %q/%q", second.FeedTitle, second.Author)
+	}
+}
+
 func TestListArticlesWithTags_WithCursor(t *testing.T) {
 	h, mockList, _, _, _, _ := setupHandler(t)
 	ctx := context.Background()
@@ -1609,3 +1635,66 @@ func TestBatchGetTagsByArticleIDs_Unimplemented(t *testing.T) {
 		t.Errorf("expected CodeUnimplemented, got %v", code)
 	}
 }
+
+// ── RAG partial reindex RPC tests ──
+
+func TestListArticlesForReindex_PassesFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockList := mocks.NewMockListArticlesForReindexPort(ctrl)
+	h := NewHandler(nil, nil, nil, nil, nil, nil, WithReindexPort(mockList))
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+	feedID := uuid.NewString()
+	created := time.Date(2026, 10, 7, 12, 0, 0, 0, time.UTC)
+
+	mockList.EXPECT().
+		ListArticlesForReindex(gomock.Any(), internal_article_port.ReindexArticleFilter{
+			CreatedFrom: &from,
+			CreatedTo:   &to,
+			FeedID:      feedID,
+		}, (*time.Time)(nil), "", 200).
+		Return([]*internal_article_port.ReindexArticle{
+			{ID: "a1", Title: "T", Content: "C", URL: "https://example.com/a1", FeedID: feedID, UserID: "u1", CreatedAt: created},
+		}, &created, "a1", nil)
+
+	resp, err := h.ListArticlesForReindex(context.Background(), connect.NewRequest(&backendv1.ListArticlesForReindexRequest{
+		CreatedFrom: timestamppb.New(from),
+		CreatedTo:   timestamppb.New(to),
+		FeedId:      feedID,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Msg.Articles) != 1 || resp.Msg.Articles[0].Url != "https://example.com/a1" || resp.Msg.Articles[0].FeedId != feedID {
+		t.Fatalf("unexpected articles %v", resp.Msg.Articles)
+	}
+	if resp.Msg.NextId != "a1" || !resp.Msg.NextCreatedAt.AsTime().Equal(created) {
+		t.Errorf("unexpected cursor %s %v", resp.Msg.NextId, resp.Msg.NextCreatedAt)
+	}
+}
+
+func TestListArticlesForReindex_InvalidArguments(t *testing.T) {
+	h := NewHandler(nil, nil, nil, nil, nil, nil, WithReindexPort(mocks.NewMockListArticlesForReindexPort(gomock.NewController(t))))
+	now := time.Now()
+
+	for name, msg := range map[string]*backendv1.ListArticlesForReindexRequest{
+		"inverted range": {CreatedFrom: timestamppb.New(now), CreatedTo: timestamppb.New(now.Add(-time.Hour))},
+		"bad feed id":    {FeedId: "not-a-uuid"},
+		"bad user id":    {UserId: "not-a-uuid"},
+	} {
+		_, err := h.ListArticlesForReindex(context.Background(), connect.NewRequest(msg))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("%s: expected CodeInvalidArgument, got %v", name, err)
+		}
+	}
+}
+
+func TestListArticlesForReindex_NotConfigured(t *testing.T) {
+	h := NewHandler(nil, nil, nil, nil, nil, nil)
+
+	_, err := h.ListArticlesForReindex(context.Background(), connect.NewRequest(&backendv1.ListArticlesForReindexRequest{}))
+	if connect.CodeOf(err) != connect.CodeUnimplemented {
+		t.Errorf("expected CodeUnimplemented, got %v", connect.CodeOf(err))
+	}
+}
@@ -15,6 +15,9 @@ type ArticleWithTags struct {
 	CreatedAt time.Time
 	UserID    string
 	Language  string
+	FeedID    string
+	FeedTitle string
+	Author    string
 }
 
 // DeletedArticle represents a deleted article.
@@ -138,3 +141,29 @@ type ListUnsummarizedArticlesPort interface {
 type HasUnsummarizedArticlesPort interface {
 	HasUnsummarizedArticles(ctx context.Context) (bool, error)
 }
+
+// ── RAG partial reindex (for rag-orchestrator) ──
+
+// ReindexArticleFilter narrows ListArticlesForReindex. Zero values disable a filter.
+type ReindexArticleFilter struct {
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	FeedID      string
+	UserID      string
+}
+
+// ReindexArticle represents an article as rag-orchestrator needs it for reindexing.
+type ReindexArticle struct {
+	ID        string
+	Title     string
+	Content   string
+	URL       string
+	FeedID    string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// ListArticlesForReindexPort provides filtered backward keyset pagination for RAG reindexing.
+type ListArticlesForReindexPort interface {
+	ListArticlesForReindex(ctx context.Context, filter ReindexArticleFilter, lastCreatedAt *time.Time, lastID string, limit int) ([]*ReindexArticle, *time.Time, string, error)
+}
@@ -4,9 +4,14 @@ import (
 	"alt/dataplane/gateway/fetch_recent_articles_gateway"
 	"alt/orchestrator/driver/preprocessor_client"
 	"alt/orchestrator/gateway/archive_article_gateway"
+	"alt/orchestrator/gateway/article_audio_gateway"
 	"alt/orchestrator/gateway/article_content_cache_gateway"
 	"alt/orchestrator/gateway/article_gateway"
+	"alt/orchestrator/gateway/article_index_outbox_gateway"
+	"alt/orchestrator/gateway/article_reconciliation_gateway"
+	"alt/orchestrator/gateway/article_share_gateway"
 	"alt/orchestrator/gateway/article_summary_gateway"
+	"alt/orchestrator/gateway/article_title_fix_gateway"
 	"alt/orchestrator/gateway/cached_article_tags_gateway"
 	"alt/orchestrator/gateway/fetch_article_gateway"
 	"alt/orchestrator/gateway/fetch_article_tags_gateway"
@@ -15,6 +20,11 @@ import (
 	"alt/orchestrator/gateway/scraping_policy_gateway"
 	"alt/orchestrator/port/rag_integration_port"
 	"alt/orchestrator/usecase/archive_article_usecase"
+	"alt/orchestrator/usecase/article_audio_usecase"
+	"alt/orchestrator/usecase/article_index_outbox_usecase"
+	"alt/orchestrator/usecase/article_reconciliation_usecase"
+	"alt/orchestrator/usecase/article_share_usecase"
+	"alt/orchestrator/usecase/article_title_fix_usecase"
 	"alt/orchestrator/usecase/fetch_article_summaries_usecase"
 	"alt/orchestrator/usecase/fetch_article_summary_usecase"
 	"alt/orchestrator/usecase/fetch_article_tags_usecase"
@@ -51,6 +61,22 @@ type ArticleModule struct {
 	BatchArticleFetcher        *batch_article_fetcher.BatchArticleFetcher
 	FetchTagCloudUsecase       *fetch_tag_cloud_usecase.FetchTagCloudUsecase
 	GetArticleSourceURLUsecase *get_article_source_url_usecase.GetArticleSourceURLUsecase
+	// ArticleShareUsecase is nil when SHARE_LINK_SECRET is unset; the
+	// share routes are not registered then.
+	ArticleShareUsecase *article_share_usecase.Usecase
+	// ArticleAudioUsecase requests TTS audio through mq-hub and tracks its
+	// generation status for the mobile player.
+	ArticleAudioUsecase *article_audio_usecase.Usecase
+	// ArticleReconciliationUsecase matches articles reported by ingest
+	// sources (Inoreader via pre-processor) against alt-backend's own.
+	ArticleReconciliationUsecase *article_reconciliation_usecase.Usecase
+	// ArticleTitleFixUsecase repairs articles stored with their URL as the
+	// title, in admin-started jobs advanced by the background job.
+	ArticleTitleFixUsecase *article_title_fix_usecase.Usecase
+	// ArticleIndexOutboxUsecase publishes articles whose indexed fields
+	// changed (or that an admin queued) so search-indexer and
+	// rag-orchestrator reindex them.
+	ArticleIndexOutboxUsecase *article_index_outbox_usecase.Usecase
 
 	// Legacy REST v1 summarize endpoints (POST /v1/feeds/summarize,
 	// /summarize/queue, GET /summarize/status/:job_id, POST /fetch/summary).
@@ -142,6 +168,40 @@ func newArticleModule(infra *InfraModule, feed *FeedModule, ragAdapter rag_integ
 	summarizeArticleUC := summarize_article_usecase.NewUsecase(altDB, preprocessorSummarizeGw, fetchArticleGw)
 	fetchArticleSummariesUC := fetch_article_summaries_usecase.NewUsecase(altDB, batchFetcher, summarizeArticleUC)
 
+	// Public article share links (signed tokens, opt-in via SHARE_LINK_SECRET)
+	var articleShareUC *article_share_usecase.Usecase
+	if shareCfg := infra.Config.ShareLink; shareCfg.Secret != "" {
+		articleShareGw := article_share_gateway.NewGateway(altDB)
+		articleShareUC = article_share_usecase.NewUsecase(articleShareGw, articleShareGw, article_share_usecase.Config{
+			Secret:     []byte(shareCfg.Secret),
+			BaseURL:    shareCfg.BaseURL,
+			DefaultTTL: shareCfg.DefaultTTL,
+			MaxTTL:     shareCfg.MaxTTL,
+		})
+	}
+
+	// Article TTS audio (generation requested via mq-hub, reported back by
+	// the TTS worker on the internal API)
+	articleAudioGw := article_audio_gateway.NewGateway(altDB)
+	articleAudioUC := article_audio_usecase.NewUsecase(
+		articleAudioGw, infra.EventPublisher, article_audio_usecase.Config{})
+
+	// Cross-source article reconciliation (URL/GUID matching, duplicate links)
+	articleReconciliationGw := article_reconciliation_gateway.NewGateway(altDB)
+	articleReconciliationUC := article_reconciliation_usecase.NewUsecase(
+		articleReconciliationGw, articleReconciliationGw, article_reconciliation_usecase.Config{})
+
+	// Article title repair (URL-as-title articles, fetched via scraping policy)
+	articleTitleFixGw := article_title_fix_gateway.NewGateway(altDB)
+	articleTitleFixUC := article_title_fix_usecase.NewUsecase(
+		articleTitleFixGw, fetchArticleGw, scrapingPolicyGw, article_title_fix_usecase.Config{})
+
+	// Article index outbox (dirty articles republished as ArticleUpdated
+	// through the event outbox)
+	articleIndexOutboxGw := article_index_outbox_gateway.NewGateway(altDB)
+	articleIndexOutboxUC := article_index_outbox_usecase.NewUsecase(
+		articleIndexOutboxGw, infra.OutboxEventPublisher, article_index_outbox_usecase.Config{})
+
 	return &ArticleModule{
 		ArticleUsecase:             fetchArticleUC,
 		ArchiveArticleUsecase:      archiveArticleUC,
@@ -156,6 +216,12 @@ func newArticleModule(infra *InfraModule, feed *FeedModule, ragAdapter rag_integ
 		BatchArticleFetcher:        batchFetcher,
 		FetchTagCloudUsecase:       fetchTagCloudUC,
 		GetArticleSourceURLUsecase: getArticleSourceURLUC,
+		ArticleShareUsecase:        articleShareUC,
+		ArticleAudioUsecase:        articleAudioUC,
+
+		ArticleReconciliationUsecase: articleReconciliationUC,
+		ArticleTitleFixUsecase:       articleTitleFixUC,
+		ArticleIndexOutboxUsecase:    articleIndexOutboxUC,
 
 		SummarizeArticleUsecase:      summarizeArticleUC,
 		FetchArticleSummariesUsecase: fetchArticleSummariesUC,
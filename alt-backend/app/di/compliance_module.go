@@ -0,0 +1,27 @@
+package di
+
+import (
+	"alt/orchestrator/gateway/legal_hold_gateway"
+	"alt/orchestrator/gateway/soft_delete_gateway"
+	"alt/orchestrator/usecase/legal_hold_usecase"
+	"alt/orchestrator/usecase/soft_delete_usecase"
+)
+
+// ComplianceModule holds the legal hold, account export and soft-delete
+// lifecycle components.
+type ComplianceModule struct {
+	LegalHoldUsecase  *legal_hold_usecase.Usecase
+	SoftDeleteUsecase *soft_delete_usecase.Usecase
+}
+
+// newComplianceModule creates the ComplianceModule backed by alt_db.
+func newComplianceModule(infra *InfraModule) *ComplianceModule {
+	gw := legal_hold_gateway.NewGateway(infra.AltDBRepository)
+	softDeleteGw := soft_delete_gateway.NewGateway(infra.AltDBRepository)
+	return &ComplianceModule{
+		LegalHoldUsecase: legal_hold_usecase.NewUsecase(gw, gw, gw),
+		// Purges consult the legal hold registry so held accounts keep
+		// their deleted rows past the restore window.
+		SoftDeleteUsecase: soft_delete_usecase.NewUsecase(softDeleteGw, softDeleteGw, gw, infra.OutboxEventPublisher, infra.Transaction),
+	}
+}
@@ -24,6 +24,8 @@ import (
 	"alt/orchestrator/usecase/csrf_token_usecase"
 	dashboard_usecase "alt/orchestrator/usecase/dashboard"
 	"alt/orchestrator/usecase/emit_trail_outcome_usecase"
+	"alt/orchestrator/usecase/feature_flag_usecase"
+	"alt/orchestrator/usecase/feed_discovery_usecase"
 	"alt/orchestrator/usecase/feed_link_usecase"
 	"alt/orchestrator/usecase/fetch_article_summaries_usecase"
 	"alt/orchestrator/usecase/fetch_article_summary_usecase"
@@ -72,6 +74,7 @@ import (
 	"alt/orchestrator/usecase/stream_article_tags_usecase"
 	"alt/orchestrator/usecase/subscription_usecase"
 	"alt/orchestrator/usecase/summarize_article_usecase"
+	"alt/orchestrator/usecase/timeline_stream_usecase"
 	"alt/orchestrator/usecase/track_home_action_usecase"
 	"alt/orchestrator/usecase/track_home_seen_usecase"
 	"alt/orchestrator/usecase/update_lens_usecase"
@@ -82,6 +85,7 @@ import (
 	"alt/shared/usecase/fetch_articles_by_tag_usecase"
 	"alt/shared/usecase/fetch_tag_cloud_usecase"
 	"alt/utils/batch_article_fetcher"
+	"alt/utils/cache"
 	altotel "alt/utils/otel"
 	"log/slog"
 
@@ -100,6 +104,8 @@ type ApplicationComponents struct {
 	Image        *ImageModule
 	Recap        *RecapModule
 	Subscription *SubscriptionModule
+	Compliance   *ComplianceModule
+	Organization *OrganizationModule
 
 	// ===== BACKWARD COMPAT: All existing fields populated from modules =====
 	// These allow existing handler code to continue working unchanged.
@@ -121,6 +127,7 @@ type ApplicationComponents struct {
 	RagConnectClient *rag_connect_gateway.Client
 	StreamChatPort   morning_letter_port.StreamChatPort
 	EventPublisher   event_publisher_port.EventPublisherPort
+	EventRelay       event_publisher_port.EventRelayPort
 
 	// Usecases
 	FetchSingleFeedUsecase              *fetch_feed_usecase.FetchSingleFeedUsecase
@@ -160,6 +167,7 @@ type ApplicationComponents struct {
 	MorningUsecase                      morning_letter_port.MorningUsecase
 	MorningLetterUsecase                morning_letter_port.MorningLetterUsecase
 	ScrapingDomainUsecase               *scraping_domain_usecase.ScrapingDomainUsecase
+	FeedDiscoveryUsecase                *feed_discovery_usecase.FeedDiscoveryUsecase
 	BatchArticleFetcher                 *batch_article_fetcher.BatchArticleFetcher
 	FetchArticleGateway                 *fetch_article_gateway.FetchArticleGateway
 	RetrieveContextUsecase              retrieve_context_usecase.RetrieveContextUsecase
@@ -210,7 +218,8 @@ type ApplicationComponents struct {
 	AppendKnowledgeEventUsecase      *append_knowledge_event_usecase.AppendKnowledgeEventUsecase
 	CreateSummaryVersionUsecase      *create_summary_version_usecase.CreateSummaryVersionUsecase
 	CreateTagSetVersionUsecase       *create_tag_set_version_usecase.CreateTagSetVersionUsecase
-	FeatureFlagGateway               *feature_flag_gateway.Gateway
+	FeatureFlagGateway               *feature_flag_gateway.CachedGateway
+	FeatureFlagUsecase               *feature_flag_usecase.Usecase
 	KnowledgeBackfillUsecase         *knowledge_backfill_usecase.Usecase
 	KnowledgeURLBackfillUsecase      *knowledge_url_backfill_usecase.Usecase
 	KnowledgeProjectionHealthUsecase *knowledge_projection_health_usecase.Usecase
@@ -241,6 +250,15 @@ type ApplicationComponents struct {
 	// Admin observability (Prometheus-backed metrics UI). Facade may be nil
 	// when cfg.AdminMonitor.Enabled is false; server.go skips registration.
 	AdminMonitor *AdminMonitorModule
+
+	// CacheVersions backs ETag revalidation. Same instance as
+	// Infra.CacheVersions; top-level so route registration tolerates the
+	// empty containers handler tests build.
+	CacheVersions *cache.VersionStore
+
+	// TimelineStream serves GET /v1/timeline/stream. Same instance as
+	// Infra.TimelineStream.
+	TimelineStream *timeline_stream_usecase.Usecase
 }
 
 func NewApplicationComponents(pool *pgxpool.Pool, cfg *config.Config) *ApplicationComponents {
@@ -275,6 +293,12 @@ func NewApplicationComponents(pool *pgxpool.Pool, cfg *config.Config) *Applicati
 	// 10. Admin observability (gated by AdminMonitor.Enabled)
 	adminMonitor := newAdminMonitorModule(infra.Config, slog.Default())
 
+	// 11. Compliance (legal holds, account exports)
+	compliance := newComplianceModule(infra)
+
+	// 12. Organizations (teams, per-org roles, invitations)
+	organization := newOrganizationModule(infra)
+
 	return &ApplicationComponents{
 		// Modules
 		Infra:        infra,
@@ -286,6 +310,8 @@ func NewApplicationComponents(pool *pgxpool.Pool, cfg *config.Config) *Applicati
 		Recap:        recap,
 		Search:       search,
 		Subscription: sub,
+		Compliance:   compliance,
+		Organization: organization,
 
 		// ===== Backward-compat fields populated from modules =====
 
@@ -305,6 +331,7 @@ func NewApplicationComponents(pool *pgxpool.Pool, cfg *config.Config) *Applicati
 		RagConnectClient: rag.RagConnectClient,
 		StreamChatPort:   rag.StreamChatPort,
 		EventPublisher:   infra.EventPublisher,
+		EventRelay:       infra.EventRelay,
 
 		// Feed usecases
 		FetchSingleFeedUsecase:              feed.FetchSingleFeedUsecase,
@@ -334,6 +361,7 @@ func NewApplicationComponents(pool *pgxpool.Pool, cfg *config.Config) *Applicati
 		FetchInoreaderSummaryUsecase:        feed.FetchInoreaderSummaryUsecase,
 		FetchRandomSubscriptionUsecase:      feed.FetchRandomSubscriptionUsecase,
 		ScrapingDomainUsecase:               feed.ScrapingDomainUsecase,
+		FeedDiscoveryUsecase:                feed.FeedDiscoveryUsecase,
 
 		// Article usecases
 		ArticleUsecase:             article.ArticleUsecase,
@@ -396,6 +424,7 @@ func NewApplicationComponents(pool *pgxpool.Pool, cfg *config.Config) *Applicati
 		CreateSummaryVersionUsecase:      knowledge.CreateSummaryVersionUsecase,
 		CreateTagSetVersionUsecase:       knowledge.CreateTagSetVersionUsecase,
 		FeatureFlagGateway:               knowledge.FeatureFlagGateway,
+		FeatureFlagUsecase:               knowledge.FeatureFlagUsecase,
 		KnowledgeBackfillUsecase:         knowledge.KnowledgeBackfillUsecase,
 		KnowledgeURLBackfillUsecase:      knowledge.KnowledgeURLBackfillUsecase,
 		KnowledgeProjectionHealthUsecase: knowledge.KnowledgeProjectionHealthUsecase,
@@ -421,6 +450,10 @@ func NewApplicationComponents(pool *pgxpool.Pool, cfg *config.Config) *Applicati
 		KnowledgeHomeMetrics: knowledge.KnowledgeHomeMetrics,
 
 		// Admin observability
-		AdminMonitor: adminMonitor,
+		AdminMonitor:  adminMonitor,
+		CacheVersions: infra.CacheVersions,
+
+		// Live timeline
+		TimelineStream: infra.TimelineStream,
 	}
 }
@@ -1,6 +1,7 @@
 package di
 
 import (
+	"alt/orchestrator/gateway/feed_discovery_gateway"
 	"alt/orchestrator/gateway/feed_link_gateway"
 	"alt/orchestrator/gateway/feed_page_cache_gateway"
 	"alt/orchestrator/gateway/feed_search_gateway"
@@ -12,6 +13,7 @@ import (
 	"alt/orchestrator/gateway/fetch_feed_tags_gateway"
 	"alt/orchestrator/gateway/fetch_inoreader_summary_gateway"
 	"alt/orchestrator/gateway/fetch_random_subscription_gateway"
+	"alt/orchestrator/gateway/home_ranking_gateway"
 	"alt/orchestrator/gateway/register_favorite_feed_gateway"
 	"alt/orchestrator/gateway/register_feed_gateway"
 	"alt/orchestrator/gateway/scraping_domain_gateway"
@@ -20,6 +22,7 @@ import (
 	"alt/orchestrator/gateway/validate_fetch_rss_gateway"
 	"alt/orchestrator/port/scraping_domain_port"
 	"alt/orchestrator/usecase/cached_feed_list_usecase"
+	"alt/orchestrator/usecase/feed_discovery_usecase"
 	"alt/orchestrator/usecase/feed_link_usecase"
 	"alt/orchestrator/usecase/fetch_feed_details_usecase"
 	"alt/orchestrator/usecase/fetch_feed_stats_usecase"
@@ -28,6 +31,7 @@ import (
 	"alt/orchestrator/usecase/fetch_inoreader_summary_usecase"
 	"alt/orchestrator/usecase/fetch_random_subscription_usecase"
 	"alt/orchestrator/usecase/fetch_trend_stats_usecase"
+	"alt/orchestrator/usecase/home_ranking_usecase"
 	"alt/orchestrator/usecase/reading_status"
 	"alt/orchestrator/usecase/register_favorite_feed_usecase"
 	"alt/orchestrator/usecase/register_feed_usecase"
@@ -48,6 +52,7 @@ type FeedModule struct {
 	FetchReadFeedsListCursorUsecase     *fetch_feed_usecase.FetchReadFeedsListCursorUsecase
 	FetchFavoriteFeedsListCursorUsecase *fetch_feed_usecase.FetchFavoriteFeedsListCursorUsecase
 	CachedFeedListUsecase               *cached_feed_list_usecase.CachedFeedListUsecase
+	HomeRankingUsecase                  *home_ranking_usecase.Usecase
 	RegisterFeedsUsecase                *register_feed_usecase.RegisterFeedsUsecase
 	RegisterFavoriteFeedUsecase         *register_favorite_feed_usecase.RegisterFavoriteFeedUsecase
 	RemoveFavoriteFeedUsecase           *remove_favorite_feed_usecase.RemoveFavoriteFeedUsecase
@@ -68,6 +73,7 @@ type FeedModule struct {
 	FetchInoreaderSummaryUsecase        fetch_inoreader_summary_usecase.FetchInoreaderSummaryUsecase
 	FetchRandomSubscriptionUsecase      *fetch_random_subscription_usecase.FetchRandomSubscriptionUsecase
 	ScrapingDomainUsecase               *scraping_domain_usecase.ScrapingDomainUsecase
+	FeedDiscoveryUsecase                *feed_discovery_usecase.FeedDiscoveryUsecase
 
 	// Gateways exposed for cross-module wiring
 	FeedPageCacheGateway         *feed_page_cache_gateway.Gateway
@@ -91,6 +97,10 @@ func newFeedModule(infra *InfraModule, sub *SubscriptionModule) *FeedModule {
 	fetchFeedsListCursorUC := fetch_feed_usecase.NewFetchFeedsListCursorUsecase(fetchFeedsListGw)
 	fetchUnreadFeedsListCursorUC := fetch_feed_usecase.NewFetchUnreadFeedsListCursorUsecase(fetchFeedsListGw)
 	cachedFeedListUC := cached_feed_list_usecase.NewCachedFeedListUsecase(fetchFeedsListGw, fetchFeedsListGw, fetchFeedsListGw)
+
+	// Server-ranked home timeline (weights per user via home_ranking_experiments)
+	homeRankingGw := home_ranking_gateway.NewGateway(altDB)
+	homeRankingUC := home_ranking_usecase.NewUsecase(fetchFeedsListGw, homeRankingGw, homeRankingGw)
 	fetchReadFeedsListCursorUC := fetch_feed_usecase.NewFetchReadFeedsListCursorUsecase(fetchFeedsListGw)
 	fetchFavoriteFeedsListCursorUC := fetch_feed_usecase.NewFetchFavoriteFeedsListCursorUsecase(fetchFeedsListGw)
 
@@ -104,7 +114,8 @@ func newFeedModule(infra *InfraModule, sub *SubscriptionModule) *FeedModule {
 		FeedLinkAvailability: altDB,
 		FeedPageInvalidator:  feedPageCacheGw,
 		SubscriptionPort:     sub.SubscriptionGateway,
-		EventPublisher:       infra.EventPublisher,
+		EventPublisher:       infra.OutboxEventPublisher,
+		Transaction:          infra.Transaction,
 	})
 	registerFavoriteFeedUC := register_favorite_feed_usecase.NewRegisterFavoriteFeedUsecase(registerFavoriteFeedGw)
 	removeFavoriteFeedUC := remove_favorite_feed_usecase.NewRemoveFavoriteFeedUsecase(registerFavoriteFeedGw)
@@ -116,8 +127,8 @@ func newFeedModule(infra *InfraModule, sub *SubscriptionModule) *FeedModule {
 
 	// Reading status
 	updateFeedStatusGw := update_feed_status_gateway.NewUpdateFeedStatusGateway(pool)
-	feedsReadingStatusUC := reading_status.NewFeedsReadingStatusUsecase(updateFeedStatusGw)
-	articlesReadingStatusUC := reading_status.NewArticlesReadingStatusUsecase(altDB)
+	feedsReadingStatusUC := reading_status.NewFeedsReadingStatusUsecase(updateFeedStatusGw, infra.OutboxEventPublisher, infra.Transaction)
+	articlesReadingStatusUC := reading_status.NewArticlesReadingStatusUsecase(altDB, infra.OutboxEventPublisher, infra.Transaction)
 
 	// Feed details / stats
 	feedSummaryGw := fetch_feed_detail_gateway.NewFeedSummaryGateway(pool)
@@ -165,6 +176,10 @@ func newFeedModule(infra *InfraModule, sub *SubscriptionModule) *FeedModule {
 	feedLinkDomainGw := feed_link_domain_gateway.NewFeedLinkDomainGateway(altDB)
 	scrapingDomainUC := scraping_domain_usecase.NewScrapingDomainUsecaseWithFeedLinkDomain(scrapingDomainGw, infra.RobotsTxtGateway, feedLinkDomainGw)
 
+	// Feed discovery (website URL -> candidate feeds)
+	feedDiscoveryGw := feed_discovery_gateway.NewGateway()
+	feedDiscoveryUC := feed_discovery_usecase.NewFeedDiscoveryUsecase(feedDiscoveryGw, feedDiscoveryGw)
+
 	return &FeedModule{
 		FetchSingleFeedUsecase:              fetchSingleFeedUC,
 		FetchFeedsListUsecase:               fetchFeedsListUC,
@@ -173,6 +188,7 @@ func newFeedModule(infra *InfraModule, sub *SubscriptionModule) *FeedModule {
 		FetchReadFeedsListCursorUsecase:     fetchReadFeedsListCursorUC,
 		FetchFavoriteFeedsListCursorUsecase: fetchFavoriteFeedsListCursorUC,
 		CachedFeedListUsecase:               cachedFeedListUC,
+		HomeRankingUsecase:                  homeRankingUC,
 		RegisterFeedsUsecase:                registerFeedsUC,
 		RegisterFavoriteFeedUsecase:         registerFavoriteFeedUC,
 		RemoveFavoriteFeedUsecase:           removeFavoriteFeedUC,
@@ -193,6 +209,7 @@ func newFeedModule(infra *InfraModule, sub *SubscriptionModule) *FeedModule {
 		FetchInoreaderSummaryUsecase:        fetchInoreaderSummaryUC,
 		FetchRandomSubscriptionUsecase:      fetchRandomSubscriptionUC,
 		ScrapingDomainUsecase:               scrapingDomainUC,
+		FeedDiscoveryUsecase:                feedDiscoveryUC,
 
 		FeedPageCacheGateway:         feedPageCacheGw,
 		FetchFeedsListGateway:        fetchFeedsListGw,
@@ -12,11 +12,14 @@ import (
 	"alt/orchestrator/port/error_handler_port"
 	"alt/orchestrator/port/rate_limiter_port"
 	"alt/orchestrator/port/search_indexer_port"
+	"alt/orchestrator/usecase/timeline_stream_usecase"
 	"alt/shared/driver/alt_db"
 	"alt/shared/driver/mqhub_connect"
 	"alt/shared/gateway/event_publisher_gateway"
 	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
 	"alt/utils"
+	"alt/utils/cache"
 	"alt/utils/rate_limiter"
 	"log/slog"
 	"net/http"
@@ -35,12 +38,28 @@ type InfraModule struct {
 	KratosClient    kratos_client.KratosClient
 	MQHubClient     *mqhub_connect.Client
 	EventPublisher  event_publisher_port.EventPublisherPort
+	// OutboxEventPublisher records events in outbox_events for the outbox
+	// worker to relay through EventRelay, for usecases whose events must
+	// not be lost while mq-hub is unavailable.
+	OutboxEventPublisher event_publisher_port.EventPublisherPort
+	EventRelay           event_publisher_port.EventRelayPort
+	// Transaction lets a usecase save a state change and the outbox row
+	// of its event in one transaction.
+	Transaction transaction_port.TransactionPort
 
 	// Shared drivers
 	AltDBRepository     *alt_db.AltDBRepository
 	SearchIndexerDriver search_indexer_port.SearchIndexerPort
 	RobotsTxtGateway    *robots_txt_gateway.RobotsTxtGateway
 
+	// CacheVersions is bumped by REST write paths so ETagMiddleware stops
+	// short-circuiting If-None-Match for the affected user.
+	CacheVersions *cache.VersionStore
+
+	// TimelineStream fans ArticleCreated and ReadStateChanged events from
+	// both event publishers out to open /v1/timeline/stream connections.
+	TimelineStream *timeline_stream_usecase.Usecase
+
 	Pool *pgxpool.Pool
 }
 
@@ -71,6 +90,14 @@ func newInfraModule(pool *pgxpool.Pool, cfg *config.Config) *InfraModule {
 	mqhubClient := mqhub_connect.NewClient(cfg.MQHub.ConnectURL, cfg.MQHub.Enabled)
 	logMQHubWiringState(cfg.MQHub.Enabled, cfg.MQHub.ConnectURL)
 	eventPublisherGw := event_publisher_gateway.NewEventPublisherGateway(mqhubClient, slog.Default())
+	outboxEventPublisherGw := event_publisher_gateway.NewOutboxEventPublisherGateway(altDBRepository, mqhubClient.IsEnabled(), slog.Default())
+
+	// Live timeline, fed by whichever publisher a usecase uses
+	timelineStream := timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{
+		MaxConnections:        cfg.Timeline.MaxStreams,
+		MaxConnectionsPerUser: cfg.Timeline.MaxStreamsPerUser,
+		ReplayBufferSize:      cfg.Timeline.ReplayBufferSize,
+	})
 
 	// Auth-hub client for identity management (abstracts Kratos)
 	kratosClientImpl := kratos_client.NewKratosClient(cfg.AuthHub.URL, cfg.Auth.BackendTokenSecret)
@@ -79,19 +106,24 @@ func newInfraModule(pool *pgxpool.Pool, cfg *config.Config) *InfraModule {
 	searchIndexerDriver := search_indexer_connect.NewConnectSearchIndexerDriver(cfg.SearchIndexer.ConnectURL, "")
 
 	return &InfraModule{
-		Config:              cfg,
-		ConfigPort:          configPort,
-		ErrorHandler:        errorHandlerPort,
-		RateLimiter:         hostRateLimiter,
-		RateLimiterPort:     rateLimiterPort,
-		HTTPClient:          httpClient,
-		KratosClient:        kratosClientImpl,
-		MQHubClient:         mqhubClient,
-		EventPublisher:      eventPublisherGw,
-		AltDBRepository:     altDBRepository,
-		SearchIndexerDriver: searchIndexerDriver,
-		RobotsTxtGateway:    robotsTxtGw,
-		Pool:                pool,
+		Config:               cfg,
+		ConfigPort:           configPort,
+		ErrorHandler:         errorHandlerPort,
+		RateLimiter:          hostRateLimiter,
+		RateLimiterPort:      rateLimiterPort,
+		HTTPClient:           httpClient,
+		KratosClient:         kratosClientImpl,
+		MQHubClient:          mqhubClient,
+		EventPublisher:       event_publisher_gateway.NewTimelineEventPublisherGateway(eventPublisherGw, timelineStream),
+		OutboxEventPublisher: event_publisher_gateway.NewTimelineEventPublisherGateway(outboxEventPublisherGw, timelineStream),
+		EventRelay:           eventPublisherGw,
+		Transaction:          alt_db.NewTxManager(pool),
+		AltDBRepository:      altDBRepository,
+		SearchIndexerDriver:  searchIndexerDriver,
+		RobotsTxtGateway:     robotsTxtGw,
+		CacheVersions:        cache.NewVersionStore(),
+		TimelineStream:       timelineStream,
+		Pool:                 pool,
 	}
 }
 
@@ -14,6 +14,7 @@ import (
 	"alt/orchestrator/usecase/archive_lens_usecase"
 	"alt/orchestrator/usecase/create_lens_usecase"
 	"alt/orchestrator/usecase/emit_trail_outcome_usecase"
+	"alt/orchestrator/usecase/feature_flag_usecase"
 	"alt/orchestrator/usecase/get_item_branches_usecase"
 	"alt/orchestrator/usecase/get_knowledge_home_usecase"
 	"alt/orchestrator/usecase/get_knowledge_trail_usecase"
@@ -63,6 +64,7 @@ type KnowledgeModule struct {
 	SLOUsecase                       *knowledge_slo_usecase.Usecase
 	AuditUsecase                     *knowledge_audit_usecase.Usecase
 	MetricsUsecase                   *knowledge_metrics_usecase.Usecase
+	FeatureFlagUsecase               *feature_flag_usecase.Usecase
 
 	// Recall / Lens usecases
 	RecallRailUsecase    *recall_rail_usecase.RecallRailUsecase
@@ -75,7 +77,7 @@ type KnowledgeModule struct {
 	ArchiveLensUsecase   *archive_lens_usecase.ArchiveLensUsecase
 
 	// Gateways
-	FeatureFlagGateway *feature_flag_gateway.Gateway
+	FeatureFlagGateway *feature_flag_gateway.CachedGateway
 
 	// Sovereign client
 	SovereignClient *sovereign_client.Client
@@ -96,7 +98,15 @@ func newKnowledgeModule(infra *InfraModule, article *ArticleModule) *KnowledgeMo
 	// Knowledge Home gateways
 	summaryVersionGw := summary_version_gateway.NewGateway(altDB)
 	tagSetVersionGw := tag_set_version_gateway.NewGateway(altDB)
-	featureFlagGw := feature_flag_gateway.NewGateway(&cfg.KnowledgeHome)
+	// Feature flags stored in alt-db win over the KNOWLEDGE_HOME_* toggles,
+	// which remain the fallback for flags that have no row.
+	featureFlagEnv := cfg.FeatureFlags.Environment
+	if featureFlagEnv == "" {
+		featureFlagEnv = cfg.AppEnv
+	}
+	featureFlagStoreGw := feature_flag_gateway.NewStoreGateway(altDB)
+	featureFlagGw := feature_flag_gateway.NewCachedGateway(featureFlagStoreGw, feature_flag_gateway.NewGateway(&cfg.KnowledgeHome), featureFlagEnv, cfg.FeatureFlags.CacheTTL)
+	featureFlagUC := feature_flag_usecase.NewUsecase(featureFlagStoreGw, featureFlagGw, featureFlagEnv)
 	knowledgeBackfillGw := knowledge_backfill_gateway.NewGateway(altDB)
 	articleURLLookupGw := article_gateway.NewArticleURLLookupGateway(infra.Pool)
 
@@ -202,6 +212,7 @@ func newKnowledgeModule(infra *InfraModule, article *ArticleModule) *KnowledgeMo
 		SLOUsecase:                       sloUC,
 		AuditUsecase:                     auditUC,
 		MetricsUsecase:                   metricsUC,
+		FeatureFlagUsecase:               featureFlagUC,
 
 		RecallRailUsecase:    recallRailUC,
 		RecallSnoozeUsecase:  recallSnoozeUC,
@@ -0,0 +1,20 @@
+package di
+
+import (
+	"alt/orchestrator/gateway/organization_gateway"
+	"alt/orchestrator/usecase/organization_usecase"
+)
+
+// OrganizationModule holds the organization, team and invitation
+// components.
+type OrganizationModule struct {
+	OrganizationUsecase *organization_usecase.Usecase
+}
+
+// newOrganizationModule creates the OrganizationModule backed by alt_db.
+func newOrganizationModule(infra *InfraModule) *OrganizationModule {
+	gw := organization_gateway.NewGateway(infra.AltDBRepository)
+	return &OrganizationModule{
+		OrganizationUsecase: organization_usecase.NewUsecase(gw, gw, gw, organization_usecase.Config{}),
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"alt/dataplane/usecase/recap_articles_usecase"
 	"alt/orchestrator/driver/recap_job_driver"
 	"alt/orchestrator/gateway/dashboard_gateway"
+	"alt/orchestrator/gateway/reading_stats_gateway"
 	"alt/orchestrator/gateway/recap_gateway"
 	dashboard_usecase "alt/orchestrator/usecase/dashboard"
+	"alt/orchestrator/usecase/reading_stats_usecase"
 	"alt/orchestrator/usecase/recap_usecase"
 )
 
@@ -16,6 +18,7 @@ type RecapModule struct {
 	RecapUsecase            *recap_usecase.RecapUsecase
 	GetRecapJobsUsecase     dashboard_usecase.GetRecapJobsUsecase
 	DashboardMetricsUsecase *dashboard_usecase.DashboardMetricsUsecase
+	ReadingStatsUsecase     *reading_stats_usecase.Usecase
 }
 
 func newRecapModule(infra *InfraModule) *RecapModule {
@@ -42,10 +45,15 @@ func newRecapModule(infra *InfraModule) *RecapModule {
 	dashboardGw := dashboard_gateway.NewDashboardGateway()
 	dashboardMetricsUC := dashboard_usecase.NewDashboardMetricsUsecase(dashboardGw)
 
+	// Reading stats (weekly digest data)
+	readingStatsGw := reading_stats_gateway.NewGateway(infra.AltDBRepository)
+	readingStatsUC := reading_stats_usecase.NewUsecase(readingStatsGw, readingStatsGw)
+
 	return &RecapModule{
 		RecapArticlesUsecase:    recapArticlesUC,
 		RecapUsecase:            recapUC,
 		GetRecapJobsUsecase:     getRecapJobsUC,
 		DashboardMetricsUsecase: dashboardMetricsUC,
+		ReadingStatsUsecase:     readingStatsUC,
 	}
 }
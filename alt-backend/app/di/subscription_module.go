@@ -36,8 +36,8 @@ func newSubscriptionModule(infra *InfraModule) *SubscriptionModule {
 	// Subscription
 	subscriptionGw := subscription_gateway.NewSubscriptionGateway(pool)
 	listSubscriptionsUC := subscription_usecase.NewListSubscriptionsUsecase(subscriptionGw)
-	subscribeUC := subscription_usecase.NewSubscribeUsecase(subscriptionGw)
-	unsubscribeUC := subscription_usecase.NewUnsubscribeUsecase(subscriptionGw)
+	subscribeUC := subscription_usecase.NewSubscribeUsecase(subscriptionGw, infra.OutboxEventPublisher, infra.Transaction)
+	unsubscribeUC := subscription_usecase.NewUnsubscribeUsecase(subscriptionGw, infra.OutboxEventPublisher, infra.Transaction)
 	deleteFeedLinkUC := feed_link_usecase.NewDeleteFeedLinkUsecase(subscriptionGw)
 
 	// OPML
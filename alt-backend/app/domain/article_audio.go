@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrArticleAudioNotFound is returned when no audio has been requested
+	// for an article, or the article is not visible to the caller.
+	ErrArticleAudioNotFound = errors.New("article audio not found")
+	// ErrArticleAudioConflict is returned when a TTS worker reports on a
+	// request that has finished or been superseded by a newer one.
+	ErrArticleAudioConflict = errors.New("article audio request is no longer in progress")
+	// ErrAudioGenerationUnavailable is returned when audio cannot be
+	// requested because mq-hub publishing is disabled.
+	ErrAudioGenerationUnavailable = errors.New("audio generation is not available")
+)
+
+// ArticleAudioStatus is the state of an article's TTS generation
+// (article_audio.status).
+type ArticleAudioStatus string
+
+const (
+	ArticleAudioStatusPending    ArticleAudioStatus = "pending"
+	ArticleAudioStatusProcessing ArticleAudioStatus = "processing"
+	ArticleAudioStatusReady      ArticleAudioStatus = "ready"
+	ArticleAudioStatusFailed     ArticleAudioStatus = "failed"
+)
+
+// InFlight reports whether generation has been requested and not finished.
+func (s ArticleAudioStatus) InFlight() bool {
+	return s == ArticleAudioStatusPending || s == ArticleAudioStatusProcessing
+}
+
+// ArticleAudio is the generated audio for an article, or the state of its
+// generation. DurationSeconds and StorageURL are set once Status is ready.
+type ArticleAudio struct {
+	ArticleID       uuid.UUID          `json:"article_id"`
+	UserID          uuid.UUID          `json:"-"`
+	Status          ArticleAudioStatus `json:"status"`
+	Voice           string             `json:"voice,omitempty"`
+	DurationSeconds float64            `json:"duration_seconds,omitempty"`
+	StorageURL      string             `json:"storage_url,omitempty"`
+	ErrorMessage    string             `json:"error,omitempty"`
+	Attempts        int                `json:"attempts"`
+	RequestedAt     time.Time          `json:"requested_at"`
+	CompletedAt     *time.Time         `json:"completed_at,omitempty"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// ArticleAudioReport is a TTS worker's progress report for one request,
+// identified by the article and the attempt number it was published with.
+type ArticleAudioReport struct {
+	ArticleID       uuid.UUID
+	Attempt         int
+	Status          ArticleAudioStatus
+	DurationSeconds float64
+	StorageURL      string
+	ErrorMessage    string
+}
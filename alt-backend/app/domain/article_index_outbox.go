@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleIndexReason records why an article was marked for reindexing
+// (article_index_outbox.reason). Only the latest reason is kept.
+type ArticleIndexReason string
+
+const (
+	// ArticleIndexTitleFixed articles had their URL title replaced by the
+	// article title repair.
+	ArticleIndexTitleFixed ArticleIndexReason = "title_fixed"
+	// ArticleIndexReconciled articles had their title filled in from another
+	// ingest source by reconciliation.
+	ArticleIndexReconciled ArticleIndexReason = "reconciled"
+	// ArticleIndexTagsChanged articles were linked to a new tag.
+	ArticleIndexTagsChanged ArticleIndexReason = "tags_changed"
+	// ArticleIndexForced articles were queued by an admin.
+	ArticleIndexForced ArticleIndexReason = "forced"
+)
+
+// DirtyArticle is an article whose indexed copies in search-indexer and
+// rag-orchestrator are out of date. Marking an article again while it is
+// queued moves MarkedAt forward and resets Attempts, so an article changed
+// several times is still published once.
+type DirtyArticle struct {
+	ArticleID uuid.UUID          `json:"article_id"`
+	Reason    ArticleIndexReason `json:"reason"`
+	MarkedAt  time.Time          `json:"marked_at"`
+	// Attempts counts earlier publishes that failed and were rescheduled.
+	Attempts int `json:"attempts"`
+}
+
+// ArticleIndexDocument is the indexed part of an article, as published in
+// its ArticleUpdated event.
+type ArticleIndexDocument struct {
+	ArticleID   string
+	UserID      string
+	FeedID      string
+	Title       string
+	URL         string
+	Content     string
+	Tags        []string
+	PublishedAt time.Time
+}
@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidArticleURL is returned when a URL cannot be normalized for
+// cross-source matching.
+var ErrInvalidArticleURL = errors.New("invalid article url")
+
+// ArticleSourceStatus is where a source record stands in reconciliation
+// (article_source_records.status).
+type ArticleSourceStatus string
+
+const (
+	// ArticleSourcePending records have not been matched yet and are still
+	// within the grace period the ingest pipeline has to create the article.
+	ArticleSourcePending ArticleSourceStatus = "pending"
+	// ArticleSourceMatched records are linked to exactly one live article.
+	ArticleSourceMatched ArticleSourceStatus = "matched"
+	// ArticleSourceOrphaned records matched no article after the grace
+	// period. They are rechecked on every run.
+	ArticleSourceOrphaned ArticleSourceStatus = "orphaned"
+	// ArticleSourceConflict records matched articles that disagree in a way
+	// reconciliation will not resolve on its own; see ConflictReason.
+	ArticleSourceConflict ArticleSourceStatus = "conflict"
+)
+
+// ArticleSourceInoreader is the source name pre-processor reports
+// Inoreader stream items under.
+const ArticleSourceInoreader = "inoreader"
+
+// Conflict reasons stored in article_source_records.conflict_reason.
+const (
+	// ReconcileConflictMultipleFeeds means the record's URL or GUID matches
+	// articles in different feeds, which may be legitimate syndication, so
+	// nothing is linked.
+	ReconcileConflictMultipleFeeds = "multiple_feeds"
+	// ReconcileConflictPublishedAt means the source and the article disagree
+	// on the publication time by more than the configured tolerance.
+	ReconcileConflictPublishedAt = "published_at_mismatch"
+)
+
+// ArticleSourceRecord is one item as an ingest source saw it, kept so the
+// same article arriving through different pipelines can be matched up.
+type ArticleSourceRecord struct {
+	ID uuid.UUID `json:"id"`
+	// Source names the pipeline, e.g. ArticleSourceInoreader.
+	Source string `json:"source"`
+	// SourceItemID is the item's ID within Source (the Inoreader item ID).
+	SourceItemID string `json:"source_item_id"`
+	// GUID is the originating feed's item GUID, when the source exposes it.
+	GUID          string     `json:"guid,omitempty"`
+	UserID        uuid.UUID  `json:"user_id"`
+	URL           string     `json:"url"`
+	NormalizedURL string     `json:"normalized_url"`
+	Title         string     `json:"title,omitempty"`
+	Author        string     `json:"author,omitempty"`
+	PublishedAt   *time.Time `json:"published_at,omitempty"`
+
+	ArticleID      *uuid.UUID          `json:"article_id,omitempty"`
+	Status         ArticleSourceStatus `json:"status"`
+	ConflictReason string              `json:"conflict_reason,omitempty"`
+	FirstSeenAt    time.Time           `json:"first_seen_at"`
+	ReconciledAt   *time.Time          `json:"reconciled_at,omitempty"`
+}
+
+// ReconciliationCandidate is a live article a source record may refer to.
+// Articles already linked as duplicates are resolved to their canonical.
+type ReconciliationCandidate struct {
+	ArticleID   uuid.UUID
+	FeedID      *uuid.UUID
+	URL         string
+	Title       string
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+}
+
+// ArticleDuplicateLink marks DuplicateArticleID as another copy of
+// CanonicalArticleID. MatchKey records why, e.g. "url:<normalized url>" or
+// "guid:<guid>".
+type ArticleDuplicateLink struct {
+	CanonicalArticleID uuid.UUID `json:"canonical_article_id"`
+	DuplicateArticleID uuid.UUID `json:"duplicate_article_id"`
+	MatchKey           string    `json:"match_key"`
+}
+
+// ReconciliationOutcome is the decision for one source record, applied
+// atomically: duplicate links, metadata merged into the canonical article,
+// and the record's new status.
+type ReconciliationOutcome struct {
+	RecordID       uuid.UUID
+	Status         ArticleSourceStatus
+	ArticleID      *uuid.UUID
+	ConflictReason string
+	// MergeTitle fills the canonical article's title only if it is blank.
+	MergeTitle string
+	// MergePublishedAt fills the canonical article's published_at only if
+	// it is NULL.
+	MergePublishedAt *time.Time
+	Duplicates       []ArticleDuplicateLink
+}
+
+// ReconciliationRunResult summarizes one reconciliation pass.
+type ReconciliationRunResult struct {
+	Scanned          int `json:"scanned"`
+	Matched          int `json:"matched"`
+	MetadataMerged   int `json:"metadata_merged"`
+	DuplicatesLinked int `json:"duplicates_linked"`
+	Orphaned         int `json:"orphaned"`
+	Conflicts        int `json:"conflicts"`
+	StillPending     int `json:"still_pending"`
+	Failed           int `json:"failed"`
+}
+
+// ReconciliationStatusCount is the number of source records per source and
+// status.
+type ReconciliationStatusCount struct {
+	Source string              `json:"source"`
+	Status ArticleSourceStatus `json:"status"`
+	Count  int64               `json:"count"`
+}
+
+// ReconciliationReport is the state of cross-source reconciliation: totals
+// plus the records that need attention.
+type ReconciliationReport struct {
+	Counts         []ReconciliationStatusCount `json:"counts"`
+	DuplicateLinks int64                       `json:"duplicate_links"`
+	Records        []*ArticleSourceRecord      `json:"records"`
+}
+
+// trackingParams are query parameters that identify a click, not a page.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref_src": true,
+	"igshid":  true,
+}
+
+// NormalizeArticleURL reduces an article URL to the form used to match the
+// same article across sources: https scheme, lower-case host without
+// "www." or a default port, no fragment, no trailing slash, and the query
+// sorted with tracking parameters (utm_* and click IDs) removed.
+func NormalizeArticleURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", ErrInvalidArticleURL
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if (scheme != "http" && scheme != "https") || u.Hostname() == "" {
+		return "", ErrInvalidArticleURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host = net.JoinHostPort(host, port)
+	}
+
+	// Encode sorts by key, so parameter order no longer matters.
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if strings.HasPrefix(lower, "utm_") || trackingParams[lower] {
+			query.Del(key)
+		}
+	}
+
+	normalized := url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     strings.TrimRight(u.Path, "/"),
+		RawPath:  strings.TrimRight(u.RawPath, "/"),
+		RawQuery: query.Encode(),
+	}
+	return normalized.String(), nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeArticleURL(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"already normal", "https://example.com/posts/1", "https://example.com/posts/1"},
+		{"http and www", "http://www.Example.com/posts/1", "https://example.com/posts/1"},
+		{"trailing slash and fragment", "https://example.com/posts/1/#comments", "https://example.com/posts/1"},
+		{"tracking params dropped", "https://example.com/p?utm_source=rss&id=7&fbclid=x&UTM_Medium=feed", "https://example.com/p?id=7"},
+		{"query sorted", "https://example.com/p?b=2&a=1", "https://example.com/p?a=1&b=2"},
+		{"default port dropped", "https://example.com:443/p", "https://example.com/p"},
+		{"custom port kept", "https://example.com:8443/p", "https://example.com:8443/p"},
+		{"root", "https://example.com/", "https://example.com"},
+		{"escaped path kept", "https://example.com/a%2Fb/", "https://example.com/a%2Fb"},
+		{"surrounding space", "  https://example.com/p  ", "https://example.com/p"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeArticleURL(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeArticleURL_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "not a url", "ftp://example.com/file", "https:///path", "://bad"} {
+		_, err := NormalizeArticleURL(raw)
+		assert.ErrorIs(t, err, ErrInvalidArticleURL, raw)
+	}
+}
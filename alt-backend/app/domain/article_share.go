@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrShareLinkNotFound is returned when a share token is malformed,
+	// carries a bad signature, or names a link that does not exist or is
+	// not visible to the caller.
+	ErrShareLinkNotFound = errors.New("share link not found")
+	// ErrShareLinkGone is returned when a share link has expired, was
+	// revoked, or its article has since been deleted.
+	ErrShareLinkGone = errors.New("share link is no longer available")
+)
+
+// ShareField names an article field a share link may expose
+// (article_share_links.fields). Anything not listed here is never served
+// on the public view, whatever the link says.
+type ShareField string
+
+const (
+	ShareFieldTitle       ShareField = "title"
+	ShareFieldURL         ShareField = "url"
+	ShareFieldPublishedAt ShareField = "published_at"
+	ShareFieldSummary     ShareField = "summary"
+	ShareFieldContent     ShareField = "content"
+)
+
+// ShareableFields is the allowlist of fields a share link may expose.
+var ShareableFields = []ShareField{
+	ShareFieldTitle,
+	ShareFieldURL,
+	ShareFieldPublishedAt,
+	ShareFieldSummary,
+	ShareFieldContent,
+}
+
+// DefaultShareFields are exposed when a link is created without an
+// explicit field list. The full article body is opt-in.
+var DefaultShareFields = []ShareField{
+	ShareFieldTitle,
+	ShareFieldURL,
+	ShareFieldPublishedAt,
+	ShareFieldSummary,
+}
+
+// IsShareableField reports whether f is on the ShareableFields allowlist.
+func IsShareableField(f ShareField) bool {
+	for _, allowed := range ShareableFields {
+		if f == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// ArticleShareLink is a public, read-only link to one of a user's
+// articles. The token itself is never stored; it is derived from ID.
+type ArticleShareLink struct {
+	ID           uuid.UUID    `json:"id"`
+	ArticleID    uuid.UUID    `json:"article_id"`
+	UserID       uuid.UUID    `json:"-"`
+	Fields       []ShareField `json:"fields"`
+	CreatedAt    time.Time    `json:"created_at"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+	RevokedAt    *time.Time   `json:"revoked_at,omitempty"`
+	ViewCount    int64        `json:"view_count"`
+	LastViewedAt *time.Time   `json:"last_viewed_at,omitempty"`
+}
+
+// Active reports whether the link can still be viewed at now.
+func (l *ArticleShareLink) Active(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
+
+// SharedArticle is the source data behind a share link's public view.
+type SharedArticle struct {
+	Title       string
+	URL         string
+	PublishedAt *time.Time
+	Content     string
+	Summary     string
+}
+
+// SharedArticleView is the public, unauthenticated view of a shared
+// article. Only fields the link exposes are populated.
+type SharedArticleView struct {
+	Title       string     `json:"title,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Summary     string     `json:"summary,omitempty"`
+	Content     string     `json:"content,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrArticleTitleFixJobActive is returned when starting a title repair
+	// job while another one is still pending or running.
+	ErrArticleTitleFixJobActive = errors.New("an article title fix job is already active")
+	// ErrArticleTitleFixJobNotFound is returned for an unknown job ID.
+	ErrArticleTitleFixJobNotFound = errors.New("article title fix job not found")
+)
+
+// ArticleTitleFixStatus is where an article title repair job stands
+// (article_title_fix_jobs.status).
+type ArticleTitleFixStatus string
+
+const (
+	// ArticleTitleFixPending jobs were requested and wait for the
+	// background job to pick them up.
+	ArticleTitleFixPending ArticleTitleFixStatus = "pending"
+	// ArticleTitleFixRunning jobs are being worked through batch by batch.
+	ArticleTitleFixRunning ArticleTitleFixStatus = "running"
+	// ArticleTitleFixCompleted jobs found no more articles to repair.
+	ArticleTitleFixCompleted ArticleTitleFixStatus = "completed"
+)
+
+// ArticleTitleFixOutcome is what happened to one article in a repair job.
+type ArticleTitleFixOutcome string
+
+const (
+	// ArticleTitleFixUpdated articles got the title found on their page.
+	ArticleTitleFixUpdated ArticleTitleFixOutcome = "updated"
+	// ArticleTitleFixFailed articles could not be fetched.
+	ArticleTitleFixFailed ArticleTitleFixOutcome = "failed"
+	// ArticleTitleFixSkipped articles were left alone: the scraping policy
+	// denied the fetch or the page had no usable title.
+	ArticleTitleFixSkipped ArticleTitleFixOutcome = "skipped"
+)
+
+// ArticleTitleFixJob is one run of the article title repair. Articles are
+// processed in id order; CursorArticleID is the last one processed, so a
+// restarted job resumes after it.
+type ArticleTitleFixJob struct {
+	JobID           uuid.UUID             `json:"job_id"`
+	Status          ArticleTitleFixStatus `json:"status"`
+	RequestedBy     *uuid.UUID            `json:"requested_by,omitempty"`
+	CursorArticleID *uuid.UUID            `json:"cursor_article_id,omitempty"`
+	TotalArticles   int                   `json:"total_articles"`
+	ProcessedCount  int                   `json:"processed_count"`
+	UpdatedCount    int                   `json:"updated_count"`
+	FailedCount     int                   `json:"failed_count"`
+	SkippedCount    int                   `json:"skipped_count"`
+	CreatedAt       time.Time             `json:"created_at"`
+	StartedAt       *time.Time            `json:"started_at,omitempty"`
+	CompletedAt     *time.Time            `json:"completed_at,omitempty"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+// ArticleTitleFixCandidate is an article whose stored title is its URL.
+type ArticleTitleFixCandidate struct {
+	ArticleID uuid.UUID
+	URL       string
+	Title     string
+}
+
+// ArticleTitleFixResult records what a job did to one article.
+type ArticleTitleFixResult struct {
+	JobID       uuid.UUID              `json:"job_id"`
+	ArticleID   uuid.UUID              `json:"article_id"`
+	URL         string                 `json:"url"`
+	OldTitle    string                 `json:"old_title"`
+	NewTitle    string                 `json:"new_title,omitempty"`
+	Outcome     ArticleTitleFixOutcome `json:"outcome"`
+	Detail      string                 `json:"detail,omitempty"`
+	ProcessedAt time.Time              `json:"processed_at"`
+}
+
+// ArticleTitleFixReport is a job together with the articles it updated and
+// the ones it could not.
+type ArticleTitleFixReport struct {
+	Job     *ArticleTitleFixJob      `json:"job"`
+	Updated []*ArticleTitleFixResult `json:"updated"`
+	Failed  []*ArticleTitleFixResult `json:"failed"`
+	Skipped []*ArticleTitleFixResult `json:"skipped"`
+}
+
+// IsURLTitle reports whether title is a URL stored in place of a real
+// title, which is what the repair job looks for.
+func IsURLTitle(title string) bool {
+	title = strings.TrimSpace(title)
+	return strings.HasPrefix(title, "http://") || strings.HasPrefix(title, "https://")
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsURLTitle(t *testing.T) {
+	assert.True(t, IsURLTitle("https://example.com/post"))
+	assert.True(t, IsURLTitle("  http://example.com/post"))
+	assert.False(t, IsURLTitle("https is not optional"))
+	assert.False(t, IsURLTitle("Go 1.24 release notes"))
+	assert.False(t, IsURLTitle(""))
+}
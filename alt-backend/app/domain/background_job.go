@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// BackgroundJobState is the persisted state of one scheduled background job
+// (background_job_states), shared by every alt-backend replica.
+type BackgroundJobState struct {
+	Name           string     `json:"name"`
+	LastStartedAt  *time.Time `json:"last_started_at,omitempty"`
+	LastFinishedAt *time.Time `json:"last_finished_at,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	// LastError is the error of the last run, nil when it succeeded.
+	LastError     *string    `json:"last_error,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	RunCount      int64      `json:"run_count"`
+	FailureCount  int64      `json:"failure_count"`
+	// LeaseOwner and LeaseExpiresAt are set while a replica runs the job.
+	LeaseOwner     *string    `json:"lease_owner,omitempty"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+}
+
+// LeaseActive reports whether some replica holds the job's lease at now.
+func (s BackgroundJobState) LeaseActive(now time.Time) bool {
+	return s.LeaseOwner != nil && s.LeaseExpiresAt != nil && s.LeaseExpiresAt.After(now)
+}
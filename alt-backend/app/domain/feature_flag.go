@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"hash/crc32"
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // Feature flag name constants for Knowledge Home.
 //
 // FlagRecallRail was retired by ADR-000913 §D-9 PR 13 once the recall
@@ -14,3 +21,69 @@ const (
 	FlagStreamUpdates             = "enable_stream_updates"
 	FlagSupersedeUX               = "enable_supersede_ux"
 )
+
+// FeatureFlagSource says which rule decided a flag evaluation.
+type FeatureFlagSource string
+
+const (
+	// FeatureFlagSourceOverride: a per-user override decided.
+	FeatureFlagSourceOverride FeatureFlagSource = "override"
+	// FeatureFlagSourceRollout: the stored flag's enabled bit and rollout
+	// percentage decided.
+	FeatureFlagSourceRollout FeatureFlagSource = "rollout"
+	// FeatureFlagSourceConfig: no stored flag; the config toggles decided.
+	FeatureFlagSourceConfig FeatureFlagSource = "config"
+)
+
+// MaxFeatureFlagNameLength bounds stored flag names.
+const MaxFeatureFlagNameLength = 64
+
+// FeatureFlag is a stored flag for one environment.
+type FeatureFlag struct {
+	Name              string     `json:"name"`
+	Environment       string     `json:"environment"`
+	Enabled           bool       `json:"enabled"`
+	RolloutPercentage int        `json:"rollout_percentage"`
+	Description       string     `json:"description"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+
+	// Overrides is only populated by admin listings.
+	Overrides []FeatureFlagOverride `json:"overrides,omitempty"`
+}
+
+// FeatureFlagOverride forces a flag on or off for one user.
+type FeatureFlagOverride struct {
+	FlagName    string     `json:"flag_name"`
+	Environment string     `json:"environment"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Enabled     bool       `json:"enabled"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// FeatureFlagEvaluation is the outcome of evaluating one flag for one user.
+type FeatureFlagEvaluation struct {
+	Name    string            `json:"name"`
+	Enabled bool              `json:"enabled"`
+	Source  FeatureFlagSource `json:"source"`
+}
+
+// FeatureFlagBucket maps userID to a stable bucket in [0, 100). A user's
+// bucket is the same for every flag, so raising a rollout percentage only
+// ever adds users.
+func FeatureFlagBucket(userID uuid.UUID) int {
+	return int(crc32.ChecksumIEEE(userID[:]) % 100)
+}
+
+// EnabledFor evaluates f for userID. override is the user's override, or
+// nil when there is none.
+func (f FeatureFlag) EnabledFor(userID uuid.UUID, override *bool) (bool, FeatureFlagSource) {
+	if override != nil {
+		return *override, FeatureFlagSourceOverride
+	}
+	if !f.Enabled || f.RolloutPercentage <= 0 {
+		return false, FeatureFlagSourceRollout
+	}
+	return f.RolloutPercentage >= 100 || FeatureFlagBucket(userID) < f.RolloutPercentage, FeatureFlagSourceRollout
+}
@@ -0,0 +1,33 @@
+package domain
+
+// Feed formats reported by feed discovery.
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+	FeedFormatJSON = "json"
+)
+
+// Where a discovered feed candidate came from.
+const (
+	FeedDiscoverySourceDirect    = "direct"     // the submitted URL is itself a feed
+	FeedDiscoverySourceLinkTag   = "link_tag"   // <link rel="alternate"> in the page head
+	FeedDiscoverySourceCommonURL = "common_url" // well-known path such as /feed or /rss.xml
+)
+
+// DiscoveryPage is a fetched web page used as the starting point for feed
+// discovery. URL is the final URL after redirects, used to resolve relative
+// links.
+type DiscoveryPage struct {
+	URL         string
+	ContentType string
+	Body        []byte
+}
+
+// DiscoveredFeed is a validated feed found for a website.
+type DiscoveredFeed struct {
+	URL       string `json:"url"`
+	Title     string `json:"title"`
+	Format    string `json:"format"`
+	ItemCount int    `json:"item_count"`
+	Source    string `json:"source"`
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidHomeRankingWeights is returned for weight sets the ranker
+// cannot use: a negative or non-finite weight, or all weights zero.
+var ErrInvalidHomeRankingWeights = errors.New("invalid home ranking weights")
+
+// DefaultHomeRankingExperiment labels users without a
+// home_ranking_experiments row.
+const DefaultHomeRankingExperiment = "default"
+
+// HomeRankingWeights are the relative weights of the home feed ranking
+// signals. Only their ratios matter: scores are normalised by their sum.
+type HomeRankingWeights struct {
+	Recency        float64 `json:"recency"`
+	FeedAffinity   float64 `json:"feed_affinity"`
+	ReadSimilarity float64 `json:"read_similarity"`
+	Trending       float64 `json:"trending"`
+}
+
+// DefaultHomeRankingWeights keep the timeline mostly chronological while
+// lifting items from the user's favourite feeds and topics.
+var DefaultHomeRankingWeights = HomeRankingWeights{
+	Recency:        0.4,
+	FeedAffinity:   0.25,
+	ReadSimilarity: 0.2,
+	Trending:       0.15,
+}
+
+// Validate reports whether w can be used to rank.
+func (w HomeRankingWeights) Validate() error {
+	for _, v := range []float64{w.Recency, w.FeedAffinity, w.ReadSimilarity, w.Trending} {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return ErrInvalidHomeRankingWeights
+		}
+	}
+	if w.sum() <= 0 {
+		return ErrInvalidHomeRankingWeights
+	}
+	return nil
+}
+
+func (w HomeRankingWeights) sum() float64 {
+	return w.Recency + w.FeedAffinity + w.ReadSimilarity + w.Trending
+}
+
+// Score blends s with w. It returns the score in [0, 1] and each signal's
+// share of it; the contributions add up to the score.
+func (w HomeRankingWeights) Score(s HomeRankingSignals) (float64, HomeRankingSignals) {
+	total := w.sum()
+	if total <= 0 {
+		return 0, HomeRankingSignals{}
+	}
+	c := HomeRankingSignals{
+		Recency:        w.Recency / total * s.Recency,
+		FeedAffinity:   w.FeedAffinity / total * s.FeedAffinity,
+		ReadSimilarity: w.ReadSimilarity / total * s.ReadSimilarity,
+		Trending:       w.Trending / total * s.Trending,
+	}
+	return c.Recency + c.FeedAffinity + c.ReadSimilarity + c.Trending, c
+}
+
+// HomeRankingExperiment assigns a user a ranking weight set
+// (home_ranking_experiments).
+type HomeRankingExperiment struct {
+	UserID     uuid.UUID          `json:"user_id"`
+	Experiment string             `json:"experiment"`
+	Weights    HomeRankingWeights `json:"weights"`
+	UpdatedAt  *time.Time         `json:"updated_at,omitempty"`
+}
+
+// HomeRankingSignals are an item's ranking signals, each in [0, 1].
+type HomeRankingSignals struct {
+	Recency        float64 `json:"recency"`
+	FeedAffinity   float64 `json:"feed_affinity"`
+	ReadSimilarity float64 `json:"read_similarity"`
+	Trending       float64 `json:"trending"`
+}
+
+// Strongest names the largest of s, preferring recency on ties.
+func (s HomeRankingSignals) Strongest() string {
+	name, best := "recency", s.Recency
+	for _, c := range []struct {
+		name  string
+		value float64
+	}{
+		{"feed_affinity", s.FeedAffinity},
+		{"read_similarity", s.ReadSimilarity},
+		{"trending", s.Trending},
+	} {
+		if c.value > best {
+			name, best = c.name, c.value
+		}
+	}
+	return name
+}
+
+// HomeRankingInputs is the per-user data the ranking signals are derived
+// from, loaded once per page.
+type HomeRankingInputs struct {
+	// FeedReads counts the user's recent reads from each candidate's
+	// subscription, keyed by candidate feed ID.
+	FeedReads map[uuid.UUID]int
+	// FeedTags lists each candidate's tags, keyed by feed ID.
+	FeedTags map[uuid.UUID][]string
+	// ReadTags counts the tags of the user's recently read items.
+	ReadTags map[string]int
+	// TrendingTags counts the tags of items recently added to the user's
+	// subscriptions.
+	TrendingTags map[string]int
+}
+
+// RankedFeedItem is a timeline item with the reasons for its position.
+type RankedFeedItem struct {
+	Item *FeedItem
+	// Rank is the item's 1-based position in the ranked page and
+	// ChronologicalRank its position in the newest-first page.
+	Rank              int
+	ChronologicalRank int
+	Score             float64
+	Signals           HomeRankingSignals
+	Contributions     HomeRankingSignals
+	// MatchedTags are the item's tags that also appear in the user's read
+	// history.
+	MatchedTags []string
+}
+
+// HomeRankingPage is one ranked page of the home feed.
+type HomeRankingPage struct {
+	Experiment string
+	Weights    HomeRankingWeights
+	Items      []RankedFeedItem
+	HasMore    bool
+	// NextCursor is the created_at of the page's oldest item. Ranking only
+	// reorders within a page, so chronological cursors keep working.
+	NextCursor *time.Time
+	// Degraded is set when the signal inputs could not be loaded and the
+	// page was ranked on recency alone.
+	Degraded bool
+}
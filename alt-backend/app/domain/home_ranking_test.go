@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHomeRankingWeights_Validate(t *testing.T) {
+	assert.NoError(t, DefaultHomeRankingWeights.Validate())
+	assert.NoError(t, HomeRankingWeights{Trending: 2}.Validate())
+	assert.ErrorIs(t, HomeRankingWeights{}.Validate(), ErrInvalidHomeRankingWeights)
+	assert.ErrorIs(t, HomeRankingWeights{Recency: 1, FeedAffinity: -0.1}.Validate(), ErrInvalidHomeRankingWeights)
+	assert.ErrorIs(t, HomeRankingWeights{Recency: math.Inf(1)}.Validate(), ErrInvalidHomeRankingWeights)
+}
+
+func TestHomeRankingWeights_ScoreNormalisesWeights(t *testing.T) {
+	signals := HomeRankingSignals{Recency: 1, FeedAffinity: 0.5}
+
+	score, contributions := HomeRankingWeights{Recency: 2, FeedAffinity: 2}.Score(signals)
+
+	assert.InDelta(t, 0.75, score, 1e-9)
+	assert.InDelta(t, 0.5, contributions.Recency, 1e-9)
+	assert.InDelta(t, 0.25, contributions.FeedAffinity, 1e-9)
+	assert.Equal(t, "recency", contributions.Strongest())
+}
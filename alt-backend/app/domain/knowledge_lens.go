@@ -1,11 +1,16 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrLensRevisionConflict is returned when a lens write names a revision
+// that is no longer current, i.e. the lens was changed by another device.
+var ErrLensRevisionConflict = errors.New("lens was modified by another request")
+
 // KnowledgeLens represents a saved viewpoint for the knowledge stream.
 type KnowledgeLens struct {
 	LensID      uuid.UUID  `json:"lens_id" db:"lens_id"`
@@ -16,6 +21,9 @@ type KnowledgeLens struct {
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	ArchivedAt  *time.Time `json:"archived_at" db:"archived_at"`
+	// Revision increases on every update or archive of the lens; writes may
+	// name the revision they expect to replace (optimistic concurrency).
+	Revision int64 `json:"revision" db:"revision"`
 
 	CurrentVersion *KnowledgeLensVersion `json:"current_version,omitempty"`
 }
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrLegalHoldAlreadyActive is returned when placing a hold on an account
+	// that already has an active hold.
+	ErrLegalHoldAlreadyActive = errors.New("account already has an active legal hold")
+	// ErrLegalHoldNotFound is returned when releasing an account without an
+	// active hold.
+	ErrLegalHoldNotFound = errors.New("no active legal hold for account")
+)
+
+// LegalHold suspends retention and deletion of an account's data across
+// services until it is released. ReleasedAt is nil while the hold is active.
+type LegalHold struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Reason     string     `json:"reason"`
+	PlacedBy   uuid.UUID  `json:"placed_by"`
+	PlacedAt   time.Time  `json:"placed_at"`
+	ReleasedBy *uuid.UUID `json:"released_by,omitempty"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+}
+
+// IsActive reports whether the hold has not been released.
+func (h *LegalHold) IsActive() bool {
+	return h.ReleasedAt == nil
+}
+
+// Compliance audit action constants (account_compliance_audit_log.action).
+const (
+	ComplianceActionLegalHoldPlaced   = "legal_hold_placed"
+	ComplianceActionLegalHoldReleased = "legal_hold_released"
+	ComplianceActionAccountExported   = "account_exported"
+)
+
+// ComplianceAuditEntry is an append-only record of a compliance action
+// taken by an admin against an account.
+type ComplianceAuditEntry struct {
+	ID        uuid.UUID      `json:"id"`
+	UserID    uuid.UUID      `json:"user_id"`
+	ActorID   uuid.UUID      `json:"actor_id"`
+	Action    string         `json:"action"`
+	Details   map[string]any `json:"details"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// AccountExportSections lists the per-account datasets included in an
+// account export, in archive order. Each section becomes <section>.jsonl.
+var AccountExportSections = []string{
+	"feed_subscriptions",
+	"favorite_feeds",
+	"feed_read_status",
+	"article_reading_status",
+	"declined_domains",
+	"legal_holds",
+}
+
+// AccountExportFile describes one file inside an account export archive.
+type AccountExportFile struct {
+	Name   string `json:"name"`
+	Rows   int64  `json:"rows"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// AccountExportManifest is written as manifest.json inside the export
+// archive so a recipient can verify every file independently.
+type AccountExportManifest struct {
+	UserID      uuid.UUID           `json:"user_id"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	GeneratedBy uuid.UUID           `json:"generated_by"`
+	LegalHold   bool                `json:"legal_hold"`
+	Files       []AccountExportFile `json:"files"`
+}
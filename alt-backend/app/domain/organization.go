@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrOrganizationNotFound is returned when an organization does not
+	// exist or the caller is not a member of it.
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrOrgSlugTaken is returned when the tenant already has an
+	// organization with the requested slug.
+	ErrOrgSlugTaken = errors.New("organization slug is already taken")
+	// ErrOrgForbidden is returned when the caller's role in the
+	// organization does not allow the operation.
+	ErrOrgForbidden = errors.New("insufficient organization role")
+	// ErrOrgMemberNotFound is returned when a user is not a member of the
+	// organization.
+	ErrOrgMemberNotFound = errors.New("organization member not found")
+	// ErrOrgLastOwner is returned when an operation would leave an
+	// organization without an owner.
+	ErrOrgLastOwner = errors.New("organization must keep at least one owner")
+	// ErrTeamNotFound is returned when a team does not exist in the
+	// organization.
+	ErrTeamNotFound = errors.New("team not found")
+	// ErrTeamNameTaken is returned when the organization already has a team
+	// with the requested name.
+	ErrTeamNameTaken = errors.New("team name is already taken")
+	// ErrOrgInvitationNotFound is returned for unknown invitation tokens and
+	// for invitations addressed to someone else.
+	ErrOrgInvitationNotFound = errors.New("organization invitation not found")
+	// ErrOrgInvitationGone is returned when an invitation has expired, was
+	// revoked, or has already been accepted.
+	ErrOrgInvitationGone = errors.New("organization invitation is no longer valid")
+)
+
+// OrgRole is a user's role within one organization
+// (organization_members.role).
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+var orgRoleRank = map[OrgRole]int{
+	OrgRoleMember: 1,
+	OrgRoleAdmin:  2,
+	OrgRoleOwner:  3,
+}
+
+// IsValid reports whether r is a known role.
+func (r OrgRole) IsValid() bool {
+	_, ok := orgRoleRank[r]
+	return ok
+}
+
+// AtLeast reports whether r grants everything min does. Unknown roles
+// grant nothing.
+func (r OrgRole) AtLeast(min OrgRole) bool {
+	rank, ok := orgRoleRank[r]
+	return ok && rank >= orgRoleRank[min]
+}
+
+// Organization groups users of one tenant. Role is the caller's role when
+// the organization is listed for a user.
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	TenantID  uuid.UUID `json:"-"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	Role      OrgRole   `json:"role,omitempty"`
+}
+
+// OrgMember is one user's membership of an organization.
+type OrgMember struct {
+	OrgID    uuid.UUID `json:"org_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Role     OrgRole   `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// Team is a group of organization members. Teams nest through ParentID
+// and never span organizations.
+type Team struct {
+	ID        uuid.UUID  `json:"id"`
+	OrgID     uuid.UUID  `json:"org_id"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TeamMember is one member of a team.
+type TeamMember struct {
+	TeamID  uuid.UUID `json:"team_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// OrgInvitation invites an email address into an organization with a
+// role. The token that redeems it is never stored, only its hash.
+type OrgInvitation struct {
+	ID         uuid.UUID  `json:"id"`
+	OrgID      uuid.UUID  `json:"org_id"`
+	TenantID   uuid.UUID  `json:"-"`
+	Email      string     `json:"email"`
+	Role       OrgRole    `json:"role"`
+	TokenHash  []byte     `json:"-"`
+	InvitedBy  uuid.UUID  `json:"invited_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	AcceptedBy *uuid.UUID `json:"accepted_by,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Pending reports whether the invitation can still be accepted at now.
+func (i *OrgInvitation) Pending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// OrgContext is the organization a request acts in, with the caller's role
+// there. TeamIDs are the teams the caller belongs to, directly or through
+// a parent team, for scoping team-owned resources.
+type OrgContext struct {
+	OrgID   uuid.UUID   `json:"org_id"`
+	UserID  uuid.UUID   `json:"user_id"`
+	Role    OrgRole     `json:"role"`
+	TeamIDs []uuid.UUID `json:"team_ids"`
+}
+
+// InTeam reports whether the caller's team scope includes teamID.
+func (o *OrgContext) InTeam(teamID uuid.UUID) bool {
+	for _, id := range o.TeamIDs {
+		if id == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+type orgContextKey string
+
+const OrgContextKey orgContextKey = "org_context"
+
+// SetOrgContext stores the request's organization context.
+func SetOrgContext(ctx context.Context, org *OrgContext) context.Context {
+	return context.WithValue(ctx, OrgContextKey, org)
+}
+
+// GetOrgFromContext returns the request's organization context, or
+// ErrOrganizationNotFound when the request is not acting in one.
+func GetOrgFromContext(ctx context.Context) (*OrgContext, error) {
+	org, ok := ctx.Value(OrgContextKey).(*OrgContext)
+	if !ok || org == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, nil
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReadingStatsTimezone is the timezone reading days and hours are bucketed
+// in, matching the morning letter's default edition timezone.
+const ReadingStatsTimezone = "Asia/Tokyo"
+
+// ReadingStatsLocation is ReadingStatsTimezone as a fixed +09:00 zone.
+// Japan has no DST, so this avoids depending on tzdata in the image.
+var ReadingStatsLocation = time.FixedZone(ReadingStatsTimezone, 9*60*60)
+
+// ReadingStatsTopN bounds the top feeds and top tags lists.
+const ReadingStatsTopN = 5
+
+// ReadingDayStat is one user's aggregated reading for one local day
+// (user_reading_daily_stats).
+type ReadingDayStat struct {
+	Date         time.Time `json:"date"`
+	ArticlesRead int       `json:"articles_read"`
+}
+
+// ReadingFeedStat is a subscription ranked by how many of its articles the
+// user read in a period.
+type ReadingFeedStat struct {
+	FeedLinkID uuid.UUID `json:"feed_link_id"`
+	FeedURL    string    `json:"feed_url"`
+	Reads      int       `json:"reads"`
+}
+
+// ReadingTagStat is a tag ranked by how many read articles carried it.
+type ReadingTagStat struct {
+	Tag   string `json:"tag"`
+	Reads int    `json:"reads"`
+}
+
+// ReadingStats is a user's reading summary over [PeriodStart, PeriodEnd],
+// both inclusive local dates. It is the payload of GET /v1/stats/reading
+// and the data behind the weekly digest.
+type ReadingStats struct {
+	PeriodStart                time.Time         `json:"period_start"`
+	PeriodEnd                  time.Time         `json:"period_end"`
+	Timezone                   string            `json:"timezone"`
+	ArticlesRead               int               `json:"articles_read"`
+	PreviousPeriodArticlesRead int               `json:"previous_period_articles_read"`
+	DailyAverage               float64           `json:"daily_average"`
+	ActiveDays                 int               `json:"active_days"`
+	BusiestHour                *int              `json:"busiest_hour,omitempty"`
+	HourlyDistribution         [24]int           `json:"hourly_distribution"`
+	Daily                      []ReadingDayStat  `json:"daily"`
+	TopFeeds                   []ReadingFeedStat `json:"top_feeds"`
+	TopTags                    []ReadingTagStat  `json:"top_tags"`
+	ComputedAt                 *time.Time        `json:"computed_at,omitempty"`
+}
+
+// ReadingStatsAggregate is what the aggregate tables hold for one user over
+// a date range, before the usecase derives averages and comparisons.
+type ReadingStatsAggregate struct {
+	Daily              []ReadingDayStat
+	HourlyDistribution [24]int
+	TopFeeds           []ReadingFeedStat
+	TopTags            []ReadingTagStat
+	ComputedAt         *time.Time
+}
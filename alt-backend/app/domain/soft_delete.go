@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SoftDeleteRestoreWindow is how long a soft-deleted feed or article stays
+// restorable. The soft-delete-purge job permanently removes rows older
+// than this.
+const SoftDeleteRestoreWindow = 30 * 24 * time.Hour
+
+var (
+	// ErrDeletedItemNotFound is returned when restoring an item that is not
+	// soft-deleted, does not exist, or is not visible to the caller.
+	ErrDeletedItemNotFound = errors.New("deleted item not found")
+	// ErrRestoreWindowExpired is returned when restoring an item deleted
+	// longer ago than SoftDeleteRestoreWindow.
+	ErrRestoreWindowExpired = errors.New("restore window has expired")
+	// ErrItemNotFound is returned when soft-deleting an item that does not
+	// exist, is already deleted, or is not visible to the caller.
+	ErrItemNotFound = errors.New("item not found")
+)
+
+// SoftDeleteEntity names a soft-deletable table
+// (soft_delete_audit_log.entity_type).
+type SoftDeleteEntity string
+
+const (
+	SoftDeleteEntityFeed    SoftDeleteEntity = "feed"
+	SoftDeleteEntityArticle SoftDeleteEntity = "article"
+)
+
+// Soft-delete audit action constants (soft_delete_audit_log.action).
+const (
+	SoftDeleteActionDeleted  = "deleted"
+	SoftDeleteActionRestored = "restored"
+	SoftDeleteActionPurged   = "purged"
+)
+
+// DeletedItem is a soft-deleted feed or article that may still be restored.
+// DeletedBy is nil for rows deleted before deleted_by was tracked.
+type DeletedItem struct {
+	Entity       SoftDeleteEntity `json:"entity"`
+	ID           uuid.UUID        `json:"id"`
+	Title        string           `json:"title"`
+	URL          string           `json:"url"`
+	DeletedAt    time.Time        `json:"deleted_at"`
+	DeletedBy    *uuid.UUID       `json:"deleted_by,omitempty"`
+	RestoreUntil time.Time        `json:"restore_until"`
+}
+
+// SoftDeleteEvent is one soft_delete_audit_log entry. Internal consumers
+// that mirror alt-backend data (the RAG index) replay these to follow
+// deletes, restores and purges.
+type SoftDeleteEvent struct {
+	ID         uuid.UUID        `json:"id"`
+	Entity     SoftDeleteEntity `json:"entity"`
+	EntityID   uuid.UUID        `json:"entity_id"`
+	Action     string           `json:"action"`
+	OccurredAt time.Time        `json:"occurred_at"`
+}
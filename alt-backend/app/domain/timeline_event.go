@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+)
+
+// TimelineEventType names an update pushed to a user's live timeline. The
+// value is sent as the SSE event name.
+type TimelineEventType string
+
+const (
+	// TimelineEventArticleCreated is a new article in one of the user's feeds.
+	TimelineEventArticleCreated TimelineEventType = "article_created"
+	// TimelineEventReadStateChanged is the user marking a feed read or
+	// unread, possibly from another device.
+	TimelineEventReadStateChanged TimelineEventType = "read_state_changed"
+)
+
+// TimelineEvent is one live timeline update for UserID. Only the fields of
+// its Type are set; the rest are left out of the JSON sent to the client.
+type TimelineEvent struct {
+	Type   TimelineEventType `json:"type"`
+	UserID string            `json:"-"`
+
+	// article_created
+	ArticleID   string     `json:"article_id,omitempty"`
+	FeedID      string     `json:"feed_id,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// read_state_changed
+	FeedURL string `json:"feed_url,omitempty"`
+	IsRead  *bool  `json:"is_read,omitempty"`
+
+	OccurredAt time.Time `json:"occurred_at"`
+}
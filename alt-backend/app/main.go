@@ -101,7 +101,7 @@ func main() {
 	container := di.NewApplicationComponents(pool, cfg)
 
 	// Start background jobs via scheduler (context-aware with graceful shutdown)
-	scheduler := job.NewJobScheduler()
+	scheduler := job.NewJobScheduler(job.WithStateStore(container.AltDBRepository))
 	job.RegisterAllJobs(scheduler, container, cfg)
 	scheduler.Start(ctx)
 
@@ -155,6 +155,7 @@ func main() {
 	}
 
 	rest.RegisterRoutes(ctx, e, container, cfg)
+	rest.RegisterBackgroundJobRoutes(e, scheduler, cfg)
 
 	serverExitCh := make(chan serverExit, 3)
 
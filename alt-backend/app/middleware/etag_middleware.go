@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"alt/domain"
+	"alt/utils/cache"
+
+	"github.com/labstack/echo/v4"
+)
+
+const defaultETagMaxEntries = 10000
+
+// ETagConfig configures ETagMiddleware.
+type ETagConfig struct {
+	// Versions is bumped by the write paths. nil disables the revalidation
+	// shortcut; ETags are still generated from the response body.
+	Versions *cache.VersionStore
+
+	// RevalidateWindow allows answering a matching If-None-Match with 304
+	// without running the handler, provided the same user fetched the same
+	// URL within the window and no write has bumped their cache version
+	// since. Zero always runs the handler.
+	RevalidateWindow time.Duration
+
+	// MaxEntries bounds the remembered ETags. Defaults to 10000.
+	MaxEntries int
+}
+
+type etagEntry struct {
+	etag         string
+	cacheControl string
+	version      uint64
+	storedAt     time.Time
+}
+
+// ETagMiddleware adds a weak ETag derived from the response body to
+// successful GET responses and answers matching If-None-Match requests with
+// 304 Not Modified. The body is hashed before compression, hence the weak
+// validator.
+//
+// Apply it per route to JSON endpoints only: the whole response is buffered.
+func ETagMiddleware(cfg ETagConfig) echo.MiddlewareFunc {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultETagMaxEntries
+	}
+	var (
+		mu      sync.Mutex
+		entries = make(map[string]etagEntry)
+	)
+
+	lookup := func(key string, version uint64, now time.Time) (etagEntry, bool) {
+		if cfg.Versions == nil || cfg.RevalidateWindow <= 0 {
+			return etagEntry{}, false
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		e, ok := entries[key]
+		if !ok || e.version != version || now.Sub(e.storedAt) > cfg.RevalidateWindow {
+			return etagEntry{}, false
+		}
+		return e, true
+	}
+
+	remember := func(key string, e etagEntry) {
+		if cfg.Versions == nil || cfg.RevalidateWindow <= 0 {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if len(entries) >= cfg.MaxEntries {
+			for k, old := range entries {
+				if e.storedAt.Sub(old.storedAt) > cfg.RevalidateWindow {
+					delete(entries, k)
+				}
+			}
+			if len(entries) >= cfg.MaxEntries {
+				clear(entries)
+			}
+		}
+		entries[key] = e
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+
+			scope := ""
+			if user, err := domain.GetUserFromContext(req.Context()); err == nil {
+				scope = user.UserID.String()
+			}
+			key := scope + " " + req.URL.RequestURI()
+			ifNoneMatch := req.Header.Get("If-None-Match")
+			version := cfg.Versions.Version(scope)
+			now := time.Now()
+
+			if ifNoneMatch != "" {
+				if e, ok := lookup(key, version, now); ok && etagMatches(ifNoneMatch, e.etag) {
+					res := c.Response()
+					res.Header().Set("ETag", e.etag)
+					if e.cacheControl != "" {
+						res.Header().Set("Cache-Control", e.cacheControl)
+					}
+					return c.NoContent(http.StatusNotModified)
+				}
+			}
+
+			res := c.Response()
+			orig := res.Writer
+			buf := &bufferedResponseWriter{ResponseWriter: orig}
+			res.Writer = buf
+			err := next(c)
+			res.Writer = orig
+
+			if !buf.wroteHeader {
+				return err
+			}
+			if buf.status != http.StatusOK || buf.body.Len() == 0 {
+				orig.WriteHeader(buf.status)
+				_, _ = orig.Write(buf.body.Bytes())
+				return err
+			}
+
+			etag := bodyETag(buf.body.Bytes())
+			header := orig.Header()
+			header.Set("ETag", etag)
+			remember(key, etagEntry{
+				etag:         etag,
+				cacheControl: header.Get("Cache-Control"),
+				version:      version,
+				storedAt:     now,
+			})
+
+			if ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+				header.Del(echo.HeaderContentLength)
+				orig.WriteHeader(http.StatusNotModified)
+				return err
+			}
+			orig.WriteHeader(buf.status)
+			_, _ = orig.Write(buf.body.Bytes())
+			return err
+		}
+	}
+}
+
+// bufferedResponseWriter holds the status and body back until the ETag is
+// known. Headers go straight to the underlying writer's map.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+}
+
+func (w *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(p)
+}
+
+// Flush is a no-op: flushing would defeat the buffering.
+func (w *bufferedResponseWriter) Flush() {}
+
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches implements the weak comparison If-None-Match uses.
+func etagMatches(ifNoneMatch, etag string) bool {
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidateUserETags bumps the cache version of the user in ctx so their
+// next conditional GET re-runs the handler. Write paths call it after a
+// successful mutation; it is a no-op without a user or a version store.
+func InvalidateUserETags(ctx context.Context, versions *cache.VersionStore) {
+	user, err := domain.GetUserFromContext(ctx)
+	if err != nil {
+		return
+	}
+	versions.Bump(user.UserID.String())
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"alt/domain"
+	"alt/utils/cache"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func etagTestUser() *domain.UserContext {
+	return &domain.UserContext{
+		UserID:    uuid.New(),
+		Email:     "reader@example.com",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+// serveETag runs one GET through the middleware and returns the recorder
+// and whether the handler ran.
+func serveETag(t *testing.T, mw echo.MiddlewareFunc, user *domain.UserContext, ifNoneMatch string, body *string) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/v1/feeds/fetch/cursor?limit=20", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	if user != nil {
+		req = req.WithContext(context.WithValue(req.Context(), domain.UserContextKey, user))
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	ran := false
+	handler := mw(func(c echo.Context) error {
+		ran = true
+		c.Response().Header().Set("Cache-Control", "private, max-age=30")
+		return c.String(http.StatusOK, *body)
+	})
+	require.NoError(t, handler(c))
+	return rec, ran
+}
+
+func TestETagMiddleware_SetsETagAndAnswers304(t *testing.T) {
+	mw := ETagMiddleware(ETagConfig{})
+	body := `{"data":[1,2,3]}`
+
+	rec, ran := serveETag(t, mw, nil, "", &body)
+	assert.True(t, ran)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, bodyETag([]byte(body)), etag)
+
+	rec, ran = serveETag(t, mw, nil, etag, &body)
+	assert.True(t, ran, "without a version store the handler always runs")
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+	body = `{"data":[1,2,3,4]}`
+	rec, _ = serveETag(t, mw, nil, etag, &body)
+	assert.Equal(t, http.StatusOK, rec.Code, "changed body must not match the old ETag")
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestETagMiddleware_RevalidationShortcutAndInvalidation(t *testing.T) {
+	versions := cache.NewVersionStore()
+	mw := ETagMiddleware(ETagConfig{Versions: versions, RevalidateWindow: time.Minute})
+	user := etagTestUser()
+	body := `{"data":"timeline"}`
+
+	rec, _ := serveETag(t, mw, user, "", &body)
+	etag := rec.Header().Get("ETag")
+
+	rec, ran := serveETag(t, mw, user, etag, &body)
+	assert.False(t, ran, "matching ETag within the window skips the handler")
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, "private, max-age=30", rec.Header().Get("Cache-Control"))
+
+	other := etagTestUser()
+	_, ran = serveETag(t, mw, other, etag, &body)
+	assert.True(t, ran, "remembered ETags are per user")
+
+	ctx := context.WithValue(context.Background(), domain.UserContextKey, user)
+	InvalidateUserETags(ctx, versions)
+	body = `{"data":"timeline after mark-as-read"}`
+	rec, ran = serveETag(t, mw, user, etag, &body)
+	assert.True(t, ran, "a write bumps the version and forces the handler to run")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, rec.Body.String())
+}
+
+func TestETagMiddleware_SkipsNonGETAndErrors(t *testing.T) {
+	mw := ETagMiddleware(ETagConfig{})
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/feeds/read", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, mw(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})(e.NewContext(req, rec)))
+	assert.Empty(t, rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/feeds/fetch/cursor", nil)
+	rec = httptest.NewRecorder()
+	require.NoError(t, mw(func(c echo.Context) error {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "boom"})
+	})(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Body.String(), "boom")
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := `W/"abc"`
+	assert.True(t, etagMatches(`W/"abc"`, etag))
+	assert.True(t, etagMatches(`"abc"`, etag))
+	assert.True(t, etagMatches(`"x", W/"abc"`, etag))
+	assert.True(t, etagMatches(`*`, etag))
+	assert.False(t, etagMatches(`"abcd"`, etag))
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"alt/domain"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubOrganizationService struct {
+	memberships map[uuid.UUID]domain.OrgRole
+}
+
+func (s *stubOrganizationService) ResolveOrgContext(_ context.Context, userID, orgID uuid.UUID) (*domain.OrgContext, error) {
+	role, ok := s.memberships[orgID]
+	if !ok {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	return &domain.OrgContext{OrgID: orgID, UserID: userID, Role: role}, nil
+}
+
+func TestOrganizationMiddleware(t *testing.T) {
+	memberOrg, adminOrg := uuid.New(), uuid.New()
+	m := NewOrganizationMiddleware(&stubOrganizationService{memberships: map[uuid.UUID]domain.OrgRole{
+		memberOrg: domain.OrgRoleMember,
+		adminOrg:  domain.OrgRoleAdmin,
+	}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	user := &domain.UserContext{UserID: uuid.New(), Email: "a@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name       string
+		path       string
+		header     string
+		adminOnly  bool
+		wantStatus int
+		wantOrg    uuid.UUID
+	}{
+		{name: "path parameter", path: "/orgs/" + memberOrg.String(), wantStatus: http.StatusOK, wantOrg: memberOrg},
+		{name: "header", path: "/feeds", header: adminOrg.String(), wantStatus: http.StatusOK, wantOrg: adminOrg},
+		{name: "no organization passes through", path: "/feeds", wantStatus: http.StatusOK},
+		{name: "not a member", path: "/orgs/" + uuid.NewString(), wantStatus: http.StatusNotFound},
+		{name: "malformed id", path: "/orgs/not-a-uuid", wantStatus: http.StatusBadRequest},
+		{name: "role too low", path: "/orgs/" + memberOrg.String(), adminOnly: true, wantStatus: http.StatusForbidden},
+		{name: "role sufficient", path: "/orgs/" + adminOrg.String(), adminOnly: true, wantStatus: http.StatusOK, wantOrg: adminOrg},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			var got uuid.UUID
+			handler := func(c echo.Context) error {
+				if org, err := domain.GetOrgFromContext(c.Request().Context()); err == nil {
+					got = org.OrgID
+				}
+				return c.NoContent(http.StatusOK)
+			}
+			mws := []echo.MiddlewareFunc{m.ExtractOrg()}
+			if tt.adminOnly {
+				mws = append(mws, m.RequireOrgRole(domain.OrgRoleAdmin))
+			}
+			e.GET("/orgs/:org_id", handler, mws...)
+			e.GET("/feeds", handler, mws...)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(OrgIDHeader, tt.header)
+			}
+			req = req.WithContext(domain.SetUserContext(req.Context(), user))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantOrg, got)
+		})
+	}
+}
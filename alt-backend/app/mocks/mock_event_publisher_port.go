@@ -69,6 +69,20 @@ func (mr *MockEventPublisherPortMockRecorder) PublishArticleCreated(ctx, event a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishArticleCreated", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishArticleCreated), ctx, event)
 }
 
+// PublishArticleDeleted mocks base method.
+func (m *MockEventPublisherPort) PublishArticleDeleted(ctx context.Context, event event_publisher_port.ArticleDeletedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishArticleDeleted", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishArticleDeleted indicates an expected call of PublishArticleDeleted.
+func (mr *MockEventPublisherPortMockRecorder) PublishArticleDeleted(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishArticleDeleted", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishArticleDeleted), ctx, event)
+}
+
 // PublishArticleUpdated mocks base method.
 func (m *MockEventPublisherPort) PublishArticleUpdated(ctx context.Context, event event_publisher_port.ArticleUpdatedEvent) error {
 	m.ctrl.T.Helper()
@@ -83,6 +97,48 @@ func (mr *MockEventPublisherPortMockRecorder) PublishArticleUpdated(ctx, event a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishArticleUpdated", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishArticleUpdated), ctx, event)
 }
 
+// PublishAudioGenerationRequested mocks base method.
+func (m *MockEventPublisherPort) PublishAudioGenerationRequested(ctx context.Context, event event_publisher_port.AudioGenerationRequestedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishAudioGenerationRequested", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishAudioGenerationRequested indicates an expected call of PublishAudioGenerationRequested.
+func (mr *MockEventPublisherPortMockRecorder) PublishAudioGenerationRequested(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishAudioGenerationRequested", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishAudioGenerationRequested), ctx, event)
+}
+
+// PublishFeedSubscribed mocks base method.
+func (m *MockEventPublisherPort) PublishFeedSubscribed(ctx context.Context, event event_publisher_port.FeedSubscribedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishFeedSubscribed", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishFeedSubscribed indicates an expected call of PublishFeedSubscribed.
+func (mr *MockEventPublisherPortMockRecorder) PublishFeedSubscribed(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishFeedSubscribed", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishFeedSubscribed), ctx, event)
+}
+
+// PublishFeedUnsubscribed mocks base method.
+func (m *MockEventPublisherPort) PublishFeedUnsubscribed(ctx context.Context, event event_publisher_port.FeedUnsubscribedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishFeedUnsubscribed", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishFeedUnsubscribed indicates an expected call of PublishFeedUnsubscribed.
+func (mr *MockEventPublisherPortMockRecorder) PublishFeedUnsubscribed(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishFeedUnsubscribed", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishFeedUnsubscribed), ctx, event)
+}
+
 // PublishIndexArticle mocks base method.
 func (m *MockEventPublisherPort) PublishIndexArticle(ctx context.Context, event event_publisher_port.IndexArticleEvent) error {
 	m.ctrl.T.Helper()
@@ -97,6 +153,20 @@ func (mr *MockEventPublisherPortMockRecorder) PublishIndexArticle(ctx, event any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishIndexArticle", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishIndexArticle), ctx, event)
 }
 
+// PublishReadStateChanged mocks base method.
+func (m *MockEventPublisherPort) PublishReadStateChanged(ctx context.Context, event event_publisher_port.ReadStateChangedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishReadStateChanged", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishReadStateChanged indicates an expected call of PublishReadStateChanged.
+func (mr *MockEventPublisherPortMockRecorder) PublishReadStateChanged(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishReadStateChanged", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishReadStateChanged), ctx, event)
+}
+
 // PublishSummarizeRequested mocks base method.
 func (m *MockEventPublisherPort) PublishSummarizeRequested(ctx context.Context, event event_publisher_port.SummarizeRequestedEvent) error {
 	m.ctrl.T.Helper()
@@ -110,3 +180,41 @@ func (mr *MockEventPublisherPortMockRecorder) PublishSummarizeRequested(ctx, eve
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishSummarizeRequested", reflect.TypeOf((*MockEventPublisherPort)(nil).PublishSummarizeRequested), ctx, event)
 }
+
+// MockEventRelayPort is a mock of EventRelayPort interface.
+type MockEventRelayPort struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRelayPortMockRecorder
+	isgomock struct{}
+}
+
+// MockEventRelayPortMockRecorder is the mock recorder for MockEventRelayPort.
+type MockEventRelayPortMockRecorder struct {
+	mock *MockEventRelayPort
+}
+
+// NewMockEventRelayPort creates a new mock instance.
+func NewMockEventRelayPort(ctrl *gomock.Controller) *MockEventRelayPort {
+	mock := &MockEventRelayPort{ctrl: ctrl}
+	mock.recorder = &MockEventRelayPortMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRelayPort) EXPECT() *MockEventRelayPortMockRecorder {
+	return m.recorder
+}
+
+// RelayEvent mocks base method.
+func (m *MockEventRelayPort) RelayEvent(ctx context.Context, event event_publisher_port.RecordedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RelayEvent indicates an expected call of RelayEvent.
+func (mr *MockEventRelayPortMockRecorder) RelayEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayEvent", reflect.TypeOf((*MockEventRelayPort)(nil).RelayEvent), ctx, event)
+}
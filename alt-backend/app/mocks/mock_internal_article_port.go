@@ -573,3 +573,44 @@ func (mr *MockHasUnsummarizedArticlesPortMockRecorder) HasUnsummarizedArticles(c
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUnsummarizedArticles", reflect.TypeOf((*MockHasUnsummarizedArticlesPort)(nil).HasUnsummarizedArticles), ctx)
 }
+
+// MockListArticlesForReindexPort is a mock of ListArticlesForReindexPort interface.
+type MockListArticlesForReindexPort struct {
+	ctrl     *gomock.Controller
+	recorder *MockListArticlesForReindexPortMockRecorder
+	isgomock struct{}
+}
+
+// MockListArticlesForReindexPortMockRecorder is the mock recorder for MockListArticlesForReindexPort.
+type MockListArticlesForReindexPortMockRecorder struct {
+	mock *MockListArticlesForReindexPort
+}
+
+// NewMockListArticlesForReindexPort creates a new mock instance.
+func NewMockListArticlesForReindexPort(ctrl *gomock.Controller) *MockListArticlesForReindexPort {
+	mock := &MockListArticlesForReindexPort{ctrl: ctrl}
+	mock.recorder = &MockListArticlesForReindexPortMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListArticlesForReindexPort) EXPECT() *MockListArticlesForReindexPortMockRecorder {
+	return m.recorder
+}
+
+// ListArticlesForReindex mocks base method.
+func (m *MockListArticlesForReindexPort) ListArticlesForReindex(ctx context.Context, filter internal_article_port.ReindexArticleFilter, lastCreatedAt *time.Time, lastID string, limit int) ([]*internal_article_port.ReindexArticle, *time.Time, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArticlesForReindex", ctx, filter, lastCreatedAt, lastID, limit)
+	ret0, _ := ret[0].([]*internal_article_port.ReindexArticle)
+	ret1, _ := ret[1].(*time.Time)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ListArticlesForReindex indicates an expected call of ListArticlesForReindex.
+func (mr *MockListArticlesForReindexPortMockRecorder) ListArticlesForReindex(ctx, filter, lastCreatedAt, lastID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArticlesForReindex", reflect.TypeOf((*MockListArticlesForReindexPort)(nil).ListArticlesForReindex), ctx, filter, lastCreatedAt, lastID, limit)
+}
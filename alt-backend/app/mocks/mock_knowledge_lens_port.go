@@ -81,17 +81,18 @@ func (m *MockCreateLensVersionPort) EXPECT() *MockCreateLensVersionPortMockRecor
 }
 
 // CreateLensVersion mocks base method.
-func (m *MockCreateLensVersionPort) CreateLensVersion(ctx context.Context, version domain.KnowledgeLensVersion) error {
+func (m *MockCreateLensVersionPort) CreateLensVersion(ctx context.Context, version domain.KnowledgeLensVersion, expectedRevision int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateLensVersion", ctx, version)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateLensVersion", ctx, version, expectedRevision)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // CreateLensVersion indicates an expected call of CreateLensVersion.
-func (mr *MockCreateLensVersionPortMockRecorder) CreateLensVersion(ctx, version any) *gomock.Call {
+func (mr *MockCreateLensVersionPortMockRecorder) CreateLensVersion(ctx, version, expectedRevision any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLensVersion", reflect.TypeOf((*MockCreateLensVersionPort)(nil).CreateLensVersion), ctx, version)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLensVersion", reflect.TypeOf((*MockCreateLensVersionPort)(nil).CreateLensVersion), ctx, version, expectedRevision)
 }
 
 // MockListLensesPort is a mock of ListLensesPort interface.
@@ -351,17 +352,17 @@ func (m *MockArchiveLensPort) EXPECT() *MockArchiveLensPortMockRecorder {
 }
 
 // ArchiveLens mocks base method.
-func (m *MockArchiveLensPort) ArchiveLens(ctx context.Context, lensID uuid.UUID) error {
+func (m *MockArchiveLensPort) ArchiveLens(ctx context.Context, lensID uuid.UUID, expectedRevision int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ArchiveLens", ctx, lensID)
+	ret := m.ctrl.Call(m, "ArchiveLens", ctx, lensID, expectedRevision)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ArchiveLens indicates an expected call of ArchiveLens.
-func (mr *MockArchiveLensPortMockRecorder) ArchiveLens(ctx, lensID any) *gomock.Call {
+func (mr *MockArchiveLensPortMockRecorder) ArchiveLens(ctx, lensID, expectedRevision any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveLens", reflect.TypeOf((*MockArchiveLensPort)(nil).ArchiveLens), ctx, lensID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveLens", reflect.TypeOf((*MockArchiveLensPort)(nil).ArchiveLens), ctx, lensID, expectedRevision)
 }
 
 // MockResolveKnowledgeHomeLensPort is a mock of ResolveKnowledgeHomeLensPort interface.
@@ -33,6 +33,7 @@ import (
 	"alt/shared/driver/alt_db"
 	"alt/shared/usecase/fetch_articles_by_tag_usecase"
 	"alt/shared/usecase/fetch_tag_cloud_usecase"
+	"alt/utils/cache"
 	"alt/utils/perf"
 	"alt/utils/safeconv"
 	"alt/utils/url_validator"
@@ -56,6 +57,9 @@ type ArticleHandlerDeps struct {
 	GetArticleSourceURL     *get_article_source_url_usecase.GetArticleSourceURLUsecase
 	ImageProxy              *image_proxy_usecase.ImageProxyUsecase
 	StreamArticleTags       *stream_article_tags_usecase.StreamArticleTagsUsecase
+	// CacheVersions is bumped after writes so REST ETag revalidation does
+	// not keep serving stale responses.
+	CacheVersions *cache.VersionStore
 }
 
 // Handler implements the ArticleService Connect-RPC service.
@@ -159,7 +163,7 @@ func (h *Handler) ArchiveArticle(
 	ctx context.Context,
 	req *connect.Request[articlesv2.ArchiveArticleRequest],
 ) (*connect.Response[articlesv2.ArchiveArticleResponse], error) {
-	_, err := middleware.GetUserContext(ctx)
+	userCtx, err := middleware.GetUserContext(ctx)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeUnauthenticated, nil)
 	}
@@ -195,6 +199,7 @@ func (h *Handler) ArchiveArticle(
 	if err := h.deps.ArchiveArticle.Execute(ctx, input); err != nil {
 		return nil, errorhandler.HandleInternalError(ctx, h.logger, err, "ArchiveArticle")
 	}
+	h.deps.CacheVersions.Bump(userCtx.UserID.String())
 
 	return connect.NewResponse(&articlesv2.ArchiveArticleResponse{
 		Message: "article archived",
@@ -22,7 +22,7 @@ func (h *Handler) MarkAsRead(
 	ctx context.Context,
 	req *connect.Request[feedsv2.MarkAsReadRequest],
 ) (*connect.Response[feedsv2.MarkAsReadResponse], error) {
-	_, err := middleware.GetUserContext(ctx)
+	userCtx, err := middleware.GetUserContext(ctx)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeUnauthenticated, nil)
 	}
@@ -56,6 +56,7 @@ func (h *Handler) MarkAsRead(
 	}
 
 	h.logger.InfoContext(ctx, "feed marked as read", "article_url", req.Msg.ArticleUrl)
+	h.deps.CacheVersions.Bump(userCtx.UserID.String())
 
 	return connect.NewResponse(&feedsv2.MarkAsReadResponse{
 		Message: "Feed read status updated",
@@ -86,6 +87,7 @@ func (h *Handler) Subscribe(
 	if err := h.deps.Subscribe.Execute(ctx, userCtx.UserID, feedLinkID); err != nil {
 		return nil, errorhandler.HandleInternalError(ctx, h.logger, err, "Subscribe")
 	}
+	h.deps.CacheVersions.Bump(userCtx.UserID.String())
 
 	return connect.NewResponse(&feedsv2.SubscribeResponse{
 		Message: "Subscribed successfully",
@@ -116,6 +118,7 @@ func (h *Handler) Unsubscribe(
 	if err := h.deps.Unsubscribe.Execute(ctx, userCtx.UserID, feedLinkID); err != nil {
 		return nil, errorhandler.HandleInternalError(ctx, h.logger, err, "Unsubscribe")
 	}
+	h.deps.CacheVersions.Bump(userCtx.UserID.String())
 
 	return connect.NewResponse(&feedsv2.UnsubscribeResponse{
 		Message: "Unsubscribed successfully",
@@ -17,6 +17,7 @@ import (
 	"alt/orchestrator/usecase/subscription_usecase"
 	"alt/shared/driver/alt_db"
 	"alt/shared/usecase/create_summary_version_usecase"
+	"alt/utils/cache"
 )
 
 // FeedHandlerDeps holds all dependencies for the Feed service handler.
@@ -43,6 +44,9 @@ type FeedHandlerDeps struct {
 	CreateSummaryVersion *create_summary_version_usecase.CreateSummaryVersionUsecase
 	// Shared
 	ImageProxy *image_proxy_usecase.ImageProxyUsecase
+	// CacheVersions is bumped after writes so REST ETag revalidation does
+	// not keep serving the pre-write timeline.
+	CacheVersions *cache.VersionStore
 }
 
 // Handler implements the FeedService Connect-RPC service.
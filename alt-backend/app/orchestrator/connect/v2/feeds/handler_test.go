@@ -63,7 +63,7 @@ func createTestHandler() *Handler {
 	summarizedUsecase := fetch_feed_stats_usecase.NewSummarizedArticlesCountUsecase(&mockSummarizedArticlesCountPort{})
 	totalArticlesUsecase := fetch_feed_stats_usecase.NewTotalArticlesCountUsecase(&mockTotalArticlesCountPort{})
 	unsummarizedUsecase := fetch_feed_stats_usecase.NewUnsummarizedArticlesCountUsecase(&mockUnsummarizedArticlesCountPort{})
-	articlesReadingStatusUsecase := reading_status.NewArticlesReadingStatusUsecase(&mockUpdateArticleStatusPort{})
+	articlesReadingStatusUsecase := reading_status.NewArticlesReadingStatusUsecase(&mockUpdateArticleStatusPort{}, nil, nil)
 
 	deps := FeedHandlerDeps{
 		FeedAmount:            feedAmountUsecase,
@@ -711,7 +711,7 @@ func TestHandler_MarkAsRead_FeedNotFound_Returns404(t *testing.T) {
 	logger.InitLogger()
 
 	// Create usecase with mock that returns ErrFeedNotFound
-	articlesReadingStatusUsecase := reading_status.NewArticlesReadingStatusUsecase(&mockUpdateArticleStatusPortReturnsNotFound{})
+	articlesReadingStatusUsecase := reading_status.NewArticlesReadingStatusUsecase(&mockUpdateArticleStatusPortReturnsNotFound{}, nil, nil)
 
 	deps := FeedHandlerDeps{
 		ArticlesReadingStatus: articlesReadingStatusUsecase,
@@ -748,7 +748,7 @@ func TestHandler_MarkAsRead_DatabaseError_Returns500(t *testing.T) {
 	logger.InitLogger()
 
 	// Create usecase with mock that returns generic error
-	articlesReadingStatusUsecase := reading_status.NewArticlesReadingStatusUsecase(&mockUpdateArticleStatusPortReturnsError{})
+	articlesReadingStatusUsecase := reading_status.NewArticlesReadingStatusUsecase(&mockUpdateArticleStatusPortReturnsError{}, nil, nil)
 
 	deps := FeedHandlerDeps{
 		ArticlesReadingStatus: articlesReadingStatusUsecase,
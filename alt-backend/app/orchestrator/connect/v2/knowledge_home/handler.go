@@ -229,6 +229,7 @@ func convertLensToProto(l domain.KnowledgeLens, v *domain.KnowledgeLensVersion)
 		Description: l.Description,
 		CreatedAt:   l.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   l.UpdatedAt.Format(time.RFC3339),
+		Revision:    l.Revision,
 	}
 	if v != nil {
 		lens.CurrentVersion = convertLensVersionToProto(*v)
@@ -2,7 +2,11 @@ package knowledge_home
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"connectrpc.com/connect"
 
@@ -59,9 +63,11 @@ func (h *Handler) CreateLens(
 		return nil, errorhandler.HandleInternalError(ctx, h.logger, err, "CreateLens")
 	}
 
-	return connect.NewResponse(&knowledgehomev1.CreateLensResponse{
+	resp := connect.NewResponse(&knowledgehomev1.CreateLensResponse{
 		Lens: convertLensToProto(result.Lens, &result.Version),
-	}), nil
+	})
+	setLensETag(resp.Header(), result.Lens.Revision)
+	return resp, nil
 }
 
 // UpdateLens creates a new version of an existing lens.
@@ -84,9 +90,15 @@ func (h *Handler) UpdateLens(
 		return nil, err
 	}
 
+	expectedRevision, err := parseIfMatchRevision(req.Header())
+	if err != nil {
+		return nil, err
+	}
+
 	input := update_lens_usecase.UpdateLensInput{
-		LensID: lensID,
-		UserID: user.UserID,
+		LensID:           lensID,
+		UserID:           user.UserID,
+		ExpectedRevision: expectedRevision,
 	}
 
 	if req.Msg.Version != nil {
@@ -99,17 +111,23 @@ func (h *Handler) UpdateLens(
 		input.SortMode = req.Msg.Version.SortMode
 	}
 
-	version, err := h.updateLensUsecase.Execute(ctx, input)
+	result, err := h.updateLensUsecase.Execute(ctx, input)
 	if err != nil {
+		if errors.Is(err, domain.ErrLensRevisionConflict) {
+			return nil, lensConflictError(expectedRevision)
+		}
 		return nil, errorhandler.HandleInternalError(ctx, h.logger, err, "UpdateLens")
 	}
 
-	return connect.NewResponse(&knowledgehomev1.UpdateLensResponse{
+	resp := connect.NewResponse(&knowledgehomev1.UpdateLensResponse{
 		Lens: &knowledgehomev1.Lens{
 			LensId:         req.Msg.LensId,
-			CurrentVersion: convertLensVersionToProto(*version),
+			CurrentVersion: convertLensVersionToProto(result.Version),
+			Revision:       result.Revision,
 		},
-	}), nil
+	})
+	setLensETag(resp.Header(), result.Revision)
+	return resp, nil
 }
 
 // DeleteLens archives a lens (soft delete).
@@ -132,7 +150,15 @@ func (h *Handler) DeleteLens(
 		return nil, err
 	}
 
-	if err := h.archiveLensUsecase.Execute(ctx, user.UserID, lensID); err != nil {
+	expectedRevision, err := parseIfMatchRevision(req.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.archiveLensUsecase.Execute(ctx, user.UserID, lensID, expectedRevision); err != nil {
+		if errors.Is(err, domain.ErrLensRevisionConflict) {
+			return nil, lensConflictError(expectedRevision)
+		}
 		return nil, errorhandler.HandleInternalError(ctx, h.logger, err, "DeleteLens")
 	}
 
@@ -202,3 +228,44 @@ func (h *Handler) SelectLens(
 
 	return connect.NewResponse(&knowledgehomev1.SelectLensResponse{}), nil
 }
+
+// parseIfMatchRevision reads the lens revision a write is conditional on from
+// the If-Match header. The ETag of a lens is its quoted revision, e.g. "3";
+// weak tags are accepted too. A missing header or "*" returns 0, which makes
+// the write unconditional.
+func parseIfMatchRevision(header http.Header) (int64, error) {
+	value := strings.TrimSpace(header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+	tag := strings.TrimPrefix(value, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, invalidIfMatch(value)
+	}
+	revision, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || revision <= 0 {
+		return 0, invalidIfMatch(value)
+	}
+	return revision, nil
+}
+
+func invalidIfMatch(value string) error {
+	return connect.NewError(connect.CodeInvalidArgument,
+		fmt.Errorf("invalid If-Match header %q: expected a lens ETag", value))
+}
+
+// setLensETag exposes the lens revision as the response ETag, for clients to
+// send back in If-Match on their next write.
+func setLensETag(header http.Header, revision int64) {
+	if revision > 0 {
+		header.Set("ETag", strconv.Quote(strconv.FormatInt(revision, 10)))
+	}
+}
+
+// lensConflictError reports a stale If-Match. Aborted maps to HTTP 409, so
+// clients re-read the lens and retry instead of overwriting another device's
+// change.
+func lensConflictError(expectedRevision int64) error {
+	return connect.NewError(connect.CodeAborted,
+		fmt.Errorf("lens was modified since revision %d; reload it and retry", expectedRevision))
+}
@@ -0,0 +1,133 @@
+package knowledge_home
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"alt/domain"
+	knowledgehomev1 "alt/gen/proto/alt/knowledge_home/v1"
+	"alt/orchestrator/usecase/archive_lens_usecase"
+	"alt/orchestrator/usecase/update_lens_usecase"
+)
+
+// fakeLensStore is a lens with a revision, checked like knowledge-sovereign
+// checks expected revisions.
+type fakeLensStore struct {
+	lens domain.KnowledgeLens
+}
+
+func (f *fakeLensStore) GetLens(_ context.Context, _ uuid.UUID) (*domain.KnowledgeLens, error) {
+	lens := f.lens
+	return &lens, nil
+}
+
+func (f *fakeLensStore) CreateLensVersion(_ context.Context, _ domain.KnowledgeLensVersion, expectedRevision int64) (int64, error) {
+	if expectedRevision != 0 && expectedRevision != f.lens.Revision {
+		return 0, domain.ErrLensRevisionConflict
+	}
+	f.lens.Revision++
+	return f.lens.Revision, nil
+}
+
+func (f *fakeLensStore) ArchiveLens(_ context.Context, _ uuid.UUID, expectedRevision int64) error {
+	if expectedRevision != 0 && expectedRevision != f.lens.Revision {
+		return domain.ErrLensRevisionConflict
+	}
+	f.lens.Revision++
+	return nil
+}
+
+func newLensTestHandler(t *testing.T) (*Handler, *fakeLensStore, context.Context) {
+	t.Helper()
+	user := snoozeTestUserContext(t)
+	store := &fakeLensStore{lens: domain.KnowledgeLens{LensID: uuid.New(), UserID: user.UserID, Revision: 3}}
+	h := &Handler{
+		updateLensUsecase:  update_lens_usecase.NewUpdateLensUsecase(store, store),
+		archiveLensUsecase: archive_lens_usecase.NewArchiveLensUsecase(store, store),
+	}
+	return h, store, domain.SetUserContext(context.Background(), user)
+}
+
+func updateLensRequest(lensID uuid.UUID, ifMatch string) *connect.Request[knowledgehomev1.UpdateLensRequest] {
+	req := connect.NewRequest(&knowledgehomev1.UpdateLensRequest{LensId: lensID.String()})
+	if ifMatch != "" {
+		req.Header().Set("If-Match", ifMatch)
+	}
+	return req
+}
+
+func TestUpdateLens_IfMatch(t *testing.T) {
+	t.Parallel()
+	h, store, ctx := newLensTestHandler(t)
+
+	resp, err := h.UpdateLens(ctx, updateLensRequest(store.lens.LensID, `"3"`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Msg.Lens.Revision)
+	assert.Equal(t, `"4"`, resp.Header().Get("ETag"))
+
+	// A second device still holding revision 3 must not overwrite the update.
+	_, err = h.UpdateLens(ctx, updateLensRequest(store.lens.LensID, `"3"`))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+	assert.Equal(t, int64(4), store.lens.Revision)
+
+	// Without If-Match the write is unconditional.
+	resp, err = h.UpdateLens(ctx, updateLensRequest(store.lens.LensID, ""))
+	require.NoError(t, err)
+	assert.Equal(t, `"5"`, resp.Header().Get("ETag"))
+}
+
+func TestDeleteLens_StaleIfMatchIsAborted(t *testing.T) {
+	t.Parallel()
+	h, store, ctx := newLensTestHandler(t)
+
+	req := connect.NewRequest(&knowledgehomev1.DeleteLensRequest{LensId: store.lens.LensID.String()})
+	req.Header().Set("If-Match", `W/"2"`)
+	_, err := h.DeleteLens(ctx, req)
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+
+	req.Header().Set("If-Match", `W/"3"`)
+	_, err = h.DeleteLens(ctx, req)
+	require.NoError(t, err)
+}
+
+func TestParseIfMatchRevision(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "absent", value: "", want: 0},
+		{name: "any", value: "*", want: 0},
+		{name: "strong", value: `"12"`, want: 12},
+		{name: "weak", value: `W/"12"`, want: 12},
+		{name: "unquoted", value: "12", wantErr: true},
+		{name: "not a number", value: `"abc"`, wantErr: true},
+		{name: "zero", value: `"0"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("If-Match", tt.value)
+			}
+			got, err := parseIfMatchRevision(header)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
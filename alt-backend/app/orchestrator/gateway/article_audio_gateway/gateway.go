@@ -0,0 +1,47 @@
+package article_audio_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements article_audio_port.ArticleAudioPort on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new article audio gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// FetchArticleAudio loads the audio for one of a user's articles.
+func (g *Gateway) FetchArticleAudio(ctx context.Context, articleID, userID uuid.UUID) (*domain.ArticleAudio, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchArticleAudio(ctx, articleID, userID)
+}
+
+// RequestArticleAudio starts a generation attempt when one is due.
+func (g *Gateway) RequestArticleAudio(ctx context.Context, articleID, userID uuid.UUID, voice string, now, staleBefore time.Time) (*domain.ArticleAudio, bool, error) {
+	if g.altDB == nil {
+		return nil, false, errDatabaseUnavailable
+	}
+	return g.altDB.RequestArticleAudio(ctx, articleID, userID, voice, now, staleBefore)
+}
+
+// ReportArticleAudio applies a TTS worker report.
+func (g *Gateway) ReportArticleAudio(ctx context.Context, report *domain.ArticleAudioReport, now time.Time) (*domain.ArticleAudio, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ReportArticleAudio(ctx, report, now)
+}
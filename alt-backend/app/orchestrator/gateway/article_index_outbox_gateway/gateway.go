@@ -0,0 +1,63 @@
+package article_index_outbox_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements article_index_outbox_port.ArticleIndexOutboxPort on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new article index outbox gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// MarkArticleIndexDirty queues an article for reindexing.
+func (g *Gateway) MarkArticleIndexDirty(ctx context.Context, articleID uuid.UUID, reason domain.ArticleIndexReason, now time.Time) (*domain.DirtyArticle, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.MarkArticleIndexDirty(ctx, articleID, reason, now)
+}
+
+// ListDirtyArticles lists queued articles that are due.
+func (g *Gateway) ListDirtyArticles(ctx context.Context, now time.Time, limit int) ([]*domain.DirtyArticle, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListDirtyArticles(ctx, now, limit)
+}
+
+// FetchArticleIndexDocument loads an article's indexed fields.
+func (g *Gateway) FetchArticleIndexDocument(ctx context.Context, articleID uuid.UUID) (*domain.ArticleIndexDocument, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchArticleIndexDocument(ctx, articleID)
+}
+
+// ClearDirtyArticle dequeues a published article.
+func (g *Gateway) ClearDirtyArticle(ctx context.Context, articleID uuid.UUID, markedAt time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.ClearDirtyArticle(ctx, articleID, markedAt)
+}
+
+// RescheduleDirtyArticle delays an article whose publish failed.
+func (g *Gateway) RescheduleDirtyArticle(ctx context.Context, articleID uuid.UUID, markedAt time.Time, errorMessage string, retryAt time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RescheduleDirtyArticle(ctx, articleID, markedAt, errorMessage, retryAt)
+}
@@ -0,0 +1,63 @@
+package article_reconciliation_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements the article_reconciliation_port interfaces on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new article reconciliation gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// UpsertArticleSourceRecords stores reported source records.
+func (g *Gateway) UpsertArticleSourceRecords(ctx context.Context, records []*domain.ArticleSourceRecord, now time.Time) (int64, error) {
+	if g.altDB == nil {
+		return 0, errDatabaseUnavailable
+	}
+	return g.altDB.UpsertArticleSourceRecords(ctx, records, now)
+}
+
+// ListUnreconciledSourceRecords lists records still to be matched.
+func (g *Gateway) ListUnreconciledSourceRecords(ctx context.Context, limit int) ([]*domain.ArticleSourceRecord, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListUnreconciledSourceRecords(ctx, limit)
+}
+
+// ApplyReconciliationOutcome persists the decision for one record.
+func (g *Gateway) ApplyReconciliationOutcome(ctx context.Context, outcome *domain.ReconciliationOutcome, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.ApplyReconciliationOutcome(ctx, outcome, now)
+}
+
+// FetchReconciliationReport loads reconciliation totals and records.
+func (g *Gateway) FetchReconciliationReport(ctx context.Context, statuses []domain.ArticleSourceStatus, limit int) (*domain.ReconciliationReport, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchReconciliationReport(ctx, statuses, limit)
+}
+
+// FindReconciliationCandidates finds articles a record may refer to.
+func (g *Gateway) FindReconciliationCandidates(ctx context.Context, userID uuid.UUID, urls []string, guid string) ([]*domain.ReconciliationCandidate, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FindReconciliationCandidates(ctx, userID, urls, guid)
+}
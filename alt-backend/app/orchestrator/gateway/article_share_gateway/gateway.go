@@ -0,0 +1,63 @@
+package article_share_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements the article_share_port interfaces on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new article share gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// CreateArticleShareLink stores a new share link.
+func (g *Gateway) CreateArticleShareLink(ctx context.Context, link *domain.ArticleShareLink) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.CreateArticleShareLink(ctx, link)
+}
+
+// ListArticleShareLinks lists a user's links for one article.
+func (g *Gateway) ListArticleShareLinks(ctx context.Context, articleID, userID uuid.UUID) ([]*domain.ArticleShareLink, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListArticleShareLinks(ctx, articleID, userID)
+}
+
+// RevokeArticleShareLink revokes a share link.
+func (g *Gateway) RevokeArticleShareLink(ctx context.Context, shareID, articleID, userID uuid.UUID, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RevokeArticleShareLink(ctx, shareID, articleID, userID, now)
+}
+
+// RecordShareLinkView counts a view of an active link.
+func (g *Gateway) RecordShareLinkView(ctx context.Context, shareID uuid.UUID, now time.Time) (*domain.ArticleShareLink, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.RecordShareLinkView(ctx, shareID, now)
+}
+
+// FetchSharedArticle loads the article behind a share link.
+func (g *Gateway) FetchSharedArticle(ctx context.Context, articleID, ownerID uuid.UUID) (*domain.SharedArticle, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchSharedArticle(ctx, articleID, ownerID)
+}
@@ -0,0 +1,87 @@
+package article_title_fix_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements article_title_fix_port.ArticleTitleFixPort on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new article title fix gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// CreateArticleTitleFixJob inserts a pending job.
+func (g *Gateway) CreateArticleTitleFixJob(ctx context.Context, requestedBy *uuid.UUID, now time.Time) (*domain.ArticleTitleFixJob, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.CreateArticleTitleFixJob(ctx, requestedBy, now)
+}
+
+// GetActiveArticleTitleFixJob loads the pending or running job.
+func (g *Gateway) GetActiveArticleTitleFixJob(ctx context.Context) (*domain.ArticleTitleFixJob, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.GetActiveArticleTitleFixJob(ctx)
+}
+
+// GetArticleTitleFixJob loads one job.
+func (g *Gateway) GetArticleTitleFixJob(ctx context.Context, jobID uuid.UUID) (*domain.ArticleTitleFixJob, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.GetArticleTitleFixJob(ctx, jobID)
+}
+
+// ListArticleTitleFixJobs lists recent jobs.
+func (g *Gateway) ListArticleTitleFixJobs(ctx context.Context, limit int) ([]*domain.ArticleTitleFixJob, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListArticleTitleFixJobs(ctx, limit)
+}
+
+// UpdateArticleTitleFixJob saves a job's state.
+func (g *Gateway) UpdateArticleTitleFixJob(ctx context.Context, job *domain.ArticleTitleFixJob) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.UpdateArticleTitleFixJob(ctx, job)
+}
+
+// ListArticleTitleFixCandidates lists articles whose title is a URL.
+func (g *Gateway) ListArticleTitleFixCandidates(ctx context.Context, afterID *uuid.UUID, limit int) ([]*domain.ArticleTitleFixCandidate, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListArticleTitleFixCandidates(ctx, afterID, limit)
+}
+
+// RecordArticleTitleFixResult persists one article's outcome and the job's progress.
+func (g *Gateway) RecordArticleTitleFixResult(ctx context.Context, job *domain.ArticleTitleFixJob, result *domain.ArticleTitleFixResult) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RecordArticleTitleFixResult(ctx, job, result)
+}
+
+// ListArticleTitleFixResults lists a job's results with one outcome.
+func (g *Gateway) ListArticleTitleFixResults(ctx context.Context, jobID uuid.UUID, outcome domain.ArticleTitleFixOutcome, limit int) ([]*domain.ArticleTitleFixResult, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListArticleTitleFixResults(ctx, jobID, outcome, limit)
+}
@@ -0,0 +1,171 @@
+package feature_flag_gateway
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/feature_flag_port"
+	"alt/utils/logger"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// storeLoadTimeout bounds one reload of the stored flags.
+const storeLoadTimeout = 3 * time.Second
+
+// CachedGateway implements feature_flag_port.FeatureFlagEvaluatorPort. It
+// evaluates the flags stored for one environment from an in-process copy
+// reloaded every ttl, and falls back to the config Gateway for flags that
+// are not stored. Flips made through another replica show up here within
+// ttl; Invalidate makes this replica's own writes visible immediately.
+//
+// While a reload is in flight other callers keep evaluating the previous
+// copy. When a reload fails the previous copy is kept for another ttl, so a
+// database outage freezes flags rather than switching them off.
+type CachedGateway struct {
+	store       feature_flag_port.FeatureFlagStorePort
+	fallback    *Gateway
+	environment string
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu         sync.Mutex
+	snap       *flagSnapshot
+	loadedAt   time.Time
+	generation uint64
+	refreshing bool
+}
+
+type flagSnapshot struct {
+	flags     map[string]domain.FeatureFlag
+	overrides map[string]map[uuid.UUID]bool
+}
+
+// NewCachedGateway creates a cached evaluator for environment.
+func NewCachedGateway(store feature_flag_port.FeatureFlagStorePort, fallback *Gateway, environment string, ttl time.Duration) *CachedGateway {
+	return &CachedGateway{
+		store:       store,
+		fallback:    fallback,
+		environment: environment,
+		ttl:         ttl,
+		now:         time.Now,
+	}
+}
+
+// IsEnabled reports whether flagName is on for userID.
+func (g *CachedGateway) IsEnabled(flagName string, userID uuid.UUID) bool {
+	return g.Evaluate(context.Background(), flagName, userID).Enabled
+}
+
+// Evaluate evaluates flagName for userID: a per-user override wins, then
+// the stored flag's enabled bit and rollout percentage, then the config
+// toggles for flags that are not stored.
+func (g *CachedGateway) Evaluate(ctx context.Context, flagName string, userID uuid.UUID) domain.FeatureFlagEvaluation {
+	snap := g.current(ctx)
+	if flag, ok := snap.flags[flagName]; ok {
+		var override *bool
+		if enabled, ok := snap.overrides[flagName][userID]; ok {
+			override = &enabled
+		}
+		enabled, source := flag.EnabledFor(userID, override)
+		return domain.FeatureFlagEvaluation{Name: flagName, Enabled: enabled, Source: source}
+	}
+	return domain.FeatureFlagEvaluation{
+		Name:    flagName,
+		Enabled: g.fallback.IsEnabled(flagName, userID),
+		Source:  domain.FeatureFlagSourceConfig,
+	}
+}
+
+// FlagNames lists the stored flags and the config flags, sorted.
+func (g *CachedGateway) FlagNames(ctx context.Context) []string {
+	snap := g.current(ctx)
+	seen := make(map[string]struct{}, len(snap.flags)+len(configFlagNames))
+	names := make([]string, 0, len(snap.flags)+len(configFlagNames))
+	for _, name := range g.fallback.FlagNames() {
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	for name := range snap.flags {
+		if _, ok := seen[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Invalidate makes the next evaluation reload the stored flags. A reload
+// already in flight may have read the old state, so its result is served
+// but not treated as fresh.
+func (g *CachedGateway) Invalidate() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.generation++
+	g.loadedAt = time.Time{}
+}
+
+// current returns the cached snapshot, reloading it first when it is stale
+// and no other caller is already doing so.
+func (g *CachedGateway) current(ctx context.Context) *flagSnapshot {
+	g.mu.Lock()
+	if g.snap != nil && (g.refreshing || g.now().Sub(g.loadedAt) < g.ttl) {
+		snap := g.snap
+		g.mu.Unlock()
+		return snap
+	}
+	g.refreshing = true
+	generation := g.generation
+	g.mu.Unlock()
+
+	snap, err := g.load(ctx)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.refreshing = false
+	if err != nil {
+		logger.Logger.WarnContext(ctx, "failed to reload feature flags; keeping previous state",
+			"environment", g.environment, "error", err)
+		if g.snap == nil {
+			g.snap = &flagSnapshot{}
+		}
+		snap = g.snap
+	} else {
+		g.snap = snap
+	}
+	if generation == g.generation {
+		g.loadedAt = g.now()
+	}
+	return snap
+}
+
+func (g *CachedGateway) load(ctx context.Context) (*flagSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeLoadTimeout)
+	defer cancel()
+
+	flags, err := g.store.ListFeatureFlags(ctx, g.environment)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := g.store.ListFeatureFlagOverrides(ctx, g.environment)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &flagSnapshot{
+		flags:     make(map[string]domain.FeatureFlag, len(flags)),
+		overrides: make(map[string]map[uuid.UUID]bool),
+	}
+	for _, flag := range flags {
+		snap.flags[flag.Name] = flag
+	}
+	for _, o := range overrides {
+		if snap.overrides[o.FlagName] == nil {
+			snap.overrides[o.FlagName] = make(map[uuid.UUID]bool)
+		}
+		snap.overrides[o.FlagName][o.UserID] = o.Enabled
+	}
+	return snap, nil
+}
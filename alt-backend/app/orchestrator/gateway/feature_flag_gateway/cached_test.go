@@ -0,0 +1,151 @@
+package feature_flag_gateway
+
+import (
+	"alt/config"
+	"alt/domain"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fakeFlagStore struct {
+	flags     []domain.FeatureFlag
+	overrides []domain.FeatureFlagOverride
+	err       error
+	loads     int
+}
+
+func (s *fakeFlagStore) ListFeatureFlags(_ context.Context, _ string) ([]domain.FeatureFlag, error) {
+	s.loads++
+	return s.flags, s.err
+}
+
+func (s *fakeFlagStore) FetchFeatureFlag(_ context.Context, _, _ string) (*domain.FeatureFlag, error) {
+	return nil, nil
+}
+
+func (s *fakeFlagStore) UpsertFeatureFlag(_ context.Context, _ *domain.FeatureFlag) error {
+	return nil
+}
+
+func (s *fakeFlagStore) DeleteFeatureFlag(_ context.Context, _, _ string) (bool, error) {
+	return false, nil
+}
+
+func (s *fakeFlagStore) ListFeatureFlagOverrides(_ context.Context, _ string) ([]domain.FeatureFlagOverride, error) {
+	return s.overrides, s.err
+}
+
+func (s *fakeFlagStore) UpsertFeatureFlagOverride(_ context.Context, _ *domain.FeatureFlagOverride) error {
+	return nil
+}
+
+func (s *fakeFlagStore) DeleteFeatureFlagOverride(_ context.Context, _, _ string, _ uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func newTestCachedGateway(store *fakeFlagStore, cfg *config.KnowledgeHomeConfig) (*CachedGateway, *time.Time) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	gw := NewCachedGateway(store, NewGateway(cfg), "production", 30*time.Second)
+	gw.now = func() time.Time { return now }
+	return gw, &now
+}
+
+func TestCachedGateway_StoredFlagBeatsConfig(t *testing.T) {
+	store := &fakeFlagStore{flags: []domain.FeatureFlag{{Name: domain.FlagLensV0, Enabled: false, RolloutPercentage: 100}}}
+	gw, _ := newTestCachedGateway(store, &config.KnowledgeHomeConfig{EnableLens: true, EnableHomePage: true, RolloutPercentage: 100})
+	userID := uuid.New()
+
+	got := gw.Evaluate(context.Background(), domain.FlagLensV0, userID)
+	if got.Enabled || got.Source != domain.FeatureFlagSourceRollout {
+		t.Errorf("stored flag: got %+v, want disabled by rollout", got)
+	}
+	got = gw.Evaluate(context.Background(), domain.FlagKnowledgeHomePage, userID)
+	if !got.Enabled || got.Source != domain.FeatureFlagSourceConfig {
+		t.Errorf("unstored flag: got %+v, want enabled by config", got)
+	}
+}
+
+func TestCachedGateway_OverrideWins(t *testing.T) {
+	on, off := uuid.New(), uuid.New()
+	store := &fakeFlagStore{
+		flags: []domain.FeatureFlag{{Name: "new_reader", Enabled: true, RolloutPercentage: 100}},
+		overrides: []domain.FeatureFlagOverride{
+			{FlagName: "new_reader", UserID: off, Enabled: false},
+			{FlagName: "new_reader", UserID: on, Enabled: true},
+		},
+	}
+	gw, _ := newTestCachedGateway(store, &config.KnowledgeHomeConfig{})
+
+	if gw.IsEnabled("new_reader", off) {
+		t.Error("override off: expected disabled despite 100% rollout")
+	}
+	if got := gw.Evaluate(context.Background(), "new_reader", on); !got.Enabled || got.Source != domain.FeatureFlagSourceOverride {
+		t.Errorf("override on: got %+v", got)
+	}
+}
+
+func TestCachedGateway_ReloadsAfterTTL(t *testing.T) {
+	store := &fakeFlagStore{}
+	gw, now := newTestCachedGateway(store, &config.KnowledgeHomeConfig{})
+	userID := uuid.New()
+
+	gw.IsEnabled("new_reader", userID)
+	gw.IsEnabled("new_reader", userID)
+	if store.loads != 1 {
+		t.Fatalf("loads = %d, want 1 within ttl", store.loads)
+	}
+
+	store.flags = []domain.FeatureFlag{{Name: "new_reader", Enabled: true, RolloutPercentage: 100}}
+	*now = now.Add(31 * time.Second)
+	if !gw.IsEnabled("new_reader", userID) {
+		t.Error("expected flag flipped in the store to be on after ttl")
+	}
+	if store.loads != 2 {
+		t.Errorf("loads = %d, want 2", store.loads)
+	}
+}
+
+func TestCachedGateway_InvalidateForcesReload(t *testing.T) {
+	store := &fakeFlagStore{}
+	gw, _ := newTestCachedGateway(store, &config.KnowledgeHomeConfig{})
+	userID := uuid.New()
+
+	gw.IsEnabled("new_reader", userID)
+	store.flags = []domain.FeatureFlag{{Name: "new_reader", Enabled: true, RolloutPercentage: 100}}
+	gw.Invalidate()
+
+	if !gw.IsEnabled("new_reader", userID) {
+		t.Error("expected invalidate to pick up the new flag immediately")
+	}
+}
+
+func TestCachedGateway_KeepsPreviousStateOnLoadError(t *testing.T) {
+	logger.InitLogger()
+	store := &fakeFlagStore{flags: []domain.FeatureFlag{{Name: "new_reader", Enabled: true, RolloutPercentage: 100}}}
+	gw, now := newTestCachedGateway(store, &config.KnowledgeHomeConfig{})
+	userID := uuid.New()
+
+	if !gw.IsEnabled("new_reader", userID) {
+		t.Fatal("expected flag on after first load")
+	}
+	store.err = errors.New("connection refused")
+	*now = now.Add(time.Minute)
+	if !gw.IsEnabled("new_reader", userID) {
+		t.Error("expected previous state to be kept when reload fails")
+	}
+}
+
+func TestCachedGateway_FlagNames(t *testing.T) {
+	store := &fakeFlagStore{flags: []domain.FeatureFlag{{Name: "new_reader"}, {Name: domain.FlagLensV0}}}
+	gw, _ := newTestCachedGateway(store, &config.KnowledgeHomeConfig{})
+
+	names := gw.FlagNames(context.Background())
+	if len(names) != len(configFlagNames)+1 {
+		t.Errorf("names = %v, want config flags plus new_reader", names)
+	}
+}
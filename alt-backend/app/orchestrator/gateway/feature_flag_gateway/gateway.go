@@ -3,12 +3,21 @@ package feature_flag_gateway
 import (
 	"alt/config"
 	"alt/domain"
-	"hash/crc32"
 	"strings"
 
 	"github.com/google/uuid"
 )
 
+// configFlagNames lists the flags Gateway has toggles for.
+var configFlagNames = []string{
+	domain.FlagKnowledgeHomePage,
+	domain.FlagKnowledgeHomeTracking,
+	domain.FlagKnowledgeHomeProjectionV2,
+	domain.FlagLensV0,
+	domain.FlagStreamUpdates,
+	domain.FlagSupersedeUX,
+}
+
 // Gateway implements feature_flag_port.FeatureFlagPort using config-based flags
 // with percentage rollout via crc32 hashing.
 type Gateway struct {
@@ -49,6 +58,11 @@ func (g *Gateway) IsEnabled(flagName string, userID uuid.UUID) bool {
 	return g.isInRolloutPercentage(userID)
 }
 
+// FlagNames lists the flags that have a config toggle.
+func (g *Gateway) FlagNames() []string {
+	return append([]string(nil), configFlagNames...)
+}
+
 // isFlagGloballyEnabled checks the per-flag toggle.
 func (g *Gateway) isFlagGloballyEnabled(flagName string) bool {
 	switch flagName {
@@ -78,7 +92,5 @@ func (g *Gateway) isInRolloutPercentage(userID uuid.UUID) bool {
 	if g.cfg.RolloutPercentage <= 0 {
 		return false
 	}
-	hash := crc32.ChecksumIEEE(userID[:])
-	bucket := hash % 100
-	return int(bucket) < g.cfg.RolloutPercentage
+	return domain.FeatureFlagBucket(userID) < g.cfg.RolloutPercentage
 }
@@ -0,0 +1,79 @@
+package feature_flag_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// StoreGateway implements feature_flag_port.FeatureFlagStorePort on top of
+// alt_db.
+type StoreGateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewStoreGateway creates a new feature flag store gateway.
+func NewStoreGateway(altDB *alt_db.AltDBRepository) *StoreGateway {
+	return &StoreGateway{altDB: altDB}
+}
+
+// ListFeatureFlags lists the stored flags of one environment.
+func (g *StoreGateway) ListFeatureFlags(ctx context.Context, environment string) ([]domain.FeatureFlag, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListFeatureFlags(ctx, environment)
+}
+
+// FetchFeatureFlag loads one stored flag.
+func (g *StoreGateway) FetchFeatureFlag(ctx context.Context, environment, name string) (*domain.FeatureFlag, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchFeatureFlag(ctx, environment, name)
+}
+
+// UpsertFeatureFlag stores a flag.
+func (g *StoreGateway) UpsertFeatureFlag(ctx context.Context, flag *domain.FeatureFlag) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.UpsertFeatureFlag(ctx, flag)
+}
+
+// DeleteFeatureFlag removes a stored flag and its overrides.
+func (g *StoreGateway) DeleteFeatureFlag(ctx context.Context, environment, name string) (bool, error) {
+	if g.altDB == nil {
+		return false, errDatabaseUnavailable
+	}
+	return g.altDB.DeleteFeatureFlag(ctx, environment, name)
+}
+
+// ListFeatureFlagOverrides lists the overrides of one environment.
+func (g *StoreGateway) ListFeatureFlagOverrides(ctx context.Context, environment string) ([]domain.FeatureFlagOverride, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListFeatureFlagOverrides(ctx, environment)
+}
+
+// UpsertFeatureFlagOverride stores a user's override.
+func (g *StoreGateway) UpsertFeatureFlagOverride(ctx context.Context, o *domain.FeatureFlagOverride) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.UpsertFeatureFlagOverride(ctx, o)
+}
+
+// DeleteFeatureFlagOverride removes a user's override.
+func (g *StoreGateway) DeleteFeatureFlagOverride(ctx context.Context, environment, name string, userID uuid.UUID) (bool, error) {
+	if g.altDB == nil {
+		return false, errDatabaseUnavailable
+	}
+	return g.altDB.DeleteFeatureFlagOverride(ctx, environment, name, userID)
+}
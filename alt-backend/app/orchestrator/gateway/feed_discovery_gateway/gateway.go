@@ -0,0 +1,131 @@
+package feed_discovery_gateway
+
+import (
+	"alt/domain"
+	"alt/utils/security"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+const (
+	// maxPageBytes bounds the HTML read for <link> discovery; feed links
+	// live in <head>, so a truncated body still finds them.
+	maxPageBytes = 2 << 20
+	// maxFeedBytes bounds a probed feed document.
+	maxFeedBytes = 5 << 20
+
+	userAgent = "Alt-RSS-Reader/1.0 (+https://alt.example.com)"
+)
+
+// Gateway fetches discovery pages and probes candidate feeds over an
+// SSRF-protected HTTP client.
+// Implements feed_discovery_port.FetchDiscoveryPagePort and ProbeFeedPort.
+type Gateway struct {
+	httpClient    *http.Client
+	ssrfValidator *security.SSRFValidator
+}
+
+// NewGateway creates a Gateway with connection-time SSRF checks.
+func NewGateway() *Gateway {
+	ssrfValidator := security.NewSSRFValidator()
+	return &Gateway{
+		httpClient:    ssrfValidator.CreateSecureHTTPClient(10 * time.Second),
+		ssrfValidator: ssrfValidator,
+	}
+}
+
+// NewGatewayWithDeps creates a Gateway with explicit dependencies (for testing).
+func NewGatewayWithDeps(httpClient *http.Client, ssrfValidator *security.SSRFValidator) *Gateway {
+	return &Gateway{
+		httpClient:    httpClient,
+		ssrfValidator: ssrfValidator,
+	}
+}
+
+// FetchDiscoveryPage fetches pageURL and returns its (size-bounded) body.
+func (g *Gateway) FetchDiscoveryPage(ctx context.Context, pageURL string) (*domain.DiscoveryPage, error) {
+	resp, err := g.get(ctx, pageURL, "text/html,application/xhtml+xml,application/rss+xml,application/atom+xml,application/feed+json;q=0.9,*/*;q=0.8")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read page body: %w", err)
+	}
+	// Resolve relative links against the post-redirect URL.
+	finalURL := pageURL
+	if resp.Request != nil && resp.Request.URL != nil {
+		finalURL = resp.Request.URL.String()
+	}
+	return &domain.DiscoveryPage{
+		URL:         finalURL,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	}, nil
+}
+
+// ProbeFeed fetches feedURL and parses it as RSS, Atom, or JSON Feed.
+func (g *Gateway) ProbeFeed(ctx context.Context, feedURL string) (*domain.DiscoveredFeed, error) {
+	resp, err := g.get(ctx, feedURL, "application/rss+xml,application/atom+xml,application/feed+json,application/xml;q=0.9,*/*;q=0.5")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read feed body: %w", err)
+	}
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parse feed: %w", err)
+	}
+
+	return &domain.DiscoveredFeed{
+		URL:       feedURL,
+		Title:     strings.TrimSpace(feed.Title),
+		Format:    feed.FeedType,
+		ItemCount: len(feed.Items),
+	}, nil
+}
+
+// get issues an SSRF-checked GET and rejects non-2xx responses.
+func (g *Gateway) get(ctx context.Context, rawURL, accept string) (*http.Response, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	safeURL, err := g.ssrfValidator.CanonicalRequestURL(ctx, parsedURL)
+	if err != nil {
+		return nil, fmt.Errorf("ssrf validation failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, safeURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", accept)
+
+	// SSRF: CanonicalRequestURL + CreateSecureHTTPClient (connection-time IP + redirect checks).
+	// codeql[go/request-forgery] - URL reconstructed by SSRFValidator.CanonicalRequestURL
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", parsedURL.Host, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("fetch %s: unexpected status %d", parsedURL.Host, resp.StatusCode)
+	}
+	return resp, nil
+}
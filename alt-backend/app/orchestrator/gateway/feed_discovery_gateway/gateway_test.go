@@ -0,0 +1,88 @@
+package feed_discovery_gateway
+
+import (
+	"alt/utils/security"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestGateway(status int, contentType, body string) *Gateway {
+	rt := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+			Request:    req,
+		}
+		resp.Header.Set("Content-Type", contentType)
+		return resp, nil
+	})
+	// A public IP literal passes SSRF validation without DNS.
+	return NewGatewayWithDeps(&http.Client{Timeout: 5 * time.Second, Transport: rt}, security.NewSSRFValidator())
+}
+
+func TestProbeFeed_RSS(t *testing.T) {
+	rss := `<?xml version="1.0"?><rss version="2.0"><channel><title> Example </title>
+		<item><title>a</title></item><item><title>b</title></item></channel></rss>`
+	g := newTestGateway(http.StatusOK, "application/rss+xml", rss)
+
+	feed, err := g.ProbeFeed(context.Background(), "https://93.184.216.34/feed.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "Example", feed.Title)
+	assert.Equal(t, "rss", feed.Format)
+	assert.Equal(t, 2, feed.ItemCount)
+	assert.Equal(t, "https://93.184.216.34/feed.xml", feed.URL)
+}
+
+func TestProbeFeed_JSONFeed(t *testing.T) {
+	jsonFeed := `{"version":"https://jsonfeed.org/version/1.1","title":"JSON Blog","items":[{"id":"1","content_text":"hi"}]}`
+	g := newTestGateway(http.StatusOK, "application/feed+json", jsonFeed)
+
+	feed, err := g.ProbeFeed(context.Background(), "https://93.184.216.34/feed.json")
+	require.NoError(t, err)
+	assert.Equal(t, "json", feed.Format)
+	assert.Equal(t, 1, feed.ItemCount)
+}
+
+func TestProbeFeed_NotAFeed(t *testing.T) {
+	g := newTestGateway(http.StatusOK, "text/html", "<html><body>hello</body></html>")
+
+	_, err := g.ProbeFeed(context.Background(), "https://93.184.216.34/")
+	require.Error(t, err)
+}
+
+func TestFetchDiscoveryPage(t *testing.T) {
+	g := newTestGateway(http.StatusOK, "text/html; charset=utf-8", "<html></html>")
+
+	page, err := g.FetchDiscoveryPage(context.Background(), "https://93.184.216.34/blog")
+	require.NoError(t, err)
+	assert.Equal(t, "https://93.184.216.34/blog", page.URL)
+	assert.Equal(t, "text/html; charset=utf-8", page.ContentType)
+	assert.Equal(t, "<html></html>", string(page.Body))
+}
+
+func TestFetchDiscoveryPage_Rejections(t *testing.T) {
+	t.Run("non-2xx", func(t *testing.T) {
+		g := newTestGateway(http.StatusNotFound, "text/html", "")
+		_, err := g.FetchDiscoveryPage(context.Background(), "https://93.184.216.34/missing")
+		require.ErrorContains(t, err, "unexpected status 404")
+	})
+	t.Run("private address", func(t *testing.T) {
+		g := newTestGateway(http.StatusOK, "text/html", "")
+		_, err := g.FetchDiscoveryPage(context.Background(), "http://10.0.0.1/")
+		require.ErrorContains(t, err, "ssrf validation failed")
+	})
+}
@@ -259,6 +259,10 @@ func (g *FetchFeedsGateway) FetchUnreadFeedsListCursor(ctx context.Context, curs
 			PublishedParsed: publishedTime,
 			OgImageURL:      derefString(feed.OgImageURL),
 		}
+		// FeedID keys the home ranking signals; a malformed ID just goes unranked.
+		if feedID, err := uuid.Parse(feed.ID); err == nil {
+			feedItem.FeedID = feedID
+		}
 
 		// Set ArticleID if article exists in database
 		if feed.ArticleID != nil {
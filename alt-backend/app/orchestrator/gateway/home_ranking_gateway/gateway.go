@@ -0,0 +1,55 @@
+package home_ranking_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements the home_ranking_port interfaces on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new home ranking gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// FetchHomeRankingExperiment loads a user's weight assignment.
+func (g *Gateway) FetchHomeRankingExperiment(ctx context.Context, userID uuid.UUID) (*domain.HomeRankingExperiment, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchHomeRankingExperiment(ctx, userID)
+}
+
+// UpsertHomeRankingExperiment stores a user's weight assignment.
+func (g *Gateway) UpsertHomeRankingExperiment(ctx context.Context, exp *domain.HomeRankingExperiment) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.UpsertHomeRankingExperiment(ctx, exp)
+}
+
+// DeleteHomeRankingExperiment removes a user's weight assignment.
+func (g *Gateway) DeleteHomeRankingExperiment(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if g.altDB == nil {
+		return false, errDatabaseUnavailable
+	}
+	return g.altDB.DeleteHomeRankingExperiment(ctx, userID)
+}
+
+// FetchHomeRankingInputs loads the ranking signal inputs for one page.
+func (g *Gateway) FetchHomeRankingInputs(ctx context.Context, userID uuid.UUID, feedIDs []uuid.UUID, historySince, trendingSince time.Time) (*domain.HomeRankingInputs, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchHomeRankingInputs(ctx, userID, feedIDs, historySince, trendingSince)
+}
@@ -0,0 +1,71 @@
+package legal_hold_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements the legal_hold_port interfaces on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new legal hold gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// PlaceLegalHold persists a new active legal hold.
+func (g *Gateway) PlaceLegalHold(ctx context.Context, hold *domain.LegalHold) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.PlaceLegalHold(ctx, hold)
+}
+
+// ReleaseLegalHold releases the active hold on an account.
+func (g *Gateway) ReleaseLegalHold(ctx context.Context, userID, releasedBy uuid.UUID) (*domain.LegalHold, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ReleaseLegalHold(ctx, userID, releasedBy)
+}
+
+// GetActiveLegalHold returns the active hold for an account, or nil.
+func (g *Gateway) GetActiveLegalHold(ctx context.Context, userID uuid.UUID) (*domain.LegalHold, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.GetActiveLegalHold(ctx, userID)
+}
+
+// ListActiveLegalHolds returns every active hold.
+func (g *Gateway) ListActiveLegalHolds(ctx context.Context) ([]*domain.LegalHold, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListActiveLegalHolds(ctx)
+}
+
+// RecordComplianceAudit appends to the compliance audit log.
+func (g *Gateway) RecordComplianceAudit(ctx context.Context, entry *domain.ComplianceAuditEntry) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RecordComplianceAudit(ctx, entry)
+}
+
+// ExportAccountSection streams one section of an account's data as JSON Lines.
+func (g *Gateway) ExportAccountSection(ctx context.Context, userID uuid.UUID, section string, w io.Writer) (int64, error) {
+	if g.altDB == nil {
+		return 0, errDatabaseUnavailable
+	}
+	return g.altDB.ExportAccountSection(ctx, userID, section, w)
+}
@@ -0,0 +1,159 @@
+package organization_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements the organization_port interfaces on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new organization gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// CreateOrganization stores a new organization and its first owner.
+func (g *Gateway) CreateOrganization(ctx context.Context, org *domain.Organization, owner uuid.UUID) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.CreateOrganization(ctx, org, owner)
+}
+
+// ListUserOrganizations lists a user's organizations.
+func (g *Gateway) ListUserOrganizations(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListUserOrganizations(ctx, userID)
+}
+
+// FetchOrgMembership loads one membership.
+func (g *Gateway) FetchOrgMembership(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchOrgMembership(ctx, orgID, userID)
+}
+
+// ListOrgMembers lists an organization's members.
+func (g *Gateway) ListOrgMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListOrgMembers(ctx, orgID)
+}
+
+// UpdateOrgMemberRole changes a member's role.
+func (g *Gateway) UpdateOrgMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.OrgRole) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.UpdateOrgMemberRole(ctx, orgID, userID, role)
+}
+
+// RemoveOrgMember removes a member from an organization.
+func (g *Gateway) RemoveOrgMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RemoveOrgMember(ctx, orgID, userID)
+}
+
+// CreateTeam stores a new team.
+func (g *Gateway) CreateTeam(ctx context.Context, team *domain.Team) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.CreateTeam(ctx, team)
+}
+
+// ListTeams lists an organization's teams.
+func (g *Gateway) ListTeams(ctx context.Context, orgID uuid.UUID) ([]*domain.Team, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListTeams(ctx, orgID)
+}
+
+// ListTeamMembers lists a team's direct members.
+func (g *Gateway) ListTeamMembers(ctx context.Context, orgID, teamID uuid.UUID) ([]*domain.TeamMember, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListTeamMembers(ctx, orgID, teamID)
+}
+
+// AddTeamMember adds an organization member to a team.
+func (g *Gateway) AddTeamMember(ctx context.Context, orgID, teamID, userID uuid.UUID, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.AddTeamMember(ctx, orgID, teamID, userID, now)
+}
+
+// RemoveTeamMember removes a member from a team.
+func (g *Gateway) RemoveTeamMember(ctx context.Context, orgID, teamID, userID uuid.UUID) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RemoveTeamMember(ctx, orgID, teamID, userID)
+}
+
+// ListUserTeamScope lists the teams a user belongs to, including sub-teams.
+func (g *Gateway) ListUserTeamScope(ctx context.Context, orgID, userID uuid.UUID) ([]uuid.UUID, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListUserTeamScope(ctx, orgID, userID)
+}
+
+// CreateOrgInvitation stores a new invitation.
+func (g *Gateway) CreateOrgInvitation(ctx context.Context, inv *domain.OrgInvitation) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.CreateOrgInvitation(ctx, inv)
+}
+
+// ListOrgInvitations lists an organization's invitations.
+func (g *Gateway) ListOrgInvitations(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgInvitation, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListOrgInvitations(ctx, orgID)
+}
+
+// RevokeOrgInvitation revokes a pending invitation.
+func (g *Gateway) RevokeOrgInvitation(ctx context.Context, orgID, invitationID uuid.UUID, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RevokeOrgInvitation(ctx, orgID, invitationID, now)
+}
+
+// FetchOrgInvitationByTokenHash looks an invitation up by token hash.
+func (g *Gateway) FetchOrgInvitationByTokenHash(ctx context.Context, tokenHash []byte) (*domain.OrgInvitation, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchOrgInvitationByTokenHash(ctx, tokenHash)
+}
+
+// AcceptOrgInvitation redeems a pending invitation for a user.
+func (g *Gateway) AcceptOrgInvitation(ctx context.Context, invitationID, userID uuid.UUID, now time.Time) (*domain.OrgMember, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.AcceptOrgInvitation(ctx, invitationID, userID, now)
+}
@@ -0,0 +1,47 @@
+package reading_stats_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements the reading_stats_port interfaces on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new reading stats gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// RecomputeReadingStats rebuilds one day of reading aggregates.
+func (g *Gateway) RecomputeReadingStats(ctx context.Context, day time.Time, loc *time.Location, now time.Time) (int64, error) {
+	if g.altDB == nil {
+		return 0, errDatabaseUnavailable
+	}
+	return g.altDB.RecomputeReadingStats(ctx, day, loc, now)
+}
+
+// FetchReadingStatsAggregate reads a user's aggregates for a date range.
+func (g *Gateway) FetchReadingStatsAggregate(ctx context.Context, userID uuid.UUID, from, to time.Time, topN int) (*domain.ReadingStatsAggregate, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.FetchReadingStatsAggregate(ctx, userID, from, to, topN)
+}
+
+// CountArticlesRead sums a user's reads for a date range.
+func (g *Gateway) CountArticlesRead(ctx context.Context, userID uuid.UUID, from, to time.Time) (int, error) {
+	if g.altDB == nil {
+		return 0, errDatabaseUnavailable
+	}
+	return g.altDB.CountArticlesRead(ctx, userID, from, to)
+}
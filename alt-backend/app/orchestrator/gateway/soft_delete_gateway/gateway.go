@@ -0,0 +1,95 @@
+package soft_delete_gateway
+
+import (
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errDatabaseUnavailable = errors.New("database connection not available")
+
+// Gateway implements the soft_delete_port interfaces on top of alt_db.
+type Gateway struct {
+	altDB *alt_db.AltDBRepository
+}
+
+// NewGateway creates a new soft-delete gateway.
+func NewGateway(altDB *alt_db.AltDBRepository) *Gateway {
+	return &Gateway{altDB: altDB}
+}
+
+// SoftDeleteFeed marks a feed deleted.
+func (g *Gateway) SoftDeleteFeed(ctx context.Context, feedID, actorID uuid.UUID, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.SoftDeleteFeed(ctx, feedID, actorID, now)
+}
+
+// RestoreFeed restores a soft-deleted feed.
+func (g *Gateway) RestoreFeed(ctx context.Context, feedID, actorID uuid.UUID, notBefore, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RestoreFeed(ctx, feedID, actorID, notBefore, now)
+}
+
+// ListDeletedFeeds lists restorable feeds.
+func (g *Gateway) ListDeletedFeeds(ctx context.Context, notBefore time.Time, limit int) ([]*domain.DeletedItem, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListDeletedFeeds(ctx, notBefore, limit)
+}
+
+// PurgeExpiredFeeds permanently removes one batch of expired feeds.
+func (g *Gateway) PurgeExpiredFeeds(ctx context.Context, cutoff time.Time, heldUserIDs []uuid.UUID, limit int) (int64, error) {
+	if g.altDB == nil {
+		return 0, errDatabaseUnavailable
+	}
+	return g.altDB.PurgeExpiredFeeds(ctx, cutoff, heldUserIDs, limit)
+}
+
+// SoftDeleteArticle marks a user's article deleted.
+func (g *Gateway) SoftDeleteArticle(ctx context.Context, articleID, userID uuid.UUID, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.SoftDeleteArticle(ctx, articleID, userID, now)
+}
+
+// RestoreArticle restores a user's soft-deleted article.
+func (g *Gateway) RestoreArticle(ctx context.Context, articleID, userID uuid.UUID, notBefore, now time.Time) error {
+	if g.altDB == nil {
+		return errDatabaseUnavailable
+	}
+	return g.altDB.RestoreArticle(ctx, articleID, userID, notBefore, now)
+}
+
+// ListUserDeletedArticles lists a user's restorable articles.
+func (g *Gateway) ListUserDeletedArticles(ctx context.Context, userID uuid.UUID, notBefore time.Time, limit int) ([]*domain.DeletedItem, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListUserDeletedArticles(ctx, userID, notBefore, limit)
+}
+
+// PurgeExpiredArticles permanently removes one batch of expired articles.
+func (g *Gateway) PurgeExpiredArticles(ctx context.Context, cutoff time.Time, heldUserIDs []uuid.UUID, limit int) (int64, error) {
+	if g.altDB == nil {
+		return 0, errDatabaseUnavailable
+	}
+	return g.altDB.PurgeExpiredArticles(ctx, cutoff, heldUserIDs, limit)
+}
+
+// ListArticleSoftDeleteEvents pages through the article audit trail.
+func (g *Gateway) ListArticleSoftDeleteEvents(ctx context.Context, since time.Time, afterID uuid.UUID, limit int) ([]*domain.SoftDeleteEvent, error) {
+	if g.altDB == nil {
+		return nil, errDatabaseUnavailable
+	}
+	return g.altDB.ListArticleSoftDeleteEvents(ctx, since, afterID, limit)
+}
@@ -0,0 +1,29 @@
+package job
+
+import (
+	"alt/orchestrator/usecase/article_index_outbox_usecase"
+	"context"
+	"fmt"
+)
+
+// articleIndexOutboxDrainer abstracts the article index outbox usecase (for testability).
+type articleIndexOutboxDrainer interface {
+	RunBatch(ctx context.Context) (int, error)
+}
+
+// ArticleIndexOutboxJob returns a JobScheduler function that publishes
+// articles marked for reindexing to search-indexer and rag-orchestrator.
+func ArticleIndexOutboxJob(uc *article_index_outbox_usecase.Usecase) func(ctx context.Context) error {
+	return articleIndexOutboxJobFn(uc)
+}
+
+func articleIndexOutboxJobFn(d articleIndexOutboxDrainer) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		// Published articles are dequeued one by one, so a timeout only
+		// leaves the rest for the next run.
+		if _, err := d.RunBatch(ctx); err != nil {
+			return fmt.Errorf("publish dirty articles: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockArticleIndexOutboxDrainer struct {
+	err   error
+	calls int
+}
+
+func (m *mockArticleIndexOutboxDrainer) RunBatch(ctx context.Context) (int, error) {
+	m.calls++
+	return 0, m.err
+}
+
+func TestArticleIndexOutboxJob_RunsBatch(t *testing.T) {
+	d := &mockArticleIndexOutboxDrainer{}
+
+	if err := articleIndexOutboxJobFn(d)(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.calls != 1 {
+		t.Errorf("expected 1 batch call, got %d", d.calls)
+	}
+}
+
+func TestArticleIndexOutboxJob_PropagatesError(t *testing.T) {
+	d := &mockArticleIndexOutboxDrainer{err: errors.New("database unavailable")}
+
+	if err := articleIndexOutboxJobFn(d)(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
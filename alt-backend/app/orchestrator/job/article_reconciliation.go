@@ -0,0 +1,30 @@
+package job
+
+import (
+	"alt/domain"
+	"alt/orchestrator/usecase/article_reconciliation_usecase"
+	"context"
+	"fmt"
+)
+
+// articleReconciler abstracts the reconciliation usecase (for testability).
+type articleReconciler interface {
+	Reconcile(ctx context.Context) (*domain.ReconciliationRunResult, error)
+}
+
+// ArticleReconciliationJob returns a JobScheduler function that matches
+// articles reported by ingest sources against alt-backend's articles,
+// links duplicates, and marks orphaned and conflicting records.
+func ArticleReconciliationJob(uc *article_reconciliation_usecase.Usecase) func(ctx context.Context) error {
+	return articleReconciliationJobFn(uc)
+}
+
+func articleReconciliationJobFn(r articleReconciler) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		// The usecase logs the run summary.
+		if _, err := r.Reconcile(ctx); err != nil {
+			return fmt.Errorf("reconcile articles: %w", err)
+		}
+		return nil
+	}
+}
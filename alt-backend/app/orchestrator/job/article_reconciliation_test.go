@@ -0,0 +1,40 @@
+package job
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockArticleReconciler struct {
+	err   error
+	calls int
+}
+
+func (m *mockArticleReconciler) Reconcile(ctx context.Context) (*domain.ReconciliationRunResult, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.ReconciliationRunResult{Scanned: 3, Matched: 2, Orphaned: 1}, nil
+}
+
+func TestArticleReconciliationJob_RunsReconcile(t *testing.T) {
+	r := &mockArticleReconciler{}
+
+	if err := articleReconciliationJobFn(r)(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if r.calls != 1 {
+		t.Errorf("expected 1 reconcile call, got %d", r.calls)
+	}
+}
+
+func TestArticleReconciliationJob_PropagatesError(t *testing.T) {
+	r := &mockArticleReconciler{err: errors.New("database unavailable")}
+
+	if err := articleReconciliationJobFn(r)(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,30 @@
+package job
+
+import (
+	"alt/domain"
+	"alt/orchestrator/usecase/article_title_fix_usecase"
+	"context"
+	"fmt"
+)
+
+// articleTitleFixer abstracts the article title fix usecase (for testability).
+type articleTitleFixer interface {
+	RunBatch(ctx context.Context) (*domain.ArticleTitleFixJob, error)
+}
+
+// ArticleTitleFixJob returns a JobScheduler function that advances the
+// active article title repair job, if an admin started one, by one batch.
+func ArticleTitleFixJob(uc *article_title_fix_usecase.Usecase) func(ctx context.Context) error {
+	return articleTitleFixJobFn(uc)
+}
+
+func articleTitleFixJobFn(f articleTitleFixer) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		// The usecase logs batch progress; the job's cursor is saved per
+		// article, so a timeout only delays the remaining work.
+		if _, err := f.RunBatch(ctx); err != nil {
+			return fmt.Errorf("fix article titles: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+package job
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockArticleTitleFixer struct {
+	err   error
+	calls int
+}
+
+func (m *mockArticleTitleFixer) RunBatch(ctx context.Context) (*domain.ArticleTitleFixJob, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return nil, nil
+}
+
+func TestArticleTitleFixJob_RunsBatch(t *testing.T) {
+	f := &mockArticleTitleFixer{}
+
+	if err := articleTitleFixJobFn(f)(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if f.calls != 1 {
+		t.Errorf("expected 1 batch call, got %d", f.calls)
+	}
+}
+
+func TestArticleTitleFixJob_PropagatesError(t *testing.T) {
+	f := &mockArticleTitleFixer{err: errors.New("database unavailable")}
+
+	if err := articleTitleFixJobFn(f)(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -30,11 +30,3 @@ func ScrapingPolicyJob(usecase *scraping_domain_usecase.ScrapingDomainUsecase) f
 		return nil
 	}
 }
-
-// DailyScrapingPolicyJobRunner is kept for backward compatibility.
-// Deprecated: Use ScrapingPolicyJob with JobScheduler instead.
-func DailyScrapingPolicyJobRunner(ctx context.Context, usecase *scraping_domain_usecase.ScrapingDomainUsecase) {
-	if err := ScrapingPolicyJob(usecase)(ctx); err != nil {
-		logger.Logger.ErrorContext(ctx, "Error in scraping policy refresh", "error", err)
-	}
-}
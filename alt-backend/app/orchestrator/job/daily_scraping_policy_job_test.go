@@ -13,7 +13,7 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func TestDailyScrapingPolicyJobRunner_InitialRun(t *testing.T) {
+func TestScrapingPolicyJob_InitialRun(t *testing.T) {
 	// Initialize logger for tests
 	logger.InitLogger()
 
@@ -38,7 +38,7 @@ func TestDailyScrapingPolicyJobRunner_InitialRun(t *testing.T) {
 	// Start job in goroutine
 	done := make(chan bool)
 	go func() {
-		DailyScrapingPolicyJobRunner(ctx, usecase)
+		_ = ScrapingPolicyJob(usecase)(ctx)
 		done <- true
 	}()
 
@@ -57,7 +57,7 @@ func TestDailyScrapingPolicyJobRunner_InitialRun(t *testing.T) {
 	}
 }
 
-func TestDailyScrapingPolicyJobRunner_ContextCancellation(t *testing.T) {
+func TestScrapingPolicyJob_ContextCancellation(t *testing.T) {
 	// Initialize logger for tests
 	logger.InitLogger()
 
@@ -79,7 +79,7 @@ func TestDailyScrapingPolicyJobRunner_ContextCancellation(t *testing.T) {
 	// Start job in goroutine
 	done := make(chan bool)
 	go func() {
-		DailyScrapingPolicyJobRunner(ctx, usecase)
+		_ = ScrapingPolicyJob(usecase)(ctx)
 		done <- true
 	}()
 
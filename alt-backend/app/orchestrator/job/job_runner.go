@@ -73,11 +73,3 @@ func stringPtrOrNil(s string) *string {
 	}
 	return &s
 }
-
-// HourlyJobRunner is kept for backward compatibility but delegates to CollectFeedsJob.
-// Deprecated: Use CollectFeedsJob with JobScheduler instead.
-func HourlyJobRunner(ctx context.Context, r *alt_db.AltDBRepository) {
-	if err := CollectFeedsJob(r)(ctx); err != nil {
-		logger.Logger.ErrorContext(ctx, "Error in feed collection", "error", err)
-	}
-}
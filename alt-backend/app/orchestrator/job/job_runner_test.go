@@ -9,7 +9,7 @@ import (
 )
 
 // TestFeedModelURLNormalization verifies that URL normalization is applied correctly
-// when creating feed models. This tests the normalization behavior that is used in HourlyJobRunner.
+// when creating feed models. This tests the normalization behavior that is used in CollectFeedsJob.
 func TestFeedModelURLNormalization(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -57,7 +57,7 @@ func TestFeedModelURLNormalization(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// This simulates the normalization logic in HourlyJobRunner
+			// This simulates the normalization logic in CollectFeedsJob
 			normalizedLink, err := utils.NormalizeURL(tt.inputURL)
 			if err != nil {
 				t.Fatalf("NormalizeURL failed: %v", err)
@@ -107,10 +107,10 @@ func TestNormalizeURLFallback(t *testing.T) {
 
 	for _, inputURL := range invalidURLs {
 		t.Run(inputURL, func(t *testing.T) {
-			// This simulates the fallback behavior in HourlyJobRunner
+			// This simulates the fallback behavior in CollectFeedsJob
 			normalizedLink, err := utils.NormalizeURL(inputURL)
 			if err != nil {
-				// Fallback to original URL on error (as done in HourlyJobRunner)
+				// Fallback to original URL on error (as done in CollectFeedsJob)
 				normalizedLink = inputURL
 			}
 
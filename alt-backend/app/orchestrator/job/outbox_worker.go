@@ -4,6 +4,7 @@ import (
 	"alt/domain"
 	"alt/orchestrator/port/rag_integration_port"
 	"alt/shared/driver/alt_db"
+	"alt/shared/port/event_publisher_port"
 	"alt/shared/port/knowledge_event_port"
 	"alt/utils/logger"
 	"context"
@@ -14,15 +15,24 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// maxRelayAttempts bounds how often an mq-hub event is delivered before
+	// it is marked FAILED. With relayBackoff doubling up to maxRelayBackoff
+	// the last attempt is about 20 minutes after the first.
+	maxRelayAttempts = 10
+	relayBackoff     = 5 * time.Second
+	maxRelayBackoff  = 5 * time.Minute
+)
+
 // OutboxWorkerJob returns a function suitable for the JobScheduler that
 // processes pending outbox events.
-func OutboxWorkerJob(repo *alt_db.AltDBRepository, ragIntegration rag_integration_port.RagIntegrationPort, knowledgeEventPort knowledge_event_port.AppendKnowledgeEventPort) func(ctx context.Context) error {
+func OutboxWorkerJob(repo *alt_db.AltDBRepository, ragIntegration rag_integration_port.RagIntegrationPort, knowledgeEventPort knowledge_event_port.AppendKnowledgeEventPort, relay event_publisher_port.EventRelayPort) func(ctx context.Context) error {
 	return func(ctx context.Context) error {
-		return processOutboxEvents(ctx, repo, ragIntegration, knowledgeEventPort)
+		return processOutboxEvents(ctx, repo, ragIntegration, knowledgeEventPort, relay)
 	}
 }
 
-func processOutboxEvents(ctx context.Context, repo *alt_db.AltDBRepository, ragIntegration rag_integration_port.RagIntegrationPort, knowledgeEventPort knowledge_event_port.AppendKnowledgeEventPort) error {
+func processOutboxEvents(ctx context.Context, repo *alt_db.AltDBRepository, ragIntegration rag_integration_port.RagIntegrationPort, knowledgeEventPort knowledge_event_port.AppendKnowledgeEventPort, relay event_publisher_port.EventRelayPort) error {
 	events, err := repo.FetchAndLockPendingOutboxEvents(ctx, 10)
 	if err != nil {
 		logger.Logger.ErrorContext(ctx, "Failed to fetch pending outbox events", "error", err)
@@ -36,7 +46,9 @@ func processOutboxEvents(ctx context.Context, repo *alt_db.AltDBRepository, ragI
 	logger.Logger.InfoContext(ctx, "Processing outbox events", "count", len(events))
 
 	for _, event := range events {
-		if event.EventType == "ARTICLE_UPSERT" {
+		if event.EventType == alt_db.OutboxEventTypeMQHub {
+			relayOutboxEvent(ctx, repo, relay, event)
+		} else if event.EventType == "ARTICLE_UPSERT" {
 			var upsertInput rag_integration_port.UpsertArticleInput
 			if err := json.Unmarshal(event.Payload, &upsertInput); err != nil {
 				logger.Logger.ErrorContext(ctx, "Failed to unmarshal outbox event payload", "event_id", event.ID, "error", err)
@@ -148,7 +160,54 @@ func emitArticleCreatedEvent(ctx context.Context, port knowledge_event_port.Appe
 	}
 }
 
-func updateStatus(ctx context.Context, repo *alt_db.AltDBRepository, id string, status string, errMsg string) {
+// relayOutboxEvent publishes a recorded mq-hub event. A failed delivery is
+// rescheduled with backoff until maxRelayAttempts; a row that cannot be
+// decoded will never succeed and fails at once.
+func relayOutboxEvent(ctx context.Context, repo outboxStatusStore, relay event_publisher_port.EventRelayPort, event alt_db.OutboxEvent) {
+	var recorded event_publisher_port.RecordedEvent
+	if err := json.Unmarshal(event.Payload, &recorded); err != nil {
+		logger.Logger.ErrorContext(ctx, "Failed to unmarshal outbox event payload", "event_id", event.ID, "error", err)
+		updateStatus(ctx, repo, event.ID, "FAILED", err.Error())
+		return
+	}
+
+	if err := relay.RelayEvent(ctx, recorded); err != nil {
+		attempt := event.Attempts + 1
+		if attempt >= maxRelayAttempts {
+			logger.Logger.ErrorContext(ctx, "Giving up relaying outbox event to mq-hub",
+				"event_id", event.ID, "event_type", recorded.EventType, "attempts", attempt, "error", err)
+			updateStatus(ctx, repo, event.ID, "FAILED", err.Error())
+			return
+		}
+		retryAt := time.Now().Add(relayRetryDelay(attempt))
+		logger.Logger.WarnContext(ctx, "Failed to relay outbox event to mq-hub, will retry",
+			"event_id", event.ID, "event_type", recorded.EventType, "attempts", attempt, "retry_at", retryAt, "error", err)
+		if err := repo.RescheduleOutboxEvent(ctx, event.ID, err.Error(), retryAt); err != nil {
+			logger.Logger.ErrorContext(ctx, "Failed to reschedule outbox event", "event_id", event.ID, "error", err)
+		}
+		return
+	}
+
+	updateStatus(ctx, repo, event.ID, "PROCESSED", "")
+}
+
+// relayRetryDelay is the wait before delivery attempt+1.
+func relayRetryDelay(attempt int) time.Duration {
+	delay := relayBackoff
+	for i := 1; i < attempt && delay < maxRelayBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRelayBackoff)
+}
+
+// outboxStatusStore is the part of alt_db.AltDBRepository that records
+// what became of an outbox event.
+type outboxStatusStore interface {
+	UpdateOutboxEventStatus(ctx context.Context, id string, status string, errorMessage *string) error
+	RescheduleOutboxEvent(ctx context.Context, id string, errorMessage string, retryAt time.Time) error
+}
+
+func updateStatus(ctx context.Context, repo outboxStatusStore, id string, status string, errMsg string) {
 	var errPtr *string
 	if errMsg != "" {
 		errPtr = &errMsg
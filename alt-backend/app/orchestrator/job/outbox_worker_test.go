@@ -2,9 +2,12 @@ package job
 
 import (
 	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"alt/shared/port/event_publisher_port"
 	"alt/utils/logger"
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -85,3 +88,99 @@ func TestEmitArticleCreatedEvent(t *testing.T) {
 		assert.Len(t, stub.events, 1) // event was attempted
 	})
 }
+
+type stubOutboxStatusStore struct {
+	statuses    map[string]string
+	rescheduled map[string]time.Time
+}
+
+func newStubOutboxStatusStore() *stubOutboxStatusStore {
+	return &stubOutboxStatusStore{statuses: map[string]string{}, rescheduled: map[string]time.Time{}}
+}
+
+func (s *stubOutboxStatusStore) UpdateOutboxEventStatus(_ context.Context, id string, status string, _ *string) error {
+	s.statuses[id] = status
+	return nil
+}
+
+func (s *stubOutboxStatusStore) RescheduleOutboxEvent(_ context.Context, id string, _ string, retryAt time.Time) error {
+	s.rescheduled[id] = retryAt
+	return nil
+}
+
+type stubEventRelay struct {
+	relayed []event_publisher_port.RecordedEvent
+	err     error
+}
+
+func (s *stubEventRelay) RelayEvent(_ context.Context, event event_publisher_port.RecordedEvent) error {
+	s.relayed = append(s.relayed, event)
+	return s.err
+}
+
+func TestRelayOutboxEvent(t *testing.T) {
+	logger.InitLogger()
+	recorded := event_publisher_port.RecordedEvent{
+		EventID:   uuid.New().String(),
+		EventType: "FeedSubscribed",
+		Stream:    "alt:events:feeds",
+		CreatedAt: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		Payload:   json.RawMessage(`{"user_id":"u1","feed_link_id":"f1"}`),
+	}
+	row, err := json.Marshal(recorded)
+	require.NoError(t, err)
+	event := alt_db.OutboxEvent{ID: "row-1", EventType: alt_db.OutboxEventTypeMQHub, Payload: row}
+
+	t.Run("publishes the recorded event unchanged", func(t *testing.T) {
+		store, relay := newStubOutboxStatusStore(), &stubEventRelay{}
+
+		relayOutboxEvent(context.Background(), store, relay, event)
+
+		require.Len(t, relay.relayed, 1)
+		assert.Equal(t, recorded.EventID, relay.relayed[0].EventID)
+		assert.True(t, recorded.CreatedAt.Equal(relay.relayed[0].CreatedAt))
+		assert.JSONEq(t, string(recorded.Payload), string(relay.relayed[0].Payload))
+		assert.Equal(t, "PROCESSED", store.statuses["row-1"])
+	})
+
+	t.Run("reschedules a failed delivery", func(t *testing.T) {
+		store, relay := newStubOutboxStatusStore(), &stubEventRelay{err: errors.New("mq-hub unavailable")}
+
+		before := time.Now()
+		relayOutboxEvent(context.Background(), store, relay, event)
+
+		assert.Empty(t, store.statuses)
+		require.Contains(t, store.rescheduled, "row-1")
+		assert.WithinDuration(t, before.Add(relayBackoff), store.rescheduled["row-1"], time.Second)
+	})
+
+	t.Run("fails after the last attempt", func(t *testing.T) {
+		store, relay := newStubOutboxStatusStore(), &stubEventRelay{err: errors.New("mq-hub unavailable")}
+		exhausted := event
+		exhausted.Attempts = maxRelayAttempts - 1
+
+		relayOutboxEvent(context.Background(), store, relay, exhausted)
+
+		assert.Empty(t, store.rescheduled)
+		assert.Equal(t, "FAILED", store.statuses["row-1"])
+	})
+
+	t.Run("fails an undecodable row without publishing", func(t *testing.T) {
+		store, relay := newStubOutboxStatusStore(), &stubEventRelay{}
+		broken := event
+		broken.Payload = []byte(`not json`)
+
+		relayOutboxEvent(context.Background(), store, relay, broken)
+
+		assert.Empty(t, relay.relayed)
+		assert.Equal(t, "FAILED", store.statuses["row-1"])
+	})
+}
+
+func TestRelayRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, relayRetryDelay(1))
+	assert.Equal(t, 10*time.Second, relayRetryDelay(2))
+	assert.Equal(t, 160*time.Second, relayRetryDelay(6))
+	assert.Equal(t, maxRelayBackoff, relayRetryDelay(7))
+	assert.Equal(t, maxRelayBackoff, relayRetryDelay(maxRelayAttempts))
+}
@@ -0,0 +1,31 @@
+package job
+
+import (
+	"alt/orchestrator/usecase/reading_stats_usecase"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// readingStatsRefresher abstracts the reading stats usecase (for testability).
+type readingStatsRefresher interface {
+	RefreshRecent(ctx context.Context) (int64, error)
+}
+
+// ReadingStatsAggregationJob returns a JobScheduler function that rebuilds
+// the per-user daily reading aggregates behind GET /v1/stats/reading.
+func ReadingStatsAggregationJob(uc *reading_stats_usecase.Usecase) func(ctx context.Context) error {
+	return readingStatsAggregationJobFn(uc)
+}
+
+func readingStatsAggregationJobFn(r readingStatsRefresher) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		n, err := r.RefreshRecent(ctx)
+		if err != nil {
+			return fmt.Errorf("aggregate reading stats: %w", err)
+		}
+
+		slog.InfoContext(ctx, "reading stats aggregation completed", "user_days", n)
+		return nil
+	}
+}
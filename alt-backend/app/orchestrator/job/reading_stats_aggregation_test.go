@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockReadingStatsRefresher struct {
+	err   error
+	calls int
+}
+
+func (m *mockReadingStatsRefresher) RefreshRecent(ctx context.Context) (int64, error) {
+	m.calls++
+	return 2, m.err
+}
+
+func TestReadingStatsAggregationJob_Refreshes(t *testing.T) {
+	r := &mockReadingStatsRefresher{}
+
+	if err := readingStatsAggregationJobFn(r)(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if r.calls != 1 {
+		t.Errorf("expected 1 refresh call, got %d", r.calls)
+	}
+}
+
+func TestReadingStatsAggregationJob_PropagatesError(t *testing.T) {
+	r := &mockReadingStatsRefresher{err: errors.New("db down")}
+
+	if err := readingStatsAggregationJobFn(r)(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -13,42 +13,83 @@ func RegisterAllJobs(scheduler *JobScheduler, container *di.ApplicationComponent
 		Name:     "hourly-feed-collector",
 		Interval: 1 * time.Hour,
 		Timeout:  30 * time.Minute,
+		Jitter:   5 * time.Minute,
 		Fn:       CollectFeedsJob(container.AltDBRepository),
 	})
 	scheduler.Add(Job{
 		Name:     "daily-scraping-policy",
 		Interval: 24 * time.Hour,
 		Timeout:  1 * time.Hour,
+		Jitter:   30 * time.Minute,
 		Fn:       ScrapingPolicyJob(container.ScrapingDomainUsecase),
 	})
 	scheduler.Add(Job{
 		Name:     "outbox-worker",
 		Interval: 5 * time.Second,
 		Timeout:  30 * time.Second,
-		Fn:       OutboxWorkerJob(container.AltDBRepository, container.RagIntegration, container.SovereignClient),
+		Fn:       OutboxWorkerJob(container.AltDBRepository, container.RagIntegration, container.SovereignClient, container.EventRelay),
 	})
 	scheduler.Add(Job{
 		Name:     "ogp-image-warmer",
 		Interval: 1 * time.Hour,
 		Timeout:  20 * time.Minute,
+		Jitter:   5 * time.Minute,
 		Fn:       OgpImageWarmerJob(container.AltDBRepository, container.ImageProxyUsecase),
 	})
 	scheduler.Add(Job{
 		Name:     "og-image-retention",
 		Interval: 6 * time.Hour,
 		Timeout:  10 * time.Minute,
+		Jitter:   10 * time.Minute,
 		Fn:       OgImageRetentionJob(container.AltDBRepository),
 	})
+	scheduler.Add(Job{
+		Name:     "soft-delete-purge",
+		Interval: 24 * time.Hour,
+		Timeout:  30 * time.Minute,
+		Jitter:   30 * time.Minute,
+		Fn:       SoftDeletePurgeJob(container.Compliance.SoftDeleteUsecase),
+	})
+	scheduler.Add(Job{
+		Name:     "reading-stats-aggregation",
+		Interval: 24 * time.Hour,
+		Timeout:  30 * time.Minute,
+		Jitter:   30 * time.Minute,
+		Fn:       ReadingStatsAggregationJob(container.Recap.ReadingStatsUsecase),
+	})
+	scheduler.Add(Job{
+		Name:     "article-reconciliation",
+		Interval: 1 * time.Hour,
+		Timeout:  15 * time.Minute,
+		Jitter:   5 * time.Minute,
+		Fn:       ArticleReconciliationJob(container.Article.ArticleReconciliationUsecase),
+	})
+	scheduler.Add(Job{
+		Name:     "article-title-fix",
+		Interval: 1 * time.Minute,
+		Timeout:  10 * time.Minute,
+		Fn:       ArticleTitleFixJob(container.Article.ArticleTitleFixUsecase),
+	})
+	scheduler.Add(Job{
+		Name:     "article-index-outbox",
+		Interval: 15 * time.Second,
+		Timeout:  2 * time.Minute,
+		Fn:       ArticleIndexOutboxJob(container.Article.ArticleIndexOutboxUsecase),
+	})
 	scheduler.Add(Job{
 		Name:     "og-image-backfill",
 		Interval: 30 * time.Minute,
 		Timeout:  20 * time.Minute,
+		Jitter:   5 * time.Minute,
 		Fn:       OgImageBackfillJob(container.AltDBRepository, container.FetchArticleGateway, container.ImageProxyUsecase),
 	})
 	scheduler.Add(Job{
 		Name:     "tag-cloud-cache-warmer",
 		Interval: 24 * time.Minute,
 		Timeout:  2 * time.Minute,
-		Fn:       TagCloudCacheWarmerJob(container.FetchTagCloudUsecase),
+		Jitter:   2 * time.Minute,
+		// The tag cloud is cached in process, so every replica warms its own.
+		PerReplica: true,
+		Fn:         TagCloudCacheWarmerJob(container.FetchTagCloudUsecase),
 	})
 }
@@ -1,29 +1,119 @@
 package job
 
 import (
+	"alt/domain"
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"os"
 	"sync"
 	"time"
 )
 
+const (
+	// leaseGrace is added to a job's timeout for its run lease, so a lease
+	// only expires on its own when the replica running the job is gone.
+	leaseGrace = time.Minute
+	// finishRecordTimeout bounds recording a run's outcome, which happens
+	// even when the run ended because the scheduler is shutting down.
+	finishRecordTimeout = 10 * time.Second
+)
+
+var (
+	// ErrJobNotFound is returned by Trigger for a name no job is registered under.
+	ErrJobNotFound = errors.New("background job not found")
+	// ErrJobRunning is returned by Trigger while the job runs on this or,
+	// with a state store, any other replica.
+	ErrJobRunning = errors.New("background job is already running")
+	// ErrSchedulerNotRunning is returned by Trigger before Start or after
+	// the scheduler's context is cancelled.
+	ErrSchedulerNotRunning = errors.New("job scheduler is not running")
+)
+
 // Job defines a periodic background job.
 type Job struct {
 	Name     string
 	Interval time.Duration
 	Timeout  time.Duration
-	Fn       func(ctx context.Context) error
+	// Jitter delays every run by a random duration below it, so replicas
+	// and jobs sharing an interval do not all start at once.
+	Jitter time.Duration
+	// PerReplica jobs run on every replica (e.g. warming an in-process
+	// cache) and skip the cross-replica lease. Overlapping runs on the same
+	// replica are still prevented.
+	PerReplica bool
+	Fn         func(ctx context.Context) error
+}
+
+// StateStore persists job state and run leases shared by all replicas.
+// *alt_db.AltDBRepository implements it.
+type StateStore interface {
+	AcquireBackgroundJobLease(ctx context.Context, name, owner string, now, expiresAt time.Time) (bool, error)
+	FinishBackgroundJobRun(ctx context.Context, name, owner string, startedAt, finishedAt time.Time, runErr *string) error
+	ListBackgroundJobStates(ctx context.Context) ([]domain.BackgroundJobState, error)
+}
+
+// JobStatus is a registered job with its schedule and last known state.
+type JobStatus struct {
+	domain.BackgroundJobState
+	Interval string `json:"interval"`
+	Timeout  string `json:"timeout"`
+	Jitter   string `json:"jitter,omitempty"`
+	// Running is true while the job runs on this or any other replica.
+	Running    bool `json:"running"`
+	PerReplica bool `json:"per_replica,omitempty"`
+}
+
+// SchedulerOption configures a JobScheduler.
+type SchedulerOption func(*JobScheduler)
+
+// WithStateStore persists job state in store and uses its leases to keep a
+// job from running on two replicas at once.
+func WithStateStore(store StateStore) SchedulerOption {
+	return func(s *JobScheduler) {
+		s.store = store
+	}
 }
 
 // JobScheduler manages periodic background jobs with context-aware shutdown.
 type JobScheduler struct {
-	jobs []Job
-	wg   sync.WaitGroup
+	jobs  []Job
+	store StateStore
+	// owner identifies this replica on the leases it takes.
+	owner string
+	clock func() time.Time
+	wg    sync.WaitGroup
+
+	mu sync.Mutex
+	// ctx is the context passed to Start; manual runs use it too.
+	ctx     context.Context
+	running map[string]bool
+	// states holds this replica's view of each job, used without a store.
+	states map[string]*domain.BackgroundJobState
 }
 
 // NewJobScheduler creates a new scheduler.
-func NewJobScheduler() *JobScheduler {
-	return &JobScheduler{}
+func NewJobScheduler(opts ...SchedulerOption) *JobScheduler {
+	s := &JobScheduler{
+		owner:   schedulerOwner(),
+		clock:   time.Now,
+		running: make(map[string]bool),
+		states:  make(map[string]*domain.BackgroundJobState),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func schedulerOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s/%d", host, os.Getpid())
 }
 
 // Add registers a job to be run when Start is called.
@@ -31,10 +121,14 @@ func (s *JobScheduler) Add(j Job) {
 	s.jobs = append(s.jobs, j)
 }
 
-// Start launches all registered jobs as goroutines. Each job runs immediately
-// on start, then repeats at its configured interval. All jobs stop when ctx
-// is cancelled.
+// Start launches all registered jobs as goroutines. Each job runs on start
+// (after its jitter), then repeats at its configured interval. All jobs stop
+// when ctx is cancelled.
 func (s *JobScheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
 	for _, j := range s.jobs {
 		s.wg.Add(1)
 		go s.runJob(ctx, j)
@@ -45,6 +139,9 @@ func (s *JobScheduler) runJob(ctx context.Context, j Job) {
 	defer s.wg.Done()
 
 	// Run immediately on start
+	if !s.waitJitter(ctx, j) {
+		return
+	}
 	s.executeJob(ctx, j)
 
 	ticker := time.NewTicker(j.Interval)
@@ -56,23 +153,206 @@ func (s *JobScheduler) runJob(ctx context.Context, j Job) {
 			slog.InfoContext(ctx, "job stopping", "job", j.Name)
 			return
 		case <-ticker.C:
+			if !s.waitJitter(ctx, j) {
+				return
+			}
 			s.executeJob(ctx, j)
 		}
 	}
 }
 
+// waitJitter sleeps for a random part of the job's jitter. It returns false
+// when ctx is cancelled first.
+func (s *JobScheduler) waitJitter(ctx context.Context, j Job) bool {
+	if j.Jitter <= 0 {
+		return true
+	}
+	timer := time.NewTimer(rand.N(j.Jitter))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 func (s *JobScheduler) executeJob(ctx context.Context, j Job) {
 	// Check if parent context is already cancelled
 	if ctx.Err() != nil {
 		return
 	}
 
+	if err := s.begin(ctx, j); err != nil {
+		if errors.Is(err, ErrJobRunning) {
+			slog.InfoContext(ctx, "job skipped: already running", "job", j.Name)
+		} else {
+			slog.ErrorContext(ctx, "job skipped: lease not acquired", "job", j.Name, "error", err)
+		}
+		return
+	}
+	s.run(ctx, j)
+}
+
+// begin claims the job for one run: on this replica, and through the store's
+// lease on all of them. Every successful begin must be followed by run.
+func (s *JobScheduler) begin(ctx context.Context, j Job) error {
+	s.mu.Lock()
+	if s.running[j.Name] {
+		s.mu.Unlock()
+		return ErrJobRunning
+	}
+	s.running[j.Name] = true
+	s.mu.Unlock()
+
+	if s.store == nil || j.PerReplica {
+		return nil
+	}
+
+	now := s.clock()
+	acquired, err := s.store.AcquireBackgroundJobLease(ctx, j.Name, s.owner, now, now.Add(j.Timeout+leaseGrace))
+	if err == nil && !acquired {
+		err = ErrJobRunning
+	}
+	if err != nil {
+		s.mu.Lock()
+		delete(s.running, j.Name)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *JobScheduler) run(ctx context.Context, j Job) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, j.Name)
+		s.mu.Unlock()
+	}()
+
 	jobCtx, cancel := context.WithTimeout(ctx, j.Timeout)
 	defer cancel()
 
-	if err := j.Fn(jobCtx); err != nil {
+	startedAt := s.clock()
+	err := j.Fn(jobCtx)
+	finishedAt := s.clock()
+
+	var errMsg *string
+	if err != nil {
 		slog.ErrorContext(ctx, "job failed", "job", j.Name, "error", err)
+		msg := err.Error()
+		errMsg = &msg
+	}
+	s.recordLocal(j.Name, startedAt, finishedAt, errMsg)
+
+	if s.store == nil || j.PerReplica {
+		return
+	}
+	recordCtx, cancelRecord := context.WithTimeout(context.WithoutCancel(ctx), finishRecordTimeout)
+	defer cancelRecord()
+	if err := s.store.FinishBackgroundJobRun(recordCtx, j.Name, s.owner, startedAt, finishedAt, errMsg); err != nil {
+		slog.ErrorContext(ctx, "failed to record job run", "job", j.Name, "error", err)
+	}
+}
+
+func (s *JobScheduler) recordLocal(name string, startedAt, finishedAt time.Time, errMsg *string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.states[name]
+	if !ok {
+		st = &domain.BackgroundJobState{Name: name}
+		s.states[name] = st
+	}
+	st.LastStartedAt = &startedAt
+	st.LastFinishedAt = &finishedAt
+	st.LastDurationMs = finishedAt.Sub(startedAt).Milliseconds()
+	st.LastError = errMsg
+	st.RunCount++
+	if errMsg != nil {
+		st.FailureCount++
+	} else {
+		st.LastSuccessAt = &finishedAt
+	}
+}
+
+// Trigger starts a run of the named job now, outside its schedule. The run
+// happens in the background; its outcome shows up in Statuses.
+func (s *JobScheduler) Trigger(name string) error {
+	j, ok := s.lookup(name)
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	s.mu.Lock()
+	ctx := s.ctx
+	s.mu.Unlock()
+	if ctx == nil || ctx.Err() != nil {
+		return ErrSchedulerNotRunning
+	}
+
+	if err := s.begin(ctx, j); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "job triggered manually", "job", j.Name)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx, j)
+	}()
+	return nil
+}
+
+func (s *JobScheduler) lookup(name string) (Job, bool) {
+	for _, j := range s.jobs {
+		if j.Name == name {
+			return j, true
+		}
+	}
+	return Job{}, false
+}
+
+// Statuses returns every registered job in registration order. With a
+// state store the state is the one shared by all replicas; per-replica
+// jobs and schedulers without a store report this replica's runs.
+func (s *JobScheduler) Statuses(ctx context.Context) ([]JobStatus, error) {
+	stored := make(map[string]domain.BackgroundJobState)
+	if s.store != nil {
+		states, err := s.store.ListBackgroundJobStates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, st := range states {
+			stored[st.Name] = st
+		}
+	}
+
+	now := s.clock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		status := JobStatus{
+			BackgroundJobState: domain.BackgroundJobState{Name: j.Name},
+			Interval:           j.Interval.String(),
+			Timeout:            j.Timeout.String(),
+			Running:            s.running[j.Name],
+			PerReplica:         j.PerReplica,
+		}
+		if j.Jitter > 0 {
+			status.Jitter = j.Jitter.String()
+		}
+		if st, ok := stored[j.Name]; ok && !j.PerReplica {
+			status.BackgroundJobState = st
+			status.Running = status.Running || st.LeaseActive(now)
+		} else if st, ok := s.states[j.Name]; ok {
+			status.BackgroundJobState = *st
+		}
+		statuses = append(statuses, status)
 	}
+	return statuses, nil
 }
 
 // Shutdown blocks until all running jobs complete.
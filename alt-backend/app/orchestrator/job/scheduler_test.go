@@ -1,7 +1,10 @@
 package job
 
 import (
+	"alt/domain"
 	"context"
+	"errors"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -160,3 +163,178 @@ func TestJobScheduler_MultipleJobs(t *testing.T) {
 		t.Errorf("expected both jobs to run, got A=%d B=%d", countA.Load(), countB.Load())
 	}
 }
+
+type fakeStateStore struct {
+	mu       sync.Mutex
+	leased   map[string]string
+	deny     bool
+	finished []string
+	errs     []*string
+}
+
+func newFakeStateStore() *fakeStateStore {
+	return &fakeStateStore{leased: make(map[string]string)}
+}
+
+func (f *fakeStateStore) AcquireBackgroundJobLease(_ context.Context, name, owner string, _, _ time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.deny || f.leased[name] != "" {
+		return false, nil
+	}
+	f.leased[name] = owner
+	return true, nil
+}
+
+func (f *fakeStateStore) FinishBackgroundJobRun(_ context.Context, name, owner string, _, _ time.Time, runErr *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.leased[name] == owner {
+		delete(f.leased, name)
+	}
+	f.finished = append(f.finished, name)
+	f.errs = append(f.errs, runErr)
+	return nil
+}
+
+func (f *fakeStateStore) ListBackgroundJobStates(context.Context) ([]domain.BackgroundJobState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	owner := "other-replica"
+	expires := time.Now().Add(time.Hour)
+	var states []domain.BackgroundJobState
+	for name := range f.leased {
+		states = append(states, domain.BackgroundJobState{Name: name, RunCount: 7, LeaseOwner: &owner, LeaseExpiresAt: &expires})
+	}
+	return states, nil
+}
+
+func TestJobScheduler_SkipsRunWhenLeaseHeldElsewhere(t *testing.T) {
+	var count atomic.Int32
+	store := newFakeStateStore()
+	store.deny = true
+
+	scheduler := NewJobScheduler(WithStateStore(store))
+	scheduler.Add(Job{
+		Name:     "leased-job",
+		Interval: time.Hour,
+		Timeout:  time.Second,
+		Fn: func(ctx context.Context) error {
+			count.Add(1)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	scheduler.Shutdown()
+
+	if got := count.Load(); got != 0 {
+		t.Errorf("expected no run while another replica holds the lease, ran %d times", got)
+	}
+}
+
+func TestJobScheduler_RecordsRunOutcome(t *testing.T) {
+	store := newFakeStateStore()
+	scheduler := NewJobScheduler(WithStateStore(store))
+	scheduler.Add(Job{
+		Name:     "failing-job",
+		Interval: time.Hour,
+		Timeout:  time.Second,
+		Fn: func(ctx context.Context) error {
+			return errors.New("upstream unavailable")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	scheduler.Shutdown()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.finished) != 1 || store.errs[0] == nil || *store.errs[0] != "upstream unavailable" {
+		t.Fatalf("expected one failed run recorded, got %v", store.finished)
+	}
+	if len(store.leased) != 0 {
+		t.Error("expected the lease to be released after the run")
+	}
+}
+
+func TestJobScheduler_TriggerPreventsOverlap(t *testing.T) {
+	release := make(chan struct{})
+	var count atomic.Int32
+
+	scheduler := NewJobScheduler()
+	scheduler.Add(Job{
+		Name:     "manual-job",
+		Interval: time.Hour,
+		Timeout:  time.Second,
+		Jitter:   time.Hour, // keep the scheduled run out of the way
+		Fn: func(ctx context.Context) error {
+			count.Add(1)
+			<-release
+			return nil
+		},
+	})
+
+	if err := scheduler.Trigger("manual-job"); !errors.Is(err, ErrSchedulerNotRunning) {
+		t.Fatalf("expected ErrSchedulerNotRunning before Start, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+
+	if err := scheduler.Trigger("manual-job"); err != nil {
+		t.Fatalf("trigger: %v", err)
+	}
+	if err := scheduler.Trigger("manual-job"); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("expected ErrJobRunning for an overlapping trigger, got %v", err)
+	}
+	if err := scheduler.Trigger("unknown"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+
+	statuses, err := scheduler.Statuses(ctx)
+	if err != nil {
+		t.Fatalf("statuses: %v", err)
+	}
+	if len(statuses) != 1 || !statuses[0].Running || statuses[0].Jitter != "1h0m0s" {
+		t.Errorf("unexpected statuses while running: %+v", statuses)
+	}
+
+	close(release)
+	cancel()
+	scheduler.Shutdown()
+
+	statuses, _ = scheduler.Statuses(context.Background())
+	if statuses[0].Running || statuses[0].RunCount != 1 || statuses[0].LastSuccessAt == nil {
+		t.Errorf("unexpected status after run: %+v", statuses[0])
+	}
+	if got := count.Load(); got != 1 {
+		t.Errorf("expected exactly one run, got %d", got)
+	}
+}
+
+func TestJobScheduler_StatusesMergeStoredState(t *testing.T) {
+	store := newFakeStateStore()
+	store.leased["shared-job"] = "other-replica"
+
+	scheduler := NewJobScheduler(WithStateStore(store))
+	scheduler.Add(Job{Name: "shared-job", Interval: time.Hour, Timeout: time.Minute, Fn: func(context.Context) error { return nil }})
+	scheduler.Add(Job{Name: "local-job", Interval: time.Minute, Timeout: time.Second, PerReplica: true, Fn: func(context.Context) error { return nil }})
+
+	statuses, err := scheduler.Statuses(context.Background())
+	if err != nil {
+		t.Fatalf("statuses: %v", err)
+	}
+	if statuses[0].Name != "shared-job" || !statuses[0].Running || statuses[0].RunCount != 7 {
+		t.Errorf("expected the stored lease to mark shared-job running, got %+v", statuses[0])
+	}
+	if statuses[1].Name != "local-job" || statuses[1].Running || !statuses[1].PerReplica {
+		t.Errorf("unexpected per-replica status: %+v", statuses[1])
+	}
+}
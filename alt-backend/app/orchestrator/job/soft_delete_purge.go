@@ -0,0 +1,37 @@
+package job
+
+import (
+	"alt/domain"
+	"alt/orchestrator/usecase/soft_delete_usecase"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// softDeletePurger abstracts the purge usecase (for testability).
+type softDeletePurger interface {
+	PurgeExpired(ctx context.Context) (soft_delete_usecase.PurgeResult, error)
+}
+
+// SoftDeletePurgeJob returns a JobScheduler function that permanently removes
+// feeds and articles soft-deleted longer ago than the restore window.
+// Accounts under legal hold are skipped.
+func SoftDeletePurgeJob(uc *soft_delete_usecase.Usecase) func(ctx context.Context) error {
+	return softDeletePurgeJobFn(uc)
+}
+
+func softDeletePurgeJobFn(p softDeletePurger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		result, err := p.PurgeExpired(ctx)
+		if err != nil {
+			return fmt.Errorf("purge soft-deleted rows: %w", err)
+		}
+
+		slog.InfoContext(ctx, "soft-delete purge completed",
+			"articles_purged", result.Articles,
+			"feeds_purged", result.Feeds,
+			"restore_window", domain.SoftDeleteRestoreWindow.String(),
+		)
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package job
+
+import (
+	"alt/orchestrator/usecase/soft_delete_usecase"
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockSoftDeletePurger struct {
+	result soft_delete_usecase.PurgeResult
+	err    error
+	calls  int
+}
+
+func (m *mockSoftDeletePurger) PurgeExpired(ctx context.Context) (soft_delete_usecase.PurgeResult, error) {
+	m.calls++
+	return m.result, m.err
+}
+
+func TestSoftDeletePurgeJob_RunsPurge(t *testing.T) {
+	p := &mockSoftDeletePurger{result: soft_delete_usecase.PurgeResult{Feeds: 1, Articles: 4}}
+
+	if err := softDeletePurgeJobFn(p)(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p.calls != 1 {
+		t.Errorf("expected 1 purge call, got %d", p.calls)
+	}
+}
+
+func TestSoftDeletePurgeJob_PropagatesError(t *testing.T) {
+	p := &mockSoftDeletePurger{err: errors.New("legal hold lookup failed")}
+
+	if err := softDeletePurgeJobFn(p)(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
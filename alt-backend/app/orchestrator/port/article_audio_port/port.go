@@ -0,0 +1,28 @@
+package article_audio_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleAudioPort stores article TTS audio and the state of its
+// generation.
+type ArticleAudioPort interface {
+	// FetchArticleAudio returns the audio for one of userID's articles. It
+	// returns domain.ErrArticleAudioNotFound when none has been requested.
+	FetchArticleAudio(ctx context.Context, articleID, userID uuid.UUID) (*domain.ArticleAudio, error)
+	// RequestArticleAudio starts a new generation attempt when there is no
+	// audio yet, the last attempt failed, or an in-flight attempt was last
+	// updated before staleBefore. Otherwise it leaves the row alone and
+	// returns it with started false. It returns domain.ErrItemNotFound when
+	// the article does not exist, is deleted, or is not owned by userID.
+	RequestArticleAudio(ctx context.Context, articleID, userID uuid.UUID, voice string, now, staleBefore time.Time) (audio *domain.ArticleAudio, started bool, err error)
+	// ReportArticleAudio applies a worker report to an in-flight attempt.
+	// It returns domain.ErrArticleAudioNotFound for unknown articles and
+	// domain.ErrArticleAudioConflict when the attempt has finished or been
+	// superseded.
+	ReportArticleAudio(ctx context.Context, report *domain.ArticleAudioReport, now time.Time) (*domain.ArticleAudio, error)
+}
@@ -0,0 +1,30 @@
+package article_index_outbox_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleIndexOutboxPort persists the queue of articles whose search and
+// RAG index entries are out of date.
+type ArticleIndexOutboxPort interface {
+	// MarkArticleIndexDirty queues a live article for reindexing, or moves
+	// an already queued one forward. It returns domain.ErrArticleNotFound
+	// for unknown or deleted articles.
+	MarkArticleIndexDirty(ctx context.Context, articleID uuid.UUID, reason domain.ArticleIndexReason, now time.Time) (*domain.DirtyArticle, error)
+	// ListDirtyArticles returns up to limit queued articles due at now,
+	// oldest mark first.
+	ListDirtyArticles(ctx context.Context, now time.Time, limit int) ([]*domain.DirtyArticle, error)
+	// FetchArticleIndexDocument loads a live article's indexed fields, or
+	// nil when it was deleted or purged.
+	FetchArticleIndexDocument(ctx context.Context, articleID uuid.UUID) (*domain.ArticleIndexDocument, error)
+	// ClearDirtyArticle dequeues an article unless it was marked again
+	// after markedAt.
+	ClearDirtyArticle(ctx context.Context, articleID uuid.UUID, markedAt time.Time) error
+	// RescheduleDirtyArticle counts a failed publish and keeps the article
+	// queued until retryAt, unless it was marked again after markedAt.
+	RescheduleDirtyArticle(ctx context.Context, articleID uuid.UUID, markedAt time.Time, errorMessage string, retryAt time.Time) error
+}
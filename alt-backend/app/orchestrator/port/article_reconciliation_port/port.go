@@ -0,0 +1,36 @@
+package article_reconciliation_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleSourceRecordPort stores what each ingest source saw and the
+// reconciliation state of every record.
+type ArticleSourceRecordPort interface {
+	// UpsertArticleSourceRecords inserts records keyed by (source,
+	// source_item_id). A record whose URL or GUID changed goes back to
+	// pending; otherwise its reconciliation state is kept.
+	UpsertArticleSourceRecords(ctx context.Context, records []*domain.ArticleSourceRecord, now time.Time) (int64, error)
+	// ListUnreconciledSourceRecords returns pending and orphaned records,
+	// oldest first.
+	ListUnreconciledSourceRecords(ctx context.Context, limit int) ([]*domain.ArticleSourceRecord, error)
+	// ApplyReconciliationOutcome links duplicates, merges metadata into the
+	// canonical article and updates the record in one transaction.
+	ApplyReconciliationOutcome(ctx context.Context, outcome *domain.ReconciliationOutcome, now time.Time) error
+	// FetchReconciliationReport returns per-source status counts and up to
+	// limit records in any of statuses, newest first.
+	FetchReconciliationReport(ctx context.Context, statuses []domain.ArticleSourceStatus, limit int) (*domain.ReconciliationReport, error)
+}
+
+// ReconciliationCandidatePort finds the articles a source record may refer to.
+type ReconciliationCandidatePort interface {
+	// FindReconciliationCandidates returns userID's live articles whose URL
+	// is one of urls, or that another source record with the same non-empty
+	// guid is already matched to. Duplicates resolve to their canonical
+	// article. Results are ordered oldest first.
+	FindReconciliationCandidates(ctx context.Context, userID uuid.UUID, urls []string, guid string) ([]*domain.ReconciliationCandidate, error)
+}
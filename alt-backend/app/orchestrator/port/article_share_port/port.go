@@ -0,0 +1,35 @@
+package article_share_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleShareLinkPort manages a user's share links. Every operation except
+// RecordShareLinkView is scoped to links owned by userID.
+type ArticleShareLinkPort interface {
+	// CreateArticleShareLink stores link. It returns domain.ErrItemNotFound
+	// when the article does not exist, is deleted, or is not owned by
+	// link.UserID.
+	CreateArticleShareLink(ctx context.Context, link *domain.ArticleShareLink) error
+	ListArticleShareLinks(ctx context.Context, articleID, userID uuid.UUID) ([]*domain.ArticleShareLink, error)
+	// RevokeArticleShareLink returns domain.ErrShareLinkNotFound when the
+	// link does not exist or is not owned by userID. Revoking an already
+	// revoked link is a no-op.
+	RevokeArticleShareLink(ctx context.Context, shareID, articleID, userID uuid.UUID, now time.Time) error
+}
+
+// SharedArticlePort serves the public side of share links.
+type SharedArticlePort interface {
+	// RecordShareLinkView counts one view of an active link and returns it.
+	// It returns domain.ErrShareLinkNotFound for unknown links and
+	// domain.ErrShareLinkGone for expired or revoked ones.
+	RecordShareLinkView(ctx context.Context, shareID uuid.UUID, now time.Time) (*domain.ArticleShareLink, error)
+	// FetchSharedArticle loads the article behind a link, with its owner's
+	// summary when there is one. It returns domain.ErrShareLinkGone when the
+	// article has been deleted.
+	FetchSharedArticle(ctx context.Context, articleID, ownerID uuid.UUID) (*domain.SharedArticle, error)
+}
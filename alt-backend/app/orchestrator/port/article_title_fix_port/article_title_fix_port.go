@@ -0,0 +1,37 @@
+package article_title_fix_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArticleTitleFixPort persists article title repair jobs, their progress
+// and per-article results.
+type ArticleTitleFixPort interface {
+	// CreateArticleTitleFixJob inserts a pending job counting the articles
+	// whose title is a URL. It returns domain.ErrArticleTitleFixJobActive
+	// when another job is pending or running.
+	CreateArticleTitleFixJob(ctx context.Context, requestedBy *uuid.UUID, now time.Time) (*domain.ArticleTitleFixJob, error)
+	// GetActiveArticleTitleFixJob returns the pending or running job, or
+	// nil when there is none.
+	GetActiveArticleTitleFixJob(ctx context.Context) (*domain.ArticleTitleFixJob, error)
+	// GetArticleTitleFixJob returns the job or domain.ErrArticleTitleFixJobNotFound.
+	GetArticleTitleFixJob(ctx context.Context, jobID uuid.UUID) (*domain.ArticleTitleFixJob, error)
+	// ListArticleTitleFixJobs returns up to limit jobs, newest first.
+	ListArticleTitleFixJobs(ctx context.Context, limit int) ([]*domain.ArticleTitleFixJob, error)
+	// UpdateArticleTitleFixJob saves the job's status and timestamps.
+	UpdateArticleTitleFixJob(ctx context.Context, job *domain.ArticleTitleFixJob) error
+
+	// ListArticleTitleFixCandidates returns up to limit articles whose title
+	// is a URL, in id order, starting after afterID (from the start when nil).
+	ListArticleTitleFixCandidates(ctx context.Context, afterID *uuid.UUID, limit int) ([]*domain.ArticleTitleFixCandidate, error)
+	// RecordArticleTitleFixResult applies an updated title, stores the
+	// result and saves the job's cursor and counters in one transaction.
+	RecordArticleTitleFixResult(ctx context.Context, job *domain.ArticleTitleFixJob, result *domain.ArticleTitleFixResult) error
+	// ListArticleTitleFixResults returns up to limit results of the job
+	// with the given outcome, in processing order.
+	ListArticleTitleFixResults(ctx context.Context, jobID uuid.UUID, outcome domain.ArticleTitleFixOutcome, limit int) ([]*domain.ArticleTitleFixResult, error)
+}
@@ -1,8 +1,42 @@
 package feature_flag_port
 
-import "github.com/google/uuid"
+import (
+	"alt/domain"
+	"context"
+
+	"github.com/google/uuid"
+)
 
 // FeatureFlagPort checks whether a feature flag is enabled for a given user.
 type FeatureFlagPort interface {
 	IsEnabled(flagName string, userID uuid.UUID) bool
 }
+
+// FeatureFlagStorePort manages stored flags and per-user overrides for one
+// environment at a time.
+type FeatureFlagStorePort interface {
+	ListFeatureFlags(ctx context.Context, environment string) ([]domain.FeatureFlag, error)
+	// FetchFeatureFlag returns nil, nil when the flag is not stored.
+	FetchFeatureFlag(ctx context.Context, environment, name string) (*domain.FeatureFlag, error)
+	// UpsertFeatureFlag sets flag.UpdatedAt.
+	UpsertFeatureFlag(ctx context.Context, flag *domain.FeatureFlag) error
+	// DeleteFeatureFlag also removes the flag's overrides and reports
+	// whether the flag existed.
+	DeleteFeatureFlag(ctx context.Context, environment, name string) (bool, error)
+	ListFeatureFlagOverrides(ctx context.Context, environment string) ([]domain.FeatureFlagOverride, error)
+	// UpsertFeatureFlagOverride sets o.CreatedAt. The flag must be stored.
+	UpsertFeatureFlagOverride(ctx context.Context, o *domain.FeatureFlagOverride) error
+	// DeleteFeatureFlagOverride reports whether the override existed.
+	DeleteFeatureFlagOverride(ctx context.Context, environment, name string, userID uuid.UUID) (bool, error)
+}
+
+// FeatureFlagEvaluatorPort evaluates flags with the rule that decided them,
+// for callers that report flag state rather than branch on it.
+type FeatureFlagEvaluatorPort interface {
+	FeatureFlagPort
+	Evaluate(ctx context.Context, flagName string, userID uuid.UUID) domain.FeatureFlagEvaluation
+	// FlagNames lists every flag the evaluator knows about.
+	FlagNames(ctx context.Context) []string
+	// Invalidate drops cached flag state so the next evaluation reloads it.
+	Invalidate()
+}
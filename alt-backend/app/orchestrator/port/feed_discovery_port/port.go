@@ -0,0 +1,19 @@
+package feed_discovery_port
+
+import (
+	"alt/domain"
+	"context"
+)
+
+// FetchDiscoveryPagePort fetches the web page a user submitted for feed
+// discovery.
+type FetchDiscoveryPagePort interface {
+	FetchDiscoveryPage(ctx context.Context, pageURL string) (*domain.DiscoveryPage, error)
+}
+
+// ProbeFeedPort fetches and parses a candidate feed URL. It returns an error
+// when the URL does not serve a parseable RSS, Atom, or JSON feed. The
+// returned feed's Source is left for the caller to set.
+type ProbeFeedPort interface {
+	ProbeFeed(ctx context.Context, feedURL string) (*domain.DiscoveredFeed, error)
+}
@@ -0,0 +1,29 @@
+package home_ranking_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HomeRankingExperimentPort manages per-user ranking weight assignments.
+type HomeRankingExperimentPort interface {
+	// FetchHomeRankingExperiment returns nil, nil when userID has no
+	// assignment.
+	FetchHomeRankingExperiment(ctx context.Context, userID uuid.UUID) (*domain.HomeRankingExperiment, error)
+	// UpsertHomeRankingExperiment replaces the user's assignment and sets
+	// exp.UpdatedAt.
+	UpsertHomeRankingExperiment(ctx context.Context, exp *domain.HomeRankingExperiment) error
+	// DeleteHomeRankingExperiment reports whether an assignment existed.
+	DeleteHomeRankingExperiment(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// HomeRankingSignalPort loads the data the ranking signals are derived from.
+type HomeRankingSignalPort interface {
+	// FetchHomeRankingInputs loads userID's reads and read tags since
+	// historySince, the tags of the candidate feeds, and the tags of items
+	// added to the user's subscriptions since trendingSince.
+	FetchHomeRankingInputs(ctx context.Context, userID uuid.UUID, feedIDs []uuid.UUID, historySince, trendingSince time.Time) (*domain.HomeRankingInputs, error)
+}
@@ -12,9 +12,11 @@ type CreateLensPort interface {
 	CreateLens(ctx context.Context, lens domain.KnowledgeLens) error
 }
 
-// CreateLensVersionPort creates a new lens version.
+// CreateLensVersionPort creates a new lens version and returns the lens's new
+// revision. A non-zero expectedRevision makes the write fail with
+// domain.ErrLensRevisionConflict unless it is the lens's current revision.
 type CreateLensVersionPort interface {
-	CreateLensVersion(ctx context.Context, version domain.KnowledgeLensVersion) error
+	CreateLensVersion(ctx context.Context, version domain.KnowledgeLensVersion, expectedRevision int64) (int64, error)
 }
 
 // ListLensesPort lists active (non-archived) lenses for a user.
@@ -47,9 +49,10 @@ type ClearCurrentLensPort interface {
 	ClearCurrentLens(ctx context.Context, userID uuid.UUID) error
 }
 
-// ArchiveLensPort archives a lens (soft delete).
+// ArchiveLensPort archives a lens (soft delete). expectedRevision is checked
+// like in CreateLensVersionPort.
 type ArchiveLensPort interface {
-	ArchiveLens(ctx context.Context, lensID uuid.UUID) error
+	ArchiveLens(ctx context.Context, lensID uuid.UUID, expectedRevision int64) error
 }
 
 // ResolveKnowledgeHomeLensPort resolves the effective lens filter for the home read path.
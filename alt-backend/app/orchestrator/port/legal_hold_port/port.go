@@ -0,0 +1,50 @@
+package legal_hold_port
+
+import (
+	"alt/domain"
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// PlaceLegalHoldPort persists a new active legal hold. Implementations
+// return domain.ErrLegalHoldAlreadyActive when the account is already held.
+type PlaceLegalHoldPort interface {
+	PlaceLegalHold(ctx context.Context, hold *domain.LegalHold) error
+}
+
+// ReleaseLegalHoldPort releases the active hold on an account and returns it.
+// Implementations return domain.ErrLegalHoldNotFound when none is active.
+type ReleaseLegalHoldPort interface {
+	ReleaseLegalHold(ctx context.Context, userID, releasedBy uuid.UUID) (*domain.LegalHold, error)
+}
+
+// GetActiveLegalHoldPort returns the active hold for an account, or nil.
+type GetActiveLegalHoldPort interface {
+	GetActiveLegalHold(ctx context.Context, userID uuid.UUID) (*domain.LegalHold, error)
+}
+
+// ListActiveLegalHoldsPort returns every active hold.
+type ListActiveLegalHoldsPort interface {
+	ListActiveLegalHolds(ctx context.Context) ([]*domain.LegalHold, error)
+}
+
+// RecordComplianceAuditPort appends to the compliance audit log.
+type RecordComplianceAuditPort interface {
+	RecordComplianceAudit(ctx context.Context, entry *domain.ComplianceAuditEntry) error
+}
+
+// ExportAccountSectionPort streams one section of an account's data to w as
+// JSON Lines and returns the number of rows written.
+type ExportAccountSectionPort interface {
+	ExportAccountSection(ctx context.Context, userID uuid.UUID, section string, w io.Writer) (int64, error)
+}
+
+// LegalHoldPort combines the legal hold registry operations.
+type LegalHoldPort interface {
+	PlaceLegalHoldPort
+	ReleaseLegalHoldPort
+	GetActiveLegalHoldPort
+	ListActiveLegalHoldsPort
+}
@@ -0,0 +1,69 @@
+package organization_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationPort stores organizations and their members.
+type OrganizationPort interface {
+	// CreateOrganization stores org with owner as its first owner. It
+	// returns domain.ErrOrgSlugTaken when the tenant already uses the slug.
+	CreateOrganization(ctx context.Context, org *domain.Organization, owner uuid.UUID) error
+	// ListUserOrganizations returns the organizations userID belongs to,
+	// each with userID's role.
+	ListUserOrganizations(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error)
+	// FetchOrgMembership returns domain.ErrOrgMemberNotFound when userID is
+	// not a member of orgID.
+	FetchOrgMembership(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error)
+	ListOrgMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error)
+	// UpdateOrgMemberRole and RemoveOrgMember return
+	// domain.ErrOrgMemberNotFound for non-members and
+	// domain.ErrOrgLastOwner when the organization would be left without
+	// an owner.
+	UpdateOrgMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.OrgRole) error
+	RemoveOrgMember(ctx context.Context, orgID, userID uuid.UUID) error
+}
+
+// TeamPort stores an organization's teams and their members.
+type TeamPort interface {
+	// CreateTeam returns domain.ErrTeamNotFound when team.ParentID is not a
+	// team of the same organization and domain.ErrTeamNameTaken when the
+	// name is in use.
+	CreateTeam(ctx context.Context, team *domain.Team) error
+	ListTeams(ctx context.Context, orgID uuid.UUID) ([]*domain.Team, error)
+	// ListTeamMembers returns domain.ErrTeamNotFound for unknown teams.
+	ListTeamMembers(ctx context.Context, orgID, teamID uuid.UUID) ([]*domain.TeamMember, error)
+	// AddTeamMember returns domain.ErrTeamNotFound for unknown teams and
+	// domain.ErrOrgMemberNotFound when userID is not an organization
+	// member. Adding an existing member is a no-op.
+	AddTeamMember(ctx context.Context, orgID, teamID, userID uuid.UUID, now time.Time) error
+	// RemoveTeamMember returns domain.ErrOrgMemberNotFound when userID is
+	// not in the team.
+	RemoveTeamMember(ctx context.Context, orgID, teamID, userID uuid.UUID) error
+	// ListUserTeamScope returns the teams userID belongs to in orgID,
+	// directly or through any ancestor team.
+	ListUserTeamScope(ctx context.Context, orgID, userID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// OrgInvitationPort stores organization invitations.
+type OrgInvitationPort interface {
+	CreateOrgInvitation(ctx context.Context, inv *domain.OrgInvitation) error
+	// ListOrgInvitations returns every invitation of orgID, newest first.
+	ListOrgInvitations(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgInvitation, error)
+	// RevokeOrgInvitation returns domain.ErrOrgInvitationNotFound for
+	// unknown invitations and domain.ErrOrgInvitationGone for ones that
+	// are no longer pending.
+	RevokeOrgInvitation(ctx context.Context, orgID, invitationID uuid.UUID, now time.Time) error
+	// FetchOrgInvitationByTokenHash returns domain.ErrOrgInvitationNotFound
+	// when no invitation has the hash.
+	FetchOrgInvitationByTokenHash(ctx context.Context, tokenHash []byte) (*domain.OrgInvitation, error)
+	// AcceptOrgInvitation marks a pending invitation accepted by userID and
+	// adds them to the organization with its role, in one transaction. An
+	// existing membership keeps its role. It returns
+	// domain.ErrOrgInvitationGone when the invitation is no longer pending.
+	AcceptOrgInvitation(ctx context.Context, invitationID, userID uuid.UUID, now time.Time) (*domain.OrgMember, error)
+}
@@ -0,0 +1,24 @@
+package reading_stats_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecomputeReadingStatsPort rebuilds the per-user reading aggregates.
+type RecomputeReadingStatsPort interface {
+	// RecomputeReadingStats replaces every user's aggregate rows for the
+	// local day (in loc) containing day and returns how many users read
+	// something that day. Re-running it for the same day is safe.
+	RecomputeReadingStats(ctx context.Context, day time.Time, loc *time.Location, now time.Time) (int64, error)
+}
+
+// FetchReadingStatsPort reads the aggregates for one user. Date ranges are
+// inclusive local dates.
+type FetchReadingStatsPort interface {
+	FetchReadingStatsAggregate(ctx context.Context, userID uuid.UUID, from, to time.Time, topN int) (*domain.ReadingStatsAggregate, error)
+	CountArticlesRead(ctx context.Context, userID uuid.UUID, from, to time.Time) (int, error)
+}
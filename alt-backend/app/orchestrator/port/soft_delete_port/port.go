@@ -0,0 +1,37 @@
+package soft_delete_port
+
+import (
+	"alt/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedSoftDeletePort soft-deletes, restores and purges feeds. Every
+// delete, restore and purge is recorded in the soft-delete audit trail by
+// the implementation, atomically with the change itself.
+type FeedSoftDeletePort interface {
+	// SoftDeleteFeed returns domain.ErrItemNotFound when the feed does not
+	// exist or is already deleted.
+	SoftDeleteFeed(ctx context.Context, feedID, actorID uuid.UUID, now time.Time) error
+	// RestoreFeed returns domain.ErrDeletedItemNotFound when the feed is not
+	// deleted and domain.ErrRestoreWindowExpired when it was deleted before
+	// notBefore.
+	RestoreFeed(ctx context.Context, feedID, actorID uuid.UUID, notBefore, now time.Time) error
+	ListDeletedFeeds(ctx context.Context, notBefore time.Time, limit int) ([]*domain.DeletedItem, error)
+	PurgeExpiredFeeds(ctx context.Context, cutoff time.Time, heldUserIDs []uuid.UUID, limit int) (int64, error)
+}
+
+// ArticleSoftDeletePort is the per-user equivalent of FeedSoftDeletePort:
+// every operation except ListArticleSoftDeleteEvents is scoped to articles
+// owned by userID.
+type ArticleSoftDeletePort interface {
+	SoftDeleteArticle(ctx context.Context, articleID, userID uuid.UUID, now time.Time) error
+	RestoreArticle(ctx context.Context, articleID, userID uuid.UUID, notBefore, now time.Time) error
+	ListUserDeletedArticles(ctx context.Context, userID uuid.UUID, notBefore time.Time, limit int) ([]*domain.DeletedItem, error)
+	PurgeExpiredArticles(ctx context.Context, cutoff time.Time, heldUserIDs []uuid.UUID, limit int) (int64, error)
+	// ListArticleSoftDeleteEvents returns article audit entries after the
+	// (since, afterID) position, oldest first, across all users.
+	ListArticleSoftDeleteEvents(ctx context.Context, since time.Time, afterID uuid.UUID, limit int) ([]*domain.SoftDeleteEvent, error)
+}
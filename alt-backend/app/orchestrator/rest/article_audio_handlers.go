@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/article_audio_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RequestArticleAudioRequest is the body of POST /v1/articles/:id/audio.
+// The body is optional; an empty voice selects the TTS default.
+type RequestArticleAudioRequest struct {
+	Voice string `json:"voice"`
+}
+
+// ReportArticleAudioRequest is the body of the TTS worker's
+// PUT /v1/internal/articles/:id/audio.
+type ReportArticleAudioRequest struct {
+	Attempt         int     `json:"attempt"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	StorageURL      string  `json:"storage_url"`
+	Error           string  `json:"error"`
+}
+
+// registerArticleAudioRoutes wires TTS audio requests and status polling
+// for the caller's own articles.
+func registerArticleAudioRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Article.ArticleAudioUsecase
+
+	articles := v1.Group("/articles", authMiddleware.RequireAuth())
+	articles.POST("/:id/audio", handleRequestArticleAudio(uc))
+	articles.GET("/:id/audio", handleGetArticleAudio(uc))
+}
+
+// handleRequestArticleAudio handles POST /v1/articles/:id/audio. It answers
+// 200 when the audio is already ready and 202 while it is being generated.
+func handleRequestArticleAudio(uc *article_audio_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+
+		var req RequestArticleAudioRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		audio, err := uc.RequestAudio(ctx, user.UserID, articleID, req.Voice)
+		switch {
+		case errors.Is(err, article_audio_usecase.ErrInvalidInput):
+			return HandleValidationError(c, err.Error(), "body", "")
+		case errors.Is(err, domain.ErrItemNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "article not found"})
+		case errors.Is(err, domain.ErrAudioGenerationUnavailable):
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "audio generation is not available"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to request article audio: %w", err), "request_article_audio")
+		}
+		if audio.Status == domain.ArticleAudioStatusReady {
+			return c.JSON(http.StatusOK, audio)
+		}
+		return c.JSON(http.StatusAccepted, audio)
+	}
+}
+
+// handleGetArticleAudio handles GET /v1/articles/:id/audio, which the
+// mobile player polls until status is ready or failed.
+func handleGetArticleAudio(uc *article_audio_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+
+		audio, err := uc.GetAudio(ctx, user.UserID, articleID)
+		switch {
+		case errors.Is(err, domain.ErrArticleAudioNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "article audio not found"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to get article audio: %w", err), "get_article_audio")
+		}
+		c.Response().Header().Set("Cache-Control", "no-store")
+		return c.JSON(http.StatusOK, audio)
+	}
+}
+
+// handleReportArticleAudio handles PUT /v1/internal/articles/:id/audio, the
+// TTS worker's progress report for the attempt it was sent. A report for a
+// finished or superseded attempt answers 409 and should be dropped.
+func handleReportArticleAudio(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid article ID"})
+		}
+
+		var req ReportArticleAudioRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+		}
+
+		audio, err := container.Article.ArticleAudioUsecase.ReportAudio(ctx, &domain.ArticleAudioReport{
+			ArticleID:       articleID,
+			Attempt:         req.Attempt,
+			Status:          domain.ArticleAudioStatus(req.Status),
+			DurationSeconds: req.DurationSeconds,
+			StorageURL:      req.StorageURL,
+			ErrorMessage:    req.Error,
+		})
+		switch {
+		case errors.Is(err, article_audio_usecase.ErrInvalidInput):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, domain.ErrArticleAudioNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Article audio not found"})
+		case errors.Is(err, domain.ErrArticleAudioConflict):
+			return c.JSON(http.StatusConflict, map[string]string{"error": "Attempt is no longer in progress"})
+		case err != nil:
+			logger.Logger.ErrorContext(ctx, "Failed to report article audio", "error", err, "article_id", articleID)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to report article audio",
+			})
+		}
+		return c.JSON(http.StatusOK, audio)
+	}
+}
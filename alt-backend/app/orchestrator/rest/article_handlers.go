@@ -23,8 +23,12 @@ import (
 func fetchArticleRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
 	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
 	articles := v1.Group("/articles", authMiddleware.RequireAuth())
-	articles.GET("/fetch/content", handleFetchArticle(container))
-	articles.GET("/fetch/cursor", handleFetchArticlesCursor(container))
+	etag := middleware_custom.ETagMiddleware(middleware_custom.ETagConfig{
+		Versions:         container.CacheVersions,
+		RevalidateWindow: cfg.Cache.ETagRevalidateWindow,
+	})
+	articles.GET("/fetch/content", handleFetchArticle(container), etag)
+	articles.GET("/fetch/cursor", handleFetchArticlesCursor(container), etag)
 	articles.GET("/by-tag", handleFetchArticlesByTag(container))
 	articles.GET("/:id/tags", handleFetchArticleTags(container))
 	articles.POST("/archive", handleArchiveArticle(container))
@@ -58,6 +62,7 @@ func handleArchiveArticle(container *di.ApplicationComponents) echo.HandlerFunc
 		if err := container.ArchiveArticleUsecase.Execute(c.Request().Context(), input); err != nil {
 			return HandleError(c, fmt.Errorf("archive article failed for %q: %w", articleURL.String(), err), "archive_article")
 		}
+		middleware_custom.InvalidateUserETags(c.Request().Context(), container.CacheVersions)
 
 		c.Response().Header().Set("Cache-Control", "no-cache")
 		return c.JSON(http.StatusOK, map[string]string{"message": "article archived"})
@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/article_index_outbox_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// registerArticleIndexRoutes wires the admin endpoint that queues an
+// article for reindexing in search-indexer and rag-orchestrator.
+func registerArticleIndexRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Article.ArticleIndexOutboxUsecase
+
+	admin := v1.Group("/admin/articles", authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+	admin.POST("/:id/reindex", handleReindexArticle(uc))
+}
+
+// handleReindexArticle handles POST /v1/admin/articles/:id/reindex
+// The article is published by the next article-index-outbox run, even when
+// it has not changed since it was last indexed.
+func handleReindexArticle(uc *article_index_outbox_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+
+		dirty, err := uc.MarkForReindex(c.Request().Context(), articleID)
+		switch {
+		case errors.Is(err, domain.ErrArticleNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "article not found"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to queue article for reindex: %w", err), "reindex_article")
+		}
+		return c.JSON(http.StatusAccepted, dirty)
+	}
+}
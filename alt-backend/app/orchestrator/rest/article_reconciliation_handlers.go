@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/article_reconciliation_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ArticleSourceRecordRequest is one item in POST /v1/internal/article-sources.
+type ArticleSourceRecordRequest struct {
+	Source       string     `json:"source"`
+	SourceItemID string     `json:"source_item_id"`
+	GUID         string     `json:"guid"`
+	UserID       string     `json:"user_id"`
+	URL          string     `json:"url"`
+	Title        string     `json:"title"`
+	Author       string     `json:"author"`
+	PublishedAt  *time.Time `json:"published_at"`
+}
+
+// RecordArticleSourcesRequest is the body of POST /v1/internal/article-sources.
+type RecordArticleSourcesRequest struct {
+	Records []ArticleSourceRecordRequest `json:"records"`
+}
+
+// registerArticleReconciliationRoutes wires the admin view of cross-source
+// article reconciliation: a report of orphaned and conflicting records and
+// a manual trigger for the hourly reconciliation job.
+func registerArticleReconciliationRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Article.ArticleReconciliationUsecase
+
+	admin := v1.Group("/admin/reconciliation", authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+	admin.GET("/articles", handleArticleReconciliationReport(uc))
+	admin.POST("/articles/run", handleRunArticleReconciliation(uc))
+}
+
+// handleArticleReconciliationReport handles GET /v1/admin/reconciliation/articles
+// Query params:
+//   - status: comma-separated statuses to list (default: orphaned,conflict)
+//   - limit: maximum records to list (default: 100, max: 500)
+func handleArticleReconciliationReport(uc *article_reconciliation_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var statuses []domain.ArticleSourceStatus
+		for _, s := range strings.Split(c.QueryParam("status"), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				statuses = append(statuses, domain.ArticleSourceStatus(s))
+			}
+		}
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+		report, err := uc.Report(c.Request().Context(), statuses, limit)
+		if err != nil {
+			if errors.Is(err, article_reconciliation_usecase.ErrInvalidInput) {
+				return HandleValidationError(c, err.Error(), "status", c.QueryParam("status"))
+			}
+			return HandleError(c, fmt.Errorf("failed to load reconciliation report: %w", err), "article_reconciliation_report")
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+}
+
+// handleRunArticleReconciliation handles POST /v1/admin/reconciliation/articles/run
+func handleRunArticleReconciliation(uc *article_reconciliation_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := uc.Reconcile(c.Request().Context())
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to reconcile articles: %w", err), "run_article_reconciliation")
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// handleRecordArticleSources handles POST /v1/internal/article-sources.
+// Ingest pipelines report the items they saw here so reconciliation can
+// match them to alt-backend's articles.
+func handleRecordArticleSources(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var req RecordArticleSourcesRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid request body",
+			})
+		}
+
+		records := make([]*domain.ArticleSourceRecord, 0, len(req.Records))
+		for i, r := range req.Records {
+			userID, err := uuid.Parse(r.UserID)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": fmt.Sprintf("Invalid user_id in record %d", i),
+				})
+			}
+			records = append(records, &domain.ArticleSourceRecord{
+				Source:       r.Source,
+				SourceItemID: r.SourceItemID,
+				GUID:         r.GUID,
+				UserID:       userID,
+				URL:          r.URL,
+				Title:        r.Title,
+				Author:       r.Author,
+				PublishedAt:  r.PublishedAt,
+			})
+		}
+
+		stored, err := container.Article.ArticleReconciliationUsecase.RecordSources(ctx, records)
+		if err != nil {
+			if errors.Is(err, article_reconciliation_usecase.ErrInvalidInput) {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			}
+			logger.Logger.ErrorContext(ctx, "Failed to record article sources", "error", err, "count", len(records))
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to record article sources",
+			})
+		}
+		return c.JSON(http.StatusOK, map[string]any{"stored": stored})
+	}
+}
@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/article_share_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateShareLinkRequest is the body of POST /v1/articles/:id/share. Both
+// fields are optional: the link lasts SHARE_LINK_DEFAULT_TTL and exposes
+// domain.DefaultShareFields unless told otherwise.
+type CreateShareLinkRequest struct {
+	ExpiresInHours int      `json:"expires_in_hours"`
+	Fields         []string `json:"fields"`
+}
+
+// registerArticleShareRoutes wires share link management for the caller's
+// own articles and the unauthenticated public view. The signed token is
+// the only credential on the public route. Nothing is registered when
+// SHARE_LINK_SECRET is unset.
+func registerArticleShareRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	if cfg.ShareLink.Secret == "" {
+		logger.Logger.Warn("article share links disabled: SHARE_LINK_SECRET is not set")
+		return
+	}
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Article.ArticleShareUsecase
+
+	articles := v1.Group("/articles", authMiddleware.RequireAuth())
+	articles.POST("/:id/share", handleCreateShareLink(uc))
+	articles.GET("/:id/share", handleListShareLinks(uc))
+	articles.DELETE("/:id/share/:share_id", handleRevokeShareLink(uc))
+
+	v1.GET("/public/shares/:token", handleViewSharedArticle(uc))
+}
+
+// handleCreateShareLink handles POST /v1/articles/:id/share
+func handleCreateShareLink(uc *article_share_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+
+		var req CreateShareLinkRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+		fields := make([]domain.ShareField, len(req.Fields))
+		for i, f := range req.Fields {
+			fields[i] = domain.ShareField(f)
+		}
+
+		link, err := uc.CreateShareLink(ctx, user.UserID, article_share_usecase.CreateInput{
+			ArticleID: articleID,
+			TTL:       time.Duration(req.ExpiresInHours) * time.Hour,
+			Fields:    fields,
+		})
+		switch {
+		case errors.Is(err, article_share_usecase.ErrInvalidInput):
+			return HandleValidationError(c, err.Error(), "body", "")
+		case errors.Is(err, domain.ErrItemNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "article not found"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to create share link: %w", err), "create_share_link")
+		}
+		return c.JSON(http.StatusCreated, link)
+	}
+}
+
+// handleListShareLinks handles GET /v1/articles/:id/share
+func handleListShareLinks(uc *article_share_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+
+		links, err := uc.ListShareLinks(ctx, user.UserID, articleID)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to list share links: %w", err), "list_share_links")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"items": links})
+	}
+}
+
+// handleRevokeShareLink handles DELETE /v1/articles/:id/share/:share_id
+func handleRevokeShareLink(uc *article_share_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+		shareID, err := uuid.Parse(c.Param("share_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid share ID", "share_id", c.Param("share_id"))
+		}
+
+		err = uc.RevokeShareLink(ctx, user.UserID, articleID, shareID)
+		switch {
+		case errors.Is(err, domain.ErrShareLinkNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "share link not found"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to revoke share link: %w", err), "revoke_share_link")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// handleViewSharedArticle handles GET /v1/public/shares/:token. It needs
+// no session; every view is counted, so responses are never cached.
+func handleViewSharedArticle(uc *article_share_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "no-store")
+		c.Response().Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+		view, err := uc.ViewSharedArticle(c.Request().Context(), c.Param("token"))
+		switch {
+		case errors.Is(err, domain.ErrShareLinkNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "share link not found"})
+		case errors.Is(err, domain.ErrShareLinkGone):
+			return c.JSON(http.StatusGone, map[string]string{"error": "share link has expired or been revoked"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to view shared article: %w", err), "view_shared_article")
+		}
+		return c.JSON(http.StatusOK, view)
+	}
+}
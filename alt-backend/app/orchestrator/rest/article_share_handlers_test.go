@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/orchestrator/usecase/article_share_usecase"
+	"alt/utils/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func shareRoutePaths(e *echo.Echo) map[string]bool {
+	paths := make(map[string]bool)
+	for _, r := range e.Routes() {
+		paths[r.Method+" "+r.Path] = true
+	}
+	return paths
+}
+
+func TestRegisterArticleShareRoutes(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("registered with a secret", func(t *testing.T) {
+		cfg := &config.Config{ShareLink: config.ShareLinkConfig{
+			Secret:     "test-secret",
+			BaseURL:    "https://example.com/share",
+			DefaultTTL: time.Hour,
+			MaxTTL:     24 * time.Hour,
+		}}
+		container := &di.ApplicationComponents{Article: &di.ArticleModule{
+			ArticleShareUsecase: article_share_usecase.NewUsecase(nil, nil, article_share_usecase.Config{
+				Secret:     []byte(cfg.ShareLink.Secret),
+				BaseURL:    cfg.ShareLink.BaseURL,
+				DefaultTTL: cfg.ShareLink.DefaultTTL,
+				MaxTTL:     cfg.ShareLink.MaxTTL,
+			}),
+		}}
+		e := echo.New()
+		registerArticleShareRoutes(e.Group("/v1"), container, cfg)
+
+		paths := shareRoutePaths(e)
+		assert.True(t, paths["POST /v1/articles/:id/share"])
+		assert.True(t, paths["GET /v1/articles/:id/share"])
+		assert.True(t, paths["DELETE /v1/articles/:id/share/:share_id"])
+		assert.True(t, paths["GET /v1/public/shares/:token"])
+
+		// A token that fails verification never reaches the store.
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/public/shares/not-a-token", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "share link not found")
+	})
+
+	t.Run("not registered without a secret", func(t *testing.T) {
+		e := echo.New()
+		registerArticleShareRoutes(e.Group("/v1"), &di.ApplicationComponents{Article: &di.ArticleModule{}}, &config.Config{})
+
+		assert.False(t, shareRoutePaths(e)["GET /v1/public/shares/:token"])
+	})
+}
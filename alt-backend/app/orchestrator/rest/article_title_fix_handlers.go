@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/article_title_fix_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// registerArticleTitleFixRoutes wires the admin endpoints of the article
+// title repair: starting a job, which the article-title-fix background job
+// then works through, and reading job progress and reports.
+func registerArticleTitleFixRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Article.ArticleTitleFixUsecase
+
+	admin := v1.Group("/admin/article-titles", authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+	admin.POST("/fix", handleStartArticleTitleFix(uc))
+	admin.GET("/fix/jobs", handleListArticleTitleFixJobs(uc))
+	admin.GET("/fix/jobs/:job_id", handleArticleTitleFixReport(uc))
+}
+
+// handleStartArticleTitleFix handles POST /v1/admin/article-titles/fix
+// The job is queued and picked up by the next background run; poll
+// GET /v1/admin/article-titles/fix/jobs/:job_id for progress.
+func handleStartArticleTitleFix(uc *article_title_fix_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+
+		job, err := uc.Start(ctx, &actor.UserID)
+		switch {
+		case errors.Is(err, domain.ErrArticleTitleFixJobActive):
+			return c.JSON(http.StatusConflict, map[string]string{"error": "an article title fix job is already active"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to start article title fix: %w", err), "start_article_title_fix")
+		}
+		return c.JSON(http.StatusAccepted, job)
+	}
+}
+
+// handleListArticleTitleFixJobs handles GET /v1/admin/article-titles/fix/jobs
+// Query params:
+//   - limit: maximum jobs to list (default: 20, max: 100)
+func handleListArticleTitleFixJobs(uc *article_title_fix_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+		jobs, err := uc.ListJobs(c.Request().Context(), limit)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to list article title fix jobs: %w", err), "list_article_title_fix_jobs")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"jobs": jobs})
+	}
+}
+
+// handleArticleTitleFixReport handles GET /v1/admin/article-titles/fix/jobs/:job_id
+// Query params:
+//   - limit: maximum results per outcome (default: 100, max: 1000)
+func handleArticleTitleFixReport(uc *article_title_fix_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		jobID, err := uuid.Parse(c.Param("job_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid job ID", "job_id", c.Param("job_id"))
+		}
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+		report, err := uc.Report(c.Request().Context(), jobID, limit)
+		switch {
+		case errors.Is(err, domain.ErrArticleTitleFixJobNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "article title fix job not found"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to load article title fix report: %w", err), "article_title_fix_report")
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"alt/config"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/job"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// backgroundJobScheduler is the part of *job.JobScheduler the admin
+// endpoints use.
+type backgroundJobScheduler interface {
+	Statuses(ctx context.Context) ([]job.JobStatus, error)
+	Trigger(name string) error
+}
+
+// RegisterBackgroundJobRoutes wires the admin view of the background job
+// scheduler: every registered job with its schedule and last run, and a
+// manual trigger. It is registered separately from RegisterRoutes because
+// the scheduler is created in main, next to the DI container.
+func RegisterBackgroundJobRoutes(e *echo.Echo, scheduler backgroundJobScheduler, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+
+	admin := e.Group("/v1/admin/jobs", middleware_custom.PathProbe, authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+	admin.GET("", handleListBackgroundJobs(scheduler))
+	admin.POST("/:name/run", handleTriggerBackgroundJob(scheduler))
+}
+
+// handleListBackgroundJobs handles GET /v1/admin/jobs
+func handleListBackgroundJobs(scheduler backgroundJobScheduler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		statuses, err := scheduler.Statuses(c.Request().Context())
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to list background jobs: %w", err), "list_background_jobs")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"jobs": statuses})
+	}
+}
+
+// handleTriggerBackgroundJob handles POST /v1/admin/jobs/:name/run
+// The run starts in the background; poll GET /v1/admin/jobs for its outcome.
+func handleTriggerBackgroundJob(scheduler backgroundJobScheduler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		name := c.Param("name")
+		err := scheduler.Trigger(name)
+		switch {
+		case errors.Is(err, job.ErrJobNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "unknown background job"})
+		case errors.Is(err, job.ErrJobRunning):
+			return c.JSON(http.StatusConflict, map[string]string{"error": "background job is already running"})
+		case errors.Is(err, job.ErrSchedulerNotRunning):
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "job scheduler is not running"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to trigger background job %s: %w", name, err), "trigger_background_job")
+		}
+		return c.JSON(http.StatusAccepted, map[string]string{"name": name, "status": "triggered"})
+	}
+}
@@ -0,0 +1,205 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/feature_flag_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// SetFeatureFlagRequest is the body of PUT /v1/admin/feature-flags/:name.
+type SetFeatureFlagRequest struct {
+	Enabled           bool   `json:"enabled"`
+	RolloutPercentage *int   `json:"rollout_percentage"`
+	Description       string `json:"description"`
+}
+
+// SetFeatureFlagOverrideRequest is the body of
+// PUT /v1/admin/feature-flags/:name/overrides/:user_id.
+type SetFeatureFlagOverrideRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// registerFeatureFlagRoutes wires flag evaluation for the signed-in user and
+// the admin endpoints that flip stored flags without a redeploy.
+func registerFeatureFlagRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.FeatureFlagUsecase
+
+	flags := v1.Group("/feature-flags", authMiddleware.RequireAuth())
+	flags.GET("", handleEvaluateFeatureFlags(uc))
+
+	admin := v1.Group("/admin/feature-flags", authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+	admin.GET("", handleListFeatureFlags(uc))
+	admin.PUT("/:name", handleSetFeatureFlag(uc))
+	admin.DELETE("/:name", handleDeleteFeatureFlag(uc))
+	admin.PUT("/:name/overrides/:user_id", handleSetFeatureFlagOverride(uc))
+	admin.DELETE("/:name/overrides/:user_id", handleClearFeatureFlagOverride(uc))
+}
+
+// handleEvaluateFeatureFlags handles GET /v1/feature-flags?names=a,b for the
+// signed-in user. Without names every known flag is evaluated.
+func handleEvaluateFeatureFlags(uc *feature_flag_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+
+		var names []string
+		for _, name := range strings.Split(c.QueryParam("names"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+
+		flags, err := uc.Evaluate(ctx, user.UserID, names)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to evaluate feature flags: %w", err), "evaluate_feature_flags")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"flags": flags})
+	}
+}
+
+// handleListFeatureFlags handles GET /v1/admin/feature-flags. Flags that
+// only exist as config toggles are not listed.
+func handleListFeatureFlags(uc *feature_flag_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		flags, err := uc.ListFlags(c.Request().Context())
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to list feature flags: %w", err), "list_feature_flags")
+		}
+		if flags == nil {
+			flags = []domain.FeatureFlag{}
+		}
+		return c.JSON(http.StatusOK, map[string]any{
+			"environment": uc.Environment(),
+			"flags":       flags,
+		})
+	}
+}
+
+// handleSetFeatureFlag handles PUT /v1/admin/feature-flags/:name.
+// rollout_percentage defaults to 100.
+func handleSetFeatureFlag(uc *feature_flag_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+
+		var req SetFeatureFlagRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+		in := feature_flag_usecase.SetFlagInput{
+			Enabled:           req.Enabled,
+			RolloutPercentage: 100,
+			Description:       req.Description,
+		}
+		if req.RolloutPercentage != nil {
+			in.RolloutPercentage = *req.RolloutPercentage
+		}
+
+		flag, err := uc.SetFlag(ctx, c.Param("name"), in, actor.UserID)
+		switch {
+		case errors.Is(err, feature_flag_usecase.ErrInvalidInput):
+			return HandleValidationError(c, err.Error(), "body", "")
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to set feature flag: %w", err), "set_feature_flag")
+		}
+		logger.Logger.InfoContext(ctx, "feature flag updated",
+			"flag", flag.Name, "environment", flag.Environment, "enabled", flag.Enabled,
+			"rollout_percentage", flag.RolloutPercentage, "actor_id", actor.UserID)
+		return c.JSON(http.StatusOK, flag)
+	}
+}
+
+// handleDeleteFeatureFlag handles DELETE /v1/admin/feature-flags/:name,
+// returning the flag to its config toggle.
+func handleDeleteFeatureFlag(uc *feature_flag_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+
+		err = uc.DeleteFlag(ctx, c.Param("name"))
+		switch {
+		case errors.Is(err, domain.ErrItemNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "feature flag not found"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to delete feature flag: %w", err), "delete_feature_flag")
+		}
+		logger.Logger.InfoContext(ctx, "feature flag deleted",
+			"flag", c.Param("name"), "environment", uc.Environment(), "actor_id", actor.UserID)
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// handleSetFeatureFlagOverride handles
+// PUT /v1/admin/feature-flags/:name/overrides/:user_id.
+func handleSetFeatureFlagOverride(uc *feature_flag_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		var req SetFeatureFlagOverrideRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		override, err := uc.SetOverride(ctx, c.Param("name"), userID, req.Enabled, actor.UserID)
+		switch {
+		case errors.Is(err, feature_flag_usecase.ErrInvalidInput):
+			return HandleValidationError(c, err.Error(), "user_id", c.Param("user_id"))
+		case errors.Is(err, domain.ErrItemNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "feature flag not found"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to set feature flag override: %w", err), "set_feature_flag_override")
+		}
+		logger.Logger.InfoContext(ctx, "feature flag override set",
+			"flag", override.FlagName, "environment", override.Environment, "user_id", userID,
+			"enabled", override.Enabled, "actor_id", actor.UserID)
+		return c.JSON(http.StatusOK, override)
+	}
+}
+
+// handleClearFeatureFlagOverride handles
+// DELETE /v1/admin/feature-flags/:name/overrides/:user_id.
+func handleClearFeatureFlagOverride(uc *feature_flag_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		err = uc.ClearOverride(c.Request().Context(), c.Param("name"), userID)
+		switch {
+		case errors.Is(err, domain.ErrItemNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "user has no override for this flag"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to clear feature flag override: %w", err), "clear_feature_flag_override")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -3,6 +3,8 @@ package rest
 import (
 	"alt/config"
 	"alt/di"
+	"alt/orchestrator/usecase/feature_flag_usecase"
+	"alt/orchestrator/usecase/timeline_stream_usecase"
 	"alt/utils/logger"
 	"bytes"
 	"context"
@@ -23,8 +25,18 @@ func TestCSPReportEndpoint(t *testing.T) {
 	// Setup Echo instance
 	e := echo.New()
 
-	// Create minimal container and config for testing
-	container := &di.ApplicationComponents{}
+	// Create a container with the modules route registration reads from;
+	// their usecases are only called by the handlers under test.
+	container := &di.ApplicationComponents{
+		Article:      &di.ArticleModule{},
+		Compliance:   &di.ComplianceModule{},
+		Recap:        &di.RecapModule{},
+		Feed:         &di.FeedModule{},
+		Organization: &di.OrganizationModule{},
+
+		FeatureFlagUsecase: feature_flag_usecase.NewUsecase(nil, nil, ""),
+		TimelineStream:     timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{}),
+	}
 	cfg := &config.Config{}
 
 	// Register routes
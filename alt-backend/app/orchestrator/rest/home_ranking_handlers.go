@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/home_ranking_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// SetHomeRankingExperimentRequest is the body of
+// PUT /v1/admin/home-ranking/experiments/:user_id.
+type SetHomeRankingExperimentRequest struct {
+	Experiment string                    `json:"experiment"`
+	Weights    domain.HomeRankingWeights `json:"weights"`
+}
+
+// registerHomeRankingRoutes wires the admin side of home feed ranking:
+// assigning users to ranking experiments. The ranked timeline itself lives
+// under /v1/feeds.
+func registerHomeRankingRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Feed.HomeRankingUsecase
+
+	admin := v1.Group("/admin/home-ranking", authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+	admin.GET("/experiments/:user_id", handleGetHomeRankingExperiment(uc))
+	admin.PUT("/experiments/:user_id", handleSetHomeRankingExperiment(uc))
+	admin.DELETE("/experiments/:user_id", handleClearHomeRankingExperiment(uc))
+}
+
+// handleGetHomeRankingExperiment handles GET /v1/admin/home-ranking/experiments/:user_id.
+// Users without an assignment report the default experiment.
+func handleGetHomeRankingExperiment(uc *home_ranking_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		exp, err := uc.GetExperiment(c.Request().Context(), userID)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to get home ranking experiment: %w", err), "get_home_ranking_experiment")
+		}
+		return c.JSON(http.StatusOK, exp)
+	}
+}
+
+// handleSetHomeRankingExperiment handles PUT /v1/admin/home-ranking/experiments/:user_id
+func handleSetHomeRankingExperiment(uc *home_ranking_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		var req SetHomeRankingExperimentRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		exp, err := uc.SetExperiment(ctx, userID, req.Experiment, req.Weights)
+		switch {
+		case errors.Is(err, home_ranking_usecase.ErrInvalidInput):
+			return HandleValidationError(c, err.Error(), "body", "")
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to set home ranking experiment: %w", err), "set_home_ranking_experiment")
+		}
+		logger.Logger.InfoContext(ctx, "home ranking experiment assigned",
+			"user_id", userID, "experiment", exp.Experiment)
+		return c.JSON(http.StatusOK, exp)
+	}
+}
+
+// handleClearHomeRankingExperiment handles DELETE /v1/admin/home-ranking/experiments/:user_id,
+// returning the user to the default weights.
+func handleClearHomeRankingExperiment(uc *home_ranking_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		err = uc.ClearExperiment(c.Request().Context(), userID)
+		switch {
+		case errors.Is(err, domain.ErrItemNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "user has no home ranking experiment"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to clear home ranking experiment: %w", err), "clear_home_ranking_experiment")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
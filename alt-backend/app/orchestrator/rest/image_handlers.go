@@ -67,10 +67,8 @@ func handleImageFetch(container *di.ApplicationComponents) echo.HandlerFunc {
 		c.Response().Header().Set("X-Image-Source", "alt-backend-proxy")   // For debugging
 
 		// COEP (Cross-Origin Embedder Policy) compliance headers
+		// CORS headers come from the API route group policy (security_middleware.go).
 		c.Response().Header().Set("Cross-Origin-Resource-Policy", "cross-origin") // Allow cross-origin usage
-		c.Response().Header().Set("Access-Control-Allow-Origin", "*")             // CORS support
-		c.Response().Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-		c.Response().Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		return c.Blob(http.StatusOK, result.ContentType, result.Data)
 	}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
@@ -40,6 +41,131 @@ func registerInternalRoutes(e *echo.Echo, container *di.ApplicationComponents) {
 
 	// GET /v1/internal/articles/recent - Fetch recent articles for rag-orchestrator
 	v1.GET("/articles/recent", handleFetchRecentArticles(container))
+
+	// GET /v1/internal/articles/deletions - Article delete/restore/purge
+	// events, so rag-orchestrator can drop deleted articles from its index.
+	v1.GET("/articles/deletions", handleListArticleDeletionEvents(container))
+
+	// Legal hold lookups for retention/deletion jobs in other services.
+	// Callers must skip any account listed here.
+	v1.GET("/legal-holds", handleListLegalHoldUserIDs(container))
+	v1.GET("/legal-holds/:user_id", handleCheckLegalHold(container))
+
+	// Ingest pipelines report the items they saw for cross-source
+	// article reconciliation.
+	v1.POST("/article-sources", handleRecordArticleSources(container))
+
+	// The TTS worker reports progress on AudioGenerationRequested events.
+	v1.PUT("/articles/:id/audio", handleReportArticleAudio(container))
+}
+
+// handleListArticleDeletionEvents pages through the article soft-delete
+// audit trail, oldest first.
+// Query params:
+//   - since: RFC3339 timestamp; only events at or after it are returned (default: the beginning)
+//   - after_id: ID of the last event seen at since, to resume within the same timestamp
+//   - limit: Maximum events to return (default: 500, max: 1000)
+func handleListArticleDeletionEvents(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		if container.Compliance == nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{
+				"error": "Soft delete is not available",
+			})
+		}
+
+		var since time.Time
+		if sinceStr := c.QueryParam("since"); sinceStr != "" {
+			parsed, err := time.Parse(time.RFC3339Nano, sinceStr)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "Invalid since parameter",
+				})
+			}
+			since = parsed
+		}
+
+		afterID := uuid.Nil
+		if afterIDStr := c.QueryParam("after_id"); afterIDStr != "" {
+			parsed, err := uuid.Parse(afterIDStr)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "Invalid after_id parameter",
+				})
+			}
+			afterID = parsed
+		}
+
+		limit := 0
+		if limitStr := c.QueryParam("limit"); limitStr != "" {
+			parsed, err := strconv.Atoi(limitStr)
+			if err != nil || parsed < 0 {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "Invalid limit parameter",
+				})
+			}
+			limit = parsed
+		}
+
+		events, err := container.Compliance.SoftDeleteUsecase.ListArticleEvents(ctx, since, afterID, limit)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "Failed to list article deletion events", "error", err)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to list article deletion events",
+			})
+		}
+		return c.JSON(http.StatusOK, map[string]any{
+			"events": events,
+			"count":  len(events),
+		})
+	}
+}
+
+// handleListLegalHoldUserIDs returns the user IDs of every account under an
+// active legal hold, so batch jobs can fetch the set once per run.
+func handleListLegalHoldUserIDs(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		holds, err := container.Compliance.LegalHoldUsecase.ListHolds(ctx)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "Failed to list legal holds", "error", err)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to list legal holds",
+			})
+		}
+
+		userIDs := make([]string, 0, len(holds))
+		for _, h := range holds {
+			userIDs = append(userIDs, h.UserID.String())
+		}
+		return c.JSON(http.StatusOK, map[string]any{"user_ids": userIDs})
+	}
+}
+
+// handleCheckLegalHold reports whether a single account is under legal hold.
+// Callers must treat a non-200 response as "on hold" and skip the account.
+func handleCheckLegalHold(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid user_id parameter",
+			})
+		}
+
+		onHold, err := container.Compliance.LegalHoldUsecase.IsOnHold(ctx, userID)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "Failed to check legal hold", "user_id", userID, "error", err)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to check legal hold",
+			})
+		}
+		return c.JSON(http.StatusOK, map[string]any{
+			"user_id": userID.String(),
+			"on_hold": onHold,
+		})
+	}
 }
 
 // handleFetchRecentArticles returns articles published within the specified time window
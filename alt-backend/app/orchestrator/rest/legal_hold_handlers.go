@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/legal_hold_usecase"
+	"alt/utils/logger"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// PlaceLegalHoldRequest is the body of PUT /v1/admin/accounts/:user_id/legal-hold.
+type PlaceLegalHoldRequest struct {
+	Reason string `json:"reason"`
+}
+
+// registerLegalHoldRoutes wires the admin-only legal hold and account export
+// endpoints. Access requires a valid JWT (RequireAuth) AND the admin role
+// (RequireAdmin); every mutation and export is written to the compliance
+// audit log by the usecase.
+func registerLegalHoldRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	admin := v1.Group("/admin", authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+
+	uc := container.Compliance.LegalHoldUsecase
+	admin.GET("/legal-holds", handleListLegalHolds(uc))
+	admin.GET("/accounts/:user_id/legal-hold", handleGetLegalHold(uc))
+	admin.PUT("/accounts/:user_id/legal-hold", handlePlaceLegalHold(uc))
+	admin.DELETE("/accounts/:user_id/legal-hold", handleReleaseLegalHold(uc))
+	admin.GET("/accounts/:user_id/export", handleExportAccount(uc))
+}
+
+// handleListLegalHolds handles GET /v1/admin/legal-holds
+func handleListLegalHolds(uc *legal_hold_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		holds, err := uc.ListHolds(c.Request().Context())
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to list legal holds: %w", err), "list_legal_holds")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"holds": holds})
+	}
+}
+
+// handleGetLegalHold handles GET /v1/admin/accounts/:user_id/legal-hold
+func handleGetLegalHold(uc *legal_hold_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		hold, err := uc.GetHold(c.Request().Context(), userID)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to get legal hold: %w", err), "get_legal_hold")
+		}
+		if hold == nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "no active legal hold"})
+		}
+		return c.JSON(http.StatusOK, hold)
+	}
+}
+
+// handlePlaceLegalHold handles PUT /v1/admin/accounts/:user_id/legal-hold
+func handlePlaceLegalHold(uc *legal_hold_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		var req PlaceLegalHoldRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		hold, err := uc.PlaceHold(ctx, actor.UserID, userID, req.Reason)
+		switch {
+		case errors.Is(err, legal_hold_usecase.ErrInvalidInput):
+			return HandleValidationError(c, err.Error(), "reason", "")
+		case errors.Is(err, domain.ErrLegalHoldAlreadyActive):
+			return c.JSON(http.StatusConflict, map[string]string{"error": "account already has an active legal hold"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to place legal hold: %w", err), "place_legal_hold")
+		}
+		return c.JSON(http.StatusCreated, hold)
+	}
+}
+
+// handleReleaseLegalHold handles DELETE /v1/admin/accounts/:user_id/legal-hold
+func handleReleaseLegalHold(uc *legal_hold_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		hold, err := uc.ReleaseHold(ctx, actor.UserID, userID)
+		switch {
+		case errors.Is(err, domain.ErrLegalHoldNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "no active legal hold"})
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to release legal hold: %w", err), "release_legal_hold")
+		}
+		return c.JSON(http.StatusOK, hold)
+	}
+}
+
+// handleExportAccount handles GET /v1/admin/accounts/:user_id/export.
+// The archive is built in memory so a failed section never yields a partial
+// download; X-Export-SHA256 carries the digest of the whole archive.
+func handleExportAccount(uc *legal_hold_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		var buf bytes.Buffer
+		manifest, err := uc.ExportAccount(ctx, actor.UserID, userID, &buf)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to export account: %w", err), "export_account")
+		}
+
+		digest := sha256.Sum256(buf.Bytes())
+		filename := fmt.Sprintf("account-%s-%s.zip", userID, manifest.GeneratedAt.Format("20060102T150405Z"))
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		c.Response().Header().Set("X-Export-SHA256", hex.EncodeToString(digest[:]))
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.Blob(http.StatusOK, "application/zip", buf.Bytes())
+	}
+}
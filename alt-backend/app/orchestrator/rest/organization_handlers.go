@@ -0,0 +1,393 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/organization_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateOrganizationRequest is the body of POST /v1/orgs.
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// UpdateOrgMemberRequest is the body of PATCH /v1/orgs/:org_id/members/:user_id.
+type UpdateOrgMemberRequest struct {
+	Role string `json:"role"`
+}
+
+// CreateTeamRequest is the body of POST /v1/orgs/:org_id/teams.
+type CreateTeamRequest struct {
+	Name     string  `json:"name"`
+	ParentID *string `json:"parent_id"`
+}
+
+// CreateOrgInvitationRequest is the body of POST /v1/orgs/:org_id/invitations.
+// An empty role invites a member.
+type CreateOrgInvitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// AcceptOrgInvitationRequest is the body of POST /v1/orgs/invitations/accept.
+type AcceptOrgInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// registerOrganizationRoutes wires organizations, teams and invitations.
+// Routes under /v1/orgs/:org_id run behind the organization middleware, so
+// handlers act on the caller's domain.OrgContext.
+func registerOrganizationRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Organization.OrganizationUsecase
+	orgMiddleware := middleware_custom.NewOrganizationMiddleware(uc, logger.Logger)
+
+	orgs := v1.Group("/orgs", authMiddleware.RequireAuth())
+	orgs.POST("", handleCreateOrganization(uc))
+	orgs.GET("", handleListOrganizations(uc))
+	orgs.POST("/invitations/accept", handleAcceptOrgInvitation(uc))
+
+	org := orgs.Group("/:org_id", orgMiddleware.ExtractOrg())
+	org.GET("/members", handleListOrgMembers(uc))
+	org.PATCH("/members/:user_id", handleUpdateOrgMember(uc))
+	org.DELETE("/members/:user_id", handleRemoveOrgMember(uc))
+	org.POST("/teams", handleCreateTeam(uc))
+	org.GET("/teams", handleListTeams(uc))
+	org.GET("/teams/:team_id/members", handleListTeamMembers(uc))
+	org.PUT("/teams/:team_id/members/:user_id", handleAddTeamMember(uc))
+	org.DELETE("/teams/:team_id/members/:user_id", handleRemoveTeamMember(uc))
+	org.POST("/invitations", handleCreateOrgInvitation(uc))
+	org.GET("/invitations", handleListOrgInvitations(uc))
+	org.DELETE("/invitations/:invitation_id", handleRevokeOrgInvitation(uc))
+}
+
+// handleCreateOrganization handles POST /v1/orgs. The caller becomes the
+// organization's owner.
+func handleCreateOrganization(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		var req CreateOrganizationRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		org, err := uc.CreateOrganization(ctx, user, req.Name, req.Slug)
+		if err != nil {
+			return handleOrganizationError(c, err, "create_organization")
+		}
+		return c.JSON(http.StatusCreated, org)
+	}
+}
+
+// handleListOrganizations handles GET /v1/orgs.
+func handleListOrganizations(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		orgs, err := uc.ListOrganizations(ctx, user.UserID)
+		if err != nil {
+			return handleOrganizationError(c, err, "list_organizations")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"organizations": orgs})
+	}
+}
+
+// handleListOrgMembers handles GET /v1/orgs/:org_id/members.
+func handleListOrgMembers(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		members, err := uc.ListMembers(ctx, oc)
+		if err != nil {
+			return handleOrganizationError(c, err, "list_org_members")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"members": members})
+	}
+}
+
+// handleUpdateOrgMember handles PATCH /v1/orgs/:org_id/members/:user_id.
+func handleUpdateOrgMember(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+		var req UpdateOrgMemberRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		if err := uc.UpdateMemberRole(ctx, oc, userID, domain.OrgRole(req.Role)); err != nil {
+			return handleOrganizationError(c, err, "update_org_member")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// handleRemoveOrgMember handles DELETE /v1/orgs/:org_id/members/:user_id.
+// Members may remove themselves to leave the organization.
+func handleRemoveOrgMember(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+
+		if err := uc.RemoveMember(ctx, oc, userID); err != nil {
+			return handleOrganizationError(c, err, "remove_org_member")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// handleCreateTeam handles POST /v1/orgs/:org_id/teams.
+func handleCreateTeam(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		var req CreateTeamRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+		var parentID *uuid.UUID
+		if req.ParentID != nil && *req.ParentID != "" {
+			id, err := uuid.Parse(*req.ParentID)
+			if err != nil {
+				return HandleValidationError(c, "Invalid parent team ID", "parent_id", *req.ParentID)
+			}
+			parentID = &id
+		}
+
+		team, err := uc.CreateTeam(ctx, oc, req.Name, parentID)
+		if err != nil {
+			return handleOrganizationError(c, err, "create_team")
+		}
+		return c.JSON(http.StatusCreated, team)
+	}
+}
+
+// handleListTeams handles GET /v1/orgs/:org_id/teams.
+func handleListTeams(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		teams, err := uc.ListTeams(ctx, oc)
+		if err != nil {
+			return handleOrganizationError(c, err, "list_teams")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"teams": teams})
+	}
+}
+
+// handleListTeamMembers handles GET /v1/orgs/:org_id/teams/:team_id/members.
+func handleListTeamMembers(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		teamID, err := uuid.Parse(c.Param("team_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid team ID", "team_id", c.Param("team_id"))
+		}
+		members, err := uc.ListTeamMembers(ctx, oc, teamID)
+		if err != nil {
+			return handleOrganizationError(c, err, "list_team_members")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"members": members})
+	}
+}
+
+// handleAddTeamMember handles PUT /v1/orgs/:org_id/teams/:team_id/members/:user_id.
+func handleAddTeamMember(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		teamID, err := uuid.Parse(c.Param("team_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid team ID", "team_id", c.Param("team_id"))
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+		if err := uc.AddTeamMember(ctx, oc, teamID, userID); err != nil {
+			return handleOrganizationError(c, err, "add_team_member")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// handleRemoveTeamMember handles DELETE /v1/orgs/:org_id/teams/:team_id/members/:user_id.
+func handleRemoveTeamMember(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		teamID, err := uuid.Parse(c.Param("team_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid team ID", "team_id", c.Param("team_id"))
+		}
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid user ID", "user_id", c.Param("user_id"))
+		}
+		if err := uc.RemoveTeamMember(ctx, oc, teamID, userID); err != nil {
+			return handleOrganizationError(c, err, "remove_team_member")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// handleCreateOrgInvitation handles POST /v1/orgs/:org_id/invitations. The
+// response carries the token once; it is not retrievable later.
+func handleCreateOrgInvitation(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		var req CreateOrgInvitationRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		inv, err := uc.CreateInvitation(ctx, oc, req.Email, domain.OrgRole(req.Role))
+		if err != nil {
+			return handleOrganizationError(c, err, "create_org_invitation")
+		}
+		c.Response().Header().Set("Cache-Control", "no-store")
+		return c.JSON(http.StatusCreated, inv)
+	}
+}
+
+// handleListOrgInvitations handles GET /v1/orgs/:org_id/invitations.
+func handleListOrgInvitations(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		invitations, err := uc.ListInvitations(ctx, oc)
+		if err != nil {
+			return handleOrganizationError(c, err, "list_org_invitations")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"invitations": invitations})
+	}
+}
+
+// handleRevokeOrgInvitation handles DELETE /v1/orgs/:org_id/invitations/:invitation_id.
+func handleRevokeOrgInvitation(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		oc, err := domain.GetOrgFromContext(ctx)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "organization not found"})
+		}
+		invitationID, err := uuid.Parse(c.Param("invitation_id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid invitation ID", "invitation_id", c.Param("invitation_id"))
+		}
+		if err := uc.RevokeInvitation(ctx, oc, invitationID); err != nil {
+			return handleOrganizationError(c, err, "revoke_org_invitation")
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// handleAcceptOrgInvitation handles POST /v1/orgs/invitations/accept for
+// the signed-in user the invitation was addressed to.
+func handleAcceptOrgInvitation(uc *organization_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		var req AcceptOrgInvitationRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+
+		member, err := uc.AcceptInvitation(ctx, user, req.Token)
+		if err != nil {
+			return handleOrganizationError(c, err, "accept_org_invitation")
+		}
+		return c.JSON(http.StatusOK, member)
+	}
+}
+
+// organizationErrorStatus maps the organization sentinel errors onto
+// HTTP statuses.
+var organizationErrorStatus = []struct {
+	err    error
+	status int
+}{
+	{domain.ErrOrgForbidden, http.StatusForbidden},
+	{domain.ErrOrganizationNotFound, http.StatusNotFound},
+	{domain.ErrOrgMemberNotFound, http.StatusNotFound},
+	{domain.ErrTeamNotFound, http.StatusNotFound},
+	{domain.ErrOrgInvitationNotFound, http.StatusNotFound},
+	{domain.ErrOrgSlugTaken, http.StatusConflict},
+	{domain.ErrTeamNameTaken, http.StatusConflict},
+	{domain.ErrOrgLastOwner, http.StatusConflict},
+	{domain.ErrOrgInvitationGone, http.StatusGone},
+}
+
+// handleOrganizationError answers known organization errors with their
+// status and message and everything else as an internal error.
+func handleOrganizationError(c echo.Context, err error, op string) error {
+	if errors.Is(err, organization_usecase.ErrInvalidInput) {
+		return HandleValidationError(c, err.Error(), "body", "")
+	}
+	for _, m := range organizationErrorStatus {
+		if errors.Is(err, m.err) {
+			return c.JSON(m.status, map[string]string{"error": m.err.Error()})
+		}
+	}
+	return HandleError(c, fmt.Errorf("organization request failed: %w", err), op)
+}
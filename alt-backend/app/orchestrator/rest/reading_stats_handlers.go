@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/reading_stats_usecase"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// registerReadingStatsRoutes wires the caller's reading statistics. The
+// numbers come from the nightly reading-stats-aggregation job, so reads
+// since its last run are not reflected until the next one.
+func registerReadingStatsRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+
+	stats := v1.Group("/stats", authMiddleware.RequireAuth())
+	stats.GET("/reading", handleGetReadingStats(container.Recap.ReadingStatsUsecase))
+}
+
+// handleGetReadingStats handles GET /v1/stats/reading?days=7
+func handleGetReadingStats(uc *reading_stats_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+
+		days := 0
+		if raw := c.QueryParam("days"); raw != "" {
+			days, err = strconv.Atoi(raw)
+			if err != nil || days <= 0 {
+				return HandleValidationError(c, "days must be a positive integer", "days", raw)
+			}
+		}
+
+		stats, err := uc.GetReadingStats(ctx, user.UserID, days)
+		switch {
+		case errors.Is(err, reading_stats_usecase.ErrInvalidInput):
+			return HandleValidationError(c, err.Error(), "days", c.QueryParam("days"))
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to get reading stats: %w", err), "get_reading_stats")
+		}
+		return c.JSON(http.StatusOK, stats)
+	}
+}
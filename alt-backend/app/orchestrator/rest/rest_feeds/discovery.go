@@ -0,0 +1,60 @@
+package rest_feeds
+
+import (
+	"alt/di"
+	"alt/domain"
+	"alt/orchestrator/usecase/feed_discovery_usecase"
+	appErrors "alt/utils/errors"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DiscoverFeedsRequest is the body of POST /v1/feeds/discover.
+type DiscoverFeedsRequest struct {
+	URL string `json:"url"`
+}
+
+// DiscoverFeedsResponse lists the feeds found for a website. Feeds is empty
+// (not an error) when the site advertises none.
+type DiscoverFeedsResponse struct {
+	URL   string                   `json:"url"`
+	Feeds []*domain.DiscoveredFeed `json:"feeds"`
+}
+
+// RestHandleDiscoverFeeds handles POST /v1/feeds/discover. It returns the
+// RSS/Atom/JSON feeds a website advertises so the frontend can offer
+// one-click subscription through /v1/rss-feed-link/register.
+func RestHandleDiscoverFeeds(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var req DiscoverFeedsRequest
+		if err := c.Bind(&req); err != nil {
+			return HandleValidationError(c, "Invalid request format", "body", "malformed JSON")
+		}
+		if strings.TrimSpace(req.URL) == "" {
+			return HandleValidationError(c, "URL is required and cannot be empty", "url", req.URL)
+		}
+
+		feeds, err := container.FeedDiscoveryUsecase.Execute(ctx, req.URL)
+		switch {
+		case errors.Is(err, feed_discovery_usecase.ErrInvalidDiscoveryURL):
+			return HandleValidationError(c, err.Error(), "url", req.URL)
+		case errors.Is(err, feed_discovery_usecase.ErrPageFetchFailed):
+			return HandleError(c, appErrors.NewExternalAPIContextError(
+				"could not fetch the website",
+				"rest",
+				"RESTHandler",
+				"discover_feeds",
+				err,
+				map[string]interface{}{"url": req.URL},
+			), "discover_feeds")
+		case err != nil:
+			return HandleError(c, err, "discover_feeds")
+		}
+
+		return c.JSON(http.StatusOK, DiscoverFeedsResponse{URL: strings.TrimSpace(req.URL), Feeds: feeds})
+	}
+}
@@ -16,7 +16,6 @@ func RestHandleFetchSingleFeed(container *di.ApplicationComponents, cfg *config.
 	return func(c echo.Context) error {
 		// Add caching headers
 		c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cfg.Cache.FeedCacheExpiry.Seconds())))
-		c.Response().Header().Set("ETag", `"single-feed"`)
 
 		feed, err := container.FetchSingleFeedUsecase.Execute(c.Request().Context())
 		if err != nil {
@@ -30,7 +29,6 @@ func RestHandleFetchFeedsList(container *di.ApplicationComponents, cfg *config.C
 	return func(c echo.Context) error {
 		// Add caching headers for feed list
 		c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cfg.Cache.SearchCacheExpiry.Seconds())))
-		c.Response().Header().Set("ETag", `"feeds-list"`)
 
 		feeds, err := container.FetchFeedsListUsecase.Execute(c.Request().Context())
 		if err != nil {
@@ -58,7 +56,6 @@ func RestHandleFetchFeedsLimit(container *di.ApplicationComponents, cfg *config.
 		// Add caching headers based on limit
 		cacheAge := GetCacheAgeForLimit(limit)
 		c.Response().Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(cacheAge))
-		c.Response().Header().Set("ETag", `"feeds-limit-`+strconv.Itoa(limit)+`"`)
 
 		feeds, err := container.FetchFeedsListUsecase.ExecuteLimit(c.Request().Context(), limit)
 		if err != nil {
@@ -87,7 +84,6 @@ func RestHandleFetchFeedsPage(container *di.ApplicationComponents) echo.HandlerF
 
 		// Add caching headers for paginated results
 		c.Response().Header().Set("Cache-Control", "public, max-age=600") // 10 minutes
-		c.Response().Header().Set("ETag", `"feeds-page-`+strconv.Itoa(page)+`"`)
 
 		feeds, err := container.FetchFeedsListUsecase.ExecutePage(ctx, page)
 		if err != nil {
@@ -2,6 +2,7 @@ package rest_feeds
 
 import (
 	"alt/di"
+	middleware_custom "alt/middleware"
 	"alt/utils/errors"
 	"alt/utils/logger"
 	"net/http"
@@ -33,6 +34,7 @@ func RestHandleMarkFeedAsRead(container *di.ApplicationComponents) echo.HandlerF
 		}
 
 		logger.Logger.InfoContext(ctx, "Feed read status updated", "feedURL", feedURL)
+		middleware_custom.InvalidateUserETags(ctx, container.CacheVersions)
 
 		// Invalidate cache after update
 		c.Response().Header().Set("Cache-Control", "no-cache")
@@ -79,6 +81,7 @@ func RestHandleRegisterFavoriteFeed(container *di.ApplicationComponents) echo.Ha
 		if err = container.RegisterFavoriteFeedUsecase.Execute(ctx, payload.URL); err != nil {
 			return HandleError(c, err, "register_favorite_feed")
 		}
+		middleware_custom.InvalidateUserETags(ctx, container.CacheVersions)
 
 		c.Response().Header().Set("Cache-Control", "no-cache")
 		return c.JSON(http.StatusOK, map[string]string{"message": "favorite feed registered"})
@@ -2,6 +2,7 @@ package rest_feeds
 
 import (
 	"alt/di"
+	middleware_custom "alt/middleware"
 	"alt/utils/logger"
 	"io"
 	"net/http"
@@ -66,6 +67,7 @@ func RestHandleImportOPML(container *di.ApplicationComponents) echo.HandlerFunc
 		if err != nil {
 			return HandleError(c, err, "import_opml")
 		}
+		middleware_custom.InvalidateUserETags(ctx, container.CacheVersions)
 
 		return c.JSON(http.StatusOK, result)
 	}
@@ -0,0 +1,139 @@
+package rest_feeds
+
+import (
+	"alt/di"
+	"alt/domain"
+	"alt/orchestrator/usecase/home_ranking_usecase"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RankingExplanation is one item of GET /v1/feeds/ranking/explain.
+type RankingExplanation struct {
+	Rank              int                       `json:"rank"`
+	ChronologicalRank int                       `json:"chronological_rank"`
+	Title             string                    `json:"title"`
+	Link              string                    `json:"link"`
+	ArticleID         string                    `json:"article_id,omitempty"`
+	CreatedAt         string                    `json:"created_at"`
+	Score             float64                   `json:"score"`
+	Signals           domain.HomeRankingSignals `json:"signals"`
+	Contributions     domain.HomeRankingSignals `json:"contributions"`
+	TopSignal         string                    `json:"top_signal"`
+	MatchedTags       []string                  `json:"matched_tags,omitempty"`
+}
+
+// RestHandleFetchRankedFeeds handles GET /v1/feeds/fetch/ranked, the unread
+// timeline ordered by the caller's ranking weights. It pages like
+// /fetch/cursor: each page holds the same items in a different order.
+func RestHandleFetchRankedFeeds(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		page, err := rankHomeFeed(c, container.Feed.HomeRankingUsecase)
+		if err != nil {
+			return err
+		}
+		if page == nil {
+			return nil
+		}
+
+		feeds := make([]*domain.FeedItem, len(page.Items))
+		for i, item := range page.Items {
+			feeds[i] = item.Item
+		}
+		c.Response().Header().Set("Cache-Control", "private, max-age=60")
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"data":        OptimizeFeedsResponse(feeds),
+			"has_more":    page.HasMore,
+			"next_cursor": formatNextCursor(page.NextCursor),
+			"experiment":  page.Experiment,
+		})
+	}
+}
+
+// RestHandleExplainFeedRanking handles GET /v1/feeds/ranking/explain, a
+// debug view of the same page as /fetch/ranked with every item's signals,
+// weighted contributions and chronological position.
+func RestHandleExplainFeedRanking(container *di.ApplicationComponents) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		page, err := rankHomeFeed(c, container.Feed.HomeRankingUsecase)
+		if err != nil {
+			return err
+		}
+		if page == nil {
+			return nil
+		}
+
+		items := make([]RankingExplanation, len(page.Items))
+		for i, item := range page.Items {
+			items[i] = RankingExplanation{
+				Rank:              item.Rank,
+				ChronologicalRank: item.ChronologicalRank,
+				Title:             item.Item.Title,
+				Link:              item.Item.Link,
+				ArticleID:         item.Item.ArticleID,
+				CreatedAt:         item.Item.PublishedParsed.Format(time.RFC3339),
+				Score:             item.Score,
+				Signals:           item.Signals,
+				Contributions:     item.Contributions,
+				TopSignal:         item.Contributions.Strongest(),
+				MatchedTags:       item.MatchedTags,
+			}
+		}
+		c.Response().Header().Set("Cache-Control", "no-store")
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"experiment":  page.Experiment,
+			"weights":     page.Weights,
+			"degraded":    page.Degraded,
+			"items":       items,
+			"has_more":    page.HasMore,
+			"next_cursor": formatNextCursor(page.NextCursor),
+		})
+	}
+}
+
+// rankHomeFeed parses limit and cursor and ranks the caller's page. A nil
+// page with a nil error means a response has already been written.
+func rankHomeFeed(c echo.Context, uc *home_ranking_usecase.Usecase) (*domain.HomeRankingPage, error) {
+	ctx := c.Request().Context()
+	user, err := domain.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+
+	limit := 0
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return nil, HandleValidationError(c, "limit must be a positive integer", "limit", raw)
+		}
+	}
+	var cursor *time.Time
+	if raw := c.QueryParam("cursor"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, HandleValidationError(c, "Invalid cursor format. Use RFC3339 format", "cursor", raw)
+		}
+		cursor = &parsed
+	}
+
+	page, err := uc.RankHomeFeed(ctx, user.UserID, cursor, limit)
+	switch {
+	case errors.Is(err, home_ranking_usecase.ErrInvalidInput):
+		return nil, HandleValidationError(c, err.Error(), "limit", c.QueryParam("limit"))
+	case err != nil:
+		return nil, HandleError(c, fmt.Errorf("failed to rank home feed: %w", err), "rank_home_feed")
+	}
+	return page, nil
+}
+
+func formatNextCursor(cursor *time.Time) interface{} {
+	if cursor == nil {
+		return nil
+	}
+	return cursor.Format(time.RFC3339)
+}
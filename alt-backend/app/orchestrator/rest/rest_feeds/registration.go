@@ -3,6 +3,7 @@ package rest_feeds
 import (
 	"alt/di"
 	"alt/domain"
+	middleware_custom "alt/middleware"
 	"alt/utils/errors"
 	"alt/utils/logger"
 	"net/http"
@@ -58,6 +59,8 @@ func RestHandleRegisterRSSFeed(container *di.ApplicationComponents) echo.Handler
 			return HandleError(c, err, "register_feed")
 		}
 
+		middleware_custom.InvalidateUserETags(ctx, container.CacheVersions)
+
 		// Invalidate cache after registration
 		c.Response().Header().Set("Cache-Control", "no-cache")
 		return c.JSON(http.StatusOK, map[string]string{"message": "RSS feed link registered"})
@@ -115,6 +118,7 @@ func RestHandleDeleteRSSFeedLink(container *di.ApplicationComponents) echo.Handl
 		if err := container.DeleteFeedLinkUsecase.Execute(ctx, userCtx.UserID, linkID); err != nil {
 			return HandleError(c, err, "delete_feed_link")
 		}
+		middleware_custom.InvalidateUserETags(ctx, container.CacheVersions)
 
 		c.Response().Header().Set("Cache-Control", "no-cache")
 		return c.JSON(http.StatusOK, map[string]string{"message": "Feed unsubscribed"})
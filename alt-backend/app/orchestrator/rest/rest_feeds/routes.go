@@ -18,22 +18,33 @@ func RegisterFeedRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg
 	// v1にまとめて適用する代わりに、feedsグループに認証ミドルウェアを適用
 	feedsGroup := v1.Group("/feeds", authMiddleware.RequireAuth())
 
+	// Response-hash ETags for the polled timeline and feed list endpoints.
+	// Write handlers below bump the user's cache version so a stale ETag is
+	// never short-circuited after a mutation.
+	etag := middleware_custom.ETagMiddleware(middleware_custom.ETagConfig{
+		Versions:         container.CacheVersions,
+		RevalidateWindow: cfg.Cache.ETagRevalidateWindow,
+	})
+
 	// Private endpoints (authentication required)
-	feedsGroup.GET("/fetch/single", RestHandleFetchSingleFeed(container, cfg))
-	feedsGroup.GET("/fetch/list", RestHandleFetchFeedsList(container, cfg))
-	feedsGroup.GET("/fetch/limit/:limit", RestHandleFetchFeedsLimit(container, cfg))
-	feedsGroup.GET("/fetch/page/:page", RestHandleFetchFeedsPage(container))
+	feedsGroup.GET("/fetch/single", RestHandleFetchSingleFeed(container, cfg), etag)
+	feedsGroup.GET("/fetch/list", RestHandleFetchFeedsList(container, cfg), etag)
+	feedsGroup.GET("/fetch/limit/:limit", RestHandleFetchFeedsLimit(container, cfg), etag)
+	feedsGroup.GET("/fetch/page/:page", RestHandleFetchFeedsPage(container), etag)
 
 	// User-specific endpoints (authentication required) - 認証必須パス
 	feedsGroup.GET("/count/unreads", RestHandleUnreadCount(container))
-	feedsGroup.GET("/fetch/cursor", RestHandleFetchUnreadFeedsCursor(container))
-	feedsGroup.GET("/fetch/viewed/cursor", RestHandleFetchReadFeedsCursor(container))
-	feedsGroup.GET("/fetch/favorites/cursor", RestHandleFetchFavoriteFeedsCursor(container))
+	feedsGroup.GET("/fetch/cursor", RestHandleFetchUnreadFeedsCursor(container), etag)
+	feedsGroup.GET("/fetch/viewed/cursor", RestHandleFetchReadFeedsCursor(container), etag)
+	feedsGroup.GET("/fetch/favorites/cursor", RestHandleFetchFavoriteFeedsCursor(container), etag)
+	feedsGroup.GET("/fetch/ranked", RestHandleFetchRankedFeeds(container), etag)
+	feedsGroup.GET("/ranking/explain", RestHandleExplainFeedRanking(container))
 	feedsGroup.POST("/read", RestHandleMarkFeedAsRead(container))
 	feedsGroup.POST("/register/favorite", RestHandleRegisterFavoriteFeed(container))
 
 	// Authentication needed endpoints (for personalized results)
 	feedsGroup.POST("/search", RestHandleSearchFeeds(container))
+	feedsGroup.POST("/discover", RestHandleDiscoverFeeds(container))
 	feedsGroup.POST("/fetch/details", RestHandleFetchFeedDetails(container))
 	feedsGroup.GET("/stats", RestHandleFeedStats(container, cfg))
 	feedsGroup.GET("/stats/detailed", RestHandleDetailedFeedStats(container, cfg))
@@ -52,7 +63,7 @@ func RegisterFeedRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg
 	// RSS feed registration (require auth) - 認証ミドルウェア付きでグループ作成
 	rss := v1.Group("/rss-feed-link", authMiddleware.RequireAuth())
 	rss.POST("/register", RestHandleRegisterRSSFeed(container))
-	rss.GET("/list", RestHandleListRSSFeedLinks(container))
+	rss.GET("/list", RestHandleListRSSFeedLinks(container), etag)
 	rss.GET("/random", RestHandleFetchRandomSubscription(container))
 	rss.DELETE("/:id", RestHandleDeleteRSSFeedLink(container))
 	rss.GET("/export/opml", RestHandleExportOPML(container))
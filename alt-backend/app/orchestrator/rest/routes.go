@@ -33,39 +33,10 @@ func RegisterRoutes(ctx context.Context, e *echo.Echo, container *di.Application
 		Limit: "2M",
 	}))
 
-	// 3. Security headers - セキュリティ設定を早期に適用 (M-008)
-	// alt-backend は HTML を返さない API なので CSP は最小許可。frame-ancestors,
-	// base-uri, form-action を明示し、違反は /security/csp-report で受ける。
-	// Referrer-Policy / Permissions-Policy で外部参照と機能アクセスを制限。
-	e.Use(middleware.SecureWithConfig(middleware.SecureConfig{
-		XSSProtection:         "1; mode=block",
-		ContentTypeNosniff:    "nosniff",
-		XFrameOptions:         "DENY",
-		HSTSMaxAge:            31536000,
-		HSTSPreloadEnabled:    true,
-		ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'self'; report-uri /security/csp-report",
-		ReferrerPolicy:        "strict-origin-when-cross-origin",
-	}))
-
-	// 4. CORS middleware - クロスオリジン制御
-	// X-Alt-Backend-Token は JWT 認証ヘッダなので preflight で許可する (M-009)。
-	// Permissions-Policy はエッジ層で配信するため Echo Secure ではなく nginx 側で
-	// 設定する想定。
-	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: cfg.Server.CORSAllowedOrigins,
-		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
-		AllowHeaders: []string{
-			echo.HeaderOrigin,
-			echo.HeaderContentType,
-			echo.HeaderAccept,
-			"Cache-Control",
-			"Authorization",
-			"X-Requested-With",
-			"X-CSRF-Token",
-			"X-Alt-Backend-Token",
-		},
-		MaxAge: 86400, // Cache preflight for 24 hours
-	}))
+	// 3-4. Security headers and CORS - ルートグループ (API / admin / image
+	// proxy / internal) ごとに config の CSP・frame options・許可オリジンを適用
+	// (security_middleware.go)。違反は /security/csp-report で受ける。
+	e.Use(securityMiddleware(cfg))
 
 	// 5. DOS protection - 悪意のあるリクエストを早期にブロック
 	// /v1/sse/ は H-001 で削除済みのため WhitelistedPaths から外した。残る
@@ -111,6 +82,7 @@ func RegisterRoutes(ctx context.Context, e *echo.Echo, container *di.Application
 			return strings.Contains(c.Request().Header.Get("Accept-Encoding"), "br") ||
 				strings.Contains(c.Path(), "/health") ||
 				strings.Contains(c.Path(), "/sse/") ||
+				c.Path() == "/v1/timeline/stream" ||
 				c.Path() == "/metrics"
 		},
 	}))
@@ -133,6 +105,18 @@ func RegisterRoutes(ctx context.Context, e *echo.Echo, container *di.Application
 	// `services.backend.v1.BackendInternalService/ListRecapArticles` に移行済。
 	registerScrapingDomainRoutes(v1, container, cfg)
 	registerDashboardRoutes(v1, container, cfg)
+	registerLegalHoldRoutes(v1, container, cfg)
+	registerSoftDeleteRoutes(v1, container, cfg)
+	registerReadingStatsRoutes(v1, container, cfg)
+	registerArticleShareRoutes(v1, container, cfg)
+	registerArticleAudioRoutes(v1, container, cfg)
+	registerOrganizationRoutes(v1, container, cfg)
+	registerArticleReconciliationRoutes(v1, container, cfg)
+	registerArticleTitleFixRoutes(v1, container, cfg)
+	registerArticleIndexRoutes(v1, container, cfg)
+	registerHomeRankingRoutes(v1, container, cfg)
+	registerFeatureFlagRoutes(v1, container, cfg)
+	registerTimelineRoutes(ctx, v1, container, cfg)
 	RegisterAugurRoutes(e, v1, container)
 	registerInternalRoutes(e, container)
 }
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"alt/config"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// routeGroup is a family of routes sharing one CORS and security header
+// policy.
+type routeGroup string
+
+const (
+	routeGroupAPI        routeGroup = "api"
+	routeGroupAdmin      routeGroup = "admin"
+	routeGroupImageProxy routeGroup = "image_proxy"
+	routeGroupInternal   routeGroup = "internal"
+)
+
+// classifyRouteGroup maps a request path to its route group. It matches on
+// the raw URL path rather than c.Path() so CORS preflights for paths with no
+// OPTIONS route still land in the right group.
+func classifyRouteGroup(path string) routeGroup {
+	switch {
+	case strings.HasPrefix(path, "/v1/internal/"):
+		return routeGroupInternal
+	case strings.HasPrefix(path, "/v1/admin/"), strings.HasPrefix(path, "/v1/dashboard/"):
+		return routeGroupAdmin
+	case strings.HasPrefix(path, "/v1/images/proxy/"):
+		return routeGroupImageProxy
+	default:
+		return routeGroupAPI
+	}
+}
+
+// secureConfig returns the security header settings shared by every group
+// except for CSP and X-Frame-Options.
+// alt-backend は HTML を返さない API なので CSP は最小許可 (M-008)。
+func secureConfig(csp, frameOptions string) middleware.SecureConfig {
+	return middleware.SecureConfig{
+		XSSProtection:         "1; mode=block",
+		ContentTypeNosniff:    "nosniff",
+		XFrameOptions:         frameOptions,
+		HSTSMaxAge:            31536000,
+		HSTSPreloadEnabled:    true,
+		ContentSecurityPolicy: csp,
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+	}
+}
+
+// corsConfig returns the CORS settings for origins.
+// X-Alt-Backend-Token は JWT 認証ヘッダなので preflight で許可する (M-009)。
+func corsConfig(origins []string) middleware.CORSConfig {
+	return middleware.CORSConfig{
+		AllowOrigins: origins,
+		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
+		AllowHeaders: []string{
+			echo.HeaderOrigin,
+			echo.HeaderContentType,
+			echo.HeaderAccept,
+			"Cache-Control",
+			"Authorization",
+			"X-Requested-With",
+			"X-CSRF-Token",
+			"X-Alt-Backend-Token",
+		},
+		MaxAge: 86400, // Cache preflight for 24 hours
+	}
+}
+
+// securityMiddleware applies each route group's security headers and CORS
+// policy from cfg. /v1/internal is service-to-service only and gets no CORS
+// middleware at all: Echo's CORS treats an empty origin list as "*".
+// Permissions-Policy はエッジ層 (nginx) で配信する。
+func securityMiddleware(cfg *config.Config) echo.MiddlewareFunc {
+	api := []echo.MiddlewareFunc{
+		middleware.SecureWithConfig(secureConfig(cfg.Security.APIContentSecurityPolicy, cfg.Security.APIFrameOptions)),
+		middleware.CORSWithConfig(corsConfig(cfg.Server.CORSAllowedOrigins)),
+	}
+	policies := map[routeGroup][]echo.MiddlewareFunc{
+		routeGroupAPI: api,
+		routeGroupAdmin: {
+			api[0],
+			middleware.CORSWithConfig(corsConfig(cfg.Security.AdminCORSAllowedOrigins)),
+		},
+		routeGroupImageProxy: {
+			middleware.SecureWithConfig(secureConfig(cfg.Security.ImageProxyContentSecurityPolicy, cfg.Security.ImageProxyFrameOptions)),
+			middleware.CORSWithConfig(corsConfig(cfg.Security.ImageProxyCORSAllowedOrigins)),
+		},
+		routeGroupInternal: {api[0]},
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		chains := make(map[routeGroup]echo.HandlerFunc, len(policies))
+		for group, mws := range policies {
+			h := next
+			for i := len(mws) - 1; i >= 0; i-- {
+				h = mws[i](h)
+			}
+			chains[group] = h
+		}
+		return func(c echo.Context) error {
+			return chains[classifyRouteGroup(c.Request().URL.Path)](c)
+		}
+	}
+}
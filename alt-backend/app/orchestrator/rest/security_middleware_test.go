@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"alt/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildRouteGroupSecurityEcho() *echo.Echo {
+	cfg := &config.Config{
+		Server: config.ServerConfig{CORSAllowedOrigins: []string{"https://app.example.com"}},
+		Security: config.SecurityConfig{
+			AdminCORSAllowedOrigins:         []string{"https://admin.example.com"},
+			ImageProxyCORSAllowedOrigins:    []string{"https://app.example.com", "https://embed.example.com"},
+			APIContentSecurityPolicy:        "default-src 'none'; frame-ancestors 'none'",
+			APIFrameOptions:                 "DENY",
+			ImageProxyContentSecurityPolicy: "default-src 'none'; sandbox",
+			ImageProxyFrameOptions:          "SAMEORIGIN",
+		},
+	}
+	e := echo.New()
+	e.Use(securityMiddleware(cfg))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/v1/feeds", ok)
+	e.GET("/v1/admin/scraping-domains", ok)
+	e.GET("/v1/images/proxy/:sig/:url", ok)
+	e.GET("/v1/internal/articles", ok)
+	return e
+}
+
+func serveWithOrigin(e *echo.Echo, method, path, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(echo.HeaderOrigin, origin)
+	if method == http.MethodOptions {
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+	}
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func TestClassifyRouteGroup(t *testing.T) {
+	assert.Equal(t, routeGroupAPI, classifyRouteGroup("/v1/feeds"))
+	assert.Equal(t, routeGroupAPI, classifyRouteGroup("/security/csp-report"))
+	assert.Equal(t, routeGroupAdmin, classifyRouteGroup("/v1/admin/legal-holds"))
+	assert.Equal(t, routeGroupAdmin, classifyRouteGroup("/v1/dashboard/metrics"))
+	assert.Equal(t, routeGroupImageProxy, classifyRouteGroup("/v1/images/proxy/sig/url"))
+	assert.Equal(t, routeGroupAPI, classifyRouteGroup("/v1/images/fetch"))
+	assert.Equal(t, routeGroupInternal, classifyRouteGroup("/v1/internal/articles"))
+}
+
+func TestSecurityMiddleware_CORSOriginsPerRouteGroup(t *testing.T) {
+	e := buildRouteGroupSecurityEcho()
+
+	tests := []struct {
+		name    string
+		path    string
+		origin  string
+		allowed bool
+	}{
+		{"api allows api origin", "/v1/feeds", "https://app.example.com", true},
+		{"api rejects admin origin", "/v1/feeds", "https://admin.example.com", false},
+		{"admin allows admin origin", "/v1/admin/scraping-domains", "https://admin.example.com", true},
+		{"admin rejects api origin", "/v1/admin/scraping-domains", "https://app.example.com", false},
+		{"image proxy allows embed origin", "/v1/images/proxy/sig/url", "https://embed.example.com", true},
+		{"internal answers no origin", "/v1/internal/articles", "https://app.example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, method := range []string{http.MethodGet, http.MethodOptions} {
+				res := serveWithOrigin(e, method, tt.path, tt.origin)
+				got := res.Header().Get(echo.HeaderAccessControlAllowOrigin)
+				if tt.allowed {
+					assert.Equal(t, tt.origin, got, method)
+				} else {
+					assert.Empty(t, got, method)
+				}
+			}
+		})
+	}
+}
+
+func TestSecurityMiddleware_HeadersPerRouteGroup(t *testing.T) {
+	e := buildRouteGroupSecurityEcho()
+
+	res := serveWithOrigin(e, http.MethodGet, "/v1/feeds", "https://app.example.com")
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", res.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "DENY", res.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "nosniff", res.Header().Get("X-Content-Type-Options"))
+
+	res = serveWithOrigin(e, http.MethodGet, "/v1/images/proxy/sig/url", "https://app.example.com")
+	assert.Equal(t, "default-src 'none'; sandbox", res.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "SAMEORIGIN", res.Header().Get("X-Frame-Options"))
+
+	res = serveWithOrigin(e, http.MethodGet, "/v1/internal/articles", "")
+	assert.Equal(t, "DENY", res.Header().Get("X-Frame-Options"))
+}
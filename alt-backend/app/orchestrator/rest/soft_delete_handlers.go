@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/soft_delete_usecase"
+	"alt/utils/cache"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// registerSoftDeleteRoutes wires delete, restore and trash listing for
+// articles (scoped to the caller's own articles) and feeds (admin only,
+// since feeds are shared across users). Deleted items stay restorable for
+// domain.SoftDeleteRestoreWindow before the purge job removes them.
+func registerSoftDeleteRoutes(v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+	uc := container.Compliance.SoftDeleteUsecase
+	versions := container.CacheVersions
+
+	articles := v1.Group("/articles", authMiddleware.RequireAuth())
+	articles.GET("/deleted", handleListDeletedArticles(uc))
+	articles.DELETE("/:id", handleDeleteArticle(uc, versions))
+	articles.POST("/:id/restore", handleRestoreArticle(uc, versions))
+
+	admin := v1.Group("/admin", authMiddleware.RequireAuth(), authMiddleware.RequireAdmin())
+	admin.GET("/feeds/deleted", handleListDeletedFeeds(uc))
+	admin.DELETE("/feeds/:id", handleDeleteFeed(uc, versions))
+	admin.POST("/feeds/:id/restore", handleRestoreFeed(uc, versions))
+}
+
+// handleListDeletedArticles handles GET /v1/articles/deleted
+func handleListDeletedArticles(uc *soft_delete_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+		items, err := uc.ListDeletedArticles(ctx, user.UserID, limit)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to list deleted articles: %w", err), "list_deleted_articles")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"items": items})
+	}
+}
+
+// handleDeleteArticle handles DELETE /v1/articles/:id
+func handleDeleteArticle(uc *soft_delete_usecase.Usecase, versions *cache.VersionStore) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+
+		err = uc.DeleteArticle(ctx, user.UserID, articleID)
+		if err == nil {
+			middleware_custom.InvalidateUserETags(ctx, versions)
+		}
+		return softDeleteResponse(c, err, "delete_article")
+	}
+}
+
+// handleRestoreArticle handles POST /v1/articles/:id/restore
+func handleRestoreArticle(uc *soft_delete_usecase.Usecase, versions *cache.VersionStore) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		articleID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid article ID", "id", c.Param("id"))
+		}
+
+		err = uc.RestoreArticle(ctx, user.UserID, articleID)
+		if err == nil {
+			middleware_custom.InvalidateUserETags(ctx, versions)
+		}
+		return restoreResponse(c, err, "restore_article")
+	}
+}
+
+// handleListDeletedFeeds handles GET /v1/admin/feeds/deleted
+func handleListDeletedFeeds(uc *soft_delete_usecase.Usecase) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+		items, err := uc.ListDeletedFeeds(c.Request().Context(), limit)
+		if err != nil {
+			return HandleError(c, fmt.Errorf("failed to list deleted feeds: %w", err), "list_deleted_feeds")
+		}
+		return c.JSON(http.StatusOK, map[string]any{"items": items})
+	}
+}
+
+// handleDeleteFeed handles DELETE /v1/admin/feeds/:id
+func handleDeleteFeed(uc *soft_delete_usecase.Usecase, versions *cache.VersionStore) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		feedID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid feed ID", "id", c.Param("id"))
+		}
+
+		err = uc.DeleteFeed(ctx, actor.UserID, feedID)
+		if err == nil {
+			// Feeds are shared, so every user's timeline changes.
+			versions.BumpAll()
+		}
+		return softDeleteResponse(c, err, "delete_feed")
+	}
+}
+
+// handleRestoreFeed handles POST /v1/admin/feeds/:id/restore
+func handleRestoreFeed(uc *soft_delete_usecase.Usecase, versions *cache.VersionStore) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		actor, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+		feedID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return HandleValidationError(c, "Invalid feed ID", "id", c.Param("id"))
+		}
+
+		err = uc.RestoreFeed(ctx, actor.UserID, feedID)
+		if err == nil {
+			versions.BumpAll()
+		}
+		return restoreResponse(c, err, "restore_feed")
+	}
+}
+
+func softDeleteResponse(c echo.Context, err error, operation string) error {
+	switch {
+	case err == nil:
+		return c.NoContent(http.StatusNoContent)
+	case errors.Is(err, domain.ErrItemNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "item not found"})
+	default:
+		return HandleError(c, fmt.Errorf("soft delete failed: %w", err), operation)
+	}
+}
+
+func restoreResponse(c echo.Context, err error, operation string) error {
+	switch {
+	case err == nil:
+		return c.JSON(http.StatusOK, map[string]string{"message": "restored"})
+	case errors.Is(err, domain.ErrDeletedItemNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "deleted item not found"})
+	case errors.Is(err, domain.ErrRestoreWindowExpired):
+		return c.JSON(http.StatusGone, map[string]string{"error": "restore window has expired"})
+	default:
+		return HandleError(c, fmt.Errorf("restore failed: %w", err), operation)
+	}
+}
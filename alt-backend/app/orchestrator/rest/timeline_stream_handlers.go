@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"alt/config"
+	"alt/di"
+	"alt/domain"
+	middleware_custom "alt/middleware"
+	"alt/orchestrator/usecase/timeline_stream_usecase"
+	"alt/utils/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// timelineRetryMillis is the reconnect delay sent to EventSource clients.
+	timelineRetryMillis = 3000
+	// timelineBusyRetryAfter is the Retry-After, in seconds, of a 429 for a
+	// user or server at its stream limit.
+	timelineBusyRetryAfter = "30"
+)
+
+// registerTimelineRoutes wires the live timeline stream. ctx is the
+// server's lifetime context: open streams end when it is cancelled, so
+// they do not hold up shutdown.
+func registerTimelineRoutes(ctx context.Context, v1 *echo.Group, container *di.ApplicationComponents, cfg *config.Config) {
+	authMiddleware := middleware_custom.NewAuthMiddleware(logger.Logger, cfg)
+
+	timeline := v1.Group("/timeline", authMiddleware.RequireAuth())
+	timeline.GET("/stream", handleTimelineStream(ctx, container.TimelineStream, cfg.Timeline.HeartbeatInterval))
+}
+
+// handleTimelineStream handles GET /v1/timeline/stream
+//
+// It streams the caller's new articles and read-state changes as
+// Server-Sent Events. Each event's id is a cursor; a reconnecting
+// EventSource sends the last one as Last-Event-ID (or a client passes
+// ?cursor=) and receives the events it missed. When those are no longer
+// available a "reset" event tells the client to reload its timeline. A
+// "ready" event carrying the current cursor follows the replay, and a
+// comment line is sent every heartbeat to keep proxies from closing an
+// idle connection. The server's write timeout does not apply: a stream
+// stays open until the client or the server goes away.
+func handleTimelineStream(serverCtx context.Context, uc *timeline_stream_usecase.Usecase, heartbeat time.Duration) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		user, err := domain.GetUserFromContext(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		}
+
+		cursor := c.Request().Header.Get("Last-Event-ID")
+		if cursor == "" {
+			cursor = c.QueryParam("cursor")
+		}
+
+		sub, err := uc.Subscribe(user.UserID.String(), cursor)
+		switch {
+		case errors.Is(err, timeline_stream_usecase.ErrTooManyConnections):
+			logger.Logger.WarnContext(ctx, "timeline stream rejected", "user_id", user.UserID, "error", err)
+			c.Response().Header().Set("Retry-After", timelineBusyRetryAfter)
+			return echo.NewHTTPError(http.StatusTooManyRequests, "too many open timeline streams")
+		case err != nil:
+			return HandleError(c, fmt.Errorf("failed to open timeline stream: %w", err), "timeline_stream")
+		}
+		defer sub.Close()
+
+		// SERVER_WRITE_TIMEOUT bounds a whole response, which would cut
+		// every stream off after that long.
+		if err := http.NewResponseController(c.Response().Writer).SetWriteDeadline(time.Time{}); err != nil {
+			logger.Logger.WarnContext(ctx, "timeline stream keeps the server write timeout", "error", err)
+		}
+
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/event-stream; charset=utf-8")
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		res.Header().Set(echo.HeaderConnection, "keep-alive")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+
+		if _, err := fmt.Fprintf(res, "retry: %d\n\n", timelineRetryMillis); err != nil {
+			return nil
+		}
+		if sub.Reset {
+			if err := writeTimelineEvent(res, "", "reset", map[string]string{"reason": "cursor_expired"}); err != nil {
+				return nil
+			}
+		}
+		for _, e := range sub.Replay {
+			if err := writeTimelineEvent(res, e.Cursor, string(e.Type), e.TimelineEvent); err != nil {
+				return nil
+			}
+		}
+		if err := writeTimelineEvent(res, sub.Cursor, "ready", map[string]string{"cursor": sub.Cursor}); err != nil {
+			return nil
+		}
+		res.Flush()
+
+		logger.Logger.InfoContext(ctx, "timeline stream opened",
+			"user_id", user.UserID, "resumed", cursor != "" && !sub.Reset, "replayed", len(sub.Replay))
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-serverCtx.Done():
+				return nil
+			case <-ticker.C:
+				if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+					return nil
+				}
+			case e, ok := <-sub.Events():
+				if !ok {
+					// Dropped for falling behind: closing lets EventSource
+					// reconnect and resume from its last cursor.
+					logger.Logger.InfoContext(ctx, "timeline stream closed", "user_id", user.UserID, "lagged", sub.Lagged())
+					return nil
+				}
+				if err := writeTimelineEvent(res, e.Cursor, string(e.Type), e.TimelineEvent); err != nil {
+					return nil
+				}
+			}
+			res.Flush()
+		}
+	}
+}
+
+// writeTimelineEvent writes one SSE event with a JSON data line. An empty
+// id leaves the client's last event ID unchanged.
+func writeTimelineEvent(w http.ResponseWriter, id, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
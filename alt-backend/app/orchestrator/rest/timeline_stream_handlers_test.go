@@ -0,0 +1,198 @@
+package rest
+
+import (
+	"alt/domain"
+	"alt/orchestrator/usecase/timeline_stream_usecase"
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseBlock is one blank-line-terminated block of an event stream.
+type sseBlock struct {
+	id, event, data string
+	comment         bool
+}
+
+func readSSEBlock(t *testing.T, r *bufio.Reader) sseBlock {
+	t.Helper()
+	var b sseBlock
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return b
+		case strings.HasPrefix(line, ":"):
+			b.comment = true
+		case strings.HasPrefix(line, "id: "):
+			b.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			b.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			b.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// nextSSEEvent skips heartbeats and the retry block.
+func nextSSEEvent(t *testing.T, r *bufio.Reader) sseBlock {
+	t.Helper()
+	for {
+		if b := readSSEBlock(t, r); b.event != "" {
+			return b
+		}
+	}
+}
+
+func newTimelineStreamServer(t *testing.T, uc *timeline_stream_usecase.Usecase, userID uuid.UUID, heartbeat time.Duration) (*httptest.Server, context.CancelFunc) {
+	t.Helper()
+	return newTimelineStreamServerWithWriteTimeout(t, uc, userID, heartbeat, 0)
+}
+
+// newTimelineStreamServerWithWriteTimeout is newTimelineStreamServer with
+// the http.Server's WriteTimeout set, as SERVER_WRITE_TIMEOUT does.
+func newTimelineStreamServerWithWriteTimeout(t *testing.T, uc *timeline_stream_usecase.Usecase, userID uuid.UUID, heartbeat, writeTimeout time.Duration) (*httptest.Server, context.CancelFunc) {
+	t.Helper()
+	serverCtx, cancel := context.WithCancel(context.Background())
+	e := echo.New()
+	e.GET("/v1/timeline/stream", handleTimelineStream(serverCtx, uc, heartbeat), func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := domain.SetUserContext(c.Request().Context(), &domain.UserContext{
+				UserID:    userID,
+				Email:     "test@example.com",
+				ExpiresAt: time.Now().Add(time.Hour),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	srv := httptest.NewUnstartedServer(e)
+	srv.Config.WriteTimeout = writeTimeout
+	srv.Start()
+	t.Cleanup(func() {
+		cancel()
+		srv.Close()
+	})
+	return srv, cancel
+}
+
+func openTimelineStream(t *testing.T, srv *httptest.Server, lastEventID string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/v1/timeline/stream", nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func TestTimelineStream_DeliversTheUsersEvents(t *testing.T) {
+	uc := timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{})
+	userID := uuid.New()
+	srv, _ := newTimelineStreamServer(t, uc, userID, time.Minute)
+
+	res := openTimelineStream(t, srv, "")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream; charset=utf-8", res.Header.Get(echo.HeaderContentType))
+	r := bufio.NewReader(res.Body)
+
+	ready := nextSSEEvent(t, r)
+	assert.Equal(t, "ready", ready.event)
+	assert.NotEmpty(t, ready.id)
+
+	uc.Publish(domain.TimelineEvent{Type: domain.TimelineEventArticleCreated, UserID: uuid.NewString(), ArticleID: "other"})
+	uc.Publish(domain.TimelineEvent{Type: domain.TimelineEventArticleCreated, UserID: userID.String(), ArticleID: "a1", Title: "Hello"})
+
+	got := nextSSEEvent(t, r)
+	assert.Equal(t, "article_created", got.event)
+	assert.NotEmpty(t, got.id)
+	assert.NotEqual(t, ready.id, got.id)
+	assert.JSONEq(t, `{"type":"article_created","article_id":"a1","title":"Hello","occurred_at":"0001-01-01T00:00:00Z"}`, got.data)
+}
+
+func TestTimelineStream_ResumesFromLastEventID(t *testing.T) {
+	uc := timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{})
+	userID := uuid.New()
+	srv, _ := newTimelineStreamServer(t, uc, userID, time.Minute)
+
+	first := openTimelineStream(t, srv, "")
+	cursor := nextSSEEvent(t, bufio.NewReader(first.Body)).id
+	first.Body.Close()
+
+	uc.Publish(domain.TimelineEvent{Type: domain.TimelineEventArticleCreated, UserID: userID.String(), ArticleID: "missed"})
+
+	r := bufio.NewReader(openTimelineStream(t, srv, cursor).Body)
+	replayed := nextSSEEvent(t, r)
+	assert.Equal(t, "article_created", replayed.event)
+	assert.Contains(t, replayed.data, `"missed"`)
+	assert.Equal(t, "ready", nextSSEEvent(t, r).event)
+
+	r = bufio.NewReader(openTimelineStream(t, srv, "unknown-1").Body)
+	assert.Equal(t, "reset", nextSSEEvent(t, r).event)
+	assert.Equal(t, "ready", nextSSEEvent(t, r).event)
+}
+
+func TestTimelineStream_Heartbeat(t *testing.T) {
+	uc := timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{})
+	srv, _ := newTimelineStreamServer(t, uc, uuid.New(), 10*time.Millisecond)
+
+	r := bufio.NewReader(openTimelineStream(t, srv, "").Body)
+	nextSSEEvent(t, r)
+
+	assert.True(t, readSSEBlock(t, r).comment)
+}
+
+func TestTimelineStream_OutlivesServerWriteTimeout(t *testing.T) {
+	uc := timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{})
+	userID := uuid.New()
+	srv, _ := newTimelineStreamServerWithWriteTimeout(t, uc, userID, 20*time.Millisecond, 100*time.Millisecond)
+
+	r := bufio.NewReader(openTimelineStream(t, srv, "").Body)
+	require.Equal(t, "ready", nextSSEEvent(t, r).event)
+
+	time.Sleep(300 * time.Millisecond)
+	uc.Publish(domain.TimelineEvent{Type: domain.TimelineEventArticleCreated, UserID: userID.String(), ArticleID: "a1"})
+
+	assert.Equal(t, string(domain.TimelineEventArticleCreated), nextSSEEvent(t, r).event)
+}
+
+func TestTimelineStream_ConnectionLimit(t *testing.T) {
+	uc := timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{MaxConnectionsPerUser: 1})
+	srv, _ := newTimelineStreamServer(t, uc, uuid.New(), time.Minute)
+
+	first := openTimelineStream(t, srv, "")
+	require.Equal(t, http.StatusOK, first.StatusCode)
+
+	second := openTimelineStream(t, srv, "")
+	assert.Equal(t, http.StatusTooManyRequests, second.StatusCode)
+	assert.Equal(t, timelineBusyRetryAfter, second.Header.Get("Retry-After"))
+}
+
+func TestTimelineStream_EndsOnServerShutdown(t *testing.T) {
+	uc := timeline_stream_usecase.NewUsecase(timeline_stream_usecase.Config{})
+	srv, shutdown := newTimelineStreamServer(t, uc, uuid.New(), time.Minute)
+
+	res := openTimelineStream(t, srv, "")
+	r := bufio.NewReader(res.Body)
+	nextSSEEvent(t, r)
+
+	shutdown()
+	_, err := io.ReadAll(r)
+	assert.NoError(t, err, "the stream ends cleanly")
+	assert.Eventually(t, func() bool { return uc.ConnectionCount() == 0 }, time.Second, 10*time.Millisecond)
+}
@@ -23,7 +23,10 @@ func NewArchiveLensUsecase(
 	}
 }
 
-func (u *ArchiveLensUsecase) Execute(ctx context.Context, userID uuid.UUID, lensID uuid.UUID) error {
+// Execute archives the user's lens. A non-zero expectedRevision (If-Match)
+// makes it fail with domain.ErrLensRevisionConflict if the lens has changed
+// since the client read it.
+func (u *ArchiveLensUsecase) Execute(ctx context.Context, userID uuid.UUID, lensID uuid.UUID, expectedRevision int64) error {
 	lens, err := u.getLens.GetLens(ctx, lensID)
 	if err != nil {
 		return fmt.Errorf("get lens: %w", err)
@@ -32,7 +35,7 @@ func (u *ArchiveLensUsecase) Execute(ctx context.Context, userID uuid.UUID, lens
 		return fmt.Errorf("lens does not belong to user")
 	}
 
-	if err := u.archivePort.ArchiveLens(ctx, lensID); err != nil {
+	if err := u.archivePort.ArchiveLens(ctx, lensID, expectedRevision); err != nil {
 		return fmt.Errorf("archive lens: %w", err)
 	}
 
@@ -21,22 +21,29 @@ func (m *mockGetLensPort) GetLens(_ context.Context, _ uuid.UUID) (*domain.Knowl
 	return m.lens, nil
 }
 
-type mockArchiveLensPort struct{ called bool }
+type mockArchiveLensPort struct {
+	called      bool
+	gotExpected int64
+}
 
-func (m *mockArchiveLensPort) ArchiveLens(_ context.Context, _ uuid.UUID) error {
+func (m *mockArchiveLensPort) ArchiveLens(_ context.Context, _ uuid.UUID, expectedRevision int64) error {
 	m.called = true
+	m.gotExpected = expectedRevision
 	return nil
 }
 
 func TestArchiveLens_Success(t *testing.T) {
 	userID := uuid.New()
 	lensID := uuid.New()
+	archivePort := &mockArchiveLensPort{}
 	uc := NewArchiveLensUsecase(
 		&mockGetLensPort{lens: &domain.KnowledgeLens{LensID: lensID, UserID: userID}},
-		&mockArchiveLensPort{},
+		archivePort,
 	)
 
-	err := uc.Execute(context.Background(), userID, lensID)
+	err := uc.Execute(context.Background(), userID, lensID, 7)
 
 	require.NoError(t, err)
+	require.True(t, archivePort.called)
+	require.Equal(t, int64(7), archivePort.gotExpected)
 }
@@ -0,0 +1,174 @@
+package article_audio_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/article_audio_port"
+	"alt/shared/port/event_publisher_port"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPendingTimeout = 30 * time.Minute
+	// maxErrorMessageLength bounds the worker-supplied failure reason
+	// stored and shown to the player.
+	maxErrorMessageLength = 500
+	enqueueFailedMessage  = "failed to enqueue audio generation"
+)
+
+// voicePattern matches the TTS service's voice identifiers.
+var voicePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// ErrInvalidInput is returned for requests and reports that fail validation.
+var ErrInvalidInput = errors.New("invalid article audio input")
+
+// Config tunes audio generation. Zero values select the defaults.
+type Config struct {
+	// PendingTimeout is how long an attempt may go without a worker
+	// report before a new request for the same article starts over.
+	PendingTimeout time.Duration
+}
+
+// Usecase requests TTS audio for a user's articles through mq-hub, serves
+// its status to the player, and records what the TTS worker reports back.
+//
+// Requests are idempotent: while audio is ready or an attempt is in
+// flight, requesting again returns the current state without publishing.
+// A failed attempt, or one the worker has been silent on for longer than
+// PendingTimeout, is retried.
+type Usecase struct {
+	audio     article_audio_port.ArticleAudioPort
+	publisher event_publisher_port.EventPublisherPort
+	cfg       Config
+	now       func() time.Time
+}
+
+// NewUsecase creates a new article audio usecase. publisher may be nil, in
+// which case RequestAudio returns domain.ErrAudioGenerationUnavailable.
+func NewUsecase(
+	audio article_audio_port.ArticleAudioPort,
+	publisher event_publisher_port.EventPublisherPort,
+	cfg Config,
+) *Usecase {
+	if cfg.PendingTimeout <= 0 {
+		cfg.PendingTimeout = defaultPendingTimeout
+	}
+	return &Usecase{audio: audio, publisher: publisher, cfg: cfg, now: time.Now}
+}
+
+// RequestAudio asks for audio of one of userID's articles. An empty voice
+// selects the TTS service's default.
+func (u *Usecase) RequestAudio(ctx context.Context, userID, articleID uuid.UUID, voice string) (*domain.ArticleAudio, error) {
+	if articleID == uuid.Nil {
+		return nil, fmt.Errorf("%w: article id is required", ErrInvalidInput)
+	}
+	if voice != "" && !voicePattern.MatchString(voice) {
+		return nil, fmt.Errorf("%w: invalid voice %q", ErrInvalidInput, voice)
+	}
+	if u.publisher == nil || !u.publisher.IsEnabled() {
+		return nil, domain.ErrAudioGenerationUnavailable
+	}
+
+	now := u.now().UTC()
+	audio, started, err := u.audio.RequestArticleAudio(ctx, articleID, userID, voice, now, now.Add(-u.cfg.PendingTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("request article audio: %w", err)
+	}
+	if !started {
+		return audio, nil
+	}
+
+	err = u.publisher.PublishAudioGenerationRequested(ctx, event_publisher_port.AudioGenerationRequestedEvent{
+		ArticleID: articleID.String(),
+		UserID:    userID.String(),
+		Voice:     voice,
+		Attempt:   audio.Attempts,
+	})
+	if err != nil {
+		// Fail the attempt so the next request retries at once instead of
+		// waiting out PendingTimeout for a worker that never heard of it.
+		if _, markErr := u.audio.ReportArticleAudio(ctx, &domain.ArticleAudioReport{
+			ArticleID:    articleID,
+			Attempt:      audio.Attempts,
+			Status:       domain.ArticleAudioStatusFailed,
+			ErrorMessage: enqueueFailedMessage,
+		}, u.now()); markErr != nil {
+			logger.Logger.ErrorContext(ctx, "failed to mark article audio as failed",
+				"article_id", articleID, "attempt", audio.Attempts, "error", markErr)
+		}
+		return nil, fmt.Errorf("enqueue audio generation: %w", err)
+	}
+
+	logger.Logger.InfoContext(ctx, "article audio requested",
+		"article_id", articleID, "user_id", userID, "attempt", audio.Attempts, "voice", voice)
+	return audio, nil
+}
+
+// GetAudio returns the audio, or the generation status, for one of
+// userID's articles. It returns domain.ErrArticleAudioNotFound when audio
+// was never requested.
+func (u *Usecase) GetAudio(ctx context.Context, userID, articleID uuid.UUID) (*domain.ArticleAudio, error) {
+	if articleID == uuid.Nil {
+		return nil, fmt.Errorf("%w: article id is required", ErrInvalidInput)
+	}
+	audio, err := u.audio.FetchArticleAudio(ctx, articleID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch article audio: %w", err)
+	}
+	return audio, nil
+}
+
+// ReportAudio records a TTS worker's progress on one attempt. Ready
+// reports must carry the storage URL and a positive duration.
+func (u *Usecase) ReportAudio(ctx context.Context, report *domain.ArticleAudioReport) (*domain.ArticleAudio, error) {
+	if err := validateReport(report); err != nil {
+		return nil, err
+	}
+
+	audio, err := u.audio.ReportArticleAudio(ctx, report, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("report article audio: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "article audio status reported",
+		"article_id", report.ArticleID, "attempt", report.Attempt, "status", report.Status)
+	return audio, nil
+}
+
+func validateReport(report *domain.ArticleAudioReport) error {
+	if report == nil || report.ArticleID == uuid.Nil {
+		return fmt.Errorf("%w: article id is required", ErrInvalidInput)
+	}
+	if report.Attempt <= 0 {
+		return fmt.Errorf("%w: attempt must be positive", ErrInvalidInput)
+	}
+
+	switch report.Status {
+	case domain.ArticleAudioStatusProcessing:
+	case domain.ArticleAudioStatusReady:
+		if report.DurationSeconds <= 0 || math.IsInf(report.DurationSeconds, 0) || math.IsNaN(report.DurationSeconds) {
+			return fmt.Errorf("%w: duration_seconds must be positive", ErrInvalidInput)
+		}
+		parsed, err := url.Parse(report.StorageURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return fmt.Errorf("%w: storage_url must be an absolute http(s) URL", ErrInvalidInput)
+		}
+	case domain.ArticleAudioStatusFailed:
+		if report.ErrorMessage == "" {
+			report.ErrorMessage = "audio generation failed"
+		}
+		if runes := []rune(report.ErrorMessage); len(runes) > maxErrorMessageLength {
+			report.ErrorMessage = string(runes[:maxErrorMessageLength])
+		}
+	default:
+		return fmt.Errorf("%w: status must be processing, ready or failed", ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package article_audio_usecase
+
+import (
+	"alt/domain"
+	"alt/mocks"
+	"alt/shared/port/event_publisher_port"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeAudio mirrors the driver contract: audio only attaches to articles
+// their user owns, new attempts start only when one is due, and reports
+// only apply to the current in-flight attempt.
+type fakeAudio struct {
+	owners map[uuid.UUID]uuid.UUID
+	rows   map[uuid.UUID]*domain.ArticleAudio
+}
+
+func newFakeAudio() *fakeAudio {
+	return &fakeAudio{owners: map[uuid.UUID]uuid.UUID{}, rows: map[uuid.UUID]*domain.ArticleAudio{}}
+}
+
+func (f *fakeAudio) FetchArticleAudio(_ context.Context, articleID, userID uuid.UUID) (*domain.ArticleAudio, error) {
+	row, ok := f.rows[articleID]
+	if !ok || row.UserID != userID {
+		return nil, domain.ErrArticleAudioNotFound
+	}
+	return row, nil
+}
+
+func (f *fakeAudio) RequestArticleAudio(_ context.Context, articleID, userID uuid.UUID, voice string, now, staleBefore time.Time) (*domain.ArticleAudio, bool, error) {
+	if f.owners[articleID] != userID {
+		return nil, false, domain.ErrItemNotFound
+	}
+	row, ok := f.rows[articleID]
+	if ok && !(row.Status == domain.ArticleAudioStatusFailed || (row.Status.InFlight() && row.UpdatedAt.Before(staleBefore))) {
+		return row, false, nil
+	}
+	attempts := 1
+	if ok {
+		attempts = row.Attempts + 1
+	}
+	row = &domain.ArticleAudio{
+		ArticleID:   articleID,
+		UserID:      userID,
+		Status:      domain.ArticleAudioStatusPending,
+		Voice:       voice,
+		Attempts:    attempts,
+		RequestedAt: now,
+		UpdatedAt:   now,
+	}
+	f.rows[articleID] = row
+	return row, true, nil
+}
+
+func (f *fakeAudio) ReportArticleAudio(_ context.Context, report *domain.ArticleAudioReport, now time.Time) (*domain.ArticleAudio, error) {
+	row, ok := f.rows[report.ArticleID]
+	if !ok {
+		return nil, domain.ErrArticleAudioNotFound
+	}
+	if row.Attempts != report.Attempt || !row.Status.InFlight() {
+		return nil, domain.ErrArticleAudioConflict
+	}
+	row.Status = report.Status
+	row.DurationSeconds = report.DurationSeconds
+	row.StorageURL = report.StorageURL
+	row.ErrorMessage = report.ErrorMessage
+	row.UpdatedAt = now
+	return row, nil
+}
+
+func newTestUsecase(t *testing.T) (*Usecase, *fakeAudio, *mocks.MockEventPublisherPort, *time.Time) {
+	ctrl := gomock.NewController(t)
+	publisher := mocks.NewMockEventPublisherPort(ctrl)
+	publisher.EXPECT().IsEnabled().Return(true).AnyTimes()
+	store := newFakeAudio()
+	uc := NewUsecase(store, publisher, Config{})
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	return uc, store, publisher, &now
+}
+
+func TestRequestAudio_PublishesOnceWhileInFlight(t *testing.T) {
+	uc, store, publisher, _ := newTestUsecase(t)
+	ctx := context.Background()
+	userID, articleID := uuid.New(), uuid.New()
+	store.owners[articleID] = userID
+
+	publisher.EXPECT().PublishAudioGenerationRequested(ctx, event_publisher_port.AudioGenerationRequestedEvent{
+		ArticleID: articleID.String(),
+		UserID:    userID.String(),
+		Voice:     "alloy",
+		Attempt:   1,
+	}).Return(nil).Times(1)
+
+	audio, err := uc.RequestAudio(ctx, userID, articleID, "alloy")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleAudioStatusPending, audio.Status)
+
+	audio, err = uc.RequestAudio(ctx, userID, articleID, "alloy")
+	require.NoError(t, err)
+	assert.Equal(t, 1, audio.Attempts)
+}
+
+func TestRequestAudio_RetriesStaleAttempt(t *testing.T) {
+	uc, store, publisher, now := newTestUsecase(t)
+	ctx := context.Background()
+	userID, articleID := uuid.New(), uuid.New()
+	store.owners[articleID] = userID
+
+	publisher.EXPECT().PublishAudioGenerationRequested(ctx, gomock.Any()).Return(nil).Times(2)
+
+	_, err := uc.RequestAudio(ctx, userID, articleID, "")
+	require.NoError(t, err)
+
+	*now = now.Add(defaultPendingTimeout + time.Minute)
+	audio, err := uc.RequestAudio(ctx, userID, articleID, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, audio.Attempts)
+}
+
+func TestRequestAudio_PublishFailureFailsAttempt(t *testing.T) {
+	uc, store, publisher, _ := newTestUsecase(t)
+	ctx := context.Background()
+	userID, articleID := uuid.New(), uuid.New()
+	store.owners[articleID] = userID
+
+	publisher.EXPECT().PublishAudioGenerationRequested(ctx, gomock.Any()).Return(errors.New("mq-hub down"))
+
+	_, err := uc.RequestAudio(ctx, userID, articleID, "")
+	require.Error(t, err)
+	assert.Equal(t, domain.ArticleAudioStatusFailed, store.rows[articleID].Status)
+	assert.Equal(t, enqueueFailedMessage, store.rows[articleID].ErrorMessage)
+}
+
+func TestRequestAudio_Validation(t *testing.T) {
+	uc, store, _, _ := newTestUsecase(t)
+	ctx := context.Background()
+	userID, articleID := uuid.New(), uuid.New()
+
+	_, err := uc.RequestAudio(ctx, userID, uuid.Nil, "")
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = uc.RequestAudio(ctx, userID, articleID, "../etc")
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = uc.RequestAudio(ctx, userID, articleID, "")
+	require.ErrorIs(t, err, domain.ErrItemNotFound)
+	assert.Empty(t, store.rows)
+}
+
+func TestRequestAudio_PublisherDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	publisher := mocks.NewMockEventPublisherPort(ctrl)
+	publisher.EXPECT().IsEnabled().Return(false)
+	uc := NewUsecase(newFakeAudio(), publisher, Config{})
+
+	_, err := uc.RequestAudio(context.Background(), uuid.New(), uuid.New(), "")
+	require.ErrorIs(t, err, domain.ErrAudioGenerationUnavailable)
+
+	uc = NewUsecase(newFakeAudio(), nil, Config{})
+	_, err = uc.RequestAudio(context.Background(), uuid.New(), uuid.New(), "")
+	require.ErrorIs(t, err, domain.ErrAudioGenerationUnavailable)
+}
+
+func TestReportAudio(t *testing.T) {
+	uc, store, publisher, _ := newTestUsecase(t)
+	ctx := context.Background()
+	userID, articleID := uuid.New(), uuid.New()
+	store.owners[articleID] = userID
+	publisher.EXPECT().PublishAudioGenerationRequested(ctx, gomock.Any()).Return(nil)
+
+	_, err := uc.RequestAudio(ctx, userID, articleID, "")
+	require.NoError(t, err)
+
+	_, err = uc.ReportAudio(ctx, &domain.ArticleAudioReport{
+		ArticleID: articleID, Attempt: 1, Status: domain.ArticleAudioStatusReady, StorageURL: "s3://bucket/a.mp3", DurationSeconds: 12,
+	})
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = uc.ReportAudio(ctx, &domain.ArticleAudioReport{
+		ArticleID: articleID, Attempt: 1, Status: domain.ArticleAudioStatusReady, StorageURL: "https://cdn.example.com/a.mp3",
+	})
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = uc.ReportAudio(ctx, &domain.ArticleAudioReport{
+		ArticleID: articleID, Attempt: 1, Status: domain.ArticleAudioStatusPending,
+	})
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = uc.ReportAudio(ctx, &domain.ArticleAudioReport{
+		ArticleID: articleID, Attempt: 2, Status: domain.ArticleAudioStatusProcessing,
+	})
+	require.ErrorIs(t, err, domain.ErrArticleAudioConflict)
+
+	audio, err := uc.ReportAudio(ctx, &domain.ArticleAudioReport{
+		ArticleID: articleID, Attempt: 1, Status: domain.ArticleAudioStatusReady,
+		StorageURL: "https://cdn.example.com/a.mp3", DurationSeconds: 183.5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleAudioStatusReady, audio.Status)
+
+	got, err := uc.GetAudio(ctx, userID, articleID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/a.mp3", got.StorageURL)
+	assert.InDelta(t, 183.5, got.DurationSeconds, 1e-9)
+
+	_, err = uc.GetAudio(ctx, uuid.New(), articleID)
+	require.ErrorIs(t, err, domain.ErrArticleAudioNotFound)
+}
@@ -0,0 +1,143 @@
+package article_index_outbox_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/article_index_outbox_port"
+	"alt/shared/port/event_publisher_port"
+	"alt/utils/logger"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBatchSize = 100
+
+	// A failed publish is retried after retryBackoff, doubling up to
+	// maxRetryBackoff. Articles are never dropped from the queue.
+	retryBackoff    = 30 * time.Second
+	maxRetryBackoff = 30 * time.Minute
+)
+
+// Config tunes the index outbox. Zero values select the defaults.
+type Config struct {
+	// BatchSize is the number of queued articles one RunBatch call publishes.
+	BatchSize int
+}
+
+// Usecase drains the article index outbox. Writes that change an article's
+// indexed fields mark it dirty in their own transaction; RunBatch publishes
+// an ArticleUpdated event with the article's current title, content and
+// tags for each marked article, which search-indexer and rag-orchestrator
+// consume to reindex just that article.
+type Usecase struct {
+	store     article_index_outbox_port.ArticleIndexOutboxPort
+	publisher event_publisher_port.EventPublisherPort
+	cfg       Config
+	now       func() time.Time
+}
+
+// NewUsecase creates a new article index outbox usecase. publisher should
+// record events in the event outbox, so a published article survives an
+// mq-hub outage once it leaves the index outbox.
+func NewUsecase(
+	store article_index_outbox_port.ArticleIndexOutboxPort,
+	publisher event_publisher_port.EventPublisherPort,
+	cfg Config,
+) *Usecase {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	return &Usecase{store: store, publisher: publisher, cfg: cfg, now: time.Now}
+}
+
+// MarkForReindex queues an article on an admin's request, whether or not it
+// changed. It returns domain.ErrArticleNotFound for unknown or deleted
+// articles.
+func (u *Usecase) MarkForReindex(ctx context.Context, articleID uuid.UUID) (*domain.DirtyArticle, error) {
+	dirty, err := u.store.MarkArticleIndexDirty(ctx, articleID, domain.ArticleIndexForced, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("mark article %s for reindex: %w", articleID, err)
+	}
+	logger.Logger.InfoContext(ctx, "article queued for reindex", "article_id", articleID)
+	return dirty, nil
+}
+
+// RunBatch publishes up to BatchSize due articles and returns how many it
+// published. Articles deleted since they were marked are dequeued without
+// an event, since their ArticleDeleted event removes them from the indexes.
+// A failed publish is rescheduled with backoff; an error reading or
+// dequeuing stops the batch, and the article is picked up again next run.
+// While event publishing is disabled nothing is dequeued.
+func (u *Usecase) RunBatch(ctx context.Context) (int, error) {
+	if !u.publisher.IsEnabled() {
+		return 0, nil
+	}
+
+	dirty, err := u.store.ListDirtyArticles(ctx, u.now(), u.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list dirty articles: %w", err)
+	}
+
+	published := 0
+	for _, d := range dirty {
+		if err := ctx.Err(); err != nil {
+			return published, err
+		}
+
+		doc, err := u.store.FetchArticleIndexDocument(ctx, d.ArticleID)
+		if err != nil {
+			return published, fmt.Errorf("fetch article %s: %w", d.ArticleID, err)
+		}
+		if doc != nil {
+			if err := u.publish(ctx, doc); err != nil {
+				u.reschedule(ctx, d, err)
+				continue
+			}
+			published++
+		}
+		if err := u.store.ClearDirtyArticle(ctx, d.ArticleID, d.MarkedAt); err != nil {
+			return published, fmt.Errorf("clear dirty article %s: %w", d.ArticleID, err)
+		}
+	}
+
+	if len(dirty) > 0 {
+		logger.Logger.InfoContext(ctx, "article index outbox batch done",
+			"queued", len(dirty), "published", published)
+	}
+	return published, nil
+}
+
+func (u *Usecase) publish(ctx context.Context, doc *domain.ArticleIndexDocument) error {
+	return u.publisher.PublishArticleUpdated(ctx, event_publisher_port.ArticleUpdatedEvent{
+		ArticleID:   doc.ArticleID,
+		UserID:      doc.UserID,
+		FeedID:      doc.FeedID,
+		Title:       doc.Title,
+		URL:         doc.URL,
+		Content:     doc.Content,
+		Tags:        doc.Tags,
+		PublishedAt: doc.PublishedAt,
+	})
+}
+
+func (u *Usecase) reschedule(ctx context.Context, d *domain.DirtyArticle, cause error) {
+	attempt := d.Attempts + 1
+	retryAt := u.now().Add(retryDelay(attempt))
+	logger.Logger.WarnContext(ctx, "failed to publish article for reindex, will retry",
+		"article_id", d.ArticleID, "attempts", attempt, "retry_at", retryAt, "error", cause)
+	if err := u.store.RescheduleDirtyArticle(ctx, d.ArticleID, d.MarkedAt, cause.Error(), retryAt); err != nil {
+		logger.Logger.ErrorContext(ctx, "failed to reschedule dirty article", "article_id", d.ArticleID, "error", err)
+	}
+}
+
+// retryDelay is the wait before publish attempt+1.
+func retryDelay(attempt int) time.Duration {
+	delay := retryBackoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
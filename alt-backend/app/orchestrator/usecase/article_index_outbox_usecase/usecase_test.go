@@ -0,0 +1,217 @@
+package article_index_outbox_usecase
+
+import (
+	"alt/domain"
+	"alt/mocks"
+	"alt/shared/port/event_publisher_port"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeStore keeps the queue and the articles in memory. Marking an article
+// again replaces its row, like the upsert in the database.
+type fakeStore struct {
+	queue       map[uuid.UUID]*domain.DirtyArticle
+	availableAt map[uuid.UUID]time.Time
+	docs        map[uuid.UUID]*domain.ArticleIndexDocument
+	fetchErr    error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		queue:       map[uuid.UUID]*domain.DirtyArticle{},
+		availableAt: map[uuid.UUID]time.Time{},
+		docs:        map[uuid.UUID]*domain.ArticleIndexDocument{},
+	}
+}
+
+func (f *fakeStore) MarkArticleIndexDirty(_ context.Context, articleID uuid.UUID, reason domain.ArticleIndexReason, now time.Time) (*domain.DirtyArticle, error) {
+	if reason == domain.ArticleIndexForced && f.docs[articleID] == nil {
+		return nil, domain.ErrArticleNotFound
+	}
+	d := &domain.DirtyArticle{ArticleID: articleID, Reason: reason, MarkedAt: now}
+	f.queue[articleID] = d
+	f.availableAt[articleID] = now
+	return d, nil
+}
+
+func (f *fakeStore) ListDirtyArticles(_ context.Context, now time.Time, limit int) ([]*domain.DirtyArticle, error) {
+	out := []*domain.DirtyArticle{}
+	for id, d := range f.queue {
+		if len(out) == limit {
+			break
+		}
+		if f.availableAt[id].After(now) {
+			continue
+		}
+		clone := *d
+		out = append(out, &clone)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) FetchArticleIndexDocument(_ context.Context, articleID uuid.UUID) (*domain.ArticleIndexDocument, error) {
+	if f.fetchErr != nil {
+		return nil, f.fetchErr
+	}
+	return f.docs[articleID], nil
+}
+
+func (f *fakeStore) ClearDirtyArticle(_ context.Context, articleID uuid.UUID, markedAt time.Time) error {
+	if d, ok := f.queue[articleID]; ok && d.MarkedAt.Equal(markedAt) {
+		delete(f.queue, articleID)
+	}
+	return nil
+}
+
+func (f *fakeStore) RescheduleDirtyArticle(_ context.Context, articleID uuid.UUID, markedAt time.Time, _ string, retryAt time.Time) error {
+	if d, ok := f.queue[articleID]; ok && d.MarkedAt.Equal(markedAt) {
+		d.Attempts++
+		f.availableAt[articleID] = retryAt
+	}
+	return nil
+}
+
+func (f *fakeStore) addArticle(title string) uuid.UUID {
+	id := uuid.New()
+	f.docs[id] = &domain.ArticleIndexDocument{
+		ArticleID: id.String(),
+		UserID:    "user-1",
+		FeedID:    "feed-1",
+		Title:     title,
+		URL:       "https://example.com/" + title,
+		Content:   "content of " + title,
+		Tags:      []string{"go"},
+	}
+	return id
+}
+
+func newTestUsecase(t *testing.T, store *fakeStore) (*Usecase, *mocks.MockEventPublisherPort, *time.Time) {
+	ctrl := gomock.NewController(t)
+	publisher := mocks.NewMockEventPublisherPort(ctrl)
+	publisher.EXPECT().IsEnabled().Return(true).AnyTimes()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	uc := NewUsecase(store, publisher, Config{})
+	uc.now = func() time.Time { return now }
+	return uc, publisher, &now
+}
+
+func TestRunBatch_PublishesCurrentArticleAndDequeues(t *testing.T) {
+	store := newFakeStore()
+	uc, publisher, now := newTestUsecase(t, store)
+	id := store.addArticle("fixed")
+	_, err := store.MarkArticleIndexDirty(context.Background(), id, domain.ArticleIndexTitleFixed, *now)
+	require.NoError(t, err)
+
+	publisher.EXPECT().PublishArticleUpdated(gomock.Any(), event_publisher_port.ArticleUpdatedEvent{
+		ArticleID: id.String(),
+		UserID:    "user-1",
+		FeedID:    "feed-1",
+		Title:     "fixed",
+		URL:       "https://example.com/fixed",
+		Content:   "content of fixed",
+		Tags:      []string{"go"},
+	}).Return(nil)
+
+	published, err := uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Empty(t, store.queue)
+}
+
+func TestRunBatch_DeletedArticleIsDequeuedWithoutEvent(t *testing.T) {
+	store := newFakeStore()
+	uc, _, now := newTestUsecase(t, store)
+	id := uuid.New()
+	_, err := store.MarkArticleIndexDirty(context.Background(), id, domain.ArticleIndexTagsChanged, *now)
+	require.NoError(t, err)
+
+	published, err := uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, store.queue)
+}
+
+func TestRunBatch_FailedPublishIsRetriedWithBackoff(t *testing.T) {
+	store := newFakeStore()
+	uc, publisher, now := newTestUsecase(t, store)
+	id := store.addArticle("a")
+	_, err := store.MarkArticleIndexDirty(context.Background(), id, domain.ArticleIndexReconciled, *now)
+	require.NoError(t, err)
+
+	publisher.EXPECT().PublishArticleUpdated(gomock.Any(), gomock.Any()).Return(errors.New("outbox unavailable"))
+	published, err := uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	require.Contains(t, store.queue, id)
+	assert.Equal(t, 1, store.queue[id].Attempts)
+	assert.Equal(t, now.Add(retryBackoff), store.availableAt[id])
+
+	// Not due yet: nothing is published.
+	published, err = uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	*now = now.Add(retryBackoff)
+	publisher.EXPECT().PublishArticleUpdated(gomock.Any(), gomock.Any()).Return(nil)
+	published, err = uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Empty(t, store.queue)
+}
+
+func TestRunBatch_FetchErrorStopsBatchAndKeepsArticle(t *testing.T) {
+	store := newFakeStore()
+	uc, _, now := newTestUsecase(t, store)
+	id := store.addArticle("a")
+	_, err := store.MarkArticleIndexDirty(context.Background(), id, domain.ArticleIndexTitleFixed, *now)
+	require.NoError(t, err)
+	store.fetchErr = errors.New("connection reset")
+
+	_, err = uc.RunBatch(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, store.queue, id)
+}
+
+func TestRunBatch_DisabledPublisherLeavesQueue(t *testing.T) {
+	store := newFakeStore()
+	ctrl := gomock.NewController(t)
+	publisher := mocks.NewMockEventPublisherPort(ctrl)
+	publisher.EXPECT().IsEnabled().Return(false)
+	uc := NewUsecase(store, publisher, Config{})
+	id := store.addArticle("a")
+	_, err := store.MarkArticleIndexDirty(context.Background(), id, domain.ArticleIndexTitleFixed, time.Now())
+	require.NoError(t, err)
+
+	published, err := uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Contains(t, store.queue, id)
+}
+
+func TestMarkForReindex(t *testing.T) {
+	store := newFakeStore()
+	uc, _, now := newTestUsecase(t, store)
+	id := store.addArticle("a")
+
+	dirty, err := uc.MarkForReindex(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleIndexForced, dirty.Reason)
+	assert.Equal(t, *now, dirty.MarkedAt)
+
+	_, err = uc.MarkForReindex(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, domain.ErrArticleNotFound)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(1))
+	assert.Equal(t, time.Minute, retryDelay(2))
+	assert.Equal(t, maxRetryBackoff, retryDelay(20))
+}
@@ -0,0 +1,296 @@
+package article_reconciliation_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/article_reconciliation_port"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxRecordBatch bounds how many source records one report may carry.
+	MaxRecordBatch = 500
+
+	defaultBatchSize            = 500
+	defaultOrphanGracePeriod    = 24 * time.Hour
+	defaultPublishedAtTolerance = 48 * time.Hour
+
+	defaultReportLimit = 100
+	maxReportLimit     = 500
+)
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid reconciliation input")
+
+// Config tunes a reconciliation run. Zero values select the defaults.
+type Config struct {
+	// BatchSize is the number of records examined per run.
+	BatchSize int
+	// OrphanGracePeriod is how long a record may stay unmatched before it
+	// is reported as orphaned, giving the ingest pipeline time to create
+	// the article.
+	OrphanGracePeriod time.Duration
+	// PublishedAtTolerance is how far the source's and the article's
+	// publication times may drift before the record is a conflict.
+	PublishedAtTolerance time.Duration
+}
+
+// Usecase matches articles reported by ingest sources (pre-processor's
+// Inoreader pipeline) against alt-backend's articles by URL and GUID,
+// fills in missing metadata, links duplicate copies of the same article,
+// and reports records that match nothing or match inconsistently.
+type Usecase struct {
+	records    article_reconciliation_port.ArticleSourceRecordPort
+	candidates article_reconciliation_port.ReconciliationCandidatePort
+	cfg        Config
+	now        func() time.Time
+}
+
+// NewUsecase creates a new article reconciliation usecase.
+func NewUsecase(
+	records article_reconciliation_port.ArticleSourceRecordPort,
+	candidates article_reconciliation_port.ReconciliationCandidatePort,
+	cfg Config,
+) *Usecase {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.OrphanGracePeriod <= 0 {
+		cfg.OrphanGracePeriod = defaultOrphanGracePeriod
+	}
+	if cfg.PublishedAtTolerance <= 0 {
+		cfg.PublishedAtTolerance = defaultPublishedAtTolerance
+	}
+	return &Usecase{records: records, candidates: candidates, cfg: cfg, now: time.Now}
+}
+
+// RecordSources validates and stores records reported by an ingest source.
+// When the same (source, source_item_id) appears twice, the last one wins.
+func (u *Usecase) RecordSources(ctx context.Context, records []*domain.ArticleSourceRecord) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+	if len(records) > MaxRecordBatch {
+		return 0, fmt.Errorf("%w: at most %d records per request", ErrInvalidInput, MaxRecordBatch)
+	}
+
+	byKey := make(map[string]int, len(records))
+	unique := make([]*domain.ArticleSourceRecord, 0, len(records))
+	for i, rec := range records {
+		if rec == nil {
+			return 0, fmt.Errorf("%w: record %d is empty", ErrInvalidInput, i)
+		}
+		rec.Source = strings.TrimSpace(rec.Source)
+		rec.SourceItemID = strings.TrimSpace(rec.SourceItemID)
+		rec.GUID = strings.TrimSpace(rec.GUID)
+		if rec.Source == "" || rec.SourceItemID == "" {
+			return 0, fmt.Errorf("%w: record %d needs source and source_item_id", ErrInvalidInput, i)
+		}
+		if rec.UserID == uuid.Nil {
+			return 0, fmt.Errorf("%w: record %d needs user_id", ErrInvalidInput, i)
+		}
+		normalized, err := domain.NormalizeArticleURL(rec.URL)
+		if err != nil {
+			return 0, fmt.Errorf("%w: record %d: %w", ErrInvalidInput, i, err)
+		}
+		rec.URL = strings.TrimSpace(rec.URL)
+		rec.NormalizedURL = normalized
+
+		key := rec.Source + "\x00" + rec.SourceItemID
+		if j, seen := byKey[key]; seen {
+			unique[j] = rec
+			continue
+		}
+		byKey[key] = len(unique)
+		unique = append(unique, rec)
+	}
+
+	n, err := u.records.UpsertArticleSourceRecords(ctx, unique, u.now())
+	if err != nil {
+		return 0, fmt.Errorf("record article sources: %w", err)
+	}
+	return n, nil
+}
+
+// Reconcile examines one batch of pending and orphaned records. A failure
+// on one record is logged and counted, and the run moves on.
+func (u *Usecase) Reconcile(ctx context.Context) (*domain.ReconciliationRunResult, error) {
+	records, err := u.records.ListUnreconciledSourceRecords(ctx, u.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("list unreconciled source records: %w", err)
+	}
+
+	result := &domain.ReconciliationRunResult{}
+	for _, rec := range records {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		result.Scanned++
+
+		candidates, err := u.candidates.FindReconciliationCandidates(ctx, rec.UserID, candidateURLs(rec), rec.GUID)
+		if err != nil {
+			logger.Logger.ErrorContext(ctx, "failed to find reconciliation candidates", "record_id", rec.ID, "error", err)
+			result.Failed++
+			continue
+		}
+
+		now := u.now()
+		outcome := u.decide(rec, candidates, now)
+		if outcome == nil {
+			result.StillPending++
+			continue
+		}
+		if err := u.records.ApplyReconciliationOutcome(ctx, outcome, now); err != nil {
+			logger.Logger.ErrorContext(ctx, "failed to apply reconciliation outcome", "record_id", rec.ID, "error", err)
+			result.Failed++
+			continue
+		}
+
+		switch outcome.Status {
+		case domain.ArticleSourceMatched:
+			result.Matched++
+		case domain.ArticleSourceOrphaned:
+			result.Orphaned++
+		case domain.ArticleSourceConflict:
+			result.Conflicts++
+		}
+		if outcome.MergeTitle != "" || outcome.MergePublishedAt != nil {
+			result.MetadataMerged++
+		}
+		result.DuplicatesLinked += len(outcome.Duplicates)
+	}
+
+	logger.Logger.InfoContext(ctx, "article reconciliation completed",
+		"scanned", result.Scanned,
+		"matched", result.Matched,
+		"metadata_merged", result.MetadataMerged,
+		"duplicates_linked", result.DuplicatesLinked,
+		"orphaned", result.Orphaned,
+		"conflicts", result.Conflicts,
+		"still_pending", result.StillPending,
+		"failed", result.Failed)
+	return result, nil
+}
+
+// Report returns reconciliation totals and up to limit records in the
+// given statuses (orphaned and conflict when none are given).
+func (u *Usecase) Report(ctx context.Context, statuses []domain.ArticleSourceStatus, limit int) (*domain.ReconciliationReport, error) {
+	if len(statuses) == 0 {
+		statuses = []domain.ArticleSourceStatus{domain.ArticleSourceOrphaned, domain.ArticleSourceConflict}
+	}
+	for _, s := range statuses {
+		switch s {
+		case domain.ArticleSourcePending, domain.ArticleSourceMatched, domain.ArticleSourceOrphaned, domain.ArticleSourceConflict:
+		default:
+			return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, s)
+		}
+	}
+	if limit <= 0 {
+		limit = defaultReportLimit
+	}
+	if limit > maxReportLimit {
+		limit = maxReportLimit
+	}
+
+	report, err := u.records.FetchReconciliationReport(ctx, statuses, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fetch reconciliation report: %w", err)
+	}
+	return report, nil
+}
+
+// decide turns a record and its candidate articles into an outcome. It
+// returns nil when an unmatched record is still within the grace period.
+func (u *Usecase) decide(rec *domain.ArticleSourceRecord, candidates []*domain.ReconciliationCandidate, now time.Time) *domain.ReconciliationOutcome {
+	outcome := &domain.ReconciliationOutcome{RecordID: rec.ID}
+
+	if len(candidates) == 0 {
+		if rec.Status != domain.ArticleSourceOrphaned && now.Sub(rec.FirstSeenAt) < u.cfg.OrphanGracePeriod {
+			return nil
+		}
+		outcome.Status = domain.ArticleSourceOrphaned
+		return outcome
+	}
+
+	if spansFeeds(candidates) {
+		outcome.Status = domain.ArticleSourceConflict
+		outcome.ConflictReason = domain.ReconcileConflictMultipleFeeds
+		return outcome
+	}
+
+	// Candidates come oldest first: the first article ingested is canonical.
+	canonical := candidates[0]
+	outcome.ArticleID = &canonical.ArticleID
+	for _, dup := range candidates[1:] {
+		outcome.Duplicates = append(outcome.Duplicates, domain.ArticleDuplicateLink{
+			CanonicalArticleID: canonical.ArticleID,
+			DuplicateArticleID: dup.ArticleID,
+			MatchKey:           matchKey(rec, dup),
+		})
+	}
+
+	if strings.TrimSpace(canonical.Title) == "" && strings.TrimSpace(rec.Title) != "" {
+		outcome.MergeTitle = strings.TrimSpace(rec.Title)
+	}
+	if canonical.PublishedAt == nil && rec.PublishedAt != nil {
+		outcome.MergePublishedAt = rec.PublishedAt
+	}
+
+	outcome.Status = domain.ArticleSourceMatched
+	if canonical.PublishedAt != nil && rec.PublishedAt != nil &&
+		absDuration(canonical.PublishedAt.Sub(*rec.PublishedAt)) > u.cfg.PublishedAtTolerance {
+		outcome.Status = domain.ArticleSourceConflict
+		outcome.ConflictReason = domain.ReconcileConflictPublishedAt
+	}
+	return outcome
+}
+
+// candidateURLs lists the URL forms an article for rec may be stored under.
+func candidateURLs(rec *domain.ArticleSourceRecord) []string {
+	if rec.URL == rec.NormalizedURL {
+		return []string{rec.URL}
+	}
+	return []string{rec.URL, rec.NormalizedURL}
+}
+
+// spansFeeds reports whether the candidates belong to more than one feed.
+// Articles without a feed do not count.
+func spansFeeds(candidates []*domain.ReconciliationCandidate) bool {
+	var feed *uuid.UUID
+	for _, c := range candidates {
+		if c.FeedID == nil {
+			continue
+		}
+		if feed == nil {
+			feed = c.FeedID
+		} else if *feed != *c.FeedID {
+			return true
+		}
+	}
+	return false
+}
+
+// matchKey explains why dup was linked: its URL normalizes to the record's,
+// or else it was found through the record's GUID.
+func matchKey(rec *domain.ArticleSourceRecord, dup *domain.ReconciliationCandidate) string {
+	if normalized, err := domain.NormalizeArticleURL(dup.URL); err == nil && normalized == rec.NormalizedURL {
+		return "url:" + rec.NormalizedURL
+	}
+	if rec.GUID != "" {
+		return "guid:" + rec.GUID
+	}
+	return "url:" + rec.NormalizedURL
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,246 @@
+package article_reconciliation_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps records in memory and serves candidates keyed by the
+// record they are for.
+type fakeStore struct {
+	records    []*domain.ArticleSourceRecord
+	upserted   []*domain.ArticleSourceRecord
+	candidates map[string][]*domain.ReconciliationCandidate
+	findErr    error
+	applied    []*domain.ReconciliationOutcome
+	report     *domain.ReconciliationReport
+	statuses   []domain.ArticleSourceStatus
+	limit      int
+}
+
+func (f *fakeStore) UpsertArticleSourceRecords(_ context.Context, records []*domain.ArticleSourceRecord, _ time.Time) (int64, error) {
+	f.upserted = records
+	return int64(len(records)), nil
+}
+
+func (f *fakeStore) ListUnreconciledSourceRecords(_ context.Context, _ int) ([]*domain.ArticleSourceRecord, error) {
+	return f.records, nil
+}
+
+func (f *fakeStore) ApplyReconciliationOutcome(_ context.Context, outcome *domain.ReconciliationOutcome, _ time.Time) error {
+	f.applied = append(f.applied, outcome)
+	return nil
+}
+
+func (f *fakeStore) FetchReconciliationReport(_ context.Context, statuses []domain.ArticleSourceStatus, limit int) (*domain.ReconciliationReport, error) {
+	f.statuses, f.limit = statuses, limit
+	return f.report, nil
+}
+
+func (f *fakeStore) FindReconciliationCandidates(_ context.Context, _ uuid.UUID, urls []string, _ string) ([]*domain.ReconciliationCandidate, error) {
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	return f.candidates[urls[0]], nil
+}
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newTestUsecase(store *fakeStore) *Usecase {
+	uc := NewUsecase(store, store, Config{})
+	uc.now = func() time.Time { return testNow }
+	return uc
+}
+
+func sourceRecord(url string, firstSeen time.Time) *domain.ArticleSourceRecord {
+	normalized, _ := domain.NormalizeArticleURL(url)
+	return &domain.ArticleSourceRecord{
+		ID:            uuid.New(),
+		Source:        domain.ArticleSourceInoreader,
+		SourceItemID:  "tag:google.com,2005:reader/item/" + url,
+		UserID:        uuid.New(),
+		URL:           url,
+		NormalizedURL: normalized,
+		Status:        domain.ArticleSourcePending,
+		FirstSeenAt:   firstSeen,
+	}
+}
+
+func TestRecordSources_NormalizesAndDedupes(t *testing.T) {
+	store := &fakeStore{}
+	uc := newTestUsecase(store)
+	userID := uuid.New()
+
+	first := &domain.ArticleSourceRecord{Source: "inoreader", SourceItemID: "item-1", UserID: userID, URL: "https://example.com/a?utm_source=x"}
+	again := &domain.ArticleSourceRecord{Source: "inoreader", SourceItemID: "item-1", UserID: userID, URL: "https://example.com/a/", Title: "A"}
+	other := &domain.ArticleSourceRecord{Source: "inoreader", SourceItemID: "item-2", UserID: userID, URL: "https://example.com/b"}
+
+	n, err := uc.RecordSources(context.Background(), []*domain.ArticleSourceRecord{first, again, other})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+	require.Len(t, store.upserted, 2)
+	assert.Equal(t, "A", store.upserted[0].Title, "last duplicate wins")
+	assert.Equal(t, "https://example.com/a", store.upserted[0].NormalizedURL)
+}
+
+func TestRecordSources_Validation(t *testing.T) {
+	uc := newTestUsecase(&fakeStore{})
+	userID := uuid.New()
+
+	for name, rec := range map[string]*domain.ArticleSourceRecord{
+		"missing source":  {SourceItemID: "1", UserID: userID, URL: "https://example.com"},
+		"missing item id": {Source: "inoreader", UserID: userID, URL: "https://example.com"},
+		"missing user":    {Source: "inoreader", SourceItemID: "1", URL: "https://example.com"},
+		"bad url":         {Source: "inoreader", SourceItemID: "1", UserID: userID, URL: "javascript:alert(1)"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := uc.RecordSources(context.Background(), []*domain.ArticleSourceRecord{rec})
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+
+	_, err := uc.RecordSources(context.Background(), make([]*domain.ArticleSourceRecord, MaxRecordBatch+1))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestReconcile_MatchesAndMergesMetadata(t *testing.T) {
+	published := testNow.Add(-2 * time.Hour)
+	rec := sourceRecord("https://example.com/post", testNow.Add(-time.Hour))
+	rec.Title = "From Inoreader"
+	rec.PublishedAt = &published
+	article := &domain.ReconciliationCandidate{ArticleID: uuid.New(), URL: rec.URL, CreatedAt: testNow.Add(-30 * time.Minute)}
+
+	store := &fakeStore{
+		records:    []*domain.ArticleSourceRecord{rec},
+		candidates: map[string][]*domain.ReconciliationCandidate{rec.URL: {article}},
+	}
+	result, err := newTestUsecase(store).Reconcile(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Matched)
+	assert.Equal(t, 1, result.MetadataMerged)
+	require.Len(t, store.applied, 1)
+	outcome := store.applied[0]
+	assert.Equal(t, domain.ArticleSourceMatched, outcome.Status)
+	assert.Equal(t, article.ArticleID, *outcome.ArticleID)
+	assert.Equal(t, "From Inoreader", outcome.MergeTitle)
+	assert.Equal(t, &published, outcome.MergePublishedAt)
+	assert.Empty(t, outcome.Duplicates)
+}
+
+func TestReconcile_LinksDuplicatesToOldest(t *testing.T) {
+	rec := sourceRecord("https://www.example.com/post/?utm_source=rss", testNow.Add(-time.Hour))
+	feed := uuid.New()
+	oldest := &domain.ReconciliationCandidate{ArticleID: uuid.New(), FeedID: &feed, URL: "https://example.com/post", Title: "Post", CreatedAt: testNow.Add(-3 * time.Hour)}
+	newer := &domain.ReconciliationCandidate{ArticleID: uuid.New(), FeedID: &feed, URL: rec.URL, Title: "Post", CreatedAt: testNow.Add(-time.Hour)}
+
+	store := &fakeStore{
+		records:    []*domain.ArticleSourceRecord{rec},
+		candidates: map[string][]*domain.ReconciliationCandidate{rec.URL: {oldest, newer}},
+	}
+	result, err := newTestUsecase(store).Reconcile(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.DuplicatesLinked)
+	outcome := store.applied[0]
+	assert.Equal(t, oldest.ArticleID, *outcome.ArticleID)
+	assert.Equal(t, []domain.ArticleDuplicateLink{{
+		CanonicalArticleID: oldest.ArticleID,
+		DuplicateArticleID: newer.ArticleID,
+		MatchKey:           "url:https://example.com/post",
+	}}, outcome.Duplicates)
+	assert.Empty(t, outcome.MergeTitle)
+}
+
+func TestReconcile_Conflicts(t *testing.T) {
+	t.Run("articles in different feeds", func(t *testing.T) {
+		rec := sourceRecord("https://example.com/syndicated", testNow.Add(-time.Hour))
+		feedA, feedB := uuid.New(), uuid.New()
+		store := &fakeStore{
+			records: []*domain.ArticleSourceRecord{rec},
+			candidates: map[string][]*domain.ReconciliationCandidate{rec.URL: {
+				{ArticleID: uuid.New(), FeedID: &feedA, URL: rec.URL},
+				{ArticleID: uuid.New(), FeedID: &feedB, URL: rec.URL},
+			}},
+		}
+		result, err := newTestUsecase(store).Reconcile(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Conflicts)
+		outcome := store.applied[0]
+		assert.Equal(t, domain.ReconcileConflictMultipleFeeds, outcome.ConflictReason)
+		assert.Nil(t, outcome.ArticleID)
+		assert.Empty(t, outcome.Duplicates)
+	})
+
+	t.Run("publication times disagree", func(t *testing.T) {
+		rec := sourceRecord("https://example.com/old", testNow.Add(-time.Hour))
+		sourceTime, articleTime := testNow.Add(-10*24*time.Hour), testNow.Add(-time.Hour)
+		rec.PublishedAt = &sourceTime
+		store := &fakeStore{
+			records: []*domain.ArticleSourceRecord{rec},
+			candidates: map[string][]*domain.ReconciliationCandidate{rec.URL: {
+				{ArticleID: uuid.New(), URL: rec.URL, Title: "Old", PublishedAt: &articleTime},
+			}},
+		}
+		result, err := newTestUsecase(store).Reconcile(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Conflicts)
+		outcome := store.applied[0]
+		assert.Equal(t, domain.ReconcileConflictPublishedAt, outcome.ConflictReason)
+		assert.NotNil(t, outcome.ArticleID)
+		assert.Nil(t, outcome.MergePublishedAt)
+	})
+}
+
+func TestReconcile_OrphansAfterGracePeriod(t *testing.T) {
+	fresh := sourceRecord("https://example.com/fresh", testNow.Add(-time.Hour))
+	stale := sourceRecord("https://example.com/stale", testNow.Add(-48*time.Hour))
+	store := &fakeStore{records: []*domain.ArticleSourceRecord{fresh, stale}}
+
+	result, err := newTestUsecase(store).Reconcile(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Scanned)
+	assert.Equal(t, 1, result.StillPending)
+	assert.Equal(t, 1, result.Orphaned)
+	require.Len(t, store.applied, 1)
+	assert.Equal(t, stale.ID, store.applied[0].RecordID)
+	assert.Equal(t, domain.ArticleSourceOrphaned, store.applied[0].Status)
+}
+
+func TestReconcile_CountsLookupFailures(t *testing.T) {
+	store := &fakeStore{
+		records: []*domain.ArticleSourceRecord{sourceRecord("https://example.com/a", testNow)},
+		findErr: errors.New("connection reset"),
+	}
+	result, err := newTestUsecase(store).Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	assert.Empty(t, store.applied)
+}
+
+func TestReport(t *testing.T) {
+	store := &fakeStore{report: &domain.ReconciliationReport{}}
+	uc := newTestUsecase(store)
+
+	_, err := uc.Report(context.Background(), nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ArticleSourceStatus{domain.ArticleSourceOrphaned, domain.ArticleSourceConflict}, store.statuses)
+	assert.Equal(t, defaultReportLimit, store.limit)
+
+	_, err = uc.Report(context.Background(), []domain.ArticleSourceStatus{domain.ArticleSourceMatched}, 10_000)
+	require.NoError(t, err)
+	assert.Equal(t, maxReportLimit, store.limit)
+
+	_, err = uc.Report(context.Background(), []domain.ArticleSourceStatus{"bogus"}, 10)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
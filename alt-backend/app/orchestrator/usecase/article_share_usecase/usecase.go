@@ -0,0 +1,244 @@
+package article_share_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/article_share_port"
+	"alt/utils/logger"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// tokenContext domain-separates share token signatures from any other HMAC
+// made with the same secret, and versions the token format.
+const tokenContext = "alt-article-share:v1:"
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid share link input")
+
+// Config holds the share link settings (config.ShareLinkConfig).
+type Config struct {
+	Secret     []byte
+	BaseURL    string
+	DefaultTTL time.Duration
+	MaxTTL     time.Duration
+}
+
+// CreateInput describes a new share link. A zero TTL selects the default
+// and an empty Fields selects domain.DefaultShareFields.
+type CreateInput struct {
+	ArticleID uuid.UUID
+	TTL       time.Duration
+	Fields    []domain.ShareField
+}
+
+// ShareLink is a share link together with its public token and URL.
+type ShareLink struct {
+	*domain.ArticleShareLink
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// Usecase creates, lists and revokes article share links for their owners
+// and serves the public view behind a token.
+//
+// A token is the link ID plus an HMAC over it. Signature checks happen
+// before any database access, so guessed or tampered tokens cost nothing,
+// and tokens are not stored, so a database read does not leak live links.
+// Expiry and revocation live in the database so links can be revoked.
+type Usecase struct {
+	links  article_share_port.ArticleShareLinkPort
+	shared article_share_port.SharedArticlePort
+	cfg    Config
+	now    func() time.Time
+}
+
+// NewUsecase creates a new article share usecase.
+func NewUsecase(
+	links article_share_port.ArticleShareLinkPort,
+	shared article_share_port.SharedArticlePort,
+	cfg Config,
+) *Usecase {
+	return &Usecase{
+		links:  links,
+		shared: shared,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+// CreateShareLink creates a share link for one of userID's articles.
+func (u *Usecase) CreateShareLink(ctx context.Context, userID uuid.UUID, in CreateInput) (*ShareLink, error) {
+	if in.ArticleID == uuid.Nil {
+		return nil, fmt.Errorf("%w: article id is required", ErrInvalidInput)
+	}
+	ttl := in.TTL
+	switch {
+	case ttl < 0:
+		return nil, fmt.Errorf("%w: ttl must not be negative", ErrInvalidInput)
+	case ttl == 0:
+		ttl = u.cfg.DefaultTTL
+	case ttl > u.cfg.MaxTTL:
+		return nil, fmt.Errorf("%w: ttl must be at most %s", ErrInvalidInput, u.cfg.MaxTTL)
+	}
+	fields, err := normalizeFields(in.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	link := &domain.ArticleShareLink{
+		ID:        uuid.New(),
+		ArticleID: in.ArticleID,
+		UserID:    userID,
+		Fields:    fields,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := u.links.CreateArticleShareLink(ctx, link); err != nil {
+		return nil, fmt.Errorf("create share link: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "article share link created",
+		"share_id", link.ID, "article_id", link.ArticleID, "user_id", userID, "expires_at", link.ExpiresAt)
+	return u.withToken(link), nil
+}
+
+// ListShareLinks returns userID's share links for an article, including
+// expired and revoked ones so the owner can see their view counts.
+func (u *Usecase) ListShareLinks(ctx context.Context, userID, articleID uuid.UUID) ([]*ShareLink, error) {
+	if articleID == uuid.Nil {
+		return nil, fmt.Errorf("%w: article id is required", ErrInvalidInput)
+	}
+	links, err := u.links.ListArticleShareLinks(ctx, articleID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list share links: %w", err)
+	}
+	out := make([]*ShareLink, len(links))
+	for i, link := range links {
+		out[i] = u.withToken(link)
+	}
+	return out, nil
+}
+
+// RevokeShareLink permanently disables one of userID's share links.
+func (u *Usecase) RevokeShareLink(ctx context.Context, userID, articleID, shareID uuid.UUID) error {
+	if articleID == uuid.Nil || shareID == uuid.Nil {
+		return fmt.Errorf("%w: article id and share id are required", ErrInvalidInput)
+	}
+	if err := u.links.RevokeArticleShareLink(ctx, shareID, articleID, userID, u.now()); err != nil {
+		return fmt.Errorf("revoke share link: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "article share link revoked",
+		"share_id", shareID, "article_id", articleID, "user_id", userID)
+	return nil
+}
+
+// ViewSharedArticle resolves a public token to the fields its link
+// exposes and counts the view. Bad tokens and unknown links return
+// domain.ErrShareLinkNotFound; expired or revoked links and deleted
+// articles return domain.ErrShareLinkGone.
+func (u *Usecase) ViewSharedArticle(ctx context.Context, token string) (*domain.SharedArticleView, error) {
+	shareID, ok := u.verifyToken(token)
+	if !ok {
+		return nil, domain.ErrShareLinkNotFound
+	}
+
+	link, err := u.shared.RecordShareLinkView(ctx, shareID, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("record share link view: %w", err)
+	}
+	article, err := u.shared.FetchSharedArticle(ctx, link.ArticleID, link.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch shared article: %w", err)
+	}
+	return project(article, link), nil
+}
+
+func (u *Usecase) withToken(link *domain.ArticleShareLink) *ShareLink {
+	token := u.sign(link.ID)
+	return &ShareLink{
+		ArticleShareLink: link,
+		Token:            token,
+		URL:              strings.TrimRight(u.cfg.BaseURL, "/") + "/" + token,
+	}
+}
+
+// sign returns "<base64url(id)>.<base64url(hmac)>".
+func (u *Usecase) sign(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:]) + "." + base64.RawURLEncoding.EncodeToString(u.mac(id))
+}
+
+func (u *Usecase) verifyToken(token string) (uuid.UUID, bool) {
+	rawID, rawSig, found := strings.Cut(token, ".")
+	if !found {
+		return uuid.Nil, false
+	}
+	idBytes, err := base64.RawURLEncoding.DecodeString(rawID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	id, err := uuid.FromBytes(idBytes)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(rawSig)
+	if err != nil || !hmac.Equal(sig, u.mac(id)) {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (u *Usecase) mac(id uuid.UUID) []byte {
+	m := hmac.New(sha256.New, u.cfg.Secret)
+	m.Write([]byte(tokenContext))
+	m.Write(id[:])
+	return m.Sum(nil)
+}
+
+// normalizeFields applies the default, rejects fields outside the
+// allowlist and drops duplicates.
+func normalizeFields(fields []domain.ShareField) ([]domain.ShareField, error) {
+	if len(fields) == 0 {
+		return append([]domain.ShareField(nil), domain.DefaultShareFields...), nil
+	}
+	seen := make(map[domain.ShareField]bool, len(fields))
+	out := make([]domain.ShareField, 0, len(fields))
+	for _, f := range fields {
+		if !domain.IsShareableField(f) {
+			return nil, fmt.Errorf("%w: field %q cannot be shared", ErrInvalidInput, f)
+		}
+		if !seen[f] {
+			seen[f] = true
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// project copies only the fields the link exposes. The allowlist is
+// re-checked here so a row edited by hand cannot widen what is served.
+func project(article *domain.SharedArticle, link *domain.ArticleShareLink) *domain.SharedArticleView {
+	view := &domain.SharedArticleView{ExpiresAt: link.ExpiresAt}
+	for _, f := range link.Fields {
+		switch f {
+		case domain.ShareFieldTitle:
+			view.Title = article.Title
+		case domain.ShareFieldURL:
+			view.URL = article.URL
+		case domain.ShareFieldPublishedAt:
+			view.PublishedAt = article.PublishedAt
+		case domain.ShareFieldSummary:
+			view.Summary = article.Summary
+		case domain.ShareFieldContent:
+			view.Content = article.Content
+		}
+	}
+	return view
+}
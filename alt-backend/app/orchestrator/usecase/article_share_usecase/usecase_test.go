@@ -0,0 +1,212 @@
+package article_share_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLinks mirrors the driver contract: links only attach to articles
+// their user owns, views only count on active links.
+type fakeLinks struct {
+	owners   map[uuid.UUID]uuid.UUID
+	articles map[uuid.UUID]*domain.SharedArticle
+	links    map[uuid.UUID]*domain.ArticleShareLink
+}
+
+func newFakeLinks() *fakeLinks {
+	return &fakeLinks{
+		owners:   map[uuid.UUID]uuid.UUID{},
+		articles: map[uuid.UUID]*domain.SharedArticle{},
+		links:    map[uuid.UUID]*domain.ArticleShareLink{},
+	}
+}
+
+func (f *fakeLinks) CreateArticleShareLink(_ context.Context, link *domain.ArticleShareLink) error {
+	if f.owners[link.ArticleID] != link.UserID {
+		return domain.ErrItemNotFound
+	}
+	f.links[link.ID] = link
+	return nil
+}
+
+func (f *fakeLinks) ListArticleShareLinks(_ context.Context, articleID, userID uuid.UUID) ([]*domain.ArticleShareLink, error) {
+	out := []*domain.ArticleShareLink{}
+	for _, l := range f.links {
+		if l.ArticleID == articleID && l.UserID == userID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeLinks) RevokeArticleShareLink(_ context.Context, shareID, articleID, userID uuid.UUID, now time.Time) error {
+	l, ok := f.links[shareID]
+	if !ok || l.ArticleID != articleID || l.UserID != userID {
+		return domain.ErrShareLinkNotFound
+	}
+	if l.RevokedAt == nil {
+		l.RevokedAt = &now
+	}
+	return nil
+}
+
+func (f *fakeLinks) RecordShareLinkView(_ context.Context, shareID uuid.UUID, now time.Time) (*domain.ArticleShareLink, error) {
+	l, ok := f.links[shareID]
+	if !ok {
+		return nil, domain.ErrShareLinkNotFound
+	}
+	if !l.Active(now) {
+		return nil, domain.ErrShareLinkGone
+	}
+	l.ViewCount++
+	l.LastViewedAt = &now
+	return l, nil
+}
+
+func (f *fakeLinks) FetchSharedArticle(_ context.Context, articleID, ownerID uuid.UUID) (*domain.SharedArticle, error) {
+	a, ok := f.articles[articleID]
+	if !ok || f.owners[articleID] != ownerID {
+		return nil, domain.ErrShareLinkGone
+	}
+	return a, nil
+}
+
+func newTestUsecase(store *fakeLinks, now *time.Time) *Usecase {
+	uc := NewUsecase(store, store, Config{
+		Secret:     []byte("test-share-secret-0123456789abcdef"),
+		BaseURL:    "https://example.com/share/",
+		DefaultTTL: 7 * 24 * time.Hour,
+		MaxTTL:     30 * 24 * time.Hour,
+	})
+	uc.now = func() time.Time { return *now }
+	return uc
+}
+
+func seedArticle(store *fakeLinks) (articleID, ownerID uuid.UUID) {
+	articleID, ownerID = uuid.New(), uuid.New()
+	store.owners[articleID] = ownerID
+	store.articles[articleID] = &domain.SharedArticle{
+		Title:   "Title",
+		URL:     "https://news.example.com/a",
+		Content: "Full body",
+		Summary: "要約",
+	}
+	return articleID, ownerID
+}
+
+func TestCreateShareLink(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("applies defaults and returns a working URL", func(t *testing.T) {
+		store := newFakeLinks()
+		uc := newTestUsecase(store, &now)
+		articleID, ownerID := seedArticle(store)
+
+		link, err := uc.CreateShareLink(ctx, ownerID, CreateInput{ArticleID: articleID})
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(7*24*time.Hour), link.ExpiresAt)
+		assert.Equal(t, domain.DefaultShareFields, link.Fields)
+		assert.Equal(t, "https://example.com/share/"+link.Token, link.URL)
+	})
+
+	t.Run("rejects fields outside the allowlist", func(t *testing.T) {
+		store := newFakeLinks()
+		uc := newTestUsecase(store, &now)
+		articleID, ownerID := seedArticle(store)
+
+		_, err := uc.CreateShareLink(ctx, ownerID, CreateInput{
+			ArticleID: articleID,
+			Fields:    []domain.ShareField{domain.ShareFieldTitle, "user_id"},
+		})
+		require.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("rejects ttl above the maximum", func(t *testing.T) {
+		store := newFakeLinks()
+		uc := newTestUsecase(store, &now)
+		articleID, ownerID := seedArticle(store)
+
+		_, err := uc.CreateShareLink(ctx, ownerID, CreateInput{ArticleID: articleID, TTL: 31 * 24 * time.Hour})
+		require.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("cannot share another user's article", func(t *testing.T) {
+		store := newFakeLinks()
+		uc := newTestUsecase(store, &now)
+		articleID, _ := seedArticle(store)
+
+		_, err := uc.CreateShareLink(ctx, uuid.New(), CreateInput{ArticleID: articleID})
+		require.ErrorIs(t, err, domain.ErrItemNotFound)
+	})
+}
+
+func TestViewSharedArticle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("exposes only the link's fields and counts views", func(t *testing.T) {
+		store := newFakeLinks()
+		uc := newTestUsecase(store, &now)
+		articleID, ownerID := seedArticle(store)
+		link, err := uc.CreateShareLink(ctx, ownerID, CreateInput{
+			ArticleID: articleID,
+			Fields:    []domain.ShareField{domain.ShareFieldTitle, domain.ShareFieldSummary},
+		})
+		require.NoError(t, err)
+
+		view, err := uc.ViewSharedArticle(ctx, link.Token)
+		require.NoError(t, err)
+		assert.Equal(t, "Title", view.Title)
+		assert.Equal(t, "要約", view.Summary)
+		assert.Empty(t, view.URL)
+		assert.Empty(t, view.Content)
+
+		_, err = uc.ViewSharedArticle(ctx, link.Token)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), store.links[link.ID].ViewCount)
+	})
+
+	t.Run("rejects tampered tokens before touching the store", func(t *testing.T) {
+		store := newFakeLinks()
+		uc := newTestUsecase(store, &now)
+		articleID, ownerID := seedArticle(store)
+		link, err := uc.CreateShareLink(ctx, ownerID, CreateInput{ArticleID: articleID})
+		require.NoError(t, err)
+
+		id, _, _ := strings.Cut(link.Token, ".")
+		for _, token := range []string{"", "garbage", id, id + ".AAAA", uc.sign(uuid.New())[:22] + link.Token[22:]} {
+			_, err := uc.ViewSharedArticle(ctx, token)
+			assert.ErrorIs(t, err, domain.ErrShareLinkNotFound, "token %q", token)
+		}
+		assert.Zero(t, store.links[link.ID].ViewCount)
+	})
+
+	t.Run("expired and revoked links are gone", func(t *testing.T) {
+		store := newFakeLinks()
+		uc := newTestUsecase(store, &now)
+		articleID, ownerID := seedArticle(store)
+		expiring, err := uc.CreateShareLink(ctx, ownerID, CreateInput{ArticleID: articleID, TTL: time.Hour})
+		require.NoError(t, err)
+		revoked, err := uc.CreateShareLink(ctx, ownerID, CreateInput{ArticleID: articleID})
+		require.NoError(t, err)
+
+		require.ErrorIs(t, uc.RevokeShareLink(ctx, uuid.New(), articleID, revoked.ID), domain.ErrShareLinkNotFound,
+			"only the owner may revoke")
+		require.NoError(t, uc.RevokeShareLink(ctx, ownerID, articleID, revoked.ID))
+		_, err = uc.ViewSharedArticle(ctx, revoked.Token)
+		require.ErrorIs(t, err, domain.ErrShareLinkGone)
+
+		later := now.Add(2 * time.Hour)
+		uc.now = func() time.Time { return later }
+		_, err = uc.ViewSharedArticle(ctx, expiring.Token)
+		require.ErrorIs(t, err, domain.ErrShareLinkGone)
+	})
+}
@@ -0,0 +1,257 @@
+package article_title_fix_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/article_title_fix_port"
+	"alt/orchestrator/port/fetch_article_port"
+	"alt/orchestrator/port/scraping_policy_port"
+	"alt/utils/html_parser"
+	"alt/utils/logger"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBatchSize = 50
+
+	defaultReportLimit = 100
+	maxReportLimit     = 1000
+
+	defaultJobListLimit = 20
+	maxJobListLimit     = 100
+)
+
+// Config tunes title repair runs. Zero values select the defaults.
+type Config struct {
+	// BatchSize is the number of articles one RunBatch call looks at.
+	// Fetches are paced by the per-host rate limiter, so this bounds how
+	// long a single scheduler tick runs.
+	BatchSize int
+}
+
+// Usecase repairs articles whose stored title is their URL by fetching the
+// page and taking the title from its HTML. Work is split into persisted
+// jobs: an admin starts one, and the background job advances it a batch at
+// a time, saving the cursor after every article so it survives restarts.
+type Usecase struct {
+	store   article_title_fix_port.ArticleTitleFixPort
+	fetcher fetch_article_port.FetchArticlePort
+	policy  scraping_policy_port.ScrapingPolicyPort
+	cfg     Config
+	now     func() time.Time
+}
+
+// NewUsecase creates a new article title fix usecase.
+func NewUsecase(
+	store article_title_fix_port.ArticleTitleFixPort,
+	fetcher fetch_article_port.FetchArticlePort,
+	policy scraping_policy_port.ScrapingPolicyPort,
+	cfg Config,
+) *Usecase {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	return &Usecase{store: store, fetcher: fetcher, policy: policy, cfg: cfg, now: time.Now}
+}
+
+// Start queues a new repair job. It returns
+// domain.ErrArticleTitleFixJobActive while another job is pending or
+// running.
+func (u *Usecase) Start(ctx context.Context, requestedBy *uuid.UUID) (*domain.ArticleTitleFixJob, error) {
+	active, err := u.store.GetActiveArticleTitleFixJob(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get active article title fix job: %w", err)
+	}
+	if active != nil {
+		return nil, domain.ErrArticleTitleFixJobActive
+	}
+
+	job, err := u.store.CreateArticleTitleFixJob(ctx, requestedBy, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("create article title fix job: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "article title fix job queued",
+		"job_id", job.JobID, "total_articles", job.TotalArticles)
+	return job, nil
+}
+
+// RunBatch advances the active job by up to BatchSize articles and returns
+// it, or nil when no job is active. A job with no articles left is marked
+// completed. Failures on single articles are recorded in the report; an
+// error saving progress stops the batch and leaves the job running so the
+// next call resumes from the last saved article.
+func (u *Usecase) RunBatch(ctx context.Context) (*domain.ArticleTitleFixJob, error) {
+	job, err := u.store.GetActiveArticleTitleFixJob(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get active article title fix job: %w", err)
+	}
+	if job == nil {
+		return nil, nil
+	}
+
+	if job.Status == domain.ArticleTitleFixPending {
+		now := u.now()
+		job.Status = domain.ArticleTitleFixRunning
+		job.StartedAt = &now
+		job.UpdatedAt = now
+		if err := u.store.UpdateArticleTitleFixJob(ctx, job); err != nil {
+			return nil, fmt.Errorf("start article title fix job: %w", err)
+		}
+	}
+
+	candidates, err := u.store.ListArticleTitleFixCandidates(ctx, job.CursorArticleID, u.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("list article title fix candidates: %w", err)
+	}
+	if len(candidates) == 0 {
+		return job, u.finish(ctx, job)
+	}
+
+	for _, c := range candidates {
+		if err := ctx.Err(); err != nil {
+			return job, err
+		}
+
+		result := u.fixTitle(ctx, job.JobID, c)
+		result.ProcessedAt = u.now()
+
+		articleID := c.ArticleID
+		job.CursorArticleID = &articleID
+		job.ProcessedCount++
+		switch result.Outcome {
+		case domain.ArticleTitleFixUpdated:
+			job.UpdatedCount++
+		case domain.ArticleTitleFixFailed:
+			job.FailedCount++
+		case domain.ArticleTitleFixSkipped:
+			job.SkippedCount++
+		}
+		job.UpdatedAt = result.ProcessedAt
+
+		if err := u.store.RecordArticleTitleFixResult(ctx, job, result); err != nil {
+			return job, fmt.Errorf("record article title fix result for %s: %w", c.ArticleID, err)
+		}
+	}
+
+	logger.Logger.InfoContext(ctx, "article title fix batch completed",
+		"job_id", job.JobID,
+		"batch", len(candidates),
+		"processed", job.ProcessedCount,
+		"updated", job.UpdatedCount,
+		"failed", job.FailedCount,
+		"skipped", job.SkippedCount,
+		"total", job.TotalArticles)
+	return job, nil
+}
+
+// Report returns a job with up to limit updated, failed and skipped
+// results each.
+func (u *Usecase) Report(ctx context.Context, jobID uuid.UUID, limit int) (*domain.ArticleTitleFixReport, error) {
+	if limit <= 0 {
+		limit = defaultReportLimit
+	}
+	if limit > maxReportLimit {
+		limit = maxReportLimit
+	}
+
+	job, err := u.store.GetArticleTitleFixJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	report := &domain.ArticleTitleFixReport{Job: job}
+	for outcome, dst := range map[domain.ArticleTitleFixOutcome]*[]*domain.ArticleTitleFixResult{
+		domain.ArticleTitleFixUpdated: &report.Updated,
+		domain.ArticleTitleFixFailed:  &report.Failed,
+		domain.ArticleTitleFixSkipped: &report.Skipped,
+	} {
+		results, err := u.store.ListArticleTitleFixResults(ctx, jobID, outcome, limit)
+		if err != nil {
+			return nil, fmt.Errorf("list %s article title fix results: %w", outcome, err)
+		}
+		*dst = results
+	}
+	return report, nil
+}
+
+// ListJobs returns up to limit jobs, newest first.
+func (u *Usecase) ListJobs(ctx context.Context, limit int) ([]*domain.ArticleTitleFixJob, error) {
+	if limit <= 0 {
+		limit = defaultJobListLimit
+	}
+	if limit > maxJobListLimit {
+		limit = maxJobListLimit
+	}
+	jobs, err := u.store.ListArticleTitleFixJobs(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list article title fix jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// fixTitle decides what happens to one article. Pages the scraping policy
+// denies (robots.txt, domain settings, crawl delay) are skipped rather than
+// fetched; a new job picks them up again.
+func (u *Usecase) fixTitle(ctx context.Context, jobID uuid.UUID, c *domain.ArticleTitleFixCandidate) *domain.ArticleTitleFixResult {
+	result := &domain.ArticleTitleFixResult{
+		JobID:     jobID,
+		ArticleID: c.ArticleID,
+		URL:       c.URL,
+		OldTitle:  c.Title,
+	}
+
+	allowed, err := u.policy.CanFetchArticle(ctx, c.URL)
+	if err != nil {
+		result.Outcome = domain.ArticleTitleFixFailed
+		result.Detail = fmt.Sprintf("scraping policy check failed: %v", err)
+		return result
+	}
+	if !allowed {
+		result.Outcome = domain.ArticleTitleFixSkipped
+		result.Detail = "fetch not allowed by scraping policy"
+		return result
+	}
+
+	content, err := u.fetcher.FetchArticleContents(ctx, c.URL)
+	if err != nil {
+		result.Outcome = domain.ArticleTitleFixFailed
+		result.Detail = err.Error()
+		return result
+	}
+	if content == nil {
+		result.Outcome = domain.ArticleTitleFixFailed
+		result.Detail = "empty response"
+		return result
+	}
+
+	title := strings.Join(strings.Fields(html_parser.ExtractTitle(*content)), " ")
+	if title == "" || domain.IsURLTitle(title) {
+		result.Outcome = domain.ArticleTitleFixSkipped
+		result.Detail = "no title found on page"
+		return result
+	}
+
+	result.Outcome = domain.ArticleTitleFixUpdated
+	result.NewTitle = title
+	return result
+}
+
+func (u *Usecase) finish(ctx context.Context, job *domain.ArticleTitleFixJob) error {
+	now := u.now()
+	job.Status = domain.ArticleTitleFixCompleted
+	job.CompletedAt = &now
+	job.UpdatedAt = now
+	if err := u.store.UpdateArticleTitleFixJob(ctx, job); err != nil {
+		return fmt.Errorf("complete article title fix job: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "article title fix job completed",
+		"job_id", job.JobID,
+		"processed", job.ProcessedCount,
+		"updated", job.UpdatedCount,
+		"failed", job.FailedCount,
+		"skipped", job.SkippedCount)
+	return nil
+}
@@ -0,0 +1,229 @@
+package article_title_fix_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore keeps one job and the article candidates in memory and pages
+// through candidates by cursor like the database does.
+type fakeStore struct {
+	job        *domain.ArticleTitleFixJob
+	candidates []*domain.ArticleTitleFixCandidate
+	recorded   []*domain.ArticleTitleFixResult
+	recordErr  error
+	updates    []domain.ArticleTitleFixStatus
+}
+
+func (f *fakeStore) CreateArticleTitleFixJob(_ context.Context, requestedBy *uuid.UUID, now time.Time) (*domain.ArticleTitleFixJob, error) {
+	f.job = &domain.ArticleTitleFixJob{
+		JobID:         uuid.New(),
+		Status:        domain.ArticleTitleFixPending,
+		RequestedBy:   requestedBy,
+		TotalArticles: len(f.candidates),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	return f.job, nil
+}
+
+func (f *fakeStore) GetActiveArticleTitleFixJob(_ context.Context) (*domain.ArticleTitleFixJob, error) {
+	if f.job == nil || f.job.Status == domain.ArticleTitleFixCompleted {
+		return nil, nil
+	}
+	clone := *f.job
+	return &clone, nil
+}
+
+func (f *fakeStore) GetArticleTitleFixJob(_ context.Context, jobID uuid.UUID) (*domain.ArticleTitleFixJob, error) {
+	if f.job == nil || f.job.JobID != jobID {
+		return nil, domain.ErrArticleTitleFixJobNotFound
+	}
+	return f.job, nil
+}
+
+func (f *fakeStore) ListArticleTitleFixJobs(_ context.Context, _ int) ([]*domain.ArticleTitleFixJob, error) {
+	return []*domain.ArticleTitleFixJob{f.job}, nil
+}
+
+func (f *fakeStore) UpdateArticleTitleFixJob(_ context.Context, job *domain.ArticleTitleFixJob) error {
+	clone := *job
+	f.job = &clone
+	f.updates = append(f.updates, job.Status)
+	return nil
+}
+
+func (f *fakeStore) ListArticleTitleFixCandidates(_ context.Context, afterID *uuid.UUID, limit int) ([]*domain.ArticleTitleFixCandidate, error) {
+	out := []*domain.ArticleTitleFixCandidate{}
+	passed := afterID == nil
+	for _, c := range f.candidates {
+		if !passed {
+			passed = c.ArticleID == *afterID
+			continue
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) RecordArticleTitleFixResult(_ context.Context, job *domain.ArticleTitleFixJob, result *domain.ArticleTitleFixResult) error {
+	if f.recordErr != nil {
+		return f.recordErr
+	}
+	clone := *job
+	f.job = &clone
+	f.recorded = append(f.recorded, result)
+	return nil
+}
+
+func (f *fakeStore) ListArticleTitleFixResults(_ context.Context, _ uuid.UUID, outcome domain.ArticleTitleFixOutcome, _ int) ([]*domain.ArticleTitleFixResult, error) {
+	out := []*domain.ArticleTitleFixResult{}
+	for _, r := range f.recorded {
+		if r.Outcome == outcome {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+type fakeFetcher struct {
+	pages   map[string]string
+	fetched []string
+}
+
+func (f *fakeFetcher) FetchArticleContents(_ context.Context, url string) (*string, error) {
+	f.fetched = append(f.fetched, url)
+	page, ok := f.pages[url]
+	if !ok {
+		return nil, errors.New("status 404")
+	}
+	return &page, nil
+}
+
+type fakePolicy struct {
+	denied map[string]bool
+}
+
+func (f *fakePolicy) CanFetchArticle(_ context.Context, url string) (bool, error) {
+	return !f.denied[url], nil
+}
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func candidate(url string) *domain.ArticleTitleFixCandidate {
+	return &domain.ArticleTitleFixCandidate{ArticleID: uuid.New(), URL: url, Title: url}
+}
+
+func newTestUsecase(store *fakeStore, fetcher *fakeFetcher, policy *fakePolicy, batch int) *Usecase {
+	uc := NewUsecase(store, fetcher, policy, Config{BatchSize: batch})
+	uc.now = func() time.Time { return testNow }
+	return uc
+}
+
+func TestStart_RejectsSecondActiveJob(t *testing.T) {
+	store := &fakeStore{}
+	uc := newTestUsecase(store, &fakeFetcher{}, &fakePolicy{}, 10)
+
+	admin := uuid.New()
+	job, err := uc.Start(context.Background(), &admin)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleTitleFixPending, job.Status)
+
+	_, err = uc.Start(context.Background(), &admin)
+	assert.ErrorIs(t, err, domain.ErrArticleTitleFixJobActive)
+}
+
+func TestRunBatch_NoActiveJob(t *testing.T) {
+	uc := newTestUsecase(&fakeStore{}, &fakeFetcher{}, &fakePolicy{}, 10)
+
+	job, err := uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestRunBatch_FixesTitlesAndResumesFromCursor(t *testing.T) {
+	ok := candidate("https://example.com/ok")
+	broken := candidate("https://example.com/missing")
+	denied := candidate("https://blocked.example.com/post")
+	untitled := candidate("https://example.com/untitled")
+	store := &fakeStore{candidates: []*domain.ArticleTitleFixCandidate{ok, broken, denied, untitled}}
+	fetcher := &fakeFetcher{pages: map[string]string{
+		ok.URL:       "<html><head><title>  Real\n Title </title></head></html>",
+		untitled.URL: "<html><body><p>no title here</p></body></html>",
+	}}
+	policy := &fakePolicy{denied: map[string]bool{denied.URL: true}}
+	uc := newTestUsecase(store, fetcher, policy, 2)
+
+	_, err := uc.Start(context.Background(), nil)
+	require.NoError(t, err)
+
+	// First batch: two articles, then the job is still running.
+	job, err := uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleTitleFixRunning, job.Status)
+	assert.Equal(t, 2, job.ProcessedCount)
+	require.NotNil(t, store.job.CursorArticleID)
+	assert.Equal(t, broken.ArticleID, *store.job.CursorArticleID)
+
+	// Second batch resumes after the saved cursor.
+	job, err = uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, job.ProcessedCount)
+
+	// Third call finds nothing left and completes the job.
+	job, err = uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, domain.ArticleTitleFixCompleted, job.Status)
+	require.NotNil(t, job.CompletedAt)
+
+	assert.Equal(t, 1, job.UpdatedCount)
+	assert.Equal(t, 1, job.FailedCount)
+	assert.Equal(t, 2, job.SkippedCount)
+	assert.NotContains(t, fetcher.fetched, denied.URL, "denied pages must not be fetched")
+
+	report, err := uc.Report(context.Background(), job.JobID, 0)
+	require.NoError(t, err)
+	require.Len(t, report.Updated, 1)
+	assert.Equal(t, "Real Title", report.Updated[0].NewTitle)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, broken.ArticleID, report.Failed[0].ArticleID)
+	assert.Len(t, report.Skipped, 2)
+}
+
+func TestRunBatch_StopsWhenProgressCannotBeSaved(t *testing.T) {
+	a := candidate("https://example.com/a")
+	store := &fakeStore{candidates: []*domain.ArticleTitleFixCandidate{a}}
+	fetcher := &fakeFetcher{pages: map[string]string{a.URL: "<title>A</title>"}}
+	uc := newTestUsecase(store, fetcher, &fakePolicy{}, 10)
+
+	_, err := uc.Start(context.Background(), nil)
+	require.NoError(t, err)
+
+	store.recordErr = errors.New("db down")
+	_, err = uc.RunBatch(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, domain.ArticleTitleFixRunning, store.job.Status)
+	assert.Nil(t, store.job.CursorArticleID, "the cursor must not move past an unsaved article")
+
+	store.recordErr = nil
+	job, err := uc.RunBatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, job.UpdatedCount)
+}
+
+func TestReport_UnknownJob(t *testing.T) {
+	uc := newTestUsecase(&fakeStore{}, &fakeFetcher{}, &fakePolicy{}, 10)
+
+	_, err := uc.Report(context.Background(), uuid.New(), 10)
+	assert.ErrorIs(t, err, domain.ErrArticleTitleFixJobNotFound)
+}
@@ -83,10 +83,12 @@ func (u *CreateLensUsecase) Execute(ctx context.Context, input CreateLensInput)
 		SortMode:      input.SortMode,
 	}
 
-	if err := u.versionPort.CreateLensVersion(ctx, version); err != nil {
+	revision, err := u.versionPort.CreateLensVersion(ctx, version, 0)
+	if err != nil {
 		return nil, fmt.Errorf("create lens version: %w", err)
 	}
 
+	lens.Revision = revision
 	lens.CurrentVersion = &version
 
 	return &CreateLensResult{Lens: lens, Version: version}, nil
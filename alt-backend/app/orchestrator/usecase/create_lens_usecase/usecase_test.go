@@ -19,9 +19,9 @@ func (m *mockCreateLensPort) CreateLens(_ context.Context, _ domain.KnowledgeLen
 
 type mockCreateLensVersionPort struct{ called bool }
 
-func (m *mockCreateLensVersionPort) CreateLensVersion(_ context.Context, _ domain.KnowledgeLensVersion) error {
+func (m *mockCreateLensVersionPort) CreateLensVersion(_ context.Context, _ domain.KnowledgeLensVersion, _ int64) (int64, error) {
 	m.called = true
-	return nil
+	return 2, nil
 }
 
 func TestCreateLens_Success(t *testing.T) {
@@ -35,4 +35,5 @@ func TestCreateLens_Success(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
+	assert.Equal(t, int64(2), result.Lens.Revision)
 }
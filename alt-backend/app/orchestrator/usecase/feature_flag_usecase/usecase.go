@@ -0,0 +1,191 @@
+package feature_flag_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/feature_flag_port"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// maxDescriptionLength bounds the free-form flag description.
+const maxDescriptionLength = 500
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid feature flag input")
+
+var flagNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// SetFlagInput is the desired state of a stored flag.
+type SetFlagInput struct {
+	Enabled           bool
+	RolloutPercentage int
+	Description       string
+}
+
+// Usecase manages the stored feature flags of one environment and
+// evaluates flags for users. Every write invalidates the evaluator's cache,
+// so the flip is visible on this replica at once and on the others within
+// the cache TTL.
+type Usecase struct {
+	store       feature_flag_port.FeatureFlagStorePort
+	evaluator   feature_flag_port.FeatureFlagEvaluatorPort
+	environment string
+}
+
+// NewUsecase creates a new feature flag usecase for environment.
+func NewUsecase(
+	store feature_flag_port.FeatureFlagStorePort,
+	evaluator feature_flag_port.FeatureFlagEvaluatorPort,
+	environment string,
+) *Usecase {
+	return &Usecase{store: store, evaluator: evaluator, environment: environment}
+}
+
+// Environment is the environment whose flags this usecase manages.
+func (u *Usecase) Environment() string {
+	return u.environment
+}
+
+// ListFlags returns the stored flags with their overrides.
+func (u *Usecase) ListFlags(ctx context.Context) ([]domain.FeatureFlag, error) {
+	flags, err := u.store.ListFeatureFlags(ctx, u.environment)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := u.store.ListFeatureFlagOverrides(ctx, u.environment)
+	if err != nil {
+		return nil, err
+	}
+
+	byFlag := make(map[string][]domain.FeatureFlagOverride)
+	for _, o := range overrides {
+		byFlag[o.FlagName] = append(byFlag[o.FlagName], o)
+	}
+	for i := range flags {
+		flags[i].Overrides = byFlag[flags[i].Name]
+	}
+	return flags, nil
+}
+
+// SetFlag creates or replaces a stored flag. A stored flag takes precedence
+// over the config toggle of the same name.
+func (u *Usecase) SetFlag(ctx context.Context, name string, in SetFlagInput, actor uuid.UUID) (*domain.FeatureFlag, error) {
+	if err := validateFlagName(name); err != nil {
+		return nil, err
+	}
+	if in.RolloutPercentage < 0 || in.RolloutPercentage > 100 {
+		return nil, fmt.Errorf("%w: rollout_percentage must be between 0 and 100", ErrInvalidInput)
+	}
+	if len(in.Description) > maxDescriptionLength {
+		return nil, fmt.Errorf("%w: description must be at most %d characters", ErrInvalidInput, maxDescriptionLength)
+	}
+
+	flag := &domain.FeatureFlag{
+		Name:              name,
+		Environment:       u.environment,
+		Enabled:           in.Enabled,
+		RolloutPercentage: in.RolloutPercentage,
+		Description:       in.Description,
+	}
+	if actor != uuid.Nil {
+		flag.UpdatedBy = &actor
+	}
+	if err := u.store.UpsertFeatureFlag(ctx, flag); err != nil {
+		return nil, err
+	}
+	u.evaluator.Invalidate()
+	return flag, nil
+}
+
+// DeleteFlag removes a stored flag and its overrides, handing the name back
+// to the config toggles. It returns domain.ErrItemNotFound when the flag is
+// not stored.
+func (u *Usecase) DeleteFlag(ctx context.Context, name string) error {
+	existed, err := u.store.DeleteFeatureFlag(ctx, u.environment, name)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		return domain.ErrItemNotFound
+	}
+	u.evaluator.Invalidate()
+	return nil
+}
+
+// SetOverride forces a stored flag on or off for one user. It returns
+// domain.ErrItemNotFound when the flag is not stored.
+func (u *Usecase) SetOverride(ctx context.Context, name string, userID uuid.UUID, enabled bool, actor uuid.UUID) (*domain.FeatureFlagOverride, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user id is required", ErrInvalidInput)
+	}
+	flag, err := u.store.FetchFeatureFlag(ctx, u.environment, name)
+	if err != nil {
+		return nil, err
+	}
+	if flag == nil {
+		return nil, domain.ErrItemNotFound
+	}
+
+	o := &domain.FeatureFlagOverride{
+		FlagName:    name,
+		Environment: u.environment,
+		UserID:      userID,
+		Enabled:     enabled,
+	}
+	if actor != uuid.Nil {
+		o.CreatedBy = &actor
+	}
+	if err := u.store.UpsertFeatureFlagOverride(ctx, o); err != nil {
+		return nil, err
+	}
+	u.evaluator.Invalidate()
+	return o, nil
+}
+
+// ClearOverride removes a user's override. It returns
+// domain.ErrItemNotFound when the user had none.
+func (u *Usecase) ClearOverride(ctx context.Context, name string, userID uuid.UUID) error {
+	existed, err := u.store.DeleteFeatureFlagOverride(ctx, u.environment, name, userID)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		return domain.ErrItemNotFound
+	}
+	u.evaluator.Invalidate()
+	return nil
+}
+
+// Evaluate evaluates names for userID, or every known flag when names is
+// empty. Unknown names evaluate to off from the config source.
+func (u *Usecase) Evaluate(ctx context.Context, userID uuid.UUID, names []string) ([]domain.FeatureFlagEvaluation, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user id is required", ErrInvalidInput)
+	}
+	if len(names) == 0 {
+		names = u.evaluator.FlagNames(ctx)
+	}
+
+	evaluations := make([]domain.FeatureFlagEvaluation, 0, len(names))
+	for _, name := range names {
+		evaluations = append(evaluations, u.evaluator.Evaluate(ctx, name, userID))
+	}
+	return evaluations, nil
+}
+
+func validateFlagName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: flag name is required", ErrInvalidInput)
+	}
+	if len(name) > domain.MaxFeatureFlagNameLength {
+		return fmt.Errorf("%w: flag name must be at most %d characters", ErrInvalidInput, domain.MaxFeatureFlagNameLength)
+	}
+	if !flagNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: flag name must be lower snake_case", ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package feature_flag_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFlagStore struct {
+	flags           []domain.FeatureFlag
+	overrides       []domain.FeatureFlagOverride
+	fetched         *domain.FeatureFlag
+	storedFlag      *domain.FeatureFlag
+	storedOverride  *domain.FeatureFlagOverride
+	deleteExisted   bool
+	overrideExisted bool
+}
+
+func (f *fakeFlagStore) ListFeatureFlags(_ context.Context, _ string) ([]domain.FeatureFlag, error) {
+	return f.flags, nil
+}
+
+func (f *fakeFlagStore) FetchFeatureFlag(_ context.Context, _, _ string) (*domain.FeatureFlag, error) {
+	return f.fetched, nil
+}
+
+func (f *fakeFlagStore) UpsertFeatureFlag(_ context.Context, flag *domain.FeatureFlag) error {
+	f.storedFlag = flag
+	return nil
+}
+
+func (f *fakeFlagStore) DeleteFeatureFlag(_ context.Context, _, _ string) (bool, error) {
+	return f.deleteExisted, nil
+}
+
+func (f *fakeFlagStore) ListFeatureFlagOverrides(_ context.Context, _ string) ([]domain.FeatureFlagOverride, error) {
+	return f.overrides, nil
+}
+
+func (f *fakeFlagStore) UpsertFeatureFlagOverride(_ context.Context, o *domain.FeatureFlagOverride) error {
+	f.storedOverride = o
+	return nil
+}
+
+func (f *fakeFlagStore) DeleteFeatureFlagOverride(_ context.Context, _, _ string, _ uuid.UUID) (bool, error) {
+	return f.overrideExisted, nil
+}
+
+type fakeEvaluator struct {
+	names       []string
+	enabled     map[string]bool
+	invalidated int
+}
+
+func (f *fakeEvaluator) IsEnabled(flagName string, _ uuid.UUID) bool {
+	return f.enabled[flagName]
+}
+
+func (f *fakeEvaluator) Evaluate(_ context.Context, flagName string, _ uuid.UUID) domain.FeatureFlagEvaluation {
+	return domain.FeatureFlagEvaluation{Name: flagName, Enabled: f.enabled[flagName], Source: domain.FeatureFlagSourceRollout}
+}
+
+func (f *fakeEvaluator) FlagNames(_ context.Context) []string {
+	return f.names
+}
+
+func (f *fakeEvaluator) Invalidate() {
+	f.invalidated++
+}
+
+func TestSetFlag_Validates(t *testing.T) {
+	tests := []struct {
+		name string
+		flag string
+		in   SetFlagInput
+	}{
+		{"empty name", "", SetFlagInput{RolloutPercentage: 100}},
+		{"upper case name", "NewReader", SetFlagInput{RolloutPercentage: 100}},
+		{"negative rollout", "new_reader", SetFlagInput{RolloutPercentage: -1}},
+		{"rollout above 100", "new_reader", SetFlagInput{RolloutPercentage: 101}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, evaluator := &fakeFlagStore{}, &fakeEvaluator{}
+			uc := NewUsecase(store, evaluator, "production")
+
+			_, err := uc.SetFlag(context.Background(), tt.flag, tt.in, uuid.New())
+			require.ErrorIs(t, err, ErrInvalidInput)
+			assert.Nil(t, store.storedFlag)
+			assert.Zero(t, evaluator.invalidated)
+		})
+	}
+}
+
+func TestSetFlag_StoresAndInvalidates(t *testing.T) {
+	store, evaluator := &fakeFlagStore{}, &fakeEvaluator{}
+	uc := NewUsecase(store, evaluator, "staging")
+	admin := uuid.New()
+
+	flag, err := uc.SetFlag(context.Background(), "new_reader", SetFlagInput{Enabled: true, RolloutPercentage: 10, Description: "New reader view"}, admin)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", flag.Environment)
+	assert.Equal(t, admin, *flag.UpdatedBy)
+	assert.Same(t, flag, store.storedFlag)
+	assert.Equal(t, 1, evaluator.invalidated)
+}
+
+func TestSetOverride_RequiresStoredFlag(t *testing.T) {
+	store, evaluator := &fakeFlagStore{}, &fakeEvaluator{}
+	uc := NewUsecase(store, evaluator, "production")
+
+	_, err := uc.SetOverride(context.Background(), domain.FlagLensV0, uuid.New(), true, uuid.New())
+	require.True(t, errors.Is(err, domain.ErrItemNotFound))
+	assert.Nil(t, store.storedOverride)
+
+	store.fetched = &domain.FeatureFlag{Name: domain.FlagLensV0}
+	o, err := uc.SetOverride(context.Background(), domain.FlagLensV0, uuid.New(), true, uuid.New())
+	require.NoError(t, err)
+	assert.True(t, o.Enabled)
+	assert.Equal(t, 1, evaluator.invalidated)
+}
+
+func TestClearOverride_NotFound(t *testing.T) {
+	uc := NewUsecase(&fakeFlagStore{}, &fakeEvaluator{}, "production")
+
+	err := uc.ClearOverride(context.Background(), "new_reader", uuid.New())
+	require.ErrorIs(t, err, domain.ErrItemNotFound)
+}
+
+func TestListFlags_AttachesOverrides(t *testing.T) {
+	user := uuid.New()
+	store := &fakeFlagStore{
+		flags:     []domain.FeatureFlag{{Name: "enable_lens"}, {Name: "new_reader"}},
+		overrides: []domain.FeatureFlagOverride{{FlagName: "new_reader", UserID: user, Enabled: true}},
+	}
+	uc := NewUsecase(store, &fakeEvaluator{}, "production")
+
+	flags, err := uc.ListFlags(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, flags[0].Overrides)
+	require.Len(t, flags[1].Overrides, 1)
+	assert.Equal(t, user, flags[1].Overrides[0].UserID)
+}
+
+func TestEvaluate_DefaultsToAllKnownFlags(t *testing.T) {
+	evaluator := &fakeEvaluator{
+		names:   []string{"enable_lens", "new_reader"},
+		enabled: map[string]bool{"new_reader": true},
+	}
+	uc := NewUsecase(&fakeFlagStore{}, evaluator, "production")
+
+	all, err := uc.Evaluate(context.Background(), uuid.New(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.FeatureFlagEvaluation{
+		{Name: "enable_lens", Enabled: false, Source: domain.FeatureFlagSourceRollout},
+		{Name: "new_reader", Enabled: true, Source: domain.FeatureFlagSourceRollout},
+	}, all)
+
+	some, err := uc.Evaluate(context.Background(), uuid.New(), []string{"new_reader"})
+	require.NoError(t, err)
+	assert.Len(t, some, 1)
+
+	_, err = uc.Evaluate(context.Background(), uuid.Nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
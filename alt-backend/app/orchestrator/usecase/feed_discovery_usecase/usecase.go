@@ -0,0 +1,226 @@
+package feed_discovery_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/feed_discovery_port"
+	"alt/utils/html_parser"
+	"alt/utils/logger"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxLinkTagCandidates caps how many advertised feeds are probed; pages
+	// with more (per-category feeds) list the site-wide ones first.
+	maxLinkTagCandidates = 10
+	// probeConcurrency bounds simultaneous outbound probes per request.
+	probeConcurrency = 4
+	probeTimeout     = 8 * time.Second
+)
+
+// commonFeedPaths are tried against the site root only when neither the
+// submitted URL nor its <link> tags yield a feed.
+var commonFeedPaths = []string{
+	"/feed",
+	"/rss",
+	"/feed.xml",
+	"/rss.xml",
+	"/atom.xml",
+	"/index.xml",
+	"/feed.json",
+}
+
+// ErrInvalidDiscoveryURL is returned when the submitted URL is not an
+// absolute http(s) URL.
+var ErrInvalidDiscoveryURL = errors.New("invalid discovery URL")
+
+// ErrPageFetchFailed wraps failures to fetch the submitted page itself.
+var ErrPageFetchFailed = errors.New("failed to fetch page")
+
+type candidate struct {
+	url    string
+	title  string
+	source string
+}
+
+// FeedDiscoveryUsecase finds subscribable feeds for an arbitrary website URL.
+type FeedDiscoveryUsecase struct {
+	pagePort  feed_discovery_port.FetchDiscoveryPagePort
+	probePort feed_discovery_port.ProbeFeedPort
+}
+
+// NewFeedDiscoveryUsecase creates a new feed discovery usecase.
+func NewFeedDiscoveryUsecase(pagePort feed_discovery_port.FetchDiscoveryPagePort, probePort feed_discovery_port.ProbeFeedPort) *FeedDiscoveryUsecase {
+	return &FeedDiscoveryUsecase{
+		pagePort:  pagePort,
+		probePort: probePort,
+	}
+}
+
+// Execute fetches rawURL, collects feed candidates (the URL itself when it is
+// a feed, then <link rel="alternate"> tags, then well-known paths as a
+// fallback), and returns the candidates that parse as feeds. An empty result
+// means the site advertises no feed.
+func (u *FeedDiscoveryUsecase) Execute(ctx context.Context, rawURL string) ([]*domain.DiscoveredFeed, error) {
+	pageURL, err := normalizeDiscoveryURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := u.pagePort.FetchDiscoveryPage(ctx, pageURL)
+	if err != nil {
+		logger.Logger.WarnContext(ctx, "feed discovery page fetch failed", "url", pageURL, "error", err)
+		return nil, fmt.Errorf("%w: %w", ErrPageFetchFailed, err)
+	}
+
+	var candidates []candidate
+	if looksLikeFeed(page) {
+		candidates = append(candidates, candidate{url: page.URL, source: domain.FeedDiscoverySourceDirect})
+	}
+	links := html_parser.ExtractFeedLinks(string(page.Body), page.URL)
+	if len(links) > maxLinkTagCandidates {
+		links = links[:maxLinkTagCandidates]
+	}
+	for _, link := range links {
+		candidates = append(candidates, candidate{url: link.URL, title: link.Title, source: domain.FeedDiscoverySourceLinkTag})
+	}
+
+	feeds := u.probeAll(ctx, candidates)
+	if len(feeds) == 0 {
+		feeds = dedupeAliases(u.probeAll(ctx, commonPathCandidates(page.URL)))
+	}
+
+	logger.Logger.InfoContext(ctx, "feed discovery completed",
+		"url", pageURL, "candidates", len(candidates), "feeds", len(feeds))
+	return feeds, nil
+}
+
+// probeAll validates candidates concurrently and returns the ones that parse,
+// in candidate order, without duplicate URLs.
+func (u *FeedDiscoveryUsecase) probeAll(ctx context.Context, candidates []candidate) []*domain.DiscoveredFeed {
+	results := make([]*domain.DiscoveredFeed, len(candidates))
+	sem := make(chan struct{}, probeConcurrency)
+	var wg sync.WaitGroup
+	for i, c := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+			feed, err := u.probePort.ProbeFeed(probeCtx, c.url)
+			if err != nil {
+				logger.Logger.DebugContext(ctx, "feed candidate rejected", "url", c.url, "source", c.source, "error", err)
+				return
+			}
+			feed.Source = c.source
+			if feed.Title == "" {
+				feed.Title = c.title
+			}
+			results[i] = feed
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]struct{}, len(results))
+	feeds := make([]*domain.DiscoveredFeed, 0, len(results))
+	for _, f := range results {
+		if f == nil {
+			continue
+		}
+		if _, dup := seen[f.URL]; dup {
+			continue
+		}
+		seen[f.URL] = struct{}{}
+		feeds = append(feeds, f)
+	}
+	return feeds
+}
+
+// normalizeDiscoveryURL accepts bare hosts ("example.com") by assuming https
+// and rejects anything that is not an absolute http(s) URL.
+func normalizeDiscoveryURL(rawURL string) (string, error) {
+	trimmed := strings.TrimSpace(rawURL)
+	if trimmed == "" {
+		return "", fmt.Errorf("%w: url is required", ErrInvalidDiscoveryURL)
+	}
+	if !strings.Contains(trimmed, "://") {
+		trimmed = "https://" + trimmed
+	}
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("%w: malformed url", ErrInvalidDiscoveryURL)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("%w: only http and https are supported", ErrInvalidDiscoveryURL)
+	}
+	parsed.Fragment = ""
+	return parsed.String(), nil
+}
+
+// looksLikeFeed reports whether the fetched page is itself a feed document,
+// judged by Content-Type and, for servers that send text/plain or
+// application/octet-stream, by the first non-blank bytes.
+func looksLikeFeed(page *domain.DiscoveryPage) bool {
+	ct := strings.ToLower(page.ContentType)
+	if strings.Contains(ct, "rss") || strings.Contains(ct, "atom") || strings.Contains(ct, "feed+json") {
+		return true
+	}
+	if strings.Contains(ct, "html") {
+		return false
+	}
+	head := bytes.TrimSpace(page.Body)
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	return bytes.HasPrefix(head, []byte("{")) ||
+		bytes.Contains(head, []byte("<rss")) ||
+		bytes.Contains(head, []byte("<feed")) ||
+		bytes.Contains(head, []byte("<rdf:RDF"))
+}
+
+func commonPathCandidates(pageURL string) []candidate {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	candidates := make([]candidate, 0, len(commonFeedPaths))
+	for _, p := range commonFeedPaths {
+		u := url.URL{Scheme: base.Scheme, Host: base.Host, Path: p}
+		candidates = append(candidates, candidate{url: u.String(), source: domain.FeedDiscoverySourceCommonURL})
+	}
+	return candidates
+}
+
+// dedupeAliases drops well-known paths that serve the same feed as an
+// earlier path (e.g. WordPress answers both /feed and /rss).
+func dedupeAliases(feeds []*domain.DiscoveredFeed) []*domain.DiscoveredFeed {
+	type key struct {
+		title  string
+		format string
+		items  int
+	}
+	seen := make(map[key]struct{}, len(feeds))
+	out := make([]*domain.DiscoveredFeed, 0, len(feeds))
+	for _, f := range feeds {
+		k := key{f.Title, f.Format, f.ItemCount}
+		if _, dup := seen[k]; dup {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, f)
+	}
+	return out
+}
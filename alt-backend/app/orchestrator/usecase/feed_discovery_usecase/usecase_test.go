@@ -0,0 +1,136 @@
+package feed_discovery_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePagePort struct {
+	page *domain.DiscoveryPage
+	err  error
+	got  string
+}
+
+func (f *fakePagePort) FetchDiscoveryPage(_ context.Context, pageURL string) (*domain.DiscoveryPage, error) {
+	f.got = pageURL
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.page, nil
+}
+
+type fakeProbePort struct {
+	mu     sync.Mutex
+	feeds  map[string]domain.DiscoveredFeed
+	probed []string
+}
+
+func (f *fakeProbePort) ProbeFeed(_ context.Context, feedURL string) (*domain.DiscoveredFeed, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probed = append(f.probed, feedURL)
+	feed, ok := f.feeds[feedURL]
+	if !ok {
+		return nil, errors.New("not a feed")
+	}
+	feed.URL = feedURL
+	return &feed, nil
+}
+
+const blogHTML = `<html><head>
+	<link rel="alternate" type="application/rss+xml" title="Posts" href="/feed.xml">
+	<link rel="alternate" type="application/atom+xml" title="Comments" href="/comments.atom">
+	<link rel="alternate" type="application/rss+xml" href="/broken.xml">
+</head></html>`
+
+func TestExecute_LinkTags(t *testing.T) {
+	pages := &fakePagePort{page: &domain.DiscoveryPage{
+		URL: "https://blog.example.com/", ContentType: "text/html; charset=utf-8", Body: []byte(blogHTML),
+	}}
+	probe := &fakeProbePort{feeds: map[string]domain.DiscoveredFeed{
+		"https://blog.example.com/feed.xml":      {Title: "Example Blog", Format: domain.FeedFormatRSS, ItemCount: 20},
+		"https://blog.example.com/comments.atom": {Format: domain.FeedFormatAtom, ItemCount: 5},
+	}}
+	uc := NewFeedDiscoveryUsecase(pages, probe)
+
+	feeds, err := uc.Execute(context.Background(), "  blog.example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "https://blog.example.com", pages.got)
+
+	require.Len(t, feeds, 2)
+	assert.Equal(t, &domain.DiscoveredFeed{
+		URL: "https://blog.example.com/feed.xml", Title: "Example Blog", Format: domain.FeedFormatRSS,
+		ItemCount: 20, Source: domain.FeedDiscoverySourceLinkTag,
+	}, feeds[0])
+	// Untitled feeds fall back to the <link title>.
+	assert.Equal(t, "Comments", feeds[1].Title)
+	// Common paths are a fallback only.
+	assert.Len(t, probe.probed, 3)
+}
+
+func TestExecute_DirectFeedURL(t *testing.T) {
+	pages := &fakePagePort{page: &domain.DiscoveryPage{
+		URL: "https://news.example.com/rss", ContentType: "text/xml", Body: []byte(`<?xml version="1.0"?><rss version="2.0"></rss>`),
+	}}
+	probe := &fakeProbePort{feeds: map[string]domain.DiscoveredFeed{
+		"https://news.example.com/rss": {Title: "News", Format: domain.FeedFormatRSS, ItemCount: 3},
+	}}
+
+	feeds, err := NewFeedDiscoveryUsecase(pages, probe).Execute(context.Background(), "https://news.example.com/rss")
+	require.NoError(t, err)
+	require.Len(t, feeds, 1)
+	assert.Equal(t, domain.FeedDiscoverySourceDirect, feeds[0].Source)
+}
+
+func TestExecute_FallsBackToCommonPaths(t *testing.T) {
+	pages := &fakePagePort{page: &domain.DiscoveryPage{
+		URL: "https://site.example.com/about", ContentType: "text/html", Body: []byte("<html><head></head></html>"),
+	}}
+	wp := domain.DiscoveredFeed{Title: "Site", Format: domain.FeedFormatRSS, ItemCount: 10}
+	probe := &fakeProbePort{feeds: map[string]domain.DiscoveredFeed{
+		"https://site.example.com/feed":      wp,
+		"https://site.example.com/rss":       wp, // alias of /feed
+		"https://site.example.com/feed.json": {Title: "Site", Format: domain.FeedFormatJSON, ItemCount: 10},
+	}}
+
+	feeds, err := NewFeedDiscoveryUsecase(pages, probe).Execute(context.Background(), "https://site.example.com/about")
+	require.NoError(t, err)
+	require.Len(t, feeds, 2)
+	assert.Equal(t, "https://site.example.com/feed", feeds[0].URL)
+	assert.Equal(t, domain.FeedDiscoverySourceCommonURL, feeds[0].Source)
+	assert.Equal(t, "https://site.example.com/feed.json", feeds[1].URL)
+}
+
+func TestExecute_NoFeeds(t *testing.T) {
+	pages := &fakePagePort{page: &domain.DiscoveryPage{URL: "https://plain.example.com/", ContentType: "text/html"}}
+
+	feeds, err := NewFeedDiscoveryUsecase(pages, &fakeProbePort{}).Execute(context.Background(), "https://plain.example.com/")
+	require.NoError(t, err)
+	assert.Empty(t, feeds)
+}
+
+func TestExecute_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		pageErr error
+		want    error
+	}{
+		{name: "empty", url: " ", want: ErrInvalidDiscoveryURL},
+		{name: "unsupported scheme", url: "ftp://example.com/feed", want: ErrInvalidDiscoveryURL},
+		{name: "page fetch fails", url: "https://down.example.com", pageErr: errors.New("connection refused"), want: ErrPageFetchFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewFeedDiscoveryUsecase(&fakePagePort{err: tt.pageErr}, &fakeProbePort{})
+			_, err := uc.Execute(context.Background(), tt.url)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package home_ranking_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/fetch_feed_port"
+	"alt/orchestrator/port/home_ranking_port"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLimit and MaxLimit match the chronological unread timeline.
+	DefaultLimit = 20
+	MaxLimit     = 100
+
+	// maxExperimentNameLength bounds the free-form experiment label.
+	maxExperimentNameLength = 64
+
+	// recencyHalfLife is the age at which an item's recency signal halves.
+	recencyHalfLife = 24 * time.Hour
+	// affinitySaturation is the read count at which feed affinity reaches
+	// 0.5; it approaches 1 as reads grow.
+	affinitySaturation = 5.0
+	// historyWindow is how far back reads count towards feed affinity and
+	// the read-history tag profile.
+	historyWindow = 30 * 24 * time.Hour
+	// trendingWindow is how far back new items count towards trending tags.
+	trendingWindow = 48 * time.Hour
+)
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid home ranking input")
+
+// Usecase ranks the unread home timeline by a weighted blend of recency,
+// feed affinity, read-history similarity and trending score. Ranking
+// reorders items within a page of the chronological timeline, so pages
+// never overlap or skip items and the cursor stays a created_at timestamp.
+type Usecase struct {
+	candidates  fetch_feed_port.UnreadFeedCursorPort
+	signals     home_ranking_port.HomeRankingSignalPort
+	experiments home_ranking_port.HomeRankingExperimentPort
+	now         func() time.Time
+}
+
+// NewUsecase creates a new home ranking usecase.
+func NewUsecase(
+	candidates fetch_feed_port.UnreadFeedCursorPort,
+	signals home_ranking_port.HomeRankingSignalPort,
+	experiments home_ranking_port.HomeRankingExperimentPort,
+) *Usecase {
+	return &Usecase{
+		candidates:  candidates,
+		signals:     signals,
+		experiments: experiments,
+		now:         time.Now,
+	}
+}
+
+// RankHomeFeed returns the page of userID's unread timeline after cursor,
+// ordered by ranking score. limit <= 0 selects DefaultLimit. The user must
+// also be on ctx, since the timeline query is scoped by it.
+func (u *Usecase) RankHomeFeed(ctx context.Context, userID uuid.UUID, cursor *time.Time, limit int) (*domain.HomeRankingPage, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user id is required", ErrInvalidInput)
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		return nil, fmt.Errorf("%w: limit must be at most %d", ErrInvalidInput, MaxLimit)
+	}
+
+	exp := u.resolveExperiment(ctx, userID)
+	page := &domain.HomeRankingPage{
+		Experiment: exp.Experiment,
+		Weights:    exp.Weights,
+		Items:      []domain.RankedFeedItem{},
+	}
+
+	// Fetch one extra item to detect hasMore, as the chronological timeline does.
+	feeds, err := u.candidates.FetchUnreadFeedsListCursor(ctx, cursor, limit+1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch unread feeds: %w", err)
+	}
+	if len(feeds) > limit {
+		page.HasMore = true
+		feeds = feeds[:limit]
+	}
+	if len(feeds) == 0 {
+		return page, nil
+	}
+	if page.HasMore {
+		oldest := feeds[len(feeds)-1].PublishedParsed
+		page.NextCursor = &oldest
+	}
+
+	now := u.now()
+	feedIDs := make([]uuid.UUID, 0, len(feeds))
+	for _, f := range feeds {
+		if f.FeedID != uuid.Nil {
+			feedIDs = append(feedIDs, f.FeedID)
+		}
+	}
+	inputs, err := u.signals.FetchHomeRankingInputs(ctx, userID, feedIDs, now.Add(-historyWindow), now.Add(-trendingWindow))
+	if err != nil {
+		// A timeline in chronological order beats no timeline.
+		logger.Logger.WarnContext(ctx, "home ranking inputs unavailable, ranking on recency only", "error", err)
+		inputs = &domain.HomeRankingInputs{}
+		page.Degraded = true
+	}
+
+	page.Items = rank(feeds, inputs, exp.Weights, now)
+	return page, nil
+}
+
+// GetExperiment returns userID's ranking weights, the defaults when the
+// user has no assignment.
+func (u *Usecase) GetExperiment(ctx context.Context, userID uuid.UUID) (*domain.HomeRankingExperiment, error) {
+	exp, err := u.experiments.FetchHomeRankingExperiment(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch home ranking experiment: %w", err)
+	}
+	if exp == nil {
+		return defaultExperiment(userID), nil
+	}
+	return exp, nil
+}
+
+// SetExperiment assigns userID to an experiment with the given weights.
+func (u *Usecase) SetExperiment(ctx context.Context, userID uuid.UUID, experiment string, weights domain.HomeRankingWeights) (*domain.HomeRankingExperiment, error) {
+	experiment = strings.TrimSpace(experiment)
+	switch {
+	case userID == uuid.Nil:
+		return nil, fmt.Errorf("%w: user id is required", ErrInvalidInput)
+	case experiment == "":
+		return nil, fmt.Errorf("%w: experiment is required", ErrInvalidInput)
+	case len(experiment) > maxExperimentNameLength:
+		return nil, fmt.Errorf("%w: experiment must be at most %d characters", ErrInvalidInput, maxExperimentNameLength)
+	}
+	if err := weights.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: weights must be non-negative and not all zero", ErrInvalidInput)
+	}
+
+	exp := &domain.HomeRankingExperiment{UserID: userID, Experiment: experiment, Weights: weights}
+	if err := u.experiments.UpsertHomeRankingExperiment(ctx, exp); err != nil {
+		return nil, fmt.Errorf("store home ranking experiment: %w", err)
+	}
+	return exp, nil
+}
+
+// ClearExperiment returns userID to the default weights. It returns
+// domain.ErrItemNotFound when the user had no assignment.
+func (u *Usecase) ClearExperiment(ctx context.Context, userID uuid.UUID) error {
+	deleted, err := u.experiments.DeleteHomeRankingExperiment(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("delete home ranking experiment: %w", err)
+	}
+	if !deleted {
+		return domain.ErrItemNotFound
+	}
+	return nil
+}
+
+// resolveExperiment falls back to the defaults when the assignment cannot
+// be loaded, so a broken row never takes the home feed down.
+func (u *Usecase) resolveExperiment(ctx context.Context, userID uuid.UUID) *domain.HomeRankingExperiment {
+	exp, err := u.experiments.FetchHomeRankingExperiment(ctx, userID)
+	if err != nil {
+		logger.Logger.WarnContext(ctx, "home ranking experiment unavailable, using default weights", "error", err)
+		return defaultExperiment(userID)
+	}
+	if exp == nil || exp.Weights.Validate() != nil {
+		return defaultExperiment(userID)
+	}
+	return exp
+}
+
+func defaultExperiment(userID uuid.UUID) *domain.HomeRankingExperiment {
+	return &domain.HomeRankingExperiment{
+		UserID:     userID,
+		Experiment: domain.DefaultHomeRankingExperiment,
+		Weights:    domain.DefaultHomeRankingWeights,
+	}
+}
+
+// rank scores feeds (newest first) and orders them by score. Ties keep
+// chronological order.
+func rank(feeds []*domain.FeedItem, inputs *domain.HomeRankingInputs, weights domain.HomeRankingWeights, now time.Time) []domain.RankedFeedItem {
+	readNorm := tagNorm(inputs.ReadTags)
+	maxTrending := 0
+	for _, n := range inputs.TrendingTags {
+		maxTrending = max(maxTrending, n)
+	}
+
+	items := make([]domain.RankedFeedItem, len(feeds))
+	for i, f := range feeds {
+		tags := inputs.FeedTags[f.FeedID]
+		signals := domain.HomeRankingSignals{
+			Recency:      recency(f.PublishedParsed, now),
+			FeedAffinity: affinity(inputs.FeedReads[f.FeedID]),
+		}
+		var matched []string
+		signals.ReadSimilarity, matched = similarity(tags, inputs.ReadTags, readNorm)
+		signals.Trending = trending(tags, inputs.TrendingTags, maxTrending)
+
+		score, contributions := weights.Score(signals)
+		items[i] = domain.RankedFeedItem{
+			Item:              f,
+			ChronologicalRank: i + 1,
+			Score:             score,
+			Signals:           signals,
+			Contributions:     contributions,
+			MatchedTags:       matched,
+		}
+	}
+
+	sort.SliceStable(items, func(a, b int) bool { return items[a].Score > items[b].Score })
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+	return items
+}
+
+// recency decays from 1 for a brand-new item, halving every
+// recencyHalfLife.
+func recency(createdAt, now time.Time) float64 {
+	if createdAt.IsZero() {
+		return 0
+	}
+	age := now.Sub(createdAt)
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, age.Hours()/recencyHalfLife.Hours())
+}
+
+// affinity grows with how often the user read the item's feed, saturating
+// towards 1.
+func affinity(reads int) float64 {
+	if reads <= 0 {
+		return 0
+	}
+	return float64(reads) / (float64(reads) + affinitySaturation)
+}
+
+// similarity is the cosine similarity between the item's tag set and the
+// user's read-tag profile. It also returns the item's tags found in the
+// profile.
+func similarity(tags []string, profile map[string]int, profileNorm float64) (float64, []string) {
+	if len(tags) == 0 || profileNorm == 0 {
+		return 0, nil
+	}
+	var (
+		dot     float64
+		matched []string
+	)
+	for _, t := range tags {
+		if n := profile[t]; n > 0 {
+			dot += float64(n)
+			matched = append(matched, t)
+		}
+	}
+	return dot / (math.Sqrt(float64(len(tags))) * profileNorm), matched
+}
+
+// trending is the item's hottest tag relative to the hottest tag overall.
+func trending(tags []string, counts map[string]int, maxCount int) float64 {
+	if maxCount == 0 {
+		return 0
+	}
+	best := 0
+	for _, t := range tags {
+		best = max(best, counts[t])
+	}
+	return float64(best) / float64(maxCount)
+}
+
+func tagNorm(counts map[string]int) float64 {
+	var sum float64
+	for _, n := range counts {
+		sum += float64(n) * float64(n)
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,185 @@
+package home_ranking_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCandidates struct {
+	feeds      []*domain.FeedItem
+	fetchLimit int
+}
+
+func (f *fakeCandidates) FetchUnreadFeedsListCursor(_ context.Context, _ *time.Time, limit int, _ []uuid.UUID) ([]*domain.FeedItem, error) {
+	f.fetchLimit = limit
+	if len(f.feeds) > limit {
+		return f.feeds[:limit], nil
+	}
+	return f.feeds, nil
+}
+
+type fakeRankingStore struct {
+	inputs    *domain.HomeRankingInputs
+	inputsErr error
+	exp       *domain.HomeRankingExperiment
+	expErr    error
+	stored    *domain.HomeRankingExperiment
+	deleted   bool
+}
+
+func (f *fakeRankingStore) FetchHomeRankingInputs(_ context.Context, _ uuid.UUID, _ []uuid.UUID, _, _ time.Time) (*domain.HomeRankingInputs, error) {
+	return f.inputs, f.inputsErr
+}
+
+func (f *fakeRankingStore) FetchHomeRankingExperiment(_ context.Context, _ uuid.UUID) (*domain.HomeRankingExperiment, error) {
+	return f.exp, f.expErr
+}
+
+func (f *fakeRankingStore) UpsertHomeRankingExperiment(_ context.Context, exp *domain.HomeRankingExperiment) error {
+	f.stored = exp
+	return nil
+}
+
+func (f *fakeRankingStore) DeleteHomeRankingExperiment(_ context.Context, _ uuid.UUID) (bool, error) {
+	return f.deleted, nil
+}
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newTestUsecase(feeds []*domain.FeedItem, store *fakeRankingStore) (*Usecase, *fakeCandidates) {
+	candidates := &fakeCandidates{feeds: feeds}
+	uc := NewUsecase(candidates, store, store)
+	uc.now = func() time.Time { return testNow }
+	return uc, candidates
+}
+
+func feedItem(title string, age time.Duration) *domain.FeedItem {
+	return &domain.FeedItem{Title: title, FeedID: uuid.New(), PublishedParsed: testNow.Add(-age)}
+}
+
+func titles(page *domain.HomeRankingPage) []string {
+	out := make([]string, len(page.Items))
+	for i, item := range page.Items {
+		out[i] = item.Item.Title
+	}
+	return out
+}
+
+func TestRankHomeFeed_BlendsSignals(t *testing.T) {
+	newest := feedItem("newest", time.Hour)
+	favourite := feedItem("favourite feed", 6*time.Hour)
+	onTopic := feedItem("on topic", 12*time.Hour)
+	store := &fakeRankingStore{inputs: &domain.HomeRankingInputs{
+		FeedReads:    map[uuid.UUID]int{favourite.FeedID: 40},
+		FeedTags:     map[uuid.UUID][]string{onTopic.FeedID: {"go", "databases"}},
+		ReadTags:     map[string]int{"go": 10},
+		TrendingTags: map[string]int{"go": 3},
+	}}
+	uc, _ := newTestUsecase([]*domain.FeedItem{newest, favourite, onTopic}, store)
+
+	page, err := uc.RankHomeFeed(context.Background(), uuid.New(), nil, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.DefaultHomeRankingExperiment, page.Experiment)
+	assert.Equal(t, []string{"on topic", "favourite feed", "newest"}, titles(page))
+	top := page.Items[0]
+	assert.Equal(t, 1, top.Rank)
+	assert.Equal(t, 3, top.ChronologicalRank)
+	assert.Equal(t, []string{"go"}, top.MatchedTags)
+	assert.InDelta(t, 1.0, top.Signals.Trending, 1e-9)
+	assert.InDelta(t, top.Score, top.Contributions.Recency+top.Contributions.FeedAffinity+
+		top.Contributions.ReadSimilarity+top.Contributions.Trending, 1e-9)
+	assert.False(t, page.Degraded)
+}
+
+func TestRankHomeFeed_UsesExperimentWeights(t *testing.T) {
+	newest := feedItem("newest", time.Hour)
+	favourite := feedItem("favourite feed", 6*time.Hour)
+	store := &fakeRankingStore{
+		inputs: &domain.HomeRankingInputs{FeedReads: map[uuid.UUID]int{favourite.FeedID: 40}},
+		exp: &domain.HomeRankingExperiment{
+			Experiment: "recency-only",
+			Weights:    domain.HomeRankingWeights{Recency: 1},
+		},
+	}
+	uc, _ := newTestUsecase([]*domain.FeedItem{newest, favourite}, store)
+
+	page, err := uc.RankHomeFeed(context.Background(), uuid.New(), nil, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, "recency-only", page.Experiment)
+	assert.Equal(t, []string{"newest", "favourite feed"}, titles(page))
+}
+
+func TestRankHomeFeed_PagesChronologically(t *testing.T) {
+	feeds := []*domain.FeedItem{
+		feedItem("a", time.Hour),
+		feedItem("b", 2*time.Hour),
+		feedItem("c", 3*time.Hour),
+	}
+	uc, candidates := newTestUsecase(feeds, &fakeRankingStore{inputs: &domain.HomeRankingInputs{}})
+
+	page, err := uc.RankHomeFeed(context.Background(), uuid.New(), nil, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, candidates.fetchLimit)
+	assert.True(t, page.HasMore)
+	require.Len(t, page.Items, 2)
+	require.NotNil(t, page.NextCursor)
+	assert.Equal(t, feeds[1].PublishedParsed, *page.NextCursor)
+}
+
+func TestRankHomeFeed_DegradesWithoutInputs(t *testing.T) {
+	store := &fakeRankingStore{
+		inputsErr: errors.New("connection refused"),
+		expErr:    errors.New("connection refused"),
+	}
+	uc, _ := newTestUsecase([]*domain.FeedItem{feedItem("old", 5*time.Hour), feedItem("new", time.Hour)}, store)
+
+	page, err := uc.RankHomeFeed(context.Background(), uuid.New(), nil, 10)
+	require.NoError(t, err)
+
+	assert.True(t, page.Degraded)
+	assert.Equal(t, domain.DefaultHomeRankingWeights, page.Weights)
+	assert.Equal(t, []string{"new", "old"}, titles(page))
+}
+
+func TestRankHomeFeed_RejectsOversizedLimit(t *testing.T) {
+	uc, _ := newTestUsecase(nil, &fakeRankingStore{})
+
+	_, err := uc.RankHomeFeed(context.Background(), uuid.New(), nil, MaxLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestSetExperiment_Validates(t *testing.T) {
+	store := &fakeRankingStore{}
+	uc, _ := newTestUsecase(nil, store)
+	userID := uuid.New()
+
+	_, err := uc.SetExperiment(context.Background(), userID, " ", domain.DefaultHomeRankingWeights)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = uc.SetExperiment(context.Background(), userID, "zero", domain.HomeRankingWeights{})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = uc.SetExperiment(context.Background(), userID, "negative", domain.HomeRankingWeights{Recency: 1, Trending: -1})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	assert.Nil(t, store.stored)
+
+	exp, err := uc.SetExperiment(context.Background(), userID, " affinity-heavy ", domain.HomeRankingWeights{Recency: 1, FeedAffinity: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "affinity-heavy", exp.Experiment)
+	assert.Same(t, exp, store.stored)
+}
+
+func TestClearExperiment_NotFound(t *testing.T) {
+	uc, _ := newTestUsecase(nil, &fakeRankingStore{})
+
+	err := uc.ClearExperiment(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, domain.ErrItemNotFound)
+}
@@ -0,0 +1,128 @@
+package legal_hold_usecase
+
+import (
+	"alt/domain"
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Export archive layout: one <section>.jsonl per domain.AccountExportSections
+// entry, manifest.json (domain.AccountExportManifest), and SHA256SUMS in
+// sha256sum(1) format covering every other file so recipients can run
+// `sha256sum -c SHA256SUMS` after unzipping.
+const (
+	exportManifestName = "manifest.json"
+	exportChecksumName = "SHA256SUMS"
+)
+
+// ExportAccount writes a zip archive of every per-account dataset to w and
+// returns its manifest. The export is recorded in the compliance audit log.
+func (u *Usecase) ExportAccount(ctx context.Context, actorID, userID uuid.UUID, w io.Writer) (*domain.AccountExportManifest, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidInput)
+	}
+
+	onHold, err := u.IsOnHold(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &domain.AccountExportManifest{
+		UserID:      userID,
+		GeneratedAt: u.now().UTC(),
+		GeneratedBy: actorID,
+		LegalHold:   onHold,
+		Files:       make([]domain.AccountExportFile, 0, len(domain.AccountExportSections)+1),
+	}
+
+	zw := zip.NewWriter(w)
+	for _, section := range domain.AccountExportSections {
+		name := section + ".jsonl"
+		var rows int64
+		file, err := writeZipEntry(zw, name, func(dst io.Writer) error {
+			n, err := u.exportPort.ExportAccountSection(ctx, userID, section, dst)
+			rows = n
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("export section %s: %w", section, err)
+		}
+		file.Rows = rows
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	manifestFile, err := writeZipEntry(zw, exportManifestName, func(dst io.Writer) error {
+		enc := json.NewEncoder(dst)
+		enc.SetIndent("", "  ")
+		return enc.Encode(manifest)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("write export manifest: %w", err)
+	}
+
+	if _, err := writeZipEntry(zw, exportChecksumName, func(dst io.Writer) error {
+		_, err := io.WriteString(dst, checksumList(append(manifest.Files, manifestFile)))
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("write export checksums: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("finalize export archive: %w", err)
+	}
+
+	details := map[string]any{
+		"manifest_sha256": manifestFile.SHA256,
+		"files":           len(manifest.Files),
+		"legal_hold":      onHold,
+	}
+	if err := u.audit(ctx, actorID, userID, domain.ComplianceActionAccountExported, details); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// writeZipEntry creates a zip entry, lets fill write its content, and returns
+// the entry's size and SHA-256.
+func writeZipEntry(zw *zip.Writer, name string, fill func(io.Writer) error) (domain.AccountExportFile, error) {
+	entry, err := zw.Create(name)
+	if err != nil {
+		return domain.AccountExportFile{}, err
+	}
+	counter := &countingHash{h: sha256.New()}
+	if err := fill(io.MultiWriter(entry, counter)); err != nil {
+		return domain.AccountExportFile{}, err
+	}
+	return domain.AccountExportFile{
+		Name:   name,
+		Bytes:  counter.n,
+		SHA256: hex.EncodeToString(counter.h.Sum(nil)),
+	}, nil
+}
+
+func checksumList(files []domain.AccountExportFile) string {
+	var b strings.Builder
+	for _, f := range files {
+		fmt.Fprintf(&b, "%s  %s\n", f.SHA256, f.Name)
+	}
+	return b.String()
+}
+
+type countingHash struct {
+	h hash.Hash
+	n int64
+}
+
+func (c *countingHash) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return c.h.Write(p)
+}
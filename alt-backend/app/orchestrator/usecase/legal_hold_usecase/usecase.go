@@ -0,0 +1,148 @@
+package legal_hold_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/legal_hold_port"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxReasonLength bounds the free-text hold reason stored in the audit trail.
+const maxReasonLength = 1000
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid legal hold input")
+
+// Usecase places and releases legal holds and produces verifiable account
+// exports. Every mutating action is recorded in the compliance audit log.
+type Usecase struct {
+	holdPort   legal_hold_port.LegalHoldPort
+	auditPort  legal_hold_port.RecordComplianceAuditPort
+	exportPort legal_hold_port.ExportAccountSectionPort
+	now        func() time.Time
+}
+
+// NewUsecase creates a new legal hold usecase.
+func NewUsecase(
+	holdPort legal_hold_port.LegalHoldPort,
+	auditPort legal_hold_port.RecordComplianceAuditPort,
+	exportPort legal_hold_port.ExportAccountSectionPort,
+) *Usecase {
+	return &Usecase{
+		holdPort:   holdPort,
+		auditPort:  auditPort,
+		exportPort: exportPort,
+		now:        time.Now,
+	}
+}
+
+// PlaceHold places an account on legal hold on behalf of actorID.
+func (u *Usecase) PlaceHold(ctx context.Context, actorID, userID uuid.UUID, reason string) (*domain.LegalHold, error) {
+	reason = strings.TrimSpace(reason)
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidInput)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidInput)
+	}
+	if len(reason) > maxReasonLength {
+		return nil, fmt.Errorf("%w: reason exceeds %d characters", ErrInvalidInput, maxReasonLength)
+	}
+
+	hold := &domain.LegalHold{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Reason:   reason,
+		PlacedBy: actorID,
+		PlacedAt: u.now(),
+	}
+	if err := u.holdPort.PlaceLegalHold(ctx, hold); err != nil {
+		return nil, fmt.Errorf("place legal hold: %w", err)
+	}
+
+	logger.Logger.InfoContext(ctx, "legal hold placed",
+		"user_id", userID, "hold_id", hold.ID, "actor_id", actorID)
+
+	// The hold is already in force; an audit failure is surfaced to the
+	// caller rather than rolled back so the account is never left unprotected.
+	if err := u.audit(ctx, actorID, userID, domain.ComplianceActionLegalHoldPlaced, map[string]any{
+		"hold_id": hold.ID.String(),
+		"reason":  reason,
+	}); err != nil {
+		return hold, err
+	}
+	return hold, nil
+}
+
+// ReleaseHold releases the active legal hold on an account.
+func (u *Usecase) ReleaseHold(ctx context.Context, actorID, userID uuid.UUID) (*domain.LegalHold, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidInput)
+	}
+
+	hold, err := u.holdPort.ReleaseLegalHold(ctx, userID, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("release legal hold: %w", err)
+	}
+
+	logger.Logger.InfoContext(ctx, "legal hold released",
+		"user_id", userID, "hold_id", hold.ID, "actor_id", actorID)
+
+	if err := u.audit(ctx, actorID, userID, domain.ComplianceActionLegalHoldReleased, map[string]any{
+		"hold_id": hold.ID.String(),
+	}); err != nil {
+		return hold, err
+	}
+	return hold, nil
+}
+
+// GetHold returns the active legal hold for an account, or nil.
+func (u *Usecase) GetHold(ctx context.Context, userID uuid.UUID) (*domain.LegalHold, error) {
+	hold, err := u.holdPort.GetActiveLegalHold(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get legal hold: %w", err)
+	}
+	return hold, nil
+}
+
+// ListHolds returns every active legal hold. Retention and deletion jobs in
+// other services fetch this set once per run and skip held accounts.
+func (u *Usecase) ListHolds(ctx context.Context) ([]*domain.LegalHold, error) {
+	holds, err := u.holdPort.ListActiveLegalHolds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list legal holds: %w", err)
+	}
+	return holds, nil
+}
+
+// IsOnHold reports whether the account has an active legal hold.
+func (u *Usecase) IsOnHold(ctx context.Context, userID uuid.UUID) (bool, error) {
+	hold, err := u.GetHold(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return hold != nil, nil
+}
+
+func (u *Usecase) audit(ctx context.Context, actorID, userID uuid.UUID, action string, details map[string]any) error {
+	entry := &domain.ComplianceAuditEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ActorID:   actorID,
+		Action:    action,
+		Details:   details,
+		CreatedAt: u.now(),
+	}
+	if err := u.auditPort.RecordComplianceAudit(ctx, entry); err != nil {
+		logger.Logger.ErrorContext(ctx, "failed to record compliance audit",
+			"action", action, "user_id", userID, "error", err)
+		return fmt.Errorf("record compliance audit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package legal_hold_usecase
+
+import (
+	"alt/domain"
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHoldPort struct {
+	active   map[uuid.UUID]*domain.LegalHold
+	placeErr error
+}
+
+func newFakeHoldPort() *fakeHoldPort {
+	return &fakeHoldPort{active: map[uuid.UUID]*domain.LegalHold{}}
+}
+
+func (f *fakeHoldPort) PlaceLegalHold(_ context.Context, hold *domain.LegalHold) error {
+	if f.placeErr != nil {
+		return f.placeErr
+	}
+	if _, ok := f.active[hold.UserID]; ok {
+		return domain.ErrLegalHoldAlreadyActive
+	}
+	f.active[hold.UserID] = hold
+	return nil
+}
+
+func (f *fakeHoldPort) ReleaseLegalHold(_ context.Context, userID, releasedBy uuid.UUID) (*domain.LegalHold, error) {
+	hold, ok := f.active[userID]
+	if !ok {
+		return nil, domain.ErrLegalHoldNotFound
+	}
+	now := time.Now()
+	hold.ReleasedBy = &releasedBy
+	hold.ReleasedAt = &now
+	delete(f.active, userID)
+	return hold, nil
+}
+
+func (f *fakeHoldPort) GetActiveLegalHold(_ context.Context, userID uuid.UUID) (*domain.LegalHold, error) {
+	return f.active[userID], nil
+}
+
+func (f *fakeHoldPort) ListActiveLegalHolds(_ context.Context) ([]*domain.LegalHold, error) {
+	holds := []*domain.LegalHold{}
+	for _, h := range f.active {
+		holds = append(holds, h)
+	}
+	return holds, nil
+}
+
+type fakeAuditPort struct {
+	entries []*domain.ComplianceAuditEntry
+	err     error
+}
+
+func (f *fakeAuditPort) RecordComplianceAudit(_ context.Context, entry *domain.ComplianceAuditEntry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+type fakeExportPort struct {
+	rows map[string][]string
+	err  error
+}
+
+func (f *fakeExportPort) ExportAccountSection(_ context.Context, _ uuid.UUID, section string, w io.Writer) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	for _, line := range f.rows[section] {
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(f.rows[section])), nil
+}
+
+func TestPlaceHold_RecordsAudit(t *testing.T) {
+	holds, audit := newFakeHoldPort(), &fakeAuditPort{}
+	uc := NewUsecase(holds, audit, &fakeExportPort{})
+	actor, user := uuid.New(), uuid.New()
+
+	hold, err := uc.PlaceHold(context.Background(), actor, user, "  litigation 2026-17  ")
+	require.NoError(t, err)
+	assert.Equal(t, "litigation 2026-17", hold.Reason)
+	assert.Equal(t, actor, hold.PlacedBy)
+
+	onHold, err := uc.IsOnHold(context.Background(), user)
+	require.NoError(t, err)
+	assert.True(t, onHold)
+
+	require.Len(t, audit.entries, 1)
+	assert.Equal(t, domain.ComplianceActionLegalHoldPlaced, audit.entries[0].Action)
+	assert.Equal(t, hold.ID.String(), audit.entries[0].Details["hold_id"])
+}
+
+func TestPlaceHold_Validation(t *testing.T) {
+	uc := NewUsecase(newFakeHoldPort(), &fakeAuditPort{}, &fakeExportPort{})
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		reason string
+	}{
+		{name: "nil user", userID: uuid.Nil, reason: "case"},
+		{name: "blank reason", userID: uuid.New(), reason: "   "},
+		{name: "reason too long", userID: uuid.New(), reason: strings.Repeat("x", maxReasonLength+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.PlaceHold(context.Background(), uuid.New(), tt.userID, tt.reason)
+			require.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestPlaceHold_AlreadyActive(t *testing.T) {
+	uc := NewUsecase(newFakeHoldPort(), &fakeAuditPort{}, &fakeExportPort{})
+	user := uuid.New()
+
+	_, err := uc.PlaceHold(context.Background(), uuid.New(), user, "first")
+	require.NoError(t, err)
+	_, err = uc.PlaceHold(context.Background(), uuid.New(), user, "second")
+	require.ErrorIs(t, err, domain.ErrLegalHoldAlreadyActive)
+}
+
+func TestPlaceHold_AuditFailureKeepsHold(t *testing.T) {
+	holds := newFakeHoldPort()
+	uc := NewUsecase(holds, &fakeAuditPort{err: errors.New("db down")}, &fakeExportPort{})
+	user := uuid.New()
+
+	hold, err := uc.PlaceHold(context.Background(), uuid.New(), user, "case")
+	require.Error(t, err)
+	require.NotNil(t, hold)
+	assert.Contains(t, holds.active, user)
+}
+
+func TestReleaseHold(t *testing.T) {
+	holds, audit := newFakeHoldPort(), &fakeAuditPort{}
+	uc := NewUsecase(holds, audit, &fakeExportPort{})
+	actor, user := uuid.New(), uuid.New()
+
+	_, err := uc.ReleaseHold(context.Background(), actor, user)
+	require.ErrorIs(t, err, domain.ErrLegalHoldNotFound)
+
+	_, err = uc.PlaceHold(context.Background(), actor, user, "case")
+	require.NoError(t, err)
+	hold, err := uc.ReleaseHold(context.Background(), actor, user)
+	require.NoError(t, err)
+	assert.False(t, hold.IsActive())
+
+	require.Len(t, audit.entries, 2)
+	assert.Equal(t, domain.ComplianceActionLegalHoldReleased, audit.entries[1].Action)
+}
+
+func TestExportAccount_VerifiableArchive(t *testing.T) {
+	holds, audit := newFakeHoldPort(), &fakeAuditPort{}
+	export := &fakeExportPort{rows: map[string][]string{
+		"feed_subscriptions": {`{"url":"https://a.example/feed"}`, `{"url":"https://b.example/feed"}`},
+		"declined_domains":   {`{"domain":"c.example"}`},
+	}}
+	uc := NewUsecase(holds, audit, export)
+	actor, user := uuid.New(), uuid.New()
+	_, err := uc.PlaceHold(context.Background(), actor, user, "case")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	manifest, err := uc.ExportAccount(context.Background(), actor, user, &buf)
+	require.NoError(t, err)
+	assert.True(t, manifest.LegalHold)
+	require.Len(t, manifest.Files, len(domain.AccountExportSections))
+	assert.Equal(t, int64(2), manifest.Files[0].Rows)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	contents := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		contents[f.Name] = data
+	}
+
+	var decoded domain.AccountExportManifest
+	require.NoError(t, json.Unmarshal(contents[exportManifestName], &decoded))
+	assert.Equal(t, user, decoded.UserID)
+
+	// Every line of SHA256SUMS must match the archived file it names.
+	sums := strings.Split(strings.TrimSpace(string(contents[exportChecksumName])), "\n")
+	assert.Len(t, sums, len(domain.AccountExportSections)+1)
+	for _, line := range sums {
+		parts := strings.SplitN(line, "  ", 2)
+		require.Len(t, parts, 2)
+		data, ok := contents[parts[1]]
+		require.True(t, ok, "checksum for missing file %s", parts[1])
+		digest := sha256.Sum256(data)
+		assert.Equal(t, hex.EncodeToString(digest[:]), parts[0], parts[1])
+	}
+
+	last := audit.entries[len(audit.entries)-1]
+	assert.Equal(t, domain.ComplianceActionAccountExported, last.Action)
+}
+
+func TestExportAccount_SectionError(t *testing.T) {
+	audit := &fakeAuditPort{}
+	uc := NewUsecase(newFakeHoldPort(), audit, &fakeExportPort{err: fmt.Errorf("query failed")})
+
+	_, err := uc.ExportAccount(context.Background(), uuid.New(), uuid.New(), io.Discard)
+	require.Error(t, err)
+	assert.Empty(t, audit.entries)
+}
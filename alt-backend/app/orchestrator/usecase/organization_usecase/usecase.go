@@ -0,0 +1,422 @@
+package organization_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/organization_port"
+	"alt/utils/logger"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultInvitationTTL = 7 * 24 * time.Hour
+	defaultMaxTeamDepth  = 5
+	maxNameLength        = 100
+	invitationTokenBytes = 32
+)
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid organization input")
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+
+// Config holds the organization settings. Zero values select the defaults.
+type Config struct {
+	// InvitationTTL is how long an invitation token can be redeemed.
+	InvitationTTL time.Duration
+	// MaxTeamDepth caps how deeply teams nest; a top-level team has depth 1.
+	MaxTeamDepth int
+}
+
+// Invitation is a new invitation together with the token that redeems it.
+// The token is only available when the invitation is created.
+type Invitation struct {
+	*domain.OrgInvitation
+	Token string `json:"token"`
+}
+
+// Usecase manages organizations, their teams and invitations, and resolves
+// the organization context of a request.
+//
+// Every operation that takes an *domain.OrgContext trusts it to have been
+// resolved by ResolveOrgContext (usually through the organization
+// middleware) and only checks the caller's role.
+type Usecase struct {
+	orgs        organization_port.OrganizationPort
+	teams       organization_port.TeamPort
+	invitations organization_port.OrgInvitationPort
+	cfg         Config
+	now         func() time.Time
+}
+
+// NewUsecase creates a new organization usecase.
+func NewUsecase(
+	orgs organization_port.OrganizationPort,
+	teams organization_port.TeamPort,
+	invitations organization_port.OrgInvitationPort,
+	cfg Config,
+) *Usecase {
+	if cfg.InvitationTTL <= 0 {
+		cfg.InvitationTTL = defaultInvitationTTL
+	}
+	if cfg.MaxTeamDepth <= 0 {
+		cfg.MaxTeamDepth = defaultMaxTeamDepth
+	}
+	return &Usecase{
+		orgs:        orgs,
+		teams:       teams,
+		invitations: invitations,
+		cfg:         cfg,
+		now:         time.Now,
+	}
+}
+
+// CreateOrganization creates an organization in user's tenant with user as
+// its owner.
+func (u *Usecase) CreateOrganization(ctx context.Context, user *domain.UserContext, name, slug string) (*domain.Organization, error) {
+	name, err := normalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if !slugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("%w: slug must be 2-63 lowercase letters, digits or hyphens", ErrInvalidInput)
+	}
+
+	org := &domain.Organization{
+		ID:        uuid.New(),
+		TenantID:  user.TenantID,
+		Name:      name,
+		Slug:      slug,
+		CreatedBy: user.UserID,
+		CreatedAt: u.now().UTC(),
+		Role:      domain.OrgRoleOwner,
+	}
+	if err := u.orgs.CreateOrganization(ctx, org, user.UserID); err != nil {
+		return nil, fmt.Errorf("create organization: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "organization created",
+		"org_id", org.ID, "tenant_id", org.TenantID, "user_id", user.UserID)
+	return org, nil
+}
+
+// ListOrganizations returns the organizations userID belongs to.
+func (u *Usecase) ListOrganizations(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	orgs, err := u.orgs.ListUserOrganizations(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// ResolveOrgContext returns userID's context in orgID. Non-members get
+// domain.ErrOrganizationNotFound, so organization IDs cannot be probed.
+func (u *Usecase) ResolveOrgContext(ctx context.Context, userID, orgID uuid.UUID) (*domain.OrgContext, error) {
+	member, err := u.orgs.FetchOrgMembership(ctx, orgID, userID)
+	if errors.Is(err, domain.ErrOrgMemberNotFound) {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch organization membership: %w", err)
+	}
+	teamIDs, err := u.teams.ListUserTeamScope(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list team scope: %w", err)
+	}
+	return &domain.OrgContext{
+		OrgID:   orgID,
+		UserID:  userID,
+		Role:    member.Role,
+		TeamIDs: teamIDs,
+	}, nil
+}
+
+// ListMembers returns the organization's members.
+func (u *Usecase) ListMembers(ctx context.Context, oc *domain.OrgContext) ([]*domain.OrgMember, error) {
+	members, err := u.orgs.ListOrgMembers(ctx, oc.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("list organization members: %w", err)
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes a member's role. Admins manage admins and
+// members; only owners can grant, revoke or change the owner role.
+func (u *Usecase) UpdateMemberRole(ctx context.Context, oc *domain.OrgContext, userID uuid.UUID, role domain.OrgRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("%w: unknown role %q", ErrInvalidInput, role)
+	}
+	if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+		return domain.ErrOrgForbidden
+	}
+	target, err := u.orgs.FetchOrgMembership(ctx, oc.OrgID, userID)
+	if err != nil {
+		return fmt.Errorf("fetch organization membership: %w", err)
+	}
+	if (role == domain.OrgRoleOwner || target.Role == domain.OrgRoleOwner) && oc.Role != domain.OrgRoleOwner {
+		return domain.ErrOrgForbidden
+	}
+	if target.Role == role {
+		return nil
+	}
+	if err := u.orgs.UpdateOrgMemberRole(ctx, oc.OrgID, userID, role); err != nil {
+		return fmt.Errorf("update organization member role: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "organization member role changed",
+		"org_id", oc.OrgID, "user_id", userID, "from", target.Role, "to", role, "by", oc.UserID)
+	return nil
+}
+
+// RemoveMember removes userID from the organization. Members may leave on
+// their own; otherwise the caller must be an admin, and only owners remove
+// owners.
+func (u *Usecase) RemoveMember(ctx context.Context, oc *domain.OrgContext, userID uuid.UUID) error {
+	if userID != oc.UserID {
+		if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+			return domain.ErrOrgForbidden
+		}
+		target, err := u.orgs.FetchOrgMembership(ctx, oc.OrgID, userID)
+		if err != nil {
+			return fmt.Errorf("fetch organization membership: %w", err)
+		}
+		if target.Role == domain.OrgRoleOwner && oc.Role != domain.OrgRoleOwner {
+			return domain.ErrOrgForbidden
+		}
+	}
+	if err := u.orgs.RemoveOrgMember(ctx, oc.OrgID, userID); err != nil {
+		return fmt.Errorf("remove organization member: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "organization member removed",
+		"org_id", oc.OrgID, "user_id", userID, "by", oc.UserID)
+	return nil
+}
+
+// CreateTeam creates a team, nested under parentID when it is set.
+func (u *Usecase) CreateTeam(ctx context.Context, oc *domain.OrgContext, name string, parentID *uuid.UUID) (*domain.Team, error) {
+	name, err := normalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+		return nil, domain.ErrOrgForbidden
+	}
+	if parentID != nil {
+		teams, err := u.teams.ListTeams(ctx, oc.OrgID)
+		if err != nil {
+			return nil, fmt.Errorf("list teams: %w", err)
+		}
+		depth, ok := teamDepth(teams, *parentID)
+		if !ok {
+			return nil, domain.ErrTeamNotFound
+		}
+		if depth >= u.cfg.MaxTeamDepth {
+			return nil, fmt.Errorf("%w: teams nest at most %d deep", ErrInvalidInput, u.cfg.MaxTeamDepth)
+		}
+	}
+
+	team := &domain.Team{
+		ID:        uuid.New(),
+		OrgID:     oc.OrgID,
+		ParentID:  parentID,
+		Name:      name,
+		CreatedAt: u.now().UTC(),
+	}
+	if err := u.teams.CreateTeam(ctx, team); err != nil {
+		return nil, fmt.Errorf("create team: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "team created",
+		"org_id", oc.OrgID, "team_id", team.ID, "by", oc.UserID)
+	return team, nil
+}
+
+// ListTeams returns the organization's teams.
+func (u *Usecase) ListTeams(ctx context.Context, oc *domain.OrgContext) ([]*domain.Team, error) {
+	teams, err := u.teams.ListTeams(ctx, oc.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+	return teams, nil
+}
+
+// ListTeamMembers returns a team's direct members.
+func (u *Usecase) ListTeamMembers(ctx context.Context, oc *domain.OrgContext, teamID uuid.UUID) ([]*domain.TeamMember, error) {
+	members, err := u.teams.ListTeamMembers(ctx, oc.OrgID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("list team members: %w", err)
+	}
+	return members, nil
+}
+
+// AddTeamMember adds an organization member to a team.
+func (u *Usecase) AddTeamMember(ctx context.Context, oc *domain.OrgContext, teamID, userID uuid.UUID) error {
+	if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+		return domain.ErrOrgForbidden
+	}
+	if err := u.teams.AddTeamMember(ctx, oc.OrgID, teamID, userID, u.now()); err != nil {
+		return fmt.Errorf("add team member: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "team member added",
+		"org_id", oc.OrgID, "team_id", teamID, "user_id", userID, "by", oc.UserID)
+	return nil
+}
+
+// RemoveTeamMember removes a member from a team.
+func (u *Usecase) RemoveTeamMember(ctx context.Context, oc *domain.OrgContext, teamID, userID uuid.UUID) error {
+	if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+		return domain.ErrOrgForbidden
+	}
+	if err := u.teams.RemoveTeamMember(ctx, oc.OrgID, teamID, userID); err != nil {
+		return fmt.Errorf("remove team member: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "team member removed",
+		"org_id", oc.OrgID, "team_id", teamID, "user_id", userID, "by", oc.UserID)
+	return nil
+}
+
+// CreateInvitation invites email into the organization. Admins can invite
+// admins and members; owners are only made by promotion.
+func (u *Usecase) CreateInvitation(ctx context.Context, oc *domain.OrgContext, email string, role domain.OrgRole) (*Invitation, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, fmt.Errorf("%w: a valid email is required", ErrInvalidInput)
+	}
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	if role != domain.OrgRoleAdmin && role != domain.OrgRoleMember {
+		return nil, fmt.Errorf("%w: invitations grant admin or member", ErrInvalidInput)
+	}
+	if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+		return nil, domain.ErrOrgForbidden
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate invitation token: %w", err)
+	}
+	now := u.now().UTC()
+	inv := &domain.OrgInvitation{
+		ID:        uuid.New(),
+		OrgID:     oc.OrgID,
+		Email:     email,
+		Role:      role,
+		TokenHash: hashToken(token),
+		InvitedBy: oc.UserID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(u.cfg.InvitationTTL),
+	}
+	if err := u.invitations.CreateOrgInvitation(ctx, inv); err != nil {
+		return nil, fmt.Errorf("create organization invitation: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "organization invitation created",
+		"org_id", oc.OrgID, "invitation_id", inv.ID, "role", role, "by", oc.UserID, "expires_at", inv.ExpiresAt)
+	return &Invitation{OrgInvitation: inv, Token: token}, nil
+}
+
+// ListInvitations returns the organization's invitations.
+func (u *Usecase) ListInvitations(ctx context.Context, oc *domain.OrgContext) ([]*domain.OrgInvitation, error) {
+	if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+		return nil, domain.ErrOrgForbidden
+	}
+	invitations, err := u.invitations.ListOrgInvitations(ctx, oc.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("list organization invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// RevokeInvitation revokes a pending invitation.
+func (u *Usecase) RevokeInvitation(ctx context.Context, oc *domain.OrgContext, invitationID uuid.UUID) error {
+	if !oc.Role.AtLeast(domain.OrgRoleAdmin) {
+		return domain.ErrOrgForbidden
+	}
+	if err := u.invitations.RevokeOrgInvitation(ctx, oc.OrgID, invitationID, u.now()); err != nil {
+		return fmt.Errorf("revoke organization invitation: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "organization invitation revoked",
+		"org_id", oc.OrgID, "invitation_id", invitationID, "by", oc.UserID)
+	return nil
+}
+
+// AcceptInvitation redeems token for user. Invitations addressed to another
+// email or tenant look unknown; expired, revoked and used ones return
+// domain.ErrOrgInvitationGone.
+func (u *Usecase) AcceptInvitation(ctx context.Context, user *domain.UserContext, token string) (*domain.OrgMember, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("%w: token is required", ErrInvalidInput)
+	}
+	inv, err := u.invitations.FetchOrgInvitationByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("fetch organization invitation: %w", err)
+	}
+	if !strings.EqualFold(inv.Email, user.Email) || inv.TenantID != user.TenantID {
+		return nil, domain.ErrOrgInvitationNotFound
+	}
+	now := u.now()
+	if !inv.Pending(now) {
+		return nil, domain.ErrOrgInvitationGone
+	}
+
+	member, err := u.invitations.AcceptOrgInvitation(ctx, inv.ID, user.UserID, now)
+	if err != nil {
+		return nil, fmt.Errorf("accept organization invitation: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "organization invitation accepted",
+		"org_id", inv.OrgID, "invitation_id", inv.ID, "user_id", user.UserID, "role", member.Role)
+	return member, nil
+}
+
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", fmt.Errorf("%w: name must be 1-%d characters", ErrInvalidInput, maxNameLength)
+	}
+	return name, nil
+}
+
+// teamDepth returns how deep id sits in the team tree, counting a
+// top-level team as 1. ok is false when id is not one of teams.
+func teamDepth(teams []*domain.Team, id uuid.UUID) (depth int, ok bool) {
+	byID := make(map[uuid.UUID]*domain.Team, len(teams))
+	for _, t := range teams {
+		byID[t.ID] = t
+	}
+	for cur := &id; cur != nil; {
+		t, found := byID[*cur]
+		if !found {
+			return 0, depth > 0
+		}
+		depth++
+		if depth > len(teams) {
+			// A cycle cannot be created through this usecase; stop rather
+			// than loop if one exists anyway.
+			break
+		}
+		cur = t.ParentID
+	}
+	return depth, depth > 0
+}
+
+func newInvitationToken() (string, error) {
+	b := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,437 @@
+package organization_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memberKey struct{ org, user uuid.UUID }
+
+// fakeStore mirrors the driver contract: the last owner cannot leave or be
+// demoted, team members must belong to the organization, and invitations
+// are accepted at most once.
+type fakeStore struct {
+	orgs        map[uuid.UUID]*domain.Organization
+	members     map[memberKey]*domain.OrgMember
+	teams       map[uuid.UUID]*domain.Team
+	teamMembers map[uuid.UUID]map[uuid.UUID]time.Time
+	invitations map[uuid.UUID]*domain.OrgInvitation
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		orgs:        map[uuid.UUID]*domain.Organization{},
+		members:     map[memberKey]*domain.OrgMember{},
+		teams:       map[uuid.UUID]*domain.Team{},
+		teamMembers: map[uuid.UUID]map[uuid.UUID]time.Time{},
+		invitations: map[uuid.UUID]*domain.OrgInvitation{},
+	}
+}
+
+func (f *fakeStore) CreateOrganization(_ context.Context, org *domain.Organization, owner uuid.UUID) error {
+	for _, o := range f.orgs {
+		if o.TenantID == org.TenantID && o.Slug == org.Slug {
+			return domain.ErrOrgSlugTaken
+		}
+	}
+	f.orgs[org.ID] = org
+	f.members[memberKey{org.ID, owner}] = &domain.OrgMember{OrgID: org.ID, UserID: owner, Role: domain.OrgRoleOwner, JoinedAt: org.CreatedAt}
+	return nil
+}
+
+func (f *fakeStore) ListUserOrganizations(_ context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	out := []*domain.Organization{}
+	for k, m := range f.members {
+		if k.user == userID {
+			org := *f.orgs[k.org]
+			org.Role = m.Role
+			out = append(out, &org)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) FetchOrgMembership(_ context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	m, ok := f.members[memberKey{orgID, userID}]
+	if !ok {
+		return nil, domain.ErrOrgMemberNotFound
+	}
+	return m, nil
+}
+
+func (f *fakeStore) ListOrgMembers(_ context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	out := []*domain.OrgMember{}
+	for k, m := range f.members {
+		if k.org == orgID {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) lastOwner(orgID, userID uuid.UUID) bool {
+	owners := 0
+	for k, m := range f.members {
+		if k.org == orgID && m.Role == domain.OrgRoleOwner {
+			owners++
+		}
+	}
+	m, ok := f.members[memberKey{orgID, userID}]
+	return ok && m.Role == domain.OrgRoleOwner && owners == 1
+}
+
+func (f *fakeStore) UpdateOrgMemberRole(_ context.Context, orgID, userID uuid.UUID, role domain.OrgRole) error {
+	m, ok := f.members[memberKey{orgID, userID}]
+	if !ok {
+		return domain.ErrOrgMemberNotFound
+	}
+	if role != domain.OrgRoleOwner && f.lastOwner(orgID, userID) {
+		return domain.ErrOrgLastOwner
+	}
+	m.Role = role
+	return nil
+}
+
+func (f *fakeStore) RemoveOrgMember(_ context.Context, orgID, userID uuid.UUID) error {
+	if _, ok := f.members[memberKey{orgID, userID}]; !ok {
+		return domain.ErrOrgMemberNotFound
+	}
+	if f.lastOwner(orgID, userID) {
+		return domain.ErrOrgLastOwner
+	}
+	delete(f.members, memberKey{orgID, userID})
+	for _, members := range f.teamMembers {
+		delete(members, userID)
+	}
+	return nil
+}
+
+func (f *fakeStore) CreateTeam(_ context.Context, team *domain.Team) error {
+	if team.ParentID != nil {
+		if p, ok := f.teams[*team.ParentID]; !ok || p.OrgID != team.OrgID {
+			return domain.ErrTeamNotFound
+		}
+	}
+	for _, t := range f.teams {
+		if t.OrgID == team.OrgID && t.Name == team.Name {
+			return domain.ErrTeamNameTaken
+		}
+	}
+	f.teams[team.ID] = team
+	return nil
+}
+
+func (f *fakeStore) ListTeams(_ context.Context, orgID uuid.UUID) ([]*domain.Team, error) {
+	out := []*domain.Team{}
+	for _, t := range f.teams {
+		if t.OrgID == orgID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) ListTeamMembers(_ context.Context, orgID, teamID uuid.UUID) ([]*domain.TeamMember, error) {
+	if t, ok := f.teams[teamID]; !ok || t.OrgID != orgID {
+		return nil, domain.ErrTeamNotFound
+	}
+	out := []*domain.TeamMember{}
+	for userID, at := range f.teamMembers[teamID] {
+		out = append(out, &domain.TeamMember{TeamID: teamID, UserID: userID, AddedAt: at})
+	}
+	return out, nil
+}
+
+func (f *fakeStore) AddTeamMember(_ context.Context, orgID, teamID, userID uuid.UUID, now time.Time) error {
+	if t, ok := f.teams[teamID]; !ok || t.OrgID != orgID {
+		return domain.ErrTeamNotFound
+	}
+	if _, ok := f.members[memberKey{orgID, userID}]; !ok {
+		return domain.ErrOrgMemberNotFound
+	}
+	if f.teamMembers[teamID] == nil {
+		f.teamMembers[teamID] = map[uuid.UUID]time.Time{}
+	}
+	if _, ok := f.teamMembers[teamID][userID]; !ok {
+		f.teamMembers[teamID][userID] = now
+	}
+	return nil
+}
+
+func (f *fakeStore) RemoveTeamMember(_ context.Context, orgID, teamID, userID uuid.UUID) error {
+	if _, ok := f.teamMembers[teamID][userID]; !ok || f.teams[teamID].OrgID != orgID {
+		return domain.ErrOrgMemberNotFound
+	}
+	delete(f.teamMembers[teamID], userID)
+	return nil
+}
+
+func (f *fakeStore) ListUserTeamScope(_ context.Context, orgID, userID uuid.UUID) ([]uuid.UUID, error) {
+	scope := map[uuid.UUID]bool{}
+	for teamID, members := range f.teamMembers {
+		if _, ok := members[userID]; ok && f.teams[teamID].OrgID == orgID {
+			scope[teamID] = true
+		}
+	}
+	for grew := true; grew; {
+		grew = false
+		for _, t := range f.teams {
+			if t.ParentID != nil && scope[*t.ParentID] && !scope[t.ID] {
+				scope[t.ID] = true
+				grew = true
+			}
+		}
+	}
+	out := []uuid.UUID{}
+	for id := range scope {
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) CreateOrgInvitation(_ context.Context, inv *domain.OrgInvitation) error {
+	inv.TenantID = f.orgs[inv.OrgID].TenantID
+	f.invitations[inv.ID] = inv
+	return nil
+}
+
+func (f *fakeStore) ListOrgInvitations(_ context.Context, orgID uuid.UUID) ([]*domain.OrgInvitation, error) {
+	out := []*domain.OrgInvitation{}
+	for _, inv := range f.invitations {
+		if inv.OrgID == orgID {
+			out = append(out, inv)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) RevokeOrgInvitation(_ context.Context, orgID, invitationID uuid.UUID, now time.Time) error {
+	inv, ok := f.invitations[invitationID]
+	if !ok || inv.OrgID != orgID {
+		return domain.ErrOrgInvitationNotFound
+	}
+	if !inv.Pending(now) {
+		return domain.ErrOrgInvitationGone
+	}
+	inv.RevokedAt = &now
+	return nil
+}
+
+func (f *fakeStore) FetchOrgInvitationByTokenHash(_ context.Context, tokenHash []byte) (*domain.OrgInvitation, error) {
+	for _, inv := range f.invitations {
+		if string(inv.TokenHash) == string(tokenHash) {
+			return inv, nil
+		}
+	}
+	return nil, domain.ErrOrgInvitationNotFound
+}
+
+func (f *fakeStore) AcceptOrgInvitation(_ context.Context, invitationID, userID uuid.UUID, now time.Time) (*domain.OrgMember, error) {
+	inv := f.invitations[invitationID]
+	if !inv.Pending(now) {
+		return nil, domain.ErrOrgInvitationGone
+	}
+	inv.AcceptedAt, inv.AcceptedBy = &now, &userID
+	key := memberKey{inv.OrgID, userID}
+	if m, ok := f.members[key]; ok {
+		return m, nil
+	}
+	f.members[key] = &domain.OrgMember{OrgID: inv.OrgID, UserID: userID, Role: inv.Role, JoinedAt: now}
+	return f.members[key], nil
+}
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newTestUsecase(cfg Config) (*Usecase, *fakeStore) {
+	store := newFakeStore()
+	u := NewUsecase(store, store, store, cfg)
+	u.now = func() time.Time { return testNow }
+	return u, store
+}
+
+func testUser(email string, tenant uuid.UUID) *domain.UserContext {
+	return &domain.UserContext{UserID: uuid.New(), Email: email, TenantID: tenant}
+}
+
+// setupOrg creates an organization owned by a new user and returns the
+// owner's context.
+func setupOrg(t *testing.T, u *Usecase) (*domain.Organization, *domain.UserContext, *domain.OrgContext) {
+	t.Helper()
+	owner := testUser("owner@example.com", uuid.New())
+	org, err := u.CreateOrganization(context.Background(), owner, "Acme", "acme")
+	require.NoError(t, err)
+	oc, err := u.ResolveOrgContext(context.Background(), owner.UserID, org.ID)
+	require.NoError(t, err)
+	return org, owner, oc
+}
+
+func TestCreateOrganization_Validation(t *testing.T) {
+	u, _ := newTestUsecase(Config{})
+	user := testUser("a@example.com", uuid.New())
+
+	org, err := u.CreateOrganization(context.Background(), user, "  Acme  ", "Acme-Labs")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", org.Name)
+	assert.Equal(t, "acme-labs", org.Slug)
+	assert.Equal(t, user.TenantID, org.TenantID)
+	assert.Equal(t, domain.OrgRoleOwner, org.Role)
+
+	_, err = u.CreateOrganization(context.Background(), user, "Other", "acme-labs")
+	require.ErrorIs(t, err, domain.ErrOrgSlugTaken)
+
+	for _, slug := range []string{"", "a", "-acme", "acme_labs", "ac me"} {
+		_, err = u.CreateOrganization(context.Background(), user, "X", slug)
+		require.ErrorIs(t, err, ErrInvalidInput, slug)
+	}
+	_, err = u.CreateOrganization(context.Background(), user, " ", "blank")
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestResolveOrgContext_HidesOtherOrganizations(t *testing.T) {
+	u, _ := newTestUsecase(Config{})
+	org, _, oc := setupOrg(t, u)
+	assert.Equal(t, domain.OrgRoleOwner, oc.Role)
+
+	_, err := u.ResolveOrgContext(context.Background(), uuid.New(), org.ID)
+	require.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+}
+
+func TestUpdateMemberRole_OwnerRules(t *testing.T) {
+	u, store := newTestUsecase(Config{})
+	ctx := context.Background()
+	org, owner, ownerCtx := setupOrg(t, u)
+
+	admin, member := uuid.New(), uuid.New()
+	store.members[memberKey{org.ID, admin}] = &domain.OrgMember{OrgID: org.ID, UserID: admin, Role: domain.OrgRoleAdmin}
+	store.members[memberKey{org.ID, member}] = &domain.OrgMember{OrgID: org.ID, UserID: member, Role: domain.OrgRoleMember}
+	adminCtx := &domain.OrgContext{OrgID: org.ID, UserID: admin, Role: domain.OrgRoleAdmin}
+	memberCtx := &domain.OrgContext{OrgID: org.ID, UserID: member, Role: domain.OrgRoleMember}
+
+	require.ErrorIs(t, u.UpdateMemberRole(ctx, memberCtx, member, domain.OrgRoleAdmin), domain.ErrOrgForbidden)
+	require.ErrorIs(t, u.UpdateMemberRole(ctx, adminCtx, member, domain.OrgRoleOwner), domain.ErrOrgForbidden)
+	require.ErrorIs(t, u.UpdateMemberRole(ctx, adminCtx, owner.UserID, domain.OrgRoleMember), domain.ErrOrgForbidden)
+	require.NoError(t, u.UpdateMemberRole(ctx, adminCtx, member, domain.OrgRoleAdmin))
+	assert.Equal(t, domain.OrgRoleAdmin, store.members[memberKey{org.ID, member}].Role)
+
+	require.ErrorIs(t, u.UpdateMemberRole(ctx, ownerCtx, owner.UserID, domain.OrgRoleAdmin), domain.ErrOrgLastOwner)
+	require.NoError(t, u.UpdateMemberRole(ctx, ownerCtx, admin, domain.OrgRoleOwner))
+	require.NoError(t, u.UpdateMemberRole(ctx, ownerCtx, owner.UserID, domain.OrgRoleAdmin))
+
+	require.ErrorIs(t, u.UpdateMemberRole(ctx, ownerCtx, admin, "superuser"), ErrInvalidInput)
+}
+
+func TestRemoveMember(t *testing.T) {
+	u, store := newTestUsecase(Config{})
+	ctx := context.Background()
+	org, owner, ownerCtx := setupOrg(t, u)
+
+	member := uuid.New()
+	store.members[memberKey{org.ID, member}] = &domain.OrgMember{OrgID: org.ID, UserID: member, Role: domain.OrgRoleMember}
+	memberCtx := &domain.OrgContext{OrgID: org.ID, UserID: member, Role: domain.OrgRoleMember}
+
+	require.ErrorIs(t, u.RemoveMember(ctx, memberCtx, owner.UserID), domain.ErrOrgForbidden)
+	require.ErrorIs(t, u.RemoveMember(ctx, ownerCtx, owner.UserID), domain.ErrOrgLastOwner)
+	require.NoError(t, u.RemoveMember(ctx, memberCtx, member), "members can leave")
+	require.ErrorIs(t, u.RemoveMember(ctx, ownerCtx, member), domain.ErrOrgMemberNotFound)
+}
+
+func TestTeams_NestingAndScope(t *testing.T) {
+	u, _ := newTestUsecase(Config{MaxTeamDepth: 2})
+	ctx := context.Background()
+	org, owner, ownerCtx := setupOrg(t, u)
+
+	eng, err := u.CreateTeam(ctx, ownerCtx, "Engineering", nil)
+	require.NoError(t, err)
+	backend, err := u.CreateTeam(ctx, ownerCtx, "Backend", &eng.ID)
+	require.NoError(t, err)
+
+	_, err = u.CreateTeam(ctx, ownerCtx, "Storage", &backend.ID)
+	require.ErrorIs(t, err, ErrInvalidInput, "depth limit")
+	missing := uuid.New()
+	_, err = u.CreateTeam(ctx, ownerCtx, "Orphan", &missing)
+	require.ErrorIs(t, err, domain.ErrTeamNotFound)
+	_, err = u.CreateTeam(ctx, ownerCtx, "Backend", nil)
+	require.ErrorIs(t, err, domain.ErrTeamNameTaken)
+
+	outsider := uuid.New()
+	require.ErrorIs(t, u.AddTeamMember(ctx, ownerCtx, eng.ID, outsider), domain.ErrOrgMemberNotFound)
+	require.NoError(t, u.AddTeamMember(ctx, ownerCtx, eng.ID, owner.UserID))
+	require.NoError(t, u.AddTeamMember(ctx, ownerCtx, eng.ID, owner.UserID), "adding twice is a no-op")
+
+	oc, err := u.ResolveOrgContext(ctx, owner.UserID, org.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{eng.ID, backend.ID}, oc.TeamIDs)
+	assert.True(t, oc.InTeam(backend.ID))
+
+	memberCtx := &domain.OrgContext{OrgID: org.ID, UserID: uuid.New(), Role: domain.OrgRoleMember}
+	_, err = u.CreateTeam(ctx, memberCtx, "Mine", nil)
+	require.ErrorIs(t, err, domain.ErrOrgForbidden)
+	require.ErrorIs(t, u.RemoveTeamMember(ctx, memberCtx, eng.ID, owner.UserID), domain.ErrOrgForbidden)
+}
+
+func TestInvitations_AcceptOnce(t *testing.T) {
+	u, store := newTestUsecase(Config{})
+	ctx := context.Background()
+	org, owner, ownerCtx := setupOrg(t, u)
+
+	inv, err := u.CreateInvitation(ctx, ownerCtx, " New@Example.com ", "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, inv.Token)
+	assert.Equal(t, domain.OrgRoleMember, inv.Role)
+	assert.Equal(t, "new@example.com", inv.Email)
+	assert.Equal(t, testNow.Add(defaultInvitationTTL), inv.ExpiresAt)
+	assert.NotContains(t, string(store.invitations[inv.ID].TokenHash), inv.Token)
+
+	invitee := testUser("NEW@example.com", owner.TenantID)
+	stranger := testUser("someone@example.com", owner.TenantID)
+	otherTenant := testUser("new@example.com", uuid.New())
+
+	_, err = u.AcceptInvitation(ctx, stranger, inv.Token)
+	require.ErrorIs(t, err, domain.ErrOrgInvitationNotFound)
+	_, err = u.AcceptInvitation(ctx, otherTenant, inv.Token)
+	require.ErrorIs(t, err, domain.ErrOrgInvitationNotFound)
+	_, err = u.AcceptInvitation(ctx, invitee, "not-a-token")
+	require.ErrorIs(t, err, domain.ErrOrgInvitationNotFound)
+
+	member, err := u.AcceptInvitation(ctx, invitee, inv.Token)
+	require.NoError(t, err)
+	assert.Equal(t, org.ID, member.OrgID)
+	assert.Equal(t, domain.OrgRoleMember, member.Role)
+
+	_, err = u.AcceptInvitation(ctx, invitee, inv.Token)
+	require.ErrorIs(t, err, domain.ErrOrgInvitationGone)
+}
+
+func TestInvitations_ExpiryAndRevocation(t *testing.T) {
+	u, _ := newTestUsecase(Config{InvitationTTL: time.Hour})
+	ctx := context.Background()
+	_, owner, ownerCtx := setupOrg(t, u)
+	invitee := testUser("new@example.com", owner.TenantID)
+
+	_, err := u.CreateInvitation(ctx, ownerCtx, "new@example.com", domain.OrgRoleOwner)
+	require.ErrorIs(t, err, ErrInvalidInput, "owners are made by promotion")
+
+	expiring, err := u.CreateInvitation(ctx, ownerCtx, "new@example.com", domain.OrgRoleAdmin)
+	require.NoError(t, err)
+	u.now = func() time.Time { return testNow.Add(2 * time.Hour) }
+	_, err = u.AcceptInvitation(ctx, invitee, expiring.Token)
+	require.ErrorIs(t, err, domain.ErrOrgInvitationGone)
+
+	revoked, err := u.CreateInvitation(ctx, ownerCtx, "new@example.com", domain.OrgRoleAdmin)
+	require.NoError(t, err)
+	require.NoError(t, u.RevokeInvitation(ctx, ownerCtx, revoked.ID))
+	_, err = u.AcceptInvitation(ctx, invitee, revoked.Token)
+	require.ErrorIs(t, err, domain.ErrOrgInvitationGone)
+
+	memberCtx := &domain.OrgContext{OrgID: ownerCtx.OrgID, UserID: uuid.New(), Role: domain.OrgRoleMember}
+	_, err = u.CreateInvitation(ctx, memberCtx, "x@example.com", domain.OrgRoleMember)
+	require.ErrorIs(t, err, domain.ErrOrgForbidden)
+	_, err = u.ListInvitations(ctx, memberCtx)
+	require.ErrorIs(t, err, domain.ErrOrgForbidden)
+}
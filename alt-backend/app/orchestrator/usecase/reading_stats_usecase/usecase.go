@@ -0,0 +1,151 @@
+package reading_stats_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/reading_stats_port"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPeriodDays is the weekly digest window.
+	DefaultPeriodDays = 7
+	// MaxPeriodDays bounds how far back one request may look.
+	MaxPeriodDays = 90
+
+	// refreshDays is how many local days each aggregation run rebuilds:
+	// yesterday (finalised) and today (partial, refreshed next run).
+	refreshDays = 2
+)
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid reading stats input")
+
+// Usecase aggregates read_status into per-user daily reading stats and
+// serves them as period summaries for the stats endpoint and the weekly
+// digest.
+type Usecase struct {
+	recompute reading_stats_port.RecomputeReadingStatsPort
+	fetch     reading_stats_port.FetchReadingStatsPort
+	now       func() time.Time
+}
+
+// NewUsecase creates a new reading stats usecase.
+func NewUsecase(
+	recompute reading_stats_port.RecomputeReadingStatsPort,
+	fetch reading_stats_port.FetchReadingStatsPort,
+) *Usecase {
+	return &Usecase{
+		recompute: recompute,
+		fetch:     fetch,
+		now:       time.Now,
+	}
+}
+
+// RefreshRecent rebuilds the aggregates for yesterday and today. Yesterday
+// is included so reads recorded after the previous run are not lost at the
+// day boundary. It returns the number of user-days written.
+func (u *Usecase) RefreshRecent(ctx context.Context) (int64, error) {
+	now := u.now()
+	var total int64
+	for i := refreshDays - 1; i >= 0; i-- {
+		day := now.AddDate(0, 0, -i)
+		n, err := u.recompute.RecomputeReadingStats(ctx, day, domain.ReadingStatsLocation, now)
+		if err != nil {
+			return total, fmt.Errorf("recompute reading stats for %s: %w", localDate(day).Format(time.DateOnly), err)
+		}
+		total += n
+	}
+	logger.Logger.InfoContext(ctx, "reading stats aggregated", "user_days", total)
+	return total, nil
+}
+
+// GetReadingStats summarises userID's reading over the last days local
+// days, today included. days <= 0 selects DefaultPeriodDays.
+func (u *Usecase) GetReadingStats(ctx context.Context, userID uuid.UUID, days int) (*domain.ReadingStats, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user id is required", ErrInvalidInput)
+	}
+	if days <= 0 {
+		days = DefaultPeriodDays
+	}
+	if days > MaxPeriodDays {
+		return nil, fmt.Errorf("%w: days must be at most %d", ErrInvalidInput, MaxPeriodDays)
+	}
+
+	end := localDate(u.now())
+	start := end.AddDate(0, 0, -(days - 1))
+
+	agg, err := u.fetch.FetchReadingStatsAggregate(ctx, userID, start, end, domain.ReadingStatsTopN)
+	if err != nil {
+		return nil, fmt.Errorf("fetch reading stats: %w", err)
+	}
+	previous, err := u.fetch.CountArticlesRead(ctx, userID, start.AddDate(0, 0, -days), start.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("count previous period reads: %w", err)
+	}
+
+	stats := &domain.ReadingStats{
+		PeriodStart:                start,
+		PeriodEnd:                  end,
+		Timezone:                   domain.ReadingStatsTimezone,
+		PreviousPeriodArticlesRead: previous,
+		HourlyDistribution:         agg.HourlyDistribution,
+		Daily:                      fillDays(start, days, agg.Daily),
+		TopFeeds:                   agg.TopFeeds,
+		TopTags:                    agg.TopTags,
+		ComputedAt:                 agg.ComputedAt,
+	}
+	for _, d := range stats.Daily {
+		stats.ArticlesRead += d.ArticlesRead
+		if d.ArticlesRead > 0 {
+			stats.ActiveDays++
+		}
+	}
+	stats.DailyAverage = math.Round(float64(stats.ArticlesRead)/float64(days)*100) / 100
+	stats.BusiestHour = busiestHour(stats.HourlyDistribution)
+	return stats, nil
+}
+
+// localDate returns t's calendar date in the stats timezone, as midnight
+// UTC so it compares equal to DATE columns scanned by pgx.
+func localDate(t time.Time) time.Time {
+	l := t.In(domain.ReadingStatsLocation)
+	return time.Date(l.Year(), l.Month(), l.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// fillDays returns one entry per day from start, with zero for days that
+// have no aggregate row.
+func fillDays(start time.Time, days int, rows []domain.ReadingDayStat) []domain.ReadingDayStat {
+	byDate := make(map[time.Time]int, len(rows))
+	for _, r := range rows {
+		byDate[r.Date.UTC()] = r.ArticlesRead
+	}
+	out := make([]domain.ReadingDayStat, days)
+	for i := range out {
+		d := start.AddDate(0, 0, i)
+		out[i] = domain.ReadingDayStat{Date: d, ArticlesRead: byDate[d]}
+	}
+	return out
+}
+
+// busiestHour returns the hour with the most reads, the earliest on ties,
+// or nil when nothing was read.
+func busiestHour(hours [24]int) *int {
+	best := -1
+	for h, n := range hours {
+		if n > 0 && (best < 0 || n > hours[best]) {
+			best = h
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	return &best
+}
@@ -0,0 +1,125 @@
+package reading_stats_usecase
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStatsStore struct {
+	agg          *domain.ReadingStatsAggregate
+	previous     int
+	recomputeErr error
+
+	recomputed []time.Time
+	fetchRange [2]time.Time
+	countRange [2]time.Time
+}
+
+func (f *fakeStatsStore) RecomputeReadingStats(_ context.Context, day time.Time, _ *time.Location, _ time.Time) (int64, error) {
+	if f.recomputeErr != nil {
+		return 0, f.recomputeErr
+	}
+	f.recomputed = append(f.recomputed, day)
+	return 3, nil
+}
+
+func (f *fakeStatsStore) FetchReadingStatsAggregate(_ context.Context, _ uuid.UUID, from, to time.Time, _ int) (*domain.ReadingStatsAggregate, error) {
+	f.fetchRange = [2]time.Time{from, to}
+	return f.agg, nil
+}
+
+func (f *fakeStatsStore) CountArticlesRead(_ context.Context, _ uuid.UUID, from, to time.Time) (int, error) {
+	f.countRange = [2]time.Time{from, to}
+	return f.previous, nil
+}
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetReadingStats_WeeklySummary(t *testing.T) {
+	var hours [24]int
+	hours[8] = 4
+	hours[22] = 6
+	store := &fakeStatsStore{
+		agg: &domain.ReadingStatsAggregate{
+			Daily: []domain.ReadingDayStat{
+				{Date: date(2026, 10, 12), ArticlesRead: 3},
+				{Date: date(2026, 10, 16), ArticlesRead: 7},
+			},
+			HourlyDistribution: hours,
+			TopFeeds:           []domain.ReadingFeedStat{{FeedURL: "https://example.com/rss", Reads: 6}},
+			TopTags:            []domain.ReadingTagStat{{Tag: "go", Reads: 5}},
+		},
+		previous: 12,
+	}
+	uc := NewUsecase(store, store)
+	// 2026-10-15 20:00 UTC is already 2026-10-16 in Tokyo.
+	uc.now = func() time.Time { return time.Date(2026, 10, 15, 20, 0, 0, 0, time.UTC) }
+
+	stats, err := uc.GetReadingStats(context.Background(), uuid.New(), 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, [2]time.Time{date(2026, 10, 10), date(2026, 10, 16)}, store.fetchRange)
+	assert.Equal(t, [2]time.Time{date(2026, 10, 3), date(2026, 10, 9)}, store.countRange)
+	assert.Equal(t, 10, stats.ArticlesRead)
+	assert.Equal(t, 12, stats.PreviousPeriodArticlesRead)
+	assert.Equal(t, 2, stats.ActiveDays)
+	assert.InDelta(t, 1.43, stats.DailyAverage, 0.001)
+	require.NotNil(t, stats.BusiestHour)
+	assert.Equal(t, 22, *stats.BusiestHour)
+	require.Len(t, stats.Daily, 7)
+	assert.Equal(t, 0, stats.Daily[0].ArticlesRead)
+	assert.Equal(t, 3, stats.Daily[2].ArticlesRead)
+	assert.Equal(t, 7, stats.Daily[6].ArticlesRead)
+	assert.Equal(t, domain.ReadingStatsTimezone, stats.Timezone)
+}
+
+func TestGetReadingStats_NoReads(t *testing.T) {
+	store := &fakeStatsStore{agg: &domain.ReadingStatsAggregate{}}
+	uc := NewUsecase(store, store)
+
+	stats, err := uc.GetReadingStats(context.Background(), uuid.New(), 30)
+	require.NoError(t, err)
+	assert.Nil(t, stats.BusiestHour)
+	assert.Len(t, stats.Daily, 30)
+	assert.Zero(t, stats.DailyAverage)
+}
+
+func TestGetReadingStats_RejectsInvalidInput(t *testing.T) {
+	store := &fakeStatsStore{agg: &domain.ReadingStatsAggregate{}}
+	uc := NewUsecase(store, store)
+
+	_, err := uc.GetReadingStats(context.Background(), uuid.New(), MaxPeriodDays+1)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = uc.GetReadingStats(context.Background(), uuid.Nil, 7)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestRefreshRecent_RecomputesYesterdayAndToday(t *testing.T) {
+	store := &fakeStatsStore{}
+	uc := NewUsecase(store, store)
+	now := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	n, err := uc.RefreshRecent(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+	assert.Equal(t, []time.Time{now.AddDate(0, 0, -1), now}, store.recomputed)
+}
+
+func TestRefreshRecent_PropagatesError(t *testing.T) {
+	store := &fakeStatsStore{recomputeErr: errors.New("connection refused")}
+	uc := NewUsecase(store, store)
+
+	_, err := uc.RefreshRecent(context.Background())
+	assert.Error(t, err)
+}
@@ -2,18 +2,27 @@ package reading_status
 
 import (
 	"alt/orchestrator/port/article_status_port"
+	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
 	"context"
 	"net/url"
 )
 
 type ArticlesReadingStatusUsecase struct {
 	updateArticleStatusGateway article_status_port.UpdateArticleStatusPort
+	events                     event_publisher_port.EventPublisherPort
+	tx                         transaction_port.TransactionPort
 }
 
-func NewArticlesReadingStatusUsecase(updateArticleStatusGateway article_status_port.UpdateArticleStatusPort) *ArticlesReadingStatusUsecase {
-	return &ArticlesReadingStatusUsecase{updateArticleStatusGateway: updateArticleStatusGateway}
+// NewArticlesReadingStatusUsecase creates an ArticlesReadingStatusUsecase.
+// events may be nil, in which case no ReadStateChanged event is emitted;
+// otherwise the read state and its event are saved in a transaction from tx.
+func NewArticlesReadingStatusUsecase(updateArticleStatusGateway article_status_port.UpdateArticleStatusPort, events event_publisher_port.EventPublisherPort, tx transaction_port.TransactionPort) *ArticlesReadingStatusUsecase {
+	return &ArticlesReadingStatusUsecase{updateArticleStatusGateway: updateArticleStatusGateway, events: events, tx: tx}
 }
 
 func (u *ArticlesReadingStatusUsecase) Execute(ctx context.Context, articleURL url.URL) error {
-	return u.updateArticleStatusGateway.MarkArticleAsRead(ctx, articleURL)
+	return markRead(ctx, u.events, u.tx, articleURL, func(ctx context.Context) error {
+		return u.updateArticleStatusGateway.MarkArticleAsRead(ctx, articleURL)
+	})
 }
@@ -0,0 +1,34 @@
+package reading_status
+
+import (
+	"alt/domain"
+	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
+	"context"
+	"net/url"
+	"time"
+)
+
+// markRead runs save and, when events is enabled, records ReadStateChanged
+// for the user in ctx in the same transaction, so the read state is not
+// saved without its event.
+func markRead(ctx context.Context, events event_publisher_port.EventPublisherPort, tx transaction_port.TransactionPort, feedURL url.URL, save func(ctx context.Context) error) error {
+	if events == nil || !events.IsEnabled() {
+		return save(ctx)
+	}
+	user, err := domain.GetUserFromContext(ctx)
+	if err != nil {
+		return save(ctx)
+	}
+	return tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := save(ctx); err != nil {
+			return err
+		}
+		return events.PublishReadStateChanged(ctx, event_publisher_port.ReadStateChangedEvent{
+			UserID:    user.UserID.String(),
+			FeedURL:   feedURL.String(),
+			IsRead:    true,
+			ChangedAt: time.Now(),
+		})
+	})
+}
@@ -3,6 +3,8 @@ package reading_status
 import (
 	"alt/domain"
 	"alt/orchestrator/port/feed_status_port"
+	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
 	"context"
 	"fmt"
 	"net/url"
@@ -10,10 +12,15 @@ import (
 
 type FeedsReadingStatusUsecase struct {
 	updateFeedStatusGateway feed_status_port.UpdateFeedStatusPort
+	events                  event_publisher_port.EventPublisherPort
+	tx                      transaction_port.TransactionPort
 }
 
-func NewFeedsReadingStatusUsecase(updateFeedStatusGateway feed_status_port.UpdateFeedStatusPort) *FeedsReadingStatusUsecase {
-	return &FeedsReadingStatusUsecase{updateFeedStatusGateway: updateFeedStatusGateway}
+// NewFeedsReadingStatusUsecase creates a FeedsReadingStatusUsecase. events
+// may be nil, in which case no ReadStateChanged event is emitted; otherwise
+// the read state and its event are saved in a transaction from tx.
+func NewFeedsReadingStatusUsecase(updateFeedStatusGateway feed_status_port.UpdateFeedStatusPort, events event_publisher_port.EventPublisherPort, tx transaction_port.TransactionPort) *FeedsReadingStatusUsecase {
+	return &FeedsReadingStatusUsecase{updateFeedStatusGateway: updateFeedStatusGateway, events: events, tx: tx}
 }
 
 func (u *FeedsReadingStatusUsecase) Execute(ctx context.Context, feedURL url.URL) error {
@@ -21,5 +28,7 @@ func (u *FeedsReadingStatusUsecase) Execute(ctx context.Context, feedURL url.URL
 	if err != nil {
 		return fmt.Errorf("authentication required: %w", err)
 	}
-	return u.updateFeedStatusGateway.UpdateFeedStatus(ctx, feedURL, user.UserID)
+	return markRead(ctx, u.events, u.tx, feedURL, func(ctx context.Context) error {
+		return u.updateFeedStatusGateway.UpdateFeedStatus(ctx, feedURL, user.UserID)
+	})
 }
@@ -3,6 +3,8 @@ package reading_status
 import (
 	"alt/domain"
 	"alt/mocks"
+	"alt/shared/port/event_publisher_port"
+	"alt/utils/logger"
 	"context"
 	"errors"
 	"net/url"
@@ -89,3 +91,69 @@ func TestFeedsReadingStatusUsecase_Execute_NoUserContext(t *testing.T) {
 		t.Fatal("expected error when user context is missing, got nil")
 	}
 }
+
+// fakeTx runs fn directly and counts how the transaction would have ended.
+type fakeTx struct {
+	committed, rolledBack int
+}
+
+func (f *fakeTx) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.rolledBack++
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+func TestFeedsReadingStatusUsecase_Execute_RecordsReadStateChangedInTx(t *testing.T) {
+	logger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.MustParse("01020304-0506-0708-090a-0b0c0d0e0f10")
+	ctx := domain.SetUserContext(context.Background(), &domain.UserContext{
+		UserID:    userID,
+		Email:     "test@example.com",
+		Role:      domain.UserRoleUser,
+		ExpiresAt: time.Now().Add(1 * time.Hour),
+	})
+	feedURL := url.URL{Scheme: "https", Host: "example.com", Path: "/feed"}
+
+	gateway := mocks.NewMockUpdateFeedStatusPort(ctrl)
+	events := mocks.NewMockEventPublisherPort(ctrl)
+	tx := &fakeTx{}
+	u := NewFeedsReadingStatusUsecase(gateway, events, tx)
+
+	events.EXPECT().IsEnabled().Return(true).AnyTimes()
+	gateway.EXPECT().UpdateFeedStatus(ctx, feedURL, userID).Return(nil)
+	events.EXPECT().PublishReadStateChanged(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, event event_publisher_port.ReadStateChangedEvent) error {
+			if event.UserID != userID.String() || event.FeedURL != "https://example.com/feed" || !event.IsRead {
+				t.Errorf("unexpected event: %+v", event)
+			}
+			return nil
+		})
+	if err := u.Execute(ctx, feedURL); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if tx.committed != 1 {
+		t.Fatalf("committed = %d, want 1", tx.committed)
+	}
+
+	// A read state whose event could not be recorded is rolled back.
+	gateway.EXPECT().UpdateFeedStatus(ctx, feedURL, userID).Return(nil)
+	events.EXPECT().PublishReadStateChanged(ctx, gomock.Any()).Return(errors.New("outbox unavailable"))
+	if err := u.Execute(ctx, feedURL); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if tx.rolledBack != 1 {
+		t.Fatalf("rolledBack = %d, want 1", tx.rolledBack)
+	}
+
+	// Nothing is recorded when the read state could not be saved.
+	gateway.EXPECT().UpdateFeedStatus(ctx, feedURL, userID).Return(errors.New("mock error"))
+	if err := u.Execute(ctx, feedURL); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
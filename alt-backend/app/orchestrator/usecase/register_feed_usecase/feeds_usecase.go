@@ -7,6 +7,7 @@ import (
 	"alt/orchestrator/port/subscription_port"
 	"alt/orchestrator/port/validate_fetch_rss_port"
 	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
 	"alt/utils/logger"
 	"context"
 	"errors"
@@ -31,6 +32,9 @@ type RegisterFeedsOpts struct {
 	FeedPageInvalidator  FeedPageInvalidator
 	SubscriptionPort     subscription_port.SubscriptionPort
 	EventPublisher       event_publisher_port.EventPublisherPort
+	// Transaction saves the auto-subscribe together with its
+	// FeedSubscribed event. Required when EventPublisher is enabled.
+	Transaction transaction_port.TransactionPort
 }
 
 type RegisterFeedsUsecase struct {
@@ -41,6 +45,7 @@ type RegisterFeedsUsecase struct {
 	subscriptionPort     subscription_port.SubscriptionPort
 	availabilityPort     feed_link_availability_port.FeedLinkAvailabilityPort
 	eventPublisher       event_publisher_port.EventPublisherPort
+	transaction          transaction_port.TransactionPort
 	feedPageInvalidator  FeedPageInvalidator
 }
 
@@ -61,6 +66,7 @@ func NewRegisterFeedsUsecase(
 		uc.feedPageInvalidator = opts.FeedPageInvalidator
 		uc.subscriptionPort = opts.SubscriptionPort
 		uc.eventPublisher = opts.EventPublisher
+		uc.transaction = opts.Transaction
 	}
 	return uc
 }
@@ -186,7 +192,20 @@ func (r *RegisterFeedsUsecase) autoSubscribeUser(ctx context.Context, feedLinkID
 	if err != nil {
 		return
 	}
-	if err := r.subscriptionPort.Subscribe(ctx, userCtx.UserID, parsedID); err != nil {
-		logger.Logger.WarnContext(ctx, "Auto-subscribe failed", "error", err)
+	if r.eventPublisher == nil || !r.eventPublisher.IsEnabled() {
+		err = r.subscriptionPort.Subscribe(ctx, userCtx.UserID, parsedID)
+	} else {
+		err = r.transaction.RunInTx(ctx, func(ctx context.Context) error {
+			if err := r.subscriptionPort.Subscribe(ctx, userCtx.UserID, parsedID); err != nil {
+				return err
+			}
+			return r.eventPublisher.PublishFeedSubscribed(ctx, event_publisher_port.FeedSubscribedEvent{
+				UserID:     userCtx.UserID.String(),
+				FeedLinkID: parsedID.String(),
+			})
+		})
+	}
+	if err != nil {
+		logger.Logger.WarnContext(ctx, "Auto-subscribe failed", "feed_link_id", parsedID, "error", err)
 	}
 }
@@ -0,0 +1,252 @@
+package soft_delete_usecase
+
+import (
+	"alt/domain"
+	"alt/orchestrator/port/legal_hold_port"
+	"alt/orchestrator/port/soft_delete_port"
+	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultListLimit and maxListLimit bound the restore-list endpoints.
+	defaultListLimit = 50
+	maxListLimit     = 200
+
+	// defaultEventLimit and maxEventLimit bound one page of the article
+	// audit trail served to internal consumers.
+	defaultEventLimit = 500
+	maxEventLimit     = 1000
+
+	// purgeBatchSize rows are removed per transaction; a run stops after
+	// maxPurgeBatches so one job tick never holds the database for long.
+	purgeBatchSize  = 500
+	maxPurgeBatches = 20
+)
+
+// ErrInvalidInput is returned for requests that fail validation.
+var ErrInvalidInput = errors.New("invalid soft delete input")
+
+// PurgeResult reports how many rows one purge run removed.
+type PurgeResult struct {
+	Feeds    int64
+	Articles int64
+}
+
+// Usecase soft-deletes and restores feeds and articles and purges rows
+// whose restore window has passed. Feeds are shared across users and are
+// managed by admins; articles are scoped to their owner.
+type Usecase struct {
+	feeds    soft_delete_port.FeedSoftDeletePort
+	articles soft_delete_port.ArticleSoftDeletePort
+	holds    legal_hold_port.ListActiveLegalHoldsPort
+	events   event_publisher_port.EventPublisherPort
+	tx       transaction_port.TransactionPort
+	now      func() time.Time
+}
+
+// NewUsecase creates a new soft-delete usecase. events may be nil, in which
+// case no ArticleDeleted event is emitted; otherwise an article delete and
+// its event are saved together in a transaction from tx.
+func NewUsecase(
+	feeds soft_delete_port.FeedSoftDeletePort,
+	articles soft_delete_port.ArticleSoftDeletePort,
+	holds legal_hold_port.ListActiveLegalHoldsPort,
+	events event_publisher_port.EventPublisherPort,
+	tx transaction_port.TransactionPort,
+) *Usecase {
+	return &Usecase{
+		feeds:    feeds,
+		articles: articles,
+		holds:    holds,
+		events:   events,
+		tx:       tx,
+		now:      time.Now,
+	}
+}
+
+// DeleteFeed soft-deletes a feed on behalf of actorID.
+func (u *Usecase) DeleteFeed(ctx context.Context, actorID, feedID uuid.UUID) error {
+	if feedID == uuid.Nil {
+		return fmt.Errorf("%w: feed id is required", ErrInvalidInput)
+	}
+	if err := u.feeds.SoftDeleteFeed(ctx, feedID, actorID, u.now()); err != nil {
+		return fmt.Errorf("soft delete feed: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "feed soft-deleted", "feed_id", feedID, "actor_id", actorID)
+	return nil
+}
+
+// RestoreFeed restores a feed deleted within the restore window.
+func (u *Usecase) RestoreFeed(ctx context.Context, actorID, feedID uuid.UUID) error {
+	if feedID == uuid.Nil {
+		return fmt.Errorf("%w: feed id is required", ErrInvalidInput)
+	}
+	now := u.now()
+	if err := u.feeds.RestoreFeed(ctx, feedID, actorID, now.Add(-domain.SoftDeleteRestoreWindow), now); err != nil {
+		return fmt.Errorf("restore feed: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "feed restored", "feed_id", feedID, "actor_id", actorID)
+	return nil
+}
+
+// ListDeletedFeeds returns feeds that can still be restored, newest first.
+func (u *Usecase) ListDeletedFeeds(ctx context.Context, limit int) ([]*domain.DeletedItem, error) {
+	items, err := u.feeds.ListDeletedFeeds(ctx, u.now().Add(-domain.SoftDeleteRestoreWindow), clampListLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("list deleted feeds: %w", err)
+	}
+	return withRestoreDeadline(items), nil
+}
+
+// DeleteArticle soft-deletes one of userID's articles.
+func (u *Usecase) DeleteArticle(ctx context.Context, userID, articleID uuid.UUID) error {
+	if articleID == uuid.Nil {
+		return fmt.Errorf("%w: article id is required", ErrInvalidInput)
+	}
+	now := u.now()
+	deleteArticle := func(ctx context.Context) error {
+		if err := u.articles.SoftDeleteArticle(ctx, articleID, userID, now); err != nil {
+			return fmt.Errorf("soft delete article: %w", err)
+		}
+		return nil
+	}
+
+	var err error
+	if u.events == nil || !u.events.IsEnabled() {
+		err = deleteArticle(ctx)
+	} else {
+		err = u.tx.RunInTx(ctx, func(ctx context.Context) error {
+			if err := deleteArticle(ctx); err != nil {
+				return err
+			}
+			return u.events.PublishArticleDeleted(ctx, event_publisher_port.ArticleDeletedEvent{
+				ArticleID: articleID.String(),
+				UserID:    userID.String(),
+				DeletedAt: now,
+			})
+		})
+	}
+	if err != nil {
+		return err
+	}
+	logger.Logger.InfoContext(ctx, "article soft-deleted", "article_id", articleID, "user_id", userID)
+	return nil
+}
+
+// RestoreArticle restores one of userID's articles deleted within the
+// restore window.
+func (u *Usecase) RestoreArticle(ctx context.Context, userID, articleID uuid.UUID) error {
+	if articleID == uuid.Nil {
+		return fmt.Errorf("%w: article id is required", ErrInvalidInput)
+	}
+	now := u.now()
+	if err := u.articles.RestoreArticle(ctx, articleID, userID, now.Add(-domain.SoftDeleteRestoreWindow), now); err != nil {
+		return fmt.Errorf("restore article: %w", err)
+	}
+	logger.Logger.InfoContext(ctx, "article restored", "article_id", articleID, "user_id", userID)
+	return nil
+}
+
+// ListDeletedArticles returns userID's articles that can still be
+// restored, newest first.
+func (u *Usecase) ListDeletedArticles(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DeletedItem, error) {
+	items, err := u.articles.ListUserDeletedArticles(ctx, userID, u.now().Add(-domain.SoftDeleteRestoreWindow), clampListLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("list deleted articles: %w", err)
+	}
+	return withRestoreDeadline(items), nil
+}
+
+// ListArticleEvents returns the article delete, restore and purge events
+// recorded after the (since, afterID) position, oldest first. Pass the
+// OccurredAt and ID of the last event seen to fetch the next page.
+func (u *Usecase) ListArticleEvents(ctx context.Context, since time.Time, afterID uuid.UUID, limit int) ([]*domain.SoftDeleteEvent, error) {
+	switch {
+	case limit <= 0:
+		limit = defaultEventLimit
+	case limit > maxEventLimit:
+		limit = maxEventLimit
+	}
+	events, err := u.articles.ListArticleSoftDeleteEvents(ctx, since, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list article soft delete events: %w", err)
+	}
+	return events, nil
+}
+
+// PurgeExpired permanently removes feeds and articles whose restore window
+// has passed. Rows belonging to accounts under legal hold are kept; if the
+// hold registry cannot be read nothing is purged.
+func (u *Usecase) PurgeExpired(ctx context.Context) (PurgeResult, error) {
+	var result PurgeResult
+
+	holds, err := u.holds.ListActiveLegalHolds(ctx)
+	if err != nil {
+		return result, fmt.Errorf("list legal holds: %w", err)
+	}
+	held := make([]uuid.UUID, 0, len(holds))
+	for _, h := range holds {
+		held = append(held, h.UserID)
+	}
+
+	cutoff := u.now().Add(-domain.SoftDeleteRestoreWindow)
+
+	// Articles first: purging a feed cascades to its articles, so the
+	// per-article count stays accurate.
+	result.Articles, err = purgeInBatches(ctx, func(ctx context.Context) (int64, error) {
+		return u.articles.PurgeExpiredArticles(ctx, cutoff, held, purgeBatchSize)
+	})
+	if err != nil {
+		return result, fmt.Errorf("purge articles: %w", err)
+	}
+	result.Feeds, err = purgeInBatches(ctx, func(ctx context.Context) (int64, error) {
+		return u.feeds.PurgeExpiredFeeds(ctx, cutoff, held, purgeBatchSize)
+	})
+	if err != nil {
+		return result, fmt.Errorf("purge feeds: %w", err)
+	}
+	return result, nil
+}
+
+func purgeInBatches(ctx context.Context, purge func(context.Context) (int64, error)) (int64, error) {
+	var total int64
+	for i := 0; i < maxPurgeBatches; i++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := purge(ctx)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < purgeBatchSize {
+			break
+		}
+	}
+	return total, nil
+}
+
+func withRestoreDeadline(items []*domain.DeletedItem) []*domain.DeletedItem {
+	for _, item := range items {
+		item.RestoreUntil = item.DeletedAt.Add(domain.SoftDeleteRestoreWindow)
+	}
+	return items
+}
+
+func clampListLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
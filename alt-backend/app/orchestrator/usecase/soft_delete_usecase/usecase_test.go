@@ -0,0 +1,307 @@
+package soft_delete_usecase
+
+import (
+	"alt/domain"
+	"alt/mocks"
+	"alt/shared/port/event_publisher_port"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeStore mirrors the driver contract for one table: deleted rows keep
+// their deletion time, restore honours notBefore, purge removes rows
+// deleted before cutoff unless their owner is held.
+type fakeStore struct {
+	owners    map[uuid.UUID]uuid.UUID
+	deletedAt map[uuid.UUID]time.Time
+	purgeErr  error
+	purgeCuts []time.Time
+	heldSeen  [][]uuid.UUID
+	// eventLimits records the limit of every audit-trail page requested.
+	eventLimits []int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{owners: map[uuid.UUID]uuid.UUID{}, deletedAt: map[uuid.UUID]time.Time{}}
+}
+
+func (f *fakeStore) softDelete(id, owner uuid.UUID, ownerScoped bool, now time.Time) error {
+	o, ok := f.owners[id]
+	if !ok || (ownerScoped && o != owner) {
+		return domain.ErrItemNotFound
+	}
+	if _, deleted := f.deletedAt[id]; deleted {
+		return domain.ErrItemNotFound
+	}
+	f.deletedAt[id] = now
+	return nil
+}
+
+func (f *fakeStore) restore(id, owner uuid.UUID, ownerScoped bool, notBefore time.Time) error {
+	at, deleted := f.deletedAt[id]
+	if !deleted || (ownerScoped && f.owners[id] != owner) {
+		return domain.ErrDeletedItemNotFound
+	}
+	if at.Before(notBefore) {
+		return domain.ErrRestoreWindowExpired
+	}
+	delete(f.deletedAt, id)
+	return nil
+}
+
+func (f *fakeStore) list(notBefore time.Time) []*domain.DeletedItem {
+	items := []*domain.DeletedItem{}
+	for id, at := range f.deletedAt {
+		if !at.Before(notBefore) {
+			items = append(items, &domain.DeletedItem{ID: id, DeletedAt: at})
+		}
+	}
+	return items
+}
+
+func (f *fakeStore) purge(cutoff time.Time, held []uuid.UUID, limit int) (int64, error) {
+	f.purgeCuts = append(f.purgeCuts, cutoff)
+	f.heldSeen = append(f.heldSeen, held)
+	if f.purgeErr != nil {
+		return 0, f.purgeErr
+	}
+	heldSet := map[uuid.UUID]bool{}
+	for _, h := range held {
+		heldSet[h] = true
+	}
+	var n int64
+	for id, at := range f.deletedAt {
+		if int(n) == limit {
+			break
+		}
+		if at.Before(cutoff) && !heldSet[f.owners[id]] {
+			delete(f.deletedAt, id)
+			delete(f.owners, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+type fakeFeedPort struct{ *fakeStore }
+
+func (f fakeFeedPort) SoftDeleteFeed(_ context.Context, feedID, actorID uuid.UUID, now time.Time) error {
+	return f.softDelete(feedID, actorID, false, now)
+}
+
+func (f fakeFeedPort) RestoreFeed(_ context.Context, feedID, actorID uuid.UUID, notBefore, _ time.Time) error {
+	return f.restore(feedID, actorID, false, notBefore)
+}
+
+func (f fakeFeedPort) ListDeletedFeeds(_ context.Context, notBefore time.Time, _ int) ([]*domain.DeletedItem, error) {
+	return f.list(notBefore), nil
+}
+
+func (f fakeFeedPort) PurgeExpiredFeeds(_ context.Context, cutoff time.Time, held []uuid.UUID, limit int) (int64, error) {
+	return f.purge(cutoff, held, limit)
+}
+
+type fakeArticlePort struct{ *fakeStore }
+
+func (f fakeArticlePort) SoftDeleteArticle(_ context.Context, articleID, userID uuid.UUID, now time.Time) error {
+	return f.softDelete(articleID, userID, true, now)
+}
+
+func (f fakeArticlePort) RestoreArticle(_ context.Context, articleID, userID uuid.UUID, notBefore, _ time.Time) error {
+	return f.restore(articleID, userID, true, notBefore)
+}
+
+func (f fakeArticlePort) ListUserDeletedArticles(_ context.Context, _ uuid.UUID, notBefore time.Time, _ int) ([]*domain.DeletedItem, error) {
+	return f.list(notBefore), nil
+}
+
+func (f fakeArticlePort) PurgeExpiredArticles(_ context.Context, cutoff time.Time, held []uuid.UUID, limit int) (int64, error) {
+	return f.purge(cutoff, held, limit)
+}
+
+func (f fakeArticlePort) ListArticleSoftDeleteEvents(_ context.Context, _ time.Time, _ uuid.UUID, limit int) ([]*domain.SoftDeleteEvent, error) {
+	f.eventLimits = append(f.eventLimits, limit)
+	return nil, nil
+}
+
+type fakeHoldLister struct {
+	holds []*domain.LegalHold
+	err   error
+}
+
+func (f *fakeHoldLister) ListActiveLegalHolds(_ context.Context) ([]*domain.LegalHold, error) {
+	return f.holds, f.err
+}
+
+type fixture struct {
+	uc       *Usecase
+	feeds    *fakeStore
+	articles *fakeStore
+	holds    *fakeHoldLister
+	now      time.Time
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		feeds:    newFakeStore(),
+		articles: newFakeStore(),
+		holds:    &fakeHoldLister{},
+		now:      time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}
+	f.uc = NewUsecase(fakeFeedPort{f.feeds}, fakeArticlePort{f.articles}, f.holds, nil, nil)
+	f.uc.now = func() time.Time { return f.now }
+	return f
+}
+
+func TestDeleteAndRestoreArticle(t *testing.T) {
+	f := newFixture()
+	owner, articleID := uuid.New(), uuid.New()
+	f.articles.owners[articleID] = owner
+
+	require.NoError(t, f.uc.DeleteArticle(context.Background(), owner, articleID))
+
+	items, err := f.uc.ListDeletedArticles(context.Background(), owner, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, f.now.Add(30*24*time.Hour), items[0].RestoreUntil)
+
+	f.now = f.now.Add(29 * 24 * time.Hour)
+	require.NoError(t, f.uc.RestoreArticle(context.Background(), owner, articleID))
+	assert.Empty(t, f.articles.deletedAt)
+}
+
+// fakeTx runs fn directly and counts how the transaction would have ended.
+type fakeTx struct {
+	committed, rolledBack int
+}
+
+func (f *fakeTx) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.rolledBack++
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+func TestDeleteArticle_RecordsArticleDeletedInTx(t *testing.T) {
+	logger.InitLogger()
+	ctrl := gomock.NewController(t)
+	events := mocks.NewMockEventPublisherPort(ctrl)
+	tx := &fakeTx{}
+	f := newFixture()
+	f.uc.events, f.uc.tx = events, tx
+	owner, articleID := uuid.New(), uuid.New()
+	f.articles.owners[articleID] = owner
+
+	events.EXPECT().IsEnabled().Return(true).AnyTimes()
+	events.EXPECT().PublishArticleDeleted(gomock.Any(), event_publisher_port.ArticleDeletedEvent{
+		ArticleID: articleID.String(),
+		UserID:    owner.String(),
+		DeletedAt: f.now,
+	}).Return(nil)
+	require.NoError(t, f.uc.DeleteArticle(context.Background(), owner, articleID))
+	assert.Equal(t, 1, tx.committed)
+
+	// A delete that did not happen records nothing.
+	err := f.uc.DeleteArticle(context.Background(), uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, domain.ErrItemNotFound)
+	assert.Equal(t, 1, tx.rolledBack)
+
+	// A delete whose event could not be recorded fails and is rolled back.
+	otherArticle := uuid.New()
+	f.articles.owners[otherArticle] = owner
+	events.EXPECT().PublishArticleDeleted(gomock.Any(), gomock.Any()).Return(errors.New("outbox unavailable"))
+	require.Error(t, f.uc.DeleteArticle(context.Background(), owner, otherArticle))
+	assert.Equal(t, 2, tx.rolledBack)
+}
+
+func TestDeleteArticle_OtherUsersArticle(t *testing.T) {
+	f := newFixture()
+	articleID := uuid.New()
+	f.articles.owners[articleID] = uuid.New()
+
+	err := f.uc.DeleteArticle(context.Background(), uuid.New(), articleID)
+	assert.ErrorIs(t, err, domain.ErrItemNotFound)
+}
+
+func TestRestoreFeed_AfterWindow(t *testing.T) {
+	f := newFixture()
+	admin, feedID := uuid.New(), uuid.New()
+	f.feeds.owners[feedID] = uuid.Nil
+
+	require.NoError(t, f.uc.DeleteFeed(context.Background(), admin, feedID))
+	f.now = f.now.Add(31 * 24 * time.Hour)
+
+	err := f.uc.RestoreFeed(context.Background(), admin, feedID)
+	assert.ErrorIs(t, err, domain.ErrRestoreWindowExpired)
+
+	items, err := f.uc.ListDeletedFeeds(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, items, "expired items are no longer listed as restorable")
+}
+
+func TestDeleteFeed_NilID(t *testing.T) {
+	f := newFixture()
+	err := f.uc.DeleteFeed(context.Background(), uuid.New(), uuid.Nil)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestPurgeExpired_SkipsHeldAccounts(t *testing.T) {
+	f := newFixture()
+	heldUser, freeUser := uuid.New(), uuid.New()
+	heldArticle, freeArticle, recentArticle := uuid.New(), uuid.New(), uuid.New()
+	f.articles.owners[heldArticle] = heldUser
+	f.articles.owners[freeArticle] = freeUser
+	f.articles.owners[recentArticle] = freeUser
+	old := f.now.Add(-31 * 24 * time.Hour)
+	f.articles.deletedAt[heldArticle] = old
+	f.articles.deletedAt[freeArticle] = old
+	f.articles.deletedAt[recentArticle] = f.now.Add(-time.Hour)
+	f.holds.holds = []*domain.LegalHold{{UserID: heldUser}}
+
+	result, err := f.uc.PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Articles)
+	assert.Contains(t, f.articles.deletedAt, heldArticle)
+	assert.Contains(t, f.articles.deletedAt, recentArticle)
+	assert.NotContains(t, f.articles.deletedAt, freeArticle)
+	assert.Equal(t, f.now.Add(-domain.SoftDeleteRestoreWindow), f.articles.purgeCuts[0])
+	assert.Equal(t, []uuid.UUID{heldUser}, f.feeds.heldSeen[0])
+}
+
+func TestPurgeExpired_HoldLookupFailureAbortsPurge(t *testing.T) {
+	f := newFixture()
+	f.holds.err = errors.New("db down")
+
+	_, err := f.uc.PurgeExpired(context.Background())
+	require.Error(t, err)
+	assert.Empty(t, f.articles.purgeCuts)
+	assert.Empty(t, f.feeds.purgeCuts)
+}
+
+func TestPurgeExpired_PropagatesPurgeError(t *testing.T) {
+	f := newFixture()
+	f.articles.purgeErr = errors.New("lock timeout")
+
+	_, err := f.uc.PurgeExpired(context.Background())
+	require.Error(t, err)
+	assert.Empty(t, f.feeds.purgeCuts, "feeds are not purged after an article purge failure")
+}
+
+func TestListArticleEvents_ClampsLimit(t *testing.T) {
+	f := newFixture()
+	for _, limit := range []int{0, 20, 5000} {
+		_, err := f.uc.ListArticleEvents(context.Background(), time.Time{}, uuid.Nil, limit)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int{defaultEventLimit, 20, maxEventLimit}, f.articles.eventLimits)
+}
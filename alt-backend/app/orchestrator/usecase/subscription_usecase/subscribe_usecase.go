@@ -2,19 +2,37 @@ package subscription_usecase
 
 import (
 	"alt/orchestrator/port/subscription_port"
+	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
 	"context"
 
 	"github.com/google/uuid"
 )
 
 type SubscribeUsecase struct {
-	port subscription_port.SubscriptionPort
+	port   subscription_port.SubscriptionPort
+	events event_publisher_port.EventPublisherPort
+	tx     transaction_port.TransactionPort
 }
 
-func NewSubscribeUsecase(port subscription_port.SubscriptionPort) *SubscribeUsecase {
-	return &SubscribeUsecase{port: port}
+// NewSubscribeUsecase creates a SubscribeUsecase. events may be nil, in
+// which case no FeedSubscribed event is emitted; otherwise the subscription
+// and its event are saved together in a transaction from tx.
+func NewSubscribeUsecase(port subscription_port.SubscriptionPort, events event_publisher_port.EventPublisherPort, tx transaction_port.TransactionPort) *SubscribeUsecase {
+	return &SubscribeUsecase{port: port, events: events, tx: tx}
 }
 
 func (u *SubscribeUsecase) Execute(ctx context.Context, userID uuid.UUID, feedLinkID uuid.UUID) error {
-	return u.port.Subscribe(ctx, userID, feedLinkID)
+	if u.events == nil || !u.events.IsEnabled() {
+		return u.port.Subscribe(ctx, userID, feedLinkID)
+	}
+	return u.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.port.Subscribe(ctx, userID, feedLinkID); err != nil {
+			return err
+		}
+		return u.events.PublishFeedSubscribed(ctx, event_publisher_port.FeedSubscribedEvent{
+			UserID:     userID.String(),
+			FeedLinkID: feedLinkID.String(),
+		})
+	})
 }
@@ -2,19 +2,37 @@ package subscription_usecase
 
 import (
 	"alt/orchestrator/port/subscription_port"
+	"alt/shared/port/event_publisher_port"
+	"alt/shared/port/transaction_port"
 	"context"
 
 	"github.com/google/uuid"
 )
 
 type UnsubscribeUsecase struct {
-	port subscription_port.SubscriptionPort
+	port   subscription_port.SubscriptionPort
+	events event_publisher_port.EventPublisherPort
+	tx     transaction_port.TransactionPort
 }
 
-func NewUnsubscribeUsecase(port subscription_port.SubscriptionPort) *UnsubscribeUsecase {
-	return &UnsubscribeUsecase{port: port}
+// NewUnsubscribeUsecase creates an UnsubscribeUsecase. events may be nil,
+// in which case no FeedUnsubscribed event is emitted; otherwise the
+// unsubscribe and its event are saved together in a transaction from tx.
+func NewUnsubscribeUsecase(port subscription_port.SubscriptionPort, events event_publisher_port.EventPublisherPort, tx transaction_port.TransactionPort) *UnsubscribeUsecase {
+	return &UnsubscribeUsecase{port: port, events: events, tx: tx}
 }
 
 func (u *UnsubscribeUsecase) Execute(ctx context.Context, userID uuid.UUID, feedLinkID uuid.UUID) error {
-	return u.port.Unsubscribe(ctx, userID, feedLinkID)
+	if u.events == nil || !u.events.IsEnabled() {
+		return u.port.Unsubscribe(ctx, userID, feedLinkID)
+	}
+	return u.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.port.Unsubscribe(ctx, userID, feedLinkID); err != nil {
+			return err
+		}
+		return u.events.PublishFeedUnsubscribed(ctx, event_publisher_port.FeedUnsubscribedEvent{
+			UserID:     userID.String(),
+			FeedLinkID: feedLinkID.String(),
+		})
+	})
 }
@@ -0,0 +1,253 @@
+package timeline_stream_usecase
+
+import (
+	"alt/domain"
+	"alt/utils/logger"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMaxConnections        = 1000
+	defaultMaxConnectionsPerUser = 5
+	defaultReplayBufferSize      = 1024
+	defaultSubscriberBuffer      = 64
+)
+
+// ErrTooManyConnections is returned by Subscribe when the user, or the
+// process as a whole, already has the maximum number of open streams.
+var ErrTooManyConnections = errors.New("too many timeline stream connections")
+
+// Config bounds the live timeline. Zero values select the defaults.
+type Config struct {
+	// MaxConnections caps open streams across all users.
+	MaxConnections int
+	// MaxConnectionsPerUser caps open streams per user, e.g. one per tab.
+	MaxConnectionsPerUser int
+	// ReplayBufferSize is how many recent events, across all users, are
+	// kept for clients resuming from a cursor.
+	ReplayBufferSize int
+	// SubscriberBuffer is how many events a stream may fall behind before
+	// it is dropped and has to resume from its cursor.
+	SubscriberBuffer int
+}
+
+// Event is a timeline update as delivered to a stream, with the cursor a
+// client sends back to resume after it.
+type Event struct {
+	Cursor string
+	domain.TimelineEvent
+}
+
+// Usecase fans timeline events out to the open streams of the user each
+// event belongs to.
+//
+// Events live in memory only. Cursors name a position in this process's
+// event sequence, so a cursor from before a restart, or from another
+// replica, cannot be resumed; neither can one whose events have left the
+// replay buffer. Subscribe reports that as Reset and the client reloads its
+// timeline instead.
+type Usecase struct {
+	cfg   Config
+	epoch string
+
+	mu     sync.Mutex
+	seq    uint64
+	replay []Event // ring of the last ReplayBufferSize events
+	next   int     // replay index the next event is written to
+	subs   map[string]map[*Subscription]struct{}
+	total  int
+}
+
+// NewUsecase creates a new timeline stream usecase.
+func NewUsecase(cfg Config) *Usecase {
+	if cfg.MaxConnections <= 0 {
+		cfg.MaxConnections = defaultMaxConnections
+	}
+	if cfg.MaxConnectionsPerUser <= 0 {
+		cfg.MaxConnectionsPerUser = defaultMaxConnectionsPerUser
+	}
+	if cfg.ReplayBufferSize <= 0 {
+		cfg.ReplayBufferSize = defaultReplayBufferSize
+	}
+	if cfg.SubscriberBuffer <= 0 {
+		cfg.SubscriberBuffer = defaultSubscriberBuffer
+	}
+	return &Usecase{
+		cfg:    cfg,
+		epoch:  strings.ReplaceAll(uuid.NewString(), "-", "")[:8],
+		replay: make([]Event, 0, cfg.ReplayBufferSize),
+		subs:   make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription is one open stream of a user's timeline.
+type Subscription struct {
+	// Replay holds the events after the resumed cursor, oldest first.
+	Replay []Event
+	// Reset is set when the requested cursor could not be resumed. Events
+	// may have been missed, so the client should reload its timeline.
+	Reset bool
+	// Cursor is the position the stream starts at, after Replay.
+	Cursor string
+
+	uc     *Usecase
+	userID string
+	events chan Event
+	closed bool
+	lagged bool
+}
+
+// Events delivers live events. It is closed when the stream falls more
+// than SubscriberBuffer events behind (see Lagged) or is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Lagged reports whether Events was closed because the stream fell behind.
+// The client can resume from the last cursor it received.
+func (s *Subscription) Lagged() bool {
+	s.uc.mu.Lock()
+	defer s.uc.mu.Unlock()
+	return s.lagged
+}
+
+// Close releases the stream's connection slot. It is safe to call more
+// than once.
+func (s *Subscription) Close() {
+	s.uc.mu.Lock()
+	defer s.uc.mu.Unlock()
+	s.uc.remove(s)
+}
+
+// Subscribe opens a stream of userID's timeline. A non-empty cursor, as
+// received with an earlier event, resumes after that event.
+func (u *Usecase) Subscribe(userID, cursor string) (*Subscription, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.total >= u.cfg.MaxConnections {
+		return nil, fmt.Errorf("%w: %d streams open", ErrTooManyConnections, u.total)
+	}
+	if len(u.subs[userID]) >= u.cfg.MaxConnectionsPerUser {
+		return nil, fmt.Errorf("%w: user has %d streams open", ErrTooManyConnections, len(u.subs[userID]))
+	}
+
+	sub := &Subscription{
+		Cursor: u.cursor(u.seq),
+		uc:     u,
+		userID: userID,
+		events: make(chan Event, u.cfg.SubscriberBuffer),
+	}
+	if cursor != "" {
+		sub.Replay, sub.Reset = u.since(userID, cursor)
+	}
+
+	if u.subs[userID] == nil {
+		u.subs[userID] = make(map[*Subscription]struct{})
+	}
+	u.subs[userID][sub] = struct{}{}
+	u.total++
+	return sub, nil
+}
+
+// Publish records event and delivers it to the user's open streams. A
+// stream whose buffer is full is dropped rather than blocking the caller.
+func (u *Usecase) Publish(event domain.TimelineEvent) {
+	if event.UserID == "" {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.seq++
+	delivered := Event{Cursor: u.cursor(u.seq), TimelineEvent: event}
+	if len(u.replay) < u.cfg.ReplayBufferSize {
+		u.replay = append(u.replay, delivered)
+	} else {
+		u.replay[u.next] = delivered
+	}
+	u.next = (u.next + 1) % u.cfg.ReplayBufferSize
+
+	for sub := range u.subs[event.UserID] {
+		select {
+		case sub.events <- delivered:
+		default:
+			sub.lagged = true
+			u.remove(sub)
+			logger.Logger.Debug("timeline stream fell behind, dropping it",
+				"user_id", event.UserID, "buffer", u.cfg.SubscriberBuffer)
+		}
+	}
+}
+
+// ConnectionCount returns the number of open streams.
+func (u *Usecase) ConnectionCount() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.total
+}
+
+// remove unregisters sub and closes its channel. u.mu must be held.
+func (u *Usecase) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.events)
+
+	delete(u.subs[sub.userID], sub)
+	if len(u.subs[sub.userID]) == 0 {
+		delete(u.subs, sub.userID)
+	}
+	u.total--
+}
+
+// since returns userID's events after cursor, or reset when the events
+// after cursor are no longer all in the replay buffer. u.mu must be held.
+func (u *Usecase) since(userID, cursor string) (events []Event, reset bool) {
+	seq, ok := u.parseCursor(cursor)
+	if !ok || seq > u.seq {
+		return nil, true
+	}
+	oldest := u.seq - uint64(len(u.replay)) + 1
+	if seq+1 < oldest {
+		return nil, true
+	}
+
+	start := 0
+	if len(u.replay) == u.cfg.ReplayBufferSize {
+		start = u.next
+	}
+	for i := range len(u.replay) {
+		e := u.replay[(start+i)%len(u.replay)]
+		if eventSeq := oldest + uint64(i); eventSeq > seq && e.UserID == userID {
+			events = append(events, e)
+		}
+	}
+	return events, false
+}
+
+func (u *Usecase) cursor(seq uint64) string {
+	return u.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseCursor returns the sequence number of a cursor issued by this
+// process.
+func (u *Usecase) parseCursor(cursor string) (uint64, bool) {
+	epoch, raw, ok := strings.Cut(cursor, "-")
+	if !ok || epoch != u.epoch {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
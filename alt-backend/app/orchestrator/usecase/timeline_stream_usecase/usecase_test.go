@@ -0,0 +1,152 @@
+package timeline_stream_usecase
+
+import (
+	"alt/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func articleEvent(userID, articleID string) domain.TimelineEvent {
+	return domain.TimelineEvent{Type: domain.TimelineEventArticleCreated, UserID: userID, ArticleID: articleID}
+}
+
+// received drains the events already delivered to sub.
+func received(sub *Subscription) []string {
+	var ids []string
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ArticleID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestUsecase_DeliversOnlyTheUsersEvents(t *testing.T) {
+	uc := NewUsecase(Config{})
+	alice, err := uc.Subscribe("alice", "")
+	require.NoError(t, err)
+	bob, err := uc.Subscribe("bob", "")
+	require.NoError(t, err)
+
+	uc.Publish(articleEvent("alice", "a1"))
+	uc.Publish(articleEvent("bob", "b1"))
+	uc.Publish(articleEvent("", "nobody"))
+	uc.Publish(articleEvent("alice", "a2"))
+
+	assert.Equal(t, []string{"a1", "a2"}, received(alice))
+	assert.Equal(t, []string{"b1"}, received(bob))
+}
+
+func TestUsecase_ResumesFromCursor(t *testing.T) {
+	uc := NewUsecase(Config{})
+	first, err := uc.Subscribe("alice", "")
+	require.NoError(t, err)
+
+	uc.Publish(articleEvent("alice", "a1"))
+	var last Event
+	select {
+	case last = <-first.Events():
+	default:
+		t.Fatal("event not delivered")
+	}
+	first.Close()
+
+	uc.Publish(articleEvent("alice", "a2"))
+	uc.Publish(articleEvent("bob", "b1"))
+	uc.Publish(articleEvent("alice", "a3"))
+
+	resumed, err := uc.Subscribe("alice", last.Cursor)
+	require.NoError(t, err)
+	assert.False(t, resumed.Reset)
+	require.Len(t, resumed.Replay, 2)
+	assert.Equal(t, "a2", resumed.Replay[0].ArticleID)
+	assert.Equal(t, "a3", resumed.Replay[1].ArticleID)
+	assert.Equal(t, resumed.Replay[1].Cursor, resumed.Cursor)
+
+	uc.Publish(articleEvent("alice", "a4"))
+	assert.Equal(t, []string{"a4"}, received(resumed), "no gap or duplicate between replay and live events")
+}
+
+func TestUsecase_UnresumableCursorResets(t *testing.T) {
+	uc := NewUsecase(Config{ReplayBufferSize: 2})
+	start, err := uc.Subscribe("alice", "")
+	require.NoError(t, err)
+	start.Close()
+
+	for _, id := range []string{"a1", "a2", "a3"} {
+		uc.Publish(articleEvent("alice", id))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "events evicted from the buffer", cursor: start.Cursor},
+		{name: "cursor from another process", cursor: "deadbeef-1"},
+		{name: "cursor ahead of this process", cursor: uc.cursor(99)},
+		{name: "malformed", cursor: "garbage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := uc.Subscribe("alice", tt.cursor)
+			require.NoError(t, err)
+			defer sub.Close()
+
+			assert.True(t, sub.Reset)
+			assert.Empty(t, sub.Replay)
+		})
+	}
+
+	sub, err := uc.Subscribe("alice", uc.cursor(1))
+	require.NoError(t, err)
+	defer sub.Close()
+	assert.False(t, sub.Reset, "events after the oldest buffered one are still there")
+	require.Len(t, sub.Replay, 2)
+	assert.Equal(t, "a2", sub.Replay[0].ArticleID)
+}
+
+func TestUsecase_ConnectionLimits(t *testing.T) {
+	uc := NewUsecase(Config{MaxConnections: 3, MaxConnectionsPerUser: 2})
+
+	a1, err := uc.Subscribe("alice", "")
+	require.NoError(t, err)
+	_, err = uc.Subscribe("alice", "")
+	require.NoError(t, err)
+	_, err = uc.Subscribe("alice", "")
+	assert.ErrorIs(t, err, ErrTooManyConnections, "per-user limit")
+
+	_, err = uc.Subscribe("bob", "")
+	require.NoError(t, err)
+	_, err = uc.Subscribe("carol", "")
+	assert.ErrorIs(t, err, ErrTooManyConnections, "global limit")
+
+	a1.Close()
+	a1.Close()
+	assert.Equal(t, 2, uc.ConnectionCount())
+	_, err = uc.Subscribe("carol", "")
+	assert.NoError(t, err, "closing frees the slot")
+}
+
+func TestUsecase_DropsStreamsThatFallBehind(t *testing.T) {
+	uc := NewUsecase(Config{SubscriberBuffer: 2})
+	slow, err := uc.Subscribe("alice", "")
+	require.NoError(t, err)
+
+	for _, id := range []string{"a1", "a2", "a3"} {
+		uc.Publish(articleEvent("alice", id))
+	}
+
+	assert.Equal(t, []string{"a1", "a2"}, received(slow))
+	_, open := <-slow.Events()
+	assert.False(t, open)
+	assert.True(t, slow.Lagged())
+	assert.Equal(t, 0, uc.ConnectionCount())
+	slow.Close()
+}
@@ -37,9 +37,18 @@ type UpdateLensInput struct {
 	IncludeRecap bool
 	IncludePulse bool
 	SortMode     string
+	// ExpectedRevision is the lens revision the client last saw (If-Match).
+	// Zero updates unconditionally.
+	ExpectedRevision int64
 }
 
-func (u *UpdateLensUsecase) Execute(ctx context.Context, input UpdateLensInput) (*domain.KnowledgeLensVersion, error) {
+type UpdateLensResult struct {
+	Version domain.KnowledgeLensVersion
+	// Revision is the lens revision after the update.
+	Revision int64
+}
+
+func (u *UpdateLensUsecase) Execute(ctx context.Context, input UpdateLensInput) (*UpdateLensResult, error) {
 	lens, err := u.getLens.GetLens(ctx, input.LensID)
 	if err != nil {
 		return nil, fmt.Errorf("get lens: %w", err)
@@ -65,9 +74,10 @@ func (u *UpdateLensUsecase) Execute(ctx context.Context, input UpdateLensInput)
 		SortMode:      input.SortMode,
 	}
 
-	if err := u.versionPort.CreateLensVersion(ctx, version); err != nil {
+	revision, err := u.versionPort.CreateLensVersion(ctx, version, input.ExpectedRevision)
+	if err != nil {
 		return nil, fmt.Errorf("create lens version: %w", err)
 	}
 
-	return &version, nil
+	return &UpdateLensResult{Version: version, Revision: revision}, nil
 }
@@ -22,11 +22,20 @@ func (m *mockGetLensPort) GetLens(_ context.Context, _ uuid.UUID) (*domain.Knowl
 	return m.lens, nil
 }
 
-type mockCreateLensVersionPort struct{ called bool }
+type mockCreateLensVersionPort struct {
+	called      bool
+	gotExpected int64
+	revision    int64
+	err         error
+}
 
-func (m *mockCreateLensVersionPort) CreateLensVersion(_ context.Context, _ domain.KnowledgeLensVersion) error {
+func (m *mockCreateLensVersionPort) CreateLensVersion(_ context.Context, _ domain.KnowledgeLensVersion, expectedRevision int64) (int64, error) {
 	m.called = true
-	return nil
+	m.gotExpected = expectedRevision
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.revision, nil
 }
 
 func TestUpdateLens_Success(t *testing.T) {
@@ -37,12 +46,49 @@ func TestUpdateLens_Success(t *testing.T) {
 		&mockCreateLensVersionPort{},
 	)
 
-	version, err := uc.Execute(context.Background(), UpdateLensInput{
+	result, err := uc.Execute(context.Background(), UpdateLensInput{
 		LensID: lensID,
 		UserID: userID,
 		Name:   "updated",
 	})
 
 	require.NoError(t, err)
-	assert.NotNil(t, version)
+	assert.NotNil(t, result)
+}
+
+func TestUpdateLens_PassesExpectedRevision(t *testing.T) {
+	userID := uuid.New()
+	lensID := uuid.New()
+	versionPort := &mockCreateLensVersionPort{revision: 4}
+	uc := NewUpdateLensUsecase(
+		&mockGetLensPort{lens: &domain.KnowledgeLens{LensID: lensID, UserID: userID, Revision: 3}},
+		versionPort,
+	)
+
+	result, err := uc.Execute(context.Background(), UpdateLensInput{
+		LensID:           lensID,
+		UserID:           userID,
+		ExpectedRevision: 3,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), versionPort.gotExpected)
+	assert.Equal(t, int64(4), result.Revision)
+}
+
+func TestUpdateLens_RevisionConflict(t *testing.T) {
+	userID := uuid.New()
+	lensID := uuid.New()
+	uc := NewUpdateLensUsecase(
+		&mockGetLensPort{lens: &domain.KnowledgeLens{LensID: lensID, UserID: userID}},
+		&mockCreateLensVersionPort{err: domain.ErrLensRevisionConflict},
+	)
+
+	_, err := uc.Execute(context.Background(), UpdateLensInput{
+		LensID:           lensID,
+		UserID:           userID,
+		ExpectedRevision: 2,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrLensRevisionConflict)
 }
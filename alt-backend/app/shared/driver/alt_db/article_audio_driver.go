@@ -0,0 +1,156 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const articleAudioColumns = `article_id, user_id, status, voice, duration_seconds, storage_url, error_message, attempts, requested_at, completed_at, updated_at`
+
+// FetchArticleAudio returns the audio row for one of userID's live articles.
+func (r *ArticleRepository) FetchArticleAudio(ctx context.Context, articleID, userID uuid.UUID) (*domain.ArticleAudio, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `
+		SELECT au.article_id, au.user_id, au.status, au.voice, au.duration_seconds, au.storage_url,
+		       au.error_message, au.attempts, au.requested_at, au.completed_at, au.updated_at
+		FROM article_audio au
+		JOIN articles a ON a.id = au.article_id
+		WHERE au.article_id = $1 AND au.user_id = $2 AND a.deleted_at IS NULL`
+
+	audio, err := scanArticleAudio(r.pool.QueryRow(ctx, query, articleID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrArticleAudioNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch article audio: %w", err)
+	}
+	return audio, nil
+}
+
+// RequestArticleAudio starts a generation attempt for one of userID's live
+// articles. The ownership check, the "is a new attempt due" check and the
+// upsert are one statement, so concurrent requests start at most one
+// attempt between them.
+func (r *ArticleRepository) RequestArticleAudio(ctx context.Context, articleID, userID uuid.UUID, voice string, now, staleBefore time.Time) (*domain.ArticleAudio, bool, error) {
+	if r == nil || r.pool == nil {
+		return nil, false, errors.New("database connection not available")
+	}
+
+	query := `
+		INSERT INTO article_audio (article_id, user_id, status, voice, attempts, requested_at, updated_at)
+		SELECT a.id, a.user_id, 'pending', $3, 1, $4, $4
+		FROM articles a
+		WHERE a.id = $1 AND a.user_id = $2 AND a.deleted_at IS NULL
+		ON CONFLICT (article_id) DO UPDATE
+		SET status = 'pending',
+		    voice = EXCLUDED.voice,
+		    duration_seconds = NULL,
+		    storage_url = NULL,
+		    error_message = NULL,
+		    attempts = article_audio.attempts + 1,
+		    requested_at = EXCLUDED.requested_at,
+		    completed_at = NULL,
+		    updated_at = EXCLUDED.updated_at
+		WHERE article_audio.status = 'failed'
+		   OR (article_audio.status IN ('pending', 'processing') AND article_audio.updated_at < $5)
+		RETURNING ` + articleAudioColumns
+
+	audio, err := scanArticleAudio(r.pool.QueryRow(ctx, query, articleID, userID, voice, now.UTC(), staleBefore.UTC()))
+	if err == nil {
+		return audio, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to request article audio: %w", err)
+	}
+
+	// Nothing was written: either the article is not the caller's, or an
+	// attempt is already ready or in flight.
+	audio, err = r.FetchArticleAudio(ctx, articleID, userID)
+	if errors.Is(err, domain.ErrArticleAudioNotFound) {
+		return nil, false, domain.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return audio, false, nil
+}
+
+// ReportArticleAudio applies a worker report to the attempt it names, as
+// long as that attempt is still in flight.
+func (r *ArticleRepository) ReportArticleAudio(ctx context.Context, report *domain.ArticleAudioReport, now time.Time) (*domain.ArticleAudio, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+	now = now.UTC()
+
+	var (
+		duration    *float64
+		storageURL  *string
+		errMessage  *string
+		completedAt *time.Time
+	)
+	switch report.Status {
+	case domain.ArticleAudioStatusReady:
+		duration, storageURL, completedAt = &report.DurationSeconds, &report.StorageURL, &now
+	case domain.ArticleAudioStatusFailed:
+		errMessage, completedAt = &report.ErrorMessage, &now
+	}
+
+	query := `
+		UPDATE article_audio
+		SET status = $3, duration_seconds = $4, storage_url = $5, error_message = $6,
+		    completed_at = $7, updated_at = $8
+		WHERE article_id = $1 AND attempts = $2 AND status IN ('pending', 'processing')
+		RETURNING ` + articleAudioColumns
+
+	audio, err := scanArticleAudio(r.pool.QueryRow(ctx, query,
+		report.ArticleID, report.Attempt, string(report.Status), duration, storageURL, errMessage, completedAt, now))
+	if err == nil {
+		return audio, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to report article audio: %w", err)
+	}
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM article_audio WHERE article_id = $1)`, report.ArticleID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to look up article audio: %w", err)
+	}
+	if exists {
+		return nil, domain.ErrArticleAudioConflict
+	}
+	return nil, domain.ErrArticleAudioNotFound
+}
+
+func scanArticleAudio(row pgx.Row) (*domain.ArticleAudio, error) {
+	var audio domain.ArticleAudio
+	var status string
+	var duration *float64
+	var storageURL, errMessage *string
+	if err := row.Scan(
+		&audio.ArticleID, &audio.UserID, &status, &audio.Voice, &duration, &storageURL,
+		&errMessage, &audio.Attempts, &audio.RequestedAt, &audio.CompletedAt, &audio.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	audio.Status = domain.ArticleAudioStatus(status)
+	if duration != nil {
+		audio.DurationSeconds = *duration
+	}
+	if storageURL != nil {
+		audio.StorageURL = *storageURL
+	}
+	if errMessage != nil {
+		audio.ErrorMessage = *errMessage
+	}
+	return &audio, nil
+}
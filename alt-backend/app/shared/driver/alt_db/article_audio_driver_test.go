@@ -0,0 +1,105 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func articleAudioRow(articleID, userID uuid.UUID, status string, attempts int, at time.Time) *pgxmock.Rows {
+	return pgxmock.NewRows([]string{
+		"article_id", "user_id", "status", "voice", "duration_seconds", "storage_url",
+		"error_message", "attempts", "requested_at", "completed_at", "updated_at",
+	}).AddRow(articleID, userID, status, "", (*float64)(nil), (*string)(nil),
+		(*string)(nil), attempts, at, (*time.Time)(nil), at)
+}
+
+func TestRequestArticleAudio_AlreadyInFlight(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	staleBefore := now.Add(-30 * time.Minute)
+	articleID, userID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`INSERT INTO article_audio`).
+		WithArgs(articleID, userID, "", now, staleBefore).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`FROM article_audio au`).
+		WithArgs(articleID, userID).
+		WillReturnRows(articleAudioRow(articleID, userID, "processing", 2, now.Add(-time.Minute)))
+
+	audio, started, err := repo.RequestArticleAudio(context.Background(), articleID, userID, "", now, staleBefore)
+	require.NoError(t, err)
+	require.False(t, started)
+	require.Equal(t, domain.ArticleAudioStatusProcessing, audio.Status)
+	require.Equal(t, 2, audio.Attempts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRequestArticleAudio_NotOwned(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	articleID, userID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`INSERT INTO article_audio`).
+		WithArgs(articleID, userID, "", now, now).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`FROM article_audio au`).
+		WithArgs(articleID, userID).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, _, err = repo.RequestArticleAudio(context.Background(), articleID, userID, "", now, now)
+	require.ErrorIs(t, err, domain.ErrItemNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportArticleAudio_DistinguishesConflictFromUnknown(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		exists bool
+		want   error
+	}{
+		{name: "finished or superseded", exists: true, want: domain.ErrArticleAudioConflict},
+		{name: "unknown", exists: false, want: domain.ErrArticleAudioNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			repo := &ArticleRepository{pool: mock}
+			report := &domain.ArticleAudioReport{
+				ArticleID:    uuid.New(),
+				Attempt:      1,
+				Status:       domain.ArticleAudioStatusFailed,
+				ErrorMessage: "voice model unavailable",
+			}
+
+			mock.ExpectQuery(`UPDATE article_audio`).
+				WithArgs(report.ArticleID, 1, "failed", (*float64)(nil), (*string)(nil), &report.ErrorMessage, pgxmock.AnyArg(), now).
+				WillReturnError(pgx.ErrNoRows)
+			mock.ExpectQuery(`SELECT EXISTS`).
+				WithArgs(report.ArticleID).
+				WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(tc.exists))
+
+			_, err = repo.ReportArticleAudio(context.Background(), report, now)
+			require.ErrorIs(t, err, tc.want)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
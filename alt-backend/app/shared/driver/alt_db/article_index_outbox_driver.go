@@ -0,0 +1,167 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// markArticleIndexDirtyQuery queues article $1 for reindexing, or moves an
+// already queued one to the front of its retry schedule.
+const markArticleIndexDirtyQuery = `
+	INSERT INTO article_index_outbox (article_id, reason, marked_at, attempts, available_at)
+	VALUES ($1, $2, $3, 0, $3)
+	ON CONFLICT (article_id) DO UPDATE
+	SET reason = EXCLUDED.reason,
+		marked_at = EXCLUDED.marked_at,
+		attempts = 0,
+		available_at = EXCLUDED.available_at,
+		last_error = NULL`
+
+// markArticleIndexDirtyTx marks an article inside the transaction that
+// changed it, so the change and the mark commit or roll back together.
+func markArticleIndexDirtyTx(ctx context.Context, tx pgx.Tx, articleID uuid.UUID, reason domain.ArticleIndexReason, now time.Time) error {
+	if _, err := tx.Exec(ctx, markArticleIndexDirtyQuery, articleID, string(reason), now.UTC()); err != nil {
+		return fmt.Errorf("mark article for reindex: %w", err)
+	}
+	return nil
+}
+
+// MarkArticleIndexDirty queues a live article for reindexing. It returns
+// domain.ErrArticleNotFound for unknown or deleted articles.
+func (r *ArticleRepository) MarkArticleIndexDirty(ctx context.Context, articleID uuid.UUID, reason domain.ArticleIndexReason, now time.Time) (*domain.DirtyArticle, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	dirty := &domain.DirtyArticle{}
+	var storedReason string
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO article_index_outbox (article_id, reason, marked_at, attempts, available_at)
+		SELECT id, $2, $3, 0, $3
+		FROM articles
+		WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (article_id) DO UPDATE
+		SET reason = EXCLUDED.reason,
+			marked_at = EXCLUDED.marked_at,
+			attempts = 0,
+			available_at = EXCLUDED.available_at,
+			last_error = NULL
+		RETURNING article_id, reason, marked_at, attempts`,
+		articleID, string(reason), now.UTC()).Scan(&dirty.ArticleID, &storedReason, &dirty.MarkedAt, &dirty.Attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrArticleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark article for reindex: %w", err)
+	}
+	dirty.Reason = domain.ArticleIndexReason(storedReason)
+	return dirty, nil
+}
+
+// ListDirtyArticles returns up to limit queued articles that are due at
+// now, oldest mark first.
+func (r *ArticleRepository) ListDirtyArticles(ctx context.Context, now time.Time, limit int) ([]*domain.DirtyArticle, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT article_id, reason, marked_at, attempts
+		FROM article_index_outbox
+		WHERE available_at <= $1
+		ORDER BY marked_at
+		LIMIT $2`, now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dirty articles: %w", err)
+	}
+	defer rows.Close()
+
+	dirty := []*domain.DirtyArticle{}
+	for rows.Next() {
+		var d domain.DirtyArticle
+		var reason string
+		if err := rows.Scan(&d.ArticleID, &reason, &d.MarkedAt, &d.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan dirty article: %w", err)
+		}
+		d.Reason = domain.ArticleIndexReason(reason)
+		dirty = append(dirty, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list dirty articles: %w", err)
+	}
+	return dirty, nil
+}
+
+// FetchArticleIndexDocument loads the indexed fields of a live article, or
+// nil when it was deleted or purged.
+func (r *ArticleRepository) FetchArticleIndexDocument(ctx context.Context, articleID uuid.UUID) (*domain.ArticleIndexDocument, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	var doc domain.ArticleIndexDocument
+	var publishedAt *time.Time
+	var tags []string
+	err := r.pool.QueryRow(ctx, `
+		SELECT a.id::text, a.user_id::text, COALESCE(a.feed_id::text, ''),
+			   a.title, a.url, a.content, a.published_at,
+			   COALESCE(
+				   array_agg(t.tag_name ORDER BY t.tag_name) FILTER (WHERE t.tag_name IS NOT NULL),
+				   '{}'
+			   )
+		FROM articles a
+		LEFT JOIN article_tags at ON a.id = at.article_id
+		LEFT JOIN feed_tags t ON at.feed_tag_id = t.id
+		WHERE a.id = $1 AND a.deleted_at IS NULL
+		GROUP BY a.id`, articleID).Scan(
+		&doc.ArticleID, &doc.UserID, &doc.FeedID,
+		&doc.Title, &doc.URL, &doc.Content, &publishedAt, &tags)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch article for reindex: %w", err)
+	}
+	if publishedAt != nil {
+		doc.PublishedAt = *publishedAt
+	}
+	doc.Tags = filterEmptyStrings(tags)
+	return &doc, nil
+}
+
+// ClearDirtyArticle removes an article from the queue unless it was marked
+// again after markedAt, in which case the newer change is still published.
+func (r *ArticleRepository) ClearDirtyArticle(ctx context.Context, articleID uuid.UUID, markedAt time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	if _, err := r.pool.Exec(ctx, `
+		DELETE FROM article_index_outbox
+		WHERE article_id = $1 AND marked_at = $2`, articleID, markedAt); err != nil {
+		return fmt.Errorf("failed to clear dirty article: %w", err)
+	}
+	return nil
+}
+
+// RescheduleDirtyArticle counts a failed publish and keeps the article
+// queued until retryAt. An article marked again after markedAt is left due.
+func (r *ArticleRepository) RescheduleDirtyArticle(ctx context.Context, articleID uuid.UUID, markedAt time.Time, errorMessage string, retryAt time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	if _, err := r.pool.Exec(ctx, `
+		UPDATE article_index_outbox
+		SET attempts = attempts + 1, available_at = $2, last_error = $3
+		WHERE article_id = $1 AND marked_at = $4`, articleID, retryAt.UTC(), errorMessage, markedAt); err != nil {
+		return fmt.Errorf("failed to reschedule dirty article: %w", err)
+	}
+	return nil
+}
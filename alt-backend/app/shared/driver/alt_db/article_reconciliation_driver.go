@@ -0,0 +1,292 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const sourceRecordColumns = `id, source, source_item_id, COALESCE(guid, ''), user_id, url, normalized_url,
+	COALESCE(title, ''), COALESCE(author, ''), published_at, article_id, status,
+	COALESCE(conflict_reason, ''), first_seen_at, reconciled_at`
+
+// sourceRecordChanged is true in an upsert when the incoming record points
+// somewhere else than the stored one.
+const sourceRecordChanged = `(article_source_records.normalized_url IS DISTINCT FROM EXCLUDED.normalized_url
+		OR article_source_records.guid IS DISTINCT FROM EXCLUDED.guid)`
+
+// upsertSourceRecordsQuery inserts a batch of records passed as parallel
+// arrays. A record whose normalized URL or GUID changed is reset to pending
+// so the next run matches it again; otherwise its state is left alone.
+const upsertSourceRecordsQuery = `
+	INSERT INTO article_source_records (
+		source, source_item_id, guid, user_id, url, normalized_url,
+		title, author, published_at, status, first_seen_at, updated_at)
+	SELECT s.source, s.item_id, NULLIF(s.guid, ''), s.user_id, s.url, s.normalized_url,
+		NULLIF(s.title, ''), NULLIF(s.author, ''), s.published_at, 'pending', $10, $10
+	FROM unnest($1::text[], $2::text[], $3::text[], $4::uuid[], $5::text[], $6::text[],
+		$7::text[], $8::text[], $9::timestamptz[])
+		AS s(source, item_id, guid, user_id, url, normalized_url, title, author, published_at)
+	ON CONFLICT (source, source_item_id) DO UPDATE SET
+		status = CASE WHEN ` + sourceRecordChanged + ` THEN 'pending' ELSE article_source_records.status END,
+		article_id = CASE WHEN ` + sourceRecordChanged + ` THEN NULL ELSE article_source_records.article_id END,
+		conflict_reason = CASE WHEN ` + sourceRecordChanged + ` THEN NULL ELSE article_source_records.conflict_reason END,
+		guid = EXCLUDED.guid,
+		user_id = EXCLUDED.user_id,
+		url = EXCLUDED.url,
+		normalized_url = EXCLUDED.normalized_url,
+		title = EXCLUDED.title,
+		author = EXCLUDED.author,
+		published_at = EXCLUDED.published_at,
+		updated_at = EXCLUDED.updated_at`
+
+// UpsertArticleSourceRecords stores records reported by an ingest source.
+// Callers must not pass two records with the same (source, source_item_id).
+func (r *ArticleRepository) UpsertArticleSourceRecords(ctx context.Context, records []*domain.ArticleSourceRecord, now time.Time) (int64, error) {
+	if r == nil || r.pool == nil {
+		return 0, errors.New("database connection not available")
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	n := len(records)
+	sources, itemIDs, guids := make([]string, n), make([]string, n), make([]string, n)
+	userIDs := make([]uuid.UUID, n)
+	urls, normalized := make([]string, n), make([]string, n)
+	titles, authors := make([]string, n), make([]string, n)
+	publishedAt := make([]*time.Time, n)
+	for i, rec := range records {
+		sources[i], itemIDs[i], guids[i] = rec.Source, rec.SourceItemID, rec.GUID
+		userIDs[i], urls[i], normalized[i] = rec.UserID, rec.URL, rec.NormalizedURL
+		titles[i], authors[i], publishedAt[i] = rec.Title, rec.Author, rec.PublishedAt
+	}
+
+	tag, err := r.pool.Exec(ctx, upsertSourceRecordsQuery,
+		sources, itemIDs, guids, userIDs, urls, normalized, titles, authors, publishedAt, now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert article source records: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ListUnreconciledSourceRecords returns up to limit pending or orphaned
+// records, least recently reconciled first, so orphans are rechecked
+// without starving new records.
+func (r *ArticleRepository) ListUnreconciledSourceRecords(ctx context.Context, limit int) ([]*domain.ArticleSourceRecord, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `SELECT ` + sourceRecordColumns + `
+		FROM article_source_records
+		WHERE status IN ('pending', 'orphaned')
+		ORDER BY reconciled_at NULLS FIRST, first_seen_at
+		LIMIT $1`
+
+	rows, err := r.pool.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unreconciled source records: %w", err)
+	}
+	return collectSourceRecords(rows)
+}
+
+// FindReconciliationCandidates returns userID's live articles matching a
+// URL in urls or already matched to another record with the same GUID.
+// Articles linked as duplicates are replaced by their canonical article.
+func (r *ArticleRepository) FindReconciliationCandidates(ctx context.Context, userID uuid.UUID, urls []string, guid string) ([]*domain.ReconciliationCandidate, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `
+		WITH hits AS (
+			SELECT a.id FROM articles a
+			WHERE a.user_id = $1 AND a.url = ANY($2) AND a.deleted_at IS NULL
+			UNION
+			SELECT rec.article_id FROM article_source_records rec
+			WHERE $3 <> '' AND rec.user_id = $1 AND rec.guid = $3 AND rec.article_id IS NOT NULL
+		), resolved AS (
+			SELECT DISTINCT COALESCE(d.canonical_article_id, h.id) AS id
+			FROM hits h
+			LEFT JOIN article_duplicate_links d ON d.duplicate_article_id = h.id
+		)
+		SELECT a.id, a.feed_id, a.url, a.title, a.published_at, a.created_at
+		FROM articles a
+		JOIN resolved ON resolved.id = a.id
+		WHERE a.deleted_at IS NULL
+		ORDER BY a.created_at, a.id`
+
+	rows, err := r.pool.Query(ctx, query, userID, urls, guid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reconciliation candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []*domain.ReconciliationCandidate{}
+	for rows.Next() {
+		var c domain.ReconciliationCandidate
+		if err := rows.Scan(&c.ArticleID, &c.FeedID, &c.URL, &c.Title, &c.PublishedAt, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation candidate: %w", err)
+		}
+		candidates = append(candidates, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find reconciliation candidates: %w", err)
+	}
+	return candidates, nil
+}
+
+// ApplyReconciliationOutcome links duplicates to the canonical article,
+// fills the canonical's blank title or missing published_at, and records
+// the outcome on the source record, all in one transaction. Existing
+// article metadata is never overwritten; an article that gained metadata
+// is marked for reindexing.
+func (r *ArticleRepository) ApplyReconciliationOutcome(ctx context.Context, outcome *domain.ReconciliationOutcome, now time.Time) (err error) {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+	now = now.UTC()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	for _, link := range outcome.Duplicates {
+		if _, err = tx.Exec(ctx, `
+			INSERT INTO article_duplicate_links (duplicate_article_id, canonical_article_id, match_key, linked_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (duplicate_article_id) DO UPDATE
+			SET canonical_article_id = EXCLUDED.canonical_article_id,
+				match_key = EXCLUDED.match_key,
+				linked_at = EXCLUDED.linked_at`,
+			link.DuplicateArticleID, link.CanonicalArticleID, link.MatchKey, now); err != nil {
+			return fmt.Errorf("link duplicate article: %w", err)
+		}
+	}
+
+	if outcome.ArticleID != nil && (outcome.MergeTitle != "" || outcome.MergePublishedAt != nil) {
+		var tag pgconn.CommandTag
+		if tag, err = tx.Exec(ctx, `
+			UPDATE articles
+			SET title = CASE WHEN btrim(title) = '' AND $2 <> '' THEN $2 ELSE title END,
+				published_at = COALESCE(published_at, $3)
+			WHERE id = $1
+			  AND ((btrim(title) = '' AND $2 <> '') OR (published_at IS NULL AND $3 IS NOT NULL))`,
+			*outcome.ArticleID, outcome.MergeTitle, outcome.MergePublishedAt); err != nil {
+			return fmt.Errorf("merge article metadata: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			if err = markArticleIndexDirtyTx(ctx, tx, *outcome.ArticleID, domain.ArticleIndexReconciled, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `
+		UPDATE article_source_records
+		SET status = $2, article_id = $3, conflict_reason = NULLIF($4, ''),
+			reconciled_at = $5, updated_at = $5
+		WHERE id = $1`,
+		outcome.RecordID, string(outcome.Status), outcome.ArticleID, outcome.ConflictReason, now); err != nil {
+		return fmt.Errorf("update source record: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// FetchReconciliationReport returns record counts per source and status,
+// the number of duplicate links, and up to limit records in statuses,
+// most recently seen first.
+func (r *ArticleRepository) FetchReconciliationReport(ctx context.Context, statuses []domain.ArticleSourceStatus, limit int) (*domain.ReconciliationReport, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	report := &domain.ReconciliationReport{
+		Counts:  []domain.ReconciliationStatusCount{},
+		Records: []*domain.ArticleSourceRecord{},
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT source, status, COUNT(*)
+		FROM article_source_records
+		GROUP BY source, status
+		ORDER BY source, status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count source records: %w", err)
+	}
+	for rows.Next() {
+		var count domain.ReconciliationStatusCount
+		var status string
+		if err := rows.Scan(&count.Source, &status, &count.Count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan source record count: %w", err)
+		}
+		count.Status = domain.ArticleSourceStatus(status)
+		report.Counts = append(report.Counts, count)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count source records: %w", err)
+	}
+
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM article_duplicate_links`).Scan(&report.DuplicateLinks); err != nil {
+		return nil, fmt.Errorf("failed to count duplicate links: %w", err)
+	}
+
+	if len(statuses) == 0 || limit <= 0 {
+		return report, nil
+	}
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	recordRows, err := r.pool.Query(ctx, `SELECT `+sourceRecordColumns+`
+		FROM article_source_records
+		WHERE status = ANY($1)
+		ORDER BY first_seen_at DESC
+		LIMIT $2`, names, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source records: %w", err)
+	}
+	report.Records, err = collectSourceRecords(recordRows)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func collectSourceRecords(rows pgx.Rows) ([]*domain.ArticleSourceRecord, error) {
+	defer rows.Close()
+
+	records := []*domain.ArticleSourceRecord{}
+	for rows.Next() {
+		var rec domain.ArticleSourceRecord
+		var status string
+		if err := rows.Scan(
+			&rec.ID, &rec.Source, &rec.SourceItemID, &rec.GUID, &rec.UserID, &rec.URL, &rec.NormalizedURL,
+			&rec.Title, &rec.Author, &rec.PublishedAt, &rec.ArticleID, &status,
+			&rec.ConflictReason, &rec.FirstSeenAt, &rec.ReconciledAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan source record: %w", err)
+		}
+		rec.Status = domain.ArticleSourceStatus(status)
+		records = append(records, &rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read source records: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,87 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyReconciliationOutcome_LinksMergesAndUpdatesInOneTx(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	published := now.Add(-time.Hour)
+	canonical, duplicate, recordID := uuid.New(), uuid.New(), uuid.New()
+	outcome := &domain.ReconciliationOutcome{
+		RecordID:         recordID,
+		Status:           domain.ArticleSourceMatched,
+		ArticleID:        &canonical,
+		MergeTitle:       "Title",
+		MergePublishedAt: &published,
+		Duplicates: []domain.ArticleDuplicateLink{
+			{CanonicalArticleID: canonical, DuplicateArticleID: duplicate, MatchKey: "url:https://example.com/a"},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO article_duplicate_links`).
+		WithArgs(duplicate, canonical, "url:https://example.com/a", now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE articles\s+SET title = CASE WHEN btrim\(title\) = ''`).
+		WithArgs(canonical, "Title", &published).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO article_index_outbox`).
+		WithArgs(canonical, "reconciled", now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE article_source_records`).
+		WithArgs(recordID, "matched", &canonical, "", now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.ApplyReconciliationOutcome(context.Background(), outcome, now))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyReconciliationOutcome_RollsBackOnFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	recordID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE article_source_records`).
+		WithArgs(recordID, "orphaned", (*uuid.UUID)(nil), "", now).
+		WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+
+	err = repo.ApplyReconciliationOutcome(context.Background(), &domain.ReconciliationOutcome{
+		RecordID: recordID,
+		Status:   domain.ArticleSourceOrphaned,
+	}, now)
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertArticleSourceRecords_Empty(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	n, err := repo.UpsertArticleSourceRecords(context.Background(), nil, time.Now())
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,176 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const shareLinkColumns = `id, article_id, user_id, fields, created_at, expires_at, revoked_at, view_count, last_viewed_at`
+
+// CreateArticleShareLink stores a share link for one of link.UserID's live
+// articles. The ownership check and the insert are one statement, so a
+// link can never point at someone else's article.
+func (r *ArticleRepository) CreateArticleShareLink(ctx context.Context, link *domain.ArticleShareLink) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	query := `
+		INSERT INTO article_share_links (id, article_id, user_id, fields, created_at, expires_at)
+		SELECT $1, a.id, $3, $4, $5, $6
+		FROM articles a
+		WHERE a.id = $2 AND a.user_id = $3 AND a.deleted_at IS NULL`
+
+	tag, err := r.pool.Exec(ctx, query,
+		link.ID, link.ArticleID, link.UserID, shareFieldsToStrings(link.Fields), link.CreatedAt, link.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrItemNotFound
+	}
+	return nil
+}
+
+// ListArticleShareLinks returns userID's links for an article, newest
+// first, including expired and revoked ones.
+func (r *ArticleRepository) ListArticleShareLinks(ctx context.Context, articleID, userID uuid.UUID) ([]*domain.ArticleShareLink, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `SELECT ` + shareLinkColumns + `
+		FROM article_share_links
+		WHERE article_id = $1 AND user_id = $2
+		ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, articleID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*domain.ArticleShareLink{}
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share link: %w", err)
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	return links, nil
+}
+
+// RevokeArticleShareLink revokes one of userID's links. The first
+// revocation time is kept if the link is revoked again.
+func (r *ArticleRepository) RevokeArticleShareLink(ctx context.Context, shareID, articleID, userID uuid.UUID, now time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	query := `
+		UPDATE article_share_links
+		SET revoked_at = COALESCE(revoked_at, $4)
+		WHERE id = $1 AND article_id = $2 AND user_id = $3`
+
+	tag, err := r.pool.Exec(ctx, query, shareID, articleID, userID, now.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrShareLinkNotFound
+	}
+	return nil
+}
+
+// RecordShareLinkView counts a view of an active link and returns it. The
+// activity check and the increment are one statement, so a link revoked
+// concurrently is never counted.
+func (r *ArticleRepository) RecordShareLinkView(ctx context.Context, shareID uuid.UUID, now time.Time) (*domain.ArticleShareLink, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+	now = now.UTC()
+
+	query := `
+		UPDATE article_share_links
+		SET view_count = view_count + 1, last_viewed_at = $2
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2
+		RETURNING ` + shareLinkColumns
+
+	link, err := scanShareLink(r.pool.QueryRow(ctx, query, shareID, now))
+	if err == nil {
+		return link, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to record share link view: %w", err)
+	}
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM article_share_links WHERE id = $1)`, shareID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to look up share link: %w", err)
+	}
+	if exists {
+		return nil, domain.ErrShareLinkGone
+	}
+	return nil, domain.ErrShareLinkNotFound
+}
+
+// FetchSharedArticle loads a live article owned by ownerID together with
+// its summary, if any.
+func (r *ArticleRepository) FetchSharedArticle(ctx context.Context, articleID, ownerID uuid.UUID) (*domain.SharedArticle, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `
+		SELECT a.title, a.url, a.published_at, a.content, COALESCE(s.summary_japanese, '')
+		FROM articles a
+		LEFT JOIN article_summaries s ON s.article_id = a.id
+		WHERE a.id = $1 AND a.user_id = $2 AND a.deleted_at IS NULL`
+
+	var article domain.SharedArticle
+	err := r.pool.QueryRow(ctx, query, articleID, ownerID).Scan(
+		&article.Title, &article.URL, &article.PublishedAt, &article.Content, &article.Summary,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrShareLinkGone
+		}
+		return nil, fmt.Errorf("failed to fetch shared article: %w", err)
+	}
+	return &article, nil
+}
+
+func scanShareLink(row pgx.Row) (*domain.ArticleShareLink, error) {
+	var link domain.ArticleShareLink
+	var fields []string
+	if err := row.Scan(
+		&link.ID, &link.ArticleID, &link.UserID, &fields, &link.CreatedAt, &link.ExpiresAt,
+		&link.RevokedAt, &link.ViewCount, &link.LastViewedAt,
+	); err != nil {
+		return nil, err
+	}
+	link.Fields = make([]domain.ShareField, len(fields))
+	for i, f := range fields {
+		link.Fields[i] = domain.ShareField(f)
+	}
+	return &link, nil
+}
+
+func shareFieldsToStrings(fields []domain.ShareField) []string {
+	out := make([]string, len(fields))
+	for i, f := range fields {
+		out[i] = string(f)
+	}
+	return out
+}
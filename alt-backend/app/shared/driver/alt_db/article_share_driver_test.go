@@ -0,0 +1,71 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateArticleShareLink_NotOwned(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	link := &domain.ArticleShareLink{
+		ID:        uuid.New(),
+		ArticleID: uuid.New(),
+		UserID:    uuid.New(),
+		Fields:    []domain.ShareField{domain.ShareFieldTitle},
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	mock.ExpectExec(`INSERT INTO article_share_links`).
+		WithArgs(link.ID, link.ArticleID, link.UserID, []string{"title"}, link.CreatedAt, link.ExpiresAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+	err = repo.CreateArticleShareLink(context.Background(), link)
+	require.ErrorIs(t, err, domain.ErrItemNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordShareLinkView_DistinguishesGoneFromUnknown(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name   string
+		exists bool
+		want   error
+	}{
+		{name: "expired or revoked", exists: true, want: domain.ErrShareLinkGone},
+		{name: "unknown", exists: false, want: domain.ErrShareLinkNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			repo := &ArticleRepository{pool: mock}
+			shareID := uuid.New()
+
+			mock.ExpectQuery(`UPDATE article_share_links\s+SET view_count = view_count \+ 1`).
+				WithArgs(shareID, now).
+				WillReturnError(pgx.ErrNoRows)
+			mock.ExpectQuery(`SELECT EXISTS`).
+				WithArgs(shareID).
+				WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(tc.exists))
+
+			_, err = repo.RecordShareLinkView(context.Background(), shareID, now)
+			require.ErrorIs(t, err, tc.want)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
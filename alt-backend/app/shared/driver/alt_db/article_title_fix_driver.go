@@ -0,0 +1,277 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const articleTitleFixJobColumns = `job_id, status, requested_by, cursor_article_id, total_articles,
+	processed_count, updated_count, failed_count, skipped_count,
+	created_at, started_at, completed_at, updated_at`
+
+// urlTitlePredicate selects live articles whose stored title is their URL.
+const urlTitlePredicate = `title ~ '^https?://' AND deleted_at IS NULL`
+
+// CreateArticleTitleFixJob inserts a pending job along with the number of
+// articles it will look at. The partial unique index on pending and running
+// jobs rejects a second active job.
+func (r *ArticleRepository) CreateArticleTitleFixJob(ctx context.Context, requestedBy *uuid.UUID, now time.Time) (*domain.ArticleTitleFixJob, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO article_title_fix_jobs (status, requested_by, total_articles, created_at, updated_at)
+		SELECT 'pending', $1, COUNT(*), $2, $2
+		FROM articles
+		WHERE `+urlTitlePredicate+`
+		RETURNING `+articleTitleFixJobColumns,
+		requestedBy, now.UTC())
+	job, err := scanArticleTitleFixJob(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.ErrArticleTitleFixJobActive
+		}
+		return nil, fmt.Errorf("failed to create article title fix job: %w", err)
+	}
+	return job, nil
+}
+
+// GetActiveArticleTitleFixJob returns the pending or running job, or nil.
+func (r *ArticleRepository) GetActiveArticleTitleFixJob(ctx context.Context) (*domain.ArticleTitleFixJob, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	row := r.pool.QueryRow(ctx, `SELECT `+articleTitleFixJobColumns+`
+		FROM article_title_fix_jobs
+		WHERE status IN ('pending', 'running')
+		ORDER BY created_at
+		LIMIT 1`)
+	job, err := scanArticleTitleFixJob(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active article title fix job: %w", err)
+	}
+	return job, nil
+}
+
+// GetArticleTitleFixJob returns one job by ID.
+func (r *ArticleRepository) GetArticleTitleFixJob(ctx context.Context, jobID uuid.UUID) (*domain.ArticleTitleFixJob, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	row := r.pool.QueryRow(ctx, `SELECT `+articleTitleFixJobColumns+`
+		FROM article_title_fix_jobs
+		WHERE job_id = $1`, jobID)
+	job, err := scanArticleTitleFixJob(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrArticleTitleFixJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get article title fix job: %w", err)
+	}
+	return job, nil
+}
+
+// ListArticleTitleFixJobs returns up to limit jobs, newest first.
+func (r *ArticleRepository) ListArticleTitleFixJobs(ctx context.Context, limit int) ([]*domain.ArticleTitleFixJob, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `SELECT `+articleTitleFixJobColumns+`
+		FROM article_title_fix_jobs
+		ORDER BY created_at DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list article title fix jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*domain.ArticleTitleFixJob{}
+	for rows.Next() {
+		job, err := scanArticleTitleFixJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan article title fix job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list article title fix jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// UpdateArticleTitleFixJob saves the job's status and timestamps. Progress
+// is saved by RecordArticleTitleFixResult.
+func (r *ArticleRepository) UpdateArticleTitleFixJob(ctx context.Context, job *domain.ArticleTitleFixJob) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE article_title_fix_jobs
+		SET status = $2, started_at = $3, completed_at = $4, updated_at = $5
+		WHERE job_id = $1`,
+		job.JobID, string(job.Status), job.StartedAt, job.CompletedAt, job.UpdatedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to update article title fix job: %w", err)
+	}
+	return nil
+}
+
+// ListArticleTitleFixCandidates returns up to limit articles whose title is
+// a URL, in id order, after afterID.
+func (r *ArticleRepository) ListArticleTitleFixCandidates(ctx context.Context, afterID *uuid.UUID, limit int) ([]*domain.ArticleTitleFixCandidate, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, url, title
+		FROM articles
+		WHERE `+urlTitlePredicate+`
+			AND ($1::uuid IS NULL OR id > $1)
+		ORDER BY id
+		LIMIT $2`, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list article title fix candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []*domain.ArticleTitleFixCandidate{}
+	for rows.Next() {
+		var c domain.ArticleTitleFixCandidate
+		if err := rows.Scan(&c.ArticleID, &c.URL, &c.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan article title fix candidate: %w", err)
+		}
+		candidates = append(candidates, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list article title fix candidates: %w", err)
+	}
+	return candidates, nil
+}
+
+// RecordArticleTitleFixResult applies an updated title, stores the result
+// and advances the job's cursor and counters in one transaction, so a
+// restart never repeats or loses an article. The title is only replaced
+// while it still equals the URL title the job read; a replaced title marks
+// the article for reindexing.
+func (r *ArticleRepository) RecordArticleTitleFixResult(ctx context.Context, job *domain.ArticleTitleFixJob, result *domain.ArticleTitleFixResult) (err error) {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	if result.Outcome == domain.ArticleTitleFixUpdated {
+		var tag pgconn.CommandTag
+		if tag, err = tx.Exec(ctx, `
+			UPDATE articles SET title = $2
+			WHERE id = $1 AND title = $3`,
+			result.ArticleID, result.NewTitle, result.OldTitle); err != nil {
+			return fmt.Errorf("update article title: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			if err = markArticleIndexDirtyTx(ctx, tx, result.ArticleID, domain.ArticleIndexTitleFixed, result.ProcessedAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err = tx.Exec(ctx, `
+		INSERT INTO article_title_fix_results
+			(job_id, article_id, url, old_title, new_title, outcome, detail, processed_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''), $8)
+		ON CONFLICT (job_id, article_id) DO UPDATE
+		SET new_title = EXCLUDED.new_title,
+			outcome = EXCLUDED.outcome,
+			detail = EXCLUDED.detail,
+			processed_at = EXCLUDED.processed_at`,
+		result.JobID, result.ArticleID, result.URL, result.OldTitle, result.NewTitle,
+		string(result.Outcome), result.Detail, result.ProcessedAt.UTC()); err != nil {
+		return fmt.Errorf("record article title fix result: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, `
+		UPDATE article_title_fix_jobs
+		SET cursor_article_id = $2, processed_count = $3, updated_count = $4,
+			failed_count = $5, skipped_count = $6, updated_at = $7
+		WHERE job_id = $1`,
+		job.JobID, job.CursorArticleID, job.ProcessedCount, job.UpdatedCount,
+		job.FailedCount, job.SkippedCount, job.UpdatedAt.UTC()); err != nil {
+		return fmt.Errorf("save article title fix progress: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// ListArticleTitleFixResults returns up to limit results of the job with
+// the given outcome, in processing order.
+func (r *ArticleRepository) ListArticleTitleFixResults(ctx context.Context, jobID uuid.UUID, outcome domain.ArticleTitleFixOutcome, limit int) ([]*domain.ArticleTitleFixResult, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT job_id, article_id, url, old_title, COALESCE(new_title, ''), outcome,
+			COALESCE(detail, ''), processed_at
+		FROM article_title_fix_results
+		WHERE job_id = $1 AND outcome = $2
+		ORDER BY processed_at, article_id
+		LIMIT $3`, jobID, string(outcome), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list article title fix results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*domain.ArticleTitleFixResult{}
+	for rows.Next() {
+		var res domain.ArticleTitleFixResult
+		var outcomeName string
+		if err := rows.Scan(&res.JobID, &res.ArticleID, &res.URL, &res.OldTitle, &res.NewTitle,
+			&outcomeName, &res.Detail, &res.ProcessedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan article title fix result: %w", err)
+		}
+		res.Outcome = domain.ArticleTitleFixOutcome(outcomeName)
+		results = append(results, &res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list article title fix results: %w", err)
+	}
+	return results, nil
+}
+
+func scanArticleTitleFixJob(row pgx.Row) (*domain.ArticleTitleFixJob, error) {
+	var job domain.ArticleTitleFixJob
+	var status string
+	if err := row.Scan(
+		&job.JobID, &status, &job.RequestedBy, &job.CursorArticleID, &job.TotalArticles,
+		&job.ProcessedCount, &job.UpdatedCount, &job.FailedCount, &job.SkippedCount, &job.CreatedAt,
+		&job.StartedAt, &job.CompletedAt, &job.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	job.Status = domain.ArticleTitleFixStatus(status)
+	return &job, nil
+}
@@ -0,0 +1,145 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordArticleTitleFixResult_UpdatesTitleAndProgressInOneTx(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	jobID, articleID := uuid.New(), uuid.New()
+	job := &domain.ArticleTitleFixJob{
+		JobID:           jobID,
+		Status:          domain.ArticleTitleFixRunning,
+		CursorArticleID: &articleID,
+		ProcessedCount:  3,
+		UpdatedCount:    2,
+		SkippedCount:    1,
+		UpdatedAt:       now,
+	}
+	result := &domain.ArticleTitleFixResult{
+		JobID:       jobID,
+		ArticleID:   articleID,
+		URL:         "https://example.com/a",
+		OldTitle:    "https://example.com/a",
+		NewTitle:    "A real title",
+		Outcome:     domain.ArticleTitleFixUpdated,
+		ProcessedAt: now,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE articles SET title = \$2\s+WHERE id = \$1 AND title = \$3`).
+		WithArgs(articleID, "A real title", "https://example.com/a").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO article_index_outbox`).
+		WithArgs(articleID, "title_fixed", now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`INSERT INTO article_title_fix_results`).
+		WithArgs(jobID, articleID, "https://example.com/a", "https://example.com/a", "A real title", "updated", "", now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE article_title_fix_jobs`).
+		WithArgs(jobID, &articleID, 3, 2, 0, 1, now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.RecordArticleTitleFixResult(context.Background(), job, result))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordArticleTitleFixResult_ChangedTitleIsNotMarkedForReindex(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	jobID, articleID := uuid.New(), uuid.New()
+	job := &domain.ArticleTitleFixJob{JobID: jobID, CursorArticleID: &articleID, ProcessedCount: 1, UpdatedCount: 1, UpdatedAt: now}
+	result := &domain.ArticleTitleFixResult{
+		JobID:       jobID,
+		ArticleID:   articleID,
+		URL:         "https://example.com/c",
+		OldTitle:    "https://example.com/c",
+		NewTitle:    "C",
+		Outcome:     domain.ArticleTitleFixUpdated,
+		ProcessedAt: now,
+	}
+
+	// The title changed since the job read it, so no row is updated and
+	// the article is not queued for reindexing.
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE articles SET title = \$2`).
+		WithArgs(articleID, "C", "https://example.com/c").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec(`INSERT INTO article_title_fix_results`).
+		WithArgs(jobID, articleID, "https://example.com/c", "https://example.com/c", "C", "updated", "", now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE article_title_fix_jobs`).
+		WithArgs(jobID, &articleID, 1, 1, 0, 0, now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.RecordArticleTitleFixResult(context.Background(), job, result))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordArticleTitleFixResult_FailedLeavesTitleAlone(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	jobID, articleID := uuid.New(), uuid.New()
+	job := &domain.ArticleTitleFixJob{JobID: jobID, CursorArticleID: &articleID, ProcessedCount: 1, FailedCount: 1, UpdatedAt: now}
+	result := &domain.ArticleTitleFixResult{
+		JobID:       jobID,
+		ArticleID:   articleID,
+		URL:         "https://example.com/b",
+		OldTitle:    "https://example.com/b",
+		Outcome:     domain.ArticleTitleFixFailed,
+		Detail:      "status 404",
+		ProcessedAt: now,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO article_title_fix_results`).
+		WithArgs(jobID, articleID, "https://example.com/b", "https://example.com/b", "", "failed", "status 404", now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`UPDATE article_title_fix_jobs`).
+		WithArgs(jobID, &articleID, 1, 0, 1, 0, now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.RecordArticleTitleFixResult(context.Background(), job, result))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateArticleTitleFixJob_RejectsSecondActiveJob(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO article_title_fix_jobs`).
+		WithArgs((*uuid.UUID)(nil), now).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	_, err = repo.CreateArticleTitleFixJob(context.Background(), nil, now)
+	require.ErrorIs(t, err, domain.ErrArticleTitleFixJobActive)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,98 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AcquireBackgroundJobLease takes the run lease of a job until expiresAt and
+// records the run's start. It returns false when another owner holds an
+// unexpired lease.
+func (r *BackgroundJobRepository) AcquireBackgroundJobLease(ctx context.Context, name, owner string, now, expiresAt time.Time) (bool, error) {
+	if r == nil || r.pool == nil {
+		return false, errors.New("database connection not available")
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO background_job_states (name, lease_owner, lease_expires_at, last_started_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (name) DO UPDATE
+		SET lease_owner = EXCLUDED.lease_owner,
+			lease_expires_at = EXCLUDED.lease_expires_at,
+			last_started_at = EXCLUDED.last_started_at,
+			updated_at = EXCLUDED.updated_at
+		WHERE background_job_states.lease_expires_at IS NULL
+			OR background_job_states.lease_expires_at <= EXCLUDED.updated_at`,
+		name, owner, expiresAt.UTC(), now.UTC())
+	if err != nil {
+		return false, fmt.Errorf("acquire background job lease: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FinishBackgroundJobRun records the outcome of a run and releases the lease
+// if owner still holds it. runErr is nil for a successful run.
+func (r *BackgroundJobRepository) FinishBackgroundJobRun(ctx context.Context, name, owner string, startedAt, finishedAt time.Time, runErr *string) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO background_job_states (name, last_started_at, last_finished_at, last_duration_ms,
+			last_error, last_success_at, run_count, failure_count, updated_at)
+		VALUES ($1, $3, $4, $5, $6::text,
+			CASE WHEN $6::text IS NULL THEN $4::timestamptz END,
+			1, CASE WHEN $6::text IS NULL THEN 0 ELSE 1 END, $4)
+		ON CONFLICT (name) DO UPDATE
+		SET last_started_at = EXCLUDED.last_started_at,
+			last_finished_at = EXCLUDED.last_finished_at,
+			last_duration_ms = EXCLUDED.last_duration_ms,
+			last_error = EXCLUDED.last_error,
+			last_success_at = COALESCE(EXCLUDED.last_success_at, background_job_states.last_success_at),
+			run_count = background_job_states.run_count + 1,
+			failure_count = background_job_states.failure_count + EXCLUDED.failure_count,
+			lease_owner = CASE WHEN background_job_states.lease_owner = $2 THEN NULL
+				ELSE background_job_states.lease_owner END,
+			lease_expires_at = CASE WHEN background_job_states.lease_owner = $2 THEN NULL
+				ELSE background_job_states.lease_expires_at END,
+			updated_at = EXCLUDED.updated_at`,
+		name, owner, startedAt.UTC(), finishedAt.UTC(), finishedAt.Sub(startedAt).Milliseconds(), runErr)
+	if err != nil {
+		return fmt.Errorf("finish background job run: %w", err)
+	}
+	return nil
+}
+
+// ListBackgroundJobStates returns the stored state of every job, by name.
+func (r *BackgroundJobRepository) ListBackgroundJobStates(ctx context.Context) ([]domain.BackgroundJobState, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT name, last_started_at, last_finished_at, last_duration_ms, last_error, last_success_at,
+			run_count, failure_count, lease_owner, lease_expires_at
+		FROM background_job_states
+		ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("list background job states: %w", err)
+	}
+	defer rows.Close()
+
+	var states []domain.BackgroundJobState
+	for rows.Next() {
+		var s domain.BackgroundJobState
+		if err := rows.Scan(&s.Name, &s.LastStartedAt, &s.LastFinishedAt, &s.LastDurationMs, &s.LastError,
+			&s.LastSuccessAt, &s.RunCount, &s.FailureCount, &s.LeaseOwner, &s.LeaseExpiresAt); err != nil {
+			return nil, fmt.Errorf("scan background job state: %w", err)
+		}
+		states = append(states, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list background job states: %w", err)
+	}
+	return states, nil
+}
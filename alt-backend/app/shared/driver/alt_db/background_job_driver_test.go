@@ -0,0 +1,84 @@
+package alt_db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireBackgroundJobLease(t *testing.T) {
+	now := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+	expires := now.Add(31 * time.Minute)
+
+	for name, tc := range map[string]struct {
+		rows int64
+		want bool
+	}{
+		"free":  {rows: 1, want: true},
+		"taken": {rows: 0, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			repo := &BackgroundJobRepository{pool: mock}
+			mock.ExpectExec(`INSERT INTO background_job_states`).
+				WithArgs("hourly-feed-collector", "pod-a", expires, now).
+				WillReturnResult(pgxmock.NewResult("INSERT", tc.rows))
+
+			got, err := repo.AcquireBackgroundJobLease(context.Background(), "hourly-feed-collector", "pod-a", now, expires)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFinishBackgroundJobRun(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &BackgroundJobRepository{pool: mock}
+	started := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+	finished := started.Add(1500 * time.Millisecond)
+	runErr := "fetch rss feed urls: timeout"
+
+	mock.ExpectExec(`ON CONFLICT \(name\) DO UPDATE`).
+		WithArgs("hourly-feed-collector", "pod-a", started, finished, int64(1500), &runErr).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.FinishBackgroundJobRun(context.Background(), "hourly-feed-collector", "pod-a", started, finished, &runErr))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListBackgroundJobStates(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &BackgroundJobRepository{pool: mock}
+	at := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+	owner := "pod-a"
+	runErr := "boom"
+
+	mock.ExpectQuery(`FROM background_job_states`).
+		WillReturnRows(pgxmock.NewRows([]string{"name", "last_started_at", "last_finished_at", "last_duration_ms", "last_error",
+			"last_success_at", "run_count", "failure_count", "lease_owner", "lease_expires_at"}).
+			AddRow("hourly-feed-collector", &at, &at, int64(1200), (*string)(nil), &at, int64(5), int64(0), (*string)(nil), (*time.Time)(nil)).
+			AddRow("outbox-worker", &at, (*time.Time)(nil), int64(0), &runErr, (*time.Time)(nil), int64(3), int64(3), &owner, &at))
+
+	states, err := repo.ListBackgroundJobStates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.Equal(t, int64(5), states[0].RunCount)
+	require.Nil(t, states[0].LastError)
+	require.Equal(t, "boom", *states[1].LastError)
+	require.True(t, states[1].LeaseActive(at.Add(-time.Second)))
+	require.False(t, states[1].LeaseActive(at))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
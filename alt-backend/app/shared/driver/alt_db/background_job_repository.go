@@ -0,0 +1,14 @@
+package alt_db
+
+// BackgroundJobRepository handles the persisted state and leases of the
+// background job scheduler.
+type BackgroundJobRepository struct {
+	pool PgxIface
+}
+
+func NewBackgroundJobRepository(pool PgxIface) *BackgroundJobRepository {
+	if pool == nil {
+		return nil
+	}
+	return &BackgroundJobRepository{pool: pool}
+}
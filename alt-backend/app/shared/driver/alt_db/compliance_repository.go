@@ -0,0 +1,14 @@
+package alt_db
+
+// ComplianceRepository handles legal holds, the compliance audit log, and
+// per-account data exports.
+type ComplianceRepository struct {
+	pool PgxIface
+}
+
+func NewComplianceRepository(pool PgxIface) *ComplianceRepository {
+	if pool == nil {
+		return nil
+	}
+	return &ComplianceRepository{pool: pool}
+}
@@ -0,0 +1,188 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListFeatureFlags returns every stored flag for environment, by name.
+func (r *FeatureFlagRepository) ListFeatureFlags(ctx context.Context, environment string) ([]domain.FeatureFlag, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT name, environment, enabled, rollout_percentage, description, updated_by, updated_at
+		FROM feature_flags
+		WHERE environment = $1
+		ORDER BY name`, environment)
+	if err != nil {
+		return nil, fmt.Errorf("list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []domain.FeatureFlag
+	for rows.Next() {
+		var (
+			flag      domain.FeatureFlag
+			updatedAt time.Time
+		)
+		if err := rows.Scan(&flag.Name, &flag.Environment, &flag.Enabled, &flag.RolloutPercentage,
+			&flag.Description, &flag.UpdatedBy, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan feature flag: %w", err)
+		}
+		flag.UpdatedAt = &updatedAt
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list feature flags: %w", err)
+	}
+	return flags, nil
+}
+
+// FetchFeatureFlag returns one stored flag, or nil when it does not exist.
+func (r *FeatureFlagRepository) FetchFeatureFlag(ctx context.Context, environment, name string) (*domain.FeatureFlag, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	flag := &domain.FeatureFlag{Name: name, Environment: environment}
+	var updatedAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		SELECT enabled, rollout_percentage, description, updated_by, updated_at
+		FROM feature_flags
+		WHERE environment = $1 AND name = $2`, environment, name).Scan(
+		&flag.Enabled, &flag.RolloutPercentage, &flag.Description, &flag.UpdatedBy, &updatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch feature flag: %w", err)
+	}
+	flag.UpdatedAt = &updatedAt
+	return flag, nil
+}
+
+// UpsertFeatureFlag creates or replaces a flag and sets flag.UpdatedAt.
+// Existing overrides are kept.
+func (r *FeatureFlagRepository) UpsertFeatureFlag(ctx context.Context, flag *domain.FeatureFlag) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	var updatedAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO feature_flags (name, environment, enabled, rollout_percentage, description, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name, environment) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			rollout_percentage = EXCLUDED.rollout_percentage,
+			description = EXCLUDED.description,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at`,
+		flag.Name, flag.Environment, flag.Enabled, flag.RolloutPercentage, flag.Description, flag.UpdatedBy,
+	).Scan(&updatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert feature flag: %w", err)
+	}
+	flag.UpdatedAt = &updatedAt
+	return nil
+}
+
+// DeleteFeatureFlag removes a flag and its overrides, reporting whether the
+// flag existed.
+func (r *FeatureFlagRepository) DeleteFeatureFlag(ctx context.Context, environment, name string) (bool, error) {
+	if r == nil || r.pool == nil {
+		return false, errors.New("database connection not available")
+	}
+
+	tag, err := r.pool.Exec(ctx, `DELETE FROM feature_flags WHERE environment = $1 AND name = $2`, environment, name)
+	if err != nil {
+		return false, fmt.Errorf("delete feature flag: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListFeatureFlagOverrides returns every override for environment, by flag
+// and user.
+func (r *FeatureFlagRepository) ListFeatureFlagOverrides(ctx context.Context, environment string) ([]domain.FeatureFlagOverride, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT flag_name, environment, user_id, enabled, created_by, created_at
+		FROM feature_flag_overrides
+		WHERE environment = $1
+		ORDER BY flag_name, user_id`, environment)
+	if err != nil {
+		return nil, fmt.Errorf("list feature flag overrides: %w", err)
+	}
+	defer rows.Close()
+
+	var overrides []domain.FeatureFlagOverride
+	for rows.Next() {
+		var (
+			o         domain.FeatureFlagOverride
+			createdAt time.Time
+		)
+		if err := rows.Scan(&o.FlagName, &o.Environment, &o.UserID, &o.Enabled, &o.CreatedBy, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan feature flag override: %w", err)
+		}
+		o.CreatedAt = &createdAt
+		overrides = append(overrides, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list feature flag overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// UpsertFeatureFlagOverride sets a user's override and o.CreatedAt. The
+// flag must exist in o.Environment.
+func (r *FeatureFlagRepository) UpsertFeatureFlagOverride(ctx context.Context, o *domain.FeatureFlagOverride) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	var createdAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO feature_flag_overrides (flag_name, environment, user_id, enabled, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (flag_name, environment, user_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			created_by = EXCLUDED.created_by,
+			created_at = NOW()
+		RETURNING created_at`,
+		o.FlagName, o.Environment, o.UserID, o.Enabled, o.CreatedBy,
+	).Scan(&createdAt)
+	if err != nil {
+		return fmt.Errorf("upsert feature flag override: %w", err)
+	}
+	o.CreatedAt = &createdAt
+	return nil
+}
+
+// DeleteFeatureFlagOverride removes a user's override, reporting whether it
+// existed.
+func (r *FeatureFlagRepository) DeleteFeatureFlagOverride(ctx context.Context, environment, name string, userID uuid.UUID) (bool, error) {
+	if r == nil || r.pool == nil {
+		return false, errors.New("database connection not available")
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM feature_flag_overrides
+		WHERE environment = $1 AND flag_name = $2 AND user_id = $3`, environment, name, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete feature flag override: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,79 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFeatureFlags(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeatureFlagRepository{pool: mock}
+	admin := uuid.New()
+	updatedAt := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM feature_flags`).
+		WithArgs("production").
+		WillReturnRows(pgxmock.NewRows([]string{"name", "environment", "enabled", "rollout_percentage", "description", "updated_by", "updated_at"}).
+			AddRow("enable_lens", "production", true, 25, "Lens rollout", &admin, updatedAt).
+			AddRow("new_reader", "production", false, 100, "", (*uuid.UUID)(nil), updatedAt))
+
+	flags, err := repo.ListFeatureFlags(context.Background(), "production")
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+	require.Equal(t, 25, flags[0].RolloutPercentage)
+	require.Equal(t, admin, *flags[0].UpdatedBy)
+	require.Nil(t, flags[1].UpdatedBy)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchFeatureFlag_Missing(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeatureFlagRepository{pool: mock}
+
+	mock.ExpectQuery(`FROM feature_flags`).
+		WithArgs("staging", "enable_lens").
+		WillReturnError(pgx.ErrNoRows)
+
+	flag, err := repo.FetchFeatureFlag(context.Background(), "staging", "enable_lens")
+	require.NoError(t, err)
+	require.Nil(t, flag)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertFeatureFlagOverride(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeatureFlagRepository{pool: mock}
+	admin := uuid.New()
+	o := &domain.FeatureFlagOverride{
+		FlagName:    "enable_lens",
+		Environment: "production",
+		UserID:      uuid.New(),
+		Enabled:     true,
+		CreatedBy:   &admin,
+	}
+	createdAt := time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO feature_flag_overrides`).
+		WithArgs("enable_lens", "production", o.UserID, true, &admin).
+		WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+
+	require.NoError(t, repo.UpsertFeatureFlagOverride(context.Background(), o))
+	require.Equal(t, createdAt, *o.CreatedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,13 @@
+package alt_db
+
+// FeatureFlagRepository handles stored feature flags and per-user overrides.
+type FeatureFlagRepository struct {
+	pool PgxIface
+}
+
+func NewFeatureFlagRepository(pool PgxIface) *FeatureFlagRepository {
+	if pool == nil {
+		return nil
+	}
+	return &FeatureFlagRepository{pool: pool}
+}
@@ -44,7 +44,7 @@ const ogImageSelectExpr = `CASE WHEN f.created_at >= NOW() - INTERVAL '7 days' T
 
 func (r *FeedRepository) GetSingleFeed(ctx context.Context) (*models.Feed, error) {
 	query := `
-		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds ORDER BY created_at DESC LIMIT 1
+		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT 1
 	`
 
 	var feed models.Feed
@@ -59,7 +59,7 @@ func (r *FeedRepository) GetSingleFeed(ctx context.Context) (*models.Feed, error
 
 func (r *FeedRepository) FetchFeedsList(ctx context.Context) ([]*models.Feed, error) {
 	query := `
-		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds ORDER BY created_at DESC LIMIT 10000
+		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT 10000
 	`
 
 	var feeds []*models.Feed
@@ -84,7 +84,7 @@ func (r *FeedRepository) FetchFeedsList(ctx context.Context) ([]*models.Feed, er
 
 func (r *FeedRepository) FetchFeedsListLimit(ctx context.Context, limit int) ([]*models.Feed, error) {
 	query := `
-		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds ORDER BY created_at DESC LIMIT $1
+		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT $1
 	`
 
 	var feeds []*models.Feed
@@ -110,7 +110,7 @@ func (r *FeedRepository) FetchFeedsListLimit(ctx context.Context, limit int) ([]
 
 func (r *FeedRepository) FetchFeedsListPage(ctx context.Context, page int) ([]*models.Feed, error) {
 	query := `
-		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds ORDER BY created_at DESC LIMIT $1 OFFSET $2
+		SELECT id, title, description, website_url, pub_date, created_at, updated_at FROM feeds WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT $1 OFFSET $2
 	`
 
 	var feeds []*models.Feed
@@ -153,6 +153,7 @@ func (r *FeedRepository) FetchUnreadFeedsListPage(ctx context.Context, page int)
 			AND rs.user_id = $3
 			AND rs.is_read = TRUE
 		)
+		AND f.deleted_at IS NULL
 		ORDER BY f.created_at DESC
 		LIMIT $1 OFFSET $2
 	`
@@ -237,6 +238,7 @@ func (r *FeedRepository) FetchUnreadFeedsListCursor(ctx context.Context, cursor
 				AND rs.is_read = TRUE
 			)
 			AND f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $2)
+			AND f.deleted_at IS NULL
 			%s
 			ORDER BY f.created_at DESC, f.id DESC
 			LIMIT $1
@@ -257,6 +259,7 @@ func (r *FeedRepository) FetchUnreadFeedsListCursor(ctx context.Context, cursor
 				AND rs.is_read = TRUE
 			)
 			AND f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $3)
+			AND f.deleted_at IS NULL
 			AND f.created_at < $1
 			%s
 			ORDER BY f.created_at DESC, f.id DESC
@@ -314,6 +317,7 @@ func (r *FeedRepository) FetchAllFeedsListCursor(ctx context.Context, cursor *ti
 			FROM feeds f
 			LEFT JOIN read_status rs ON rs.feed_id = f.id AND rs.user_id = $2
 			WHERE f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $2)
+			AND f.deleted_at IS NULL
 			%s
 			ORDER BY f.created_at DESC, f.id DESC
 			LIMIT $1
@@ -329,6 +333,7 @@ func (r *FeedRepository) FetchAllFeedsListCursor(ctx context.Context, cursor *ti
 			FROM feeds f
 			LEFT JOIN read_status rs ON rs.feed_id = f.id AND rs.user_id = $3
 			WHERE f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $3)
+			AND f.deleted_at IS NULL
 			AND f.created_at < $1
 			%s
 			ORDER BY f.created_at DESC, f.id DESC
@@ -383,6 +388,7 @@ func (r *FeedRepository) FetchReadFeedsListCursor(ctx context.Context, cursor *t
 			WHERE rs.is_read = TRUE
 			AND rs.user_id = $2
 			AND f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $2)
+			AND f.deleted_at IS NULL
 			ORDER BY rs.read_at DESC, f.id DESC
 			LIMIT $1
 		`, ogImageSelectExpr)
@@ -397,6 +403,7 @@ func (r *FeedRepository) FetchReadFeedsListCursor(ctx context.Context, cursor *t
 			WHERE rs.is_read = TRUE
 			AND rs.user_id = $3
 			AND f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $3)
+			AND f.deleted_at IS NULL
 			AND rs.read_at < $1
 			ORDER BY rs.read_at DESC, f.id DESC
 			LIMIT $2
@@ -445,6 +452,7 @@ func (r *FeedRepository) FetchFavoriteFeedsListCursor(ctx context.Context, curso
                        INNER JOIN favorite_feeds ff ON ff.feed_id = f.id
                        WHERE ff.user_id = $2
                        AND f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $2)
+                       AND f.deleted_at IS NULL
                        ORDER BY ff.created_at DESC, f.id DESC
                        LIMIT $1
                `, ogImageSelectExpr)
@@ -458,6 +466,7 @@ func (r *FeedRepository) FetchFavoriteFeedsListCursor(ctx context.Context, curso
                        INNER JOIN favorite_feeds ff ON ff.feed_id = f.id
                        WHERE ff.user_id = $3 AND ff.created_at < $1
                        AND f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $3)
+                       AND f.deleted_at IS NULL
                        ORDER BY ff.created_at DESC, f.id DESC
                        LIMIT $2
                `, ogImageSelectExpr)
@@ -492,7 +501,7 @@ func (r *FeedRepository) FetchFeedsByFeedLinkID(ctx context.Context, feedLinkID
 		        ORDER BY a.created_at DESC LIMIT 1) AS article_id,
 		       f.og_image_url
 		FROM feeds f
-		WHERE f.feed_link_id = $1
+		WHERE f.feed_link_id = $1 AND f.deleted_at IS NULL
 		ORDER BY f.created_at DESC, f.id DESC
 		LIMIT 200
 	`
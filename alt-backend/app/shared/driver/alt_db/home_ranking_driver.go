@@ -0,0 +1,211 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// homeRankingProfileTags bounds the read-history and trending tag profiles.
+// The long tail barely moves similarity or trending scores.
+const homeRankingProfileTags = 200
+
+const (
+	// $2 is the candidate feed IDs. Reads are counted per subscription, so a
+	// candidate scores on the user's history with its whole feed.
+	homeRankingFeedReadsQuery = `
+		SELECT c.id, COUNT(*)::int
+		FROM feeds c
+		JOIN feeds f ON f.feed_link_id = c.feed_link_id
+		JOIN read_status rs ON rs.feed_id = f.id
+		WHERE c.id = ANY($2::uuid[])
+		  AND rs.user_id = $1 AND rs.is_read = TRUE AND rs.read_at >= $3
+		GROUP BY c.id`
+
+	homeRankingFeedTagsQuery = `
+		SELECT feed_id, tag_name
+		FROM feed_tags
+		WHERE feed_id = ANY($1::uuid[])
+		ORDER BY feed_id, tag_name`
+
+	homeRankingReadTagsQuery = `
+		SELECT ft.tag_name, COUNT(DISTINCT rs.feed_id)::int AS reads
+		FROM read_status rs
+		JOIN feed_tags ft ON ft.feed_id = rs.feed_id
+		WHERE rs.user_id = $1 AND rs.is_read = TRUE AND rs.read_at >= $2
+		GROUP BY ft.tag_name
+		ORDER BY reads DESC, ft.tag_name
+		LIMIT $3`
+
+	homeRankingTrendingTagsQuery = `
+		SELECT ft.tag_name, COUNT(DISTINCT f.id)::int AS items
+		FROM feeds f
+		JOIN feed_tags ft ON ft.feed_id = f.id
+		WHERE f.created_at >= $2
+		  AND f.deleted_at IS NULL
+		  AND f.feed_link_id IN (SELECT feed_link_id FROM user_feed_subscriptions WHERE user_id = $1)
+		GROUP BY ft.tag_name
+		ORDER BY items DESC, ft.tag_name
+		LIMIT $3`
+)
+
+// FetchHomeRankingExperiment returns userID's ranking weights, or nil when
+// the user has no assignment.
+func (r *FeedRepository) FetchHomeRankingExperiment(ctx context.Context, userID uuid.UUID) (*domain.HomeRankingExperiment, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	exp := &domain.HomeRankingExperiment{UserID: userID}
+	var updatedAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		SELECT experiment, recency_weight, feed_affinity_weight, read_similarity_weight, trending_weight, updated_at
+		FROM home_ranking_experiments
+		WHERE user_id = $1`, userID).Scan(
+		&exp.Experiment,
+		&exp.Weights.Recency, &exp.Weights.FeedAffinity, &exp.Weights.ReadSimilarity, &exp.Weights.Trending,
+		&updatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch home ranking experiment: %w", err)
+	}
+	exp.UpdatedAt = &updatedAt
+	return exp, nil
+}
+
+// UpsertHomeRankingExperiment replaces userID's ranking weights.
+func (r *FeedRepository) UpsertHomeRankingExperiment(ctx context.Context, exp *domain.HomeRankingExperiment) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	var updatedAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO home_ranking_experiments
+			(user_id, experiment, recency_weight, feed_affinity_weight, read_similarity_weight, trending_weight)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			experiment = EXCLUDED.experiment,
+			recency_weight = EXCLUDED.recency_weight,
+			feed_affinity_weight = EXCLUDED.feed_affinity_weight,
+			read_similarity_weight = EXCLUDED.read_similarity_weight,
+			trending_weight = EXCLUDED.trending_weight,
+			updated_at = NOW()
+		RETURNING updated_at`,
+		exp.UserID, exp.Experiment,
+		exp.Weights.Recency, exp.Weights.FeedAffinity, exp.Weights.ReadSimilarity, exp.Weights.Trending,
+	).Scan(&updatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert home ranking experiment: %w", err)
+	}
+	exp.UpdatedAt = &updatedAt
+	return nil
+}
+
+// DeleteHomeRankingExperiment returns userID to the default weights.
+func (r *FeedRepository) DeleteHomeRankingExperiment(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if r == nil || r.pool == nil {
+		return false, errors.New("database connection not available")
+	}
+
+	tag, err := r.pool.Exec(ctx, `DELETE FROM home_ranking_experiments WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete home ranking experiment: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// FetchHomeRankingInputs loads everything the home ranking signals of one
+// page are derived from.
+func (r *FeedRepository) FetchHomeRankingInputs(ctx context.Context, userID uuid.UUID, feedIDs []uuid.UUID, historySince, trendingSince time.Time) (*domain.HomeRankingInputs, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	ids := make([]string, len(feedIDs))
+	for i, id := range feedIDs {
+		ids[i] = id.String()
+	}
+	inputs := &domain.HomeRankingInputs{
+		FeedReads:    map[uuid.UUID]int{},
+		FeedTags:     map[uuid.UUID][]string{},
+		ReadTags:     map[string]int{},
+		TrendingTags: map[string]int{},
+	}
+
+	rows, err := r.pool.Query(ctx, homeRankingFeedReadsQuery, userID, ids, historySince)
+	if err != nil {
+		return nil, fmt.Errorf("query feed reads: %w", err)
+	}
+	for rows.Next() {
+		var (
+			feedID uuid.UUID
+			reads  int
+		)
+		if err := rows.Scan(&feedID, &reads); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan feed reads: %w", err)
+		}
+		inputs.FeedReads[feedID] = reads
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate feed reads: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, homeRankingFeedTagsQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query feed tags: %w", err)
+	}
+	for rows.Next() {
+		var (
+			feedID uuid.UUID
+			tag    string
+		)
+		if err := rows.Scan(&feedID, &tag); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan feed tags: %w", err)
+		}
+		inputs.FeedTags[feedID] = append(inputs.FeedTags[feedID], tag)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate feed tags: %w", err)
+	}
+
+	if err := r.scanTagCounts(ctx, inputs.ReadTags, homeRankingReadTagsQuery, userID, historySince, homeRankingProfileTags); err != nil {
+		return nil, fmt.Errorf("read tags: %w", err)
+	}
+	if err := r.scanTagCounts(ctx, inputs.TrendingTags, homeRankingTrendingTagsQuery, userID, trendingSince, homeRankingProfileTags); err != nil {
+		return nil, fmt.Errorf("trending tags: %w", err)
+	}
+	return inputs, nil
+}
+
+// scanTagCounts runs a (tag_name, count) query into dst.
+func (r *FeedRepository) scanTagCounts(ctx context.Context, dst map[string]int, query string, args ...any) error {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			tag   string
+			count int
+		)
+		if err := rows.Scan(&tag, &count); err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		dst[tag] = count
+	}
+	return rows.Err()
+}
@@ -0,0 +1,88 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchHomeRankingInputs(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeedRepository{pool: mock}
+	userID, feedA, feedB := uuid.New(), uuid.New(), uuid.New()
+	historySince := time.Date(2026, 9, 16, 0, 0, 0, 0, time.UTC)
+	trendingSince := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	ids := []string{feedA.String(), feedB.String()}
+
+	mock.ExpectQuery(`JOIN read_status rs ON rs.feed_id = f.id`).
+		WithArgs(userID, ids, historySince).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "count"}).AddRow(feedA, 12))
+	mock.ExpectQuery(`FROM feed_tags`).
+		WithArgs(ids).
+		WillReturnRows(pgxmock.NewRows([]string{"feed_id", "tag_name"}).
+			AddRow(feedB, "databases").
+			AddRow(feedB, "go"))
+	mock.ExpectQuery(`FROM read_status rs`).
+		WithArgs(userID, historySince, homeRankingProfileTags).
+		WillReturnRows(pgxmock.NewRows([]string{"tag_name", "reads"}).AddRow("go", 9))
+	mock.ExpectQuery(`user_feed_subscriptions`).
+		WithArgs(userID, trendingSince, homeRankingProfileTags).
+		WillReturnRows(pgxmock.NewRows([]string{"tag_name", "items"}).AddRow("go", 4).AddRow("rust", 2))
+
+	inputs, err := repo.FetchHomeRankingInputs(context.Background(), userID, []uuid.UUID{feedA, feedB}, historySince, trendingSince)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]int{feedA: 12}, inputs.FeedReads)
+	require.Equal(t, map[uuid.UUID][]string{feedB: {"databases", "go"}}, inputs.FeedTags)
+	require.Equal(t, map[string]int{"go": 9}, inputs.ReadTags)
+	require.Equal(t, map[string]int{"go": 4, "rust": 2}, inputs.TrendingTags)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchHomeRankingExperiment_NoAssignment(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeedRepository{pool: mock}
+	userID := uuid.New()
+
+	mock.ExpectQuery(`FROM home_ranking_experiments`).
+		WithArgs(userID).
+		WillReturnError(pgx.ErrNoRows)
+
+	exp, err := repo.FetchHomeRankingExperiment(context.Background(), userID)
+	require.NoError(t, err)
+	require.Nil(t, exp)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertHomeRankingExperiment(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeedRepository{pool: mock}
+	exp := &domain.HomeRankingExperiment{
+		UserID:     uuid.New(),
+		Experiment: "affinity-heavy",
+		Weights:    domain.HomeRankingWeights{Recency: 1, FeedAffinity: 3},
+	}
+	updatedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`INSERT INTO home_ranking_experiments`).
+		WithArgs(exp.UserID, "affinity-heavy", 1.0, 3.0, 0.0, 0.0).
+		WillReturnRows(pgxmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+	require.NoError(t, repo.UpsertHomeRankingExperiment(context.Background(), exp))
+	require.Equal(t, updatedAt, *exp.UpdatedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -15,6 +15,13 @@ type InternalArticleWithTags struct {
 	CreatedAt time.Time
 	UserID    string
 	Language  string
+	FeedID    string
+	// FeedTitle is the title of the article's feed; empty when the article
+	// has no feed.
+	FeedTitle string
+	// Author comes from the latest matched ingest source record (see
+	// article_source_records); empty when no source reported one.
+	Author string
 }
 
 // InternalDeletedArticle is the driver-level model for deleted articles.
@@ -23,6 +30,19 @@ type InternalDeletedArticle struct {
 	DeletedAt time.Time
 }
 
+// articleAuthorLateral selects the author of article a from the newest
+// matched ingest source record that reported one. Articles carry no author
+// column; reconciliation links source records to articles instead.
+const articleAuthorLateral = `
+				SELECT s.author
+				FROM article_source_records s
+				WHERE s.article_id = a.id
+				  AND s.status = 'matched'
+				  AND COALESCE(s.author, '') <> ''
+				ORDER BY s.updated_at DESC
+				LIMIT 1
+			`
+
 // ListArticlesWithTags fetches articles with tags using backward keyset pagination.
 func (r *InternalRepository) ListArticlesWithTags(ctx context.Context, lastCreatedAt *time.Time, lastID string, limit int) ([]*InternalArticleWithTags, *time.Time, string, error) {
 	var query string
@@ -31,9 +51,10 @@ func (r *InternalRepository) ListArticlesWithTags(ctx context.Context, lastCreat
 	if lastCreatedAt == nil || lastCreatedAt.IsZero() {
 		query = `
 			SELECT a.id, a.title, a.content, a.created_at, a.user_id, a.language,
+				   COALESCE(a.feed_id::text, ''), COALESCE(f.title, ''), COALESCE(src.author, ''),
 				   COALESCE(tags.tag_names, '{}') as tag_names
 			FROM (
-				SELECT id, title, content, created_at, user_id, language
+				SELECT id, title, content, created_at, user_id, language, feed_id
 				FROM articles
 				WHERE deleted_at IS NULL
 				ORDER BY created_at DESC, id DESC
@@ -45,15 +66,18 @@ func (r *InternalRepository) ListArticlesWithTags(ctx context.Context, lastCreat
 				JOIN feed_tags t ON at.feed_tag_id = t.id
 				WHERE at.article_id = a.id
 			) tags ON TRUE
+			LEFT JOIN feeds f ON f.id = a.feed_id
+			LEFT JOIN LATERAL (` + articleAuthorLateral + `) src ON TRUE
 			ORDER BY a.created_at DESC, a.id DESC
 		`
 		args = []interface{}{limit}
 	} else {
 		query = `
 			SELECT a.id, a.title, a.content, a.created_at, a.user_id, a.language,
+				   COALESCE(a.feed_id::text, ''), COALESCE(f.title, ''), COALESCE(src.author, ''),
 				   COALESCE(tags.tag_names, '{}') as tag_names
 			FROM (
-				SELECT id, title, content, created_at, user_id, language
+				SELECT id, title, content, created_at, user_id, language, feed_id
 				FROM articles
 				WHERE deleted_at IS NULL
 				  AND (created_at, id) < ($1, $2)
@@ -66,6 +90,8 @@ func (r *InternalRepository) ListArticlesWithTags(ctx context.Context, lastCreat
 				JOIN feed_tags t ON at.feed_tag_id = t.id
 				WHERE at.article_id = a.id
 			) tags ON TRUE
+			LEFT JOIN feeds f ON f.id = a.feed_id
+			LEFT JOIN LATERAL (` + articleAuthorLateral + `) src ON TRUE
 			ORDER BY a.created_at DESC, a.id DESC
 		`
 		args = []interface{}{*lastCreatedAt, lastID, limit}
@@ -85,7 +111,8 @@ func (r *InternalRepository) ListArticlesWithTags(ctx context.Context, lastCreat
 		var article InternalArticleWithTags
 		var tagNames []string
 
-		if err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.CreatedAt, &article.UserID, &article.Language, &tagNames); err != nil {
+		if err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.CreatedAt, &article.UserID, &article.Language,
+			&article.FeedID, &article.FeedTitle, &article.Author, &tagNames); err != nil {
 			return nil, nil, "", err
 		}
 
@@ -110,9 +137,10 @@ func (r *InternalRepository) ListArticlesWithTagsForward(ctx context.Context, in
 	if lastCreatedAt == nil || lastCreatedAt.IsZero() {
 		query = `
 			SELECT a.id, a.title, a.content, a.created_at, a.user_id, a.language,
+				   COALESCE(a.feed_id::text, ''), COALESCE(f.title, ''), COALESCE(src.author, ''),
 				   COALESCE(tags.tag_names, '{}') as tag_names
 			FROM (
-				SELECT id, title, content, created_at, user_id, language
+				SELECT id, title, content, created_at, user_id, language, feed_id
 				FROM articles
 				WHERE deleted_at IS NULL
 				  AND created_at > $1
@@ -125,15 +153,18 @@ func (r *InternalRepository) ListArticlesWithTagsForward(ctx context.Context, in
 				JOIN feed_tags t ON at.feed_tag_id = t.id
 				WHERE at.article_id = a.id
 			) tags ON TRUE
+			LEFT JOIN feeds f ON f.id = a.feed_id
+			LEFT JOIN LATERAL (` + articleAuthorLateral + `) src ON TRUE
 			ORDER BY a.created_at ASC, a.id ASC
 		`
 		args = []interface{}{*incrementalMark, limit}
 	} else {
 		query = `
 			SELECT a.id, a.title, a.content, a.created_at, a.user_id, a.language,
+				   COALESCE(a.feed_id::text, ''), COALESCE(f.title, ''), COALESCE(src.author, ''),
 				   COALESCE(tags.tag_names, '{}') as tag_names
 			FROM (
-				SELECT id, title, content, created_at, user_id, language
+				SELECT id, title, content, created_at, user_id, language, feed_id
 				FROM articles
 				WHERE deleted_at IS NULL
 				  AND created_at > $1
@@ -147,6 +178,8 @@ func (r *InternalRepository) ListArticlesWithTagsForward(ctx context.Context, in
 				JOIN feed_tags t ON at.feed_tag_id = t.id
 				WHERE at.article_id = a.id
 			) tags ON TRUE
+			LEFT JOIN feeds f ON f.id = a.feed_id
+			LEFT JOIN LATERAL (` + articleAuthorLateral + `) src ON TRUE
 			ORDER BY a.created_at ASC, a.id ASC
 		`
 		args = []interface{}{*incrementalMark, *lastCreatedAt, lastID, limit}
@@ -166,7 +199,8 @@ func (r *InternalRepository) ListArticlesWithTagsForward(ctx context.Context, in
 		var article InternalArticleWithTags
 		var tagNames []string
 
-		if err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.CreatedAt, &article.UserID, &article.Language, &tagNames); err != nil {
+		if err := rows.Scan(&article.ID, &article.Title, &article.Content, &article.CreatedAt, &article.UserID, &article.Language,
+			&article.FeedID, &article.FeedTitle, &article.Author, &tagNames); err != nil {
 			return nil, nil, "", err
 		}
 
@@ -248,6 +282,7 @@ func (r *InternalRepository) GetLatestArticleTimestamp(ctx context.Context) (*ti
 func (r *InternalRepository) GetArticleWithTagsByID(ctx context.Context, articleID string) (*InternalArticleWithTags, error) {
 	query := `
 		SELECT a.id, a.title, a.content, a.created_at, a.user_id, a.language,
+			   COALESCE(a.feed_id::text, ''), COALESCE(f.title, ''), COALESCE(src.author, ''),
 			   COALESCE(
 				   array_agg(t.tag_name ORDER BY t.tag_name) FILTER (WHERE t.tag_name IS NOT NULL),
 				   '{}'
@@ -255,15 +290,18 @@ func (r *InternalRepository) GetArticleWithTagsByID(ctx context.Context, article
 		FROM articles a
 		LEFT JOIN article_tags at ON a.id = at.article_id
 		LEFT JOIN feed_tags t ON at.feed_tag_id = t.id
+		LEFT JOIN feeds f ON f.id = a.feed_id
+		LEFT JOIN LATERAL (` + articleAuthorLateral + `) src ON TRUE
 		WHERE a.id = $1 AND a.deleted_at IS NULL
-		GROUP BY a.id
+		GROUP BY a.id, f.title, src.author
 	`
 
 	var article InternalArticleWithTags
 	var tagNames []string
 
 	err := r.pool.QueryRow(ctx, query, articleID).Scan(
-		&article.ID, &article.Title, &article.Content, &article.CreatedAt, &article.UserID, &article.Language, &tagNames,
+		&article.ID, &article.Title, &article.Content, &article.CreatedAt, &article.UserID, &article.Language,
+		&article.FeedID, &article.FeedTitle, &article.Author, &tagNames,
 	)
 	if err != nil {
 		return nil, err
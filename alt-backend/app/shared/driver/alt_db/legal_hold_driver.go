@@ -0,0 +1,230 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const legalHoldColumns = `id, user_id, reason, placed_by, placed_at, released_by, released_at`
+
+// accountExportQueries maps each domain.AccountExportSections entry to a query
+// that selects the account's rows as one JSON document per row. $1 is the
+// user ID. Ordering is fixed so repeated exports are byte-identical.
+var accountExportQueries = map[string]string{
+	"feed_subscriptions": `
+		SELECT row_to_json(t)::text FROM (
+			SELECT ufs.feed_link_id, fl.url, ufs.created_at
+			FROM user_feed_subscriptions ufs
+			JOIN feed_links fl ON fl.id = ufs.feed_link_id
+			WHERE ufs.user_id = $1
+			ORDER BY ufs.created_at, ufs.feed_link_id
+		) t`,
+	"favorite_feeds": `
+		SELECT row_to_json(t)::text FROM (
+			SELECT * FROM favorite_feeds
+			WHERE user_id = $1
+			ORDER BY created_at, feed_id
+		) t`,
+	"feed_read_status": `
+		SELECT row_to_json(t)::text FROM (
+			SELECT * FROM read_status
+			WHERE user_id = $1
+			ORDER BY created_at, id
+		) t`,
+	"article_reading_status": `
+		SELECT row_to_json(t)::text FROM (
+			SELECT * FROM user_reading_status
+			WHERE user_id = $1
+			ORDER BY created_at, id
+		) t`,
+	"declined_domains": `
+		SELECT row_to_json(t)::text FROM (
+			SELECT * FROM declined_domains
+			WHERE user_id = $1
+			ORDER BY created_at, id
+		) t`,
+	"legal_holds": `
+		SELECT row_to_json(t)::text FROM (
+			SELECT ` + legalHoldColumns + ` FROM account_legal_holds
+			WHERE user_id = $1
+			ORDER BY placed_at, id
+		) t`,
+}
+
+// PlaceLegalHold inserts an active hold. The partial unique index on
+// (user_id) WHERE released_at IS NULL rejects a second active hold.
+func (r *ComplianceRepository) PlaceLegalHold(ctx context.Context, hold *domain.LegalHold) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	query := `
+		INSERT INTO account_legal_holds (id, user_id, reason, placed_by, placed_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.pool.Exec(ctx, query, hold.ID, hold.UserID, hold.Reason, hold.PlacedBy, hold.PlacedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrLegalHoldAlreadyActive
+		}
+		return fmt.Errorf("failed to place legal hold: %w", err)
+	}
+	return nil
+}
+
+// ReleaseLegalHold marks the account's active hold released.
+func (r *ComplianceRepository) ReleaseLegalHold(ctx context.Context, userID, releasedBy uuid.UUID) (*domain.LegalHold, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `
+		UPDATE account_legal_holds
+		SET released_by = $2, released_at = NOW()
+		WHERE user_id = $1 AND released_at IS NULL
+		RETURNING ` + legalHoldColumns
+
+	hold, err := scanLegalHold(r.pool.QueryRow(ctx, query, userID, releasedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrLegalHoldNotFound
+		}
+		return nil, fmt.Errorf("failed to release legal hold: %w", err)
+	}
+	return hold, nil
+}
+
+// GetActiveLegalHold returns the account's active hold, or nil if none.
+func (r *ComplianceRepository) GetActiveLegalHold(ctx context.Context, userID uuid.UUID) (*domain.LegalHold, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `SELECT ` + legalHoldColumns + `
+		FROM account_legal_holds
+		WHERE user_id = $1 AND released_at IS NULL`
+
+	hold, err := scanLegalHold(r.pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get legal hold: %w", err)
+	}
+	return hold, nil
+}
+
+// ListActiveLegalHolds returns every active hold, oldest first.
+func (r *ComplianceRepository) ListActiveLegalHolds(ctx context.Context) ([]*domain.LegalHold, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	query := `SELECT ` + legalHoldColumns + `
+		FROM account_legal_holds
+		WHERE released_at IS NULL
+		ORDER BY placed_at`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+	defer rows.Close()
+
+	holds := []*domain.LegalHold{}
+	for rows.Next() {
+		hold, err := scanLegalHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan legal hold: %w", err)
+		}
+		holds = append(holds, hold)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate legal holds: %w", err)
+	}
+	return holds, nil
+}
+
+// RecordComplianceAudit appends an entry to account_compliance_audit_log.
+func (r *ComplianceRepository) RecordComplianceAudit(ctx context.Context, entry *domain.ComplianceAuditEntry) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	details := entry.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to encode compliance audit details: %w", err)
+	}
+
+	query := `
+		INSERT INTO account_compliance_audit_log (id, user_id, actor_id, action, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	// JSONB is passed as string: pgx's simple protocol would otherwise send
+	// []byte as bytea.
+	if _, err := r.pool.Exec(ctx, query,
+		entry.ID, entry.UserID, entry.ActorID, entry.Action, string(detailsJSON), entry.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to record compliance audit: %w", err)
+	}
+	return nil
+}
+
+// ExportAccountSection writes the account's rows for section to w as JSON
+// Lines and returns the row count.
+func (r *ComplianceRepository) ExportAccountSection(ctx context.Context, userID uuid.UUID, section string, w io.Writer) (int64, error) {
+	if r == nil || r.pool == nil {
+		return 0, errors.New("database connection not available")
+	}
+
+	query, ok := accountExportQueries[section]
+	if !ok {
+		return 0, fmt.Errorf("unknown export section: %s", section)
+	}
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export %s: %w", section, err)
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return count, fmt.Errorf("failed to scan %s row: %w", section, err)
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return count, fmt.Errorf("failed to write %s row: %w", section, err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to iterate %s rows: %w", section, err)
+	}
+	return count, nil
+}
+
+func scanLegalHold(row pgx.Row) (*domain.LegalHold, error) {
+	var hold domain.LegalHold
+	if err := row.Scan(
+		&hold.ID, &hold.UserID, &hold.Reason, &hold.PlacedBy, &hold.PlacedAt,
+		&hold.ReleasedBy, &hold.ReleasedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
@@ -0,0 +1,98 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountExportQueries_CoverEverySection(t *testing.T) {
+	for _, section := range domain.AccountExportSections {
+		_, ok := accountExportQueries[section]
+		assert.True(t, ok, "missing export query for section %s", section)
+	}
+	assert.Len(t, accountExportQueries, len(domain.AccountExportSections))
+}
+
+func TestPlaceLegalHold_DuplicateActiveHold(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ComplianceRepository{pool: mock}
+	hold := &domain.LegalHold{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Reason:   "litigation",
+		PlacedBy: uuid.New(),
+		PlacedAt: time.Now(),
+	}
+
+	mock.ExpectExec(`INSERT INTO account_legal_holds`).
+		WithArgs(hold.ID, hold.UserID, hold.Reason, hold.PlacedBy, hold.PlacedAt).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	err = repo.PlaceLegalHold(context.Background(), hold)
+	require.ErrorIs(t, err, domain.ErrLegalHoldAlreadyActive)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReleaseLegalHold_NoActiveHold(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ComplianceRepository{pool: mock}
+	userID, adminID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`UPDATE account_legal_holds`).
+		WithArgs(userID, adminID).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "user_id", "reason", "placed_by", "placed_at", "released_by", "released_at",
+		}))
+
+	hold, err := repo.ReleaseLegalHold(context.Background(), userID, adminID)
+	require.ErrorIs(t, err, domain.ErrLegalHoldNotFound)
+	assert.Nil(t, hold)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportAccountSection_WritesJSONLines(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ComplianceRepository{pool: mock}
+	userID := uuid.New()
+
+	mock.ExpectQuery(`FROM declined_domains`).
+		WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"row_to_json"}).
+			AddRow(`{"domain":"a.example"}`).
+			AddRow(`{"domain":"b.example"}`))
+
+	var buf bytes.Buffer
+	n, err := repo.ExportAccountSection(context.Background(), userID, "declined_domains", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, "{\"domain\":\"a.example\"}\n{\"domain\":\"b.example\"}\n", buf.String())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportAccountSection_UnknownSection(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ComplianceRepository{pool: mock}
+	_, err = repo.ExportAccountSection(context.Background(), uuid.New(), "articles", &bytes.Buffer{})
+	require.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package alt_db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InternalReindexArticleFilter narrows ListArticlesForReindex. Zero values disable a filter.
+type InternalReindexArticleFilter struct {
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	FeedID      string
+	UserID      string
+}
+
+// InternalReindexArticle is the driver-level model for an article selected for RAG reindexing.
+type InternalReindexArticle struct {
+	ID        string
+	Title     string
+	Content   string
+	URL       string
+	FeedID    string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// ListArticlesForReindex returns non-deleted articles matching filter, using
+// backward keyset pagination (created_at DESC, id DESC). CreatedFrom is
+// inclusive and CreatedTo exclusive.
+func (r *InternalRepository) ListArticlesForReindex(ctx context.Context, filter InternalReindexArticleFilter, lastCreatedAt *time.Time, lastID string, limit int) ([]InternalReindexArticle, *time.Time, string, error) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.FeedID != "" {
+		conditions = append(conditions, "feed_id = "+arg(filter.FeedID))
+	}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = "+arg(filter.UserID))
+	}
+	if lastCreatedAt != nil && !lastCreatedAt.IsZero() {
+		createdAt := arg(*lastCreatedAt)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < (%s, %s)", createdAt, arg(lastID)))
+	}
+
+	query := `
+		SELECT id, title, content, url, COALESCE(feed_id::text, ''), user_id, created_at
+		FROM articles
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ` + arg(limit)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("list articles for reindex: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []InternalReindexArticle
+	var finalCreatedAt *time.Time
+	var finalID string
+
+	for rows.Next() {
+		var a InternalReindexArticle
+		if err := rows.Scan(&a.ID, &a.Title, &a.Content, &a.URL, &a.FeedID, &a.UserID, &a.CreatedAt); err != nil {
+			return nil, nil, "", fmt.Errorf("scan reindex article: %w", err)
+		}
+		articles = append(articles, a)
+		finalCreatedAt = &a.CreatedAt
+		finalID = a.ID
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, "", fmt.Errorf("iterate reindex articles: %w", err)
+	}
+
+	return articles, finalCreatedAt, finalID, nil
+}
@@ -0,0 +1,67 @@
+package alt_db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListArticlesForReindex_NoFilters(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &InternalRepository{pool: mock}
+
+	t1 := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	rows := pgxmock.NewRows([]string{"id", "title", "content", "url", "feed_id", "user_id", "created_at"}).
+		AddRow("art-1", "Title 1", "Content 1", "https://example.com/1", "feed-1", "user-1", t1)
+
+	mock.ExpectQuery(`WHERE deleted_at IS NULL\s+ORDER BY created_at DESC, id DESC\s+LIMIT \$1`).
+		WithArgs(100).
+		WillReturnRows(rows)
+
+	articles, nextCreatedAt, nextID, err := repo.ListArticlesForReindex(context.Background(), InternalReindexArticleFilter{}, nil, "", 100)
+	require.NoError(t, err)
+	require.Len(t, articles, 1)
+	assert.Equal(t, "https://example.com/1", articles[0].URL)
+	assert.Equal(t, "feed-1", articles[0].FeedID)
+	require.NotNil(t, nextCreatedAt)
+	assert.Equal(t, t1, *nextCreatedAt)
+	assert.Equal(t, "art-1", nextID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListArticlesForReindex_AllFiltersWithCursor(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &InternalRepository{pool: mock}
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+	cursor := time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`created_at >= \$1 AND created_at < \$2 AND feed_id = \$3 AND user_id = \$4 AND \(created_at, id\) < \(\$5, \$6\)\s+ORDER BY created_at DESC, id DESC\s+LIMIT \$7`).
+		WithArgs(from, to, "feed-1", "user-1", cursor, "art-9", 50).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "content", "url", "feed_id", "user_id", "created_at"}))
+
+	articles, nextCreatedAt, nextID, err := repo.ListArticlesForReindex(context.Background(), InternalReindexArticleFilter{
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		FeedID:      "feed-1",
+		UserID:      "user-1",
+	}, &cursor, "art-9", 50)
+	require.NoError(t, err)
+	assert.Empty(t, articles)
+	assert.Nil(t, nextCreatedAt)
+	assert.Empty(t, nextID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
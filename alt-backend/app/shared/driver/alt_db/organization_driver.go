@@ -0,0 +1,575 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CreateOrganization stores org and makes owner its first owner in one
+// transaction.
+func (r *OrganizationRepository) CreateOrganization(ctx context.Context, org *domain.Organization, owner uuid.UUID) (err error) {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	if _, err = tx.Exec(ctx, `
+		INSERT INTO organizations (id, tenant_id, name, slug, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		org.ID, org.TenantID, org.Name, org.Slug, org.CreatedBy, org.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			err = domain.ErrOrgSlugTaken
+			return err
+		}
+		return fmt.Errorf("insert organization: %w", err)
+	}
+	if _, err = tx.Exec(ctx, `
+		INSERT INTO organization_members (org_id, user_id, role, joined_at)
+		VALUES ($1, $2, 'owner', $3)`,
+		org.ID, owner, org.CreatedAt); err != nil {
+		return fmt.Errorf("insert organization owner: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// ListUserOrganizations returns userID's organizations by name, each with
+// userID's role.
+func (r *OrganizationRepository) ListUserOrganizations(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT o.id, o.tenant_id, o.name, o.slug, o.created_by, o.created_at, m.role
+		FROM organizations o
+		JOIN organization_members m ON m.org_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name, o.id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := []*domain.Organization{}
+	for rows.Next() {
+		var org domain.Organization
+		var role string
+		if err := rows.Scan(&org.ID, &org.TenantID, &org.Name, &org.Slug, &org.CreatedBy, &org.CreatedAt, &role); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		org.Role = domain.OrgRole(role)
+		orgs = append(orgs, &org)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// FetchOrgMembership returns userID's membership of orgID.
+func (r *OrganizationRepository) FetchOrgMembership(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	member, err := scanOrgMember(r.pool.QueryRow(ctx, `
+		SELECT org_id, user_id, role, joined_at
+		FROM organization_members
+		WHERE org_id = $1 AND user_id = $2`, orgID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrgMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch organization membership: %w", err)
+	}
+	return member, nil
+}
+
+// ListOrgMembers returns orgID's members, earliest first.
+func (r *OrganizationRepository) ListOrgMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT org_id, user_id, role, joined_at
+		FROM organization_members
+		WHERE org_id = $1
+		ORDER BY joined_at, user_id`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*domain.OrgMember{}
+	for rows.Next() {
+		member, err := scanOrgMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	return members, nil
+}
+
+// UpdateOrgMemberRole changes userID's role in orgID. The owner rows are
+// locked first, so two concurrent demotions cannot both pass the
+// last-owner check.
+func (r *OrganizationRepository) UpdateOrgMemberRole(ctx context.Context, orgID, userID uuid.UUID, role domain.OrgRole) (err error) {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	if role != domain.OrgRoleOwner {
+		if err = checkNotLastOwner(ctx, tx, orgID, userID); err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE organization_members SET role = $3
+		WHERE org_id = $1 AND user_id = $2`, orgID, userID, string(role))
+	if err != nil {
+		return fmt.Errorf("update organization member role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = domain.ErrOrgMemberNotFound
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// RemoveOrgMember removes userID from orgID and, through the foreign key,
+// from all of its teams.
+func (r *OrganizationRepository) RemoveOrgMember(ctx context.Context, orgID, userID uuid.UUID) (err error) {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	if err = checkNotLastOwner(ctx, tx, orgID, userID); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM organization_members
+		WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("remove organization member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = domain.ErrOrgMemberNotFound
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// checkNotLastOwner locks orgID's owners and returns domain.ErrOrgLastOwner
+// when userID is the only one.
+func checkNotLastOwner(ctx context.Context, tx pgx.Tx, orgID, userID uuid.UUID) error {
+	rows, err := tx.Query(ctx, `
+		SELECT user_id FROM organization_members
+		WHERE org_id = $1 AND role = 'owner'
+		FOR UPDATE`, orgID)
+	if err != nil {
+		return fmt.Errorf("lock organization owners: %w", err)
+	}
+	defer rows.Close()
+
+	var owners []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("scan organization owner: %w", err)
+		}
+		owners = append(owners, id)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("lock organization owners: %w", err)
+	}
+	if len(owners) == 1 && owners[0] == userID {
+		return domain.ErrOrgLastOwner
+	}
+	return nil
+}
+
+// CreateTeam stores a team. The composite foreign key rejects a parent from
+// another organization.
+func (r *OrganizationRepository) CreateTeam(ctx context.Context, team *domain.Team) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO teams (id, org_id, parent_team_id, name, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		team.ID, team.OrgID, team.ParentID, team.Name, team.CreatedAt)
+	switch {
+	case isUniqueViolation(err):
+		return domain.ErrTeamNameTaken
+	case isForeignKeyViolation(err):
+		return domain.ErrTeamNotFound
+	case err != nil:
+		return fmt.Errorf("failed to create team: %w", err)
+	}
+	return nil
+}
+
+// ListTeams returns orgID's teams by name.
+func (r *OrganizationRepository) ListTeams(ctx context.Context, orgID uuid.UUID) ([]*domain.Team, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, org_id, parent_team_id, name, created_at
+		FROM teams
+		WHERE org_id = $1
+		ORDER BY name, id`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []*domain.Team{}
+	for rows.Next() {
+		var team domain.Team
+		if err := rows.Scan(&team.ID, &team.OrgID, &team.ParentID, &team.Name, &team.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, &team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return teams, nil
+}
+
+// ListTeamMembers returns a team's direct members, earliest first.
+func (r *OrganizationRepository) ListTeamMembers(ctx context.Context, orgID, teamID uuid.UUID) ([]*domain.TeamMember, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM teams WHERE id = $1 AND org_id = $2)`, teamID, orgID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to look up team: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT team_id, user_id, added_at
+		FROM team_members
+		WHERE team_id = $1
+		ORDER BY added_at, user_id`, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*domain.TeamMember{}
+	for rows.Next() {
+		var m domain.TeamMember
+		if err := rows.Scan(&m.TeamID, &m.UserID, &m.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		members = append(members, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+	return members, nil
+}
+
+// AddTeamMember adds an organization member to one of its teams.
+func (r *OrganizationRepository) AddTeamMember(ctx context.Context, orgID, teamID, userID uuid.UUID, now time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO team_members (team_id, org_id, user_id, added_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (team_id, user_id) DO NOTHING`,
+		teamID, orgID, userID, now.UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			if pgErr.ConstraintName == "fk_team_members_member" {
+				return domain.ErrOrgMemberNotFound
+			}
+			return domain.ErrTeamNotFound
+		}
+		return fmt.Errorf("failed to add team member: %w", err)
+	}
+	return nil
+}
+
+// RemoveTeamMember removes userID from one team; sub-team memberships are
+// left alone.
+func (r *OrganizationRepository) RemoveTeamMember(ctx context.Context, orgID, teamID, userID uuid.UUID) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM team_members
+		WHERE team_id = $1 AND org_id = $2 AND user_id = $3`, teamID, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrOrgMemberNotFound
+	}
+	return nil
+}
+
+// ListUserTeamScope walks down the team tree from every team userID is a
+// direct member of.
+func (r *OrganizationRepository) ListUserTeamScope(ctx context.Context, orgID, userID uuid.UUID) ([]uuid.UUID, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH RECURSIVE scope AS (
+			SELECT team_id AS id FROM team_members
+			WHERE org_id = $1 AND user_id = $2
+			UNION
+			SELECT t.id FROM teams t
+			JOIN scope s ON t.parent_team_id = s.id
+		)
+		SELECT id FROM scope ORDER BY id`, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team scope: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan team scope: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list team scope: %w", err)
+	}
+	return ids, nil
+}
+
+// CreateOrgInvitation stores an invitation with its token hash.
+func (r *OrganizationRepository) CreateOrgInvitation(ctx context.Context, inv *domain.OrgInvitation) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO organization_invitations (id, org_id, email, role, token_hash, invited_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		inv.ID, inv.OrgID, inv.Email, string(inv.Role), inv.TokenHash, inv.InvitedBy, inv.CreatedAt, inv.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization invitation: %w", err)
+	}
+	return nil
+}
+
+// ListOrgInvitations returns orgID's invitations, newest first.
+func (r *OrganizationRepository) ListOrgInvitations(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgInvitation, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT i.id, i.org_id, o.tenant_id, i.email, i.role, i.invited_by, i.created_at, i.expires_at,
+		       i.accepted_at, i.accepted_by, i.revoked_at
+		FROM organization_invitations i
+		JOIN organizations o ON o.id = i.org_id
+		WHERE i.org_id = $1
+		ORDER BY i.created_at DESC, i.id`, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []*domain.OrgInvitation{}
+	for rows.Next() {
+		inv, err := scanOrgInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization invitation: %w", err)
+		}
+		invitations = append(invitations, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// RevokeOrgInvitation revokes a pending invitation.
+func (r *OrganizationRepository) RevokeOrgInvitation(ctx context.Context, orgID, invitationID uuid.UUID, now time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+	now = now.UTC()
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE organization_invitations SET revoked_at = $3
+		WHERE id = $1 AND org_id = $2
+		  AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $3`,
+		invitationID, orgID, now)
+	if err != nil {
+		return fmt.Errorf("failed to revoke organization invitation: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM organization_invitations WHERE id = $1 AND org_id = $2)`, invitationID, orgID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to look up organization invitation: %w", err)
+	}
+	if exists {
+		return domain.ErrOrgInvitationGone
+	}
+	return domain.ErrOrgInvitationNotFound
+}
+
+// FetchOrgInvitationByTokenHash looks an invitation up by its token hash.
+func (r *OrganizationRepository) FetchOrgInvitationByTokenHash(ctx context.Context, tokenHash []byte) (*domain.OrgInvitation, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	inv, err := scanOrgInvitation(r.pool.QueryRow(ctx, `
+		SELECT i.id, i.org_id, o.tenant_id, i.email, i.role, i.invited_by, i.created_at, i.expires_at,
+		       i.accepted_at, i.accepted_by, i.revoked_at
+		FROM organization_invitations i
+		JOIN organizations o ON o.id = i.org_id
+		WHERE i.token_hash = $1`, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrgInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch organization invitation: %w", err)
+	}
+	return inv, nil
+}
+
+// AcceptOrgInvitation claims a pending invitation and adds its member in
+// one transaction, so an invitation admits at most one user.
+func (r *OrganizationRepository) AcceptOrgInvitation(ctx context.Context, invitationID, userID uuid.UUID, now time.Time) (member *domain.OrgMember, err error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+	now = now.UTC()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	var orgID uuid.UUID
+	var role string
+	err = tx.QueryRow(ctx, `
+		UPDATE organization_invitations SET accepted_at = $3, accepted_by = $2
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $3
+		RETURNING org_id, role`, invitationID, userID, now).Scan(&orgID, &role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = domain.ErrOrgInvitationGone
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claim organization invitation: %w", err)
+	}
+
+	// An existing member keeps their role rather than being downgraded.
+	member, err = scanOrgMember(tx.QueryRow(ctx, `
+		INSERT INTO organization_members (org_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET role = organization_members.role
+		RETURNING org_id, user_id, role, joined_at`, orgID, userID, role, now))
+	if err != nil {
+		return nil, fmt.Errorf("add organization member: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return member, nil
+}
+
+func scanOrgMember(row pgx.Row) (*domain.OrgMember, error) {
+	var m domain.OrgMember
+	var role string
+	if err := row.Scan(&m.OrgID, &m.UserID, &role, &m.JoinedAt); err != nil {
+		return nil, err
+	}
+	m.Role = domain.OrgRole(role)
+	return &m, nil
+}
+
+func scanOrgInvitation(row pgx.Row) (*domain.OrgInvitation, error) {
+	var inv domain.OrgInvitation
+	var role string
+	if err := row.Scan(
+		&inv.ID, &inv.OrgID, &inv.TenantID, &inv.Email, &role, &inv.InvitedBy, &inv.CreatedAt, &inv.ExpiresAt,
+		&inv.AcceptedAt, &inv.AcceptedBy, &inv.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	inv.Role = domain.OrgRole(role)
+	return &inv, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
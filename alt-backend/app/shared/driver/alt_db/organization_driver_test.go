@@ -0,0 +1,167 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrganization_SlugTaken(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &OrganizationRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	org := &domain.Organization{ID: uuid.New(), TenantID: uuid.New(), Name: "Acme", Slug: "acme", CreatedBy: uuid.New(), CreatedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO organizations`).
+		WithArgs(org.ID, org.TenantID, org.Name, org.Slug, org.CreatedBy, org.CreatedAt).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+	mock.ExpectRollback()
+
+	err = repo.CreateOrganization(context.Background(), org, org.CreatedBy)
+	require.ErrorIs(t, err, domain.ErrOrgSlugTaken)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateOrgMemberRole_LastOwner(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &OrganizationRepository{pool: mock}
+	orgID, ownerID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).
+		WithArgs(orgID).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id"}).AddRow(ownerID))
+	mock.ExpectRollback()
+
+	err = repo.UpdateOrgMemberRole(context.Background(), orgID, ownerID, domain.OrgRoleAdmin)
+	require.ErrorIs(t, err, domain.ErrOrgLastOwner)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveOrgMember_OtherOwnerRemains(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &OrganizationRepository{pool: mock}
+	orgID, ownerID, otherOwner := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).
+		WithArgs(orgID).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id"}).AddRow(ownerID).AddRow(otherOwner))
+	mock.ExpectExec(`DELETE FROM organization_members`).
+		WithArgs(orgID, ownerID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.RemoveOrgMember(context.Background(), orgID, ownerID))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTeamMember_MapsForeignKeys(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name       string
+		constraint string
+		want       error
+	}{
+		{name: "not an organization member", constraint: "fk_team_members_member", want: domain.ErrOrgMemberNotFound},
+		{name: "unknown team", constraint: "fk_team_members_team", want: domain.ErrTeamNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			repo := &OrganizationRepository{pool: mock}
+			orgID, teamID, userID := uuid.New(), uuid.New(), uuid.New()
+
+			mock.ExpectExec(`INSERT INTO team_members`).
+				WithArgs(teamID, orgID, userID, now).
+				WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: tc.constraint})
+
+			err = repo.AddTeamMember(context.Background(), orgID, teamID, userID, now)
+			require.ErrorIs(t, err, tc.want)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListUserTeamScope(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &OrganizationRepository{pool: mock}
+	orgID, userID := uuid.New(), uuid.New()
+	parent, child := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`WITH RECURSIVE scope`).
+		WithArgs(orgID, userID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(parent).AddRow(child))
+
+	ids, err := repo.ListUserTeamScope(context.Background(), orgID, userID)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{parent, child}, ids)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcceptOrgInvitation_NoLongerPending(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &OrganizationRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	invID, userID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE organization_invitations SET accepted_at`).
+		WithArgs(invID, userID, now).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = repo.AcceptOrgInvitation(context.Background(), invID, userID, now)
+	require.ErrorIs(t, err, domain.ErrOrgInvitationGone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcceptOrgInvitation_AddsMember(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &OrganizationRepository{pool: mock}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	invID, orgID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE organization_invitations SET accepted_at`).
+		WithArgs(invID, userID, now).
+		WillReturnRows(pgxmock.NewRows([]string{"org_id", "role"}).AddRow(orgID, "admin"))
+	mock.ExpectQuery(`INSERT INTO organization_members`).
+		WithArgs(orgID, userID, "admin", now).
+		WillReturnRows(pgxmock.NewRows([]string{"org_id", "user_id", "role", "joined_at"}).AddRow(orgID, userID, "admin", now))
+	mock.ExpectCommit()
+
+	member, err := repo.AcceptOrgInvitation(context.Background(), invID, userID, now)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrgRoleAdmin, member.Role)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
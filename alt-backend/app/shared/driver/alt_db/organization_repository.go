@@ -0,0 +1,14 @@
+package alt_db
+
+// OrganizationRepository handles organizations, their members, teams and
+// invitations.
+type OrganizationRepository struct {
+	pool PgxIface
+}
+
+func NewOrganizationRepository(pool PgxIface) *OrganizationRepository {
+	if pool == nil {
+		return nil
+	}
+	return &OrganizationRepository{pool: pool}
+}
@@ -0,0 +1,212 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Every recompute query reads one local day of read_status: $2 and $3 are
+// the day's UTC bounds (read_at is a UTC TIMESTAMP).
+const (
+	deleteReadingDailyStatsQuery     = `DELETE FROM user_reading_daily_stats WHERE stat_date = $1`
+	deleteReadingDailyFeedStatsQuery = `DELETE FROM user_reading_daily_feed_stats WHERE stat_date = $1`
+	deleteReadingDailyTagStatsQuery  = `DELETE FROM user_reading_daily_tag_stats WHERE stat_date = $1`
+
+	insertReadingDailyStatsQuery = `
+		WITH per_hour AS (
+			SELECT user_id,
+			       EXTRACT(HOUR FROM (read_at AT TIME ZONE 'UTC') AT TIME ZONE $4)::int AS hour,
+			       COUNT(*)::int AS reads
+			FROM read_status
+			WHERE is_read = TRUE AND read_at >= $2 AND read_at < $3
+			GROUP BY 1, 2
+		)
+		INSERT INTO user_reading_daily_stats (user_id, stat_date, articles_read, reads_by_hour, computed_at)
+		SELECT u.user_id, $1::date,
+		       SUM(COALESCE(p.reads, 0))::int,
+		       array_agg(COALESCE(p.reads, 0) ORDER BY h.hour),
+		       $5
+		FROM (SELECT DISTINCT user_id FROM per_hour) u
+		CROSS JOIN generate_series(0, 23) AS h(hour)
+		LEFT JOIN per_hour p ON p.user_id = u.user_id AND p.hour = h.hour
+		GROUP BY u.user_id`
+
+	insertReadingDailyFeedStatsQuery = `
+		INSERT INTO user_reading_daily_feed_stats (user_id, stat_date, feed_link_id, reads)
+		SELECT rs.user_id, $1::date, f.feed_link_id, COUNT(*)::int
+		FROM read_status rs
+		JOIN feeds f ON f.id = rs.feed_id
+		WHERE rs.is_read = TRUE AND rs.read_at >= $2 AND rs.read_at < $3
+		  AND f.feed_link_id IS NOT NULL
+		GROUP BY rs.user_id, f.feed_link_id`
+
+	insertReadingDailyTagStatsQuery = `
+		INSERT INTO user_reading_daily_tag_stats (user_id, stat_date, tag_name, reads)
+		SELECT rs.user_id, $1::date, ft.tag_name, COUNT(DISTINCT rs.feed_id)::int
+		FROM read_status rs
+		JOIN feed_tags ft ON ft.feed_id = rs.feed_id
+		WHERE rs.is_read = TRUE AND rs.read_at >= $2 AND rs.read_at < $3
+		GROUP BY rs.user_id, ft.tag_name`
+)
+
+// RecomputeReadingStats rebuilds every user's aggregate rows for the local
+// calendar day containing day (in loc), replacing whatever a previous run
+// stored. It returns how many users read something that day.
+func (r *DashboardRepository) RecomputeReadingStats(ctx context.Context, day time.Time, loc *time.Location, now time.Time) (users int64, err error) {
+	if r == nil || r.pool == nil {
+		return 0, errors.New("database connection not available")
+	}
+
+	local := day.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	statDate := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	from, to := dayStart.UTC(), dayStart.AddDate(0, 0, 1).UTC()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	for _, q := range []string{deleteReadingDailyStatsQuery, deleteReadingDailyFeedStatsQuery, deleteReadingDailyTagStatsQuery} {
+		if _, err = tx.Exec(ctx, q, statDate); err != nil {
+			return 0, fmt.Errorf("clear reading stats: %w", err)
+		}
+	}
+
+	tag, err := tx.Exec(ctx, insertReadingDailyStatsQuery, statDate, from, to, loc.String(), now.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("aggregate daily reading stats: %w", err)
+	}
+	users = tag.RowsAffected()
+
+	if _, err = tx.Exec(ctx, insertReadingDailyFeedStatsQuery, statDate, from, to); err != nil {
+		return 0, fmt.Errorf("aggregate feed reading stats: %w", err)
+	}
+	if _, err = tx.Exec(ctx, insertReadingDailyTagStatsQuery, statDate, from, to); err != nil {
+		return 0, fmt.Errorf("aggregate tag reading stats: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return users, nil
+}
+
+// FetchReadingStatsAggregate returns userID's aggregate rows for the
+// inclusive date range [from, to] with the topN feeds and tags.
+func (r *DashboardRepository) FetchReadingStatsAggregate(ctx context.Context, userID uuid.UUID, from, to time.Time, topN int) (*domain.ReadingStatsAggregate, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+
+	agg := &domain.ReadingStatsAggregate{
+		Daily:    []domain.ReadingDayStat{},
+		TopFeeds: []domain.ReadingFeedStat{},
+		TopTags:  []domain.ReadingTagStat{},
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT stat_date, articles_read, reads_by_hour, computed_at
+		FROM user_reading_daily_stats
+		WHERE user_id = $1 AND stat_date BETWEEN $2 AND $3
+		ORDER BY stat_date`, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query daily reading stats: %w", err)
+	}
+	for rows.Next() {
+		var (
+			day        domain.ReadingDayStat
+			byHour     []int32
+			computedAt time.Time
+		)
+		if err := rows.Scan(&day.Date, &day.ArticlesRead, &byHour, &computedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan daily reading stats: %w", err)
+		}
+		for hour, n := range byHour {
+			if hour < len(agg.HourlyDistribution) {
+				agg.HourlyDistribution[hour] += int(n)
+			}
+		}
+		if agg.ComputedAt == nil || computedAt.After(*agg.ComputedAt) {
+			agg.ComputedAt = &computedAt
+		}
+		agg.Daily = append(agg.Daily, day)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate daily reading stats: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT s.feed_link_id, COALESCE(fl.url, ''), SUM(s.reads)::int AS reads
+		FROM user_reading_daily_feed_stats s
+		LEFT JOIN feed_links fl ON fl.id = s.feed_link_id
+		WHERE s.user_id = $1 AND s.stat_date BETWEEN $2 AND $3
+		GROUP BY s.feed_link_id, fl.url
+		ORDER BY reads DESC, s.feed_link_id
+		LIMIT $4`, userID, from, to, topN)
+	if err != nil {
+		return nil, fmt.Errorf("query feed reading stats: %w", err)
+	}
+	for rows.Next() {
+		var feed domain.ReadingFeedStat
+		if err := rows.Scan(&feed.FeedLinkID, &feed.FeedURL, &feed.Reads); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan feed reading stats: %w", err)
+		}
+		agg.TopFeeds = append(agg.TopFeeds, feed)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate feed reading stats: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT tag_name, SUM(reads)::int AS reads
+		FROM user_reading_daily_tag_stats
+		WHERE user_id = $1 AND stat_date BETWEEN $2 AND $3
+		GROUP BY tag_name
+		ORDER BY reads DESC, tag_name
+		LIMIT $4`, userID, from, to, topN)
+	if err != nil {
+		return nil, fmt.Errorf("query tag reading stats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tag domain.ReadingTagStat
+		if err := rows.Scan(&tag.Tag, &tag.Reads); err != nil {
+			return nil, fmt.Errorf("scan tag reading stats: %w", err)
+		}
+		agg.TopTags = append(agg.TopTags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tag reading stats: %w", err)
+	}
+
+	return agg, nil
+}
+
+// CountArticlesRead sums userID's articles_read over the inclusive date
+// range [from, to].
+func (r *DashboardRepository) CountArticlesRead(ctx context.Context, userID uuid.UUID, from, to time.Time) (int, error) {
+	if r == nil || r.pool == nil {
+		return 0, errors.New("database connection not available")
+	}
+
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(articles_read), 0)::int
+		FROM user_reading_daily_stats
+		WHERE user_id = $1 AND stat_date BETWEEN $2 AND $3`, userID, from, to).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("count articles read: %w", err)
+	}
+	return total, nil
+}
@@ -0,0 +1,97 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecomputeReadingStats_ReplacesLocalDay(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &DashboardRepository{pool: mock}
+	// 2026-10-15 16:30 UTC is 2026-10-16 01:30 in Tokyo.
+	day := time.Date(2026, 10, 15, 16, 30, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	statDate := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 10, 15, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_reading_daily_stats`).WithArgs(statDate).WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectExec(`DELETE FROM user_reading_daily_feed_stats`).WithArgs(statDate).WillReturnResult(pgxmock.NewResult("DELETE", 4))
+	mock.ExpectExec(`DELETE FROM user_reading_daily_tag_stats`).WithArgs(statDate).WillReturnResult(pgxmock.NewResult("DELETE", 6))
+	mock.ExpectExec(`INSERT INTO user_reading_daily_stats`).
+		WithArgs(statDate, from, to, domain.ReadingStatsTimezone, now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 3))
+	mock.ExpectExec(`INSERT INTO user_reading_daily_feed_stats`).WithArgs(statDate, from, to).WillReturnResult(pgxmock.NewResult("INSERT", 5))
+	mock.ExpectExec(`INSERT INTO user_reading_daily_tag_stats`).WithArgs(statDate, from, to).WillReturnResult(pgxmock.NewResult("INSERT", 7))
+	mock.ExpectCommit()
+
+	users, err := repo.RecomputeReadingStats(context.Background(), day, domain.ReadingStatsLocation, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), users)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecomputeReadingStats_RollsBackOnError(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &DashboardRepository{pool: mock}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_reading_daily_stats`).WithArgs(pgxmock.AnyArg()).WillReturnError(errors.New("lock timeout"))
+	mock.ExpectRollback()
+
+	_, err = repo.RecomputeReadingStats(context.Background(), time.Now(), domain.ReadingStatsLocation, time.Now())
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFetchReadingStatsAggregate_SumsHours(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &DashboardRepository{pool: mock}
+	userID, feedLinkID := uuid.New(), uuid.New()
+	from := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	computedAt := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	dayA, dayB := make([]int32, 24), make([]int32, 24)
+	dayA[9], dayB[9], dayB[21] = 2, 1, 4
+
+	mock.ExpectQuery(`FROM user_reading_daily_stats`).
+		WithArgs(userID, from, to).
+		WillReturnRows(pgxmock.NewRows([]string{"stat_date", "articles_read", "reads_by_hour", "computed_at"}).
+			AddRow(from, 2, dayA, computedAt.Add(-24*time.Hour)).
+			AddRow(to, 5, dayB, computedAt))
+	mock.ExpectQuery(`FROM user_reading_daily_feed_stats`).
+		WithArgs(userID, from, to, domain.ReadingStatsTopN).
+		WillReturnRows(pgxmock.NewRows([]string{"feed_link_id", "url", "reads"}).
+			AddRow(feedLinkID, "https://example.com/rss", 7))
+	mock.ExpectQuery(`FROM user_reading_daily_tag_stats`).
+		WithArgs(userID, from, to, domain.ReadingStatsTopN).
+		WillReturnRows(pgxmock.NewRows([]string{"tag_name", "reads"}).AddRow("go", 3))
+
+	agg, err := repo.FetchReadingStatsAggregate(context.Background(), userID, from, to, domain.ReadingStatsTopN)
+	require.NoError(t, err)
+	require.Len(t, agg.Daily, 2)
+	require.Equal(t, 3, agg.HourlyDistribution[9])
+	require.Equal(t, 4, agg.HourlyDistribution[21])
+	require.Equal(t, computedAt, *agg.ComputedAt)
+	require.Equal(t, []domain.ReadingFeedStat{{FeedLinkID: feedLinkID, FeedURL: "https://example.com/rss", Reads: 7}}, agg.TopFeeds)
+	require.Equal(t, []domain.ReadingTagStat{{Tag: "go", Reads: 3}}, agg.TopTags)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -41,6 +41,10 @@ type AltDBRepository struct {
 	*OutboxRepository
 	*DashboardRepository
 	*TenantRepository
+	*ComplianceRepository
+	*FeatureFlagRepository
+	*OrganizationRepository
+	*BackgroundJobRepository
 }
 
 func NewAltDBRepository(pool PgxIface) *AltDBRepository {
@@ -48,20 +52,24 @@ func NewAltDBRepository(pool PgxIface) *AltDBRepository {
 		return nil
 	}
 	return &AltDBRepository{
-		pool:                   pool,
-		FeedRepository:         NewFeedRepository(pool),
-		ArticleRepository:      NewArticleRepository(pool),
-		TagRepository:          NewTagRepository(pool),
-		ScrapingRepository:     NewScrapingRepository(pool),
-		ImageRepository:        NewImageRepository(pool),
-		RecapRepository:        NewRecapRepository(pool),
-		SubscriptionRepository: NewSubscriptionRepository(pool),
-		InternalRepository:     NewInternalRepository(pool),
-		SummaryRepository:      NewSummaryRepository(pool),
-		KnowledgeRepository:    NewKnowledgeRepository(pool),
-		OutboxRepository:       NewOutboxRepository(pool),
-		DashboardRepository:    NewDashboardRepository(pool),
-		TenantRepository:       NewTenantRepository(pool),
+		pool:                    pool,
+		FeedRepository:          NewFeedRepository(pool),
+		ArticleRepository:       NewArticleRepository(pool),
+		TagRepository:           NewTagRepository(pool),
+		ScrapingRepository:      NewScrapingRepository(pool),
+		ImageRepository:         NewImageRepository(pool),
+		RecapRepository:         NewRecapRepository(pool),
+		SubscriptionRepository:  NewSubscriptionRepository(pool),
+		InternalRepository:      NewInternalRepository(pool),
+		SummaryRepository:       NewSummaryRepository(pool),
+		KnowledgeRepository:     NewKnowledgeRepository(pool),
+		OutboxRepository:        NewOutboxRepository(pool),
+		DashboardRepository:     NewDashboardRepository(pool),
+		TenantRepository:        NewTenantRepository(pool),
+		ComplianceRepository:    NewComplianceRepository(pool),
+		FeatureFlagRepository:   NewFeatureFlagRepository(pool),
+		OrganizationRepository:  NewOrganizationRepository(pool),
+		BackgroundJobRepository: NewBackgroundJobRepository(pool),
 	}
 }
 
@@ -78,19 +86,23 @@ func NewAltDBRepositoryWithPool(pool *pgxpool.Pool) *AltDBRepository {
 // repositories. Used in tests that need a valid struct to test error paths.
 func NewAltDBRepositoryForTest() *AltDBRepository {
 	return &AltDBRepository{
-		FeedRepository:         &FeedRepository{},
-		ArticleRepository:      &ArticleRepository{},
-		TagRepository:          &TagRepository{},
-		ScrapingRepository:     &ScrapingRepository{},
-		ImageRepository:        &ImageRepository{},
-		RecapRepository:        &RecapRepository{},
-		SubscriptionRepository: &SubscriptionRepository{},
-		InternalRepository:     &InternalRepository{},
-		SummaryRepository:      &SummaryRepository{},
-		KnowledgeRepository:    &KnowledgeRepository{},
-		OutboxRepository:       &OutboxRepository{},
-		DashboardRepository:    &DashboardRepository{},
-		TenantRepository:       &TenantRepository{},
+		FeedRepository:          &FeedRepository{},
+		ArticleRepository:       &ArticleRepository{},
+		TagRepository:           &TagRepository{},
+		ScrapingRepository:      &ScrapingRepository{},
+		ImageRepository:         &ImageRepository{},
+		RecapRepository:         &RecapRepository{},
+		SubscriptionRepository:  &SubscriptionRepository{},
+		InternalRepository:      &InternalRepository{},
+		SummaryRepository:       &SummaryRepository{},
+		KnowledgeRepository:     &KnowledgeRepository{},
+		OutboxRepository:        &OutboxRepository{},
+		DashboardRepository:     &DashboardRepository{},
+		TenantRepository:        &TenantRepository{},
+		ComplianceRepository:    &ComplianceRepository{},
+		FeatureFlagRepository:   &FeatureFlagRepository{},
+		OrganizationRepository:  &OrganizationRepository{},
+		BackgroundJobRepository: &BackgroundJobRepository{},
 	}
 }
 
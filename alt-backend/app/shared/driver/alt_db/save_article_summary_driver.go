@@ -8,7 +8,15 @@ import (
 
 // SaveArticleSummary saves an article summary to the database
 // If a summary already exists for the article and user, it will be updated
+// and keeps its recorded language.
 func (r *SummaryRepository) SaveArticleSummary(ctx context.Context, articleID string, userID string, articleTitle string, summary string) error {
+	return r.SaveArticleSummaryWithLanguage(ctx, articleID, userID, articleTitle, summary, "")
+}
+
+// SaveArticleSummaryWithLanguage saves an article summary written in
+// language ("ja", "en"). An empty language stores new summaries as Japanese
+// and leaves the language of an existing summary unchanged.
+func (r *SummaryRepository) SaveArticleSummaryWithLanguage(ctx context.Context, articleID string, userID string, articleTitle string, summary string, language string) error {
 	if articleID == "" {
 		return fmt.Errorf("article_id is required")
 	}
@@ -20,16 +28,17 @@ func (r *SummaryRepository) SaveArticleSummary(ctx context.Context, articleID st
 	}
 
 	query := `
-		INSERT INTO article_summaries (article_id, user_id, article_title, summary_japanese)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO article_summaries (article_id, user_id, article_title, summary_japanese, summary_language)
+		VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'ja'))
 		ON CONFLICT (article_id, user_id)
 		DO UPDATE SET
 			article_title = EXCLUDED.article_title,
 			summary_japanese = EXCLUDED.summary_japanese,
+			summary_language = CASE WHEN $5 = '' THEN article_summaries.summary_language ELSE EXCLUDED.summary_language END,
 			created_at = CURRENT_TIMESTAMP
 	`
 
-	commandTag, err := r.pool.Exec(ctx, query, articleID, userID, articleTitle, summary)
+	commandTag, err := r.pool.Exec(ctx, query, articleID, userID, articleTitle, summary, language)
 	if err != nil {
 		logger.Logger.ErrorContext(ctx, "Failed to save article summary", "error", err, "article_id", articleID)
 		return fmt.Errorf("failed to save article summary: %w", err)
@@ -38,7 +47,7 @@ func (r *SummaryRepository) SaveArticleSummary(ctx context.Context, articleID st
 	if commandTag.RowsAffected() == 0 {
 		logger.Logger.WarnContext(ctx, "No rows affected when saving article summary", "article_id", articleID)
 	} else {
-		logger.Logger.InfoContext(ctx, "Article summary saved successfully", "article_id", articleID, "rows_affected", commandTag.RowsAffected())
+		logger.Logger.InfoContext(ctx, "Article summary saved successfully", "article_id", articleID, "language", language, "rows_affected", commandTag.RowsAffected())
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package alt_db
 import (
 	"alt/utils/logger"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,6 +16,24 @@ const insertOutboxQuery = `
 	VALUES ($1, $2)
 `
 
+// OutboxEventTypeMQHub marks an outbox row holding an mq-hub event that the
+// outbox worker relays as-is.
+const OutboxEventTypeMQHub = "MQHUB_EVENT"
+
+// SaveOutboxEvent inserts an event into the outbox table, inside the
+// transaction TxManager.RunInTx put in ctx if there is one.
+func (r *OutboxRepository) SaveOutboxEvent(ctx context.Context, eventType string, payload []byte) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+	if _, err := connFromContext(ctx, r.pool).Exec(ctx, insertOutboxQuery, eventType, string(payload)); err != nil {
+		err = fmt.Errorf("failed to insert outbox event: %w", err)
+		logger.SafeErrorContext(ctx, "failed to save outbox event", "event_type", eventType, "error", err)
+		return err
+	}
+	return nil
+}
+
 // SaveOutboxEventWithTx inserts an event into the outbox table using a provided transaction.
 func (r *OutboxRepository) SaveOutboxEventWithTx(ctx context.Context, tx pgx.Tx, eventType string, payload []byte) error {
 	if _, err := tx.Exec(ctx, insertOutboxQuery, eventType, string(payload)); err != nil {
@@ -28,10 +47,12 @@ func (r *OutboxRepository) SaveOutboxEventWithTx(ctx context.Context, tx pgx.Tx,
 
 // OutboxEvent represents a row in the outbox_events table.
 type OutboxEvent struct {
-	ID        string    `json:"id"`
-	EventType string    `json:"event_type"`
-	Payload   []byte    `json:"payload"`
-	Status    string    `json:"status"`
+	ID        string `json:"id"`
+	EventType string `json:"event_type"`
+	Payload   []byte `json:"payload"`
+	Status    string `json:"status"`
+	// Attempts counts earlier deliveries that failed and were rescheduled.
+	Attempts  int       `json:"attempts"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -46,9 +67,9 @@ func (r *OutboxRepository) FetchAndLockPendingOutboxEvents(ctx context.Context,
 	defer tx.Rollback(ctx)
 
 	rows, err := tx.Query(ctx, `
-		SELECT id, event_type, payload, status, created_at
+		SELECT id, event_type, payload, status, attempts, created_at
 		FROM outbox_events
-		WHERE status = 'PENDING'
+		WHERE status = 'PENDING' AND available_at <= NOW()
 		ORDER BY created_at ASC
 		LIMIT $1
 		FOR UPDATE SKIP LOCKED
@@ -62,7 +83,7 @@ func (r *OutboxRepository) FetchAndLockPendingOutboxEvents(ctx context.Context,
 	for rows.Next() {
 		var e OutboxEvent
 		var id uuid.UUID
-		if err := rows.Scan(&id, &e.EventType, &e.Payload, &e.Status, &e.CreatedAt); err != nil {
+		if err := rows.Scan(&id, &e.EventType, &e.Payload, &e.Status, &e.Attempts, &e.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
 		}
 		e.ID = id.String()
@@ -107,6 +128,22 @@ func (r *OutboxRepository) UpdateOutboxEventStatus(ctx context.Context, id strin
 	return nil
 }
 
+// RescheduleOutboxEvent returns a failed event to PENDING so it is fetched
+// again once retryAt has passed, and counts the failed attempt.
+func (r *OutboxRepository) RescheduleOutboxEvent(ctx context.Context, id string, errorMessage string, retryAt time.Time) error {
+	query := `
+		UPDATE outbox_events
+		SET status = 'PENDING', attempts = attempts + 1, available_at = $1, error_message = $2
+		WHERE id = $3
+	`
+
+	if _, err := r.pool.Exec(ctx, query, retryAt, errorMessage, id); err != nil {
+		return fmt.Errorf("failed to reschedule outbox event: %w", err)
+	}
+
+	return nil
+}
+
 // PruneOutboxEvents deletes processed events older than the specified duration.
 func (r *OutboxRepository) PruneOutboxEvents(ctx context.Context, olderThan time.Duration) (int64, error) {
 	query := `DELETE FROM outbox_events WHERE status = 'PROCESSED' AND processed_at < $1`
@@ -28,6 +28,7 @@ func (a *FeedRepository) SearchFeedsByTitle(ctx context.Context, query string, u
 		INNER JOIN articles a ON f.id = a.feed_id
 		WHERE a.user_id = $1
 		AND LOWER(f.title) LIKE $2
+		AND f.deleted_at IS NULL AND a.deleted_at IS NULL
 		ORDER BY f.pub_date DESC
 		LIMIT 50
 	`
@@ -0,0 +1,329 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"alt/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// softDeleteTable describes how a soft-deletable table is queried. Feeds and
+// articles share every query below so delete, restore, list and purge
+// behave identically for both.
+type softDeleteTable struct {
+	entity   domain.SoftDeleteEntity
+	table    string
+	urlCol   string
+	ownerCol string // empty when rows are not owned by a single user
+	// heldClause excludes rows belonging to accounts under legal hold from
+	// the purge. $2 is the held user IDs (uuid[]).
+	heldClause string
+}
+
+var (
+	feedSoftDeleteTable = softDeleteTable{
+		entity: domain.SoftDeleteEntityFeed,
+		table:  "feeds",
+		urlCol: "website_url",
+		// Purging a feed cascades to its articles, so a feed is kept while
+		// any held account has an article under it.
+		heldClause: `NOT EXISTS (SELECT 1 FROM articles a WHERE a.feed_id = t.id AND a.user_id = ANY($2::uuid[]))`,
+	}
+	articleSoftDeleteTable = softDeleteTable{
+		entity:     domain.SoftDeleteEntityArticle,
+		table:      "articles",
+		urlCol:     "url",
+		ownerCol:   "user_id",
+		heldClause: `NOT (t.user_id = ANY($2::uuid[]))`,
+	}
+)
+
+const insertSoftDeleteAuditQuery = `
+	INSERT INTO soft_delete_audit_log (entity_type, entity_id, action, actor_id, created_at)
+	VALUES ($1, $2, $3, $4, $5)
+`
+
+// SoftDeleteFeed marks a feed deleted on behalf of actorID.
+func (r *FeedRepository) SoftDeleteFeed(ctx context.Context, feedID, actorID uuid.UUID, now time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+	return softDeleteRow(ctx, r.pool, feedSoftDeleteTable, feedID, actorID, nil, now)
+}
+
+// RestoreFeed clears a feed's soft delete if it was deleted at or after
+// notBefore.
+func (r *FeedRepository) RestoreFeed(ctx context.Context, feedID, actorID uuid.UUID, notBefore, now time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+	return restoreRow(ctx, r.pool, feedSoftDeleteTable, feedID, actorID, nil, notBefore, now)
+}
+
+// ListDeletedFeeds returns feeds soft-deleted at or after notBefore, newest first.
+func (r *FeedRepository) ListDeletedFeeds(ctx context.Context, notBefore time.Time, limit int) ([]*domain.DeletedItem, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+	return listDeletedRows(ctx, r.pool, feedSoftDeleteTable, nil, notBefore, limit)
+}
+
+// PurgeExpiredFeeds permanently deletes up to limit feeds soft-deleted
+// before cutoff and returns how many were removed.
+func (r *FeedRepository) PurgeExpiredFeeds(ctx context.Context, cutoff time.Time, heldUserIDs []uuid.UUID, limit int) (int64, error) {
+	if r == nil || r.pool == nil {
+		return 0, errors.New("database connection not available")
+	}
+	return purgeExpiredRows(ctx, r.pool, feedSoftDeleteTable, cutoff, heldUserIDs, limit)
+}
+
+// SoftDeleteArticle marks one of userID's articles deleted. Inside a
+// transaction in ctx, the update and its audit entry run in a savepoint of it.
+func (r *ArticleRepository) SoftDeleteArticle(ctx context.Context, articleID, userID uuid.UUID, now time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+	return softDeleteRow(ctx, connFromContext(ctx, r.pool), articleSoftDeleteTable, articleID, userID, &userID, now)
+}
+
+// RestoreArticle clears the soft delete on one of userID's articles if it
+// was deleted at or after notBefore.
+func (r *ArticleRepository) RestoreArticle(ctx context.Context, articleID, userID uuid.UUID, notBefore, now time.Time) error {
+	if r == nil || r.pool == nil {
+		return errors.New("database connection not available")
+	}
+	return restoreRow(ctx, r.pool, articleSoftDeleteTable, articleID, userID, &userID, notBefore, now)
+}
+
+// ListUserDeletedArticles returns userID's articles soft-deleted at or after
+// notBefore, newest first.
+func (r *ArticleRepository) ListUserDeletedArticles(ctx context.Context, userID uuid.UUID, notBefore time.Time, limit int) ([]*domain.DeletedItem, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+	return listDeletedRows(ctx, r.pool, articleSoftDeleteTable, &userID, notBefore, limit)
+}
+
+// PurgeExpiredArticles permanently deletes up to limit articles
+// soft-deleted before cutoff, skipping accounts in heldUserIDs.
+func (r *ArticleRepository) PurgeExpiredArticles(ctx context.Context, cutoff time.Time, heldUserIDs []uuid.UUID, limit int) (int64, error) {
+	if r == nil || r.pool == nil {
+		return 0, errors.New("database connection not available")
+	}
+	return purgeExpiredRows(ctx, r.pool, articleSoftDeleteTable, cutoff, heldUserIDs, limit)
+}
+
+// ListArticleSoftDeleteEvents returns up to limit article audit entries
+// positioned after (since, afterID), oldest first. Entries are ordered by
+// (created_at, id) so callers can page with the last entry they saw.
+func (r *ArticleRepository) ListArticleSoftDeleteEvents(ctx context.Context, since time.Time, afterID uuid.UUID, limit int) ([]*domain.SoftDeleteEvent, error) {
+	if r == nil || r.pool == nil {
+		return nil, errors.New("database connection not available")
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, entity_id, action, created_at FROM soft_delete_audit_log
+		WHERE entity_type = $1 AND (created_at, id) > ($2, $3)
+		ORDER BY created_at, id
+		LIMIT $4`,
+		string(domain.SoftDeleteEntityArticle), since.UTC(), afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list article soft delete events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.SoftDeleteEvent{}
+	for rows.Next() {
+		event := &domain.SoftDeleteEvent{Entity: domain.SoftDeleteEntityArticle}
+		if err := rows.Scan(&event.ID, &event.EntityID, &event.Action, &event.OccurredAt); err != nil {
+			return nil, fmt.Errorf("scan article soft delete event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate article soft delete events: %w", err)
+	}
+	return events, nil
+}
+
+// softDeleteRow sets deleted_at/deleted_by and records the audit entry in
+// the same transaction. ownerID, when set, restricts the update to rows the
+// owner holds. Already-deleted rows report domain.ErrItemNotFound.
+func softDeleteRow(ctx context.Context, pool dbConn, t softDeleteTable, id, actorID uuid.UUID, ownerID *uuid.UUID, now time.Time) (err error) {
+	now = now.UTC()
+	query := `UPDATE ` + t.table + ` SET deleted_at = $2, deleted_by = $3 WHERE id = $1 AND deleted_at IS NULL`
+	args := []any{id, now, actorID}
+	if ownerID != nil {
+		query += ` AND ` + t.ownerCol + ` = $4`
+		args = append(args, *ownerID)
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("soft delete %s: %w", t.entity, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrItemNotFound
+	}
+	if _, err = tx.Exec(ctx, insertSoftDeleteAuditQuery, string(t.entity), id, domain.SoftDeleteActionDeleted, actorID, now); err != nil {
+		return fmt.Errorf("record soft delete audit: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// restoreRow clears deleted_at/deleted_by if the row was deleted at or after
+// notBefore, recording the audit entry in the same transaction.
+func restoreRow(ctx context.Context, pool PgxIface, t softDeleteTable, id, actorID uuid.UUID, ownerID *uuid.UUID, notBefore, now time.Time) (err error) {
+	selectQuery := `SELECT deleted_at FROM ` + t.table + ` WHERE id = $1 AND deleted_at IS NOT NULL`
+	args := []any{id}
+	if ownerID != nil {
+		selectQuery += ` AND ` + t.ownerCol + ` = $2`
+		args = append(args, *ownerID)
+	}
+	selectQuery += ` FOR UPDATE`
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	var deletedAt time.Time
+	if err = tx.QueryRow(ctx, selectQuery, args...).Scan(&deletedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrDeletedItemNotFound
+		}
+		return fmt.Errorf("load deleted %s: %w", t.entity, err)
+	}
+	if deletedAt.Before(notBefore.UTC()) {
+		return domain.ErrRestoreWindowExpired
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE `+t.table+` SET deleted_at = NULL, deleted_by = NULL WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("restore %s: %w", t.entity, err)
+	}
+	if _, err = tx.Exec(ctx, insertSoftDeleteAuditQuery, string(t.entity), id, domain.SoftDeleteActionRestored, actorID, now.UTC()); err != nil {
+		return fmt.Errorf("record restore audit: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func listDeletedRows(ctx context.Context, pool PgxIface, t softDeleteTable, ownerID *uuid.UUID, notBefore time.Time, limit int) ([]*domain.DeletedItem, error) {
+	query := `SELECT id, title, ` + t.urlCol + `, deleted_at, deleted_by FROM ` + t.table + `
+		WHERE deleted_at IS NOT NULL AND deleted_at >= $1`
+	args := []any{notBefore.UTC()}
+	if ownerID != nil {
+		query += ` AND ` + t.ownerCol + ` = $2`
+		args = append(args, *ownerID)
+	}
+	query += fmt.Sprintf(` ORDER BY deleted_at DESC, id LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list deleted %s: %w", t.entity, err)
+	}
+	defer rows.Close()
+
+	items := []*domain.DeletedItem{}
+	for rows.Next() {
+		item := &domain.DeletedItem{Entity: t.entity}
+		if err := rows.Scan(&item.ID, &item.Title, &item.URL, &item.DeletedAt, &item.DeletedBy); err != nil {
+			return nil, fmt.Errorf("scan deleted %s: %w", t.entity, err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate deleted %s: %w", t.entity, err)
+	}
+	return items, nil
+}
+
+// purgeExpiredRows hard-deletes one batch of rows soft-deleted before cutoff
+// and records a "purged" audit entry per row. SKIP LOCKED lets a restore
+// that is holding the row win over a concurrent purge.
+func purgeExpiredRows(ctx context.Context, pool PgxIface, t softDeleteTable, cutoff time.Time, heldUserIDs []uuid.UUID, limit int) (purged int64, err error) {
+	held := uuidStrings(heldUserIDs)
+	query := `
+		DELETE FROM ` + t.table + ` WHERE id IN (
+			SELECT t.id FROM ` + t.table + ` t
+			WHERE t.deleted_at IS NOT NULL AND t.deleted_at < $1
+			  AND ` + t.heldClause + `
+			ORDER BY t.deleted_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	rows, err := tx.Query(ctx, query, cutoff.UTC(), held, limit)
+	if err != nil {
+		return 0, fmt.Errorf("purge %s: %w", t.entity, err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan purged %s: %w", t.entity, err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("purge %s: %w", t.entity, err)
+	}
+
+	if len(ids) > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO soft_delete_audit_log (entity_type, entity_id, action)
+			SELECT $1, unnest($2::uuid[]), $3`,
+			string(t.entity), uuidStrings(ids), domain.SoftDeleteActionPurged)
+		if err != nil {
+			return 0, fmt.Errorf("record purge audit: %w", err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return int64(len(ids)), nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id.String())
+	}
+	return out
+}
+
+// rollbackUnlessCommitted rolls tx back when *errp is set on return.
+func rollbackUnlessCommitted(ctx context.Context, tx pgx.Tx, errp *error) {
+	if *errp == nil {
+		return
+	}
+	if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+		logger.SafeWarnContext(ctx, "Error rolling back transaction", "error", rbErr)
+	}
+}
@@ -0,0 +1,162 @@
+package alt_db
+
+import (
+	"alt/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDeleteArticle_RecordsAuditInSameTx(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	articleID, userID := uuid.New(), uuid.New()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE articles SET deleted_at = \$2, deleted_by = \$3 WHERE id = \$1 AND deleted_at IS NULL AND user_id = \$4`).
+		WithArgs(articleID, now, userID, userID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`INSERT INTO soft_delete_audit_log`).
+		WithArgs("article", articleID, domain.SoftDeleteActionDeleted, userID, now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.SoftDeleteArticle(context.Background(), articleID, userID, now))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSoftDeleteFeed_AlreadyDeleted(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeedRepository{pool: mock}
+	feedID, adminID := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE feeds SET deleted_at`).
+		WithArgs(feedID, now, adminID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+
+	err = repo.SoftDeleteFeed(context.Background(), feedID, adminID, now)
+	require.ErrorIs(t, err, domain.ErrItemNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreFeed_WindowExpired(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeedRepository{pool: mock}
+	feedID, adminID := uuid.New(), uuid.New()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	notBefore := now.Add(-domain.SoftDeleteRestoreWindow)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT deleted_at FROM feeds WHERE id = \$1 AND deleted_at IS NOT NULL FOR UPDATE`).
+		WithArgs(feedID).
+		WillReturnRows(pgxmock.NewRows([]string{"deleted_at"}).AddRow(notBefore.Add(-time.Hour)))
+	mock.ExpectRollback()
+
+	err = repo.RestoreFeed(context.Background(), feedID, adminID, notBefore, now)
+	require.ErrorIs(t, err, domain.ErrRestoreWindowExpired)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreArticle_NotDeleted(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	articleID, userID := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT deleted_at FROM articles WHERE id = \$1 AND deleted_at IS NOT NULL AND user_id = \$2 FOR UPDATE`).
+		WithArgs(articleID, userID).
+		WillReturnRows(pgxmock.NewRows([]string{"deleted_at"}))
+	mock.ExpectRollback()
+
+	err = repo.RestoreArticle(context.Background(), articleID, userID, now.Add(-domain.SoftDeleteRestoreWindow), now)
+	require.ErrorIs(t, err, domain.ErrDeletedItemNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeExpiredArticles_SkipsHeldAccountsAndAudits(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	cutoff := time.Date(2026, 9, 16, 12, 0, 0, 0, time.UTC)
+	held := uuid.New()
+	purged1, purged2 := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)DELETE FROM articles WHERE id IN \(.*t.deleted_at < \$1.*NOT \(t.user_id = ANY\(\$2::uuid\[\]\)\).*FOR UPDATE SKIP LOCKED`).
+		WithArgs(cutoff, []string{held.String()}, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(purged1).AddRow(purged2))
+	mock.ExpectExec(`INSERT INTO soft_delete_audit_log`).
+		WithArgs("article", []string{purged1.String(), purged2.String()}, domain.SoftDeleteActionPurged).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mock.ExpectCommit()
+
+	n, err := repo.PurgeExpiredArticles(context.Background(), cutoff, []uuid.UUID{held}, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeExpiredFeeds_NothingExpired(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &FeedRepository{pool: mock}
+	cutoff := time.Date(2026, 9, 16, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)DELETE FROM feeds WHERE id IN \(.*NOT EXISTS \(SELECT 1 FROM articles a`).
+		WithArgs(cutoff, []string{}, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	n, err := repo.PurgeExpiredFeeds(context.Background(), cutoff, nil, 100)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListArticleSoftDeleteEvents_PagesByPosition(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &ArticleRepository{pool: mock}
+	since := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	afterID, eventID, articleID := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`(?s)FROM soft_delete_audit_log.*WHERE entity_type = \$1 AND \(created_at, id\) > \(\$2, \$3\).*ORDER BY created_at, id`).
+		WithArgs("article", since, afterID, 50).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "entity_id", "action", "created_at"}).
+			AddRow(eventID, articleID, domain.SoftDeleteActionDeleted, since.Add(time.Second)))
+
+	events, err := repo.ListArticleSoftDeleteEvents(context.Background(), since, afterID, 50)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, articleID, events[0].EntityID)
+	require.Equal(t, domain.SoftDeleteEntityArticle, events[0].Entity)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -41,27 +41,29 @@ func (r *SubscriptionRepository) FetchSubscriptions(ctx context.Context, userID
 	return sources, nil
 }
 
-// InsertSubscription inserts a user feed subscription.
+// InsertSubscription inserts a user feed subscription, joining the
+// transaction in ctx if there is one.
 func (r *SubscriptionRepository) InsertSubscription(ctx context.Context, userID uuid.UUID, feedLinkID uuid.UUID) error {
 	query := `
 		INSERT INTO user_feed_subscriptions (user_id, feed_link_id)
 		VALUES ($1, $2)
 		ON CONFLICT DO NOTHING
 	`
-	_, err := r.pool.Exec(ctx, query, userID, feedLinkID)
+	_, err := connFromContext(ctx, r.pool).Exec(ctx, query, userID, feedLinkID)
 	if err != nil {
 		return fmt.Errorf("insert subscription: %w", err)
 	}
 	return nil
 }
 
-// DeleteSubscription deletes a user feed subscription.
+// DeleteSubscription deletes a user feed subscription, joining the
+// transaction in ctx if there is one.
 func (r *SubscriptionRepository) DeleteSubscription(ctx context.Context, userID uuid.UUID, feedLinkID uuid.UUID) error {
 	query := `
 		DELETE FROM user_feed_subscriptions
 		WHERE user_id = $1 AND feed_link_id = $2
 	`
-	_, err := r.pool.Exec(ctx, query, userID, feedLinkID)
+	_, err := connFromContext(ctx, r.pool).Exec(ctx, query, userID, feedLinkID)
 	if err != nil {
 		return fmt.Errorf("delete subscription: %w", err)
 	}
@@ -0,0 +1,94 @@
+package alt_db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// dbConn is the part of PgxIface that a pgx.Tx also provides, so a driver
+// can run the same statements inside or outside a caller's transaction.
+// Begin on a transaction opens a savepoint.
+type dbConn interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type txContextKey struct{}
+
+// txScope is the transaction RunInTx puts in ctx.
+type txScope struct {
+	tx          pgx.Tx
+	afterCommit []func()
+}
+
+func txScopeFromContext(ctx context.Context) *txScope {
+	scope, _ := ctx.Value(txContextKey{}).(*txScope)
+	return scope
+}
+
+// connFromContext returns the transaction TxManager.RunInTx put in ctx, or
+// pool when ctx carries none.
+func connFromContext(ctx context.Context, pool PgxIface) dbConn {
+	if scope := txScopeFromContext(ctx); scope != nil {
+		return scope.tx
+	}
+	return pool
+}
+
+// AfterCommit runs fn once the transaction in ctx has committed, and not at
+// all if it rolls back. Without a transaction in ctx, fn runs right away.
+// It is for side effects outside the database, such as notifying open
+// streams, that must not announce a change before it is saved.
+func AfterCommit(ctx context.Context, fn func()) {
+	if scope := txScopeFromContext(ctx); scope != nil {
+		scope.afterCommit = append(scope.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+// TxManager implements transaction_port.TransactionPort.
+type TxManager struct {
+	pool PgxIface
+}
+
+// NewTxManager creates a new TxManager.
+func NewTxManager(pool PgxIface) *TxManager {
+	return &TxManager{pool: pool}
+}
+
+// RunInTx runs fn in a transaction. Drivers that look up their connection
+// with connFromContext use it for calls made with the ctx passed to fn. A
+// RunInTx nested in another joins the outer transaction.
+func (m *TxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if txScopeFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	if m == nil || m.pool == nil {
+		return errors.New("database connection not available")
+	}
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer rollbackUnlessCommitted(ctx, tx, &err)
+
+	scope := &txScope{tx: tx}
+	if err = fn(context.WithValue(ctx, txContextKey{}, scope)); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	for _, f := range scope.afterCommit {
+		f()
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package alt_db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pgxmock "github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxManager_RunInTx_CommitsChangeWithOutboxRow(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	subs := &SubscriptionRepository{pool: mock}
+	outbox := &OutboxRepository{pool: mock}
+	userID, feedLinkID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO user_feed_subscriptions`).
+		WithArgs(userID, feedLinkID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`INSERT INTO outbox_events`).
+		WithArgs(OutboxEventTypeMQHub, `{}`).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	notified := false
+	err = NewTxManager(mock).RunInTx(context.Background(), func(ctx context.Context) error {
+		if err := subs.InsertSubscription(ctx, userID, feedLinkID); err != nil {
+			return err
+		}
+		AfterCommit(ctx, func() { notified = true })
+		require.False(t, notified, "AfterCommit must wait for the commit")
+		return outbox.SaveOutboxEvent(ctx, OutboxEventTypeMQHub, []byte(`{}`))
+	})
+	require.NoError(t, err)
+	require.True(t, notified)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_RunInTx_FailedOutboxRowRollsBackChange(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	subs := &SubscriptionRepository{pool: mock}
+	outbox := &OutboxRepository{pool: mock}
+	userID, feedLinkID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM user_feed_subscriptions`).
+		WithArgs(userID, feedLinkID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(`INSERT INTO outbox_events`).
+		WithArgs(OutboxEventTypeMQHub, `{}`).
+		WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	notified := false
+	err = NewTxManager(mock).RunInTx(context.Background(), func(ctx context.Context) error {
+		if err := subs.DeleteSubscription(ctx, userID, feedLinkID); err != nil {
+			return err
+		}
+		AfterCommit(ctx, func() { notified = true })
+		return outbox.SaveOutboxEvent(ctx, OutboxEventTypeMQHub, []byte(`{}`))
+	})
+	require.Error(t, err)
+	require.False(t, notified, "nothing is announced for a rolled-back change")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTxManager_RunInTx_DriverTransactionBecomesSavepoint(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	articles := &ArticleRepository{pool: mock}
+	articleID, userID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectBegin() // savepoint
+	mock.ExpectExec(`UPDATE articles SET deleted_at`).
+		WithArgs(articleID, pgxmock.AnyArg(), userID, userID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback() // savepoint
+	mock.ExpectRollback()
+
+	err = NewTxManager(mock).RunInTx(context.Background(), func(ctx context.Context) error {
+		return articles.SoftDeleteArticle(ctx, articleID, userID, time.Now())
+	})
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
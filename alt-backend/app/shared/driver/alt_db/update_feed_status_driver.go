@@ -13,6 +13,8 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// UpdateFeedStatus marks a feed read for userID. Inside a transaction in
+// ctx, the upsert runs in a savepoint of it.
 func (r *FeedRepository) UpdateFeedStatus(ctx context.Context, feedURL url.URL, userID uuid.UUID) error {
 	// Normalize the input URL
 	normalizedInputURL, err := utils.NormalizeURL(feedURL.String())
@@ -25,8 +27,9 @@ func (r *FeedRepository) UpdateFeedStatus(ctx context.Context, feedURL url.URL,
 	// This changes from O(n) to O(1) with the index on feeds.website_url
 	getFeedQuery := `SELECT id FROM feeds WHERE website_url = $1`
 
+	conn := connFromContext(ctx, r.pool)
 	var feedID string
-	err = r.pool.QueryRow(ctx, getFeedQuery, normalizedInputURL).Scan(&feedID)
+	err = conn.QueryRow(ctx, getFeedQuery, normalizedInputURL).Scan(&feedID)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -46,7 +49,7 @@ func (r *FeedRepository) UpdateFeedStatus(ctx context.Context, feedURL url.URL,
 		"normalizedURL", normalizedInputURL)
 
 	// Start transaction for upsert
-	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		logger.SafeErrorContext(ctx, "Error beginning transaction", "error", err)
 		return fmt.Errorf("failed to begin transaction: %w", err)
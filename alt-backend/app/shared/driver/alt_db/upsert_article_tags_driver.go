@@ -10,6 +10,8 @@ import (
 
 // upsertTagCTE combines feed_tags upsert and article_tags link into a single query.
 // This eliminates the N+1 pattern of separate QueryRow + Exec per tag.
+// A newly linked tag also marks the article for reindexing; re-sending a tag
+// the article already has only updates its confidence.
 const upsertTagCTE = `
 	WITH ft AS (
 		INSERT INTO feed_tags (feed_id, tag_name, confidence)
@@ -18,10 +20,20 @@ const upsertTagCTE = `
 			confidence = EXCLUDED.confidence,
 			updated_at = CURRENT_TIMESTAMP
 		RETURNING id
+	), linked AS (
+		INSERT INTO article_tags (article_id, feed_tag_id)
+		SELECT $4::uuid, ft.id FROM ft
+		ON CONFLICT (article_id, feed_tag_id) DO NOTHING
+		RETURNING article_id
 	)
-	INSERT INTO article_tags (article_id, feed_tag_id)
-	SELECT $4::uuid, ft.id FROM ft
-	ON CONFLICT (article_id, feed_tag_id) DO NOTHING
+	INSERT INTO article_index_outbox (article_id, reason, marked_at, attempts, available_at)
+	SELECT article_id, 'tags_changed', NOW(), 0, NOW() FROM linked
+	ON CONFLICT (article_id) DO UPDATE
+	SET reason = EXCLUDED.reason,
+		marked_at = EXCLUDED.marked_at,
+		attempts = 0,
+		available_at = EXCLUDED.available_at,
+		last_error = NULL
 `
 
 // UpsertArticleTags upserts tags for an article.
@@ -14,6 +14,7 @@ import (
 // It resolves the feed by URL (feeds.website_url) and upserts a record in read_status.
 // Note: This function is named MarkArticleAsRead for API compatibility, but it
 // operates on feeds (not articles) because not all feeds have corresponding articles.
+// It joins the transaction in ctx if there is one.
 func (r *SubscriptionRepository) MarkArticleAsRead(ctx context.Context, articleURL url.URL) error {
 	user, err := domain.GetUserFromContext(ctx)
 	if err != nil {
@@ -39,7 +40,7 @@ func (r *SubscriptionRepository) MarkArticleAsRead(ctx context.Context, articleU
 		SET is_read = TRUE, read_at = CURRENT_TIMESTAMP
 	`
 
-	tag, err := r.pool.Exec(ctx, upsertQuery, normalizedURL, user.UserID)
+	tag, err := connFromContext(ctx, r.pool).Exec(ctx, upsertQuery, normalizedURL, user.UserID)
 	if err != nil {
 		logger.SafeErrorContext(ctx, "Error updating feed read status",
 			"error", err,
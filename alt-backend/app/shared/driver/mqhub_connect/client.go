@@ -21,16 +21,25 @@ const (
 	StreamKeySummaries = "alt:events:summaries"
 	StreamKeyTags      = "alt:events:tags"
 	StreamKeyIndex     = "alt:events:index"
+	StreamKeyAudio     = "alt:events:audio"
+	StreamKeyFeeds     = "alt:events:feeds"
 )
 
 // EventType constants matching mq-hub domain.
 const (
 	EventTypeArticleCreated         = "ArticleCreated"
 	EventTypeArticleUpdated         = "ArticleUpdated"
+	EventTypeArticleDeleted         = "ArticleDeleted"
 	EventTypeSummarizeRequested     = "SummarizeRequested"
 	EventTypeIndexArticle           = "IndexArticle"
 	EventTypeTagGenerationRequested = "TagGenerationRequested"
 	EventTypeTagGenerationCompleted = "TagGenerationCompleted"
+
+	EventTypeAudioGenerationRequested = "AudioGenerationRequested"
+
+	EventTypeFeedSubscribed   = "FeedSubscribed"
+	EventTypeFeedUnsubscribed = "FeedUnsubscribed"
+	EventTypeReadStateChanged = "ReadStateChanged"
 )
 
 // Client provides Connect-RPC client for mq-hub.
@@ -95,6 +104,36 @@ type IndexArticlePayload struct {
 	FeedID    string `json:"feed_id"`
 }
 
+// AudioGenerationRequestedPayload represents the payload for AudioGenerationRequested event.
+type AudioGenerationRequestedPayload struct {
+	ArticleID string `json:"article_id"`
+	UserID    string `json:"user_id"`
+	Voice     string `json:"voice,omitempty"`
+	Attempt   int    `json:"attempt"`
+}
+
+// ArticleDeletedPayload represents the payload for ArticleDeleted event.
+type ArticleDeletedPayload struct {
+	ArticleID string    `json:"article_id"`
+	UserID    string    `json:"user_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// FeedSubscriptionPayload represents the payload for FeedSubscribed and
+// FeedUnsubscribed events.
+type FeedSubscriptionPayload struct {
+	UserID     string `json:"user_id"`
+	FeedLinkID string `json:"feed_link_id"`
+}
+
+// ReadStateChangedPayload represents the payload for ReadStateChanged event.
+type ReadStateChangedPayload struct {
+	UserID    string    `json:"user_id"`
+	FeedURL   string    `json:"feed_url"`
+	IsRead    bool      `json:"is_read"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
 // PublishArticleCreated publishes an ArticleCreated event.
 // Callers must check IsEnabled before invoking this; the enabled/disabled
 // decision is made once at the gateway boundary (event_publisher_gateway),
@@ -209,6 +248,59 @@ func (c *Client) PublishIndexArticle(ctx context.Context, payload IndexArticlePa
 	return resp.Msg.MessageId, nil
 }
 
+// PublishAudioGenerationRequested publishes an AudioGenerationRequested event.
+// Callers must check IsEnabled before invoking this; see PublishArticleCreated.
+func (c *Client) PublishAudioGenerationRequested(ctx context.Context, payload AudioGenerationRequestedPayload) (string, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	event := &mqhubv1.Event{
+		EventId:   uuid.New().String(),
+		EventType: EventTypeAudioGenerationRequested,
+		Source:    "alt-backend",
+		CreatedAt: timestamppb.Now(),
+		Payload:   payloadBytes,
+		Metadata:  map[string]string{},
+	}
+
+	resp, err := c.client.Publish(ctx, connect.NewRequest(&mqhubv1.PublishRequest{
+		Stream: StreamKeyAudio,
+		Event:  event,
+	}))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Msg.MessageId, nil
+}
+
+// PublishRecorded publishes an event whose ID, creation time and payload
+// were fixed when it was recorded, so that redelivering it from the outbox
+// publishes the same event rather than a new one.
+// Callers must check IsEnabled before invoking this; see PublishArticleCreated.
+func (c *Client) PublishRecorded(ctx context.Context, stream, eventID, eventType string, createdAt time.Time, payload []byte) (string, error) {
+	event := &mqhubv1.Event{
+		EventId:   eventID,
+		EventType: eventType,
+		Source:    "alt-backend",
+		CreatedAt: timestamppb.New(createdAt),
+		Payload:   payload,
+		Metadata:  map[string]string{},
+	}
+
+	resp, err := c.client.Publish(ctx, connect.NewRequest(&mqhubv1.PublishRequest{
+		Stream: stream,
+		Event:  event,
+	}))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Msg.MessageId, nil
+}
+
 // GenerateTagsRequest represents a request for synchronous tag generation.
 type GenerateTagsRequest struct {
 	ArticleID string
@@ -40,9 +40,9 @@ func (c *Client) CreateLens(ctx context.Context, lens domain.KnowledgeLens) erro
 	return nil
 }
 
-func (c *Client) CreateLensVersion(ctx context.Context, version domain.KnowledgeLensVersion) error {
+func (c *Client) CreateLensVersion(ctx context.Context, version domain.KnowledgeLensVersion, expectedRevision int64) (int64, error) {
 	if !c.enabled {
-		return nil
+		return 0, nil
 	}
 
 	pv := &sovereignv1.LensVersion{
@@ -61,13 +61,14 @@ func (c *Client) CreateLensVersion(ctx context.Context, version domain.Knowledge
 		pv.SupersededBy = version.SupersededBy.String()
 	}
 
-	_, err := c.client.CreateLensVersion(ctx, connect.NewRequest(&sovereignv1.CreateLensVersionRequest{
-		Version: pv,
+	resp, err := c.client.CreateLensVersion(ctx, connect.NewRequest(&sovereignv1.CreateLensVersionRequest{
+		Version:          pv,
+		ExpectedRevision: expectedRevision,
 	}))
 	if err != nil {
-		return fmt.Errorf("sovereign CreateLensVersion: %w", err)
+		return 0, fmt.Errorf("sovereign CreateLensVersion: %w", lensWriteError(err))
 	}
-	return nil
+	return resp.Msg.Revision, nil
 }
 
 func (c *Client) ListLenses(ctx context.Context, userID uuid.UUID) ([]domain.KnowledgeLens, error) {
@@ -191,20 +192,30 @@ func (c *Client) ClearCurrentLens(ctx context.Context, userID uuid.UUID) error {
 	return nil
 }
 
-func (c *Client) ArchiveLens(ctx context.Context, lensID uuid.UUID) error {
+func (c *Client) ArchiveLens(ctx context.Context, lensID uuid.UUID, expectedRevision int64) error {
 	if !c.enabled {
 		return nil
 	}
 
 	_, err := c.client.ArchiveLens(ctx, connect.NewRequest(&sovereignv1.ArchiveLensRequest{
-		LensId: lensID.String(),
+		LensId:           lensID.String(),
+		ExpectedRevision: expectedRevision,
 	}))
 	if err != nil {
-		return fmt.Errorf("sovereign ArchiveLens: %w", err)
+		return fmt.Errorf("sovereign ArchiveLens: %w", lensWriteError(err))
 	}
 	return nil
 }
 
+// lensWriteError translates sovereign's Aborted code, used for a stale
+// expected revision, into domain.ErrLensRevisionConflict.
+func lensWriteError(err error) error {
+	if connect.CodeOf(err) == connect.CodeAborted {
+		return fmt.Errorf("%w: %w", domain.ErrLensRevisionConflict, err)
+	}
+	return err
+}
+
 func (c *Client) ResolveKnowledgeHomeLens(ctx context.Context, userID uuid.UUID, lensID *uuid.UUID) (*domain.KnowledgeHomeLensFilter, error) {
 	if !c.enabled {
 		return nil, nil
@@ -253,6 +264,7 @@ func protoToLens(pb *sovereignv1.Lens) domain.KnowledgeLens {
 		TenantID:    parseUUID(pb.TenantId),
 		Name:        pb.Name,
 		Description: pb.Description,
+		Revision:    pb.Revision,
 	}
 	if pb.CreatedAt != nil {
 		lens.CreatedAt = pb.CreatedAt.AsTime()
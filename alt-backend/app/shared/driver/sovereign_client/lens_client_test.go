@@ -0,0 +1,87 @@
+package sovereign_client
+
+import (
+	"alt/domain"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sovereignv1 "alt/gen/proto/services/sovereign/v1"
+	"alt/gen/proto/services/sovereign/v1/sovereignv1connect"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockLensHandler struct {
+	sovereignv1connect.UnimplementedKnowledgeSovereignServiceHandler
+	currentRevision int64
+	gotExpected     int64
+}
+
+func (m *mockLensHandler) CreateLensVersion(
+	_ context.Context,
+	req *connect.Request[sovereignv1.CreateLensVersionRequest],
+) (*connect.Response[sovereignv1.CreateLensVersionResponse], error) {
+	m.gotExpected = req.Msg.ExpectedRevision
+	if req.Msg.ExpectedRevision != 0 && req.Msg.ExpectedRevision != m.currentRevision {
+		return nil, connect.NewError(connect.CodeAborted, errors.New("lens revision conflict"))
+	}
+	m.currentRevision++
+	return connect.NewResponse(&sovereignv1.CreateLensVersionResponse{Revision: m.currentRevision}), nil
+}
+
+func (m *mockLensHandler) ArchiveLens(
+	_ context.Context,
+	req *connect.Request[sovereignv1.ArchiveLensRequest],
+) (*connect.Response[sovereignv1.ArchiveLensResponse], error) {
+	m.gotExpected = req.Msg.ExpectedRevision
+	if req.Msg.ExpectedRevision != 0 && req.Msg.ExpectedRevision != m.currentRevision {
+		return nil, connect.NewError(connect.CodeAborted, errors.New("lens revision conflict"))
+	}
+	return connect.NewResponse(&sovereignv1.ArchiveLensResponse{}), nil
+}
+
+func setupLensServer(t *testing.T, handler *mockLensHandler) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	path, h := sovereignv1connect.NewKnowledgeSovereignServiceHandler(handler)
+	mux.Handle(path, h)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, true)
+}
+
+func TestCreateLensVersion_ReturnsNewRevision(t *testing.T) {
+	handler := &mockLensHandler{currentRevision: 3}
+	client := setupLensServer(t, handler)
+
+	revision, err := client.CreateLensVersion(context.Background(), domain.KnowledgeLensVersion{
+		LensVersionID: uuid.New(),
+		LensID:        uuid.New(),
+	}, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), handler.gotExpected)
+	assert.Equal(t, int64(4), revision)
+}
+
+func TestLensWrites_AbortedMapsToRevisionConflict(t *testing.T) {
+	client := setupLensServer(t, &mockLensHandler{currentRevision: 5})
+
+	_, err := client.CreateLensVersion(context.Background(), domain.KnowledgeLensVersion{
+		LensVersionID: uuid.New(),
+		LensID:        uuid.New(),
+	}, 4)
+	assert.ErrorIs(t, err, domain.ErrLensRevisionConflict)
+
+	err = client.ArchiveLens(context.Background(), uuid.New(), 4)
+	assert.ErrorIs(t, err, domain.ErrLensRevisionConflict)
+
+	err = client.ArchiveLens(context.Background(), uuid.New(), 5)
+	assert.NoError(t, err)
+}
@@ -154,6 +154,97 @@ func (g *EventPublisherGateway) PublishIndexArticle(ctx context.Context, event e
 	return nil
 }
 
+// PublishAudioGenerationRequested publishes an AudioGenerationRequested event.
+func (g *EventPublisherGateway) PublishAudioGenerationRequested(ctx context.Context, event event_publisher_port.AudioGenerationRequestedEvent) error {
+	if !g.client.IsEnabled() {
+		g.logger.Debug("mqhub disabled, skipping PublishAudioGenerationRequested", "article_id", event.ArticleID)
+		return nil
+	}
+
+	payload := mqhub_connect.AudioGenerationRequestedPayload{
+		ArticleID: event.ArticleID,
+		UserID:    event.UserID,
+		Voice:     event.Voice,
+		Attempt:   event.Attempt,
+	}
+
+	messageID, err := g.client.PublishAudioGenerationRequested(ctx, payload)
+	if err != nil {
+		g.logger.ErrorContext(ctx, "failed to publish AudioGenerationRequested event",
+			"article_id", event.ArticleID,
+			"error", err,
+		)
+		return fmt.Errorf("publish AudioGenerationRequested: %w", err)
+	}
+
+	g.logger.Info("published AudioGenerationRequested event",
+		"article_id", event.ArticleID,
+		"attempt", event.Attempt,
+		"message_id", messageID,
+	)
+	return nil
+}
+
+// PublishArticleDeleted publishes an ArticleDeleted event.
+func (g *EventPublisherGateway) PublishArticleDeleted(ctx context.Context, event event_publisher_port.ArticleDeletedEvent) error {
+	recorded, err := recordArticleDeleted(event)
+	if err != nil {
+		return err
+	}
+	return g.RelayEvent(ctx, recorded)
+}
+
+// PublishFeedSubscribed publishes a FeedSubscribed event.
+func (g *EventPublisherGateway) PublishFeedSubscribed(ctx context.Context, event event_publisher_port.FeedSubscribedEvent) error {
+	recorded, err := recordFeedSubscribed(event)
+	if err != nil {
+		return err
+	}
+	return g.RelayEvent(ctx, recorded)
+}
+
+// PublishFeedUnsubscribed publishes a FeedUnsubscribed event.
+func (g *EventPublisherGateway) PublishFeedUnsubscribed(ctx context.Context, event event_publisher_port.FeedUnsubscribedEvent) error {
+	recorded, err := recordFeedUnsubscribed(event)
+	if err != nil {
+		return err
+	}
+	return g.RelayEvent(ctx, recorded)
+}
+
+// PublishReadStateChanged publishes a ReadStateChanged event.
+func (g *EventPublisherGateway) PublishReadStateChanged(ctx context.Context, event event_publisher_port.ReadStateChangedEvent) error {
+	recorded, err := recordReadStateChanged(event)
+	if err != nil {
+		return err
+	}
+	return g.RelayEvent(ctx, recorded)
+}
+
+// RelayEvent publishes an event recorded earlier, keeping its event ID.
+// It implements EventRelayPort for the outbox worker.
+func (g *EventPublisherGateway) RelayEvent(ctx context.Context, event event_publisher_port.RecordedEvent) error {
+	if !g.client.IsEnabled() {
+		g.logger.Debug("mqhub disabled, skipping "+event.EventType, "event_id", event.EventID)
+		return nil
+	}
+
+	messageID, err := g.client.PublishRecorded(ctx, event.Stream, event.EventID, event.EventType, event.CreatedAt, event.Payload)
+	if err != nil {
+		g.logger.ErrorContext(ctx, "failed to publish "+event.EventType+" event",
+			"event_id", event.EventID,
+			"error", err,
+		)
+		return fmt.Errorf("publish %s: %w", event.EventType, err)
+	}
+
+	g.logger.Info("published "+event.EventType+" event",
+		"event_id", event.EventID,
+		"message_id", messageID,
+	)
+	return nil
+}
+
 // IsEnabled returns true if event publishing is enabled.
 func (g *EventPublisherGateway) IsEnabled() bool {
 	return g.client.IsEnabled()
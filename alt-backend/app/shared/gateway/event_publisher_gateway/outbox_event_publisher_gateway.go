@@ -0,0 +1,146 @@
+package event_publisher_gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"alt/shared/driver/alt_db"
+	"alt/shared/port/event_publisher_port"
+)
+
+// outboxWriter is the part of alt_db.OutboxRepository the gateway needs.
+type outboxWriter interface {
+	SaveOutboxEvent(ctx context.Context, eventType string, payload []byte) error
+}
+
+// OutboxEventPublisherGateway implements EventPublisherPort by recording
+// events in the outbox_events table instead of calling mq-hub. The outbox
+// worker relays them through EventPublisherGateway.RelayEvent and retries
+// deliveries that fail, so an event survives an mq-hub outage or a restart
+// once it has been recorded. Called inside TransactionPort.RunInTx, the row
+// is written in the caller's transaction.
+type OutboxEventPublisherGateway struct {
+	outbox  outboxWriter
+	enabled bool
+	logger  *slog.Logger
+}
+
+// NewOutboxEventPublisherGateway creates a new OutboxEventPublisherGateway.
+// enabled follows the mq-hub client: nothing is recorded while publishing
+// is disabled, since the relay would drop it anyway.
+func NewOutboxEventPublisherGateway(outbox outboxWriter, enabled bool, logger *slog.Logger) *OutboxEventPublisherGateway {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &OutboxEventPublisherGateway{
+		outbox:  outbox,
+		enabled: enabled,
+		logger:  logger,
+	}
+}
+
+// PublishArticleCreated records an ArticleCreated event.
+func (g *OutboxEventPublisherGateway) PublishArticleCreated(ctx context.Context, event event_publisher_port.ArticleCreatedEvent) error {
+	recorded, err := recordArticleCreated(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishArticleUpdated records an ArticleUpdated event.
+func (g *OutboxEventPublisherGateway) PublishArticleUpdated(ctx context.Context, event event_publisher_port.ArticleUpdatedEvent) error {
+	recorded, err := recordArticleUpdated(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishSummarizeRequested records a SummarizeRequested event.
+func (g *OutboxEventPublisherGateway) PublishSummarizeRequested(ctx context.Context, event event_publisher_port.SummarizeRequestedEvent) error {
+	recorded, err := recordSummarizeRequested(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishIndexArticle records an IndexArticle event.
+func (g *OutboxEventPublisherGateway) PublishIndexArticle(ctx context.Context, event event_publisher_port.IndexArticleEvent) error {
+	recorded, err := recordIndexArticle(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishAudioGenerationRequested records an AudioGenerationRequested event.
+func (g *OutboxEventPublisherGateway) PublishAudioGenerationRequested(ctx context.Context, event event_publisher_port.AudioGenerationRequestedEvent) error {
+	recorded, err := recordAudioGenerationRequested(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishArticleDeleted records an ArticleDeleted event.
+func (g *OutboxEventPublisherGateway) PublishArticleDeleted(ctx context.Context, event event_publisher_port.ArticleDeletedEvent) error {
+	recorded, err := recordArticleDeleted(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishFeedSubscribed records a FeedSubscribed event.
+func (g *OutboxEventPublisherGateway) PublishFeedSubscribed(ctx context.Context, event event_publisher_port.FeedSubscribedEvent) error {
+	recorded, err := recordFeedSubscribed(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishFeedUnsubscribed records a FeedUnsubscribed event.
+func (g *OutboxEventPublisherGateway) PublishFeedUnsubscribed(ctx context.Context, event event_publisher_port.FeedUnsubscribedEvent) error {
+	recorded, err := recordFeedUnsubscribed(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// PublishReadStateChanged records a ReadStateChanged event.
+func (g *OutboxEventPublisherGateway) PublishReadStateChanged(ctx context.Context, event event_publisher_port.ReadStateChangedEvent) error {
+	recorded, err := recordReadStateChanged(event)
+	if err != nil {
+		return err
+	}
+	return g.record(ctx, recorded)
+}
+
+// IsEnabled returns true if event publishing is enabled.
+func (g *OutboxEventPublisherGateway) IsEnabled() bool {
+	return g.enabled
+}
+
+func (g *OutboxEventPublisherGateway) record(ctx context.Context, event event_publisher_port.RecordedEvent) error {
+	if !g.enabled {
+		g.logger.Debug("mqhub disabled, skipping "+event.EventType, "event_id", event.EventID)
+		return nil
+	}
+
+	row, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal %s for outbox: %w", event.EventType, err)
+	}
+	if err := g.outbox.SaveOutboxEvent(ctx, alt_db.OutboxEventTypeMQHub, row); err != nil {
+		return fmt.Errorf("record %s: %w", event.EventType, err)
+	}
+
+	g.logger.DebugContext(ctx, "recorded "+event.EventType+" event in outbox", "event_id", event.EventID)
+	return nil
+}
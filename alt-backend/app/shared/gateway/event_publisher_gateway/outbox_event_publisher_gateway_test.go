@@ -0,0 +1,59 @@
+package event_publisher_gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"alt/shared/driver/alt_db"
+	"alt/shared/driver/mqhub_connect"
+	"alt/shared/port/event_publisher_port"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubOutbox struct {
+	eventTypes []string
+	rows       [][]byte
+}
+
+func (s *stubOutbox) SaveOutboxEvent(_ context.Context, eventType string, payload []byte) error {
+	s.eventTypes = append(s.eventTypes, eventType)
+	s.rows = append(s.rows, payload)
+	return nil
+}
+
+func TestOutboxEventPublisherGateway_RecordsEvent(t *testing.T) {
+	outbox := &stubOutbox{}
+	g := NewOutboxEventPublisherGateway(outbox, true, nil)
+	deletedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, g.PublishArticleDeleted(context.Background(), event_publisher_port.ArticleDeletedEvent{
+		ArticleID: "a1",
+		UserID:    "u1",
+		DeletedAt: deletedAt,
+	}))
+
+	require.Equal(t, []string{alt_db.OutboxEventTypeMQHub}, outbox.eventTypes)
+	var recorded event_publisher_port.RecordedEvent
+	require.NoError(t, json.Unmarshal(outbox.rows[0], &recorded))
+	assert.NotEmpty(t, recorded.EventID)
+	assert.Equal(t, mqhub_connect.EventTypeArticleDeleted, recorded.EventType)
+	assert.Equal(t, mqhub_connect.StreamKeyArticles, recorded.Stream)
+	assert.JSONEq(t, `{"article_id":"a1","user_id":"u1","deleted_at":"2026-10-16T12:00:00Z"}`, string(recorded.Payload))
+}
+
+func TestOutboxEventPublisherGateway_DisabledRecordsNothing(t *testing.T) {
+	outbox := &stubOutbox{}
+	g := NewOutboxEventPublisherGateway(outbox, false, nil)
+
+	require.NoError(t, g.PublishFeedSubscribed(context.Background(), event_publisher_port.FeedSubscribedEvent{
+		UserID:     "u1",
+		FeedLinkID: "f1",
+	}))
+
+	assert.False(t, g.IsEnabled())
+	assert.Empty(t, outbox.rows)
+}
@@ -0,0 +1,110 @@
+package event_publisher_gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"alt/shared/driver/mqhub_connect"
+	"alt/shared/port/event_publisher_port"
+
+	"github.com/google/uuid"
+)
+
+// newRecordedEvent stamps an mq-hub event with its ID and creation time.
+func newRecordedEvent(stream, eventType string, payload any) (event_publisher_port.RecordedEvent, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return event_publisher_port.RecordedEvent{}, fmt.Errorf("marshal %s payload: %w", eventType, err)
+	}
+	return event_publisher_port.RecordedEvent{
+		EventID:   uuid.New().String(),
+		EventType: eventType,
+		Stream:    stream,
+		CreatedAt: time.Now().UTC(),
+		Payload:   payloadBytes,
+	}, nil
+}
+
+func recordArticleCreated(event event_publisher_port.ArticleCreatedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyArticles, mqhub_connect.EventTypeArticleCreated, mqhub_connect.ArticleCreatedPayload{
+		ArticleID:   event.ArticleID,
+		UserID:      event.UserID,
+		FeedID:      event.FeedID,
+		Title:       event.Title,
+		URL:         event.URL,
+		Content:     event.Content,
+		Tags:        event.Tags,
+		PublishedAt: event.PublishedAt,
+	})
+}
+
+func recordArticleUpdated(event event_publisher_port.ArticleUpdatedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyArticles, mqhub_connect.EventTypeArticleUpdated, mqhub_connect.ArticleCreatedPayload{
+		ArticleID:   event.ArticleID,
+		UserID:      event.UserID,
+		FeedID:      event.FeedID,
+		Title:       event.Title,
+		URL:         event.URL,
+		Content:     event.Content,
+		Tags:        event.Tags,
+		PublishedAt: event.PublishedAt,
+	})
+}
+
+func recordSummarizeRequested(event event_publisher_port.SummarizeRequestedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyArticles, mqhub_connect.EventTypeSummarizeRequested, mqhub_connect.SummarizeRequestedPayload{
+		ArticleID: event.ArticleID,
+		UserID:    event.UserID,
+		Title:     event.Title,
+		Streaming: event.Streaming,
+	})
+}
+
+func recordIndexArticle(event event_publisher_port.IndexArticleEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyIndex, mqhub_connect.EventTypeIndexArticle, mqhub_connect.IndexArticlePayload{
+		ArticleID: event.ArticleID,
+		UserID:    event.UserID,
+		FeedID:    event.FeedID,
+	})
+}
+
+func recordAudioGenerationRequested(event event_publisher_port.AudioGenerationRequestedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyAudio, mqhub_connect.EventTypeAudioGenerationRequested, mqhub_connect.AudioGenerationRequestedPayload{
+		ArticleID: event.ArticleID,
+		UserID:    event.UserID,
+		Voice:     event.Voice,
+		Attempt:   event.Attempt,
+	})
+}
+
+func recordArticleDeleted(event event_publisher_port.ArticleDeletedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyArticles, mqhub_connect.EventTypeArticleDeleted, mqhub_connect.ArticleDeletedPayload{
+		ArticleID: event.ArticleID,
+		UserID:    event.UserID,
+		DeletedAt: event.DeletedAt,
+	})
+}
+
+func recordFeedSubscribed(event event_publisher_port.FeedSubscribedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyFeeds, mqhub_connect.EventTypeFeedSubscribed, mqhub_connect.FeedSubscriptionPayload{
+		UserID:     event.UserID,
+		FeedLinkID: event.FeedLinkID,
+	})
+}
+
+func recordFeedUnsubscribed(event event_publisher_port.FeedUnsubscribedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyFeeds, mqhub_connect.EventTypeFeedUnsubscribed, mqhub_connect.FeedSubscriptionPayload{
+		UserID:     event.UserID,
+		FeedLinkID: event.FeedLinkID,
+	})
+}
+
+func recordReadStateChanged(event event_publisher_port.ReadStateChangedEvent) (event_publisher_port.RecordedEvent, error) {
+	return newRecordedEvent(mqhub_connect.StreamKeyFeeds, mqhub_connect.EventTypeReadStateChanged, mqhub_connect.ReadStateChangedPayload{
+		UserID:    event.UserID,
+		FeedURL:   event.FeedURL,
+		IsRead:    event.IsRead,
+		ChangedAt: event.ChangedAt,
+	})
+}
@@ -0,0 +1,96 @@
+package event_publisher_gateway
+
+import (
+	"context"
+	"time"
+
+	"alt/domain"
+	"alt/shared/driver/alt_db"
+	"alt/shared/port/event_publisher_port"
+)
+
+// timelineSink is the part of timeline_stream_usecase.Usecase the gateway
+// needs.
+type timelineSink interface {
+	Publish(event domain.TimelineEvent)
+}
+
+// TimelineEventPublisherGateway wraps an EventPublisherPort and, once an
+// ArticleCreated or ReadStateChanged event has been published, pushes it
+// to the user's live timeline streams. Every other event, and IsEnabled,
+// is passed through unchanged, so the timeline sees what the wrapped
+// publisher publishes. An event recorded inside a transaction reaches the
+// timeline only once the transaction commits.
+type TimelineEventPublisherGateway struct {
+	event_publisher_port.EventPublisherPort
+	timeline timelineSink
+}
+
+// NewTimelineEventPublisherGateway creates a new TimelineEventPublisherGateway.
+func NewTimelineEventPublisherGateway(next event_publisher_port.EventPublisherPort, timeline timelineSink) *TimelineEventPublisherGateway {
+	return &TimelineEventPublisherGateway{EventPublisherPort: next, timeline: timeline}
+}
+
+// PublishArticleCreated publishes an ArticleCreated event and adds the
+// article to the user's timeline. Articles a user registers through a feed
+// carry no UserID; the user in ctx owns them.
+func (g *TimelineEventPublisherGateway) PublishArticleCreated(ctx context.Context, event event_publisher_port.ArticleCreatedEvent) error {
+	if err := g.EventPublisherPort.PublishArticleCreated(ctx, event); err != nil {
+		return err
+	}
+
+	var publishedAt *time.Time
+	if !event.PublishedAt.IsZero() {
+		publishedAt = &event.PublishedAt
+	}
+	g.publish(ctx, domain.TimelineEvent{
+		Type:        domain.TimelineEventArticleCreated,
+		UserID:      timelineUserID(ctx, event.UserID),
+		ArticleID:   event.ArticleID,
+		FeedID:      event.FeedID,
+		Title:       event.Title,
+		URL:         event.URL,
+		PublishedAt: publishedAt,
+		OccurredAt:  time.Now().UTC(),
+	})
+	return nil
+}
+
+// PublishReadStateChanged publishes a ReadStateChanged event and tells the
+// user's other open timelines about it.
+func (g *TimelineEventPublisherGateway) PublishReadStateChanged(ctx context.Context, event event_publisher_port.ReadStateChangedEvent) error {
+	if err := g.EventPublisherPort.PublishReadStateChanged(ctx, event); err != nil {
+		return err
+	}
+
+	isRead := event.IsRead
+	changedAt := event.ChangedAt
+	if changedAt.IsZero() {
+		changedAt = time.Now()
+	}
+	g.publish(ctx, domain.TimelineEvent{
+		Type:       domain.TimelineEventReadStateChanged,
+		UserID:     timelineUserID(ctx, event.UserID),
+		FeedURL:    event.FeedURL,
+		IsRead:     &isRead,
+		OccurredAt: changedAt.UTC(),
+	})
+	return nil
+}
+
+// publish hands event to the timeline once the transaction in ctx, if any,
+// has committed.
+func (g *TimelineEventPublisherGateway) publish(ctx context.Context, event domain.TimelineEvent) {
+	alt_db.AfterCommit(ctx, func() { g.timeline.Publish(event) })
+}
+
+// timelineUserID returns userID, or the user in ctx when it is empty.
+func timelineUserID(ctx context.Context, userID string) string {
+	if userID != "" {
+		return userID
+	}
+	if user, err := domain.GetUserFromContext(ctx); err == nil {
+		return user.UserID.String()
+	}
+	return ""
+}
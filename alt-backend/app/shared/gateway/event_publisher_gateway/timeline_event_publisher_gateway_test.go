@@ -0,0 +1,108 @@
+package event_publisher_gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"alt/domain"
+	"alt/shared/port/event_publisher_port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTimeline struct {
+	events []domain.TimelineEvent
+}
+
+func (s *stubTimeline) Publish(event domain.TimelineEvent) {
+	s.events = append(s.events, event)
+}
+
+type failingOutbox struct{}
+
+func (failingOutbox) SaveOutboxEvent(context.Context, string, []byte) error {
+	return errors.New("db down")
+}
+
+func TestTimelineEventPublisherGateway_ArticleCreated(t *testing.T) {
+	outbox := &stubOutbox{}
+	timeline := &stubTimeline{}
+	g := NewTimelineEventPublisherGateway(NewOutboxEventPublisherGateway(outbox, true, nil), timeline)
+	publishedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, g.PublishArticleCreated(context.Background(), event_publisher_port.ArticleCreatedEvent{
+		ArticleID:   "a1",
+		UserID:      "u1",
+		FeedID:      "f1",
+		Title:       "Title",
+		URL:         "https://example.com/a1",
+		Content:     "body",
+		PublishedAt: publishedAt,
+	}))
+
+	assert.Len(t, outbox.rows, 1, "the wrapped publisher still records the event")
+	require.Len(t, timeline.events, 1)
+	got := timeline.events[0]
+	assert.Equal(t, domain.TimelineEventArticleCreated, got.Type)
+	assert.Equal(t, "u1", got.UserID)
+	assert.Equal(t, "a1", got.ArticleID)
+	assert.Equal(t, "f1", got.FeedID)
+	assert.Equal(t, &publishedAt, got.PublishedAt)
+	assert.False(t, got.OccurredAt.IsZero())
+}
+
+func TestTimelineEventPublisherGateway_UserFromContext(t *testing.T) {
+	timeline := &stubTimeline{}
+	g := NewTimelineEventPublisherGateway(NewOutboxEventPublisherGateway(&stubOutbox{}, true, nil), timeline)
+	userID := uuid.New()
+	ctx := domain.SetUserContext(context.Background(), &domain.UserContext{
+		UserID:    userID,
+		Email:     "user@example.com",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	changedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, g.PublishArticleCreated(ctx, event_publisher_port.ArticleCreatedEvent{ArticleID: "a1"}))
+	require.NoError(t, g.PublishReadStateChanged(ctx, event_publisher_port.ReadStateChangedEvent{
+		FeedURL:   "https://example.com/feed",
+		IsRead:    true,
+		ChangedAt: changedAt,
+	}))
+
+	require.Len(t, timeline.events, 2)
+	assert.Equal(t, userID.String(), timeline.events[0].UserID)
+	assert.Nil(t, timeline.events[0].PublishedAt)
+
+	read := timeline.events[1]
+	assert.Equal(t, domain.TimelineEventReadStateChanged, read.Type)
+	assert.Equal(t, userID.String(), read.UserID)
+	assert.Equal(t, "https://example.com/feed", read.FeedURL)
+	require.NotNil(t, read.IsRead)
+	assert.True(t, *read.IsRead)
+	assert.Equal(t, changedAt, read.OccurredAt)
+}
+
+func TestTimelineEventPublisherGateway_FailedPublishIsNotStreamed(t *testing.T) {
+	timeline := &stubTimeline{}
+	g := NewTimelineEventPublisherGateway(NewOutboxEventPublisherGateway(failingOutbox{}, true, nil), timeline)
+
+	err := g.PublishArticleCreated(context.Background(), event_publisher_port.ArticleCreatedEvent{ArticleID: "a1", UserID: "u1"})
+
+	assert.Error(t, err)
+	assert.Empty(t, timeline.events)
+}
+
+func TestTimelineEventPublisherGateway_PassesThroughOtherEvents(t *testing.T) {
+	outbox := &stubOutbox{}
+	timeline := &stubTimeline{}
+	g := NewTimelineEventPublisherGateway(NewOutboxEventPublisherGateway(outbox, false, nil), timeline)
+
+	require.NoError(t, g.PublishFeedSubscribed(context.Background(), event_publisher_port.FeedSubscribedEvent{UserID: "u1", FeedLinkID: "f1"}))
+
+	assert.False(t, g.IsEnabled(), "IsEnabled follows the wrapped publisher")
+	assert.Empty(t, timeline.events)
+}
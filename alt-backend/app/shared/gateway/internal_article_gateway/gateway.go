@@ -118,7 +118,7 @@ func (g *Gateway) CreateArticle(ctx context.Context, params internal_article_por
 
 // SaveArticleSummary implements SaveArticleSummaryPort.
 func (g *Gateway) SaveArticleSummary(ctx context.Context, articleID string, userID string, summary string, language string) error {
-	err := g.repo.SaveArticleSummary(ctx, articleID, userID, "", summary)
+	err := g.repo.SaveArticleSummaryWithLanguage(ctx, articleID, userID, "", summary, language)
 	if err != nil {
 		return fmt.Errorf("SaveArticleSummary: %w", err)
 	}
@@ -346,6 +346,34 @@ func (g *Gateway) HasUnsummarizedArticles(ctx context.Context) (bool, error) {
 	return has, nil
 }
 
+// ListArticlesForReindex implements ListArticlesForReindexPort.
+func (g *Gateway) ListArticlesForReindex(ctx context.Context, filter internal_article_port.ReindexArticleFilter, lastCreatedAt *time.Time, lastID string, limit int) ([]*internal_article_port.ReindexArticle, *time.Time, string, error) {
+	driverArticles, nextCreatedAt, nextID, err := g.repo.ListArticlesForReindex(ctx, alt_db.InternalReindexArticleFilter{
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		FeedID:      filter.FeedID,
+		UserID:      filter.UserID,
+	}, lastCreatedAt, lastID, limit)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("ListArticlesForReindex: %w", err)
+	}
+
+	articles := make([]*internal_article_port.ReindexArticle, len(driverArticles))
+	for i, da := range driverArticles {
+		articles[i] = &internal_article_port.ReindexArticle{
+			ID:        da.ID,
+			Title:     da.Title,
+			Content:   da.Content,
+			URL:       da.URL,
+			FeedID:    da.FeedID,
+			UserID:    da.UserID,
+			CreatedAt: da.CreatedAt,
+		}
+	}
+
+	return articles, nextCreatedAt, nextID, nil
+}
+
 // GetEmptyFeedID implements GetEmptyFeedIDPort.
 func (g *Gateway) GetEmptyFeedID(ctx context.Context, feedURL string) (string, error) {
 	feedID, err := g.repo.GetEmptyFeedID(ctx, feedURL)
@@ -364,5 +392,8 @@ func toPortArticle(da *alt_db.InternalArticleWithTags) *internal_article_port.Ar
 		CreatedAt: da.CreatedAt,
 		UserID:    da.UserID,
 		Language:  da.Language,
+		FeedID:    da.FeedID,
+		FeedTitle: da.FeedTitle,
+		Author:    da.Author,
 	}
 }
@@ -3,6 +3,7 @@ package event_publisher_port
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
@@ -45,6 +46,55 @@ type IndexArticleEvent struct {
 	FeedID    string
 }
 
+// AudioGenerationRequestedEvent asks the TTS worker to read an article
+// aloud. Attempt counts requests for the same article, so the worker can
+// drop deliveries of a request that has since been superseded.
+type AudioGenerationRequestedEvent struct {
+	ArticleID string
+	UserID    string
+	Voice     string // empty selects the TTS service's default voice
+	Attempt   int
+}
+
+// ArticleDeletedEvent represents a user deleting one of their articles.
+type ArticleDeletedEvent struct {
+	ArticleID string
+	UserID    string
+	DeletedAt time.Time
+}
+
+// FeedSubscribedEvent represents a user subscribing to a feed link.
+type FeedSubscribedEvent struct {
+	UserID     string
+	FeedLinkID string
+}
+
+// FeedUnsubscribedEvent represents a user unsubscribing from a feed link.
+type FeedUnsubscribedEvent struct {
+	UserID     string
+	FeedLinkID string
+}
+
+// ReadStateChangedEvent represents a user marking a feed read or unread.
+// FeedURL is the URL the user marked, as they sent it.
+type ReadStateChangedEvent struct {
+	UserID    string
+	FeedURL   string
+	IsRead    bool
+	ChangedAt time.Time
+}
+
+// RecordedEvent is an event stored in the outbox until it is relayed to
+// mq-hub. The relay publishes it unchanged, so a retried delivery keeps
+// its EventID and CreatedAt.
+type RecordedEvent struct {
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Stream    string          `json:"stream"`
+	CreatedAt time.Time       `json:"created_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
 // EventPublisherPort defines the interface for publishing domain events.
 type EventPublisherPort interface {
 	// PublishArticleCreated publishes an ArticleCreated event.
@@ -59,6 +109,27 @@ type EventPublisherPort interface {
 	// PublishIndexArticle publishes an IndexArticle event.
 	PublishIndexArticle(ctx context.Context, event IndexArticleEvent) error
 
+	// PublishAudioGenerationRequested publishes an AudioGenerationRequested event.
+	PublishAudioGenerationRequested(ctx context.Context, event AudioGenerationRequestedEvent) error
+
+	// PublishArticleDeleted publishes an ArticleDeleted event.
+	PublishArticleDeleted(ctx context.Context, event ArticleDeletedEvent) error
+
+	// PublishFeedSubscribed publishes a FeedSubscribed event.
+	PublishFeedSubscribed(ctx context.Context, event FeedSubscribedEvent) error
+
+	// PublishFeedUnsubscribed publishes a FeedUnsubscribed event.
+	PublishFeedUnsubscribed(ctx context.Context, event FeedUnsubscribedEvent) error
+
+	// PublishReadStateChanged publishes a ReadStateChanged event.
+	PublishReadStateChanged(ctx context.Context, event ReadStateChangedEvent) error
+
 	// IsEnabled returns true if event publishing is enabled.
 	IsEnabled() bool
 }
+
+// EventRelayPort delivers events recorded in the outbox to mq-hub.
+type EventRelayPort interface {
+	// RelayEvent publishes a recorded event as-is.
+	RelayEvent(ctx context.Context, event RecordedEvent) error
+}
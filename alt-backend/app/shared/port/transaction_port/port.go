@@ -0,0 +1,11 @@
+package transaction_port
+
+import "context"
+
+// TransactionPort runs several repository calls as one unit of work.
+type TransactionPort interface {
+	// RunInTx runs fn in a database transaction that is committed when fn
+	// returns nil and rolled back otherwise. Repository calls made with the
+	// ctx passed to fn join the transaction.
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
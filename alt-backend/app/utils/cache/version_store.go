@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// VersionStore hands out monotonically increasing cache versions per scope
+// (typically a user ID). Write paths call Bump after a successful mutation;
+// readers compare the version they cached against Version to decide whether
+// a remembered response may still be reused.
+//
+// A scope's effective version also moves when BumpAll is called, so data
+// shared by every user (feed contents refreshed by a background job) can
+// invalidate everything at once.
+//
+// A nil *VersionStore is valid: Version always returns 0 and bumps are
+// no-ops.
+type VersionStore struct {
+	global atomic.Uint64
+	mu     sync.RWMutex
+	scopes map[string]uint64
+}
+
+// NewVersionStore creates an empty VersionStore. Unknown scopes start at
+// version 0.
+func NewVersionStore() *VersionStore {
+	return &VersionStore{scopes: make(map[string]uint64)}
+}
+
+// Version returns the current version of scope, combined with the global
+// version.
+func (s *VersionStore) Version(scope string) uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.RLock()
+	v := s.scopes[scope]
+	s.mu.RUnlock()
+	return v + s.global.Load()
+}
+
+// Bump invalidates everything cached under scope.
+func (s *VersionStore) Bump(scope string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.scopes[scope]++
+	s.mu.Unlock()
+}
+
+// BumpAll invalidates every scope.
+func (s *VersionStore) BumpAll() {
+	if s == nil {
+		return
+	}
+	s.global.Add(1)
+}
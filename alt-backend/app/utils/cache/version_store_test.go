@@ -0,0 +1,36 @@
+package cache
+
+import "testing"
+
+func TestVersionStore_BumpIsScoped(t *testing.T) {
+	s := NewVersionStore()
+
+	if got := s.Version("alice"); got != 0 {
+		t.Fatalf("Version(alice) = %d, want 0", got)
+	}
+
+	s.Bump("alice")
+	if got := s.Version("alice"); got != 1 {
+		t.Fatalf("Version(alice) after Bump = %d, want 1", got)
+	}
+	if got := s.Version("bob"); got != 0 {
+		t.Fatalf("Version(bob) = %d, want 0 after bumping alice", got)
+	}
+
+	s.BumpAll()
+	if got := s.Version("alice"); got != 2 {
+		t.Fatalf("Version(alice) after BumpAll = %d, want 2", got)
+	}
+	if got := s.Version("bob"); got != 1 {
+		t.Fatalf("Version(bob) after BumpAll = %d, want 1", got)
+	}
+}
+
+func TestVersionStore_NilIsNoop(t *testing.T) {
+	var s *VersionStore
+	s.Bump("alice")
+	s.BumpAll()
+	if got := s.Version("alice"); got != 0 {
+		t.Fatalf("nil Version = %d, want 0", got)
+	}
+}
@@ -0,0 +1,93 @@
+package html_parser
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// feedLinkTypes maps <link type> values that advertise a feed to the feed
+// format they declare. Plain application/json is deliberately absent:
+// WordPress uses it on rel=alternate links to its REST API, not to a feed.
+var feedLinkTypes = map[string]string{
+	"application/rss+xml":   "rss",
+	"application/atom+xml":  "atom",
+	"application/feed+json": "json",
+}
+
+// FeedLink is a feed advertised by a page through <link rel="alternate">.
+type FeedLink struct {
+	URL    string
+	Title  string
+	Format string
+}
+
+// ExtractFeedLinks returns the feeds a page advertises via
+// <link rel="alternate" type="application/rss+xml|atom+xml|feed+json"> tags,
+// in document order. Relative hrefs are resolved against baseURL; duplicates
+// and non-http(s) URLs are dropped.
+func ExtractFeedLinks(raw, baseURL string) []FeedLink {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(trimmed))
+	if err != nil {
+		return nil
+	}
+
+	var base *url.URL
+	if b := strings.TrimSpace(baseURL); b != "" {
+		if parsed, perr := url.Parse(b); perr == nil {
+			base = parsed
+		}
+	}
+
+	seen := make(map[string]struct{})
+	var links []FeedLink
+	doc.Find("link[href]").Each(func(_ int, s *goquery.Selection) {
+		if !hasRelToken(s.AttrOr("rel", ""), "alternate") {
+			return
+		}
+		mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(s.AttrOr("type", ""), ";", 2)[0]))
+		format, ok := feedLinkTypes[mediaType]
+		if !ok {
+			return
+		}
+
+		href, err := url.Parse(strings.TrimSpace(s.AttrOr("href", "")))
+		if err != nil {
+			return
+		}
+		if base != nil {
+			href = base.ResolveReference(href)
+		}
+		if href.Scheme != "http" && href.Scheme != "https" {
+			return
+		}
+		href.Fragment = ""
+		abs := href.String()
+		if _, dup := seen[abs]; dup {
+			return
+		}
+		seen[abs] = struct{}{}
+
+		links = append(links, FeedLink{
+			URL:    abs,
+			Title:  strings.TrimSpace(s.AttrOr("title", "")),
+			Format: format,
+		})
+	})
+	return links
+}
+
+func hasRelToken(rel, token string) bool {
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		if r == token {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package html_parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractFeedLinks(t *testing.T) {
+	raw := `<html><head>
+		<link rel="stylesheet" href="/style.css">
+		<link rel="alternate" type="application/rss+xml" title="Main feed" href="/feed.xml">
+		<link rel="alternate" type="application/atom+xml; charset=utf-8" href="https://blog.example.com/atom.xml#top">
+		<link rel="Alternate" type="application/feed+json" title=" JSON " href="feed.json">
+		<link rel="alternate" type="application/json" href="/wp-json/wp/v2/pages/1">
+		<link rel="alternate" type="application/rss+xml" href="/feed.xml">
+		<link rel="alternate" type="application/rss+xml" href="javascript:alert(1)">
+		<link rel="alternate" hreflang="ja" href="/ja/">
+	</head><body></body></html>`
+
+	links := ExtractFeedLinks(raw, "https://blog.example.com/posts/")
+
+	assert.Equal(t, []FeedLink{
+		{URL: "https://blog.example.com/feed.xml", Title: "Main feed", Format: "rss"},
+		{URL: "https://blog.example.com/atom.xml", Format: "atom"},
+		{URL: "https://blog.example.com/posts/feed.json", Title: "JSON", Format: "json"},
+	}, links)
+}
+
+func TestExtractFeedLinks_Empty(t *testing.T) {
+	assert.Nil(t, ExtractFeedLinks("", "https://example.com"))
+	assert.Nil(t, ExtractFeedLinks("<html><head></head></html>", "https://example.com"))
+}
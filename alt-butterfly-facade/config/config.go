@@ -4,6 +4,7 @@ package config
 import (
 	"errors"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -35,6 +36,16 @@ type Config struct {
 	// AcolyteConnectURL is the URL of the Acolyte orchestrator (e.g., http://acolyte-orchestrator:8090)
 	AcolyteConnectURL string
 
+	// TTS Queue Configuration
+	// TTSMaxConcurrentPerUser is the number of synthesis requests a user may run at once
+	TTSMaxConcurrentPerUser int
+	// TTSMaxQueuedPerUser is the number of synthesis requests a user may have waiting
+	TTSMaxQueuedPerUser int
+	// TTSAudioCacheSize is the maximum number of cached audio responses (0 disables the cache)
+	TTSAudioCacheSize int
+	// TTSAudioCacheTTL is how long generated audio is served from the cache
+	TTSAudioCacheTTL time.Duration
+
 	// BFF Feature Flags
 	// EnableCache enables response caching
 	EnableCache bool
@@ -80,6 +91,12 @@ func NewConfig() *Config {
 		TTSConnectURL:          getEnv("TTS_CONNECT_URL", ""),
 		AcolyteConnectURL:      getEnv("ACOLYTE_CONNECT_URL", ""),
 
+		// TTS Queue Configuration
+		TTSMaxConcurrentPerUser: getIntEnv("TTS_MAX_CONCURRENT_PER_USER", 1),
+		TTSMaxQueuedPerUser:     getIntEnv("TTS_MAX_QUEUED_PER_USER", 5),
+		TTSAudioCacheSize:       getIntEnv("TTS_AUDIO_CACHE_SIZE", 100),
+		TTSAudioCacheTTL:        getDurationEnv("TTS_AUDIO_CACHE_TTL", 24*time.Hour),
+
 		// BFF Feature Flags (all enabled by default)
 		EnableCache:              true,
 		EnableCircuitBreaker:     true,
@@ -145,6 +162,15 @@ func (c *Config) Validate() error {
 	if c.BackendConnectURL == "" {
 		return errors.New("BACKEND_CONNECT_URL is required")
 	}
+	if c.TTSMaxConcurrentPerUser < 1 {
+		return errors.New("TTS_MAX_CONCURRENT_PER_USER must be at least 1")
+	}
+	if c.TTSMaxQueuedPerUser < 1 {
+		return errors.New("TTS_MAX_QUEUED_PER_USER must be at least 1")
+	}
+	if c.TTSAudioCacheSize < 0 {
+		return errors.New("TTS_AUDIO_CACHE_SIZE must not be negative")
+	}
 	return nil
 }
 
@@ -165,3 +191,13 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getIntEnv returns the value of an environment variable as an int or a default value.
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
@@ -141,6 +141,16 @@ func TestConfig_Validate(t *testing.T) {
 			modify:  func(c *Config) { c.BackendConnectURL = "" },
 			wantErr: true,
 		},
+		{
+			name:    "zero TTS concurrency",
+			modify:  func(c *Config) { c.TTSMaxConcurrentPerUser = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "negative TTS audio cache size",
+			modify:  func(c *Config) { c.TTSAudioCacheSize = -1 },
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -159,6 +169,28 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestNewConfig_TTSQueue(t *testing.T) {
+	os.Clearenv()
+
+	cfg := NewConfig()
+	assert.Equal(t, 1, cfg.TTSMaxConcurrentPerUser)
+	assert.Equal(t, 5, cfg.TTSMaxQueuedPerUser)
+	assert.Equal(t, 100, cfg.TTSAudioCacheSize)
+	assert.Equal(t, 24*time.Hour, cfg.TTSAudioCacheTTL)
+
+	os.Setenv("TTS_MAX_CONCURRENT_PER_USER", "2")
+	os.Setenv("TTS_MAX_QUEUED_PER_USER", "10")
+	os.Setenv("TTS_AUDIO_CACHE_SIZE", "not-a-number")
+	os.Setenv("TTS_AUDIO_CACHE_TTL", "6h")
+	defer os.Clearenv()
+
+	cfg = NewConfig()
+	assert.Equal(t, 2, cfg.TTSMaxConcurrentPerUser)
+	assert.Equal(t, 10, cfg.TTSMaxQueuedPerUser)
+	assert.Equal(t, 100, cfg.TTSAudioCacheSize, "invalid values fall back to the default")
+	assert.Equal(t, 6*time.Hour, cfg.TTSAudioCacheTTL)
+}
+
 func TestNewConfig_BFFFeatureFlags_Defaults(t *testing.T) {
 	os.Clearenv()
 
@@ -0,0 +1,226 @@
+// Package handler provides HTTP handlers for the BFF service.
+package handler
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrTTSQueueFull is returned by TTSQueue.Acquire when the user already has
+// the maximum number of synthesis requests waiting.
+var ErrTTSQueueFull = errors.New("tts queue full")
+
+// TTSQueueEntry describes one waiting synthesis request.
+type TTSQueueEntry struct {
+	RequestID string `json:"request_id"`
+	// Position is 1 for the request that runs next.
+	Position int `json:"position"`
+}
+
+// TTSQueueStatus is a snapshot of one user's synthesis queue.
+type TTSQueueStatus struct {
+	Active  int             `json:"active"`
+	Limit   int             `json:"limit"`
+	Waiting []TTSQueueEntry `json:"waiting"`
+}
+
+type ttsWaiter struct {
+	requestID string
+	ready     chan struct{}
+	granted   bool
+}
+
+type ttsUserQueue struct {
+	active      int
+	waiting     *list.List // of *ttsWaiter, front = next to run
+	subscribers map[chan TTSQueueStatus]struct{}
+}
+
+// TTSQueue limits concurrent synthesis requests per user. Requests beyond the
+// limit wait in FIFO order; waiters and subscribers are notified on every
+// change so the status stream can report queue positions.
+type TTSQueue struct {
+	mu            sync.Mutex
+	users         map[uuid.UUID]*ttsUserQueue
+	maxConcurrent int
+	maxWaiting    int
+}
+
+// NewTTSQueue creates a queue allowing maxConcurrent in-flight requests and
+// maxWaiting queued requests per user. Values below 1 are raised to 1.
+func NewTTSQueue(maxConcurrent, maxWaiting int) *TTSQueue {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if maxWaiting < 1 {
+		maxWaiting = 1
+	}
+	return &TTSQueue{
+		users:         make(map[uuid.UUID]*ttsUserQueue),
+		maxConcurrent: maxConcurrent,
+		maxWaiting:    maxWaiting,
+	}
+}
+
+// Acquire blocks until the user has a free synthesis slot, ctx is done, or
+// the user's queue is full. The returned release func must be called once the
+// request finishes; calling it more than once is safe.
+func (q *TTSQueue) Acquire(ctx context.Context, userID uuid.UUID, requestID string) (func(), error) {
+	q.mu.Lock()
+	uq := q.userQueue(userID)
+
+	if uq.active < q.maxConcurrent && uq.waiting.Len() == 0 {
+		uq.active++
+		q.notifyLocked(uq)
+		q.mu.Unlock()
+		return q.releaseFunc(userID), nil
+	}
+
+	if uq.waiting.Len() >= q.maxWaiting {
+		q.cleanupLocked(userID, uq)
+		q.mu.Unlock()
+		return nil, ErrTTSQueueFull
+	}
+
+	w := &ttsWaiter{requestID: requestID, ready: make(chan struct{})}
+	el := uq.waiting.PushBack(w)
+	q.notifyLocked(uq)
+	q.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return q.releaseFunc(userID), nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		if w.granted {
+			// The slot was handed over concurrently with cancellation;
+			// pass it on instead of leaking it.
+			q.mu.Unlock()
+			q.releaseFunc(userID)()
+			return nil, ctx.Err()
+		}
+		uq.waiting.Remove(el)
+		q.notifyLocked(uq)
+		q.cleanupLocked(userID, uq)
+		q.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// Status returns the current queue snapshot for userID.
+func (q *TTSQueue) Status(userID uuid.UUID) TTSQueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	uq, ok := q.users[userID]
+	if !ok {
+		return TTSQueueStatus{Limit: q.maxConcurrent, Waiting: []TTSQueueEntry{}}
+	}
+	return q.statusLocked(uq)
+}
+
+// Subscribe returns a channel that receives the user's queue status
+// immediately and after every change. Only the latest status is buffered;
+// slow readers skip intermediate states. The cancel func must be called to
+// unsubscribe.
+func (q *TTSQueue) Subscribe(userID uuid.UUID) (<-chan TTSQueueStatus, func()) {
+	ch := make(chan TTSQueueStatus, 1)
+
+	q.mu.Lock()
+	uq := q.userQueue(userID)
+	uq.subscribers[ch] = struct{}{}
+	ch <- q.statusLocked(uq)
+	q.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			if uq, ok := q.users[userID]; ok {
+				delete(uq.subscribers, ch)
+				q.cleanupLocked(userID, uq)
+			}
+		})
+	}
+}
+
+func (q *TTSQueue) releaseFunc(userID uuid.UUID) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+
+			uq, ok := q.users[userID]
+			if !ok {
+				return
+			}
+			uq.active--
+			if front := uq.waiting.Front(); front != nil && uq.active < q.maxConcurrent {
+				w := uq.waiting.Remove(front).(*ttsWaiter)
+				w.granted = true
+				uq.active++
+				close(w.ready)
+			}
+			q.notifyLocked(uq)
+			q.cleanupLocked(userID, uq)
+		})
+	}
+}
+
+func (q *TTSQueue) userQueue(userID uuid.UUID) *ttsUserQueue {
+	uq, ok := q.users[userID]
+	if !ok {
+		uq = &ttsUserQueue{
+			waiting:     list.New(),
+			subscribers: make(map[chan TTSQueueStatus]struct{}),
+		}
+		q.users[userID] = uq
+	}
+	return uq
+}
+
+// cleanupLocked drops idle users so the map does not grow with every user
+// that ever synthesized audio.
+func (q *TTSQueue) cleanupLocked(userID uuid.UUID, uq *ttsUserQueue) {
+	if uq.active == 0 && uq.waiting.Len() == 0 && len(uq.subscribers) == 0 {
+		delete(q.users, userID)
+	}
+}
+
+func (q *TTSQueue) statusLocked(uq *ttsUserQueue) TTSQueueStatus {
+	status := TTSQueueStatus{
+		Active:  uq.active,
+		Limit:   q.maxConcurrent,
+		Waiting: make([]TTSQueueEntry, 0, uq.waiting.Len()),
+	}
+	position := 1
+	for el := uq.waiting.Front(); el != nil; el = el.Next() {
+		status.Waiting = append(status.Waiting, TTSQueueEntry{
+			RequestID: el.Value.(*ttsWaiter).requestID,
+			Position:  position,
+		})
+		position++
+	}
+	return status
+}
+
+func (q *TTSQueue) notifyLocked(uq *ttsUserQueue) {
+	if len(uq.subscribers) == 0 {
+		return
+	}
+	status := q.statusLocked(uq)
+	for ch := range uq.subscribers {
+		// Replace any unread status with the latest one.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- status
+	}
+}
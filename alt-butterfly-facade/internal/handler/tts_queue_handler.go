@@ -0,0 +1,395 @@
+// Package handler provides HTTP handlers for the BFF service.
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"alt-butterfly-facade/internal/cache"
+	ttsv1 "alt-butterfly-facade/internal/gen/proto/alt/tts/v1"
+	"alt-butterfly-facade/internal/middleware"
+)
+
+// TTSRequestIDHeader lets clients correlate a synthesis request with the
+// entries reported by the queue status stream. The BFF generates one when the
+// client does not send it and echoes it on the response.
+const TTSRequestIDHeader = "X-Tts-Request-Id"
+
+// maxTTSRequestBytes bounds the request body buffered for cache-key hashing.
+const maxTTSRequestBytes = 1 << 20
+
+// maxCachedAudioBytes keeps single long articles from crowding out the cache.
+const maxCachedAudioBytes = 16 << 20
+
+// ttsQueueKeepAlive is how often the status stream writes an SSE comment so
+// idle connections are not closed by intermediaries.
+const ttsQueueKeepAlive = 15 * time.Second
+
+// cacheableTTSProcedures lists the procedures whose audio is cached.
+var cacheableTTSProcedures = map[string]bool{
+	"/alt.tts.v1.TTSService/Synthesize":       true,
+	"/alt.tts.v1.TTSService/SynthesizeStream": true,
+}
+
+// TTSQueueConfig holds the TTS queue and audio cache settings.
+type TTSQueueConfig struct {
+	// MaxConcurrentPerUser is the number of synthesis requests a user may run at once.
+	MaxConcurrentPerUser int
+	// MaxQueuedPerUser is the number of requests a user may have waiting; more are rejected with 429.
+	MaxQueuedPerUser int
+	// AudioCacheSize is the maximum number of cached audio responses. 0 disables the cache.
+	AudioCacheSize int
+	// AudioCacheTTL is how long generated audio is served from the cache.
+	AudioCacheTTL time.Duration
+}
+
+// TTSQueueHandler sits in front of the TTS proxy. It serves previously
+// generated audio from a cache keyed by text hash and otherwise admits the
+// request through the per-user TTSQueue before forwarding it.
+type TTSQueueHandler struct {
+	next            http.Handler
+	queue           *TTSQueue
+	audioCache      *cache.ResponseCache
+	audioCacheTTL   time.Duration
+	authInterceptor *middleware.AuthInterceptor
+	logger          *slog.Logger
+}
+
+// NewTTSQueueHandler creates a TTS queue handler wrapping next.
+func NewTTSQueueHandler(
+	next http.Handler,
+	secret []byte,
+	issuer, audience string,
+	logger *slog.Logger,
+	config TTSQueueConfig,
+) *TTSQueueHandler {
+	h := &TTSQueueHandler{
+		next:            next,
+		queue:           NewTTSQueue(config.MaxConcurrentPerUser, config.MaxQueuedPerUser),
+		audioCacheTTL:   config.AudioCacheTTL,
+		authInterceptor: middleware.NewAuthInterceptor(logger, secret, issuer, audience),
+		logger:          logger,
+	}
+	if config.AudioCacheSize > 0 && config.AudioCacheTTL > 0 {
+		h.audioCache = cache.NewResponseCache(config.AudioCacheSize)
+	}
+	return h
+}
+
+// Queue returns the queue shared with the status handler.
+func (h *TTSQueueHandler) Queue() *TTSQueue {
+	return h.queue
+}
+
+// GetCacheStats returns audio cache statistics, or nil when the cache is disabled.
+func (h *TTSQueueHandler) GetCacheStats() *cache.CacheStats {
+	if h.audioCache == nil {
+		return nil
+	}
+	stats := h.audioCache.Stats()
+	return &stats
+}
+
+// ServeHTTP implements http.Handler.
+func (h *TTSQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get(middleware.BackendTokenHeader)
+	userCtx, err := h.authInterceptor.ValidateToken(token)
+	if err != nil {
+		h.logError("authentication failed", err)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var cacheKey string
+	if h.audioCache != nil && cacheableTTSProcedures[r.URL.Path] {
+		body, readErr := io.ReadAll(io.LimitReader(r.Body, maxTTSRequestBytes+1))
+		r.Body.Close()
+		if readErr != nil || len(body) > maxTTSRequestBytes {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+
+		cacheKey = buildTTSAudioCacheKey(r.URL.Path, r.Header.Get("Content-Type"), body)
+		if cacheKey != "" {
+			if entry, found := h.audioCache.Get(cacheKey); found {
+				h.writeCachedAudio(w, entry)
+				return
+			}
+		}
+	}
+
+	requestID := r.Header.Get(TTSRequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	w.Header().Set(TTSRequestIDHeader, requestID)
+
+	release, err := h.queue.Acquire(r.Context(), userCtx.UserID, requestID)
+	if errors.Is(err, ErrTTSQueueFull) {
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Gateway Timeout", http.StatusGatewayTimeout)
+		return
+	}
+	defer release()
+
+	if cacheKey == "" {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("X-Cache", "MISS")
+	capture := &ttsCaptureWriter{ResponseWriter: w}
+	h.next.ServeHTTP(capture, r)
+	h.storeAudio(cacheKey, r.URL.Path, capture)
+}
+
+// writeCachedAudio replays a cached synthesis response.
+func (h *TTSQueueHandler) writeCachedAudio(w http.ResponseWriter, entry *cache.CacheEntry) {
+	copyResponseHeaders(entry.Headers, w.Header())
+	w.Header().Set("X-Cache", "HIT")
+	w.WriteHeader(entry.StatusCode)
+	if _, err := w.Write(entry.Response); err != nil {
+		h.logError("cached audio write failed", err)
+	}
+}
+
+// storeAudio caches a completed synthesis response. Errors, oversized audio
+// and streams that ended with an error are not cached.
+func (h *TTSQueueHandler) storeAudio(key, path string, capture *ttsCaptureWriter) {
+	status := capture.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if status != http.StatusOK || capture.overflow || capture.buf.Len() == 0 {
+		return
+	}
+	body := capture.buf.Bytes()
+	if isStreamingProcedure(path) && !connectStreamSucceeded(body) {
+		return
+	}
+
+	h.audioCache.Set(key, &cache.CacheEntry{
+		Response:   bytes.Clone(body),
+		StatusCode: status,
+		Headers:    capture.Header().Clone(),
+		CachedAt:   time.Now(),
+		TTL:        h.audioCacheTTL,
+	})
+}
+
+// logError logs an error with context.
+func (h *TTSQueueHandler) logError(msg string, err error) {
+	if h.logger != nil {
+		h.logger.Error(msg, "error", err)
+	}
+}
+
+// buildTTSAudioCacheKey hashes the text and combines it with the voice, speed,
+// procedure and codec that shape the cached bytes. It returns "" when the
+// request body cannot be decoded, leaving that request uncached.
+func buildTTSAudioCacheKey(path, contentType string, body []byte) string {
+	codec := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+
+	var msg []byte
+	switch codec {
+	case "application/json", "application/proto":
+		msg = body
+	case "application/connect+json", "application/connect+proto":
+		payload, ok := firstConnectEnvelope(body)
+		if !ok {
+			return ""
+		}
+		msg = payload
+	default:
+		return ""
+	}
+
+	req := &ttsv1.SynthesizeRequest{}
+	var err error
+	if strings.HasSuffix(codec, "json") {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(msg, req)
+	} else {
+		err = proto.Unmarshal(msg, req)
+	}
+	if err != nil || req.GetText() == "" {
+		return ""
+	}
+
+	textHash := sha256.Sum256([]byte(req.GetText()))
+	return strings.Join([]string{
+		path,
+		codec,
+		req.GetVoice(),
+		strconv.FormatFloat(req.GetSpeed(), 'f', -1, 64),
+		hex.EncodeToString(textHash[:]),
+	}, ":")
+}
+
+// firstConnectEnvelope returns the payload of the first uncompressed message
+// in a Connect streaming body (1 flag byte, 4 byte big-endian length).
+func firstConnectEnvelope(body []byte) ([]byte, bool) {
+	if len(body) < 5 || body[0] != 0 {
+		return nil, false
+	}
+	size := binary.BigEndian.Uint32(body[1:5])
+	if uint64(len(body)-5) < uint64(size) {
+		return nil, false
+	}
+	return body[5 : 5+size], true
+}
+
+// connectStreamSucceeded walks a complete Connect streaming response and
+// reports whether it ended with an end-stream message carrying no error.
+func connectStreamSucceeded(body []byte) bool {
+	for len(body) >= 5 {
+		flags := body[0]
+		size := binary.BigEndian.Uint32(body[1:5])
+		if uint64(len(body)-5) < uint64(size) {
+			return false
+		}
+		payload := body[5 : 5+size]
+		body = body[5+size:]
+
+		if flags&0x01 != 0 {
+			// Compressed envelopes are not inspected; skip caching.
+			return false
+		}
+		if flags&0x02 != 0 {
+			var end struct {
+				Error json.RawMessage `json:"error"`
+			}
+			if err := json.Unmarshal(payload, &end); err != nil {
+				return false
+			}
+			return len(end.Error) == 0 && len(body) == 0
+		}
+	}
+	return false
+}
+
+// ttsCaptureWriter forwards a response to the client while keeping a copy of
+// the body for the audio cache.
+type ttsCaptureWriter struct {
+	http.ResponseWriter
+	status   int
+	buf      bytes.Buffer
+	overflow bool
+}
+
+func (c *ttsCaptureWriter) WriteHeader(status int) {
+	c.status = status
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *ttsCaptureWriter) Write(p []byte) (int, error) {
+	if !c.overflow {
+		if c.buf.Len()+len(p) > maxCachedAudioBytes {
+			c.overflow = true
+			c.buf = bytes.Buffer{}
+		} else {
+			c.buf.Write(p)
+		}
+	}
+	return c.ResponseWriter.Write(p)
+}
+
+// Flush keeps streamCopy's per-chunk flushing working through the wrapper.
+func (c *ttsCaptureWriter) Flush() {
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// TTSQueueStatusHandler streams the caller's TTS queue status as
+// Server-Sent Events: one "status" event on connect and after every change.
+type TTSQueueStatusHandler struct {
+	queue           *TTSQueue
+	authInterceptor *middleware.AuthInterceptor
+	logger          *slog.Logger
+}
+
+// NewTTSQueueStatusHandler creates a status stream handler for queue.
+func NewTTSQueueStatusHandler(queue *TTSQueue, secret []byte, issuer, audience string, logger *slog.Logger) *TTSQueueStatusHandler {
+	return &TTSQueueStatusHandler{
+		queue:           queue,
+		authInterceptor: middleware.NewAuthInterceptor(logger, secret, issuer, audience),
+		logger:          logger,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *TTSQueueStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.Header.Get(middleware.BackendTokenHeader)
+	userCtx, err := h.authInterceptor.ValidateToken(token)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	updates, cancel := h.queue.Subscribe(userCtx.UserID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(ttsQueueKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case status := <-updates:
+			data, err := json.Marshal(status)
+			if err != nil {
+				if h.logger != nil {
+					h.logger.Error("tts queue status encode failed", "error", err)
+				}
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
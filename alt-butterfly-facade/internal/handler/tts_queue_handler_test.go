@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ttsTestSecret = []byte("test-secret-key-for-tts-queue-tests")
+
+func newTestTTSQueueHandler(t *testing.T, next http.Handler, cfg TTSQueueConfig) *TTSQueueHandler {
+	t.Helper()
+	return NewTTSQueueHandler(next, ttsTestSecret, "auth-hub", "alt-backend", nil, cfg)
+}
+
+func connectEnvelope(flags byte, payload string) []byte {
+	out := make([]byte, 5, 5+len(payload))
+	out[0] = flags
+	binary.BigEndian.PutUint32(out[1:5], uint32(len(payload)))
+	return append(out, payload...)
+}
+
+func TestTTSQueueHandler_ServesCachedAudio(t *testing.T) {
+	var calls atomic.Int32
+	upstream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"audioWav":"UklGRg=="}`))
+	})
+	h := newTestTTSQueueHandler(t, upstream, TTSQueueConfig{
+		MaxConcurrentPerUser: 1, MaxQueuedPerUser: 2, AudioCacheSize: 10, AudioCacheTTL: time.Hour,
+	})
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/alt.tts.v1.TTSService/Synthesize", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Alt-Backend-Token", createValidToken(t, ttsTestSecret))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send(`{"text":"こんにちは","voice":"jf_alpha","speed":1}`)
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+	assert.NotEmpty(t, first.Header().Get(TTSRequestIDHeader))
+
+	second := send(`{"text":"こんにちは","voice":"jf_alpha","speed":1}`)
+	require.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+
+	third := send(`{"text":"こんにちは","voice":"jf_alpha","speed":1.5}`)
+	assert.Equal(t, "MISS", third.Header().Get("X-Cache"), "a different speed is a different audio")
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTTSQueueHandler_DoesNotCacheFailedStream(t *testing.T) {
+	var calls atomic.Int32
+	upstream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/connect+json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(connectEnvelope(0, `{"audioChunk":"AAAA"}`))
+		w.Write(connectEnvelope(0x02, `{"error":{"code":"internal","message":"synthesis failed"}}`))
+	})
+	h := newTestTTSQueueHandler(t, upstream, TTSQueueConfig{
+		MaxConcurrentPerUser: 1, MaxQueuedPerUser: 2, AudioCacheSize: 10, AudioCacheTTL: time.Hour,
+	})
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/alt.tts.v1.TTSService/SynthesizeStream",
+			strings.NewReader(string(connectEnvelope(0, `{"text":"hello"}`))))
+		req.Header.Set("Content-Type", "application/connect+json")
+		req.Header.Set("X-Alt-Backend-Token", createValidToken(t, ttsTestSecret))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTTSQueueHandler_RejectsUnauthenticated(t *testing.T) {
+	h := newTestTTSQueueHandler(t, http.NotFoundHandler(), TTSQueueConfig{MaxConcurrentPerUser: 1, MaxQueuedPerUser: 1})
+
+	req := httptest.NewRequest(http.MethodPost, "/alt.tts.v1.TTSService/Synthesize", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestConnectStreamSucceeded(t *testing.T) {
+	ok := append(connectEnvelope(0, `{"audioChunk":"AAAA"}`), connectEnvelope(0x02, `{}`)...)
+	assert.True(t, connectStreamSucceeded(ok))
+
+	failed := append(connectEnvelope(0, `{"audioChunk":"AAAA"}`), connectEnvelope(0x02, `{"error":{"code":"internal"}}`)...)
+	assert.False(t, connectStreamSucceeded(failed))
+
+	assert.False(t, connectStreamSucceeded(connectEnvelope(0, `{"audioChunk":"AAAA"}`)), "truncated stream")
+}
+
+func TestBuildTTSAudioCacheKey_HashesText(t *testing.T) {
+	key := buildTTSAudioCacheKey("/alt.tts.v1.TTSService/Synthesize", "application/json", []byte(`{"text":"secret article body"}`))
+	require.NotEmpty(t, key)
+	assert.NotContains(t, key, "secret article body")
+
+	assert.Empty(t, buildTTSAudioCacheKey("/alt.tts.v1.TTSService/Synthesize", "application/json", []byte(`{"text":""}`)))
+	assert.Empty(t, buildTTSAudioCacheKey("/alt.tts.v1.TTSService/Synthesize", "application/grpc", []byte(`{}`)))
+}
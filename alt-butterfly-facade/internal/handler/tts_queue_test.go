@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTSQueue_LimitsConcurrencyPerUser(t *testing.T) {
+	q := NewTTSQueue(1, 5)
+	user := uuid.New()
+
+	release, err := q.Acquire(context.Background(), user, "first")
+	require.NoError(t, err)
+
+	acquired := make(chan func(), 1)
+	go func() {
+		r, err := q.Acquire(context.Background(), user, "second")
+		if err == nil {
+			acquired <- r
+		}
+	}()
+
+	require.Eventually(t, func() bool { return len(q.Status(user).Waiting) == 1 }, time.Second, 5*time.Millisecond)
+	select {
+	case <-acquired:
+		t.Fatal("second request must wait for the first to finish")
+	default:
+	}
+
+	// Other users are not affected by this user's queue.
+	otherRelease, err := q.Acquire(context.Background(), uuid.New(), "other")
+	require.NoError(t, err)
+	otherRelease()
+
+	release()
+	select {
+	case r := <-acquired:
+		r()
+	case <-time.After(time.Second):
+		t.Fatal("second request was not admitted after release")
+	}
+
+	status := q.Status(user)
+	assert.Equal(t, 0, status.Active)
+	assert.Empty(t, status.Waiting)
+}
+
+func TestTTSQueue_ReportsPositions(t *testing.T) {
+	q := NewTTSQueue(1, 5)
+	user := uuid.New()
+
+	release, err := q.Acquire(context.Background(), user, "running")
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, id := range []string{"a", "b"} {
+		go q.Acquire(ctx, user, id) //nolint:errcheck
+		require.Eventually(t, func() bool {
+			w := q.Status(user).Waiting
+			return len(w) > 0 && w[len(w)-1].RequestID == id
+		}, time.Second, 5*time.Millisecond)
+	}
+
+	status := q.Status(user)
+	assert.Equal(t, 1, status.Active)
+	assert.Equal(t, []TTSQueueEntry{{RequestID: "a", Position: 1}, {RequestID: "b", Position: 2}}, status.Waiting)
+}
+
+func TestTTSQueue_RejectsWhenFull(t *testing.T) {
+	q := NewTTSQueue(1, 1)
+	user := uuid.New()
+
+	release, err := q.Acquire(context.Background(), user, "running")
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Acquire(ctx, user, "waiting") //nolint:errcheck
+	require.Eventually(t, func() bool { return len(q.Status(user).Waiting) == 1 }, time.Second, 5*time.Millisecond)
+
+	_, err = q.Acquire(context.Background(), user, "rejected")
+	assert.ErrorIs(t, err, ErrTTSQueueFull)
+}
+
+func TestTTSQueue_CancelledWaiterLeavesQueue(t *testing.T) {
+	q := NewTTSQueue(1, 5)
+	user := uuid.New()
+
+	release, err := q.Acquire(context.Background(), user, "running")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = q.Acquire(ctx, user, "gives-up")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, q.Status(user).Waiting)
+
+	release()
+	assert.Equal(t, 0, q.Status(user).Active)
+}
+
+func TestTTSQueue_SubscribeReceivesUpdates(t *testing.T) {
+	q := NewTTSQueue(1, 5)
+	user := uuid.New()
+
+	updates, unsubscribe := q.Subscribe(user)
+	defer unsubscribe()
+
+	initial := <-updates
+	assert.Equal(t, 0, initial.Active)
+	assert.Equal(t, 1, initial.Limit)
+
+	release, err := q.Acquire(context.Background(), user, "running")
+	require.NoError(t, err)
+	assert.Equal(t, 1, (<-updates).Active)
+
+	release()
+	assert.Equal(t, 0, (<-updates).Active)
+}
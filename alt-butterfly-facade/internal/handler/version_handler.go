@@ -0,0 +1,154 @@
+// Package handler provides HTTP handlers for the BFF service.
+package handler
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"alt-butterfly-facade/internal/versioning"
+)
+
+// maxTranslatedBodyBytes bounds request bodies read for translation.
+const maxTranslatedBodyBytes = 4 << 20
+
+// VersionHandler negotiates the client payload version in front of the
+// Connect-RPC proxy. Requests from clients on a deprecated version are
+// upgraded to the current shape, and their responses downgraded, by the
+// procedure's adapters; every call is counted per version.
+//
+// Only unary Connect JSON is translated. Protobuf payloads need no
+// translation because renamed fields keep their field numbers, and
+// streaming procedures have no adapters.
+type VersionHandler struct {
+	next     http.Handler
+	registry *versioning.Registry
+	usage    *versioning.UsageRecorder
+	logger   *slog.Logger
+}
+
+// NewVersionHandler wraps next with version negotiation using registry.
+func NewVersionHandler(next http.Handler, registry *versioning.Registry, logger *slog.Logger) *VersionHandler {
+	return &VersionHandler{
+		next:     next,
+		registry: registry,
+		usage:    versioning.NewUsageRecorder(),
+		logger:   logger,
+	}
+}
+
+// Usage returns the per-version call counts.
+func (h *VersionHandler) Usage() []versioning.Usage {
+	return h.usage.Snapshot()
+}
+
+// ServeHTTP implements http.Handler.
+func (h *VersionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	version, err := versioning.ParseVersion(r.Header.Get(versioning.ClientVersionHeader))
+	if err != nil {
+		if h.logger != nil {
+			h.logger.WarnContext(r.Context(), "rejected client version", "path", r.URL.Path, "error", err)
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	procedure := r.URL.Path
+	usageKey := procedure
+	if !h.registry.Known(procedure) {
+		usageKey = versioning.OtherProcedure
+	}
+	if version.Deprecated() {
+		w.Header().Set("Deprecation", "true")
+	}
+
+	if !h.registry.NeedsTranslation(procedure, version) || !isTranslatable(r) {
+		h.usage.Record(usageKey, version, false)
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	h.usage.Record(usageKey, version, true)
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTranslatedBodyBytes))
+	r.Body.Close()
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	body, err = h.registry.TranslateRequest(procedure, version, body)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Del("Content-Length")
+
+	buf := newBufferedResponseWriter()
+	h.next.ServeHTTP(buf, r)
+
+	respBody := buf.body.Bytes()
+	if buf.status == http.StatusOK && isJSONContentType(buf.header.Get("Content-Type")) && buf.header.Get("Content-Encoding") == "" {
+		translated, err := h.registry.TranslateResponse(procedure, version, respBody)
+		if err != nil {
+			// The client may still cope with the current shape; failing the
+			// call outright would be worse.
+			if h.logger != nil {
+				h.logger.ErrorContext(r.Context(), "failed to translate response",
+					"procedure", procedure, "client_version", version.String(), "error", err)
+			}
+		} else {
+			respBody = translated
+		}
+	}
+
+	for k, v := range buf.header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(respBody)))
+	w.WriteHeader(buf.status)
+	w.Write(respBody)
+}
+
+// isTranslatable reports whether r is a unary Connect JSON request.
+func isTranslatable(r *http.Request) bool {
+	return r.Method == http.MethodPost &&
+		!isStreamingProcedure(r.URL.Path) &&
+		r.Header.Get("Content-Encoding") == "" &&
+		isJSONContentType(r.Header.Get("Content-Type"))
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// bufferedResponseWriter holds a response so it can be translated before
+// it is sent.
+type bufferedResponseWriter struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header), status: http.StatusOK}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header { return b.header }
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	if !b.wroteHeader {
+		b.status = status
+		b.wroteHeader = true
+	}
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"alt-butterfly-facade/internal/versioning"
+)
+
+const getKnowledgeHomePath = "/alt.knowledge_home.v1.KnowledgeHomeService/GetKnowledgeHome"
+
+// echoKnowledgeHome records the request body it receives and answers with a
+// current-shape GetKnowledgeHome response.
+type echoKnowledgeHome struct {
+	received string
+	calls    int
+}
+
+func (e *echoKnowledgeHome) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.calls++
+	body, _ := io.ReadAll(r.Body)
+	e.received = string(body)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Cache", "MISS")
+	w.Write([]byte(`{"items":[{"itemKey":"k1","url":"https://example.com/a"}]}`))
+}
+
+func TestVersionHandler_TranslatesDeprecatedClients(t *testing.T) {
+	next := &echoKnowledgeHome{}
+	h := NewVersionHandler(next, versioning.DefaultRegistry(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, getKnowledgeHomePath, strings.NewReader(`{"limit":10}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(versioning.ClientVersionHeader, "1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"items":[{"itemKey":"k1","link":"https://example.com/a"}]}`, rec.Body.String())
+	assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+	assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	assert.Equal(t, `{"limit":10}`, next.received)
+	assert.Equal(t, []versioning.Usage{
+		{Procedure: getKnowledgeHomePath, Version: 1, Calls: 1, Translated: 1},
+	}, h.Usage())
+}
+
+func TestVersionHandler_PassesCurrentClientsThrough(t *testing.T) {
+	next := &echoKnowledgeHome{}
+	h := NewVersionHandler(next, versioning.DefaultRegistry(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, getKnowledgeHomePath, strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.JSONEq(t, `{"items":[{"itemKey":"k1","url":"https://example.com/a"}]}`, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("Deprecation"))
+
+	// Unknown procedures are grouped so arbitrary paths cannot grow the stats.
+	req = httptest.NewRequest(http.MethodPost, "/alt.feeds.v2.FeedService/GetFeedStats", strings.NewReader(`{}`))
+	req.Header.Set(versioning.ClientVersionHeader, "v1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, []versioning.Usage{
+		{Procedure: versioning.OtherProcedure, Version: 1, Calls: 1},
+		{Procedure: getKnowledgeHomePath, Version: 2, Calls: 1},
+	}, h.Usage())
+}
+
+func TestVersionHandler_DoesNotTranslateProtobuf(t *testing.T) {
+	next := &echoKnowledgeHome{}
+	h := NewVersionHandler(next, versioning.DefaultRegistry(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, getKnowledgeHomePath, strings.NewReader("binary"))
+	req.Header.Set("Content-Type", "application/proto")
+	req.Header.Set(versioning.ClientVersionHeader, "1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, "binary", next.received)
+	assert.Contains(t, rec.Body.String(), `"url"`)
+	assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+	assert.Equal(t, int64(0), h.Usage()[0].Translated)
+}
+
+func TestVersionHandler_RejectsUnsupportedVersions(t *testing.T) {
+	next := &echoKnowledgeHome{}
+	h := NewVersionHandler(next, versioning.DefaultRegistry(), nil)
+
+	for _, v := range []string{"0", "99", "beta"} {
+		req := httptest.NewRequest(http.MethodPost, getKnowledgeHomePath, strings.NewReader(`{}`))
+		req.Header.Set(versioning.ClientVersionHeader, v)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, v)
+	}
+	assert.Equal(t, 0, next.calls)
+}
+
+func TestVersionHandler_LeavesErrorResponsesAlone(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"not_found","url":"x"}`))
+	})
+	h := NewVersionHandler(next, versioning.DefaultRegistry(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, getKnowledgeHomePath, strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(versioning.ClientVersionHeader, "1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, `{"code":"not_found","url":"x"}`, rec.Body.String())
+}
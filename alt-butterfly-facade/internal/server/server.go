@@ -16,6 +16,7 @@ import (
 	"alt-butterfly-facade/internal/client"
 	"alt-butterfly-facade/internal/handler"
 	"alt-butterfly-facade/internal/middleware"
+	"alt-butterfly-facade/internal/versioning"
 )
 
 // HealthResponse represents the health check response.
@@ -36,6 +37,9 @@ type Config struct {
 	TTSConnectURL     string
 	AcolyteConnectURL string
 
+	// TTS queue and audio cache configuration
+	TTSQueue handler.TTSQueueConfig
+
 	// BFF Feature Configuration
 	BFFConfig handler.BFFConfig
 }
@@ -105,6 +109,11 @@ func NewServerWithTransports(
 		)
 	}
 
+	// Old client builds state their payload version; the version handler
+	// translates their v1 payloads to and from the current shapes and
+	// counts calls per version for /v1/bff/stats.
+	versionHandler := handler.NewVersionHandler(mainHandler, versioning.DefaultRegistry(), logger)
+
 	// Create aggregation handler
 	aggregationHandler := handler.NewAggregationHandler(
 		createQueryFetcher(backendClient),
@@ -155,6 +164,11 @@ func NewServerWithTransports(
 				}
 			}
 		}
+		stats.APIVersions = &APIVersionStatsResponse{
+			Current: versioning.CurrentVersion,
+			Minimum: versioning.MinimumVersion,
+			Usage:   versionHandler.Usage(),
+		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(stats)
@@ -222,10 +236,20 @@ func NewServerWithTransports(
 		ttsProxy := handler.NewProxyHandler(
 			ttsClient, cfg.Secret, cfg.Issuer, cfg.Audience, logger, cfg.StreamingTimeout, cfg.StreamingTimeout,
 		)
+		// Synthesis is GPU-bound on a single tts-speaker, so requests pass
+		// through a per-user queue and repeated texts are served from the
+		// audio cache without reaching the speaker at all.
+		ttsQueue := handler.NewTTSQueueHandler(
+			ttsProxy, cfg.Secret, cfg.Issuer, cfg.Audience, logger, cfg.TTSQueue,
+		)
 		// BFF validates JWT before forwarding (authInterceptor, line ~115).
 		// mTLS transport-layer auth is available when TTS_CONNECT_MTLS_URL is set.
 		// Without it, traffic runs HTTP/1.1 over the operator's overlay network.
-		mux.Handle("/alt.tts.v1.TTSService/", ttsProxy)
+		mux.Handle("/alt.tts.v1.TTSService/", ttsQueue)
+		// Queue position stream (SSE) for the caller's pending synthesis requests.
+		mux.Handle("/v1/bff/tts/queue", handler.NewTTSQueueStatusHandler(
+			ttsQueue.Queue(), cfg.Secret, cfg.Issuer, cfg.Audience, logger,
+		))
 	}
 
 	// Knowledge Home admin routing (before catch-all).
@@ -285,7 +309,7 @@ func NewServerWithTransports(
 
 	// Register proxy handler for all other paths
 	// Connect-RPC uses paths like /alt.feeds.v2.FeedService/GetFeedStats
-	mux.Handle("/", mainHandler)
+	mux.Handle("/", versionHandler)
 
 	// Support HTTP/2 without TLS (h2c) for Connect-RPC streaming
 	return h2c.NewHandler(mux, &http2.Server{})
@@ -295,6 +319,7 @@ func NewServerWithTransports(
 type BFFStats struct {
 	Cache          *CacheStatsResponse          `json:"cache,omitempty"`
 	CircuitBreaker *CircuitBreakerStatsResponse `json:"circuit_breaker,omitempty"`
+	APIVersions    *APIVersionStatsResponse     `json:"api_versions,omitempty"`
 }
 
 // CacheStatsResponse represents cache statistics in the API response.
@@ -311,6 +336,14 @@ type CircuitBreakerStatsResponse struct {
 	TotalFailures  int64  `json:"total_failures"`
 }
 
+// APIVersionStatsResponse reports which client payload versions are in use.
+// Calls with a version below Current still rely on deprecated shapes.
+type APIVersionStatsResponse struct {
+	Current versioning.Version `json:"current"`
+	Minimum versioning.Version `json:"minimum"`
+	Usage   []versioning.Usage `json:"usage"`
+}
+
 // createQueryFetcher creates a query fetcher function for the aggregation handler.
 func createQueryFetcher(backendClient *client.BackendClient) handler.QueryFetcher {
 	return func(path string, token string, body []byte) (*handler.AggregatedResult, error) {
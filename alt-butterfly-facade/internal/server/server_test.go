@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,6 +13,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"alt-butterfly-facade/internal/handler"
 )
 
 func TestNewServer(t *testing.T) {
@@ -330,3 +333,38 @@ func createValidToken(t *testing.T, secret []byte) string {
 	}
 	return tokenStr
 }
+
+func TestServer_TranslatesV1FeedRequests(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"limit":20,"excludeFeedLinkIds":["feed-a"]}`, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[],"hasMore":false}`))
+	}))
+	defer backend.Close()
+
+	cfg := Config{
+		BackendURL:       backend.URL,
+		Secret:           []byte("test-secret"),
+		Issuer:           "auth-hub",
+		Audience:         "alt-backend",
+		RequestTimeout:   30 * time.Second,
+		StreamingTimeout: 5 * time.Minute,
+		BFFConfig:        handler.BFFConfig{EnableErrorNormalization: true},
+	}
+	srv := NewServerWithTransport(cfg, nil, http.DefaultTransport)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/alt.feeds.v2.FeedService/GetUnreadFeeds",
+		strings.NewReader(`{"limit":20,"excludeFeedLinkId":"feed-a"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Alt-Client-Version", "1")
+	req.Header.Set("X-Alt-Backend-Token", createValidToken(t, []byte("test-secret")))
+	recorder := httptest.NewRecorder()
+	srv.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "true", recorder.Header().Get("Deprecation"))
+}
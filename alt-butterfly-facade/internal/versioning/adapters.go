@@ -0,0 +1,67 @@
+package versioning
+
+// Procedures with v1 adapters.
+const (
+	procedureGetUnreadFeeds   = "/alt.feeds.v2.FeedService/GetUnreadFeeds"
+	procedureGetAllFeeds      = "/alt.feeds.v2.FeedService/GetAllFeeds"
+	procedureGetKnowledgeHome = "/alt.knowledge_home.v1.KnowledgeHomeService/GetKnowledgeHome"
+)
+
+// DefaultRegistry returns the adapters for every shape change since
+// MinimumVersion.
+func DefaultRegistry() *Registry {
+	return NewRegistry(
+		// v2: a single excluded feed link became a list.
+		Adapter{Procedure: procedureGetUnreadFeeds, Since: 2, UpgradeRequest: upgradeExcludeFeedLinkID},
+		Adapter{Procedure: procedureGetAllFeeds, Since: 2, UpgradeRequest: upgradeExcludeFeedLinkID},
+		// v2: KnowledgeHomeItem.link was renamed to url.
+		Adapter{Procedure: procedureGetKnowledgeHome, Since: 2, DowngradeResponse: downgradeKnowledgeHomeItemURL},
+	)
+}
+
+// upgradeExcludeFeedLinkID moves v1's excludeFeedLinkId into
+// excludeFeedLinkIds. Connect JSON accepts both the camelCase and the proto
+// field name, so both spellings are handled.
+func upgradeExcludeFeedLinkID(msg Message) {
+	listKey := "excludeFeedLinkIds"
+	if _, ok := msg[listKey]; !ok {
+		if _, ok := msg["exclude_feed_link_ids"]; ok {
+			listKey = "exclude_feed_link_ids"
+		}
+	}
+	for _, key := range []string{"excludeFeedLinkId", "exclude_feed_link_id"} {
+		id, ok := msg[key].(string)
+		delete(msg, key)
+		if !ok || id == "" {
+			continue
+		}
+		ids, _ := msg[listKey].([]any)
+		if !containsString(ids, id) {
+			msg[listKey] = append(ids, id)
+		}
+	}
+}
+
+// downgradeKnowledgeHomeItemURL renames url back to link on every item.
+func downgradeKnowledgeHomeItemURL(msg Message) {
+	items, _ := msg["items"].([]any)
+	for _, raw := range items {
+		item, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if url, ok := item["url"]; ok {
+			item["link"] = url
+			delete(item, "url")
+		}
+	}
+}
+
+func containsString(list []any, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
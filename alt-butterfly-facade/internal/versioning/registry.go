@@ -0,0 +1,116 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Message is a decoded Connect JSON message. Numbers are json.Number so
+// 64-bit values survive a round trip unchanged.
+type Message map[string]any
+
+// Adapter translates one procedure's payloads across the shape change
+// introduced in version Since: requests from Since-1 clients are upgraded
+// and responses to them downgraded.
+type Adapter struct {
+	// Procedure is the Connect-RPC path, e.g.
+	// "/alt.feeds.v2.FeedService/GetUnreadFeeds".
+	Procedure string
+	Since     Version
+	// UpgradeRequest rewrites a Since-1 request into the Since shape. Nil
+	// when the request shape did not change.
+	UpgradeRequest func(Message)
+	// DowngradeResponse rewrites a Since response into the Since-1 shape.
+	// Nil when the response shape did not change.
+	DowngradeResponse func(Message)
+}
+
+// Registry holds the adapters for every procedure whose shape changed.
+type Registry struct {
+	adapters map[string][]Adapter // ascending Since
+}
+
+// NewRegistry creates a registry from adapters.
+func NewRegistry(adapters ...Adapter) *Registry {
+	r := &Registry{adapters: make(map[string][]Adapter)}
+	for _, a := range adapters {
+		r.adapters[a.Procedure] = append(r.adapters[a.Procedure], a)
+	}
+	for _, list := range r.adapters {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Since < list[j].Since })
+	}
+	return r
+}
+
+// Known reports whether any adapter is registered for procedure.
+func (r *Registry) Known(procedure string) bool {
+	return len(r.adapters[procedure]) > 0
+}
+
+// NeedsTranslation reports whether a v client's payloads for procedure
+// differ from the current shape.
+func (r *Registry) NeedsTranslation(procedure string, v Version) bool {
+	return len(r.pending(procedure, v)) > 0
+}
+
+// pending returns the adapters for shape changes newer than v, oldest first.
+func (r *Registry) pending(procedure string, v Version) []Adapter {
+	list := r.adapters[procedure]
+	for i, a := range list {
+		if a.Since > v {
+			return list[i:]
+		}
+	}
+	return nil
+}
+
+// TranslateRequest upgrades a v client's request body to the current shape.
+func (r *Registry) TranslateRequest(procedure string, v Version, body []byte) ([]byte, error) {
+	adapters := r.pending(procedure, v)
+	return translate(body, len(adapters), func(i int) func(Message) {
+		return adapters[i].UpgradeRequest
+	})
+}
+
+// TranslateResponse downgrades a current response body to a v client's shape.
+func (r *Registry) TranslateResponse(procedure string, v Version, body []byte) ([]byte, error) {
+	adapters := r.pending(procedure, v)
+	return translate(body, len(adapters), func(i int) func(Message) {
+		// Undo the newest change first.
+		return adapters[len(adapters)-1-i].DowngradeResponse
+	})
+}
+
+// translate applies n steps to body, leaving it untouched when no step has
+// anything to do.
+func translate(body []byte, n int, step func(i int) func(Message)) ([]byte, error) {
+	var steps []func(Message)
+	for i := range n {
+		if fn := step(i); fn != nil {
+			steps = append(steps, fn)
+		}
+	}
+	if len(steps) == 0 || len(bytes.TrimSpace(body)) == 0 {
+		return body, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var msg Message
+	if err := dec.Decode(&msg); err != nil {
+		return nil, fmt.Errorf("decode message: %w", err)
+	}
+	if msg == nil {
+		return body, nil
+	}
+	for _, fn := range steps {
+		fn(msg)
+	}
+	out, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("encode message: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+package versioning
+
+import (
+	"sort"
+	"sync"
+)
+
+// OtherProcedure groups calls to procedures without adapters, so arbitrary
+// request paths cannot grow the usage table.
+const OtherProcedure = "other"
+
+// Usage counts calls made with one payload version to one procedure.
+type Usage struct {
+	Procedure  string  `json:"procedure"`
+	Version    Version `json:"version"`
+	Calls      int64   `json:"calls"`
+	Translated int64   `json:"translated"`
+}
+
+type usageKey struct {
+	procedure string
+	version   Version
+}
+
+// UsageRecorder counts calls per payload version, showing how much traffic
+// still relies on deprecated shapes. It is safe for concurrent use.
+type UsageRecorder struct {
+	mu     sync.Mutex
+	counts map[usageKey]*Usage
+}
+
+// NewUsageRecorder creates an empty recorder.
+func NewUsageRecorder() *UsageRecorder {
+	return &UsageRecorder{counts: make(map[usageKey]*Usage)}
+}
+
+// Record counts one call; translated is true when its payloads were rewritten.
+func (u *UsageRecorder) Record(procedure string, v Version, translated bool) {
+	key := usageKey{procedure: procedure, version: v}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	entry, ok := u.counts[key]
+	if !ok {
+		entry = &Usage{Procedure: procedure, Version: v}
+		u.counts[key] = entry
+	}
+	entry.Calls++
+	if translated {
+		entry.Translated++
+	}
+}
+
+// Snapshot returns the counts ordered by version, then procedure.
+func (u *UsageRecorder) Snapshot() []Usage {
+	u.mu.Lock()
+	out := make([]Usage, 0, len(u.counts))
+	for _, entry := range u.counts {
+		out = append(out, *entry)
+	}
+	u.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Version != out[j].Version {
+			return out[i].Version < out[j].Version
+		}
+		return out[i].Procedure < out[j].Procedure
+	})
+	return out
+}
@@ -0,0 +1,54 @@
+// Package versioning keeps old client builds working while the backend's
+// payload shapes evolve. Clients state the payload version they were built
+// against in ClientVersionHeader; requests from older versions are upgraded
+// to the current shape before they reach alt-backend, and responses are
+// downgraded on the way back, by per-procedure adapters.
+package versioning
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ClientVersionHeader carries the payload version a client speaks, e.g. "1"
+// or "v1". Requests without it are treated as CurrentVersion, which is what
+// alt-frontend-sv always sends.
+const ClientVersionHeader = "X-Alt-Client-Version"
+
+// Version is a client payload version.
+type Version int
+
+const (
+	// MinimumVersion is the oldest payload version still translated.
+	MinimumVersion Version = 1
+	// CurrentVersion is the payload version alt-backend speaks.
+	CurrentVersion Version = 2
+)
+
+// ParseVersion parses a ClientVersionHeader value. An empty value is
+// CurrentVersion; values outside [MinimumVersion, CurrentVersion] are errors.
+func ParseVersion(raw string) (Version, error) {
+	raw = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(raw)), "v")
+	if raw == "" {
+		return CurrentVersion, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid client version %q", raw)
+	}
+	v := Version(n)
+	if v < MinimumVersion || v > CurrentVersion {
+		return 0, fmt.Errorf("unsupported client version %d (supported: %d-%d)", v, MinimumVersion, CurrentVersion)
+	}
+	return v, nil
+}
+
+// Deprecated reports whether v is older than CurrentVersion.
+func (v Version) Deprecated() bool {
+	return v < CurrentVersion
+}
+
+func (v Version) String() string {
+	return "v" + strconv.Itoa(int(v))
+}
@@ -0,0 +1,106 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    Version
+		wantErr bool
+	}{
+		{raw: "", want: CurrentVersion},
+		{raw: "1", want: 1},
+		{raw: "v1", want: 1},
+		{raw: " V2 ", want: 2},
+		{raw: "0", wantErr: true},
+		{raw: "3", wantErr: true},
+		{raw: "1.4.2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseVersion(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultRegistry_UpgradesExcludeFeedLinkID(t *testing.T) {
+	r := DefaultRegistry()
+	require.True(t, r.NeedsTranslation(procedureGetUnreadFeeds, 1))
+	assert.False(t, r.NeedsTranslation(procedureGetUnreadFeeds, CurrentVersion))
+
+	out, err := r.TranslateRequest(procedureGetUnreadFeeds, 1, []byte(`{"limit":20,"excludeFeedLinkId":"feed-a"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"limit":20,"excludeFeedLinkIds":["feed-a"]}`, string(out))
+
+	out, err = r.TranslateRequest(procedureGetAllFeeds, 1, []byte(`{"exclude_feed_link_id":"feed-a","exclude_feed_link_ids":["feed-a","feed-b"]}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"exclude_feed_link_ids":["feed-a","feed-b"]}`, string(out))
+}
+
+func TestDefaultRegistry_DowngradesKnowledgeHomeItemURL(t *testing.T) {
+	r := DefaultRegistry()
+
+	out, err := r.TranslateResponse(procedureGetKnowledgeHome, 1,
+		[]byte(`{"items":[{"itemKey":"k1","url":"https://example.com/a","score":9007199254740993}],"nextCursor":"c"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[{"itemKey":"k1","link":"https://example.com/a","score":9007199254740993}],"nextCursor":"c"}`, string(out))
+
+	// Requests are unchanged: only the response shape moved.
+	body := []byte(`{"limit":10}`)
+	out, err = r.TranslateRequest(procedureGetKnowledgeHome, 1, body)
+	require.NoError(t, err)
+	assert.Equal(t, body, out)
+}
+
+func TestRegistry_AppliesStepsInOrder(t *testing.T) {
+	var order []string
+	r := NewRegistry(
+		Adapter{Procedure: "/p", Since: 3,
+			UpgradeRequest:    func(Message) { order = append(order, "up3") },
+			DowngradeResponse: func(Message) { order = append(order, "down3") }},
+		Adapter{Procedure: "/p", Since: 2,
+			UpgradeRequest:    func(Message) { order = append(order, "up2") },
+			DowngradeResponse: func(Message) { order = append(order, "down2") }},
+	)
+
+	_, err := r.TranslateRequest("/p", 1, []byte(`{}`))
+	require.NoError(t, err)
+	_, err = r.TranslateResponse("/p", 1, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up2", "up3", "down3", "down2"}, order)
+
+	order = nil
+	_, err = r.TranslateRequest("/p", 2, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"up3"}, order)
+}
+
+func TestRegistry_RejectsMalformedJSON(t *testing.T) {
+	_, err := DefaultRegistry().TranslateRequest(procedureGetUnreadFeeds, 1, []byte(`{"limit":`))
+	assert.Error(t, err)
+}
+
+func TestUsageRecorder_Snapshot(t *testing.T) {
+	u := NewUsageRecorder()
+	u.Record(procedureGetUnreadFeeds, 2, false)
+	u.Record(procedureGetUnreadFeeds, 1, true)
+	u.Record(procedureGetUnreadFeeds, 1, true)
+	u.Record(OtherProcedure, 1, false)
+
+	assert.Equal(t, []Usage{
+		{Procedure: procedureGetUnreadFeeds, Version: 1, Calls: 2, Translated: 2},
+		{Procedure: OtherProcedure, Version: 1, Calls: 1},
+		{Procedure: procedureGetUnreadFeeds, Version: 2, Calls: 1},
+	}, u.Snapshot())
+}
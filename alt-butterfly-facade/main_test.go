@@ -36,6 +36,10 @@ func TestBuildServerConfig_WiresBFFConfigFromAppConfig(t *testing.T) {
 	assert.Equal(t, cfg.CBSuccessThreshold, serverCfg.BFFConfig.CBSuccessThreshold)
 	assert.Equal(t, cfg.CBOpenTimeout, serverCfg.BFFConfig.CBOpenTimeout)
 	assert.Equal(t, cfg.DedupWindow, serverCfg.BFFConfig.DedupWindow)
+	assert.Equal(t, cfg.TTSMaxConcurrentPerUser, serverCfg.TTSQueue.MaxConcurrentPerUser)
+	assert.Equal(t, cfg.TTSMaxQueuedPerUser, serverCfg.TTSQueue.MaxQueuedPerUser)
+	assert.Equal(t, cfg.TTSAudioCacheSize, serverCfg.TTSQueue.AudioCacheSize)
+	assert.Equal(t, cfg.TTSAudioCacheTTL, serverCfg.TTSQueue.AudioCacheTTL)
 }
 
 // TestBuildServerConfig_ResultingServer_UsesBFFHandler proves the wiring gap
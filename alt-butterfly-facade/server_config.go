@@ -34,6 +34,12 @@ func buildServerConfig(cfg *config.Config, backendURL, ttsURL, acolyteURL string
 		StreamingTimeout:  cfg.StreamingTimeout,
 		TTSConnectURL:     ttsURL,
 		AcolyteConnectURL: acolyteURL,
+		TTSQueue: handler.TTSQueueConfig{
+			MaxConcurrentPerUser: cfg.TTSMaxConcurrentPerUser,
+			MaxQueuedPerUser:     cfg.TTSMaxQueuedPerUser,
+			AudioCacheSize:       cfg.TTSAudioCacheSize,
+			AudioCacheTTL:        cfg.TTSAudioCacheTTL,
+		},
 		BFFConfig: handler.BFFConfig{
 			EnableCache:              cfg.EnableCache,
 			EnableCircuitBreaker:     cfg.EnableCircuitBreaker,
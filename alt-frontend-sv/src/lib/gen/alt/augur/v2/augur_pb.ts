@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file alt/augur/v2/augur.proto.
  */
 export const file_alt_augur_v2_augur: GenFile = /*@__PURE__*/
-  fileDesc("ChhhbHQvYXVndXIvdjIvYXVndXIucHJvdG8SDGFsdC5hdWd1ci52MiJZChFTdHJlYW1DaGF0UmVxdWVzdBIrCghtZXNzYWdlcxgBIAMoCzIZLmFsdC5hdWd1ci52Mi5DaGF0TWVzc2FnZRIXCg9jb252ZXJzYXRpb25faWQYAiABKAkiugEKC0NoYXRNZXNzYWdlEgwKBHJvbGUYASABKAkSDwoHY29udGVudBgCIAEoCRIuCgpjcmVhdGVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIpCgljaXRhdGlvbnMYBCADKAsyFi5hbHQuYXVndXIudjIuQ2l0YXRpb24SMQoRcmVsYXRlZF9jaXRhdGlvbnMYBSADKAsyFi5hbHQuYXVndXIudjIuQ2l0YXRpb24i4AEKElN0cmVhbUNoYXRSZXNwb25zZRIMCgRraW5kGAEgASgJEg8KBWRlbHRhGAIgASgJSAASKQoEbWV0YRgDIAEoCzIZLmFsdC5hdWd1ci52Mi5NZXRhUGF5bG9hZEgAEikKBGRvbmUYBCABKAsyGS5hbHQuYXVndXIudjIuRG9uZVBheWxvYWRIABIXCg1mYWxsYmFja19jb2RlGAUgASgJSAASFwoNZXJyb3JfbWVzc2FnZRgGIAEoCUgAEhgKDnRoaW5raW5nX2RlbHRhGAcgASgJSABCCQoHcGF5bG9hZCJRCgtNZXRhUGF5bG9hZBIpCgljaXRhdGlvbnMYASADKAsyFi5hbHQuYXVndXIudjIuQ2l0YXRpb24SFwoPY29udmVyc2F0aW9uX2lkGAIgASgJInYKCENpdGF0aW9uEgsKA3VybBgBIAEoCRINCgV0aXRsZRgCIAEoCRIUCgxwdWJsaXNoZWRfYXQYAyABKAkSKAoEa2luZBgEIAEoDjIaLmFsdC5hdWd1ci52Mi5DaXRhdGlvbktpbmQSDgoGcmVmX2lkGAUgASgJIp0BCgtEb25lUGF5bG9hZBIOCgZhbnN3ZXIYASABKAkSKQoJY2l0YXRpb25zGAIgAygLMhYuYWx0LmF1Z3VyLnYyLkNpdGF0aW9uEg4KBmludGVudBgDIAEoCRIQCghzdHJhdGVneRgEIAEoCRIxChFyZWxhdGVkX2NpdGF0aW9ucxgFIAMoCzIWLmFsdC5hdWd1ci52Mi5DaXRhdGlvbiI2ChZSZXRyaWV2ZUNvbnRleHRSZXF1ZXN0Eg0KBXF1ZXJ5GAEgASgJEg0KBWxpbWl0GAIgASgFIkYKF1JldHJpZXZlQ29udGV4dFJlc3BvbnNlEisKCGNvbnRleHRzGAEgAygLMhkuYWx0LmF1Z3VyLnYyLkNvbnRleHRJdGVtIk4KC0NvbnRleHRJdGVtEgsKA3VybBgBIAEoCRINCgV0aXRsZRgCIAEoCRIUCgxwdWJsaXNoZWRfYXQYAyABKAkSDQoFc2NvcmUYBCABKAIiywEKE0NvbnZlcnNhdGlvblN1bW1hcnkSCgoCaWQYASABKAkSDQoFdGl0bGUYAiABKAkSLgoKY3JlYXRlZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASNAoQbGFzdF9hY3Rpdml0eV9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASHAoUbGFzdF9tZXNzYWdlX3ByZXZpZXcYBSABKAkSFQoNbWVzc2FnZV9jb3VudBgGIAEoBSJBChhMaXN0Q29udmVyc2F0aW9uc1JlcXVlc3QSEQoJcGFnZV9zaXplGAEgASgFEhIKCnBhZ2VfdG9rZW4YAiABKAkibgoZTGlzdENvbnZlcnNhdGlvbnNSZXNwb25zZRI4Cg1jb252ZXJzYXRpb25zGAEgAygLMiEuYWx0LmF1Z3VyLnYyLkNvbnZlcnNhdGlvblN1bW1hcnkSFwoPbmV4dF9wYWdlX3Rva2VuGAIgASgJIiQKFkdldENvbnZlcnNhdGlvblJlcXVlc3QSCgoCaWQYASABKAkikQEKF0dldENvbnZlcnNhdGlvblJlc3BvbnNlEgoKAmlkGAEgASgJEg0KBXRpdGxlGAIgASgJEi4KCmNyZWF0ZWRfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEisKCG1lc3NhZ2VzGAQgAygLMhkuYWx0LmF1Z3VyLnYyLkNoYXRNZXNzYWdlIicKGURlbGV0ZUNvbnZlcnNhdGlvblJlcXVlc3QSCgoCaWQYASABKAkiHAoaRGVsZXRlQ29udmVyc2F0aW9uUmVzcG9uc2UqegoMQ2l0YXRpb25LaW5kEh0KGUNJVEFUSU9OX0tJTkRfVU5TUEVDSUZJRUQQABIVChFDSVRBVElPTl9LSU5EX1dFQhABEhkKFUNJVEFUSU9OX0tJTkRfQVJUSUNMRRACEhkKFUNJVEFUSU9OX0tJTkRfU1VNTUFSWRADMvADCgxBdWd1clNlcnZpY2USUQoKU3RyZWFtQ2hhdBIfLmFsdC5hdWd1ci52Mi5TdHJlYW1DaGF0UmVxdWVzdBogLmFsdC5hdWd1ci52Mi5TdHJlYW1DaGF0UmVzcG9uc2UwARJeCg9SZXRyaWV2ZUNvbnRleHQSJC5hbHQuYXVndXIudjIuUmV0cmlldmVDb250ZXh0UmVxdWVzdBolLmFsdC5hdWd1ci52Mi5SZXRyaWV2ZUNvbnRleHRSZXNwb25zZRJkChFMaXN0Q29udmVyc2F0aW9ucxImLmFsdC5hdWd1ci52Mi5MaXN0Q29udmVyc2F0aW9uc1JlcXVlc3QaJy5hbHQuYXVndXIudjIuTGlzdENvbnZlcnNhdGlvbnNSZXNwb25zZRJeCg9HZXRDb252ZXJzYXRpb24SJC5hbHQuYXVndXIudjIuR2V0Q29udmVyc2F0aW9uUmVxdWVzdBolLmFsdC5hdWd1ci52Mi5HZXRDb252ZXJzYXRpb25SZXNwb25zZRJnChJEZWxldGVDb252ZXJzYXRpb24SJy5hbHQuYXVndXIudjIuRGVsZXRlQ29udmVyc2F0aW9uUmVxdWVzdBooLmFsdC5hdWd1ci52Mi5EZWxldGVDb252ZXJzYXRpb25SZXNwb25zZUIkWiJhbHQvZ2VuL3Byb3RvL2FsdC9hdWd1ci92MjthdWd1cnYyYgZwcm90bzM", [file_google_protobuf_timestamp]);
+  fileDesc("ChhhbHQvYXVndXIvdjIvYXVndXIucHJvdG8SDGFsdC5hdWd1ci52MiKHAQoRU3RyZWFtQ2hhdFJlcXVlc3QSKwoIbWVzc2FnZXMYASADKAsyGS5hbHQuYXVndXIudjIuQ2hhdE1lc3NhZ2USFwoPY29udmVyc2F0aW9uX2lkGAIgASgJEhUKDWFuc3dlcl9sZW5ndGgYAyABKAkSFQoNcmVhZGluZ19sZXZlbBgEIAEoCSK6AQoLQ2hhdE1lc3NhZ2USDAoEcm9sZRgBIAEoCRIPCgdjb250ZW50GAIgASgJEi4KCmNyZWF0ZWRfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEikKCWNpdGF0aW9ucxgEIAMoCzIWLmFsdC5hdWd1ci52Mi5DaXRhdGlvbhIxChFyZWxhdGVkX2NpdGF0aW9ucxgFIAMoCzIWLmFsdC5hdWd1ci52Mi5DaXRhdGlvbiLgAQoSU3RyZWFtQ2hhdFJlc3BvbnNlEgwKBGtpbmQYASABKAkSDwoFZGVsdGEYAiABKAlIABIpCgRtZXRhGAMgASgLMhkuYWx0LmF1Z3VyLnYyLk1ldGFQYXlsb2FkSAASKQoEZG9uZRgEIAEoCzIZLmFsdC5hdWd1ci52Mi5Eb25lUGF5bG9hZEgAEhcKDWZhbGxiYWNrX2NvZGUYBSABKAlIABIXCg1lcnJvcl9tZXNzYWdlGAYgASgJSAASGAoOdGhpbmtpbmdfZGVsdGEYByABKAlIAEIJCgdwYXlsb2FkIlEKC01ldGFQYXlsb2FkEikKCWNpdGF0aW9ucxgBIAMoCzIWLmFsdC5hdWd1ci52Mi5DaXRhdGlvbhIXCg9jb252ZXJzYXRpb25faWQYAiABKAkidgoIQ2l0YXRpb24SCwoDdXJsGAEgASgJEg0KBXRpdGxlGAIgASgJEhQKDHB1Ymxpc2hlZF9hdBgDIAEoCRIoCgRraW5kGAQgASgOMhouYWx0LmF1Z3VyLnYyLkNpdGF0aW9uS2luZBIOCgZyZWZfaWQYBSABKAkinQEKC0RvbmVQYXlsb2FkEg4KBmFuc3dlchgBIAEoCRIpCgljaXRhdGlvbnMYAiADKAsyFi5hbHQuYXVndXIudjIuQ2l0YXRpb24SDgoGaW50ZW50GAMgASgJEhAKCHN0cmF0ZWd5GAQgASgJEjEKEXJlbGF0ZWRfY2l0YXRpb25zGAUgAygLMhYuYWx0LmF1Z3VyLnYyLkNpdGF0aW9uIjYKFlJldHJpZXZlQ29udGV4dFJlcXVlc3QSDQoFcXVlcnkYASABKAkSDQoFbGltaXQYAiABKAUiRgoXUmV0cmlldmVDb250ZXh0UmVzcG9uc2USKwoIY29udGV4dHMYASADKAsyGS5hbHQuYXVndXIudjIuQ29udGV4dEl0ZW0iTgoLQ29udGV4dEl0ZW0SCwoDdXJsGAEgASgJEg0KBXRpdGxlGAIgASgJEhQKDHB1Ymxpc2hlZF9hdBgDIAEoCRINCgVzY29yZRgEIAEoAiLLAQoTQ29udmVyc2F0aW9uU3VtbWFyeRIKCgJpZBgBIAEoCRINCgV0aXRsZRgCIAEoCRIuCgpjcmVhdGVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBI0ChBsYXN0X2FjdGl2aXR5X2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIcChRsYXN0X21lc3NhZ2VfcHJldmlldxgFIAEoCRIVCg1tZXNzYWdlX2NvdW50GAYgASgFIkEKGExpc3RDb252ZXJzYXRpb25zUmVxdWVzdBIRCglwYWdlX3NpemUYASABKAUSEgoKcGFnZV90b2tlbhgCIAEoCSJuChlMaXN0Q29udmVyc2F0aW9uc1Jlc3BvbnNlEjgKDWNvbnZlcnNhdGlvbnMYASADKAsyIS5hbHQuYXVndXIudjIuQ29udmVyc2F0aW9uU3VtbWFyeRIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiJAoWR2V0Q29udmVyc2F0aW9uUmVxdWVzdBIKCgJpZBgBIAEoCSKRAQoXR2V0Q29udmVyc2F0aW9uUmVzcG9uc2USCgoCaWQYASABKAkSDQoFdGl0bGUYAiABKAkSLgoKY3JlYXRlZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASKwoIbWVzc2FnZXMYBCADKAsyGS5hbHQuYXVndXIudjIuQ2hhdE1lc3NhZ2UiJwoZRGVsZXRlQ29udmVyc2F0aW9uUmVxdWVzdBIKCgJpZBgBIAEoCSIcChpEZWxldGVDb252ZXJzYXRpb25SZXNwb25zZSp6CgxDaXRhdGlvbktpbmQSHQoZQ0lUQVRJT05fS0lORF9VTlNQRUNJRklFRBAAEhUKEUNJVEFUSU9OX0tJTkRfV0VCEAESGQoVQ0lUQVRJT05fS0lORF9BUlRJQ0xFEAISGQoVQ0lUQVRJT05fS0lORF9TVU1NQVJZEAMy8AMKDEF1Z3VyU2VydmljZRJRCgpTdHJlYW1DaGF0Eh8uYWx0LmF1Z3VyLnYyLlN0cmVhbUNoYXRSZXF1ZXN0GiAuYWx0LmF1Z3VyLnYyLlN0cmVhbUNoYXRSZXNwb25zZTABEl4KD1JldHJpZXZlQ29udGV4dBIkLmFsdC5hdWd1ci52Mi5SZXRyaWV2ZUNvbnRleHRSZXF1ZXN0GiUuYWx0LmF1Z3VyLnYyLlJldHJpZXZlQ29udGV4dFJlc3BvbnNlEmQKEUxpc3RDb252ZXJzYXRpb25zEiYuYWx0LmF1Z3VyLnYyLkxpc3RDb252ZXJzYXRpb25zUmVxdWVzdBonLmFsdC5hdWd1ci52Mi5MaXN0Q29udmVyc2F0aW9uc1Jlc3BvbnNlEl4KD0dldENvbnZlcnNhdGlvbhIkLmFsdC5hdWd1ci52Mi5HZXRDb252ZXJzYXRpb25SZXF1ZXN0GiUuYWx0LmF1Z3VyLnYyLkdldENvbnZlcnNhdGlvblJlc3BvbnNlEmcKEkRlbGV0ZUNvbnZlcnNhdGlvbhInLmFsdC5hdWd1ci52Mi5EZWxldGVDb252ZXJzYXRpb25SZXF1ZXN0GiguYWx0LmF1Z3VyLnYyLkRlbGV0ZUNvbnZlcnNhdGlvblJlc3BvbnNlQiRaImFsdC9nZW4vcHJvdG8vYWx0L2F1Z3VyL3YyO2F1Z3VydjJiBnByb3RvMw", [file_google_protobuf_timestamp]);
 
 /**
  * StreamChatRequest is the request for streaming chat.
@@ -34,6 +34,22 @@ export type StreamChatRequest = Message<"alt.augur.v2.StreamChatRequest"> & {
    * @generated from field: string conversation_id = 2;
    */
   conversationId: string;
+
+  /**
+   * Optional target answer length: "short", "medium" or "long". Empty keeps
+   * the intent-driven default.
+   *
+   * @generated from field: string answer_length = 3;
+   */
+  answerLength: string;
+
+  /**
+   * Optional reading level: "basic", "general" or "expert". Empty keeps the
+   * default analyst register.
+   *
+   * @generated from field: string reading_level = 4;
+   */
+  readingLevel: string;
 };
 
 /**
@@ -73,3 +73,50 @@ export async function getBackendToken(
 		return null;
 	}
 }
+
+export type PasswordViolation = { code: string; message: string };
+
+export type PasswordCheckResult = {
+	valid: boolean;
+	violations: PasswordViolation[];
+	breach_checked: boolean;
+	history_checked: boolean;
+};
+
+/**
+ * auth-hubのパスワードポリシーで新しいパスワードを検査する。
+ * セッションcookieがあればパスワード変更として再利用チェックも行われる。
+ * auth-hubに届かない場合はnull（呼び出し側はKratosの検証に任せる）。
+ */
+export async function checkPassword(
+	password: string,
+	cookie: string | null,
+): Promise<PasswordCheckResult | null> {
+	try {
+		const response = await fetch(`${AUTH_HUB_URL}/password/check`, {
+			method: "POST",
+			headers: {
+				"Content-Type": "application/json",
+				...(cookie ? { cookie } : {}),
+			},
+			body: JSON.stringify({ password }),
+			cache: "no-store",
+			signal: AbortSignal.timeout(AUTH_HUB_TIMEOUT_MS),
+		});
+
+		if (!response.ok) {
+			console.warn(
+				`Auth-hub password check returned ${response.status}: ${response.statusText}`,
+			);
+			return null;
+		}
+
+		return (await response.json()) as PasswordCheckResult;
+	} catch (error) {
+		console.error("Failed to check password:", {
+			message: error instanceof Error ? error.message : String(error),
+			authHubUrl: AUTH_HUB_URL,
+		});
+		return null;
+	}
+}
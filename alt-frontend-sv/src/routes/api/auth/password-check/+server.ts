@@ -0,0 +1,26 @@
+import { json, type RequestHandler } from "@sveltejs/kit";
+import { checkPassword } from "$lib/server/auth";
+
+/**
+ * POST /api/auth/password-check
+ * Checks a new password against auth-hub's password policy before the
+ * registration/settings form is submitted to Kratos.
+ */
+export const POST: RequestHandler = async ({ request }) => {
+	let password: unknown;
+	try {
+		({ password } = await request.json());
+	} catch {
+		return json({ error: "Invalid request body" }, { status: 400 });
+	}
+	if (typeof password !== "string" || password === "") {
+		return json({ error: "password is required" }, { status: 400 });
+	}
+
+	const result = await checkPassword(password, request.headers.get("cookie"));
+	if (!result) {
+		return json({ error: "Password policy unavailable" }, { status: 503 });
+	}
+
+	return json(result, { headers: { "Cache-Control": "no-store" } });
+};
@@ -4,6 +4,7 @@ import { env } from "$env/dynamic/private";
 import { ory } from "$lib/ory";
 import { isAbsoluteUrl, sanitizeReturnTo } from "$lib/server/return-to";
 import type { PageServerLoad } from "./$types";
+import { LOGIN_CODE_FLOW_COOKIE, loginCodeFlowCookieOptions } from "./code/flow-cookie";
 
 function requireEnv(name: string, fallbackDev: string): string {
 	const value = env[name];
@@ -35,7 +36,7 @@ function buildKratosRedirectUrl(returnTo: string): string {
 	return initUrl.toString();
 }
 
-export const load: PageServerLoad = async ({ url, locals, request }) => {
+export const load: PageServerLoad = async ({ url, locals, request, cookies }) => {
 	// If already logged in, redirect to home or return_to
 	if (locals.session) {
 		const returnToParam = url.searchParams.get("return_to");
@@ -67,6 +68,16 @@ export const load: PageServerLoad = async ({ url, locals, request }) => {
 		// クッキーを渡してflowを取得
 		const cookie = request.headers.get("cookie") || undefined;
 		const { data: flowData } = await ory.getLoginFlow({ id: flow, cookie });
+		// サインインリンクのメール送信後、この端末に flow ID を紐付ける。
+		// /auth/login/code はこの cookie が無いと flow を特定できないため、
+		// 転送されたリンクを別端末で開いてもログインできない。
+		if (flowData.state === "sent_email") {
+			cookies.set(
+				LOGIN_CODE_FLOW_COOKIE,
+				flowData.id,
+				loginCodeFlowCookieOptions(flowData.expires_at, url.protocol === "https:"),
+			);
+		}
 		return {
 			flow: flowData,
 		};
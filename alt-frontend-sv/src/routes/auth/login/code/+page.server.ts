@@ -0,0 +1,29 @@
+import { ory } from "$lib/ory";
+import type { PageServerLoad } from "./$types";
+import { isValidLoginCode, LOGIN_CODE_FLOW_COOKIE } from "./flow-cookie";
+
+// サインインリンク (/auth/login/code?code=123456) の着地点。
+// リンクを要求した端末の cookie から login flow を復元し、確認ボタン付きの
+// フォームを返す。メールのリンクスキャナーがコードを消費しないよう、
+// 自動送信はしない。
+export const load: PageServerLoad = async ({ url, request, cookies }) => {
+	const code = url.searchParams.get("code");
+	if (!isValidLoginCode(code)) {
+		return { error: "invalid_link" as const };
+	}
+
+	const flowId = cookies.get(LOGIN_CODE_FLOW_COOKIE);
+	if (!flowId) {
+		return { error: "other_device" as const };
+	}
+
+	try {
+		const cookie = request.headers.get("cookie") || undefined;
+		const { data: flow } = await ory.getLoginFlow({ id: flowId, cookie });
+		return { flow, code };
+	} catch (error) {
+		console.error("Failed to fetch login flow for sign-in link:", error);
+		cookies.delete(LOGIN_CODE_FLOW_COOKIE, { path: "/auth/login" });
+		return { error: "expired" as const };
+	}
+};
@@ -0,0 +1,19 @@
+// Binds an emailed sign-in link to the browser that requested it. The cookie
+// holds the Kratos login flow ID; Kratos additionally checks its own CSRF
+// cookie for that flow, so a forwarded link cannot be completed elsewhere.
+export const LOGIN_CODE_FLOW_COOKIE = "alt_login_code_flow";
+
+export function loginCodeFlowCookieOptions(expiresAt: string, secure: boolean) {
+	return {
+		path: "/auth/login",
+		httpOnly: true,
+		sameSite: "lax" as const,
+		secure,
+		expires: new Date(expiresAt),
+	};
+}
+
+// Kratos one-time login codes are six digits.
+export function isValidLoginCode(code: string | null): code is string {
+	return code !== null && /^\d{6}$/.test(code);
+}
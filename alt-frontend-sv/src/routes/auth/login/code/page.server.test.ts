@@ -0,0 +1,65 @@
+import { describe, it, expect, vi, beforeEach } from "vitest";
+
+const getLoginFlow = vi.fn();
+vi.mock("$lib/ory", () => ({
+	ory: { getLoginFlow },
+}));
+
+function makeCookies(values: Record<string, string> = {}) {
+	return {
+		get: vi.fn((name: string) => values[name]),
+		delete: vi.fn(),
+	};
+}
+
+async function callLoad(pathAndQuery: string, cookies = makeCookies()) {
+	const { load } = await import("./+page.server");
+	return load({
+		url: new URL(`http://localhost:4173${pathAndQuery}`),
+		request: new Request("http://localhost:4173/auth/login/code"),
+		cookies,
+		// biome-ignore lint: test double, real event shape not needed
+	} as any);
+}
+
+describe("sign-in link +page.server load", () => {
+	beforeEach(() => {
+		vi.clearAllMocks();
+	});
+
+	it("rejects a malformed code without contacting Kratos", async () => {
+		const result = await callLoad("/auth/login/code?code=abc");
+
+		expect(result).toEqual({ error: "invalid_link" });
+		expect(getLoginFlow).not.toHaveBeenCalled();
+	});
+
+	it("refuses links opened on another device", async () => {
+		const result = await callLoad("/auth/login/code?code=123456");
+
+		expect(result).toEqual({ error: "other_device" });
+		expect(getLoginFlow).not.toHaveBeenCalled();
+	});
+
+	it("returns the bound flow and code", async () => {
+		getLoginFlow.mockResolvedValue({ data: { id: "flow-1" } });
+
+		const result = await callLoad(
+			"/auth/login/code?code=123456",
+			makeCookies({ alt_login_code_flow: "flow-1" }),
+		);
+
+		expect(getLoginFlow).toHaveBeenCalledWith(expect.objectContaining({ id: "flow-1" }));
+		expect(result).toEqual({ flow: { id: "flow-1" }, code: "123456" });
+	});
+
+	it("clears the binding when the flow has expired", async () => {
+		getLoginFlow.mockRejectedValue(new Error("gone"));
+		const cookies = makeCookies({ alt_login_code_flow: "flow-1" });
+
+		const result = await callLoad("/auth/login/code?code=123456", cookies);
+
+		expect(result).toEqual({ error: "expired" });
+		expect(cookies.delete).toHaveBeenCalledWith("alt_login_code_flow", { path: "/auth/login" });
+	});
+});
@@ -24,6 +24,7 @@ async function callLoad(params: {
 	url: URL;
 	locals: { session: unknown };
 	request: Request;
+	cookies?: { set: ReturnType<typeof vi.fn> };
 }) {
 	const { load } = await import("./+page.server");
 	// biome-ignore lint: test double, real event shape not needed
@@ -152,6 +153,26 @@ describe("login +page.server load", () => {
 			expect(result).toEqual({ flow: { id: "flow-1" } });
 		});
 
+		it("binds the flow to this browser once a sign-in link was emailed", async () => {
+			getLoginFlow.mockResolvedValue({
+				data: { id: "flow-1", state: "sent_email", expires_at: "2026-10-16T10:00:00Z" },
+			});
+			const cookies = { set: vi.fn() };
+
+			await callLoad({
+				url: makeUrl("/auth/login?flow=flow-1"),
+				locals: { session: null },
+				request: makeRequest(),
+				cookies,
+			});
+
+			expect(cookies.set).toHaveBeenCalledWith(
+				"alt_login_code_flow",
+				"flow-1",
+				expect.objectContaining({ path: "/auth/login", httpOnly: true }),
+			);
+		});
+
 		it("redirects to the error page when the flow fetch fails", async () => {
 			getLoginFlow.mockRejectedValue(new Error("expired"));
 
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+var bootstrapCmd = &cobra.Command{
+	Use:   "bootstrap",
+	Short: "Bring up a working Alt environment in one command",
+	Long: `Bootstrap collapses the first-time setup runbook into one supervised command.
+
+With --environment staging|production the full server setup runs:
+  1. altctl init   (prerequisites, .env, secrets, atlas.sum)
+  2. Check the Inoreader OAuth2 client credentials in secrets/
+  3. Build and start the environment's stack profile
+  4. Print the OAuth2 authorization steps for pre-processor-sidecar
+  5. Run deploy-system/smoke-test.sh
+
+Compose has no namespaces: every stack runs in the single "alt" project,
+so environments differ only in their stack profile and secrets.
+
+Subcommands:
+  local   Initialize .env/secrets, build and start the stacks, run smoke tests
+
+Examples:
+  altctl bootstrap local                        # Full local stack for new contributors
+  altctl bootstrap --environment staging        # First-time staging host setup
+  altctl bootstrap --environment production --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runBootstrap,
+}
+
+var bootstrapLocalCmd = &cobra.Command{
+	Use:   "local",
+	Short: "Bootstrap a local development stack",
+	Long: `Bootstrap a working local Alt stack from a fresh checkout.
+
+Alt is Compose-first: the local environment is the Docker Compose stack
+(there is no kind/k3s cluster to create). This command performs:
+  1. altctl init   (prerequisites, .env from template, secrets, atlas.sum)
+  2. Build images and start the stacks with dependencies resolved
+  3. Run deploy-system/smoke-test.sh against the running stack
+
+Re-running is safe: existing .env and secrets are kept unless --force is used.
+
+Examples:
+  altctl bootstrap local                      # Default stacks (db, auth, core, workers)
+  altctl bootstrap local --stacks core,rag    # Custom stack selection
+  altctl bootstrap local --no-build           # Use already-built images
+  altctl bootstrap local --no-smoke           # Skip smoke tests`,
+	Args: cobra.NoArgs,
+	RunE: runBootstrapLocal,
+}
+
+func init() {
+	rootCmd.AddCommand(bootstrapCmd)
+	bootstrapCmd.AddCommand(bootstrapLocalCmd)
+
+	bootstrapCmd.Flags().String("environment", "local", "target environment (local, staging, production)")
+	addBootstrapFlags(bootstrapCmd)
+	addBootstrapFlags(bootstrapLocalCmd)
+}
+
+// addBootstrapFlags registers the flags shared by every bootstrap flow.
+// force/skip-secrets are read by runInit, which bootstrap reuses.
+func addBootstrapFlags(c *cobra.Command) {
+	c.Flags().Bool("force", false, "overwrite existing .env and secret files")
+	c.Flags().Bool("skip-secrets", false, "skip secret file generation")
+	c.Flags().StringSlice("stacks", nil, "stacks to start (default: the environment's stack profile)")
+	c.Flags().Bool("no-build", false, "start without rebuilding images")
+	c.Flags().Bool("no-smoke", false, "skip smoke tests after startup")
+	c.Flags().Duration("build-timeout", 30*time.Minute, "timeout for build phase")
+	c.Flags().Duration("startup-timeout", 10*time.Minute, "timeout for container startup")
+}
+
+func runBootstrapLocal(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+
+	// Step 1: init (prerequisites, env, secrets, checksums)
+	printer.Header("Bootstrap 1/3: Initialize")
+	fmt.Println()
+	if err := runInit(cmd, nil); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	// Step 2: build + start
+	printer.Header("Bootstrap 2/3: Start Stacks")
+	stackNames, _ := cmd.Flags().GetStringSlice("stacks")
+	if len(stackNames) == 0 {
+		stackNames = cfg.Defaults.Stacks
+	}
+	if err := bootstrapStartStacks(cmd, printer, stackNames, "local"); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	// Step 3: smoke tests
+	printer.Header("Bootstrap 3/3: Smoke Tests")
+	if err := bootstrapSmokeTests(cmd, printer); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	printer.Success("Local environment is ready")
+	printer.PrintHints("bootstrap local")
+	return nil
+}
+
+// bootstrapStartStacks builds (unless --no-build) and starts the given stacks,
+// printing a partial-startup diagnostic when some services fail to come up.
+func bootstrapStartStacks(cmd *cobra.Command, printer *output.Printer, stackNames []string, env string) error {
+	stacks, files, err := resolveBootstrapStacks(stackNames)
+	if err != nil {
+		return err
+	}
+	for _, s := range stacks {
+		printer.Info("  • %s: %s", printer.Bold(s.Name), s.Description)
+	}
+	fmt.Println()
+
+	client := compose.NewClient(getProjectRoot(), getComposeDir(), logger, dryRun)
+
+	noBuild, _ := cmd.Flags().GetBool("no-build")
+	if !noBuild {
+		buildTimeout, _ := cmd.Flags().GetDuration("build-timeout")
+		buildCtx, buildCancel := context.WithTimeout(cmd.Context(), buildTimeout)
+		defer buildCancel()
+		if err := client.Build(buildCtx, compose.BuildOptions{
+			Files:    files,
+			Parallel: true,
+			Progress: "auto",
+		}); err != nil {
+			return &output.CLIError{
+				Summary:    "bootstrap build failed",
+				Detail:     err.Error(),
+				Suggestion: fmt.Sprintf("Run 'altctl build' to diagnose build issues, then re-run 'altctl bootstrap --environment %s --no-build'", env),
+				ExitCode:   output.ExitComposeError,
+			}
+		}
+		printer.Success("Images built")
+	}
+
+	startupTimeout, _ := cmd.Flags().GetDuration("startup-timeout")
+	startCtx, startCancel := context.WithTimeout(cmd.Context(), startupTimeout)
+	defer startCancel()
+	if err := client.Up(startCtx, compose.UpOptions{
+		Files:   files,
+		Detach:  true,
+		Timeout: startupTimeout,
+	}); err != nil {
+		printer.Error("Failed to start stacks: %v", err)
+
+		psCtx, psCancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+		defer psCancel()
+		if statuses, psErr := client.PS(psCtx, files); psErr == nil {
+			diag := classifyServices(stacks, statuses)
+			if cliErr := buildPartialStartupError(diag, err); cliErr != nil {
+				fmt.Println()
+				printDiagnostic(printer, diag)
+				return cliErr
+			}
+		}
+		return err
+	}
+	printer.Success("Stacks started")
+	return nil
+}
+
+// bootstrapSmokeTests runs the smoke test script unless --no-smoke is set.
+func bootstrapSmokeTests(cmd *cobra.Command, printer *output.Printer) error {
+	noSmoke, _ := cmd.Flags().GetBool("no-smoke")
+	if noSmoke {
+		printer.Info("Skipped (--no-smoke)")
+		return nil
+	}
+	if err := runSmokeTests(cmd.Context(), printer); err != nil {
+		return &output.CLIError{
+			Summary:    "smoke tests failed after bootstrap",
+			Detail:     err.Error(),
+			Suggestion: "Stacks are running; inspect with 'altctl status' and 'altctl logs <service>'",
+			ExitCode:   output.ExitGeneral,
+		}
+	}
+	printer.Success("Smoke tests passed")
+	return nil
+}
+
+// resolveBootstrapStacks resolves stack dependencies and returns the stacks
+// together with their compose files in start order.
+func resolveBootstrapStacks(stackNames []string) ([]*stack.Stack, []string, error) {
+	registry := stack.NewRegistry()
+	resolver := stack.NewDependencyResolver(registry)
+	stacks, err := resolver.Resolve(stackNames)
+	if err != nil {
+		return nil, nil, &output.CLIError{
+			Summary:    "failed resolving dependencies",
+			Detail:     err.Error(),
+			Suggestion: "Check stack definitions with 'altctl list --deps'",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+
+	var files []string
+	for _, s := range stacks {
+		if s.ComposeFile != "" {
+			files = append(files, s.ComposeFile)
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil, &output.CLIError{
+			Summary:    "no compose files to start",
+			Suggestion: "Run 'altctl list' to see available stacks",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+	return stacks, files, nil
+}
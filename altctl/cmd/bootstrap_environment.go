@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/setup"
+)
+
+// bootstrapEnvironment describes how a target environment is brought up.
+type bootstrapEnvironment struct {
+	// Stacks is the stack profile deployed for the environment. Empty means
+	// the configured default stacks.
+	Stacks []string
+	// RequireOAuth fails the bootstrap when the Inoreader OAuth2 client
+	// credentials are still empty placeholders.
+	RequireOAuth bool
+}
+
+// bootstrapEnvironments maps --environment values to their profiles.
+// Staging and production run the same Compose stacks on a server host;
+// production additionally ships logs and runs the backup schedule.
+var bootstrapEnvironments = map[string]bootstrapEnvironment{
+	"local": {},
+	"staging": {
+		Stacks:       []string{"core", "workers", "recap", "rag", "mq", "observability"},
+		RequireOAuth: true,
+	},
+	"production": {
+		Stacks:       []string{"core", "workers", "recap", "rag", "mq", "observability", "logging", "backup"},
+		RequireOAuth: true,
+	},
+}
+
+// oauthSecretFiles are the user-provided secrets the pre-processor-sidecar
+// OAuth2 flow (auth-token-manager) cannot run without.
+var oauthSecretFiles = []string{"inoreader_client_id.txt", "inoreader_client_secret.txt"}
+
+func bootstrapEnvironmentNames() []string {
+	names := make([]string, 0, len(bootstrapEnvironments))
+	for name := range bootstrapEnvironments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runBootstrap(cmd *cobra.Command, args []string) error {
+	envName, _ := cmd.Flags().GetString("environment")
+	env, ok := bootstrapEnvironments[envName]
+	if !ok {
+		return &output.CLIError{
+			Summary:    fmt.Sprintf("unknown environment: %s", envName),
+			Suggestion: fmt.Sprintf("Use one of: %s", strings.Join(bootstrapEnvironmentNames(), ", ")),
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+	if envName == "local" {
+		return runBootstrapLocal(cmd, args)
+	}
+	return runBootstrapEnvironment(cmd, envName, env)
+}
+
+func runBootstrapEnvironment(cmd *cobra.Command, envName string, env bootstrapEnvironment) error {
+	printer := newPrinter()
+
+	// Step 1: init (prerequisites, env, secrets, checksums)
+	printer.Header(fmt.Sprintf("Bootstrap 1/5: Initialize (%s)", envName))
+	fmt.Println()
+	if err := runInit(cmd, nil); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	// Step 2: OAuth2 credentials must be filled in before the workers stack
+	// starts, otherwise auth-token-manager and the sidecar crash-loop.
+	printer.Header("Bootstrap 2/5: OAuth2 Credentials")
+	if err := checkOAuthCredentials(printer, env.RequireOAuth); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	// Step 3: build + start the environment's stack profile
+	printer.Header("Bootstrap 3/5: Deploy Stacks")
+	stackNames, _ := cmd.Flags().GetStringSlice("stacks")
+	if len(stackNames) == 0 {
+		stackNames = env.Stacks
+	}
+	if len(stackNames) == 0 {
+		stackNames = cfg.Defaults.Stacks
+	}
+	if err := bootstrapStartStacks(cmd, printer, stackNames, envName); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	// Step 4: OAuth2 authorization is a browser flow and cannot be automated
+	printer.Header("Bootstrap 4/5: OAuth2 Authorization")
+	printOAuthGuidance(printer)
+	fmt.Println()
+
+	// Step 5: smoke tests
+	printer.Header("Bootstrap 5/5: Smoke Tests")
+	if err := bootstrapSmokeTests(cmd, printer); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	printer.Success("%s environment is ready", envName)
+	printer.PrintHints("bootstrap")
+	return nil
+}
+
+// checkOAuthCredentials verifies the Inoreader client credentials are filled
+// in. Missing credentials are fatal when required, a warning otherwise.
+func checkOAuthCredentials(printer *output.Printer, required bool) error {
+	if dryRun {
+		printer.Info("[dry-run] Would check secrets/%s", strings.Join(oauthSecretFiles, ", secrets/"))
+		return nil
+	}
+
+	empty := setup.EmptySecrets(filepath.Join(getProjectRoot(), "secrets"), oauthSecretFiles)
+	if len(empty) == 0 {
+		printer.Success("Inoreader OAuth2 client credentials present")
+		return nil
+	}
+	for _, name := range empty {
+		printer.Warning("Empty: secrets/%s", name)
+	}
+	if !required {
+		return nil
+	}
+	return &output.CLIError{
+		Summary:    "Inoreader OAuth2 client credentials are not set",
+		Detail:     fmt.Sprintf("empty or missing: %s", strings.Join(empty, ", ")),
+		Suggestion: "Fill in the client ID/secret from the Inoreader developer console, then re-run bootstrap",
+		ExitCode:   output.ExitConfigError,
+	}
+}
+
+// printOAuthGuidance explains the one-time authorization that lets
+// auth-token-manager issue tokens to pre-processor-sidecar.
+func printOAuthGuidance(printer *output.Printer) {
+	printer.Info("pre-processor-sidecar needs an Inoreader token issued by auth-token-manager.")
+	printer.Info("Complete the one-time authorization in a browser:")
+	printer.Info("  1. On a remote host, forward the port: ssh -L 9201:127.0.0.1:9201 <host>")
+	printer.Info("  2. Open http://127.0.0.1:9201/auth?token=<secrets/internal_auth_token.txt>")
+	printer.Info("  3. Approve access; the callback stores the token in the oauth_token_data volume")
+	printer.Info("  4. Confirm with 'altctl logs auth-token-manager' and 'altctl logs pre-processor-sidecar'")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/integrity"
 	"github.com/alt-project/altctl/internal/output"
 	"github.com/alt-project/altctl/internal/stack"
 )
@@ -23,19 +24,88 @@ var deployCmd = &cobra.Command{
 
 Deploy performs the following steps:
   1. git fetch + pull (fast-forward only)
-  2. Build images for the target stacks
-  3. Start/restart services with the new images
-  4. Run smoke tests to verify deployment
+  2. (optional, --frozen-lockfile) Verify every stack matches its pin in
+     the deploy lockfile
+  3. Check for conflicts: secret files with foreign ownership or loose
+     permissions, and container names held by another Compose project
+  4. (optional, --prewarm) Pre-pull registry images for the target stacks
+  5. Build images for the target stacks
+  6. Run the schema migration jobs of the target stacks and check that
+     none is failed or left pending
+  7. Start/restart services with the new images
+  8. Run smoke tests to verify deployment
+  9. Probe the data integrity of the deployed database services
+
+On an interactive terminal each conflict is shown with its proposed
+resolution and risk level, and you approve, skip, or abort per item.
+With --non-interactive, or when stdin is not a TTY, conflicts are only
+reported as warnings and the deploy continues.
+
+--prewarm pulls registry images (e.g. the large Ollama/LLM images used by
+news-creator) while the old containers keep serving, so the restart in
+step 7 no longer waits on multi-GB downloads.
+
+The migration gate runs each migration job (the Atlas migrators, and
+kratos-migrate for auth) with 'docker compose run', then runs it again in
+status mode. If a job fails, or its status still shows pending or failed
+migrations, the deploy stops before any application container is
+recreated. --skip-migrations bypasses the gate; the jobs then only run as
+part of step 7, as before.
+
+With --dry-run, each stack is rendered with 'docker compose config' and the
+manifests are written to a timestamped directory under --report-dir together
+with report.md, which lists services, images, and the changes since the last
+real deployment. A successful real deploy records its rendered manifests as
+the baseline for that comparison.
+
+When the config selects a node type from its price table (cost.node and
+cost.nodes), the report also estimates the monthly cost of each stack and
+service from their resource reservations (falling back to limits), with the
+delta against the last real deployment.
+
+A successful real deploy also pins each deployed stack in the deploy
+lockfile (.altctl/deploy.lock.json): compose file digest and commit, and the
+digest of the rendered services including .env values. --frozen-lockfile
+refuses to deploy if any target stack differs from its pin and leaves the
+lockfile untouched; refresh pins on purpose with 'altctl lock update'.
+
+The integrity phase runs read-only probes with 'docker compose exec' against
+each deployed PostgreSQL service (replication lag of attached standbys, WAL
+retained by replication slots, and a spot check that reads a few random
+tables in full so page checksum failures surface) and against Meilisearch
+(health, empty indexes, tasks failed since the deploy started). Each result
+is graded ok, warning or critical against the integrity section of the
+config. Results are written to report.md of a new run directory under
+--report-dir; a critical result fails the deploy after the services are up
+and the baseline and lockfile are recorded. --no-integrity skips the phase.
 
 If no stacks are specified, deploys the default stacks.
 Dependencies are automatically resolved.
 
+--only deploys just the named stacks. Adding --with-deps also deploys those
+of their upstream dependencies that need it: stacks with no running
+service, and stacks whose rendered configuration differs from their pin in
+the deploy lockfile (or that have no pin). Dependencies that are running
+and match their pin are skipped. --restart-dependents restarts the running
+downstream stacks after the deployed stacks are up, so they reconnect to
+the new containers; stopped dependents are left stopped.
+
 Examples:
   altctl deploy                   # Deploy default stacks
   altctl deploy core              # Deploy core stack only
+  altctl deploy --only core --with-deps
+                                  # Deploy core plus stale dependencies
+  altctl deploy --only db --restart-dependents
+                                  # Deploy db, then restart what uses it
   altctl deploy --no-pull         # Skip git pull, just rebuild and restart
   altctl deploy --no-smoke        # Skip smoke tests after deploy
-  altctl deploy --no-cache        # Build without Docker cache`,
+  altctl deploy --no-cache        # Build without Docker cache
+  altctl deploy ai --prewarm      # Pre-pull large images before restarting
+  altctl deploy --non-interactive # Report conflicts without prompting
+  altctl deploy --dry-run         # Show commands and write a change report
+  altctl deploy --frozen-lockfile # Refuse to deploy unpinned changes
+  altctl deploy --skip-migrations # Restart without the migration gate
+  altctl deploy db --no-integrity # Skip the post-deploy integrity probes`,
 	Args:              cobra.ArbitraryArgs,
 	ValidArgsFunction: completeStackNames,
 	RunE:              runDeploy,
@@ -49,12 +119,27 @@ func init() {
 	deployCmd.Flags().Bool("no-cache", false, "build without Docker cache")
 	deployCmd.Flags().Bool("pull", false, "pull base images before building")
 	deployCmd.Flags().Bool("no-deps", false, "don't deploy dependent stacks")
+	deployCmd.Flags().StringSlice("only", nil, "deploy only these stacks (see --with-deps)")
+	deployCmd.Flags().Bool("with-deps", false, "with --only, also deploy dependencies that are not running or differ from their pin")
+	deployCmd.Flags().Bool("restart-dependents", false, "restart running downstream stacks after deploying")
+	deployCmd.Flags().Bool("prewarm", false, "pre-pull registry images before building and restarting")
+	deployCmd.Flags().Duration("prewarm-timeout", 30*time.Minute, "timeout for image pre-pull phase")
 	deployCmd.Flags().Duration("build-timeout", 30*time.Minute, "timeout for build phase")
 	deployCmd.Flags().Duration("startup-timeout", 5*time.Minute, "timeout for container startup")
+	deployCmd.Flags().Bool("skip-migrations", false, "don't gate the rollout on schema migrations")
+	deployCmd.Flags().Duration("migration-timeout", 10*time.Minute, "timeout for each migration job")
+	deployCmd.Flags().Bool("no-integrity", false, "skip the post-deploy data integrity probes")
+	deployCmd.Flags().Duration("integrity-timeout", 2*time.Minute, "timeout for the integrity probes of each database service")
+	deployCmd.Flags().String("report-dir", "", "directory for rendered manifests and dry-run reports (default: <project>/.altctl/deploy-reports)")
+	deployCmd.Flags().Bool("no-report", false, "skip rendering manifests and the dry-run report")
+	deployCmd.Flags().Bool("non-interactive", false, "report conflicts as warnings instead of prompting")
+	deployCmd.Flags().Bool("frozen-lockfile", false, "refuse to deploy stacks that differ from the deploy lockfile")
+	deployCmd.Flags().String("lockfile", "", "deploy lockfile path (default: <project>/.altctl/deploy.lock.json)")
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
 	printer := newPrinter()
+	startedAt := time.Now()
 	registry := stack.NewRegistry()
 	resolver := stack.NewDependencyResolver(registry)
 
@@ -68,10 +153,37 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	// Resolve dependencies unless --no-deps
 	noDeps, _ := cmd.Flags().GetBool("no-deps")
+	only, _ := cmd.Flags().GetStringSlice("only")
+	withDeps, _ := cmd.Flags().GetBool("with-deps")
 	var stacks []*stack.Stack
 	var err error
 
-	if noDeps {
+	if err := validateSelectiveFlags(args, only, noDeps, withDeps); err != nil {
+		return err
+	}
+
+	if len(only) > 0 {
+		for _, name := range only {
+			if _, ok := registry.Get(name); !ok {
+				return &output.CLIError{
+					Summary:    fmt.Sprintf("unknown stack: %s", name),
+					Suggestion: "Run 'altctl list' to see available stacks",
+					ExitCode:   output.ExitUsageError,
+				}
+			}
+		}
+		plan, planErr := planSelectiveDeploy(resolver, only, withDeps, probeStaleStacks(cmd, printer))
+		if planErr != nil {
+			return &output.CLIError{
+				Summary:    "failed resolving dependencies",
+				Detail:     planErr.Error(),
+				Suggestion: "Check stack definitions with 'altctl list --deps'",
+				ExitCode:   output.ExitUsageError,
+			}
+		}
+		printSelectivePlan(printer, plan, only)
+		stacks = plan.Stacks
+	} else if noDeps {
 		for _, name := range stackNames {
 			s, ok := registry.Get(name)
 			if !ok {
@@ -127,7 +239,42 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
-	// Phase 2: Build
+	client := compose.NewClient(
+		getProjectRoot(),
+		getComposeDir(),
+		logger,
+		dryRun,
+	)
+
+	// Phase 2 (optional): Refuse stacks that drifted from their pins
+	frozenLockfile, _ := cmd.Flags().GetBool("frozen-lockfile")
+	if frozenLockfile {
+		if err := checkFrozenLockfile(cmd, printer, stacks); err != nil {
+			return err
+		}
+	}
+
+	// Phase 3: Conflicts
+	nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+	if err := resolveDeployConflicts(cmd.Context(), printer, files, nonInteractive); err != nil {
+		return err
+	}
+
+	// Phase 4 (optional): Pre-pull registry images while old containers serve
+	prewarm, _ := cmd.Flags().GetBool("prewarm")
+	if prewarm {
+		printer.Header("Pre-pulling Images")
+		prewarmTimeout, _ := cmd.Flags().GetDuration("prewarm-timeout")
+		if err := prewarmImages(cmd.Context(), client, files, prewarmTimeout); err != nil {
+			// Non-fatal: up pulls anything still missing, just with more downtime.
+			printer.Warning("Image pre-pull incomplete: %v", err)
+		} else {
+			printer.Success("Images pre-pulled")
+		}
+		fmt.Println()
+	}
+
+	// Phase 5: Build
 	printer.Header("Building Images")
 	for _, s := range stacks {
 		printer.Info("  • %s", printer.Bold(s.Name))
@@ -138,13 +285,6 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	pullImages, _ := cmd.Flags().GetBool("pull")
 	buildTimeout, _ := cmd.Flags().GetDuration("build-timeout")
 
-	client := compose.NewClient(
-		getProjectRoot(),
-		getComposeDir(),
-		logger,
-		dryRun,
-	)
-
 	buildCtx, buildCancel := context.WithTimeout(cmd.Context(), buildTimeout)
 	defer buildCancel()
 
@@ -167,7 +307,23 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	printer.Success("Images built")
 	fmt.Println()
 
-	// Phase 3: Start services
+	// Phase 6: Migration gate
+	skipMigrations, _ := cmd.Flags().GetBool("skip-migrations")
+	if skipMigrations {
+		printer.Warning("Skipping the migration gate (--skip-migrations)")
+		fmt.Println()
+	} else {
+		printer.Header("Running Migrations")
+		migrationTimeout, _ := cmd.Flags().GetDuration("migration-timeout")
+		if err := runMigrationGate(cmd.Context(), printer, client, files, stacks, migrationTimeout); err != nil {
+			printer.Error("Migration gate failed")
+			return err
+		}
+		printer.Success("Migrations applied")
+		fmt.Println()
+	}
+
+	// Phase 7: Start services
 	printer.Header("Starting Services")
 	startupTimeout, _ := cmd.Flags().GetDuration("startup-timeout")
 
@@ -200,7 +356,22 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	printer.Success("Services started")
 	fmt.Println()
 
-	// Phase 4: Smoke tests
+	restartDeps, _ := cmd.Flags().GetBool("restart-dependents")
+	if restartDeps {
+		printer.Header("Restarting Dependents")
+		if err := restartDependents(cmd.Context(), printer, client, resolver, stacks, startupTimeout); err != nil {
+			printer.Error("Failed to restart dependents: %v", err)
+			return &output.CLIError{
+				Summary:    "deployed stacks are up but dependents were not restarted",
+				Detail:     err.Error(),
+				Suggestion: "Run 'altctl restart <stack>' for the remaining dependents",
+				ExitCode:   output.ExitComposeError,
+			}
+		}
+		fmt.Println()
+	}
+
+	// Phase 8: Smoke tests
 	noSmoke, _ := cmd.Flags().GetBool("no-smoke")
 	if !noSmoke {
 		printer.Header("Running Smoke Tests")
@@ -214,11 +385,89 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	// Phase 9: Data integrity
+	var integrityResults []integrity.Result
+	noIntegrity, _ := cmd.Flags().GetBool("no-integrity")
+	if !noIntegrity {
+		printer.Header("Verifying Data Integrity")
+		integrityTimeout, _ := cmd.Flags().GetDuration("integrity-timeout")
+		integrityResults = runIntegrityProbes(cmd.Context(), printer, client, files, stacks, startedAt, integrityTimeout)
+		fmt.Println()
+	}
+
+	noReport, _ := cmd.Flags().GetBool("no-report")
+	if dryRun {
+		if !noReport {
+			writeDryRunReport(cmd, printer, stacks)
+			fmt.Println()
+		}
+	} else {
+		manifests, renderErrors := renderStackManifests(cmd.Context(), stacks)
+		for name, msg := range renderErrors {
+			printer.Warning("Could not record deployed manifest for %s: %s", name, msg)
+		}
+		if !noReport {
+			store := deployReportStore(cmd)
+			if len(integrityResults) > 0 {
+				writeDeployReport(printer, store, manifests, renderErrors, integrityResults)
+			}
+			recordDeployBaseline(printer, store, manifests)
+		}
+		if !frozenLockfile {
+			recordDeployLock(cmd, printer, stacks, manifests)
+		}
+	}
+
+	if err := integrityError(integrityResults); err != nil {
+		printer.Error("Data integrity probes failed")
+		return err
+	}
+
 	printer.Success("Deployment completed successfully")
 	printer.PrintHints("deploy")
 	return nil
 }
 
+// validateSelectiveFlags rejects flag combinations that make --only
+// ambiguous.
+func validateSelectiveFlags(args, only []string, noDeps, withDeps bool) error {
+	switch {
+	case len(only) > 0 && len(args) > 0:
+		return &output.CLIError{
+			Summary:    "--only cannot be combined with stack arguments",
+			Suggestion: "Name the stacks in --only, e.g. 'altctl deploy --only core,workers'",
+			ExitCode:   output.ExitUsageError,
+		}
+	case len(only) > 0 && noDeps:
+		return &output.CLIError{
+			Summary:    "--only cannot be combined with --no-deps",
+			Suggestion: "--only already skips dependencies unless --with-deps is set",
+			ExitCode:   output.ExitUsageError,
+		}
+	case withDeps && len(only) == 0:
+		return &output.CLIError{
+			Summary:    "--with-deps requires --only",
+			Suggestion: "Use 'altctl deploy --only <stack> --with-deps'",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+	return nil
+}
+
+// prewarmImages pulls registry images for the given compose files. Services
+// with a build section are skipped (they are produced by the build phase), and
+// a single failing image does not abort the rest of the pre-pull.
+func prewarmImages(ctx context.Context, client *compose.Client, files []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return client.Pull(ctx, compose.PullOptions{
+		Files:              files,
+		IgnoreBuildable:    true,
+		IgnorePullFailures: true,
+	})
+}
+
 // gitPull fetches and pulls the latest code. Returns true if changes were pulled.
 func gitPull(ctx context.Context, printer *output.Printer) (bool, error) {
 	root := getProjectRoot()
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/conflict"
+	"github.com/alt-project/altctl/internal/output"
+)
+
+// detectDeployConflicts looks for secret files the deploy user cannot manage
+// and fixed container names held outside this Compose project. Detection is
+// read-only and always runs for real, even under --dry-run. A compose file
+// that fails to render only produces a warning: the deploy itself will
+// surface the real error.
+func detectDeployConflicts(ctx context.Context, printer *output.Printer, files []string) ([]conflict.Conflict, error) {
+	conflicts, err := conflict.DetectSecrets(filepath.Join(getProjectRoot(), "secrets"))
+	if err != nil {
+		return nil, err
+	}
+
+	client := compose.NewClient(getProjectRoot(), getComposeDir(), logger, false)
+	renderCtx, cancel := context.WithTimeout(ctx, renderTimeout)
+	rendered, err := client.RenderConfig(renderCtx, files)
+	cancel()
+	if err != nil {
+		printer.Warning("Could not render compose config, skipping container checks: %v", err)
+		return conflicts, nil
+	}
+	project, names, err := conflict.ParseRendered(rendered)
+	if err != nil {
+		printer.Warning("Skipping container checks: %v", err)
+		return conflicts, nil
+	}
+
+	docker := compose.NewExecutor(getProjectRoot(), logger, false)
+	containers, err := conflict.DetectContainers(ctx, docker, project, names)
+	if err != nil {
+		return nil, err
+	}
+	return append(conflicts, containers...), nil
+}
+
+// resolveDeployConflicts reports detected conflicts and, on an interactive
+// terminal, asks the operator to approve, skip or abort each proposed
+// resolution. With --non-interactive or without a TTY the conflicts are only
+// printed as warnings and the deploy proceeds as before.
+func resolveDeployConflicts(ctx context.Context, printer *output.Printer, files []string, nonInteractive bool) error {
+	printer.Header("Checking for Conflicts")
+
+	conflicts, err := detectDeployConflicts(ctx, printer, files)
+	if err != nil {
+		// Non-fatal: detection is a safety net, not a prerequisite.
+		printer.Warning("Conflict detection failed: %v", err)
+		fmt.Println()
+		return nil
+	}
+	if len(conflicts) == 0 {
+		printer.Success("No conflicts found")
+		fmt.Println()
+		return nil
+	}
+
+	if dryRun || nonInteractive || !stdinIsTerminal() {
+		for _, c := range conflicts {
+			printer.Warning("[%s risk] %s %s: %s", c.Risk, c.Kind, c.Resource, c.Detail)
+			printer.Info("  proposed resolution: %s", c.Resolution)
+		}
+		if dryRun {
+			printer.Info("[dry-run] %d conflict(s) would be offered for resolution", len(conflicts))
+		} else {
+			printer.Info("Continuing without resolving (non-interactive)")
+		}
+		fmt.Println()
+		return nil
+	}
+
+	prompter := conflict.NewPrompter(os.Stdin, os.Stdout)
+	outcome, err := conflict.ResolveAll(ctx, conflicts, prompter.Decide)
+	if errors.Is(err, conflict.ErrAborted) {
+		return &output.CLIError{
+			Summary:    "deployment aborted during conflict resolution",
+			Detail:     fmt.Sprintf("%d resolved, %d skipped before abort", len(outcome.Resolved), len(outcome.Skipped)),
+			Suggestion: "Fix the remaining conflicts by hand, or re-run with --non-interactive to deploy anyway",
+			ExitCode:   output.ExitGeneral,
+		}
+	}
+	if err != nil {
+		return &output.CLIError{
+			Summary:    "failed to resolve deployment conflict",
+			Detail:     err.Error(),
+			Suggestion: "Resolve it by hand (it may need sudo) and re-run deploy",
+			ExitCode:   output.ExitGeneral,
+		}
+	}
+	printer.Success("%d conflict(s) resolved, %d skipped", len(outcome.Resolved), len(outcome.Skipped))
+	fmt.Println()
+	return nil
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal. Deploys
+// run from systemd or CI have no TTY and never prompt.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/integrity"
+	"github.com/alt-project/altctl/internal/migrate"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+// meilisearchKeyFile is where compose/db.yaml mounts the Meilisearch master
+// key inside the container.
+const meilisearchKeyFile = "/run/secrets/meili_master_key"
+
+// composeExecer runs integrity probes with 'docker compose exec -T' against
+// the deploy's compose files.
+type composeExecer struct {
+	client *compose.Client
+	files  []string
+}
+
+func (e composeExecer) Exec(ctx context.Context, service string, command []string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	err := e.client.ExecWithOptions(ctx, compose.ExecOptions{Files: e.files, Service: service, Command: command}, &stdout, &stderr)
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// integrityTargets returns the database services of the target stacks that
+// have integrity probes: the PostgreSQL instances known to the backup
+// registry, and Meilisearch.
+func integrityTargets(stacks []*stack.Stack) []integrity.Target {
+	deployed := make(map[string]bool)
+	for _, s := range stacks {
+		for _, svc := range s.Services {
+			deployed[svc] = true
+		}
+	}
+
+	var targets []integrity.Target
+	for _, v := range migrate.NewVolumeRegistry().PostgreSQL() {
+		if deployed[v.Service] {
+			targets = append(targets, integrity.Target{
+				Kind:     integrity.KindPostgres,
+				Service:  v.Service,
+				Database: v.DBName,
+				User:     v.DBUser,
+			})
+		}
+	}
+	if deployed["meilisearch"] {
+		targets = append(targets, integrity.Target{
+			Kind:    integrity.KindMeilisearch,
+			Service: "meilisearch",
+			KeyFile: meilisearchKeyFile,
+		})
+	}
+	return targets
+}
+
+// integrityThresholds converts the integrity section of the config.
+func integrityThresholds() integrity.Thresholds {
+	if cfg == nil {
+		return integrity.Thresholds{}
+	}
+	in := cfg.Integrity
+	const mib = 1 << 20
+	return integrity.Thresholds{
+		ReplicationLagWarningBytes:  in.ReplicationLagWarningMB * mib,
+		ReplicationLagCriticalBytes: in.ReplicationLagCriticalMB * mib,
+		WALRetainedWarningBytes:     in.WALRetainedWarningMB * mib,
+		WALRetainedCriticalBytes:    in.WALRetainedCriticalMB * mib,
+		SampleTables:                in.SampleTables,
+		SampleMaxTableBytes:         in.SampleMaxTableMB * mib,
+		FailedTasksWarning:          in.FailedTasksWarning,
+		FailedTasksCritical:         in.FailedTasksCritical,
+	}
+}
+
+// runIntegrityProbes probes every database service of the target stacks and
+// prints one line per check. since is when the deploy started. Each target
+// gets its own timeout so one hung database does not starve the rest.
+// Under --dry-run the probes are only listed.
+func runIntegrityProbes(ctx context.Context, printer *output.Printer, client *compose.Client, files []string, stacks []*stack.Stack, since time.Time, timeout time.Duration) []integrity.Result {
+	targets := integrityTargets(stacks)
+	if len(targets) == 0 {
+		printer.Info("No database services in the target stacks")
+		return nil
+	}
+	if dryRun {
+		for _, t := range targets {
+			fmt.Printf("[dry-run] probe %s (%s)\n", t.Service, t.Kind)
+		}
+		return nil
+	}
+
+	prober := integrity.NewProber(composeExecer{client: client, files: files}, integrityThresholds(), since)
+	var results []integrity.Result
+	for _, t := range targets {
+		printer.Info("  • %s", printer.Bold(t.Service))
+		probeCtx, cancel := context.WithTimeout(ctx, timeout)
+		targetResults := prober.Probe(probeCtx, t)
+		cancel()
+
+		for _, r := range targetResults {
+			switch r.Status {
+			case integrity.StatusCritical:
+				printer.Error("%s %s: %s", r.Service, r.Check, r.Summary)
+			case integrity.StatusWarning:
+				printer.Warning("%s %s: %s", r.Service, r.Check, r.Summary)
+			default:
+				printer.Success("%s %s: %s", r.Service, r.Check, r.Summary)
+			}
+		}
+		results = append(results, targetResults...)
+	}
+	return results
+}
+
+// integrityError fails a deploy whose probes found a critical problem. The
+// services stay up: the deploy itself finished, and rolling back a database
+// is an operator decision.
+func integrityError(results []integrity.Result) error {
+	var critical []string
+	for _, r := range results {
+		if r.Status == integrity.StatusCritical {
+			critical = append(critical, fmt.Sprintf("%s %s: %s", r.Service, r.Check, r.Summary))
+		}
+	}
+	if len(critical) == 0 {
+		return nil
+	}
+	return &output.CLIError{
+		Summary:    "deployed, but data integrity probes found critical problems",
+		Detail:     strings.Join(critical, "\n"),
+		Suggestion: "Inspect the database services with 'altctl logs db' and the deploy report before serving traffic",
+		ExitCode:   output.ExitGeneral,
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alt-project/altctl/internal/integrity"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+func TestIntegrityTargets(t *testing.T) {
+	registry := stack.NewRegistry()
+	var stacks []*stack.Stack
+	for _, name := range []string{"db", "auth", "core"} {
+		s, ok := registry.Get(name)
+		if !ok {
+			t.Fatalf("stack %s not registered", name)
+		}
+		stacks = append(stacks, s)
+	}
+
+	got := make(map[string]integrity.Target)
+	for _, target := range integrityTargets(stacks) {
+		got[target.Service] = target
+	}
+
+	if db, ok := got["db"]; !ok || db.Kind != integrity.KindPostgres || db.Database != "alt" || db.User != "alt_db_user" {
+		t.Errorf("db target = %+v, want postgres alt/alt_db_user", db)
+	}
+	if meili, ok := got["meilisearch"]; !ok || meili.Kind != integrity.KindMeilisearch || meili.KeyFile == "" {
+		t.Errorf("meilisearch target = %+v, want meilisearch with key file", meili)
+	}
+	if _, ok := got["clickhouse"]; ok {
+		t.Error("clickhouse has no probes and should not be a target")
+	}
+	if _, ok := got["recap-db"]; ok {
+		t.Error("recap-db is not in the target stacks")
+	}
+}
+
+func TestIntegrityError(t *testing.T) {
+	if err := integrityError([]integrity.Result{{Status: integrity.StatusOK}, {Status: integrity.StatusWarning}}); err != nil {
+		t.Errorf("warnings should not fail the deploy, got %v", err)
+	}
+
+	err := integrityError([]integrity.Result{
+		{Service: "db", Check: "checksums", Status: integrity.StatusCritical, Summary: "1 new page checksum failure(s)"},
+	})
+	var cliErr *output.CLIError
+	if !errors.As(err, &cliErr) {
+		t.Fatalf("expected CLIError, got %v", err)
+	}
+	if !strings.Contains(cliErr.Detail, "db checksums: 1 new page checksum failure(s)") {
+		t.Errorf("detail = %q", cliErr.Detail)
+	}
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+// migrationStatusTimeout bounds one post-apply status check.
+const migrationStatusTimeout = 2 * time.Minute
+
+// migrationStatus is what a migration job's status check reported.
+type migrationStatus struct {
+	Pending int
+	// Failed is set when the tool recorded a migration attempt that errored.
+	Failed bool
+}
+
+// runMigrationGate applies the schema migrations of the target stacks, one
+// job at a time in stack order, and then asks each job for its status. A job
+// that fails, or that still reports pending or failed migrations, stops the
+// deploy before any application container is recreated. files is the full
+// set of deploy compose files, so jobs can start databases defined in other
+// stacks.
+func runMigrationGate(ctx context.Context, printer *output.Printer, client *compose.Client, files []string, stacks []*stack.Stack, timeout time.Duration) error {
+	var jobs []stack.Migration
+	for _, s := range stacks {
+		jobs = append(jobs, s.Migrations...)
+	}
+	if len(jobs) == 0 {
+		printer.Info("No migration jobs in the target stacks")
+		return nil
+	}
+
+	for _, job := range jobs {
+		printer.Info("  • %s", printer.Bold(job.Service))
+
+		applyCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := client.Run(applyCtx, compose.RunOptions{Files: files, Service: job.Service, Remove: true}, os.Stdout, os.Stderr)
+		cancel()
+		if err != nil {
+			return migrationGateError(job, "failed", err.Error())
+		}
+		if dryRun {
+			continue
+		}
+
+		var out bytes.Buffer
+		statusCtx, cancel := context.WithTimeout(ctx, migrationStatusTimeout)
+		err = client.Run(statusCtx, compose.RunOptions{
+			Files:   files,
+			Service: job.Service,
+			Command: migrationStatusCommand(job.Tool),
+			Remove:  true,
+		}, &out, &out)
+		cancel()
+		if err != nil {
+			return migrationGateError(job, "status check failed", fmt.Sprintf("%v\n%s", err, out.String()))
+		}
+
+		status, err := parseMigrationStatus(job.Tool, out.String())
+		switch {
+		case err != nil:
+			return migrationGateError(job, "status could not be read", fmt.Sprintf("%v\n%s", err, out.String()))
+		case status.Failed:
+			return migrationGateError(job, "reports a failed migration", out.String())
+		case status.Pending > 0:
+			return migrationGateError(job, fmt.Sprintf("still has %d pending migration(s)", status.Pending), out.String())
+		}
+		printer.Success("%s is up to date", job.Service)
+	}
+	return nil
+}
+
+func migrationGateError(job stack.Migration, what, detail string) error {
+	return &output.CLIError{
+		Summary:    fmt.Sprintf("migration job %s %s; application rollout blocked", job.Service, what),
+		Detail:     strings.TrimSpace(detail),
+		Suggestion: fmt.Sprintf("Inspect with 'altctl logs %s', fix the migration and redeploy (--skip-migrations bypasses this gate)", job.Service),
+		ExitCode:   output.ExitComposeError,
+	}
+}
+
+// migrationStatusCommand is the command that makes a job report its status
+// instead of applying.
+func migrationStatusCommand(tool stack.MigrationTool) []string {
+	switch tool {
+	case stack.MigrationToolKratos:
+		return []string{"kratos", "migrate", "sql", "status", "-e", "--config", "/etc/config/kratos/kratos.yml"}
+	default:
+		return []string{"status"}
+	}
+}
+
+// parseMigrationStatus reads the output of migrationStatusCommand.
+func parseMigrationStatus(tool stack.MigrationTool, out string) (migrationStatus, error) {
+	switch tool {
+	case stack.MigrationToolKratos:
+		return parseKratosStatus(out)
+	default:
+		return parseAtlasStatus(out)
+	}
+}
+
+// parseAtlasStatus reads 'atlas migrate status' output:
+//
+//	Migration Status: PENDING
+//	  -- Current Version: 20261016070000
+//	  -- Next Version:    20261016080000
+//	  -- Executed Files:  412
+//	  -- Pending Files:   1
+//
+// A failed attempt adds "Last migration attempt had errors" and an
+// "-- Error:" line.
+func parseAtlasStatus(out string) (migrationStatus, error) {
+	var (
+		status migrationStatus
+		state  string
+	)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "Migration Status:"):
+			state = strings.TrimSpace(strings.TrimPrefix(line, "Migration Status:"))
+		case strings.HasPrefix(line, "-- Pending Files:"):
+			n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "-- Pending Files:")))
+			if err == nil {
+				status.Pending = n
+			}
+		case strings.Contains(line, "Last migration attempt had errors"), strings.HasPrefix(line, "-- Error:"):
+			status.Failed = true
+		}
+	}
+
+	switch state {
+	case "OK":
+		return status, nil
+	case "PENDING":
+		if status.Pending == 0 {
+			// PENDING without a file count is still not clean.
+			status.Pending = 1
+		}
+		return status, nil
+	case "":
+		return status, fmt.Errorf("no \"Migration Status\" line in atlas output")
+	default:
+		return status, fmt.Errorf("unknown atlas migration status %q", state)
+	}
+}
+
+// parseKratosStatus reads 'kratos migrate sql status' output, a table with
+// one migration per row ending in "Applied" or "Pending".
+func parseKratosStatus(out string) (migrationStatus, error) {
+	var (
+		status migrationStatus
+		rows   int
+	)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[len(fields)-1] {
+		case "Applied":
+			rows++
+		case "Pending":
+			rows++
+			status.Pending++
+		}
+	}
+	if rows == 0 {
+		return status, fmt.Errorf("no migration rows in kratos output")
+	}
+	return status, nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+func TestParseAtlasStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		out         string
+		wantPending int
+		wantFailed  bool
+		wantErr     bool
+	}{
+		{
+			name: "up to date",
+			out: `[INFO] Checking migration status...
+Migration Status: OK
+  -- Current Version: 20261016080000
+  -- Next Version:    Already at latest version
+  -- Executed Files:  413
+  -- Pending Files:   0`,
+		},
+		{
+			name: "pending",
+			out: `Migration Status: PENDING
+  -- Current Version: 20261016070000
+  -- Next Version:    20261016080000
+  -- Executed Files:  412
+  -- Pending Files:   2`,
+			wantPending: 2,
+		},
+		{
+			name: "failed attempt",
+			out: `Migration Status: PENDING
+  -- Current Version: 20261016070000
+  -- Next Version:    20261016080000
+  -- Executed Files:  413 (last one partially)
+  -- Pending Files:   1
+
+Last migration attempt had errors:
+  -- SQL:   CREATE TABLE feature_flags (...);
+  -- Error: pq: relation "feature_flags" already exists`,
+			wantPending: 1,
+			wantFailed:  true,
+		},
+		{name: "unrecognised output", out: "connection refused", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMigrationStatus(stack.MigrationToolAtlas, tt.out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Pending != tt.wantPending || got.Failed != tt.wantFailed {
+				t.Errorf("status = %+v, want pending %d failed %v", got, tt.wantPending, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestParseKratosStatus(t *testing.T) {
+	out := `Version			Name					Status
+20150100000001000000	networks				Applied
+20241106142200000001	identity_credentials_index		Applied
+20250101000000000000	session_devices				Pending
+`
+	got, err := parseMigrationStatus(stack.MigrationToolKratos, out)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got.Pending != 1 {
+		t.Errorf("pending = %d, want 1", got.Pending)
+	}
+
+	if _, err := parseMigrationStatus(stack.MigrationToolKratos, "error: dial tcp: connection refused"); err == nil {
+		t.Error("expected an error for output without migration rows")
+	}
+}
+
+func TestDeploy_SkipMigrations(t *testing.T) {
+	setupDeployTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"deploy", "core", "--no-pull", "--no-smoke", "--no-report", "--skip-migrations", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("deploy --skip-migrations failed: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/deployreport"
+	"github.com/alt-project/altctl/internal/integrity"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+// renderTimeout bounds `docker compose config` for a single stack.
+const renderTimeout = 1 * time.Minute
+
+// deployReportStore returns the store for rendered manifests, honoring
+// --report-dir.
+func deployReportStore(cmd *cobra.Command) deployreport.Store {
+	dir, _ := cmd.Flags().GetString("report-dir")
+	if dir == "" {
+		dir = filepath.Join(getProjectRoot(), ".altctl", "deploy-reports")
+	}
+	return deployreport.Store{Dir: dir}
+}
+
+// renderStackManifests renders each stack's compose file on its own so the
+// artifacts map one-to-one onto stacks. Rendering is read-only and always
+// runs for real, even under --dry-run. Stacks that fail to render are
+// returned in the error map instead of aborting the others.
+func renderStackManifests(ctx context.Context, stacks []*stack.Stack) ([]*deployreport.StackManifest, map[string]string) {
+	client := compose.NewClient(getProjectRoot(), getComposeDir(), logger, false)
+
+	var manifests []*deployreport.StackManifest
+	renderErrors := make(map[string]string)
+	for _, s := range stacks {
+		if s.ComposeFile == "" {
+			continue
+		}
+		renderCtx, cancel := context.WithTimeout(ctx, renderTimeout)
+		rendered, err := client.RenderConfig(renderCtx, []string{s.ComposeFile})
+		cancel()
+		if err != nil {
+			renderErrors[s.Name] = err.Error()
+			continue
+		}
+		m, err := deployreport.ParseManifest(s.Name, rendered)
+		if err != nil {
+			renderErrors[s.Name] = err.Error()
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, renderErrors
+}
+
+// writeDryRunReport renders every target stack, diffs it against the last
+// real deployment, and writes the manifests plus report.md to a timestamped
+// directory. Failures only produce warnings: the report is advisory.
+func writeDryRunReport(cmd *cobra.Command, printer *output.Printer, stacks []*stack.Stack) {
+	printer.Header("Dry-run Report")
+	store := deployReportStore(cmd)
+
+	manifests, renderErrors := renderStackManifests(cmd.Context(), stacks)
+	for name, msg := range renderErrors {
+		printer.Warning("Could not render %s: %s", name, msg)
+	}
+
+	report, err := store.BuildReport(time.Now(), manifests, renderErrors)
+	if err != nil {
+		printer.Warning("Could not build dry-run report: %v", err)
+		return
+	}
+	if prices, ok := deployPriceTable(); ok {
+		report.EstimateCosts(prices)
+	}
+	path, err := store.WriteRun(report)
+	if err != nil {
+		printer.Warning("Could not write dry-run report: %v", err)
+		return
+	}
+
+	for _, sr := range report.Stacks {
+		if !sr.HasBaseline {
+			printer.Info("  • %s: %d services (no previous deploy recorded)", printer.Bold(sr.Manifest.Stack), len(sr.Manifest.Services))
+			continue
+		}
+		printer.Info("  • %s: %d services, %d changes", printer.Bold(sr.Manifest.Stack), len(sr.Manifest.Services), len(sr.Changes))
+	}
+	if report.Prices != nil {
+		current, previous := report.TotalCost()
+		printer.Info("  Estimated monthly cost: %s (last deploy: %s)",
+			report.Prices.FormatMoney(current), report.Prices.FormatMoney(previous))
+	}
+	printer.Success("Report written to %s", path)
+}
+
+// deployPriceTable builds the dry-run price table from the cost section of
+// the config. It reports false when no node type is selected.
+func deployPriceTable() (deployreport.PriceTable, bool) {
+	if cfg == nil || cfg.Cost.Node == "" {
+		return deployreport.PriceTable{}, false
+	}
+	node, ok := cfg.Cost.SelectedNode()
+	if !ok {
+		return deployreport.PriceTable{}, false
+	}
+	return deployreport.PriceTable{
+		Currency: cfg.Cost.Currency,
+		Node: deployreport.NodeType{
+			Name:         cfg.Cost.Node,
+			CPUs:         node.CPUs,
+			MemoryBytes:  int64(node.MemoryGiB * (1 << 30)),
+			GPUs:         node.GPUs,
+			MonthlyPrice: node.MonthlyPrice,
+		},
+	}, true
+}
+
+// writeDeployReport writes the manifests of a real deploy together with its
+// integrity probe results. It runs before the baseline is replaced so the
+// report still lists what the deploy changed.
+func writeDeployReport(printer *output.Printer, store deployreport.Store, manifests []*deployreport.StackManifest, renderErrors map[string]string, results []integrity.Result) {
+	report, err := store.BuildReport(time.Now(), manifests, renderErrors)
+	if err != nil {
+		printer.Warning("Could not build deploy report: %v", err)
+		return
+	}
+	report.Deployed = true
+	report.Integrity = results
+	path, err := store.WriteRun(report)
+	if err != nil {
+		printer.Warning("Could not write deploy report: %v", err)
+		return
+	}
+	printer.Info("Deploy report written to %s", path)
+}
+
+// recordDeployBaseline stores the rendered manifests of a successful deploy
+// so later dry runs can diff against it.
+func recordDeployBaseline(printer *output.Printer, store deployreport.Store, manifests []*deployreport.StackManifest) {
+	if err := store.SaveBaseline(manifests); err != nil {
+		printer.Warning("Could not record deployed manifests: %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+// statusProbeTimeout bounds the 'docker compose ps' calls that decide which
+// dependencies and dependents are running.
+const statusProbeTimeout = 15 * time.Second
+
+// selectivePlan is the outcome of planning a --only deploy.
+type selectivePlan struct {
+	// Stacks are the stacks to deploy, dependencies first.
+	Stacks []*stack.Stack
+	// Included maps each upstream dependency pulled into the deploy to the
+	// reason it was included.
+	Included map[string]string
+	// Skipped lists upstream dependencies left alone because they are
+	// running and match their pin.
+	Skipped []string
+}
+
+// staleProbe reports which of the given upstream stacks need deploying,
+// keyed by stack name with the reason as value.
+type staleProbe func(upstream []*stack.Stack) map[string]string
+
+// planSelectiveDeploy selects the --only stacks and, with withDeps, those of
+// their upstream dependencies that probe reports as stale. Dependencies are
+// never dropped from the middle of the graph on their own: a stale stack is
+// deployed even when the stacks it depends on are current.
+func planSelectiveDeploy(resolver *stack.DependencyResolver, only []string, withDeps bool, probe staleProbe) (*selectivePlan, error) {
+	closure, err := resolver.Resolve(only)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(only))
+	for _, name := range only {
+		selected[name] = true
+	}
+
+	plan := &selectivePlan{Included: make(map[string]string)}
+	if withDeps {
+		var upstream []*stack.Stack
+		for _, s := range closure {
+			if !selected[s.Name] {
+				upstream = append(upstream, s)
+			}
+		}
+		stale := probe(upstream)
+		for _, s := range upstream {
+			if reason, ok := stale[s.Name]; ok {
+				selected[s.Name] = true
+				plan.Included[s.Name] = reason
+			} else {
+				plan.Skipped = append(plan.Skipped, s.Name)
+			}
+		}
+	}
+
+	for _, s := range closure {
+		if selected[s.Name] {
+			plan.Stacks = append(plan.Stacks, s)
+		}
+	}
+	return plan, nil
+}
+
+// probeStaleStacks is the staleProbe used by deploy: a stack is stale when
+// none of its services is running, or when its rendered configuration
+// differs from its pin in the deploy lockfile. A stack with no pin counts as
+// stale, since nothing records what is deployed. Probing is read-only and
+// always runs for real, even under --dry-run; when a probe fails every stack
+// it covers is treated as stale.
+func probeStaleStacks(cmd *cobra.Command, printer *output.Printer) staleProbe {
+	return func(upstream []*stack.Stack) map[string]string {
+		stale := make(map[string]string)
+
+		running, err := runningStacks(cmd.Context(), upstream)
+		if err != nil {
+			printer.Warning("Could not read service status: %v", err)
+		}
+		for _, s := range upstream {
+			switch {
+			case err != nil:
+				stale[s.Name] = "service status unknown"
+			case len(s.Services) > 0 && !running[s.Name]:
+				stale[s.Name] = "not running"
+			}
+		}
+
+		lock, err := deployLockStore(cmd).Load()
+		if err != nil {
+			printer.Warning("Could not read deploy lockfile: %v", err)
+			for _, s := range upstream {
+				if _, ok := stale[s.Name]; !ok {
+					stale[s.Name] = "deploy lockfile unreadable"
+				}
+			}
+			return stale
+		}
+		manifests, renderErrors := renderStackManifests(cmd.Context(), upstream)
+		for name := range renderErrors {
+			if _, ok := stale[name]; !ok {
+				stale[name] = "could not render to compare with its pin"
+			}
+		}
+		pins, err := buildStackPins(cmd.Context(), upstream, manifests)
+		if err != nil {
+			printer.Warning("Could not pin dependencies: %v", err)
+			for _, m := range manifests {
+				if _, ok := stale[m.Stack]; !ok {
+					stale[m.Stack] = "could not compare with its pin"
+				}
+			}
+			return stale
+		}
+
+		changed := make(map[string][]string)
+		for _, d := range lock.Check(pins) {
+			changed[d.Stack] = append(changed[d.Stack], d.Field)
+		}
+		for name, fields := range changed {
+			if _, ok := stale[name]; ok {
+				continue
+			}
+			if slices.Contains(fields, "stack") {
+				stale[name] = "not pinned in deploy lockfile"
+			} else {
+				stale[name] = fmt.Sprintf("changed since pinned (%s)", strings.Join(fields, ", "))
+			}
+		}
+		return stale
+	}
+}
+
+// runningStacks reports which of stacks have at least one running service.
+func runningStacks(ctx context.Context, stacks []*stack.Stack) (map[string]bool, error) {
+	var files []string
+	for _, s := range stacks {
+		if s.ComposeFile != "" {
+			files = append(files, s.ComposeFile)
+		}
+	}
+	running := make(map[string]bool)
+	if len(files) == 0 {
+		return running, nil
+	}
+
+	client := compose.NewClient(getProjectRoot(), getComposeDir(), logger, false)
+	ctx, cancel := context.WithTimeout(ctx, statusProbeTimeout)
+	defer cancel()
+	statuses, err := client.PS(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+
+	diag := classifyServices(stacks, statuses)
+	for _, svc := range diag.running {
+		running[diag.expected[svc]] = true
+	}
+	for _, svc := range diag.unhealthy {
+		running[diag.expected[svc]] = true
+	}
+	return running, nil
+}
+
+// printSelectivePlan shows which stacks a --only deploy will touch and why.
+func printSelectivePlan(printer *output.Printer, plan *selectivePlan, only []string) {
+	printer.Header("Selective Deploy")
+	for _, s := range plan.Stacks {
+		switch {
+		case slices.Contains(only, s.Name):
+			printer.Info("  • %s (requested)", printer.Bold(s.Name))
+		case plan.Included[s.Name] != "":
+			printer.Info("  • %s (dependency: %s)", printer.Bold(s.Name), plan.Included[s.Name])
+		}
+	}
+	for _, name := range plan.Skipped {
+		printer.Info("  - %s (dependency: up to date, skipped)", name)
+	}
+	fmt.Println()
+}
+
+// restartDependents restarts the running stacks downstream of deployed so
+// they reconnect to the new containers. Stacks that are not running are left
+// stopped: restarting would start them. Under --dry-run a failed status read
+// only warns and every dependent is listed.
+func restartDependents(ctx context.Context, printer *output.Printer, client *compose.Client, resolver *stack.DependencyResolver, deployed []*stack.Stack, timeout time.Duration) error {
+	dependents, err := downstreamStacks(resolver, deployed)
+	if err != nil {
+		return err
+	}
+	if len(dependents) == 0 {
+		printer.Info("No downstream stacks to restart")
+		return nil
+	}
+
+	running, err := runningStacks(ctx, dependents)
+	if err != nil {
+		if !dryRun {
+			return fmt.Errorf("reading dependent status: %w", err)
+		}
+		// Show every restart a real run could issue.
+		printer.Warning("Could not read service status: %v", err)
+	}
+	for _, s := range dependents {
+		if err == nil && !running[s.Name] {
+			printer.Info("  - %s (not running, skipped)", s.Name)
+			continue
+		}
+		printer.Info("  • %s", printer.Bold(s.Name))
+		restartCtx, cancel := context.WithTimeout(ctx, timeout)
+		restartErr := client.Restart(restartCtx, []string{s.ComposeFile}, timeout)
+		cancel()
+		if restartErr != nil {
+			return fmt.Errorf("restarting %s: %w", s.Name, restartErr)
+		}
+	}
+	return nil
+}
+
+// downstreamStacks returns the stacks outside deployed that depend on any of
+// them, directly or transitively, dependencies first.
+func downstreamStacks(resolver *stack.DependencyResolver, deployed []*stack.Stack) ([]*stack.Stack, error) {
+	names := make([]string, 0, len(deployed))
+	isDeployed := make(map[string]bool, len(deployed))
+	for _, s := range deployed {
+		names = append(names, s.Name)
+		isDeployed[s.Name] = true
+	}
+
+	affected, err := resolver.ResolveWithDependents(names)
+	if err != nil {
+		return nil, err
+	}
+	// ResolveWithDependents returns stop order and also pulls in the
+	// dependents' other upstream stacks; keep only true dependents and
+	// restart dependencies first.
+	var dependents []*stack.Stack
+	for i := len(affected) - 1; i >= 0; i-- {
+		s := affected[i]
+		if isDeployed[s.Name] || s.ComposeFile == "" {
+			continue
+		}
+		upstream, err := resolver.Resolve([]string{s.Name})
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(upstream, func(u *stack.Stack) bool { return isDeployed[u.Name] }) {
+			dependents = append(dependents, s)
+		}
+	}
+	return dependents, nil
+}
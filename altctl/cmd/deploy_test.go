@@ -2,9 +2,15 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/alt-project/altctl/internal/config"
+	"github.com/alt-project/altctl/internal/deployreport"
+	"github.com/alt-project/altctl/internal/stack"
 )
 
 func setupDeployTest(t *testing.T) {
@@ -24,6 +30,14 @@ func setupDeployTest(t *testing.T) {
 	deployCmd.Flags().Set("no-cache", "false")
 	deployCmd.Flags().Set("pull", "false")
 	deployCmd.Flags().Set("no-deps", "false")
+	deployCmd.Flags().Set("prewarm", "false")
+	deployCmd.Flags().Set("report-dir", "")
+	deployCmd.Flags().Set("no-report", "false")
+	deployCmd.Flags().Set("with-deps", "false")
+	deployCmd.Flags().Set("restart-dependents", "false")
+	deployCmd.Flags().Set("skip-migrations", "false")
+	// A slice flag appends on Set once changed, so replace its value instead
+	deployCmd.Flags().Lookup("only").Value.(interface{ Replace([]string) error }).Replace(nil)
 }
 
 func TestDeploy_DefaultStacks(t *testing.T) {
@@ -99,3 +113,158 @@ func TestDeploy_UnknownStack_NoDeps(t *testing.T) {
 		t.Fatal("expected error for unknown stack with --no-deps, got nil")
 	}
 }
+
+func TestDeploy_Prewarm(t *testing.T) {
+	setupDeployTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"deploy", "ai", "--prewarm", "--no-pull", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("deploy --prewarm failed: %v", err)
+	}
+}
+
+func TestDeploy_DryRunWritesReport(t *testing.T) {
+	setupDeployTest(t)
+	reportDir := t.TempDir()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"deploy", "core", "--no-pull", "--no-smoke", "--dry-run", "--report-dir", reportDir})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("deploy --dry-run failed: %v", err)
+	}
+
+	// Rendering fails without real compose files, but the report is still
+	// written and records why.
+	reports, err := filepath.Glob(filepath.Join(reportDir, "*", deployreport.ReportFilename))
+	if err != nil || len(reports) != 1 {
+		t.Fatalf("expected one report in %s, got %v (err=%v)", reportDir, reports, err)
+	}
+	data, err := os.ReadFile(reports[0])
+	if err != nil {
+		t.Fatalf("reading report: %v", err)
+	}
+	if !strings.Contains(string(data), "Render errors") {
+		t.Errorf("expected render errors section in report:\n%s", data)
+	}
+}
+
+func TestDeploy_OnlyWithDeps(t *testing.T) {
+	setupDeployTest(t)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"deploy", "--only", "core", "--with-deps", "--restart-dependents", "--no-pull", "--no-smoke", "--no-report", "--dry-run"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("deploy --only core --with-deps failed: %v", err)
+	}
+}
+
+func TestDeploy_OnlyRejectsConflictingFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"stack arguments", []string{"deploy", "workers", "--only", "core", "--dry-run"}},
+		{"no-deps", []string{"deploy", "--only", "core", "--no-deps", "--dry-run"}},
+		{"with-deps without only", []string{"deploy", "core", "--with-deps", "--dry-run"}},
+		{"unknown stack", []string{"deploy", "--only", "nonexistent", "--dry-run"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupDeployTest(t)
+
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetArgs(tt.args)
+
+			if err := rootCmd.Execute(); err == nil {
+				t.Fatal("expected usage error, got nil")
+			}
+		})
+	}
+}
+
+func TestPlanSelectiveDeploy(t *testing.T) {
+	resolver := stack.NewDependencyResolver(stack.NewRegistry())
+	stale := map[string]string{"auth": "not running", "db": "changed since pinned (values)"}
+	var probed []string
+	probe := func(upstream []*stack.Stack) map[string]string {
+		for _, s := range upstream {
+			probed = append(probed, s.Name)
+		}
+		return stale
+	}
+
+	plan, err := planSelectiveDeploy(resolver, []string{"core"}, true, probe)
+	if err != nil {
+		t.Fatalf("planSelectiveDeploy failed: %v", err)
+	}
+
+	if slices.Contains(probed, "core") {
+		t.Errorf("requested stack should not be probed, probed %v", probed)
+	}
+	var names []string
+	for _, s := range plan.Stacks {
+		names = append(names, s.Name)
+	}
+	if want := []string{"db", "auth", "core"}; !slices.Equal(names, want) {
+		t.Errorf("stacks = %v, want %v", names, want)
+	}
+	if plan.Included["auth"] != "not running" || plan.Included["db"] == "" {
+		t.Errorf("unexpected inclusion reasons: %v", plan.Included)
+	}
+	for _, name := range []string{"base", "pgbouncer", "sovereign"} {
+		if !slices.Contains(plan.Skipped, name) {
+			t.Errorf("expected %s to be skipped, skipped %v", name, plan.Skipped)
+		}
+	}
+}
+
+func TestPlanSelectiveDeploy_WithoutDeps(t *testing.T) {
+	resolver := stack.NewDependencyResolver(stack.NewRegistry())
+	probe := func([]*stack.Stack) map[string]string {
+		t.Fatal("probe should not run without --with-deps")
+		return nil
+	}
+
+	plan, err := planSelectiveDeploy(resolver, []string{"workers", "core"}, false, probe)
+	if err != nil {
+		t.Fatalf("planSelectiveDeploy failed: %v", err)
+	}
+	if len(plan.Stacks) != 2 || plan.Stacks[0].Name != "core" || plan.Stacks[1].Name != "workers" {
+		t.Errorf("expected core then workers, got %v", plan.Stacks)
+	}
+}
+
+func TestDownstreamStacks(t *testing.T) {
+	registry := stack.NewRegistry()
+	resolver := stack.NewDependencyResolver(registry)
+	auth, _ := registry.Get("auth")
+
+	dependents, err := downstreamStacks(resolver, []*stack.Stack{auth})
+	if err != nil {
+		t.Fatalf("downstreamStacks failed: %v", err)
+	}
+
+	var names []string
+	for _, s := range dependents {
+		names = append(names, s.Name)
+	}
+	if !slices.Contains(names, "core") {
+		t.Errorf("expected core among dependents, got %v", names)
+	}
+	for _, upstream := range []string{"base", "db", "pgbouncer", "auth"} {
+		if slices.Contains(names, upstream) {
+			t.Errorf("%s is not downstream of auth, got %v", upstream, names)
+		}
+	}
+	if slices.Index(names, "core") > slices.Index(names, "workers") {
+		t.Errorf("expected core before workers, got %v", names)
+	}
+}
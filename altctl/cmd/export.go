@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/gitops"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export --gitops [stacks...]",
+	Short: "Export rendered stacks as a GitOps repository layout",
+	Long: `Render stacks with resolved values and write them out for a GitOps agent.
+
+Each stack is rendered with 'docker compose config' (the same rendering
+deploy uses) and written as one app per stack:
+
+  <output>/compose.yaml                include: every app, dependencies first
+  <output>/apps/<stack>/compose.yaml   the stack's services, fully rendered
+  <output>/apps/<stack>/app.yaml       depends_on, services, images, variables
+  <output>/apps/<stack>/.env.example   variables to supply at deploy time
+
+Credentials found in environment and build args are replaced by ${VAR}
+references and listed in .env.example; the project root is replaced by
+${ALT_ROOT}. Services pulled in through include (such as the pki-agent
+sidecars) are exported once, by the first stack that needs them, and
+depends_on across apps is marked required: false so each app can also be
+deployed on its own.
+
+Re-running the export overwrites the apps and removes app directories of
+stacks no longer exported, so the output can be committed as-is.
+
+If no stacks are specified, exports the default stacks. Dependencies are
+automatically resolved.
+
+Examples:
+  altctl export --gitops -o ../alt-gitops
+  altctl export --gitops core rag --pin-digests
+  altctl export --gitops --dry-run`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeStackNames,
+	RunE:              runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().Bool("gitops", false, "write a GitOps layout (one app per stack)")
+	exportCmd.Flags().StringP("output", "o", "gitops", "directory to write the export to")
+	exportCmd.Flags().Bool("pin-digests", false, "pin every image to its registry digest")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+	gitopsMode, _ := cmd.Flags().GetBool("gitops")
+	outDir, _ := cmd.Flags().GetString("output")
+	pinDigests, _ := cmd.Flags().GetBool("pin-digests")
+
+	if !gitopsMode {
+		return &output.CLIError{
+			Summary:    "no export format selected",
+			Suggestion: "Pass --gitops (the only supported format)",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+
+	registry := stack.NewRegistry()
+	names := args
+	if len(names) == 0 {
+		for _, s := range registry.DefaultStacks() {
+			names = append(names, s.Name)
+		}
+	}
+	stacks, err := stack.NewDependencyResolver(registry).Resolve(names)
+	if err != nil {
+		return &output.CLIError{
+			Summary:    err.Error(),
+			Suggestion: "Run 'altctl list' to see available stacks",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+
+	projectRoot, err := filepath.Abs(getProjectRoot())
+	if err != nil {
+		return fmt.Errorf("resolving project root: %w", err)
+	}
+
+	printer.Header("Rendering Stacks")
+	sources, err := renderExportSources(cmd.Context(), stacks, pinDigests)
+	if err != nil {
+		return err
+	}
+	apps, err := gitops.Build(sources, projectRoot)
+	if err != nil {
+		return &output.CLIError{
+			Summary:    "failed to build GitOps export",
+			Detail:     err.Error(),
+			Suggestion: "Export overlapping stacks in separate runs",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+
+	for _, app := range apps {
+		vars := "none"
+		if len(app.Variables) > 0 {
+			vars = strings.Join(app.Variables, ", ")
+		}
+		printer.Info("  • %s: %d services, %d images; variables: %s",
+			printer.Bold(app.Stack), len(app.Services), len(app.Images), vars)
+	}
+
+	if dryRun {
+		printer.Info("[dry-run] would write %d apps to %s", len(apps), outDir)
+		return nil
+	}
+	if err := gitops.Write(outDir, apps, projectRoot); err != nil {
+		return &output.CLIError{
+			Summary:  "failed to write GitOps export",
+			Detail:   err.Error(),
+			ExitCode: output.ExitGeneral,
+		}
+	}
+	printer.Success("Exported %d apps to %s", len(apps), outDir)
+	printer.PrintHints("export")
+	return nil
+}
+
+// renderExportSources renders each stack on its own, with its profile
+// active. Rendering is read-only and runs even under --dry-run. Unlike the
+// dry-run deploy report, a stack that fails to render aborts the export:
+// a partial export would silently drop services from the GitOps repo.
+func renderExportSources(ctx context.Context, stacks []*stack.Stack, pinDigests bool) ([]gitops.Source, error) {
+	client := compose.NewClient(getProjectRoot(), getComposeDir(), logger, false)
+
+	var sources []gitops.Source
+	for _, s := range stacks {
+		if s.ComposeFile == "" {
+			continue
+		}
+		opts := compose.RenderOptions{ResolveImageDigests: pinDigests}
+		if s.HasProfile() {
+			opts.Profiles = []string{s.Profile}
+		}
+
+		renderCtx, cancel := context.WithTimeout(ctx, renderTimeout)
+		rendered, err := client.RenderConfigWithOptions(renderCtx, []string{s.ComposeFile}, opts)
+		cancel()
+		if err != nil {
+			return nil, &output.CLIError{
+				Summary:    fmt.Sprintf("failed to render stack %s", s.Name),
+				Detail:     err.Error(),
+				Suggestion: "Check the stack with 'docker compose -f compose/" + s.ComposeFile + " config'",
+				ExitCode:   output.ExitComposeError,
+			}
+		}
+		declared, err := gitops.DeclaredServices(filepath.Join(getComposeDir(), s.ComposeFile))
+		if err != nil {
+			return nil, fmt.Errorf("reading services of %s: %w", s.Name, err)
+		}
+
+		sources = append(sources, gitops.Source{
+			Stack:     s.Name,
+			DependsOn: s.DependsOn,
+			Declared:  declared,
+			Rendered:  rendered,
+		})
+	}
+	return sources, nil
+}
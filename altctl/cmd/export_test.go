@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/alt-project/altctl/internal/output"
+)
+
+func TestExport_RequiresFormat(t *testing.T) {
+	setupNamespaceTest(t)
+
+	err := runExport(exportCmd, nil)
+	cliErr, ok := err.(*output.CLIError)
+	if !ok || cliErr.ExitCode != output.ExitUsageError {
+		t.Fatalf("want usage error, got %v", err)
+	}
+}
+
+func TestExport_RejectsUnknownStack(t *testing.T) {
+	setupNamespaceTest(t)
+	exportCmd.Flags().Set("gitops", "true")
+	t.Cleanup(func() { exportCmd.Flags().Set("gitops", "false") })
+
+	err := runExport(exportCmd, []string{"no-such-stack"})
+	cliErr, ok := err.(*output.CLIError)
+	if !ok || cliErr.ExitCode != output.ExitUsageError {
+		t.Fatalf("want usage error, got %v", err)
+	}
+}
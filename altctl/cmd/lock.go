@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/deploylock"
+	"github.com/alt-project/altctl/internal/deployreport"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Manage the deploy lockfile",
+	Long: `Manage the deploy lockfile (.altctl/deploy.lock.json).
+
+The lockfile pins, per stack, the compose file digest and the last git
+commit that touched it, the digest of the rendered services (which covers
+.env values and image tags interpolated into them), and the images used.
+Every successful 'altctl deploy' records the pins of the stacks it deployed.
+
+'altctl deploy --frozen-lockfile' refuses to deploy when any target stack
+no longer matches its pin. Use 'altctl lock update' to accept changes on
+purpose.
+
+Examples:
+  altctl lock update               # Re-pin every stack already in the lockfile
+  altctl lock update core ai       # Pin core and ai (with dependencies)
+  altctl lock update --dry-run     # Show what would change without writing`,
+}
+
+var lockUpdateCmd = &cobra.Command{
+	Use:               "update [stacks...]",
+	Short:             "Refresh lockfile pins from the current compose files and .env",
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeStackNames,
+	RunE:              runLockUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+	lockCmd.AddCommand(lockUpdateCmd)
+
+	lockUpdateCmd.Flags().String("lockfile", "", "deploy lockfile path (default: <project>/.altctl/deploy.lock.json)")
+}
+
+func runLockUpdate(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+	store := deployLockStore(cmd)
+
+	lock, err := store.Load()
+	if err != nil {
+		return &output.CLIError{
+			Summary:  "failed to read deploy lockfile",
+			Detail:   err.Error(),
+			ExitCode: output.ExitConfigError,
+		}
+	}
+
+	stackNames := args
+	if len(stackNames) == 0 {
+		for name := range lock.Stacks {
+			stackNames = append(stackNames, name)
+		}
+	}
+	if len(stackNames) == 0 {
+		stackNames = cfg.Defaults.Stacks
+	}
+
+	resolver := stack.NewDependencyResolver(stack.NewRegistry())
+	stacks, err := resolver.Resolve(stackNames)
+	if err != nil {
+		return &output.CLIError{
+			Summary:    "failed resolving dependencies",
+			Detail:     err.Error(),
+			Suggestion: "Check stack definitions with 'altctl list --deps'",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+
+	printer.Header("Updating Deploy Lockfile")
+	manifests, renderErrors := renderStackManifests(cmd.Context(), stacks)
+	if len(renderErrors) > 0 {
+		return renderErrorsCLIError(renderErrors)
+	}
+	pins, err := buildStackPins(cmd.Context(), stacks, manifests)
+	if err != nil {
+		return &output.CLIError{
+			Summary:  "failed to pin stacks",
+			Detail:   err.Error(),
+			ExitCode: output.ExitGeneral,
+		}
+	}
+
+	drift := lock.Check(pins)
+	if len(drift) == 0 {
+		printer.Success("Lockfile already up to date")
+		return nil
+	}
+	printDrift(printer, drift)
+
+	if dryRun {
+		printer.Info("[dry-run] would write %s", store.Path)
+		return nil
+	}
+	lock.Pin(pins)
+	if err := store.Save(lock); err != nil {
+		return &output.CLIError{
+			Summary:  "failed to write deploy lockfile",
+			Detail:   err.Error(),
+			ExitCode: output.ExitGeneral,
+		}
+	}
+	printer.Success("Pinned %d stacks in %s", len(pins), store.Path)
+	return nil
+}
+
+// deployLockStore returns the lockfile store, honoring --lockfile.
+func deployLockStore(cmd *cobra.Command) deploylock.Store {
+	path, _ := cmd.Flags().GetString("lockfile")
+	if path == "" {
+		path = filepath.Join(getProjectRoot(), ".altctl", "deploy.lock.json")
+	}
+	return deploylock.Store{Path: path}
+}
+
+// buildStackPins pins each rendered manifest to its stack's compose file.
+func buildStackPins(ctx context.Context, stacks []*stack.Stack, manifests []*deployreport.StackManifest) (map[string]deploylock.Pin, error) {
+	byName := make(map[string]*stack.Stack, len(stacks))
+	for _, s := range stacks {
+		byName[s.Name] = s
+	}
+
+	now := time.Now().UTC()
+	pins := make(map[string]deploylock.Pin, len(manifests))
+	for _, m := range manifests {
+		s, ok := byName[m.Stack]
+		if !ok {
+			continue
+		}
+		path := filepath.Join(getComposeDir(), s.ComposeFile)
+		composeDigest, err := deploylock.FileDigest(path)
+		if err != nil {
+			return nil, fmt.Errorf("hashing %s: %w", s.ComposeFile, err)
+		}
+		pins[m.Stack] = deploylock.Pin{
+			ComposeFile:   s.ComposeFile,
+			ComposeDigest: composeDigest,
+			Commit:        lastCommitFor(ctx, path),
+			ValuesDigest:  m.Digest(),
+			Images:        m.Images(),
+			PinnedAt:      now,
+		}
+	}
+	return pins, nil
+}
+
+// lastCommitFor returns the last commit that touched path, or "" when it is
+// untracked or git is unavailable.
+func lastCommitFor(ctx context.Context, path string) string {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	gitCmd := exec.CommandContext(ctx, "git", "log", "-1", "--format=%H", "--", path)
+	gitCmd.Dir = getProjectRoot()
+	out, err := gitCmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// checkFrozenLockfile fails the deploy when any target stack differs from
+// its pin. It renders for real, even under --dry-run, so a dry run reports
+// the same verdict a real deploy would.
+func checkFrozenLockfile(cmd *cobra.Command, printer *output.Printer, stacks []*stack.Stack) error {
+	printer.Header("Checking Deploy Lockfile")
+	store := deployLockStore(cmd)
+
+	lock, err := store.Load()
+	if err != nil {
+		return &output.CLIError{
+			Summary:  "failed to read deploy lockfile",
+			Detail:   err.Error(),
+			ExitCode: output.ExitConfigError,
+		}
+	}
+	manifests, renderErrors := renderStackManifests(cmd.Context(), stacks)
+	if len(renderErrors) > 0 {
+		return renderErrorsCLIError(renderErrors)
+	}
+	pins, err := buildStackPins(cmd.Context(), stacks, manifests)
+	if err != nil {
+		return &output.CLIError{
+			Summary:  "failed to pin stacks",
+			Detail:   err.Error(),
+			ExitCode: output.ExitGeneral,
+		}
+	}
+
+	drift := lock.Check(pins)
+	if len(drift) == 0 {
+		printer.Success("All stacks match %s", store.Path)
+		fmt.Println()
+		return nil
+	}
+	printDrift(printer, drift)
+
+	var lines []string
+	for _, d := range drift {
+		lines = append(lines, fmt.Sprintf("%s %s: %s -> %s", d.Stack, d.Field, d.Locked, d.Current))
+	}
+	return &output.CLIError{
+		Summary:    fmt.Sprintf("%d stack(s) changed since they were pinned", countDriftedStacks(drift)),
+		Detail:     strings.Join(lines, "\n"),
+		Suggestion: "Review the changes, then run 'altctl lock update' to pin them",
+		ExitCode:   output.ExitConfigError,
+	}
+}
+
+// recordDeployLock pins the stacks a successful deploy just shipped. A
+// failure only warns: the deploy itself already succeeded.
+func recordDeployLock(cmd *cobra.Command, printer *output.Printer, stacks []*stack.Stack, manifests []*deployreport.StackManifest) {
+	store := deployLockStore(cmd)
+	lock, err := store.Load()
+	if err != nil {
+		printer.Warning("Could not update deploy lockfile: %v", err)
+		return
+	}
+	pins, err := buildStackPins(cmd.Context(), stacks, manifests)
+	if err != nil {
+		printer.Warning("Could not update deploy lockfile: %v", err)
+		return
+	}
+	lock.Pin(pins)
+	if err := store.Save(lock); err != nil {
+		printer.Warning("Could not update deploy lockfile: %v", err)
+	}
+}
+
+func printDrift(printer *output.Printer, drift []deploylock.Drift) {
+	for _, d := range drift {
+		printer.Info("  • %s %s: %s → %s", printer.Bold(d.Stack), d.Field, d.Locked, d.Current)
+	}
+}
+
+func countDriftedStacks(drift []deploylock.Drift) int {
+	seen := make(map[string]bool)
+	for _, d := range drift {
+		seen[d.Stack] = true
+	}
+	return len(seen)
+}
+
+func renderErrorsCLIError(renderErrors map[string]string) *output.CLIError {
+	var lines []string
+	for name, msg := range renderErrors {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, msg))
+	}
+	slices.Sort(lines)
+	return &output.CLIError{
+		Summary:    "could not render stacks to compare with the lockfile",
+		Detail:     strings.Join(lines, "\n"),
+		Suggestion: "Run 'docker compose config' on the failing stack to see the error",
+		ExitCode:   output.ExitComposeError,
+	}
+}
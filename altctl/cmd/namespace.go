@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/namespace"
+	"github.com/alt-project/altctl/internal/output"
+)
+
+// namespaceTimeout bounds the docker calls made by namespace commands.
+const namespaceTimeout = 5 * time.Minute
+
+var namespaceCmd = &cobra.Command{
+	Use:     "namespace",
+	Aliases: []string{"ns"},
+	Short:   "Create, label, and tear down environment namespaces",
+	Long: `Manage environment namespaces as a set.
+
+Compose has no namespaces of its own: a namespace is a Compose project name
+plus the labels and protection annotation altctl records for it in
+.altctl/namespaces.json. Bring stacks up inside a namespace with
+COMPOSE_PROJECT_NAME=<namespace> altctl up ...; every container, volume and
+network Compose creates is labelled with the project name, and destroy
+removes exactly that set.
+
+Production namespaces are always protected. Destroying a protected
+namespace requires --yes-i-know together with --environment naming its
+environment; use --dry-run first to preview what would be removed.
+
+Examples:
+  altctl namespace create alt-staging --environment staging --label owner=ops
+  altctl namespace create alt --environment production
+  altctl namespace label alt-staging tier=2 owner-
+  altctl namespace list
+  altctl namespace destroy alt-staging --dry-run
+  altctl namespace destroy alt --yes-i-know --environment production`,
+}
+
+var namespaceCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Register a namespace for an environment",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNamespaceCreate,
+}
+
+var namespaceLabelCmd = &cobra.Command{
+	Use:   "label <name> [key=value | key-]...",
+	Short: "Set or remove namespace labels and protection",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runNamespaceLabel,
+}
+
+var namespaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered namespaces",
+	Args:  cobra.NoArgs,
+	RunE:  runNamespaceList,
+}
+
+var namespaceDestroyCmd = &cobra.Command{
+	Use:   "destroy <name>",
+	Short: "Remove every container, volume and network in a namespace",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNamespaceDestroy,
+}
+
+func init() {
+	rootCmd.AddCommand(namespaceCmd)
+	namespaceCmd.AddCommand(namespaceCreateCmd, namespaceLabelCmd, namespaceListCmd, namespaceDestroyCmd)
+
+	namespaceCreateCmd.Flags().String("environment", "", "environment the namespace serves (local, staging, production)")
+	namespaceCreateCmd.Flags().StringSlice("label", nil, "label to attach, as key=value (repeatable)")
+	namespaceCreateCmd.Flags().Bool("protect", false, "require confirmation to destroy (always on for production)")
+	_ = namespaceCreateCmd.MarkFlagRequired("environment")
+
+	namespaceLabelCmd.Flags().Bool("protect", false, "add the protection annotation")
+	namespaceLabelCmd.Flags().Bool("unprotect", false, "remove the protection annotation (not allowed for production)")
+	namespaceLabelCmd.MarkFlagsMutuallyExclusive("protect", "unprotect")
+
+	namespaceDestroyCmd.Flags().Bool("yes-i-know", false, "confirm destroying a protected namespace")
+	namespaceDestroyCmd.Flags().String("environment", "", "environment of the namespace being destroyed (required with --yes-i-know)")
+}
+
+// namespaceStore returns the registry kept under the project root.
+func namespaceStore() namespace.Store {
+	return namespace.Store{Path: filepath.Join(getProjectRoot(), ".altctl", "namespaces.json")}
+}
+
+func runNamespaceCreate(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+	name := args[0]
+	envName, _ := cmd.Flags().GetString("environment")
+	labelPairs, _ := cmd.Flags().GetStringSlice("label")
+	protect, _ := cmd.Flags().GetBool("protect")
+
+	if _, ok := bootstrapEnvironments[envName]; !ok {
+		return &output.CLIError{
+			Summary:    fmt.Sprintf("unknown environment: %s", envName),
+			Suggestion: fmt.Sprintf("Use one of: %s", strings.Join(bootstrapEnvironmentNames(), ", ")),
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+	labels, err := namespace.ParseLabels(labelPairs)
+	if err != nil {
+		return namespaceUsageError(err)
+	}
+	ns, err := namespace.New(name, envName, labels, protect, time.Now())
+	if err != nil {
+		return namespaceUsageError(err)
+	}
+
+	if dryRun {
+		printer.Info("[dry-run] would create namespace %s (%s)", printer.Bold(ns.Name), namespace.FormatLabels(ns.Labels))
+		return nil
+	}
+	if err := namespaceStore().Create(ns); err != nil {
+		return namespaceStoreError(err)
+	}
+
+	printer.Success("Namespace %s created", ns.Name)
+	if ns.Protected() {
+		printer.Info("  protected: destroy requires --yes-i-know --environment %s", ns.Environment)
+	}
+	printer.Info("  start stacks with: COMPOSE_PROJECT_NAME=%s altctl up", ns.Name)
+	printer.PrintHints("namespace create")
+	return nil
+}
+
+func runNamespaceLabel(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+	protect, _ := cmd.Flags().GetBool("protect")
+	unprotect, _ := cmd.Flags().GetBool("unprotect")
+
+	store := namespaceStore()
+	ns, err := store.Get(args[0])
+	if err != nil {
+		return namespaceStoreError(err)
+	}
+	if len(args) == 1 && !protect && !unprotect {
+		return namespaceUsageError(errors.New("nothing to change: pass key=value, key-, --protect or --unprotect"))
+	}
+
+	if err := ns.ApplyLabels(args[1:]); err != nil {
+		return namespaceUsageError(err)
+	}
+	if protect || unprotect {
+		if err := ns.SetProtected(protect); err != nil {
+			return namespaceUsageError(err)
+		}
+	}
+
+	if dryRun {
+		printer.Info("[dry-run] would set %s labels to %s (protected=%t)", ns.Name, namespace.FormatLabels(ns.Labels), ns.Protected())
+		return nil
+	}
+	if err := store.Update(ns); err != nil {
+		return namespaceStoreError(err)
+	}
+	printer.Success("Namespace %s labelled: %s (protected=%t)", ns.Name, namespace.FormatLabels(ns.Labels), ns.Protected())
+	return nil
+}
+
+func runNamespaceList(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+	all, err := namespaceStore().List()
+	if err != nil {
+		return namespaceStoreError(err)
+	}
+
+	printer.Header("Namespaces")
+	if len(all) == 0 {
+		printer.Info("No namespaces registered")
+		return nil
+	}
+
+	table := output.NewTable([]string{"NAME", "ENVIRONMENT", "PROTECTED", "LABELS", "CREATED AT"})
+	for _, ns := range all {
+		table.AddRow([]string{
+			ns.Name,
+			ns.Environment,
+			fmt.Sprintf("%t", ns.Protected()),
+			namespace.FormatLabels(ns.Labels),
+			ns.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func runNamespaceDestroy(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+	yesIKnow, _ := cmd.Flags().GetBool("yes-i-know")
+	confirmEnv, _ := cmd.Flags().GetString("environment")
+
+	store := namespaceStore()
+	ns, err := store.Get(args[0])
+	if err != nil {
+		return namespaceStoreError(err)
+	}
+
+	// The protection check runs before anything touches Docker; --dry-run
+	// previews are always allowed.
+	if !dryRun {
+		if err := namespace.CheckDestroy(ns, yesIKnow, confirmEnv); err != nil {
+			return &output.CLIError{
+				Summary:    fmt.Sprintf("refusing to destroy protected namespace %s", ns.Name),
+				Detail:     err.Error(),
+				Suggestion: fmt.Sprintf("Preview with --dry-run, then re-run with --yes-i-know --environment %s", ns.Environment),
+				ExitCode:   output.ExitUsageError,
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), namespaceTimeout)
+	defer cancel()
+
+	// Listing is read-only and always runs for real so --dry-run can show
+	// exactly what would be removed; removal itself is skipped under
+	// --dry-run below.
+	docker := compose.NewExecutor(getProjectRoot(), logger, false)
+	res, err := namespace.ListResources(ctx, docker, ns.Name)
+	if err != nil {
+		return &output.CLIError{
+			Summary:    fmt.Sprintf("failed to list resources of namespace %s", ns.Name),
+			Detail:     err.Error(),
+			Suggestion: "Check that the Docker daemon is running",
+			ExitCode:   output.ExitComposeError,
+		}
+	}
+
+	printer.Header(fmt.Sprintf("Namespace %s (%s)", ns.Name, ns.Environment))
+	printNamespaceResources(printer, res)
+
+	if dryRun {
+		printer.Info("[dry-run] %d resource(s) would be removed and the namespace unregistered", res.Count())
+		if ns.Protected() {
+			printer.Warning("namespace is protected: destroy requires --yes-i-know --environment %s", ns.Environment)
+		}
+		return nil
+	}
+
+	if err := namespace.RemoveResources(ctx, docker, res); err != nil {
+		return &output.CLIError{
+			Summary:    fmt.Sprintf("failed to destroy namespace %s", ns.Name),
+			Detail:     err.Error(),
+			Suggestion: "Re-run destroy; resources already removed are skipped",
+			ExitCode:   output.ExitComposeError,
+		}
+	}
+	if err := store.Delete(ns.Name); err != nil {
+		return namespaceStoreError(err)
+	}
+
+	printer.Success("Namespace %s destroyed (%d resource(s) removed)", ns.Name, res.Count())
+	return nil
+}
+
+func printNamespaceResources(printer *output.Printer, res namespace.Resources) {
+	if res.Empty() {
+		printer.Info("No containers, volumes or networks found")
+		return
+	}
+	for _, group := range []struct {
+		kind  string
+		names []string
+	}{
+		{"containers", res.Containers},
+		{"volumes", res.Volumes},
+		{"networks", res.Networks},
+	} {
+		if len(group.names) == 0 {
+			continue
+		}
+		printer.Info("%s:", printer.Bold(group.kind))
+		for _, name := range group.names {
+			printer.Info("  • %s", name)
+		}
+	}
+}
+
+func namespaceUsageError(err error) error {
+	return &output.CLIError{
+		Summary:  err.Error(),
+		ExitCode: output.ExitUsageError,
+	}
+}
+
+func namespaceStoreError(err error) error {
+	switch {
+	case errors.Is(err, namespace.ErrNotFound):
+		return &output.CLIError{
+			Summary:    err.Error(),
+			Suggestion: "Run 'altctl namespace list' to see registered namespaces",
+			ExitCode:   output.ExitUsageError,
+		}
+	case errors.Is(err, namespace.ErrExists):
+		return &output.CLIError{
+			Summary:    err.Error(),
+			Suggestion: "Choose another name or label the existing namespace",
+			ExitCode:   output.ExitUsageError,
+		}
+	default:
+		return &output.CLIError{
+			Summary:  "failed to update namespace registry",
+			Detail:   err.Error(),
+			ExitCode: output.ExitConfigError,
+		}
+	}
+}
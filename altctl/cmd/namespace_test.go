@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/alt-project/altctl/internal/config"
+	"github.com/alt-project/altctl/internal/namespace"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func setupNamespaceTest(t *testing.T) {
+	t.Helper()
+	cfg = &config.Config{
+		Output:   config.OutputConfig{Colors: false},
+		Logging:  config.LoggingConfig{Level: "info", Format: "text"},
+		Defaults: config.DefaultsConfig{Stacks: []string{"db", "auth", "core", "workers"}},
+		Project:  config.ProjectConfig{Root: t.TempDir()},
+		Compose:  config.ComposeConfig{Dir: "compose"},
+	}
+	dryRun = false
+	quiet = true
+	resetNamespaceFlags()
+}
+
+// resetNamespaceFlags restores the namespace subcommands' flags to their
+// defaults and clears Changed, so mutually exclusive flags set by an
+// earlier test do not look set to the next one.
+func resetNamespaceFlags() {
+	for _, c := range []*cobra.Command{namespaceCreateCmd, namespaceLabelCmd, namespaceDestroyCmd} {
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				_ = sv.Replace(nil)
+			} else {
+				_ = f.Value.Set(f.DefValue)
+			}
+			f.Changed = false
+		})
+	}
+}
+
+// executeNamespace runs rootCmd against the test's project directory and
+// puts --project-dir back afterwards, since rootCmd is shared by every
+// test in the package.
+func executeNamespace(t *testing.T, args ...string) error {
+	t.Helper()
+	flag := rootCmd.PersistentFlags().Lookup("project-dir")
+	value, changed := flag.Value.String(), flag.Changed
+	t.Cleanup(func() {
+		_ = flag.Value.Set(value)
+		flag.Changed = changed
+	})
+
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"--project-dir", cfg.Project.Root}, args...))
+	return rootCmd.Execute()
+}
+
+func TestNamespace_CreateAndLabel(t *testing.T) {
+	setupNamespaceTest(t)
+
+	if err := executeNamespace(t, "namespace", "create", "alt-staging", "--environment", "staging", "--label", "owner=ops"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if err := executeNamespace(t, "namespace", "label", "alt-staging", "tier=2", "owner-"); err != nil {
+		t.Fatalf("label failed: %v", err)
+	}
+
+	ns, err := namespaceStore().Get("alt-staging")
+	if err != nil {
+		t.Fatalf("namespace not registered: %v", err)
+	}
+	if ns.Labels["tier"] != "2" || ns.Labels["owner"] != "" || ns.Protected() {
+		t.Fatalf("unexpected namespace %+v", ns)
+	}
+}
+
+func TestNamespace_CreateUnknownEnvironment(t *testing.T) {
+	setupNamespaceTest(t)
+
+	err := executeNamespace(t, "namespace", "create", "alt-qa", "--environment", "qa")
+	var cliErr *output.CLIError
+	if !errors.As(err, &cliErr) || cliErr.ExitCode != output.ExitUsageError {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestNamespace_DestroyProductionRequiresConfirmation(t *testing.T) {
+	setupNamespaceTest(t)
+
+	if err := executeNamespace(t, "namespace", "create", "alt", "--environment", "production"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"namespace", "destroy", "alt"},
+		{"namespace", "destroy", "alt", "--yes-i-know"},
+		{"namespace", "destroy", "alt", "--yes-i-know", "--environment", "staging"},
+	} {
+		resetNamespaceFlags()
+		err := executeNamespace(t, args...)
+		var cliErr *output.CLIError
+		if !errors.As(err, &cliErr) || cliErr.ExitCode != output.ExitUsageError {
+			t.Fatalf("%v: expected refusal, got %v", args, err)
+		}
+	}
+
+	if _, err := namespaceStore().Get("alt"); errors.Is(err, namespace.ErrNotFound) {
+		t.Fatal("production namespace was unregistered despite the refusal")
+	}
+}
+
+func TestNamespace_UnprotectProductionRefused(t *testing.T) {
+	setupNamespaceTest(t)
+
+	if err := executeNamespace(t, "namespace", "create", "alt", "--environment", "production"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if err := executeNamespace(t, "namespace", "label", "alt", "--unprotect"); err == nil {
+		t.Fatal("unprotecting a production namespace succeeded")
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/ssl"
+)
+
+// sslTimeout bounds a status sweep or a rotation across every sidecar;
+// each rotation waits on step-ca.
+const sslTimeout = 5 * time.Minute
+
+var sslCmd = &cobra.Command{
+	Use:   "ssl",
+	Short: "Inspect and rotate east-west mTLS certificates",
+	Long: `Inspect and rotate the mTLS leaf certificates of running services.
+
+Certificates are issued by step-ca and renewed by one pki-agent sidecar per
+service (compose/pki.yaml) once RENEW_AT_FRACTION of their lifetime has
+passed. These commands run the sidecar's own pki-agent binary inside its
+container: status reads the cert on the service's volume, rotate reissues
+it immediately through the same provisioner and policy.
+
+Examples:
+  altctl ssl status
+  altctl ssl status alt-backend --json
+  altctl ssl rotate auth-hub
+  altctl ssl rotate --all --dry-run`,
+}
+
+var sslStatusCmd = &cobra.Command{
+	Use:   "status [service...]",
+	Short: "Show certificate validity and next renewal per service",
+	RunE:  runSSLStatus,
+}
+
+var sslRotateCmd = &cobra.Command{
+	Use:   "rotate <service>... | --all",
+	Short: "Reissue certificates now instead of at the next renewal",
+	RunE:  runSSLRotate,
+}
+
+func init() {
+	rootCmd.AddCommand(sslCmd)
+	sslCmd.AddCommand(sslStatusCmd, sslRotateCmd)
+
+	sslStatusCmd.Flags().Bool("json", false, "output as JSON")
+	sslRotateCmd.Flags().Bool("all", false, "rotate every running service's certificate")
+	sslRotateCmd.Flags().Bool("json", false, "output as JSON")
+}
+
+// composeProjectName is the project the compose executor runs under.
+func composeProjectName() string {
+	if name := os.Getenv("COMPOSE_PROJECT_NAME"); name != "" {
+		return name
+	}
+	return "alt"
+}
+
+func runSSLStatus(cmd *cobra.Command, args []string) error {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), sslTimeout)
+	defer cancel()
+
+	// Read-only, so it runs for real even under --dry-run.
+	docker := compose.NewExecutor(getProjectRoot(), logger, false)
+	sidecars, err := selectSidecars(ctx, docker, args)
+	if err != nil {
+		return err
+	}
+
+	reports := ssl.Status(ctx, docker, sidecars)
+	if err := printSSLReports(reports, jsonOutput, "Certificates"); err != nil {
+		return err
+	}
+	if ssl.Unhealthy(reports) {
+		return &output.CLIError{
+			Summary:    "one or more certificates are missing, expired or unreadable",
+			Suggestion: "Check 'altctl logs pki-agent-<service>', then 'altctl ssl rotate <service>'",
+			ExitCode:   output.ExitGeneral,
+		}
+	}
+	return nil
+}
+
+func runSSLRotate(cmd *cobra.Command, args []string) error {
+	printer := newPrinter()
+	all, _ := cmd.Flags().GetBool("all")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	if all == (len(args) > 0) {
+		return &output.CLIError{
+			Summary:    "name the services to rotate, or pass --all",
+			Suggestion: "Run 'altctl ssl status' to see services with certificates",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), sslTimeout)
+	defer cancel()
+
+	docker := compose.NewExecutor(getProjectRoot(), logger, false)
+	sidecars, err := selectSidecars(ctx, docker, args)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		for _, s := range sidecars {
+			printer.Info("[dry-run] would reissue the %s certificate via %s", printer.Bold(s.Service), s.Container)
+		}
+		return nil
+	}
+
+	reports := ssl.Rotate(ctx, docker, sidecars)
+	if err := printSSLReports(reports, jsonOutput, "Rotated certificates"); err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Error != "" {
+			return &output.CLIError{
+				Summary:    fmt.Sprintf("rotation failed for %s", r.Service),
+				Detail:     r.Error,
+				Suggestion: "Check that step-ca is healthy: altctl logs step-ca",
+				ExitCode:   output.ExitGeneral,
+			}
+		}
+	}
+	if !jsonOutput {
+		printer.Success("%d certificate(s) reissued; services pick them up on their next reload", len(reports))
+		printer.PrintHints("ssl rotate")
+	}
+	return nil
+}
+
+// selectSidecars lists the running pki-agent sidecars and narrows them to
+// services (all of them when services is empty).
+func selectSidecars(ctx context.Context, docker ssl.Runner, services []string) ([]ssl.Sidecar, error) {
+	sidecars, err := ssl.ListSidecars(ctx, docker, composeProjectName())
+	if err != nil {
+		return nil, &output.CLIError{
+			Summary:    "failed to list pki-agent sidecars",
+			Detail:     err.Error(),
+			Suggestion: "Check that the Docker daemon is running",
+			ExitCode:   output.ExitComposeError,
+		}
+	}
+	if len(sidecars) == 0 {
+		return nil, &output.CLIError{
+			Summary:    "no pki-agent sidecars are running",
+			Suggestion: "Start the stack first: altctl up",
+			ExitCode:   output.ExitComposeError,
+		}
+	}
+	selected, err := ssl.Select(sidecars, services)
+	if err != nil {
+		return nil, &output.CLIError{
+			Summary:    err.Error(),
+			Suggestion: "Run 'altctl ssl status' to see services with certificates",
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+	return selected, nil
+}
+
+func printSSLReports(reports []ssl.Report, jsonOutput bool, title string) error {
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(reports)
+	}
+
+	printer := newPrinter()
+	printer.Header(title)
+	now := time.Now()
+	table := output.NewTable([]string{"SERVICE", "STATE", "EXPIRES", "RENEWS", "SERIAL"})
+	for _, r := range reports {
+		if r.Error != "" {
+			table.AddRow([]string{r.Service, printer.StatusBadge("exited") + " error", "-", "-", firstLine(r.Error)})
+			continue
+		}
+		c := r.Cert
+		expires, renews := "-", "-"
+		if !c.NotAfter.IsZero() {
+			expires = fmt.Sprintf("%s (%s)", c.NotAfter.Local().Format(time.RFC3339), formatUntil(c.NotAfter.Sub(now)))
+			renews = formatUntil(c.RenewAt.Sub(now))
+		}
+		table.AddRow([]string{r.Service, sslStateBadge(printer, c.State) + " " + c.State, expires, renews, orDash(c.Serial)})
+	}
+	table.Render()
+	return nil
+}
+
+func sslStateBadge(printer *output.Printer, state string) string {
+	switch state {
+	case "fresh":
+		return printer.StatusBadge("healthy")
+	case "near_expiry":
+		return printer.StatusBadge("starting")
+	default:
+		return printer.StatusBadge("exited")
+	}
+}
+
+// formatUntil renders d as "in 3h20m" or "5m ago", rounded to minutes.
+func formatUntil(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < 0 {
+		return strings.TrimSuffix((-d).String(), "0s") + " ago"
+	}
+	return "in " + strings.TrimSuffix(d.String(), "0s")
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alt-project/altctl/internal/output"
+)
+
+func TestSSLRotate_RequiresServicesOrAll(t *testing.T) {
+	setupNamespaceTest(t)
+	t.Cleanup(func() { sslRotateCmd.Flags().Set("all", "false") })
+
+	for _, args := range [][]string{
+		{"ssl", "rotate"},
+		{"ssl", "rotate", "alt-backend", "--all"},
+	} {
+		err := executeNamespace(t, args...)
+		cliErr, ok := err.(*output.CLIError)
+		if !ok || cliErr.ExitCode != output.ExitUsageError {
+			t.Fatalf("%v: want usage error, got %v", args, err)
+		}
+		sslRotateCmd.Flags().Set("all", "false")
+	}
+}
+
+func TestFormatUntil(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{3*time.Hour + 20*time.Minute + 10*time.Second, "in 3h20m"},
+		{-5 * time.Minute, "5m ago"},
+		{30 * time.Second, "in 1m"},
+	}
+	for _, tt := range tests {
+		if got := formatUntil(tt.d); got != tt.want {
+			t.Errorf("formatUntil(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alt-project/altctl/internal/compose"
+	"github.com/alt-project/altctl/internal/output"
+	"github.com/alt-project/altctl/internal/stack"
+	"github.com/alt-project/altctl/internal/usage"
+)
+
+// topTimeout bounds the container listing and the stats sample; rendering
+// has its own per-file renderTimeout.
+const topTimeout = 1 * time.Minute
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show CPU and memory usage against compose allocations",
+	Long: `Show CPU and memory usage per service against the compose allocations.
+
+Usage is a single 'docker stats' sample of every running container in the
+selected namespaces (Compose projects). It is compared with
+deploy.resources from the rendered compose files, scaled by the number of
+running replicas:
+
+  at-limit           usage is at 90% or more of the limit
+  under-provisioned  usage is above the reservation
+  over-provisioned   usage is below 20% of the reservation
+  no-limits          neither a limit nor a reservation is set
+
+A service's status is the worse of its CPU and memory statuses. CPU is in
+cores; 1.00 is one fully used core.
+
+Examples:
+  altctl top
+  altctl top --sort memory
+  altctl top --namespace staging --json`,
+	RunE: runTop,
+}
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+
+	topCmd.Flags().StringSlice("namespace", nil, "namespaces to include (default: the current project and every registered namespace)")
+	topCmd.Flags().String("sort", usage.SortStatus, "sort by "+strings.Join(usage.SortKeys, ", "))
+	topCmd.Flags().Bool("json", false, "output as JSON")
+}
+
+func runTop(cmd *cobra.Command, args []string) error {
+	namespaces, _ := cmd.Flags().GetStringSlice("namespace")
+	sortKey, _ := cmd.Flags().GetString("sort")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	if !slices.Contains(usage.SortKeys, sortKey) {
+		return &output.CLIError{
+			Summary:    fmt.Sprintf("unknown sort key %q", sortKey),
+			Suggestion: "Use one of: " + strings.Join(usage.SortKeys, ", "),
+			ExitCode:   output.ExitUsageError,
+		}
+	}
+	if len(namespaces) == 0 {
+		var err error
+		if namespaces, err = topNamespaces(); err != nil {
+			return err
+		}
+	}
+
+	printer := newPrinter()
+	if dryRun {
+		printer.Info("[dry-run] would sample docker stats for namespaces: %s", strings.Join(namespaces, ", "))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), topTimeout)
+	defer cancel()
+
+	docker := compose.NewExecutor(getProjectRoot(), logger, false)
+	containers, err := usage.ListContainers(ctx, docker, namespaces)
+	if err != nil {
+		return topSampleError(err)
+	}
+	samples, err := usage.Stats(ctx, docker, containers)
+	if err != nil {
+		return topSampleError(err)
+	}
+
+	allocs, stackOf := topAllocations(cmd.Context(), printer, jsonOutput)
+	workloads := usage.Build(samples, allocs, stackOf)
+	usage.Sort(workloads, sortKey)
+	return printTop(printer, workloads, jsonOutput)
+}
+
+func topSampleError(err error) error {
+	return &output.CLIError{
+		Summary:    "failed to sample container usage",
+		Detail:     err.Error(),
+		Suggestion: "Check that the Docker daemon is running",
+		ExitCode:   output.ExitComposeError,
+	}
+}
+
+// topNamespaces returns the current Compose project followed by every
+// other registered namespace.
+func topNamespaces() ([]string, error) {
+	registered, err := namespaceStore().List()
+	if err != nil {
+		return nil, namespaceStoreError(err)
+	}
+	names := []string{composeProjectName()}
+	for _, ns := range registered {
+		if !slices.Contains(names, ns.Name) {
+			names = append(names, ns.Name)
+		}
+	}
+	return names, nil
+}
+
+// topAllocations renders each stack's compose file once and returns the
+// per-service allocations plus the stack each service belongs to. A file
+// that fails to render only produces a warning; its services then show as
+// no-limits.
+func topAllocations(ctx context.Context, printer *output.Printer, jsonOutput bool) (map[string]usage.Allocation, map[string]string) {
+	client := compose.NewClient(getProjectRoot(), getComposeDir(), logger, false)
+	allocs := make(map[string]usage.Allocation)
+	stackOf := make(map[string]string)
+	rendered := make(map[string]bool)
+
+	for _, s := range stack.NewRegistry().All() {
+		for _, svc := range s.Services {
+			stackOf[svc] = s.Name
+		}
+		if s.ComposeFile == "" || rendered[s.ComposeFile] {
+			continue
+		}
+		rendered[s.ComposeFile] = true
+
+		renderCtx, cancel := context.WithTimeout(ctx, renderTimeout)
+		out, err := client.RenderConfig(renderCtx, []string{s.ComposeFile})
+		cancel()
+		var fileAllocs map[string]usage.Allocation
+		if err == nil {
+			fileAllocs, err = usage.ParseAllocations(out)
+		}
+		if err != nil {
+			if !jsonOutput {
+				printer.Warning("Could not read allocations from %s: %v", s.ComposeFile, err)
+			}
+			continue
+		}
+		for svc, a := range fileAllocs {
+			allocs[svc] = a
+		}
+	}
+	return allocs, stackOf
+}
+
+func printTop(printer *output.Printer, workloads []usage.Workload, jsonOutput bool) error {
+	if jsonOutput {
+		if workloads == nil {
+			workloads = []usage.Workload{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(workloads)
+	}
+
+	printer.Header("Resource Usage")
+	if len(workloads) == 0 {
+		printer.Info("No running containers in the selected namespaces")
+		return nil
+	}
+
+	table := output.NewTable([]string{"NAMESPACE", "STACK", "SERVICE", "N", "CPU (USED/RES/LIMIT)", "MEMORY (USED/RES/LIMIT)", "STATUS"})
+	flagged := 0
+	for _, w := range workloads {
+		if w.Status != usage.StatusOK {
+			flagged++
+		}
+		table.AddRow([]string{
+			w.Namespace,
+			orDash(w.Stack),
+			w.Service,
+			fmt.Sprintf("%d", w.Containers),
+			formatCPU(w.CPU),
+			formatMemory(w.Memory),
+			topStatusBadge(printer, w.Status) + " " + string(w.Status),
+		})
+	}
+	table.Render()
+
+	fmt.Println()
+	printer.Info("%d of %d services need attention (sample taken %s)", flagged, len(workloads), time.Now().Format(time.TimeOnly))
+	printer.PrintHints("top")
+	return nil
+}
+
+func formatCPU(r usage.Resource) string {
+	alloc := func(v float64) string {
+		if v == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%.2f / %s / %s", r.Usage, alloc(r.Reservation), alloc(r.Limit))
+}
+
+func formatMemory(r usage.Resource) string {
+	alloc := func(v float64) string {
+		if v == 0 {
+			return "-"
+		}
+		return usage.FormatBytes(int64(v))
+	}
+	return fmt.Sprintf("%s / %s / %s", usage.FormatBytes(int64(r.Usage)), alloc(r.Reservation), alloc(r.Limit))
+}
+
+func topStatusBadge(printer *output.Printer, status usage.Status) string {
+	switch status {
+	case usage.StatusOK:
+		return printer.StatusBadge("healthy")
+	case usage.StatusAtLimit, usage.StatusUnderProvisioned:
+		return printer.StatusBadge("exited")
+	case usage.StatusOverProvisioned:
+		return printer.StatusBadge("starting")
+	default:
+		return printer.StatusBadge("unknown")
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/alt-project/altctl/internal/namespace"
+	"github.com/alt-project/altctl/internal/output"
+)
+
+func TestTop_RejectsUnknownSortKey(t *testing.T) {
+	setupNamespaceTest(t)
+	t.Cleanup(func() { topCmd.Flags().Set("sort", "status") })
+
+	err := executeNamespace(t, "top", "--sort", "disk")
+	cliErr, ok := err.(*output.CLIError)
+	if !ok || cliErr.ExitCode != output.ExitUsageError {
+		t.Fatalf("want usage error, got %v", err)
+	}
+}
+
+func TestTopNamespaces_IncludesRegistered(t *testing.T) {
+	setupNamespaceTest(t)
+	t.Setenv("COMPOSE_PROJECT_NAME", "")
+
+	for _, name := range []string{"staging", "alt"} {
+		ns := &namespace.Namespace{Name: name, Environment: "staging"}
+		if err := namespaceStore().Create(ns); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+
+	got, err := topNamespaces()
+	if err != nil {
+		t.Fatalf("topNamespaces: %v", err)
+	}
+	if len(got) != 2 || got[0] != "alt" || got[1] != "staging" {
+		t.Fatalf("namespaces = %v", got)
+	}
+}
@@ -47,6 +47,14 @@ type BuildOptions struct {
 	Progress string
 }
 
+// PullOptions configures the pull command
+type PullOptions struct {
+	Files              []string
+	IgnoreBuildable    bool // skip services that have a build section
+	IgnorePullFailures bool // continue when a single image fails to pull
+	Quiet              bool
+}
+
 // LogsOptions configures the logs command
 type LogsOptions struct {
 	Follow     bool
@@ -55,6 +63,21 @@ type LogsOptions struct {
 	Since      string
 }
 
+// RunOptions configures the run command
+type RunOptions struct {
+	Files   []string
+	Service string
+	Command []string // empty runs the service's default command
+	Remove  bool     // remove the container when it exits
+}
+
+// ExecOptions configures the exec command
+type ExecOptions struct {
+	Files   []string
+	Service string
+	Command []string
+}
+
 // ServiceStatus represents the status of a running service
 type ServiceStatus struct {
 	Name   string `json:"Name"`
@@ -115,6 +138,18 @@ func (c *Client) Down(ctx context.Context, opts DownOptions) error {
 	return c.executor.Run(ctx, "docker", append([]string{"compose"}, args...))
 }
 
+// Restart restarts the services' existing containers without recreating them
+func (c *Client) Restart(ctx context.Context, files []string, timeout time.Duration) error {
+	args := c.buildFileArgs(files)
+	args = append(args, "restart")
+
+	if timeout > 0 {
+		args = append(args, "--timeout", fmt.Sprintf("%d", int(timeout.Seconds())))
+	}
+
+	return c.executor.Run(ctx, "docker", append([]string{"compose"}, args...))
+}
+
 // Build builds service images
 func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	args := c.buildFileArgs(opts.Files)
@@ -136,6 +171,24 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	return c.executor.Run(ctx, "docker", append([]string{"compose"}, args...))
 }
 
+// Pull pulls service images without touching running containers
+func (c *Client) Pull(ctx context.Context, opts PullOptions) error {
+	args := c.buildFileArgs(opts.Files)
+	args = append(args, "pull")
+
+	if opts.IgnoreBuildable {
+		args = append(args, "--ignore-buildable")
+	}
+	if opts.IgnorePullFailures {
+		args = append(args, "--ignore-pull-failures")
+	}
+	if opts.Quiet {
+		args = append(args, "--quiet")
+	}
+
+	return c.executor.Run(ctx, "docker", append([]string{"compose"}, args...))
+}
+
 // Logs streams logs from a service
 func (c *Client) Logs(ctx context.Context, service string, opts LogsOptions) error {
 	args := []string{"compose", "logs"}
@@ -198,6 +251,50 @@ func (c *Client) Config(ctx context.Context, files []string) ([]byte, error) {
 	return c.executor.RunWithOutput(ctx, "docker", append([]string{"compose"}, args...))
 }
 
+// RenderOptions configures RenderConfigWithOptions.
+type RenderOptions struct {
+	// Profiles activates services gated behind these compose profiles.
+	Profiles []string
+	// ResolveImageDigests pins every image to its registry digest.
+	ResolveImageDigests bool
+}
+
+// RenderConfig returns the fully interpolated compose configuration as JSON.
+// It is read-only, so callers may run it even when the client is in dry-run
+// mode by using a non-dry-run client.
+func (c *Client) RenderConfig(ctx context.Context, files []string) ([]byte, error) {
+	return c.RenderConfigWithOptions(ctx, files, RenderOptions{})
+}
+
+// RenderConfigWithOptions is RenderConfig with profiles and digest pinning.
+func (c *Client) RenderConfigWithOptions(ctx context.Context, files []string, opts RenderOptions) ([]byte, error) {
+	args := c.buildFileArgs(files)
+	for _, p := range opts.Profiles {
+		args = append(args, "--profile", p)
+	}
+	args = append(args, "config", "--format", "json")
+	if opts.ResolveImageDigests {
+		args = append(args, "--resolve-image-digests")
+	}
+
+	return c.executor.RunWithOutput(ctx, "docker", append([]string{"compose"}, args...))
+}
+
+// Run runs a one-off container of a service, starting its dependencies
+// first, and waits for it to exit. No TTY is allocated, so the output can be
+// captured.
+func (c *Client) Run(ctx context.Context, opts RunOptions, stdout, stderr io.Writer) error {
+	args := c.buildFileArgs(opts.Files)
+	args = append(args, "run", "-T")
+	if opts.Remove {
+		args = append(args, "--rm")
+	}
+	args = append(args, opts.Service)
+	args = append(args, opts.Command...)
+
+	return c.executor.RunWithPipes(ctx, "docker", append([]string{"compose"}, args...), stdout, stderr)
+}
+
 // Exec runs a command in a running container
 func (c *Client) Exec(ctx context.Context, service string, command []string, stdout, stderr io.Writer) error {
 	args := []string{"compose", "exec", service}
@@ -206,6 +303,16 @@ func (c *Client) Exec(ctx context.Context, service string, command []string, std
 	return c.executor.RunWithPipes(ctx, "docker", args, stdout, stderr)
 }
 
+// ExecWithOptions runs a command in a running container of the given compose
+// files without allocating a TTY, so its output can be captured.
+func (c *Client) ExecWithOptions(ctx context.Context, opts ExecOptions, stdout, stderr io.Writer) error {
+	args := c.buildFileArgs(opts.Files)
+	args = append(args, "exec", "-T", opts.Service)
+	args = append(args, opts.Command...)
+
+	return c.executor.RunWithPipes(ctx, "docker", append([]string{"compose"}, args...), stdout, stderr)
+}
+
 // buildFileArgs constructs the -f arguments for compose files.
 // When .env exists in the project root, --env-file is prepended so that
 // variable interpolation works regardless of the compose file location.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -12,12 +13,14 @@ import (
 
 // Config represents the complete altctl configuration
 type Config struct {
-	Project  ProjectConfig  `mapstructure:"project"`
-	Compose  ComposeConfig  `mapstructure:"compose"`
-	Defaults DefaultsConfig `mapstructure:"defaults"`
-	Stacks   StacksConfig   `mapstructure:"stacks"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
-	Output   OutputConfig   `mapstructure:"output"`
+	Project   ProjectConfig   `mapstructure:"project"`
+	Compose   ComposeConfig   `mapstructure:"compose"`
+	Defaults  DefaultsConfig  `mapstructure:"defaults"`
+	Stacks    StacksConfig    `mapstructure:"stacks"`
+	Logging   LoggingConfig   `mapstructure:"logging"`
+	Output    OutputConfig    `mapstructure:"output"`
+	Cost      CostConfig      `mapstructure:"cost"`
+	Integrity IntegrityConfig `mapstructure:"integrity"`
 }
 
 // ProjectConfig contains project-level settings
@@ -59,6 +62,42 @@ type OutputConfig struct {
 	Progress bool `mapstructure:"progress"`
 }
 
+// CostConfig contains the node price table used to estimate monthly cost
+// in deploy dry-run reports. Estimation is off while Node is empty.
+type CostConfig struct {
+	Currency string               `mapstructure:"currency"`
+	Node     string               `mapstructure:"node"`
+	Nodes    map[string]NodePrice `mapstructure:"nodes"`
+}
+
+// NodePrice is one node type's capacity and monthly price
+type NodePrice struct {
+	CPUs         float64 `mapstructure:"cpus"`
+	MemoryGiB    float64 `mapstructure:"memory_gib"`
+	GPUs         int     `mapstructure:"gpus"`
+	MonthlyPrice float64 `mapstructure:"monthly_price"`
+}
+
+// IntegrityConfig contains the thresholds of the post-deploy data integrity
+// probes. A zero threshold disables that level.
+type IntegrityConfig struct {
+	ReplicationLagWarningMB  int64 `mapstructure:"replication_lag_warning_mb"`
+	ReplicationLagCriticalMB int64 `mapstructure:"replication_lag_critical_mb"`
+	WALRetainedWarningMB     int64 `mapstructure:"wal_retained_warning_mb"`
+	WALRetainedCriticalMB    int64 `mapstructure:"wal_retained_critical_mb"`
+	SampleTables             int   `mapstructure:"sample_tables"`
+	SampleMaxTableMB         int64 `mapstructure:"sample_max_table_mb"`
+	FailedTasksWarning       int   `mapstructure:"failed_tasks_warning"`
+	FailedTasksCritical      int   `mapstructure:"failed_tasks_critical"`
+}
+
+// SelectedNode returns the price of the node type named by Node. Names are
+// matched case-insensitively because Viper lowercases map keys.
+func (c CostConfig) SelectedNode() (NodePrice, bool) {
+	node, ok := c.Nodes[strings.ToLower(c.Node)]
+	return node, ok
+}
+
 // Load reads configuration from file and environment variables
 func Load(cfgFile, projectDir string) (*Config, error) {
 	v := viper.New()
@@ -138,6 +177,19 @@ func setDefaults(v *viper.Viper) {
 	// Output defaults
 	v.SetDefault("output.colors", true)
 	v.SetDefault("output.progress", true)
+
+	// Cost defaults
+	v.SetDefault("cost.currency", "USD")
+
+	// Integrity probe defaults
+	v.SetDefault("integrity.replication_lag_warning_mb", 64)
+	v.SetDefault("integrity.replication_lag_critical_mb", 512)
+	v.SetDefault("integrity.wal_retained_warning_mb", 1024)
+	v.SetDefault("integrity.wal_retained_critical_mb", 4096)
+	v.SetDefault("integrity.sample_tables", 3)
+	v.SetDefault("integrity.sample_max_table_mb", 256)
+	v.SetDefault("integrity.failed_tasks_warning", 1)
+	v.SetDefault("integrity.failed_tasks_critical", 10)
 }
 
 // detectProjectRoot attempts to find the Alt project root directory
@@ -193,6 +245,56 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("invalid logging format: %s (must be text or json)", cfg.Logging.Format)
 	}
 
+	if err := validateCost(&cfg.Cost); err != nil {
+		return err
+	}
+
+	if err := validateIntegrity(&cfg.Integrity); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateCost checks the selected node type, if any, can price a report
+func validateCost(cost *CostConfig) error {
+	if cost.Node == "" {
+		return nil
+	}
+	node, ok := cost.SelectedNode()
+	if !ok {
+		return fmt.Errorf("cost.node %q is not defined in cost.nodes", cost.Node)
+	}
+	if node.CPUs <= 0 || node.MemoryGiB <= 0 {
+		return fmt.Errorf("cost.nodes.%s: cpus and memory_gib must be positive", cost.Node)
+	}
+	if node.GPUs < 0 || node.MonthlyPrice < 0 {
+		return fmt.Errorf("cost.nodes.%s: gpus and monthly_price must not be negative", cost.Node)
+	}
+	return nil
+}
+
+// validateIntegrity checks each warning threshold is below its critical one
+func validateIntegrity(in *IntegrityConfig) error {
+	pairs := []struct {
+		name              string
+		warning, critical int64
+	}{
+		{"replication_lag", in.ReplicationLagWarningMB, in.ReplicationLagCriticalMB},
+		{"wal_retained", in.WALRetainedWarningMB, in.WALRetainedCriticalMB},
+		{"failed_tasks", int64(in.FailedTasksWarning), int64(in.FailedTasksCritical)},
+	}
+	for _, p := range pairs {
+		if p.warning < 0 || p.critical < 0 {
+			return fmt.Errorf("integrity.%s thresholds must not be negative", p.name)
+		}
+		if p.warning > 0 && p.critical > 0 && p.warning > p.critical {
+			return fmt.Errorf("integrity.%s: warning threshold %d exceeds critical threshold %d", p.name, p.warning, p.critical)
+		}
+	}
+	if in.SampleTables < 0 || in.SampleMaxTableMB < 0 {
+		return fmt.Errorf("integrity.sample_tables and sample_max_table_mb must not be negative")
+	}
 	return nil
 }
 
@@ -0,0 +1,94 @@
+// Package conflict detects resources that would make a deploy fail or
+// misbehave — secret files with foreign ownership or loose permissions, and
+// containers whose name is held by another Compose project — and resolves
+// them one by one with the operator's approval.
+package conflict
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Kind classifies a conflict.
+type Kind string
+
+const (
+	KindSecretOwnership    Kind = "secret-ownership"
+	KindSecretPermissions  Kind = "secret-permissions"
+	KindContainerOwnership Kind = "container-ownership"
+)
+
+// Risk is how much damage applying the proposed resolution can do.
+type Risk string
+
+const (
+	RiskLow    Risk = "low"
+	RiskMedium Risk = "medium"
+	RiskHigh   Risk = "high"
+)
+
+// ErrAborted is returned when the operator aborts resolution.
+var ErrAborted = errors.New("conflict resolution aborted by operator")
+
+// Conflict is one detected problem plus the action that would fix it.
+type Conflict struct {
+	Kind       Kind
+	Resource   string
+	Detail     string
+	Resolution string
+	Risk       Risk
+
+	resolve func(ctx context.Context) error
+}
+
+// Resolve applies the proposed resolution.
+func (c Conflict) Resolve(ctx context.Context) error {
+	if c.resolve == nil {
+		return fmt.Errorf("%s %s has no automatic resolution", c.Kind, c.Resource)
+	}
+	return c.resolve(ctx)
+}
+
+// Decision is the operator's answer for one conflict.
+type Decision int
+
+const (
+	Approve Decision = iota
+	Skip
+	Abort
+)
+
+// Decider returns the decision for the i-th of n conflicts.
+type Decider func(i, n int, c Conflict) (Decision, error)
+
+// Outcome records what happened to each conflict.
+type Outcome struct {
+	Resolved []Conflict
+	Skipped  []Conflict
+}
+
+// ResolveAll asks decide about each conflict in order and applies the
+// approved ones. It stops at the first abort (returning ErrAborted) or the
+// first failed resolution; conflicts already resolved stay resolved.
+func ResolveAll(ctx context.Context, conflicts []Conflict, decide Decider) (Outcome, error) {
+	var out Outcome
+	for i, c := range conflicts {
+		decision, err := decide(i, len(conflicts), c)
+		if err != nil {
+			return out, err
+		}
+		switch decision {
+		case Abort:
+			return out, ErrAborted
+		case Skip:
+			out.Skipped = append(out.Skipped, c)
+		case Approve:
+			if err := c.Resolve(ctx); err != nil {
+				return out, fmt.Errorf("resolving %s %s: %w", c.Kind, c.Resource, err)
+			}
+			out.Resolved = append(out.Resolved, c)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,179 @@
+package conflict
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectSecrets_LoosePermissions(t *testing.T) {
+	dir := t.TempDir()
+	loose := filepath.Join(dir, "postgres_password.txt")
+	if err := os.WriteFile(loose, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ok.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts, err := DetectSecrets(dir)
+	if err != nil {
+		t.Fatalf("DetectSecrets: %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %+v, want 1", conflicts)
+	}
+	c := conflicts[0]
+	if c.Kind != KindSecretPermissions || c.Resource != loose || c.Risk != RiskLow {
+		t.Fatalf("conflict = %+v", c)
+	}
+
+	if err := c.Resolve(context.Background()); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	info, err := os.Stat(loose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("mode after resolve = %04o, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestDetectSecrets_MissingDir(t *testing.T) {
+	conflicts, err := DetectSecrets(filepath.Join(t.TempDir(), "secrets"))
+	if err != nil || conflicts != nil {
+		t.Fatalf("DetectSecrets = %v, %v; want nil, nil", conflicts, err)
+	}
+}
+
+func TestParseRendered(t *testing.T) {
+	rendered := []byte(`{"name":"alt","services":{
+		"db":{"container_name":"alt-db"},
+		"meilisearch":{"container_name":"alt-meilisearch"},
+		"alt-backend":{}
+	}}`)
+	project, names, err := ParseRendered(rendered)
+	if err != nil {
+		t.Fatalf("ParseRendered: %v", err)
+	}
+	if project != "alt" {
+		t.Fatalf("project = %q, want alt", project)
+	}
+	if want := []string{"alt-db", "alt-meilisearch"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("names = %q, want %q", names, want)
+	}
+}
+
+type fakeRunner struct {
+	outputs map[string]string
+	runs    []string
+}
+
+func (f *fakeRunner) RunWithOutput(_ context.Context, _ string, args []string) ([]byte, error) {
+	// args: ps -a --filter name=^/<name>$ --format ...
+	name := strings.TrimSuffix(strings.TrimPrefix(args[3], "name=^/"), "$")
+	return []byte(f.outputs[name]), nil
+}
+
+func (f *fakeRunner) Run(_ context.Context, _ string, args []string) error {
+	f.runs = append(f.runs, strings.Join(args, " "))
+	return nil
+}
+
+func TestDetectContainers(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{
+		"alt-db":          "alt-db\trunning\talt\n",
+		"alt-meilisearch": "alt-meilisearch\trunning\talt-staging\n",
+		"alt-redis":       "alt-redis\texited\t\n",
+		// A substring match from another container must not count.
+		"alt-nats": "alt-nats-old\trunning\tother\n",
+	}}
+
+	conflicts, err := DetectContainers(context.Background(), runner, "alt",
+		[]string{"alt-db", "alt-meilisearch", "alt-redis", "alt-nats"})
+	if err != nil {
+		t.Fatalf("DetectContainers: %v", err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want 2", conflicts)
+	}
+	if c := conflicts[0]; c.Resource != "alt-meilisearch" || c.Risk != RiskHigh || !strings.Contains(c.Detail, "alt-staging") {
+		t.Fatalf("conflicts[0] = %+v", c)
+	}
+	if c := conflicts[1]; c.Resource != "alt-redis" || c.Risk != RiskMedium || !strings.Contains(c.Detail, "not managed by Compose") {
+		t.Fatalf("conflicts[1] = %+v", c)
+	}
+
+	if err := conflicts[1].Resolve(context.Background()); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if want := []string{"rm -f alt-redis"}; !reflect.DeepEqual(runner.runs, want) {
+		t.Fatalf("runs = %q, want %q", runner.runs, want)
+	}
+}
+
+func testConflicts(resolved *[]string) []Conflict {
+	mk := func(name string) Conflict {
+		return Conflict{
+			Kind:       KindContainerOwnership,
+			Resource:   name,
+			Detail:     "held elsewhere",
+			Resolution: "docker rm -f " + name,
+			Risk:       RiskMedium,
+			resolve: func(context.Context) error {
+				*resolved = append(*resolved, name)
+				return nil
+			},
+		}
+	}
+	return []Conflict{mk("a"), mk("b"), mk("c")}
+}
+
+func TestResolveAll_WithPrompter(t *testing.T) {
+	var resolved []string
+	var out bytes.Buffer
+	p := NewPrompter(strings.NewReader("a\nwhat\ns\ny\n"), &out)
+
+	outcome, err := ResolveAll(context.Background(), testConflicts(&resolved), p.Decide)
+	if err != nil {
+		t.Fatalf("ResolveAll: %v", err)
+	}
+	if want := []string{"a", "c"}; !reflect.DeepEqual(resolved, want) {
+		t.Fatalf("resolved = %q, want %q", resolved, want)
+	}
+	if len(outcome.Resolved) != 2 || len(outcome.Skipped) != 1 || outcome.Skipped[0].Resource != "b" {
+		t.Fatalf("outcome = %+v", outcome)
+	}
+	for _, want := range []string{"[1/3] MEDIUM risk", "resolution: docker rm -f b"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("prompt output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestResolveAll_AbortAndEOF(t *testing.T) {
+	var resolved []string
+	p := NewPrompter(strings.NewReader("a\nq\n"), &bytes.Buffer{})
+	outcome, err := ResolveAll(context.Background(), testConflicts(&resolved), p.Decide)
+	if !errors.Is(err, ErrAborted) {
+		t.Fatalf("err = %v, want ErrAborted", err)
+	}
+	if len(outcome.Resolved) != 1 || len(resolved) != 1 {
+		t.Fatalf("outcome = %+v, resolved = %q", outcome, resolved)
+	}
+
+	resolved = nil
+	p = NewPrompter(strings.NewReader(""), &bytes.Buffer{})
+	if _, err := ResolveAll(context.Background(), testConflicts(&resolved), p.Decide); !errors.Is(err, ErrAborted) {
+		t.Fatalf("EOF: err = %v, want ErrAborted", err)
+	}
+	if len(resolved) != 0 {
+		t.Fatalf("EOF resolved %q, want nothing", resolved)
+	}
+}
@@ -0,0 +1,96 @@
+package conflict
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// projectLabel is the label Docker Compose puts on every container it
+// creates; a container without it, or with another project's value, is not
+// ours to recreate.
+const projectLabel = "com.docker.compose.project"
+
+// Runner executes docker commands. compose.DefaultExecutor satisfies it.
+type Runner interface {
+	Run(ctx context.Context, cmd string, args []string) error
+	RunWithOutput(ctx context.Context, cmd string, args []string) ([]byte, error)
+}
+
+// ParseRendered extracts the project name and the explicit container_name
+// values from 'docker compose config --format json' output. Services without
+// container_name get project-scoped names and cannot collide.
+func ParseRendered(rendered []byte) (project string, names []string, err error) {
+	var doc struct {
+		Name     string `json:"name"`
+		Services map[string]struct {
+			ContainerName string `json:"container_name"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(rendered, &doc); err != nil {
+		return "", nil, fmt.Errorf("parsing rendered compose config: %w", err)
+	}
+	for _, svc := range doc.Services {
+		if svc.ContainerName != "" {
+			names = append(names, svc.ContainerName)
+		}
+	}
+	sort.Strings(names)
+	return doc.Name, names, nil
+}
+
+// DetectContainers reports each fixed container name that is already taken
+// by a container outside project. Compose refuses to start a service whose
+// container_name is held by another project (or by a container started with
+// plain docker run), so the deploy would fail halfway through.
+func DetectContainers(ctx context.Context, runner Runner, project string, names []string) ([]Conflict, error) {
+	var conflicts []Conflict
+	for _, name := range names {
+		out, err := runner.RunWithOutput(ctx, "docker", []string{
+			"ps", "-a",
+			"--filter", "name=^/" + name + "$",
+			"--format", `{{.Names}}\t{{.State}}\t{{.Label "` + projectLabel + `"}}`,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("inspecting container %s: %w", name, err)
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			fields := strings.SplitN(strings.TrimSpace(line), "\t", 3)
+			if len(fields) < 2 || fields[0] != name {
+				continue
+			}
+			owner := ""
+			if len(fields) == 3 {
+				owner = fields[2]
+			}
+			if owner == project {
+				continue
+			}
+			conflicts = append(conflicts, containerConflict(runner, name, fields[1], owner))
+		}
+	}
+	return conflicts, nil
+}
+
+func containerConflict(runner Runner, name, state, owner string) Conflict {
+	detail := fmt.Sprintf("container name is held by a %s container not managed by Compose", state)
+	if owner != "" {
+		detail = fmt.Sprintf("container name is held by a %s container of project %q", state, owner)
+	}
+	risk := RiskMedium
+	if state == "running" {
+		risk = RiskHigh
+	}
+	return Conflict{
+		Kind:       KindContainerOwnership,
+		Resource:   name,
+		Detail:     detail,
+		Resolution: "docker rm -f " + name,
+		Risk:       risk,
+		resolve: func(ctx context.Context) error {
+			return runner.Run(ctx, "docker", []string{"rm", "-f", name})
+		},
+	}
+}
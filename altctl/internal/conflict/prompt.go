@@ -0,0 +1,47 @@
+package conflict
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Prompter asks the operator about each conflict on a terminal.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter reads answers from in and writes prompts to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Decide shows the conflict, its proposed resolution and risk, and reads
+// approve/skip/abort. Unrecognised answers are asked again; end of input
+// aborts so a closed stdin never approves anything.
+func (p *Prompter) Decide(i, n int, c Conflict) (Decision, error) {
+	fmt.Fprintf(p.out, "[%d/%d] %s risk  %s: %s\n", i+1, n, strings.ToUpper(string(c.Risk)), c.Kind, c.Resource)
+	fmt.Fprintf(p.out, "      problem:    %s\n", c.Detail)
+	fmt.Fprintf(p.out, "      resolution: %s\n", c.Resolution)
+	for {
+		fmt.Fprint(p.out, "      [a]pprove, [s]kip, [q] abort? ")
+		line, err := p.in.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "a", "approve", "y", "yes":
+			return Approve, nil
+		case "s", "skip", "n", "no":
+			return Skip, nil
+		case "q", "abort", "quit":
+			return Abort, nil
+		}
+		if err == io.EOF {
+			fmt.Fprintln(p.out)
+			return Abort, nil
+		}
+		if err != nil {
+			return Abort, fmt.Errorf("reading answer: %w", err)
+		}
+	}
+}
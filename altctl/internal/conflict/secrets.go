@@ -0,0 +1,79 @@
+package conflict
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// secretFileMode is what altctl init writes secrets with.
+const secretFileMode os.FileMode = 0o600
+
+// DetectSecrets checks the regular files directly under dir. A file is in
+// conflict when it is owned by a different user than dir itself (typically
+// created with sudo, so the deploy user cannot read or rotate it) or when
+// group or others can read it. A missing dir yields no conflicts.
+func DetectSecrets(dir string) ([]Conflict, error) {
+	dirInfo, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading secrets directory: %w", err)
+	}
+	dirUID, dirGID, hasOwner := fileOwner(dirInfo)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("listing secrets directory: %w", err)
+	}
+
+	var conflicts []Conflict
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		info, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		if uid, _, ok := fileOwner(info); ok && hasOwner && uid != dirUID {
+			conflicts = append(conflicts, Conflict{
+				Kind:       KindSecretOwnership,
+				Resource:   path,
+				Detail:     fmt.Sprintf("owned by uid %d, secrets directory is owned by uid %d", uid, dirUID),
+				Resolution: fmt.Sprintf("chown %d:%d %s", dirUID, dirGID, path),
+				Risk:       RiskMedium,
+				resolve: func(context.Context) error {
+					return os.Chown(path, dirUID, dirGID)
+				},
+			})
+		}
+
+		if perm := info.Mode().Perm(); perm&0o077 != 0 {
+			conflicts = append(conflicts, Conflict{
+				Kind:       KindSecretPermissions,
+				Resource:   path,
+				Detail:     fmt.Sprintf("mode %04o lets group/others read the secret", perm),
+				Resolution: fmt.Sprintf("chmod %04o %s", secretFileMode, path),
+				Risk:       RiskLow,
+				resolve: func(context.Context) error {
+					return os.Chmod(path, secretFileMode)
+				},
+			})
+		}
+	}
+	return conflicts, nil
+}
+
+func fileOwner(info os.FileInfo) (uid, gid int, ok bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(st.Uid), int(st.Gid), true
+}
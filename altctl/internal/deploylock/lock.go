@@ -0,0 +1,172 @@
+// Package deploylock pins what each stack was last deployed from, so a
+// frozen deploy can refuse compose or .env changes nobody meant to ship.
+package deploylock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// FormatVersion is the lockfile schema version written by Save.
+const FormatVersion = 1
+
+const (
+	dirPerm  = 0700
+	filePerm = 0600
+)
+
+// Pin records the inputs one stack was deployed from.
+type Pin struct {
+	// ComposeFile is the stack's compose file, relative to the compose dir.
+	ComposeFile string `json:"compose_file"`
+	// ComposeDigest is the SHA-256 of the compose file as written.
+	ComposeDigest string `json:"compose_digest"`
+	// Commit is the last git commit that touched the compose file. It is
+	// informational; only the digests are compared.
+	Commit string `json:"commit,omitempty"`
+	// ValuesDigest is the digest of the rendered services, so it also
+	// covers .env values and image tags interpolated into them.
+	ValuesDigest string `json:"values_digest"`
+	// Images lists the images the rendered stack references.
+	Images   []string  `json:"images,omitempty"`
+	PinnedAt time.Time `json:"pinned_at"`
+}
+
+// Lockfile maps stack names to their pins.
+type Lockfile struct {
+	Version int            `json:"version"`
+	Stacks  map[string]Pin `json:"stacks"`
+}
+
+// Drift is one difference between a pin and what would be deployed now.
+type Drift struct {
+	Stack string
+	// Field is "stack" when the stack has no pin at all, otherwise
+	// "compose", "values" or "images".
+	Field   string
+	Locked  string
+	Current string
+}
+
+// Check compares current pins against the lockfile and returns every
+// difference, sorted by stack. Stacks in the lockfile but not in current
+// are ignored: a deploy of a subset of stacks is not drift.
+func (l *Lockfile) Check(current map[string]Pin) []Drift {
+	var drift []Drift
+	for _, name := range sortedStacks(current) {
+		cur := current[name]
+		locked, ok := l.Stacks[name]
+		if !ok {
+			drift = append(drift, Drift{Stack: name, Field: "stack", Locked: "(not pinned)", Current: cur.ComposeFile})
+			continue
+		}
+		if locked.ComposeDigest != cur.ComposeDigest {
+			drift = append(drift, Drift{Stack: name, Field: "compose", Locked: short(locked.ComposeDigest), Current: short(cur.ComposeDigest)})
+		}
+		if locked.ValuesDigest != cur.ValuesDigest {
+			drift = append(drift, Drift{Stack: name, Field: "values", Locked: short(locked.ValuesDigest), Current: short(cur.ValuesDigest)})
+		}
+		if !slices.Equal(locked.Images, cur.Images) {
+			drift = append(drift, Drift{Stack: name, Field: "images", Locked: fmt.Sprint(locked.Images), Current: fmt.Sprint(cur.Images)})
+		}
+	}
+	return drift
+}
+
+// Pin records pins, replacing any previous pin for the same stacks.
+func (l *Lockfile) Pin(pins map[string]Pin) {
+	if l.Stacks == nil {
+		l.Stacks = make(map[string]Pin, len(pins))
+	}
+	for name, p := range pins {
+		l.Stacks[name] = p
+	}
+}
+
+// Store reads and writes the lockfile at Path, normally
+// <project root>/.altctl/deploy.lock.json.
+type Store struct {
+	Path string
+}
+
+// Load returns the lockfile, or an empty one if none has been written yet.
+func (s Store) Load() (*Lockfile, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Lockfile{Version: FormatVersion, Stacks: map[string]Pin{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading lockfile: %w", err)
+	}
+	var l Lockfile
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", s.Path, err)
+	}
+	if l.Version != FormatVersion {
+		return nil, fmt.Errorf("%s: unsupported lockfile version %d", s.Path, l.Version)
+	}
+	if l.Stacks == nil {
+		l.Stacks = map[string]Pin{}
+	}
+	return &l, nil
+}
+
+// Save writes the lockfile. encoding/json sorts map keys, so unchanged pins
+// produce an unchanged file.
+func (s Store) Save(l *Lockfile) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), dirPerm); err != nil {
+		return fmt.Errorf("creating lockfile directory: %w", err)
+	}
+	l.Version = FormatVersion
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding lockfile: %w", err)
+	}
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), filePerm); err != nil {
+		return fmt.Errorf("writing lockfile: %w", err)
+	}
+	if err := os.Rename(tmp, s.Path); err != nil {
+		return fmt.Errorf("writing lockfile: %w", err)
+	}
+	return nil
+}
+
+// FileDigest returns the SHA-256 of the file at path.
+func FileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sortedStacks(pins map[string]Pin) []string {
+	names := make([]string, 0, len(pins))
+	for name := range pins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// short abbreviates a digest for display.
+func short(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
@@ -0,0 +1,119 @@
+package deploylock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func pin(compose, values string, images ...string) Pin {
+	return Pin{
+		ComposeFile:   "core.yaml",
+		ComposeDigest: compose,
+		ValuesDigest:  values,
+		Images:        images,
+		PinnedAt:      time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCheck(t *testing.T) {
+	lock := &Lockfile{Stacks: map[string]Pin{
+		"core": pin("aaaaaaaaaaaaaaaa", "bbbbbbbbbbbbbbbb", "postgres:17"),
+		"db":   pin("cccc", "dddd"),
+		"ai":   pin("eeee", "ffff"),
+	}}
+
+	drift := lock.Check(map[string]Pin{
+		"core":    pin("aaaaaaaaaaaaaaaa", "9999999999999999", "postgres:18"),
+		"db":      pin("cccc", "dddd"),
+		"workers": pin("1111", "2222"),
+	})
+
+	want := []Drift{
+		{Stack: "core", Field: "values", Locked: "bbbbbbbbbbbb", Current: "999999999999"},
+		{Stack: "core", Field: "images", Locked: "[postgres:17]", Current: "[postgres:18]"},
+		{Stack: "workers", Field: "stack", Locked: "(not pinned)", Current: "core.yaml"},
+	}
+	if len(drift) != len(want) {
+		t.Fatalf("got %d drifts %+v, want %d", len(drift), drift, len(want))
+	}
+	for i := range want {
+		if drift[i] != want[i] {
+			t.Errorf("drift[%d] = %+v, want %+v", i, drift[i], want[i])
+		}
+	}
+}
+
+func TestCheck_NoDrift(t *testing.T) {
+	lock := &Lockfile{Stacks: map[string]Pin{"core": pin("a", "b")}}
+	if drift := lock.Check(map[string]Pin{"core": pin("a", "b")}); len(drift) != 0 {
+		t.Errorf("expected no drift, got %+v", drift)
+	}
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), ".altctl", "deploy.lock.json")}
+
+	lock, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load on missing file: %v", err)
+	}
+	if len(lock.Stacks) != 0 {
+		t.Fatalf("expected empty lockfile, got %+v", lock)
+	}
+
+	lock.Pin(map[string]Pin{"core": pin("a", "b", "postgres:17")})
+	if err := store.Save(lock); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	first, err := os.ReadFile(store.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := loaded.Stacks["core"]; got.ValuesDigest != "b" || got.Images[0] != "postgres:17" {
+		t.Errorf("unexpected pin after round trip: %+v", got)
+	}
+
+	// Re-saving unchanged pins must not churn the file.
+	if err := store.Save(loaded); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	second, _ := os.ReadFile(store.Path)
+	if string(first) != string(second) {
+		t.Errorf("lockfile changed on re-save:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestStore_RejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.lock.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "stacks": {}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (Store{Path: path}).Load(); err == nil {
+		t.Error("expected an error for an unsupported lockfile version")
+	}
+}
+
+func TestFileDigest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "core.yaml")
+	if err := os.WriteFile(path, []byte("services: {}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	a, err := FileDigest(path)
+	if err != nil {
+		t.Fatalf("FileDigest: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("services: {db: {}}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := FileDigest(path)
+	if a == b || len(a) != 64 {
+		t.Errorf("digests %q and %q should differ and be hex SHA-256", a, b)
+	}
+}
@@ -0,0 +1,267 @@
+package deployreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Resources is what one replica of a service asks of its node, taken from
+// deploy.resources.reservations, falling back to deploy.resources.limits
+// and then to the legacy cpus / mem_reservation / mem_limit keys.
+type Resources struct {
+	CPUs        float64 `json:"cpus,omitempty"`
+	MemoryBytes int64   `json:"memory_bytes,omitempty"`
+	// GPUs is the number of GPU devices reserved; -1 means all of them.
+	GPUs     int `json:"gpus,omitempty"`
+	Replicas int `json:"replicas"`
+}
+
+// Sized reports whether the service declares any resources at all.
+func (r Resources) Sized() bool {
+	return r.CPUs > 0 || r.MemoryBytes > 0 || r.GPUs != 0
+}
+
+// NodeType is one entry of the node price table: a machine's capacity and
+// what it costs per month.
+type NodeType struct {
+	Name         string
+	CPUs         float64
+	MemoryBytes  int64
+	GPUs         int
+	MonthlyPrice float64
+}
+
+// PriceTable prices the dry-run report. Every stack is assumed to run on
+// Node.
+type PriceTable struct {
+	Currency string
+	Node     NodeType
+}
+
+// ServiceCost is the estimated monthly cost of one service.
+type ServiceCost struct {
+	Service   string
+	Resources Resources
+	// Share is the largest fraction of the node's CPU, memory or GPUs one
+	// replica reserves.
+	Share   float64
+	Monthly float64
+}
+
+// StackCost is the estimated monthly cost of one stack.
+type StackCost struct {
+	Monthly  float64
+	Services []ServiceCost
+	// Unsized lists services that declare no resources and so are not
+	// counted in Monthly.
+	Unsized []string
+}
+
+// EstimateCost prices m on prices.Node. A service costs the node's monthly
+// price times its dominant share of the node, per replica.
+func EstimateCost(m *StackManifest, prices PriceTable) *StackCost {
+	cost := &StackCost{}
+	if m == nil {
+		return cost
+	}
+	node := prices.Node
+	for _, s := range m.Services {
+		res := s.Resources
+		if !res.Sized() {
+			cost.Unsized = append(cost.Unsized, s.Name)
+			continue
+		}
+
+		var share float64
+		if node.CPUs > 0 {
+			share = math.Max(share, res.CPUs/node.CPUs)
+		}
+		if node.MemoryBytes > 0 {
+			share = math.Max(share, float64(res.MemoryBytes)/float64(node.MemoryBytes))
+		}
+		switch {
+		case res.GPUs < 0 && node.GPUs > 0:
+			share = 1
+		case res.GPUs > 0 && node.GPUs > 0:
+			share = math.Max(share, float64(res.GPUs)/float64(node.GPUs))
+		}
+
+		sc := ServiceCost{
+			Service:   s.Name,
+			Resources: res,
+			Share:     share,
+			Monthly:   share * float64(res.Replicas) * node.MonthlyPrice,
+		}
+		cost.Monthly += sc.Monthly
+		cost.Services = append(cost.Services, sc)
+	}
+	return cost
+}
+
+// EstimateCosts prices every stack in r, and its baseline when there is
+// one, so the report can show deltas against the last deployment.
+func (r *Report) EstimateCosts(prices PriceTable) {
+	r.Prices = &prices
+	for i := range r.Stacks {
+		sr := &r.Stacks[i]
+		sr.Cost = EstimateCost(sr.Manifest, prices)
+		if sr.Baseline != nil {
+			sr.BaselineCost = EstimateCost(sr.Baseline, prices)
+		}
+	}
+}
+
+// TotalCost returns the estimated monthly cost of all priced stacks and
+// of their baselines. Stacks without a baseline count as zero before.
+func (r *Report) TotalCost() (current, previous float64) {
+	for _, sr := range r.Stacks {
+		if sr.Cost != nil {
+			current += sr.Cost.Monthly
+		}
+		if sr.BaselineCost != nil {
+			previous += sr.BaselineCost.Monthly
+		}
+	}
+	return current, previous
+}
+
+// parseResources extracts Resources from a rendered service definition.
+// Values Compose would reject are ignored rather than failing the report.
+func parseResources(def map[string]json.RawMessage) Resources {
+	res := Resources{Replicas: 1}
+
+	var deploy struct {
+		Replicas  *int `json:"replicas"`
+		Resources struct {
+			Limits       resourceSpec `json:"limits"`
+			Reservations resourceSpec `json:"reservations"`
+		} `json:"resources"`
+	}
+	if raw, ok := def["deploy"]; ok {
+		_ = json.Unmarshal(raw, &deploy)
+	}
+	if deploy.Replicas != nil {
+		res.Replicas = max(*deploy.Replicas, 0)
+	}
+
+	reserve, limit := deploy.Resources.Reservations, deploy.Resources.Limits
+	res.CPUs = firstPositive(reserve.cpus(), limit.cpus(), parseCPUs(def["cpus"]))
+	res.MemoryBytes = int64(firstPositive(
+		float64(reserve.memory()), float64(limit.memory()),
+		float64(parseBytes(def["mem_reservation"])), float64(parseBytes(def["mem_limit"])),
+	))
+	res.GPUs = reserve.gpus()
+	return res
+}
+
+type resourceSpec struct {
+	CPUs    json.RawMessage `json:"cpus"`
+	Memory  json.RawMessage `json:"memory"`
+	Devices []struct {
+		Capabilities []string        `json:"capabilities"`
+		Count        json.RawMessage `json:"count"`
+		DeviceIDs    []string        `json:"device_ids"`
+	} `json:"devices"`
+}
+
+func (s resourceSpec) cpus() float64 { return parseCPUs(s.CPUs) }
+func (s resourceSpec) memory() int64 { return parseBytes(s.Memory) }
+
+// gpus counts GPU devices. A device entry without count or device_ids
+// reserves every GPU, as does count "all" (rendered as -1).
+func (s resourceSpec) gpus() int {
+	n := 0
+	for _, d := range s.Devices {
+		if !slices.Contains(d.Capabilities, "gpu") {
+			continue
+		}
+		if len(d.DeviceIDs) > 0 {
+			n += len(d.DeviceIDs)
+			continue
+		}
+		count, ok := parseCount(d.Count)
+		if !ok || count < 0 {
+			return -1
+		}
+		n += count
+	}
+	return n
+}
+
+func parseCount(raw json.RawMessage) (int, bool) {
+	s := unquote(raw)
+	if s == "" || strings.EqualFold(s, "all") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+func parseCPUs(raw json.RawMessage) float64 {
+	f, err := strconv.ParseFloat(unquote(raw), 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return f
+}
+
+// parseBytes accepts a plain byte count (how Compose renders memory) or a
+// Docker-style size such as "256M" or "1.5gb", in binary units.
+func parseBytes(raw json.RawMessage) int64 {
+	s := strings.ToLower(unquote(raw))
+	if s == "" {
+		return 0
+	}
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "b"), "i")
+	mult := float64(1)
+	if i := strings.IndexAny(s, "kmgt"); i >= 0 && i == len(s)-1 {
+		mult = math.Pow(1024, float64(strings.IndexByte("kmgt", s[i])+1))
+		s = s[:i]
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return int64(f * mult)
+}
+
+func unquote(raw json.RawMessage) string {
+	s := strings.TrimSpace(string(raw))
+	if s == "" || s == "null" {
+		return ""
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return strings.TrimSpace(str)
+	}
+	return s
+}
+
+func firstPositive(vals ...float64) float64 {
+	for _, v := range vals {
+		if v > 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+// FormatMoney renders amount in the table's currency, e.g. "12.34 USD".
+func (p PriceTable) FormatMoney(amount float64) string {
+	if p.Currency == "" {
+		return fmt.Sprintf("%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, p.Currency)
+}
+
+// sortedServiceCosts returns costs ordered by descending monthly cost.
+func sortedServiceCosts(costs []ServiceCost) []ServiceCost {
+	out := append([]ServiceCost(nil), costs...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Monthly > out[j].Monthly })
+	return out
+}
@@ -0,0 +1,127 @@
+package deployreport
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+const renderedSized = `{
+  "services": {
+    "alt-backend": {"image": "alt-backend:latest", "deploy": {"replicas": 2, "resources": {"reservations": {"cpus": 1, "memory": "4294967296"}}}},
+    "news-creator": {"image": "ollama", "deploy": {"resources": {"reservations": {"devices": [{"driver": "nvidia", "count": -1, "capabilities": ["gpu"]}]}}}},
+    "redis": {"image": "redis:8", "mem_limit": "512m"},
+    "nginx": {"image": "nginx"}
+  }
+}`
+
+var testPrices = PriceTable{
+	Currency: "USD",
+	Node:     NodeType{Name: "gpu-box", CPUs: 8, MemoryBytes: 32 << 30, GPUs: 1, MonthlyPrice: 160},
+}
+
+func TestParseResources(t *testing.T) {
+	m := mustParse(t, "core", renderedSized)
+	got := make(map[string]Resources)
+	for _, s := range m.Services {
+		got[s.Name] = s.Resources
+	}
+
+	if r := got["alt-backend"]; r.CPUs != 1 || r.MemoryBytes != 4<<30 || r.Replicas != 2 {
+		t.Errorf("alt-backend resources = %+v", r)
+	}
+	if r := got["news-creator"]; r.GPUs != -1 {
+		t.Errorf("news-creator should reserve all GPUs, got %+v", r)
+	}
+	if r := got["redis"]; r.MemoryBytes != 512<<20 {
+		t.Errorf("redis should fall back to mem_limit, got %+v", r)
+	}
+	if got["nginx"].Sized() {
+		t.Errorf("nginx declares no resources, got %+v", got["nginx"])
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	for in, want := range map[string]int64{
+		`"268435456"`: 256 << 20,
+		`"256M"`:      256 << 20,
+		`"1.5gb"`:     3 << 29,
+		`"2GiB"`:      2 << 30,
+		`1024`:        1024,
+		`"lots"`:      0,
+	} {
+		if got := parseBytes([]byte(in)); got != want {
+			t.Errorf("parseBytes(%s) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	cost := EstimateCost(mustParse(t, "core", renderedSized), testPrices)
+
+	byName := make(map[string]ServiceCost)
+	for _, sc := range cost.Services {
+		byName[sc.Service] = sc
+	}
+	// 1 of 8 CPUs and 4 of 32 GiB are both 1/8 of the node, times two replicas.
+	if sc := byName["alt-backend"]; math.Abs(sc.Monthly-40) > 1e-9 {
+		t.Errorf("alt-backend monthly = %v, want 40", sc.Monthly)
+	}
+	if sc := byName["news-creator"]; sc.Share != 1 || sc.Monthly != 160 {
+		t.Errorf("news-creator should take the whole node, got %+v", sc)
+	}
+	if sc := byName["redis"]; math.Abs(sc.Monthly-2.5) > 1e-9 {
+		t.Errorf("redis monthly = %v, want 2.5", sc.Monthly)
+	}
+	if strings.Join(cost.Unsized, ",") != "nginx" {
+		t.Errorf("unsized = %v, want [nginx]", cost.Unsized)
+	}
+	if math.Abs(cost.Monthly-202.5) > 1e-9 {
+		t.Errorf("stack monthly = %v, want 202.5", cost.Monthly)
+	}
+}
+
+func TestReport_EstimateCostsDelta(t *testing.T) {
+	prev := mustParse(t, "core", `{"services": {"redis": {"image": "redis:8", "mem_limit": "512m"}}}`)
+	cur := mustParse(t, "core", renderedSized)
+	r := &Report{Stacks: []StackReport{
+		{Manifest: cur, HasBaseline: true, Baseline: prev, Changes: Diff(prev, cur)},
+		{Manifest: mustParse(t, "ai", `{"services": {"x": {"cpus": 2}}}`)},
+	}}
+	r.EstimateCosts(testPrices)
+
+	current, previous := r.TotalCost()
+	if math.Abs(current-242.5) > 1e-9 || math.Abs(previous-2.5) > 1e-9 {
+		t.Errorf("TotalCost = %v, %v; want 242.5, 2.5", current, previous)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, r); err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Estimated monthly cost",
+		"| core | 202.50 USD | 2.50 USD | +200.00 USD | 1 |",
+		"| ai | 40.00 USD | - | new | 0 |",
+		"| **Total** | **242.50 USD** | 2.50 USD | +240.00 USD | |",
+		"| news-creator | 1 | - | - | all | 100.0% | 160.00 USD |",
+		"Not priced (no resource reservations or limits): nginx",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderMarkdown_NoPricesOmitsCost(t *testing.T) {
+	r := &Report{Stacks: []StackReport{{Manifest: mustParse(t, "core", renderedSized)}}}
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, r); err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	if strings.Contains(buf.String(), "Estimated monthly cost") {
+		t.Errorf("cost section rendered without a price table:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,206 @@
+package deployreport
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alt-project/altctl/internal/integrity"
+)
+
+// RenderMarkdown writes a human-readable summary of r.
+func RenderMarkdown(w io.Writer, r *Report) error {
+	var b strings.Builder
+
+	if r.Deployed {
+		fmt.Fprintf(&b, "# altctl deploy report\n\n")
+	} else {
+		fmt.Fprintf(&b, "# altctl deploy dry-run report\n\n")
+	}
+	fmt.Fprintf(&b, "Generated: %s\n\n", r.GeneratedAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Stack | Services | Images | Changes vs last deploy |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, sr := range r.Stacks {
+		changes := fmt.Sprintf("%d", len(sr.Changes))
+		if !sr.HasBaseline {
+			changes = "no baseline"
+		}
+		fmt.Fprintf(&b, "| %s | %d | %d | %s |\n",
+			sr.Manifest.Stack, len(sr.Manifest.Services), len(sr.Manifest.Images()), changes)
+	}
+	b.WriteString("\n")
+
+	if r.Prices != nil {
+		writeCostSummary(&b, r)
+	}
+
+	if len(r.Integrity) > 0 {
+		writeIntegrity(&b, r.Integrity)
+	}
+
+	if len(r.RenderErrors) > 0 {
+		b.WriteString("## Render errors\n\n")
+		for _, stack := range sortedKeys(r.RenderErrors) {
+			fmt.Fprintf(&b, "- **%s**: %s\n", stack, r.RenderErrors[stack])
+		}
+		b.WriteString("\n")
+	}
+
+	for _, sr := range r.Stacks {
+		fmt.Fprintf(&b, "## %s\n\n", sr.Manifest.Stack)
+		fmt.Fprintf(&b, "Manifest: `%s.json`\n\n", sr.Manifest.Stack)
+
+		b.WriteString("| Service | Image | Built locally |\n|---|---|---|\n")
+		for _, s := range sr.Manifest.Services {
+			image := s.Image
+			if image == "" {
+				image = "-"
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %s |\n", s.Name, image, yesNo(s.Build))
+		}
+		b.WriteString("\n")
+
+		if r.Prices != nil && sr.Cost != nil {
+			writeStackCost(&b, *r.Prices, sr.Cost)
+		}
+
+		switch {
+		case !sr.HasBaseline:
+			b.WriteString("No previous deployment recorded; every service is new.\n\n")
+		case len(sr.Changes) == 0:
+			b.WriteString("No changes since the last deployment.\n\n")
+		default:
+			b.WriteString("### Changes since last deployment\n\n")
+			for _, c := range sr.Changes {
+				b.WriteString("- " + describeChange(c) + "\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeCostSummary(b *strings.Builder, r *Report) {
+	p := *r.Prices
+	node := p.Node
+	b.WriteString("## Estimated monthly cost\n\n")
+	fmt.Fprintf(b, "Node `%s`: %s CPUs, %s memory, %d GPUs at %s/month. ",
+		node.Name, formatFloat(node.CPUs), formatBytes(node.MemoryBytes), node.GPUs, p.FormatMoney(node.MonthlyPrice))
+	b.WriteString("Each replica costs the node price times its largest share of the node's CPU, memory or GPUs; " +
+		"services that reserve nothing are not counted.\n\n")
+
+	b.WriteString("| Stack | Estimate | Last deploy | Delta | Unsized services |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, sr := range r.Stacks {
+		if sr.Cost == nil {
+			continue
+		}
+		before, delta := "-", "new"
+		if sr.BaselineCost != nil {
+			before = p.FormatMoney(sr.BaselineCost.Monthly)
+			delta = formatDelta(p, sr.Cost.Monthly-sr.BaselineCost.Monthly)
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %d |\n",
+			sr.Manifest.Stack, p.FormatMoney(sr.Cost.Monthly), before, delta, len(sr.Cost.Unsized))
+	}
+	current, previous := r.TotalCost()
+	fmt.Fprintf(b, "| **Total** | **%s** | %s | %s | |\n\n",
+		p.FormatMoney(current), p.FormatMoney(previous), formatDelta(p, current-previous))
+}
+
+func writeIntegrity(b *strings.Builder, results []integrity.Result) {
+	b.WriteString("## Data integrity\n\n")
+	fmt.Fprintf(b, "Overall: **%s**\n\n", integrity.Worst(results))
+	b.WriteString("| Service | Check | Status | Result |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, res := range results {
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n",
+			res.Service, res.Check, res.Status, strings.ReplaceAll(res.Summary, "|", "\\|"))
+	}
+	b.WriteString("\n")
+}
+
+func writeStackCost(b *strings.Builder, p PriceTable, cost *StackCost) {
+	if len(cost.Services) > 0 {
+		b.WriteString("| Service | Replicas | CPUs | Memory | GPUs | Node share | Estimate |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		for _, sc := range sortedServiceCosts(cost.Services) {
+			res := sc.Resources
+			gpus := fmt.Sprintf("%d", res.GPUs)
+			if res.GPUs < 0 {
+				gpus = "all"
+			}
+			fmt.Fprintf(b, "| %s | %d | %s | %s | %s | %.1f%% | %s |\n",
+				sc.Service, res.Replicas, formatFloat(res.CPUs), formatBytes(res.MemoryBytes), gpus,
+				sc.Share*100, p.FormatMoney(sc.Monthly))
+		}
+		b.WriteString("\n")
+	}
+	if len(cost.Unsized) > 0 {
+		fmt.Fprintf(b, "Not priced (no resource reservations or limits): %s\n\n", strings.Join(cost.Unsized, ", "))
+	}
+}
+
+func formatDelta(p PriceTable, d float64) string {
+	if math.Abs(d) < 0.005 {
+		return "±0"
+	}
+	if d > 0 {
+		return "+" + p.FormatMoney(d)
+	}
+	return p.FormatMoney(d)
+}
+
+func formatFloat(f float64) string {
+	if f == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatBytes(n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	const gib = 1 << 30
+	if n >= gib {
+		return strconv.FormatFloat(float64(n)/gib, 'f', -1, 64) + " GiB"
+	}
+	return strconv.FormatFloat(float64(n)/(1<<20), 'f', 0, 64) + " MiB"
+}
+
+func describeChange(c Change) string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("**%s** added", c.Service)
+	case ChangeRemoved:
+		return fmt.Sprintf("**%s** removed", c.Service)
+	case ChangeImage:
+		return fmt.Sprintf("**%s** image `%s` → `%s`", c.Service, orDash(c.From), orDash(c.To))
+	default:
+		fields := append([]string(nil), c.Fields...)
+		sort.Strings(fields)
+		return fmt.Sprintf("**%s** config changed: %s", c.Service, strings.Join(fields, ", "))
+	}
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,237 @@
+// Package deployreport renders per-stack Compose manifests and summarizes
+// what a deploy would change relative to the last real deployment.
+package deployreport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alt-project/altctl/internal/integrity"
+)
+
+// Service summarizes one service of a rendered Compose manifest.
+type Service struct {
+	Name  string `json:"name"`
+	Image string `json:"image,omitempty"`
+	Build bool   `json:"build"`
+	// Digest is the SHA-256 of the service's canonical rendered definition.
+	Digest string `json:"digest"`
+	// Resources is what each replica reserves, for cost estimation.
+	Resources Resources `json:"resources"`
+
+	fields map[string]string // top-level key -> canonical JSON digest
+}
+
+// StackManifest is the fully rendered Compose configuration of one stack,
+// as produced by `docker compose config --format json`.
+type StackManifest struct {
+	Stack    string
+	Rendered []byte
+	Services []Service
+}
+
+// ParseManifest parses rendered Compose JSON for stack.
+func ParseManifest(stack string, rendered []byte) (*StackManifest, error) {
+	var doc struct {
+		Services map[string]map[string]json.RawMessage `json:"services"`
+	}
+	if err := json.Unmarshal(rendered, &doc); err != nil {
+		return nil, fmt.Errorf("parsing rendered manifest for %s: %w", stack, err)
+	}
+
+	m := &StackManifest{Stack: stack, Rendered: rendered}
+	for name, def := range doc.Services {
+		svc := Service{Name: name, fields: make(map[string]string, len(def))}
+		whole := sha256.New()
+		for _, key := range sortedKeys(def) {
+			canon, err := canonicalJSON(def[key])
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s.%s in %s: %w", name, key, stack, err)
+			}
+			svc.fields[key] = digest(canon)
+			fmt.Fprintf(whole, "%s=%s\n", key, canon)
+		}
+		if raw, ok := def["image"]; ok {
+			_ = json.Unmarshal(raw, &svc.Image)
+		}
+		_, svc.Build = def["build"]
+		svc.Resources = parseResources(def)
+		svc.Digest = hex.EncodeToString(whole.Sum(nil))
+		m.Services = append(m.Services, svc)
+	}
+	sort.Slice(m.Services, func(i, j int) bool { return m.Services[i].Name < m.Services[j].Name })
+	return m, nil
+}
+
+// Images returns the distinct images referenced by the stack, sorted.
+func (m *StackManifest) Images() []string {
+	seen := make(map[string]bool)
+	var images []string
+	for _, s := range m.Services {
+		if s.Image != "" && !seen[s.Image] {
+			seen[s.Image] = true
+			images = append(images, s.Image)
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
+// Digest returns the SHA-256 over every service's digest. It changes
+// whenever any service's rendered definition does, including .env values
+// interpolated into it.
+func (m *StackManifest) Digest() string {
+	h := sha256.New()
+	for _, s := range m.Services {
+		fmt.Fprintf(h, "%s=%s\n", s.Name, s.Digest)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ChangeKind classifies a difference between two manifests.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeImage   ChangeKind = "image"
+	ChangeConfig  ChangeKind = "config"
+)
+
+// Change is one service-level difference. Fields lists the top-level keys
+// that changed; values are deliberately omitted because rendered manifests
+// contain interpolated environment variables, which may hold secrets.
+type Change struct {
+	Service string     `json:"service"`
+	Kind    ChangeKind `json:"kind"`
+	From    string     `json:"from,omitempty"`
+	To      string     `json:"to,omitempty"`
+	Fields  []string   `json:"fields,omitempty"`
+}
+
+// Diff compares cur against prev. A nil prev reports every service as added.
+func Diff(prev, cur *StackManifest) []Change {
+	before := make(map[string]Service)
+	if prev != nil {
+		for _, s := range prev.Services {
+			before[s.Name] = s
+		}
+	}
+
+	var changes []Change
+	for _, s := range cur.Services {
+		old, ok := before[s.Name]
+		delete(before, s.Name)
+		switch {
+		case !ok:
+			changes = append(changes, Change{Service: s.Name, Kind: ChangeAdded, To: s.Image})
+		case old.Digest == s.Digest:
+			// unchanged
+		default:
+			if old.Image != s.Image {
+				changes = append(changes, Change{Service: s.Name, Kind: ChangeImage, From: old.Image, To: s.Image})
+			}
+			if fields := changedFields(old, s); len(fields) > 0 {
+				changes = append(changes, Change{Service: s.Name, Kind: ChangeConfig, Fields: fields})
+			}
+		}
+	}
+	for name, s := range before {
+		changes = append(changes, Change{Service: name, Kind: ChangeRemoved, From: s.Image})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Service < changes[j].Service })
+	return changes
+}
+
+// changedFields returns the top-level keys (other than image) whose values
+// differ between a and b.
+func changedFields(a, b Service) []string {
+	keys := make(map[string]bool)
+	for k := range a.fields {
+		keys[k] = true
+	}
+	for k := range b.fields {
+		keys[k] = true
+	}
+
+	var fields []string
+	for k := range keys {
+		if k != "image" && a.fields[k] != b.fields[k] {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// StackReport pairs a rendered stack with its diff against the baseline.
+type StackReport struct {
+	Manifest *StackManifest
+	// HasBaseline is false when no real deployment of the stack was recorded.
+	HasBaseline bool
+	Baseline    *StackManifest
+	Changes     []Change
+
+	// Cost and BaselineCost are set by Report.EstimateCosts.
+	Cost         *StackCost
+	BaselineCost *StackCost
+}
+
+// Report is the dry-run summary across all target stacks.
+type Report struct {
+	GeneratedAt time.Time
+	Stacks      []StackReport
+	// RenderErrors maps stack name to the reason it could not be rendered.
+	RenderErrors map[string]string
+	// Prices is nil unless costs were estimated.
+	Prices *PriceTable
+	// Deployed is set when the report records a real deploy rather than a
+	// dry run.
+	Deployed bool
+	// Integrity holds the post-deploy data integrity probe results.
+	Integrity []integrity.Result
+}
+
+// ChangeCount returns the number of changes across all stacks.
+func (r *Report) ChangeCount() int {
+	n := 0
+	for _, s := range r.Stacks {
+		n += len(s.Changes)
+	}
+	return n
+}
+
+func canonicalJSON(raw json.RawMessage) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	// encoding/json sorts map keys, which makes the output canonical.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+func digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,184 @@
+package deployreport
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alt-project/altctl/internal/integrity"
+)
+
+const renderedV1 = `{
+  "name": "alt",
+  "services": {
+    "alt-backend": {"build": {"context": "alt-backend"}, "image": "alt-backend:latest", "environment": {"LOG_LEVEL": "info"}},
+    "db": {"image": "postgres:17", "environment": {"POSTGRES_PASSWORD": "s3cret"}}
+  }
+}`
+
+const renderedV2 = `{
+  "name": "alt",
+  "services": {
+    "alt-backend": {"build": {"context": "alt-backend"}, "image": "alt-backend:latest", "environment": {"LOG_LEVEL": "debug"}},
+    "db": {"image": "postgres:18", "environment": {"POSTGRES_PASSWORD": "s3cret"}},
+    "meilisearch": {"image": "getmeili/meilisearch:v1.15"}
+  }
+}`
+
+func mustParse(t *testing.T, stack, rendered string) *StackManifest {
+	t.Helper()
+	m, err := ParseManifest(stack, []byte(rendered))
+	if err != nil {
+		t.Fatalf("ParseManifest: %v", err)
+	}
+	return m
+}
+
+func TestParseManifest(t *testing.T) {
+	m := mustParse(t, "core", renderedV1)
+
+	if len(m.Services) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(m.Services))
+	}
+	if m.Services[0].Name != "alt-backend" || !m.Services[0].Build {
+		t.Errorf("expected built alt-backend first, got %+v", m.Services[0])
+	}
+	if got := m.Images(); strings.Join(got, ",") != "alt-backend:latest,postgres:17" {
+		t.Errorf("unexpected images: %v", got)
+	}
+}
+
+func TestParseManifest_DigestIgnoresKeyOrder(t *testing.T) {
+	a := mustParse(t, "s", `{"services":{"x":{"image":"a","environment":{"A":"1","B":"2"}}}}`)
+	b := mustParse(t, "s", `{"services":{"x":{"environment":{"B":"2","A":"1"},"image":"a"}}}`)
+
+	if a.Services[0].Digest != b.Services[0].Digest {
+		t.Error("digest should not depend on key order")
+	}
+}
+
+func TestStackManifest_Digest(t *testing.T) {
+	v1 := mustParse(t, "core", renderedV1)
+	if v1.Digest() != mustParse(t, "core", renderedV1).Digest() {
+		t.Error("digest should be stable for the same manifest")
+	}
+	if v1.Digest() == mustParse(t, "core", renderedV2).Digest() {
+		t.Error("digest should change when a service changes")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	changes := Diff(mustParse(t, "core", renderedV1), mustParse(t, "core", renderedV2))
+
+	want := []string{
+		"alt-backend config environment",
+		"db image ",
+		"meilisearch added ",
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Service+" "+string(c.Kind)+" "+strings.Join(c.Fields, ","))
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected changes:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestDiff_NoBaseline(t *testing.T) {
+	changes := Diff(nil, mustParse(t, "core", renderedV1))
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	for _, c := range changes {
+		if c.Kind != ChangeAdded {
+			t.Errorf("expected added, got %s", c.Kind)
+		}
+	}
+}
+
+func TestStore_RoundTripAndReport(t *testing.T) {
+	store := Store{Dir: t.TempDir()}
+
+	if err := store.SaveBaseline([]*StackManifest{mustParse(t, "core", renderedV1)}); err != nil {
+		t.Fatalf("SaveBaseline: %v", err)
+	}
+
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	r, err := store.BuildReport(now, []*StackManifest{
+		mustParse(t, "core", renderedV2),
+		mustParse(t, "ai", renderedV1),
+	}, map[string]string{"rag": "docker compose config failed"})
+	if err != nil {
+		t.Fatalf("BuildReport: %v", err)
+	}
+	if !r.Stacks[0].HasBaseline || r.Stacks[1].HasBaseline {
+		t.Errorf("unexpected baseline flags: %v %v", r.Stacks[0].HasBaseline, r.Stacks[1].HasBaseline)
+	}
+
+	path, err := store.WriteRun(r)
+	if err != nil {
+		t.Fatalf("WriteRun: %v", err)
+	}
+	if want := filepath.Join(store.Dir, "20261016T093000Z", ReportFilename); path != want {
+		t.Errorf("report path = %s, want %s", path, want)
+	}
+	for _, name := range []string{"core.json", "ai.json"} {
+		info, err := os.Stat(filepath.Join(filepath.Dir(path), name))
+		if err != nil {
+			t.Fatalf("missing manifest %s: %v", name, err)
+		}
+		if info.Mode().Perm() != filePerm {
+			t.Errorf("%s mode = %v, want %v", name, info.Mode().Perm(), os.FileMode(filePerm))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading report: %v", err)
+	}
+	report := string(data)
+	for _, want := range []string{
+		"| core | 3 | 3 | 3 |",
+		"| ai | 2 | 2 | no baseline |",
+		"**db** image `postgres:17` → `postgres:18`",
+		"**alt-backend** config changed: environment",
+		"**rag**: docker compose config failed",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "s3cret") {
+		t.Error("report must not contain environment values")
+	}
+}
+
+func TestRenderMarkdown_Integrity(t *testing.T) {
+	r := &Report{
+		Deployed: true,
+		Stacks:   []StackReport{{Manifest: mustParse(t, "db", renderedV1)}},
+		Integrity: []integrity.Result{
+			{Service: "db", Check: "replication", Status: integrity.StatusOK, Summary: "no standbys attached"},
+			{Service: "meilisearch", Check: "tasks", Status: integrity.StatusWarning, Summary: "2 failed task(s) since deploy start; latest on a|b: x"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, r); err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# altctl deploy report\n",
+		"## Data integrity",
+		"Overall: **warning**",
+		"| db | replication | ok | no standbys attached |",
+		`| meilisearch | tasks | warning | 2 failed task(s) since deploy start; latest on a\|b: x |`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package deployreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// BaselineDirName holds the manifests of the last real deployment.
+	BaselineDirName = "last-deploy"
+	// ReportFilename is the Markdown summary written into each run directory.
+	ReportFilename = "report.md"
+
+	// Rendered manifests contain interpolated .env values, so artifacts are
+	// private to the operator.
+	dirPerm  = 0700
+	filePerm = 0600
+)
+
+// Store persists rendered manifests and reports under Dir:
+//
+//	<Dir>/last-deploy/<stack>.json      manifests of the last real deploy
+//	<Dir>/<timestamp>/<stack>.json      manifests rendered by a dry run or deploy
+//	<Dir>/<timestamp>/report.md         summary of that run
+type Store struct {
+	Dir string
+}
+
+// LoadBaseline returns the manifest recorded for stack by the last real
+// deployment, or nil if none was recorded.
+func (s Store) LoadBaseline(stack string) (*StackManifest, error) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, BaselineDirName, stack+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline for %s: %w", stack, err)
+	}
+	return ParseManifest(stack, data)
+}
+
+// SaveBaseline records manifests as the last real deployment.
+func (s Store) SaveBaseline(manifests []*StackManifest) error {
+	dir := filepath.Join(s.Dir, BaselineDirName)
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return fmt.Errorf("creating baseline directory: %w", err)
+	}
+	for _, m := range manifests {
+		if err := writeManifest(dir, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRun writes every rendered manifest and the Markdown report into a new
+// timestamped directory and returns the report path.
+func (s Store) WriteRun(r *Report) (string, error) {
+	dir := filepath.Join(s.Dir, r.GeneratedAt.UTC().Format("20060102T150405Z"))
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return "", fmt.Errorf("creating report directory: %w", err)
+	}
+	for _, sr := range r.Stacks {
+		if err := writeManifest(dir, sr.Manifest); err != nil {
+			return "", err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, r); err != nil {
+		return "", fmt.Errorf("rendering report: %w", err)
+	}
+	path := filepath.Join(dir, ReportFilename)
+	if err := os.WriteFile(path, buf.Bytes(), filePerm); err != nil {
+		return "", fmt.Errorf("writing report: %w", err)
+	}
+	return path, nil
+}
+
+// BuildReport diffs each manifest against its stored baseline.
+func (s Store) BuildReport(now time.Time, manifests []*StackManifest, renderErrors map[string]string) (*Report, error) {
+	r := &Report{GeneratedAt: now, RenderErrors: renderErrors}
+	for _, m := range manifests {
+		prev, err := s.LoadBaseline(m.Stack)
+		if err != nil {
+			return nil, err
+		}
+		r.Stacks = append(r.Stacks, StackReport{
+			Manifest:    m,
+			HasBaseline: prev != nil,
+			Baseline:    prev,
+			Changes:     Diff(prev, m),
+		})
+	}
+	return r, nil
+}
+
+func writeManifest(dir string, m *StackManifest) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, m.Rendered, "", "  "); err != nil {
+		return fmt.Errorf("formatting manifest for %s: %w", m.Stack, err)
+	}
+	buf.WriteByte('\n')
+	if err := os.WriteFile(filepath.Join(dir, m.Stack+".json"), buf.Bytes(), filePerm); err != nil {
+		return fmt.Errorf("writing manifest for %s: %w", m.Stack, err)
+	}
+	return nil
+}
@@ -0,0 +1,418 @@
+// Package gitops turns rendered stack configurations into a directory a
+// GitOps agent can reconcile from, so the stacks altctl deploys
+// imperatively can be handed to a pull-based workflow without rewriting
+// the compose files.
+//
+// The layout is one app per stack plus a root that includes them all:
+//
+//	<dir>/compose.yaml                include: every app, dependencies first
+//	<dir>/apps/<stack>/compose.yaml   the stack's services, fully rendered
+//	<dir>/apps/<stack>/app.yaml       stack metadata, images and variables
+//	<dir>/apps/<stack>/.env.example   variables to supply at deploy time
+//
+// Rendered configurations contain interpolated .env values, so secrets
+// are replaced by ${VAR} references before anything is written, and the
+// project root is replaced by ${ALT_ROOT} so build contexts and bind
+// mounts do not pin the exporting host's checkout path.
+package gitops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// RootVar replaces the project root in every exported path.
+	RootVar = "ALT_ROOT"
+
+	appsDir      = "apps"
+	composeFile  = "compose.yaml"
+	metadataFile = "app.yaml"
+	envFile      = ".env.example"
+
+	header = "# Generated by 'altctl export --gitops'. Do not edit; re-export instead.\n"
+)
+
+// secretKeyRe matches environment and build-arg names whose values are
+// credentials. *_FILE variables hold paths to secret files, not secrets.
+var secretKeyRe = regexp.MustCompile(`(?i)(PASSWORD|PASSWD|SECRET|TOKEN|API_?KEY|PRIVATE_KEY|CREDENTIAL|DSN|DATABASE_URL)`)
+
+// topLevelKinds are the top-level resources services refer to by name.
+var topLevelKinds = []string{"networks", "volumes", "secrets", "configs"}
+
+// Source is one stack's input to the export.
+type Source struct {
+	Stack     string
+	DependsOn []string
+	// Declared lists the services the stack's own compose file defines,
+	// as opposed to those it pulls in through include.
+	Declared []string
+	// Rendered is 'docker compose config --format json' output.
+	Rendered []byte
+}
+
+// App is one exported stack.
+type App struct {
+	Stack     string   `yaml:"stack"`
+	DependsOn []string `yaml:"depends_on,omitempty"`
+	Services  []string `yaml:"services"`
+	Images    []string `yaml:"images,omitempty"`
+	// Variables must be set in the app's environment when it is deployed.
+	Variables []string `yaml:"variables"`
+
+	compose map[string]any
+}
+
+// Build splits the rendered sources into apps. Every service is exported
+// exactly once: by the stack whose compose file declares it, or, for
+// services only reached through include (such as the pki sidecars), by
+// the first stack in sources that renders it. Stacks left without
+// services (such as base, which only defines shared resources) produce no
+// app. sources must be in dependency order.
+func Build(sources []Source, projectRoot string) ([]*App, error) {
+	owner := make(map[string]string)
+	for _, src := range sources {
+		for _, svc := range src.Declared {
+			if prev, ok := owner[svc]; ok && prev != src.Stack {
+				return nil, fmt.Errorf("service %s is declared by both %s and %s; export them separately", svc, prev, src.Stack)
+			}
+			owner[svc] = src.Stack
+		}
+	}
+
+	docs := make([]map[string]any, len(sources))
+	for i, src := range sources {
+		dec := json.NewDecoder(bytes.NewReader(src.Rendered))
+		dec.UseNumber()
+		if err := dec.Decode(&docs[i]); err != nil {
+			return nil, fmt.Errorf("parsing rendered config of %s: %w", src.Stack, err)
+		}
+		normalizeNumbers(docs[i])
+		for _, svc := range sortedKeys(asMap(docs[i]["services"])) {
+			if _, ok := owner[svc]; !ok {
+				owner[svc] = src.Stack
+			}
+		}
+	}
+
+	apps := make([]*App, 0, len(sources))
+	for i, src := range sources {
+		if app := buildApp(src, docs[i], owner, projectRoot); len(app.Services) > 0 {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
+
+func buildApp(src Source, doc map[string]any, owner map[string]string, projectRoot string) *App {
+	app := &App{Stack: src.Stack, DependsOn: src.DependsOn, Services: []string{}, Variables: []string{}}
+
+	services := make(map[string]any)
+	for name, def := range asMap(doc["services"]) {
+		if owner[name] == src.Stack {
+			services[name] = def
+			app.Services = append(app.Services, name)
+		}
+	}
+	slices.Sort(app.Services)
+
+	vars := redactSecrets(services)
+	for _, name := range app.Services {
+		svc := asMap(services[name])
+		// A dependency exported by another app may not be running when
+		// this app is deployed on its own; Compose then warns instead of
+		// refusing to start.
+		for dep, cond := range asMap(svc["depends_on"]) {
+			if owner[dep] != src.Stack {
+				if c := asMap(cond); c != nil {
+					c["required"] = false
+				}
+			}
+		}
+		if image, ok := svc["image"].(string); ok && !slices.Contains(app.Images, image) {
+			app.Images = append(app.Images, image)
+		}
+	}
+	slices.Sort(app.Images)
+
+	compose := map[string]any{"services": services}
+	if name, ok := doc["name"]; ok {
+		compose["name"] = name
+	}
+	for _, kind := range topLevelKinds {
+		if kept := referenced(services, kind, asMap(doc[kind])); len(kept) > 0 {
+			compose[kind] = kept
+		}
+	}
+
+	if projectRoot != "" && rewriteRoot(compose, filepath.Clean(projectRoot)) {
+		vars = append(vars, RootVar)
+	}
+	slices.Sort(vars)
+	app.Variables = slices.Compact(append(app.Variables, vars...))
+	app.compose = compose
+	return app
+}
+
+// redactSecrets replaces credential values in environment and build args
+// with ${VAR} references and returns the variable names. A name whose
+// value differs between services gets a per-service variable so the
+// services do not end up sharing one credential.
+func redactSecrets(services map[string]any) []string {
+	type site struct {
+		m       map[string]any
+		service string
+	}
+	values := make(map[string]map[string]bool)
+	sites := make(map[string][]site)
+	for name, def := range services {
+		svc := asMap(def)
+		for _, m := range []map[string]any{asMap(svc["environment"]), asMap(asMap(svc["build"])["args"])} {
+			for key, v := range m {
+				s, ok := v.(string)
+				if !ok || s == "" || strings.HasSuffix(strings.ToUpper(key), "_FILE") || !secretKeyRe.MatchString(key) {
+					continue
+				}
+				if values[key] == nil {
+					values[key] = make(map[string]bool)
+				}
+				values[key][s] = true
+				sites[key] = append(sites[key], site{m, name})
+			}
+		}
+	}
+
+	var vars []string
+	for key, ss := range sites {
+		for _, st := range ss {
+			v := key
+			if len(values[key]) > 1 {
+				v = envName(st.service) + "_" + key
+			}
+			st.m[key] = "${" + v + "}"
+			vars = append(vars, v)
+		}
+	}
+	return vars
+}
+
+// referenced keeps the top-level resources of kind that services use.
+func referenced(services map[string]any, kind string, defs map[string]any) map[string]any {
+	used := make(map[string]bool)
+	for _, def := range services {
+		switch refs := asMap(def)[kind].(type) {
+		case map[string]any: // networks: {name: {...}}
+			for name := range refs {
+				used[name] = true
+			}
+		case []any: // volumes, secrets, configs: [{source: name, ...}]
+			for _, ref := range refs {
+				r := asMap(ref)
+				if src, ok := r["source"].(string); ok && (kind != "volumes" || r["type"] == "volume") {
+					used[src] = true
+				}
+			}
+		}
+	}
+	kept := make(map[string]any)
+	for name, def := range defs {
+		if used[name] {
+			kept[name] = def
+		}
+	}
+	return kept
+}
+
+// rewriteRoot replaces root at the start of every string in v with
+// ${ALT_ROOT} and reports whether anything changed.
+func rewriteRoot(v any, root string) bool {
+	changed := false
+	rewrite := func(s string) (string, bool) {
+		if s == root || strings.HasPrefix(s, root+string(filepath.Separator)) {
+			return "${" + RootVar + "}" + strings.TrimPrefix(s, root), true
+		}
+		return s, false
+	}
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if s, ok := e.(string); ok {
+				if r, ok := rewrite(s); ok {
+					t[k], changed = r, true
+				}
+			} else if rewriteRoot(e, root) {
+				changed = true
+			}
+		}
+	case []any:
+		for i, e := range t {
+			if s, ok := e.(string); ok {
+				if r, ok := rewrite(s); ok {
+					t[i], changed = r, true
+				}
+			} else if rewriteRoot(e, root) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// normalizeNumbers turns the json.Numbers in v into int64 or float64, so
+// byte counts stay exact and YAML emits numbers rather than strings.
+func normalizeNumbers(v any) {
+	convert := func(n json.Number) any {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		f, _ := n.Float64()
+		return f
+	}
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			if n, ok := e.(json.Number); ok {
+				t[k] = convert(n)
+			} else {
+				normalizeNumbers(e)
+			}
+		}
+	case []any:
+		for i, e := range t {
+			if n, ok := e.(json.Number); ok {
+				t[i] = convert(n)
+			} else {
+				normalizeNumbers(e)
+			}
+		}
+	}
+}
+
+// Write replaces the export under dir with apps. App directories left over
+// from a previous export whose stack is no longer exported are removed;
+// nothing else under dir is touched.
+func Write(dir string, apps []*App, projectRoot string) error {
+	root := filepath.Join(dir, appsDir)
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", root, err)
+	}
+
+	include := make([]string, 0, len(apps))
+	exported := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		appDir := filepath.Join(root, app.Stack)
+		if err := os.MkdirAll(appDir, 0o755); err != nil {
+			return fmt.Errorf("creating %s: %w", appDir, err)
+		}
+		if err := writeYAML(filepath.Join(appDir, composeFile), app.compose); err != nil {
+			return err
+		}
+		if err := writeYAML(filepath.Join(appDir, metadataFile), app); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(appDir, envFile), []byte(envExample(app, projectRoot))); err != nil {
+			return err
+		}
+		include = append(include, filepath.ToSlash(filepath.Join(appsDir, app.Stack, composeFile)))
+		exported[app.Stack] = true
+	}
+
+	if err := pruneApps(root, exported); err != nil {
+		return err
+	}
+	return writeYAML(filepath.Join(dir, composeFile), map[string]any{"include": include})
+}
+
+func pruneApps(root string, exported map[string]bool) error {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", root, err)
+	}
+	for _, e := range entries {
+		if !e.IsDir() || exported[e.Name()] {
+			continue
+		}
+		// Only directories this package wrote carry app.yaml.
+		if _, err := os.Stat(filepath.Join(root, e.Name(), metadataFile)); err != nil {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, e.Name())); err != nil {
+			return fmt.Errorf("removing stale app %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
+
+func envExample(app *App, projectRoot string) string {
+	var b strings.Builder
+	b.WriteString("# Variables the " + app.Stack + " app expects at deploy time.\n")
+	b.WriteString("# Values were redacted from the export; supply them from your secret store.\n")
+	for _, v := range app.Variables {
+		value := ""
+		if v == RootVar {
+			value = filepath.Clean(projectRoot)
+			b.WriteString("# Checkout of the Alt repository (build contexts, bind mounts, secret files).\n")
+		}
+		b.WriteString(v + "=" + value + "\n")
+	}
+	return b.String()
+}
+
+func writeYAML(path string, v any) error {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encoding %s: %w", path, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding %s: %w", path, err)
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// DeclaredServices returns the service names a compose file defines
+// itself, ignoring anything it includes.
+func DeclaredServices(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Services map[string]yaml.Node `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return sortedKeys(doc.Services), nil
+}
+
+func envName(service string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(service))
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
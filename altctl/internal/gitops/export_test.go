@@ -0,0 +1,249 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const root = "/srv/alt"
+
+var (
+	baseRendered = `{"name":"alt","services":{},"networks":{"alt-network":{"name":"alt_alt-network"}}}`
+
+	dbRendered = `{
+  "name": "alt",
+  "services": {
+    "db": {
+      "image": "postgres:17",
+      "environment": {"POSTGRES_PASSWORD": "hunter2", "POSTGRES_PASSWORD_FILE": "/run/secrets/pw", "POSTGRES_DB": "alt"},
+      "networks": {"alt-network": null},
+      "volumes": [{"type": "volume", "source": "db_data", "target": "/var/lib/postgresql/data"}],
+      "deploy": {"resources": {"limits": {"memory": 1073741824}}}
+    }
+  },
+  "networks": {"alt-network": {"name": "alt_alt-network"}, "unused": {}},
+  "volumes": {"db_data": {}, "orphan": {}}
+}`
+
+	coreRendered = `{
+  "name": "alt",
+  "services": {
+    "db": {"image": "postgres:17", "environment": {"POSTGRES_PASSWORD": "hunter2"}},
+    "backend": {
+      "image": "alt-backend:latest",
+      "build": {"context": "/srv/alt/alt-backend", "args": {"API_KEY": "abc"}},
+      "environment": {"DB_PASSWORD": "hunter2", "LOG_LEVEL": "info"},
+      "depends_on": {"db": {"condition": "service_healthy", "required": true}, "backend-pki": {"condition": "service_started", "required": true}},
+      "volumes": [{"type": "bind", "source": "/srv/alt/config", "target": "/config"}]
+    },
+    "backend-pki": {"image": "step-ca:latest", "environment": {"DB_PASSWORD": "other"}}
+  }
+}`
+)
+
+func testSources() []Source {
+	return []Source{
+		{Stack: "base", Declared: nil, Rendered: []byte(baseRendered)},
+		{Stack: "db", DependsOn: []string{"base"}, Declared: []string{"db"}, Rendered: []byte(dbRendered)},
+		{Stack: "core", DependsOn: []string{"base", "db"}, Declared: []string{"backend"}, Rendered: []byte(coreRendered)},
+	}
+}
+
+func appByStack(t *testing.T, apps []*App, name string) *App {
+	t.Helper()
+	for _, app := range apps {
+		if app.Stack == name {
+			return app
+		}
+	}
+	t.Fatalf("no app for stack %s", name)
+	return nil
+}
+
+func TestBuildOwnership(t *testing.T) {
+	apps, err := Build(testSources(), root)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("got %d apps, want 2 (base has no services)", len(apps))
+	}
+
+	db := appByStack(t, apps, "db")
+	if !slices.Equal(db.Services, []string{"db"}) {
+		t.Errorf("db services = %v", db.Services)
+	}
+	// backend-pki is only reached through include, so core owns it.
+	core := appByStack(t, apps, "core")
+	if !slices.Equal(core.Services, []string{"backend", "backend-pki"}) {
+		t.Errorf("core services = %v", core.Services)
+	}
+	if !slices.Equal(core.Images, []string{"alt-backend:latest", "step-ca:latest"}) {
+		t.Errorf("core images = %v", core.Images)
+	}
+}
+
+func TestBuildRejectsDuplicateDeclarations(t *testing.T) {
+	sources := testSources()
+	sources[2].Declared = []string{"backend", "db"}
+	if _, err := Build(sources, root); err == nil || !strings.Contains(err.Error(), "declared by both db and core") {
+		t.Fatalf("Build() error = %v, want duplicate declaration error", err)
+	}
+}
+
+func TestBuildRedactsSecrets(t *testing.T) {
+	apps, err := Build(testSources(), root)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	db := appByStack(t, apps, "db")
+	env := asMap(asMap(asMap(db.compose["services"])["db"])["environment"])
+	if env["POSTGRES_PASSWORD"] != "${POSTGRES_PASSWORD}" {
+		t.Errorf("POSTGRES_PASSWORD = %v", env["POSTGRES_PASSWORD"])
+	}
+	if env["POSTGRES_PASSWORD_FILE"] != "/run/secrets/pw" {
+		t.Errorf("POSTGRES_PASSWORD_FILE = %v, want path kept", env["POSTGRES_PASSWORD_FILE"])
+	}
+	if env["POSTGRES_DB"] != "alt" {
+		t.Errorf("POSTGRES_DB = %v, want value kept", env["POSTGRES_DB"])
+	}
+
+	// DB_PASSWORD differs between backend and backend-pki, so each gets its own variable.
+	core := appByStack(t, apps, "core")
+	want := []string{RootVar, "API_KEY", "BACKEND_DB_PASSWORD", "BACKEND_PKI_DB_PASSWORD"}
+	if !slices.Equal(core.Variables, want) {
+		t.Errorf("core variables = %v, want %v", core.Variables, want)
+	}
+	backend := asMap(asMap(core.compose["services"])["backend"])
+	if got := asMap(backend["environment"])["DB_PASSWORD"]; got != "${BACKEND_DB_PASSWORD}" {
+		t.Errorf("backend DB_PASSWORD = %v", got)
+	}
+	if got := asMap(asMap(backend["build"])["args"])["API_KEY"]; got != "${API_KEY}" {
+		t.Errorf("backend API_KEY = %v", got)
+	}
+}
+
+func TestBuildCrossAppDependencies(t *testing.T) {
+	apps, err := Build(testSources(), root)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	backend := asMap(asMap(appByStack(t, apps, "core").compose["services"])["backend"])
+	deps := asMap(backend["depends_on"])
+	if got := asMap(deps["db"])["required"]; got != false {
+		t.Errorf("depends_on db required = %v, want false", got)
+	}
+	if got := asMap(deps["backend-pki"])["required"]; got != true {
+		t.Errorf("depends_on backend-pki required = %v, want true", got)
+	}
+}
+
+func TestBuildRewritesRootAndPrunesResources(t *testing.T) {
+	apps, err := Build(testSources(), root)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	backend := asMap(asMap(appByStack(t, apps, "core").compose["services"])["backend"])
+	if got := asMap(backend["build"])["context"]; got != "${ALT_ROOT}/alt-backend" {
+		t.Errorf("build context = %v", got)
+	}
+	if got := asMap(backend["volumes"].([]any)[0])["source"]; got != "${ALT_ROOT}/config" {
+		t.Errorf("bind source = %v", got)
+	}
+
+	db := appByStack(t, apps, "db")
+	if got := sortedKeys(asMap(db.compose["networks"])); !slices.Equal(got, []string{"alt-network"}) {
+		t.Errorf("db networks = %v", got)
+	}
+	if got := sortedKeys(asMap(db.compose["volumes"])); !slices.Equal(got, []string{"db_data"}) {
+		t.Errorf("db volumes = %v", got)
+	}
+	if slices.Contains(db.Variables, RootVar) {
+		t.Errorf("db variables = %v, want no %s", db.Variables, RootVar)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, appsDir, "old")
+	if err := os.MkdirAll(stale, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(stale, metadataFile), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	unrelated := filepath.Join(dir, appsDir, "handwritten")
+	if err := os.MkdirAll(unrelated, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	apps, err := Build(testSources(), root)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if err := Write(dir, apps, root); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale app not removed: %v", err)
+	}
+	if _, err := os.Stat(unrelated); err != nil {
+		t.Errorf("directory without %s was removed: %v", metadataFile, err)
+	}
+
+	var top struct {
+		Include []string `yaml:"include"`
+	}
+	data, err := os.ReadFile(filepath.Join(dir, composeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(data, &top); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"apps/db/compose.yaml", "apps/core/compose.yaml"}; !slices.Equal(top.Include, want) {
+		t.Errorf("include = %v, want %v", top.Include, want)
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, appsDir, "db", composeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "memory: 1073741824") {
+		t.Errorf("db compose.yaml lost numeric memory limit:\n%s", data)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("db compose.yaml leaks a secret:\n%s", data)
+	}
+
+	env, err := os.ReadFile(filepath.Join(dir, appsDir, "core", envFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(env), RootVar+"="+root+"\n") || !strings.Contains(string(env), "API_KEY=\n") {
+		t.Errorf(".env.example = %q", env)
+	}
+}
+
+func TestDeclaredServices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "core.yaml")
+	content := "include:\n  - base.yaml\nservices:\n  web:\n    image: nginx\n  api:\n    image: api\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DeclaredServices(path)
+	if err != nil {
+		t.Fatalf("DeclaredServices() error = %v", err)
+	}
+	if !slices.Equal(got, []string{"api", "web"}) {
+		t.Errorf("DeclaredServices() = %v", got)
+	}
+}
@@ -0,0 +1,140 @@
+// Package integrity runs read-only data integrity probes against the
+// database services of a deployment: replication and WAL retention,
+// table read spot checks for PostgreSQL, and index health for Meilisearch.
+package integrity
+
+import (
+	"context"
+	"time"
+)
+
+// Status grades one probe result against its thresholds.
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusWarning  Status = "warning"
+	StatusCritical Status = "critical"
+)
+
+func (s Status) rank() int {
+	switch s {
+	case StatusCritical:
+		return 2
+	case StatusWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Result is the outcome of one check against one service.
+type Result struct {
+	Service string `json:"service"`
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Summary string `json:"summary"`
+}
+
+// Worst returns the most severe status among results, StatusOK if empty.
+func Worst(results []Result) Status {
+	worst := StatusOK
+	for _, r := range results {
+		if r.Status.rank() > worst.rank() {
+			worst = r.Status
+		}
+	}
+	return worst
+}
+
+// Kind selects the probes run against a target.
+type Kind string
+
+const (
+	KindPostgres    Kind = "postgres"
+	KindMeilisearch Kind = "meilisearch"
+)
+
+// Target is a running compose service to probe.
+type Target struct {
+	Kind    Kind
+	Service string
+
+	// Database and User are the PostgreSQL connection used by psql inside
+	// the container.
+	Database string
+	User     string
+
+	// KeyFile is the path of the Meilisearch master key inside the
+	// container.
+	KeyFile string
+}
+
+// Thresholds decides when a measurement is a warning or critical. A zero
+// threshold disables that level.
+type Thresholds struct {
+	// ReplicationLagWarningBytes and ReplicationLagCriticalBytes bound how
+	// far the slowest standby's replay lags behind the primary's WAL.
+	ReplicationLagWarningBytes  int64
+	ReplicationLagCriticalBytes int64
+	// WALRetainedWarningBytes and WALRetainedCriticalBytes bound the WAL
+	// a replication slot keeps on disk; a forgotten slot fills the volume.
+	WALRetainedWarningBytes  int64
+	WALRetainedCriticalBytes int64
+
+	// SampleTables is how many user tables the spot check reads in full,
+	// picked at random among those no larger than SampleMaxTableBytes.
+	SampleTables        int
+	SampleMaxTableBytes int64
+
+	// FailedTasksWarning and FailedTasksCritical bound the Meilisearch
+	// tasks that failed since the deploy started.
+	FailedTasksWarning  int
+	FailedTasksCritical int
+}
+
+// grade compares value against a warning and a critical threshold.
+func grade(value, warning, critical int64) Status {
+	switch {
+	case critical > 0 && value >= critical:
+		return StatusCritical
+	case warning > 0 && value >= warning:
+		return StatusWarning
+	default:
+		return StatusOK
+	}
+}
+
+// Execer runs a command inside a running compose service and returns its
+// standard output. The error should carry standard error.
+type Execer interface {
+	Exec(ctx context.Context, service string, command []string) ([]byte, error)
+}
+
+// Prober runs the probes for each target.
+type Prober struct {
+	exec       Execer
+	thresholds Thresholds
+	// since is when the deploy started; only Meilisearch tasks enqueued
+	// after it count as failures of this deploy.
+	since time.Time
+}
+
+// NewProber returns a Prober that reaches services through exec.
+func NewProber(exec Execer, thresholds Thresholds, since time.Time) *Prober {
+	return &Prober{exec: exec, thresholds: thresholds, since: since}
+}
+
+// Probe runs every check for t. Probes never fail as a whole: a check that
+// cannot run is reported as a critical result, since an unreachable
+// database right after a deploy is itself a finding.
+func (p *Prober) Probe(ctx context.Context, t Target) []Result {
+	switch t.Kind {
+	case KindPostgres:
+		return p.probePostgres(ctx, t)
+	case KindMeilisearch:
+		return p.probeMeilisearch(ctx, t)
+	default:
+		return []Result{{Service: t.Service, Check: "probe", Status: StatusCritical, Summary: "unknown target kind " + string(t.Kind)}}
+	}
+}
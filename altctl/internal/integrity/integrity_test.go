@@ -0,0 +1,206 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeExec answers each command with the first reply whose key is a
+// substring of the joined command line.
+type fakeExec struct {
+	replies []reply
+	calls   [][]string
+}
+
+type reply struct {
+	match string
+	out   string
+	err   error
+}
+
+func (f *fakeExec) Exec(_ context.Context, _ string, command []string) ([]byte, error) {
+	f.calls = append(f.calls, command)
+	line := strings.Join(command, " ")
+	for _, r := range f.replies {
+		if strings.Contains(line, r.match) {
+			return []byte(r.out), r.err
+		}
+	}
+	return nil, errors.New("unexpected command: " + line)
+}
+
+var testThresholds = Thresholds{
+	ReplicationLagWarningBytes:  64 << 20,
+	ReplicationLagCriticalBytes: 512 << 20,
+	WALRetainedWarningBytes:     1 << 30,
+	WALRetainedCriticalBytes:    4 << 30,
+	SampleTables:                2,
+	SampleMaxTableBytes:         256 << 20,
+	FailedTasksWarning:          1,
+	FailedTasksCritical:         10,
+}
+
+var pgTarget = Target{Kind: KindPostgres, Service: "db", Database: "alt", User: "alt_db_user"}
+
+func statuses(results []Result) map[string]Status {
+	m := make(map[string]Status, len(results))
+	for _, r := range results {
+		m[r.Check] = r.Status
+	}
+	return m
+}
+
+func TestProbePostgres(t *testing.T) {
+	tests := []struct {
+		name    string
+		replies []reply
+		want    map[string]Status
+	}{
+		{
+			name: "healthy primary without standbys",
+			replies: []reply{
+				{match: "pg_is_in_recovery", out: "f|0|0|0|0|0|on|0\n"},
+				{match: "pg_stat_user_tables", out: "public.feeds\npublic.articles\n"},
+				{match: "count(*) FROM public.feeds", out: "12\n30\n0\n"},
+			},
+			want: map[string]Status{"replication": StatusOK, "wal": StatusOK, "checksums": StatusOK},
+		},
+		{
+			name: "lagging standby and bloated slot",
+			replies: []reply{
+				{match: "pg_is_in_recovery", out: "f|1|104857600|2|1|5368709120|on|0\n"},
+				{match: "pg_stat_user_tables", out: ""},
+				{match: "checksum_failures", out: "0\n"},
+			},
+			want: map[string]Status{"replication": StatusWarning, "wal": StatusCritical, "checksums": StatusOK},
+		},
+		{
+			name: "new checksum failures while reading",
+			replies: []reply{
+				{match: "pg_is_in_recovery", out: "f|0|0|0|0|0|on|1\n"},
+				{match: "pg_stat_user_tables", out: "public.feeds\n"},
+				{match: "count(*) FROM public.feeds", out: "12\n3\n"},
+			},
+			want: map[string]Status{"checksums": StatusCritical},
+		},
+		{
+			name: "old checksum failures",
+			replies: []reply{
+				{match: "pg_is_in_recovery", out: "f|0|0|0|0|0|on|1\n"},
+				{match: "pg_stat_user_tables", out: "public.feeds\n"},
+				{match: "count(*) FROM public.feeds", out: "12\n1\n"},
+			},
+			want: map[string]Status{"checksums": StatusWarning},
+		},
+		{
+			name: "unreadable table",
+			replies: []reply{
+				{match: "pg_is_in_recovery", out: "f|0|0|0|0|0|off|0\n"},
+				{match: "pg_stat_user_tables", out: "public.feeds\n"},
+				{match: "count(*) FROM public.feeds", err: errors.New("ERROR: could not read block 3")},
+			},
+			want: map[string]Status{"checksums": StatusCritical},
+		},
+		{
+			name:    "unreachable",
+			replies: []reply{{match: "psql", err: errors.New("service \"db\" is not running")}},
+			want:    map[string]Status{"connection": StatusCritical},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProber(&fakeExec{replies: tt.replies}, testThresholds, time.Time{})
+			got := statuses(p.Probe(context.Background(), pgTarget))
+			for check, want := range tt.want {
+				if got[check] != want {
+					t.Errorf("%s = %q, want %q (all: %v)", check, got[check], want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestProbePostgres_SampleDisabled(t *testing.T) {
+	th := testThresholds
+	th.SampleTables = 0
+	exec := &fakeExec{replies: []reply{
+		{match: "pg_is_in_recovery", out: "t|0|0|0|0|0|on|0\n"},
+		{match: "checksum_failures", out: "0\n"},
+	}}
+
+	results := NewProber(exec, th, time.Time{}).Probe(context.Background(), pgTarget)
+	if w := Worst(results); w != StatusOK {
+		t.Errorf("Worst = %q, want ok: %+v", w, results)
+	}
+	for _, c := range exec.calls {
+		if strings.Contains(strings.Join(c, " "), "pg_stat_user_tables") {
+			t.Errorf("sampled tables with SampleTables = 0: %v", c)
+		}
+	}
+}
+
+func TestProbeMeilisearch(t *testing.T) {
+	since := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		replies []reply
+		want    map[string]Status
+	}{
+		{
+			name: "healthy",
+			replies: []reply{
+				{match: "/health", out: `{"status":"available"}`},
+				{match: "/stats", out: `{"indexes":{"articles":{"numberOfDocuments":120,"isIndexing":false}}}`},
+				{match: "/tasks", out: `{"results":[],"total":0}`},
+			},
+			want: map[string]Status{"health": StatusOK, "indexes": StatusOK, "tasks": StatusOK},
+		},
+		{
+			name: "empty index and failed tasks",
+			replies: []reply{
+				{match: "/health", out: `{"status":"available"}`},
+				{match: "/stats", out: `{"indexes":{"articles":{"numberOfDocuments":0},"tags":{"numberOfDocuments":4}}}`},
+				{match: "/tasks", out: `{"results":[{"indexUid":"articles","error":{"message":"disk full"}}],"total":12}`},
+			},
+			want: map[string]Status{"indexes": StatusWarning, "tasks": StatusCritical},
+		},
+		{
+			name:    "down",
+			replies: []reply{{match: "/health", err: errors.New("curl: (7) Failed to connect")}},
+			want:    map[string]Status{"health": StatusCritical},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &fakeExec{replies: tt.replies}
+			target := Target{Kind: KindMeilisearch, Service: "meilisearch", KeyFile: "/run/secrets/meili_master_key"}
+			got := statuses(NewProber(exec, testThresholds, since).Probe(context.Background(), target))
+			for check, want := range tt.want {
+				if got[check] != want {
+					t.Errorf("%s = %q, want %q (all: %v)", check, got[check], want, got)
+				}
+			}
+			for _, c := range exec.calls {
+				line := strings.Join(c, " ")
+				if strings.Contains(line, "/tasks") && !strings.Contains(line, "afterEnqueuedAt=2026-10-16T09%3A00%3A00Z") {
+					t.Errorf("tasks query not bounded by deploy start: %s", line)
+				}
+			}
+		})
+	}
+}
+
+func TestWorst(t *testing.T) {
+	results := []Result{{Status: StatusOK}, {Status: StatusCritical}, {Status: StatusWarning}}
+	if w := Worst(results); w != StatusCritical {
+		t.Errorf("Worst = %q, want critical", w)
+	}
+	if w := Worst(nil); w != StatusOK {
+		t.Errorf("Worst(nil) = %q, want ok", w)
+	}
+}
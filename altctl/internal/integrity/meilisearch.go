@@ -0,0 +1,131 @@
+package integrity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// meilisearchURL is where Meilisearch listens inside its own container.
+const meilisearchURL = "http://localhost:7700"
+
+type meiliHealth struct {
+	Status string `json:"status"`
+}
+
+type meiliStats struct {
+	Indexes map[string]struct {
+		NumberOfDocuments int64 `json:"numberOfDocuments"`
+		IsIndexing        bool  `json:"isIndexing"`
+	} `json:"indexes"`
+}
+
+type meiliTasks struct {
+	Total   int `json:"total"`
+	Results []struct {
+		IndexUID string `json:"indexUid"`
+		Error    struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"results"`
+}
+
+func (p *Prober) probeMeilisearch(ctx context.Context, t Target) []Result {
+	var health meiliHealth
+	if err := p.meiliGet(ctx, t, "/health", &health); err != nil {
+		return []Result{failed(t.Service, "health", err)}
+	}
+	if health.Status != "available" {
+		return []Result{{Service: t.Service, Check: "health", Status: StatusCritical, Summary: "status " + health.Status}}
+	}
+
+	return []Result{
+		{Service: t.Service, Check: "health", Status: StatusOK, Summary: "available"},
+		p.indexesResult(ctx, t),
+		p.failedTasksResult(ctx, t),
+	}
+}
+
+// indexesResult warns about empty indexes: after a deploy they usually mean
+// the volume was not mounted or a reindex has not run.
+func (p *Prober) indexesResult(ctx context.Context, t Target) Result {
+	var stats meiliStats
+	if err := p.meiliGet(ctx, t, "/stats", &stats); err != nil {
+		return failed(t.Service, "indexes", err)
+	}
+
+	r := Result{Service: t.Service, Check: "indexes", Status: StatusOK}
+	var empty, indexing []string
+	var docs int64
+	for uid, idx := range stats.Indexes {
+		docs += idx.NumberOfDocuments
+		if idx.NumberOfDocuments == 0 {
+			empty = append(empty, uid)
+		}
+		if idx.IsIndexing {
+			indexing = append(indexing, uid)
+		}
+	}
+	sort.Strings(empty)
+	sort.Strings(indexing)
+
+	switch {
+	case len(stats.Indexes) == 0:
+		r.Status = StatusWarning
+		r.Summary = "no indexes"
+	case len(empty) > 0:
+		r.Status = StatusWarning
+		r.Summary = fmt.Sprintf("%d of %d index(es) empty: %s", len(empty), len(stats.Indexes), strings.Join(empty, ", "))
+	default:
+		r.Summary = fmt.Sprintf("%d index(es), %d documents", len(stats.Indexes), docs)
+	}
+	if len(indexing) > 0 {
+		r.Summary += fmt.Sprintf("; indexing: %s", strings.Join(indexing, ", "))
+	}
+	return r
+}
+
+func (p *Prober) failedTasksResult(ctx context.Context, t Target) Result {
+	query := url.Values{"statuses": {"failed"}, "limit": {"1"}}
+	if !p.since.IsZero() {
+		query.Set("afterEnqueuedAt", p.since.UTC().Format(time.RFC3339))
+	}
+	var tasks meiliTasks
+	if err := p.meiliGet(ctx, t, "/tasks?"+query.Encode(), &tasks); err != nil {
+		return failed(t.Service, "tasks", err)
+	}
+
+	r := Result{
+		Service: t.Service,
+		Check:   "tasks",
+		Status:  grade(int64(tasks.Total), int64(p.thresholds.FailedTasksWarning), int64(p.thresholds.FailedTasksCritical)),
+		Summary: fmt.Sprintf("%d failed task(s) since deploy start", tasks.Total),
+	}
+	if len(tasks.Results) > 0 {
+		last := tasks.Results[0]
+		r.Summary += fmt.Sprintf("; latest on %s: %s", last.IndexUID, last.Error.Message)
+	}
+	return r
+}
+
+// meiliGet fetches path with curl inside the container and decodes the JSON
+// body into v. The master key is read from KeyFile by the container's shell
+// so it never appears in altctl's process arguments.
+func (p *Prober) meiliGet(ctx context.Context, t Target, path string, v any) error {
+	command := []string{"curl", "-sSf", meilisearchURL + path}
+	if t.KeyFile != "" {
+		command = []string{"sh", "-c", `curl -sSf -H "Authorization: Bearer $(cat "$1")" "$2"`, "sh", t.KeyFile, meilisearchURL + path}
+	}
+	out, err := p.exec.Exec(ctx, t.Service, command)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(out, v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
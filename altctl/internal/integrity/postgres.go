@@ -0,0 +1,212 @@
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// postgresStatsQuery reports replication, slot and checksum state in one
+// '|'-separated row. WAL positions are only meaningful on a primary.
+const postgresStatsQuery = `SELECT pg_is_in_recovery(),
+  (SELECT count(*) FROM pg_stat_replication),
+  CASE WHEN pg_is_in_recovery() THEN 0 ELSE COALESCE((SELECT max(pg_wal_lsn_diff(pg_current_wal_lsn(), replay_lsn)) FROM pg_stat_replication), 0)::bigint END,
+  (SELECT count(*) FROM pg_replication_slots),
+  (SELECT count(*) FROM pg_replication_slots WHERE NOT active),
+  CASE WHEN pg_is_in_recovery() THEN 0 ELSE COALESCE((SELECT max(pg_wal_lsn_diff(pg_current_wal_lsn(), restart_lsn)) FROM pg_replication_slots), 0)::bigint END,
+  current_setting('data_checksums'),
+  COALESCE((SELECT checksum_failures FROM pg_stat_database WHERE datname = current_database()), 0)`
+
+// checksumFailuresQuery re-reads the failure counter after the spot check.
+const checksumFailuresQuery = `SELECT COALESCE((SELECT checksum_failures FROM pg_stat_database WHERE datname = current_database()), 0)`
+
+// postgresStats is the parsed postgresStatsQuery row.
+type postgresStats struct {
+	InRecovery       bool
+	Standbys         int
+	ReplayLagBytes   int64
+	Slots            int
+	InactiveSlots    int
+	WALRetainedBytes int64
+	DataChecksums    bool
+	ChecksumFailures int64
+}
+
+func (p *Prober) probePostgres(ctx context.Context, t Target) []Result {
+	out, err := p.exec.Exec(ctx, t.Service, psqlCommand(t, postgresStatsQuery))
+	if err != nil {
+		return []Result{failed(t.Service, "connection", err)}
+	}
+	stats, err := parsePostgresStats(string(out))
+	if err != nil {
+		return []Result{failed(t.Service, "connection", err)}
+	}
+
+	return []Result{
+		p.replicationResult(t, stats),
+		p.walResult(t, stats),
+		p.spotCheck(ctx, t, stats),
+	}
+}
+
+func (p *Prober) replicationResult(t Target, s postgresStats) Result {
+	r := Result{Service: t.Service, Check: "replication", Status: StatusOK}
+	switch {
+	case s.InRecovery:
+		r.Summary = "standby in recovery"
+	case s.Standbys == 0:
+		r.Summary = "no standbys attached"
+	default:
+		r.Status = grade(s.ReplayLagBytes, p.thresholds.ReplicationLagWarningBytes, p.thresholds.ReplicationLagCriticalBytes)
+		r.Summary = fmt.Sprintf("%d standby(s), max replay lag %s", s.Standbys, formatBytes(s.ReplayLagBytes))
+	}
+	return r
+}
+
+func (p *Prober) walResult(t Target, s postgresStats) Result {
+	r := Result{Service: t.Service, Check: "wal", Status: StatusOK}
+	switch {
+	case s.InRecovery:
+		r.Summary = "standby in recovery"
+	case s.Slots == 0:
+		r.Summary = "no replication slots"
+	default:
+		r.Status = grade(s.WALRetainedBytes, p.thresholds.WALRetainedWarningBytes, p.thresholds.WALRetainedCriticalBytes)
+		r.Summary = fmt.Sprintf("%s retained by %d replication slot(s), %d inactive", formatBytes(s.WALRetainedBytes), s.Slots, s.InactiveSlots)
+	}
+	return r
+}
+
+// spotCheck reads a random sample of tables in full. With data checksums
+// enabled every page read is verified, so new checksum failures show up in
+// pg_stat_database; either way a table that cannot be read is critical.
+func (p *Prober) spotCheck(ctx context.Context, t Target, s postgresStats) Result {
+	r := Result{Service: t.Service, Check: "checksums", Status: StatusOK}
+
+	var tables []string
+	if p.thresholds.SampleTables > 0 {
+		out, err := p.exec.Exec(ctx, t.Service, psqlCommand(t, sampleTablesQuery(p.thresholds)))
+		if err != nil {
+			return failed(t.Service, "checksums", err)
+		}
+		tables = nonEmptyLines(string(out))
+	}
+
+	queries := make([]string, 0, len(tables)+1)
+	for _, table := range tables {
+		queries = append(queries, "SELECT count(*) FROM "+table)
+	}
+	queries = append(queries, checksumFailuresQuery)
+	out, err := p.exec.Exec(ctx, t.Service, psqlCommand(t, queries...))
+	if err != nil {
+		r.Status = StatusCritical
+		r.Summary = fmt.Sprintf("reading sampled tables (%s) failed: %v", strings.Join(tables, ", "), err)
+		return r
+	}
+	lines := nonEmptyLines(string(out))
+	if len(lines) != len(queries) {
+		return failed(t.Service, "checksums", fmt.Errorf("expected %d result lines from psql, got %d", len(queries), len(lines)))
+	}
+	var rows int64
+	for _, line := range lines[:len(tables)] {
+		n, _ := strconv.ParseInt(line, 10, 64)
+		rows += n
+	}
+	after, err := strconv.ParseInt(lines[len(lines)-1], 10, 64)
+	if err != nil {
+		return failed(t.Service, "checksums", fmt.Errorf("parsing checksum_failures %q: %w", lines[len(lines)-1], err))
+	}
+
+	switch {
+	case after > s.ChecksumFailures:
+		r.Status = StatusCritical
+		r.Summary = fmt.Sprintf("%d new page checksum failure(s) while reading %s", after-s.ChecksumFailures, strings.Join(tables, ", "))
+	case after > 0:
+		r.Status = StatusWarning
+		r.Summary = fmt.Sprintf("%d page checksum failure(s) recorded for %s", after, t.Database)
+	default:
+		r.Summary = fmt.Sprintf("%d sampled table(s) readable, %d rows", len(tables), rows)
+		if !s.DataChecksums {
+			r.Summary += " (page checksums disabled)"
+		}
+	}
+	return r
+}
+
+// sampleTablesQuery picks random user tables small enough to read in full,
+// already quoted for use in SQL.
+func sampleTablesQuery(th Thresholds) string {
+	query := `SELECT format('%I.%I', schemaname, relname) FROM pg_stat_user_tables`
+	if th.SampleMaxTableBytes > 0 {
+		query += fmt.Sprintf(" WHERE pg_total_relation_size(relid) <= %d", th.SampleMaxTableBytes)
+	}
+	return query + fmt.Sprintf(" ORDER BY random() LIMIT %d", th.SampleTables)
+}
+
+// psqlCommand runs queries unaligned and without headers, one -c each so
+// psql prints every result.
+func psqlCommand(t Target, queries ...string) []string {
+	cmd := []string{"psql", "-X", "-A", "-t", "-F", "|", "-v", "ON_ERROR_STOP=1", "-U", t.User, "-d", t.Database}
+	for _, q := range queries {
+		cmd = append(cmd, "-c", q)
+	}
+	return cmd
+}
+
+func parsePostgresStats(out string) (postgresStats, error) {
+	var s postgresStats
+	lines := nonEmptyLines(out)
+	if len(lines) != 1 {
+		return s, fmt.Errorf("expected one stats row from psql, got %q", strings.TrimSpace(out))
+	}
+	fields := strings.Split(lines[0], "|")
+	if len(fields) != 8 {
+		return s, fmt.Errorf("expected 8 stats columns, got %q", lines[0])
+	}
+
+	var n [8]int64
+	for _, i := range []int{1, 2, 3, 4, 5, 7} {
+		v, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return s, fmt.Errorf("parsing stats column %d %q: %w", i+1, fields[i], err)
+		}
+		n[i] = v
+	}
+	return postgresStats{
+		InRecovery:       fields[0] == "t",
+		Standbys:         int(n[1]),
+		ReplayLagBytes:   n[2],
+		Slots:            int(n[3]),
+		InactiveSlots:    int(n[4]),
+		WALRetainedBytes: n[5],
+		DataChecksums:    fields[6] == "on",
+		ChecksumFailures: n[7],
+	}, nil
+}
+
+func failed(service, check string, err error) Result {
+	return Result{Service: service, Check: check, Status: StatusCritical, Summary: "probe failed: " + err.Error()}
+}
+
+func nonEmptyLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func formatBytes(n int64) string {
+	const mib = 1 << 20
+	switch {
+	case n >= 1<<30:
+		return strconv.FormatFloat(float64(n)/(1<<30), 'f', 1, 64) + " GiB"
+	case n >= mib:
+		return strconv.FormatFloat(float64(n)/mib, 'f', 1, 64) + " MiB"
+	default:
+		return strconv.FormatInt(n, 10) + " B"
+	}
+}
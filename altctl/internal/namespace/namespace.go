@@ -0,0 +1,211 @@
+// Package namespace manages environment namespaces for altctl.
+//
+// Compose has no namespaces of its own, so a namespace is a Compose project
+// name (COMPOSE_PROJECT_NAME) plus the labels and protection annotation
+// altctl records for it. Every container, volume and network Compose
+// creates carries the com.docker.compose.project label, which is how
+// destroy finds the set of resources belonging to a namespace.
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// ProjectLabel is the label Docker Compose puts on every resource it
+	// creates for a project.
+	ProjectLabel = "com.docker.compose.project"
+
+	// EnvironmentLabel records which environment a namespace serves.
+	EnvironmentLabel = "alt.environment"
+
+	// ProtectedAnnotation marks a namespace that destroy refuses to delete
+	// without explicit confirmation. Production namespaces always carry it.
+	ProtectedAnnotation = "alt.protected"
+
+	// ProductionEnvironment is the environment whose namespaces are
+	// protected unconditionally.
+	ProductionEnvironment = "production"
+)
+
+var (
+	// ErrNotFound is returned for a namespace that is not registered.
+	ErrNotFound = errors.New("namespace not found")
+	// ErrExists is returned when creating a namespace that is already registered.
+	ErrExists = errors.New("namespace already exists")
+	// ErrProtected is returned when destroying a protected namespace without
+	// the required confirmation.
+	ErrProtected = errors.New("namespace is protected")
+)
+
+// nameRe mirrors Compose's project name rule: lowercase letters, digits,
+// dashes and underscores, starting with a letter or digit.
+var nameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// labelKeyRe restricts label keys to the characters Docker accepts without
+// quoting surprises.
+var labelKeyRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9._/-]*[a-z0-9])?$`)
+
+// Namespace is one registered environment namespace.
+type Namespace struct {
+	Name        string            `json:"name"`
+	Environment string            `json:"environment"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// New builds a namespace for environment. Production namespaces are
+// annotated as protected regardless of protect.
+func New(name, environment string, labels map[string]string, protect bool, now time.Time) (*Namespace, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	if environment == "" {
+		return nil, fmt.Errorf("environment is required")
+	}
+	for k := range labels {
+		if err := validateLabelKey(k); err != nil {
+			return nil, err
+		}
+	}
+
+	ns := &Namespace{
+		Name:        name,
+		Environment: environment,
+		Labels:      maps.Clone(labels),
+		CreatedAt:   now.UTC(),
+	}
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[EnvironmentLabel] = environment
+	if protect || environment == ProductionEnvironment {
+		ns.Annotations = map[string]string{ProtectedAnnotation: "true"}
+	}
+	return ns, nil
+}
+
+// ValidateName checks name against Compose's project name rules.
+func ValidateName(name string) error {
+	if !nameRe.MatchString(name) {
+		return fmt.Errorf("invalid namespace name %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// Protected reports whether destroy must be confirmed explicitly.
+func (n *Namespace) Protected() bool {
+	return n.Environment == ProductionEnvironment || n.Annotations[ProtectedAnnotation] == "true"
+}
+
+// SetProtected adds or removes the protection annotation. Production
+// namespaces cannot be unprotected.
+func (n *Namespace) SetProtected(protect bool) error {
+	if protect {
+		if n.Annotations == nil {
+			n.Annotations = map[string]string{}
+		}
+		n.Annotations[ProtectedAnnotation] = "true"
+		return nil
+	}
+	if n.Environment == ProductionEnvironment {
+		return fmt.Errorf("%w: production namespaces cannot be unprotected", ErrProtected)
+	}
+	delete(n.Annotations, ProtectedAnnotation)
+	return nil
+}
+
+// ApplyLabels applies kubectl-style label edits: "key=value" sets a label
+// and "key-" removes it. The environment label is managed by altctl and
+// cannot be edited.
+func (n *Namespace) ApplyLabels(edits []string) error {
+	set := map[string]string{}
+	var remove []string
+	for _, edit := range edits {
+		if key, ok := strings.CutSuffix(edit, "-"); ok && !strings.Contains(edit, "=") {
+			remove = append(remove, key)
+			continue
+		}
+		key, value, ok := strings.Cut(edit, "=")
+		if !ok {
+			return fmt.Errorf("invalid label %q: use key=value to set or key- to remove", edit)
+		}
+		set[key] = value
+	}
+
+	for _, key := range slices.Concat(slices.Collect(maps.Keys(set)), remove) {
+		if err := validateLabelKey(key); err != nil {
+			return err
+		}
+		if key == EnvironmentLabel {
+			return fmt.Errorf("label %s is managed by altctl and cannot be edited", EnvironmentLabel)
+		}
+	}
+
+	if n.Labels == nil {
+		n.Labels = map[string]string{}
+	}
+	maps.Copy(n.Labels, set)
+	for _, key := range remove {
+		delete(n.Labels, key)
+	}
+	return nil
+}
+
+// ParseLabels parses "key=value" pairs, as given to --label.
+func ParseLabels(pairs []string) (map[string]string, error) {
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid label %q: use key=value", pair)
+		}
+		if err := validateLabelKey(key); err != nil {
+			return nil, err
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// FormatLabels renders labels as sorted "key=value" pairs.
+func FormatLabels(labels map[string]string) string {
+	keys := slices.Sorted(maps.Keys(labels))
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + labels[k]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// CheckDestroy enforces the protection annotation: a protected namespace
+// is only destroyed when yesIKnow is set and confirmEnvironment names the
+// namespace's environment, so a destroy aimed at staging cannot take out
+// production through a typo in the namespace name.
+func CheckDestroy(n *Namespace, yesIKnow bool, confirmEnvironment string) error {
+	if !n.Protected() {
+		return nil
+	}
+	if !yesIKnow || confirmEnvironment != n.Environment {
+		return fmt.Errorf("%w: %s (environment %s) requires --yes-i-know --environment %s",
+			ErrProtected, n.Name, n.Environment, n.Environment)
+	}
+	return nil
+}
+
+func validateLabelKey(key string) error {
+	if !labelKeyRe.MatchString(key) {
+		return fmt.Errorf("invalid label key %q", key)
+	}
+	if key == ProjectLabel || key == ProtectedAnnotation {
+		return fmt.Errorf("label key %q is reserved", key)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+func TestNew_ProductionIsAlwaysProtected(t *testing.T) {
+	ns, err := New("alt", ProductionEnvironment, nil, false, testNow)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if !ns.Protected() {
+		t.Fatal("production namespace is not protected")
+	}
+	if ns.Labels[EnvironmentLabel] != ProductionEnvironment {
+		t.Fatalf("environment label = %q", ns.Labels[EnvironmentLabel])
+	}
+	if err := ns.SetProtected(false); !errors.Is(err, ErrProtected) {
+		t.Fatalf("SetProtected(false) on production: got %v, want ErrProtected", err)
+	}
+}
+
+func TestNew_RejectsInvalidInput(t *testing.T) {
+	cases := []struct {
+		name, env string
+		labels    map[string]string
+	}{
+		{"Alt-Staging", "staging", nil},
+		{"-alt", "staging", nil},
+		{"alt-staging", "", nil},
+		{"alt-staging", "staging", map[string]string{ProjectLabel: "x"}},
+		{"alt-staging", "staging", map[string]string{"Bad Key": "x"}},
+	}
+	for _, tc := range cases {
+		if _, err := New(tc.name, tc.env, tc.labels, false, testNow); err == nil {
+			t.Errorf("New(%q, %q, %v) succeeded, want error", tc.name, tc.env, tc.labels)
+		}
+	}
+}
+
+func TestApplyLabels(t *testing.T) {
+	ns, err := New("alt-staging", "staging", map[string]string{"team": "core", "tier": "2"}, false, testNow)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := ns.ApplyLabels([]string{"team=search", "tier-", "owner=ops"}); err != nil {
+		t.Fatalf("ApplyLabels: %v", err)
+	}
+	want := map[string]string{EnvironmentLabel: "staging", "team": "search", "owner": "ops"}
+	if !reflect.DeepEqual(ns.Labels, want) {
+		t.Fatalf("labels = %v, want %v", ns.Labels, want)
+	}
+
+	if err := ns.ApplyLabels([]string{EnvironmentLabel + "=production"}); err == nil {
+		t.Fatal("editing the environment label succeeded")
+	}
+	if err := ns.ApplyLabels([]string{"novalue"}); err == nil {
+		t.Fatal("label without '=' or trailing '-' succeeded")
+	}
+}
+
+func TestCheckDestroy(t *testing.T) {
+	staging, _ := New("alt-staging", "staging", nil, false, testNow)
+	prod, _ := New("alt", ProductionEnvironment, nil, false, testNow)
+	protectedStaging, _ := New("alt-staging-2", "staging", nil, true, testNow)
+
+	if err := CheckDestroy(staging, false, ""); err != nil {
+		t.Fatalf("unprotected namespace: %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		yesIKnow bool
+		env      string
+	}{
+		{"no confirmation", false, ""},
+		{"token only", true, ""},
+		{"environment only", false, ProductionEnvironment},
+		{"wrong environment", true, "staging"},
+	} {
+		if err := CheckDestroy(prod, tc.yesIKnow, tc.env); !errors.Is(err, ErrProtected) {
+			t.Errorf("%s: got %v, want ErrProtected", tc.desc, err)
+		}
+	}
+
+	if err := CheckDestroy(prod, true, ProductionEnvironment); err != nil {
+		t.Fatalf("confirmed production destroy: %v", err)
+	}
+	if err := CheckDestroy(protectedStaging, true, "staging"); err != nil {
+		t.Fatalf("confirmed protected staging destroy: %v", err)
+	}
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), ".altctl", "namespaces.json")}
+
+	all, err := store.List()
+	if err != nil || len(all) != 0 {
+		t.Fatalf("empty store: got %v, %v", all, err)
+	}
+
+	staging, _ := New("alt-staging", "staging", nil, false, testNow)
+	prod, _ := New("alt", ProductionEnvironment, nil, false, testNow)
+	for _, ns := range []*Namespace{staging, prod} {
+		if err := store.Create(ns); err != nil {
+			t.Fatalf("Create(%s): %v", ns.Name, err)
+		}
+	}
+	if err := store.Create(staging); !errors.Is(err, ErrExists) {
+		t.Fatalf("duplicate Create: got %v, want ErrExists", err)
+	}
+
+	got, err := store.Get("alt")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !got.Protected() || !got.CreatedAt.Equal(testNow) {
+		t.Fatalf("round-tripped namespace = %+v", got)
+	}
+
+	if err := got.ApplyLabels([]string{"owner=ops"}); err != nil {
+		t.Fatalf("ApplyLabels: %v", err)
+	}
+	if err := store.Update(got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, _ = store.Get("alt"); got.Labels["owner"] != "ops" {
+		t.Fatalf("update not persisted: %v", got.Labels)
+	}
+
+	if err := store.Delete("alt-staging"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get("alt-staging"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Delete: got %v, want ErrNotFound", err)
+	}
+	if err := store.Delete("alt-staging"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("second Delete: got %v, want ErrNotFound", err)
+	}
+}
+
+type fakeRunner struct {
+	outputs map[string]string
+	runs    []string
+}
+
+func (f *fakeRunner) RunWithOutput(_ context.Context, _ string, args []string) ([]byte, error) {
+	return []byte(f.outputs[args[0]]), nil
+}
+
+func (f *fakeRunner) Run(_ context.Context, _ string, args []string) error {
+	f.runs = append(f.runs, strings.Join(args, " "))
+	return nil
+}
+
+func TestListAndRemoveResources(t *testing.T) {
+	runner := &fakeRunner{outputs: map[string]string{
+		"ps":      "alt-staging-db-1\nalt-staging-alt-backend-1\n",
+		"volume":  "alt-staging_db_data_17\n",
+		"network": "",
+	}}
+
+	res, err := ListResources(context.Background(), runner, "alt-staging")
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if res.Count() != 3 || len(res.Networks) != 0 {
+		t.Fatalf("resources = %+v", res)
+	}
+
+	if err := RemoveResources(context.Background(), runner, res); err != nil {
+		t.Fatalf("RemoveResources: %v", err)
+	}
+	want := []string{
+		"rm -f -v alt-staging-db-1 alt-staging-alt-backend-1",
+		"volume rm alt-staging_db_data_17",
+	}
+	if !reflect.DeepEqual(runner.runs, want) {
+		t.Fatalf("runs = %q, want %q", runner.runs, want)
+	}
+}
@@ -0,0 +1,85 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Runner executes docker commands. compose.DefaultExecutor satisfies it.
+type Runner interface {
+	Run(ctx context.Context, cmd string, args []string) error
+	RunWithOutput(ctx context.Context, cmd string, args []string) ([]byte, error)
+}
+
+// Resources is the set of Docker objects that belong to a namespace.
+type Resources struct {
+	Containers []string
+	Volumes    []string
+	Networks   []string
+}
+
+// Empty reports whether there is nothing to remove.
+func (r Resources) Empty() bool {
+	return len(r.Containers) == 0 && len(r.Volumes) == 0 && len(r.Networks) == 0
+}
+
+// Count returns the total number of resources.
+func (r Resources) Count() int {
+	return len(r.Containers) + len(r.Volumes) + len(r.Networks)
+}
+
+// ListResources finds every container (running or not), volume and network
+// labelled with the namespace's Compose project name. It is read-only, so
+// callers run it with a non-dry-run runner even under --dry-run.
+func ListResources(ctx context.Context, runner Runner, name string) (Resources, error) {
+	filter := fmt.Sprintf("label=%s=%s", ProjectLabel, name)
+	var res Resources
+	var err error
+
+	if res.Containers, err = listNames(ctx, runner, []string{"ps", "-a", "--filter", filter, "--format", "{{.Names}}"}); err != nil {
+		return res, fmt.Errorf("listing containers: %w", err)
+	}
+	if res.Volumes, err = listNames(ctx, runner, []string{"volume", "ls", "--filter", filter, "--format", "{{.Name}}"}); err != nil {
+		return res, fmt.Errorf("listing volumes: %w", err)
+	}
+	if res.Networks, err = listNames(ctx, runner, []string{"network", "ls", "--filter", filter, "--format", "{{.Name}}"}); err != nil {
+		return res, fmt.Errorf("listing networks: %w", err)
+	}
+	return res, nil
+}
+
+// RemoveResources deletes res in dependency order: containers first (they
+// hold volumes and networks), then volumes, then networks.
+func RemoveResources(ctx context.Context, runner Runner, res Resources) error {
+	if len(res.Containers) > 0 {
+		if err := runner.Run(ctx, "docker", append([]string{"rm", "-f", "-v"}, res.Containers...)); err != nil {
+			return fmt.Errorf("removing containers: %w", err)
+		}
+	}
+	if len(res.Volumes) > 0 {
+		if err := runner.Run(ctx, "docker", append([]string{"volume", "rm"}, res.Volumes...)); err != nil {
+			return fmt.Errorf("removing volumes: %w", err)
+		}
+	}
+	if len(res.Networks) > 0 {
+		if err := runner.Run(ctx, "docker", append([]string{"network", "rm"}, res.Networks...)); err != nil {
+			return fmt.Errorf("removing networks: %w", err)
+		}
+	}
+	return nil
+}
+
+func listNames(ctx context.Context, runner Runner, args []string) ([]string, error) {
+	out, err := runner.RunWithOutput(ctx, "docker", args)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
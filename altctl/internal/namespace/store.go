@@ -0,0 +1,115 @@
+package namespace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	dirPerm  = 0700
+	filePerm = 0600
+)
+
+// Store persists registered namespaces as a single JSON file, normally
+// <project root>/.altctl/namespaces.json.
+type Store struct {
+	Path string
+}
+
+// List returns every registered namespace sorted by name.
+func (s Store) List() ([]*Namespace, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading namespaces: %w", err)
+	}
+	var all []*Namespace
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", s.Path, err)
+	}
+	slices.SortFunc(all, func(a, b *Namespace) int { return strings.Compare(a.Name, b.Name) })
+	return all, nil
+}
+
+// Get returns the namespace called name or ErrNotFound.
+func (s Store) Get(name string) (*Namespace, error) {
+	all, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range all {
+		if ns.Name == name {
+			return ns, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// Create registers ns, failing with ErrExists if the name is taken.
+func (s Store) Create(ns *Namespace) error {
+	all, err := s.List()
+	if err != nil {
+		return err
+	}
+	for _, existing := range all {
+		if existing.Name == ns.Name {
+			return fmt.Errorf("%w: %s", ErrExists, ns.Name)
+		}
+	}
+	return s.write(append(all, ns))
+}
+
+// Update replaces the registered namespace with the same name.
+func (s Store) Update(ns *Namespace) error {
+	all, err := s.List()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(all, func(existing *Namespace) bool { return existing.Name == ns.Name })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, ns.Name)
+	}
+	all[i] = ns
+	return s.write(all)
+}
+
+// Delete unregisters the namespace called name.
+func (s Store) Delete(name string) error {
+	all, err := s.List()
+	if err != nil {
+		return err
+	}
+	kept := slices.DeleteFunc(all, func(ns *Namespace) bool { return ns.Name == name })
+	if len(kept) == len(all) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return s.write(kept)
+}
+
+func (s Store) write(all []*Namespace) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), dirPerm); err != nil {
+		return fmt.Errorf("creating namespace directory: %w", err)
+	}
+	slices.SortFunc(all, func(a, b *Namespace) int { return strings.Compare(a.Name, b.Name) })
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding namespaces: %w", err)
+	}
+	// Write-then-rename so an interrupted write never leaves a truncated
+	// registry behind.
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), filePerm); err != nil {
+		return fmt.Errorf("writing namespaces: %w", err)
+	}
+	if err := os.Rename(tmp, s.Path); err != nil {
+		return fmt.Errorf("writing namespaces: %w", err)
+	}
+	return nil
+}
@@ -16,11 +16,17 @@ var CommandHints = map[string][]string{
 	"logs":             {"status"},
 	"restart":          {"status", "logs <service>"},
 	"init":             {"up", "status", "list"},
+	"bootstrap":        {"status", "logs auth-token-manager", "deploy"},
+	"bootstrap local":  {"status", "logs <service>", "down"},
 	"deploy":           {"status", "logs <service>", "down"},
+	"export":           {"list", "deploy --dry-run"},
 	"migrate backup":   {"migrate verify", "migrate list", "migrate status"},
 	"migrate restore":  {"migrate verify", "status"},
 	"migrate status":   {"migrate backup", "migrate snapshot", "migrate list"},
 	"migrate snapshot": {"migrate verify", "migrate list", "migrate status"},
+	"namespace create": {"namespace list", "up"},
+	"ssl rotate":       {"ssl status", "logs <service>"},
+	"top":              {"status", "config", "logs <service>"},
 }
 
 // PrintHints prints "See also" hints for a command. No-op in quiet mode or if command has no hints.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // SecretSpec defines a secret file to be generated
@@ -43,6 +44,7 @@ func DefaultSecretSpecs() []SecretSpec {
 		{Filename: "csrf_secret.txt", Description: "CSRF token secret (min 32 chars)", AutoGenerate: true, Length: 32},
 		{Filename: "auth_shared_secret.txt", Description: "Auth shared secret", AutoGenerate: true, Length: 32},
 		{Filename: "backend_token_secret.txt", Description: "Backend JWT token secret", AutoGenerate: true, Length: 32},
+		{Filename: "kratos_webhook_secret.txt", Description: "Kratos -> auth-hub lifecycle webhook secret (X-Internal-Auth)", AutoGenerate: true, Length: 32},
 		{Filename: "pp_db_password.txt", Description: "Pre-processor dedicated DB password", AutoGenerate: true, Length: 32},
 		{Filename: "image_proxy_secret.txt", Description: "Image proxy HMAC secret", AutoGenerate: true, Length: 32},
 		{Filename: "internal_auth_token.txt", Description: "auth-token-manager internal auth token (X-Internal-Auth)", AutoGenerate: true, Length: 32},
@@ -50,6 +52,8 @@ func DefaultSecretSpecs() []SecretSpec {
 		{Filename: "hugging_face_token.txt", Description: "Hugging Face API token (for AI features)", AutoGenerate: false},
 		{Filename: "inoreader_client_id.txt", Description: "Inoreader OAuth client ID", AutoGenerate: false},
 		{Filename: "inoreader_client_secret.txt", Description: "Inoreader OAuth client secret", AutoGenerate: false},
+		{Filename: "kratos_oidc_google_client_secret.txt", Description: "Google OIDC client secret (social sign-in)", AutoGenerate: false},
+		{Filename: "kratos_oidc_github_client_secret.txt", Description: "GitHub OAuth client secret (social sign-in)", AutoGenerate: false},
 	}
 }
 
@@ -107,3 +111,16 @@ func generateRandomSecret(length int) (string, error) {
 	}
 	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
+
+// EmptySecrets returns the filenames in dir that are missing or contain only
+// whitespace. Used to detect user-provided secrets left as placeholders.
+func EmptySecrets(dir string, filenames []string) []string {
+	var empty []string
+	for _, name := range filenames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || strings.TrimSpace(string(data)) == "" {
+			empty = append(empty, name)
+		}
+	}
+	return empty
+}
@@ -56,6 +56,8 @@ func TestDefaultSecretSpecs_OptionalSecretsNotAutoGenerated(t *testing.T) {
 		"hugging_face_token.txt",
 		"inoreader_client_id.txt",
 		"inoreader_client_secret.txt",
+		"kratos_oidc_google_client_secret.txt",
+		"kratos_oidc_github_client_secret.txt",
 	}
 
 	specMap := make(map[string]SecretSpec)
@@ -189,3 +191,18 @@ func TestGenerateRandomSecret_Unique(t *testing.T) {
 		t.Error("two generated secrets should differ")
 	}
 }
+
+func TestEmptySecrets(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "filled.txt"), []byte("value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "blank.txt"), []byte("  \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got := EmptySecrets(dir, []string{"filled.txt", "blank.txt", "missing.txt"})
+	if len(got) != 2 || got[0] != "blank.txt" || got[1] != "missing.txt" {
+		t.Errorf("expected [blank.txt missing.txt], got %v", got)
+	}
+}
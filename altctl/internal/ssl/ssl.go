@@ -0,0 +1,161 @@
+// Package ssl reports on and rotates the stack's east-west mTLS leaf
+// certificates.
+//
+// Certificates are issued by step-ca and kept within their validity window
+// by one pki-agent sidecar per consumer service (compose/pki.yaml); each
+// sidecar writes straight into its consumer's cert volume. altctl holds no
+// CA material and does not schedule anything itself: it runs the sidecar's
+// own `pki-agent status` / `pki-agent rotate` inside the running container,
+// so a forced rotation goes through the same provisioner, X.509 policy and
+// atomic write as a scheduled one.
+package ssl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// SidecarPrefix is the compose service name prefix of pki-agent sidecars.
+	SidecarPrefix = "pki-agent-"
+
+	serviceLabel = "com.docker.compose.service"
+	projectLabel = "com.docker.compose.project"
+	agentBinary  = "/usr/local/bin/pki-agent"
+)
+
+// Runner executes docker commands. compose.DefaultExecutor satisfies it.
+type Runner interface {
+	RunWithOutput(ctx context.Context, cmd string, args []string) ([]byte, error)
+}
+
+// Sidecar is a running pki-agent container.
+type Sidecar struct {
+	Container string `json:"container"`
+	// Service is the consumer service whose cert the sidecar owns.
+	Service string `json:"service"`
+}
+
+// CertStatus mirrors the JSON printed by `pki-agent status`.
+type CertStatus struct {
+	State     string    `json:"state"`
+	Subject   string    `json:"subject,omitempty"`
+	SANs      []string  `json:"sans,omitempty"`
+	Issuer    string    `json:"issuer,omitempty"`
+	Serial    string    `json:"serial,omitempty"`
+	NotBefore time.Time `json:"not_before,omitzero"`
+	NotAfter  time.Time `json:"not_after,omitzero"`
+	RenewAt   time.Time `json:"renew_at,omitzero"`
+}
+
+// Report is one sidecar's result. Exactly one of Cert and Error is set.
+type Report struct {
+	Sidecar
+	Cert  *CertStatus `json:"cert,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// ListSidecars finds the running pki-agent containers of a Compose project,
+// sorted by consumer service.
+func ListSidecars(ctx context.Context, runner Runner, project string) ([]Sidecar, error) {
+	out, err := runner.RunWithOutput(ctx, "docker", []string{
+		"ps",
+		"--filter", fmt.Sprintf("label=%s=%s", projectLabel, project),
+		"--format", fmt.Sprintf(`{{.Names}}\t{{.Label "%s"}}`, serviceLabel),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing containers: %w", err)
+	}
+
+	var sidecars []Sidecar
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		container, service, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok || !strings.HasPrefix(service, SidecarPrefix) {
+			continue
+		}
+		sidecars = append(sidecars, Sidecar{
+			Container: container,
+			Service:   strings.TrimPrefix(service, SidecarPrefix),
+		})
+	}
+	slices.SortFunc(sidecars, func(a, b Sidecar) int { return strings.Compare(a.Service, b.Service) })
+	return sidecars, nil
+}
+
+// Select returns the sidecars owning certs for services, in the order
+// given. An empty services selects every sidecar. Unknown names are an
+// error so a typo cannot silently rotate nothing.
+func Select(sidecars []Sidecar, services []string) ([]Sidecar, error) {
+	if len(services) == 0 {
+		return sidecars, nil
+	}
+	selected := make([]Sidecar, 0, len(services))
+	var unknown []string
+	for _, name := range services {
+		name = strings.TrimPrefix(name, SidecarPrefix)
+		i := slices.IndexFunc(sidecars, func(s Sidecar) bool { return s.Service == name })
+		if i < 0 {
+			unknown = append(unknown, name)
+			continue
+		}
+		selected = append(selected, sidecars[i])
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("no running pki-agent sidecar for: %s", strings.Join(unknown, ", "))
+	}
+	return selected, nil
+}
+
+// Status asks each sidecar for its on-disk cert. Failures are recorded per
+// sidecar rather than aborting the sweep.
+func Status(ctx context.Context, runner Runner, sidecars []Sidecar) []Report {
+	return run(ctx, runner, sidecars, "status")
+}
+
+// Rotate forces each sidecar to reissue its cert now and returns the new
+// status. Sidecars are rotated one at a time so a CA outage shows up on the
+// first failure instead of on every service at once.
+func Rotate(ctx context.Context, runner Runner, sidecars []Sidecar) []Report {
+	return run(ctx, runner, sidecars, "rotate")
+}
+
+func run(ctx context.Context, runner Runner, sidecars []Sidecar, command string) []Report {
+	reports := make([]Report, 0, len(sidecars))
+	for _, s := range sidecars {
+		report := Report{Sidecar: s}
+		out, err := runner.RunWithOutput(ctx, "docker", []string{"exec", s.Container, agentBinary, command})
+		if err != nil {
+			report.Error = strings.TrimSpace(err.Error())
+			reports = append(reports, report)
+			continue
+		}
+		var cert CertStatus
+		if err := json.Unmarshal(out, &cert); err != nil {
+			report.Error = fmt.Sprintf("parsing pki-agent output: %v", err)
+		} else {
+			report.Cert = &cert
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// Unhealthy reports whether any sidecar failed to answer or holds a cert
+// that is missing, corrupt or expired. near_expiry is not unhealthy on its
+// own: the sidecar reissues it on its next tick.
+func Unhealthy(reports []Report) bool {
+	return slices.ContainsFunc(reports, func(r Report) bool {
+		if r.Error != "" || r.Cert == nil {
+			return true
+		}
+		switch r.Cert.State {
+		case "missing", "corrupt", "expired":
+			return true
+		}
+		return false
+	})
+}
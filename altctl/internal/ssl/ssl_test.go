@@ -0,0 +1,114 @@
+package ssl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeRunner answers `docker ps` with ps and `docker exec <container> ...`
+// from execs keyed by container.
+type fakeRunner struct {
+	ps    string
+	execs map[string]string
+	fail  map[string]error
+	calls []string
+}
+
+func (f *fakeRunner) RunWithOutput(_ context.Context, _ string, args []string) ([]byte, error) {
+	f.calls = append(f.calls, strings.Join(args, " "))
+	if args[0] == "ps" {
+		return []byte(f.ps), nil
+	}
+	container := args[1]
+	if err := f.fail[container]; err != nil {
+		return nil, err
+	}
+	return []byte(f.execs[container]), nil
+}
+
+func TestListSidecars(t *testing.T) {
+	runner := &fakeRunner{ps: strings.Join([]string{
+		"alt-pki-agent-auth-hub-1\tpki-agent-auth-hub",
+		"alt-alt-backend-1\talt-backend",
+		"alt-step-ca-1\tstep-ca",
+		"alt-pki-agent-alt-backend-1\tpki-agent-alt-backend",
+	}, "\n")}
+
+	sidecars, err := ListSidecars(context.Background(), runner, "alt")
+	if err != nil {
+		t.Fatalf("ListSidecars: %v", err)
+	}
+	if len(sidecars) != 2 || sidecars[0].Service != "alt-backend" || sidecars[1].Service != "auth-hub" {
+		t.Fatalf("sidecars = %+v", sidecars)
+	}
+	if !strings.Contains(runner.calls[0], "label=com.docker.compose.project=alt") {
+		t.Fatalf("ps not filtered by project: %s", runner.calls[0])
+	}
+}
+
+func TestSelect(t *testing.T) {
+	sidecars := []Sidecar{{Container: "a", Service: "alt-backend"}, {Container: "b", Service: "auth-hub"}}
+
+	got, err := Select(sidecars, []string{"pki-agent-auth-hub"})
+	if err != nil || len(got) != 1 || got[0].Container != "b" {
+		t.Fatalf("Select = %+v, %v", got, err)
+	}
+	if got, _ := Select(sidecars, nil); len(got) != 2 {
+		t.Fatalf("empty selection should return all, got %+v", got)
+	}
+	if _, err := Select(sidecars, []string{"auth-hub", "nope"}); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Fatalf("unknown service should fail, got %v", err)
+	}
+}
+
+func TestRotateAndStatus(t *testing.T) {
+	runner := &fakeRunner{
+		execs: map[string]string{
+			"a": `{"state":"fresh","subject":"alt-backend","serial":"ab","not_before":"2026-10-16T00:00:00Z","not_after":"2026-10-17T00:00:00Z","renew_at":"2026-10-16T15:50:24Z"}`,
+			"c": `not json`,
+		},
+		fail: map[string]error{"b": errors.New("exit status 1: issue cert: pki-agent: CA unreachable")},
+	}
+	sidecars := []Sidecar{{Container: "a", Service: "alt-backend"}, {Container: "b", Service: "auth-hub"}, {Container: "c", Service: "tag-generator"}}
+
+	reports := Rotate(context.Background(), runner, sidecars)
+	if len(reports) != 3 {
+		t.Fatalf("reports = %+v", reports)
+	}
+	if reports[0].Cert == nil || reports[0].Cert.Serial != "ab" || reports[0].Cert.RenewAt.IsZero() {
+		t.Fatalf("report[0] = %+v", reports[0])
+	}
+	if !strings.Contains(reports[1].Error, "CA unreachable") || reports[1].Cert != nil {
+		t.Fatalf("report[1] = %+v", reports[1])
+	}
+	if !strings.HasPrefix(reports[2].Error, "parsing pki-agent output") {
+		t.Fatalf("report[2] = %+v", reports[2])
+	}
+	if runner.calls[0] != "exec a /usr/local/bin/pki-agent rotate" {
+		t.Fatalf("unexpected exec: %s", runner.calls[0])
+	}
+	if !Unhealthy(reports) {
+		t.Fatal("failed rotation should be unhealthy")
+	}
+}
+
+func TestUnhealthy(t *testing.T) {
+	tests := []struct {
+		state string
+		want  bool
+	}{
+		{"fresh", false},
+		{"near_expiry", false},
+		{"expired", true},
+		{"missing", true},
+		{"corrupt", true},
+	}
+	for _, tt := range tests {
+		reports := []Report{{Cert: &CertStatus{State: "fresh"}}, {Cert: &CertStatus{State: tt.state}}}
+		if got := Unhealthy(reports); got != tt.want {
+			t.Errorf("Unhealthy(%s) = %v, want %v", tt.state, got, tt.want)
+		}
+	}
+}
@@ -25,6 +25,7 @@ var defaultStacks = []Stack{
 		Description: "Database services (PostgreSQL 17, Meilisearch, ClickHouse)",
 		ComposeFile: "db.yaml",
 		Services:    []string{"db", "meilisearch", "clickhouse"},
+		Migrations:  []Migration{{Service: "pre-processor-db-migrator", Tool: MigrationToolAtlas}},
 		DependsOn:   []string{"base"},
 		Optional:    false,
 		Provides:    []Feature{FeatureDatabase},
@@ -42,6 +43,7 @@ var defaultStacks = []Stack{
 		Description: "Authentication services (Kratos, auth-hub)",
 		ComposeFile: "auth.yaml",
 		Services:    []string{"kratos-db", "kratos-migrate", "kratos", "auth-hub"},
+		Migrations:  []Migration{{Service: "kratos-migrate", Tool: MigrationToolKratos}},
 		DependsOn:   []string{"base", "pgbouncer"},
 		Optional:    false,
 		Provides:    []Feature{FeatureAuth},
@@ -51,6 +53,7 @@ var defaultStacks = []Stack{
 		Description:      "Core application services (nginx, frontend, backend)",
 		ComposeFile:      "core.yaml",
 		Services:         []string{"nginx", "alt-frontend-sv", "alt-backend", "migrate"},
+		Migrations:       []Migration{{Service: "migrate", Tool: MigrationToolAtlas}},
 		DependsOn:        []string{"base", "db", "auth", "sovereign"},
 		Optional:         false,
 		RequiresFeatures: []Feature{FeatureSearch, FeatureBFF}, // Search UI requires search-indexer; frontend depends on alt-butterfly-facade
@@ -59,7 +62,7 @@ var defaultStacks = []Stack{
 		Name:        "ai",
 		Description: "AI/LLM services (Ollama, news-creator, pre-processor)",
 		ComposeFile: "ai.yaml",
-		Services:    []string{"redis-cache", "news-creator-backend", "news-creator", "news-creator-volume-init", "pre-processor"},
+		Services:    []string{"redis-cache", "news-creator-backend", "news-creator", "news-creator-volume-init", "pre-processor-thumbnails-init", "pre-processor"},
 		DependsOn:   []string{"base", "db", "mq", "core"},
 		Profile:     "ollama",
 		RequiresGPU: true,
@@ -81,6 +84,7 @@ var defaultStacks = []Stack{
 		Description: "Recap services (article summarization)",
 		ComposeFile: "recap.yaml",
 		Services:    []string{"recap-db", "recap-db-migrator", "recap-worker", "recap-subworker", "dashboard", "recap-evaluator"},
+		Migrations:  []Migration{{Service: "recap-db-migrator", Tool: MigrationToolAtlas}},
 		DependsOn:   []string{"base", "db", "core"},
 		Profile:     "recap",
 		Optional:    true,
@@ -108,6 +112,7 @@ var defaultStacks = []Stack{
 		Description: "RAG extension services",
 		ComposeFile: "rag.yaml",
 		Services:    []string{"rag-db", "rag-db-migrator", "rag-orchestrator"},
+		Migrations:  []Migration{{Service: "rag-db-migrator", Tool: MigrationToolAtlas}},
 		DependsOn:   []string{"base", "db", "core", "workers"},
 		Profile:     "rag-extension",
 		Optional:    true,
@@ -193,6 +198,7 @@ var defaultStacks = []Stack{
 		Description: "Knowledge Sovereign (durable knowledge state owner)",
 		ComposeFile: "sovereign.yaml",
 		Services:    []string{"knowledge-sovereign-db", "knowledge-sovereign-db-migrator", "knowledge-sovereign"},
+		Migrations:  []Migration{{Service: "knowledge-sovereign-db-migrator", Tool: MigrationToolAtlas}},
 		DependsOn:   []string{"base"},
 		Optional:    false,
 	},
@@ -151,3 +151,28 @@ func TestNoOrphanComposeFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestRegistryMigrationsMatchCompose(t *testing.T) {
+	dir := composeDir(t)
+	registry := NewRegistry()
+
+	for _, s := range registry.All() {
+		if len(s.Migrations) == 0 {
+			continue
+		}
+		path := filepath.Join(dir, s.ComposeFile)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue // Covered by TestEveryStackComposeFileExists
+		}
+
+		composeSet := make(map[string]bool)
+		for _, svc := range parseComposeServices(t, path) {
+			composeSet[svc] = true
+		}
+		for _, m := range s.Migrations {
+			if !composeSet[m.Service] {
+				t.Errorf("stack %q lists migration job %q which is not defined in %s", s.Name, m.Service, s.ComposeFile)
+			}
+		}
+	}
+}
@@ -17,11 +17,35 @@ type Stack struct {
 	RequiresGPU bool          `json:"requires_gpu"`
 	Timeout     time.Duration `json:"timeout"`
 
+	// Migrations lists the stack's one-shot schema migration jobs. Deploy
+	// runs them, and checks that nothing is left pending, before starting
+	// the rest of the stack.
+	Migrations []Migration `json:"migrations,omitempty"`
+
 	// Feature-based dependencies
 	Provides         []Feature `json:"provides,omitempty"`          // Features this stack provides
 	RequiresFeatures []Feature `json:"requires_features,omitempty"` // Features this stack needs to function
 }
 
+// MigrationTool identifies the migration tool a job runs, which decides how
+// its status is checked.
+type MigrationTool string
+
+const (
+	// MigrationToolAtlas is an Atlas migrator image wrapping migrate.sh,
+	// which takes "apply" and "status" as its command.
+	MigrationToolAtlas MigrationTool = "atlas"
+	// MigrationToolKratos is "kratos migrate sql" run by the Kratos image.
+	MigrationToolKratos MigrationTool = "kratos"
+)
+
+// Migration is a schema migration job: a compose service that applies its
+// migrations with its default command and exits.
+type Migration struct {
+	Service string        `json:"service"`
+	Tool    MigrationTool `json:"tool"`
+}
+
 // IsDefault returns true if this stack should be started by default
 func (s *Stack) IsDefault() bool {
 	return !s.Optional
@@ -0,0 +1,102 @@
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// binaryUnits maps the memory suffixes Compose and `docker stats` print to
+// multipliers. Like Docker's own RAMInBytes, k/m/g are binary whether or
+// not they carry an "i", so "2G" in a compose file equals "2GiB" in stats.
+var binaryUnits = map[string]float64{
+	"":  1,
+	"b": 1,
+	"k": 1 << 10, "kb": 1 << 10, "kib": 1 << 10,
+	"m": 1 << 20, "mb": 1 << 20, "mib": 1 << 20,
+	"g": 1 << 30, "gb": 1 << 30, "gib": 1 << 30,
+	"t": 1 << 40, "tb": 1 << 40, "tib": 1 << 40,
+}
+
+// ParseBytes parses a memory size such as "256M", "1.5GiB" or "268435456".
+func ParseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	mult, ok := binaryUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit in %q", s)
+	}
+	return int64(math.Round(n * mult)), nil
+}
+
+// FormatBytes renders n with the largest binary unit that keeps it >= 1.
+func FormatBytes(n int64) string {
+	v := float64(n)
+	for _, unit := range []string{"B", "KiB", "MiB", "GiB"} {
+		if v < 1024 {
+			if unit == "B" {
+				return fmt.Sprintf("%.0f%s", v, unit)
+			}
+			return fmt.Sprintf("%.1f%s", v, unit)
+		}
+		v /= 1024
+	}
+	return fmt.Sprintf("%.1fTiB", v)
+}
+
+// parsePercent parses a `docker stats` percentage such as "12.50%".
+func parsePercent(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q", s)
+	}
+	return v, nil
+}
+
+// rawCPUs decodes a rendered cpus value. Compose renders it as a number or
+// a string depending on version, and either may be absent.
+func rawCPUs(raw json.RawMessage) (float64, error) {
+	s, err := rawScalar(raw)
+	if err != nil || s == "" {
+		return 0, err
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cpus %q", s)
+	}
+	return v, nil
+}
+
+// rawBytes decodes a rendered memory value: a byte count as a number or
+// string, or a size with a unit when taken verbatim from the file.
+func rawBytes(raw json.RawMessage) (int64, error) {
+	s, err := rawScalar(raw)
+	if err != nil || s == "" {
+		return 0, err
+	}
+	return ParseBytes(s)
+}
+
+func rawScalar(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", fmt.Errorf("expected a number or string, got %s", raw)
+	}
+	return n.String(), nil
+}
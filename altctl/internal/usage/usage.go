@@ -0,0 +1,334 @@
+// Package usage compares what running services actually consume with the
+// CPU and memory the compose files allocate to them.
+//
+// Usage comes from a single `docker stats --no-stream` sample of every
+// container labelled with one of the selected Compose projects (altctl
+// namespaces). Allocations come from deploy.resources in the rendered
+// compose configuration: limits are the hard cap, reservations what the
+// service is expected to need. A workload near its limit or above its
+// reservation is under-provisioned; one far below its reservation is
+// holding capacity it does not use.
+package usage
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	projectLabel = "com.docker.compose.project"
+	serviceLabel = "com.docker.compose.service"
+
+	// LimitPressure is the fraction of a limit above which a workload is
+	// reported at-limit: memory there is close to an OOM kill, CPU to
+	// throttling.
+	LimitPressure = 0.9
+	// IdleReservation is the fraction of a reservation below which a
+	// workload is reported over-provisioned.
+	IdleReservation = 0.2
+)
+
+// Runner executes docker commands. compose.DefaultExecutor satisfies it.
+type Runner interface {
+	RunWithOutput(ctx context.Context, cmd string, args []string) ([]byte, error)
+}
+
+// Status classifies a workload against its allocation.
+type Status string
+
+const (
+	StatusAtLimit          Status = "at-limit"
+	StatusUnderProvisioned Status = "under-provisioned"
+	StatusOverProvisioned  Status = "over-provisioned"
+	StatusUnbounded        Status = "no-limits"
+	StatusOK               Status = "ok"
+)
+
+// severity orders statuses from most to least in need of attention.
+func (s Status) severity() int {
+	switch s {
+	case StatusAtLimit:
+		return 4
+	case StatusUnderProvisioned:
+		return 3
+	case StatusOverProvisioned:
+		return 2
+	case StatusUnbounded:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Allocation is one container's deploy.resources. Zero means unset.
+type Allocation struct {
+	CPULimit          float64
+	CPUReservation    float64
+	MemoryLimit       int64
+	MemoryReservation int64
+}
+
+// Container is a running container of a Compose project.
+type Container struct {
+	Name      string
+	Namespace string
+	Service   string
+}
+
+// Sample is one container's usage at the time of the stats call.
+type Sample struct {
+	Container
+	// CPU is in cores: docker stats reports 100% per fully used core.
+	CPU    float64
+	Memory int64
+}
+
+// Resource compares usage of one resource with the service's allocation,
+// summed over its containers. CPU is in cores, memory in bytes.
+type Resource struct {
+	Usage       float64 `json:"usage"`
+	Reservation float64 `json:"reservation,omitempty"`
+	Limit       float64 `json:"limit,omitempty"`
+	Status      Status  `json:"status"`
+}
+
+// Workload is one service in one namespace.
+type Workload struct {
+	Namespace  string   `json:"namespace"`
+	Stack      string   `json:"stack,omitempty"`
+	Service    string   `json:"service"`
+	Containers int      `json:"containers"`
+	CPU        Resource `json:"cpu"`
+	Memory     Resource `json:"memory"`
+	Status     Status   `json:"status"`
+}
+
+// ParseAllocations extracts each service's deploy.resources from
+// 'docker compose config --format json' output. The legacy cpus, mem_limit
+// and mem_reservation keys are used when deploy.resources leaves a value
+// unset.
+func ParseAllocations(rendered []byte) (map[string]Allocation, error) {
+	type resources struct {
+		CPUs   json.RawMessage `json:"cpus"`
+		Memory json.RawMessage `json:"memory"`
+	}
+	var doc struct {
+		Services map[string]struct {
+			Deploy struct {
+				Resources struct {
+					Limits       resources `json:"limits"`
+					Reservations resources `json:"reservations"`
+				} `json:"resources"`
+			} `json:"deploy"`
+			CPUs           json.RawMessage `json:"cpus"`
+			MemLimit       json.RawMessage `json:"mem_limit"`
+			MemReservation json.RawMessage `json:"mem_reservation"`
+		} `json:"services"`
+	}
+	if err := json.Unmarshal(rendered, &doc); err != nil {
+		return nil, fmt.Errorf("parsing rendered compose config: %w", err)
+	}
+
+	allocs := make(map[string]Allocation, len(doc.Services))
+	for name, svc := range doc.Services {
+		res := svc.Deploy.Resources
+		var a Allocation
+		var err error
+		if a.CPULimit, err = firstCPUs(res.Limits.CPUs, svc.CPUs); err != nil {
+			return nil, fmt.Errorf("service %s: cpu limit: %w", name, err)
+		}
+		if a.CPUReservation, err = rawCPUs(res.Reservations.CPUs); err != nil {
+			return nil, fmt.Errorf("service %s: cpu reservation: %w", name, err)
+		}
+		if a.MemoryLimit, err = firstBytes(res.Limits.Memory, svc.MemLimit); err != nil {
+			return nil, fmt.Errorf("service %s: memory limit: %w", name, err)
+		}
+		if a.MemoryReservation, err = firstBytes(res.Reservations.Memory, svc.MemReservation); err != nil {
+			return nil, fmt.Errorf("service %s: memory reservation: %w", name, err)
+		}
+		allocs[name] = a
+	}
+	return allocs, nil
+}
+
+func firstCPUs(raws ...json.RawMessage) (float64, error) {
+	for _, raw := range raws {
+		if v, err := rawCPUs(raw); err != nil || v > 0 {
+			return v, err
+		}
+	}
+	return 0, nil
+}
+
+func firstBytes(raws ...json.RawMessage) (int64, error) {
+	for _, raw := range raws {
+		if v, err := rawBytes(raw); err != nil || v > 0 {
+			return v, err
+		}
+	}
+	return 0, nil
+}
+
+// ListContainers finds the running containers of the given Compose
+// projects, or of every Compose project when projects is empty.
+func ListContainers(ctx context.Context, runner Runner, projects []string) ([]Container, error) {
+	out, err := runner.RunWithOutput(ctx, "docker", []string{
+		"ps",
+		"--filter", "label=" + projectLabel,
+		"--format", fmt.Sprintf(`{{.Names}}\t{{.Label "%s"}}\t{{.Label "%s"}}`, projectLabel, serviceLabel),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing containers: %w", err)
+	}
+
+	var containers []Container
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 || fields[2] == "" {
+			continue
+		}
+		if len(projects) > 0 && !slices.Contains(projects, fields[1]) {
+			continue
+		}
+		containers = append(containers, Container{Name: fields[0], Namespace: fields[1], Service: fields[2]})
+	}
+	return containers, nil
+}
+
+// Stats takes one `docker stats` sample of containers. Containers that
+// exit between listing and sampling are left out.
+func Stats(ctx context.Context, runner Runner, containers []Container) ([]Sample, error) {
+	if len(containers) == 0 {
+		return nil, nil
+	}
+	byName := make(map[string]Container, len(containers))
+	args := []string{"stats", "--no-stream", "--format", "{{json .}}"}
+	for _, c := range containers {
+		byName[c.Name] = c
+		args = append(args, c.Name)
+	}
+	out, err := runner.RunWithOutput(ctx, "docker", args)
+	if err != nil {
+		return nil, fmt.Errorf("sampling container stats: %w", err)
+	}
+
+	var samples []Sample
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var row struct {
+			Name     string `json:"Name"`
+			CPUPerc  string `json:"CPUPerc"`
+			MemUsage string `json:"MemUsage"`
+		}
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return nil, fmt.Errorf("parsing docker stats output: %w", err)
+		}
+		c, ok := byName[row.Name]
+		if !ok {
+			continue
+		}
+		cpu, err := parsePercent(row.CPUPerc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", row.Name, err)
+		}
+		// MemUsage is "<used> / <cgroup limit or host memory>".
+		used, _, _ := strings.Cut(row.MemUsage, "/")
+		mem, err := ParseBytes(used)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", row.Name, err)
+		}
+		samples = append(samples, Sample{Container: c, CPU: cpu / 100, Memory: mem})
+	}
+	return samples, nil
+}
+
+// Build groups samples into one workload per namespace and service. A
+// service's allocation is per container, so it is scaled by the number of
+// replicas sampled. stackOf maps services to their altctl stack.
+func Build(samples []Sample, allocs map[string]Allocation, stackOf map[string]string) []Workload {
+	type key struct{ namespace, service string }
+	index := make(map[key]int)
+	var workloads []Workload
+	for _, s := range samples {
+		k := key{s.Namespace, s.Service}
+		i, ok := index[k]
+		if !ok {
+			i = len(workloads)
+			index[k] = i
+			workloads = append(workloads, Workload{Namespace: s.Namespace, Stack: stackOf[s.Service], Service: s.Service})
+		}
+		w := &workloads[i]
+		w.Containers++
+		w.CPU.Usage += s.CPU
+		w.Memory.Usage += float64(s.Memory)
+	}
+
+	for i := range workloads {
+		w := &workloads[i]
+		a := allocs[w.Service]
+		n := float64(w.Containers)
+		w.CPU.Limit, w.CPU.Reservation = a.CPULimit*n, a.CPUReservation*n
+		w.Memory.Limit, w.Memory.Reservation = float64(a.MemoryLimit)*n, float64(a.MemoryReservation)*n
+		w.CPU.Status = classify(w.CPU)
+		w.Memory.Status = classify(w.Memory)
+		w.Status = w.CPU.Status
+		if w.Memory.Status.severity() > w.Status.severity() {
+			w.Status = w.Memory.Status
+		}
+	}
+	Sort(workloads, SortName)
+	return workloads
+}
+
+func classify(r Resource) Status {
+	switch {
+	case r.Limit == 0 && r.Reservation == 0:
+		return StatusUnbounded
+	case r.Limit > 0 && r.Usage >= r.Limit*LimitPressure:
+		return StatusAtLimit
+	case r.Reservation > 0 && r.Usage > r.Reservation:
+		return StatusUnderProvisioned
+	case r.Reservation > 0 && r.Usage < r.Reservation*IdleReservation:
+		return StatusOverProvisioned
+	default:
+		return StatusOK
+	}
+}
+
+// Sort keys accepted by Sort.
+const (
+	SortName   = "name"
+	SortCPU    = "cpu"
+	SortMemory = "memory"
+	SortStatus = "status"
+)
+
+// SortKeys lists the valid sort keys.
+var SortKeys = []string{SortName, SortCPU, SortMemory, SortStatus}
+
+// Sort orders workloads in place: cpu and memory by usage, heaviest first;
+// status by severity, most urgent first; name by namespace then service.
+// Ties always fall back to name order.
+func Sort(workloads []Workload, key string) {
+	slices.SortStableFunc(workloads, func(a, b Workload) int {
+		var c int
+		switch key {
+		case SortCPU:
+			c = cmp.Compare(b.CPU.Usage, a.CPU.Usage)
+		case SortMemory:
+			c = cmp.Compare(b.Memory.Usage, a.Memory.Usage)
+		case SortStatus:
+			c = cmp.Compare(b.Status.severity(), a.Status.severity())
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Service, b.Service))
+	})
+}
@@ -0,0 +1,158 @@
+package usage
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// fakeRunner answers `docker ps` with ps and `docker stats` with stats.
+type fakeRunner struct {
+	ps    string
+	stats string
+	calls []string
+}
+
+func (f *fakeRunner) RunWithOutput(_ context.Context, _ string, args []string) ([]byte, error) {
+	f.calls = append(f.calls, strings.Join(args, " "))
+	if args[0] == "ps" {
+		return []byte(f.ps), nil
+	}
+	return []byte(f.stats), nil
+}
+
+func TestParseBytes(t *testing.T) {
+	cases := map[string]int64{
+		"0B":        0,
+		"268435456": 268435456,
+		"256M":      256 << 20,
+		"2G":        2 << 30,
+		"1.5GiB":    3 << 29,
+		"512MiB ":   512 << 20,
+		"4.5kB":     4608,
+	}
+	for in, want := range cases {
+		got, err := ParseBytes(in)
+		if err != nil || got != want {
+			t.Errorf("ParseBytes(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "G", "12 parsecs"} {
+		if _, err := ParseBytes(in); err == nil {
+			t.Errorf("ParseBytes(%q) should fail", in)
+		}
+	}
+}
+
+func TestParseAllocations(t *testing.T) {
+	rendered := `{"name":"alt","services":{
+		"db":{"deploy":{"resources":{"limits":{"cpus":4,"memory":"4294967296"},"reservations":{"cpus":"2.0","memory":"2147483648"}}}},
+		"legacy":{"cpus":0.5,"mem_limit":"512M"},
+		"bare":{"image":"busybox"}
+	}}`
+
+	allocs, err := ParseAllocations([]byte(rendered))
+	if err != nil {
+		t.Fatalf("ParseAllocations: %v", err)
+	}
+	if got := allocs["db"]; got != (Allocation{CPULimit: 4, CPUReservation: 2, MemoryLimit: 4 << 30, MemoryReservation: 2 << 30}) {
+		t.Errorf("db = %+v", got)
+	}
+	if got := allocs["legacy"]; got != (Allocation{CPULimit: 0.5, MemoryLimit: 512 << 20}) {
+		t.Errorf("legacy = %+v", got)
+	}
+	if got, ok := allocs["bare"]; !ok || got != (Allocation{}) {
+		t.Errorf("bare = %+v, %v", got, ok)
+	}
+
+	if _, err := ParseAllocations([]byte(`{"services":{"x":{"deploy":{"resources":{"limits":{"memory":"lots"}}}}}}`)); err == nil {
+		t.Error("invalid memory should fail")
+	}
+}
+
+func TestListContainersAndStats(t *testing.T) {
+	runner := &fakeRunner{
+		ps: strings.Join([]string{
+			"alt-alt-backend-1\talt\talt-backend",
+			"staging-alt-backend-1\tstaging\talt-backend",
+			"other-web-1\tother\tweb",
+			"orphan\talt\t",
+		}, "\n"),
+		stats: strings.Join([]string{
+			`{"Name":"alt-alt-backend-1","CPUPerc":"150.00%","MemUsage":"1.5GiB / 2GiB"}`,
+			`{"Name":"staging-alt-backend-1","CPUPerc":"0.50%","MemUsage":"64MiB / 31.2GiB"}`,
+		}, "\n"),
+	}
+	ctx := context.Background()
+
+	containers, err := ListContainers(ctx, runner, []string{"alt", "staging"})
+	if err != nil {
+		t.Fatalf("ListContainers: %v", err)
+	}
+	if len(containers) != 2 || containers[1] != (Container{Name: "staging-alt-backend-1", Namespace: "staging", Service: "alt-backend"}) {
+		t.Fatalf("containers = %+v", containers)
+	}
+
+	samples, err := Stats(ctx, runner, containers)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if len(samples) != 2 || samples[0].CPU != 1.5 || samples[0].Memory != 3<<29 || samples[1].Memory != 64<<20 {
+		t.Fatalf("samples = %+v", samples)
+	}
+	if !strings.HasSuffix(runner.calls[1], "alt-alt-backend-1 staging-alt-backend-1") {
+		t.Errorf("stats not limited to listed containers: %s", runner.calls[1])
+	}
+}
+
+func TestBuild(t *testing.T) {
+	allocs := map[string]Allocation{
+		"db":     {CPULimit: 2, MemoryLimit: 1 << 30, MemoryReservation: 512 << 20},
+		"worker": {CPUReservation: 1, MemoryReservation: 1 << 30},
+		"api":    {CPUReservation: 0.5, MemoryReservation: 256 << 20},
+	}
+	samples := []Sample{
+		{Container: Container{Namespace: "alt", Service: "db"}, CPU: 0.5, Memory: 1000 << 20},
+		{Container: Container{Namespace: "alt", Service: "worker"}, CPU: 0.05, Memory: 100 << 20},
+		{Container: Container{Namespace: "alt", Service: "api"}, CPU: 0.6, Memory: 200 << 20},
+		{Container: Container{Namespace: "alt", Service: "api"}, CPU: 0.2, Memory: 200 << 20},
+		{Container: Container{Namespace: "alt", Service: "cron"}, CPU: 0.1, Memory: 10 << 20},
+	}
+
+	workloads := Build(samples, allocs, map[string]string{"db": "core"})
+	byService := make(map[string]Workload)
+	for _, w := range workloads {
+		byService[w.Service] = w
+	}
+
+	if w := byService["db"]; w.Memory.Status != StatusAtLimit || w.CPU.Status != StatusOK || w.Status != StatusAtLimit || w.Stack != "core" {
+		t.Errorf("db = %+v", w)
+	}
+	if w := byService["worker"]; w.Status != StatusOverProvisioned {
+		t.Errorf("worker = %+v", w)
+	}
+	// Two replicas: reservations double, so 0.8 cores against 1.0 is fine.
+	if w := byService["api"]; w.Containers != 2 || w.CPU.Reservation != 1 || w.CPU.Status != StatusOK || w.Memory.Status != StatusOK {
+		t.Errorf("api = %+v", w)
+	}
+	if w := byService["cron"]; w.Status != StatusUnbounded {
+		t.Errorf("cron = %+v", w)
+	}
+
+	Sort(workloads, SortStatus)
+	if workloads[0].Service != "db" || workloads[len(workloads)-1].Service != "api" {
+		t.Errorf("status order = %v", serviceNames(workloads))
+	}
+	Sort(workloads, SortCPU)
+	if got := serviceNames(workloads); strings.Join(got, ",") != "api,db,cron,worker" {
+		t.Errorf("cpu order = %v", got)
+	}
+}
+
+func serviceNames(workloads []Workload) []string {
+	names := make([]string, len(workloads))
+	for i, w := range workloads {
+		names[i] = w.Service
+	}
+	return names
+}
@@ -11,14 +11,15 @@ import (
 	"syscall"
 	"time"
 
-	"auth-hub/internal/adapter/gateway"
 	adapterhandler "auth-hub/internal/adapter/handler"
-	infracache "auth-hub/internal/infrastructure/cache"
-	infratoken "auth-hub/internal/infrastructure/token"
+	"auth-hub/internal/domain"
+	infrabreach "auth-hub/internal/infrastructure/breach"
+	infranotify "auth-hub/internal/infrastructure/notify"
 	"auth-hub/internal/usecase"
 
 	"auth-hub/config"
 	appmiddleware "auth-hub/middleware"
+	"auth-hub/problem"
 	"auth-hub/tlsutil"
 	"auth-hub/utils/logger"
 	"auth-hub/utils/otel"
@@ -26,6 +27,8 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 )
@@ -65,36 +68,91 @@ func main() {
 	slog.InfoContext(ctx, "configuration loaded",
 		"kratos_url", cfg.KratosURL,
 		"port", cfg.Port,
-		"cache_ttl", cfg.CacheTTL)
-
-	// Infrastructure
-	sessionCache := infracache.NewSessionCache(cfg.CacheTTL)
-	kratosGateway := gateway.NewKratosGateway(cfg.KratosURL, cfg.KratosAdminURL, 5*time.Second)
-	jwtIssuer := infratoken.NewJWTIssuer(infratoken.JWTConfig{
-		Secret:   cfg.BackendTokenSecret,
-		Issuer:   cfg.BackendTokenIssuer,
-		Audience: cfg.BackendTokenAudience,
-		TTL:      cfg.BackendTokenTTL,
-	})
-	csrfGenerator := infratoken.NewHMACCSRFGenerator(cfg.CSRFSecret)
+		"cache_ttl", cfg.CacheTTL,
+		"cache_stale_ttl", cfg.CacheStaleTTL,
+		"session_fingerprint_mode", cfg.FingerprintMode,
+		"ready_failure_threshold", cfg.ReadyFailureThreshold,
+		"ready_probe_timeout", cfg.ReadyProbeTimeout)
+
+	// Every tenant gets its own Kratos gateway, caches and signing keys.
+	// Without TENANTS_FILE there is one tenant built from the settings above.
+	breachChecker := infrabreach.NewPwnedPasswords(cfg.PasswordBreachAPIURL, 3*time.Second)
+	var notifier domain.NotificationGateway
+	if cfg.LoginAlertEnabled {
+		email, err := infranotify.NewEmailGateway(infranotify.EmailConfig{
+			SMTPURL: cfg.LoginAlertSMTPURL,
+			From:    cfg.LoginAlertFrom,
+			Timeout: 10 * time.Second,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to configure login alert email", "error", err)
+			os.Exit(1)
+		}
+		notifier = infranotify.Fanout{email}
+	}
+	tenants := tenantSettingsFromConfig(cfg)
+	router := make(tenantRouter, len(tenants))
+	routes := make([]appmiddleware.TenantRoute, 0, len(cfg.Tenants))
+	var readinessChecks []usecase.ReadinessCheck
+	webhookEnabled := false
+	rpcBurst := int(cfg.ConnectRate * 2)
+	if rpcBurst < 10 {
+		rpcBurst = 10
+	}
+	rpcLimiter := rate.NewLimiter(rate.Limit(cfg.ConnectRate), rpcBurst)
+	for _, t := range tenants {
+		checkPrefix := ""
+		if len(cfg.Tenants) > 0 {
+			checkPrefix = t.id + "/"
+		}
+		stack, err := newTenantStack(cfg, t, checkPrefix, breachChecker, notifier, rpcLimiter)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to build tenant", "tenant_id", t.id, "error", err)
+			os.Exit(1)
+		}
+		router[t.id] = stack
+		readinessChecks = append(readinessChecks, stack.readiness...)
+		webhookEnabled = webhookEnabled || stack.kratosHook != nil
+	}
+	for _, t := range cfg.Tenants {
+		routes = append(routes, appmiddleware.TenantRoute{ID: t.ID, Hosts: t.Hosts})
+		slog.InfoContext(ctx, "tenant configured",
+			"tenant_id", t.ID,
+			"hosts", t.Hosts,
+			"kratos_url", t.KratosURL,
+			"kratos_webhook", t.KratosWebhookSecret != "")
+	}
+	tenantResolver := appmiddleware.NewTenantResolver(cfg.TenantHeader, routes)
+	if len(cfg.Tenants) > 0 {
+		slog.InfoContext(ctx, "multi-tenant mode enabled",
+			"tenants", len(cfg.Tenants),
+			"tenant_header", cfg.TenantHeader)
+	}
 
-	// Usecases
-	validateUC := usecase.NewValidateSession(kratosGateway, sessionCache, slog.Default())
-	sessionUC := usecase.NewGetSession(kratosGateway, sessionCache, jwtIssuer, slog.Default())
-	csrfUC := usecase.NewGenerateCSRF(kratosGateway, csrfGenerator, slog.Default())
-	systemUserUC := usecase.NewGetSystemUser(kratosGateway, slog.Default())
+	readinessUC := usecase.NewCheckReadiness(readinessChecks, cfg.ReadyProbeTimeout, slog.Default())
+
+	// Session fingerprint binding: strictness is set per environment via
+	// SESSION_FINGERPRINT_MODE (report by default).
+	switch domain.FingerprintMode(cfg.FingerprintMode) {
+	case domain.FingerprintEnforce, domain.FingerprintReport:
+		slog.InfoContext(ctx, "session fingerprint binding enabled",
+			"mode", cfg.FingerprintMode,
+			"ipv4_prefix", cfg.FingerprintIPv4Prefix,
+			"ipv6_prefix", cfg.FingerprintIPv6Prefix,
+			"binding_ttl", cfg.FingerprintTTL)
+	default:
+		slog.WarnContext(ctx, "session fingerprint binding disabled: SESSION_FINGERPRINT_MODE=off; stolen session cookies are accepted from any client")
+	}
 
 	// Handlers
-	validateHandler := adapterhandler.NewValidateHandler(validateUC, jwtIssuer)
-	sessionHandler := adapterhandler.NewSessionHandler(sessionUC)
-	csrfHandler := adapterhandler.NewCSRFHandler(csrfUC)
 	healthHandler := adapterhandler.NewHealthHandler()
-	internalHandler := adapterhandler.NewInternalHandler(systemUserUC)
+	readyHandler := adapterhandler.NewReadyHandler(readinessUC)
 
 	// Setup Echo server
 	e := echo.New()
 	e.HideBanner = true
 	e.HidePort = true
+	e.HTTPErrorHandler = adapterhandler.HTTPErrorHandler
 
 	// Only trust X-Forwarded-For from the internal nginx reverse proxy (private/
 	// link-local ranges); RateLimiter keys on c.RealIP(), so an untrusted XFF
@@ -113,7 +171,8 @@ func main() {
 	// Request logging
 	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		Skipper: func(c echo.Context) bool {
-			return c.Request().URL.Path == "/health"
+			path := c.Request().URL.Path
+			return path == "/health" || path == "/ready"
 		},
 		LogStatus:   true,
 		LogURI:      true,
@@ -161,20 +220,157 @@ func main() {
 	csrfRL := appmiddleware.NewRateLimiter(rate.Limit(cfg.CSRFRateLimit), csrfBurst)
 	internalRL := appmiddleware.NewRateLimiter(10.0/60.0, 3) // 10 req/min
 
-	// Public routes
-	e.GET("/validate", validateHandler.Handle, validateRL.Middleware())
-	e.GET("/session", sessionHandler.Handle, sessionRL.Middleware())
-	e.POST("/csrf", csrfHandler.Handle, csrfRL.Middleware())
+	// Abuse guards block IPs that keep failing session or CSRF checks, on
+	// top of the rate limits. Expired sessions are not counted: browsers
+	// keep sending a stale cookie until the user logs in again.
+	abuseGuard := func(endpoint string, threshold int, codes ...problem.Code) *appmiddleware.AbuseGuard {
+		return appmiddleware.NewAbuseGuard(appmiddleware.AbuseGuardConfig{
+			Endpoint:  endpoint,
+			Codes:     codes,
+			Threshold: threshold,
+			Window:    cfg.AbuseWindow,
+			BanBase:   cfg.AbuseBanBase,
+			BanMax:    cfg.AbuseBanMax,
+			StrikeTTL: cfg.AbuseStrikeTTL,
+		})
+	}
+	sessionFailures := []problem.Code{problem.AuthenticationRequired, problem.ReauthenticationRequired}
+	validateGuard := abuseGuard("validate", cfg.AbuseValidateThreshold, sessionFailures...)
+	sessionGuard := abuseGuard("session", cfg.AbuseSessionThreshold, sessionFailures...)
+	csrfGuard := abuseGuard("csrf", cfg.AbuseCSRFThreshold, problem.CSRFMismatch)
+	slog.InfoContext(ctx, "abuse guards enabled",
+		"validate_threshold", cfg.AbuseValidateThreshold,
+		"session_threshold", cfg.AbuseSessionThreshold,
+		"csrf_threshold", cfg.AbuseCSRFThreshold,
+		"window", cfg.AbuseWindow.String(),
+		"ban_base", cfg.AbuseBanBase.String(),
+		"ban_max", cfg.AbuseBanMax.String())
+
+	// Process-level probes cover every tenant and are not tenant-scoped.
 	e.GET("/health", healthHandler.Handle)
+	e.GET("/ready", readyHandler.Handle)
+
+	// Everything else is served by the requesting tenant's stack; requests
+	// that resolve to no tenant are rejected.
+	tenantResolved := tenantResolver.Middleware()
 
-	// Internal routes (protected by shared secret)
+	// Public routes
+	e.GET("/validate", router.route(func(s *tenantStack) echo.HandlerFunc { return s.validate }), tenantResolved, validateRL.Middleware(), validateGuard.Middleware())
+	e.GET("/session", router.route(func(s *tenantStack) echo.HandlerFunc { return s.session }), tenantResolved, sessionRL.Middleware(), sessionGuard.Middleware())
+	e.POST("/csrf", router.route(func(s *tenantStack) echo.HandlerFunc { return s.csrf }), tenantResolved, csrfRL.Middleware(), sessionGuard.Middleware())
+
+	// Password policy, checked by the frontend before it submits a Kratos
+	// registration or settings flow. Limits are set per environment.
+	passwordBurst := int(cfg.PasswordCheckRate * 10)
+	if passwordBurst < 10 {
+		passwordBurst = 10
+	}
+	passwordRL := appmiddleware.NewRateLimiter(rate.Limit(cfg.PasswordCheckRate), passwordBurst)
+	e.POST("/password/check", router.route(func(s *tenantStack) echo.HandlerFunc { return s.passwordCheck }), tenantResolved, passwordRL.Middleware())
+	e.GET("/password/policy", router.route(func(s *tenantStack) echo.HandlerFunc { return s.passwordPolicy }), tenantResolved)
+	slog.InfoContext(ctx, "password policy enabled",
+		"min_length", cfg.PasswordMinLength,
+		"required_classes", cfg.PasswordRequiredClasses,
+		"breach_check", cfg.PasswordBreachCheck,
+		"history_size", cfg.PasswordHistorySize)
+
+	// Accounts linked for Google/GitHub sign-in. Linking runs through
+	// Kratos' settings flow; unlinking is CSRF-protected and needs a recent
+	// sign-in (LINKED_ACCOUNT_REAUTH_MAX_AGE).
+	e.GET("/linked-accounts", router.route(func(s *tenantStack) echo.HandlerFunc { return s.linkedAccounts }), tenantResolved, sessionRL.Middleware(), sessionGuard.Middleware())
+	e.DELETE("/linked-accounts/:provider", router.route(func(s *tenantStack) echo.HandlerFunc { return s.unlinkAccount }), tenantResolved, sessionRL.Middleware(), sessionGuard.Middleware(), csrfGuard.Middleware())
+
+	// Internal routes (protected by the tenant's shared secret, applied in
+	// its stack)
 	internalGroup := e.Group("/internal",
+		tenantResolved,
 		internalRL.Middleware(),
 	)
-	if cfg.BackendTokenSecret != "" {
-		internalGroup.Use(appmiddleware.InternalAuth(cfg.BackendTokenSecret))
+	internalGroup.GET("/system-user", router.route(func(s *tenantStack) echo.HandlerFunc { return s.systemUser }))
+
+	// RFC 8693 token exchange for service-to-service delegation. Outside the
+	// internal group for the same reason as the webhook: services exchange
+	// per outgoing call, far above 10 req/min.
+	exchangeBurst := int(cfg.TokenExchangeRate * 10)
+	if exchangeBurst < 10 {
+		exchangeBurst = 10
+	}
+	exchangeRL := appmiddleware.NewRateLimiter(rate.Limit(cfg.TokenExchangeRate), exchangeBurst)
+	e.POST("/internal/token/exchange", router.route(func(s *tenantStack) echo.HandlerFunc { return s.tokenExchange }),
+		tenantResolved,
+		exchangeRL.Middleware(),
+	)
+	audiences := []string{cfg.BackendTokenAudience}
+	for _, a := range cfg.ServiceTokens {
+		audiences = append(audiences, a.Audience)
+	}
+	slog.InfoContext(ctx, "token exchange enabled",
+		"path", "/internal/token/exchange",
+		"audiences", audiences,
+		"rate_limit", cfg.TokenExchangeRate)
+
+	// Service accounts: the admin API sits in the internal group behind the
+	// tenant's shared secret; the token endpoint authenticates with each
+	// account's own secret and shares the token exchange rate limit.
+	if cfg.ServiceAccountsDir != "" {
+		internalGroup.POST("/service-accounts", router.route(func(s *tenantStack) echo.HandlerFunc { return s.serviceAccountCreate }))
+		internalGroup.GET("/service-accounts", router.route(func(s *tenantStack) echo.HandlerFunc { return s.serviceAccountList }))
+		internalGroup.GET("/service-accounts/:id", router.route(func(s *tenantStack) echo.HandlerFunc { return s.serviceAccountGet }))
+		internalGroup.POST("/service-accounts/:id/rotate", router.route(func(s *tenantStack) echo.HandlerFunc { return s.serviceAccountRotate }))
+		internalGroup.POST("/service-accounts/:id/disable", router.route(func(s *tenantStack) echo.HandlerFunc { return s.serviceAccountDisable }))
+		e.POST("/internal/token", router.route(func(s *tenantStack) echo.HandlerFunc { return s.serviceAccountToken }),
+			tenantResolved,
+			exchangeRL.Middleware(),
+		)
+		slog.InfoContext(ctx, "service accounts enabled",
+			"dir", cfg.ServiceAccountsDir,
+			"token_path", "/internal/token")
+	}
+
+	// Kratos lifecycle webhooks. Registered outside the internal group: they
+	// authenticate with their own secret and a burst of logouts must not be
+	// throttled at 10 req/min.
+	if webhookEnabled {
+		hookBurst := int(cfg.KratosWebhookRate * 10)
+		if hookBurst < 10 {
+			hookBurst = 10
+		}
+		hookRL := appmiddleware.NewRateLimiter(rate.Limit(cfg.KratosWebhookRate), hookBurst)
+		e.POST("/internal/hooks/kratos", router.route(func(s *tenantStack) echo.HandlerFunc { return s.kratosHook }),
+			tenantResolved,
+			hookRL.Middleware(),
+		)
+		// Password policy enforcement for registration and settings flows.
+		e.POST("/internal/hooks/kratos/password", router.route(func(s *tenantStack) echo.HandlerFunc { return s.passwordHook }),
+			tenantResolved,
+			hookRL.Middleware(),
+		)
+		slog.InfoContext(ctx, "kratos lifecycle webhook enabled",
+			"path", "/internal/hooks/kratos",
+			"password_path", "/internal/hooks/kratos/password",
+			"rate_limit", cfg.KratosWebhookRate)
+	} else {
+		slog.WarnContext(ctx, "kratos lifecycle webhook disabled: no KRATOS_WEBHOOK_SECRET (or tenant kratos_webhook_secret) set; cached sessions expire only by CACHE_TTL")
+	}
+
+	// Login alert links. Opened from an email, so they carry no session: the
+	// signed token is the credential. POST is what mail clients send for
+	// one-click unsubscribe (RFC 8058).
+	if cfg.LoginAlertEnabled {
+		loginAlertRL := appmiddleware.NewRateLimiter(10.0/60.0, 5) // 10 req/min
+		revoke := router.route(func(s *tenantStack) echo.HandlerFunc { return s.loginAlertRevoke })
+		optOut := router.route(func(s *tenantStack) echo.HandlerFunc { return s.loginAlertOptOut })
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			e.Add(method, "/login-alerts/revoke", revoke, tenantResolved, loginAlertRL.Middleware())
+			e.Add(method, "/login-alerts/opt-out", optOut, tenantResolved, loginAlertRL.Middleware())
+		}
+		slog.InfoContext(ctx, "login alerts enabled",
+			"link_ttl", cfg.LoginAlertLinkTTL,
+			"known_device_ttl", cfg.LoginAlertKnownDeviceTTL)
+		if !webhookEnabled {
+			slog.WarnContext(ctx, "login alerts enabled but the kratos lifecycle webhook is disabled; no alerts will be sent")
+		}
 	}
-	internalGroup.GET("/system-user", internalHandler.HandleSystemUser)
 
 	// Start server with errgroup for graceful shutdown
 	address := fmt.Sprintf(":%s", cfg.Port)
@@ -189,6 +385,23 @@ func main() {
 		return nil
 	})
 
+	// Connect-RPC listener for Go services (services.auth.v1.AuthService).
+	// HTTP/2 without TLS (h2c) for internal traffic on the compose network.
+	connectServer := &http.Server{
+		Addr:              ":" + cfg.ConnectPort,
+		Handler:           h2c.NewHandler(router.rpcHandler(tenantResolver), &http2.Server{}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	g.Go(func() error {
+		slog.InfoContext(ctx, "starting auth-hub connect-rpc server",
+			"address", connectServer.Addr,
+			"rate_limit", cfg.ConnectRate)
+		if err := connectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+
 	// Optional mTLS HTTPS listener mirroring the Echo handler.
 	// ClientAuth defaults to NoClientCert; enabled by MTLS_LISTEN=true.
 	var mtlsServer *http.Server
@@ -227,6 +440,7 @@ func main() {
 		if mtlsServer != nil {
 			_ = mtlsServer.Shutdown(shutdownCtx)
 		}
+		_ = connectServer.Shutdown(shutdownCtx)
 		return e.Shutdown(shutdownCtx)
 	})
 
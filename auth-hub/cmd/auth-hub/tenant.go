@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"auth-hub/config"
+	"auth-hub/internal/adapter/gateway"
+	adapterhandler "auth-hub/internal/adapter/handler"
+	"auth-hub/internal/adapter/rpc"
+	"auth-hub/internal/domain"
+	infrabreach "auth-hub/internal/infrastructure/breach"
+	infracache "auth-hub/internal/infrastructure/cache"
+	infrastore "auth-hub/internal/infrastructure/store"
+	infratoken "auth-hub/internal/infrastructure/token"
+	"auth-hub/internal/usecase"
+	appmiddleware "auth-hub/middleware"
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// tenantStack is everything auth-hub builds per Alt instance: the Kratos
+// gateway, session and fingerprint caches, signing keys and the handlers
+// that use them. Nothing in a stack is shared with another tenant.
+type tenantStack struct {
+	validate       echo.HandlerFunc
+	session        echo.HandlerFunc
+	csrf           echo.HandlerFunc
+	systemUser     echo.HandlerFunc
+	tokenExchange  echo.HandlerFunc
+	kratosHook     echo.HandlerFunc // nil when the tenant has no webhook secret
+	passwordHook   echo.HandlerFunc // nil when the tenant has no webhook secret
+	passwordCheck  echo.HandlerFunc
+	passwordPolicy echo.HandlerFunc
+	linkedAccounts echo.HandlerFunc
+	unlinkAccount  echo.HandlerFunc
+	// Login alert links; nil unless LOGIN_ALERT_ENABLED is on.
+	loginAlertRevoke echo.HandlerFunc
+	loginAlertOptOut echo.HandlerFunc
+	// Service account admin API and client credentials token endpoint;
+	// nil unless SERVICE_ACCOUNTS_DIR is set.
+	serviceAccountCreate  echo.HandlerFunc
+	serviceAccountList    echo.HandlerFunc
+	serviceAccountGet     echo.HandlerFunc
+	serviceAccountRotate  echo.HandlerFunc
+	serviceAccountDisable echo.HandlerFunc
+	serviceAccountToken   echo.HandlerFunc
+	// Connect-RPC AuthService, served on CONNECT_PORT.
+	rpc http.Handler
+
+	readiness []usecase.ReadinessCheck
+}
+
+// tenantSettings are the per-tenant values a stack is built from; the rest
+// comes from the instance-wide config.
+type tenantSettings struct {
+	id                  string
+	kratosURL           string
+	kratosAdminURL      string
+	csrfSecret          string
+	backendTokenSecret  string
+	kratosWebhookSecret string
+	loginAlertPublicURL string
+}
+
+// tenantSettingsFromConfig returns one entry per configured tenant, or a
+// single DefaultTenantID entry built from the instance-wide settings.
+func tenantSettingsFromConfig(cfg *config.Config) []tenantSettings {
+	if len(cfg.Tenants) == 0 {
+		return []tenantSettings{{
+			id:                  appmiddleware.DefaultTenantID,
+			kratosURL:           cfg.KratosURL,
+			kratosAdminURL:      cfg.KratosAdminURL,
+			csrfSecret:          cfg.CSRFSecret,
+			backendTokenSecret:  cfg.BackendTokenSecret,
+			kratosWebhookSecret: cfg.KratosWebhookSecret,
+			loginAlertPublicURL: cfg.LoginAlertPublicURL,
+		}}
+	}
+	out := make([]tenantSettings, 0, len(cfg.Tenants))
+	for _, t := range cfg.Tenants {
+		publicURL := t.LoginAlertPublicURL
+		if publicURL == "" {
+			publicURL = cfg.LoginAlertPublicURL
+		}
+		out = append(out, tenantSettings{
+			id:                  t.ID,
+			kratosURL:           t.KratosURL,
+			kratosAdminURL:      t.KratosAdminURL,
+			csrfSecret:          t.CSRFSecret,
+			backendTokenSecret:  t.BackendTokenSecret,
+			kratosWebhookSecret: t.KratosWebhookSecret,
+			loginAlertPublicURL: publicURL,
+		})
+	}
+	return out
+}
+
+// newTenantStack builds the stack for t. breachChecker and notifier are
+// shared: they hold no tenant data. notifier is nil when login alerts are
+// off. rpcLimiter is the Connect-RPC budget shared by every tenant.
+func newTenantStack(cfg *config.Config, t tenantSettings, checkPrefix string, breachChecker *infrabreach.PwnedPasswords, notifier domain.NotificationGateway, rpcLimiter *rate.Limiter) (*tenantStack, error) {
+	logger := slog.Default().With("tenant_id", t.id)
+
+	// Infrastructure
+	sessionCache := infracache.NewSessionCacheWithStaleTTL(cfg.CacheTTL, cfg.CacheStaleTTL)
+	kratosGateway := gateway.NewKratosGateway(t.kratosURL, t.kratosAdminURL, 5*time.Second)
+	serviceAudiences := make([]infratoken.AudienceProfile, 0, len(cfg.ServiceTokens))
+	for _, a := range cfg.ServiceTokens {
+		serviceAudiences = append(serviceAudiences, infratoken.AudienceProfile{Audience: a.Audience, TTL: a.TTL, Scopes: a.Scopes})
+	}
+	jwtIssuer := infratoken.NewJWTIssuer(infratoken.JWTConfig{
+		Secret:   t.backendTokenSecret,
+		Issuer:   cfg.BackendTokenIssuer,
+		Audience: cfg.BackendTokenAudience,
+		TTL:      cfg.BackendTokenTTL,
+		Services: serviceAudiences,
+	})
+	csrfGenerator := infratoken.NewHMACCSRFGenerator(t.csrfSecret)
+	fingerprintStore := infracache.NewFingerprintStore(cfg.FingerprintTTL)
+
+	// Usecases
+	validateUC := usecase.NewValidateSession(kratosGateway, sessionCache, logger)
+	sessionUC := usecase.NewGetSession(kratosGateway, sessionCache, jwtIssuer, logger).WithServiceTokens(jwtIssuer)
+	exchangeUC := usecase.NewExchangeToken(jwtIssuer, logger)
+	csrfUC := usecase.NewGenerateCSRF(kratosGateway, csrfGenerator, logger)
+	systemUserUC := usecase.NewGetSystemUser(kratosGateway, logger)
+	invalidateUC := usecase.NewInvalidateSessions(sessionCache, logger)
+	if cfg.IdentityCacheTTL > 0 {
+		identityCache := infracache.NewIdentityCache(cfg.IdentityCacheTTL)
+		systemUserUC.WithCache(identityCache)
+		// Traits are reloaded through the admin API, so they are only
+		// cached apart from sessions when the tenant has one.
+		if t.kratosAdminURL != "" {
+			validateUC.WithIdentityCache(identityCache, kratosGateway)
+			sessionUC.WithIdentityCache(identityCache, kratosGateway)
+			invalidateUC.WithIdentityCache(identityCache)
+		}
+	}
+	fingerprintUC := usecase.NewCheckFingerprint(fingerprintStore, kratosGateway, usecase.FingerprintPolicy{
+		Mode:       domain.FingerprintMode(cfg.FingerprintMode),
+		IPv4Prefix: cfg.FingerprintIPv4Prefix,
+		IPv6Prefix: cfg.FingerprintIPv6Prefix,
+	}, logger)
+	checkPasswordUC := usecase.NewCheckPassword(passwordPolicyFromConfig(cfg), kratosGateway, breachChecker, kratosGateway, logger)
+	passwordHistoryUC := usecase.NewRecordPasswordHistory(kratosGateway, cfg.PasswordHistorySize, logger)
+	linkedAccountsUC := usecase.NewManageLinkedAccounts(kratosGateway, kratosGateway, csrfGenerator, cfg.LinkedAccountReauthMaxAge, logger)
+
+	var (
+		fingerprintGuard *adapterhandler.FingerprintGuard
+		rpcFingerprint   *usecase.CheckFingerprint
+	)
+	switch domain.FingerprintMode(cfg.FingerprintMode) {
+	case domain.FingerprintEnforce, domain.FingerprintReport:
+		fingerprintGuard = adapterhandler.NewFingerprintGuard(fingerprintUC, cfg.StepUpURL)
+		rpcFingerprint = fingerprintUC
+	}
+
+	// Handlers. Internal endpoints authenticate with the tenant's own
+	// secrets, so one tenant's services cannot call another's.
+	internalAuth := appmiddleware.InternalAuth(t.backendTokenSecret)
+	passwordPolicyHandler := adapterhandler.NewPasswordPolicyHandler(checkPasswordUC)
+	linkedAccountsHandler := adapterhandler.NewLinkedAccountsHandler(linkedAccountsUC)
+	stack := &tenantStack{
+		validate:       adapterhandler.NewValidateHandler(validateUC, jwtIssuer, fingerprintGuard).Handle,
+		session:        adapterhandler.NewSessionHandler(sessionUC, fingerprintGuard).Handle,
+		csrf:           adapterhandler.NewCSRFHandler(csrfUC).Handle,
+		systemUser:     internalAuth(adapterhandler.NewInternalHandler(systemUserUC).HandleSystemUser),
+		tokenExchange:  internalAuth(adapterhandler.NewTokenExchangeHandler(exchangeUC).Handle),
+		passwordCheck:  passwordPolicyHandler.Check,
+		passwordPolicy: passwordPolicyHandler.Policy,
+		linkedAccounts: linkedAccountsHandler.List,
+		unlinkAccount:  linkedAccountsHandler.Unlink,
+		rpc: rpc.NewHandler(
+			rpc.NewAuthService(validateUC, sessionUC, csrfUC, jwtIssuer, rpcFingerprint),
+			t.backendTokenSecret,
+			rpcLimiter,
+		),
+		readiness: []usecase.ReadinessCheck{
+			{Name: checkPrefix + domain.DependencyKratos, Probe: kratosGateway, FailureThreshold: cfg.ReadyFailureThreshold},
+			{Name: checkPrefix + domain.DependencySessionCache, Probe: sessionCache, FailureThreshold: cfg.ReadyFailureThreshold},
+			{Name: checkPrefix + domain.DependencySigningKey, Probe: jwtIssuer, FailureThreshold: cfg.ReadyFailureThreshold},
+		},
+	}
+	var notifyLoginUC *usecase.NotifyLogin
+	if notifier != nil {
+		// Links are signed with a key derived from the tenant's backend
+		// token secret, so they only work on the tenant that sent them.
+		linkSigner := infratoken.NewHMACLoginAlertSigner(t.backendTokenSecret)
+		notifyLoginUC = usecase.NewNotifyLogin(infracache.NewKnownDeviceStore(cfg.LoginAlertKnownDeviceTTL), kratosGateway, linkSigner, notifier, usecase.LoginAlertPolicy{
+			PublicURL:  t.loginAlertPublicURL,
+			LinkTTL:    cfg.LoginAlertLinkTTL,
+			IPv4Prefix: cfg.FingerprintIPv4Prefix,
+			IPv6Prefix: cfg.FingerprintIPv6Prefix,
+		}, logger)
+		loginAlertHandler := adapterhandler.NewLoginAlertHandler(usecase.NewApplyLoginAlertLink(linkSigner, kratosGateway, kratosGateway, sessionCache, logger))
+		stack.loginAlertRevoke = loginAlertHandler.Revoke
+		stack.loginAlertOptOut = loginAlertHandler.OptOut
+	}
+
+	if t.kratosWebhookSecret != "" {
+		hook := adapterhandler.NewKratosHookHandler(invalidateUC).WithPasswordHistory(passwordHistoryUC)
+		if notifyLoginUC != nil {
+			hook = hook.WithLoginAlerts(notifyLoginUC)
+		}
+		webhookAuth := appmiddleware.InternalAuth(t.kratosWebhookSecret)
+		stack.kratosHook = webhookAuth(hook.Handle)
+		stack.passwordHook = webhookAuth(passwordPolicyHandler.Enforce)
+	}
+
+	if cfg.ServiceAccountsDir != "" {
+		// One file per tenant, so accounts and their tokens never cross
+		// instances.
+		accounts, err := infrastore.NewServiceAccountFile(filepath.Join(cfg.ServiceAccountsDir, t.id+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: load service accounts: %w", t.id, err)
+		}
+		serviceAccountHandler := adapterhandler.NewServiceAccountHandler(
+			usecase.NewManageServiceAccounts(accounts, serviceAccountAudiences(cfg), logger),
+			usecase.NewIssueServiceAccountToken(accounts, jwtIssuer, logger),
+		)
+		stack.serviceAccountCreate = internalAuth(serviceAccountHandler.Create)
+		stack.serviceAccountList = internalAuth(serviceAccountHandler.List)
+		stack.serviceAccountGet = internalAuth(serviceAccountHandler.Get)
+		stack.serviceAccountRotate = internalAuth(serviceAccountHandler.Rotate)
+		stack.serviceAccountDisable = internalAuth(serviceAccountHandler.Disable)
+		// The token endpoint authenticates with the account's own secret.
+		stack.serviceAccountToken = serviceAccountHandler.Token
+	}
+	return stack, nil
+}
+
+// serviceAccountAudiences maps every audience tokens are issued for to the
+// scopes it allows; service accounts can be granted any of them.
+func serviceAccountAudiences(cfg *config.Config) map[string][]string {
+	audiences := map[string][]string{cfg.BackendTokenAudience: nil}
+	for _, a := range cfg.ServiceTokens {
+		audiences[a.Audience] = a.Scopes
+	}
+	return audiences
+}
+
+func passwordPolicyFromConfig(cfg *config.Config) domain.PasswordPolicy {
+	policy := domain.PasswordPolicy{
+		MinLength:     cfg.PasswordMinLength,
+		CheckBreached: cfg.PasswordBreachCheck,
+		HistorySize:   cfg.PasswordHistorySize,
+	}
+	for _, class := range cfg.PasswordRequiredClasses {
+		policy.RequiredClasses = append(policy.RequiredClasses, domain.CharClass(class))
+	}
+	return policy
+}
+
+// tenantRouter dispatches each request to the stack of the tenant resolved
+// by TenantResolver.Middleware.
+type tenantRouter map[string]*tenantStack
+
+func (r tenantRouter) route(pick func(*tenantStack) echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		stack, ok := r[appmiddleware.TenantID(c)]
+		if !ok {
+			return echo.NewHTTPError(http.StatusNotFound,
+				problem.New(http.StatusNotFound, problem.UnknownTenant, "unknown tenant"))
+		}
+		h := pick(stack)
+		if h == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "not enabled for this tenant")
+		}
+		return h(c)
+	}
+}
+
+// rpcHandler dispatches Connect-RPC calls to the stack of the tenant the
+// request resolves to. The Connect listener has no Echo context, so the
+// tenant is resolved here rather than by TenantResolver.Middleware.
+func (r tenantRouter) rpcHandler(resolver *appmiddleware.TenantResolver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id, resolved := resolver.Resolve(req)
+		stack, ok := r[id]
+		if !resolved || !ok {
+			http.Error(w, "unknown tenant", http.StatusNotFound)
+			return
+		}
+		stack.rpc.ServeHTTP(w, req)
+	})
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -14,6 +16,8 @@ type Config struct {
 	KratosAdminURL       string        // Kratos Admin API URL (port 4434)
 	Port                 string        // Service port
 	CacheTTL             time.Duration // Session cache TTL
+	CacheStaleTTL        time.Duration // Serve-stale window past CacheTTL while refreshing (0 disables)
+	IdentityCacheTTL     time.Duration // Identity traits and system user cache TTL (default: 30m, 0 disables)
 	CSRFSecret           string        // CSRF secret for token generation
 	BackendTokenSecret   string        // Secret for signing backend JWT tokens
 	BackendTokenIssuer   string        // JWT issuer claim
@@ -22,8 +26,86 @@ type Config struct {
 	ValidateRateLimit    float64       // Validate endpoint: requests per second (default: 100/60 ≈ 1.67)
 	SessionRateLimit     float64       // Session endpoint: requests per second (default: 30/60 = 0.5)
 	CSRFRateLimit        float64       // CSRF endpoint: requests per second (default: 100)
+	KratosWebhookSecret  string        // Shared secret Kratos sends on lifecycle webhooks (empty disables the endpoint)
+	KratosWebhookRate    float64       // Kratos webhook endpoint: requests per second (default: 10)
+
+	FingerprintMode       string        // Session fingerprint binding: off, report or enforce (default: report)
+	FingerprintIPv4Prefix int           // IPv4 prefix length hashed into the fingerprint (default: 24)
+	FingerprintIPv6Prefix int           // IPv6 prefix length hashed into the fingerprint (default: 64)
+	FingerprintTTL        time.Duration // How long a binding is kept (default: 24h, the Kratos session lifespan)
+	StepUpURL             string        // Where clients re-authenticate after a fingerprint mismatch
+
+	ServiceTokens     []ServiceTokenAudience // Downstream audiences service tokens can be issued for, besides the backend one
+	TokenExchangeRate float64                // Token exchange endpoint: requests per second (default: 20)
+
+	ServiceAccountsDir string // Directory holding each tenant's service accounts as <tenant id>.json (empty disables service accounts)
+
+	ConnectPort string  // Connect-RPC (h2c) listener port for Go services (default: 8889)
+	ConnectRate float64 // Connect-RPC calls across all tenants: requests per second (default: 50)
+
+	PasswordMinLength       int      // Minimum password length in characters (default: 12)
+	PasswordRequiredClasses []string // Character classes every password needs: lower, upper, digit, symbol (default: lower,upper,digit)
+	PasswordBreachCheck     bool     // Reject passwords found in Pwned Passwords (default: true)
+	PasswordBreachAPIURL    string   // Pwned Passwords range API base URL
+	PasswordHistorySize     int      // Recent passwords, current included, that cannot be reused (default: 5, 0 disables)
+	PasswordCheckRate       float64  // Password check endpoint: requests per second (default: 5)
+
+	LinkedAccountReauthMaxAge time.Duration // How recently the user must have signed in to unlink a provider (default: 10m, the Kratos privileged session age)
+
+	AbuseValidateThreshold int           // Failed /validate session checks from one IP within AbuseWindow before it is blocked (default: 50, 0 disables)
+	AbuseSessionThreshold  int           // Failed session checks on /session, /csrf and /linked-accounts from one IP before it is blocked (default: 20, 0 disables)
+	AbuseCSRFThreshold     int           // CSRF mismatches from one IP before it is blocked (default: 5, 0 disables)
+	AbuseWindow            time.Duration // How far back failures are counted (default: 5m)
+	AbuseBanBase           time.Duration // First block's duration, doubled for each repeat (default: 1m)
+	AbuseBanMax            time.Duration // Longest block (default: 1h)
+	AbuseStrikeTTL         time.Duration // How long a block counts towards a longer next one (default: 24h)
+
+	ReadyFailureThreshold int           // Consecutive failed probes before a dependency marks /ready unavailable (default: 3)
+	ReadyProbeTimeout     time.Duration // Timeout for each dependency probe on /ready (default: 2s)
+
+	LoginAlertEnabled        bool          // Email users about logins from new devices or locations (default: false)
+	LoginAlertPublicURL      string        // Browser-reachable base URL of the /login-alerts link endpoints
+	LoginAlertLinkTTL        time.Duration // How long the revoke and opt-out links in an alert work (default: 72h)
+	LoginAlertKnownDeviceTTL time.Duration // How long a device or location stays known after its last login (default: 90 days)
+	LoginAlertSMTPURL        string        // smtp:// or smtps:// URL, with credentials, alerts are sent through
+	LoginAlertFrom           string        // Sender address of alert emails
+
+	Tenants      []Tenant // Alt instances fronted by this deployment, from TENANTS_FILE (empty: single instance from the settings above)
+	TenantHeader string   // Header the reverse proxy sets to the tenant ID (default: X-Auth-Hub-Tenant)
+}
+
+// Tenant is one Alt instance fronted by a shared auth-hub. Each tenant has
+// its own Kratos, its own signing secrets and its own caches, so a session
+// or token from one instance is never accepted by another. Issuer, audience
+// and token TTLs are shared. Not to be confused with the per-user tenant_id
+// token claim.
+type Tenant struct {
+	ID                     string   `json:"id"`
+	Hosts                  []string `json:"hosts"`
+	KratosURL              string   `json:"kratos_url"`
+	KratosAdminURL         string   `json:"kratos_admin_url"`
+	CSRFSecret             string   `json:"csrf_secret"`
+	CSRFSecretFile         string   `json:"csrf_secret_file"`
+	BackendTokenSecret     string   `json:"backend_token_secret"`
+	BackendTokenSecretFile string   `json:"backend_token_secret_file"`
+	KratosWebhookSecret    string   `json:"kratos_webhook_secret"`
+	// LoginAlertPublicURL overrides the instance-wide LOGIN_ALERT_PUBLIC_URL,
+	// since alert links must point at the tenant's own host.
+	LoginAlertPublicURL string `json:"login_alert_public_url"`
+}
+
+// ServiceTokenAudience configures the audience-scoped tokens issued for one
+// downstream service, in addition to the alt-backend token.
+type ServiceTokenAudience struct {
+	Audience string
+	TTL      time.Duration
+	Scopes   []string
 }
 
+// maxServiceTokenTTL caps service token lifetimes; they are bearer tokens
+// that cannot be revoked before expiry.
+const maxServiceTokenTTL = time.Hour
+
 // Load reads configuration from environment variables with sensible defaults
 func Load() (*Config, error) {
 	config := &Config{
@@ -31,6 +113,8 @@ func Load() (*Config, error) {
 		KratosAdminURL:       getEnv("KRATOS_ADMIN_URL", "http://kratos:4434"),
 		Port:                 getEnv("PORT", "8888"),
 		CacheTTL:             5 * time.Minute, // Default 5 minutes
+		CacheStaleTTL:        30 * time.Second,
+		IdentityCacheTTL:     30 * time.Minute,
 		CSRFSecret:           getEnv("CSRF_SECRET", ""),
 		BackendTokenSecret:   getEnv("BACKEND_TOKEN_SECRET", ""),
 		BackendTokenIssuer:   getEnv("BACKEND_TOKEN_ISSUER", "auth-hub"),
@@ -39,6 +123,47 @@ func Load() (*Config, error) {
 		ValidateRateLimit:    100.0 / 60.0,    // Default: ~1.67 req/s (100 req/min)
 		SessionRateLimit:     30.0 / 60.0,     // Default: 0.5 req/s (30 req/min)
 		CSRFRateLimit:        100.0,           // Default: 100 req/s
+		KratosWebhookSecret:  getEnv("KRATOS_WEBHOOK_SECRET", ""),
+		KratosWebhookRate:    10.0, // Default: 10 req/s
+		TokenExchangeRate:    20.0, // Default: 20 req/s
+		ServiceAccountsDir:   getEnv("SERVICE_ACCOUNTS_DIR", ""),
+
+		ConnectPort: getEnv("CONNECT_PORT", "8889"),
+		ConnectRate: 50.0,
+
+		FingerprintMode:       getEnv("SESSION_FINGERPRINT_MODE", "report"),
+		FingerprintIPv4Prefix: 24,
+		FingerprintIPv6Prefix: 64,
+		FingerprintTTL:        24 * time.Hour,
+		StepUpURL:             getEnv("SESSION_STEP_UP_URL", "/ory/self-service/login/browser?refresh=true"),
+
+		PasswordMinLength:       12,
+		PasswordRequiredClasses: []string{"lower", "upper", "digit"},
+		PasswordBreachCheck:     true,
+		PasswordBreachAPIURL:    getEnv("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com"),
+		PasswordHistorySize:     5,
+		PasswordCheckRate:       5.0,
+
+		LinkedAccountReauthMaxAge: 10 * time.Minute,
+
+		AbuseValidateThreshold: 50,
+		AbuseSessionThreshold:  20,
+		AbuseCSRFThreshold:     5,
+		AbuseWindow:            5 * time.Minute,
+		AbuseBanBase:           time.Minute,
+		AbuseBanMax:            time.Hour,
+		AbuseStrikeTTL:         24 * time.Hour,
+
+		ReadyFailureThreshold: 3,
+		ReadyProbeTimeout:     2 * time.Second,
+
+		LoginAlertPublicURL:      getEnv("LOGIN_ALERT_PUBLIC_URL", ""),
+		LoginAlertLinkTTL:        72 * time.Hour,
+		LoginAlertKnownDeviceTTL: 90 * 24 * time.Hour,
+		LoginAlertSMTPURL:        getEnv("LOGIN_ALERT_SMTP_URL", ""),
+		LoginAlertFrom:           getEnv("LOGIN_ALERT_FROM", ""),
+
+		TenantHeader: getEnv("TENANT_HEADER", "X-Auth-Hub-Tenant"),
 	}
 
 	// Parse CACHE_TTL if provided
@@ -50,6 +175,24 @@ func Load() (*Config, error) {
 		config.CacheTTL = duration
 	}
 
+	// Parse CACHE_STALE_TTL if provided
+	if staleTTLStr := os.Getenv("CACHE_STALE_TTL"); staleTTLStr != "" {
+		duration, err := time.ParseDuration(staleTTLStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CACHE_STALE_TTL format: %w", err)
+		}
+		config.CacheStaleTTL = duration
+	}
+
+	// Parse IDENTITY_CACHE_TTL if provided
+	if v := os.Getenv("IDENTITY_CACHE_TTL"); v != "" {
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IDENTITY_CACHE_TTL format: %w", err)
+		}
+		config.IdentityCacheTTL = duration
+	}
+
 	// Parse VALIDATE_RATE_LIMIT if provided (requests per second)
 	if v := os.Getenv("VALIDATE_RATE_LIMIT"); v != "" {
 		r, err := strconv.ParseFloat(v, 64)
@@ -77,6 +220,40 @@ func Load() (*Config, error) {
 		config.CSRFRateLimit = r
 	}
 
+	// Parse KRATOS_WEBHOOK_RATE_LIMIT if provided (requests per second)
+	if v := os.Getenv("KRATOS_WEBHOOK_RATE_LIMIT"); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KRATOS_WEBHOOK_RATE_LIMIT: %w", err)
+		}
+		config.KratosWebhookRate = r
+	}
+
+	// Parse SESSION_FINGERPRINT_IPV4_PREFIX / _IPV6_PREFIX if provided
+	if v := os.Getenv("SESSION_FINGERPRINT_IPV4_PREFIX"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SESSION_FINGERPRINT_IPV4_PREFIX: %w", err)
+		}
+		config.FingerprintIPv4Prefix = n
+	}
+	if v := os.Getenv("SESSION_FINGERPRINT_IPV6_PREFIX"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SESSION_FINGERPRINT_IPV6_PREFIX: %w", err)
+		}
+		config.FingerprintIPv6Prefix = n
+	}
+
+	// Parse SESSION_FINGERPRINT_TTL if provided
+	if v := os.Getenv("SESSION_FINGERPRINT_TTL"); v != "" {
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SESSION_FINGERPRINT_TTL format: %w", err)
+		}
+		config.FingerprintTTL = duration
+	}
+
 	// Parse BACKEND_TOKEN_TTL if provided
 	if ttlStr := os.Getenv("BACKEND_TOKEN_TTL"); ttlStr != "" {
 		duration, err := time.ParseDuration(ttlStr)
@@ -86,6 +263,174 @@ func Load() (*Config, error) {
 		config.BackendTokenTTL = duration
 	}
 
+	// Parse SERVICE_TOKEN_AUDIENCES if provided
+	if v := os.Getenv("SERVICE_TOKEN_AUDIENCES"); v != "" {
+		audiences, err := parseServiceTokenAudiences(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SERVICE_TOKEN_AUDIENCES: %w", err)
+		}
+		config.ServiceTokens = audiences
+	}
+
+	// Parse TOKEN_EXCHANGE_RATE_LIMIT if provided (requests per second)
+	if v := os.Getenv("TOKEN_EXCHANGE_RATE_LIMIT"); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TOKEN_EXCHANGE_RATE_LIMIT: %w", err)
+		}
+		config.TokenExchangeRate = r
+	}
+
+	// Parse CONNECT_RATE_LIMIT if provided (requests per second)
+	if v := os.Getenv("CONNECT_RATE_LIMIT"); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CONNECT_RATE_LIMIT: %w", err)
+		}
+		config.ConnectRate = r
+	}
+
+	// Parse password policy overrides (set per environment)
+	if v := os.Getenv("PASSWORD_MIN_LENGTH"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PASSWORD_MIN_LENGTH: %w", err)
+		}
+		config.PasswordMinLength = n
+	}
+	if v, ok := os.LookupEnv("PASSWORD_REQUIRED_CLASSES"); ok {
+		config.PasswordRequiredClasses = nil
+		for class := range strings.SplitSeq(v, ",") {
+			if class = strings.TrimSpace(class); class != "" {
+				config.PasswordRequiredClasses = append(config.PasswordRequiredClasses, class)
+			}
+		}
+	}
+	if v := os.Getenv("PASSWORD_BREACH_CHECK"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PASSWORD_BREACH_CHECK: %w", err)
+		}
+		config.PasswordBreachCheck = b
+	}
+	if v := os.Getenv("PASSWORD_HISTORY_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PASSWORD_HISTORY_SIZE: %w", err)
+		}
+		config.PasswordHistorySize = n
+	}
+	if v := os.Getenv("PASSWORD_CHECK_RATE_LIMIT"); v != "" {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PASSWORD_CHECK_RATE_LIMIT: %w", err)
+		}
+		config.PasswordCheckRate = r
+	}
+
+	if v := os.Getenv("LINKED_ACCOUNT_REAUTH_MAX_AGE"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LINKED_ACCOUNT_REAUTH_MAX_AGE format: %w", err)
+		}
+		config.LinkedAccountReauthMaxAge = d
+	}
+
+	if v := os.Getenv("ABUSE_VALIDATE_THRESHOLD"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABUSE_VALIDATE_THRESHOLD: %w", err)
+		}
+		config.AbuseValidateThreshold = n
+	}
+	if v := os.Getenv("ABUSE_SESSION_THRESHOLD"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABUSE_SESSION_THRESHOLD: %w", err)
+		}
+		config.AbuseSessionThreshold = n
+	}
+	if v := os.Getenv("ABUSE_CSRF_THRESHOLD"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABUSE_CSRF_THRESHOLD: %w", err)
+		}
+		config.AbuseCSRFThreshold = n
+	}
+	if v := os.Getenv("ABUSE_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABUSE_WINDOW format: %w", err)
+		}
+		config.AbuseWindow = d
+	}
+	if v := os.Getenv("ABUSE_BAN_BASE"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABUSE_BAN_BASE format: %w", err)
+		}
+		config.AbuseBanBase = d
+	}
+	if v := os.Getenv("ABUSE_BAN_MAX"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABUSE_BAN_MAX format: %w", err)
+		}
+		config.AbuseBanMax = d
+	}
+	if v := os.Getenv("ABUSE_STRIKE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABUSE_STRIKE_TTL format: %w", err)
+		}
+		config.AbuseStrikeTTL = d
+	}
+
+	if v := os.Getenv("READY_FAILURE_THRESHOLD"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid READY_FAILURE_THRESHOLD: %w", err)
+		}
+		config.ReadyFailureThreshold = n
+	}
+	if v := os.Getenv("READY_PROBE_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid READY_PROBE_TIMEOUT format: %w", err)
+		}
+		config.ReadyProbeTimeout = d
+	}
+
+	if v := os.Getenv("LOGIN_ALERT_ENABLED"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LOGIN_ALERT_ENABLED: %w", err)
+		}
+		config.LoginAlertEnabled = b
+	}
+	if v := os.Getenv("LOGIN_ALERT_LINK_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LOGIN_ALERT_LINK_TTL format: %w", err)
+		}
+		config.LoginAlertLinkTTL = d
+	}
+	if v := os.Getenv("LOGIN_ALERT_KNOWN_DEVICE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LOGIN_ALERT_KNOWN_DEVICE_TTL format: %w", err)
+		}
+		config.LoginAlertKnownDeviceTTL = d
+	}
+
+	if path := os.Getenv("TENANTS_FILE"); path != "" {
+		tenants, err := loadTenants(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TENANTS_FILE: %w", err)
+		}
+		config.Tenants = tenants
+	}
+
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return nil, err
@@ -108,24 +453,315 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("CACHE_TTL must be positive")
 	}
 
-	// CSRF_SECRET is required for security - no fallback to hardcoded values
-	if c.CSRFSecret == "" {
-		return fmt.Errorf("CSRF_SECRET is required")
+	// A stale session may outlive a Kratos logout by up to CACHE_STALE_TTL,
+	// so keep the window within one cache TTL.
+	if c.CacheStaleTTL < 0 || c.CacheStaleTTL > c.CacheTTL {
+		return fmt.Errorf("CACHE_STALE_TTL must be between 0 and CACHE_TTL")
+	}
+
+	if c.IdentityCacheTTL < 0 {
+		return fmt.Errorf("IDENTITY_CACHE_TTL cannot be negative")
+	}
+
+	// With tenants configured every request belongs to one of them, so the
+	// instance-wide secrets are unused and each tenant must bring its own.
+	if len(c.Tenants) > 0 {
+		if err := c.validateTenants(); err != nil {
+			return err
+		}
+	} else {
+		// CSRF_SECRET is required for security - no fallback to hardcoded values
+		if c.CSRFSecret == "" {
+			return fmt.Errorf("CSRF_SECRET is required")
+		}
+		if len(c.CSRFSecret) < 32 {
+			return fmt.Errorf("CSRF_SECRET must be at least 32 characters")
+		}
+
+		if c.BackendTokenSecret == "" {
+			return fmt.Errorf("BACKEND_TOKEN_SECRET is required")
+		}
+		if len(c.BackendTokenSecret) < 32 {
+			return fmt.Errorf("BACKEND_TOKEN_SECRET must be at least 32 characters")
+		}
+	}
+
+	seen := map[string]bool{c.BackendTokenAudience: true}
+	for _, a := range c.ServiceTokens {
+		if a.Audience == "" {
+			return fmt.Errorf("SERVICE_TOKEN_AUDIENCES: audience cannot be empty")
+		}
+		if seen[a.Audience] {
+			return fmt.Errorf("SERVICE_TOKEN_AUDIENCES: duplicate audience %q", a.Audience)
+		}
+		seen[a.Audience] = true
+		if a.TTL <= 0 || a.TTL > maxServiceTokenTTL {
+			return fmt.Errorf("SERVICE_TOKEN_AUDIENCES: TTL for %q must be between 0 and %s", a.Audience, maxServiceTokenTTL)
+		}
+	}
+
+	// KRATOS_WEBHOOK_SECRET is optional, but a configured one must be strong.
+	if c.KratosWebhookSecret != "" && len(c.KratosWebhookSecret) < 32 {
+		return fmt.Errorf("KRATOS_WEBHOOK_SECRET must be at least 32 characters")
+	}
+
+	// Fingerprint knobs only matter once binding is on; an unset mode
+	// (zero Config) means off.
+	switch c.FingerprintMode {
+	case "", "off":
+	case "report", "enforce":
+		if c.FingerprintIPv4Prefix < 0 || c.FingerprintIPv4Prefix > 32 {
+			return fmt.Errorf("SESSION_FINGERPRINT_IPV4_PREFIX must be between 0 and 32")
+		}
+		if c.FingerprintIPv6Prefix < 0 || c.FingerprintIPv6Prefix > 128 {
+			return fmt.Errorf("SESSION_FINGERPRINT_IPV6_PREFIX must be between 0 and 128")
+		}
+		if c.FingerprintTTL <= 0 {
+			return fmt.Errorf("SESSION_FINGERPRINT_TTL must be positive")
+		}
+	default:
+		return fmt.Errorf("SESSION_FINGERPRINT_MODE must be off, report or enforce")
+	}
+
+	// Kratos' bcrypt hasher uses at most 72 bytes, so a longer minimum
+	// would reject every password.
+	if c.PasswordMinLength < 0 || c.PasswordMinLength > 72 {
+		return fmt.Errorf("PASSWORD_MIN_LENGTH must be between 0 and 72")
+	}
+	for _, class := range c.PasswordRequiredClasses {
+		switch class {
+		case "lower", "upper", "digit", "symbol":
+		default:
+			return fmt.Errorf("PASSWORD_REQUIRED_CLASSES: unknown class %q (want lower, upper, digit or symbol)", class)
+		}
+	}
+	if c.PasswordBreachCheck && c.PasswordBreachAPIURL == "" {
+		return fmt.Errorf("PASSWORD_BREACH_API_URL cannot be empty when PASSWORD_BREACH_CHECK is on")
+	}
+	// Each remembered password costs one bcrypt comparison per check.
+	if c.PasswordHistorySize < 0 || c.PasswordHistorySize > 24 {
+		return fmt.Errorf("PASSWORD_HISTORY_SIZE must be between 0 and 24")
+	}
+
+	if c.LoginAlertEnabled {
+		if err := c.validateLoginAlerts(); err != nil {
+			return err
+		}
+	}
+
+	if c.LinkedAccountReauthMaxAge < 0 {
+		return fmt.Errorf("LINKED_ACCOUNT_REAUTH_MAX_AGE cannot be negative")
+	}
+
+	if err := c.validateAbuseGuard(); err != nil {
+		return err
+	}
+
+	// Readiness knobs; zero values (zero Config) fall back to the usecase
+	// defaults.
+	if c.ReadyFailureThreshold < 0 {
+		return fmt.Errorf("READY_FAILURE_THRESHOLD cannot be negative")
+	}
+	if c.ReadyProbeTimeout < 0 {
+		return fmt.Errorf("READY_PROBE_TIMEOUT cannot be negative")
+	}
+
+	return nil
+}
+
+// validateAbuseGuard checks the abuse guard settings. The durations only
+// matter once an endpoint has a threshold, so the zero Config stays valid.
+func (c *Config) validateAbuseGuard() error {
+	thresholds := []struct {
+		env   string
+		value int
+	}{
+		{"ABUSE_VALIDATE_THRESHOLD", c.AbuseValidateThreshold},
+		{"ABUSE_SESSION_THRESHOLD", c.AbuseSessionThreshold},
+		{"ABUSE_CSRF_THRESHOLD", c.AbuseCSRFThreshold},
+	}
+	enabled := false
+	for _, t := range thresholds {
+		if t.value < 0 {
+			return fmt.Errorf("%s cannot be negative", t.env)
+		}
+		enabled = enabled || t.value > 0
+	}
+	if !enabled {
+		return nil
 	}
-	if len(c.CSRFSecret) < 32 {
-		return fmt.Errorf("CSRF_SECRET must be at least 32 characters")
+	if c.AbuseWindow <= 0 {
+		return fmt.Errorf("ABUSE_WINDOW must be positive")
 	}
+	if c.AbuseBanBase <= 0 {
+		return fmt.Errorf("ABUSE_BAN_BASE must be positive")
+	}
+	if c.AbuseBanMax < c.AbuseBanBase {
+		return fmt.Errorf("ABUSE_BAN_MAX must be at least ABUSE_BAN_BASE")
+	}
+	if c.AbuseStrikeTTL < 0 {
+		return fmt.Errorf("ABUSE_STRIKE_TTL cannot be negative")
+	}
+	return nil
+}
+
+// maxLoginAlertLinkTTL caps login alert links; they act without a session.
+const maxLoginAlertLinkTTL = 7 * 24 * time.Hour
 
-	if c.BackendTokenSecret == "" {
-		return fmt.Errorf("BACKEND_TOKEN_SECRET is required")
+// validateLoginAlerts checks the login alert settings once alerts are on.
+// Every tenant needs a public URL for its links, its own or the
+// instance-wide one.
+func (c *Config) validateLoginAlerts() error {
+	if c.LoginAlertSMTPURL == "" {
+		return fmt.Errorf("LOGIN_ALERT_SMTP_URL is required when LOGIN_ALERT_ENABLED is on")
+	}
+	if c.LoginAlertFrom == "" {
+		return fmt.Errorf("LOGIN_ALERT_FROM is required when LOGIN_ALERT_ENABLED is on")
 	}
-	if len(c.BackendTokenSecret) < 32 {
-		return fmt.Errorf("BACKEND_TOKEN_SECRET must be at least 32 characters")
+	if c.LoginAlertLinkTTL <= 0 || c.LoginAlertLinkTTL > maxLoginAlertLinkTTL {
+		return fmt.Errorf("LOGIN_ALERT_LINK_TTL must be between 0 and %s", maxLoginAlertLinkTTL)
 	}
+	if c.LoginAlertKnownDeviceTTL <= 0 {
+		return fmt.Errorf("LOGIN_ALERT_KNOWN_DEVICE_TTL must be positive")
+	}
+
+	if len(c.Tenants) == 0 {
+		if err := validatePublicURL(c.LoginAlertPublicURL); err != nil {
+			return fmt.Errorf("LOGIN_ALERT_PUBLIC_URL %w", err)
+		}
+		return nil
+	}
+	for _, t := range c.Tenants {
+		publicURL := t.LoginAlertPublicURL
+		if publicURL == "" {
+			publicURL = c.LoginAlertPublicURL
+		}
+		if err := validatePublicURL(publicURL); err != nil {
+			return fmt.Errorf("TENANTS_FILE: login_alert_public_url for %q %w", t.ID, err)
+		}
+	}
+	return nil
+}
+
+func validatePublicURL(v string) error {
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("must be an absolute http(s) URL")
+	}
+	return nil
+}
 
+// validateTenants checks each tenant is complete and that no host or secret
+// is shared: a shared signing secret would let one instance's tokens pass
+// another instance's backend.
+func (c *Config) validateTenants() error {
+	ids := map[string]bool{}
+	hosts := map[string]string{}
+	secrets := map[string]string{}
+	for _, t := range c.Tenants {
+		if t.ID == "" {
+			return fmt.Errorf("TENANTS_FILE: tenant id cannot be empty")
+		}
+		if ids[t.ID] {
+			return fmt.Errorf("TENANTS_FILE: duplicate tenant %q", t.ID)
+		}
+		ids[t.ID] = true
+
+		for _, h := range t.Hosts {
+			if owner, ok := hosts[h]; ok {
+				return fmt.Errorf("TENANTS_FILE: host %q belongs to both %q and %q", h, owner, t.ID)
+			}
+			hosts[h] = t.ID
+		}
+		if t.KratosURL == "" || t.KratosAdminURL == "" {
+			return fmt.Errorf("TENANTS_FILE: tenant %q needs kratos_url and kratos_admin_url", t.ID)
+		}
+		if len(t.CSRFSecret) < 32 {
+			return fmt.Errorf("TENANTS_FILE: csrf_secret for %q must be at least 32 characters", t.ID)
+		}
+		if len(t.BackendTokenSecret) < 32 {
+			return fmt.Errorf("TENANTS_FILE: backend_token_secret for %q must be at least 32 characters", t.ID)
+		}
+		if t.KratosWebhookSecret != "" && len(t.KratosWebhookSecret) < 32 {
+			return fmt.Errorf("TENANTS_FILE: kratos_webhook_secret for %q must be at least 32 characters", t.ID)
+		}
+		for _, secret := range []string{t.CSRFSecret, t.BackendTokenSecret, t.KratosWebhookSecret} {
+			if secret == "" {
+				continue
+			}
+			if owner, ok := secrets[secret]; ok {
+				return fmt.Errorf("TENANTS_FILE: tenants %q and %q share a secret", owner, t.ID)
+			}
+			secrets[secret] = t.ID
+		}
+	}
+	if c.TenantHeader == "" {
+		return fmt.Errorf("TENANT_HEADER cannot be empty when tenants are configured")
+	}
 	return nil
 }
 
+// loadTenants reads the JSON tenant list at path. Secrets may be given
+// inline or, with the *_file fields, read from mounted secret files. Hosts
+// are lowercased.
+func loadTenants(path string) ([]Tenant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tenants []Tenant
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i := range tenants {
+		t := &tenants[i]
+		for _, f := range []struct{ value, file *string }{
+			{&t.CSRFSecret, &t.CSRFSecretFile},
+			{&t.BackendTokenSecret, &t.BackendTokenSecretFile},
+		} {
+			if *f.file == "" {
+				continue
+			}
+			content, err := os.ReadFile(*f.file)
+			if err != nil {
+				return nil, fmt.Errorf("tenant %q: %w", t.ID, err)
+			}
+			*f.value = strings.TrimSpace(string(content))
+		}
+		for j, h := range t.Hosts {
+			t.Hosts[j] = strings.ToLower(strings.TrimSpace(h))
+		}
+	}
+	return tenants, nil
+}
+
+// parseServiceTokenAudiences parses comma-separated audience:ttl[:scopes]
+// entries, scopes being space-separated, e.g.
+// "rag-orchestrator:2m:rag.query rag.read,tts:1m:tts.synthesize".
+func parseServiceTokenAudiences(v string) ([]ServiceTokenAudience, error) {
+	var audiences []ServiceTokenAudience
+	for entry := range strings.SplitSeq(v, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("%q: expected audience:ttl[:scopes]", entry)
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", entry, err)
+		}
+		a := ServiceTokenAudience{Audience: strings.TrimSpace(parts[0]), TTL: ttl}
+		if len(parts) == 3 {
+			a.Scopes = strings.Fields(parts[2])
+		}
+		audiences = append(audiences, a)
+	}
+	return audiences, nil
+}
+
 // getEnv retrieves an environment variable or returns a fallback value
 func getEnv(key, fallback string) string {
 	// Check for _FILE suffix
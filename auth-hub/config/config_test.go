@@ -162,6 +162,59 @@ func TestLoad_ValidateRateLimit_Invalid(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid VALIDATE_RATE_LIMIT")
 }
 
+func TestLoad_CacheStaleTTL(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("CACHE_STALE_TTL")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.CacheStaleTTL)
+
+	os.Setenv("CACHE_STALE_TTL", "0s")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cfg.CacheStaleTTL)
+
+	os.Setenv("CACHE_STALE_TTL", "10m")
+	_, err = Load()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CACHE_STALE_TTL")
+}
+
+func TestLoad_IdentityCacheTTL(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("IDENTITY_CACHE_TTL")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, cfg.IdentityCacheTTL)
+
+	os.Setenv("IDENTITY_CACHE_TTL", "2h")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, cfg.IdentityCacheTTL)
+
+	os.Setenv("IDENTITY_CACHE_TTL", "0s")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cfg.IdentityCacheTTL)
+
+	os.Setenv("IDENTITY_CACHE_TTL", "-1m")
+	_, err = Load()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "IDENTITY_CACHE_TTL")
+}
+
 func TestLoad_CSRFRateLimit_Default(t *testing.T) {
 	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
 	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
@@ -206,6 +259,351 @@ func TestLoad_CSRFRateLimit_Invalid(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid CSRF_RATE_LIMIT")
 }
 
+func TestLoad_KratosWebhookSecret(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("KRATOS_WEBHOOK_SECRET")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.KratosWebhookSecret, "webhook is disabled by default")
+	assert.InDelta(t, 10.0, cfg.KratosWebhookRate, 0.001)
+
+	os.Setenv("KRATOS_WEBHOOK_SECRET", "this-is-a-valid-kratos-webhook-secret-32-chars")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "this-is-a-valid-kratos-webhook-secret-32-chars", cfg.KratosWebhookSecret)
+
+	os.Setenv("KRATOS_WEBHOOK_SECRET", "too-short")
+	_, err = Load()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "KRATOS_WEBHOOK_SECRET")
+}
+
+func TestLoad_SessionFingerprint(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("SESSION_FINGERPRINT_MODE")
+		os.Unsetenv("SESSION_FINGERPRINT_IPV4_PREFIX")
+		os.Unsetenv("SESSION_FINGERPRINT_TTL")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "report", cfg.FingerprintMode, "binding is observed but not enforced by default")
+	assert.Equal(t, 24, cfg.FingerprintIPv4Prefix)
+	assert.Equal(t, 64, cfg.FingerprintIPv6Prefix)
+	assert.Equal(t, 24*time.Hour, cfg.FingerprintTTL)
+	assert.Contains(t, cfg.StepUpURL, "refresh=true")
+
+	os.Setenv("SESSION_FINGERPRINT_MODE", "enforce")
+	os.Setenv("SESSION_FINGERPRINT_IPV4_PREFIX", "16")
+	os.Setenv("SESSION_FINGERPRINT_TTL", "12h")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "enforce", cfg.FingerprintMode)
+	assert.Equal(t, 16, cfg.FingerprintIPv4Prefix)
+	assert.Equal(t, 12*time.Hour, cfg.FingerprintTTL)
+
+	os.Setenv("SESSION_FINGERPRINT_IPV4_PREFIX", "33")
+	_, err = Load()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SESSION_FINGERPRINT_IPV4_PREFIX")
+
+	os.Unsetenv("SESSION_FINGERPRINT_IPV4_PREFIX")
+	os.Setenv("SESSION_FINGERPRINT_MODE", "strict")
+	_, err = Load()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SESSION_FINGERPRINT_MODE")
+}
+
+func TestLoad_ServiceTokenAudiences(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("SERVICE_TOKEN_AUDIENCES")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.ServiceTokens, "only the backend audience by default")
+	assert.InDelta(t, 20.0, cfg.TokenExchangeRate, 0.001)
+
+	os.Setenv("SERVICE_TOKEN_AUDIENCES", "rag-orchestrator:2m:rag.query rag.read, tts:1m")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, []ServiceTokenAudience{
+		{Audience: "rag-orchestrator", TTL: 2 * time.Minute, Scopes: []string{"rag.query", "rag.read"}},
+		{Audience: "tts", TTL: time.Minute},
+	}, cfg.ServiceTokens)
+
+	for _, bad := range []string{
+		"tts",            // missing TTL
+		"tts:soon",       // unparsable TTL
+		"tts:2h",         // above the cap
+		"tts:1m,tts:2m",  // duplicate
+		"alt-backend:1m", // shadows the backend audience
+		":1m",            // empty audience
+	} {
+		os.Setenv("SERVICE_TOKEN_AUDIENCES", bad)
+		_, err = Load()
+		assert.Error(t, err, bad)
+		assert.Contains(t, err.Error(), "SERVICE_TOKEN_AUDIENCES", bad)
+	}
+}
+
+func TestLoad_PasswordPolicy(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("PASSWORD_MIN_LENGTH")
+		os.Unsetenv("PASSWORD_REQUIRED_CLASSES")
+		os.Unsetenv("PASSWORD_BREACH_CHECK")
+		os.Unsetenv("PASSWORD_HISTORY_SIZE")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 12, cfg.PasswordMinLength)
+	assert.Equal(t, []string{"lower", "upper", "digit"}, cfg.PasswordRequiredClasses)
+	assert.True(t, cfg.PasswordBreachCheck)
+	assert.Equal(t, 5, cfg.PasswordHistorySize)
+
+	os.Setenv("PASSWORD_MIN_LENGTH", "8")
+	os.Setenv("PASSWORD_REQUIRED_CLASSES", "")
+	os.Setenv("PASSWORD_BREACH_CHECK", "false")
+	os.Setenv("PASSWORD_HISTORY_SIZE", "0")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 8, cfg.PasswordMinLength)
+	assert.Empty(t, cfg.PasswordRequiredClasses, "an empty list disables class requirements")
+	assert.False(t, cfg.PasswordBreachCheck)
+	assert.Zero(t, cfg.PasswordHistorySize)
+
+	for env, bad := range map[string]string{
+		"PASSWORD_MIN_LENGTH":       "100",
+		"PASSWORD_REQUIRED_CLASSES": "lower,emoji",
+		"PASSWORD_HISTORY_SIZE":     "-1",
+	} {
+		os.Setenv(env, bad)
+		_, err = Load()
+		assert.Error(t, err, env)
+		assert.Contains(t, err.Error(), env)
+		os.Unsetenv(env)
+	}
+}
+
+func TestLoad_Readiness(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("READY_FAILURE_THRESHOLD")
+		os.Unsetenv("READY_PROBE_TIMEOUT")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, cfg.ReadyFailureThreshold)
+	assert.Equal(t, 2*time.Second, cfg.ReadyProbeTimeout)
+
+	os.Setenv("READY_FAILURE_THRESHOLD", "1")
+	os.Setenv("READY_PROBE_TIMEOUT", "500ms")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cfg.ReadyFailureThreshold)
+	assert.Equal(t, 500*time.Millisecond, cfg.ReadyProbeTimeout)
+
+	for env, bad := range map[string]string{
+		"READY_FAILURE_THRESHOLD": "-1",
+		"READY_PROBE_TIMEOUT":     "soon",
+	} {
+		os.Setenv(env, bad)
+		_, err = Load()
+		assert.Error(t, err, env)
+		assert.Contains(t, err.Error(), env)
+		os.Unsetenv(env)
+	}
+}
+
+func TestLoad_AbuseGuard(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	envs := []string{
+		"ABUSE_VALIDATE_THRESHOLD", "ABUSE_SESSION_THRESHOLD", "ABUSE_CSRF_THRESHOLD",
+		"ABUSE_WINDOW", "ABUSE_BAN_BASE", "ABUSE_BAN_MAX", "ABUSE_STRIKE_TTL",
+	}
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		for _, env := range envs {
+			os.Unsetenv(env)
+		}
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 50, cfg.AbuseValidateThreshold)
+	assert.Equal(t, 20, cfg.AbuseSessionThreshold)
+	assert.Equal(t, 5, cfg.AbuseCSRFThreshold)
+	assert.Equal(t, 5*time.Minute, cfg.AbuseWindow)
+	assert.Equal(t, time.Minute, cfg.AbuseBanBase)
+	assert.Equal(t, time.Hour, cfg.AbuseBanMax)
+	assert.Equal(t, 24*time.Hour, cfg.AbuseStrikeTTL)
+
+	os.Setenv("ABUSE_VALIDATE_THRESHOLD", "0")
+	os.Setenv("ABUSE_CSRF_THRESHOLD", "3")
+	os.Setenv("ABUSE_WINDOW", "10m")
+	os.Setenv("ABUSE_BAN_BASE", "30s")
+	os.Setenv("ABUSE_BAN_MAX", "6h")
+	os.Setenv("ABUSE_STRIKE_TTL", "48h")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cfg.AbuseValidateThreshold)
+	assert.Equal(t, 3, cfg.AbuseCSRFThreshold)
+	assert.Equal(t, 10*time.Minute, cfg.AbuseWindow)
+	assert.Equal(t, 30*time.Second, cfg.AbuseBanBase)
+	assert.Equal(t, 6*time.Hour, cfg.AbuseBanMax)
+	assert.Equal(t, 48*time.Hour, cfg.AbuseStrikeTTL)
+	for _, env := range envs {
+		os.Unsetenv(env)
+	}
+
+	for env, bad := range map[string]string{
+		"ABUSE_SESSION_THRESHOLD": "-1",
+		"ABUSE_CSRF_THRESHOLD":    "many",
+		"ABUSE_WINDOW":            "0s",
+		"ABUSE_BAN_BASE":          "soon",
+		"ABUSE_BAN_MAX":           "30s", // below ABUSE_BAN_BASE
+		"ABUSE_STRIKE_TTL":        "-1h",
+	} {
+		os.Setenv(env, bad)
+		_, err = Load()
+		assert.Error(t, err, env)
+		assert.Contains(t, err.Error(), env)
+		os.Unsetenv(env)
+	}
+
+	// With every endpoint disabled the durations are not checked.
+	os.Setenv("ABUSE_VALIDATE_THRESHOLD", "0")
+	os.Setenv("ABUSE_SESSION_THRESHOLD", "0")
+	os.Setenv("ABUSE_CSRF_THRESHOLD", "0")
+	os.Setenv("ABUSE_WINDOW", "0s")
+	_, err = Load()
+	assert.NoError(t, err)
+}
+
+func TestLoad_LinkedAccountReauthMaxAge(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("LINKED_ACCOUNT_REAUTH_MAX_AGE")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, cfg.LinkedAccountReauthMaxAge)
+
+	os.Setenv("LINKED_ACCOUNT_REAUTH_MAX_AGE", "5m")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, cfg.LinkedAccountReauthMaxAge)
+
+	for _, bad := range []string{"-1m", "soon"} {
+		os.Setenv("LINKED_ACCOUNT_REAUTH_MAX_AGE", bad)
+		_, err = Load()
+		assert.Error(t, err, bad)
+		assert.Contains(t, err.Error(), "LINKED_ACCOUNT_REAUTH_MAX_AGE")
+	}
+}
+
+func TestLoad_LoginAlerts(t *testing.T) {
+	os.Setenv("CSRF_SECRET", "this-is-a-valid-csrf-secret-that-is-at-least-32-chars")
+	os.Setenv("BACKEND_TOKEN_SECRET", "this-is-a-valid-backend-token-secret-32-chars-long")
+	defer func() {
+		os.Unsetenv("CSRF_SECRET")
+		os.Unsetenv("BACKEND_TOKEN_SECRET")
+		os.Unsetenv("LOGIN_ALERT_ENABLED")
+		os.Unsetenv("LOGIN_ALERT_PUBLIC_URL")
+		os.Unsetenv("LOGIN_ALERT_LINK_TTL")
+		os.Unsetenv("LOGIN_ALERT_KNOWN_DEVICE_TTL")
+		os.Unsetenv("LOGIN_ALERT_SMTP_URL")
+		os.Unsetenv("LOGIN_ALERT_FROM")
+	}()
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.False(t, cfg.LoginAlertEnabled)
+	assert.Equal(t, 72*time.Hour, cfg.LoginAlertLinkTTL)
+	assert.Equal(t, 90*24*time.Hour, cfg.LoginAlertKnownDeviceTTL)
+
+	os.Setenv("LOGIN_ALERT_ENABLED", "true")
+	_, err = Load()
+	assert.ErrorContains(t, err, "LOGIN_ALERT_SMTP_URL")
+
+	os.Setenv("LOGIN_ALERT_SMTP_URL", "smtps://alerts:pw@smtp.example.com:465")
+	os.Setenv("LOGIN_ALERT_FROM", "Alt <security@example.com>")
+	os.Setenv("LOGIN_ALERT_PUBLIC_URL", "https://alt.example.com/security/login-alerts")
+	os.Setenv("LOGIN_ALERT_LINK_TTL", "24h")
+	cfg, err = Load()
+	assert.NoError(t, err)
+	assert.True(t, cfg.LoginAlertEnabled)
+	assert.Equal(t, 24*time.Hour, cfg.LoginAlertLinkTTL)
+
+	for env, bad := range map[string]string{
+		"LOGIN_ALERT_ENABLED":          "maybe",
+		"LOGIN_ALERT_PUBLIC_URL":       "/security/login-alerts",
+		"LOGIN_ALERT_LINK_TTL":         "720h",
+		"LOGIN_ALERT_KNOWN_DEVICE_TTL": "0s",
+	} {
+		prev := os.Getenv(env)
+		os.Setenv(env, bad)
+		_, err = Load()
+		assert.Error(t, err, env)
+		assert.Contains(t, err.Error(), env)
+		os.Setenv(env, prev)
+	}
+}
+
+func TestConfig_ValidateLoginAlertTenants(t *testing.T) {
+	tenant := func(id, suffix, publicURL string) Tenant {
+		return Tenant{
+			ID:                  id,
+			KratosURL:           "http://kratos-" + id + ":4433",
+			KratosAdminURL:      "http://kratos-" + id + ":4434",
+			CSRFSecret:          "csrf-secret-that-is-at-least-32-chars-" + suffix,
+			BackendTokenSecret:  "backend-token-secret-at-least-32-chars-" + suffix,
+			LoginAlertPublicURL: publicURL,
+		}
+	}
+	cfg := &Config{
+		KratosURL: "http://kratos:4433", Port: "8888", CacheTTL: time.Minute, TenantHeader: "X-Auth-Hub-Tenant",
+		LoginAlertEnabled: true, LoginAlertSMTPURL: "smtp://smtp:25", LoginAlertFrom: "security@example.com",
+		LoginAlertLinkTTL: time.Hour, LoginAlertKnownDeviceTTL: time.Hour,
+		Tenants: []Tenant{tenant("a", "1", "https://a.example.com/security/login-alerts"), tenant("b", "2", "")},
+	}
+
+	err := cfg.Validate()
+	assert.ErrorContains(t, err, `login_alert_public_url for "b"`)
+
+	cfg.LoginAlertPublicURL = "https://alt.example.com/security/login-alerts"
+	assert.NoError(t, cfg.Validate(), "tenants without their own URL fall back to the instance-wide one")
+}
+
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -338,3 +736,75 @@ func TestConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_Tenants(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := dir + "/beta-backend-secret"
+	assert.NoError(t, os.WriteFile(secretFile, []byte("beta-backend-token-secret-32-chars-long!!\n"), 0o600))
+	tenantsFile := dir + "/tenants.json"
+	assert.NoError(t, os.WriteFile(tenantsFile, []byte(`[
+		{"id": "alpha", "hosts": ["Alpha.Example.com"], "kratos_url": "http://kratos-alpha:4433", "kratos_admin_url": "http://kratos-alpha:4434",
+		 "csrf_secret": "alpha-csrf-secret-that-is-at-least-32-chars", "backend_token_secret": "alpha-backend-token-secret-32-chars-long"},
+		{"id": "beta", "kratos_url": "http://kratos-beta:4433", "kratos_admin_url": "http://kratos-beta:4434",
+		 "csrf_secret": "beta-csrf-secret-that-is-at-least-32-chars", "backend_token_secret_file": "`+secretFile+`"}
+	]`), 0o600))
+
+	os.Setenv("TENANTS_FILE", tenantsFile)
+	defer os.Unsetenv("TENANTS_FILE")
+
+	// Instance-wide secrets are not needed once tenants bring their own.
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Tenants, 2)
+	assert.Equal(t, "X-Auth-Hub-Tenant", cfg.TenantHeader)
+	assert.Equal(t, []string{"alpha.example.com"}, cfg.Tenants[0].Hosts)
+	assert.Equal(t, "beta-backend-token-secret-32-chars-long!!", cfg.Tenants[1].BackendTokenSecret)
+
+	os.Setenv("TENANTS_FILE", dir+"/missing.json")
+	_, err = Load()
+	assert.ErrorContains(t, err, "TENANTS_FILE")
+}
+
+func TestConfig_ValidateTenants(t *testing.T) {
+	tenant := func(id, suffix string) Tenant {
+		return Tenant{
+			ID:                 id,
+			KratosURL:          "http://kratos-" + id + ":4433",
+			KratosAdminURL:     "http://kratos-" + id + ":4434",
+			CSRFSecret:         "csrf-secret-that-is-at-least-32-chars-" + suffix,
+			BackendTokenSecret: "backend-token-secret-at-least-32-chars-" + suffix,
+		}
+	}
+	base := func(tenants ...Tenant) *Config {
+		return &Config{KratosURL: "http://kratos:4433", Port: "8888", CacheTTL: time.Minute, TenantHeader: "X-Auth-Hub-Tenant", Tenants: tenants}
+	}
+
+	assert.NoError(t, base(tenant("a", "1"), tenant("b", "2")).Validate())
+
+	sharedSecret := tenant("b", "2")
+	sharedSecret.BackendTokenSecret = tenant("a", "1").BackendTokenSecret
+	hostA, hostB := tenant("a", "1"), tenant("b", "2")
+	hostA.Hosts, hostB.Hosts = []string{"alt.example.com"}, []string{"alt.example.com"}
+	noKratos := tenant("a", "1")
+	noKratos.KratosAdminURL = ""
+	weakSecret := tenant("a", "1")
+	weakSecret.CSRFSecret = "short"
+
+	for name, tc := range map[string]struct {
+		cfg  *Config
+		want string
+	}{
+		"duplicate id":     {base(tenant("a", "1"), tenant("a", "2")), "duplicate tenant"},
+		"empty id":         {base(tenant("", "1")), "tenant id"},
+		"shared secret":    {base(tenant("a", "1"), sharedSecret), "share a secret"},
+		"shared host":      {base(hostA, hostB), "belongs to both"},
+		"missing kratos":   {base(noKratos), "kratos_admin_url"},
+		"weak secret":      {base(weakSecret), "csrf_secret"},
+		"no tenant header": {&Config{KratosURL: "http://kratos:4433", Port: "8888", CacheTTL: time.Minute, Tenants: []Tenant{tenant("a", "1")}}, "TENANT_HEADER"},
+	} {
+		err := tc.cfg.Validate()
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), tc.want, name)
+		}
+	}
+}
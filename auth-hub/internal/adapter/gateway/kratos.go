@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"auth-hub/internal/domain"
@@ -13,9 +15,12 @@ import (
 	kratos "github.com/ory/kratos-client-go"
 )
 
-// KratosGateway implements domain.SessionValidator and domain.IdentityProvider.
+// KratosGateway implements domain.SessionValidator, domain.IdentityProvider,
+// domain.IdentityTraitsProvider, domain.PasswordHistory,
+// domain.LinkedAccountStore and domain.HealthProbe.
 type KratosGateway struct {
 	client       *kratos.APIClient
+	baseURL      string
 	adminBaseURL string
 	httpClient   *http.Client
 }
@@ -41,6 +46,7 @@ func NewKratosGateway(baseURL, adminBaseURL string, timeout time.Duration) *Krat
 
 	return &KratosGateway{
 		client:       kratos.NewAPIClient(configuration),
+		baseURL:      baseURL,
 		adminBaseURL: adminBaseURL,
 		httpClient:   httpClient,
 	}
@@ -70,6 +76,10 @@ func (g *KratosGateway) ValidateSession(ctx context.Context, cookie string) (*do
 	}
 
 	if session.Active != nil && !*session.Active {
+		// Tell an expired session apart so clients can prompt a fresh login.
+		if session.ExpiresAt != nil && !session.ExpiresAt.After(time.Now()) {
+			return nil, domain.ErrSessionExpired
+		}
 		return nil, domain.ErrSessionInactive
 	}
 
@@ -77,37 +87,7 @@ func (g *KratosGateway) ValidateSession(ctx context.Context, cookie string) (*do
 		return nil, domain.ErrMissingIdentity
 	}
 
-	email := ""
-	role := "user"
-	traits, ok := session.Identity.Traits.(map[string]interface{})
-	if !ok {
-		slog.WarnContext(ctx, "kratos identity traits are not a map, defaulting role to user",
-			"identity_id", session.Identity.Id)
-	} else {
-		if emailVal, ok := traits["email"]; ok {
-			if emailStr, ok := emailVal.(string); ok {
-				email = emailStr
-			}
-		}
-		roleVal, hasRole := traits["role"]
-		switch {
-		case !hasRole:
-			slog.WarnContext(ctx, "kratos identity traits missing role, defaulting to user",
-				"identity_id", session.Identity.Id)
-		default:
-			roleStr, isString := roleVal.(string)
-			switch {
-			case !isString:
-				slog.WarnContext(ctx, "kratos identity role trait is not a string, defaulting to user",
-					"identity_id", session.Identity.Id)
-			case roleStr == "admin":
-				role = "admin"
-			case roleStr != "user":
-				slog.WarnContext(ctx, "kratos identity has unexpected role trait, defaulting to user",
-					"identity_id", session.Identity.Id, "role", roleStr)
-			}
-		}
-	}
+	traits := parseIdentityTraits(ctx, session.Identity.Id, session.Identity.Traits)
 
 	var createdAt time.Time
 	if session.Identity.CreatedAt != nil {
@@ -115,17 +95,105 @@ func (g *KratosGateway) ValidateSession(ctx context.Context, cookie string) (*do
 	}
 
 	return &domain.Identity{
-		UserID:    session.Identity.Id,
-		Email:     email,
-		Role:      role,
-		SessionID: session.Id,
-		CreatedAt: createdAt,
+		UserID:          session.Identity.Id,
+		Email:           traits.Email,
+		Name:            traits.Name,
+		Role:            traits.Role,
+		SessionID:       session.Id,
+		CreatedAt:       createdAt,
+		AuthenticatedAt: session.GetAuthenticatedAt(),
 	}, nil
 }
 
+// parseIdentityTraits reads the email, name and role traits. A missing or
+// unexpected role defaults to "user".
+func parseIdentityTraits(ctx context.Context, identityID string, raw interface{}) domain.IdentityTraits {
+	out := domain.IdentityTraits{Role: "user"}
+	traits, ok := raw.(map[string]interface{})
+	if !ok {
+		slog.WarnContext(ctx, "kratos identity traits are not a map, defaulting role to user",
+			"identity_id", identityID)
+		return out
+	}
+
+	if emailVal, ok := traits["email"]; ok {
+		if emailStr, ok := emailVal.(string); ok {
+			out.Email = emailStr
+		}
+	}
+	if name, ok := traits["name"].(map[string]interface{}); ok {
+		first, _ := name["first"].(string)
+		last, _ := name["last"].(string)
+		out.Name = strings.TrimSpace(first + " " + last)
+	}
+
+	roleVal, hasRole := traits["role"]
+	switch {
+	case !hasRole:
+		slog.WarnContext(ctx, "kratos identity traits missing role, defaulting to user",
+			"identity_id", identityID)
+	default:
+		roleStr, isString := roleVal.(string)
+		switch {
+		case !isString:
+			slog.WarnContext(ctx, "kratos identity role trait is not a string, defaulting to user",
+				"identity_id", identityID)
+		case roleStr == "admin":
+			out.Role = "admin"
+		case roleStr != "user":
+			slog.WarnContext(ctx, "kratos identity has unexpected role trait, defaulting to user",
+				"identity_id", identityID, "role", roleStr)
+		}
+	}
+	return out
+}
+
 // adminIdentity represents a Kratos identity from Admin API.
 type adminIdentity struct {
-	ID string `json:"id"`
+	ID     string      `json:"id"`
+	State  string      `json:"state"`
+	Traits interface{} `json:"traits"`
+}
+
+// GetIdentityTraits fetches one identity's traits from the Kratos Admin API.
+func (g *KratosGateway) GetIdentityTraits(ctx context.Context, identityID string) (*domain.IdentityTraits, error) {
+	if g.adminBaseURL == "" {
+		return nil, domain.ErrAdminNotConfigured
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("%s/admin/identities/%s", g.adminBaseURL, url.PathEscape(identityID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, domain.ErrMissingIdentity
+	default:
+		return nil, fmt.Errorf("%w: admin API returned status %d", domain.ErrKratosUnavailable, resp.StatusCode)
+	}
+
+	var identity adminIdentity
+	if err := json.NewDecoder(resp.Body).Decode(&identity); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	if identity.State == "inactive" {
+		return nil, domain.ErrSessionInactive
+	}
+
+	traits := parseIdentityTraits(ctx, identity.ID, identity.Traits)
+	return &traits, nil
 }
 
 // GetFirstIdentityID fetches the first identity ID from Kratos Admin API.
@@ -137,9 +205,9 @@ func (g *KratosGateway) GetFirstIdentityID(ctx context.Context) (string, error)
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	url := fmt.Sprintf("%s/admin/identities?page_size=1", g.adminBaseURL)
+	endpoint := fmt.Sprintf("%s/admin/identities?page_size=1", g.adminBaseURL)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return "", fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
 	}
@@ -165,3 +233,23 @@ func (g *KratosGateway) GetFirstIdentityID(ctx context.Context) (string, error)
 
 	return identities[0].ID, nil
 }
+
+// Probe checks Kratos' own readiness endpoint on the public API, the one
+// session validation depends on.
+func (g *KratosGateway) Probe(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/health/ready", nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: readiness endpoint returned status %d", domain.ErrKratosUnavailable, resp.StatusCode)
+	}
+	return nil
+}
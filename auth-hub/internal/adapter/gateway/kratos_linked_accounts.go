@@ -0,0 +1,78 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// adminCredentialsIdentity is the part of an admin API identity the linked
+// accounts need. Only the oidc credential's config is requested; the other
+// credential types are listed without theirs.
+type adminCredentialsIdentity struct {
+	ID          string `json:"id"`
+	Credentials map[string]struct {
+		Identifiers []string `json:"identifiers"`
+		Config      struct {
+			Providers []struct {
+				Provider string `json:"provider"`
+				Subject  string `json:"subject"`
+			} `json:"providers"`
+		} `json:"config"`
+	} `json:"credentials"`
+}
+
+// SignInMethods implements domain.LinkedAccountStore.
+func (g *KratosGateway) SignInMethods(ctx context.Context, identityID string) (*domain.SignInMethods, error) {
+	var identity adminCredentialsIdentity
+	if err := g.getAdminIdentity(ctx, identityID, "?include_credential=oidc", &identity); err != nil {
+		return nil, err
+	}
+
+	methods := &domain.SignInMethods{}
+	_, methods.Password = identity.Credentials["password"]
+	if code, ok := identity.Credentials["code"]; ok && len(code.Identifiers) > 0 {
+		methods.Code = true
+	}
+	for _, p := range identity.Credentials["oidc"].Config.Providers {
+		methods.Linked = append(methods.Linked, domain.LinkedAccount{Provider: p.Provider, Subject: p.Subject})
+	}
+	return methods, nil
+}
+
+// UnlinkAccount implements domain.LinkedAccountStore. Kratos identifies an
+// OIDC link by "<provider>:<subject>".
+func (g *KratosGateway) UnlinkAccount(ctx context.Context, identityID string, account domain.LinkedAccount) error {
+	if g.adminBaseURL == "" {
+		return domain.ErrAdminNotConfigured
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("%s/admin/identities/%s/credentials/oidc?identifier=%s",
+		g.adminBaseURL, url.PathEscape(identityID), url.QueryEscape(account.Provider+":"+account.Subject))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return domain.ErrLinkedAccountNotFound
+	default:
+		return fmt.Errorf("%w: admin API returned status %d", domain.ErrKratosUnavailable, resp.StatusCode)
+	}
+}
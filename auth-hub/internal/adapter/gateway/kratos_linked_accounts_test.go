@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKratosGateway_SignInMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin/identities/user-1", r.URL.Path)
+		assert.Equal(t, "oidc", r.URL.Query().Get("include_credential"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "user-1",
+			"credentials": {
+				"code": {"type": "code", "identifiers": ["a@example.com"]},
+				"oidc": {"type": "oidc", "identifiers": ["google:g-1", "github:42"], "config": {"providers": [
+					{"provider": "google", "subject": "g-1", "initial_id_token": "encrypted"},
+					{"provider": "github", "subject": "42"}
+				]}}
+			}
+		}`))
+	}))
+	defer server.Close()
+	g := NewKratosGateway(server.URL, server.URL, time.Second)
+
+	methods, err := g.SignInMethods(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.False(t, methods.Password)
+	assert.True(t, methods.Code)
+	assert.Equal(t, []domain.LinkedAccount{
+		{Provider: "google", Subject: "g-1"},
+		{Provider: "github", Subject: "42"},
+	}, methods.Linked)
+}
+
+func TestKratosGateway_UnlinkAccount(t *testing.T) {
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/admin/identities/user-1/credentials/oidc", r.URL.Path)
+		assert.Equal(t, "google:g-1", r.URL.Query().Get("identifier"))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	g := NewKratosGateway(server.URL, server.URL, time.Second)
+	account := domain.LinkedAccount{Provider: "google", Subject: "g-1"}
+
+	assert.NoError(t, g.UnlinkAccount(context.Background(), "user-1", account))
+
+	status = http.StatusNotFound
+	assert.ErrorIs(t, g.UnlinkAccount(context.Background(), "user-1", account), domain.ErrLinkedAccountNotFound)
+
+	status = http.StatusInternalServerError
+	assert.ErrorIs(t, g.UnlinkAccount(context.Background(), "user-1", account), domain.ErrKratosUnavailable)
+}
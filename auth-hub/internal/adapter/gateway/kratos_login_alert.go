@@ -0,0 +1,75 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// loginAlertsOptOutKey marks, in the identity's admin metadata, that the
+// user turned login alerts off. Absent means alerts are on.
+const loginAlertsOptOutKey = "login_alerts_opt_out"
+
+// adminMetadataIdentity is the part of an admin API identity the login
+// alert preference needs.
+type adminMetadataIdentity struct {
+	ID            string         `json:"id"`
+	MetadataAdmin map[string]any `json:"metadata_admin"`
+}
+
+// LoginAlertsEnabled implements domain.LoginAlertPreferences.
+func (g *KratosGateway) LoginAlertsEnabled(ctx context.Context, identityID string) (bool, error) {
+	var identity adminMetadataIdentity
+	if err := g.getAdminIdentity(ctx, identityID, "", &identity); err != nil {
+		return false, err
+	}
+	optedOut, _ := identity.MetadataAdmin[loginAlertsOptOutKey].(bool)
+	return !optedOut, nil
+}
+
+// DisableLoginAlerts implements domain.LoginAlertPreferences.
+func (g *KratosGateway) DisableLoginAlerts(ctx context.Context, identityID string) error {
+	var identity adminMetadataIdentity
+	if err := g.getAdminIdentity(ctx, identityID, "", &identity); err != nil {
+		return err
+	}
+	metadata := identity.MetadataAdmin
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+	metadata[loginAlertsOptOutKey] = true
+	return g.patchMetadataAdmin(ctx, identityID, metadata)
+}
+
+// RevokeSession implements domain.SessionRevoker. Revoking a session that
+// is already gone succeeds, so a login alert link can be followed twice.
+func (g *KratosGateway) RevokeSession(ctx context.Context, kratosSessionID string) error {
+	if g.adminBaseURL == "" {
+		return domain.ErrAdminNotConfigured
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, g.adminBaseURL+"/admin/sessions/"+url.PathEscape(kratosSessionID), nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("%w: admin API returned status %d", domain.ErrKratosUnavailable, resp.StatusCode)
+	}
+}
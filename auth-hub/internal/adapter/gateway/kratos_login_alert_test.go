@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKratosGateway_LoginAlertPreference(t *testing.T) {
+	metadata := map[string]any{"password_history": []any{"$2a$hash"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin/identities/user-1", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{"id": "user-1", "metadata_admin": metadata})
+		case http.MethodPatch:
+			var patch []struct {
+				Value map[string]any `json:"value"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			metadata = patch[0].Value
+			w.Write([]byte(`{"id":"user-1"}`))
+		}
+	}))
+	defer server.Close()
+	g := NewKratosGateway(server.URL, server.URL, time.Second)
+
+	enabled, err := g.LoginAlertsEnabled(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.True(t, enabled, "alerts are on by default")
+
+	require.NoError(t, g.DisableLoginAlerts(context.Background(), "user-1"))
+	assert.Equal(t, []any{"$2a$hash"}, metadata["password_history"], "other admin metadata is kept")
+
+	enabled, err = g.LoginAlertsEnabled(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.False(t, enabled)
+}
+
+func TestKratosGateway_RevokeSession(t *testing.T) {
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/admin/sessions/ks-1", r.URL.Path)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	g := NewKratosGateway(server.URL, server.URL, time.Second)
+
+	assert.NoError(t, g.RevokeSession(context.Background(), "ks-1"))
+
+	status = http.StatusNotFound
+	assert.NoError(t, g.RevokeSession(context.Background(), "ks-1"), "already revoked")
+
+	status = http.StatusInternalServerError
+	assert.ErrorIs(t, g.RevokeSession(context.Background(), "ks-1"), domain.ErrKratosUnavailable)
+}
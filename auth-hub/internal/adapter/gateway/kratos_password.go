@@ -0,0 +1,163 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordHistoryKey is where previous password hashes are kept in the
+// identity's admin metadata, which only the admin API can read or write.
+const passwordHistoryKey = "password_history"
+
+// adminPasswordIdentity is the part of an admin API identity the password
+// history needs.
+type adminPasswordIdentity struct {
+	ID          string `json:"id"`
+	Credentials struct {
+		Password struct {
+			Config struct {
+				HashedPassword string `json:"hashed_password"`
+			} `json:"config"`
+		} `json:"password"`
+	} `json:"credentials"`
+	MetadataAdmin map[string]any `json:"metadata_admin"`
+}
+
+// recentHashes returns the current hash followed by the stored history,
+// newest first and without duplicates.
+func (i *adminPasswordIdentity) recentHashes() []string {
+	var hashes []string
+	if h := i.Credentials.Password.Config.HashedPassword; h != "" {
+		hashes = append(hashes, h)
+	}
+	stored, _ := i.MetadataAdmin[passwordHistoryKey].([]any)
+	for _, v := range stored {
+		if h, ok := v.(string); ok && h != "" && !slices.Contains(hashes, h) {
+			hashes = append(hashes, h)
+		}
+	}
+	return hashes
+}
+
+// UsedRecently implements domain.PasswordHistory. Only bcrypt hashes, the
+// hasher Kratos is configured with, can be compared; others are skipped.
+func (g *KratosGateway) UsedRecently(ctx context.Context, identityID, password string, n int) (bool, error) {
+	identity, err := g.getPasswordIdentity(ctx, identityID)
+	if err != nil {
+		return false, err
+	}
+
+	hashes := identity.recentHashes()
+	if len(hashes) > n {
+		hashes = hashes[:n]
+	}
+	for _, h := range hashes {
+		if !strings.HasPrefix(h, "$2") {
+			continue
+		}
+		if bcrypt.CompareHashAndPassword([]byte(h), []byte(password)) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RecordCurrent implements domain.PasswordHistory.
+func (g *KratosGateway) RecordCurrent(ctx context.Context, identityID string, n int) error {
+	identity, err := g.getPasswordIdentity(ctx, identityID)
+	if err != nil {
+		return err
+	}
+
+	hashes := identity.recentHashes()
+	if len(hashes) > n {
+		hashes = hashes[:n]
+	}
+	metadata := identity.MetadataAdmin
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+	metadata[passwordHistoryKey] = hashes
+	return g.patchMetadataAdmin(ctx, identityID, metadata)
+}
+
+// patchMetadataAdmin replaces the identity's admin metadata with metadata.
+func (g *KratosGateway) patchMetadataAdmin(ctx context.Context, identityID string, metadata map[string]any) error {
+	// "add" on /metadata_admin creates or replaces the whole object, so it
+	// works whether or not the identity had admin metadata before.
+	body, err := json.Marshal([]map[string]any{{"op": "add", "path": "/metadata_admin", "value": metadata}})
+	if err != nil {
+		return fmt.Errorf("encode identity patch: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, g.adminBaseURL+"/admin/identities/"+url.PathEscape(identityID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: admin API returned status %d", domain.ErrKratosUnavailable, resp.StatusCode)
+	}
+	return nil
+}
+
+func (g *KratosGateway) getPasswordIdentity(ctx context.Context, identityID string) (*adminPasswordIdentity, error) {
+	var identity adminPasswordIdentity
+	if err := g.getAdminIdentity(ctx, identityID, "?include_credential=password", &identity); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// getAdminIdentity decodes the admin API identity into out; query is
+// appended to the identity URL as-is.
+func (g *KratosGateway) getAdminIdentity(ctx context.Context, identityID, query string, out any) error {
+	if g.adminBaseURL == "" {
+		return domain.ErrAdminNotConfigured
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	endpoint := fmt.Sprintf("%s/admin/identities/%s%s", g.adminBaseURL, url.PathEscape(identityID), query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: admin API returned status %d", domain.ErrKratosUnavailable, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrKratosUnavailable, err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func bcryptHash(t *testing.T, password string) string {
+	t.Helper()
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(h)
+}
+
+// passwordIdentityServer serves one identity with the given current hash
+// and stored history, and captures PATCH bodies.
+func passwordIdentityServer(t *testing.T, current string, history []string, patches *[]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin/identities/user-1", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "password", r.URL.Query().Get("include_credential"))
+			identity := map[string]any{
+				"id": "user-1",
+				"credentials": map[string]any{
+					"password": map[string]any{"config": map[string]any{"hashed_password": current}},
+				},
+			}
+			if history != nil {
+				identity["metadata_admin"] = map[string]any{"password_history": history, "note": "kept"}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(identity)
+		case http.MethodPatch:
+			var patch any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			*patches = append(*patches, patch)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id":"user-1"}`))
+		}
+	}))
+}
+
+func TestKratosGateway_UsedRecently(t *testing.T) {
+	current := bcryptHash(t, "current-pass")
+	older := bcryptHash(t, "older-pass")
+	oldest := bcryptHash(t, "oldest-pass")
+	server := passwordIdentityServer(t, current, []string{current, older, oldest}, nil)
+	defer server.Close()
+
+	gw := NewKratosGateway("http://unused", server.URL, 5*time.Second)
+	ctx := context.Background()
+
+	used, err := gw.UsedRecently(ctx, "user-1", "current-pass", 3)
+	assert.NoError(t, err)
+	assert.True(t, used, "the current password counts as recent")
+
+	used, err = gw.UsedRecently(ctx, "user-1", "older-pass", 3)
+	assert.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = gw.UsedRecently(ctx, "user-1", "oldest-pass", 2)
+	assert.NoError(t, err)
+	assert.False(t, used, "only the n most recent passwords are compared")
+
+	used, err = gw.UsedRecently(ctx, "user-1", "brand-new-pass", 3)
+	assert.NoError(t, err)
+	assert.False(t, used)
+}
+
+func TestKratosGateway_RecordCurrent(t *testing.T) {
+	current := bcryptHash(t, "current-pass")
+	var patches []any
+	server := passwordIdentityServer(t, current, []string{"$2a$older", "$2a$oldest"}, &patches)
+	defer server.Close()
+
+	gw := NewKratosGateway("http://unused", server.URL, 5*time.Second)
+	err := gw.RecordCurrent(context.Background(), "user-1", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []any{[]any{map[string]any{
+		"op":   "add",
+		"path": "/metadata_admin",
+		"value": map[string]any{
+			"password_history": []any{current, "$2a$older"},
+			"note":             "kept",
+		},
+	}}}, patches)
+}
+
+func TestKratosGateway_RecordCurrent_AdminNotConfigured(t *testing.T) {
+	gw := NewKratosGateway("http://unused", "", 5*time.Second)
+	err := gw.RecordCurrent(context.Background(), "user-1", 5)
+
+	assert.True(t, errors.Is(err, domain.ErrAdminNotConfigured))
+}
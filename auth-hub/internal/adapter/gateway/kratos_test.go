@@ -66,6 +66,47 @@ func TestKratosGateway_GetFirstIdentityID_ServerError(t *testing.T) {
 	assert.True(t, errors.Is(err, domain.ErrKratosUnavailable))
 }
 
+func TestKratosGateway_GetIdentityTraits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/identities/user-1":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":    "user-1",
+				"state": "active",
+				"traits": map[string]any{
+					"email": "ada@example.com",
+					"name":  map[string]any{"first": "Ada", "last": "Lovelace"},
+					"role":  "admin",
+				},
+			})
+		case "/admin/identities/user-2":
+			json.NewEncoder(w).Encode(map[string]any{"id": "user-2", "state": "inactive", "traits": map[string]any{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gw := NewKratosGateway("http://unused", server.URL, 5*time.Second)
+
+	traits, err := gw.GetIdentityTraits(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.IdentityTraits{Email: "ada@example.com", Name: "Ada Lovelace", Role: "admin"}, *traits)
+
+	_, err = gw.GetIdentityTraits(context.Background(), "user-2")
+	assert.True(t, errors.Is(err, domain.ErrSessionInactive))
+
+	_, err = gw.GetIdentityTraits(context.Background(), "missing")
+	assert.True(t, errors.Is(err, domain.ErrMissingIdentity))
+}
+
+func TestKratosGateway_GetIdentityTraits_AdminNotConfigured(t *testing.T) {
+	gw := NewKratosGateway("http://unused", "", 5*time.Second)
+	_, err := gw.GetIdentityTraits(context.Background(), "user-1")
+	assert.True(t, errors.Is(err, domain.ErrAdminNotConfigured))
+}
+
 func TestKratosGateway_ValidateSession_429_ReturnsRateLimited(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusTooManyRequests)
@@ -79,6 +120,31 @@ func TestKratosGateway_ValidateSession_429_ReturnsRateLimited(t *testing.T) {
 	assert.True(t, errors.Is(err, domain.ErrRateLimited))
 }
 
+func TestKratosGateway_ValidateSession_InactiveSessions(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := map[string]any{
+			"id":       "session-1",
+			"active":   false,
+			"identity": map[string]any{"id": "user-1", "schema_id": "default", "schema_url": "", "traits": map[string]any{}},
+		}
+		if r.Header.Get("Cookie") == "ory_kratos_session=expired" {
+			session["expires_at"] = expiresAt.Format(time.RFC3339)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(session)
+	}))
+	defer server.Close()
+
+	gw := NewKratosGateway(server.URL, "", 5*time.Second)
+
+	_, err := gw.ValidateSession(context.Background(), "ory_kratos_session=expired")
+	assert.True(t, errors.Is(err, domain.ErrSessionExpired))
+
+	_, err = gw.ValidateSession(context.Background(), "ory_kratos_session=revoked")
+	assert.True(t, errors.Is(err, domain.ErrSessionInactive))
+}
+
 func TestKratosGateway_ValidateSession_EmptyCookie(t *testing.T) {
 	gw := NewKratosGateway("http://unused", "", 5*time.Second)
 	identity, err := gw.ValidateSession(context.Background(), "")
@@ -86,3 +152,21 @@ func TestKratosGateway_ValidateSession_EmptyCookie(t *testing.T) {
 	assert.Nil(t, identity)
 	assert.True(t, errors.Is(err, domain.ErrSessionNotFound))
 }
+
+func TestKratosGateway_Probe(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/health/ready", r.URL.Path)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	gw := NewKratosGateway(server.URL, "", 5*time.Second)
+	assert.NoError(t, gw.Probe(context.Background()))
+
+	status = http.StatusServiceUnavailable
+	assert.ErrorIs(t, gw.Probe(context.Background()), domain.ErrKratosUnavailable)
+
+	server.Close()
+	assert.ErrorIs(t, gw.Probe(context.Background()), domain.ErrKratosUnavailable)
+}
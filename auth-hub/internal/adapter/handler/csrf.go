@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"auth-hub/internal/usecase"
+	"auth-hub/problem"
 
 	"github.com/labstack/echo/v4"
 )
@@ -34,9 +35,7 @@ func (h *CSRFHandler) Handle(c echo.Context) error {
 	rawCookie := c.Request().Header.Get("Cookie")
 	if rawCookie == "" {
 		slog.WarnContext(ctx, "csrf token request without session cookie")
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "session cookie required",
-		})
+		return problemError(http.StatusUnauthorized, problem.SessionRequired, "session cookie required")
 	}
 
 	sessionID := extractSessionID(rawCookie)
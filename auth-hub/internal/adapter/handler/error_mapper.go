@@ -2,39 +2,116 @@ package handler
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
 
 	"auth-hub/internal/domain"
+	"auth-hub/problem"
 
 	"github.com/labstack/echo/v4"
 )
 
-// mapDomainError converts a domain error into an appropriate echo.HTTPError.
+// problemError returns an echo.HTTPError whose message is the problem, so
+// HTTPErrorHandler renders it with its code.
+func problemError(status int, code problem.Code, detail string) *echo.HTTPError {
+	return echo.NewHTTPError(status, problem.New(status, code, detail))
+}
+
+// mapDomainError converts a domain error into an appropriate echo.HTTPError
+// carrying a problem.
 func mapDomainError(err error) *echo.HTTPError {
 	switch {
+	case errors.Is(err, domain.ErrSessionExpired):
+		return problemError(http.StatusUnauthorized, problem.SessionExpired, "session expired")
+
 	case errors.Is(err, domain.ErrSessionNotFound),
 		errors.Is(err, domain.ErrAuthFailed),
-		errors.Is(err, domain.ErrSessionExpired),
 		errors.Is(err, domain.ErrSessionInactive),
 		errors.Is(err, domain.ErrMissingIdentity):
-		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+		return problemError(http.StatusUnauthorized, problem.AuthenticationRequired, "authentication required")
+
+	case errors.Is(err, domain.ErrStepUpRequired):
+		return problemError(http.StatusUnauthorized, problem.ReauthenticationRequired, "re-authentication required")
 
 	case errors.Is(err, domain.ErrKratosUnavailable):
-		return echo.NewHTTPError(http.StatusBadGateway, "identity provider unavailable")
+		return problemError(http.StatusBadGateway, problem.KratosUnavailable, "identity provider unavailable")
 
 	case errors.Is(err, domain.ErrAdminNotConfigured),
 		errors.Is(err, domain.ErrNoIdentitiesFound):
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal configuration error")
+		return problemError(http.StatusInternalServerError, problem.ConfigurationError, "internal configuration error")
 
 	case errors.Is(err, domain.ErrTokenGeneration),
 		errors.Is(err, domain.ErrCSRFSecretMissing),
 		errors.Is(err, domain.ErrBackendSecretWeak):
-		return echo.NewHTTPError(http.StatusInternalServerError, "token generation error")
+		return problemError(http.StatusInternalServerError, problem.TokenGenerationFailed, "token generation error")
+
+	case errors.Is(err, domain.ErrUnknownAudience),
+		errors.Is(err, domain.ErrInvalidScope):
+		return problemError(http.StatusBadRequest, problem.UnknownAudience, "unknown token audience")
+
+	case errors.Is(err, domain.ErrInvalidLifecycleEvent):
+		return problemError(http.StatusBadRequest, problem.InvalidLifecycleEvent, "invalid lifecycle event")
+
+	case errors.Is(err, domain.ErrInvalidLoginAlertLink):
+		return problemError(http.StatusBadRequest, problem.InvalidLink, "invalid or expired link")
+
+	case errors.Is(err, domain.ErrNotificationFailed):
+		return problemError(http.StatusBadGateway, problem.NotificationFailed, "notification delivery failed")
+
+	case errors.Is(err, domain.ErrCSRFTokenInvalid),
+		errors.Is(err, domain.ErrCSRFTokenExpired):
+		return problemError(http.StatusForbidden, problem.CSRFMismatch, "invalid CSRF token")
+
+	case errors.Is(err, domain.ErrLinkedAccountNotFound):
+		return problemError(http.StatusNotFound, problem.LinkedAccountNotFound, "linked account not found")
+
+	case errors.Is(err, domain.ErrLastSignInMethod):
+		return problemError(http.StatusConflict, problem.LastSignInMethod, "cannot remove the last sign-in method")
+
+	case errors.Is(err, domain.ErrServiceAccountNotFound):
+		return problemError(http.StatusNotFound, problem.ServiceAccountNotFound, "service account not found")
+
+	case errors.Is(err, domain.ErrServiceAccountExists):
+		return problemError(http.StatusConflict, problem.ServiceAccountExists, "service account already exists")
 
 	case errors.Is(err, domain.ErrRateLimited):
-		return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+		return problemError(http.StatusTooManyRequests, problem.RateLimited, "rate limit exceeded")
 
 	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
+		return problemError(http.StatusInternalServerError, problem.InternalError, "internal error")
+	}
+}
+
+// HTTPErrorHandler renders every error as problem+json. Errors from
+// mapDomainError keep their code; plain echo.HTTPErrors, such as Echo's own
+// 404 and 405, get a generic code for their status; anything else is an
+// internal error whose message is not exposed.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	p := problem.New(http.StatusInternalServerError, problem.InternalError, "internal error")
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		switch msg := he.Message.(type) {
+		case *problem.Problem:
+			p = msg
+		case string:
+			p = problem.ForStatus(he.Code, msg)
+		default:
+			p = problem.ForStatus(he.Code, http.StatusText(he.Code))
+		}
+	}
+
+	resp := *p
+	resp.Instance = c.Request().URL.Path
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(resp.Status)
+	} else {
+		err = resp.Write(c.Response())
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request().Context(), "failed to write error response", "error", err)
 	}
 }
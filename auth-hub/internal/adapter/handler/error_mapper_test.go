@@ -1,41 +1,63 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"auth-hub/internal/domain"
+	"auth-hub/problem"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMapDomainError(t *testing.T) {
 	tests := []struct {
-		name     string
-		err      error
-		wantCode int
+		name        string
+		err         error
+		wantCode    int
+		wantProblem problem.Code
 	}{
-		{"session not found", domain.ErrSessionNotFound, http.StatusUnauthorized},
-		{"auth failed", domain.ErrAuthFailed, http.StatusUnauthorized},
-		{"session expired", domain.ErrSessionExpired, http.StatusUnauthorized},
-		{"session inactive", domain.ErrSessionInactive, http.StatusUnauthorized},
-		{"missing identity", domain.ErrMissingIdentity, http.StatusUnauthorized},
-		{"kratos unavailable", domain.ErrKratosUnavailable, http.StatusBadGateway},
-		{"admin not configured", domain.ErrAdminNotConfigured, http.StatusInternalServerError},
-		{"no identities found", domain.ErrNoIdentitiesFound, http.StatusInternalServerError},
-		{"token generation", domain.ErrTokenGeneration, http.StatusInternalServerError},
-		{"csrf secret missing", domain.ErrCSRFSecretMissing, http.StatusInternalServerError},
-		{"backend secret weak", domain.ErrBackendSecretWeak, http.StatusInternalServerError},
-		{"rate limited", domain.ErrRateLimited, http.StatusTooManyRequests},
-		{"unknown error", errors.New("something unexpected"), http.StatusInternalServerError},
+		{"session not found", domain.ErrSessionNotFound, http.StatusUnauthorized, problem.AuthenticationRequired},
+		{"auth failed", domain.ErrAuthFailed, http.StatusUnauthorized, problem.AuthenticationRequired},
+		{"session expired", domain.ErrSessionExpired, http.StatusUnauthorized, problem.SessionExpired},
+		{"session inactive", domain.ErrSessionInactive, http.StatusUnauthorized, problem.AuthenticationRequired},
+		{"missing identity", domain.ErrMissingIdentity, http.StatusUnauthorized, problem.AuthenticationRequired},
+		{"step-up required", domain.ErrStepUpRequired, http.StatusUnauthorized, problem.ReauthenticationRequired},
+		{"kratos unavailable", domain.ErrKratosUnavailable, http.StatusBadGateway, problem.KratosUnavailable},
+		{"admin not configured", domain.ErrAdminNotConfigured, http.StatusInternalServerError, problem.ConfigurationError},
+		{"no identities found", domain.ErrNoIdentitiesFound, http.StatusInternalServerError, problem.ConfigurationError},
+		{"token generation", domain.ErrTokenGeneration, http.StatusInternalServerError, problem.TokenGenerationFailed},
+		{"csrf secret missing", domain.ErrCSRFSecretMissing, http.StatusInternalServerError, problem.TokenGenerationFailed},
+		{"backend secret weak", domain.ErrBackendSecretWeak, http.StatusInternalServerError, problem.TokenGenerationFailed},
+		{"unknown audience", domain.ErrUnknownAudience, http.StatusBadRequest, problem.UnknownAudience},
+		{"invalid scope", domain.ErrInvalidScope, http.StatusBadRequest, problem.UnknownAudience},
+		{"invalid lifecycle event", domain.ErrInvalidLifecycleEvent, http.StatusBadRequest, problem.InvalidLifecycleEvent},
+		{"invalid login alert link", domain.ErrInvalidLoginAlertLink, http.StatusBadRequest, problem.InvalidLink},
+		{"notification failed", domain.ErrNotificationFailed, http.StatusBadGateway, problem.NotificationFailed},
+		{"csrf token invalid", domain.ErrCSRFTokenInvalid, http.StatusForbidden, problem.CSRFMismatch},
+		{"csrf token expired", domain.ErrCSRFTokenExpired, http.StatusForbidden, problem.CSRFMismatch},
+		{"linked account not found", domain.ErrLinkedAccountNotFound, http.StatusNotFound, problem.LinkedAccountNotFound},
+		{"last sign-in method", domain.ErrLastSignInMethod, http.StatusConflict, problem.LastSignInMethod},
+		{"service account not found", domain.ErrServiceAccountNotFound, http.StatusNotFound, problem.ServiceAccountNotFound},
+		{"service account exists", domain.ErrServiceAccountExists, http.StatusConflict, problem.ServiceAccountExists},
+		{"rate limited", domain.ErrRateLimited, http.StatusTooManyRequests, problem.RateLimited},
+		{"unknown error", errors.New("something unexpected"), http.StatusInternalServerError, problem.InternalError},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			httpErr := mapDomainError(tt.err)
 			assert.Equal(t, tt.wantCode, httpErr.Code)
+			p, ok := httpErr.Message.(*problem.Problem)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantProblem, p.Code)
+			assert.Equal(t, tt.wantCode, p.Status)
 		})
 	}
 }
@@ -57,3 +79,36 @@ func TestMapDomainError_ReturnsEchoHTTPError(t *testing.T) {
 	assert.NotNil(t, httpErr)
 	assert.Equal(t, http.StatusTooManyRequests, httpErr.Code)
 }
+
+func TestHTTPErrorHandler_WritesProblemJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   problem.Code
+		wantDetail string
+	}{
+		{"domain error", mapDomainError(fmt.Errorf("csrf: %w", domain.ErrCSRFTokenExpired)), http.StatusForbidden, problem.CSRFMismatch, "invalid CSRF token"},
+		{"plain http error", echo.ErrMethodNotAllowed, http.StatusMethodNotAllowed, problem.MethodNotAllowed, "Method Not Allowed"},
+		{"unexpected error", errors.New("dial tcp: connection refused"), http.StatusInternalServerError, problem.InternalError, "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/csrf", nil), rec)
+
+			HTTPErrorHandler(tt.err, c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, problem.ContentType, rec.Header().Get(echo.HeaderContentType))
+			var got problem.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tt.wantCode, got.Code)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantDetail, got.Detail)
+			assert.Equal(t, "/csrf", got.Instance)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// deviceIDHeader carries an optional frontend-generated device ID that
+	// replaces User-Agent + IP subnet in the fingerprint.
+	deviceIDHeader = "X-Alt-Device-Id"
+	// stepUpHeader tells a rejected client where to re-authenticate. nginx
+	// must copy it from the auth_request response with auth_request_set.
+	stepUpHeader = "X-Alt-Step-Up"
+)
+
+// FingerprintGuard runs the session fingerprint check for a request.
+// A nil guard allows every request.
+type FingerprintGuard struct {
+	uc        *usecase.CheckFingerprint
+	stepUpURL string
+}
+
+// NewFingerprintGuard creates a guard that points rejected clients at
+// stepUpURL.
+func NewFingerprintGuard(uc *usecase.CheckFingerprint, stepUpURL string) *FingerprintGuard {
+	return &FingerprintGuard{uc: uc, stepUpURL: stepUpURL}
+}
+
+func (g *FingerprintGuard) check(c echo.Context, cookieValue, userID string) error {
+	if g == nil {
+		return nil
+	}
+	err := g.uc.Execute(c.Request().Context(), cookieValue, userID, clientInfo(c))
+	if errors.Is(err, domain.ErrStepUpRequired) && g.stepUpURL != "" {
+		c.Response().Header().Set(stepUpHeader, g.stepUpURL)
+	}
+	return err
+}
+
+func clientInfo(c echo.Context) domain.ClientInfo {
+	req := c.Request()
+	return domain.ClientInfo{
+		UserAgent: req.UserAgent(),
+		IP:        c.RealIP(),
+		DeviceID:  req.Header.Get(deviceIDHeader),
+	}
+}
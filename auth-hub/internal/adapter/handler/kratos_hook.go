@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// KratosHookHandler receives session/identity lifecycle webhooks from Kratos.
+type KratosHookHandler struct {
+	uc        *usecase.InvalidateSessions
+	passwords *usecase.RecordPasswordHistory
+	logins    *usecase.NotifyLogin
+}
+
+// NewKratosHookHandler creates a new Kratos webhook handler.
+func NewKratosHookHandler(uc *usecase.InvalidateSessions) *KratosHookHandler {
+	return &KratosHookHandler{uc: uc}
+}
+
+// WithPasswordHistory records the new password on identity.password_changed
+// events, so the password policy can reject reuse.
+func (h *KratosHookHandler) WithPasswordHistory(uc *usecase.RecordPasswordHistory) *KratosHookHandler {
+	h.passwords = uc
+	return h
+}
+
+// WithLoginAlerts checks session.login events for new devices and
+// locations and alerts the user about them.
+func (h *KratosHookHandler) WithLoginAlerts(uc *usecase.NotifyLogin) *KratosHookHandler {
+	h.logins = uc
+	return h
+}
+
+// kratosHookRequest is the body rendered by the Kratos web_hook jsonnet
+// templates. The fields after SessionID are only sent with session.login.
+type kratosHookRequest struct {
+	Event           string    `json:"event"`
+	IdentityID      string    `json:"identity_id"`
+	SessionID       string    `json:"session_id"`
+	Email           string    `json:"email"`
+	IPAddress       string    `json:"ip_address"`
+	UserAgent       string    `json:"user_agent"`
+	Location        string    `json:"location"`
+	AuthenticatedAt time.Time `json:"authenticated_at"`
+}
+
+// kratosHookResponse reports how many cache entries were dropped.
+type kratosHookResponse struct {
+	Invalidated int `json:"invalidated"`
+}
+
+// Handle processes POST /internal/hooks/kratos.
+func (h *KratosHookHandler) Handle(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req kratosHookRequest
+	if err := c.Bind(&req); err != nil {
+		return problemError(http.StatusBadRequest, problem.InvalidRequest, "invalid request body")
+	}
+
+	event := domain.LifecycleEvent{
+		Type:       domain.LifecycleEventType(req.Event),
+		IdentityID: req.IdentityID,
+		SessionID:  req.SessionID,
+	}
+	n, err := h.uc.Execute(ctx, event)
+	if err != nil {
+		slog.WarnContext(ctx, "kratos webhook rejected", "event", req.Event, "error", err, "remote_addr", c.RealIP())
+		return mapDomainError(err)
+	}
+
+	if event.Type == domain.LifecyclePasswordChanged && h.passwords != nil {
+		if err := h.passwords.Execute(ctx, event.IdentityID); err != nil {
+			return mapDomainError(err)
+		}
+	}
+
+	if event.Type == domain.LifecycleLogin && h.logins != nil {
+		err := h.logins.Execute(ctx, domain.Login{
+			IdentityID:      req.IdentityID,
+			SessionID:       req.SessionID,
+			Email:           req.Email,
+			Client:          domain.ClientInfo{UserAgent: req.UserAgent, IP: req.IPAddress},
+			Location:        req.Location,
+			AuthenticatedAt: req.AuthenticatedAt,
+		})
+		if err != nil {
+			slog.WarnContext(ctx, "login alert failed", "identity_id", req.IdentityID, "error", err)
+			return mapDomainError(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, kratosHookResponse{Invalidated: n})
+}
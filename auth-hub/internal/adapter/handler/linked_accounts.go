@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"auth-hub/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LinkedAccountsHandler lets the signed-in user see and remove the Google or
+// GitHub accounts linked for social sign-in. Linking itself runs through
+// Kratos' settings flow.
+type LinkedAccountsHandler struct {
+	uc *usecase.ManageLinkedAccounts
+}
+
+// NewLinkedAccountsHandler creates a new linked accounts handler.
+func NewLinkedAccountsHandler(uc *usecase.ManageLinkedAccounts) *LinkedAccountsHandler {
+	return &LinkedAccountsHandler{uc: uc}
+}
+
+// linkedAccount is one entry of linkedAccountsResponse.
+type linkedAccount struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+// linkedAccountsResponse lists the sign-in methods so the frontend can tell
+// whether an unlink would be refused.
+type linkedAccountsResponse struct {
+	Password bool            `json:"password"`
+	Code     bool            `json:"code"`
+	Accounts []linkedAccount `json:"accounts"`
+}
+
+// List processes GET /linked-accounts.
+func (h *LinkedAccountsHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	methods, err := h.uc.List(ctx, c.Request().Header.Get("Cookie"))
+	if err != nil {
+		return mapDomainError(err)
+	}
+
+	resp := linkedAccountsResponse{
+		Password: methods.Password,
+		Code:     methods.Code,
+		Accounts: make([]linkedAccount, 0, len(methods.Linked)),
+	}
+	for _, a := range methods.Linked {
+		resp.Accounts = append(resp.Accounts, linkedAccount{Provider: a.Provider, Subject: a.Subject})
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Unlink processes DELETE /linked-accounts/:provider. The X-CSRF-Token
+// header must carry a token from POST /csrf for the same session.
+func (h *LinkedAccountsHandler) Unlink(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rawCookie := c.Request().Header.Get("Cookie")
+	err := h.uc.Unlink(ctx, rawCookie, extractSessionID(rawCookie),
+		c.Request().Header.Get("X-CSRF-Token"), c.Param("provider"))
+	if err != nil {
+		return mapDomainError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LoginAlertHandler serves the one-click links in login alert emails.
+type LoginAlertHandler struct {
+	uc *usecase.ApplyLoginAlertLink
+}
+
+// NewLoginAlertHandler creates a new login alert link handler.
+func NewLoginAlertHandler(uc *usecase.ApplyLoginAlertLink) *LoginAlertHandler {
+	return &LoginAlertHandler{uc: uc}
+}
+
+// Revoke handles GET and POST /login-alerts/revoke?token=...
+func (h *LoginAlertHandler) Revoke(c echo.Context) error {
+	return h.apply(c, domain.LoginAlertRevoke,
+		"The session has been signed out. If you did not sign in, change your password now.")
+}
+
+// OptOut handles GET and POST /login-alerts/opt-out?token=...; POST is the
+// RFC 8058 one-click unsubscribe mail clients send.
+func (h *LoginAlertHandler) OptOut(c echo.Context) error {
+	return h.apply(c, domain.LoginAlertOptOut,
+		"Sign-in alerts are turned off for your account.")
+}
+
+func (h *LoginAlertHandler) apply(c echo.Context, action domain.LoginAlertAction, done string) error {
+	// The token is a bearer credential in the URL; keep it out of Referer.
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+
+	token := c.QueryParam("token")
+	if token == "" {
+		return problemError(http.StatusBadRequest, problem.InvalidRequest, "token is required")
+	}
+	if err := h.uc.Execute(c.Request().Context(), action, token); err != nil {
+		return mapDomainError(err)
+	}
+	return c.String(http.StatusOK, done)
+}
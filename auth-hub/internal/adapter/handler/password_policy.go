@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PasswordPolicyHandler lets the frontend check a new password before it
+// submits a Kratos registration or settings flow, and enforces the policy
+// when Kratos calls back before saving it.
+type PasswordPolicyHandler struct {
+	uc *usecase.CheckPassword
+}
+
+// NewPasswordPolicyHandler creates a new password policy handler.
+func NewPasswordPolicyHandler(uc *usecase.CheckPassword) *PasswordPolicyHandler {
+	return &PasswordPolicyHandler{uc: uc}
+}
+
+// passwordCheckRequest is the body of POST /password/check.
+type passwordCheckRequest struct {
+	Password string `json:"password"`
+}
+
+// passwordHookRequest is the body rendered by the password_policy.jsonnet
+// Kratos web_hook. IdentityID is empty at registration.
+type passwordHookRequest struct {
+	IdentityID string `json:"identity_id"`
+	Password   string `json:"password"`
+}
+
+// kratosErrorMessageID is Kratos's generic validation error
+// (text.ErrorValidationGeneric); hooks have no ids of their own.
+const kratosErrorMessageID = 4000001
+
+// kratosHookMessages is the body a parsed Kratos web_hook returns to
+// interrupt a flow and show messages on its fields.
+type kratosHookMessages struct {
+	Messages []kratosFieldMessages `json:"messages"`
+}
+
+type kratosFieldMessages struct {
+	InstancePtr string               `json:"instance_ptr"`
+	Messages    []kratosFieldMessage `json:"messages"`
+}
+
+type kratosFieldMessage struct {
+	ID      int               `json:"id"`
+	Text    string            `json:"text"`
+	Type    string            `json:"type"`
+	Context map[string]string `json:"context"`
+}
+
+// passwordViolation is one failed rule. Code is stable for the frontend to
+// map to a localized message; Message is an English fallback.
+type passwordViolation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// passwordCheckResponse reports the outcome of a check.
+type passwordCheckResponse struct {
+	Valid          bool                `json:"valid"`
+	Violations     []passwordViolation `json:"violations"`
+	BreachChecked  bool                `json:"breach_checked"`
+	HistoryChecked bool                `json:"history_checked"`
+}
+
+// passwordPolicyResponse describes the active policy so the frontend can
+// show requirements up front.
+type passwordPolicyResponse struct {
+	MinLength       int      `json:"min_length"`
+	MaxBytes        int      `json:"max_bytes"`
+	RequiredClasses []string `json:"required_classes"`
+	BreachCheck     bool     `json:"breach_check"`
+	HistorySize     int      `json:"history_size"`
+}
+
+// Check processes POST /password/check. The session cookie, when present,
+// enables the reuse check for password changes.
+func (h *PasswordPolicyHandler) Check(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req passwordCheckRequest
+	if err := c.Bind(&req); err != nil || req.Password == "" {
+		return problemError(http.StatusBadRequest, problem.InvalidRequest, "password is required")
+	}
+
+	result, err := h.uc.Execute(ctx, req.Password, c.Request().Header.Get("Cookie"))
+	if err != nil {
+		return mapDomainError(err)
+	}
+
+	resp := passwordCheckResponse{
+		Valid:          result.Valid(),
+		Violations:     make([]passwordViolation, 0, len(result.Violations)),
+		BreachChecked:  result.BreachChecked,
+		HistoryChecked: result.HistoryChecked,
+	}
+	codes := make([]string, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		resp.Violations = append(resp.Violations, passwordViolation{Code: string(v.Code), Message: v.Message})
+		codes = append(codes, string(v.Code))
+	}
+
+	// Never log the password; the codes are enough to see which rules bite.
+	slog.InfoContext(ctx, "password checked",
+		"valid", resp.Valid,
+		"violations", codes,
+		"breach_checked", resp.BreachChecked,
+		"history_checked", resp.HistoryChecked)
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Enforce processes POST /internal/hooks/kratos/password, a web_hook with
+// response.parse on the registration and settings password flows. Kratos
+// aborts the flow on a 4xx and shows the messages on the password field,
+// so a password reaches Kratos only if it passes the policy, whatever
+// /password/check told the client.
+func (h *PasswordPolicyHandler) Enforce(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req passwordHookRequest
+	if err := c.Bind(&req); err != nil {
+		return problemError(http.StatusBadRequest, problem.InvalidRequest, "invalid request body")
+	}
+	if req.Password == "" {
+		// The password only reaches the hook through the flow's
+		// transient_payload; without it the policy cannot be checked.
+		slog.WarnContext(ctx, "password hook without password, rejecting", "identity_id", req.IdentityID)
+		return c.JSON(http.StatusBadRequest, kratosPasswordMessages(passwordViolation{
+			Code:    "password_missing",
+			Message: "The password could not be checked. Reload the page and try again.",
+		}))
+	}
+
+	result := h.uc.ExecuteForIdentity(ctx, req.Password, req.IdentityID)
+	codes := make([]string, 0, len(result.Violations))
+	violations := make([]passwordViolation, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		violations = append(violations, passwordViolation{Code: string(v.Code), Message: v.Message})
+		codes = append(codes, string(v.Code))
+	}
+
+	slog.InfoContext(ctx, "password policy enforced",
+		"identity_id", req.IdentityID,
+		"valid", result.Valid(),
+		"violations", codes,
+		"breach_checked", result.BreachChecked,
+		"history_checked", result.HistoryChecked)
+
+	if !result.Valid() {
+		return c.JSON(http.StatusBadRequest, kratosPasswordMessages(violations...))
+	}
+	return c.JSON(http.StatusOK, struct{}{})
+}
+
+// kratosPasswordMessages renders violations as errors on the password field.
+func kratosPasswordMessages(violations ...passwordViolation) kratosHookMessages {
+	field := kratosFieldMessages{InstancePtr: "#/password"}
+	for _, v := range violations {
+		field.Messages = append(field.Messages, kratosFieldMessage{
+			ID:      kratosErrorMessageID,
+			Text:    v.Message,
+			Type:    "error",
+			Context: map[string]string{"reason": v.Code},
+		})
+	}
+	return kratosHookMessages{Messages: []kratosFieldMessages{field}}
+}
+
+// Policy processes GET /password/policy.
+func (h *PasswordPolicyHandler) Policy(c echo.Context) error {
+	policy := h.uc.Policy()
+	classes := make([]string, 0, len(policy.RequiredClasses))
+	for _, class := range policy.RequiredClasses {
+		classes = append(classes, string(class))
+	}
+	return c.JSON(http.StatusOK, passwordPolicyResponse{
+		MinLength:       policy.MinLength,
+		MaxBytes:        domain.MaxPasswordBytes,
+		RequiredClasses: classes,
+		BreachCheck:     policy.CheckBreached,
+		HistorySize:     policy.HistorySize,
+	})
+}
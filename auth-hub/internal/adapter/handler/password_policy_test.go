@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicyHandler_Enforce(t *testing.T) {
+	policy := domain.PasswordPolicy{
+		MinLength:       12,
+		RequiredClasses: []domain.CharClass{domain.CharClassLower, domain.CharClassUpper, domain.CharClassDigit},
+	}
+	h := NewPasswordPolicyHandler(usecase.NewCheckPassword(policy, nil, nil, nil, slog.Default()))
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantReasons []string
+	}{
+		{"compliant", `{"identity_id":"","password":"Correct-Horse-42"}`, http.StatusOK, nil},
+		{"too short", `{"identity_id":"user-1","password":"Short-1a"}`, http.StatusBadRequest, []string{"password_too_short"}},
+		{"no password in the payload", `{"identity_id":"","password":""}`, http.StatusBadRequest, []string{"password_missing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/internal/hooks/kratos/password", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			require.NoError(t, h.Enforce(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantReasons == nil {
+				assert.JSONEq(t, `{}`, rec.Body.String())
+				return
+			}
+
+			var body kratosHookMessages
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Len(t, body.Messages, 1)
+			assert.Equal(t, "#/password", body.Messages[0].InstancePtr)
+			reasons := make([]string, 0, len(body.Messages[0].Messages))
+			for _, m := range body.Messages[0].Messages {
+				assert.Equal(t, "error", m.Type)
+				assert.NotEmpty(t, m.Text)
+				reasons = append(reasons, m.Context["reason"])
+			}
+			assert.Equal(t, tt.wantReasons, reasons)
+		})
+	}
+}
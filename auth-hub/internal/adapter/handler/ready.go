@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReadyHandler reports whether this instance can validate sessions, so
+// Kubernetes only routes to instances whose dependencies work. /health stays
+// a static liveness check.
+type ReadyHandler struct {
+	uc *usecase.CheckReadiness
+}
+
+// NewReadyHandler creates a new readiness handler.
+func NewReadyHandler(uc *usecase.CheckReadiness) *ReadyHandler {
+	return &ReadyHandler{uc: uc}
+}
+
+// dependencyStatusResponse is one dependency in the /ready response.
+// Status is "ok", "degraded" (failing but under its threshold) or
+// "unavailable".
+type dependencyStatusResponse struct {
+	Name                string     `json:"name"`
+	Status              string     `json:"status"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	FailureThreshold    int        `json:"failure_threshold"`
+	LatencyMs           int64      `json:"latency_ms"`
+	Error               string     `json:"error,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+}
+
+// readyResponse is the body of GET /ready.
+type readyResponse struct {
+	Status       string                     `json:"status"`
+	CheckedAt    time.Time                  `json:"checked_at"`
+	Dependencies []dependencyStatusResponse `json:"dependencies"`
+}
+
+// Handle processes the /ready endpoint: 200 when every dependency is ready,
+// 503 otherwise.
+func (h *ReadyHandler) Handle(c echo.Context) error {
+	report := h.uc.Execute(c.Request().Context())
+
+	resp := readyResponse{
+		Status:       "ready",
+		CheckedAt:    report.CheckedAt,
+		Dependencies: make([]dependencyStatusResponse, 0, len(report.Dependencies)),
+	}
+	for _, d := range report.Dependencies {
+		resp.Dependencies = append(resp.Dependencies, toDependencyStatusResponse(d))
+	}
+
+	code := http.StatusOK
+	if !report.Ready {
+		resp.Status = "not_ready"
+		code = http.StatusServiceUnavailable
+	}
+	return c.JSON(code, resp)
+}
+
+func toDependencyStatusResponse(d domain.DependencyStatus) dependencyStatusResponse {
+	out := dependencyStatusResponse{
+		Name:                d.Name,
+		ConsecutiveFailures: d.ConsecutiveFailures,
+		FailureThreshold:    d.FailureThreshold,
+		LatencyMs:           d.Latency.Milliseconds(),
+		Error:               d.LastError,
+	}
+	switch {
+	case !d.Ready:
+		out.Status = "unavailable"
+	case !d.LastProbeOK:
+		out.Status = "degraded"
+	default:
+		out.Status = "ok"
+	}
+	if !d.LastSuccess.IsZero() {
+		last := d.LastSuccess
+		out.LastSuccess = &last
+	}
+	return out
+}
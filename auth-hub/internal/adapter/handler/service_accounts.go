@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ServiceAccountHandler serves the admin API for service accounts, behind
+// the tenant's internal secret, and the client credentials token endpoint
+// the accounts themselves call.
+type ServiceAccountHandler struct {
+	manage *usecase.ManageServiceAccounts
+	issue  *usecase.IssueServiceAccountToken
+}
+
+// NewServiceAccountHandler creates a new service account handler.
+func NewServiceAccountHandler(manage *usecase.ManageServiceAccounts, issue *usecase.IssueServiceAccountToken) *ServiceAccountHandler {
+	return &ServiceAccountHandler{manage: manage, issue: issue}
+}
+
+// serviceGrant is one audience an account may obtain tokens for.
+type serviceGrant struct {
+	Audience string   `json:"audience"`
+	Scopes   []string `json:"scopes"`
+}
+
+// createServiceAccountRequest is the body of POST /internal/service-accounts.
+// TokenTTL is a Go duration such as "5m".
+type createServiceAccountRequest struct {
+	Name        string         `json:"name"`
+	Grants      []serviceGrant `json:"grants"`
+	TokenTTL    string         `json:"token_ttl"`
+	IPAllowlist []string       `json:"ip_allowlist"`
+}
+
+// rotateServiceAccountRequest is the optional body of
+// POST /internal/service-accounts/:id/rotate. GracePeriod is a Go duration;
+// "0s" revokes the current secret at once.
+type rotateServiceAccountRequest struct {
+	GracePeriod string `json:"grace_period"`
+}
+
+// serviceCredential describes a secret without its hash.
+type serviceCredential struct {
+	ID         string     `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// serviceAccountUsage is when and from where the account last obtained a
+// token.
+type serviceAccountUsage struct {
+	TokensIssued int64      `json:"tokens_issued"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP   string     `json:"last_used_ip,omitempty"`
+	LastAudience string     `json:"last_audience,omitempty"`
+}
+
+// serviceAccountResponse is one account. ClientSecret is only set in the
+// responses to create and rotate.
+type serviceAccountResponse struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Grants       []serviceGrant      `json:"grants"`
+	TokenTTL     string              `json:"token_ttl"`
+	IPAllowlist  []string            `json:"ip_allowlist"`
+	Credentials  []serviceCredential `json:"credentials"`
+	Usage        serviceAccountUsage `json:"usage"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	DisabledAt   *time.Time          `json:"disabled_at,omitempty"`
+	ClientSecret string              `json:"client_secret,omitempty"`
+}
+
+// serviceAccountListResponse is the body of GET /internal/service-accounts.
+type serviceAccountListResponse struct {
+	Accounts []serviceAccountResponse `json:"accounts"`
+}
+
+// clientCredentialsResponse is the RFC 6749 section 5.1 success response.
+type clientCredentialsResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// Create processes POST /internal/service-accounts. The response carries
+// the client secret, which cannot be read again.
+func (h *ServiceAccountHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req createServiceAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return problemError(http.StatusBadRequest, problem.InvalidRequest, "invalid request body")
+	}
+	var ttl time.Duration
+	if req.TokenTTL != "" {
+		d, err := time.ParseDuration(req.TokenTTL)
+		if err != nil {
+			return problemError(http.StatusBadRequest, problem.InvalidRequest, "token_ttl must be a duration such as 5m")
+		}
+		ttl = d
+	}
+	grants := make([]domain.ServiceGrant, 0, len(req.Grants))
+	for _, g := range req.Grants {
+		grants = append(grants, domain.ServiceGrant{Audience: g.Audience, Scopes: g.Scopes})
+	}
+
+	created, err := h.manage.Create(ctx, usecase.CreateServiceAccountRequest{
+		Name:        req.Name,
+		Grants:      grants,
+		TokenTTL:    ttl,
+		IPAllowlist: req.IPAllowlist,
+	})
+	if err != nil {
+		return serviceAccountError(err)
+	}
+
+	resp := toServiceAccountResponse(created.Account)
+	resp.ClientSecret = created.ClientSecret
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// List processes GET /internal/service-accounts.
+func (h *ServiceAccountHandler) List(c echo.Context) error {
+	accounts, err := h.manage.List(c.Request().Context())
+	if err != nil {
+		return serviceAccountError(err)
+	}
+
+	resp := serviceAccountListResponse{Accounts: make([]serviceAccountResponse, 0, len(accounts))}
+	for i := range accounts {
+		resp.Accounts = append(resp.Accounts, toServiceAccountResponse(&accounts[i]))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Get processes GET /internal/service-accounts/:id.
+func (h *ServiceAccountHandler) Get(c echo.Context) error {
+	account, err := h.manage.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceAccountError(err)
+	}
+	return c.JSON(http.StatusOK, toServiceAccountResponse(account))
+}
+
+// Rotate processes POST /internal/service-accounts/:id/rotate. The response
+// carries the new client secret; the previous one keeps working for the
+// grace period, one hour unless the body says otherwise.
+func (h *ServiceAccountHandler) Rotate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req rotateServiceAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return problemError(http.StatusBadRequest, problem.InvalidRequest, "invalid request body")
+	}
+	grace := usecase.DefaultRotationGrace
+	if req.GracePeriod != "" {
+		d, err := time.ParseDuration(req.GracePeriod)
+		if err != nil {
+			return problemError(http.StatusBadRequest, problem.InvalidRequest, "grace_period must be a duration such as 1h")
+		}
+		grace = d
+	}
+
+	rotated, err := h.manage.Rotate(ctx, c.Param("id"), grace)
+	if err != nil {
+		return serviceAccountError(err)
+	}
+
+	resp := toServiceAccountResponse(rotated.Account)
+	resp.ClientSecret = rotated.ClientSecret
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Disable processes POST /internal/service-accounts/:id/disable.
+func (h *ServiceAccountHandler) Disable(c echo.Context) error {
+	account, err := h.manage.Disable(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceAccountError(err)
+	}
+	return c.JSON(http.StatusOK, toServiceAccountResponse(account))
+}
+
+// Token processes an application/x-www-form-urlencoded client credentials
+// request. The client authenticates with HTTP Basic or, as RFC 6749 also
+// allows, client_id and client_secret form fields.
+func (h *ServiceAccountHandler) Token(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	clientID, clientSecret, ok := c.Request().BasicAuth()
+	if !ok {
+		clientID, clientSecret = c.FormValue("client_id"), c.FormValue("client_secret")
+	}
+
+	result, err := h.issue.Execute(ctx, usecase.ClientCredentialsRequest{
+		GrantType:    c.FormValue("grant_type"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Audience:     c.FormValue("audience"),
+		Scope:        c.FormValue("scope"),
+		ClientIP:     c.RealIP(),
+	})
+	c.Response().Header().Set("Cache-Control", "no-store")
+	if err != nil {
+		status, code, description := clientCredentialsError(err)
+		slog.WarnContext(ctx, "service account token rejected", "error_code", code, "error", err, "client_id", clientID, "remote_addr", c.RealIP())
+		if status == http.StatusUnauthorized {
+			c.Response().Header().Set("WWW-Authenticate", `Basic realm="auth-hub"`)
+		}
+		return c.JSON(status, tokenErrorResponse{Error: code, ErrorDescription: description})
+	}
+
+	return c.JSON(http.StatusOK, clientCredentialsResponse{
+		AccessToken: result.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   result.ExpiresIn,
+		Scope:       result.Scope,
+	})
+}
+
+// clientCredentialsError maps a domain error to an HTTP status and OAuth
+// error code.
+func clientCredentialsError(err error) (status int, code, description string) {
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedGrantType):
+		return http.StatusBadRequest, "unsupported_grant_type", "grant_type must be " + domain.GrantTypeClientCredentials
+	case errors.Is(err, domain.ErrInvalidClient):
+		return http.StatusUnauthorized, "invalid_client", "client authentication failed"
+	case errors.Is(err, domain.ErrClientIPForbidden):
+		return http.StatusForbidden, "unauthorized_client", "client is not allowed from this address"
+	case errors.Is(err, domain.ErrUnknownAudience):
+		return http.StatusBadRequest, "invalid_target", "audience is missing or not granted to this client"
+	case errors.Is(err, domain.ErrInvalidScope):
+		return http.StatusBadRequest, "invalid_scope", "scope exceeds what this client is granted"
+	default:
+		return http.StatusInternalServerError, "server_error", "token request failed"
+	}
+}
+
+// serviceAccountError maps admin API errors. Validation errors keep their
+// message, which names the offending field, as the problem detail.
+func serviceAccountError(err error) *echo.HTTPError {
+	switch {
+	case errors.Is(err, domain.ErrInvalidServiceAccount),
+		errors.Is(err, domain.ErrUnknownAudience),
+		errors.Is(err, domain.ErrInvalidScope):
+		return problemError(http.StatusBadRequest, problem.InvalidRequest, err.Error())
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func toServiceAccountResponse(a *domain.ServiceAccount) serviceAccountResponse {
+	resp := serviceAccountResponse{
+		ID:          a.ID,
+		Name:        a.Name,
+		Grants:      make([]serviceGrant, 0, len(a.Grants)),
+		TokenTTL:    a.TokenTTL.String(),
+		IPAllowlist: make([]string, 0, len(a.IPAllowlist)),
+		Credentials: make([]serviceCredential, 0, len(a.Credentials)),
+		Usage: serviceAccountUsage{
+			TokensIssued: a.Usage.TokensIssued,
+			LastUsedAt:   a.Usage.LastUsedAt,
+			LastUsedIP:   a.Usage.LastUsedIP,
+			LastAudience: a.Usage.LastAudience,
+		},
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+		DisabledAt: a.DisabledAt,
+	}
+	for _, g := range a.Grants {
+		resp.Grants = append(resp.Grants, serviceGrant{Audience: g.Audience, Scopes: g.Scopes})
+	}
+	for _, p := range a.IPAllowlist {
+		resp.IPAllowlist = append(resp.IPAllowlist, p.String())
+	}
+	for _, cred := range a.Credentials {
+		resp.Credentials = append(resp.Credentials, serviceCredential{
+			ID:         cred.ID,
+			CreatedAt:  cred.CreatedAt,
+			ExpiresAt:  cred.ExpiresAt,
+			LastUsedAt: cred.LastUsedAt,
+		})
+	}
+	return resp
+}
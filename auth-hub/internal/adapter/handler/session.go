@@ -2,21 +2,25 @@ package handler
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"auth-hub/internal/usecase"
+	"auth-hub/problem"
 
 	"github.com/labstack/echo/v4"
 )
 
 // SessionHandler handles /session endpoint returning JSON for the frontend.
 type SessionHandler struct {
-	uc *usecase.GetSession
+	uc    *usecase.GetSession
+	guard *FingerprintGuard
 }
 
-// NewSessionHandler creates a new session handler.
-func NewSessionHandler(uc *usecase.GetSession) *SessionHandler {
-	return &SessionHandler{uc: uc}
+// NewSessionHandler creates a new session handler. guard may be nil to skip
+// fingerprint binding.
+func NewSessionHandler(uc *usecase.GetSession, guard *FingerprintGuard) *SessionHandler {
+	return &SessionHandler{uc: uc, guard: guard}
 }
 
 // sessionUser represents the user object in the response.
@@ -24,6 +28,7 @@ type sessionUser struct {
 	ID          string    `json:"id"`
 	TenantID    string    `json:"tenantId"`
 	Email       string    `json:"email"`
+	Name        string    `json:"name,omitempty"`
 	Role        string    `json:"role"`
 	CreatedAt   time.Time `json:"createdAt"`
 	LastLoginAt time.Time `json:"lastLoginAt,omitempty"`
@@ -35,33 +40,58 @@ type sessionInfo struct {
 	Active bool   `json:"active"`
 }
 
+// serviceToken represents one audience-scoped token in the response.
+type serviceToken struct {
+	Audience  string    `json:"audience"`
+	Token     string    `json:"token"`
+	Scope     string    `json:"scope,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 // sessionResponse represents the JSON response structure.
 type sessionResponse struct {
-	OK      bool        `json:"ok"`
-	User    sessionUser `json:"user"`
-	Session sessionInfo `json:"session"`
+	OK            bool           `json:"ok"`
+	User          sessionUser    `json:"user"`
+	Session       sessionInfo    `json:"session"`
+	ServiceTokens []serviceToken `json:"serviceTokens,omitempty"`
 }
 
-// Handle processes the /session endpoint and returns JSON.
+// Handle processes the /session endpoint and returns JSON. Service tokens
+// are issued for each ?audience= value (repeated or comma-separated); the
+// backend token is always returned in X-Alt-Backend-Token.
 func (h *SessionHandler) Handle(c echo.Context) error {
 	cookie, err := c.Cookie("ory_kratos_session")
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "session cookie not found")
+		return problemError(http.StatusUnauthorized, problem.SessionRequired, "session cookie not found")
 	}
 
-	result, err := h.uc.Execute(c.Request().Context(), cookie.Value)
+	result, err := h.uc.ExecuteFor(c.Request().Context(), cookie.Value, requestedAudiences(c))
 	if err != nil {
 		return mapDomainError(err)
 	}
+	if err := h.guard.check(c, cookie.Value, result.UserID); err != nil {
+		return mapDomainError(err)
+	}
 
 	c.Response().Header().Set("X-Alt-Backend-Token", result.BackendToken)
 
+	tokens := make([]serviceToken, 0, len(result.ServiceTokens))
+	for _, t := range result.ServiceTokens {
+		tokens = append(tokens, serviceToken{
+			Audience:  t.Audience,
+			Token:     t.Token,
+			Scope:     strings.Join(t.Scopes, " "),
+			ExpiresAt: t.ExpiresAt,
+		})
+	}
+
 	return c.JSON(http.StatusOK, sessionResponse{
 		OK: true,
 		User: sessionUser{
 			ID:          result.UserID,
 			TenantID:    result.TenantID,
 			Email:       result.Email,
+			Name:        result.Name,
 			Role:        result.Role,
 			CreatedAt:   result.CreatedAt,
 			LastLoginAt: time.Now(),
@@ -70,5 +100,19 @@ func (h *SessionHandler) Handle(c echo.Context) error {
 			ID:     result.SessionID,
 			Active: true,
 		},
+		ServiceTokens: tokens,
 	})
 }
+
+// requestedAudiences collects the audience query parameters.
+func requestedAudiences(c echo.Context) []string {
+	var audiences []string
+	for _, v := range c.QueryParams()["audience"] {
+		for aud := range strings.SplitSeq(v, ",") {
+			if aud = strings.TrimSpace(aud); aud != "" {
+				audiences = append(audiences, aud)
+			}
+		}
+	}
+	return audiences
+}
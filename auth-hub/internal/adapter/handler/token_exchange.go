@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TokenExchangeHandler handles the RFC 8693 token exchange endpoint used by
+// services to call other services on a user's behalf.
+type TokenExchangeHandler struct {
+	uc *usecase.ExchangeToken
+}
+
+// NewTokenExchangeHandler creates a new token exchange handler.
+func NewTokenExchangeHandler(uc *usecase.ExchangeToken) *TokenExchangeHandler {
+	return &TokenExchangeHandler{uc: uc}
+}
+
+// tokenExchangeResponse is the RFC 8693 section 2.2.1 success response.
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+// tokenErrorResponse is the RFC 6749 section 5.2 error response.
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// Handle processes an application/x-www-form-urlencoded exchange request.
+func (h *TokenExchangeHandler) Handle(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	result, err := h.uc.Execute(ctx, usecase.TokenExchangeRequest{
+		GrantType:          c.FormValue("grant_type"),
+		SubjectToken:       c.FormValue("subject_token"),
+		SubjectTokenType:   c.FormValue("subject_token_type"),
+		RequestedTokenType: c.FormValue("requested_token_type"),
+		Audience:           c.FormValue("audience"),
+		Scope:              c.FormValue("scope"),
+	})
+	if err != nil {
+		status, code, description := tokenExchangeError(err)
+		slog.WarnContext(ctx, "token exchange rejected", "error_code", code, "error", err, "remote_addr", c.RealIP())
+		c.Response().Header().Set("Cache-Control", "no-store")
+		return c.JSON(status, tokenErrorResponse{Error: code, ErrorDescription: description})
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, tokenExchangeResponse{
+		AccessToken:     result.AccessToken,
+		IssuedTokenType: result.IssuedTokenType,
+		TokenType:       "Bearer",
+		ExpiresIn:       result.ExpiresIn,
+		Scope:           result.Scope,
+	})
+}
+
+// tokenExchangeError maps a domain error to an HTTP status and OAuth error
+// code. Anything unexpected is a server error with a generic description.
+func tokenExchangeError(err error) (status int, code, description string) {
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedGrantType):
+		return http.StatusBadRequest, "unsupported_grant_type", "grant_type must be " + domain.GrantTypeExchange
+	case errors.Is(err, domain.ErrInvalidSubjectToken):
+		return http.StatusBadRequest, "invalid_grant", "subject_token is invalid or expired"
+	case errors.Is(err, domain.ErrUnknownAudience):
+		return http.StatusBadRequest, "invalid_target", "audience is missing or not a known service"
+	case errors.Is(err, domain.ErrInvalidScope):
+		return http.StatusBadRequest, "invalid_scope", "scope exceeds what the audience allows"
+	case errors.Is(err, domain.ErrInvalidTokenRequest):
+		return http.StatusBadRequest, "invalid_request", err.Error()
+	default:
+		return http.StatusInternalServerError, "server_error", "token exchange failed"
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"auth-hub/internal/domain"
 	"auth-hub/internal/usecase"
+	"auth-hub/problem"
 
 	"github.com/labstack/echo/v4"
 )
@@ -15,30 +16,34 @@ import (
 type ValidateHandler struct {
 	uc    *usecase.ValidateSession
 	token domain.TokenIssuer
+	guard *FingerprintGuard
 }
 
 // NewValidateHandler creates a new validate handler.
 // token must be non-nil: /validate is the sole source of the backend JWT that
 // nginx forwards downstream, so an unwired TokenIssuer is a startup bug, not a
-// degraded mode.
-func NewValidateHandler(uc *usecase.ValidateSession, token domain.TokenIssuer) *ValidateHandler {
+// degraded mode. guard may be nil to skip fingerprint binding.
+func NewValidateHandler(uc *usecase.ValidateSession, token domain.TokenIssuer, guard *FingerprintGuard) *ValidateHandler {
 	if token == nil {
 		panic("handler: NewValidateHandler requires a non-nil TokenIssuer")
 	}
-	return &ValidateHandler{uc: uc, token: token}
+	return &ValidateHandler{uc: uc, token: token, guard: guard}
 }
 
 // Handle processes the /validate endpoint.
 func (h *ValidateHandler) Handle(c echo.Context) error {
 	cookie, err := c.Cookie("ory_kratos_session")
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "session cookie not found")
+		return problemError(http.StatusUnauthorized, problem.SessionRequired, "session cookie not found")
 	}
 
 	identity, err := h.uc.Execute(c.Request().Context(), cookie.Value)
 	if err != nil {
 		return mapDomainError(err)
 	}
+	if err := h.guard.check(c, cookie.Value, identity.UserID); err != nil {
+		return mapDomainError(err)
+	}
 
 	// Issue JWT backend token for nginx to forward to backend. A failure here
 	// must fail closed: without this header the backend receives no proof of
@@ -0,0 +1,158 @@
+// Package rpc serves auth-hub's Connect-RPC API (services.auth.v1) for Go
+// services that would otherwise call /validate, /session and /csrf over
+// hand-rolled HTTP.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "auth-hub/gen/proto/services/auth/v1"
+	"auth-hub/gen/proto/services/auth/v1/authv1connect"
+	"auth-hub/internal/domain"
+	"auth-hub/internal/usecase"
+)
+
+// AuthService implements authv1connect.AuthServiceHandler on the same
+// usecases as the REST handlers.
+type AuthService struct {
+	validate    *usecase.ValidateSession
+	session     *usecase.GetSession
+	csrf        *usecase.GenerateCSRF
+	token       domain.TokenIssuer
+	fingerprint *usecase.CheckFingerprint
+}
+
+var _ authv1connect.AuthServiceHandler = (*AuthService)(nil)
+
+// NewAuthService creates the service. token must be non-nil for the same
+// reason as in handler.NewValidateHandler. fingerprint may be nil to skip
+// fingerprint binding.
+func NewAuthService(validate *usecase.ValidateSession, session *usecase.GetSession, csrf *usecase.GenerateCSRF, token domain.TokenIssuer, fingerprint *usecase.CheckFingerprint) *AuthService {
+	if token == nil {
+		panic("rpc: NewAuthService requires a non-nil TokenIssuer")
+	}
+	return &AuthService{validate: validate, session: session, csrf: csrf, token: token, fingerprint: fingerprint}
+}
+
+// Validate checks a session and issues the backend JWT.
+func (s *AuthService) Validate(ctx context.Context, req *connect.Request[authv1.ValidateRequest]) (*connect.Response[authv1.ValidateResponse], error) {
+	sessionToken, err := requireSessionToken(req.Msg.GetSessionToken())
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.validate.Execute(ctx, sessionToken)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	if err := s.checkFingerprint(ctx, sessionToken, identity.UserID, req.Msg.GetClient()); err != nil {
+		return nil, connectError(err)
+	}
+
+	// Fail closed like /validate: a caller without the backend token has
+	// no proof of authentication to forward.
+	backendToken, err := s.token.IssueBackendToken(identity, identity.SessionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to issue backend token in validate rpc", "error", err)
+		return nil, connectError(fmt.Errorf("%w: %w", domain.ErrTokenGeneration, err))
+	}
+
+	role := identity.Role
+	if role == "" {
+		role = "user"
+	}
+	return connect.NewResponse(&authv1.ValidateResponse{
+		UserId:       identity.UserID,
+		TenantId:     identity.TenantID,
+		Email:        identity.Email,
+		Role:         role,
+		BackendToken: backendToken,
+	}), nil
+}
+
+// GetSession returns the session's user, the backend JWT and one service
+// token per requested audience.
+func (s *AuthService) GetSession(ctx context.Context, req *connect.Request[authv1.GetSessionRequest]) (*connect.Response[authv1.GetSessionResponse], error) {
+	sessionToken, err := requireSessionToken(req.Msg.GetSessionToken())
+	if err != nil {
+		return nil, err
+	}
+
+	var audiences []string
+	for _, aud := range req.Msg.GetAudiences() {
+		if aud = strings.TrimSpace(aud); aud != "" {
+			audiences = append(audiences, aud)
+		}
+	}
+
+	result, err := s.session.ExecuteFor(ctx, sessionToken, audiences)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	if err := s.checkFingerprint(ctx, sessionToken, result.UserID, req.Msg.GetClient()); err != nil {
+		return nil, connectError(err)
+	}
+
+	tokens := make([]*authv1.ServiceToken, 0, len(result.ServiceTokens))
+	for _, t := range result.ServiceTokens {
+		tokens = append(tokens, &authv1.ServiceToken{
+			Audience:  t.Audience,
+			Token:     t.Token,
+			Scopes:    t.Scopes,
+			ExpiresAt: timestamppb.New(t.ExpiresAt),
+		})
+	}
+	return connect.NewResponse(&authv1.GetSessionResponse{
+		User: &authv1.SessionUser{
+			Id:        result.UserID,
+			TenantId:  result.TenantID,
+			Email:     result.Email,
+			Role:      result.Role,
+			CreatedAt: timestamppb.New(result.CreatedAt),
+		},
+		BackendToken:  result.BackendToken,
+		ServiceTokens: tokens,
+	}), nil
+}
+
+// GenerateCSRFToken issues a CSRF token bound to the session.
+func (s *AuthService) GenerateCSRFToken(ctx context.Context, req *connect.Request[authv1.GenerateCSRFTokenRequest]) (*connect.Response[authv1.GenerateCSRFTokenResponse], error) {
+	sessionToken, err := requireSessionToken(req.Msg.GetSessionToken())
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.csrf.Execute(ctx, "ory_kratos_session="+sessionToken, sessionToken)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&authv1.GenerateCSRFTokenResponse{CsrfToken: token}), nil
+}
+
+// checkFingerprint runs the fingerprint check when the caller forwarded the
+// browser's client info. Without it there is no browser to bind to.
+func (s *AuthService) checkFingerprint(ctx context.Context, sessionToken, userID string, client *authv1.ClientInfo) error {
+	if s.fingerprint == nil || client == nil {
+		return nil
+	}
+	return s.fingerprint.Execute(ctx, sessionToken, userID, domain.ClientInfo{
+		UserAgent: client.GetUserAgent(),
+		IP:        client.GetIp(),
+		DeviceID:  client.GetDeviceId(),
+	})
+}
+
+func requireSessionToken(token string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", connect.NewError(connect.CodeInvalidArgument, errors.New("session_token is required"))
+	}
+	return token, nil
+}
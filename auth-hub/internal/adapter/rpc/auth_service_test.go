@@ -0,0 +1,147 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	authv1 "auth-hub/gen/proto/services/auth/v1"
+	"auth-hub/gen/proto/services/auth/v1/authv1connect"
+	"auth-hub/internal/domain"
+	infracache "auth-hub/internal/infrastructure/cache"
+	"auth-hub/internal/usecase"
+)
+
+const testSecret = "internal-secret"
+
+type fakeValidator struct {
+	identity *domain.Identity
+	err      error
+}
+
+func (f *fakeValidator) ValidateSession(_ context.Context, _ string) (*domain.Identity, error) {
+	return f.identity, f.err
+}
+
+type fakeTokenIssuer struct{}
+
+func (fakeTokenIssuer) IssueBackendToken(identity *domain.Identity, _ string) (string, error) {
+	return "jwt-for-" + identity.UserID, nil
+}
+
+type fakeCSRF struct{}
+
+func (fakeCSRF) Generate(sessionID string) (string, error) {
+	return "csrf-for-" + sessionID, nil
+}
+
+func newTestClient(t *testing.T, v *fakeValidator, limiter *rate.Limiter) authv1connect.AuthServiceClient {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cache := infracache.NewSessionCache(time.Minute)
+	svc := NewAuthService(
+		usecase.NewValidateSession(v, cache, logger),
+		usecase.NewGetSession(v, cache, fakeTokenIssuer{}, logger),
+		usecase.NewGenerateCSRF(v, fakeCSRF{}, logger),
+		fakeTokenIssuer{},
+		nil,
+	)
+	if limiter == nil {
+		limiter = rate.NewLimiter(rate.Inf, 0)
+	}
+	srv := httptest.NewServer(NewHandler(svc, testSecret, limiter))
+	t.Cleanup(srv.Close)
+	return authv1connect.NewAuthServiceClient(srv.Client(), srv.URL)
+}
+
+func authed[T any](msg *T) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	req.Header().Set(internalAuthHeader, testSecret)
+	return req
+}
+
+func TestAuthService_Validate(t *testing.T) {
+	client := newTestClient(t, &fakeValidator{identity: &domain.Identity{UserID: "user-1", Email: "a@example.com"}}, nil)
+
+	res, err := client.Validate(context.Background(), authed(&authv1.ValidateRequest{SessionToken: "cookie"}))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", res.Msg.GetUserId())
+	assert.Equal(t, "user-1", res.Msg.GetTenantId())
+	assert.Equal(t, "user", res.Msg.GetRole(), "empty role defaults like /validate")
+	assert.Equal(t, "jwt-for-user-1", res.Msg.GetBackendToken())
+}
+
+func TestAuthService_GetSessionAndCSRF(t *testing.T) {
+	client := newTestClient(t, &fakeValidator{identity: &domain.Identity{UserID: "user-1", Role: "admin"}}, nil)
+
+	session, err := client.GetSession(context.Background(), authed(&authv1.GetSessionRequest{SessionToken: "cookie"}))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", session.Msg.GetUser().GetId())
+	assert.Equal(t, "admin", session.Msg.GetUser().GetRole())
+	assert.Equal(t, "jwt-for-user-1", session.Msg.GetBackendToken())
+	assert.Empty(t, session.Msg.GetServiceTokens())
+
+	csrf, err := client.GenerateCSRFToken(context.Background(), authed(&authv1.GenerateCSRFTokenRequest{SessionToken: "cookie"}))
+	require.NoError(t, err)
+	assert.Equal(t, "csrf-for-cookie", csrf.Msg.GetCsrfToken())
+}
+
+func TestAuthService_Errors(t *testing.T) {
+	client := newTestClient(t, &fakeValidator{err: domain.ErrSessionNotFound}, nil)
+
+	_, err := client.Validate(context.Background(), authed(&authv1.ValidateRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "missing session token")
+
+	_, err = client.Validate(context.Background(), authed(&authv1.ValidateRequest{SessionToken: "cookie"}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func TestHandler_InternalAuth(t *testing.T) {
+	client := newTestClient(t, &fakeValidator{identity: &domain.Identity{UserID: "user-1"}}, nil)
+
+	_, err := client.Validate(context.Background(), connect.NewRequest(&authv1.ValidateRequest{SessionToken: "cookie"}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	req := connect.NewRequest(&authv1.ValidateRequest{SessionToken: "cookie"})
+	req.Header().Set(internalAuthHeader, "wrong")
+	_, err = client.Validate(context.Background(), req)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestHandler_RateLimit(t *testing.T) {
+	client := newTestClient(t, &fakeValidator{identity: &domain.Identity{UserID: "user-1"}}, rate.NewLimiter(rate.Every(time.Hour), 1))
+
+	_, err := client.Validate(context.Background(), authed(&authv1.ValidateRequest{SessionToken: "cookie"}))
+	require.NoError(t, err)
+	_, err = client.Validate(context.Background(), authed(&authv1.ValidateRequest{SessionToken: "cookie"}))
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+}
+
+func TestConnectError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want connect.Code
+	}{
+		{domain.ErrAuthFailed, connect.CodeUnauthenticated},
+		{domain.ErrStepUpRequired, connect.CodeUnauthenticated},
+		{domain.ErrKratosUnavailable, connect.CodeUnavailable},
+		{domain.ErrTokenGeneration, connect.CodeInternal},
+		{domain.ErrUnknownAudience, connect.CodeInvalidArgument},
+		{domain.ErrRateLimited, connect.CodeResourceExhausted},
+		{errors.New("something unexpected"), connect.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.want, connectError(tt.err).Code())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"errors"
+
+	"connectrpc.com/connect"
+
+	"auth-hub/internal/domain"
+)
+
+// connectError maps domain errors to Connect codes, mirroring the HTTP
+// statuses handler.mapDomainError picks for the REST endpoints. Messages
+// stay generic so internal details never reach the caller.
+func connectError(err error) *connect.Error {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound),
+		errors.Is(err, domain.ErrAuthFailed),
+		errors.Is(err, domain.ErrSessionExpired),
+		errors.Is(err, domain.ErrSessionInactive),
+		errors.Is(err, domain.ErrMissingIdentity):
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+
+	case errors.Is(err, domain.ErrStepUpRequired):
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("re-authentication required"))
+
+	case errors.Is(err, domain.ErrKratosUnavailable):
+		return connect.NewError(connect.CodeUnavailable, errors.New("identity provider unavailable"))
+
+	case errors.Is(err, domain.ErrAdminNotConfigured),
+		errors.Is(err, domain.ErrNoIdentitiesFound):
+		return connect.NewError(connect.CodeInternal, errors.New("internal configuration error"))
+
+	case errors.Is(err, domain.ErrTokenGeneration),
+		errors.Is(err, domain.ErrCSRFSecretMissing),
+		errors.Is(err, domain.ErrBackendSecretWeak):
+		return connect.NewError(connect.CodeInternal, errors.New("token generation error"))
+
+	case errors.Is(err, domain.ErrUnknownAudience),
+		errors.Is(err, domain.ErrInvalidScope):
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("unknown token audience"))
+
+	case errors.Is(err, domain.ErrRateLimited):
+		return connect.NewError(connect.CodeResourceExhausted, errors.New("rate limit exceeded"))
+
+	default:
+		return connect.NewError(connect.CodeInternal, errors.New("internal error"))
+	}
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"log/slog"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/otelconnect"
+	"golang.org/x/time/rate"
+
+	"auth-hub/gen/proto/services/auth/v1/authv1connect"
+)
+
+// NewHandler mounts svc for one tenant. Calls must present that tenant's
+// internalSecret; limiter is shared across tenants so the process-wide RPC
+// budget does not grow with the tenant count.
+func NewHandler(svc *AuthService, internalSecret string, limiter *rate.Limiter) http.Handler {
+	// otelconnect is the outermost layer so spans wrap auth and rate-limit
+	// rejections too. Failing to build it must not block startup.
+	interceptors := []connect.Interceptor{}
+	if otelInt, err := otelconnect.NewInterceptor(); err == nil {
+		interceptors = append(interceptors, otelInt)
+	} else {
+		slog.Warn("failed to create OTel Connect interceptor, proceeding without tracing", "error", err)
+	}
+	interceptors = append(interceptors,
+		newInternalAuthInterceptor(internalSecret),
+		newRateLimitInterceptor(limiter),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(authv1connect.NewAuthServiceHandler(svc, connect.WithInterceptors(interceptors...)))
+	return mux
+}
@@ -9,6 +9,10 @@ var (
 	ErrAuthFailed      = errors.New("authentication failed")
 	ErrSessionInactive = errors.New("session is not active")
 	ErrMissingIdentity = errors.New("missing identity in session")
+	// ErrStepUpRequired means the session is being used from a client whose
+	// fingerprint differs from the one it was bound to; the user must
+	// re-authenticate before it is accepted again.
+	ErrStepUpRequired = errors.New("re-authentication required")
 )
 
 // Token errors.
@@ -20,6 +24,15 @@ var (
 	ErrBackendSecretWeak = errors.New("backend token secret too weak")
 )
 
+// Token exchange errors. Each maps to an RFC 8693 / RFC 6749 error code.
+var (
+	ErrUnsupportedGrantType = errors.New("unsupported grant type")
+	ErrInvalidTokenRequest  = errors.New("invalid token request")
+	ErrInvalidSubjectToken  = errors.New("invalid subject token")
+	ErrUnknownAudience      = errors.New("unknown token audience")
+	ErrInvalidScope         = errors.New("requested scope not allowed for audience")
+)
+
 // External service errors.
 var (
 	ErrKratosUnavailable  = errors.New("identity provider unavailable")
@@ -27,6 +40,44 @@ var (
 	ErrNoIdentitiesFound  = errors.New("no identities found")
 )
 
+// Readiness probe errors.
+var (
+	ErrCacheUnavailable      = errors.New("session cache unavailable")
+	ErrSigningKeyUnavailable = errors.New("token signing key unavailable")
+)
+
+// Webhook errors.
+var (
+	ErrInvalidLifecycleEvent = errors.New("invalid identity lifecycle event")
+)
+
+// Login alert errors.
+var (
+	// ErrInvalidLoginAlertLink covers login alert links that are malformed,
+	// forged, expired or used for another action.
+	ErrInvalidLoginAlertLink = errors.New("invalid login alert link")
+	ErrNotificationFailed    = errors.New("notification delivery failed")
+)
+
+// Linked account errors.
+var (
+	ErrLinkedAccountNotFound = errors.New("linked account not found")
+	// ErrLastSignInMethod means removing the link would leave the identity
+	// with no way to sign in.
+	ErrLastSignInMethod = errors.New("cannot remove the last sign-in method")
+)
+
+// Service account errors.
+var (
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrServiceAccountExists   = errors.New("service account already exists")
+	ErrInvalidServiceAccount  = errors.New("invalid service account")
+	// ErrInvalidClient covers unknown accounts, wrong or expired secrets and
+	// disabled accounts alike, so a caller cannot probe which it was.
+	ErrInvalidClient     = errors.New("invalid client credentials")
+	ErrClientIPForbidden = errors.New("client address not allowed")
+)
+
 // Rate limiting errors.
 var (
 	ErrRateLimited = errors.New("rate limit exceeded")
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// ClientInfo describes the client presenting a session cookie. DeviceID is
+// an opaque identifier the frontend may send; when present it takes
+// precedence over UserAgent and IP.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+	DeviceID  string
+}
+
+// FingerprintMode controls how a session whose client fingerprint changed
+// is treated.
+type FingerprintMode string
+
+const (
+	// FingerprintOff disables binding entirely.
+	FingerprintOff FingerprintMode = "off"
+	// FingerprintReport binds sessions and logs mismatches but lets them through.
+	FingerprintReport FingerprintMode = "report"
+	// FingerprintEnforce rejects mismatches until the user re-authenticates.
+	FingerprintEnforce FingerprintMode = "enforce"
+)
+
+// FingerprintBinding is the fingerprint a session was bound to and when.
+type FingerprintBinding struct {
+	Fingerprint string
+	BoundAt     time.Time
+}
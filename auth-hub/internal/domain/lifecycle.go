@@ -0,0 +1,55 @@
+package domain
+
+import "fmt"
+
+// LifecycleEventType names a Kratos session/identity lifecycle change.
+type LifecycleEventType string
+
+const (
+	// LifecycleLogout ends one session. Without a session ID every session
+	// of the identity is treated as logged out.
+	LifecycleLogout LifecycleEventType = "session.logout"
+	// LifecycleIdentityUpdated changes traits (email, name, role). With an
+	// identity cache only the traits are reloaded; otherwise all of the
+	// identity's cached sessions are, since they carry the traits.
+	LifecycleIdentityUpdated LifecycleEventType = "identity.updated"
+	// LifecycleIdentityDeactivated disables the identity; Kratos rejects its
+	// sessions from now on.
+	LifecycleIdentityDeactivated LifecycleEventType = "identity.deactivated"
+	// LifecyclePasswordChanged follows registration and password changes;
+	// the new password is added to the identity's history, and cached
+	// sessions are reloaded as for identity.updated.
+	LifecyclePasswordChanged LifecycleEventType = "identity.password_changed"
+	// LifecycleLogin follows every completed login. It evicts nothing; it
+	// feeds the login alert check for new devices and locations.
+	LifecycleLogin LifecycleEventType = "session.login"
+)
+
+// LifecycleEvent is one webhook delivery from Kratos.
+type LifecycleEvent struct {
+	Type       LifecycleEventType
+	IdentityID string
+	SessionID  string
+}
+
+// Validate checks that the event names a known type and carries the IDs
+// that type needs.
+func (e LifecycleEvent) Validate() error {
+	switch e.Type {
+	case LifecycleLogout:
+		if e.SessionID == "" && e.IdentityID == "" {
+			return fmt.Errorf("%w: %s needs session_id or identity_id", ErrInvalidLifecycleEvent, e.Type)
+		}
+	case LifecycleLogin:
+		if e.IdentityID == "" || e.SessionID == "" {
+			return fmt.Errorf("%w: %s needs identity_id and session_id", ErrInvalidLifecycleEvent, e.Type)
+		}
+	case LifecycleIdentityUpdated, LifecycleIdentityDeactivated, LifecyclePasswordChanged:
+		if e.IdentityID == "" {
+			return fmt.Errorf("%w: %s needs identity_id", ErrInvalidLifecycleEvent, e.Type)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidLifecycleEvent, e.Type)
+	}
+	return nil
+}
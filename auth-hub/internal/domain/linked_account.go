@@ -0,0 +1,46 @@
+package domain
+
+// LinkedAccount is an upstream OIDC identity (Google, GitHub, ...) linked
+// to a local identity. Kratos owns the link; auth-hub only reads and
+// removes it.
+type LinkedAccount struct {
+	// Provider is the Kratos provider ID, e.g. "google".
+	Provider string
+	// Subject is the provider's stable user ID.
+	Subject string
+}
+
+// SignInMethods are the ways an identity can sign in.
+type SignInMethods struct {
+	Password bool
+	// Code is set when the identity can sign in with an emailed one-time
+	// code.
+	Code   bool
+	Linked []LinkedAccount
+}
+
+// Without returns the methods left after unlinking provider.
+func (m SignInMethods) Without(provider string) SignInMethods {
+	out := SignInMethods{Password: m.Password, Code: m.Code}
+	for _, a := range m.Linked {
+		if a.Provider != provider {
+			out.Linked = append(out.Linked, a)
+		}
+	}
+	return out
+}
+
+// Account returns the account linked for provider.
+func (m SignInMethods) Account(provider string) (LinkedAccount, bool) {
+	for _, a := range m.Linked {
+		if a.Provider == provider {
+			return a, true
+		}
+	}
+	return LinkedAccount{}, false
+}
+
+// Usable reports whether at least one method remains.
+func (m SignInMethods) Usable() bool {
+	return m.Password || m.Code || len(m.Linked) > 0
+}
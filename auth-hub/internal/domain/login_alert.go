@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// Login is one completed login as reported by the Kratos login webhook.
+type Login struct {
+	IdentityID string
+	// SessionID is the Kratos session UUID the login created.
+	SessionID string
+	Email     string
+	Client    ClientInfo
+	// Location is the coarse location Kratos derives for the session
+	// device (e.g. "Tokyo, JP"), empty when unknown.
+	Location        string
+	AuthenticatedAt time.Time
+}
+
+// LoginNovelty says what about a login had not been seen for the identity.
+type LoginNovelty struct {
+	// FirstLogin is set when nothing is known about the identity yet, as
+	// after a restart; such logins are learned without alerting.
+	FirstLogin  bool
+	NewDevice   bool
+	NewLocation bool
+}
+
+// Anomalous reports whether the login warrants an alert.
+func (n LoginNovelty) Anomalous() bool {
+	return !n.FirstLogin && (n.NewDevice || n.NewLocation)
+}
+
+// LoginAlert is the notification sent for an anomalous login.
+type LoginAlert struct {
+	IdentityID  string
+	Email       string
+	At          time.Time
+	IP          string
+	UserAgent   string
+	Location    string
+	NewDevice   bool
+	NewLocation bool
+	// RevokeURL ends the session in one click; OptOutURL turns login
+	// alerts off for the identity.
+	RevokeURL string
+	OptOutURL string
+}
+
+// LoginAlertAction is what a login alert link does when followed.
+type LoginAlertAction string
+
+const (
+	LoginAlertRevoke LoginAlertAction = "revoke"
+	LoginAlertOptOut LoginAlertAction = "opt_out"
+)
+
+// LoginAlertLink is the signed content of a login alert link.
+type LoginAlertLink struct {
+	Action     LoginAlertAction
+	IdentityID string
+	SessionID  string
+	ExpiresAt  time.Time
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxPasswordBytes is the longest password Kratos' bcrypt hasher can use in
+// full; bcrypt ignores everything past 72 bytes.
+const MaxPasswordBytes = 72
+
+// CharClass is a category of characters a password policy can require.
+type CharClass string
+
+const (
+	CharClassLower  CharClass = "lower"
+	CharClassUpper  CharClass = "upper"
+	CharClassDigit  CharClass = "digit"
+	CharClassSymbol CharClass = "symbol"
+)
+
+// ParseCharClass validates a configured character class name.
+func ParseCharClass(s string) (CharClass, error) {
+	switch c := CharClass(s); c {
+	case CharClassLower, CharClassUpper, CharClassDigit, CharClassSymbol:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown character class %q (want lower, upper, digit or symbol)", s)
+	}
+}
+
+// PasswordViolationCode identifies a failed password rule. Codes are stable
+// so the frontend can map them to localized messages.
+type PasswordViolationCode string
+
+const (
+	PasswordTooShort      PasswordViolationCode = "password_too_short"
+	PasswordTooLong       PasswordViolationCode = "password_too_long"
+	PasswordMissingLower  PasswordViolationCode = "password_missing_lowercase"
+	PasswordMissingUpper  PasswordViolationCode = "password_missing_uppercase"
+	PasswordMissingDigit  PasswordViolationCode = "password_missing_digit"
+	PasswordMissingSymbol PasswordViolationCode = "password_missing_symbol"
+	PasswordBreached      PasswordViolationCode = "password_breached"
+	PasswordRecentlyUsed  PasswordViolationCode = "password_recently_used"
+)
+
+// PasswordViolation is one rule a password failed, with an English message
+// for clients that do not localize the code.
+type PasswordViolation struct {
+	Code    PasswordViolationCode
+	Message string
+}
+
+// PasswordPolicy is the set of rules new passwords must satisfy at
+// registration and on password change.
+type PasswordPolicy struct {
+	// MinLength is counted in characters, not bytes.
+	MinLength       int
+	RequiredClasses []CharClass
+	// CheckBreached rejects passwords found in the breach corpus.
+	CheckBreached bool
+	// HistorySize is how many of the identity's most recent passwords,
+	// including the current one, may not be reused. 0 disables the check.
+	HistorySize int
+}
+
+// CheckRules evaluates the rules that need nothing but the password itself:
+// length and character classes.
+func (p PasswordPolicy) CheckRules(password string) []PasswordViolation {
+	var violations []PasswordViolation
+
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordTooShort,
+			Message: fmt.Sprintf("Password must be at least %d characters long.", p.MinLength),
+		})
+	}
+	if len(password) > MaxPasswordBytes {
+		violations = append(violations, PasswordViolation{
+			Code:    PasswordTooLong,
+			Message: fmt.Sprintf("Password must be at most %d bytes long.", MaxPasswordBytes),
+		})
+	}
+
+	present := charClasses(password)
+	for _, class := range p.RequiredClasses {
+		if !present[class] {
+			violations = append(violations, missingClassViolations[class])
+		}
+	}
+	return violations
+}
+
+// charClasses reports which classes occur in password. Anything that is
+// neither a letter nor a digit counts as a symbol.
+func charClasses(password string) map[CharClass]bool {
+	present := make(map[CharClass]bool, 4)
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			present[CharClassLower] = true
+		case unicode.IsUpper(r):
+			present[CharClassUpper] = true
+		case unicode.IsDigit(r):
+			present[CharClassDigit] = true
+		case !unicode.IsLetter(r):
+			present[CharClassSymbol] = true
+		}
+	}
+	return present
+}
+
+var missingClassViolations = map[CharClass]PasswordViolation{
+	CharClassLower:  {Code: PasswordMissingLower, Message: "Password must contain a lowercase letter."},
+	CharClassUpper:  {Code: PasswordMissingUpper, Message: "Password must contain an uppercase letter."},
+	CharClassDigit:  {Code: PasswordMissingDigit, Message: "Password must contain a digit."},
+	CharClassSymbol: {Code: PasswordMissingSymbol, Message: "Password must contain a symbol."},
+}
@@ -1,16 +1,66 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // SessionValidator validates a session cookie against the identity provider.
 type SessionValidator interface {
 	ValidateSession(ctx context.Context, cookie string) (*Identity, error)
 }
 
+// SessionLoader resolves a session from the identity provider on a cache miss.
+type SessionLoader func(ctx context.Context) (*CachedSession, error)
+
 // SessionCache provides read/write access to cached session data.
 type SessionCache interface {
 	Get(sessionID string) (*CachedSession, bool)
 	Set(sessionID string, session CachedSession)
+	// GetOrLoad returns the cached session or calls load, coalescing
+	// concurrent loads for the same session.
+	GetOrLoad(ctx context.Context, sessionID string, load SessionLoader) (*CachedSession, error)
+}
+
+// SessionInvalidator evicts cached sessions ahead of their TTL when Kratos
+// reports a lifecycle change. Both methods return the number of entries
+// removed.
+type SessionInvalidator interface {
+	InvalidateKratosSession(kratosSessionID string) int
+	InvalidateIdentity(identityID string) int
+}
+
+// IdentityTraitsLoader reads an identity's traits from the identity provider
+// on a cache miss.
+type IdentityTraitsLoader func(ctx context.Context) (*IdentityTraits, error)
+
+// SystemUserLoader reads the system user ID on a cache miss.
+type SystemUserLoader func(ctx context.Context) (string, error)
+
+// IdentityCache caches identity traits by identity ID and the system user
+// ID, with a longer TTL than sessions. Concurrent loads for the same key are
+// coalesced.
+type IdentityCache interface {
+	// SetTraits stores traits read by a load that started at loadedAt,
+	// unless the identity was invalidated after that.
+	SetTraits(identityID string, traits IdentityTraits, loadedAt time.Time)
+	GetOrLoadTraits(ctx context.Context, identityID string, load IdentityTraitsLoader) (*IdentityTraits, error)
+	GetOrLoadSystemUser(ctx context.Context, load SystemUserLoader) (string, error)
+	// InvalidateTraits drops the identity's traits, and the system user ID
+	// if it is that identity, returning the number of entries removed.
+	InvalidateTraits(identityID string) int
+}
+
+// IdentityTraitsProvider reads one identity's traits from the admin API.
+type IdentityTraitsProvider interface {
+	GetIdentityTraits(ctx context.Context, identityID string) (*IdentityTraits, error)
+}
+
+// FingerprintStore remembers which client fingerprint each session is bound
+// to. Sessions are keyed like SessionCache, by cookie value.
+type FingerprintStore interface {
+	Get(sessionID string) (FingerprintBinding, bool)
+	Bind(sessionID string, binding FingerprintBinding)
 }
 
 // TokenIssuer generates signed backend JWT tokens.
@@ -18,6 +68,13 @@ type TokenIssuer interface {
 	IssueBackendToken(identity *Identity, sessionID string) (string, error)
 }
 
+// ServiceTokenIssuer issues audience-scoped tokens for downstream services
+// and verifies the ones it issued when they are presented for exchange.
+type ServiceTokenIssuer interface {
+	IssueServiceToken(identity *Identity, sessionID string, req ServiceTokenRequest) (*ServiceToken, error)
+	VerifyToken(token string) (*VerifiedToken, error)
+}
+
 // CSRFTokenGenerator generates CSRF tokens from session identifiers.
 type CSRFTokenGenerator interface {
 	Generate(sessionID string) (string, error)
@@ -27,3 +84,83 @@ type CSRFTokenGenerator interface {
 type IdentityProvider interface {
 	GetFirstIdentityID(ctx context.Context) (string, error)
 }
+
+// BreachChecker looks passwords up in a corpus of breached credentials.
+type BreachChecker interface {
+	// BreachCount returns how often password appears in the corpus; 0 means
+	// it was not found.
+	BreachCount(ctx context.Context, password string) (int, error)
+}
+
+// PasswordHistory remembers an identity's recent password hashes so they
+// cannot be reused.
+type PasswordHistory interface {
+	// UsedRecently reports whether password matches the identity's current
+	// password or one of the previous ones, looking at most n passwords back.
+	UsedRecently(ctx context.Context, identityID, password string, n int) (bool, error)
+	// RecordCurrent adds the identity's current password hash to its
+	// history, keeping the n most recent.
+	RecordCurrent(ctx context.Context, identityID string, n int) error
+}
+
+// NotificationGateway delivers notifications to users over one channel.
+// Email is the first; other channels implement the same interface.
+type NotificationGateway interface {
+	SendLoginAlert(ctx context.Context, alert LoginAlert) error
+}
+
+// KnownDeviceStore remembers the client fingerprints and locations each
+// identity has logged in from.
+type KnownDeviceStore interface {
+	// Observe records a login and reports what about it was not seen before.
+	Observe(identityID, fingerprint, location string) LoginNovelty
+}
+
+// LoginAlertPreferences stores whether an identity wants login alerts.
+type LoginAlertPreferences interface {
+	LoginAlertsEnabled(ctx context.Context, identityID string) (bool, error)
+	DisableLoginAlerts(ctx context.Context, identityID string) error
+}
+
+// SessionRevoker ends a Kratos session by its session ID.
+type SessionRevoker interface {
+	RevokeSession(ctx context.Context, kratosSessionID string) error
+}
+
+// LoginAlertLinkSigner signs and verifies the action links in login alerts.
+type LoginAlertLinkSigner interface {
+	Sign(link LoginAlertLink) (string, error)
+	Verify(token string) (*LoginAlertLink, error)
+}
+
+// CSRFTokenValidator checks a token issued by CSRFTokenGenerator for the
+// same session.
+type CSRFTokenValidator interface {
+	Validate(sessionID, token string) error
+}
+
+// LinkedAccountStore reads and removes an identity's links to upstream OIDC
+// providers through the admin API. Links are created by Kratos itself, in
+// the registration, login and settings flows.
+type LinkedAccountStore interface {
+	SignInMethods(ctx context.Context, identityID string) (*SignInMethods, error)
+	UnlinkAccount(ctx context.Context, identityID string, account LinkedAccount) error
+}
+
+// ServiceAccountStore persists service accounts.
+type ServiceAccountStore interface {
+	List(ctx context.Context) ([]ServiceAccount, error)
+	// Get returns ErrServiceAccountNotFound for unknown IDs.
+	Get(ctx context.Context, id string) (*ServiceAccount, error)
+	// Create returns ErrServiceAccountExists when the ID or name is taken.
+	Create(ctx context.Context, account ServiceAccount) error
+	// Update applies fn to the stored account and saves the result, unless
+	// fn returns an error. Updates of one account are serialized.
+	Update(ctx context.Context, id string, fn func(*ServiceAccount) error) (*ServiceAccount, error)
+}
+
+// HealthProbe checks that one dependency auth-hub needs to validate
+// sessions is usable right now.
+type HealthProbe interface {
+	Probe(ctx context.Context) error
+}
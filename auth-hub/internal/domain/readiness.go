@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Dependencies checked by the readiness endpoint. An instance is only ready
+// when it can reach Kratos, use its session cache and sign tokens.
+const (
+	DependencyKratos       = "kratos"
+	DependencySessionCache = "session_cache"
+	DependencySigningKey   = "jwt_signing_key"
+)
+
+// DependencyStatus is the readiness verdict for one dependency.
+type DependencyStatus struct {
+	Name string
+	// Ready is false until the dependency has passed a probe once, and
+	// again once ConsecutiveFailures reaches FailureThreshold.
+	Ready bool
+	// LastProbeOK reports the most recent probe; a dependency can be Ready
+	// with a failed last probe while it is still under the threshold.
+	LastProbeOK         bool
+	ConsecutiveFailures int
+	FailureThreshold    int
+	LastError           string
+	LastSuccess         time.Time
+	Latency             time.Duration
+}
+
+// ReadinessReport is the outcome of one readiness check.
+type ReadinessReport struct {
+	Ready        bool
+	CheckedAt    time.Time
+	Dependencies []DependencyStatus
+}
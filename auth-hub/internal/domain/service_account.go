@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"net/netip"
+	"time"
+)
+
+// GrantTypeClientCredentials is the RFC 6749 grant service accounts use to
+// obtain tokens.
+const GrantTypeClientCredentials = "client_credentials"
+
+// ServiceRole is the role claim of tokens issued to service accounts, so
+// downstream services can tell them from user tokens.
+const ServiceRole = "service"
+
+// ServiceAccount is a non-human client, such as pre-processor or
+// search-indexer, that obtains short-lived tokens for a fixed set of
+// audiences and scopes with its own client secret instead of a shared one.
+type ServiceAccount struct {
+	// ID is the client_id and the subject of the tokens issued to it.
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Grants lists the audiences the account can obtain tokens for and the
+	// scopes it holds for each.
+	Grants []ServiceGrant `json:"grants"`
+	// TokenTTL caps the lifetime of the account's tokens below the
+	// audience TTL.
+	TokenTTL time.Duration `json:"token_ttl"`
+	// IPAllowlist limits where tokens can be requested from; empty allows
+	// any address.
+	IPAllowlist []netip.Prefix `json:"ip_allowlist"`
+	// Credentials holds the current secret first and, during a rotation,
+	// the previous one until it expires.
+	Credentials []ServiceCredential `json:"credentials"`
+	Usage       ServiceAccountUsage `json:"usage"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	// DisabledAt is set once the account may no longer obtain tokens.
+	// Tokens issued before that stay valid until they expire.
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+// ServiceGrant lets a service account obtain tokens for one audience with
+// at most Scopes, which must be scopes the audience is configured with.
+type ServiceGrant struct {
+	Audience string   `json:"audience"`
+	Scopes   []string `json:"scopes"`
+}
+
+// ServiceCredential is one client secret of a service account. Only its
+// SHA-256 hash is kept; the secret itself is shown once, when created.
+type ServiceCredential struct {
+	ID         string     `json:"id"`
+	SecretHash string     `json:"secret_hash"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Active reports whether the credential can still be used at now.
+func (c ServiceCredential) Active(now time.Time) bool {
+	return c.ExpiresAt == nil || now.Before(*c.ExpiresAt)
+}
+
+// ServiceAccountUsage records when and from where an account last obtained
+// a token, so unused accounts and stale secrets can be found and removed.
+type ServiceAccountUsage struct {
+	TokensIssued int64      `json:"tokens_issued"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP   string     `json:"last_used_ip,omitempty"`
+	LastAudience string     `json:"last_audience,omitempty"`
+}
+
+// Disabled reports whether the account was disabled.
+func (a *ServiceAccount) Disabled() bool {
+	return a.DisabledAt != nil
+}
+
+// Grant returns the account's grant for audience.
+func (a *ServiceAccount) Grant(audience string) (ServiceGrant, bool) {
+	for _, g := range a.Grants {
+		if g.Audience == audience {
+			return g, true
+		}
+	}
+	return ServiceGrant{}, false
+}
+
+// AllowsIP reports whether ip may request tokens for the account.
+func (a *ServiceAccount) AllowsIP(ip netip.Addr) bool {
+	if len(a.IPAllowlist) == 0 {
+		return true
+	}
+	ip = ip.Unmap()
+	for _, p := range a.IPAllowlist {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// RFC 8693 token type identifiers accepted and issued by the token exchange.
+const (
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	GrantTypeExchange    = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// ServiceTokenRequest describes an audience-scoped token to mint.
+type ServiceTokenRequest struct {
+	// Audience is the downstream service the token is for. It must be one
+	// of the configured audiences.
+	Audience string
+	// Scopes narrows the audience's configured scopes; nil grants all of them.
+	Scopes []string
+	// Actors is the delegation chain recorded in the act claim, most recent
+	// actor first. Empty for tokens issued straight from a user session.
+	Actors []string
+	// NotAfter caps the token's expiry below the audience TTL when set, so
+	// a delegated token never outlives the token it was exchanged from.
+	NotAfter time.Time
+}
+
+// ServiceToken is a signed token scoped to one downstream service.
+type ServiceToken struct {
+	Audience  string
+	Token     string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// VerifiedToken is the content of a token auth-hub issued earlier and has
+// just checked the signature, issuer, expiry and audience of.
+type VerifiedToken struct {
+	Identity  Identity
+	SessionID string
+	// Audience is the service the token was issued to, i.e. the service
+	// presenting it for exchange.
+	Audience  string
+	Scopes    []string
+	Actors    []string
+	ExpiresAt time.Time
+}
@@ -11,16 +11,34 @@ type Identity struct {
 	UserID    string
 	TenantID  string
 	Email     string
+	Name      string
 	Role      string
 	SessionID string
 	CreatedAt time.Time
+	// AuthenticatedAt is when the user last completed a login for this
+	// session; a step-up re-authentication moves it forward.
+	AuthenticatedAt time.Time
 }
 
 // CachedSession holds session data stored in the cache.
+// KratosSessionID is the Kratos session UUID (not the cookie token the cache
+// is keyed by); lifecycle webhooks identify sessions by it.
 type CachedSession struct {
-	UserID    string
-	TenantID  string
-	Email     string
-	Role      string
-	CreatedAt time.Time
+	UserID          string
+	TenantID        string
+	Email           string
+	Name            string
+	Role            string
+	KratosSessionID string
+	CreatedAt       time.Time
+}
+
+// IdentityTraits are the identity attributes auth-hub passes on with every
+// session. They change far less often than sessions are validated, so they
+// are cached per identity, separately from session validity.
+type IdentityTraits struct {
+	Email string
+	// Name is the display name built from the first and last name traits.
+	Name string
+	Role string
 }
@@ -0,0 +1,66 @@
+package breach
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+
+func TestPwnedPasswords_BreachCount_Found(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/range/5BAA6", r.URL.Path)
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+		fmt.Fprintf(w, "%s:9659365\r\n", passwordSuffix)
+		fmt.Fprint(w, "01330C689E5D64F660D6947A93AD634EF8F:0\r\n")
+	}))
+	defer server.Close()
+
+	n, err := NewPwnedPasswords(server.URL, time.Second).BreachCount(context.Background(), "password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9659365, n)
+}
+
+func TestPwnedPasswords_BreachCount_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+	}))
+	defer server.Close()
+
+	n, err := NewPwnedPasswords(server.URL, time.Second).BreachCount(context.Background(), "password")
+
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestPwnedPasswords_BreachCount_PaddedEntryIsNotABreach(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s:0\r\n", passwordSuffix)
+	}))
+	defer server.Close()
+
+	n, err := NewPwnedPasswords(server.URL, time.Second).BreachCount(context.Background(), "password")
+
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestPwnedPasswords_BreachCount_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewPwnedPasswords(server.URL, time.Second).BreachCount(context.Background(), "password")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// FingerprintStore keeps session fingerprint bindings in memory for ttl
+// after they were last written. It outlives SessionCache entries on
+// purpose: a binding must survive cache refreshes to mean anything.
+// Implements domain.FingerprintStore.
+type FingerprintStore struct {
+	mu       sync.RWMutex
+	bindings map[string]domain.FingerprintBinding
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewFingerprintStore creates a store whose bindings expire after ttl.
+func NewFingerprintStore(ttl time.Duration) *FingerprintStore {
+	s := &FingerprintStore{
+		bindings: make(map[string]domain.FingerprintBinding),
+		ttl:      ttl,
+		now:      time.Now,
+	}
+	go s.cleanupLoop()
+	return s
+}
+
+// Get returns the live binding for sessionID.
+func (s *FingerprintStore) Get(sessionID string) (domain.FingerprintBinding, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b, ok := s.bindings[sessionID]
+	if !ok || s.now().Sub(b.BoundAt) > s.ttl {
+		return domain.FingerprintBinding{}, false
+	}
+	return b, true
+}
+
+// Bind stores or replaces the binding for sessionID.
+func (s *FingerprintStore) Bind(sessionID string, binding domain.FingerprintBinding) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bindings[sessionID] = binding
+}
+
+func (s *FingerprintStore) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, b := range s.bindings {
+		if now.Sub(b.BoundAt) > s.ttl {
+			delete(s.bindings, id)
+		}
+	}
+}
+
+func (s *FingerprintStore) cleanupLoop() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.cleanup()
+	}
+}
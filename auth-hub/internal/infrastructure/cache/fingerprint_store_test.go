@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprintStore_BindGetAndExpire(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewFingerprintStore(time.Hour)
+	s.now = clock.Now
+
+	_, ok := s.Get("session-abc")
+	assert.False(t, ok)
+
+	s.Bind("session-abc", domain.FingerprintBinding{Fingerprint: "fp-1", BoundAt: clock.Now()})
+	b, ok := s.Get("session-abc")
+	assert.True(t, ok)
+	assert.Equal(t, "fp-1", b.Fingerprint)
+
+	clock.Advance(30 * time.Minute)
+	s.Bind("session-abc", domain.FingerprintBinding{Fingerprint: "fp-2", BoundAt: clock.Now()})
+	clock.Advance(45 * time.Minute)
+	b, ok = s.Get("session-abc")
+	assert.True(t, ok, "rebinding restarts the TTL")
+	assert.Equal(t, "fp-2", b.Fingerprint)
+
+	clock.Advance(time.Hour)
+	_, ok = s.Get("session-abc")
+	assert.False(t, ok)
+
+	s.cleanup()
+	s.mu.RLock()
+	assert.Empty(t, s.bindings)
+	s.mu.RUnlock()
+}
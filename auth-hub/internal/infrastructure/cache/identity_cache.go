@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// systemUserKey is the singleflight key of the system user lookup; trait
+// lookups use "traits:<identity ID>".
+const systemUserKey = "system-user"
+
+type traitsEntry struct {
+	traits    domain.IdentityTraits
+	expiresAt time.Time
+}
+
+// IdentityCache provides thread-safe in-memory caching of identity traits
+// and the system user ID. Entries live longer than sessions: identity
+// webhooks invalidate them, so the TTL only bounds how long a missed
+// webhook can leave traits stale.
+// Implements domain.IdentityCache.
+type IdentityCache struct {
+	mu     sync.RWMutex
+	traits map[string]*traitsEntry
+	// invalidated records when each identity was last invalidated, so a
+	// load that started earlier does not write old traits back.
+	invalidated     map[string]time.Time
+	systemUser      string
+	systemUserUntil time.Time
+	ttl             time.Duration
+	group           singleflight.Group
+	now             func() time.Time
+}
+
+// NewIdentityCache creates an identity cache with the specified TTL.
+func NewIdentityCache(ttl time.Duration) *IdentityCache {
+	c := &IdentityCache{
+		traits:      make(map[string]*traitsEntry),
+		invalidated: make(map[string]time.Time),
+		ttl:         ttl,
+		now:         time.Now,
+	}
+	go c.cleanupLoop()
+	return c
+}
+
+// SetTraits stores traits read by a load that started at loadedAt, unless
+// the identity was invalidated after that.
+func (c *IdentityCache) SetTraits(identityID string, traits domain.IdentityTraits, loadedAt time.Time) {
+	if identityID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if at, ok := c.invalidated[identityID]; ok && !loadedAt.After(at) {
+		return
+	}
+	c.traits[identityID] = &traitsEntry{traits: traits, expiresAt: c.now().Add(c.ttl)}
+}
+
+// GetOrLoadTraits returns the cached traits of identityID, calling load on a
+// miss. Concurrent callers for the same identity share a single load.
+func (c *IdentityCache) GetOrLoadTraits(ctx context.Context, identityID string, load domain.IdentityTraitsLoader) (*domain.IdentityTraits, error) {
+	c.mu.RLock()
+	entry, found := c.traits[identityID]
+	c.mu.RUnlock()
+	if found && c.now().Before(entry.expiresAt) {
+		traits := entry.traits
+		return &traits, nil
+	}
+
+	loadFn := func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		startedAt := c.now()
+		traits, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.SetTraits(identityID, *traits, startedAt)
+		return *traits, nil
+	}
+
+	select {
+	case res := <-c.group.DoChan("traits:"+identityID, loadFn):
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		traits := res.Val.(domain.IdentityTraits)
+		return &traits, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GetOrLoadSystemUser returns the cached system user ID, calling load on a
+// miss.
+func (c *IdentityCache) GetOrLoadSystemUser(ctx context.Context, load domain.SystemUserLoader) (string, error) {
+	c.mu.RLock()
+	userID, until := c.systemUser, c.systemUserUntil
+	c.mu.RUnlock()
+	if userID != "" && c.now().Before(until) {
+		return userID, nil
+	}
+
+	loadFn := func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		startedAt := c.now()
+		userID, err := load(loadCtx)
+		if err != nil {
+			return "", err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if at, ok := c.invalidated[userID]; !ok || startedAt.After(at) {
+			c.systemUser = userID
+			c.systemUserUntil = c.now().Add(c.ttl)
+		}
+		return userID, nil
+	}
+
+	select {
+	case res := <-c.group.DoChan(systemUserKey, loadFn):
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// InvalidateTraits drops the traits of identityID, and the system user ID if
+// it is that identity, returning how many entries were removed.
+func (c *IdentityCache) InvalidateTraits(identityID string) int {
+	if identityID == "" {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidated[identityID] = c.now()
+	removed := 0
+	if _, ok := c.traits[identityID]; ok {
+		delete(c.traits, identityID)
+		removed++
+	}
+	if c.systemUser == identityID {
+		c.systemUser = ""
+		removed++
+	}
+	return removed
+}
+
+// cleanup removes expired entries and invalidation records older than any
+// load that could still be in flight.
+func (c *IdentityCache) cleanup() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for id, entry := range c.traits {
+		if !now.Before(entry.expiresAt) {
+			delete(c.traits, id)
+		}
+	}
+	for id, at := range c.invalidated {
+		if now.Sub(at) > refreshTimeout {
+			delete(c.invalidated, id)
+		}
+	}
+}
+
+// cleanupLoop runs periodic cleanup of expired entries.
+func (c *IdentityCache) cleanupLoop() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.cleanup()
+	}
+}
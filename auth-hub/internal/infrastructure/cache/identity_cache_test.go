@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIdentityCache(ttl time.Duration) (*IdentityCache, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := NewIdentityCache(ttl)
+	c.now = clock.Now
+	return c, clock
+}
+
+func traitsLoader(calls *atomic.Int32, traits domain.IdentityTraits) domain.IdentityTraitsLoader {
+	return func(context.Context) (*domain.IdentityTraits, error) {
+		calls.Add(1)
+		return &traits, nil
+	}
+}
+
+func TestIdentityCache_SeededTraitsSkipLoad(t *testing.T) {
+	c, clock := newTestIdentityCache(30 * time.Minute)
+	c.SetTraits("id-1", domain.IdentityTraits{Email: "a@example.com", Role: "admin"}, clock.Now())
+
+	var calls atomic.Int32
+	got, err := c.GetOrLoadTraits(context.Background(), "id-1", traitsLoader(&calls, domain.IdentityTraits{Email: "other@example.com"}))
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com", got.Email)
+	assert.Equal(t, "admin", got.Role)
+	assert.Zero(t, calls.Load())
+
+	// Past the TTL the traits are loaded again.
+	clock.Advance(31 * time.Minute)
+	got, err = c.GetOrLoadTraits(context.Background(), "id-1", traitsLoader(&calls, domain.IdentityTraits{Email: "other@example.com"}))
+	require.NoError(t, err)
+	assert.Equal(t, "other@example.com", got.Email)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdentityCache_InvalidateTraitsForcesReload(t *testing.T) {
+	c, clock := newTestIdentityCache(30 * time.Minute)
+	c.SetTraits("id-1", domain.IdentityTraits{Role: "admin"}, clock.Now())
+	c.SetTraits("id-2", domain.IdentityTraits{Role: "user"}, clock.Now())
+
+	assert.Equal(t, 1, c.InvalidateTraits("id-1"))
+	assert.Equal(t, 0, c.InvalidateTraits(""))
+
+	var calls atomic.Int32
+	got, err := c.GetOrLoadTraits(context.Background(), "id-1", traitsLoader(&calls, domain.IdentityTraits{Role: "user"}))
+	require.NoError(t, err)
+	assert.Equal(t, "user", got.Role)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Other identities keep their traits.
+	_, err = c.GetOrLoadTraits(context.Background(), "id-2", traitsLoader(&calls, domain.IdentityTraits{}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdentityCache_SetTraitsIgnoresLoadsOlderThanInvalidation(t *testing.T) {
+	c, clock := newTestIdentityCache(30 * time.Minute)
+
+	loadStarted := clock.Now()
+	clock.Advance(time.Second)
+	c.InvalidateTraits("id-1")
+	clock.Advance(time.Second)
+	// The session load read the traits before the identity was updated.
+	c.SetTraits("id-1", domain.IdentityTraits{Role: "admin"}, loadStarted)
+
+	var calls atomic.Int32
+	got, err := c.GetOrLoadTraits(context.Background(), "id-1", traitsLoader(&calls, domain.IdentityTraits{Role: "user"}))
+	require.NoError(t, err)
+	assert.Equal(t, "user", got.Role)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdentityCache_GetOrLoadTraits_CoalescesConcurrentMisses(t *testing.T) {
+	c, _ := newTestIdentityCache(30 * time.Minute)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (*domain.IdentityTraits, error) {
+		calls.Add(1)
+		<-release
+		return &domain.IdentityTraits{Email: "a@example.com"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := c.GetOrLoadTraits(context.Background(), "id-1", load)
+			assert.NoError(t, err)
+			assert.Equal(t, "a@example.com", got.Email)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdentityCache_SystemUser(t *testing.T) {
+	c, clock := newTestIdentityCache(30 * time.Minute)
+
+	var calls atomic.Int32
+	load := func(context.Context) (string, error) {
+		calls.Add(1)
+		return "system-1", nil
+	}
+
+	for range 3 {
+		userID, err := c.GetOrLoadSystemUser(context.Background(), load)
+		require.NoError(t, err)
+		assert.Equal(t, "system-1", userID)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Invalidating the system user's identity drops the cached ID.
+	assert.Equal(t, 1, c.InvalidateTraits("system-1"))
+	clock.Advance(time.Second)
+	_, err := c.GetOrLoadSystemUser(context.Background(), load)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdentityCache_SystemUserErrorNotCached(t *testing.T) {
+	c, _ := newTestIdentityCache(30 * time.Minute)
+
+	_, err := c.GetOrLoadSystemUser(context.Background(), func(context.Context) (string, error) {
+		return "", domain.ErrKratosUnavailable
+	})
+	assert.True(t, errors.Is(err, domain.ErrKratosUnavailable))
+
+	userID, err := c.GetOrLoadSystemUser(context.Background(), func(context.Context) (string, error) {
+		return "system-1", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "system-1", userID)
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// maxKnownPerIdentity bounds how many fingerprints and how many locations
+// are remembered per identity; the least recently seen is forgotten first.
+const maxKnownPerIdentity = 32
+
+// KnownDeviceStore remembers, in memory, the fingerprints and locations each
+// identity logged in from during the last ttl. After a restart every
+// identity starts unknown again, so its next login is learned rather than
+// alerted on. Implements domain.KnownDeviceStore.
+type KnownDeviceStore struct {
+	mu         sync.Mutex
+	identities map[string]*knownDevices
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+type knownDevices struct {
+	fingerprints map[string]time.Time
+	locations    map[string]time.Time
+}
+
+// NewKnownDeviceStore creates a store that forgets devices and locations
+// not seen for ttl.
+func NewKnownDeviceStore(ttl time.Duration) *KnownDeviceStore {
+	s := &KnownDeviceStore{
+		identities: make(map[string]*knownDevices),
+		ttl:        ttl,
+		now:        time.Now,
+	}
+	go s.cleanupLoop()
+	return s
+}
+
+// Observe records the login and reports what was new about it. An empty
+// location is never new.
+func (s *KnownDeviceStore) Observe(identityID, fingerprint, location string) domain.LoginNovelty {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	known, ok := s.identities[identityID]
+	if ok {
+		known.expire(now, s.ttl)
+	}
+	if !ok || len(known.fingerprints) == 0 {
+		known = &knownDevices{fingerprints: map[string]time.Time{}, locations: map[string]time.Time{}}
+		s.identities[identityID] = known
+		remember(known.fingerprints, fingerprint, now)
+		if location != "" {
+			remember(known.locations, location, now)
+		}
+		return domain.LoginNovelty{FirstLogin: true}
+	}
+
+	var novelty domain.LoginNovelty
+	if _, seen := known.fingerprints[fingerprint]; !seen {
+		novelty.NewDevice = true
+	}
+	remember(known.fingerprints, fingerprint, now)
+	if location != "" {
+		if _, seen := known.locations[location]; !seen {
+			novelty.NewLocation = true
+		}
+		remember(known.locations, location, now)
+	}
+	return novelty
+}
+
+func (k *knownDevices) expire(now time.Time, ttl time.Duration) {
+	for _, m := range []map[string]time.Time{k.fingerprints, k.locations} {
+		for key, seen := range m {
+			if now.Sub(seen) > ttl {
+				delete(m, key)
+			}
+		}
+	}
+}
+
+// remember marks key as seen at now, evicting the least recently seen entry
+// when m is full.
+func remember(m map[string]time.Time, key string, now time.Time) {
+	if _, ok := m[key]; !ok && len(m) >= maxKnownPerIdentity {
+		var oldest string
+		for k, seen := range m {
+			if oldest == "" || seen.Before(m[oldest]) {
+				oldest = k
+			}
+		}
+		delete(m, oldest)
+	}
+	m[key] = now
+}
+
+func (s *KnownDeviceStore) cleanup() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, known := range s.identities {
+		known.expire(now, s.ttl)
+		if len(known.fingerprints) == 0 {
+			delete(s.identities, id)
+		}
+	}
+}
+
+func (s *KnownDeviceStore) cleanupLoop() {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.cleanup()
+	}
+}
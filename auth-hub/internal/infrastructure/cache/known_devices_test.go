@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnownDeviceStore_Observe(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewKnownDeviceStore(30 * 24 * time.Hour)
+	s.now = clock.Now
+
+	assert.Equal(t, domain.LoginNovelty{FirstLogin: true}, s.Observe("user-1", "fp-laptop", "Tokyo, JP"))
+	assert.Equal(t, domain.LoginNovelty{}, s.Observe("user-1", "fp-laptop", "Tokyo, JP"))
+	assert.Equal(t, domain.LoginNovelty{}, s.Observe("user-1", "fp-laptop", ""), "unknown location is not new")
+	assert.Equal(t, domain.LoginNovelty{NewDevice: true}, s.Observe("user-1", "fp-phone", "Tokyo, JP"))
+	assert.Equal(t, domain.LoginNovelty{NewLocation: true}, s.Observe("user-1", "fp-phone", "Osaka, JP"))
+	assert.Equal(t, domain.LoginNovelty{FirstLogin: true}, s.Observe("user-2", "fp-laptop", "Tokyo, JP"), "identities are tracked separately")
+
+	clock.Advance(31 * 24 * time.Hour)
+	assert.Equal(t, domain.LoginNovelty{FirstLogin: true}, s.Observe("user-1", "fp-phone", "Osaka, JP"), "forgotten after ttl")
+
+	s.cleanup()
+	s.mu.Lock()
+	assert.Len(t, s.identities, 1)
+	s.mu.Unlock()
+}
+
+func TestKnownDeviceStore_EvictsLeastRecentlySeen(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewKnownDeviceStore(365 * 24 * time.Hour)
+	s.now = clock.Now
+
+	for i := range maxKnownPerIdentity {
+		s.Observe("user-1", fmt.Sprintf("fp-%d", i), "")
+		clock.Advance(time.Minute)
+	}
+	s.Observe("user-1", "fp-0", "") // refresh the oldest
+	s.Observe("user-1", "fp-new", "")
+
+	assert.False(t, s.Observe("user-1", "fp-0", "").NewDevice)
+	assert.True(t, s.Observe("user-1", "fp-1", "").NewDevice, "least recently seen device is evicted")
+}
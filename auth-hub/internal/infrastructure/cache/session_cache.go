@@ -1,46 +1,93 @@
 package cache
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
 	"auth-hub/internal/domain"
+
+	"golang.org/x/sync/singleflight"
 )
 
+// refreshTimeout bounds a coalesced Kratos lookup. It is detached from the
+// triggering request's context so one caller disconnecting cannot fail every
+// request waiting on the same session.
+const refreshTimeout = 10 * time.Second
+
+// cleanupInterval is how often expired entries are swept. Probe reports the
+// cache unhealthy once a sweep is overdue by several intervals.
+const cleanupInterval = 1 * time.Minute
+
 // cacheEntry represents a cached session with user identity information.
+// The entry is fresh until expiresAt and may be served stale (while a
+// background refresh runs) until staleUntil.
 type cacheEntry struct {
-	session   domain.CachedSession
-	expiresAt time.Time
+	session    domain.CachedSession
+	expiresAt  time.Time
+	staleUntil time.Time
+}
+
+// tombstone records a webhook invalidation so a Kratos load that was already
+// in flight when it arrived does not write the revoked session back.
+type tombstone struct {
+	epoch uint64
+	at    time.Time
 }
 
 // SessionCache provides thread-safe in-memory session caching with TTL.
-// Implements domain.SessionCache.
+// Concurrent misses for the same session are coalesced into one load, and
+// recently expired entries are served stale while a single background
+// refresh revalidates them.
+// Implements domain.SessionCache, domain.SessionInvalidator and
+// domain.HealthProbe.
 type SessionCache struct {
-	mu      sync.RWMutex
-	entries map[string]*cacheEntry
-	ttl     time.Duration
+	mu          sync.RWMutex
+	entries     map[string]*cacheEntry
+	tombstones  map[string]tombstone
+	epoch       uint64
+	ttl         time.Duration
+	staleTTL    time.Duration
+	group       singleflight.Group
+	now         func() time.Time
+	lastCleanup time.Time
 }
 
-// NewSessionCache creates a new session cache with the specified TTL.
+// NewSessionCache creates a new session cache with the specified TTL and no
+// stale-while-revalidate window.
 func NewSessionCache(ttl time.Duration) *SessionCache {
+	return NewSessionCacheWithStaleTTL(ttl, 0)
+}
+
+// NewSessionCacheWithStaleTTL creates a session cache that serves entries up
+// to staleTTL past expiry while refreshing them in the background.
+func NewSessionCacheWithStaleTTL(ttl, staleTTL time.Duration) *SessionCache {
 	c := &SessionCache{
-		entries: make(map[string]*cacheEntry),
-		ttl:     ttl,
+		entries:    make(map[string]*cacheEntry),
+		tombstones: make(map[string]tombstone),
+		ttl:        ttl,
+		staleTTL:   staleTTL,
+		now:        time.Now,
 	}
+	c.lastCleanup = c.now()
 	go c.cleanupLoop()
 	return c
 }
 
-// Get retrieves a cached session by session ID.
+// Get retrieves a fresh cached session by session ID.
 func (c *SessionCache) Get(sessionID string) (*domain.CachedSession, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	entry, found := c.entries[sessionID]
-	if !found || time.Now().After(entry.expiresAt) {
+	if !found || c.now().After(entry.expiresAt) {
 		return nil, false
 	}
-	return &entry.session, true
+	session := entry.session
+	return &session, true
 }
 
 // Set stores session data in the cache.
@@ -48,31 +95,212 @@ func (c *SessionCache) Set(sessionID string, session domain.CachedSession) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	now := c.now()
+	c.entries[sessionID] = &cacheEntry{
+		session:    session,
+		expiresAt:  now.Add(c.ttl),
+		staleUntil: now.Add(c.ttl + c.staleTTL),
+	}
+}
+
+// GetOrLoad returns the cached session, calling load on a miss. Concurrent
+// callers for the same session share a single load. A stale entry is
+// returned immediately and refreshed in the background; if the refresh finds
+// the session revoked the entry is dropped so the next call fails.
+func (c *SessionCache) GetOrLoad(ctx context.Context, sessionID string, load domain.SessionLoader) (*domain.CachedSession, error) {
+	c.mu.RLock()
+	entry, found := c.entries[sessionID]
+	c.mu.RUnlock()
+
+	if found {
+		now := c.now()
+		session := entry.session
+		if !now.After(entry.expiresAt) {
+			return &session, nil
+		}
+		if now.Before(entry.staleUntil) {
+			c.group.DoChan(sessionID, c.loadFunc(ctx, sessionID, load))
+			return &session, nil
+		}
+	}
+
+	select {
+	case res := <-c.group.DoChan(sessionID, c.loadFunc(ctx, sessionID, load)):
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		session := res.Val.(domain.CachedSession)
+		return &session, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// loadFunc wraps load for singleflight: it runs on a detached context,
+// stores successful results, and evicts the entry when the identity provider
+// reports the session is no longer valid.
+func (c *SessionCache) loadFunc(ctx context.Context, sessionID string, load domain.SessionLoader) func() (any, error) {
+	return func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		c.mu.RLock()
+		startEpoch := c.epoch
+		c.mu.RUnlock()
+
+		session, err := load(loadCtx)
+		if err != nil {
+			if isSessionRevoked(err) {
+				c.delete(sessionID)
+			} else {
+				// Keep any stale entry: Kratos being briefly unavailable
+				// should not log out every active user.
+				slog.WarnContext(ctx, "session refresh failed", "error", err)
+			}
+			return nil, err
+		}
+		c.setUnlessInvalidated(sessionID, *session, startEpoch)
+		return *session, nil
+	}
+}
+
+// setUnlessInvalidated stores session unless a webhook invalidated its
+// Kratos session or identity after the load that produced it started.
+func (c *SessionCache) setUnlessInvalidated(sessionID string, session domain.CachedSession, since uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range []string{sessionTombstoneKey(session.KratosSessionID), identityTombstoneKey(session.UserID)} {
+		if t, ok := c.tombstones[key]; ok && t.epoch > since {
+			delete(c.entries, sessionID)
+			return
+		}
+	}
+	now := c.now()
 	c.entries[sessionID] = &cacheEntry{
-		session:   session,
-		expiresAt: time.Now().Add(c.ttl),
+		session:    session,
+		expiresAt:  now.Add(c.ttl),
+		staleUntil: now.Add(c.ttl + c.staleTTL),
+	}
+}
+
+// InvalidateKratosSession drops every entry for the given Kratos session ID
+// and returns how many were removed.
+func (c *SessionCache) InvalidateKratosSession(kratosSessionID string) int {
+	if kratosSessionID == "" {
+		return 0
+	}
+	return c.invalidate(sessionTombstoneKey(kratosSessionID), func(s domain.CachedSession) bool {
+		return s.KratosSessionID == kratosSessionID
+	})
+}
+
+// InvalidateIdentity drops every entry belonging to identityID and returns
+// how many were removed.
+func (c *SessionCache) InvalidateIdentity(identityID string) int {
+	if identityID == "" {
+		return 0
+	}
+	return c.invalidate(identityTombstoneKey(identityID), func(s domain.CachedSession) bool {
+		return s.UserID == identityID
+	})
+}
+
+func (c *SessionCache) invalidate(key string, match func(domain.CachedSession) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	c.tombstones[key] = tombstone{epoch: c.epoch, at: c.now()}
+
+	removed := 0
+	for id, entry := range c.entries {
+		if match(entry.session) {
+			delete(c.entries, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+func sessionTombstoneKey(kratosSessionID string) string {
+	if kratosSessionID == "" {
+		return ""
 	}
+	return "session:" + kratosSessionID
 }
 
-// cleanup removes expired entries.
+func identityTombstoneKey(identityID string) string {
+	if identityID == "" {
+		return ""
+	}
+	return "identity:" + identityID
+}
+
+func (c *SessionCache) delete(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, sessionID)
+}
+
+// isSessionRevoked reports whether err means the session itself is invalid,
+// as opposed to the identity provider being unreachable.
+func isSessionRevoked(err error) bool {
+	return errors.Is(err, domain.ErrSessionNotFound) ||
+		errors.Is(err, domain.ErrSessionExpired) ||
+		errors.Is(err, domain.ErrSessionInactive) ||
+		errors.Is(err, domain.ErrAuthFailed) ||
+		errors.Is(err, domain.ErrMissingIdentity)
+}
+
+// cleanup removes entries past their stale window and tombstones older than
+// any load that could still be in flight.
 func (c *SessionCache) cleanup() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := time.Now()
+	now := c.now()
+	c.lastCleanup = now
 	for id, entry := range c.entries {
-		if now.After(entry.expiresAt) {
+		if now.After(entry.staleUntil) {
 			delete(c.entries, id)
 		}
 	}
+	for key, t := range c.tombstones {
+		if now.Sub(t.at) > refreshTimeout {
+			delete(c.tombstones, key)
+		}
+	}
 }
 
 // cleanupLoop runs periodic cleanup of expired entries.
 func (c *SessionCache) cleanupLoop() {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(cleanupInterval)
 	defer ticker.Stop()
 
 	for range ticker.C {
 		c.cleanup()
 	}
 }
+
+// Probe reports the cache unhealthy when its lock cannot be taken before ctx
+// ends, or when the cleanup loop has stopped sweeping, which would let the
+// cache grow without bound.
+func (c *SessionCache) Probe(ctx context.Context) error {
+	lastCleanup := make(chan time.Time, 1)
+	go func() {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		lastCleanup <- c.lastCleanup
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%w: lock not acquired: %w", domain.ErrCacheUnavailable, ctx.Err())
+	case at := <-lastCleanup:
+		if overdue := c.now().Sub(at); overdue > 3*cleanupInterval {
+			return fmt.Errorf("%w: last cleanup %s ago", domain.ErrCacheUnavailable, overdue.Round(time.Second))
+		}
+		return nil
+	}
+}
@@ -1,12 +1,17 @@
 package cache
 
 import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"auth-hub/internal/domain"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSessionCache_SetAndGet(t *testing.T) {
@@ -49,3 +54,271 @@ func TestSessionCache_Expiration(t *testing.T) {
 	assert.False(t, found)
 	assert.Nil(t, got)
 }
+
+// fakeClock lets tests move the cache across its fresh/stale boundaries.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func newTestCache(ttl, staleTTL time.Duration) (*SessionCache, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := NewSessionCacheWithStaleTTL(ttl, staleTTL)
+	c.now = clock.Now
+	return c, clock
+}
+
+func TestSessionCache_GetOrLoad_CoalescesConcurrentMisses(t *testing.T) {
+	c, _ := newTestCache(time.Minute, 0)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (*domain.CachedSession, error) {
+		calls.Add(1)
+		<-release
+		return &domain.CachedSession{UserID: "user-1"}, nil
+	}
+
+	const callers = 50
+	var wg sync.WaitGroup
+	results := make(chan string, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := c.GetOrLoad(context.Background(), "sess-hot", load)
+			if err == nil {
+				results <- got.UserID
+			}
+		}()
+	}
+
+	// Let every caller block on the in-flight load before releasing it.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(t, int32(1), calls.Load(), "concurrent misses must share one load")
+	count := 0
+	for userID := range results {
+		assert.Equal(t, "user-1", userID)
+		count++
+	}
+	assert.Equal(t, callers, count)
+}
+
+func TestSessionCache_GetOrLoad_ServesStaleWhileRevalidating(t *testing.T) {
+	c, clock := newTestCache(time.Minute, 30*time.Second)
+	c.Set("sess-1", domain.CachedSession{UserID: "user-old"})
+	clock.Advance(time.Minute + 10*time.Second) // expired, inside stale window
+
+	var calls atomic.Int32
+	load := func(context.Context) (*domain.CachedSession, error) {
+		calls.Add(1)
+		return &domain.CachedSession{UserID: "user-new"}, nil
+	}
+
+	got, err := c.GetOrLoad(context.Background(), "sess-1", load)
+	require.NoError(t, err)
+	assert.Equal(t, "user-old", got.UserID, "stale entry is served immediately")
+
+	require.Eventually(t, func() bool {
+		fresh, found := c.Get("sess-1")
+		return found && fresh.UserID == "user-new"
+	}, time.Second, 5*time.Millisecond, "background refresh should replace the stale entry")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSessionCache_GetOrLoad_PastStaleWindowBlocks(t *testing.T) {
+	c, clock := newTestCache(time.Minute, 30*time.Second)
+	c.Set("sess-1", domain.CachedSession{UserID: "user-old"})
+	clock.Advance(2 * time.Minute)
+
+	got, err := c.GetOrLoad(context.Background(), "sess-1", func(context.Context) (*domain.CachedSession, error) {
+		return &domain.CachedSession{UserID: "user-new"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "user-new", got.UserID)
+}
+
+func TestSessionCache_GetOrLoad_RefreshErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantEntry bool
+	}{
+		{name: "revoked session is evicted", err: domain.ErrAuthFailed, wantEntry: false},
+		{name: "inactive session is evicted", err: domain.ErrSessionInactive, wantEntry: false},
+		{name: "kratos outage keeps stale entry", err: fmt.Errorf("%w: timeout", domain.ErrKratosUnavailable), wantEntry: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, clock := newTestCache(time.Minute, 30*time.Second)
+			c.Set("sess-1", domain.CachedSession{UserID: "user-1"})
+			clock.Advance(time.Minute + time.Second)
+
+			done := make(chan struct{})
+			load := func(context.Context) (*domain.CachedSession, error) {
+				defer close(done)
+				return nil, tt.err
+			}
+
+			got, err := c.GetOrLoad(context.Background(), "sess-1", load)
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", got.UserID)
+			<-done
+
+			// The stale hit triggered the refresh; wait for its outcome.
+			require.Eventually(t, func() bool {
+				c.mu.RLock()
+				defer c.mu.RUnlock()
+				_, found := c.entries["sess-1"]
+				return found == tt.wantEntry
+			}, time.Second, 5*time.Millisecond)
+		})
+	}
+}
+
+func TestSessionCache_GetOrLoad_DoesNotCacheErrors(t *testing.T) {
+	c, _ := newTestCache(time.Minute, 0)
+
+	_, err := c.GetOrLoad(context.Background(), "sess-bad", func(context.Context) (*domain.CachedSession, error) {
+		return nil, domain.ErrSessionNotFound
+	})
+	require.ErrorIs(t, err, domain.ErrSessionNotFound)
+
+	got, err := c.GetOrLoad(context.Background(), "sess-bad", func(context.Context) (*domain.CachedSession, error) {
+		return &domain.CachedSession{UserID: "user-1"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", got.UserID)
+}
+
+func TestSessionCache_GetOrLoad_WaiterContextCanceled(t *testing.T) {
+	c, _ := newTestCache(time.Minute, 0)
+
+	release := make(chan struct{})
+	defer close(release)
+	load := func(context.Context) (*domain.CachedSession, error) {
+		<-release
+		return &domain.CachedSession{UserID: "user-1"}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.GetOrLoad(ctx, "sess-slow", load)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSessionCache_InvalidateKratosSession(t *testing.T) {
+	c, _ := newTestCache(time.Minute, 0)
+	c.Set("cookie-a", domain.CachedSession{UserID: "user-1", KratosSessionID: "ks-a"})
+	c.Set("cookie-b", domain.CachedSession{UserID: "user-1", KratosSessionID: "ks-b"})
+
+	assert.Equal(t, 1, c.InvalidateKratosSession("ks-a"))
+	assert.Equal(t, 0, c.InvalidateKratosSession("ks-a"))
+	assert.Equal(t, 0, c.InvalidateKratosSession(""))
+
+	_, found := c.Get("cookie-a")
+	assert.False(t, found)
+	_, found = c.Get("cookie-b")
+	assert.True(t, found, "other sessions of the identity stay cached")
+}
+
+func TestSessionCache_InvalidateIdentity(t *testing.T) {
+	c, _ := newTestCache(time.Minute, 0)
+	c.Set("cookie-a", domain.CachedSession{UserID: "user-1", KratosSessionID: "ks-a"})
+	c.Set("cookie-b", domain.CachedSession{UserID: "user-1", KratosSessionID: "ks-b"})
+	c.Set("cookie-c", domain.CachedSession{UserID: "user-2", KratosSessionID: "ks-c"})
+
+	assert.Equal(t, 2, c.InvalidateIdentity("user-1"))
+
+	_, found := c.Get("cookie-a")
+	assert.False(t, found)
+	_, found = c.Get("cookie-b")
+	assert.False(t, found)
+	_, found = c.Get("cookie-c")
+	assert.True(t, found)
+}
+
+func TestSessionCache_InvalidateDuringLoadIsNotOverwritten(t *testing.T) {
+	c, _ := newTestCache(time.Minute, 0)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(context.Context) (*domain.CachedSession, error) {
+		close(started)
+		<-release
+		return &domain.CachedSession{UserID: "user-1", KratosSessionID: "ks-a"}, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = c.GetOrLoad(context.Background(), "cookie-a", load)
+	}()
+
+	<-started
+	c.InvalidateIdentity("user-1")
+	close(release)
+	<-done
+
+	_, found := c.Get("cookie-a")
+	assert.False(t, found, "a load that raced an invalidation must not repopulate the cache")
+
+	// Loads that start after the invalidation are cached as usual.
+	_, err := c.GetOrLoad(context.Background(), "cookie-a", func(context.Context) (*domain.CachedSession, error) {
+		return &domain.CachedSession{UserID: "user-1", KratosSessionID: "ks-a"}, nil
+	})
+	assert.NoError(t, err)
+	_, found = c.Get("cookie-a")
+	assert.True(t, found)
+}
+
+func TestSessionCache_CleanupPrunesTombstones(t *testing.T) {
+	c, clock := newTestCache(time.Minute, 0)
+	c.InvalidateKratosSession("ks-a")
+
+	c.cleanup()
+	assert.Len(t, c.tombstones, 1)
+
+	clock.Advance(refreshTimeout + time.Second)
+	c.cleanup()
+	assert.Empty(t, c.tombstones)
+}
+
+func TestSessionCache_Probe(t *testing.T) {
+	c, clock := newTestCache(time.Minute, 0)
+	c.cleanup()
+
+	require.NoError(t, c.Probe(context.Background()))
+
+	clock.Advance(4 * cleanupInterval)
+	err := c.Probe(context.Background())
+	assert.ErrorIs(t, err, domain.ErrCacheUnavailable, "a stalled cleanup loop lets the cache grow unbounded")
+
+	c.cleanup()
+	require.NoError(t, c.Probe(context.Background()))
+
+	// A lock held past the probe deadline is reported, not waited on.
+	c.mu.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = c.Probe(ctx)
+	c.mu.Unlock()
+	assert.ErrorIs(t, err, domain.ErrCacheUnavailable)
+}
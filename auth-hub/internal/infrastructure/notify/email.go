@@ -0,0 +1,186 @@
+// Package notify implements domain.NotificationGateway for the channels
+// auth-hub can reach users on.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// EmailConfig configures EmailGateway.
+type EmailConfig struct {
+	// SMTPURL is smtp://[user:pass@]host:port (STARTTLS when offered) or
+	// smtps://[user:pass@]host:port (implicit TLS).
+	SMTPURL string
+	From    string
+	Timeout time.Duration
+}
+
+// EmailGateway sends notifications as plain-text email over SMTP.
+// Implements domain.NotificationGateway.
+type EmailGateway struct {
+	addr     string
+	host     string
+	implicit bool
+	auth     smtp.Auth
+	from     string
+	timeout  time.Duration
+	now      func() time.Time
+}
+
+// NewEmailGateway parses cfg.SMTPURL and returns a gateway for it.
+func NewEmailGateway(cfg EmailConfig) (*EmailGateway, error) {
+	u, err := url.Parse(cfg.SMTPURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse SMTP URL: %w", err)
+	}
+	if u.Scheme != "smtp" && u.Scheme != "smtps" {
+		return nil, fmt.Errorf("SMTP URL scheme must be smtp or smtps, got %q", u.Scheme)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("SMTP URL needs host:port")
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("sender address is required")
+	}
+
+	g := &EmailGateway{
+		addr:     u.Host,
+		host:     u.Hostname(),
+		implicit: u.Scheme == "smtps",
+		from:     cfg.From,
+		timeout:  cfg.Timeout,
+		now:      time.Now,
+	}
+	if g.timeout <= 0 {
+		g.timeout = 10 * time.Second
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		g.auth = smtp.PlainAuth("", u.User.Username(), password, g.host)
+	}
+	return g, nil
+}
+
+// SendLoginAlert implements domain.NotificationGateway.
+func (g *EmailGateway) SendLoginAlert(ctx context.Context, alert domain.LoginAlert) error {
+	if strings.ContainsAny(alert.Email, "\r\n") || alert.Email == "" {
+		return fmt.Errorf("%w: invalid recipient", domain.ErrNotificationFailed)
+	}
+	msg, err := buildLoginAlertMessage(g.from, alert, g.now())
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrNotificationFailed, err)
+	}
+	if err := g.send(ctx, alert.Email, msg); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrNotificationFailed, err)
+	}
+	return nil
+}
+
+func (g *EmailGateway) send(ctx context.Context, to string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", g.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if g.implicit {
+		conn = tls.Client(conn, &tls.Config{ServerName: g.host, MinVersion: tls.VersionTLS12})
+	}
+
+	c, err := smtp.NewClient(conn, g.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if !g.implicit {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: g.host, MinVersion: tls.VersionTLS12}); err != nil {
+				return err
+			}
+		}
+	}
+	if g.auth != nil {
+		if err := c.Auth(g.auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(g.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+var loginAlertBody = template.Must(template.New("login_alert").Parse(`A new sign-in to your Alt account was detected{{if .NewDevice}} from a device{{if .NewLocation}} and location{{end}} you have not used recently{{else if .NewLocation}} from a location you have not used recently{{end}}.
+
+Time:     {{.At}}
+Device:   {{or .UserAgent "unknown"}}
+IP:       {{or .IP "unknown"}}
+Location: {{or .Location "unknown"}}
+
+If this was you, no action is needed.
+
+If this was not you, end that session now:
+{{.RevokeURL}}
+Then change your password.
+
+To stop receiving sign-in alerts:
+{{.OptOutURL}}
+`))
+
+// buildLoginAlertMessage renders the RFC 5322 message for alert.
+func buildLoginAlertMessage(from string, alert domain.LoginAlert, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	data := struct {
+		domain.LoginAlert
+		At string
+	}{alert, alert.At.UTC().Format("2006-01-02 15:04:05 MST")}
+	if err := loginAlertBody.Execute(&body, data); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", alert.Email)
+	fmt.Fprintf(&msg, "Subject: New sign-in to your Alt account\r\n")
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	if alert.OptOutURL != "" {
+		fmt.Fprintf(&msg, "List-Unsubscribe: <%s>\r\n", alert.OptOutURL)
+		fmt.Fprintf(&msg, "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+	}
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&msg, "\r\n")
+	msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+	return msg.Bytes(), nil
+}
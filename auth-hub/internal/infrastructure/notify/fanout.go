@@ -0,0 +1,25 @@
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"auth-hub/internal/domain"
+)
+
+// Fanout delivers each notification over every gateway, so channels can be
+// added next to email without touching the usecases. Every gateway is tried;
+// the errors of those that failed are joined. Implements
+// domain.NotificationGateway.
+type Fanout []domain.NotificationGateway
+
+// SendLoginAlert implements domain.NotificationGateway.
+func (f Fanout) SendLoginAlert(ctx context.Context, alert domain.LoginAlert) error {
+	var errs []error
+	for _, g := range f {
+		if err := g.SendLoginAlert(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubGateway struct {
+	sent int
+	err  error
+}
+
+func (s *stubGateway) SendLoginAlert(context.Context, domain.LoginAlert) error {
+	s.sent++
+	return s.err
+}
+
+func TestFanout_TriesEveryGateway(t *testing.T) {
+	failing := &stubGateway{err: errors.New("boom")}
+	ok := &stubGateway{}
+
+	err := Fanout{failing, ok}.SendLoginAlert(context.Background(), domain.LoginAlert{})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, failing.sent)
+	assert.Equal(t, 1, ok.sent)
+}
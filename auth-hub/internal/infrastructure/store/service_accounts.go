@@ -0,0 +1,187 @@
+// Package store keeps the little state auth-hub owns itself, as opposed to
+// identities and sessions, which live in Kratos.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"auth-hub/internal/domain"
+)
+
+// ServiceAccountFile keeps service accounts in memory and writes the whole
+// set to a JSON file after every change. The file is replaced by renaming
+// a temporary one, so a crash never leaves it half-written.
+//
+// Each file must have a single writer: two auth-hub replicas sharing one
+// would overwrite each other's changes.
+// Implements domain.ServiceAccountStore.
+type ServiceAccountFile struct {
+	path string
+
+	mu       sync.Mutex
+	accounts map[string]domain.ServiceAccount
+}
+
+// NewServiceAccountFile loads the accounts stored at path. A missing file
+// is an empty store; it is created on the first change.
+func NewServiceAccountFile(path string) (*ServiceAccountFile, error) {
+	s := &ServiceAccountFile{path: path, accounts: map[string]domain.ServiceAccount{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var accounts []domain.ServiceAccount
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for _, a := range accounts {
+		s.accounts[a.ID] = a
+	}
+	return s, nil
+}
+
+// List returns all accounts ordered by name.
+func (s *ServiceAccountFile) List(_ context.Context) ([]domain.ServiceAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedLocked(), nil
+}
+
+// Get returns a copy of the account.
+func (s *ServiceAccountFile) Get(_ context.Context, id string) (*domain.ServiceAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.accounts[id]
+	if !ok {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+	a = clone(a)
+	return &a, nil
+}
+
+// Create adds the account unless its ID or name is taken.
+func (s *ServiceAccountFile) Create(_ context.Context, account domain.ServiceAccount) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.accounts[account.ID]; ok {
+		return fmt.Errorf("%w: id %q", domain.ErrServiceAccountExists, account.ID)
+	}
+	for _, a := range s.accounts {
+		if a.Name == account.Name {
+			return fmt.Errorf("%w: name %q", domain.ErrServiceAccountExists, account.Name)
+		}
+	}
+
+	s.accounts[account.ID] = clone(account)
+	if err := s.saveLocked(); err != nil {
+		delete(s.accounts, account.ID)
+		return err
+	}
+	return nil
+}
+
+// Update applies fn to a copy of the account and stores the copy. The
+// in-memory account is only replaced once the file is written.
+func (s *ServiceAccountFile) Update(_ context.Context, id string, fn func(*domain.ServiceAccount) error) (*domain.ServiceAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, ok := s.accounts[id]
+	if !ok {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+	next := clone(prev)
+	if err := fn(&next); err != nil {
+		return nil, err
+	}
+
+	s.accounts[id] = next
+	if err := s.saveLocked(); err != nil {
+		s.accounts[id] = prev
+		return nil, err
+	}
+	out := clone(next)
+	return &out, nil
+}
+
+func (s *ServiceAccountFile) sortedLocked() []domain.ServiceAccount {
+	out := make([]domain.ServiceAccount, 0, len(s.accounts))
+	for _, a := range s.accounts {
+		out = append(out, clone(a))
+	}
+	slices.SortFunc(out, func(a, b domain.ServiceAccount) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// saveLocked writes every account to a temporary file next to path and
+// renames it over path. The file holds secret hashes, so it is only
+// readable by its owner.
+func (s *ServiceAccountFile) saveLocked() error {
+	data, err := json.MarshalIndent(s.sortedLocked(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode service accounts: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("save service accounts: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save service accounts: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save service accounts: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save service accounts: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("save service accounts: %w", err)
+	}
+	return nil
+}
+
+// clone copies the account's slices and pointers so callers cannot change
+// the stored account.
+func clone(a domain.ServiceAccount) domain.ServiceAccount {
+	a.Grants = slices.Clone(a.Grants)
+	for i := range a.Grants {
+		a.Grants[i].Scopes = slices.Clone(a.Grants[i].Scopes)
+	}
+	a.IPAllowlist = slices.Clone(a.IPAllowlist)
+	a.Credentials = slices.Clone(a.Credentials)
+	for i := range a.Credentials {
+		a.Credentials[i].ExpiresAt = clonePtr(a.Credentials[i].ExpiresAt)
+		a.Credentials[i].LastUsedAt = clonePtr(a.Credentials[i].LastUsedAt)
+	}
+	a.Usage.LastUsedAt = clonePtr(a.Usage.LastUsedAt)
+	a.DisabledAt = clonePtr(a.DisabledAt)
+	return a
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccount(id, name string) domain.ServiceAccount {
+	created := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	return domain.ServiceAccount{
+		ID:          id,
+		Name:        name,
+		Grants:      []domain.ServiceGrant{{Audience: "search-indexer", Scopes: []string{"search.index"}}},
+		TokenTTL:    5 * time.Minute,
+		IPAllowlist: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+		Credentials: []domain.ServiceCredential{{ID: "c1", SecretHash: "hash", CreatedAt: created}},
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}
+}
+
+func TestServiceAccountFile_PersistsAcrossReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.json")
+	ctx := context.Background()
+
+	s, err := NewServiceAccountFile(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Create(ctx, testAccount("sa_2", "search-indexer")))
+	require.NoError(t, s.Create(ctx, testAccount("sa_1", "pre-processor")))
+	_, err = s.Update(ctx, "sa_1", func(a *domain.ServiceAccount) error {
+		a.Usage.TokensIssued = 3
+		return nil
+	})
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "file holds secret hashes")
+
+	reloaded, err := NewServiceAccountFile(path)
+	require.NoError(t, err)
+	accounts, err := reloaded.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "pre-processor", accounts[0].Name, "ordered by name")
+	assert.Equal(t, int64(3), accounts[0].Usage.TokensIssued)
+	assert.Equal(t, testAccount("sa_2", "search-indexer"), accounts[1])
+}
+
+func TestServiceAccountFile_MissingFileIsEmpty(t *testing.T) {
+	s, err := NewServiceAccountFile(filepath.Join(t.TempDir(), "none.json"))
+	require.NoError(t, err)
+
+	accounts, err := s.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, accounts)
+	_, err = s.Get(context.Background(), "sa_1")
+	assert.ErrorIs(t, err, domain.ErrServiceAccountNotFound)
+}
+
+func TestServiceAccountFile_RejectsDuplicates(t *testing.T) {
+	s, err := NewServiceAccountFile(filepath.Join(t.TempDir(), "default.json"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, s.Create(ctx, testAccount("sa_1", "pre-processor")))
+	assert.ErrorIs(t, s.Create(ctx, testAccount("sa_1", "other")), domain.ErrServiceAccountExists)
+	assert.ErrorIs(t, s.Create(ctx, testAccount("sa_2", "pre-processor")), domain.ErrServiceAccountExists)
+}
+
+func TestServiceAccountFile_FailedUpdateChangesNothing(t *testing.T) {
+	s, err := NewServiceAccountFile(filepath.Join(t.TempDir(), "default.json"))
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, s.Create(ctx, testAccount("sa_1", "pre-processor")))
+
+	boom := errors.New("boom")
+	_, err = s.Update(ctx, "sa_1", func(a *domain.ServiceAccount) error {
+		a.Grants[0].Scopes[0] = "changed"
+		a.Credentials = nil
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+
+	got, err := s.Get(ctx, "sa_1")
+	require.NoError(t, err)
+	assert.Equal(t, testAccount("sa_1", "pre-processor"), *got)
+
+	_, err = s.Update(ctx, "sa_unknown", func(*domain.ServiceAccount) error { return nil })
+	assert.ErrorIs(t, err, domain.ErrServiceAccountNotFound)
+}
+
+func TestServiceAccountFile_GetReturnsCopy(t *testing.T) {
+	s, err := NewServiceAccountFile(filepath.Join(t.TempDir(), "default.json"))
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, s.Create(ctx, testAccount("sa_1", "pre-processor")))
+
+	got, err := s.Get(ctx, "sa_1")
+	require.NoError(t, err)
+	got.Credentials[0].SecretHash = "tampered"
+
+	again, err := s.Get(ctx, "sa_1")
+	require.NoError(t, err)
+	assert.Equal(t, "hash", again.Credentials[0].SecretHash)
+}
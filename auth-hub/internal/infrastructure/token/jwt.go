@@ -1,6 +1,10 @@
 package token
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"auth-hub/internal/domain"
@@ -14,33 +18,179 @@ type JWTConfig struct {
 	Issuer   string
 	Audience string
 	TTL      time.Duration
+	// Services are the downstream audiences, besides Audience, that
+	// audience-scoped service tokens can be issued for.
+	Services []AudienceProfile
+}
+
+// AudienceProfile configures the tokens minted for one downstream service.
+type AudienceProfile struct {
+	Audience string
+	TTL      time.Duration
+	// Scopes lists what the service may do on the user's behalf. Tokens for
+	// an audience without scopes carry no scope claim.
+	Scopes []string
 }
 
 // backendClaims represents the JWT claims for backend authentication.
 // TenantID carries the tenant_id claim consumed by alt-backend; in single-tenant
 // deployments it equals Subject (UserID), but keeping it as a dedicated claim
 // decouples tenant from user identity and prepares for multi-tenant upgrades.
+//
+// Service tokens reuse the same claims minus email, plus scope and, when
+// obtained by token exchange, the RFC 8693 act chain.
 type backendClaims struct {
-	Email    string `json:"email"`
-	Role     string `json:"role"`
-	Sid      string `json:"sid"`
-	TenantID string `json:"tenant_id"`
+	Email    string       `json:"email,omitempty"`
+	Role     string       `json:"role"`
+	Sid      string       `json:"sid"`
+	TenantID string       `json:"tenant_id"`
+	Scope    string       `json:"scope,omitempty"`
+	Act      *actorClaims `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// actorClaims is the RFC 8693 act claim: the current actor, nesting the
+// actors before it.
+type actorClaims struct {
+	Subject string       `json:"sub"`
+	Act     *actorClaims `json:"act,omitempty"`
+}
+
 // JWTIssuer generates JWT tokens for backend authentication.
-// Implements domain.TokenIssuer.
+// Implements domain.TokenIssuer, domain.ServiceTokenIssuer and
+// domain.HealthProbe.
 type JWTIssuer struct {
-	cfg JWTConfig
+	cfg      JWTConfig
+	profiles map[string]AudienceProfile
+	now      func() time.Time
 }
 
 // NewJWTIssuer creates a new JWT issuer.
 func NewJWTIssuer(cfg JWTConfig) *JWTIssuer {
-	return &JWTIssuer{cfg: cfg}
+	profiles := make(map[string]AudienceProfile, len(cfg.Services)+1)
+	for _, p := range cfg.Services {
+		profiles[p.Audience] = p
+	}
+	// The backend audience is always a valid target, with its own TTL and
+	// without scopes, so exchanged alt-backend tokens match session ones.
+	profiles[cfg.Audience] = AudienceProfile{Audience: cfg.Audience, TTL: cfg.TTL}
+	return &JWTIssuer{cfg: cfg, profiles: profiles, now: time.Now}
 }
 
 // IssueBackendToken generates a signed JWT token.
 func (j *JWTIssuer) IssueBackendToken(identity *domain.Identity, sessionID string) (string, error) {
+	now := j.now()
+	claims := j.userClaims(identity, sessionID, j.cfg.Audience, now, now.Add(j.cfg.TTL))
+	claims.Email = identity.Email
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(j.cfg.Secret))
+}
+
+// IssueServiceToken generates a token scoped to one configured downstream
+// audience. It returns domain.ErrUnknownAudience for unconfigured audiences
+// and domain.ErrInvalidScope when req.Scopes exceeds the audience's scopes.
+func (j *JWTIssuer) IssueServiceToken(identity *domain.Identity, sessionID string, req domain.ServiceTokenRequest) (*domain.ServiceToken, error) {
+	profile, ok := j.profiles[req.Audience]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnknownAudience, req.Audience)
+	}
+
+	scopes := profile.Scopes
+	if req.Scopes != nil {
+		for _, s := range req.Scopes {
+			if !slices.Contains(profile.Scopes, s) {
+				return nil, fmt.Errorf("%w: %q for %q", domain.ErrInvalidScope, s, req.Audience)
+			}
+		}
+		scopes = req.Scopes
+	}
+
+	now := j.now()
+	expiresAt := now.Add(profile.TTL)
+	if !req.NotAfter.IsZero() && req.NotAfter.Before(expiresAt) {
+		expiresAt = req.NotAfter
+	}
+
+	claims := j.userClaims(identity, sessionID, req.Audience, now, expiresAt)
+	claims.Scope = strings.Join(scopes, " ")
+	claims.Act = actorChain(req.Actors)
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.cfg.Secret))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ServiceToken{
+		Audience:  req.Audience,
+		Token:     signed,
+		Scopes:    slices.Clone(scopes),
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// VerifyToken checks a token auth-hub issued for any configured audience.
+// Every failure is reported as domain.ErrInvalidSubjectToken.
+func (j *JWTIssuer) VerifyToken(tokenString string) (*domain.VerifiedToken, error) {
+	claims := &backendClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims,
+		func(*jwt.Token) (any, error) { return []byte(j.cfg.Secret), nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(j.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(j.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidSubjectToken, err)
+	}
+	if len(claims.Audience) != 1 {
+		return nil, fmt.Errorf("%w: expected exactly one audience", domain.ErrInvalidSubjectToken)
+	}
+	if _, ok := j.profiles[claims.Audience[0]]; !ok {
+		return nil, fmt.Errorf("%w: audience %q is not configured", domain.ErrInvalidSubjectToken, claims.Audience[0])
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", domain.ErrInvalidSubjectToken)
+	}
+
+	var actors []string
+	for a := claims.Act; a != nil; a = a.Act {
+		actors = append(actors, a.Subject)
+	}
+	return &domain.VerifiedToken{
+		Identity: domain.Identity{
+			UserID:   claims.Subject,
+			TenantID: claims.TenantID,
+			Email:    claims.Email,
+			Role:     claims.Role,
+		},
+		SessionID: claims.Sid,
+		Audience:  claims.Audience[0],
+		Scopes:    strings.Fields(claims.Scope),
+		Actors:    actors,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// Probe signs a short-lived token for the backend audience and verifies it,
+// proving the signing key is loaded and usable.
+func (j *JWTIssuer) Probe(_ context.Context) error {
+	if j.cfg.Secret == "" {
+		return fmt.Errorf("%w: secret not configured", domain.ErrSigningKeyUnavailable)
+	}
+	now := j.now()
+	claims := j.userClaims(&domain.Identity{UserID: "readiness-probe"}, "", j.cfg.Audience, now, now.Add(time.Minute))
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.cfg.Secret))
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrSigningKeyUnavailable, err)
+	}
+	if _, err := j.VerifyToken(signed); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrSigningKeyUnavailable, err)
+	}
+	return nil
+}
+
+// userClaims builds the claims shared by backend and service tokens.
+func (j *JWTIssuer) userClaims(identity *domain.Identity, sessionID, audience string, now, expiresAt time.Time) backendClaims {
 	role := identity.Role
 	if role == "" {
 		role = "user"
@@ -55,21 +205,25 @@ func (j *JWTIssuer) IssueBackendToken(identity *domain.Identity, sessionID strin
 		tenantID = identity.UserID
 	}
 
-	now := time.Now()
-	claims := backendClaims{
-		Email:    identity.Email,
+	return backendClaims{
 		Role:     role,
 		Sid:      sessionID,
 		TenantID: tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    j.cfg.Issuer,
-			Audience:  jwt.ClaimStrings{j.cfg.Audience},
+			Audience:  jwt.ClaimStrings{audience},
 			Subject:   identity.UserID,
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(j.cfg.TTL)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.cfg.Secret))
+// actorChain nests actors, most recent first, into an act claim.
+func actorChain(actors []string) *actorClaims {
+	var act *actorClaims
+	for i := len(actors) - 1; i >= 0; i-- {
+		act = &actorClaims{Subject: actors[i], Act: act}
+	}
+	return act
 }
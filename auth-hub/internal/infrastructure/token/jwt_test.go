@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -198,3 +199,138 @@ func TestJWTIssuer_InvalidSignature(t *testing.T) {
 	})
 	assert.Error(t, err)
 }
+
+func newServiceIssuer() *JWTIssuer {
+	return NewJWTIssuer(JWTConfig{
+		Secret:   "this-is-a-valid-backend-token-secret-32-chars-long",
+		Issuer:   "auth-hub",
+		Audience: "alt-backend",
+		TTL:      5 * time.Minute,
+		Services: []AudienceProfile{
+			{Audience: "rag-orchestrator", TTL: 2 * time.Minute, Scopes: []string{"rag.query", "rag.read"}},
+			{Audience: "tts", TTL: time.Minute, Scopes: []string{"tts.synthesize"}},
+		},
+	})
+}
+
+func TestJWTIssuer_IssueServiceToken(t *testing.T) {
+	issuer := newServiceIssuer()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	issuer.now = func() time.Time { return now }
+
+	identity := &domain.Identity{UserID: "user-123", Email: "test@example.com"}
+	tok, err := issuer.IssueServiceToken(identity, "session-abc", domain.ServiceTokenRequest{Audience: "rag-orchestrator"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rag-orchestrator", tok.Audience)
+	assert.Equal(t, []string{"rag.query", "rag.read"}, tok.Scopes)
+	assert.Equal(t, now.Add(2*time.Minute), tok.ExpiresAt)
+
+	parsed, err := jwt.ParseWithClaims(tok.Token, &backendClaims{}, func(token *jwt.Token) (any, error) {
+		return []byte("this-is-a-valid-backend-token-secret-32-chars-long"), nil
+	}, jwt.WithTimeFunc(issuer.now))
+	assert.NoError(t, err)
+	claims := parsed.Claims.(*backendClaims)
+	assert.Equal(t, jwt.ClaimStrings{"rag-orchestrator"}, claims.Audience)
+	assert.Equal(t, "rag.query rag.read", claims.Scope)
+	assert.Empty(t, claims.Email, "service tokens do not carry the email")
+	assert.Equal(t, "user-123", claims.TenantID)
+	assert.Nil(t, claims.Act)
+}
+
+func TestJWTIssuer_IssueServiceToken_Rejects(t *testing.T) {
+	issuer := newServiceIssuer()
+	identity := &domain.Identity{UserID: "user-123"}
+
+	_, err := issuer.IssueServiceToken(identity, "s", domain.ServiceTokenRequest{Audience: "search-indexer"})
+	assert.ErrorIs(t, err, domain.ErrUnknownAudience)
+
+	_, err = issuer.IssueServiceToken(identity, "s", domain.ServiceTokenRequest{Audience: "tts", Scopes: []string{"rag.query"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidScope)
+
+	tok, err := issuer.IssueServiceToken(identity, "s", domain.ServiceTokenRequest{Audience: "rag-orchestrator", Scopes: []string{"rag.read"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rag.read"}, tok.Scopes, "scopes can be narrowed")
+}
+
+func TestJWTIssuer_VerifyToken_RoundTripsDelegation(t *testing.T) {
+	issuer := newServiceIssuer()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	issuer.now = func() time.Time { return now }
+
+	identity := &domain.Identity{UserID: "user-123", TenantID: "tenant-1", Role: "admin"}
+	notAfter := now.Add(30 * time.Second)
+	tok, err := issuer.IssueServiceToken(identity, "session-abc", domain.ServiceTokenRequest{
+		Audience: "tts",
+		Actors:   []string{"rag-orchestrator", "alt-backend"},
+		NotAfter: notAfter,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, notAfter, tok.ExpiresAt, "NotAfter caps the audience TTL")
+
+	verified, err := issuer.VerifyToken(tok.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-123", verified.Identity.UserID)
+	assert.Equal(t, "tenant-1", verified.Identity.TenantID)
+	assert.Equal(t, "admin", verified.Identity.Role)
+	assert.Equal(t, "session-abc", verified.SessionID)
+	assert.Equal(t, "tts", verified.Audience)
+	assert.Equal(t, []string{"tts.synthesize"}, verified.Scopes)
+	assert.Equal(t, []string{"rag-orchestrator", "alt-backend"}, verified.Actors)
+	assert.True(t, notAfter.Equal(verified.ExpiresAt))
+}
+
+func TestJWTIssuer_VerifyToken_Invalid(t *testing.T) {
+	issuer := newServiceIssuer()
+	identity := &domain.Identity{UserID: "user-123"}
+
+	backend, err := issuer.IssueBackendToken(identity, "s")
+	assert.NoError(t, err)
+	_, err = issuer.VerifyToken(backend)
+	assert.NoError(t, err, "backend tokens can be exchanged")
+
+	other := NewJWTIssuer(JWTConfig{
+		Secret:   "another-backend-token-secret-that-is-32-chars-long",
+		Issuer:   "auth-hub",
+		Audience: "alt-backend",
+		TTL:      5 * time.Minute,
+	})
+	forged, err := other.IssueBackendToken(identity, "s")
+	assert.NoError(t, err)
+
+	expiredIssuer := newServiceIssuer()
+	expiredIssuer.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	expired, err := expiredIssuer.IssueBackendToken(identity, "s")
+	assert.NoError(t, err)
+
+	unknownAud := NewJWTIssuer(JWTConfig{
+		Secret:   "this-is-a-valid-backend-token-secret-32-chars-long",
+		Issuer:   "auth-hub",
+		Audience: "search-indexer",
+		TTL:      5 * time.Minute,
+	})
+	foreign, err := unknownAud.IssueBackendToken(identity, "s")
+	assert.NoError(t, err)
+
+	for name, tok := range map[string]string{
+		"wrong secret":     forged,
+		"expired":          expired,
+		"unknown audience": foreign,
+		"garbage":          "not-a-jwt",
+	} {
+		_, err := issuer.VerifyToken(tok)
+		assert.ErrorIs(t, err, domain.ErrInvalidSubjectToken, name)
+	}
+}
+
+func TestJWTIssuer_Probe(t *testing.T) {
+	issuer := NewJWTIssuer(JWTConfig{
+		Secret:   "this-is-a-valid-backend-token-secret-32-chars-long",
+		Issuer:   "auth-hub",
+		Audience: "alt-backend",
+		TTL:      5 * time.Minute,
+	})
+	assert.NoError(t, issuer.Probe(context.Background()))
+
+	missing := NewJWTIssuer(JWTConfig{Issuer: "auth-hub", Audience: "alt-backend", TTL: 5 * time.Minute})
+	assert.ErrorIs(t, missing.Probe(context.Background()), domain.ErrSigningKeyUnavailable)
+}
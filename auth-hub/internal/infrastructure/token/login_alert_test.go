@@ -0,0 +1,58 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACLoginAlertSigner_RoundTrip(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := NewHMACLoginAlertSigner(testCSRFSecret)
+	s.now = func() time.Time { return now }
+
+	link := domain.LoginAlertLink{
+		Action:     domain.LoginAlertRevoke,
+		IdentityID: "user-1",
+		SessionID:  "ks-1",
+		ExpiresAt:  now.Add(time.Hour),
+	}
+	token, err := s.Sign(link)
+	require.NoError(t, err)
+
+	got, err := s.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, link, *got)
+}
+
+func TestHMACLoginAlertSigner_Rejects(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := NewHMACLoginAlertSigner(testCSRFSecret)
+	s.now = func() time.Time { return now }
+	other := NewHMACLoginAlertSigner(testCSRFSecret + "-other-tenant")
+
+	valid, err := s.Sign(domain.LoginAlertLink{Action: domain.LoginAlertOptOut, IdentityID: "user-1", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	expired, err := s.Sign(domain.LoginAlertLink{Action: domain.LoginAlertOptOut, IdentityID: "user-1", ExpiresAt: now})
+	require.NoError(t, err)
+	foreign, err := other.Sign(domain.LoginAlertLink{Action: domain.LoginAlertOptOut, IdentityID: "user-1", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	for name, token := range map[string]string{
+		"empty":         "",
+		"no signature":  "eyJhIjoib3B0X291dCJ9",
+		"tampered":      "x" + valid,
+		"expired":       expired,
+		"other tenant":  foreign,
+		"bad signature": valid[:len(valid)-2] + "AA",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.Verify(token)
+			assert.ErrorIs(t, err, domain.ErrInvalidLoginAlertLink)
+		})
+	}
+}
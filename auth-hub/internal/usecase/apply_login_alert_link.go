@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"auth-hub/internal/domain"
+)
+
+// ApplyLoginAlertLink carries out the action of a followed login alert
+// link: revoking the session the alert was about, or turning login alerts
+// off. The signed link is the only credential; the user need not be signed
+// in, since the alert may be about someone else holding their account.
+type ApplyLoginAlertLink struct {
+	signer      domain.LoginAlertLinkSigner
+	revoker     domain.SessionRevoker
+	prefs       domain.LoginAlertPreferences
+	invalidator domain.SessionInvalidator
+	logger      *slog.Logger
+}
+
+// NewApplyLoginAlertLink creates a new ApplyLoginAlertLink usecase.
+func NewApplyLoginAlertLink(
+	s domain.LoginAlertLinkSigner,
+	r domain.SessionRevoker,
+	p domain.LoginAlertPreferences,
+	i domain.SessionInvalidator,
+	l *slog.Logger,
+) *ApplyLoginAlertLink {
+	return &ApplyLoginAlertLink{signer: s, revoker: r, prefs: p, invalidator: i, logger: l}
+}
+
+// Execute verifies token and applies it if it was issued for action.
+// Revoking also evicts the session from the cache so it stops validating
+// at once. Both actions are idempotent.
+func (uc *ApplyLoginAlertLink) Execute(ctx context.Context, action domain.LoginAlertAction, token string) error {
+	link, err := uc.signer.Verify(token)
+	if err != nil {
+		return err
+	}
+	if link.Action != action {
+		return domain.ErrInvalidLoginAlertLink
+	}
+
+	switch action {
+	case domain.LoginAlertRevoke:
+		if link.SessionID == "" {
+			return domain.ErrInvalidLoginAlertLink
+		}
+		if err := uc.revoker.RevokeSession(ctx, link.SessionID); err != nil {
+			return err
+		}
+		uc.invalidator.InvalidateKratosSession(link.SessionID)
+	case domain.LoginAlertOptOut:
+		if err := uc.prefs.DisableLoginAlerts(ctx, link.IdentityID); err != nil {
+			return err
+		}
+	default:
+		return domain.ErrInvalidLoginAlertLink
+	}
+
+	uc.logger.InfoContext(ctx, "login alert link applied",
+		"action", string(action),
+		"identity_id", link.IdentityID)
+	return nil
+}
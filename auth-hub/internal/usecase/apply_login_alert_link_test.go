@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockSessionRevoker implements domain.SessionRevoker for testing.
+type mockSessionRevoker struct {
+	revoked []string
+	err     error
+}
+
+func (m *mockSessionRevoker) RevokeSession(_ context.Context, id string) error {
+	m.revoked = append(m.revoked, id)
+	return m.err
+}
+
+func TestApplyLoginAlertLink_Revoke(t *testing.T) {
+	revoker := &mockSessionRevoker{}
+	inv := &mockInvalidator{}
+	uc := NewApplyLoginAlertLink(mockLinkSigner{}, revoker, &mockLoginAlertPrefs{}, inv, slog.Default())
+
+	assert.NoError(t, uc.Execute(context.Background(), domain.LoginAlertRevoke, "revoke|user-1|ks-1"))
+	assert.Equal(t, []string{"ks-1"}, revoker.revoked)
+	assert.Equal(t, []string{"ks-1"}, inv.sessions)
+}
+
+func TestApplyLoginAlertLink_OptOut(t *testing.T) {
+	prefs := &mockLoginAlertPrefs{}
+	revoker := &mockSessionRevoker{}
+	uc := NewApplyLoginAlertLink(mockLinkSigner{}, revoker, prefs, &mockInvalidator{}, slog.Default())
+
+	assert.NoError(t, uc.Execute(context.Background(), domain.LoginAlertOptOut, "opt_out|user-1|"))
+	assert.True(t, prefs.optedOut["user-1"])
+	assert.Empty(t, revoker.revoked)
+}
+
+func TestApplyLoginAlertLink_RejectsInvalidLinks(t *testing.T) {
+	tests := []struct {
+		name   string
+		action domain.LoginAlertAction
+		token  string
+	}{
+		{"malformed", domain.LoginAlertRevoke, "garbage"},
+		{"opt-out link used to revoke", domain.LoginAlertRevoke, "opt_out|user-1|"},
+		{"revoke link used to opt out", domain.LoginAlertOptOut, "revoke|user-1|ks-1"},
+		{"revoke without session", domain.LoginAlertRevoke, "revoke|user-1|"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoker := &mockSessionRevoker{}
+			prefs := &mockLoginAlertPrefs{}
+			uc := NewApplyLoginAlertLink(mockLinkSigner{}, revoker, prefs, &mockInvalidator{}, slog.Default())
+
+			assert.ErrorIs(t, uc.Execute(context.Background(), tt.action, tt.token), domain.ErrInvalidLoginAlertLink)
+			assert.Empty(t, revoker.revoked)
+			assert.Empty(t, prefs.optedOut)
+		})
+	}
+}
+
+func TestApplyLoginAlertLink_RevokeFailureKeepsCache(t *testing.T) {
+	inv := &mockInvalidator{}
+	uc := NewApplyLoginAlertLink(mockLinkSigner{}, &mockSessionRevoker{err: domain.ErrKratosUnavailable}, &mockLoginAlertPrefs{}, inv, slog.Default())
+
+	assert.ErrorIs(t, uc.Execute(context.Background(), domain.LoginAlertRevoke, "revoke|user-1|ks-1"), domain.ErrKratosUnavailable)
+	assert.Empty(t, inv.sessions)
+}
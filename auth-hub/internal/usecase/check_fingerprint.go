@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// FingerprintPolicy configures CheckFingerprint. The prefixes set how much
+// of the client IP goes into the fingerprint, so clients roaming within a
+// carrier or office subnet keep their session.
+type FingerprintPolicy struct {
+	Mode       domain.FingerprintMode
+	IPv4Prefix int
+	IPv6Prefix int
+}
+
+// CheckFingerprint binds a session to the client that first presented it
+// and checks every later use against that binding.
+type CheckFingerprint struct {
+	store     domain.FingerprintStore
+	validator domain.SessionValidator
+	policy    FingerprintPolicy
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewCheckFingerprint creates a new CheckFingerprint usecase.
+func NewCheckFingerprint(s domain.FingerprintStore, v domain.SessionValidator, p FingerprintPolicy, l *slog.Logger) *CheckFingerprint {
+	return &CheckFingerprint{store: s, validator: v, policy: p, logger: l, now: time.Now}
+}
+
+// Execute checks the session identified by cookieValue against client.
+//
+// The first use binds the session. On a mismatch, report mode logs and
+// rebinds to the new client; enforce mode asks Kratos whether the user
+// logged in again after the binding (the step-up flow: the frontend sends
+// the user through a refresh login on ErrStepUpRequired) and rebinds if so,
+// otherwise it returns domain.ErrStepUpRequired.
+func (uc *CheckFingerprint) Execute(ctx context.Context, cookieValue, userID string, client domain.ClientInfo) error {
+	if uc == nil || uc.policy.Mode == domain.FingerprintOff {
+		return nil
+	}
+
+	fp := clientFingerprint(client, uc.policy.IPv4Prefix, uc.policy.IPv6Prefix)
+	now := uc.now()
+	bound, ok := uc.store.Get(cookieValue)
+	if !ok {
+		uc.store.Bind(cookieValue, domain.FingerprintBinding{Fingerprint: fp, BoundAt: now})
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(bound.Fingerprint), []byte(fp)) == 1 {
+		return nil
+	}
+
+	if uc.policy.Mode == domain.FingerprintReport {
+		uc.logger.WarnContext(ctx, "session fingerprint mismatch",
+			"user_id", userID,
+			"mode", string(uc.policy.Mode),
+			"device_id_present", client.DeviceID != "")
+		uc.store.Bind(cookieValue, domain.FingerprintBinding{Fingerprint: fp, BoundAt: now})
+		return nil
+	}
+
+	identity, err := uc.validator.ValidateSession(ctx, fmt.Sprintf("ory_kratos_session=%s", cookieValue))
+	if err != nil {
+		return err
+	}
+	if identity.AuthenticatedAt.After(bound.BoundAt) {
+		uc.logger.InfoContext(ctx, "session rebound after re-authentication", "user_id", userID)
+		uc.store.Bind(cookieValue, domain.FingerprintBinding{Fingerprint: fp, BoundAt: now})
+		return nil
+	}
+
+	uc.logger.WarnContext(ctx, "session fingerprint mismatch, step-up required",
+		"user_id", userID,
+		"mode", string(uc.policy.Mode),
+		"device_id_present", client.DeviceID != "")
+	return domain.ErrStepUpRequired
+}
+
+// clientFingerprint hashes the device ID when the client sends one, and
+// otherwise the User-Agent plus the network the IP belongs to. Only the
+// hash is kept, so bindings never hold raw client addresses.
+func clientFingerprint(c domain.ClientInfo, ipv4Prefix, ipv6Prefix int) string {
+	material := "ua\x00" + c.UserAgent + "\x00net\x00" + clientNetwork(c.IP, ipv4Prefix, ipv6Prefix)
+	if c.DeviceID != "" {
+		material = "device\x00" + c.DeviceID
+	}
+	sum := sha256.Sum256([]byte(material))
+	return hex.EncodeToString(sum[:])
+}
+
+// clientNetwork masks ip to the configured prefix. Unparseable input is
+// returned as-is so it still contributes to the fingerprint.
+func clientNetwork(ip string, ipv4Prefix, ipv6Prefix int) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	addr = addr.Unmap()
+	bits := ipv6Prefix
+	if addr.Is4() {
+		bits = ipv4Prefix
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return addr.String()
+	}
+	return prefix.String()
+}
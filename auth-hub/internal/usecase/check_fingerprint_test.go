@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockFingerprintStore implements domain.FingerprintStore for testing.
+type mockFingerprintStore struct {
+	bindings map[string]domain.FingerprintBinding
+}
+
+func newMockFingerprintStore() *mockFingerprintStore {
+	return &mockFingerprintStore{bindings: make(map[string]domain.FingerprintBinding)}
+}
+
+func (m *mockFingerprintStore) Get(sessionID string) (domain.FingerprintBinding, bool) {
+	b, ok := m.bindings[sessionID]
+	return b, ok
+}
+
+func (m *mockFingerprintStore) Bind(sessionID string, b domain.FingerprintBinding) {
+	m.bindings[sessionID] = b
+}
+
+var (
+	fpTestNow = time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	laptop    = domain.ClientInfo{UserAgent: "Mozilla/5.0 (X11; Linux x86_64) Firefox/140.0", IP: "203.0.113.10"}
+)
+
+func newTestCheckFingerprint(mode domain.FingerprintMode, v domain.SessionValidator) (*CheckFingerprint, *mockFingerprintStore) {
+	store := newMockFingerprintStore()
+	uc := NewCheckFingerprint(store, v, FingerprintPolicy{Mode: mode, IPv4Prefix: 24, IPv6Prefix: 64}, slog.Default())
+	uc.now = func() time.Time { return fpTestNow }
+	return uc, store
+}
+
+func TestCheckFingerprint_BindsOnFirstUseAndToleratesSameSubnet(t *testing.T) {
+	validator := &mockValidator{}
+	uc, store := newTestCheckFingerprint(domain.FingerprintEnforce, validator)
+	ctx := context.Background()
+
+	assert.NoError(t, uc.Execute(ctx, "session-abc", "user-123", laptop))
+	assert.Contains(t, store.bindings, "session-abc")
+
+	roamed := laptop
+	roamed.IP = "203.0.113.77"
+	assert.NoError(t, uc.Execute(ctx, "session-abc", "user-123", roamed), "same /24 keeps the fingerprint")
+	assert.False(t, validator.called, "matching fingerprints never call Kratos")
+}
+
+func TestCheckFingerprint_EnforceRequiresStepUp(t *testing.T) {
+	validator := &mockValidator{identity: &domain.Identity{UserID: "user-123", AuthenticatedAt: fpTestNow.Add(-time.Hour)}}
+	uc, store := newTestCheckFingerprint(domain.FingerprintEnforce, validator)
+	ctx := context.Background()
+
+	assert.NoError(t, uc.Execute(ctx, "session-abc", "user-123", laptop))
+	original := store.bindings["session-abc"]
+
+	stolen := domain.ClientInfo{UserAgent: "curl/8.9.1", IP: "198.51.100.4"}
+	err := uc.Execute(ctx, "session-abc", "user-123", stolen)
+	assert.ErrorIs(t, err, domain.ErrStepUpRequired)
+	assert.Equal(t, "ory_kratos_session=session-abc", validator.cookie)
+	assert.Equal(t, original, store.bindings["session-abc"], "a rejected client must not rebind")
+
+	// The user completes a refresh login from the new client.
+	validator.identity.AuthenticatedAt = fpTestNow.Add(time.Minute)
+	uc.now = func() time.Time { return fpTestNow.Add(2 * time.Minute) }
+	assert.NoError(t, uc.Execute(ctx, "session-abc", "user-123", stolen))
+	assert.NotEqual(t, original.Fingerprint, store.bindings["session-abc"].Fingerprint)
+}
+
+func TestCheckFingerprint_EnforcePropagatesKratosErrors(t *testing.T) {
+	validator := &mockValidator{err: domain.ErrKratosUnavailable}
+	uc, _ := newTestCheckFingerprint(domain.FingerprintEnforce, validator)
+	ctx := context.Background()
+
+	assert.NoError(t, uc.Execute(ctx, "session-abc", "user-123", laptop))
+	err := uc.Execute(ctx, "session-abc", "user-123", domain.ClientInfo{UserAgent: "other", IP: "198.51.100.4"})
+	assert.ErrorIs(t, err, domain.ErrKratosUnavailable)
+}
+
+func TestCheckFingerprint_ReportLogsAndRebinds(t *testing.T) {
+	validator := &mockValidator{}
+	uc, store := newTestCheckFingerprint(domain.FingerprintReport, validator)
+	ctx := context.Background()
+
+	assert.NoError(t, uc.Execute(ctx, "session-abc", "user-123", laptop))
+	first := store.bindings["session-abc"].Fingerprint
+
+	assert.NoError(t, uc.Execute(ctx, "session-abc", "user-123", domain.ClientInfo{UserAgent: "curl/8.9.1", IP: "198.51.100.4"}))
+	assert.NotEqual(t, first, store.bindings["session-abc"].Fingerprint)
+	assert.False(t, validator.called)
+}
+
+func TestCheckFingerprint_OffAndNil(t *testing.T) {
+	uc, store := newTestCheckFingerprint(domain.FingerprintOff, &mockValidator{})
+	assert.NoError(t, uc.Execute(context.Background(), "session-abc", "user-123", laptop))
+	assert.Empty(t, store.bindings)
+
+	var nilUC *CheckFingerprint
+	assert.NoError(t, nilUC.Execute(context.Background(), "session-abc", "user-123", laptop))
+}
+
+func TestClientFingerprint(t *testing.T) {
+	withDevice := laptop
+	withDevice.DeviceID = "device-1"
+	otherNetwork := withDevice
+	otherNetwork.IP = "198.51.100.4"
+	assert.Equal(t, clientFingerprint(withDevice, 24, 64), clientFingerprint(otherNetwork, 24, 64),
+		"a device ID overrides UA and IP")
+
+	v6a := domain.ClientInfo{UserAgent: "ua", IP: "2001:db8:1:2::10"}
+	v6b := domain.ClientInfo{UserAgent: "ua", IP: "2001:db8:1:2:ffff::1"}
+	v6c := domain.ClientInfo{UserAgent: "ua", IP: "2001:db8:1:3::10"}
+	assert.Equal(t, clientFingerprint(v6a, 24, 64), clientFingerprint(v6b, 24, 64))
+	assert.NotEqual(t, clientFingerprint(v6a, 24, 64), clientFingerprint(v6c, 24, 64))
+
+	mapped := domain.ClientInfo{UserAgent: "ua", IP: "::ffff:203.0.113.10"}
+	plain := domain.ClientInfo{UserAgent: "ua", IP: "203.0.113.99"}
+	assert.Equal(t, clientFingerprint(mapped, 24, 64), clientFingerprint(plain, 24, 64))
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"auth-hub/internal/domain"
+)
+
+// PasswordCheckResult is the outcome of evaluating a candidate password.
+type PasswordCheckResult struct {
+	Violations []domain.PasswordViolation
+	// BreachChecked is false when the policy asks for a breach check but the
+	// corpus could not be reached. The password is not rejected for that.
+	BreachChecked bool
+	// HistoryChecked is true when the password was compared against the
+	// signed-in identity's recent passwords.
+	HistoryChecked bool
+}
+
+// Valid reports whether the password satisfied every rule that was checked.
+func (r *PasswordCheckResult) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// CheckPassword evaluates a candidate password against the configured
+// policy, at registration and on password change: Execute for the
+// frontend's advisory check before it submits to Kratos, ExecuteForIdentity
+// for the Kratos webhook that enforces the policy.
+type CheckPassword struct {
+	policy    domain.PasswordPolicy
+	validator domain.SessionValidator
+	breaches  domain.BreachChecker
+	history   domain.PasswordHistory
+	logger    *slog.Logger
+}
+
+// NewCheckPassword creates a new CheckPassword usecase. breaches and history
+// may be nil to skip those checks regardless of the policy.
+func NewCheckPassword(
+	policy domain.PasswordPolicy,
+	v domain.SessionValidator,
+	b domain.BreachChecker,
+	h domain.PasswordHistory,
+	l *slog.Logger,
+) *CheckPassword {
+	return &CheckPassword{policy: policy, validator: v, breaches: b, history: h, logger: l}
+}
+
+// Policy returns the policy passwords are checked against.
+func (uc *CheckPassword) Policy() domain.PasswordPolicy {
+	return uc.policy
+}
+
+// Execute checks password. The reuse check needs an identity, so it runs
+// only when cookie belongs to a valid session (a password change); during
+// registration there is no session and it is skipped. Breach corpus and
+// history lookups fail open: an outage there must not block sign-ups.
+func (uc *CheckPassword) Execute(ctx context.Context, password, cookie string) (*PasswordCheckResult, error) {
+	result := uc.checkRulesAndBreach(ctx, password)
+
+	if uc.policy.HistorySize > 0 && uc.history != nil && cookie != "" {
+		identity, err := uc.validator.ValidateSession(ctx, cookie)
+		switch {
+		case err == nil:
+			uc.checkHistory(ctx, result, identity.UserID, password)
+		// Not signed in (registration with a stale cookie): nothing to compare.
+		case errors.Is(err, domain.ErrAuthFailed) || errors.Is(err, domain.ErrSessionInactive) ||
+			errors.Is(err, domain.ErrSessionExpired) || errors.Is(err, domain.ErrSessionNotFound) ||
+			errors.Is(err, domain.ErrMissingIdentity):
+		default:
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ExecuteForIdentity checks password as Kratos is about to save it for
+// identityID. identityID is empty at registration, where there is no
+// history to compare with. Lookups fail open as in Execute.
+func (uc *CheckPassword) ExecuteForIdentity(ctx context.Context, password, identityID string) *PasswordCheckResult {
+	result := uc.checkRulesAndBreach(ctx, password)
+	if uc.policy.HistorySize > 0 && uc.history != nil && identityID != "" {
+		uc.checkHistory(ctx, result, identityID, password)
+	}
+	return result
+}
+
+// checkRulesAndBreach runs the checks that need only the password.
+func (uc *CheckPassword) checkRulesAndBreach(ctx context.Context, password string) *PasswordCheckResult {
+	result := &PasswordCheckResult{Violations: uc.policy.CheckRules(password)}
+
+	if uc.policy.CheckBreached && uc.breaches != nil {
+		count, err := uc.breaches.BreachCount(ctx, password)
+		switch {
+		case err != nil:
+			uc.logger.WarnContext(ctx, "password breach check unavailable, skipping", "error", err)
+		case count > 0:
+			result.BreachChecked = true
+			result.Violations = append(result.Violations, domain.PasswordViolation{
+				Code:    domain.PasswordBreached,
+				Message: "This password has appeared in a data breach. Choose a different one.",
+			})
+		default:
+			result.BreachChecked = true
+		}
+	}
+	return result
+}
+
+// checkHistory adds a violation to result when password is one of
+// identityID's recent passwords.
+func (uc *CheckPassword) checkHistory(ctx context.Context, result *PasswordCheckResult, identityID, password string) {
+	reused, err := uc.history.UsedRecently(ctx, identityID, password, uc.policy.HistorySize)
+	if err != nil {
+		uc.logger.WarnContext(ctx, "password history unavailable, skipping reuse check",
+			"identity_id", identityID, "error", err)
+		return
+	}
+	result.HistoryChecked = true
+	if reused {
+		result.Violations = append(result.Violations, domain.PasswordViolation{
+			Code:    domain.PasswordRecentlyUsed,
+			Message: "This password was used recently. Choose one you have not used before.",
+		})
+	}
+}
+
+// RecordPasswordHistory adds an identity's new password to its history when
+// Kratos reports a registration or password change.
+type RecordPasswordHistory struct {
+	history domain.PasswordHistory
+	size    int
+	logger  *slog.Logger
+}
+
+// NewRecordPasswordHistory creates a new RecordPasswordHistory usecase that
+// keeps size passwords per identity.
+func NewRecordPasswordHistory(h domain.PasswordHistory, size int, l *slog.Logger) *RecordPasswordHistory {
+	return &RecordPasswordHistory{history: h, size: size, logger: l}
+}
+
+// Execute records the identity's current password. It is a no-op when the
+// policy keeps no history.
+func (uc *RecordPasswordHistory) Execute(ctx context.Context, identityID string) error {
+	if uc.size <= 0 {
+		return nil
+	}
+	if err := uc.history.RecordCurrent(ctx, identityID, uc.size); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to record password history", "identity_id", identityID, "error", err)
+		return err
+	}
+	uc.logger.InfoContext(ctx, "password history recorded", "identity_id", identityID)
+	return nil
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockBreachChecker implements domain.BreachChecker for testing.
+type mockBreachChecker struct {
+	count int
+	err   error
+}
+
+func (m *mockBreachChecker) BreachCount(_ context.Context, _ string) (int, error) {
+	return m.count, m.err
+}
+
+// mockPasswordHistory implements domain.PasswordHistory for testing.
+type mockPasswordHistory struct {
+	used       bool
+	err        error
+	identityID string
+	n          int
+	recorded   []string
+}
+
+func (m *mockPasswordHistory) UsedRecently(_ context.Context, identityID, _ string, n int) (bool, error) {
+	m.identityID = identityID
+	m.n = n
+	return m.used, m.err
+}
+
+func (m *mockPasswordHistory) RecordCurrent(_ context.Context, identityID string, n int) error {
+	m.recorded = append(m.recorded, identityID)
+	m.n = n
+	return m.err
+}
+
+var testPasswordPolicy = domain.PasswordPolicy{
+	MinLength:       12,
+	RequiredClasses: []domain.CharClass{domain.CharClassLower, domain.CharClassUpper, domain.CharClassDigit},
+	CheckBreached:   true,
+	HistorySize:     5,
+}
+
+func violationCodes(r *PasswordCheckResult) []domain.PasswordViolationCode {
+	codes := make([]domain.PasswordViolationCode, 0, len(r.Violations))
+	for _, v := range r.Violations {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func TestCheckPassword_Rules(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     []domain.PasswordViolationCode
+	}{
+		{"valid", "Correct-Horse-42", []domain.PasswordViolationCode{}},
+		{"too short", "Ab1", []domain.PasswordViolationCode{domain.PasswordTooShort}},
+		{"missing classes", "correcthorsebattery", []domain.PasswordViolationCode{domain.PasswordMissingUpper, domain.PasswordMissingDigit}},
+		{"length counts characters", "Pässwörd1ÄÖÜ", []domain.PasswordViolationCode{}},
+		{"over bcrypt limit", "Aa1" + string(make([]byte, 70)), []domain.PasswordViolationCode{domain.PasswordTooLong}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewCheckPassword(testPasswordPolicy, &mockValidator{}, &mockBreachChecker{}, nil, slog.Default())
+
+			result, err := uc.Execute(context.Background(), tt.password, "")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, violationCodes(result))
+			assert.Equal(t, len(tt.want) == 0, result.Valid())
+		})
+	}
+}
+
+func TestCheckPassword_Breached(t *testing.T) {
+	uc := NewCheckPassword(testPasswordPolicy, &mockValidator{}, &mockBreachChecker{count: 3}, nil, slog.Default())
+
+	result, err := uc.Execute(context.Background(), "Correct-Horse-42", "")
+
+	assert.NoError(t, err)
+	assert.True(t, result.BreachChecked)
+	assert.Equal(t, []domain.PasswordViolationCode{domain.PasswordBreached}, violationCodes(result))
+}
+
+func TestCheckPassword_BreachCheckFailsOpen(t *testing.T) {
+	uc := NewCheckPassword(testPasswordPolicy, &mockValidator{}, &mockBreachChecker{err: errors.New("timeout")}, nil, slog.Default())
+
+	result, err := uc.Execute(context.Background(), "Correct-Horse-42", "")
+
+	assert.NoError(t, err)
+	assert.False(t, result.BreachChecked)
+	assert.True(t, result.Valid())
+}
+
+func TestCheckPassword_RecentlyUsed(t *testing.T) {
+	validator := &mockValidator{identity: &domain.Identity{UserID: "user-1"}}
+	history := &mockPasswordHistory{used: true}
+	uc := NewCheckPassword(testPasswordPolicy, validator, &mockBreachChecker{}, history, slog.Default())
+
+	result, err := uc.Execute(context.Background(), "Correct-Horse-42", "ory_kratos_session=abc")
+
+	assert.NoError(t, err)
+	assert.True(t, result.HistoryChecked)
+	assert.Equal(t, []domain.PasswordViolationCode{domain.PasswordRecentlyUsed}, violationCodes(result))
+	assert.Equal(t, "user-1", history.identityID)
+	assert.Equal(t, 5, history.n)
+}
+
+func TestCheckPassword_HistorySkippedWithoutSession(t *testing.T) {
+	tests := []struct {
+		name      string
+		cookie    string
+		validator *mockValidator
+	}{
+		{"no cookie", "", &mockValidator{}},
+		{"stale cookie", "ory_kratos_session=old", &mockValidator{err: domain.ErrAuthFailed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &mockPasswordHistory{used: true}
+			uc := NewCheckPassword(testPasswordPolicy, tt.validator, &mockBreachChecker{}, history, slog.Default())
+
+			result, err := uc.Execute(context.Background(), "Correct-Horse-42", tt.cookie)
+
+			assert.NoError(t, err)
+			assert.False(t, result.HistoryChecked)
+			assert.True(t, result.Valid())
+			assert.Empty(t, history.identityID)
+		})
+	}
+}
+
+func TestCheckPassword_KratosUnavailable(t *testing.T) {
+	validator := &mockValidator{err: domain.ErrKratosUnavailable}
+	uc := NewCheckPassword(testPasswordPolicy, validator, &mockBreachChecker{}, &mockPasswordHistory{}, slog.Default())
+
+	_, err := uc.Execute(context.Background(), "Correct-Horse-42", "ory_kratos_session=abc")
+
+	assert.ErrorIs(t, err, domain.ErrKratosUnavailable)
+}
+
+func TestCheckPassword_ExecuteForIdentity(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		identityID  string
+		history     *mockPasswordHistory
+		want        []domain.PasswordViolationCode
+		wantHistory bool
+	}{
+		{"registration skips history", "Correct-Horse-42", "", &mockPasswordHistory{used: true}, []domain.PasswordViolationCode{}, false},
+		{"settings rejects reuse", "Correct-Horse-42", "user-1", &mockPasswordHistory{used: true}, []domain.PasswordViolationCode{domain.PasswordRecentlyUsed}, true},
+		{"history fails open", "Correct-Horse-42", "user-1", &mockPasswordHistory{err: errors.New("kratos down")}, []domain.PasswordViolationCode{}, false},
+		{"rules apply", "short", "user-1", &mockPasswordHistory{}, []domain.PasswordViolationCode{domain.PasswordTooShort, domain.PasswordMissingUpper, domain.PasswordMissingDigit}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewCheckPassword(testPasswordPolicy, &mockValidator{}, &mockBreachChecker{}, tt.history, slog.Default())
+
+			result := uc.ExecuteForIdentity(context.Background(), tt.password, tt.identityID)
+
+			assert.Equal(t, tt.want, violationCodes(result))
+			assert.Equal(t, tt.wantHistory, result.HistoryChecked)
+			assert.Equal(t, tt.identityID, tt.history.identityID)
+		})
+	}
+}
+
+func TestRecordPasswordHistory(t *testing.T) {
+	history := &mockPasswordHistory{}
+
+	err := NewRecordPasswordHistory(history, 5, slog.Default()).Execute(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-1"}, history.recorded)
+	assert.Equal(t, 5, history.n)
+
+	disabled := &mockPasswordHistory{}
+	err = NewRecordPasswordHistory(disabled, 0, slog.Default()).Execute(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Empty(t, disabled.recorded)
+}
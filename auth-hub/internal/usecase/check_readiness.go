@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// ReadinessCheck is one dependency probed by CheckReadiness.
+type ReadinessCheck struct {
+	Name  string
+	Probe domain.HealthProbe
+	// FailureThreshold is how many consecutive failed probes mark the
+	// dependency not ready, so a single blip does not pull the instance out
+	// of rotation. Values below 1 are treated as 1.
+	FailureThreshold int
+}
+
+// probeState carries a dependency's history across readiness checks.
+type probeState struct {
+	everSucceeded       bool
+	consecutiveFailures int
+	lastError           string
+	lastSuccess         time.Time
+	ready               bool
+}
+
+// CheckReadiness probes every dependency auth-hub needs to validate
+// sessions. A dependency is not ready until it has passed once, and again
+// after FailureThreshold consecutive failures; the instance is ready only
+// when every dependency is.
+type CheckReadiness struct {
+	checks  []ReadinessCheck
+	timeout time.Duration
+	logger  *slog.Logger
+	now     func() time.Time
+
+	// mu serializes checks so concurrent /ready requests do not double
+	// count a failure.
+	mu    sync.Mutex
+	state map[string]*probeState
+}
+
+// NewCheckReadiness creates a readiness check. timeout bounds each probe.
+func NewCheckReadiness(checks []ReadinessCheck, timeout time.Duration, l *slog.Logger) *CheckReadiness {
+	state := make(map[string]*probeState, len(checks))
+	for _, c := range checks {
+		state[c.Name] = &probeState{}
+	}
+	return &CheckReadiness{checks: checks, timeout: timeout, logger: l, now: time.Now, state: state}
+}
+
+// probeResult is one probe outcome, collected before state is updated.
+type probeResult struct {
+	err     error
+	latency time.Duration
+}
+
+// Execute probes all dependencies concurrently and returns the report.
+func (uc *CheckReadiness) Execute(ctx context.Context) domain.ReadinessReport {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	results := make([]probeResult, len(uc.checks))
+	var wg sync.WaitGroup
+	for i, c := range uc.checks {
+		wg.Go(func() {
+			probeCtx, cancel := context.WithTimeout(ctx, uc.timeout)
+			defer cancel()
+			start := uc.now()
+			err := c.Probe.Probe(probeCtx)
+			results[i] = probeResult{err: err, latency: uc.now().Sub(start)}
+		})
+	}
+	wg.Wait()
+
+	report := domain.ReadinessReport{Ready: true, CheckedAt: uc.now()}
+	for i, c := range uc.checks {
+		status := uc.record(ctx, c, results[i], report.CheckedAt)
+		report.Dependencies = append(report.Dependencies, status)
+		if !status.Ready {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+// record folds one probe result into the dependency's state and logs
+// readiness transitions.
+func (uc *CheckReadiness) record(ctx context.Context, c ReadinessCheck, res probeResult, at time.Time) domain.DependencyStatus {
+	threshold := max(c.FailureThreshold, 1)
+	st := uc.state[c.Name]
+	wasReady := st.ready
+
+	if res.err == nil {
+		st.everSucceeded = true
+		st.consecutiveFailures = 0
+		st.lastError = ""
+		st.lastSuccess = at
+	} else {
+		st.consecutiveFailures++
+		st.lastError = res.err.Error()
+	}
+	st.ready = st.everSucceeded && st.consecutiveFailures < threshold
+
+	switch {
+	case wasReady && !st.ready:
+		uc.logger.WarnContext(ctx, "dependency not ready",
+			"dependency", c.Name,
+			"consecutive_failures", st.consecutiveFailures,
+			"error", st.lastError)
+	case !wasReady && st.ready:
+		uc.logger.InfoContext(ctx, "dependency ready", "dependency", c.Name)
+	}
+
+	return domain.DependencyStatus{
+		Name:                c.Name,
+		Ready:               st.ready,
+		LastProbeOK:         res.err == nil,
+		ConsecutiveFailures: st.consecutiveFailures,
+		FailureThreshold:    threshold,
+		LastError:           st.lastError,
+		LastSuccess:         st.lastSuccess,
+		Latency:             res.latency,
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProbe implements domain.HealthProbe for testing.
+type fakeProbe struct {
+	err error
+}
+
+func (p *fakeProbe) Probe(context.Context) error { return p.err }
+
+// blockingProbe waits for its context, like a dependency that never answers.
+type blockingProbe struct{}
+
+func (blockingProbe) Probe(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCheckReadiness_NotReadyUntilFirstSuccess(t *testing.T) {
+	kratos := &fakeProbe{err: domain.ErrKratosUnavailable}
+	uc := NewCheckReadiness([]ReadinessCheck{
+		{Name: domain.DependencyKratos, Probe: kratos, FailureThreshold: 3},
+	}, time.Second, slog.Default())
+
+	report := uc.Execute(context.Background())
+	assert.False(t, report.Ready, "a dependency that never passed is not ready, whatever the threshold")
+	require.Len(t, report.Dependencies, 1)
+	assert.Equal(t, 1, report.Dependencies[0].ConsecutiveFailures)
+	assert.Contains(t, report.Dependencies[0].LastError, "identity provider unavailable")
+
+	kratos.err = nil
+	report = uc.Execute(context.Background())
+	assert.True(t, report.Ready)
+	assert.Zero(t, report.Dependencies[0].ConsecutiveFailures)
+	assert.Empty(t, report.Dependencies[0].LastError)
+	assert.False(t, report.Dependencies[0].LastSuccess.IsZero())
+}
+
+func TestCheckReadiness_FailureThreshold(t *testing.T) {
+	kratos := &fakeProbe{}
+	cache := &fakeProbe{}
+	uc := NewCheckReadiness([]ReadinessCheck{
+		{Name: domain.DependencyKratos, Probe: kratos, FailureThreshold: 3},
+		{Name: domain.DependencySessionCache, Probe: cache, FailureThreshold: 1},
+	}, time.Second, slog.Default())
+	require.True(t, uc.Execute(context.Background()).Ready)
+
+	kratos.err = errors.New("connection refused")
+	for i := 1; i < 3; i++ {
+		report := uc.Execute(context.Background())
+		assert.True(t, report.Ready, "failure %d is under the threshold", i)
+		assert.True(t, report.Dependencies[0].Ready)
+		assert.False(t, report.Dependencies[0].LastProbeOK)
+		assert.Equal(t, i, report.Dependencies[0].ConsecutiveFailures)
+	}
+	report := uc.Execute(context.Background())
+	assert.False(t, report.Ready)
+	assert.False(t, report.Dependencies[0].Ready)
+	assert.True(t, report.Dependencies[1].Ready)
+
+	kratos.err = nil
+	cache.err = domain.ErrCacheUnavailable
+	report = uc.Execute(context.Background())
+	assert.False(t, report.Ready, "a threshold of 1 fails on the first error")
+	assert.True(t, report.Dependencies[0].Ready)
+	assert.False(t, report.Dependencies[1].Ready)
+}
+
+func TestCheckReadiness_ProbeTimeout(t *testing.T) {
+	uc := NewCheckReadiness([]ReadinessCheck{
+		{Name: domain.DependencyKratos, Probe: blockingProbe{}, FailureThreshold: 1},
+	}, 20*time.Millisecond, slog.Default())
+
+	start := time.Now()
+	report := uc.Execute(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, report.Ready)
+	assert.Contains(t, report.Dependencies[0].LastError, "deadline exceeded")
+}
+
+func TestCheckReadiness_ThresholdBelowOneIsOne(t *testing.T) {
+	uc := NewCheckReadiness([]ReadinessCheck{
+		{Name: domain.DependencySigningKey, Probe: &fakeProbe{}},
+	}, time.Second, slog.Default())
+
+	report := uc.Execute(context.Background())
+	assert.True(t, report.Ready)
+	assert.Equal(t, 1, report.Dependencies[0].FailureThreshold)
+}
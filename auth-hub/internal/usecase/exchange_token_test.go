@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockServiceTokenIssuer implements domain.ServiceTokenIssuer for testing.
+type mockServiceTokenIssuer struct {
+	verified   *domain.VerifiedToken
+	verifyErr  error
+	issueErr   error
+	requests   []domain.ServiceTokenRequest
+	identities []domain.Identity
+}
+
+func (m *mockServiceTokenIssuer) IssueServiceToken(identity *domain.Identity, _ string, req domain.ServiceTokenRequest) (*domain.ServiceToken, error) {
+	m.requests = append(m.requests, req)
+	m.identities = append(m.identities, *identity)
+	if m.issueErr != nil {
+		return nil, m.issueErr
+	}
+	expiresAt := time.Date(2026, 10, 16, 12, 2, 0, 0, time.UTC)
+	if !req.NotAfter.IsZero() && req.NotAfter.Before(expiresAt) {
+		expiresAt = req.NotAfter
+	}
+	return &domain.ServiceToken{Audience: req.Audience, Token: "token-for-" + req.Audience, Scopes: req.Scopes, ExpiresAt: expiresAt}, nil
+}
+
+func (m *mockServiceTokenIssuer) VerifyToken(_ string) (*domain.VerifiedToken, error) {
+	return m.verified, m.verifyErr
+}
+
+func newTestExchangeToken(issuer *mockServiceTokenIssuer) *ExchangeToken {
+	uc := NewExchangeToken(issuer, slog.Default())
+	uc.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	return uc
+}
+
+func validExchangeRequest() TokenExchangeRequest {
+	return TokenExchangeRequest{
+		GrantType:        domain.GrantTypeExchange,
+		SubjectToken:     "subject-jwt",
+		SubjectTokenType: domain.TokenTypeJWT,
+		Audience:         "rag-orchestrator",
+	}
+}
+
+func TestExchangeToken_Delegates(t *testing.T) {
+	subjectExpiry := time.Date(2026, 10, 16, 12, 1, 0, 0, time.UTC)
+	issuer := &mockServiceTokenIssuer{verified: &domain.VerifiedToken{
+		Identity:  domain.Identity{UserID: "user-123"},
+		SessionID: "session-abc",
+		Audience:  "alt-backend",
+		Actors:    []string{"alt-frontend-sv"},
+		ExpiresAt: subjectExpiry,
+	}}
+
+	req := validExchangeRequest()
+	req.Scope = "rag.query"
+	result, err := newTestExchangeToken(issuer).Execute(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token-for-rag-orchestrator", result.AccessToken)
+	assert.Equal(t, domain.TokenTypeJWT, result.IssuedTokenType)
+	assert.Equal(t, int64(60), result.ExpiresIn, "capped at the subject token's expiry")
+	assert.Equal(t, "rag.query", result.Scope)
+
+	assert.Len(t, issuer.requests, 1)
+	assert.Equal(t, []string{"alt-backend", "alt-frontend-sv"}, issuer.requests[0].Actors, "presenting service becomes the current actor")
+	assert.Equal(t, []string{"rag.query"}, issuer.requests[0].Scopes)
+	assert.Equal(t, subjectExpiry, issuer.requests[0].NotAfter)
+}
+
+func TestExchangeToken_RejectsMalformedRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*TokenExchangeRequest)
+		wantErr error
+	}{
+		{"wrong grant type", func(r *TokenExchangeRequest) { r.GrantType = "client_credentials" }, domain.ErrUnsupportedGrantType},
+		{"missing subject token", func(r *TokenExchangeRequest) { r.SubjectToken = "" }, domain.ErrInvalidTokenRequest},
+		{"unsupported subject token type", func(r *TokenExchangeRequest) { r.SubjectTokenType = "urn:ietf:params:oauth:token-type:saml2" }, domain.ErrInvalidTokenRequest},
+		{"unsupported requested token type", func(r *TokenExchangeRequest) { r.RequestedTokenType = "urn:ietf:params:oauth:token-type:refresh_token" }, domain.ErrInvalidTokenRequest},
+		{"missing audience", func(r *TokenExchangeRequest) { r.Audience = "" }, domain.ErrUnknownAudience},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := &mockServiceTokenIssuer{}
+			req := validExchangeRequest()
+			tt.mutate(&req)
+
+			_, err := newTestExchangeToken(issuer).Execute(context.Background(), req)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, issuer.requests, "nothing is issued")
+		})
+	}
+}
+
+func TestExchangeToken_PropagatesIssuerErrors(t *testing.T) {
+	issuer := &mockServiceTokenIssuer{verifyErr: domain.ErrInvalidSubjectToken}
+	_, err := newTestExchangeToken(issuer).Execute(context.Background(), validExchangeRequest())
+	assert.ErrorIs(t, err, domain.ErrInvalidSubjectToken)
+
+	issuer = &mockServiceTokenIssuer{
+		verified: &domain.VerifiedToken{Identity: domain.Identity{UserID: "user-123"}, Audience: "alt-backend"},
+		issueErr: domain.ErrInvalidScope,
+	}
+	_, err = newTestExchangeToken(issuer).Execute(context.Background(), validExchangeRequest())
+	assert.ErrorIs(t, err, domain.ErrInvalidScope)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"auth-hub/internal/domain"
@@ -14,18 +15,25 @@ type SessionResult struct {
 	UserID       string
 	TenantID     string
 	Email        string
+	Name         string
 	Role         string
 	SessionID    string
 	CreatedAt    time.Time
 	BackendToken string
+	// ServiceTokens holds the audience-scoped tokens requested alongside
+	// the backend token, in request order.
+	ServiceTokens []domain.ServiceToken
 }
 
 // GetSession orchestrates session retrieval with JWT generation for frontend consumption.
 type GetSession struct {
-	validator domain.SessionValidator
-	cache     domain.SessionCache
-	token     domain.TokenIssuer
-	logger    *slog.Logger
+	validator  domain.SessionValidator
+	cache      domain.SessionCache
+	identities domain.IdentityCache
+	traits     domain.IdentityTraitsProvider
+	token      domain.TokenIssuer
+	services   domain.ServiceTokenIssuer
+	logger     *slog.Logger
 }
 
 // NewGetSession creates a new GetSession usecase.
@@ -33,49 +41,37 @@ func NewGetSession(v domain.SessionValidator, c domain.SessionCache, t domain.To
 	return &GetSession{validator: v, cache: c, token: t, logger: l}
 }
 
-// Execute validates the session and generates a backend JWT token.
-func (uc *GetSession) Execute(ctx context.Context, cookieValue string) (*SessionResult, error) {
-	var identity *domain.Identity
-	var tenantID string
-	var createdAt time.Time
+// WithServiceTokens enables issuing audience-scoped service tokens from
+// ExecuteFor. Without it, requesting any audience fails with
+// domain.ErrUnknownAudience.
+func (uc *GetSession) WithServiceTokens(s domain.ServiceTokenIssuer) *GetSession {
+	uc.services = s
+	return uc
+}
 
-	var role string
+// WithIdentityCache takes identity traits from c instead of the cached
+// session, like ValidateSession.WithIdentityCache.
+func (uc *GetSession) WithIdentityCache(c domain.IdentityCache, p domain.IdentityTraitsProvider) *GetSession {
+	uc.identities = c
+	uc.traits = p
+	return uc
+}
 
-	// Check cache first
-	if cached, found := uc.cache.Get(cookieValue); found {
-		identity = &domain.Identity{
-			UserID:    cached.UserID,
-			TenantID:  cached.TenantID,
-			Email:     cached.Email,
-			Role:      cached.Role,
-			SessionID: cookieValue,
-		}
-		tenantID = cached.TenantID
-		role = cached.Role
-		createdAt = cached.CreatedAt
-	} else {
-		// Cache miss – validate with Kratos
-		fullCookie := fmt.Sprintf("ory_kratos_session=%s", cookieValue)
-		kratosIdentity, err := uc.validator.ValidateSession(ctx, fullCookie)
-		if err != nil {
-			return nil, err
-		}
+// Execute validates the session and generates a backend JWT token.
+func (uc *GetSession) Execute(ctx context.Context, cookieValue string) (*SessionResult, error) {
+	return uc.ExecuteFor(ctx, cookieValue, nil)
+}
 
-		identity = kratosIdentity
-		identity.SessionID = cookieValue
-		tenantID = identity.UserID // Single-tenant: tenant == user
-		identity.TenantID = tenantID
-		role = identity.Role
-		createdAt = identity.CreatedAt
+// ExecuteFor is Execute plus one service token per audience in audiences.
+func (uc *GetSession) ExecuteFor(ctx context.Context, cookieValue string, audiences []string) (*SessionResult, error) {
+	cached, err := uc.cache.GetOrLoad(ctx, cookieValue, kratosSessionLoader(uc.validator, cookieValue, uc.identities))
+	if err != nil {
+		return nil, err
+	}
 
-		// Populate cache
-		uc.cache.Set(cookieValue, domain.CachedSession{
-			UserID:    identity.UserID,
-			TenantID:  tenantID,
-			Email:     identity.Email,
-			Role:      identity.Role,
-			CreatedAt: identity.CreatedAt,
-		})
+	identity, err := sessionIdentity(ctx, uc.identities, uc.traits, cookieValue, cached)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate backend JWT
@@ -85,17 +81,48 @@ func (uc *GetSession) Execute(ctx context.Context, cookieValue string) (*Session
 		return nil, fmt.Errorf("%w: %w", domain.ErrTokenGeneration, err)
 	}
 
+	var serviceTokens []domain.ServiceToken
+	if len(audiences) > 0 {
+		if uc.services == nil {
+			return nil, fmt.Errorf("%w: service tokens are not enabled", domain.ErrUnknownAudience)
+		}
+		serviceTokens, err = issueSessionServiceTokens(uc.services, identity, cookieValue, audiences)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	role := identity.Role
 	if role == "" {
 		role = "user"
 	}
 
 	return &SessionResult{
-		UserID:       identity.UserID,
-		TenantID:     tenantID,
-		Email:        identity.Email,
-		Role:         role,
-		SessionID:    cookieValue,
-		CreatedAt:    createdAt,
-		BackendToken: backendToken,
+		UserID:        identity.UserID,
+		TenantID:      identity.TenantID,
+		Email:         identity.Email,
+		Name:          identity.Name,
+		Role:          role,
+		SessionID:     cookieValue,
+		CreatedAt:     identity.CreatedAt,
+		BackendToken:  backendToken,
+		ServiceTokens: serviceTokens,
 	}, nil
 }
+
+// issueSessionServiceTokens mints one token per requested audience for a
+// session. Repeated audiences are issued once.
+func issueSessionServiceTokens(issuer domain.ServiceTokenIssuer, identity *domain.Identity, sessionID string, audiences []string) ([]domain.ServiceToken, error) {
+	tokens := make([]domain.ServiceToken, 0, len(audiences))
+	for i, aud := range audiences {
+		if slices.Contains(audiences[:i], aud) {
+			continue
+		}
+		t, err := issuer.IssueServiceToken(identity, sessionID, domain.ServiceTokenRequest{Audience: aud})
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *t)
+	}
+	return tokens, nil
+}
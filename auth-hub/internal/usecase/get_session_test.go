@@ -173,3 +173,49 @@ func TestGetSession_TokenGenerationError(t *testing.T) {
 	assert.Nil(t, result)
 	assert.True(t, errors.Is(err, domain.ErrTokenGeneration))
 }
+
+func TestGetSession_ServiceTokens(t *testing.T) {
+	cache := newMockCache()
+	cache.Set("session-abc", domain.CachedSession{UserID: "user-123"})
+	services := &mockServiceTokenIssuer{}
+
+	uc := NewGetSession(&mockValidator{}, cache, &mockTokenIssuer{token: "jwt-token-123"}, slog.Default()).
+		WithServiceTokens(services)
+	result, err := uc.ExecuteFor(context.Background(), "session-abc", []string{"rag-orchestrator", "tts", "rag-orchestrator"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "jwt-token-123", result.BackendToken)
+	assert.Len(t, result.ServiceTokens, 2, "repeated audiences are issued once")
+	assert.Equal(t, "token-for-rag-orchestrator", result.ServiceTokens[0].Token)
+	assert.Equal(t, "token-for-tts", result.ServiceTokens[1].Token)
+	assert.Empty(t, services.requests[0].Actors, "session tokens are not delegated")
+}
+
+func TestGetSession_ServiceTokensDisabled(t *testing.T) {
+	cache := newMockCache()
+	cache.Set("session-abc", domain.CachedSession{UserID: "user-123"})
+
+	uc := NewGetSession(&mockValidator{}, cache, &mockTokenIssuer{token: "jwt-token-123"}, slog.Default())
+	_, err := uc.ExecuteFor(context.Background(), "session-abc", []string{"tts"})
+	assert.ErrorIs(t, err, domain.ErrUnknownAudience)
+
+	result, err := uc.Execute(context.Background(), "session-abc")
+	assert.NoError(t, err)
+	assert.Empty(t, result.ServiceTokens)
+}
+
+func TestGetSession_IdentityCacheTraits(t *testing.T) {
+	cache := newMockCache()
+	cache.Set("session-abc", domain.CachedSession{UserID: "user-1", TenantID: "user-1", Email: "old@example.com"})
+	identities := newMockIdentityCache()
+	identities.traits["user-1"] = domain.IdentityTraits{Email: "new@example.com", Name: "Ada Lovelace", Role: "admin"}
+
+	uc := NewGetSession(&mockValidator{}, cache, &mockTokenIssuer{token: "jwt"}, slog.Default()).
+		WithIdentityCache(identities, &mockTraitsProvider{})
+	result, err := uc.Execute(context.Background(), "session-abc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", result.Email)
+	assert.Equal(t, "Ada Lovelace", result.Name)
+	assert.Equal(t, "admin", result.Role)
+}
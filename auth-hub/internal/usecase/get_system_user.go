@@ -10,6 +10,7 @@ import (
 // GetSystemUser retrieves the system user ID from the identity provider.
 type GetSystemUser struct {
 	provider domain.IdentityProvider
+	cache    domain.IdentityCache
 	logger   *slog.Logger
 }
 
@@ -18,9 +19,22 @@ func NewGetSystemUser(p domain.IdentityProvider, l *slog.Logger) *GetSystemUser
 	return &GetSystemUser{provider: p, logger: l}
 }
 
+// WithCache keeps the system user ID in c, so internal services do not list
+// identities through the admin API on every call.
+func (uc *GetSystemUser) WithCache(c domain.IdentityCache) *GetSystemUser {
+	uc.cache = c
+	return uc
+}
+
 // Execute fetches the first identity ID for internal service operations.
 func (uc *GetSystemUser) Execute(ctx context.Context) (string, error) {
-	userID, err := uc.provider.GetFirstIdentityID(ctx)
+	var userID string
+	var err error
+	if uc.cache != nil {
+		userID, err = uc.cache.GetOrLoadSystemUser(ctx, uc.provider.GetFirstIdentityID)
+	} else {
+		userID, err = uc.provider.GetFirstIdentityID(ctx)
+	}
 	if err != nil {
 		uc.logger.ErrorContext(ctx, "failed to fetch system user", "error", err)
 		return "", err
@@ -41,3 +41,26 @@ func TestGetSystemUser_ProviderError(t *testing.T) {
 	assert.Empty(t, userID)
 	assert.Contains(t, err.Error(), "admin API not configured")
 }
+
+// countingIdentityProvider counts GetFirstIdentityID calls.
+type countingIdentityProvider struct {
+	mockIdentityProvider
+	calls int
+}
+
+func (m *countingIdentityProvider) GetFirstIdentityID(ctx context.Context) (string, error) {
+	m.calls++
+	return m.mockIdentityProvider.GetFirstIdentityID(ctx)
+}
+
+func TestGetSystemUser_Cached(t *testing.T) {
+	provider := &countingIdentityProvider{mockIdentityProvider: mockIdentityProvider{userID: "system-user-001"}}
+
+	uc := NewGetSystemUser(provider, slog.Default()).WithCache(newMockIdentityCache())
+	for range 3 {
+		userID, err := uc.Execute(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "system-user-001", userID)
+	}
+	assert.Equal(t, 1, provider.calls)
+}
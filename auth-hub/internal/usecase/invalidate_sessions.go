@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"auth-hub/internal/domain"
+)
+
+// InvalidateSessions evicts cached sessions when Kratos reports a session or
+// identity lifecycle change, so logouts and trait changes take effect
+// immediately instead of after the cache TTL.
+type InvalidateSessions struct {
+	invalidator domain.SessionInvalidator
+	identities  domain.IdentityCache
+	logger      *slog.Logger
+}
+
+// NewInvalidateSessions creates a new InvalidateSessions usecase.
+func NewInvalidateSessions(i domain.SessionInvalidator, l *slog.Logger) *InvalidateSessions {
+	return &InvalidateSessions{invalidator: i, logger: l}
+}
+
+// WithIdentityCache also invalidates identity traits. An identity update
+// then drops only the identity's traits: its sessions stay valid and pick up
+// the new traits on their next validation.
+func (uc *InvalidateSessions) WithIdentityCache(c domain.IdentityCache) *InvalidateSessions {
+	uc.identities = c
+	return uc
+}
+
+// Execute applies event to the cache and returns the number of entries
+// removed. A logout carrying a session ID drops only that session; a login
+// drops nothing; an identity update with an identity cache drops only the
+// identity's traits; every other event drops all sessions of the identity,
+// and its traits.
+func (uc *InvalidateSessions) Execute(ctx context.Context, event domain.LifecycleEvent) (int, error) {
+	if err := event.Validate(); err != nil {
+		return 0, err
+	}
+	if event.Type == domain.LifecycleLogin {
+		return 0, nil
+	}
+
+	var removed int
+	switch {
+	case event.Type == domain.LifecycleLogout && event.SessionID != "":
+		removed = uc.invalidator.InvalidateKratosSession(event.SessionID)
+	case event.Type == domain.LifecycleIdentityUpdated && uc.identities != nil:
+		removed = uc.identities.InvalidateTraits(event.IdentityID)
+	default:
+		removed = uc.invalidator.InvalidateIdentity(event.IdentityID)
+		if uc.identities != nil {
+			removed += uc.identities.InvalidateTraits(event.IdentityID)
+		}
+	}
+
+	uc.logger.InfoContext(ctx, "sessions invalidated",
+		"event", string(event.Type),
+		"identity_id", event.IdentityID,
+		"invalidated", removed)
+	return removed, nil
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockInvalidator implements domain.SessionInvalidator for testing.
+type mockInvalidator struct {
+	sessions   []string
+	identities []string
+}
+
+func (m *mockInvalidator) InvalidateKratosSession(id string) int {
+	m.sessions = append(m.sessions, id)
+	return 1
+}
+
+func (m *mockInvalidator) InvalidateIdentity(id string) int {
+	m.identities = append(m.identities, id)
+	return 2
+}
+
+func TestInvalidateSessions_LogoutWithSessionID(t *testing.T) {
+	inv := &mockInvalidator{}
+	uc := NewInvalidateSessions(inv, slog.Default())
+
+	n, err := uc.Execute(context.Background(), domain.LifecycleEvent{
+		Type:       domain.LifecycleLogout,
+		IdentityID: "user-1",
+		SessionID:  "ks-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"ks-1"}, inv.sessions)
+	assert.Empty(t, inv.identities)
+}
+
+func TestInvalidateSessions_IdentityWide(t *testing.T) {
+	tests := []struct {
+		name  string
+		event domain.LifecycleEvent
+	}{
+		{"logout without session", domain.LifecycleEvent{Type: domain.LifecycleLogout, IdentityID: "user-1"}},
+		{"identity updated", domain.LifecycleEvent{Type: domain.LifecycleIdentityUpdated, IdentityID: "user-1"}},
+		{"identity deactivated", domain.LifecycleEvent{Type: domain.LifecycleIdentityDeactivated, IdentityID: "user-1", SessionID: "ks-1"}},
+		{"password changed", domain.LifecycleEvent{Type: domain.LifecyclePasswordChanged, IdentityID: "user-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &mockInvalidator{}
+			uc := NewInvalidateSessions(inv, slog.Default())
+
+			n, err := uc.Execute(context.Background(), tt.event)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 2, n)
+			assert.Equal(t, []string{"user-1"}, inv.identities)
+			assert.Empty(t, inv.sessions)
+		})
+	}
+}
+
+func TestInvalidateSessions_LoginEvictsNothing(t *testing.T) {
+	inv := &mockInvalidator{}
+	uc := NewInvalidateSessions(inv, slog.Default())
+
+	n, err := uc.Execute(context.Background(), domain.LifecycleEvent{
+		Type:       domain.LifecycleLogin,
+		IdentityID: "user-1",
+		SessionID:  "ks-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Empty(t, inv.sessions)
+	assert.Empty(t, inv.identities)
+}
+
+func TestInvalidateSessions_InvalidEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event domain.LifecycleEvent
+	}{
+		{"unknown type", domain.LifecycleEvent{Type: "identity.created", IdentityID: "user-1"}},
+		{"logout without ids", domain.LifecycleEvent{Type: domain.LifecycleLogout}},
+		{"update without identity", domain.LifecycleEvent{Type: domain.LifecycleIdentityUpdated, SessionID: "ks-1"}},
+		{"login without session", domain.LifecycleEvent{Type: domain.LifecycleLogin, IdentityID: "user-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &mockInvalidator{}
+			uc := NewInvalidateSessions(inv, slog.Default())
+
+			n, err := uc.Execute(context.Background(), tt.event)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidLifecycleEvent)
+			assert.Zero(t, n)
+			assert.Empty(t, inv.sessions)
+			assert.Empty(t, inv.identities)
+		})
+	}
+}
+
+func TestInvalidateSessions_IdentityCache(t *testing.T) {
+	t.Run("identity updated drops only traits", func(t *testing.T) {
+		inv := &mockInvalidator{}
+		identities := newMockIdentityCache()
+		identities.traits["user-1"] = domain.IdentityTraits{Role: "admin"}
+		uc := NewInvalidateSessions(inv, slog.Default()).WithIdentityCache(identities)
+
+		n, err := uc.Execute(context.Background(), domain.LifecycleEvent{Type: domain.LifecycleIdentityUpdated, IdentityID: "user-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Empty(t, inv.identities, "sessions stay cached")
+		assert.Equal(t, []string{"user-1"}, identities.invalidated)
+	})
+
+	t.Run("deactivation drops sessions and traits", func(t *testing.T) {
+		inv := &mockInvalidator{}
+		identities := newMockIdentityCache()
+		identities.traits["user-1"] = domain.IdentityTraits{Role: "admin"}
+		uc := NewInvalidateSessions(inv, slog.Default()).WithIdentityCache(identities)
+
+		n, err := uc.Execute(context.Background(), domain.LifecycleEvent{Type: domain.LifecycleIdentityDeactivated, IdentityID: "user-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Equal(t, []string{"user-1"}, inv.identities)
+		assert.Equal(t, []string{"user-1"}, identities.invalidated)
+	})
+}
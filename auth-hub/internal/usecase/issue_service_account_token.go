@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// ClientCredentialsRequest is an RFC 6749 section 4.4 token request from a
+// service account.
+type ClientCredentialsRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	// Audience may be left empty when the account has a single grant.
+	Audience string
+	// Scope is a space-separated narrowing of the account's scopes for the
+	// audience.
+	Scope string
+	// ClientIP is the address the request came from, checked against the
+	// account's allowlist.
+	ClientIP string
+}
+
+// ClientCredentialsResult is the RFC 6749 section 5.1 token response.
+type ClientCredentialsResult struct {
+	AccessToken string
+	ExpiresIn   int64
+	Scope       string
+}
+
+// IssueServiceAccountToken authenticates a service account by its client
+// secret and issues it a short-lived token for one of its audiences. The
+// token's subject is the account ID and its role domain.ServiceRole.
+type IssueServiceAccountToken struct {
+	store  domain.ServiceAccountStore
+	issuer domain.ServiceTokenIssuer
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewIssueServiceAccountToken creates a new IssueServiceAccountToken usecase.
+func NewIssueServiceAccountToken(s domain.ServiceAccountStore, i domain.ServiceTokenIssuer, l *slog.Logger) *IssueServiceAccountToken {
+	return &IssueServiceAccountToken{store: s, issuer: i, logger: l, now: time.Now}
+}
+
+// Execute checks the client credentials, source address, audience and
+// scopes, issues the token and records the use on the account. Failing to
+// record usage is logged but does not fail the request.
+func (uc *IssueServiceAccountToken) Execute(ctx context.Context, req ClientCredentialsRequest) (*ClientCredentialsResult, error) {
+	if req.GrantType != domain.GrantTypeClientCredentials {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedGrantType, req.GrantType)
+	}
+	if req.ClientID == "" || req.ClientSecret == "" {
+		return nil, fmt.Errorf("%w: client_id and client_secret are required", domain.ErrInvalidClient)
+	}
+
+	account, err := uc.store.Get(ctx, req.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidClient, err)
+	}
+	if account.Disabled() {
+		return nil, fmt.Errorf("%w: account %s is disabled", domain.ErrInvalidClient, account.ID)
+	}
+	now := uc.now()
+	credID, ok := matchCredential(account.Credentials, req.ClientSecret, now)
+	if !ok {
+		return nil, fmt.Errorf("%w: secret does not match an active credential of %s", domain.ErrInvalidClient, account.ID)
+	}
+
+	ip, err := netip.ParseAddr(req.ClientIP)
+	if err != nil || !account.AllowsIP(ip) {
+		return nil, fmt.Errorf("%w: %q for %s", domain.ErrClientIPForbidden, req.ClientIP, account.ID)
+	}
+
+	audience := req.Audience
+	if audience == "" && len(account.Grants) == 1 {
+		audience = account.Grants[0].Audience
+	}
+	grant, ok := account.Grant(audience)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not granted to %s", domain.ErrUnknownAudience, audience, account.ID)
+	}
+	scopes := grant.Scopes
+	if req.Scope != "" {
+		scopes = strings.Fields(req.Scope)
+		for _, s := range scopes {
+			if !slices.Contains(grant.Scopes, s) {
+				return nil, fmt.Errorf("%w: %q for %q", domain.ErrInvalidScope, s, audience)
+			}
+		}
+	}
+	if scopes == nil {
+		// An empty, non-nil list asks the issuer for no scopes rather than
+		// every scope of the audience.
+		scopes = []string{}
+	}
+
+	issued, err := uc.issuer.IssueServiceToken(
+		&domain.Identity{UserID: account.ID, Role: domain.ServiceRole}, "",
+		domain.ServiceTokenRequest{
+			Audience: audience,
+			Scopes:   scopes,
+			NotAfter: now.Add(account.TokenTTL),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.recordUsage(ctx, account.ID, credID, ip.String(), audience, now)
+	uc.logger.InfoContext(ctx, "service account token issued",
+		"service_account_id", account.ID,
+		"name", account.Name,
+		"audience", issued.Audience,
+		"credential_id", credID,
+		"client_ip", ip.String())
+
+	return &ClientCredentialsResult{
+		AccessToken: issued.Token,
+		ExpiresIn:   max(int64(issued.ExpiresAt.Sub(now).Seconds()), 0),
+		Scope:       strings.Join(issued.Scopes, " "),
+	}, nil
+}
+
+func (uc *IssueServiceAccountToken) recordUsage(ctx context.Context, accountID, credID, ip, audience string, now time.Time) {
+	_, err := uc.store.Update(ctx, accountID, func(a *domain.ServiceAccount) error {
+		a.Usage.TokensIssued++
+		a.Usage.LastUsedAt = &now
+		a.Usage.LastUsedIP = ip
+		a.Usage.LastAudience = audience
+		for i := range a.Credentials {
+			if a.Credentials[i].ID == credID {
+				a.Credentials[i].LastUsedAt = &now
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		uc.logger.WarnContext(ctx, "failed to record service account usage",
+			"service_account_id", accountID, "error", err)
+	}
+}
+
+// matchCredential returns the ID of the active credential whose hash
+// matches secret. Every active credential is compared, in constant time.
+func matchCredential(creds []domain.ServiceCredential, secret string, now time.Time) (string, bool) {
+	hash := []byte(hashClientSecret(secret))
+	matched := ""
+	for _, c := range creds {
+		if subtle.ConstantTimeCompare(hash, []byte(c.SecretHash)) == 1 && c.Active(now) {
+			matched = c.ID
+		}
+	}
+	return matched, matched != ""
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServiceAccount creates an account that may call search-indexer from
+// 10.0.0.0/8 and returns it with its client secret.
+func newTestServiceAccount(t *testing.T, store *mockServiceAccountStore, ttl time.Duration) (*domain.ServiceAccount, string) {
+	t.Helper()
+	req := validCreateServiceAccountRequest()
+	req.Grants[0].Scopes = []string{"search.index", "search.read"}
+	req.TokenTTL = ttl
+	created, err := newTestManageServiceAccounts(store).Create(context.Background(), req)
+	require.NoError(t, err)
+	return created.Account, created.ClientSecret
+}
+
+func newTestIssueServiceAccountToken(store domain.ServiceAccountStore, issuer *mockServiceTokenIssuer) *IssueServiceAccountToken {
+	uc := NewIssueServiceAccountToken(store, issuer, slog.Default())
+	uc.now = func() time.Time { return serviceAccountsNow }
+	return uc
+}
+
+func TestIssueServiceAccountToken_IssuesAndRecordsUsage(t *testing.T) {
+	store := newMockServiceAccountStore()
+	account, secret := newTestServiceAccount(t, store, time.Minute)
+	issuer := &mockServiceTokenIssuer{}
+
+	result, err := newTestIssueServiceAccountToken(store, issuer).Execute(context.Background(), ClientCredentialsRequest{
+		GrantType:    domain.GrantTypeClientCredentials,
+		ClientID:     account.ID,
+		ClientSecret: secret,
+		Scope:        "search.read",
+		ClientIP:     "10.1.2.3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "token-for-search-indexer", result.AccessToken, "single grant is the default audience")
+	assert.Equal(t, int64(60), result.ExpiresIn, "capped at the account's token TTL")
+	assert.Equal(t, "search.read", result.Scope)
+
+	require.Len(t, issuer.identities, 1)
+	assert.Equal(t, domain.Identity{UserID: account.ID, Role: domain.ServiceRole}, issuer.identities[0])
+
+	stored := store.accounts[account.ID]
+	assert.Equal(t, int64(1), stored.Usage.TokensIssued)
+	assert.Equal(t, "10.1.2.3", stored.Usage.LastUsedIP)
+	assert.Equal(t, "search-indexer", stored.Usage.LastAudience)
+	assert.Equal(t, serviceAccountsNow, *stored.Credentials[0].LastUsedAt)
+}
+
+func TestIssueServiceAccountToken_DefaultsToGrantedScopes(t *testing.T) {
+	store := newMockServiceAccountStore()
+	account, secret := newTestServiceAccount(t, store, 0)
+	issuer := &mockServiceTokenIssuer{}
+
+	_, err := newTestIssueServiceAccountToken(store, issuer).Execute(context.Background(), ClientCredentialsRequest{
+		GrantType:    domain.GrantTypeClientCredentials,
+		ClientID:     account.ID,
+		ClientSecret: secret,
+		Audience:     "search-indexer",
+		ClientIP:     "10.1.2.3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"search.index", "search.read"}, issuer.requests[0].Scopes)
+}
+
+func TestIssueServiceAccountToken_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*ClientCredentialsRequest)
+		want   error
+	}{
+		{"wrong grant type", func(r *ClientCredentialsRequest) { r.GrantType = "password" }, domain.ErrUnsupportedGrantType},
+		{"unknown client", func(r *ClientCredentialsRequest) { r.ClientID = "sa_unknown" }, domain.ErrInvalidClient},
+		{"wrong secret", func(r *ClientCredentialsRequest) { r.ClientSecret = "guess" }, domain.ErrInvalidClient},
+		{"missing secret", func(r *ClientCredentialsRequest) { r.ClientSecret = "" }, domain.ErrInvalidClient},
+		{"address outside allowlist", func(r *ClientCredentialsRequest) { r.ClientIP = "203.0.113.9" }, domain.ErrClientIPForbidden},
+		{"unparseable address", func(r *ClientCredentialsRequest) { r.ClientIP = "" }, domain.ErrClientIPForbidden},
+		{"audience not granted", func(r *ClientCredentialsRequest) { r.Audience = "rag-orchestrator" }, domain.ErrUnknownAudience},
+		{"scope not granted", func(r *ClientCredentialsRequest) { r.Scope = "search.admin" }, domain.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMockServiceAccountStore()
+			account, secret := newTestServiceAccount(t, store, 0)
+			issuer := &mockServiceTokenIssuer{}
+			req := ClientCredentialsRequest{
+				GrantType:    domain.GrantTypeClientCredentials,
+				ClientID:     account.ID,
+				ClientSecret: secret,
+				ClientIP:     "10.1.2.3",
+			}
+			tt.mutate(&req)
+
+			_, err := newTestIssueServiceAccountToken(store, issuer).Execute(context.Background(), req)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Empty(t, issuer.requests, "no token issued")
+			assert.Zero(t, store.accounts[account.ID].Usage.TokensIssued)
+		})
+	}
+}
+
+func TestIssueServiceAccountToken_RotationAndDisable(t *testing.T) {
+	store := newMockServiceAccountStore()
+	account, oldSecret := newTestServiceAccount(t, store, 0)
+	manage := newTestManageServiceAccounts(store)
+	rotated, err := manage.Rotate(context.Background(), account.ID, time.Hour)
+	require.NoError(t, err)
+
+	issue := newTestIssueServiceAccountToken(store, &mockServiceTokenIssuer{})
+	request := func(secret string) error {
+		_, err := issue.Execute(context.Background(), ClientCredentialsRequest{
+			GrantType:    domain.GrantTypeClientCredentials,
+			ClientID:     account.ID,
+			ClientSecret: secret,
+			ClientIP:     "10.1.2.3",
+		})
+		return err
+	}
+
+	assert.NoError(t, request(rotated.ClientSecret))
+	assert.NoError(t, request(oldSecret), "previous secret works during the grace period")
+
+	issue.now = func() time.Time { return serviceAccountsNow.Add(time.Hour) }
+	assert.ErrorIs(t, request(oldSecret), domain.ErrInvalidClient, "previous secret expires after the grace period")
+	assert.NoError(t, request(rotated.ClientSecret))
+
+	_, err = manage.Disable(context.Background(), account.ID)
+	require.NoError(t, err)
+	assert.ErrorIs(t, request(rotated.ClientSecret), domain.ErrInvalidClient)
+}
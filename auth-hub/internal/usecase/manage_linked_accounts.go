@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// ManageLinkedAccounts lists and removes the upstream OIDC accounts linked
+// to the signed-in identity. Linking happens in Kratos' own flows; removing
+// a link is guarded here because Kratos' admin API, which does the removal,
+// checks nothing on the user's behalf.
+type ManageLinkedAccounts struct {
+	validator domain.SessionValidator
+	store     domain.LinkedAccountStore
+	csrf      domain.CSRFTokenValidator
+	// reauthMaxAge is how recently the user must have signed in to unlink;
+	// zero skips the check.
+	reauthMaxAge time.Duration
+	now          func() time.Time
+	logger       *slog.Logger
+}
+
+// NewManageLinkedAccounts creates a new ManageLinkedAccounts usecase.
+func NewManageLinkedAccounts(
+	v domain.SessionValidator,
+	s domain.LinkedAccountStore,
+	csrf domain.CSRFTokenValidator,
+	reauthMaxAge time.Duration,
+	l *slog.Logger,
+) *ManageLinkedAccounts {
+	return &ManageLinkedAccounts{validator: v, store: s, csrf: csrf, reauthMaxAge: reauthMaxAge, now: time.Now, logger: l}
+}
+
+// List returns the sign-in methods of the session's identity.
+func (uc *ManageLinkedAccounts) List(ctx context.Context, cookie string) (*domain.SignInMethods, error) {
+	identity, err := uc.identity(ctx, cookie)
+	if err != nil {
+		return nil, err
+	}
+	return uc.store.SignInMethods(ctx, identity.UserID)
+}
+
+// Unlink removes the link to provider. The request must carry a CSRF token
+// issued for the session, the user must have signed in within reauthMaxAge
+// (as for Kratos privileged settings), and another sign-in method has to
+// remain so the account cannot be locked out.
+func (uc *ManageLinkedAccounts) Unlink(ctx context.Context, cookie, sessionID, csrfToken, provider string) error {
+	identity, err := uc.identity(ctx, cookie)
+	if err != nil {
+		return err
+	}
+	if err := uc.csrf.Validate(sessionID, csrfToken); err != nil {
+		return err
+	}
+	if uc.reauthMaxAge > 0 && uc.now().Sub(identity.AuthenticatedAt) > uc.reauthMaxAge {
+		return domain.ErrStepUpRequired
+	}
+
+	methods, err := uc.store.SignInMethods(ctx, identity.UserID)
+	if err != nil {
+		return err
+	}
+	account, ok := methods.Account(provider)
+	if !ok {
+		return domain.ErrLinkedAccountNotFound
+	}
+	if !methods.Without(provider).Usable() {
+		return domain.ErrLastSignInMethod
+	}
+
+	if err := uc.store.UnlinkAccount(ctx, identity.UserID, account); err != nil {
+		uc.logger.ErrorContext(ctx, "failed to unlink account",
+			"user_id", identity.UserID, "provider", provider, "error", err)
+		return err
+	}
+	uc.logger.InfoContext(ctx, "linked account removed", "user_id", identity.UserID, "provider", provider)
+	return nil
+}
+
+func (uc *ManageLinkedAccounts) identity(ctx context.Context, cookie string) (*domain.Identity, error) {
+	if cookie == "" {
+		return nil, domain.ErrSessionNotFound
+	}
+	identity, err := uc.validator.ValidateSession(ctx, cookie)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrAuthFailed, err)
+	}
+	return identity, nil
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockLinkedAccountStore implements domain.LinkedAccountStore for testing.
+type mockLinkedAccountStore struct {
+	methods  domain.SignInMethods
+	unlinked []domain.LinkedAccount
+}
+
+func (m *mockLinkedAccountStore) SignInMethods(_ context.Context, _ string) (*domain.SignInMethods, error) {
+	methods := m.methods
+	return &methods, nil
+}
+
+func (m *mockLinkedAccountStore) UnlinkAccount(_ context.Context, _ string, account domain.LinkedAccount) error {
+	m.unlinked = append(m.unlinked, account)
+	return nil
+}
+
+// mockCSRFValidator accepts only the token "valid" for session "sess".
+type mockCSRFValidator struct{}
+
+func (mockCSRFValidator) Validate(sessionID, token string) error {
+	if sessionID != "sess" || token != "valid" {
+		return domain.ErrCSRFTokenInvalid
+	}
+	return nil
+}
+
+var linkedAccountsNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newTestManageLinkedAccounts(store domain.LinkedAccountStore, authenticatedAt time.Time) *ManageLinkedAccounts {
+	v := &mockValidator{identity: &domain.Identity{UserID: "user-1", AuthenticatedAt: authenticatedAt}}
+	uc := NewManageLinkedAccounts(v, store, mockCSRFValidator{}, 10*time.Minute, slog.Default())
+	uc.now = func() time.Time { return linkedAccountsNow }
+	return uc
+}
+
+var (
+	googleAccount = domain.LinkedAccount{Provider: "google", Subject: "g-1"}
+	githubAccount = domain.LinkedAccount{Provider: "github", Subject: "42"}
+)
+
+func TestManageLinkedAccounts_List(t *testing.T) {
+	store := &mockLinkedAccountStore{methods: domain.SignInMethods{Password: true, Linked: []domain.LinkedAccount{googleAccount}}}
+	uc := newTestManageLinkedAccounts(store, linkedAccountsNow)
+
+	methods, err := uc.List(context.Background(), "ory_kratos_session=sess")
+	require.NoError(t, err)
+	assert.True(t, methods.Password)
+	assert.Equal(t, []domain.LinkedAccount{googleAccount}, methods.Linked)
+
+	_, err = uc.List(context.Background(), "")
+	assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+}
+
+func TestManageLinkedAccounts_Unlink(t *testing.T) {
+	tests := []struct {
+		name            string
+		methods         domain.SignInMethods
+		provider        string
+		csrfToken       string
+		authenticatedAt time.Time
+		wantErr         error
+	}{
+		{
+			name:            "password remains",
+			methods:         domain.SignInMethods{Password: true, Linked: []domain.LinkedAccount{googleAccount}},
+			provider:        "google",
+			csrfToken:       "valid",
+			authenticatedAt: linkedAccountsNow.Add(-time.Minute),
+		},
+		{
+			name:            "other provider remains",
+			methods:         domain.SignInMethods{Linked: []domain.LinkedAccount{googleAccount, githubAccount}},
+			provider:        "github",
+			csrfToken:       "valid",
+			authenticatedAt: linkedAccountsNow,
+		},
+		{
+			name:            "email code remains",
+			methods:         domain.SignInMethods{Code: true, Linked: []domain.LinkedAccount{googleAccount}},
+			provider:        "google",
+			csrfToken:       "valid",
+			authenticatedAt: linkedAccountsNow,
+		},
+		{
+			name:            "last sign-in method",
+			methods:         domain.SignInMethods{Linked: []domain.LinkedAccount{googleAccount}},
+			provider:        "google",
+			csrfToken:       "valid",
+			authenticatedAt: linkedAccountsNow,
+			wantErr:         domain.ErrLastSignInMethod,
+		},
+		{
+			name:            "not linked",
+			methods:         domain.SignInMethods{Password: true, Linked: []domain.LinkedAccount{googleAccount}},
+			provider:        "github",
+			csrfToken:       "valid",
+			authenticatedAt: linkedAccountsNow,
+			wantErr:         domain.ErrLinkedAccountNotFound,
+		},
+		{
+			name:            "stale sign-in",
+			methods:         domain.SignInMethods{Password: true, Linked: []domain.LinkedAccount{googleAccount}},
+			provider:        "google",
+			csrfToken:       "valid",
+			authenticatedAt: linkedAccountsNow.Add(-time.Hour),
+			wantErr:         domain.ErrStepUpRequired,
+		},
+		{
+			name:            "bad csrf token",
+			methods:         domain.SignInMethods{Password: true, Linked: []domain.LinkedAccount{googleAccount}},
+			provider:        "google",
+			csrfToken:       "forged",
+			authenticatedAt: linkedAccountsNow,
+			wantErr:         domain.ErrCSRFTokenInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockLinkedAccountStore{methods: tt.methods}
+			uc := newTestManageLinkedAccounts(store, tt.authenticatedAt)
+
+			err := uc.Unlink(context.Background(), "ory_kratos_session=sess", "sess", tt.csrfToken, tt.provider)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				assert.Empty(t, store.unlinked)
+				return
+			}
+			require.NoError(t, err)
+			account, _ := tt.methods.Account(tt.provider)
+			assert.Equal(t, []domain.LinkedAccount{account}, store.unlinked)
+		})
+	}
+}
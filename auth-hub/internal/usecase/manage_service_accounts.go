@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"regexp"
+	"slices"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+const (
+	// defaultServiceAccountTokenTTL applies when an account is created
+	// without a token TTL; the audience TTL still caps it.
+	defaultServiceAccountTokenTTL = 5 * time.Minute
+	// maxServiceAccountTokenTTL matches the cap on audience TTLs: tokens
+	// cannot be revoked before they expire.
+	maxServiceAccountTokenTTL = time.Hour
+
+	// DefaultRotationGrace is how long the previous secret keeps working
+	// after a rotation when the caller does not say, enough for a rolling
+	// restart of the service using it.
+	DefaultRotationGrace = time.Hour
+	maxRotationGrace     = 7 * 24 * time.Hour
+)
+
+var serviceAccountNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// CreateServiceAccountRequest describes a service account to create.
+type CreateServiceAccountRequest struct {
+	Name   string
+	Grants []domain.ServiceGrant
+	// TokenTTL of zero selects the default of five minutes.
+	TokenTTL time.Duration
+	// IPAllowlist holds CIDRs or single addresses; empty allows any.
+	IPAllowlist []string
+}
+
+// ServiceAccountSecret is an account together with a client secret that
+// was just generated for it. The secret cannot be retrieved later.
+type ServiceAccountSecret struct {
+	Account      *domain.ServiceAccount
+	ClientSecret string
+}
+
+// ManageServiceAccounts creates, rotates and disables service accounts on
+// behalf of an operator or a deployment job. Grants are checked against
+// the configured audiences, so an account can never be granted more than
+// auth-hub would issue to anyone.
+type ManageServiceAccounts struct {
+	store domain.ServiceAccountStore
+	// audiences maps each configured audience to the scopes it allows.
+	audiences map[string][]string
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewManageServiceAccounts creates a new ManageServiceAccounts usecase.
+// audiences maps every audience tokens can be issued for to its scopes.
+func NewManageServiceAccounts(s domain.ServiceAccountStore, audiences map[string][]string, l *slog.Logger) *ManageServiceAccounts {
+	return &ManageServiceAccounts{store: s, audiences: audiences, logger: l, now: time.Now}
+}
+
+// Create validates the request and stores a new account with a fresh
+// client secret.
+func (uc *ManageServiceAccounts) Create(ctx context.Context, req CreateServiceAccountRequest) (*ServiceAccountSecret, error) {
+	if !serviceAccountNameRe.MatchString(req.Name) {
+		return nil, fmt.Errorf("%w: name must be 1-64 lowercase letters, digits, '.', '_' or '-'", domain.ErrInvalidServiceAccount)
+	}
+	if err := uc.validateGrants(req.Grants); err != nil {
+		return nil, err
+	}
+
+	ttl := req.TokenTTL
+	if ttl == 0 {
+		ttl = defaultServiceAccountTokenTTL
+	}
+	if ttl < 0 || ttl > maxServiceAccountTokenTTL {
+		return nil, fmt.Errorf("%w: token_ttl must be between 0 and %s", domain.ErrInvalidServiceAccount, maxServiceAccountTokenTTL)
+	}
+
+	allowlist, err := parseIPAllowlist(req.IPAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.now()
+	cred, secret, err := newServiceCredential(now)
+	if err != nil {
+		return nil, err
+	}
+
+	account := domain.ServiceAccount{
+		ID:          "sa_" + id,
+		Name:        req.Name,
+		Grants:      req.Grants,
+		TokenTTL:    ttl,
+		IPAllowlist: allowlist,
+		Credentials: []domain.ServiceCredential{cred},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.store.Create(ctx, account); err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "service account created",
+		"service_account_id", account.ID,
+		"name", account.Name,
+		"audiences", grantAudiences(account.Grants),
+		"ip_allowlist", req.IPAllowlist)
+	return &ServiceAccountSecret{Account: &account, ClientSecret: secret}, nil
+}
+
+// List returns every service account, disabled ones included.
+func (uc *ManageServiceAccounts) List(ctx context.Context) ([]domain.ServiceAccount, error) {
+	return uc.store.List(ctx)
+}
+
+// Get returns one service account.
+func (uc *ManageServiceAccounts) Get(ctx context.Context, id string) (*domain.ServiceAccount, error) {
+	return uc.store.Get(ctx, id)
+}
+
+// Rotate issues a new client secret. The current secret keeps working for
+// grace so the service can be redeployed with the new one; a zero grace
+// revokes it at once. Any secret from an earlier rotation is dropped, so
+// an account never has more than two.
+func (uc *ManageServiceAccounts) Rotate(ctx context.Context, id string, grace time.Duration) (*ServiceAccountSecret, error) {
+	if grace < 0 || grace > maxRotationGrace {
+		return nil, fmt.Errorf("%w: grace period must be between 0 and %s", domain.ErrInvalidServiceAccount, maxRotationGrace)
+	}
+
+	now := uc.now()
+	cred, secret, err := newServiceCredential(now)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := uc.store.Update(ctx, id, func(a *domain.ServiceAccount) error {
+		if a.Disabled() {
+			return fmt.Errorf("%w: account is disabled", domain.ErrInvalidServiceAccount)
+		}
+		creds := []domain.ServiceCredential{cred}
+		if grace > 0 && len(a.Credentials) > 0 && a.Credentials[0].Active(now) {
+			prev := a.Credentials[0]
+			expiresAt := now.Add(grace)
+			if prev.ExpiresAt == nil || expiresAt.Before(*prev.ExpiresAt) {
+				prev.ExpiresAt = &expiresAt
+			}
+			creds = append(creds, prev)
+		}
+		a.Credentials = creds
+		a.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "service account secret rotated",
+		"service_account_id", account.ID,
+		"name", account.Name,
+		"grace_period", grace)
+	return &ServiceAccountSecret{Account: account, ClientSecret: secret}, nil
+}
+
+// Disable stops the account from obtaining tokens. Tokens it already
+// holds stay valid until they expire, at most its token TTL. Disabling a
+// disabled account keeps the original time.
+func (uc *ManageServiceAccounts) Disable(ctx context.Context, id string) (*domain.ServiceAccount, error) {
+	now := uc.now()
+	account, err := uc.store.Update(ctx, id, func(a *domain.ServiceAccount) error {
+		if a.DisabledAt == nil {
+			a.DisabledAt = &now
+			a.UpdatedAt = now
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "service account disabled",
+		"service_account_id", account.ID,
+		"name", account.Name)
+	return account, nil
+}
+
+// validateGrants checks that each audience is configured, granted once and
+// given only scopes the audience allows.
+func (uc *ManageServiceAccounts) validateGrants(grants []domain.ServiceGrant) error {
+	if len(grants) == 0 {
+		return fmt.Errorf("%w: at least one audience must be granted", domain.ErrInvalidServiceAccount)
+	}
+	seen := map[string]bool{}
+	for _, g := range grants {
+		allowed, ok := uc.audiences[g.Audience]
+		if !ok {
+			return fmt.Errorf("%w: %q", domain.ErrUnknownAudience, g.Audience)
+		}
+		if seen[g.Audience] {
+			return fmt.Errorf("%w: audience %q granted twice", domain.ErrInvalidServiceAccount, g.Audience)
+		}
+		seen[g.Audience] = true
+		for _, s := range g.Scopes {
+			if !slices.Contains(allowed, s) {
+				return fmt.Errorf("%w: %q for %q", domain.ErrInvalidScope, s, g.Audience)
+			}
+		}
+	}
+	return nil
+}
+
+// parseIPAllowlist accepts CIDRs and single addresses, the latter as
+// host-length prefixes.
+func parseIPAllowlist(entries []string) ([]netip.Prefix, error) {
+	var out []netip.Prefix
+	for _, e := range entries {
+		if p, err := netip.ParsePrefix(e); err == nil {
+			out = append(out, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(e)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ip_allowlist entry %q is not an address or CIDR", domain.ErrInvalidServiceAccount, e)
+		}
+		addr = addr.Unmap()
+		out = append(out, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return out, nil
+}
+
+// newServiceCredential generates a 256-bit client secret and the
+// credential storing its hash. A plain SHA-256 is enough: unlike a
+// password, the secret is random and cannot be guessed from a dictionary.
+func newServiceCredential(now time.Time) (domain.ServiceCredential, string, error) {
+	id, err := randomHex(4)
+	if err != nil {
+		return domain.ServiceCredential{}, "", err
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return domain.ServiceCredential{}, "", fmt.Errorf("%w: %w", domain.ErrTokenGeneration, err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+	return domain.ServiceCredential{ID: id, SecretHash: hashClientSecret(secret), CreatedAt: now}, secret, nil
+}
+
+func hashClientSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("%w: %w", domain.ErrTokenGeneration, err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func grantAudiences(grants []domain.ServiceGrant) []string {
+	out := make([]string, 0, len(grants))
+	for _, g := range grants {
+		out = append(out, g.Audience)
+	}
+	return out
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/netip"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockServiceAccountStore implements domain.ServiceAccountStore in memory.
+// Accounts are stored by value, so changes outside Update are not seen.
+type mockServiceAccountStore struct {
+	accounts map[string]domain.ServiceAccount
+}
+
+func newMockServiceAccountStore() *mockServiceAccountStore {
+	return &mockServiceAccountStore{accounts: map[string]domain.ServiceAccount{}}
+}
+
+func (m *mockServiceAccountStore) List(_ context.Context) ([]domain.ServiceAccount, error) {
+	out := make([]domain.ServiceAccount, 0, len(m.accounts))
+	for _, a := range m.accounts {
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func (m *mockServiceAccountStore) Get(_ context.Context, id string) (*domain.ServiceAccount, error) {
+	a, ok := m.accounts[id]
+	if !ok {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+	return &a, nil
+}
+
+func (m *mockServiceAccountStore) Create(_ context.Context, account domain.ServiceAccount) error {
+	for _, a := range m.accounts {
+		if a.Name == account.Name {
+			return domain.ErrServiceAccountExists
+		}
+	}
+	m.accounts[account.ID] = account
+	return nil
+}
+
+func (m *mockServiceAccountStore) Update(_ context.Context, id string, fn func(*domain.ServiceAccount) error) (*domain.ServiceAccount, error) {
+	a, ok := m.accounts[id]
+	if !ok {
+		return nil, domain.ErrServiceAccountNotFound
+	}
+	a.Credentials = append([]domain.ServiceCredential(nil), a.Credentials...)
+	if err := fn(&a); err != nil {
+		return nil, err
+	}
+	m.accounts[id] = a
+	return &a, nil
+}
+
+var serviceAccountsNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newTestManageServiceAccounts(store domain.ServiceAccountStore) *ManageServiceAccounts {
+	uc := NewManageServiceAccounts(store, map[string][]string{
+		"alt-backend":      nil,
+		"search-indexer":   {"search.index", "search.read"},
+		"rag-orchestrator": {"rag.query"},
+	}, slog.Default())
+	uc.now = func() time.Time { return serviceAccountsNow }
+	return uc
+}
+
+func validCreateServiceAccountRequest() CreateServiceAccountRequest {
+	return CreateServiceAccountRequest{
+		Name:        "pre-processor",
+		Grants:      []domain.ServiceGrant{{Audience: "search-indexer", Scopes: []string{"search.index"}}},
+		IPAllowlist: []string{"10.0.0.0/8", "192.168.1.7"},
+	}
+}
+
+func TestManageServiceAccounts_Create(t *testing.T) {
+	store := newMockServiceAccountStore()
+	uc := newTestManageServiceAccounts(store)
+
+	created, err := uc.Create(context.Background(), validCreateServiceAccountRequest())
+	require.NoError(t, err)
+
+	account := created.Account
+	assert.Regexp(t, `^sa_[0-9a-f]{16}$`, account.ID)
+	assert.Equal(t, defaultServiceAccountTokenTTL, account.TokenTTL)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.168.1.7/32")}, account.IPAllowlist)
+	require.Len(t, account.Credentials, 1)
+	assert.Equal(t, hashClientSecret(created.ClientSecret), account.Credentials[0].SecretHash, "only the hash is stored")
+	assert.NotContains(t, account.Credentials[0].SecretHash, created.ClientSecret)
+	assert.Contains(t, store.accounts, account.ID)
+
+	_, err = uc.Create(context.Background(), validCreateServiceAccountRequest())
+	assert.ErrorIs(t, err, domain.ErrServiceAccountExists)
+}
+
+func TestManageServiceAccounts_CreateRejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*CreateServiceAccountRequest)
+		want   error
+	}{
+		{"bad name", func(r *CreateServiceAccountRequest) { r.Name = "Pre Processor" }, domain.ErrInvalidServiceAccount},
+		{"no grants", func(r *CreateServiceAccountRequest) { r.Grants = nil }, domain.ErrInvalidServiceAccount},
+		{"unknown audience", func(r *CreateServiceAccountRequest) { r.Grants[0].Audience = "mystery" }, domain.ErrUnknownAudience},
+		{"scope of another audience", func(r *CreateServiceAccountRequest) { r.Grants[0].Scopes = []string{"rag.query"} }, domain.ErrInvalidScope},
+		{"duplicate grant", func(r *CreateServiceAccountRequest) { r.Grants = append(r.Grants, r.Grants[0]) }, domain.ErrInvalidServiceAccount},
+		{"ttl too long", func(r *CreateServiceAccountRequest) { r.TokenTTL = 2 * time.Hour }, domain.ErrInvalidServiceAccount},
+		{"bad allowlist entry", func(r *CreateServiceAccountRequest) { r.IPAllowlist = []string{"10.0.0.0/33"} }, domain.ErrInvalidServiceAccount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validCreateServiceAccountRequest()
+			tt.mutate(&req)
+			_, err := newTestManageServiceAccounts(newMockServiceAccountStore()).Create(context.Background(), req)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestManageServiceAccounts_RotateKeepsPreviousSecretForGrace(t *testing.T) {
+	uc := newTestManageServiceAccounts(newMockServiceAccountStore())
+	created, err := uc.Create(context.Background(), validCreateServiceAccountRequest())
+	require.NoError(t, err)
+	first := created.Account.Credentials[0]
+
+	rotated, err := uc.Rotate(context.Background(), created.Account.ID, time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, created.ClientSecret, rotated.ClientSecret)
+	require.Len(t, rotated.Account.Credentials, 2)
+	assert.Equal(t, hashClientSecret(rotated.ClientSecret), rotated.Account.Credentials[0].SecretHash, "new secret first")
+	assert.Equal(t, first.ID, rotated.Account.Credentials[1].ID)
+	assert.Equal(t, serviceAccountsNow.Add(time.Hour), *rotated.Account.Credentials[1].ExpiresAt)
+
+	// A second rotation drops the first secret: at most two are kept.
+	again, err := uc.Rotate(context.Background(), created.Account.ID, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, again.Account.Credentials, 2)
+	assert.Equal(t, rotated.Account.Credentials[0].ID, again.Account.Credentials[1].ID)
+}
+
+func TestManageServiceAccounts_RotateWithoutGraceRevokesAtOnce(t *testing.T) {
+	uc := newTestManageServiceAccounts(newMockServiceAccountStore())
+	created, err := uc.Create(context.Background(), validCreateServiceAccountRequest())
+	require.NoError(t, err)
+
+	rotated, err := uc.Rotate(context.Background(), created.Account.ID, 0)
+	require.NoError(t, err)
+	assert.Len(t, rotated.Account.Credentials, 1)
+
+	_, err = uc.Rotate(context.Background(), created.Account.ID, -time.Second)
+	assert.ErrorIs(t, err, domain.ErrInvalidServiceAccount)
+	_, err = uc.Rotate(context.Background(), "sa_unknown", time.Hour)
+	assert.ErrorIs(t, err, domain.ErrServiceAccountNotFound)
+}
+
+func TestManageServiceAccounts_Disable(t *testing.T) {
+	uc := newTestManageServiceAccounts(newMockServiceAccountStore())
+	created, err := uc.Create(context.Background(), validCreateServiceAccountRequest())
+	require.NoError(t, err)
+
+	disabled, err := uc.Disable(context.Background(), created.Account.ID)
+	require.NoError(t, err)
+	assert.True(t, disabled.Disabled())
+	assert.Equal(t, serviceAccountsNow, *disabled.DisabledAt)
+
+	uc.now = func() time.Time { return serviceAccountsNow.Add(time.Hour) }
+	again, err := uc.Disable(context.Background(), created.Account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, serviceAccountsNow, *again.DisabledAt, "keeps the original time")
+
+	_, err = uc.Rotate(context.Background(), created.Account.ID, time.Hour)
+	assert.ErrorIs(t, err, domain.ErrInvalidServiceAccount, "disabled accounts get no new secrets")
+}
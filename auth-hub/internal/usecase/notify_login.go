@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"auth-hub/internal/domain"
+)
+
+// LoginAlertPolicy configures NotifyLogin. PublicURL is where the login
+// alert link endpoints are reachable from the user's browser; links are
+// PublicURL + "/revoke" and PublicURL + "/opt-out". The prefixes set how
+// much of the client IP identifies a device, as for session fingerprints.
+type LoginAlertPolicy struct {
+	PublicURL  string
+	LinkTTL    time.Duration
+	IPv4Prefix int
+	IPv6Prefix int
+}
+
+// NotifyLogin alerts users to logins from a device or location they have
+// not used recently, with links to revoke the new session or turn the
+// alerts off.
+type NotifyLogin struct {
+	devices domain.KnownDeviceStore
+	prefs   domain.LoginAlertPreferences
+	signer  domain.LoginAlertLinkSigner
+	gateway domain.NotificationGateway
+	policy  LoginAlertPolicy
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// NewNotifyLogin creates a new NotifyLogin usecase.
+func NewNotifyLogin(
+	d domain.KnownDeviceStore,
+	p domain.LoginAlertPreferences,
+	s domain.LoginAlertLinkSigner,
+	g domain.NotificationGateway,
+	policy LoginAlertPolicy,
+	l *slog.Logger,
+) *NotifyLogin {
+	return &NotifyLogin{devices: d, prefs: p, signer: s, gateway: g, policy: policy, logger: l, now: time.Now}
+}
+
+// Execute records login and sends an alert when it came from a new device
+// or location. Every login is recorded, including those of users who opted
+// out, so turning alerts back on does not flag known devices.
+func (uc *NotifyLogin) Execute(ctx context.Context, login domain.Login) error {
+	fp := clientFingerprint(login.Client, uc.policy.IPv4Prefix, uc.policy.IPv6Prefix)
+	novelty := uc.devices.Observe(login.IdentityID, fp, login.Location)
+	if !novelty.Anomalous() {
+		return nil
+	}
+
+	enabled, err := uc.prefs.LoginAlertsEnabled(ctx, login.IdentityID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		uc.logger.InfoContext(ctx, "login alert skipped: user opted out", "identity_id", login.IdentityID)
+		return nil
+	}
+	if login.Email == "" {
+		uc.logger.WarnContext(ctx, "login alert skipped: identity has no email", "identity_id", login.IdentityID)
+		return nil
+	}
+
+	expires := uc.now().Add(uc.policy.LinkTTL)
+	revokeURL, err := uc.link("/revoke", domain.LoginAlertLink{
+		Action:     domain.LoginAlertRevoke,
+		IdentityID: login.IdentityID,
+		SessionID:  login.SessionID,
+		ExpiresAt:  expires,
+	})
+	if err != nil {
+		return err
+	}
+	optOutURL, err := uc.link("/opt-out", domain.LoginAlertLink{
+		Action:     domain.LoginAlertOptOut,
+		IdentityID: login.IdentityID,
+		ExpiresAt:  expires,
+	})
+	if err != nil {
+		return err
+	}
+
+	at := login.AuthenticatedAt
+	if at.IsZero() {
+		at = uc.now()
+	}
+	if err := uc.gateway.SendLoginAlert(ctx, domain.LoginAlert{
+		IdentityID:  login.IdentityID,
+		Email:       login.Email,
+		At:          at,
+		IP:          login.Client.IP,
+		UserAgent:   login.Client.UserAgent,
+		Location:    login.Location,
+		NewDevice:   novelty.NewDevice,
+		NewLocation: novelty.NewLocation,
+		RevokeURL:   revokeURL,
+		OptOutURL:   optOutURL,
+	}); err != nil {
+		return err
+	}
+
+	uc.logger.InfoContext(ctx, "login alert sent",
+		"identity_id", login.IdentityID,
+		"new_device", novelty.NewDevice,
+		"new_location", novelty.NewLocation)
+	return nil
+}
+
+func (uc *NotifyLogin) link(path string, l domain.LoginAlertLink) (string, error) {
+	token, err := uc.signer.Sign(l)
+	if err != nil {
+		return "", err
+	}
+	return uc.policy.PublicURL + path + "?token=" + url.QueryEscape(token), nil
+}
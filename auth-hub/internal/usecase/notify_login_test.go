@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"auth-hub/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKnownDevices implements domain.KnownDeviceStore for testing.
+type mockKnownDevices struct {
+	novelty      domain.LoginNovelty
+	fingerprints []string
+}
+
+func (m *mockKnownDevices) Observe(_, fingerprint, _ string) domain.LoginNovelty {
+	m.fingerprints = append(m.fingerprints, fingerprint)
+	return m.novelty
+}
+
+// mockLoginAlertPrefs implements domain.LoginAlertPreferences for testing.
+type mockLoginAlertPrefs struct {
+	optedOut map[string]bool
+	err      error
+}
+
+func (m *mockLoginAlertPrefs) LoginAlertsEnabled(_ context.Context, identityID string) (bool, error) {
+	return !m.optedOut[identityID], m.err
+}
+
+func (m *mockLoginAlertPrefs) DisableLoginAlerts(_ context.Context, identityID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.optedOut == nil {
+		m.optedOut = map[string]bool{}
+	}
+	m.optedOut[identityID] = true
+	return nil
+}
+
+// mockLinkSigner implements domain.LoginAlertLinkSigner with readable,
+// unsigned tokens.
+type mockLinkSigner struct{}
+
+func (mockLinkSigner) Sign(l domain.LoginAlertLink) (string, error) {
+	return strings.Join([]string{string(l.Action), l.IdentityID, l.SessionID}, "|"), nil
+}
+
+func (mockLinkSigner) Verify(token string) (*domain.LoginAlertLink, error) {
+	parts := strings.Split(token, "|")
+	if len(parts) != 3 {
+		return nil, domain.ErrInvalidLoginAlertLink
+	}
+	return &domain.LoginAlertLink{Action: domain.LoginAlertAction(parts[0]), IdentityID: parts[1], SessionID: parts[2]}, nil
+}
+
+// mockNotificationGateway implements domain.NotificationGateway for testing.
+type mockNotificationGateway struct {
+	alerts []domain.LoginAlert
+	err    error
+}
+
+func (m *mockNotificationGateway) SendLoginAlert(_ context.Context, a domain.LoginAlert) error {
+	m.alerts = append(m.alerts, a)
+	return m.err
+}
+
+var testLogin = domain.Login{
+	IdentityID:      "user-1",
+	SessionID:       "ks-1",
+	Email:           "user@example.com",
+	Client:          laptop,
+	Location:        "Osaka, JP",
+	AuthenticatedAt: fpTestNow,
+}
+
+func newTestNotifyLogin(novelty domain.LoginNovelty, prefs *mockLoginAlertPrefs) (*NotifyLogin, *mockKnownDevices, *mockNotificationGateway) {
+	devices := &mockKnownDevices{novelty: novelty}
+	gateway := &mockNotificationGateway{}
+	uc := NewNotifyLogin(devices, prefs, mockLinkSigner{}, gateway, LoginAlertPolicy{
+		PublicURL:  "https://alt.example.com/security/login-alerts",
+		LinkTTL:    72 * time.Hour,
+		IPv4Prefix: 24,
+		IPv6Prefix: 64,
+	}, slog.Default())
+	uc.now = func() time.Time { return fpTestNow }
+	return uc, devices, gateway
+}
+
+func TestNotifyLogin_AlertsOnNewDevice(t *testing.T) {
+	uc, devices, gateway := newTestNotifyLogin(domain.LoginNovelty{NewDevice: true}, &mockLoginAlertPrefs{})
+
+	require.NoError(t, uc.Execute(context.Background(), testLogin))
+
+	assert.Equal(t, []string{clientFingerprint(laptop, 24, 64)}, devices.fingerprints)
+	require.Len(t, gateway.alerts, 1)
+	alert := gateway.alerts[0]
+	assert.Equal(t, "user@example.com", alert.Email)
+	assert.Equal(t, laptop.IP, alert.IP)
+	assert.Equal(t, "Osaka, JP", alert.Location)
+	assert.True(t, alert.NewDevice)
+	assert.Equal(t, fpTestNow, alert.At)
+	assert.Equal(t, "https://alt.example.com/security/login-alerts/revoke?token=revoke%7Cuser-1%7Cks-1", alert.RevokeURL)
+	assert.Equal(t, "https://alt.example.com/security/login-alerts/opt-out?token=opt_out%7Cuser-1%7C", alert.OptOutURL)
+}
+
+func TestNotifyLogin_NoAlert(t *testing.T) {
+	tests := []struct {
+		name    string
+		novelty domain.LoginNovelty
+		prefs   *mockLoginAlertPrefs
+		login   domain.Login
+	}{
+		{"known device", domain.LoginNovelty{}, &mockLoginAlertPrefs{}, testLogin},
+		{"first login after restart", domain.LoginNovelty{FirstLogin: true}, &mockLoginAlertPrefs{}, testLogin},
+		{"opted out", domain.LoginNovelty{NewDevice: true}, &mockLoginAlertPrefs{optedOut: map[string]bool{"user-1": true}}, testLogin},
+		{"no email", domain.LoginNovelty{NewLocation: true}, &mockLoginAlertPrefs{}, domain.Login{IdentityID: "user-1", SessionID: "ks-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, devices, gateway := newTestNotifyLogin(tt.novelty, tt.prefs)
+
+			assert.NoError(t, uc.Execute(context.Background(), tt.login))
+			assert.Len(t, devices.fingerprints, 1, "every login is recorded")
+			assert.Empty(t, gateway.alerts)
+		})
+	}
+}
+
+func TestNotifyLogin_Errors(t *testing.T) {
+	uc, _, gateway := newTestNotifyLogin(domain.LoginNovelty{NewDevice: true}, &mockLoginAlertPrefs{err: domain.ErrKratosUnavailable})
+	assert.ErrorIs(t, uc.Execute(context.Background(), testLogin), domain.ErrKratosUnavailable)
+	assert.Empty(t, gateway.alerts, "no alert when the preference is unknown")
+
+	uc, _, gateway = newTestNotifyLogin(domain.LoginNovelty{NewDevice: true}, &mockLoginAlertPrefs{})
+	gateway.err = errors.Join(domain.ErrNotificationFailed, errors.New("smtp down"))
+	assert.ErrorIs(t, uc.Execute(context.Background(), testLogin), domain.ErrNotificationFailed)
+}
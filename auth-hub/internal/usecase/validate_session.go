@@ -4,15 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"auth-hub/internal/domain"
 )
 
 // ValidateSession orchestrates session validation with cache-through strategy.
 type ValidateSession struct {
-	validator domain.SessionValidator
-	cache     domain.SessionCache
-	logger    *slog.Logger
+	validator  domain.SessionValidator
+	cache      domain.SessionCache
+	identities domain.IdentityCache
+	traits     domain.IdentityTraitsProvider
+	logger     *slog.Logger
 }
 
 // NewValidateSession creates a new ValidateSession usecase.
@@ -20,35 +23,80 @@ func NewValidateSession(v domain.SessionValidator, c domain.SessionCache, l *slo
 	return &ValidateSession{validator: v, cache: c, logger: l}
 }
 
+// WithIdentityCache takes identity traits from c instead of the cached
+// session, reloading them through p after an invalidation. Trait changes then
+// only cost one admin API call per identity rather than re-validating every
+// session of it.
+func (uc *ValidateSession) WithIdentityCache(c domain.IdentityCache, p domain.IdentityTraitsProvider) *ValidateSession {
+	uc.identities = c
+	uc.traits = p
+	return uc
+}
+
 // Execute validates the session identified by cookieValue.
 // Returns the identity with TenantID set (single-tenant: TenantID == UserID).
+// Concurrent validations of the same session share one Kratos call.
 func (uc *ValidateSession) Execute(ctx context.Context, cookieValue string) (*domain.Identity, error) {
-	// Check cache first
-	if cached, found := uc.cache.Get(cookieValue); found {
-		return &domain.Identity{
-			UserID:    cached.UserID,
-			TenantID:  cached.TenantID,
-			Email:     cached.Email,
-			SessionID: cookieValue,
+	cached, err := uc.cache.GetOrLoad(ctx, cookieValue, kratosSessionLoader(uc.validator, cookieValue, uc.identities))
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionIdentity(ctx, uc.identities, uc.traits, cookieValue, cached)
+}
+
+// kratosSessionLoader returns a cache loader that validates cookieValue with
+// Kratos and converts the identity into its cached form. The traits Kratos
+// returns also seed identities, when set.
+func kratosSessionLoader(v domain.SessionValidator, cookieValue string, identities domain.IdentityCache) domain.SessionLoader {
+	return func(ctx context.Context) (*domain.CachedSession, error) {
+		startedAt := time.Now()
+		fullCookie := fmt.Sprintf("ory_kratos_session=%s", cookieValue)
+		identity, err := v.ValidateSession(ctx, fullCookie)
+		if err != nil {
+			return nil, err
+		}
+		if identities != nil {
+			identities.SetTraits(identity.UserID, domain.IdentityTraits{
+				Email: identity.Email,
+				Name:  identity.Name,
+				Role:  identity.Role,
+			}, startedAt)
+		}
+		return &domain.CachedSession{
+			UserID:          identity.UserID,
+			TenantID:        identity.UserID, // Single-tenant: tenant == user
+			Email:           identity.Email,
+			Name:            identity.Name,
+			Role:            identity.Role,
+			KratosSessionID: identity.SessionID,
+			CreatedAt:       identity.CreatedAt,
 		}, nil
 	}
+}
 
-	// Cache miss – validate with Kratos
-	fullCookie := fmt.Sprintf("ory_kratos_session=%s", cookieValue)
-	identity, err := uc.validator.ValidateSession(ctx, fullCookie)
-	if err != nil {
-		return nil, err
+// sessionIdentity builds the identity of a validated session. With an
+// identity cache the traits come from it; a failed reload is an error rather
+// than a fallback to the session's traits, which may carry a revoked role.
+func sessionIdentity(ctx context.Context, identities domain.IdentityCache, provider domain.IdentityTraitsProvider, cookieValue string, cached *domain.CachedSession) (*domain.Identity, error) {
+	traits := domain.IdentityTraits{Email: cached.Email, Name: cached.Name, Role: cached.Role}
+	if identities != nil {
+		loaded, err := identities.GetOrLoadTraits(ctx, cached.UserID, func(ctx context.Context) (*domain.IdentityTraits, error) {
+			return provider.GetIdentityTraits(ctx, cached.UserID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		traits = *loaded
 	}
 
-	// Store in cache (single-tenant: TenantID == UserID)
-	uc.cache.Set(cookieValue, domain.CachedSession{
-		UserID:    identity.UserID,
-		TenantID:  identity.UserID,
-		Email:     identity.Email,
-		CreatedAt: identity.CreatedAt,
-	})
-
-	identity.SessionID = cookieValue
-	identity.TenantID = identity.UserID // Single-tenant fallback
-	return identity, nil
+	return &domain.Identity{
+		UserID:    cached.UserID,
+		TenantID:  cached.TenantID,
+		Email:     traits.Email,
+		Name:      traits.Name,
+		Role:      traits.Role,
+		SessionID: cookieValue,
+		CreatedAt: cached.CreatedAt,
+	}, nil
 }
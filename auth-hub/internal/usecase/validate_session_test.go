@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"auth-hub/internal/domain"
 
@@ -46,6 +47,18 @@ func (m *mockCache) Set(sessionID string, session domain.CachedSession) {
 	m.entries[sessionID] = session
 }
 
+func (m *mockCache) GetOrLoad(ctx context.Context, sessionID string, load domain.SessionLoader) (*domain.CachedSession, error) {
+	if cached, found := m.Get(sessionID); found {
+		return cached, nil
+	}
+	session, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.Set(sessionID, *session)
+	return session, nil
+}
+
 func TestValidateSession_CacheHit(t *testing.T) {
 	cache := newMockCache()
 	cache.Set("session-abc", domain.CachedSession{
@@ -105,3 +118,109 @@ func TestValidateSession_KratosError(t *testing.T) {
 	assert.Nil(t, identity)
 	assert.True(t, errors.Is(err, domain.ErrAuthFailed))
 }
+
+// mockIdentityCache implements domain.IdentityCache for testing.
+type mockIdentityCache struct {
+	traits      map[string]domain.IdentityTraits
+	systemUser  string
+	invalidated []string
+}
+
+func newMockIdentityCache() *mockIdentityCache {
+	return &mockIdentityCache{traits: make(map[string]domain.IdentityTraits)}
+}
+
+func (m *mockIdentityCache) SetTraits(identityID string, traits domain.IdentityTraits, _ time.Time) {
+	m.traits[identityID] = traits
+}
+
+func (m *mockIdentityCache) GetOrLoadTraits(ctx context.Context, identityID string, load domain.IdentityTraitsLoader) (*domain.IdentityTraits, error) {
+	if traits, found := m.traits[identityID]; found {
+		return &traits, nil
+	}
+	traits, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.traits[identityID] = *traits
+	return traits, nil
+}
+
+func (m *mockIdentityCache) GetOrLoadSystemUser(ctx context.Context, load domain.SystemUserLoader) (string, error) {
+	if m.systemUser != "" {
+		return m.systemUser, nil
+	}
+	userID, err := load(ctx)
+	if err != nil {
+		return "", err
+	}
+	m.systemUser = userID
+	return userID, nil
+}
+
+func (m *mockIdentityCache) InvalidateTraits(identityID string) int {
+	m.invalidated = append(m.invalidated, identityID)
+	if _, found := m.traits[identityID]; !found {
+		return 0
+	}
+	delete(m.traits, identityID)
+	return 1
+}
+
+// mockTraitsProvider implements domain.IdentityTraitsProvider for testing.
+type mockTraitsProvider struct {
+	traits *domain.IdentityTraits
+	err    error
+	calls  int
+}
+
+func (m *mockTraitsProvider) GetIdentityTraits(_ context.Context, _ string) (*domain.IdentityTraits, error) {
+	m.calls++
+	return m.traits, m.err
+}
+
+func TestValidateSession_IdentityCache_SeededFromSession(t *testing.T) {
+	cache := newMockCache()
+	identities := newMockIdentityCache()
+	validator := &mockValidator{
+		identity: &domain.Identity{UserID: "user-1", Email: "a@example.com", Name: "Ada Lovelace", Role: "admin"},
+	}
+	provider := &mockTraitsProvider{}
+
+	uc := NewValidateSession(validator, cache, slog.Default()).WithIdentityCache(identities, provider)
+	identity, err := uc.Execute(context.Background(), "session-abc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", identity.Name)
+	assert.Equal(t, "admin", identity.Role)
+	assert.Equal(t, domain.IdentityTraits{Email: "a@example.com", Name: "Ada Lovelace", Role: "admin"}, identities.traits["user-1"])
+	assert.Zero(t, provider.calls, "traits from the session load need no admin call")
+}
+
+func TestValidateSession_IdentityCache_ReloadsInvalidatedTraits(t *testing.T) {
+	cache := newMockCache()
+	cache.Set("session-abc", domain.CachedSession{UserID: "user-1", TenantID: "user-1", Email: "a@example.com", Role: "admin"})
+	identities := newMockIdentityCache()
+	validator := &mockValidator{}
+	provider := &mockTraitsProvider{traits: &domain.IdentityTraits{Email: "a@example.com", Role: "user"}}
+
+	uc := NewValidateSession(validator, cache, slog.Default()).WithIdentityCache(identities, provider)
+	identity, err := uc.Execute(context.Background(), "session-abc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user", identity.Role, "the demoted role replaces the cached session's")
+	assert.Equal(t, 1, provider.calls)
+	assert.False(t, validator.called, "the session itself stays cached")
+}
+
+func TestValidateSession_IdentityCache_LoadError(t *testing.T) {
+	cache := newMockCache()
+	cache.Set("session-abc", domain.CachedSession{UserID: "user-1", TenantID: "user-1", Role: "admin"})
+	provider := &mockTraitsProvider{err: domain.ErrKratosUnavailable}
+
+	uc := NewValidateSession(&mockValidator{}, cache, slog.Default()).WithIdentityCache(newMockIdentityCache(), provider)
+	identity, err := uc.Execute(context.Background(), "session-abc")
+
+	assert.Nil(t, identity)
+	assert.True(t, errors.Is(err, domain.ErrKratosUnavailable))
+}
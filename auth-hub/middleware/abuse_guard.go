@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AbuseGuardConfig defines when an endpoint group blocks a client.
+type AbuseGuardConfig struct {
+	// Endpoint names the group in security events.
+	Endpoint string
+	// Codes are the problem codes that count as a failed attempt.
+	Codes []problem.Code
+	// Threshold is the number of failures within Window that blocks the
+	// client. Zero disables the guard.
+	Threshold int
+	// Window is how far back failures are counted.
+	Window time.Duration
+	// BanBase is the first block's duration; each further block while
+	// earlier ones are remembered doubles it.
+	BanBase time.Duration
+	// BanMax caps the block duration.
+	BanMax time.Duration
+	// StrikeTTL is how long a block is remembered for escalation.
+	StrikeTTL time.Duration
+}
+
+// abuseState tracks one client's recent failures and blocks.
+type abuseState struct {
+	failures    []time.Time // at most Threshold, oldest first
+	bannedUntil time.Time
+	strikes     int
+	lastStrike  time.Time
+	lastSeen    time.Time
+}
+
+// AbuseGuard blocks clients that keep failing authentication on an endpoint
+// group, e.g. many unknown session tokens or CSRF mismatches from one IP.
+// Unlike RateLimiter it only counts failed requests, so it catches slow
+// guessing that stays under the rate limit, and blocks for longer each time
+// a client comes back.
+type AbuseGuard struct {
+	cfg     AbuseGuardConfig
+	mu      sync.Mutex
+	clients map[string]*abuseState
+	// clientKey keys the client hashes in security events. It is random
+	// per process, so events never carry a reversible IP.
+	clientKey []byte
+	now       func() time.Time
+}
+
+// NewAbuseGuard creates a per-IP abuse guard.
+func NewAbuseGuard(cfg AbuseGuardConfig) *AbuseGuard {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	g := &AbuseGuard{
+		cfg:       cfg,
+		clients:   make(map[string]*abuseState),
+		clientKey: key,
+		now:       time.Now,
+	}
+	if cfg.Threshold > 0 {
+		go g.cleanupLoop()
+	}
+	return g
+}
+
+// blockedFor returns how long ip is still blocked, or zero.
+func (g *AbuseGuard) blockedFor(ip string) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	s, exists := g.clients[ip]
+	if !exists {
+		return 0
+	}
+	return max(s.bannedUntil.Sub(g.now()), 0)
+}
+
+// recordFailure counts a failure from ip and returns the block it triggers,
+// or zero.
+func (g *AbuseGuard) recordFailure(ip string) (ban time.Duration, strikes int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	s, exists := g.clients[ip]
+	if !exists {
+		s = &abuseState{}
+		g.clients[ip] = s
+	}
+	s.lastSeen = now
+
+	cutoff := now.Add(-g.cfg.Window)
+	s.failures = slices.DeleteFunc(s.failures, func(t time.Time) bool { return !t.After(cutoff) })
+	s.failures = append(s.failures, now)
+	if len(s.failures) < g.cfg.Threshold {
+		return 0, 0
+	}
+
+	if now.Sub(s.lastStrike) > g.cfg.StrikeTTL {
+		s.strikes = 0
+	}
+	s.strikes++
+	s.lastStrike = now
+	s.failures = s.failures[:0]
+
+	ban = g.banDuration(s.strikes)
+	s.bannedUntil = now.Add(ban)
+	return ban, s.strikes
+}
+
+// banDuration doubles BanBase for every strike after the first, up to BanMax.
+func (g *AbuseGuard) banDuration(strikes int) time.Duration {
+	ban := float64(g.cfg.BanBase) * math.Pow(2, float64(strikes-1))
+	if ban >= float64(g.cfg.BanMax) {
+		return g.cfg.BanMax
+	}
+	return time.Duration(ban)
+}
+
+// counts reports whether err is a failure the guard counts.
+func (g *AbuseGuard) counts(err error) bool {
+	var he *echo.HTTPError
+	if !errors.As(err, &he) {
+		return false
+	}
+	p, ok := he.Message.(*problem.Problem)
+	return ok && slices.Contains(g.cfg.Codes, p.Code)
+}
+
+// clientHash identifies ip in security events without revealing it.
+func (g *AbuseGuard) clientHash(ip string) string {
+	mac := hmac.New(sha256.New, g.clientKey)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// cleanupLoop removes clients that are neither blocked nor remembered every
+// 3 minutes.
+func (g *AbuseGuard) cleanupLoop() {
+	ticker := time.NewTicker(3 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		g.cleanup()
+	}
+}
+
+func (g *AbuseGuard) cleanup() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	for ip, s := range g.clients {
+		if now.Before(s.bannedUntil) {
+			continue
+		}
+		if now.Sub(s.lastSeen) > g.cfg.Window && now.Sub(s.lastStrike) > g.cfg.StrikeTTL {
+			delete(g.clients, ip)
+		}
+	}
+}
+
+// Middleware returns an Echo middleware that rejects blocked clients and
+// counts the failures of the rest.
+func (g *AbuseGuard) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if g.cfg.Threshold <= 0 {
+			return next
+		}
+		return func(c echo.Context) error {
+			ip := c.RealIP()
+
+			if remaining := g.blockedFor(ip); remaining > 0 {
+				retryAfter := max(int(math.Ceil(remaining.Seconds())), 1)
+				c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				return echo.NewHTTPError(http.StatusTooManyRequests,
+					problem.New(http.StatusTooManyRequests, problem.ClientBlocked, "too many failed attempts"))
+			}
+
+			err := next(c)
+			if g.counts(err) {
+				if ban, strikes := g.recordFailure(ip); ban > 0 {
+					slog.WarnContext(c.Request().Context(), "security event: client blocked after repeated failures",
+						"security_event", "abuse_block",
+						"endpoint", g.cfg.Endpoint,
+						"client", g.clientHash(ip),
+						"failures", g.cfg.Threshold,
+						"window", g.cfg.Window.String(),
+						"ban", ban.String(),
+						"strike", strikes)
+				}
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAbuseGuardTest returns a guard with a settable clock and an Echo
+// instance whose /test route fails with the code in the X-Fail header.
+func newAbuseGuardTest(cfg AbuseGuardConfig) (*AbuseGuard, *echo.Echo, *time.Time) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	g := NewAbuseGuard(cfg)
+	g.now = func() time.Time { return now }
+
+	e := echo.New()
+	e.GET("/test", func(c echo.Context) error {
+		if code := c.Request().Header.Get("X-Fail"); code != "" {
+			return echo.NewHTTPError(http.StatusUnauthorized,
+				problem.New(http.StatusUnauthorized, problem.Code(code), "failed"))
+		}
+		return c.String(http.StatusOK, "ok")
+	}, g.Middleware())
+	return g, e, &now
+}
+
+func abuseGuardConfig() AbuseGuardConfig {
+	return AbuseGuardConfig{
+		Endpoint:  "test",
+		Codes:     []problem.Code{problem.AuthenticationRequired},
+		Threshold: 3,
+		Window:    time.Minute,
+		BanBase:   time.Minute,
+		BanMax:    5 * time.Minute,
+		StrikeTTL: time.Hour,
+	}
+}
+
+func serveFrom(e *echo.Echo, ip, failCode string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = ip + ":1234"
+	if failCode != "" {
+		req.Header.Set("X-Fail", failCode)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAbuseGuard_BlocksAfterThreshold(t *testing.T) {
+	_, e, _ := newAbuseGuardTest(abuseGuardConfig())
+
+	for range 3 {
+		assert.Equal(t, http.StatusUnauthorized, serveFrom(e, "1.2.3.4", "authentication_required").Code)
+	}
+
+	rec := serveFrom(e, "1.2.3.4", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "blocked even with a valid request")
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), string(problem.ClientBlocked))
+
+	assert.Equal(t, http.StatusOK, serveFrom(e, "5.6.7.8", "").Code, "other clients are not blocked")
+}
+
+func TestAbuseGuard_CountsOnlyConfiguredCodes(t *testing.T) {
+	_, e, _ := newAbuseGuardTest(abuseGuardConfig())
+
+	for range 10 {
+		serveFrom(e, "1.2.3.4", "session_expired")
+		serveFrom(e, "1.2.3.4", "")
+	}
+
+	assert.Equal(t, http.StatusOK, serveFrom(e, "1.2.3.4", "").Code)
+}
+
+func TestAbuseGuard_FailuresOutsideWindowAreForgotten(t *testing.T) {
+	_, e, now := newAbuseGuardTest(abuseGuardConfig())
+
+	serveFrom(e, "1.2.3.4", "authentication_required")
+	serveFrom(e, "1.2.3.4", "authentication_required")
+	*now = now.Add(2 * time.Minute)
+	serveFrom(e, "1.2.3.4", "authentication_required")
+
+	assert.Equal(t, http.StatusOK, serveFrom(e, "1.2.3.4", "").Code)
+}
+
+func TestAbuseGuard_BanDoublesUpToMax(t *testing.T) {
+	_, e, now := newAbuseGuardTest(abuseGuardConfig())
+
+	for _, want := range []string{"60", "120", "240", "300", "300"} {
+		for range 3 {
+			serveFrom(e, "1.2.3.4", "authentication_required")
+		}
+		rec := serveFrom(e, "1.2.3.4", "")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, want, rec.Header().Get("Retry-After"))
+
+		*now = now.Add(10 * time.Minute) // past the ban, within StrikeTTL
+		assert.Equal(t, http.StatusOK, serveFrom(e, "1.2.3.4", "").Code)
+	}
+}
+
+func TestAbuseGuard_StrikesExpire(t *testing.T) {
+	_, e, now := newAbuseGuardTest(abuseGuardConfig())
+
+	for range 2 {
+		for range 3 {
+			serveFrom(e, "1.2.3.4", "authentication_required")
+		}
+		*now = now.Add(2 * time.Hour) // past StrikeTTL
+	}
+
+	for range 3 {
+		serveFrom(e, "1.2.3.4", "authentication_required")
+	}
+	assert.Equal(t, "60", serveFrom(e, "1.2.3.4", "").Header().Get("Retry-After"))
+}
+
+func TestAbuseGuard_ZeroThresholdDisables(t *testing.T) {
+	cfg := abuseGuardConfig()
+	cfg.Threshold = 0
+	_, e, _ := newAbuseGuardTest(cfg)
+
+	for range 10 {
+		serveFrom(e, "1.2.3.4", "authentication_required")
+	}
+
+	assert.Equal(t, http.StatusOK, serveFrom(e, "1.2.3.4", "").Code)
+}
+
+func TestAbuseGuard_CleanupKeepsRememberedClients(t *testing.T) {
+	g, e, now := newAbuseGuardTest(abuseGuardConfig())
+
+	for range 3 {
+		serveFrom(e, "1.2.3.4", "authentication_required")
+	}
+	serveFrom(e, "5.6.7.8", "authentication_required")
+
+	*now = now.Add(10 * time.Minute)
+	g.cleanup()
+	assert.Contains(t, g.clients, "1.2.3.4", "strike still counts towards the next ban")
+	assert.NotContains(t, g.clients, "5.6.7.8")
+
+	*now = now.Add(2 * time.Hour)
+	g.cleanup()
+	assert.Empty(t, g.clients)
+}
+
+func TestAbuseGuard_ClientHashHidesIP(t *testing.T) {
+	g := NewAbuseGuard(abuseGuardConfig())
+
+	h := g.clientHash("1.2.3.4")
+	assert.Len(t, h, 16)
+	assert.NotContains(t, h, "1.2.3.4")
+	assert.Equal(t, h, g.clientHash("1.2.3.4"))
+	assert.NotEqual(t, h, g.clientHash("5.6.7.8"))
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/subtle"
 	"net/http"
 
+	"auth-hub/problem"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -17,10 +19,12 @@ func InternalAuth(sharedSecret string) echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			provided := []byte(c.Request().Header.Get(internalAuthHeader))
 			if len(provided) == 0 {
-				return echo.NewHTTPError(http.StatusUnauthorized, "missing internal auth header")
+				return echo.NewHTTPError(http.StatusUnauthorized,
+					problem.New(http.StatusUnauthorized, problem.InternalAuthRequired, "missing internal auth header"))
 			}
 			if subtle.ConstantTimeCompare(provided, secretBytes) != 1 {
-				return echo.NewHTTPError(http.StatusForbidden, "invalid internal auth")
+				return echo.NewHTTPError(http.StatusForbidden,
+					problem.New(http.StatusForbidden, problem.InternalAuthInvalid, "invalid internal auth"))
 			}
 			return next(c)
 		}
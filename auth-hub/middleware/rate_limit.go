@@ -6,6 +6,8 @@ import (
 	"sync"
 	"time"
 
+	"auth-hub/problem"
+
 	"github.com/labstack/echo/v4"
 	"golang.org/x/time/rate"
 )
@@ -84,7 +86,8 @@ func (rl *RateLimiter) Middleware() echo.MiddlewareFunc {
 			if !limiter.Allow() {
 				retryAfter := max(int(1.0/float64(rl.rate)), 1)
 				c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
-				return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+				return echo.NewHTTPError(http.StatusTooManyRequests,
+					problem.New(http.StatusTooManyRequests, problem.RateLimited, "rate limit exceeded"))
 			}
 
 			return next(c)
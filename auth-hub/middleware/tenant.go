@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"auth-hub/problem"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultTenantID is the tenant every request belongs to when no tenants
+// are configured.
+const DefaultTenantID = "default"
+
+const tenantContextKey = "tenant_id"
+
+// TenantResolver maps a request to the Alt instance it is for.
+type TenantResolver struct {
+	header string
+	ids    map[string]bool
+	hosts  map[string]string
+}
+
+// TenantRoute is how one tenant is recognised: its ID, which the reverse
+// proxy may inject in a header, and the hosts it is served on.
+type TenantRoute struct {
+	ID    string
+	Hosts []string
+}
+
+// NewTenantResolver creates a resolver. With no routes every request
+// resolves to DefaultTenantID and the header and host are ignored.
+func NewTenantResolver(header string, routes []TenantRoute) *TenantResolver {
+	r := &TenantResolver{header: header, ids: map[string]bool{}, hosts: map[string]string{}}
+	for _, route := range routes {
+		r.ids[route.ID] = true
+		for _, h := range route.Hosts {
+			r.hosts[strings.ToLower(h)] = route.ID
+		}
+	}
+	return r
+}
+
+// Resolve returns the tenant for req. The injected header wins, but when
+// the host also names a tenant the two must agree, so a client-supplied
+// header that the proxy failed to strip cannot switch tenants on a known
+// host. A request matching no tenant is not served rather than falling
+// back to another instance.
+func (r *TenantResolver) Resolve(req *http.Request) (string, bool) {
+	if len(r.ids) == 0 {
+		return DefaultTenantID, true
+	}
+
+	hostTenant, hostKnown := r.hosts[requestHost(req)]
+	if id := strings.TrimSpace(req.Header.Get(r.header)); id != "" {
+		if !r.ids[id] || (hostKnown && hostTenant != id) {
+			return "", false
+		}
+		return id, true
+	}
+	return hostTenant, hostKnown
+}
+
+// Middleware stores the resolved tenant on the echo context and rejects
+// requests that match none.
+func (r *TenantResolver) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id, ok := r.Resolve(c.Request())
+			if !ok {
+				return echo.NewHTTPError(http.StatusNotFound,
+					problem.New(http.StatusNotFound, problem.UnknownTenant, "unknown tenant"))
+			}
+			c.Set(tenantContextKey, id)
+			return next(c)
+		}
+	}
+}
+
+// TenantID returns the tenant stored by TenantResolver.Middleware, or ""
+// when the middleware did not run.
+func TenantID(c echo.Context) string {
+	id, _ := c.Get(tenantContextKey).(string)
+	return id
+}
+
+// requestHost is the lowercased Host header without its port.
+func requestHost(req *http.Request) string {
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTenantResolver() *TenantResolver {
+	return NewTenantResolver("X-Auth-Hub-Tenant", []TenantRoute{
+		{ID: "alpha", Hosts: []string{"alpha.example.com"}},
+		{ID: "beta", Hosts: []string{"Beta.Example.com", "beta.internal"}},
+		{ID: "gamma"},
+	})
+}
+
+func TestTenantResolver_NoTenantsResolvesDefault(t *testing.T) {
+	r := NewTenantResolver("X-Auth-Hub-Tenant", nil)
+	req := httptest.NewRequest(http.MethodGet, "http://anything.example.com/validate", nil)
+	req.Header.Set("X-Auth-Hub-Tenant", "alpha")
+
+	id, ok := r.Resolve(req)
+	assert.True(t, ok)
+	assert.Equal(t, DefaultTenantID, id)
+}
+
+func TestTenantResolver_Resolve(t *testing.T) {
+	r := newTestTenantResolver()
+	tests := []struct {
+		name   string
+		host   string
+		header string
+		want   string
+		wantOK bool
+	}{
+		{name: "host", host: "alpha.example.com", want: "alpha", wantOK: true},
+		{name: "host with port and case", host: "BETA.example.com:8443", want: "beta", wantOK: true},
+		{name: "header on unmapped host", host: "auth-hub:8888", header: "gamma", want: "gamma", wantOK: true},
+		{name: "header agreeing with host", host: "beta.internal", header: "beta", want: "beta", wantOK: true},
+		{name: "header contradicting host", host: "alpha.example.com", header: "beta", wantOK: false},
+		{name: "unknown header", host: "auth-hub:8888", header: "delta", wantOK: false},
+		{name: "unknown host", host: "evil.example.com", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/validate", nil)
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set("X-Auth-Hub-Tenant", tt.header)
+			}
+			id, ok := r.Resolve(req)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, id)
+		})
+	}
+}
+
+func TestTenantResolver_Middleware(t *testing.T) {
+	e := echo.New()
+	e.Use(newTestTenantResolver().Middleware())
+	e.GET("/validate", func(c echo.Context) error {
+		return c.String(http.StatusOK, TenantID(c))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/validate", nil)
+	req.Host = "alpha.example.com"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "alpha", rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/validate", nil)
+	req.Host = "unknown.example.com"
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,169 @@
+// Package problem defines the RFC 7807 problem+json bodies auth-hub returns
+// for failed requests, and the machine-readable codes they carry. It lives
+// outside internal/ so callers such as alt-butterfly-facade can decode a
+// response and branch on Code rather than on the human-readable detail.
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// ContentType is the media type of a problem body.
+const ContentType = "application/problem+json"
+
+// typePrefix namespaces problem types; the code is appended to it.
+const typePrefix = "urn:alt:auth-hub:problem:"
+
+// Code identifies what went wrong. Codes are part of auth-hub's API: add new
+// ones freely, but never change or reuse an existing one.
+type Code string
+
+// Session codes.
+const (
+	// SessionRequired means the request carried no Kratos session cookie.
+	SessionRequired Code = "session_required"
+	// SessionExpired means the session existed but is past its expiry; the
+	// user has to log in again.
+	SessionExpired Code = "session_expired"
+	// AuthenticationRequired covers sessions that are unknown, inactive or
+	// otherwise rejected by Kratos.
+	AuthenticationRequired Code = "authentication_required"
+	// ReauthenticationRequired means the session is used from a client it
+	// was not bound to and must be confirmed by logging in again.
+	ReauthenticationRequired Code = "reauthentication_required"
+)
+
+// CSRF and token codes.
+const (
+	// CSRFMismatch means the CSRF token was invalid, expired or issued for
+	// another session; fetching a new one and retrying is enough.
+	CSRFMismatch          Code = "csrf_mismatch"
+	TokenGenerationFailed Code = "token_generation_failed"
+	UnknownAudience       Code = "unknown_audience"
+)
+
+// Upstream codes.
+const (
+	// KratosUnavailable means auth-hub could not reach Kratos; the request
+	// may succeed if retried.
+	KratosUnavailable  Code = "kratos_unavailable"
+	NotificationFailed Code = "notification_failed"
+)
+
+// Request and resource codes.
+const (
+	InvalidRequest         Code = "invalid_request"
+	InvalidLifecycleEvent  Code = "invalid_lifecycle_event"
+	InvalidLink            Code = "invalid_link"
+	LinkedAccountNotFound  Code = "linked_account_not_found"
+	LastSignInMethod       Code = "last_sign_in_method"
+	ServiceAccountNotFound Code = "service_account_not_found"
+	ServiceAccountExists   Code = "service_account_exists"
+	UnknownTenant          Code = "unknown_tenant"
+	InternalAuthRequired   Code = "internal_auth_required"
+	InternalAuthInvalid    Code = "internal_auth_invalid"
+	RateLimited            Code = "rate_limited"
+	NotFound               Code = "not_found"
+	MethodNotAllowed       Code = "method_not_allowed"
+	ConfigurationError     Code = "configuration_error"
+	InternalError          Code = "internal_error"
+	UnexpectedStatus       Code = "unexpected_status"
+	ServiceUnavailable     Code = "service_unavailable"
+	// ClientBlocked means the client is blocked for a while after too many
+	// failed attempts; Retry-After says for how long.
+	ClientBlocked Code = "client_blocked"
+)
+
+// Problem is an RFC 7807 problem details object with an auth-hub Code as
+// extension member. It implements error so handlers can return it.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     Code   `json:"code"`
+}
+
+// New returns a problem for status with the status text as title.
+func New(status int, code Code, detail string) *Problem {
+	return &Problem{
+		Type:   typePrefix + string(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// ForStatus returns a problem for a status that has no more specific code,
+// such as Echo's own 404 and 405 responses.
+func ForStatus(status int, detail string) *Problem {
+	return New(status, codeForStatus(status), detail)
+}
+
+func codeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return InvalidRequest
+	case http.StatusUnauthorized:
+		return AuthenticationRequired
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusMethodNotAllowed:
+		return MethodNotAllowed
+	case http.StatusTooManyRequests:
+		return RateLimited
+	case http.StatusServiceUnavailable:
+		return ServiceUnavailable
+	case http.StatusInternalServerError:
+		return InternalError
+	default:
+		return UnexpectedStatus
+	}
+}
+
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return fmt.Sprintf("%d %s", p.Status, p.Code)
+	}
+	return fmt.Sprintf("%d %s: %s", p.Status, p.Code, p.Detail)
+}
+
+// Write sends the problem as the response.
+func (p *Problem) Write(w http.ResponseWriter) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_, err = w.Write(body)
+	return err
+}
+
+// maxBodyBytes bounds how much of a response Read decodes.
+const maxBodyBytes = 64 << 10
+
+// Read decodes the problem in an auth-hub error response. It reports false
+// when the response is not problem+json, e.g. one from nginx in front of
+// auth-hub, in which case callers fall back to the status code. The body is
+// consumed but not closed.
+func Read(resp *http.Response) (*Problem, bool) {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != ContentType {
+		return nil, false
+	}
+	var p Problem
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxBodyBytes)).Decode(&p); err != nil || p.Code == "" {
+		return nil, false
+	}
+	if p.Status == 0 {
+		p.Status = resp.StatusCode
+	}
+	return &p, true
+}
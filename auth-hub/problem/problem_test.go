@@ -0,0 +1,40 @@
+package problem
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	rec := httptest.NewRecorder()
+	require.NoError(t, New(http.StatusUnauthorized, SessionExpired, "session expired").Write(rec))
+
+	p, ok := Read(rec.Result())
+	require.True(t, ok)
+	assert.Equal(t, &Problem{
+		Type:   "urn:alt:auth-hub:problem:session_expired",
+		Title:  "Unauthorized",
+		Status: http.StatusUnauthorized,
+		Detail: "session expired",
+		Code:   SessionExpired,
+	}, p)
+}
+
+func TestRead_NotAProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "text/html")
+	rec.WriteHeader(http.StatusBadGateway)
+	_, _ = rec.WriteString("<html>502 Bad Gateway</html>")
+
+	_, ok := Read(rec.Result())
+	assert.False(t, ok)
+}
+
+func TestForStatus(t *testing.T) {
+	assert.Equal(t, NotFound, ForStatus(http.StatusNotFound, "Not Found").Code)
+	assert.Equal(t, UnexpectedStatus, ForStatus(http.StatusTeapot, "").Code)
+}
@@ -0,0 +1,34 @@
+-- Search Events Table
+-- One row per search served by search-indexer (query, filters, latency,
+-- result count), written in batches by its analytics sink over the HTTP
+-- interface. Warm-up probes and searches rejected by input validation are
+-- not recorded.
+--
+-- Created: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS search_events (
+    timestamp DateTime64(3, 'UTC') CODEC(DoubleDelta, ZSTD(1)),
+
+    -- Entry point: articles, articles_date_filter, articles_page, by_user, by_user_page
+    kind LowCardinality(String),
+
+    -- Sanitized query as sent to Meilisearch
+    query String CODEC(ZSTD(1)),
+    user_id String,
+
+    -- Filters
+    published_after Nullable(DateTime('UTC')),
+    published_before Nullable(DateTime('UTC')),
+    offset Int64,
+    limit UInt32,
+
+    -- Outcome
+    result_count UInt32,
+    estimated_total_hits Int64,
+    latency_ms Float64,
+    error String
+) ENGINE = MergeTree()
+PARTITION BY toYYYYMMDD(timestamp)
+ORDER BY (kind, timestamp)
+TTL toDateTime(timestamp) + INTERVAL 90 DAY DELETE
+SETTINGS ttl_only_drop_parts = 1;
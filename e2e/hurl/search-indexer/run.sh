@@ -64,6 +64,10 @@ source "$ROOT/e2e/hurl/_lib/compose-up-with-retry.sh"
 # rotates both.
 MEILI_MASTER_KEY="$(tr -d '\n' < "$ROOT/e2e/fixtures/staging-secrets/meili_master_key.txt")"
 
+# /v1/search API key for the "e2e" caller; must match SEARCH_API_KEYS on
+# the search-indexer service in compose.staging.yaml.
+SEARCH_API_KEY="staging-e2e-search-key"
+
 REPORT_DIR="$ROOT/e2e/reports/search-indexer-$RUN_ID"
 mkdir -p "$REPORT_DIR"
 
@@ -106,6 +110,7 @@ hurl_run --test \
   --retry-interval 500 \
   --file-root "$ROOT" \
   --secret "meili_master_key=$MEILI_MASTER_KEY" \
+  --secret "search_api_key=$SEARCH_API_KEY" \
   --report-junit "$REPORT_DIR/junit.xml" \
   --report-html  "$REPORT_DIR/html" \
   e2e/hurl/search-indexer/0[1-9]-*.hurl
@@ -11,16 +11,23 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// ErrLensRevisionConflict is returned by lens writes whose expected revision
+// is no longer current: another client changed the lens first.
+var ErrLensRevisionConflict = errors.New("lens revision conflict")
+
 // KnowledgeLens represents a saved viewpoint.
 type KnowledgeLens struct {
-	LensID         uuid.UUID
-	UserID         uuid.UUID
-	TenantID       uuid.UUID
-	Name           string
-	Description    string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	ArchivedAt     *time.Time
+	LensID      uuid.UUID
+	UserID      uuid.UUID
+	TenantID    uuid.UUID
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ArchivedAt  *time.Time
+	// Revision is bumped by every write to the lens and serves as its
+	// optimistic concurrency token.
+	Revision       int64
 	CurrentVersion *KnowledgeLensVersion
 }
 
@@ -50,7 +57,7 @@ type KnowledgeCurrentLens struct {
 // ListLenses returns lenses for a user with their current versions.
 func (r *Repository) ListLenses(ctx context.Context, userID uuid.UUID) ([]KnowledgeLens, error) {
 	query := `SELECT l.lens_id, l.user_id, l.tenant_id, l.name, l.description,
-		l.created_at, l.updated_at, l.archived_at,
+		l.created_at, l.updated_at, l.archived_at, l.revision,
 		lv.lens_version_id, lv.lens_id, lv.created_at, lv.query_text,
 		lv.tag_ids_json, lv.source_ids_json, lv.time_window_json,
 		lv.include_recap, lv.include_pulse, lv.sort_mode, lv.superseded_by
@@ -84,7 +91,7 @@ func (r *Repository) ListLenses(ctx context.Context, userID uuid.UUID) ([]Knowle
 
 		if err := rows.Scan(
 			&l.LensID, &l.UserID, &l.TenantID, &l.Name, &l.Description,
-			&l.CreatedAt, &l.UpdatedAt, &l.ArchivedAt,
+			&l.CreatedAt, &l.UpdatedAt, &l.ArchivedAt, &l.Revision,
 			&versionID, &vLensID, &vCreatedAt, &vQueryText,
 			&vTagIDsJSON, &vSourceIDsJSON, &vTimeWindowJSON,
 			&vIncludeRecap, &vIncludePulse, &vSortMode, &vSupersededBy,
@@ -131,11 +138,11 @@ func (r *Repository) ListLenses(ctx context.Context, userID uuid.UUID) ([]Knowle
 
 // GetLens returns a lens by ID.
 func (r *Repository) GetLens(ctx context.Context, lensID uuid.UUID) (*KnowledgeLens, error) {
-	query := `SELECT lens_id, user_id, tenant_id, name, description, created_at, updated_at, archived_at
+	query := `SELECT lens_id, user_id, tenant_id, name, description, created_at, updated_at, archived_at, revision
 		FROM knowledge_lenses WHERE lens_id = $1`
 	var l KnowledgeLens
 	err := r.pool.QueryRow(ctx, query, lensID).Scan(
-		&l.LensID, &l.UserID, &l.TenantID, &l.Name, &l.Description, &l.CreatedAt, &l.UpdatedAt, &l.ArchivedAt,
+		&l.LensID, &l.UserID, &l.TenantID, &l.Name, &l.Description, &l.CreatedAt, &l.UpdatedAt, &l.ArchivedAt, &l.Revision,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -212,18 +219,25 @@ func (r *Repository) ClearCurrentLens(ctx context.Context, userID uuid.UUID) err
 	return nil
 }
 
-// ArchiveLens marks a lens as archived and clears its selection.
-func (r *Repository) ArchiveLens(ctx context.Context, lensID uuid.UUID) error {
+// ArchiveLens marks a lens as archived and clears its selection. A non-zero
+// expectedRevision must match the lens revision, otherwise
+// ErrLensRevisionConflict is returned and nothing is changed.
+func (r *Repository) ArchiveLens(ctx context.Context, lensID uuid.UUID, expectedRevision int64) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("ArchiveLens begin: %w", err)
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck // no-op once Commit has succeeded
 
-	query := `UPDATE knowledge_lenses SET archived_at = now(), updated_at = now() WHERE lens_id = $1`
-	if _, err := tx.Exec(ctx, query, lensID); err != nil {
+	query := `UPDATE knowledge_lenses SET archived_at = now(), updated_at = now(), revision = revision + 1
+		WHERE lens_id = $1 AND ($2::bigint = 0 OR revision = $2)`
+	tag, err := tx.Exec(ctx, query, lensID, expectedRevision)
+	if err != nil {
 		return fmt.Errorf("ArchiveLens: %w", err)
 	}
+	if tag.RowsAffected() == 0 && expectedRevision != 0 {
+		return ErrLensRevisionConflict
+	}
 	// Clear selection if this lens was selected
 	clearQuery := `DELETE FROM knowledge_current_lens WHERE lens_id = $1`
 	if _, err := tx.Exec(ctx, clearQuery, lensID); err != nil {
@@ -246,33 +260,62 @@ func (r *Repository) CreateLens(ctx context.Context, l KnowledgeLens) error {
 	return nil
 }
 
-// CreateLensVersion inserts a new lens version.
-func (r *Repository) CreateLensVersion(ctx context.Context, v KnowledgeLensVersion) error {
+// CreateLensVersion inserts a new lens version and bumps the lens revision,
+// returning the new revision. A non-zero expectedRevision must match the
+// current one, otherwise ErrLensRevisionConflict is returned and nothing is
+// written. Archived lenses take no new versions.
+func (r *Repository) CreateLensVersion(ctx context.Context, v KnowledgeLensVersion, expectedRevision int64) (int64, error) {
 	tagIDsJSON, err := json.Marshal(v.TagIDs)
 	if err != nil {
-		return fmt.Errorf("marshal tag_ids: %w", err)
+		return 0, fmt.Errorf("marshal tag_ids: %w", err)
 	}
 	sourceIDsJSON, err := json.Marshal(v.SourceIDs)
 	if err != nil {
-		return fmt.Errorf("marshal source_ids: %w", err)
+		return 0, fmt.Errorf("marshal source_ids: %w", err)
 	}
 	timeWindowJSON, err := json.Marshal(v.TimeWindow)
 	if err != nil {
-		return fmt.Errorf("marshal time_window: %w", err)
+		return 0, fmt.Errorf("marshal time_window: %w", err)
 	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("CreateLensVersion begin: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op once Commit has succeeded
+
+	// Bumping the revision first locks the lens row, so concurrent writers
+	// with the same expected revision are serialized and all but one fail.
+	bumpQuery := `UPDATE knowledge_lenses SET revision = revision + 1, updated_at = $3
+		WHERE lens_id = $1 AND archived_at IS NULL AND ($2::bigint = 0 OR revision = $2)
+		RETURNING revision`
+	var revision int64
+	if err := tx.QueryRow(ctx, bumpQuery, v.LensID, expectedRevision, v.CreatedAt).Scan(&revision); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if expectedRevision != 0 {
+				return 0, ErrLensRevisionConflict
+			}
+			return 0, fmt.Errorf("CreateLensVersion: lens %s not found or archived", v.LensID)
+		}
+		return 0, fmt.Errorf("CreateLensVersion bump revision: %w", err)
+	}
+
 	query := `INSERT INTO knowledge_lens_versions
 		(lens_version_id, lens_id, created_at, query_text, tag_ids_json, source_ids_json,
 		 time_window_json, include_recap, include_pulse, sort_mode)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
-	_, err = r.pool.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		v.LensVersionID, v.LensID, v.CreatedAt, v.QueryText,
 		tagIDsJSON, sourceIDsJSON, timeWindowJSON,
 		v.IncludeRecap, v.IncludePulse, v.SortMode,
 	)
 	if err != nil {
-		return fmt.Errorf("CreateLensVersion: %w", err)
+		return 0, fmt.Errorf("CreateLensVersion: %w", err)
 	}
-	return nil
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("CreateLensVersion commit: %w", err)
+	}
+	return revision, nil
 }
 
 // ResolveLensFilter resolves the lens filter for a user.
@@ -0,0 +1,130 @@
+package sovereign_db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func testLensVersion(lensID uuid.UUID) KnowledgeLensVersion {
+	return KnowledgeLensVersion{
+		LensVersionID: uuid.New(),
+		LensID:        lensID,
+		CreatedAt:     time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		QueryText:     "rust",
+		TagIDs:        []string{"rust"},
+		SortMode:      "relevance",
+	}
+}
+
+func TestCreateLensVersion_BumpsRevision(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &Repository{pool: mock}
+	v := testLensVersion(uuid.New())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE knowledge_lenses SET revision = revision \+ 1`).
+		WithArgs(v.LensID, int64(3), v.CreatedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"revision"}).AddRow(int64(4)))
+	mock.ExpectExec(`INSERT INTO knowledge_lens_versions`).
+		WithArgs(v.LensVersionID, v.LensID, v.CreatedAt, v.QueryText,
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			v.IncludeRecap, v.IncludePulse, v.SortMode).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	revision, err := repo.CreateLensVersion(context.Background(), v, 3)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), revision)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateLensVersion_StaleRevisionConflicts(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &Repository{pool: mock}
+	v := testLensVersion(uuid.New())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE knowledge_lenses SET revision = revision \+ 1`).
+		WithArgs(v.LensID, int64(2), v.CreatedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"revision"}))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateLensVersion(context.Background(), v, 2)
+	require.True(t, errors.Is(err, ErrLensRevisionConflict), "got %v", err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateLensVersion_UnconditionalOnMissingLens(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := &Repository{pool: mock}
+	v := testLensVersion(uuid.New())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE knowledge_lenses SET revision = revision \+ 1`).
+		WithArgs(v.LensID, int64(0), v.CreatedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"revision"}))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateLensVersion(context.Background(), v, 0)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrLensRevisionConflict))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArchiveLens_Revision(t *testing.T) {
+	for name, tc := range map[string]struct {
+		expected int64
+		rows     int64
+		wantErr  error
+	}{
+		"matching revision":  {expected: 5, rows: 1},
+		"stale revision":     {expected: 4, rows: 0, wantErr: ErrLensRevisionConflict},
+		"unconditional":      {expected: 0, rows: 1},
+		"unconditional noop": {expected: 0, rows: 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer mock.Close()
+
+			repo := &Repository{pool: mock}
+			lensID := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(`UPDATE knowledge_lenses SET archived_at = now\(\)`).
+				WithArgs(lensID, tc.expected).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tc.rows))
+			if tc.wantErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(`DELETE FROM knowledge_current_lens`).
+					WithArgs(lensID).
+					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				mock.ExpectCommit()
+			}
+
+			err = repo.ArchiveLens(context.Background(), lensID, tc.expected)
+			if tc.wantErr != nil {
+				require.True(t, errors.Is(err, tc.wantErr), "got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
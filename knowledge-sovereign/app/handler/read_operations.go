@@ -62,10 +62,10 @@ type ReadOperations interface {
 	GetCurrentLensSelection(ctx context.Context, userID uuid.UUID) (*sovereign_db.KnowledgeCurrentLens, error)
 	ResolveLensFilter(ctx context.Context, userID uuid.UUID, lensID *uuid.UUID) (*sovereign_db.LensFilter, error)
 	CreateLens(ctx context.Context, l sovereign_db.KnowledgeLens) error
-	CreateLensVersion(ctx context.Context, v sovereign_db.KnowledgeLensVersion) error
+	CreateLensVersion(ctx context.Context, v sovereign_db.KnowledgeLensVersion, expectedRevision int64) (int64, error)
 	SelectCurrentLens(ctx context.Context, c sovereign_db.KnowledgeCurrentLens) error
 	ClearCurrentLens(ctx context.Context, userID uuid.UUID) error
-	ArchiveLens(ctx context.Context, lensID uuid.UUID) error
+	ArchiveLens(ctx context.Context, lensID uuid.UUID, expectedRevision int64) error
 
 	// Recall signals
 	ListRecallSignalsByUser(ctx context.Context, userID uuid.UUID, sinceDays int) ([]sovereign_db.RecallSignal, error)
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"connectrpc.com/connect"
@@ -178,10 +179,11 @@ func (h *SovereignHandler) CreateLensVersion(
 		SortMode:      pv.SortMode,
 		CreatedAt:     pv.CreatedAt.AsTime(),
 	}
-	if err := h.readDB.CreateLensVersion(ctx, v); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("CreateLensVersion: %w", err))
+	revision, err := h.readDB.CreateLensVersion(ctx, v, req.Msg.ExpectedRevision)
+	if err != nil {
+		return nil, lensWriteError("CreateLensVersion", err)
 	}
-	return connect.NewResponse(&sovereignv1.CreateLensVersionResponse{}), nil
+	return connect.NewResponse(&sovereignv1.CreateLensVersionResponse{Revision: revision}), nil
 }
 
 func (h *SovereignHandler) SelectCurrentLens(
@@ -241,12 +243,22 @@ func (h *SovereignHandler) ArchiveLens(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	if err := h.readDB.ArchiveLens(ctx, lensID); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("ArchiveLens: %w", err))
+	if err := h.readDB.ArchiveLens(ctx, lensID, req.Msg.ExpectedRevision); err != nil {
+		return nil, lensWriteError("ArchiveLens", err)
 	}
 	return connect.NewResponse(&sovereignv1.ArchiveLensResponse{}), nil
 }
 
+// lensWriteError maps a failed lens write to a connect error. A stale
+// expected revision is reported as Aborted so callers can tell it apart
+// from a server failure.
+func lensWriteError(op string, err error) error {
+	if errors.Is(err, sovereign_db.ErrLensRevisionConflict) {
+		return connect.NewError(connect.CodeAborted, fmt.Errorf("%s: %w", op, err))
+	}
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("%s: %w", op, err))
+}
+
 // --- conversion helpers ---
 
 func lensToProto(l sovereign_db.KnowledgeLens) *sovereignv1.Lens {
@@ -258,6 +270,7 @@ func lensToProto(l sovereign_db.KnowledgeLens) *sovereignv1.Lens {
 		Description: l.Description,
 		CreatedAt:   timestamppb.New(l.CreatedAt),
 		UpdatedAt:   timestamppb.New(l.UpdatedAt),
+		Revision:    l.Revision,
 	}
 	if l.ArchivedAt != nil {
 		pb.ArchivedAt = timestamppb.New(*l.ArchivedAt)
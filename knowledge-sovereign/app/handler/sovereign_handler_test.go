@@ -11,6 +11,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"knowledge-sovereign/driver/sovereign_db"
 	sovereignv1 "knowledge-sovereign/gen/proto/services/sovereign/v1"
 	"knowledge-sovereign/gen/proto/services/sovereign/v1/sovereignv1connect"
@@ -197,14 +198,17 @@ func (m *mockRepo) ResolveLensFilter(_ context.Context, _ uuid.UUID, _ *uuid.UUI
 func (m *mockRepo) CreateLens(_ context.Context, _ sovereign_db.KnowledgeLens) error {
 	return m.returnErr
 }
-func (m *mockRepo) CreateLensVersion(_ context.Context, _ sovereign_db.KnowledgeLensVersion) error {
-	return m.returnErr
+func (m *mockRepo) CreateLensVersion(_ context.Context, _ sovereign_db.KnowledgeLensVersion, _ int64) (int64, error) {
+	if m.returnErr != nil {
+		return 0, m.returnErr
+	}
+	return 2, nil
 }
 func (m *mockRepo) SelectCurrentLens(_ context.Context, _ sovereign_db.KnowledgeCurrentLens) error {
 	return m.returnErr
 }
-func (m *mockRepo) ClearCurrentLens(_ context.Context, _ uuid.UUID) error { return m.returnErr }
-func (m *mockRepo) ArchiveLens(_ context.Context, _ uuid.UUID) error      { return m.returnErr }
+func (m *mockRepo) ClearCurrentLens(_ context.Context, _ uuid.UUID) error     { return m.returnErr }
+func (m *mockRepo) ArchiveLens(_ context.Context, _ uuid.UUID, _ int64) error { return m.returnErr }
 func (m *mockRepo) ListRecallSignalsByUser(_ context.Context, _ uuid.UUID, _ int) ([]sovereign_db.RecallSignal, error) {
 	return nil, m.returnErr
 }
@@ -540,3 +544,36 @@ func TestGetLens_PopulatesCurrentVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestArchiveLens_RevisionConflictIsAborted(t *testing.T) {
+	repo := &mockRepo{returnErr: fmt.Errorf("ArchiveLens: %w", sovereign_db.ErrLensRevisionConflict)}
+	client, cleanup := setupTestServer(repo)
+	defer cleanup()
+
+	_, err := client.ArchiveLens(context.Background(),
+		connect.NewRequest(&sovereignv1.ArchiveLensRequest{
+			LensId:           uuid.NewString(),
+			ExpectedRevision: 3,
+		}))
+
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+}
+
+func TestCreateLensVersion_ReturnsRevision(t *testing.T) {
+	client, cleanup := setupTestServer(&mockRepo{})
+	defer cleanup()
+
+	resp, err := client.CreateLensVersion(context.Background(),
+		connect.NewRequest(&sovereignv1.CreateLensVersionRequest{
+			Version: &sovereignv1.LensVersion{
+				LensVersionId: uuid.NewString(),
+				LensId:        uuid.NewString(),
+				CreatedAt:     timestamppb.Now(),
+			},
+			ExpectedRevision: 1,
+		}))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Msg.Revision)
+}
@@ -0,0 +1,4 @@
+-- Optimistic concurrency for lenses. revision is bumped by every write to a
+-- lens (new filter version, archive); clients send it back as If-Match so
+-- concurrent edits from two devices fail instead of overwriting each other.
+ALTER TABLE knowledge_lenses ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 1;
@@ -14,6 +14,20 @@ if [ -f /run/secrets/kratos_cipher_secret ]; then
     export KRATOS_CIPHER_SECRET=$(cat /run/secrets/kratos_cipher_secret | tr -d '\n')
 fi
 
+if [ -f /run/secrets/kratos_webhook_secret ]; then
+    export KRATOS_WEBHOOK_SECRET=$(cat /run/secrets/kratos_webhook_secret | tr -d '\n')
+fi
+
+# OIDC provider client secrets (social sign-in); client IDs come from the
+# environment. Empty when a provider is not configured.
+if [ -f /run/secrets/kratos_oidc_google_client_secret ]; then
+    export KRATOS_OIDC_GOOGLE_CLIENT_SECRET=$(cat /run/secrets/kratos_oidc_google_client_secret | tr -d '\n')
+fi
+
+if [ -f /run/secrets/kratos_oidc_github_client_secret ]; then
+    export KRATOS_OIDC_GITHUB_CLIENT_SECRET=$(cat /run/secrets/kratos_oidc_github_client_secret | tr -d '\n')
+fi
+
 # Construct DSN if not already set (or override it to ensure password is used)
 # We assume other DSN components are set via env vars or defaults
 DB_USER=${KRATOS_DB_USER:-kratos_user}
@@ -32,7 +46,7 @@ export DSN="postgres://${DB_USER}:${KRATOS_DB_PASSWORD}@${DB_HOST}:${DB_PORT}/${
 # Expand environment variables in kratos.yml using sed
 if [ -f /etc/config/kratos/kratos.yml ]; then
     # Create a temporary file with expanded environment variables
-    sed "s|\${KRATOS_COOKIE_SECRET}|${KRATOS_COOKIE_SECRET}|g; s|\${KRATOS_CIPHER_SECRET}|${KRATOS_CIPHER_SECRET}|g; s|\${DSN}|${DSN}|g" /etc/config/kratos/kratos.yml > /tmp/kratos.yml
+    sed "s|\${KRATOS_COOKIE_SECRET}|${KRATOS_COOKIE_SECRET}|g; s|\${KRATOS_CIPHER_SECRET}|${KRATOS_CIPHER_SECRET}|g; s|\${KRATOS_WEBHOOK_SECRET}|${KRATOS_WEBHOOK_SECRET}|g; s|\${KRATOS_OIDC_GOOGLE_CLIENT_ID}|${KRATOS_OIDC_GOOGLE_CLIENT_ID}|g; s|\${KRATOS_OIDC_GOOGLE_CLIENT_SECRET}|${KRATOS_OIDC_GOOGLE_CLIENT_SECRET}|g; s|\${KRATOS_OIDC_GITHUB_CLIENT_ID}|${KRATOS_OIDC_GITHUB_CLIENT_ID}|g; s|\${KRATOS_OIDC_GITHUB_CLIENT_SECRET}|${KRATOS_OIDC_GITHUB_CLIENT_SECRET}|g; s|\${DSN}|${DSN}|g" /etc/config/kratos/kratos.yml > /tmp/kratos.yml
     # Use the expanded config file
     export KRATOS_CONFIG_FILE=/tmp/kratos.yml
 fi
@@ -0,0 +1,39 @@
+-- Legal hold registry and compliance audit trail.
+--
+-- account_legal_holds keeps the full hold history per account. At most one
+-- hold per user may be active (released_at IS NULL); retention and deletion
+-- jobs in every service consult alt-backend's internal legal-hold API and
+-- skip held accounts.
+CREATE TABLE IF NOT EXISTS account_legal_holds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    placed_by UUID NOT NULL,
+    placed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    released_by UUID,
+    released_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT chk_account_legal_holds_release
+        CHECK ((released_at IS NULL) = (released_by IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_account_legal_holds_active_user
+    ON account_legal_holds (user_id)
+    WHERE released_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_account_legal_holds_user_placed
+    ON account_legal_holds (user_id, placed_at DESC);
+
+-- Append-only record of compliance actions (hold placed/released, account
+-- exported). details carries action-specific data such as the export
+-- manifest checksum.
+CREATE TABLE IF NOT EXISTS account_compliance_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    action TEXT NOT NULL
+        CHECK (action IN ('legal_hold_placed', 'legal_hold_released', 'account_exported')),
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_compliance_audit_log_user_created
+    ON account_compliance_audit_log (user_id, created_at DESC);
@@ -0,0 +1,26 @@
+-- Consistent soft-delete for feeds and articles.
+--
+-- Both tables carry deleted_at + deleted_by. Soft-deleted rows stay
+-- restorable for 30 days; alt-backend's soft-delete-purge job then removes
+-- them permanently (FK cascades take their tags, summaries and read state).
+-- deleted_by is NULL for rows deleted before this migration.
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS deleted_by UUID;
+
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE feeds ADD COLUMN IF NOT EXISTS deleted_by UUID;
+CREATE INDEX IF NOT EXISTS idx_feeds_deleted_at ON feeds (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Append-only trail of every delete, restore and purge. actor_id is NULL
+-- for purges performed by the background job. entity_id is not a foreign
+-- key so the trail survives the purge it records.
+CREATE TABLE IF NOT EXISTS soft_delete_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type TEXT NOT NULL CHECK (entity_type IN ('feed', 'article')),
+    entity_id UUID NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('deleted', 'restored', 'purged')),
+    actor_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_soft_delete_audit_log_entity
+    ON soft_delete_audit_log (entity_type, entity_id, created_at DESC);
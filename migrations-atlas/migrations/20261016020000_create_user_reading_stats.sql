@@ -0,0 +1,39 @@
+-- Per-user reading statistics, recomputed nightly by alt-backend's
+-- reading-stats-aggregation job from read_status.
+--
+-- stat_date is the local (Asia/Tokyo) calendar day a read happened on and
+-- reads_by_hour holds 24 local-hour buckets. Each job run replaces the rows
+-- for the days it recomputes, so the tables are safe to rebuild at any time.
+CREATE TABLE IF NOT EXISTS user_reading_daily_stats (
+    user_id UUID NOT NULL,
+    stat_date DATE NOT NULL,
+    articles_read INTEGER NOT NULL DEFAULT 0,
+    reads_by_hour INTEGER[] NOT NULL DEFAULT array_fill(0, ARRAY[24]),
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, stat_date),
+    CONSTRAINT chk_user_reading_daily_stats_hours
+        CHECK (array_length(reads_by_hour, 1) = 24)
+);
+
+-- Reads per subscription (feed_links) per day. feed_link_id is not a foreign
+-- key so history survives an unsubscribe.
+CREATE TABLE IF NOT EXISTS user_reading_daily_feed_stats (
+    user_id UUID NOT NULL,
+    stat_date DATE NOT NULL,
+    feed_link_id UUID NOT NULL,
+    reads INTEGER NOT NULL,
+    PRIMARY KEY (user_id, stat_date, feed_link_id)
+);
+
+-- Reads per tag per day; a read article counts once for each of its tags.
+CREATE TABLE IF NOT EXISTS user_reading_daily_tag_stats (
+    user_id UUID NOT NULL,
+    stat_date DATE NOT NULL,
+    tag_name TEXT NOT NULL,
+    reads INTEGER NOT NULL,
+    PRIMARY KEY (user_id, stat_date, tag_name)
+);
+
+-- The nightly job scans one day of reads across all users.
+CREATE INDEX IF NOT EXISTS idx_read_status_read_at_read
+    ON read_status (read_at) WHERE is_read = TRUE;
@@ -0,0 +1,21 @@
+-- Public, read-only share links for articles (alt-backend
+-- POST /v1/articles/:id/share).
+--
+-- The share token is an HMAC over id and is never stored, so reading this
+-- table does not yield working links. fields is the subset of alt-backend's
+-- shareable-field allowlist the link exposes. Links die with their article.
+CREATE TABLE IF NOT EXISTS article_share_links (
+    id UUID PRIMARY KEY,
+    article_id UUID NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    fields TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    view_count BIGINT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT chk_article_share_links_expiry CHECK (expires_at > created_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_share_links_article_user
+    ON article_share_links (article_id, user_id, created_at DESC);
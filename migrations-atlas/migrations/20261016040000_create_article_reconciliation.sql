@@ -0,0 +1,52 @@
+-- Cross-source article reconciliation (alt-backend article-reconciliation job).
+--
+-- article_source_records holds every item an ingest source reported to
+-- alt-backend (POST /v1/internal/article-sources), keyed by the source's own
+-- item ID. The hourly job matches each record to an article by URL or feed
+-- GUID, fills in missing article metadata, and marks records that match no
+-- article (orphaned) or match inconsistently (conflict).
+CREATE TABLE IF NOT EXISTS article_source_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source TEXT NOT NULL,
+    source_item_id TEXT NOT NULL,
+    guid TEXT,
+    user_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    normalized_url TEXT NOT NULL,
+    title TEXT,
+    author TEXT,
+    published_at TIMESTAMP WITH TIME ZONE,
+    article_id UUID REFERENCES articles (id) ON DELETE SET NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    conflict_reason TEXT,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reconciled_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT uq_article_source_records_item UNIQUE (source, source_item_id),
+    CONSTRAINT chk_article_source_records_status
+        CHECK (status IN ('pending', 'matched', 'orphaned', 'conflict'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_source_records_unreconciled
+    ON article_source_records (reconciled_at NULLS FIRST, first_seen_at)
+    WHERE status IN ('pending', 'orphaned');
+CREATE INDEX IF NOT EXISTS idx_article_source_records_user_guid
+    ON article_source_records (user_id, guid)
+    WHERE guid IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_article_source_records_article_id
+    ON article_source_records (article_id);
+
+-- Articles that are copies of another article of the same user, e.g. the
+-- same post stored under a tracking-parameter URL variant. The canonical
+-- article is the one ingested first.
+CREATE TABLE IF NOT EXISTS article_duplicate_links (
+    duplicate_article_id UUID PRIMARY KEY REFERENCES articles (id) ON DELETE CASCADE,
+    canonical_article_id UUID NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    match_key TEXT NOT NULL,
+    linked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_article_duplicate_links_distinct
+        CHECK (duplicate_article_id <> canonical_article_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_duplicate_links_canonical
+    ON article_duplicate_links (canonical_article_id);
@@ -0,0 +1,27 @@
+-- Per-user ranking weights for the server-ranked home feed (alt-backend
+-- GET /v1/feeds/fetch/ranked).
+--
+-- A user without a row is ranked with alt-backend's built-in default
+-- weights. experiment is a free-form label operators use to group users
+-- sharing one weight set; it is echoed on the ranking debug endpoint.
+-- Weights are relative: the ranker normalises them by their sum.
+CREATE TABLE IF NOT EXISTS home_ranking_experiments (
+    user_id UUID PRIMARY KEY,
+    experiment TEXT NOT NULL,
+    recency_weight DOUBLE PRECISION NOT NULL,
+    feed_affinity_weight DOUBLE PRECISION NOT NULL,
+    read_similarity_weight DOUBLE PRECISION NOT NULL,
+    trending_weight DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_home_ranking_experiments_weights CHECK (
+        recency_weight >= 0
+        AND feed_affinity_weight >= 0
+        AND read_similarity_weight >= 0
+        AND trending_weight >= 0
+        AND recency_weight + feed_affinity_weight + read_similarity_weight + trending_weight > 0
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_home_ranking_experiments_experiment
+    ON home_ranking_experiments (experiment);
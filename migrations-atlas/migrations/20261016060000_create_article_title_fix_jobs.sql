@@ -0,0 +1,53 @@
+-- Article title repair jobs (alt-backend article-title-fix job).
+--
+-- Articles ingested before the title extractor existed were stored with
+-- their URL as the title. An admin starts a job (POST
+-- /v1/admin/article-titles/fix); the background job then walks those
+-- articles in id order, fetches each page through the scraping policy and
+-- the rate-limited fetcher, and replaces the title with the page's own.
+-- cursor_article_id is saved after every article so a restart resumes
+-- where the previous run stopped.
+CREATE TABLE IF NOT EXISTS article_title_fix_jobs (
+    job_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    status TEXT NOT NULL DEFAULT 'pending',
+    requested_by UUID,
+    cursor_article_id UUID,
+    total_articles INTEGER NOT NULL DEFAULT 0,
+    processed_count INTEGER NOT NULL DEFAULT 0,
+    updated_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_article_title_fix_jobs_status
+        CHECK (status IN ('pending', 'running', 'completed'))
+);
+
+-- At most one job may be pending or running at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_article_title_fix_jobs_active
+    ON article_title_fix_jobs ((true))
+    WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_article_title_fix_jobs_created_at
+    ON article_title_fix_jobs (created_at DESC);
+
+-- One row per article a job looked at: the report of updated, failed and
+-- skipped titles.
+CREATE TABLE IF NOT EXISTS article_title_fix_results (
+    job_id UUID NOT NULL REFERENCES article_title_fix_jobs (job_id) ON DELETE CASCADE,
+    article_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    old_title TEXT NOT NULL,
+    new_title TEXT,
+    outcome TEXT NOT NULL,
+    -- Why the title was not updated (fetch error, policy denial, ...).
+    detail TEXT,
+    processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (job_id, article_id),
+    CONSTRAINT chk_article_title_fix_results_outcome
+        CHECK (outcome IN ('updated', 'failed', 'skipped'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_title_fix_results_job_outcome
+    ON article_title_fix_results (job_id, outcome, processed_at);
@@ -0,0 +1,8 @@
+-- Position index for replaying the soft-delete audit trail.
+--
+-- rag-orchestrator's deletion sync pages through article events with
+-- GET /v1/internal/articles/deletions, keyset on (created_at, id) within
+-- one entity_type. The existing (entity_type, entity_id, created_at) index
+-- serves per-item history only.
+CREATE INDEX IF NOT EXISTS idx_soft_delete_audit_log_position
+    ON soft_delete_audit_log (entity_type, created_at, id);
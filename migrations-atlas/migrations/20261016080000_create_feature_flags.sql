@@ -0,0 +1,40 @@
+-- Database-backed feature flags for alt-backend.
+--
+-- Flags are scoped per environment (alt-backend's APP_ENV unless
+-- FEATURE_FLAG_ENVIRONMENT overrides it), so one database can hold staging
+-- and production settings side by side. A flag is on for a user when a
+-- per-user override says so, or, without an override, when the flag is
+-- enabled and the user's crc32 bucket falls below rollout_percentage.
+-- Flags without a row here fall back to alt-backend's config toggles.
+CREATE TABLE IF NOT EXISTS feature_flags (
+    name TEXT NOT NULL,
+    environment TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    rollout_percentage INTEGER NOT NULL DEFAULT 100,
+    description TEXT NOT NULL DEFAULT '',
+    updated_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (name, environment),
+    CONSTRAINT chk_feature_flags_rollout_percentage
+        CHECK (rollout_percentage BETWEEN 0 AND 100)
+);
+
+-- Per-user overrides win over the flag's enabled/rollout settings in both
+-- directions; they are removed with their flag.
+CREATE TABLE IF NOT EXISTS feature_flag_overrides (
+    flag_name TEXT NOT NULL,
+    environment TEXT NOT NULL,
+    user_id UUID NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (flag_name, environment, user_id),
+    CONSTRAINT fk_feature_flag_overrides_flag
+        FOREIGN KEY (flag_name, environment)
+        REFERENCES feature_flags (name, environment)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_feature_flag_overrides_environment
+    ON feature_flag_overrides (environment);
@@ -0,0 +1,9 @@
+-- Language of each article summary. pre-processor summarizes in the owning
+-- user's locale (or a language requested for on-demand re-summarization),
+-- so summary_japanese no longer always holds Japanese; this column records
+-- which language it is in. Existing summaries were all generated with the
+-- Japanese prompts.
+ALTER TABLE article_summaries
+    ADD COLUMN IF NOT EXISTS summary_language TEXT NOT NULL DEFAULT 'ja';
+
+COMMENT ON COLUMN article_summaries.summary_language IS 'Language of summary_japanese (ja, en)';
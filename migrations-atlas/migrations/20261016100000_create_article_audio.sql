@@ -0,0 +1,25 @@
+-- Text-to-speech audio for articles (alt-backend POST/GET
+-- /v1/articles/:id/audio), one row per article.
+--
+-- alt-backend inserts the row as pending and publishes an
+-- AudioGenerationRequested event on alt:events:audio; the TTS worker reports
+-- back through PUT /v1/internal/articles/:id/audio. attempts counts requests
+-- so a report for a superseded request can be told apart and dropped.
+-- storage_url and duration_seconds are set once the audio is ready.
+CREATE TABLE IF NOT EXISTS article_audio (
+    article_id UUID PRIMARY KEY REFERENCES articles (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    voice TEXT NOT NULL DEFAULT '',
+    duration_seconds DOUBLE PRECISION,
+    storage_url TEXT,
+    error_message TEXT,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_article_audio_status
+        CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    CONSTRAINT chk_article_audio_ready
+        CHECK (status <> 'ready' OR (storage_url IS NOT NULL AND duration_seconds > 0))
+);
@@ -0,0 +1,84 @@
+-- Organizations and teams (alt-backend /v1/orgs).
+--
+-- An organization belongs to its creator's tenant. Roles are scoped per
+-- organization (organization_members.role), so a user can own one
+-- organization and be a plain member of another. Teams form a tree inside
+-- one organization through parent_team_id; membership of a team extends to
+-- its sub-teams. Composite foreign keys keep a team's parent and members in
+-- the team's own organization, and removing someone from the organization
+-- removes them from its teams.
+--
+-- Invitations are redeemed with a random token shown once to the inviter;
+-- only its SHA-256 is stored.
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_organizations_tenant_slug UNIQUE (tenant_id, slug)
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    org_id UUID NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL,
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, user_id),
+    CONSTRAINT chk_organization_members_role CHECK (role IN ('owner', 'admin', 'member'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user
+    ON organization_members (user_id);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id UUID PRIMARY KEY,
+    org_id UUID NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    parent_team_id UUID,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_teams_org_name UNIQUE (org_id, name),
+    CONSTRAINT uq_teams_id_org UNIQUE (id, org_id),
+    -- A parent must be in the same organization.
+    FOREIGN KEY (parent_team_id, org_id) REFERENCES teams (id, org_id) ON DELETE CASCADE,
+    CONSTRAINT chk_teams_not_own_parent CHECK (parent_team_id IS NULL OR parent_team_id <> id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_teams_parent
+    ON teams (parent_team_id);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id UUID NOT NULL,
+    org_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, user_id),
+    CONSTRAINT fk_team_members_team FOREIGN KEY (team_id, org_id)
+        REFERENCES teams (id, org_id) ON DELETE CASCADE,
+    CONSTRAINT fk_team_members_member FOREIGN KEY (org_id, user_id)
+        REFERENCES organization_members (org_id, user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_org_user
+    ON team_members (org_id, user_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id UUID PRIMARY KEY,
+    org_id UUID NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL,
+    token_hash BYTEA NOT NULL,
+    invited_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    accepted_by UUID,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT uq_organization_invitations_token UNIQUE (token_hash),
+    CONSTRAINT chk_organization_invitations_role CHECK (role IN ('admin', 'member')),
+    CONSTRAINT chk_organization_invitations_expiry CHECK (expires_at > created_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org
+    ON organization_invitations (org_id, created_at DESC);
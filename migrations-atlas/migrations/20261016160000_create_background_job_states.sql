@@ -0,0 +1,21 @@
+-- Per-job state of the alt-backend background job scheduler.
+--
+-- One row per registered job name, written by whichever replica ran it last.
+-- lease_owner/lease_expires_at are set while a replica runs the job: a
+-- replica only starts a run after taking the lease, so the same job never
+-- runs on two replicas at once. The lease expires on its own (job timeout
+-- plus a grace period) when a replica dies mid-run.
+CREATE TABLE IF NOT EXISTS background_job_states (
+    name TEXT PRIMARY KEY,
+    last_started_at TIMESTAMP WITH TIME ZONE,
+    last_finished_at TIMESTAMP WITH TIME ZONE,
+    last_duration_ms BIGINT NOT NULL DEFAULT 0,
+    -- Error of the last run; NULL when it succeeded.
+    last_error TEXT,
+    last_success_at TIMESTAMP WITH TIME ZONE,
+    run_count BIGINT NOT NULL DEFAULT 0,
+    failure_count BIGINT NOT NULL DEFAULT 0,
+    lease_owner TEXT,
+    lease_expires_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,9 @@
+-- Retry state for outbox events relayed to mq-hub.
+--
+-- A delivery that fails is returned to PENDING with attempts incremented and
+-- available_at pushed back, so the outbox worker retries it with backoff
+-- instead of marking it FAILED on the first error. Existing rows are
+-- available immediately.
+ALTER TABLE outbox_events
+    ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS available_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
@@ -0,0 +1,27 @@
+-- Articles whose search and RAG index entries are out of date (alt-backend
+-- article-index-outbox job).
+--
+-- Writes that change an article's indexed fields after it was created (the
+-- article title repair, reconciliation filling in a title, tag-generator
+-- linking a new tag) mark the article here in the same transaction. The
+-- background job publishes an ArticleUpdated event for each marked article
+-- so search-indexer and rag-orchestrator reindex only what changed, and
+-- removes the row once the event is recorded. Admins can mark an article
+-- with POST /v1/admin/articles/:id/reindex.
+--
+-- One row per article: marking a queued article again moves marked_at
+-- forward, so several changes are published as one event.
+CREATE TABLE IF NOT EXISTS article_index_outbox (
+    article_id UUID PRIMARY KEY,
+    reason TEXT NOT NULL,
+    marked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    -- Failed publishes are retried with backoff from available_at.
+    attempts INTEGER NOT NULL DEFAULT 0,
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    CONSTRAINT chk_article_index_outbox_reason
+        CHECK (reason IN ('title_fixed', 'reconciled', 'tags_changed', 'forced'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_index_outbox_available_at
+    ON article_index_outbox (available_at);
@@ -0,0 +1,294 @@
+// Package bridge serves the WebSocket endpoint that forwards Redis stream
+// events to browser clients.
+//
+// Protocol (JSON text frames):
+//
+//	server → client
+//	  {"type":"event","stream":"...","id":"...","event":{...}}
+//	  {"type":"snapshot","streams":[{"stream":"...","last_id":"...","length":n}],"dropped":n}
+//	  {"type":"subscribed","streams":[...],"event_types":[...]}
+//	  {"type":"heartbeat"} / {"type":"pong"} / {"type":"error","error":"..."}
+//	client → server
+//	  {"type":"subscribe","streams":[...],"event_types":[...]}
+//	  {"type":"ping"} / {"type":"pong"}
+//
+// A snapshot replaces the events a lagging client missed; the client should
+// refetch its view instead of expecting every event. A connection that sends
+// nothing for three heartbeat intervals is closed.
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"mq-hub/domain"
+	"mq-hub/metrics"
+	"mq-hub/middleware"
+	"mq-hub/usecase"
+)
+
+const (
+	// TokenQueryParam carries the backend token for browsers, which cannot
+	// set headers on a WebSocket handshake.
+	TokenQueryParam = "token"
+
+	defaultHeartbeat  = 30 * time.Second
+	writeTimeout      = 10 * time.Second
+	maxClientFrame    = 4 << 10
+	controlQueueDepth = 4
+)
+
+// Handler upgrades authenticated requests to WebSocket bridge connections.
+type Handler struct {
+	bridge    *usecase.StreamBridge
+	validator *middleware.BackendTokenValidator
+	heartbeat time.Duration
+}
+
+// NewHandler creates a new bridge Handler. A non-positive heartbeat selects
+// the default of 30s.
+func NewHandler(bridge *usecase.StreamBridge, validator *middleware.BackendTokenValidator, heartbeat time.Duration) *Handler {
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+	return &Handler{bridge: bridge, validator: validator, heartbeat: heartbeat}
+}
+
+// ServeHTTP authenticates the request and builds the initial filter from the
+// streams and event_types query parameters before upgrading, so rejected
+// clients get a plain HTTP status.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get(middleware.BackendTokenHeader)
+	if token == "" {
+		token = r.URL.Query().Get(TokenQueryParam)
+	}
+	userID, err := h.validator.Validate(token)
+	if err != nil {
+		slog.WarnContext(r.Context(), "bridge authentication failed", "error", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	filter, err := domain.NewSubscriptionFilter(splitList(q.Get("streams")), splitList(q.Get("event_types")), h.bridge.Streams())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The token has already been checked, so the Origin check that
+	// websocket.Handler performs is not needed.
+	srv := websocket.Server{Handler: func(ws *websocket.Conn) {
+		h.serve(r.Context(), ws, userID, filter)
+	}}
+	srv.ServeHTTP(w, r)
+}
+
+func (h *Handler) serve(ctx context.Context, ws *websocket.Conn, userID string, filter domain.SubscriptionFilter) {
+	ws.MaxPayloadBytes = maxClientFrame
+	defer ws.Close()
+
+	sub := h.bridge.Subscribe(userID, filter)
+	defer h.bridge.Unsubscribe(sub)
+
+	metrics.BridgeConnections.Inc()
+	defer metrics.BridgeConnections.Dec()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	control := make(chan any, controlQueueDepth)
+	go func() {
+		defer cancel()
+		h.readLoop(ctx, ws, sub, control)
+	}()
+
+	if err := h.writeLoop(ctx, ws, sub, control); err != nil {
+		slog.InfoContext(ctx, "bridge connection closed", "user_id", userID, "error", err)
+	}
+}
+
+// writeLoop is the only writer on ws. Every write sets its own deadline,
+// which also replaces the server-wide WriteTimeout left on the hijacked
+// connection.
+func (h *Handler) writeLoop(ctx context.Context, ws *websocket.Conn, sub *usecase.Subscription, control <-chan any) error {
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	send := func(v any) error {
+		if err := ws.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			return err
+		}
+		return websocket.JSON.Send(ws, v)
+	}
+
+	if err := send(subscribedFrame(sub.Filter())); err != nil {
+		return err
+	}
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-sub.Events():
+			err = send(newEventFrame(&msg))
+		case <-sub.Lagged():
+			var snap *usecase.BridgeSnapshot
+			if snap, err = h.bridge.Snapshot(ctx, sub); err == nil {
+				err = send(newSnapshotFrame(snap))
+			}
+		case v := <-control:
+			err = send(v)
+		case <-ticker.C:
+			err = send(frame{Type: "heartbeat"})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readLoop handles client frames until the connection fails or goes quiet
+// for three heartbeat intervals. Replies go through control so writeLoop
+// stays the only writer.
+func (h *Handler) readLoop(ctx context.Context, ws *websocket.Conn, sub *usecase.Subscription, control chan<- any) {
+	reply := func(v any) {
+		select {
+		case control <- v:
+		case <-ctx.Done():
+		}
+	}
+
+	for {
+		if err := ws.SetReadDeadline(time.Now().Add(3 * h.heartbeat)); err != nil {
+			return
+		}
+		var msg subscriptionFrame
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				reply(errorFrame("malformed message"))
+				continue
+			}
+			return
+		}
+
+		switch msg.Type {
+		case "subscribe":
+			filter, err := domain.NewSubscriptionFilter(msg.Streams, msg.EventTypes, h.bridge.Streams())
+			if err != nil {
+				reply(errorFrame(err.Error()))
+				continue
+			}
+			sub.SetFilter(filter)
+			reply(subscribedFrame(filter))
+		case "ping":
+			reply(frame{Type: "pong"})
+		case "pong":
+		default:
+			reply(errorFrame("unknown message type " + msg.Type))
+		}
+	}
+}
+
+type frame struct {
+	Type  string `json:"type"`
+	Error string `json:"error,omitempty"`
+}
+
+func errorFrame(msg string) frame {
+	return frame{Type: "error", Error: msg}
+}
+
+// subscriptionFrame is both the client's subscribe request and the
+// server's confirmation.
+type subscriptionFrame struct {
+	Type       string   `json:"type"`
+	Streams    []string `json:"streams"`
+	EventTypes []string `json:"event_types"`
+}
+
+func subscribedFrame(f domain.SubscriptionFilter) subscriptionFrame {
+	out := subscriptionFrame{Type: "subscribed", Streams: []string{}, EventTypes: []string{}}
+	for _, s := range f.StreamList() {
+		out.Streams = append(out.Streams, s.String())
+	}
+	for t := range f.EventTypes {
+		out.EventTypes = append(out.EventTypes, string(t))
+	}
+	sort.Strings(out.EventTypes)
+	return out
+}
+
+type eventFrame struct {
+	Type   string    `json:"type"`
+	Stream string    `json:"stream"`
+	ID     string    `json:"id"`
+	Event  eventBody `json:"event"`
+}
+
+type eventBody struct {
+	EventID   string            `json:"event_id"`
+	EventType string            `json:"event_type"`
+	Source    string            `json:"source"`
+	CreatedAt time.Time         `json:"created_at"`
+	Payload   json.RawMessage   `json:"payload,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// newEventFrame embeds JSON payloads as-is and sends anything else as a
+// string.
+func newEventFrame(msg *domain.StreamMessage) eventFrame {
+	ev := msg.Event
+	body := eventBody{
+		EventID:   ev.EventID,
+		EventType: string(ev.EventType),
+		Source:    ev.Source,
+		CreatedAt: ev.CreatedAt,
+		Metadata:  ev.Metadata,
+	}
+	if len(ev.Payload) > 0 {
+		if json.Valid(ev.Payload) {
+			body.Payload = ev.Payload
+		} else {
+			body.Payload, _ = json.Marshal(string(ev.Payload))
+		}
+	}
+	return eventFrame{Type: "event", Stream: msg.Stream.String(), ID: msg.ID, Event: body}
+}
+
+type snapshotFrame struct {
+	Type    string       `json:"type"`
+	Streams []streamHead `json:"streams"`
+	Dropped int          `json:"dropped"`
+}
+
+type streamHead struct {
+	Stream string `json:"stream"`
+	LastID string `json:"last_id"`
+	Length int64  `json:"length"`
+}
+
+func newSnapshotFrame(snap *usecase.BridgeSnapshot) snapshotFrame {
+	out := snapshotFrame{Type: "snapshot", Streams: make([]streamHead, 0, len(snap.Heads)), Dropped: snap.Dropped}
+	for _, head := range snap.Heads {
+		out.Streams = append(out.Streams, streamHead{Stream: head.Stream.String(), LastID: head.LastEntryID, Length: head.Length})
+	}
+	return out
+}
+
+func splitList(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
@@ -0,0 +1,152 @@
+package bridge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"mq-hub/domain"
+	"mq-hub/middleware"
+	"mq-hub/usecase"
+)
+
+const testSecret = "test-backend-token-secret-at-least-32-chars"
+
+// stubReader returns the batches tests push into msgs.
+type stubReader struct {
+	msgs chan []domain.StreamMessage
+}
+
+func (s *stubReader) ReadStreams(ctx context.Context, _ map[domain.StreamKey]string, _ int64, _ time.Duration) ([]domain.StreamMessage, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case msgs := <-s.msgs:
+		return msgs, nil
+	case <-time.After(10 * time.Millisecond):
+		return nil, nil
+	}
+}
+
+func (s *stubReader) StreamHead(_ context.Context, stream domain.StreamKey) (domain.StreamHead, error) {
+	return domain.StreamHead{Stream: stream}, nil
+}
+
+func testToken(t *testing.T, userID string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   userID,
+		Issuer:    "auth-hub",
+		Audience:  jwt.ClaimStrings{"alt-backend"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *stubReader) {
+	t.Helper()
+	reader := &stubReader{msgs: make(chan []domain.StreamMessage, 1)}
+	sb := usecase.NewStreamBridge(reader, usecase.StreamBridgeOptions{})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go sb.Run(ctx)
+
+	validator, err := middleware.NewBackendTokenValidator([]byte(testSecret), "auth-hub", "alt-backend")
+	require.NoError(t, err)
+	srv := httptest.NewServer(NewHandler(sb, validator, time.Minute))
+	t.Cleanup(srv.Close)
+	return srv, reader
+}
+
+func dial(t *testing.T, srv *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/?" + query
+	ws, err := websocket.Dial(url, "", srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { ws.Close() })
+	require.NoError(t, ws.SetDeadline(time.Now().Add(5*time.Second)))
+	return ws
+}
+
+func receive(t *testing.T, ws *websocket.Conn) map[string]any {
+	t.Helper()
+	var msg map[string]any
+	require.NoError(t, websocket.JSON.Receive(ws, &msg))
+	return msg
+}
+
+func TestHandler_RejectsMissingOrInvalidToken(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	for _, query := range []string{"", "token=not-a-jwt"} {
+		resp, err := http.Get(srv.URL + "/?" + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+}
+
+func TestHandler_RejectsUnknownStream(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/?streams=alt:events:index&token=" + testToken(t, "user-1"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHandler_StreamsFilteredEvents(t *testing.T) {
+	srv, reader := newTestServer(t)
+	ws := dial(t, srv, "streams=alt:events:articles&token="+testToken(t, "user-1"))
+
+	subscribed := receive(t, ws)
+	assert.Equal(t, "subscribed", subscribed["type"])
+	assert.Equal(t, []any{"alt:events:articles"}, subscribed["streams"])
+
+	reader.msgs <- []domain.StreamMessage{
+		{Stream: domain.StreamKeyTags, ID: "1-0", Event: &domain.Event{EventType: domain.EventTypeTagsGenerated}},
+		{Stream: domain.StreamKeyArticles, ID: "2-0", Event: &domain.Event{
+			EventID:   "ev-1",
+			EventType: domain.EventTypeArticleCreated,
+			Payload:   []byte(`{"article_id":"a1"}`),
+		}},
+	}
+
+	event := receive(t, ws)
+	assert.Equal(t, "event", event["type"])
+	assert.Equal(t, "2-0", event["id"])
+	body := event["event"].(map[string]any)
+	assert.Equal(t, "ArticleCreated", body["event_type"])
+	assert.Equal(t, map[string]any{"article_id": "a1"}, body["payload"])
+}
+
+func TestHandler_SubscribeAndPing(t *testing.T) {
+	srv, _ := newTestServer(t)
+	ws := dial(t, srv, "token="+testToken(t, "user-1"))
+	receive(t, ws)
+
+	require.NoError(t, websocket.JSON.Send(ws, map[string]any{
+		"type":        "subscribe",
+		"streams":     []string{"alt:events:summaries"},
+		"event_types": []string{"ArticleSummarized"},
+	}))
+	subscribed := receive(t, ws)
+	assert.Equal(t, "subscribed", subscribed["type"])
+	assert.Equal(t, []any{"alt:events:summaries"}, subscribed["streams"])
+	assert.Equal(t, []any{"ArticleSummarized"}, subscribed["event_types"])
+
+	require.NoError(t, websocket.JSON.Send(ws, map[string]any{"type": "subscribe", "streams": []string{"alt:events:index"}}))
+	assert.Equal(t, "error", receive(t, ws)["type"])
+
+	require.NoError(t, websocket.JSON.Send(ws, map[string]any{"type": "ping"}))
+	assert.Equal(t, "pong", receive(t, ws)["type"])
+}
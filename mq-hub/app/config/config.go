@@ -5,12 +5,25 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"mq-hub/domain"
 )
 
 // Config holds the configuration for mq-hub.
 type Config struct {
-	// RedisURL is the Redis connection URL.
+	// RedisURL is the Redis connection URL. In sentinel mode it names the
+	// Sentinels and ?master_name=; in cluster mode the seed nodes (more as
+	// ?addr=).
 	RedisURL string
+	// RedisMode is how RedisURL is connected to: standalone, sentinel or
+	// cluster.
+	RedisMode domain.RedisMode
+	// RedisPublishRetries is how many times a publish rejected during a
+	// Redis failover is retried, starting RedisPublishRetryBackoff apart.
+	RedisPublishRetries      int
+	RedisPublishRetryBackoff time.Duration
 	// ConnectPort is the port for the Connect-RPC server.
 	ConnectPort int
 	// LogLevel is the logging level.
@@ -19,11 +32,47 @@ type Config struct {
 	RedisPoolSize int
 	// MaxBatchSize is the maximum number of events in a batch.
 	MaxBatchSize int
+	// MaxEventPayloadBytes is the largest payload a batched event may carry.
+	// 0 means no limit.
+	MaxEventPayloadBytes int
+	// MaxBatchPayloadBytes is the total payload budget of one batch. 0 means
+	// no limit.
+	MaxBatchPayloadBytes int
 	// StreamMaxLen is the approximate max length for Redis Streams trimming via XADD MAXLEN ~.
 	// 0 means no trimming.
 	StreamMaxLen int64
+	// StreamMaxAge is the default age bound enforced by the background
+	// trimmer. 0 disables age-based trimming.
+	StreamMaxAge time.Duration
+	// StreamRetention holds the per-stream policies enforced by the
+	// background trimmer: STREAM_MAX_LEN/STREAM_MAX_AGE for every known
+	// stream, overridden by STREAM_RETENTION entries.
+	StreamRetention domain.RetentionPolicies
+	// StreamTrimInterval is how often the trimmer runs.
+	StreamTrimInterval time.Duration
+	// IdempotencyTTL is how long a published idempotency key deduplicates
+	// retries. 0 disables deduplication.
+	IdempotencyTTL time.Duration
+	// BackendTokenSecret verifies the backend JWTs presented by WebSocket
+	// bridge clients. Empty disables the bridge.
+	BackendTokenSecret string
+	// BackendTokenIssuer and BackendTokenAudience must match the claims
+	// auth-hub issues.
+	BackendTokenIssuer   string
+	BackendTokenAudience string
+	// BridgeStreams are the streams the WebSocket bridge exposes.
+	BridgeStreams []domain.StreamKey
+	// BridgeSendBuffer is how many events may queue per bridge client before
+	// it falls back to snapshots.
+	BridgeSendBuffer int
+	// BridgeHeartbeatInterval is how often the bridge sends heartbeats.
+	BridgeHeartbeatInterval time.Duration
 }
 
+// minBackendTokenSecretLen matches auth-hub's BACKEND_TOKEN_SECRET
+// validation; both ends use the same HMAC key.
+const minBackendTokenSecretLen = 32
+
 // NewConfig creates a new Config from environment variables. It fails fast
 // (returns an error) if any numeric env var is set but not parseable,
 // instead of silently treating it as 0.
@@ -36,25 +85,122 @@ func NewConfig() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parse REDIS_POOL_SIZE: %w", err)
 	}
+	redisMode, err := domain.ParseRedisMode(os.Getenv("REDIS_MODE"))
+	if err != nil {
+		return nil, fmt.Errorf("parse REDIS_MODE: %w", err)
+	}
+	publishRetries, err := strconv.Atoi(getEnvOrDefault("REDIS_PUBLISH_RETRIES", "3"))
+	if err != nil || publishRetries < 0 {
+		return nil, fmt.Errorf("parse REDIS_PUBLISH_RETRIES: invalid count %q", os.Getenv("REDIS_PUBLISH_RETRIES"))
+	}
+	publishRetryBackoff, err := time.ParseDuration(getEnvOrDefault("REDIS_PUBLISH_RETRY_BACKOFF", "200ms"))
+	if err != nil || publishRetryBackoff <= 0 {
+		return nil, fmt.Errorf("parse REDIS_PUBLISH_RETRY_BACKOFF: invalid duration %q", os.Getenv("REDIS_PUBLISH_RETRY_BACKOFF"))
+	}
 	maxBatchSize, err := strconv.Atoi(getEnvOrDefault("MAX_BATCH_SIZE", "1000"))
 	if err != nil {
 		return nil, fmt.Errorf("parse MAX_BATCH_SIZE: %w", err)
 	}
+	maxEventPayload, err := strconv.Atoi(getEnvOrDefault("MAX_EVENT_PAYLOAD_BYTES", "1048576"))
+	if err != nil || maxEventPayload < 0 {
+		return nil, fmt.Errorf("parse MAX_EVENT_PAYLOAD_BYTES: invalid size %q", os.Getenv("MAX_EVENT_PAYLOAD_BYTES"))
+	}
+	maxBatchPayload, err := strconv.Atoi(getEnvOrDefault("MAX_BATCH_PAYLOAD_BYTES", "16777216"))
+	if err != nil || maxBatchPayload < 0 {
+		return nil, fmt.Errorf("parse MAX_BATCH_PAYLOAD_BYTES: invalid size %q", os.Getenv("MAX_BATCH_PAYLOAD_BYTES"))
+	}
 	streamMaxLen, err := strconv.ParseInt(getEnvOrDefault("STREAM_MAX_LEN", "10000"), 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("parse STREAM_MAX_LEN: %w", err)
 	}
 
+	streamMaxAge, err := time.ParseDuration(getEnvOrDefault("STREAM_MAX_AGE", "0s"))
+	if err != nil || streamMaxAge < 0 {
+		return nil, fmt.Errorf("parse STREAM_MAX_AGE: invalid duration %q", os.Getenv("STREAM_MAX_AGE"))
+	}
+	trimInterval, err := time.ParseDuration(getEnvOrDefault("STREAM_TRIM_INTERVAL", "1m"))
+	if err != nil || trimInterval <= 0 {
+		return nil, fmt.Errorf("parse STREAM_TRIM_INTERVAL: invalid duration %q", os.Getenv("STREAM_TRIM_INTERVAL"))
+	}
+	retention, err := domain.ParseRetentionPolicies(
+		os.Getenv("STREAM_RETENTION"),
+		domain.DefaultRetentionPolicies(domain.RetentionPolicy{MaxLen: streamMaxLen, MaxAge: streamMaxAge}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parse STREAM_RETENTION: %w", err)
+	}
+
+	idempotencyTTL, err := time.ParseDuration(getEnvOrDefault("IDEMPOTENCY_TTL", "24h"))
+	if err != nil || idempotencyTTL < 0 {
+		return nil, fmt.Errorf("parse IDEMPOTENCY_TTL: invalid duration %q", os.Getenv("IDEMPOTENCY_TTL"))
+	}
+
+	backendTokenSecret, err := loadBackendTokenSecret()
+	if err != nil {
+		return nil, err
+	}
+	bridgeStreams := domain.DefaultBridgeStreams
+	if raw := os.Getenv("WS_BRIDGE_STREAMS"); raw != "" {
+		if bridgeStreams, err = domain.ParseBridgeStreams(raw); err != nil {
+			return nil, fmt.Errorf("parse WS_BRIDGE_STREAMS: %w", err)
+		}
+	}
+	bridgeSendBuffer, err := strconv.Atoi(getEnvOrDefault("WS_BRIDGE_SEND_BUFFER", "256"))
+	if err != nil || bridgeSendBuffer <= 0 {
+		return nil, fmt.Errorf("parse WS_BRIDGE_SEND_BUFFER: invalid size %q", os.Getenv("WS_BRIDGE_SEND_BUFFER"))
+	}
+	heartbeat, err := time.ParseDuration(getEnvOrDefault("WS_HEARTBEAT_INTERVAL", "30s"))
+	if err != nil || heartbeat <= 0 {
+		return nil, fmt.Errorf("parse WS_HEARTBEAT_INTERVAL: invalid duration %q", os.Getenv("WS_HEARTBEAT_INTERVAL"))
+	}
+
 	return &Config{
-		RedisURL:      getEnvOrDefault("REDIS_URL", "redis://localhost:6379"),
-		ConnectPort:   port,
-		LogLevel:      getEnvOrDefault("LOG_LEVEL", "info"),
-		RedisPoolSize: poolSize,
-		MaxBatchSize:  maxBatchSize,
-		StreamMaxLen:  streamMaxLen,
+		RedisURL:             getEnvOrDefault("REDIS_URL", "redis://localhost:6379"),
+		RedisMode:            redisMode,
+		ConnectPort:          port,
+		LogLevel:             getEnvOrDefault("LOG_LEVEL", "info"),
+		RedisPoolSize:        poolSize,
+		MaxBatchSize:         maxBatchSize,
+		MaxEventPayloadBytes: maxEventPayload,
+		MaxBatchPayloadBytes: maxBatchPayload,
+		StreamMaxLen:         streamMaxLen,
+		StreamMaxAge:         streamMaxAge,
+		StreamRetention:      retention,
+		StreamTrimInterval:   trimInterval,
+		IdempotencyTTL:       idempotencyTTL,
+
+		RedisPublishRetries:      publishRetries,
+		RedisPublishRetryBackoff: publishRetryBackoff,
+
+		BackendTokenSecret:      backendTokenSecret,
+		BackendTokenIssuer:      getEnvOrDefault("BACKEND_TOKEN_ISSUER", "auth-hub"),
+		BackendTokenAudience:    getEnvOrDefault("BACKEND_TOKEN_AUDIENCE", "alt-backend"),
+		BridgeStreams:           bridgeStreams,
+		BridgeSendBuffer:        bridgeSendBuffer,
+		BridgeHeartbeatInterval: heartbeat,
 	}, nil
 }
 
+// loadBackendTokenSecret reads BACKEND_TOKEN_SECRET_FILE, falling back to
+// BACKEND_TOKEN_SECRET. Neither being set is not an error: the bridge is
+// simply not served.
+func loadBackendTokenSecret() (string, error) {
+	secret := os.Getenv("BACKEND_TOKEN_SECRET")
+	source := "BACKEND_TOKEN_SECRET"
+	if path := os.Getenv("BACKEND_TOKEN_SECRET_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read BACKEND_TOKEN_SECRET_FILE: %w", err)
+		}
+		secret = strings.TrimSpace(string(data))
+		source = "BACKEND_TOKEN_SECRET_FILE"
+	}
+	if secret != "" && len(secret) < minBackendTokenSecretLen {
+		return "", fmt.Errorf("%s must be at least %d characters", source, minBackendTokenSecretLen)
+	}
+	return secret, nil
+}
+
 // getEnvOrDefault returns the value of an environment variable or a default value.
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -57,7 +57,11 @@ func (h *Handler) Publish(ctx context.Context, req *connect.Request[mqhubv1.Publ
 	}), nil
 }
 
-// PublishBatch sends multiple events to a Redis Stream.
+// PublishBatch sends multiple events to a Redis Stream. Per-event outcomes
+// (published, rejected by validation or quota, failed in Redis) come back in
+// Results with an OK status so the caller can see them and retry only the
+// PUBLISH_FAILED entries; a Connect error would drop the response body.
+// Only whole-batch failures return an error.
 func (h *Handler) PublishBatch(ctx context.Context, req *connect.Request[mqhubv1.PublishBatchRequest]) (*connect.Response[mqhubv1.PublishBatchResponse], error) {
 	events := make([]*domain.Event, len(req.Msg.Events))
 	for i, protoEvent := range req.Msg.Events {
@@ -65,8 +69,7 @@ func (h *Handler) PublishBatch(ctx context.Context, req *connect.Request[mqhubv1
 	}
 
 	result, err := h.publishUsecase.PublishBatch(ctx, domain.StreamKey(req.Msg.Stream), events)
-	var partialErr *domain.PartialPublishError
-	if err != nil && !errors.As(err, &partialErr) {
+	if err != nil {
 		return connect.NewResponse(&mqhubv1.PublishBatchResponse{
 			SuccessCount: 0,
 			FailureCount: int32(len(events)),
@@ -80,19 +83,39 @@ func (h *Handler) PublishBatch(ctx context.Context, req *connect.Request[mqhubv1
 			ErrorMessage: e.ErrorMessage,
 		}
 	}
+	protoResults := make([]*mqhubv1.PublishResult, len(result.Results))
+	for i, r := range result.Results {
+		protoResults[i] = &mqhubv1.PublishResult{
+			Index:        int32(r.Index),
+			Status:       publishStatusToProto(r.Status),
+			MessageId:    r.MessageID,
+			ErrorMessage: r.ErrorMessage,
+		}
+	}
 
-	response := connect.NewResponse(&mqhubv1.PublishBatchResponse{
+	return connect.NewResponse(&mqhubv1.PublishBatchResponse{
 		MessageIds:   result.MessageIDs,
 		SuccessCount: result.SuccessCount,
 		FailureCount: result.FailureCount,
 		Errors:       protoErrors,
-	})
-	if partialErr != nil {
-		// Some events in the batch failed; report the partial result but
-		// still surface a non-OK status so at-least-once clients retry.
-		return response, mapPublishErr(err)
+		Results:      protoResults,
+	}), nil
+}
+
+// publishStatusToProto converts a domain PublishStatus to its proto enum.
+func publishStatusToProto(status domain.PublishStatus) mqhubv1.PublishStatus {
+	switch status {
+	case domain.PublishStatusOK:
+		return mqhubv1.PublishStatus_PUBLISH_STATUS_OK
+	case domain.PublishStatusValidationError:
+		return mqhubv1.PublishStatus_PUBLISH_STATUS_VALIDATION_ERROR
+	case domain.PublishStatusQuotaExceeded:
+		return mqhubv1.PublishStatus_PUBLISH_STATUS_QUOTA_EXCEEDED
+	case domain.PublishStatusFailed:
+		return mqhubv1.PublishStatus_PUBLISH_STATUS_PUBLISH_FAILED
+	default:
+		return mqhubv1.PublishStatus_PUBLISH_STATUS_UNSPECIFIED
 	}
-	return response, nil
 }
 
 // mapPublishErr classifies usecase/domain errors into Connect RPC codes so
@@ -106,6 +129,11 @@ func mapPublishErr(err error) error {
 	if errors.Is(err, domain.ErrInvalidEvent) || errors.Is(err, usecase.ErrBatchTooLarge) {
 		return connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	if errors.Is(err, usecase.ErrIdempotencyKeyInFlight) {
+		// The original publish is still running; retrying shortly returns
+		// its message ID.
+		return connect.NewError(connect.CodeAborted, err)
+	}
 	return connect.NewError(connect.CodeUnavailable, err)
 }
 
@@ -182,12 +210,13 @@ func protoEventToDomain(protoEvent *mqhubv1.Event) *domain.Event {
 	}
 
 	return &domain.Event{
-		EventID:   protoEvent.EventId,
-		EventType: domain.EventType(protoEvent.EventType),
-		Source:    protoEvent.Source,
-		CreatedAt: createdAt,
-		Payload:   protoEvent.Payload,
-		Metadata:  protoEvent.Metadata,
+		EventID:        protoEvent.EventId,
+		EventType:      domain.EventType(protoEvent.EventType),
+		Source:         protoEvent.Source,
+		CreatedAt:      createdAt,
+		Payload:        protoEvent.Payload,
+		Metadata:       protoEvent.Metadata,
+		IdempotencyKey: protoEvent.IdempotencyKey,
 	}
 }
 
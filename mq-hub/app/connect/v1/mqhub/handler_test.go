@@ -121,6 +121,10 @@ func TestHandler_Publish(t *testing.T) {
 		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
 	})
 
+	t.Run("maps an in-flight idempotency key to aborted", func(t *testing.T) {
+		assert.Equal(t, connect.CodeAborted, connect.CodeOf(mapPublishErr(usecase.ErrIdempotencyKeyInFlight)))
+	})
+
 	t.Run("returns error when publish fails", func(t *testing.T) {
 		mockPort := new(MockStreamPort)
 		uc := usecase.NewPublishUsecase(mockPort)
@@ -216,6 +220,47 @@ func TestHandler_PublishBatch(t *testing.T) {
 		assert.Equal(t, int32(1), resp.Msg.FailureCount)
 		mockPort.AssertExpectations(t)
 	})
+
+	t.Run("reports per-event results for a partially rejected batch", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := usecase.NewPublishUsecase(mockPort)
+		handler := NewHandler(uc)
+
+		ctx := context.Background()
+
+		mockPort.On("PublishBatch", ctx, domain.StreamKey("articles"), mock.AnythingOfType("[]*domain.Event")).
+			Return([]string{"123-0"}, nil)
+
+		req := connect.NewRequest(&mqhubv1.PublishBatchRequest{
+			Stream: "articles",
+			Events: []*mqhubv1.Event{
+				{
+					EventId:   "test-1",
+					Source:    "alt-backend",
+					CreatedAt: timestamppb.New(time.Now()),
+				},
+				{
+					EventId:   "test-2",
+					EventType: "ArticleCreated",
+					Source:    "alt-backend",
+					CreatedAt: timestamppb.New(time.Now()),
+				},
+			},
+		})
+
+		resp, err := handler.PublishBatch(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), resp.Msg.SuccessCount)
+		assert.Equal(t, int32(1), resp.Msg.FailureCount)
+		require.Len(t, resp.Msg.Results, 2)
+		assert.Equal(t, mqhubv1.PublishStatus_PUBLISH_STATUS_VALIDATION_ERROR, resp.Msg.Results[0].Status)
+		assert.Contains(t, resp.Msg.Results[0].ErrorMessage, "event_type")
+		assert.Equal(t, mqhubv1.PublishStatus_PUBLISH_STATUS_OK, resp.Msg.Results[1].Status)
+		assert.Equal(t, "123-0", resp.Msg.Results[1].MessageId)
+		assert.Equal(t, []string{"", "123-0"}, resp.Msg.MessageIds)
+		mockPort.AssertExpectations(t)
+	})
 }
 
 func TestHandler_CreateConsumerGroup(t *testing.T) {
@@ -450,12 +495,13 @@ func TestProtoEventToDomain(t *testing.T) {
 	t.Run("converts proto event to domain event", func(t *testing.T) {
 		now := time.Now()
 		protoEvent := &mqhubv1.Event{
-			EventId:   "test-1",
-			EventType: "ArticleCreated",
-			Source:    "alt-backend",
-			CreatedAt: timestamppb.New(now),
-			Payload:   []byte(`{"article_id": "123"}`),
-			Metadata:  map[string]string{"trace_id": "abc123"},
+			EventId:        "test-1",
+			EventType:      "ArticleCreated",
+			Source:         "alt-backend",
+			CreatedAt:      timestamppb.New(now),
+			Payload:        []byte(`{"article_id": "123"}`),
+			Metadata:       map[string]string{"trace_id": "abc123"},
+			IdempotencyKey: "article-123",
 		}
 
 		domainEvent := protoEventToDomain(protoEvent)
@@ -466,6 +512,7 @@ func TestProtoEventToDomain(t *testing.T) {
 		assert.Equal(t, now.Unix(), domainEvent.CreatedAt.Unix())
 		assert.Equal(t, []byte(`{"article_id": "123"}`), domainEvent.Payload)
 		assert.Equal(t, "abc123", domainEvent.Metadata["trace_id"])
+		assert.Equal(t, "article-123", domainEvent.IdempotencyKey)
 	})
 
 	t.Run("returns nil for nil input", func(t *testing.T) {
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PublishStatus is the outcome of a single event in a batch publish.
+type PublishStatus string
+
+// Per-event batch publish outcomes.
+const (
+	// PublishStatusOK means the event was appended to the stream.
+	PublishStatusOK PublishStatus = "ok"
+	// PublishStatusValidationError means the event was nil or missing a
+	// required field. Retrying it unchanged will fail again.
+	PublishStatusValidationError PublishStatus = "validation_error"
+	// PublishStatusQuotaExceeded means the event did not fit the batch
+	// limits. Retrying it unchanged in the same batch will fail again.
+	PublishStatusQuotaExceeded PublishStatus = "quota_exceeded"
+	// PublishStatusFailed means the event was valid but Redis did not
+	// accept it. It is safe to retry.
+	PublishStatusFailed PublishStatus = "publish_failed"
+)
+
+// ErrBatchTooLarge is returned when a batch has more events than
+// BatchLimits.MaxEvents. It rejects the whole batch.
+var ErrBatchTooLarge = errors.New("batch size exceeds maximum allowed")
+
+// ErrQuotaExceeded is the sentinel wrapped by every per-event limit
+// rejection.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// BatchLimits bounds what a single PublishBatch call may carry. A zero
+// field disables that bound.
+type BatchLimits struct {
+	// MaxEvents is the maximum number of events in one batch.
+	MaxEvents int
+	// MaxEventPayloadBytes is the maximum payload size of one event.
+	MaxEventPayloadBytes int
+	// MaxBatchPayloadBytes is the maximum total payload size of the events
+	// accepted from one batch.
+	MaxBatchPayloadBytes int
+}
+
+// EventRejection records why one event in a batch will not be published.
+type EventRejection struct {
+	// Index is the position of the event in the original batch.
+	Index int
+	// Status is PublishStatusValidationError or PublishStatusQuotaExceeded.
+	Status PublishStatus
+	// Err wraps ErrInvalidEvent or ErrQuotaExceeded.
+	Err error
+}
+
+// Check validates events against the limits. A batch over MaxEvents is
+// rejected as a whole with ErrBatchTooLarge; otherwise every event is judged
+// on its own and the rejected ones are returned in index order, leaving the
+// rest publishable.
+//
+// The batch payload budget is spent in index order by accepted events only,
+// so an event that would overflow it is rejected while a smaller one after
+// it may still fit.
+func (l BatchLimits) Check(events []*Event) ([]EventRejection, error) {
+	if l.MaxEvents > 0 && len(events) > l.MaxEvents {
+		return nil, ErrBatchTooLarge
+	}
+
+	var rejections []EventRejection
+	batchBytes := 0
+	for i, event := range events {
+		if event == nil {
+			rejections = append(rejections, EventRejection{
+				Index:  i,
+				Status: PublishStatusValidationError,
+				Err:    fmt.Errorf("event is nil: %w", ErrInvalidEvent),
+			})
+			continue
+		}
+		if err := event.Validate(); err != nil {
+			rejections = append(rejections, EventRejection{Index: i, Status: PublishStatusValidationError, Err: err})
+			continue
+		}
+		size := len(event.Payload)
+		if l.MaxEventPayloadBytes > 0 && size > l.MaxEventPayloadBytes {
+			rejections = append(rejections, EventRejection{
+				Index:  i,
+				Status: PublishStatusQuotaExceeded,
+				Err:    fmt.Errorf("payload is %d bytes, limit is %d: %w", size, l.MaxEventPayloadBytes, ErrQuotaExceeded),
+			})
+			continue
+		}
+		if l.MaxBatchPayloadBytes > 0 && batchBytes+size > l.MaxBatchPayloadBytes {
+			rejections = append(rejections, EventRejection{
+				Index:  i,
+				Status: PublishStatusQuotaExceeded,
+				Err:    fmt.Errorf("batch payload limit of %d bytes reached: %w", l.MaxBatchPayloadBytes, ErrQuotaExceeded),
+			})
+			continue
+		}
+		batchBytes += size
+	}
+	return rejections, nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchEvent(id string, payload string) *Event {
+	return &Event{
+		EventID:   id,
+		EventType: EventTypeArticleCreated,
+		Source:    "alt-backend",
+		CreatedAt: time.Now(),
+		Payload:   []byte(payload),
+	}
+}
+
+func TestBatchLimits_Check(t *testing.T) {
+	t.Run("rejects the whole batch over MaxEvents", func(t *testing.T) {
+		limits := BatchLimits{MaxEvents: 1}
+		_, err := limits.Check([]*Event{batchEvent("a", ""), batchEvent("b", "")})
+		assert.ErrorIs(t, err, ErrBatchTooLarge)
+	})
+
+	t.Run("judges each event on its own", func(t *testing.T) {
+		limits := BatchLimits{MaxEvents: 10, MaxEventPayloadBytes: 3}
+		invalid := batchEvent("", "")
+		rejections, err := limits.Check([]*Event{batchEvent("a", "ok"), nil, invalid, batchEvent("d", "toolong")})
+		require.NoError(t, err)
+		require.Len(t, rejections, 3)
+
+		assert.Equal(t, 1, rejections[0].Index)
+		assert.Equal(t, PublishStatusValidationError, rejections[0].Status)
+		assert.ErrorIs(t, rejections[0].Err, ErrInvalidEvent)
+		assert.Equal(t, 2, rejections[1].Index)
+		assert.ErrorIs(t, rejections[1].Err, ErrInvalidEvent)
+		assert.Equal(t, 3, rejections[2].Index)
+		assert.Equal(t, PublishStatusQuotaExceeded, rejections[2].Status)
+		assert.ErrorIs(t, rejections[2].Err, ErrQuotaExceeded)
+	})
+
+	t.Run("spends the batch payload budget in order", func(t *testing.T) {
+		limits := BatchLimits{MaxBatchPayloadBytes: 5}
+		rejections, err := limits.Check([]*Event{batchEvent("a", "abc"), batchEvent("b", "abc"), batchEvent("c", "ab")})
+		require.NoError(t, err)
+		require.Len(t, rejections, 1)
+		assert.Equal(t, 1, rejections[0].Index)
+		assert.Equal(t, PublishStatusQuotaExceeded, rejections[0].Status)
+	})
+
+	t.Run("zero limits accept everything valid", func(t *testing.T) {
+		rejections, err := BatchLimits{}.Check([]*Event{batchEvent("a", "payload")})
+		require.NoError(t, err)
+		assert.Empty(t, rejections)
+	})
+}
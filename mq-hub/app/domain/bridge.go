@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MetadataUserID is the event metadata key naming the user an event belongs
+// to. The WebSocket bridge only forwards such events to that user.
+const MetadataUserID = "user_id"
+
+// DefaultBridgeStreams are the streams browser clients may subscribe to when
+// WS_BRIDGE_STREAMS is not set. The index stream carries internal commands
+// and is not exposed.
+var DefaultBridgeStreams = []StreamKey{StreamKeyArticles, StreamKeySummaries, StreamKeyTags}
+
+// StreamMessage is one stream entry read for the bridge.
+type StreamMessage struct {
+	// Stream is the stream the entry was read from.
+	Stream StreamKey
+	// ID is the Redis entry ID.
+	ID string
+	// Event is the decoded entry.
+	Event *Event
+}
+
+// StreamHead is the position of a stream at a point in time, sent to lagging
+// clients in place of the events they missed.
+type StreamHead struct {
+	Stream StreamKey
+	// LastEntryID is "" when the stream is empty or does not exist.
+	LastEntryID string
+	Length      int64
+}
+
+// SubscriptionFilter selects which stream entries a bridge connection
+// receives. An empty EventTypes set means every event type.
+type SubscriptionFilter struct {
+	Streams    map[StreamKey]bool
+	EventTypes map[EventType]bool
+}
+
+// NewSubscriptionFilter builds a filter from requested stream and event type
+// names. No streams means all allowed streams; a stream outside allowed is
+// an error.
+func NewSubscriptionFilter(streams, eventTypes []string, allowed []StreamKey) (SubscriptionFilter, error) {
+	permitted := make(map[StreamKey]bool, len(allowed))
+	for _, s := range allowed {
+		permitted[s] = true
+	}
+
+	f := SubscriptionFilter{
+		Streams:    make(map[StreamKey]bool),
+		EventTypes: make(map[EventType]bool),
+	}
+	for _, name := range streams {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		s := StreamKey(name)
+		if !permitted[s] {
+			return SubscriptionFilter{}, fmt.Errorf("stream %q is not available for subscription", name)
+		}
+		f.Streams[s] = true
+	}
+	if len(f.Streams) == 0 {
+		f.Streams = permitted
+	}
+	for _, name := range eventTypes {
+		if name = strings.TrimSpace(name); name != "" {
+			f.EventTypes[EventType(name)] = true
+		}
+	}
+	return f, nil
+}
+
+// StreamList returns the filter's streams in a stable order.
+func (f SubscriptionFilter) StreamList() []StreamKey {
+	out := make([]StreamKey, 0, len(f.Streams))
+	for s := range f.Streams {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Matches reports whether msg should be delivered to userID under f. Events
+// addressed to another user (metadata user_id) never match.
+func (f SubscriptionFilter) Matches(msg *StreamMessage, userID string) bool {
+	if msg == nil || msg.Event == nil || !f.Streams[msg.Stream] {
+		return false
+	}
+	if len(f.EventTypes) > 0 && !f.EventTypes[msg.Event.EventType] {
+		return false
+	}
+	if owner := msg.Event.Metadata[MetadataUserID]; owner != "" && owner != userID {
+		return false
+	}
+	return true
+}
+
+// ParseBridgeStreams parses a comma-separated list of stream keys. Every key
+// must be a known stream.
+func ParseBridgeStreams(raw string) ([]StreamKey, error) {
+	var out []StreamKey
+	seen := make(map[StreamKey]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		s := StreamKey(part)
+		if !s.IsValid() {
+			return nil, fmt.Errorf("unknown stream %q", part)
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no streams given")
+	}
+	return out, nil
+}
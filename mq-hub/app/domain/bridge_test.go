@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSubscriptionFilter(t *testing.T) {
+	allowed := []StreamKey{StreamKeyArticles, StreamKeySummaries}
+
+	f, err := NewSubscriptionFilter(nil, nil, allowed)
+	require.NoError(t, err)
+	assert.Equal(t, allowed, f.StreamList(), "no streams means every allowed stream")
+
+	f, err = NewSubscriptionFilter([]string{" alt:events:summaries "}, []string{"ArticleSummarized"}, allowed)
+	require.NoError(t, err)
+	assert.Equal(t, []StreamKey{StreamKeySummaries}, f.StreamList())
+	assert.True(t, f.EventTypes[EventTypeArticleSummarized])
+
+	_, err = NewSubscriptionFilter([]string{"alt:events:index"}, nil, allowed)
+	assert.Error(t, err, "streams outside the allowed set are rejected")
+}
+
+func TestSubscriptionFilter_Matches(t *testing.T) {
+	f, err := NewSubscriptionFilter([]string{"alt:events:articles"}, []string{"ArticleCreated"}, DefaultBridgeStreams)
+	require.NoError(t, err)
+
+	msg := func(stream StreamKey, eventType EventType, owner string) *StreamMessage {
+		meta := map[string]string{}
+		if owner != "" {
+			meta[MetadataUserID] = owner
+		}
+		return &StreamMessage{Stream: stream, ID: "1-0", Event: &Event{EventType: eventType, Metadata: meta}}
+	}
+
+	assert.True(t, f.Matches(msg(StreamKeyArticles, EventTypeArticleCreated, ""), "u1"))
+	assert.True(t, f.Matches(msg(StreamKeyArticles, EventTypeArticleCreated, "u1"), "u1"))
+	assert.False(t, f.Matches(msg(StreamKeyArticles, EventTypeArticleCreated, "u2"), "u1"), "other users' events are never forwarded")
+	assert.False(t, f.Matches(msg(StreamKeyArticles, EventTypeArticleUpdated, ""), "u1"))
+	assert.False(t, f.Matches(msg(StreamKeyTags, EventTypeArticleCreated, ""), "u1"))
+}
+
+func TestParseBridgeStreams(t *testing.T) {
+	streams, err := ParseBridgeStreams("alt:events:articles, alt:events:tags,alt:events:articles")
+	require.NoError(t, err)
+	assert.Equal(t, []StreamKey{StreamKeyArticles, StreamKeyTags}, streams)
+
+	_, err = ParseBridgeStreams("alt:events:unknown")
+	assert.Error(t, err)
+	_, err = ParseBridgeStreams(" , ")
+	assert.Error(t, err)
+}
@@ -19,6 +19,9 @@ const (
 	// EventTypeArticleUpdated is emitted when an existing article's content
 	// or tags change. Consumers (search-indexer) upsert by article_id.
 	EventTypeArticleUpdated EventType = "ArticleUpdated"
+	// EventTypeArticleDeleted is emitted when an article is deleted.
+	// Consumers (search-indexer) remove it by article_id.
+	EventTypeArticleDeleted EventType = "ArticleDeleted"
 	// EventTypeSummarizeRequested is emitted when summarization is requested.
 	EventTypeSummarizeRequested EventType = "SummarizeRequested"
 	// EventTypeArticleSummarized is emitted when summarization completes.
@@ -31,6 +34,15 @@ const (
 	EventTypeTagGenerationRequested EventType = "TagGenerationRequested"
 	// EventTypeTagGenerationCompleted is the reply event for tag generation.
 	EventTypeTagGenerationCompleted EventType = "TagGenerationCompleted"
+	// EventTypeAudioGenerationRequested is emitted when a user asks for an
+	// article to be read aloud.
+	EventTypeAudioGenerationRequested EventType = "AudioGenerationRequested"
+	// EventTypeFeedSubscribed is emitted when a user subscribes to a feed.
+	EventTypeFeedSubscribed EventType = "FeedSubscribed"
+	// EventTypeFeedUnsubscribed is emitted when a user unsubscribes from a feed.
+	EventTypeFeedUnsubscribed EventType = "FeedUnsubscribed"
+	// EventTypeReadStateChanged is emitted when a user marks a feed read.
+	EventTypeReadStateChanged EventType = "ReadStateChanged"
 )
 
 // Event represents a domain event to be published to Redis Streams.
@@ -47,6 +59,9 @@ type Event struct {
 	Payload []byte
 	// Metadata contains additional context (tracing, correlation IDs).
 	Metadata map[string]string
+	// IdempotencyKey optionally identifies a logical publish across client
+	// retries. It is not written to the stream.
+	IdempotencyKey string
 }
 
 // NewEvent creates a new Event with a generated UUID and current timestamp.
@@ -91,5 +106,8 @@ func (e *Event) Validate() error {
 	if e.CreatedAt.IsZero() {
 		return fmt.Errorf("created_at is required: %w", ErrInvalidEvent)
 	}
+	if len(e.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency_key must be at most %d bytes: %w", MaxIdempotencyKeyLength, ErrInvalidEvent)
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -99,6 +100,18 @@ func TestEvent_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "created_at is required",
 		},
+		{
+			name: "idempotency key too long",
+			event: Event{
+				EventID:        "550e8400-e29b-41d4-a716-446655440000",
+				EventType:      EventTypeArticleCreated,
+				Source:         "alt-backend",
+				CreatedAt:      time.Now(),
+				IdempotencyKey: strings.Repeat("k", MaxIdempotencyKeyLength+1),
+			},
+			wantErr: true,
+			errMsg:  "idempotency_key must be at most",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,28 @@
+package domain
+
+import "errors"
+
+// MaxIdempotencyKeyLength bounds Event.IdempotencyKey. Keys are stored in
+// Redis for the whole idempotency window, so they must stay small.
+const MaxIdempotencyKeyLength = 256
+
+// ErrIdempotencyKeyInFlight is returned when another publish with the same
+// idempotency key has claimed it but not finished yet. The caller should
+// retry after a short delay; the retry then sees the original message ID.
+var ErrIdempotencyKeyInFlight = errors.New("publish with the same idempotency key is in progress")
+
+// IdempotencyClaim is the outcome of claiming one idempotency key.
+type IdempotencyClaim struct {
+	// Claimed is true when this call took the key and must publish the
+	// event, then commit or release the key.
+	Claimed bool
+	// MessageID is the stream ID recorded by an earlier publish with the
+	// same key. It is "" when Claimed is true, or when the earlier publish
+	// is still in flight.
+	MessageID string
+}
+
+// Duplicate reports whether the key belongs to a completed earlier publish.
+func (c IdempotencyClaim) Duplicate() bool {
+	return !c.Claimed && c.MessageID != ""
+}
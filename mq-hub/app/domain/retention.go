@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy bounds how much history a stream keeps. A zero field
+// disables that bound.
+type RetentionPolicy struct {
+	// MaxLen is the approximate maximum number of entries to keep.
+	MaxLen int64
+	// MaxAge drops entries older than this, but never entries a consumer
+	// group has not yet acknowledged.
+	MaxAge time.Duration
+}
+
+// IsZero reports whether the policy enforces nothing.
+func (p RetentionPolicy) IsZero() bool {
+	return p.MaxLen == 0 && p.MaxAge == 0
+}
+
+// String renders the policy in the STREAM_RETENTION option syntax.
+func (p RetentionPolicy) String() string {
+	var parts []string
+	if p.MaxLen > 0 {
+		parts = append(parts, "maxlen:"+strconv.FormatInt(p.MaxLen, 10))
+	}
+	if p.MaxAge > 0 {
+		parts = append(parts, "maxage:"+p.MaxAge.String())
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ",")
+}
+
+// RetentionPolicies maps streams to their retention policy.
+type RetentionPolicies map[StreamKey]RetentionPolicy
+
+// Streams returns the stream keys in deterministic order.
+func (rp RetentionPolicies) Streams() []StreamKey {
+	keys := make([]StreamKey, 0, len(rp))
+	for k := range rp {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// DefaultRetentionPolicies applies def to every known stream.
+func DefaultRetentionPolicies(def RetentionPolicy) RetentionPolicies {
+	policies := make(RetentionPolicies, len(validStreamKeys))
+	for key := range validStreamKeys {
+		policies[key] = def
+	}
+	return policies
+}
+
+// ParseRetentionPolicies parses per-stream overrides of the form
+//
+//	alt:events:articles=maxlen:100000,maxage:168h;alt:events:tags=maxage:72h
+//
+// Each override replaces only the bounds it names; the rest are inherited
+// from base. Unknown options, negative values, and empty stream names are
+// rejected so a typo fails startup instead of silently disabling trimming.
+func ParseRetentionPolicies(spec string, base RetentionPolicies) (RetentionPolicies, error) {
+	policies := make(RetentionPolicies, len(base))
+	for k, v := range base {
+		policies[k] = v
+	}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		stream, opts, ok := strings.Cut(entry, "=")
+		stream = strings.TrimSpace(stream)
+		if !ok || stream == "" {
+			return nil, fmt.Errorf("retention entry %q: expected <stream>=<options>", entry)
+		}
+
+		policy := policies[StreamKey(stream)]
+		for _, opt := range strings.Split(opts, ",") {
+			name, value, ok := strings.Cut(strings.TrimSpace(opt), ":")
+			if !ok {
+				return nil, fmt.Errorf("retention entry %q: option %q: expected <name>:<value>", entry, opt)
+			}
+			switch strings.TrimSpace(name) {
+			case "maxlen":
+				n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("retention entry %q: invalid maxlen %q", entry, value)
+				}
+				policy.MaxLen = n
+			case "maxage":
+				d, err := time.ParseDuration(strings.TrimSpace(value))
+				if err != nil || d < 0 {
+					return nil, fmt.Errorf("retention entry %q: invalid maxage %q", entry, value)
+				}
+				policy.MaxAge = d
+			default:
+				return nil, fmt.Errorf("retention entry %q: unknown option %q", entry, name)
+			}
+		}
+		policies[StreamKey(stream)] = policy
+	}
+	return policies, nil
+}
+
+// CompareStreamIDs compares two Redis stream IDs ("<ms>-<seq>") numerically,
+// returning -1, 0, or +1. A missing sequence part is treated as 0.
+func CompareStreamIDs(a, b string) int {
+	am, as := splitStreamID(a)
+	bm, bs := splitStreamID(b)
+	switch {
+	case am < bm:
+		return -1
+	case am > bm:
+		return 1
+	case as < bs:
+		return -1
+	case as > bs:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// StreamIDAt returns the smallest stream ID that could have been assigned at t.
+func StreamIDAt(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10) + "-0"
+}
+
+func splitStreamID(id string) (uint64, uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(msPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
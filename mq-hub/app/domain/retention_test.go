@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetentionPolicies(t *testing.T) {
+	base := DefaultRetentionPolicies(RetentionPolicy{MaxLen: 10000})
+
+	t.Run("empty spec keeps defaults for every known stream", func(t *testing.T) {
+		policies, err := ParseRetentionPolicies("", base)
+		require.NoError(t, err)
+		assert.Len(t, policies, len(validStreamKeys))
+		assert.Equal(t, RetentionPolicy{MaxLen: 10000}, policies[StreamKeyArticles])
+	})
+
+	t.Run("overrides only the named bounds", func(t *testing.T) {
+		policies, err := ParseRetentionPolicies(
+			"alt:events:articles=maxlen:100000,maxage:168h; alt:events:tags=maxage:72h", base)
+		require.NoError(t, err)
+		assert.Equal(t, RetentionPolicy{MaxLen: 100000, MaxAge: 168 * time.Hour}, policies[StreamKeyArticles])
+		assert.Equal(t, RetentionPolicy{MaxLen: 10000, MaxAge: 72 * time.Hour}, policies[StreamKeyTags])
+		assert.Equal(t, RetentionPolicy{MaxLen: 10000}, policies[StreamKeyIndex])
+	})
+
+	t.Run("maxlen 0 disables the length bound", func(t *testing.T) {
+		policies, err := ParseRetentionPolicies("alt:events:index=maxlen:0", base)
+		require.NoError(t, err)
+		assert.True(t, policies[StreamKeyIndex].IsZero())
+	})
+
+	t.Run("does not mutate base", func(t *testing.T) {
+		_, err := ParseRetentionPolicies("alt:events:articles=maxlen:1", base)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), base[StreamKeyArticles].MaxLen)
+	})
+
+	invalid := []string{
+		"alt:events:articles",
+		"=maxlen:1",
+		"alt:events:articles=maxlen",
+		"alt:events:articles=maxlen:-1",
+		"alt:events:articles=maxage:forever",
+		"alt:events:articles=ttl:1h",
+	}
+	for _, spec := range invalid {
+		t.Run("rejects "+spec, func(t *testing.T) {
+			_, err := ParseRetentionPolicies(spec, base)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRetentionPolicy_String(t *testing.T) {
+	assert.Equal(t, "none", RetentionPolicy{}.String())
+	assert.Equal(t, "maxlen:5,maxage:1h0m0s", RetentionPolicy{MaxLen: 5, MaxAge: time.Hour}.String())
+}
+
+func TestCompareStreamIDs(t *testing.T) {
+	assert.Equal(t, 0, CompareStreamIDs("1700000000000-1", "1700000000000-1"))
+	assert.Equal(t, -1, CompareStreamIDs("1700000000000-9", "1700000000001-0"))
+	assert.Equal(t, 1, CompareStreamIDs("1700000000000-10", "1700000000000-9"))
+	// Numeric, not lexical: 999 < 1000.
+	assert.Equal(t, -1, CompareStreamIDs("999-0", "1000-0"))
+	assert.Equal(t, 0, CompareStreamIDs("5", "5-0"))
+}
+
+func TestStreamIDAt(t *testing.T) {
+	assert.Equal(t, "1700000000000-0", StreamIDAt(time.UnixMilli(1700000000000)))
+}
@@ -18,6 +18,11 @@ const (
 	StreamKeyTags StreamKey = "alt:events:tags"
 	// StreamKeyIndex is the stream for index commands.
 	StreamKeyIndex StreamKey = "alt:events:index"
+	// StreamKeyAudio is the stream for article audio (TTS) generation events.
+	StreamKeyAudio StreamKey = "alt:events:audio"
+	// StreamKeyFeeds is the stream for per-user feed state events:
+	// subscriptions and read state.
+	StreamKeyFeeds StreamKey = "alt:events:feeds"
 )
 
 // validStreamKeys contains all valid stream keys.
@@ -26,6 +31,8 @@ var validStreamKeys = map[StreamKey]bool{
 	StreamKeySummaries: true,
 	StreamKeyTags:      true,
 	StreamKeyIndex:     true,
+	StreamKeyAudio:     true,
+	StreamKeyFeeds:     true,
 }
 
 // IsValid returns true if the stream key is a known valid key.
@@ -49,6 +56,8 @@ const (
 	ConsumerGroupTagGenerator ConsumerGroup = "tag-generator-group"
 	// ConsumerGroupSearchIndexer is the group for search-indexer service.
 	ConsumerGroupSearchIndexer ConsumerGroup = "search-indexer-group"
+	// ConsumerGroupTTSWorker is the group for the article audio (TTS) worker.
+	ConsumerGroupTTSWorker ConsumerGroup = "tts-worker-group"
 )
 
 // validConsumerGroups contains all valid consumer groups.
@@ -56,6 +65,7 @@ var validConsumerGroups = map[ConsumerGroup]bool{
 	ConsumerGroupPreProcessor:  true,
 	ConsumerGroupTagGenerator:  true,
 	ConsumerGroupSearchIndexer: true,
+	ConsumerGroupTTSWorker:     true,
 }
 
 // IsValid returns true if the consumer group is a known valid group.
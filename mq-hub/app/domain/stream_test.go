@@ -12,6 +12,8 @@ func TestStreamKey_Constants(t *testing.T) {
 	assert.Equal(t, StreamKey("alt:events:summaries"), StreamKeySummaries)
 	assert.Equal(t, StreamKey("alt:events:tags"), StreamKeyTags)
 	assert.Equal(t, StreamKey("alt:events:index"), StreamKeyIndex)
+	assert.Equal(t, StreamKey("alt:events:audio"), StreamKeyAudio)
+	assert.Equal(t, StreamKey("alt:events:feeds"), StreamKeyFeeds)
 }
 
 func TestConsumerGroup_Constants(t *testing.T) {
@@ -31,6 +33,8 @@ func TestStreamKey_IsValid(t *testing.T) {
 		{"valid summaries stream", StreamKeySummaries, true},
 		{"valid tags stream", StreamKeyTags, true},
 		{"valid index stream", StreamKeyIndex, true},
+		{"valid audio stream", StreamKeyAudio, true},
+		{"valid feeds stream", StreamKeyFeeds, true},
 		{"invalid stream", StreamKey("invalid"), false},
 		{"empty stream", StreamKey(""), false},
 	}
@@ -51,6 +55,7 @@ func TestConsumerGroup_IsValid(t *testing.T) {
 		{"valid pre-processor group", ConsumerGroupPreProcessor, true},
 		{"valid tag-generator group", ConsumerGroupTagGenerator, true},
 		{"valid search-indexer group", ConsumerGroupSearchIndexer, true},
+		{"valid tts-worker group", ConsumerGroupTTSWorker, true},
 		{"invalid group", ConsumerGroup("invalid"), false},
 		{"empty group", ConsumerGroup(""), false},
 	}
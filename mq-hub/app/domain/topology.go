@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RedisMode selects how mq-hub connects to Redis.
+type RedisMode string
+
+const (
+	// RedisModeStandalone connects to a single Redis server.
+	RedisModeStandalone RedisMode = "standalone"
+	// RedisModeSentinel discovers the master through Redis Sentinel and
+	// follows it across failovers.
+	RedisModeSentinel RedisMode = "sentinel"
+	// RedisModeCluster connects to a Redis Cluster.
+	RedisModeCluster RedisMode = "cluster"
+)
+
+// ParseRedisMode parses a REDIS_MODE value. Empty means standalone.
+func ParseRedisMode(s string) (RedisMode, error) {
+	switch mode := RedisMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return RedisModeStandalone, nil
+	case RedisModeStandalone, RedisModeSentinel, RedisModeCluster:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown redis mode %q (want standalone, sentinel or cluster)", s)
+	}
+}
+
+// Redis node roles reported in a RedisTopology.
+const (
+	RedisRoleMaster   = "master"
+	RedisRoleReplica  = "replica"
+	RedisRoleSentinel = "sentinel"
+)
+
+// RedisNode is one server of the Redis deployment as mq-hub sees it.
+type RedisNode struct {
+	Addr string
+	Role string
+	// Healthy is false for nodes that did not answer, or that Sentinel or
+	// the cluster reports as failing.
+	Healthy bool
+	// Slots is the number of hash slots a cluster master serves.
+	Slots int
+}
+
+// RedisTopology describes the Redis deployment behind mq-hub.
+type RedisTopology struct {
+	Mode RedisMode
+	// Masters are the current master addresses: one outside cluster mode,
+	// one per shard in it.
+	Masters []string
+	Nodes   []RedisNode
+	// State is the cluster state ("ok" or "fail"); empty outside cluster mode.
+	State string
+	// Failovers counts master changes observed since mq-hub started.
+	Failovers int64
+}
+
+// HealthyNodes returns how many nodes of role are healthy and how many
+// there are in total.
+func (t *RedisTopology) HealthyNodes(role string) (healthy, total int) {
+	for _, n := range t.Nodes {
+		if n.Role != role {
+			continue
+		}
+		total++
+		if n.Healthy {
+			healthy++
+		}
+	}
+	return healthy, total
+}
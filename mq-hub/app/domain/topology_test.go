@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedisMode(t *testing.T) {
+	for in, want := range map[string]RedisMode{
+		"":           RedisModeStandalone,
+		"standalone": RedisModeStandalone,
+		"Sentinel":   RedisModeSentinel,
+		" cluster ":  RedisModeCluster,
+	} {
+		got, err := ParseRedisMode(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := ParseRedisMode("replicated")
+	assert.Error(t, err)
+}
+
+func TestRedisTopology_HealthyNodes(t *testing.T) {
+	topo := &RedisTopology{Nodes: []RedisNode{
+		{Addr: "a:6379", Role: RedisRoleMaster, Healthy: true},
+		{Addr: "b:6379", Role: RedisRoleReplica, Healthy: true},
+		{Addr: "c:6379", Role: RedisRoleReplica, Healthy: false},
+	}}
+
+	healthy, total := topo.HealthyNodes(RedisRoleReplica)
+	assert.Equal(t, 1, healthy)
+	assert.Equal(t, 2, total)
+
+	healthy, total = topo.HealthyNodes(RedisRoleSentinel)
+	assert.Zero(t, healthy)
+	assert.Zero(t, total)
+}
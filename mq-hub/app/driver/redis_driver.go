@@ -6,24 +6,65 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 
 	"mq-hub/domain"
+	"mq-hub/metrics"
+)
+
+const (
+	// maxRetryBackoff caps the doubling wait between publish retries.
+	maxRetryBackoff = 5 * time.Second
+	// clusterReadPoll is how long ReadStreams waits between polls in
+	// cluster mode, where it cannot block on several streams at once.
+	clusterReadPoll = 100 * time.Millisecond
 )
 
 // RedisDriver implements StreamPort using Redis Streams.
 type RedisDriver struct {
-	client       *redis.Client
+	client       redis.UniversalClient
+	mode         domain.RedisMode
 	streamMaxLen int64
+	retries      int
+	retryBackoff time.Duration
+
+	// sentinels are queried for the topology in sentinel mode.
+	sentinels  []sentinelConn
+	masterName string
+
+	topoMu sync.Mutex
+	// masters maps each shard (the first slot of its range in cluster mode,
+	// "" otherwise) to the master last seen serving it.
+	masters   map[string]string
+	failovers int64
+}
+
+type sentinelConn struct {
+	addr   string
+	client *redis.SentinelClient
 }
 
 // RedisDriverOptions contains configuration for Redis driver.
 type RedisDriverOptions struct {
 	PoolSize     int
 	StreamMaxLen int64
+	// Mode selects how the URL is read: a redis:// URL of one server, of
+	// the Sentinels (with ?master_name=), or of cluster seed nodes (more
+	// seeds as ?addr=). Empty means standalone.
+	Mode domain.RedisMode
+	// PublishRetries is how many times a publish rejected while Redis fails
+	// over (READONLY, MASTERDOWN, CLUSTERDOWN, TRYAGAIN, LOADING, or a
+	// refused connection) is tried again, waiting PublishRetryBackoff
+	// (doubling) in between. Such errors mean the write was not applied, so
+	// retrying cannot duplicate it.
+	PublishRetries      int
+	PublishRetryBackoff time.Duration
 }
 
 // NewRedisDriver creates a new Redis driver.
@@ -31,7 +72,8 @@ func NewRedisDriver(addr string) (*RedisDriver, error) {
 	return NewRedisDriverWithOptions(addr, nil)
 }
 
-// NewRedisDriverWithOptions creates a new Redis driver with options.
+// NewRedisDriverWithOptions creates a new standalone Redis driver with
+// options. opts.Mode is ignored.
 func NewRedisDriverWithOptions(addr string, opts *RedisDriverOptions) (*RedisDriver, error) {
 	redisOpts := &redis.Options{
 		Addr: addr,
@@ -41,14 +83,7 @@ func NewRedisDriverWithOptions(addr string, opts *RedisDriverOptions) (*RedisDri
 		redisOpts.PoolSize = opts.PoolSize
 	}
 
-	client := redis.NewClient(redisOpts)
-
-	d := &RedisDriver{client: client}
-	if opts != nil && opts.StreamMaxLen > 0 {
-		d.streamMaxLen = opts.StreamMaxLen
-	}
-
-	return d, nil
+	return newRedisDriver(redis.NewClient(redisOpts), domain.RedisModeStandalone, opts), nil
 }
 
 // NewRedisDriverWithURL creates a new Redis driver from a URL.
@@ -56,29 +91,94 @@ func NewRedisDriverWithURL(url string) (*RedisDriver, error) {
 	return NewRedisDriverWithURLAndOptions(url, nil)
 }
 
-// NewRedisDriverWithURLAndOptions creates a new Redis driver from a URL with options.
+// NewRedisDriverWithURLAndOptions creates a new Redis driver from a URL with
+// options, in the mode driverOpts.Mode selects.
 func NewRedisDriverWithURLAndOptions(url string, driverOpts *RedisDriverOptions) (*RedisDriver, error) {
-	opts, err := redis.ParseURL(url)
-	if err != nil {
-		return nil, fmt.Errorf("parse redis url: %w", err)
+	mode := domain.RedisModeStandalone
+	if driverOpts != nil && driverOpts.Mode != "" {
+		mode = driverOpts.Mode
 	}
-
-	if driverOpts != nil && driverOpts.PoolSize > 0 {
-		opts.PoolSize = driverOpts.PoolSize
+	poolSize := 0
+	if driverOpts != nil {
+		poolSize = driverOpts.PoolSize
 	}
 
-	client := redis.NewClient(opts)
+	switch mode {
+	case domain.RedisModeStandalone:
+		opts, err := redis.ParseURL(url)
+		if err != nil {
+			return nil, fmt.Errorf("parse redis url: %w", err)
+		}
+		if poolSize > 0 {
+			opts.PoolSize = poolSize
+		}
+		return newRedisDriver(redis.NewClient(opts), mode, driverOpts), nil
 
-	d := &RedisDriver{client: client}
-	if driverOpts != nil && driverOpts.StreamMaxLen > 0 {
-		d.streamMaxLen = driverOpts.StreamMaxLen
+	case domain.RedisModeSentinel:
+		opts, err := redis.ParseFailoverURL(url)
+		if err != nil {
+			return nil, fmt.Errorf("parse redis sentinel url: %w", err)
+		}
+		if opts.MasterName == "" {
+			return nil, errors.New("parse redis sentinel url: master_name is required")
+		}
+		if poolSize > 0 {
+			opts.PoolSize = poolSize
+		}
+		d := newRedisDriver(redis.NewFailoverClient(opts), mode, driverOpts)
+		d.masterName = opts.MasterName
+		for _, addr := range opts.SentinelAddrs {
+			d.sentinels = append(d.sentinels, sentinelConn{
+				addr: addr,
+				client: redis.NewSentinelClient(&redis.Options{
+					Addr:      addr,
+					Username:  opts.SentinelUsername,
+					Password:  opts.SentinelPassword,
+					TLSConfig: opts.TLSConfig,
+				}),
+			})
+		}
+		return d, nil
+
+	case domain.RedisModeCluster:
+		opts, err := redis.ParseClusterURL(url)
+		if err != nil {
+			return nil, fmt.Errorf("parse redis cluster url: %w", err)
+		}
+		if poolSize > 0 {
+			opts.PoolSize = poolSize
+		}
+		return newRedisDriver(redis.NewClusterClient(opts), mode, driverOpts), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported redis mode %q", mode)
 	}
+}
+
+func newRedisDriver(client redis.UniversalClient, mode domain.RedisMode, opts *RedisDriverOptions) *RedisDriver {
+	d := &RedisDriver{client: client, mode: mode, masters: make(map[string]string)}
+	if opts != nil {
+		if opts.StreamMaxLen > 0 {
+			d.streamMaxLen = opts.StreamMaxLen
+		}
+		if opts.PublishRetries > 0 && opts.PublishRetryBackoff > 0 {
+			d.retries = opts.PublishRetries
+			d.retryBackoff = opts.PublishRetryBackoff
+		}
+	}
+	return d
+}
 
-	return d, nil
+// Mode returns the mode the driver connects to Redis in.
+func (d *RedisDriver) Mode() domain.RedisMode {
+	return d.mode
 }
 
-// Close closes the Redis connection.
+// Close closes the Redis connections.
 func (d *RedisDriver) Close() error {
+	for _, sc := range d.sentinels {
+		_ = sc.client.Close()
+	}
 	return d.client.Close()
 }
 
@@ -102,7 +202,11 @@ func (d *RedisDriver) Publish(ctx context.Context, stream domain.StreamKey, even
 		args.Approx = true
 	}
 
-	result, err := d.client.XAdd(ctx, args).Result()
+	var result string
+	err = d.retryFailover(ctx, stream, func() error {
+		result, err = d.client.XAdd(ctx, args).Result()
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("xadd %s: %w", stream.String(), err)
 	}
@@ -123,10 +227,7 @@ func (d *RedisDriver) PublishBatch(ctx context.Context, stream domain.StreamKey,
 	}
 
 	messageIDs := make([]string, len(events))
-	cmds := make([]*redis.StringCmd, len(events))
-
-	// Use pipeline for efficient batch publishing
-	pipe := d.client.Pipeline()
+	xaddArgs := make([]*redis.XAddArgs, len(events))
 
 	for i, event := range events {
 		if event == nil {
@@ -144,22 +245,57 @@ func (d *RedisDriver) PublishBatch(ctx context.Context, stream domain.StreamKey,
 			args.MaxLen = d.streamMaxLen
 			args.Approx = true
 		}
-		cmds[i] = pipe.XAdd(ctx, args)
-	}
+		xaddArgs[i] = args
+	}
+
+	// Use pipeline for efficient batch publishing. Events rejected because
+	// Redis is failing over are sent again in a smaller pipeline.
+	pending := make([]int, len(events))
+	for i := range pending {
+		pending[i] = i
+	}
+	errs := make([]error, len(events))
+	var execErr error
+	for attempt := 0; ; attempt++ {
+		pipe := d.client.Pipeline()
+		cmds := make([]*redis.StringCmd, len(pending))
+		for j, i := range pending {
+			cmds[j] = pipe.XAdd(ctx, xaddArgs[i])
+		}
 
-	// Exec only reports whether the pipeline as a whole had an error; it does
-	// not tell us which individual XADD commands failed. Redis still runs
-	// every queued command even when one fails, so we must inspect each
-	// cmd.Err() to know which events actually landed.
-	_, execErr := pipe.Exec(ctx)
+		// Exec only reports whether the pipeline as a whole had an error; it does
+		// not tell us which individual XADD commands failed. Redis still runs
+		// every queued command even when one fails, so we must inspect each
+		// cmd.Err() to know which events actually landed.
+		_, execErr = pipe.Exec(ctx)
+
+		var retry []int
+		for j, i := range pending {
+			if err := cmds[j].Err(); err != nil {
+				errs[i] = err
+				if isFailoverErr(err) {
+					retry = append(retry, i)
+				}
+				continue
+			}
+			errs[i] = nil
+			messageIDs[i] = cmds[j].Val()
+		}
+		if len(retry) == 0 || attempt >= d.retries {
+			break
+		}
+		metrics.RecordPublishRetry(stream.String(), len(retry))
+		if err := d.waitRetry(ctx, attempt); err != nil {
+			break
+		}
+		pending = retry
+	}
 
 	var failures []domain.PublishFailure
-	for i, cmd := range cmds {
-		if err := cmd.Err(); err != nil {
+	for i, err := range errs {
+		if err != nil {
 			failures = append(failures, domain.PublishFailure{Index: i, Err: err})
-			continue
 		}
-		messageIDs[i] = cmd.Val()
 	}
 
 	if len(failures) > 0 {
@@ -172,6 +308,37 @@ func (d *RedisDriver) PublishBatch(ctx context.Context, stream domain.StreamKey,
 	return messageIDs, nil
 }
 
+// retryFailover runs op until it succeeds, fails with an error other than a
+// failover error, or has been retried d.retries times.
+func (d *RedisDriver) retryFailover(ctx context.Context, stream domain.StreamKey, op func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= d.retries || !isFailoverErr(err) {
+			return err
+		}
+		metrics.RecordPublishRetry(stream.String(), 1)
+		if d.waitRetry(ctx, attempt) != nil {
+			return err
+		}
+	}
+}
+
+// waitRetry sleeps before retry attempt+1, doubling the backoff each time.
+func (d *RedisDriver) waitRetry(ctx context.Context, attempt int) error {
+	backoff := d.retryBackoff << attempt
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // CreateConsumerGroup creates a consumer group for a stream.
 func (d *RedisDriver) CreateConsumerGroup(ctx context.Context, stream domain.StreamKey, group domain.ConsumerGroup, startID string) error {
 	err := d.client.XGroupCreateMkStream(ctx, stream.String(), group.String(), startID).Err()
@@ -278,6 +445,22 @@ func isNoSuchKeyErr(err error) bool {
 	return strings.Contains(msg, "no such key") || strings.HasPrefix(msg, "ERR no such key")
 }
 
+// isFailoverErr reports whether err means Redis rejected a command because
+// it is failing over or resharding: the command was not applied and may be
+// sent again once the new master is up.
+func isFailoverErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if redis.IsReadOnlyError(err) || redis.IsMasterDownError(err) || redis.IsClusterDownError(err) ||
+		redis.IsTryAgainError(err) || redis.IsLoadingError(err) {
+		return true
+	}
+	// The old master is gone and the client has not switched yet.
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 // SubscribeWithTimeout waits for a message on a reply stream with timeout.
 // Uses XREAD with blocking to wait for messages.
 func (d *RedisDriver) SubscribeWithTimeout(ctx context.Context, stream domain.StreamKey, timeout time.Duration) (*domain.Event, error) {
@@ -314,6 +497,254 @@ func (d *RedisDriver) Expire(ctx context.Context, stream domain.StreamKey, ttl t
 	return d.client.Expire(ctx, stream.String(), ttl).Err()
 }
 
+// TrimMaxLen approximately trims a stream to maxLen entries (XTRIM MAXLEN ~).
+func (d *RedisDriver) TrimMaxLen(ctx context.Context, stream domain.StreamKey, maxLen int64) (int64, error) {
+	n, err := d.client.XTrimMaxLenApprox(ctx, stream.String(), maxLen, 0).Result()
+	if err != nil {
+		return 0, fmt.Errorf("xtrim maxlen %s: %w", stream.String(), err)
+	}
+	return n, nil
+}
+
+// TrimMinID approximately removes entries older than minID (XTRIM MINID ~).
+func (d *RedisDriver) TrimMinID(ctx context.Context, stream domain.StreamKey, minID string) (int64, error) {
+	n, err := d.client.XTrimMinIDApprox(ctx, stream.String(), minID, 0).Result()
+	if err != nil {
+		return 0, fmt.Errorf("xtrim minid %s: %w", stream.String(), err)
+	}
+	return n, nil
+}
+
+// OldestUnackedID returns the lowest entry ID still needed by any consumer
+// group, or "" if the stream has no groups (or does not exist).
+func (d *RedisDriver) OldestUnackedID(ctx context.Context, stream domain.StreamKey) (string, error) {
+	groups, err := d.client.XInfoGroups(ctx, stream.String()).Result()
+	if err != nil {
+		if isNoSuchKeyErr(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("xinfo groups %s: %w", stream.String(), err)
+	}
+
+	oldest := ""
+	for _, g := range groups {
+		boundary := g.LastDeliveredID
+		if boundary == "0" {
+			// A group created at "0" that has delivered nothing reports
+			// its last-delivered ID without a sequence part.
+			boundary = "0-0"
+		}
+		if g.Pending > 0 {
+			pending, err := d.client.XPending(ctx, stream.String(), g.Name).Result()
+			if err != nil {
+				return "", fmt.Errorf("xpending %s %s: %w", stream.String(), g.Name, err)
+			}
+			boundary = pending.Lower
+		}
+		if oldest == "" || domain.CompareStreamIDs(boundary, oldest) < 0 {
+			oldest = boundary
+		}
+	}
+	return oldest, nil
+}
+
+// ReadStreams reads entries after the given IDs from several streams with
+// one blocking XREAD. A block timeout returns no messages and no error.
+func (d *RedisDriver) ReadStreams(ctx context.Context, after map[domain.StreamKey]string, count int64, block time.Duration) ([]domain.StreamMessage, error) {
+	if len(after) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(after))
+	for stream := range after {
+		keys = append(keys, stream.String())
+	}
+	sort.Strings(keys)
+	args := make([]string, 0, 2*len(keys))
+	args = append(args, keys...)
+	for _, k := range keys {
+		args = append(args, after[domain.StreamKey(k)])
+	}
+
+	if d.mode == domain.RedisModeCluster && len(keys) > 1 {
+		return d.readStreamsCluster(ctx, keys, after, count, block)
+	}
+
+	streams, err := d.client.XRead(ctx, &redis.XReadArgs{
+		Streams: args,
+		Count:   count,
+		Block:   block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("xread: %w", err)
+	}
+
+	return d.streamMessages(streams), nil
+}
+
+// readStreamsCluster reads each stream with its own non-blocking XREAD, as a
+// cluster rejects an XREAD over keys in different hash slots. When nothing is
+// new it waits up to clusterReadPoll before returning, so callers looping on
+// ReadStreams poll instead of spinning.
+func (d *RedisDriver) readStreamsCluster(ctx context.Context, keys []string, after map[domain.StreamKey]string, count int64, block time.Duration) ([]domain.StreamMessage, error) {
+	pipe := d.client.Pipeline()
+	cmds := make([]*redis.XStreamSliceCmd, len(keys))
+	for i, k := range keys {
+		cmds[i] = pipe.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{k, after[domain.StreamKey(k)]},
+			Count:   count,
+			Block:   -1,
+		})
+	}
+	_, _ = pipe.Exec(ctx)
+
+	var streams []redis.XStream
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			return nil, fmt.Errorf("xread: %w", err)
+		}
+		streams = append(streams, cmd.Val()...)
+	}
+	if out := d.streamMessages(streams); len(out) > 0 {
+		return out, nil
+	}
+
+	wait := clusterReadPoll
+	if block > 0 && block < wait {
+		wait = block
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+	return nil, nil
+}
+
+// streamMessages flattens an XREAD reply.
+func (d *RedisDriver) streamMessages(streams []redis.XStream) []domain.StreamMessage {
+	var out []domain.StreamMessage
+	for _, s := range streams {
+		for _, msg := range s.Messages {
+			out = append(out, domain.StreamMessage{
+				Stream: domain.StreamKey(s.Stream),
+				ID:     msg.ID,
+				Event:  d.parseEventFromMessage(msg),
+			})
+		}
+	}
+	return out
+}
+
+// StreamHead returns the last entry ID and length of a stream. Unlike
+// GetStreamInfo it does not fail for a stream that does not exist yet.
+func (d *RedisDriver) StreamHead(ctx context.Context, stream domain.StreamKey) (domain.StreamHead, error) {
+	head := domain.StreamHead{Stream: stream}
+
+	pipe := d.client.Pipeline()
+	lenCmd := pipe.XLen(ctx, stream.String())
+	lastCmd := pipe.XRevRangeN(ctx, stream.String(), "+", "-", 1)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return head, fmt.Errorf("read head of %s: %w", stream.String(), err)
+	}
+
+	head.Length = lenCmd.Val()
+	if last := lastCmd.Val(); len(last) > 0 {
+		head.LastEntryID = last[0].ID
+	}
+	return head, nil
+}
+
+// idempotencyPending is stored under a claimed idempotency key until the
+// publish commits its message ID.
+const idempotencyPending = "pending"
+
+// idempotencyKey is the Redis key holding one stream's idempotency key.
+func idempotencyKey(stream domain.StreamKey, key string) string {
+	return "mqhub:idempotency:" + stream.String() + ":" + key
+}
+
+// ClaimIdempotencyKeys claims keys with SET NX and reads the current value
+// of the ones already held, in two pipelined round trips.
+func (d *RedisDriver) ClaimIdempotencyKeys(ctx context.Context, stream domain.StreamKey, keys []string, pendingTTL time.Duration) ([]domain.IdempotencyClaim, error) {
+	claims := make([]domain.IdempotencyClaim, len(keys))
+	if len(keys) == 0 {
+		return claims, nil
+	}
+
+	pipe := d.client.Pipeline()
+	setCmds := make([]*redis.BoolCmd, len(keys))
+	for i, key := range keys {
+		setCmds[i] = pipe.SetNX(ctx, idempotencyKey(stream, key), idempotencyPending, pendingTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("claim idempotency keys on %s: %w", stream.String(), err)
+	}
+
+	pipe = d.client.Pipeline()
+	getCmds := make(map[int]*redis.StringCmd)
+	for i, cmd := range setCmds {
+		if cmd.Val() {
+			claims[i].Claimed = true
+			continue
+		}
+		getCmds[i] = pipe.Get(ctx, idempotencyKey(stream, keys[i]))
+	}
+	if len(getCmds) == 0 {
+		return claims, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("read idempotency keys on %s: %w", stream.String(), err)
+	}
+	for i, cmd := range getCmds {
+		// A key that expired between the two round trips reads as nil and
+		// is reported as in flight; the caller's retry will claim it.
+		if v := cmd.Val(); v != idempotencyPending {
+			claims[i].MessageID = v
+		}
+	}
+	return claims, nil
+}
+
+// CommitIdempotencyKeys overwrites the in-flight marker of each key with its
+// message ID.
+func (d *RedisDriver) CommitIdempotencyKeys(ctx context.Context, stream domain.StreamKey, messageIDs map[string]string, ttl time.Duration) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	pipe := d.client.Pipeline()
+	for key, id := range messageIDs {
+		pipe.Set(ctx, idempotencyKey(stream, key), id, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("commit idempotency keys on %s: %w", stream.String(), err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKeys deletes claimed keys. Each key gets its own DEL:
+// in cluster mode the keys live in different hash slots.
+func (d *RedisDriver) ReleaseIdempotencyKeys(ctx context.Context, stream domain.StreamKey, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	pipe := d.client.Pipeline()
+	for _, key := range keys {
+		pipe.Del(ctx, idempotencyKey(stream, key))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("release idempotency keys on %s: %w", stream.String(), err)
+	}
+	return nil
+}
+
 // parseEventFromMessage converts a Redis stream message to a domain Event.
 func (d *RedisDriver) parseEventFromMessage(msg redis.XMessage) *domain.Event {
 	event := &domain.Event{
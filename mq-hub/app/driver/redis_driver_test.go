@@ -294,3 +294,149 @@ func setupTestDriver(t *testing.T) (*RedisDriver, func()) {
 
 	return driver, cleanup
 }
+
+func TestRedisDriver_Trim(t *testing.T) {
+	publishN := func(t *testing.T, d *RedisDriver, n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			_, err := d.Publish(context.Background(), domain.StreamKeyArticles, &domain.Event{
+				EventID:   fmt.Sprintf("evt-%d", i),
+				EventType: domain.EventTypeArticleCreated,
+				Source:    "test",
+				CreatedAt: time.Now(),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("TrimMaxLen removes the oldest entries", func(t *testing.T) {
+		driver, cleanup := setupTestDriver(t)
+		defer cleanup()
+		ctx := context.Background()
+		publishN(t, driver, 10)
+
+		removed, err := driver.TrimMaxLen(ctx, domain.StreamKeyArticles, 4)
+		require.NoError(t, err)
+
+		info, err := driver.GetStreamInfo(ctx, domain.StreamKeyArticles)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), info.Length+removed)
+		assert.LessOrEqual(t, info.Length, int64(10))
+	})
+
+	t.Run("TrimMinID removes entries below the ID", func(t *testing.T) {
+		driver, cleanup := setupTestDriver(t)
+		defer cleanup()
+		ctx := context.Background()
+		publishN(t, driver, 3)
+
+		info, err := driver.GetStreamInfo(ctx, domain.StreamKeyArticles)
+		require.NoError(t, err)
+
+		_, err = driver.TrimMinID(ctx, domain.StreamKeyArticles, info.LastEntryID)
+		require.NoError(t, err)
+
+		info, err = driver.GetStreamInfo(ctx, domain.StreamKeyArticles)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, info.Length, int64(1))
+		assert.LessOrEqual(t, info.Length, int64(3))
+	})
+
+	t.Run("OldestUnackedID", func(t *testing.T) {
+		driver, cleanup := setupTestDriver(t)
+		defer cleanup()
+		ctx := context.Background()
+
+		id, err := driver.OldestUnackedID(ctx, domain.StreamKeyArticles)
+		require.NoError(t, err)
+		assert.Empty(t, id, "missing stream has no groups")
+
+		publishN(t, driver, 3)
+		id, err = driver.OldestUnackedID(ctx, domain.StreamKeyArticles)
+		require.NoError(t, err)
+		assert.Empty(t, id, "stream without groups has no constraint")
+
+		require.NoError(t, driver.CreateConsumerGroup(ctx, domain.StreamKeyArticles, domain.ConsumerGroupPreProcessor, "0"))
+		id, err = driver.OldestUnackedID(ctx, domain.StreamKeyArticles)
+		require.NoError(t, err)
+		assert.Equal(t, "0-0", id, "nothing delivered yet: every entry is still needed")
+	})
+}
+
+func TestRedisDriver_ReadStreams(t *testing.T) {
+	driver, cleanup := setupTestDriver(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	head, err := driver.StreamHead(ctx, domain.StreamKeyArticles)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StreamHead{Stream: domain.StreamKeyArticles}, head, "missing stream has an empty head")
+
+	event := &domain.Event{
+		EventID:   "evt-1",
+		EventType: domain.EventTypeArticleCreated,
+		Source:    "test",
+		CreatedAt: time.Now(),
+		Payload:   []byte(`{"article_id":"a1"}`),
+		Metadata:  map[string]string{domain.MetadataUserID: "u1"},
+	}
+	articleID, err := driver.Publish(ctx, domain.StreamKeyArticles, event)
+	require.NoError(t, err)
+	tagID, err := driver.Publish(ctx, domain.StreamKeyTags, &domain.Event{
+		EventID: "evt-2", EventType: domain.EventTypeTagsGenerated, Source: "test", CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	msgs, err := driver.ReadStreams(ctx, map[domain.StreamKey]string{
+		domain.StreamKeyArticles: "0-0",
+		domain.StreamKeyTags:     "0-0",
+	}, 10, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	byStream := map[domain.StreamKey]domain.StreamMessage{}
+	for _, m := range msgs {
+		byStream[m.Stream] = m
+	}
+	assert.Equal(t, articleID, byStream[domain.StreamKeyArticles].ID)
+	assert.Equal(t, "u1", byStream[domain.StreamKeyArticles].Event.Metadata[domain.MetadataUserID])
+	assert.Equal(t, tagID, byStream[domain.StreamKeyTags].ID)
+
+	msgs, err = driver.ReadStreams(ctx, map[domain.StreamKey]string{domain.StreamKeyArticles: articleID}, 10, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, msgs, "block timeout returns no messages")
+
+	head, err = driver.StreamHead(ctx, domain.StreamKeyArticles)
+	require.NoError(t, err)
+	assert.Equal(t, articleID, head.LastEntryID)
+	assert.Equal(t, int64(1), head.Length)
+}
+
+func TestRedisDriver_IdempotencyKeys(t *testing.T) {
+	driver, cleanup := setupTestDriver(t)
+	defer cleanup()
+	ctx := context.Background()
+	stream := domain.StreamKeyArticles
+
+	claims, err := driver.ClaimIdempotencyKeys(ctx, stream, []string{"a", "b"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.IdempotencyClaim{{Claimed: true}, {Claimed: true}}, claims)
+
+	// A second claim sees "a" in flight until it is committed.
+	claims, err = driver.ClaimIdempotencyKeys(ctx, stream, []string{"a"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, domain.IdempotencyClaim{}, claims[0])
+
+	require.NoError(t, driver.CommitIdempotencyKeys(ctx, stream, map[string]string{"a": "1-0"}, time.Hour))
+	require.NoError(t, driver.ReleaseIdempotencyKeys(ctx, stream, []string{"b"}))
+
+	claims, err = driver.ClaimIdempotencyKeys(ctx, stream, []string{"a", "b"}, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claims[0].Duplicate())
+	assert.Equal(t, "1-0", claims[0].MessageID)
+	assert.True(t, claims[1].Claimed, "released key can be claimed again")
+
+	// Keys are scoped per stream.
+	claims, err = driver.ClaimIdempotencyKeys(ctx, domain.StreamKeySummaries, []string{"a"}, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claims[0].Claimed)
+}
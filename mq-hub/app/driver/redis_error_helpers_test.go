@@ -2,6 +2,7 @@ package driver
 
 import (
 	"errors"
+	"net"
 	"testing"
 )
 
@@ -53,3 +54,32 @@ func TestIsNoSuchKeyErr(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFailoverErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "readonly replica", err: errors.New("READONLY You can't write against a read only replica."), want: true},
+		{name: "master down", err: errors.New("MASTERDOWN Link with MASTER is down"), want: true},
+		{name: "cluster down", err: errors.New("CLUSTERDOWN The cluster is down"), want: true},
+		{name: "try again", err: errors.New("TRYAGAIN Multiple keys request during rehashing of slot"), want: true},
+		{name: "loading", err: errors.New("LOADING Redis is loading the dataset in memory"), want: true},
+		{name: "dial refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, want: true},
+		{name: "read timeout", err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("i/o timeout")}, want: false},
+		{name: "wrong type", err: errors.New("WRONGTYPE Operation against a key holding the wrong kind of value"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isFailoverErr(tt.err); got != tt.want {
+				t.Fatalf("isFailoverErr(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"mq-hub/domain"
+	"mq-hub/metrics"
+)
+
+// Topology reports the Redis deployment: the current masters, every node
+// mq-hub knows of with its health, and how many master changes were seen.
+// Each call compares the masters with the previous call's, so failovers are
+// noticed as often as the topology is checked.
+func (d *RedisDriver) Topology(ctx context.Context) (*domain.RedisTopology, error) {
+	var (
+		topo   *domain.RedisTopology
+		shards map[string]string
+		err    error
+	)
+	switch d.mode {
+	case domain.RedisModeSentinel:
+		topo, shards, err = d.sentinelTopology(ctx)
+	case domain.RedisModeCluster:
+		topo, shards, err = d.clusterTopology(ctx)
+	default:
+		topo, shards = d.standaloneTopology(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	topo.Failovers = d.observeMasters(ctx, shards)
+	return topo, nil
+}
+
+func (d *RedisDriver) standaloneTopology(ctx context.Context) (*domain.RedisTopology, map[string]string) {
+	addr := ""
+	if c, ok := d.client.(*redis.Client); ok {
+		addr = c.Options().Addr
+	}
+	return &domain.RedisTopology{
+		Mode:    domain.RedisModeStandalone,
+		Masters: []string{addr},
+		Nodes: []domain.RedisNode{{
+			Addr:    addr,
+			Role:    domain.RedisRoleMaster,
+			Healthy: d.client.Ping(ctx).Err() == nil,
+		}},
+	}, map[string]string{"": addr}
+}
+
+// sentinelTopology asks the Sentinels for the master and its replicas. The
+// first Sentinel that answers is believed; the others are only checked for
+// reachability.
+func (d *RedisDriver) sentinelTopology(ctx context.Context) (*domain.RedisTopology, map[string]string, error) {
+	topo := &domain.RedisTopology{Mode: domain.RedisModeSentinel}
+	var (
+		master   string
+		replicas []map[string]string
+		lastErr  error
+	)
+	for _, sc := range d.sentinels {
+		node := domain.RedisNode{Addr: sc.addr, Role: domain.RedisRoleSentinel}
+		addr, err := sc.client.GetMasterAddrByName(ctx, d.masterName).Result()
+		if err == nil && len(addr) == 2 {
+			node.Healthy = true
+			if master == "" {
+				master = net.JoinHostPort(addr[0], addr[1])
+				replicas, err = sc.client.Replicas(ctx, d.masterName).Result()
+				if err != nil {
+					slog.WarnContext(ctx, "redis_sentinel_replicas_failed", "sentinel", sc.addr, "error", err)
+				}
+			}
+		} else if err != nil {
+			lastErr = err
+		}
+		topo.Nodes = append(topo.Nodes, node)
+	}
+	if master == "" {
+		if lastErr == nil {
+			lastErr = errors.New("no sentinel configured")
+		}
+		return nil, nil, fmt.Errorf("resolve redis master %q: %w", d.masterName, lastErr)
+	}
+
+	topo.Masters = []string{master}
+	topo.Nodes = append(topo.Nodes, domain.RedisNode{
+		Addr:    master,
+		Role:    domain.RedisRoleMaster,
+		Healthy: d.client.Ping(ctx).Err() == nil,
+	})
+	for _, r := range replicas {
+		topo.Nodes = append(topo.Nodes, domain.RedisNode{
+			Addr:    net.JoinHostPort(r["ip"], r["port"]),
+			Role:    domain.RedisRoleReplica,
+			Healthy: !sentinelFlagsDown(r["flags"]),
+		})
+	}
+	sortNodes(topo.Nodes)
+	return topo, map[string]string{"": master}, nil
+}
+
+// sentinelFlagsDown reports whether a Sentinel flags field marks the node as
+// unusable.
+func sentinelFlagsDown(flags string) bool {
+	for _, f := range strings.Split(flags, ",") {
+		switch f {
+		case "s_down", "o_down", "disconnected":
+			return true
+		}
+	}
+	return false
+}
+
+// clusterTopology reads the slot map and pings every shard node.
+func (d *RedisDriver) clusterTopology(ctx context.Context) (*domain.RedisTopology, map[string]string, error) {
+	cc, ok := d.client.(*redis.ClusterClient)
+	if !ok {
+		return nil, nil, errors.New("cluster topology requires a cluster client")
+	}
+	slots, err := cc.ClusterSlots(ctx).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cluster slots: %w", err)
+	}
+
+	var mu sync.Mutex
+	reachable := make(map[string]bool)
+	_ = cc.ForEachShard(ctx, func(ctx context.Context, c *redis.Client) error {
+		if c.Ping(ctx).Err() == nil {
+			mu.Lock()
+			reachable[c.NodeAddress()] = true
+			mu.Unlock()
+		}
+		return nil
+	})
+
+	topo := &domain.RedisTopology{Mode: domain.RedisModeCluster, State: clusterState(ctx, cc)}
+	shards := make(map[string]string, len(slots))
+	nodes := make(map[string]*domain.RedisNode)
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start < slots[j].Start })
+	for _, s := range slots {
+		for i, n := range s.Nodes {
+			node, ok := nodes[n.Addr]
+			if !ok {
+				node = &domain.RedisNode{Addr: n.Addr, Role: domain.RedisRoleReplica, Healthy: reachable[n.Addr]}
+				nodes[n.Addr] = node
+			}
+			if i == 0 {
+				node.Role = domain.RedisRoleMaster
+				node.Slots += s.End - s.Start + 1
+			}
+		}
+		if len(s.Nodes) > 0 {
+			shards[strconv.Itoa(s.Start)] = s.Nodes[0].Addr
+			if !slices.Contains(topo.Masters, s.Nodes[0].Addr) {
+				topo.Masters = append(topo.Masters, s.Nodes[0].Addr)
+			}
+		}
+	}
+	for _, n := range nodes {
+		topo.Nodes = append(topo.Nodes, *n)
+	}
+	sortNodes(topo.Nodes)
+	return topo, shards, nil
+}
+
+// clusterState returns the cluster_state field of CLUSTER INFO, or
+// "unknown" when it cannot be read.
+func clusterState(ctx context.Context, cc *redis.ClusterClient) string {
+	info, err := cc.ClusterInfo(ctx).Result()
+	if err != nil {
+		return "unknown"
+	}
+	for _, line := range strings.Split(info, "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), "cluster_state:"); ok {
+			return v
+		}
+	}
+	return "unknown"
+}
+
+// observeMasters records the masters of shards, counting every shard whose
+// master differs from the last observation as a failover, and returns the
+// running failover count.
+func (d *RedisDriver) observeMasters(ctx context.Context, shards map[string]string) int64 {
+	d.topoMu.Lock()
+	defer d.topoMu.Unlock()
+
+	for shard, addr := range shards {
+		prev, seen := d.masters[shard]
+		if seen && prev != addr {
+			d.failovers++
+			metrics.RecordRedisFailover()
+			slog.WarnContext(ctx, "redis_failover_detected",
+				"mode", string(d.mode),
+				"shard", shard,
+				"old_master", prev,
+				"new_master", addr,
+			)
+		}
+	}
+	d.masters = shards
+	return d.failovers
+}
+
+// sortNodes orders nodes by role (masters first) and address.
+func sortNodes(nodes []domain.RedisNode) {
+	rank := map[string]int{domain.RedisRoleMaster: 0, domain.RedisRoleReplica: 1, domain.RedisRoleSentinel: 2}
+	sort.Slice(nodes, func(i, j int) bool {
+		if rank[nodes[i].Role] != rank[nodes[j].Role] {
+			return rank[nodes[i].Role] < rank[nodes[j].Role]
+		}
+		return nodes[i].Addr < nodes[j].Addr
+	})
+}
@@ -0,0 +1,196 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mq-hub/domain"
+)
+
+// failoverHook makes the next fails commands or pipelines fail with a
+// READONLY error, as a replica does right after a failover.
+type failoverHook struct {
+	fails int
+}
+
+var errReadOnly = errors.New("READONLY You can't write against a read only replica.")
+
+func (h *failoverHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *failoverHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if h.fails > 0 {
+			h.fails--
+			cmd.SetErr(errReadOnly)
+			return errReadOnly
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h *failoverHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if h.fails > 0 {
+			h.fails--
+			// Only the first command lands on the old master.
+			cmds[0].SetErr(errReadOnly)
+			err := next(ctx, cmds[1:])
+			if err == nil {
+				err = errReadOnly
+			}
+			return err
+		}
+		return next(ctx, cmds)
+	}
+}
+
+func setupRetryingDriver(t *testing.T, fails, retries int) *RedisDriver {
+	t.Helper()
+	mr := NewMiniredis(t)
+	t.Cleanup(mr.Close)
+	d, err := NewRedisDriverWithOptions(mr.Addr(), &RedisDriverOptions{
+		PublishRetries:      retries,
+		PublishRetryBackoff: time.Millisecond,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { d.Close() })
+	d.client.AddHook(&failoverHook{fails: fails})
+	return d
+}
+
+func testEvent(id string) *domain.Event {
+	return &domain.Event{EventID: id, EventType: domain.EventTypeArticleCreated, Source: "test", CreatedAt: time.Now()}
+}
+
+func TestRedisDriver_PublishRetriesFailover(t *testing.T) {
+	t.Run("retries until the new master accepts the write", func(t *testing.T) {
+		d := setupRetryingDriver(t, 2, 3)
+
+		id, err := d.Publish(context.Background(), domain.StreamKeyArticles, testEvent("evt-1"))
+		require.NoError(t, err)
+		assert.NotEmpty(t, id)
+	})
+
+	t.Run("gives up after the configured retries", func(t *testing.T) {
+		d := setupRetryingDriver(t, 3, 2)
+
+		_, err := d.Publish(context.Background(), domain.StreamKeyArticles, testEvent("evt-1"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errReadOnly)
+	})
+
+	t.Run("batch resends only the rejected events", func(t *testing.T) {
+		d := setupRetryingDriver(t, 1, 3)
+		ctx := context.Background()
+
+		ids, err := d.PublishBatch(ctx, domain.StreamKeyArticles, []*domain.Event{testEvent("evt-1"), testEvent("evt-2"), testEvent("evt-3")})
+		require.NoError(t, err)
+		for _, id := range ids {
+			assert.NotEmpty(t, id)
+		}
+
+		head, err := d.StreamHead(ctx, domain.StreamKeyArticles)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), head.Length, "no event is appended twice")
+	})
+
+	t.Run("batch without retries reports the rejected event", func(t *testing.T) {
+		d := setupRetryingDriver(t, 1, 0)
+
+		ids, err := d.PublishBatch(context.Background(), domain.StreamKeyArticles, []*domain.Event{testEvent("evt-1"), testEvent("evt-2")})
+		var partial *domain.PartialPublishError
+		require.ErrorAs(t, err, &partial)
+		require.Len(t, partial.Failures, 1)
+		assert.Equal(t, 0, partial.Failures[0].Index)
+		assert.Empty(t, ids[0])
+		assert.NotEmpty(t, ids[1])
+	})
+}
+
+func TestRedisDriver_Topology_Standalone(t *testing.T) {
+	driver, cleanup := setupTestDriver(t)
+	defer cleanup()
+
+	topo, err := driver.Topology(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, domain.RedisModeStandalone, topo.Mode)
+	require.Len(t, topo.Nodes, 1)
+	assert.Equal(t, domain.RedisRoleMaster, topo.Nodes[0].Role)
+	assert.True(t, topo.Nodes[0].Healthy)
+	assert.Equal(t, []string{topo.Nodes[0].Addr}, topo.Masters)
+	assert.Zero(t, topo.Failovers)
+}
+
+func TestRedisDriver_ClusterMode(t *testing.T) {
+	mr := NewMiniredis(t)
+	defer mr.Close()
+	d, err := NewRedisDriverWithURLAndOptions("redis://"+mr.Addr(), &RedisDriverOptions{Mode: domain.RedisModeCluster})
+	require.NoError(t, err)
+	defer d.Close()
+	ctx := context.Background()
+
+	articleID, err := d.Publish(ctx, domain.StreamKeyArticles, testEvent("evt-1"))
+	require.NoError(t, err)
+	tagID, err := d.Publish(ctx, domain.StreamKeyTags, testEvent("evt-2"))
+	require.NoError(t, err)
+
+	msgs, err := d.ReadStreams(ctx, map[domain.StreamKey]string{
+		domain.StreamKeyArticles: "0-0",
+		domain.StreamKeyTags:     "0-0",
+	}, 10, time.Second)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.ElementsMatch(t, []string{articleID, tagID}, []string{msgs[0].ID, msgs[1].ID})
+
+	start := time.Now()
+	msgs, err = d.ReadStreams(ctx, map[domain.StreamKey]string{
+		domain.StreamKeyArticles: articleID,
+		domain.StreamKeyTags:     tagID,
+	}, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+	assert.Less(t, time.Since(start), 10*clusterReadPoll, "cluster reads poll instead of blocking")
+
+	topo, err := d.Topology(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RedisModeCluster, topo.Mode)
+	require.Len(t, topo.Masters, 1)
+	healthy, total := topo.HealthyNodes(domain.RedisRoleMaster)
+	assert.Equal(t, 1, healthy)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, 16384, topo.Nodes[0].Slots)
+}
+
+func TestRedisDriver_SentinelURLRequiresMasterName(t *testing.T) {
+	_, err := NewRedisDriverWithURLAndOptions("redis://sentinel-1:26379", &RedisDriverOptions{Mode: domain.RedisModeSentinel})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "master_name")
+
+	d, err := NewRedisDriverWithURLAndOptions(
+		"redis://sentinel-1:26379?master_name=alt&addr=sentinel-2:26379",
+		&RedisDriverOptions{Mode: domain.RedisModeSentinel},
+	)
+	require.NoError(t, err)
+	defer d.Close()
+	assert.Equal(t, domain.RedisModeSentinel, d.Mode())
+	require.Len(t, d.sentinels, 2)
+	assert.Equal(t, "sentinel-2:26379", d.sentinels[1].addr)
+}
+
+func TestRedisDriver_ObserveMastersCountsFailovers(t *testing.T) {
+	driver, cleanup := setupTestDriver(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	assert.Zero(t, driver.observeMasters(ctx, map[string]string{"0": "a:6379", "8192": "b:6379"}))
+	assert.Zero(t, driver.observeMasters(ctx, map[string]string{"0": "a:6379", "8192": "b:6379"}))
+	assert.Equal(t, int64(1), driver.observeMasters(ctx, map[string]string{"0": "a:6379", "8192": "c:6379"}))
+	// A shard seen for the first time is not a failover.
+	assert.Equal(t, int64(1), driver.observeMasters(ctx, map[string]string{"0": "a:6379", "8192": "c:6379", "12000": "d:6379"}))
+}
@@ -15,11 +15,14 @@ import (
 	"connectrpc.com/connect"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"mq-hub/bridge"
 	"mq-hub/config"
 	"mq-hub/connect/v1/mqhub"
+	"mq-hub/domain"
 	"mq-hub/driver"
 	"mq-hub/gateway"
 	mqhubv1connect "mq-hub/gen/proto/services/mqhub/v1/mqhubv1connect"
+	"mq-hub/middleware"
 	"mq-hub/usecase"
 	"mq-hub/utils/logger"
 )
@@ -51,10 +54,14 @@ func run() error {
 		"reason", "no mTLS listener configured; PeerIdentityMiddleware is not applied to any handler",
 	)
 
-	// Initialize Redis driver with connection pool
+	// Initialize Redis driver with connection pool. Publishes rejected
+	// while Sentinel or the cluster fails over are retried with backoff.
 	redisDriver, err := driver.NewRedisDriverWithURLAndOptions(cfg.RedisURL, &driver.RedisDriverOptions{
-		PoolSize:     cfg.RedisPoolSize,
-		StreamMaxLen: cfg.StreamMaxLen,
+		PoolSize:            cfg.RedisPoolSize,
+		StreamMaxLen:        cfg.StreamMaxLen,
+		Mode:                cfg.RedisMode,
+		PublishRetries:      cfg.RedisPublishRetries,
+		PublishRetryBackoff: cfg.RedisPublishRetryBackoff,
 	})
 	if err != nil {
 		return fmt.Errorf("connect to Redis: %w", err)
@@ -67,13 +74,41 @@ func run() error {
 	if err := redisDriver.Ping(ctx); err != nil {
 		return fmt.Errorf("ping Redis: %w", err)
 	}
+	slog.InfoContext(ctx, "redis_connected", "mode", string(redisDriver.Mode()))
+
+	// Background retention trimmer. XADD MAXLEN only bounds streams written
+	// through mq-hub; the trimmer also enforces age limits and covers entries
+	// added by other producers.
+	retentionUsecase := usecase.NewRetentionUsecase(redisDriver, cfg.StreamRetention)
+	trimCtx, stopTrimmer := context.WithCancel(ctx)
+	defer stopTrimmer()
+	if retentionUsecase.Enabled() {
+		for _, stream := range retentionUsecase.Policies().Streams() {
+			slog.InfoContext(ctx, "stream_retention_enabled",
+				"stream", stream.String(),
+				"policy", retentionUsecase.Policies()[stream].String(),
+				"interval", cfg.StreamTrimInterval.String(),
+			)
+		}
+		go retentionUsecase.Run(trimCtx, cfg.StreamTrimInterval)
+	} else {
+		slog.WarnContext(ctx, "stream_retention_disabled",
+			"reason", "no STREAM_MAX_LEN, STREAM_MAX_AGE, or STREAM_RETENTION bound configured; streams grow without limit",
+		)
+	}
 
 	// Initialize gateway
 	streamGateway := gateway.NewStreamGateway(redisDriver)
 
-	// Initialize usecases with batch size limit
+	// Initialize usecases with batch limits and idempotency-key
+	// deduplication (keys live next to the streams in Redis)
 	publishUsecase := usecase.NewPublishUsecaseWithOptions(streamGateway, &usecase.PublishUsecaseOptions{
-		MaxBatchSize: cfg.MaxBatchSize,
+		MaxBatchSize:         cfg.MaxBatchSize,
+		MaxEventPayloadBytes: cfg.MaxEventPayloadBytes,
+		MaxBatchPayloadBytes: cfg.MaxBatchPayloadBytes,
+		Idempotency:          redisDriver,
+		IdempotencyTTL:       cfg.IdempotencyTTL,
+		Topology:             redisDriver,
 	})
 	generateTagsUsecase := usecase.NewGenerateTagsUsecase(streamGateway)
 
@@ -98,12 +133,17 @@ func run() error {
 		// concatenation — a quote in the error text would otherwise break
 		// the response body and leak internal error detail to the client.
 		body := struct {
-			Healthy       bool   `json:"healthy"`
-			RedisStatus   string `json:"redis_status"`
-			UptimeSeconds int64  `json:"uptime_seconds"`
+			Healthy       bool           `json:"healthy"`
+			RedisStatus   string         `json:"redis_status"`
+			UptimeSeconds int64          `json:"uptime_seconds"`
+			RedisTopology *redisTopology `json:"redis_topology,omitempty"`
 		}{
 			Healthy:       health.Healthy,
 			UptimeSeconds: health.UptimeSeconds,
+			RedisTopology: newRedisTopology(health.Topology),
+		}
+		if health.TopologyError != "" {
+			slog.WarnContext(r.Context(), "redis topology check failed", "error", health.TopologyError)
 		}
 		if health.Healthy {
 			body.RedisStatus = "connected"
@@ -126,6 +166,29 @@ func run() error {
 	// Prometheus metrics endpoint
 	mux.Handle("/metrics", promhttp.Handler())
 
+	// WebSocket bridge for browser clients. It authenticates with the
+	// backend JWT, so without a secret it is not served at all.
+	bridgeCtx, stopBridge := context.WithCancel(ctx)
+	defer stopBridge()
+	if cfg.BackendTokenSecret != "" {
+		validator, err := middleware.NewBackendTokenValidator(
+			[]byte(cfg.BackendTokenSecret), cfg.BackendTokenIssuer, cfg.BackendTokenAudience)
+		if err != nil {
+			return fmt.Errorf("create backend token validator: %w", err)
+		}
+		streamBridge := usecase.NewStreamBridge(redisDriver, usecase.StreamBridgeOptions{
+			Streams:    cfg.BridgeStreams,
+			SendBuffer: cfg.BridgeSendBuffer,
+		})
+		go streamBridge.Run(bridgeCtx)
+		mux.Handle("/v1/ws/streams", bridge.NewHandler(streamBridge, validator, cfg.BridgeHeartbeatInterval))
+		slog.InfoContext(ctx, "websocket_bridge_enabled", "path", "/v1/ws/streams", "streams", cfg.BridgeStreams)
+	} else {
+		slog.WarnContext(ctx, "websocket_bridge_disabled",
+			"reason", "BACKEND_TOKEN_SECRET not configured",
+		)
+	}
+
 	// Start server with graceful shutdown
 	addr := fmt.Sprintf(":%d", cfg.ConnectPort)
 	srv := &http.Server{
@@ -164,6 +227,8 @@ func run() error {
 	defer cancel()
 
 	slog.InfoContext(ctx, "shutting down server gracefully")
+	stopTrimmer()
+	stopBridge()
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		return fmt.Errorf("server shutdown failed: %w", err)
 	}
@@ -172,6 +237,39 @@ func run() error {
 	return nil
 }
 
+// redisTopology is the /health view of domain.RedisTopology.
+type redisTopology struct {
+	Mode      string      `json:"mode"`
+	Masters   []string    `json:"masters"`
+	State     string      `json:"cluster_state,omitempty"`
+	Failovers int64       `json:"failovers"`
+	Nodes     []redisNode `json:"nodes"`
+}
+
+type redisNode struct {
+	Addr    string `json:"addr"`
+	Role    string `json:"role"`
+	Healthy bool   `json:"healthy"`
+	Slots   int    `json:"slots,omitempty"`
+}
+
+func newRedisTopology(t *domain.RedisTopology) *redisTopology {
+	if t == nil {
+		return nil
+	}
+	out := &redisTopology{
+		Mode:      string(t.Mode),
+		Masters:   t.Masters,
+		State:     t.State,
+		Failovers: t.Failovers,
+		Nodes:     make([]redisNode, 0, len(t.Nodes)),
+	}
+	for _, n := range t.Nodes {
+		out.Nodes = append(out.Nodes, redisNode{Addr: n.Addr, Role: n.Role, Healthy: n.Healthy, Slots: n.Slots})
+	}
+	return out
+}
+
 // loggingInterceptor creates a Connect interceptor for logging.
 func loggingInterceptor() connect.UnaryInterceptorFunc {
 	return func(next connect.UnaryFunc) connect.UnaryFunc {
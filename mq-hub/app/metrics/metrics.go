@@ -39,6 +39,28 @@ var (
 		[]string{"stream"},
 	)
 
+	// BatchMessagesTotal counts events submitted in batches by their
+	// per-event outcome.
+	BatchMessagesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "batch_messages_total",
+			Help:      "Total number of batch-published events by outcome",
+		},
+		[]string{"stream", "status"},
+	)
+
+	// PartialBatchTotal counts batches where some events were published and
+	// others were not.
+	PartialBatchTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "batch_partial_failures_total",
+			Help:      "Total number of batches with both published and rejected or failed events",
+		},
+		[]string{"stream"},
+	)
+
 	// ErrorsTotal counts errors by type.
 	ErrorsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -49,6 +71,83 @@ var (
 		[]string{"operation", "error_type"},
 	)
 
+	// DeduplicatedTotal counts publishes skipped by idempotency key.
+	DeduplicatedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "deduplicated_messages_total",
+			Help:      "Total number of publishes answered from an earlier publish with the same idempotency key",
+		},
+		[]string{"stream"},
+	)
+
+	// TrimmedTotal counts stream entries removed by the retention trimmer.
+	TrimmedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "stream_trimmed_messages_total",
+			Help:      "Total number of stream entries removed by retention trimming",
+		},
+		[]string{"stream", "policy"},
+	)
+
+	// BridgeConnections tracks open WebSocket bridge connections.
+	BridgeConnections = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "mqhub",
+			Name:      "bridge_connections",
+			Help:      "Number of open WebSocket bridge connections",
+		},
+	)
+
+	// BridgeDroppedTotal counts events not delivered to lagging bridge clients.
+	BridgeDroppedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "bridge_dropped_events_total",
+			Help:      "Total number of events dropped for WebSocket clients that fell behind",
+		},
+	)
+
+	// BridgeSnapshotsTotal counts snapshots sent to lagging bridge clients.
+	BridgeSnapshotsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "bridge_snapshots_total",
+			Help:      "Total number of snapshots sent to WebSocket clients in place of dropped events",
+		},
+	)
+
+	// PublishRetriesTotal counts events sent again because Redis was
+	// failing over.
+	PublishRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "publish_failover_retries_total",
+			Help:      "Total number of events sent again after a Redis failover error",
+		},
+		[]string{"stream"},
+	)
+
+	// RedisFailoversTotal counts master changes seen in the Redis topology.
+	RedisFailoversTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "mqhub",
+			Name:      "redis_failovers_total",
+			Help:      "Total number of Redis master changes observed",
+		},
+	)
+
+	// RedisNodes tracks the Redis nodes of the last topology check.
+	RedisNodes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "mqhub",
+			Name:      "redis_nodes",
+			Help:      "Number of Redis nodes by role and health at the last topology check",
+		},
+		[]string{"role", "state"},
+	)
+
 	// RedisConnectionStatus tracks Redis connection status.
 	RedisConnectionStatus = promauto.NewGauge(
 		prometheus.GaugeOpts{
@@ -72,11 +171,59 @@ func RecordBatchPublish(stream, status string, batchSize int, duration float64)
 	BatchSize.WithLabelValues(stream).Observe(float64(batchSize))
 }
 
+// RecordBatchOutcomes records how many events of a batch ended in each
+// per-event status, and whether the batch was a partial failure.
+func RecordBatchOutcomes(stream string, counts map[string]int, partial bool) {
+	for status, n := range counts {
+		BatchMessagesTotal.WithLabelValues(stream, status).Add(float64(n))
+	}
+	if partial {
+		PartialBatchTotal.WithLabelValues(stream).Inc()
+	}
+}
+
+// RecordDeduplicated records n publishes skipped by idempotency key.
+func RecordDeduplicated(stream string, n int) {
+	DeduplicatedTotal.WithLabelValues(stream).Add(float64(n))
+}
+
+// RecordTrim records entries removed from a stream by a retention policy
+// ("maxlen" or "maxage").
+func RecordTrim(stream, policy string, removed int64) {
+	TrimmedTotal.WithLabelValues(stream, policy).Add(float64(removed))
+}
+
 // RecordError records an error.
 func RecordError(operation, errorType string) {
 	ErrorsTotal.WithLabelValues(operation, errorType).Inc()
 }
 
+// RecordBridgeDrop records an event dropped for a lagging bridge client.
+func RecordBridgeDrop() {
+	BridgeDroppedTotal.Inc()
+}
+
+// RecordBridgeSnapshot records a snapshot sent to a lagging bridge client.
+func RecordBridgeSnapshot() {
+	BridgeSnapshotsTotal.Inc()
+}
+
+// RecordPublishRetry records n events sent again after a failover error.
+func RecordPublishRetry(stream string, n int) {
+	PublishRetriesTotal.WithLabelValues(stream).Add(float64(n))
+}
+
+// RecordRedisFailover records an observed Redis master change.
+func RecordRedisFailover() {
+	RedisFailoversTotal.Inc()
+}
+
+// SetRedisNodes sets the healthy and unhealthy node counts of role.
+func SetRedisNodes(role string, healthy, total int) {
+	RedisNodes.WithLabelValues(role, "healthy").Set(float64(healthy))
+	RedisNodes.WithLabelValues(role, "unhealthy").Set(float64(total - healthy))
+}
+
 // SetRedisConnected sets Redis connection status to connected.
 func SetRedisConnected() {
 	RedisConnectionStatus.Set(1)
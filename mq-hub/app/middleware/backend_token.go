@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// BackendTokenHeader carries the backend JWT issued by auth-hub.
+const BackendTokenHeader = "X-Alt-Backend-Token"
+
+var (
+	errMissingToken    = errors.New("missing backend token")
+	errInvalidToken    = errors.New("invalid backend token")
+	errInvalidIssuer   = errors.New("invalid issuer")
+	errInvalidAudience = errors.New("invalid audience")
+	errMissingSubject  = errors.New("missing subject")
+)
+
+// BackendTokenValidator verifies the HMAC-signed backend JWTs that auth-hub
+// issues and alt-backend accepts, so browser clients can reuse the token
+// they already hold.
+type BackendTokenValidator struct {
+	secret   []byte
+	issuer   string
+	audience string
+}
+
+// NewBackendTokenValidator creates a validator. The secret must not be empty.
+func NewBackendTokenValidator(secret []byte, issuer, audience string) (*BackendTokenValidator, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("backend token secret is empty")
+	}
+	return &BackendTokenValidator{secret: secret, issuer: issuer, audience: audience}, nil
+}
+
+// Validate checks the token's signature, expiry, issuer and audience and
+// returns the user ID from its subject.
+func (v *BackendTokenValidator) Validate(tokenStr string) (string, error) {
+	if tokenStr == "" {
+		return "", errMissingToken
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	parsed, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return v.secret, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errInvalidToken, err)
+	}
+	if !parsed.Valid {
+		return "", errInvalidToken
+	}
+	if claims.Issuer != v.issuer {
+		return "", errInvalidIssuer
+	}
+	if !slices.Contains(claims.Audience, v.audience) {
+		return "", errInvalidAudience
+	}
+	if claims.Subject == "" {
+		return "", errMissingSubject
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testBackendSecret = "test-backend-token-secret-at-least-32-chars"
+
+func signBackendToken(t *testing.T, secret string, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func validBackendClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   "user-1",
+		Issuer:    "auth-hub",
+		Audience:  jwt.ClaimStrings{"alt-backend"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func TestBackendTokenValidator_RejectsEmptySecret(t *testing.T) {
+	if _, err := NewBackendTokenValidator(nil, "auth-hub", "alt-backend"); err == nil {
+		t.Fatal("expected an error for an empty secret")
+	}
+}
+
+func TestBackendTokenValidator_Validate(t *testing.T) {
+	v, err := NewBackendTokenValidator([]byte(testBackendSecret), "auth-hub", "alt-backend")
+	if err != nil {
+		t.Fatalf("new validator: %v", err)
+	}
+
+	userID, err := v.Validate(signBackendToken(t, testBackendSecret, validBackendClaims()))
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if userID != "user-1" {
+		t.Fatalf("user id: got %q, want user-1", userID)
+	}
+
+	expired := validBackendClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	wrongIssuer := validBackendClaims()
+	wrongIssuer.Issuer = "someone-else"
+	wrongAudience := validBackendClaims()
+	wrongAudience.Audience = jwt.ClaimStrings{"other-service"}
+	noSubject := validBackendClaims()
+	noSubject.Subject = ""
+
+	for name, token := range map[string]string{
+		"missing":        "",
+		"garbage":        "not-a-jwt",
+		"wrong secret":   signBackendToken(t, "another-secret-that-is-also-32-chars-long", validBackendClaims()),
+		"expired":        signBackendToken(t, testBackendSecret, expired),
+		"wrong issuer":   signBackendToken(t, testBackendSecret, wrongIssuer),
+		"wrong audience": signBackendToken(t, testBackendSecret, wrongAudience),
+		"no subject":     signBackendToken(t, testBackendSecret, noSubject),
+	} {
+		if _, err := v.Validate(token); err == nil {
+			t.Errorf("%s: expected token to be rejected", name)
+		}
+	}
+}
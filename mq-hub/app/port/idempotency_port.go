@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mq-hub/domain"
+)
+
+// IdempotencyPort records which idempotency keys have been published to a
+// stream, so retried publishes can be answered with the original message ID
+// instead of being appended again. Keys are scoped per stream.
+type IdempotencyPort interface {
+	// ClaimIdempotencyKeys tries to take each key for a new publish. A key
+	// nobody holds is claimed and marked in flight for pendingTTL; for the
+	// others the returned claim carries the recorded message ID, or "" while
+	// the earlier publish is still in flight. The result has one entry per
+	// key, in order.
+	ClaimIdempotencyKeys(ctx context.Context, stream domain.StreamKey, keys []string, pendingTTL time.Duration) ([]domain.IdempotencyClaim, error)
+
+	// CommitIdempotencyKeys records the message ID published for each
+	// claimed key and keeps it for ttl.
+	CommitIdempotencyKeys(ctx context.Context, stream domain.StreamKey, messageIDs map[string]string, ttl time.Duration) error
+
+	// ReleaseIdempotencyKeys drops claims whose publish failed so a retry can
+	// claim them again.
+	ReleaseIdempotencyKeys(ctx context.Context, stream domain.StreamKey, keys []string) error
+}
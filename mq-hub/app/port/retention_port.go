@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+
+	"mq-hub/domain"
+)
+
+// StreamTrimPort defines the Redis Streams operations used to enforce
+// retention policies.
+type StreamTrimPort interface {
+	// TrimMaxLen approximately trims a stream to maxLen entries and returns
+	// the number of entries removed.
+	TrimMaxLen(ctx context.Context, stream domain.StreamKey, maxLen int64) (int64, error)
+
+	// TrimMinID approximately removes entries with IDs lower than minID and
+	// returns the number of entries removed.
+	TrimMinID(ctx context.Context, stream domain.StreamKey, minID string) (int64, error)
+
+	// OldestUnackedID returns the lowest entry ID any consumer group still
+	// needs: its oldest pending entry, or its last-delivered ID when nothing
+	// is pending. It returns "" if the stream has no consumer groups.
+	OldestUnackedID(ctx context.Context, stream domain.StreamKey) (string, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"mq-hub/domain"
+)
+
+// StreamReadPort defines the Redis Streams reads used by the WebSocket
+// bridge. Reads are plain XREADs, not consumer-group reads, so the bridge
+// never acknowledges or steals entries from the backend consumers.
+type StreamReadPort interface {
+	// ReadStreams returns entries after the given per-stream IDs, blocking
+	// up to block when there are none. It returns no messages and no error
+	// when block expires.
+	ReadStreams(ctx context.Context, after map[domain.StreamKey]string, count int64, block time.Duration) ([]domain.StreamMessage, error)
+
+	// StreamHead returns the last entry ID and length of a stream. A
+	// missing stream has an empty head.
+	StreamHead(ctx context.Context, stream domain.StreamKey) (domain.StreamHead, error)
+}
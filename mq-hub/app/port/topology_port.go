@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"mq-hub/domain"
+)
+
+// TopologyPort reports the shape and health of the Redis deployment.
+type TopologyPort interface {
+	// Topology returns the current masters and known nodes, and how many
+	// master changes have been observed.
+	Topology(ctx context.Context) (*domain.RedisTopology, error)
+}
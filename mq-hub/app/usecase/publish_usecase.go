@@ -4,6 +4,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"mq-hub/domain"
@@ -12,20 +13,35 @@ import (
 )
 
 // ErrBatchTooLarge is returned when batch size exceeds the limit.
-var ErrBatchTooLarge = errors.New("batch size exceeds maximum allowed")
+var ErrBatchTooLarge = domain.ErrBatchTooLarge
+
+// ErrIdempotencyKeyInFlight is returned when a publish with the same
+// idempotency key is still running.
+var ErrIdempotencyKeyInFlight = domain.ErrIdempotencyKeyInFlight
+
+// idempotencyPendingTTL bounds how long a claimed idempotency key blocks
+// retries if mq-hub dies before committing or releasing it.
+const idempotencyPendingTTL = time.Minute
 
 // PublishResult contains the result of publishing an event.
 type PublishResult struct {
 	MessageID string
 	Success   bool
+	// Duplicate is set when the event's idempotency key matched an earlier
+	// publish; MessageID is then that publish's ID and nothing was appended.
+	Duplicate bool
 }
 
 // PublishBatchResult contains the results of batch publishing.
 type PublishBatchResult struct {
+	// MessageIDs has one entry per input event; entries for events that
+	// were not published are "".
 	MessageIDs   []string
 	SuccessCount int32
 	FailureCount int32
 	Errors       []PublishError
+	// Results has one entry per input event, in input order.
+	Results []PublishEventResult
 }
 
 // PublishError represents an error for a specific event in a batch.
@@ -34,23 +50,51 @@ type PublishError struct {
 	ErrorMessage string
 }
 
+// PublishEventResult is the outcome of one event in a batch.
+type PublishEventResult struct {
+	Index        int
+	Status       domain.PublishStatus
+	MessageID    string
+	ErrorMessage string
+}
+
 // HealthStatus contains the health status of the service.
 type HealthStatus struct {
 	Healthy       bool
 	RedisStatus   string
 	UptimeSeconds int64
+	// Topology is the Redis deployment, when a topology port is configured
+	// and could be read.
+	Topology *domain.RedisTopology
+	// TopologyError is why Topology could not be read.
+	TopologyError string
 }
 
 // PublishUsecaseOptions contains configuration for PublishUsecase.
 type PublishUsecaseOptions struct {
 	MaxBatchSize int
+	// MaxEventPayloadBytes and MaxBatchPayloadBytes bound batch payload
+	// sizes; 0 disables the bound.
+	MaxEventPayloadBytes int
+	MaxBatchPayloadBytes int
+	// Idempotency stores the idempotency keys of recent publishes. Nil
+	// disables deduplication; keys on events are then ignored.
+	Idempotency port.IdempotencyPort
+	// IdempotencyTTL is how long a published key deduplicates retries. 0
+	// disables deduplication.
+	IdempotencyTTL time.Duration
+	// Topology adds the Redis topology to health checks. Nil leaves it out.
+	Topology port.TopologyPort
 }
 
 // PublishUsecase handles event publishing operations.
 type PublishUsecase struct {
-	streamPort   port.StreamPort
-	startTime    time.Time
-	maxBatchSize int
+	streamPort     port.StreamPort
+	startTime      time.Time
+	batchLimits    domain.BatchLimits
+	idempotency    port.IdempotencyPort
+	idempotencyTTL time.Duration
+	topology       port.TopologyPort
 }
 
 // NewPublishUsecase creates a new PublishUsecase with default options.
@@ -60,20 +104,62 @@ func NewPublishUsecase(streamPort port.StreamPort) *PublishUsecase {
 
 // NewPublishUsecaseWithOptions creates a new PublishUsecase with options.
 func NewPublishUsecaseWithOptions(streamPort port.StreamPort, opts *PublishUsecaseOptions) *PublishUsecase {
-	maxBatchSize := 1000 // default
-	if opts != nil && opts.MaxBatchSize > 0 {
-		maxBatchSize = opts.MaxBatchSize
+	limits := domain.BatchLimits{MaxEvents: 1000} // default
+	if opts != nil {
+		if opts.MaxBatchSize > 0 {
+			limits.MaxEvents = opts.MaxBatchSize
+		}
+		limits.MaxEventPayloadBytes = opts.MaxEventPayloadBytes
+		limits.MaxBatchPayloadBytes = opts.MaxBatchPayloadBytes
 	}
 
-	return &PublishUsecase{
-		streamPort:   streamPort,
-		startTime:    time.Now(),
-		maxBatchSize: maxBatchSize,
+	u := &PublishUsecase{
+		streamPort:  streamPort,
+		startTime:   time.Now(),
+		batchLimits: limits,
 	}
+	if opts != nil && opts.Idempotency != nil && opts.IdempotencyTTL > 0 {
+		u.idempotency = opts.Idempotency
+		u.idempotencyTTL = opts.IdempotencyTTL
+	}
+	if opts != nil {
+		u.topology = opts.Topology
+	}
+	return u
+}
+
+// idempotencyKeyOf returns the key to deduplicate event on, or "" when the
+// event has none or deduplication is disabled.
+func (u *PublishUsecase) idempotencyKeyOf(event *domain.Event) string {
+	if u.idempotency == nil || event == nil {
+		return ""
+	}
+	return event.IdempotencyKey
 }
 
 // Publish publishes a single event to a stream.
+//
+// An event carrying an idempotency key is published at most once per key
+// and stream within the idempotency window: a retry of a completed publish
+// returns the original message ID without appending, and a retry racing the
+// original fails with ErrIdempotencyKeyInFlight.
 func (u *PublishUsecase) Publish(ctx context.Context, stream domain.StreamKey, event *domain.Event) (*PublishResult, error) {
+	key := u.idempotencyKeyOf(event)
+	if key != "" {
+		claims, err := u.idempotency.ClaimIdempotencyKeys(ctx, stream, []string{key}, idempotencyPendingTTL)
+		if err != nil {
+			metrics.RecordError("publish", "idempotency_error")
+			return &PublishResult{Success: false}, err
+		}
+		switch claim := claims[0]; {
+		case claim.Duplicate():
+			metrics.RecordDeduplicated(stream.String(), 1)
+			return &PublishResult{MessageID: claim.MessageID, Success: true, Duplicate: true}, nil
+		case !claim.Claimed:
+			return &PublishResult{Success: false}, ErrIdempotencyKeyInFlight
+		}
+	}
+
 	start := time.Now()
 
 	messageID, err := u.streamPort.Publish(ctx, stream, event)
@@ -82,6 +168,9 @@ func (u *PublishUsecase) Publish(ctx context.Context, stream domain.StreamKey, e
 	if err != nil {
 		metrics.RecordPublish(stream.String(), "error", duration)
 		metrics.RecordError("publish", "redis_error")
+		if key != "" {
+			u.releaseKeys(ctx, stream, []string{key})
+		}
 		return &PublishResult{
 			MessageID: "",
 			Success:   false,
@@ -89,68 +178,291 @@ func (u *PublishUsecase) Publish(ctx context.Context, stream domain.StreamKey, e
 	}
 
 	metrics.RecordPublish(stream.String(), "success", duration)
+	if key != "" {
+		u.commitKeys(ctx, stream, map[string]string{key: messageID})
+	}
 	return &PublishResult{
 		MessageID: messageID,
 		Success:   true,
 	}, nil
 }
 
-// PublishBatch publishes multiple events to a stream.
+// PublishBatch publishes multiple events to a stream. Each event succeeds
+// or fails on its own: events rejected by the batch limits are reported as
+// validation or quota failures and the rest are still published, so a
+// single bad event no longer costs the whole batch.
+//
+// An error is returned only when nothing could be attempted per event: the
+// batch exceeds MaxBatchSize (ErrBatchTooLarge) or Redis failed the whole
+// pipeline. Per-event failures are reported in Results with a nil error.
+//
+// Idempotency keys are honoured per event as in Publish, so retrying a
+// partially failed batch unchanged appends only the events that failed: the
+// others come back OK with their original message IDs.
 func (u *PublishUsecase) PublishBatch(ctx context.Context, stream domain.StreamKey, events []*domain.Event) (*PublishBatchResult, error) {
 	batchSize := len(events)
 
-	// Check batch size limit
-	if batchSize > u.maxBatchSize {
+	rejections, err := u.batchLimits.Check(events)
+	if err != nil {
 		metrics.RecordError("publish_batch", "batch_too_large")
+		results := make([]PublishEventResult, batchSize)
+		for i := range results {
+			results[i] = PublishEventResult{Index: i, Status: domain.PublishStatusQuotaExceeded, ErrorMessage: err.Error()}
+		}
+		metrics.RecordBatchOutcomes(stream.String(), map[string]int{string(domain.PublishStatusQuotaExceeded): batchSize}, false)
 		return &PublishBatchResult{
 			MessageIDs:   nil,
 			SuccessCount: 0,
 			FailureCount: int32(batchSize),
-		}, ErrBatchTooLarge
+			Results:      results,
+		}, err
 	}
 
-	start := time.Now()
-
-	messageIDs, err := u.streamPort.PublishBatch(ctx, stream, events)
-	duration := time.Since(start).Seconds()
+	results := make([]PublishEventResult, batchSize)
+	for i := range results {
+		results[i] = PublishEventResult{Index: i, Status: domain.PublishStatusOK}
+	}
+	for _, r := range rejections {
+		results[r.Index].Status = r.Status
+		results[r.Index].ErrorMessage = r.Err.Error()
+	}
 
-	var partialErr *domain.PartialPublishError
-	if errors.As(err, &partialErr) {
-		// Some events published successfully; report exactly which ones
-		// failed instead of forcing the caller to retry (and duplicate)
-		// the entire batch.
-		metrics.RecordBatchPublish(stream.String(), "partial_error", batchSize, duration)
-		metrics.RecordError("publish_batch", "redis_error")
-		publishErrors := make([]PublishError, 0, len(partialErr.Failures))
-		for _, f := range partialErr.Failures {
-			publishErrors = append(publishErrors, PublishError{Index: f.Index, ErrorMessage: f.Err.Error()})
+	claimed, aliasOf, err := u.claimBatchKeys(ctx, stream, events, results)
+	if err != nil {
+		metrics.RecordError("publish_batch", "idempotency_error")
+		for i := range results {
+			if results[i].Status == domain.PublishStatusOK {
+				results[i].Status = domain.PublishStatusFailed
+				results[i].MessageID = ""
+				results[i].ErrorMessage = err.Error()
+			}
 		}
+		metrics.RecordBatchOutcomes(stream.String(), countStatuses(results), false)
 		return &PublishBatchResult{
-			MessageIDs:   messageIDs,
-			SuccessCount: int32(batchSize - len(partialErr.Failures)),
-			FailureCount: int32(len(partialErr.Failures)),
-			Errors:       publishErrors,
-		}, partialErr
+			MessageIDs:   nil,
+			SuccessCount: 0,
+			FailureCount: int32(batchSize),
+			Errors:       publishErrors(results),
+			Results:      results,
+		}, err
 	}
+	deduplicated := len(aliasOf)
 
-	if err != nil {
+	accepted := make([]*domain.Event, 0, batchSize-len(rejections))
+	acceptedIndex := make([]int, 0, batchSize-len(rejections))
+	for i, event := range events {
+		if _, alias := aliasOf[i]; alias || results[i].Status != domain.PublishStatusOK {
+			continue
+		}
+		if results[i].MessageID != "" {
+			// Answered from an earlier publish with the same key.
+			deduplicated++
+			continue
+		}
+		accepted = append(accepted, event)
+		acceptedIndex = append(acceptedIndex, i)
+	}
+
+	start := time.Now()
+	var messageIDs []string
+	if len(accepted) > 0 {
+		messageIDs, err = u.streamPort.PublishBatch(ctx, stream, accepted)
+	}
+	duration := time.Since(start).Seconds()
+
+	var partialErr *domain.PartialPublishError
+	if err != nil && !errors.As(err, &partialErr) {
+		// The pipeline failed as a whole; nothing landed, so the caller
+		// should retry the batch.
 		metrics.RecordBatchPublish(stream.String(), "error", batchSize, duration)
 		metrics.RecordError("publish_batch", "redis_error")
+		for _, i := range acceptedIndex {
+			results[i].Status = domain.PublishStatusFailed
+			results[i].ErrorMessage = err.Error()
+		}
+		u.settleBatchKeys(ctx, stream, results, claimed, aliasOf)
+		metrics.RecordBatchOutcomes(stream.String(), countStatuses(results), false)
 		return &PublishBatchResult{
 			MessageIDs:   nil,
 			SuccessCount: 0,
 			FailureCount: int32(batchSize),
+			Errors:       publishErrors(results),
+			Results:      results,
 		}, err
 	}
+	if partialErr != nil {
+		// Some events published successfully; report exactly which ones
+		// failed instead of forcing the caller to retry (and duplicate)
+		// the entire batch.
+		metrics.RecordError("publish_batch", "redis_error")
+		for _, f := range partialErr.Failures {
+			i := acceptedIndex[f.Index]
+			results[i].Status = domain.PublishStatusFailed
+			results[i].ErrorMessage = f.Err.Error()
+		}
+	}
+
+	for j, id := range messageIDs {
+		i := acceptedIndex[j]
+		if results[i].Status == domain.PublishStatusOK {
+			results[i].MessageID = id
+		}
+	}
+	u.settleBatchKeys(ctx, stream, results, claimed, aliasOf)
+	if deduplicated > 0 {
+		metrics.RecordDeduplicated(stream.String(), deduplicated)
+	}
+
+	ids := make([]string, batchSize)
+	var successCount int32
+	for i, r := range results {
+		if r.Status == domain.PublishStatusOK {
+			ids[i] = r.MessageID
+			successCount++
+		}
+	}
+
+	partial := successCount > 0 && int(successCount) < batchSize
+	status := "success"
+	switch {
+	case partial:
+		status = "partial_error"
+	case successCount == 0 && batchSize > 0:
+		status = "error"
+	}
+	metrics.RecordBatchPublish(stream.String(), status, batchSize, duration)
+	metrics.RecordBatchOutcomes(stream.String(), countStatuses(results), partial)
 
-	metrics.RecordBatchPublish(stream.String(), "success", batchSize, duration)
 	return &PublishBatchResult{
-		MessageIDs:   messageIDs,
-		SuccessCount: int32(len(messageIDs)),
-		FailureCount: 0,
+		MessageIDs:   ids,
+		SuccessCount: successCount,
+		FailureCount: int32(batchSize) - successCount,
+		Errors:       publishErrors(results),
+		Results:      results,
 	}, nil
 }
 
+// claimBatchKeys claims the idempotency keys of the events still OK in
+// results. An event whose key belongs to a completed publish gets that
+// publish's message ID in results, and one whose key is in flight is failed;
+// neither is published. A key repeated within the batch is claimed for its
+// first event only and the later events are returned in aliasOf to share its
+// outcome. claimed maps each key taken by this call to the event publishing
+// it.
+func (u *PublishUsecase) claimBatchKeys(ctx context.Context, stream domain.StreamKey, events []*domain.Event, results []PublishEventResult) (claimed map[string]int, aliasOf map[int]int, err error) {
+	if u.idempotency == nil {
+		return nil, nil, nil
+	}
+
+	first := make(map[string]int)
+	aliasOf = make(map[int]int)
+	var keys []string
+	for i, event := range events {
+		key := u.idempotencyKeyOf(event)
+		if key == "" || results[i].Status != domain.PublishStatusOK {
+			continue
+		}
+		if j, ok := first[key]; ok {
+			aliasOf[i] = j
+			continue
+		}
+		first[key] = i
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, aliasOf, nil
+	}
+
+	claims, err := u.idempotency.ClaimIdempotencyKeys(ctx, stream, keys, idempotencyPendingTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	claimed = make(map[string]int)
+	for n, claim := range claims {
+		i := first[keys[n]]
+		switch {
+		case claim.Claimed:
+			claimed[keys[n]] = i
+		case claim.Duplicate():
+			results[i].MessageID = claim.MessageID
+		default:
+			results[i].Status = domain.PublishStatusFailed
+			results[i].ErrorMessage = ErrIdempotencyKeyInFlight.Error()
+		}
+	}
+	return claimed, aliasOf, nil
+}
+
+// settleBatchKeys copies each first event's outcome to the events sharing
+// its key, then commits the claimed keys that published and releases the
+// rest.
+func (u *PublishUsecase) settleBatchKeys(ctx context.Context, stream domain.StreamKey, results []PublishEventResult, claimed map[string]int, aliasOf map[int]int) {
+	for i, j := range aliasOf {
+		results[i].Status = results[j].Status
+		results[i].MessageID = results[j].MessageID
+		results[i].ErrorMessage = results[j].ErrorMessage
+	}
+
+	commit := make(map[string]string)
+	var release []string
+	for key, i := range claimed {
+		if results[i].Status == domain.PublishStatusOK {
+			commit[key] = results[i].MessageID
+		} else {
+			release = append(release, key)
+		}
+	}
+	u.commitKeys(ctx, stream, commit)
+	u.releaseKeys(ctx, stream, release)
+}
+
+// commitKeys records published idempotency keys. The events are already in
+// the stream, so a failure is only logged: the keys stay in flight until
+// idempotencyPendingTTL and then stop deduplicating.
+func (u *PublishUsecase) commitKeys(ctx context.Context, stream domain.StreamKey, messageIDs map[string]string) {
+	if len(messageIDs) == 0 {
+		return
+	}
+	if err := u.idempotency.CommitIdempotencyKeys(ctx, stream, messageIDs, u.idempotencyTTL); err != nil {
+		metrics.RecordError("publish", "idempotency_error")
+		slog.WarnContext(ctx, "failed to commit idempotency keys",
+			"stream", stream.String(), "keys", len(messageIDs), "error", err)
+	}
+}
+
+// releaseKeys frees the idempotency keys of failed publishes so retries can
+// claim them. A failure is only logged; the claims expire on their own.
+func (u *PublishUsecase) releaseKeys(ctx context.Context, stream domain.StreamKey, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	if err := u.idempotency.ReleaseIdempotencyKeys(ctx, stream, keys); err != nil {
+		metrics.RecordError("publish", "idempotency_error")
+		slog.WarnContext(ctx, "failed to release idempotency keys",
+			"stream", stream.String(), "keys", len(keys), "error", err)
+	}
+}
+
+// publishErrors lists the non-OK results in the legacy PublishError shape.
+func publishErrors(results []PublishEventResult) []PublishError {
+	var errs []PublishError
+	for _, r := range results {
+		if r.Status != domain.PublishStatusOK {
+			errs = append(errs, PublishError{Index: r.Index, ErrorMessage: r.ErrorMessage})
+		}
+	}
+	return errs
+}
+
+// countStatuses tallies results by status for metrics.
+func countStatuses(results []PublishEventResult) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[string(r.Status)]++
+	}
+	return counts
+}
+
 // CreateConsumerGroup creates a consumer group for a stream.
 func (u *PublishUsecase) CreateConsumerGroup(ctx context.Context, stream domain.StreamKey, group domain.ConsumerGroup, startID string) error {
 	return u.streamPort.CreateConsumerGroup(ctx, stream, group, startID)
@@ -179,5 +491,21 @@ func (u *PublishUsecase) HealthCheck(ctx context.Context) *HealthStatus {
 		metrics.SetRedisConnected()
 	}
 
+	// The topology is informational: a replica or Sentinel being down does
+	// not make mq-hub unhealthy while the master answers.
+	if u.topology != nil {
+		topo, err := u.topology.Topology(ctx)
+		if err != nil {
+			status.TopologyError = err.Error()
+			metrics.RecordError("topology", "redis_error")
+		} else {
+			status.Topology = topo
+			for _, role := range []string{domain.RedisRoleMaster, domain.RedisRoleReplica, domain.RedisRoleSentinel} {
+				healthy, total := topo.HealthyNodes(role)
+				metrics.SetRedisNodes(role, healthy, total)
+			}
+		}
+	}
+
 	return status
 }
@@ -198,6 +198,228 @@ func TestPublishUsecase_PublishBatch(t *testing.T) {
 		assert.Len(t, result.MessageIDs, 5)
 		mockPort.AssertExpectations(t)
 	})
+
+	t.Run("publishes valid events and reports rejected ones", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := NewPublishUsecaseWithOptions(mockPort, &PublishUsecaseOptions{
+			MaxEventPayloadBytes: 4,
+		})
+
+		ctx := context.Background()
+		valid := &domain.Event{EventID: "ok", EventType: domain.EventTypeArticleCreated, Source: "alt-backend", CreatedAt: time.Now(), Payload: []byte("ok")}
+		invalid := &domain.Event{EventID: "bad", Source: "alt-backend", CreatedAt: time.Now()}
+		tooBig := &domain.Event{EventID: "big", EventType: domain.EventTypeArticleCreated, Source: "alt-backend", CreatedAt: time.Now(), Payload: []byte("too big")}
+		events := []*domain.Event{invalid, valid, nil, tooBig}
+
+		mockPort.On("PublishBatch", ctx, domain.StreamKeyArticles, []*domain.Event{valid}).
+			Return([]string{"1-0"}, nil)
+
+		result, err := uc.PublishBatch(ctx, domain.StreamKeyArticles, events)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "1-0", "", ""}, result.MessageIDs)
+		assert.Equal(t, int32(1), result.SuccessCount)
+		assert.Equal(t, int32(3), result.FailureCount)
+		require.Len(t, result.Results, 4)
+		assert.Equal(t, domain.PublishStatusValidationError, result.Results[0].Status)
+		assert.Equal(t, domain.PublishStatusOK, result.Results[1].Status)
+		assert.Equal(t, "1-0", result.Results[1].MessageID)
+		assert.Equal(t, domain.PublishStatusValidationError, result.Results[2].Status)
+		assert.Equal(t, domain.PublishStatusQuotaExceeded, result.Results[3].Status)
+		assert.Len(t, result.Errors, 3)
+		mockPort.AssertExpectations(t)
+	})
+
+	t.Run("maps redis partial failures back to request indices", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := NewPublishUsecase(mockPort)
+
+		ctx := context.Background()
+		invalid := &domain.Event{EventID: "bad", Source: "alt-backend", CreatedAt: time.Now()}
+		first := &domain.Event{EventID: "a", EventType: domain.EventTypeArticleCreated, Source: "alt-backend", CreatedAt: time.Now()}
+		second := &domain.Event{EventID: "b", EventType: domain.EventTypeArticleCreated, Source: "alt-backend", CreatedAt: time.Now()}
+
+		mockPort.On("PublishBatch", ctx, domain.StreamKeyArticles, []*domain.Event{first, second}).
+			Return([]string{"1-0", ""}, &domain.PartialPublishError{
+				TotalEvents: 2,
+				Failures:    []domain.PublishFailure{{Index: 1, Err: errors.New("OOM")}},
+			})
+
+		result, err := uc.PublishBatch(ctx, domain.StreamKeyArticles, []*domain.Event{invalid, first, second})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", "1-0", ""}, result.MessageIDs)
+		assert.Equal(t, domain.PublishStatusOK, result.Results[1].Status)
+		assert.Equal(t, domain.PublishStatusFailed, result.Results[2].Status)
+		assert.Equal(t, "OOM", result.Results[2].ErrorMessage)
+		assert.Equal(t, int32(1), result.SuccessCount)
+		assert.Equal(t, int32(2), result.FailureCount)
+	})
+
+	t.Run("does not call redis when every event is rejected", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := NewPublishUsecase(mockPort)
+
+		result, err := uc.PublishBatch(context.Background(), domain.StreamKeyArticles, []*domain.Event{nil})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), result.FailureCount)
+		assert.Equal(t, domain.PublishStatusValidationError, result.Results[0].Status)
+		mockPort.AssertNotCalled(t, "PublishBatch")
+	})
+}
+
+// fakeIdempotencyStore is an in-memory port.IdempotencyPort. A key mapped
+// to "" is in flight.
+type fakeIdempotencyStore struct {
+	keys map[string]string
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{keys: make(map[string]string)}
+}
+
+func (f *fakeIdempotencyStore) ClaimIdempotencyKeys(_ context.Context, stream domain.StreamKey, keys []string, _ time.Duration) ([]domain.IdempotencyClaim, error) {
+	claims := make([]domain.IdempotencyClaim, len(keys))
+	for i, key := range keys {
+		id, held := f.keys[stream.String()+"|"+key]
+		if !held {
+			f.keys[stream.String()+"|"+key] = ""
+			claims[i].Claimed = true
+			continue
+		}
+		claims[i].MessageID = id
+	}
+	return claims, nil
+}
+
+func (f *fakeIdempotencyStore) CommitIdempotencyKeys(_ context.Context, stream domain.StreamKey, messageIDs map[string]string, _ time.Duration) error {
+	for key, id := range messageIDs {
+		f.keys[stream.String()+"|"+key] = id
+	}
+	return nil
+}
+
+func (f *fakeIdempotencyStore) ReleaseIdempotencyKeys(_ context.Context, stream domain.StreamKey, keys []string) error {
+	for _, key := range keys {
+		delete(f.keys, stream.String()+"|"+key)
+	}
+	return nil
+}
+
+func TestPublishUsecase_Idempotency(t *testing.T) {
+	newEvent := func(id, key string) *domain.Event {
+		return &domain.Event{EventID: id, EventType: domain.EventTypeArticleCreated, Source: "alt-backend", CreatedAt: time.Now(), IdempotencyKey: key}
+	}
+	newUsecase := func(mockPort *MockStreamPort, store *fakeIdempotencyStore) *PublishUsecase {
+		return NewPublishUsecaseWithOptions(mockPort, &PublishUsecaseOptions{Idempotency: store, IdempotencyTTL: time.Hour})
+	}
+
+	t.Run("retried publish returns the original message ID", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := newUsecase(mockPort, newFakeIdempotencyStore())
+		ctx := context.Background()
+		event := newEvent("evt-1", "article-42")
+
+		mockPort.On("Publish", ctx, domain.StreamKeyArticles, event).Return("1-0", nil).Once()
+
+		first, err := uc.Publish(ctx, domain.StreamKeyArticles, event)
+		require.NoError(t, err)
+		retry, err := uc.Publish(ctx, domain.StreamKeyArticles, event)
+		require.NoError(t, err)
+
+		assert.False(t, first.Duplicate)
+		assert.True(t, retry.Success)
+		assert.True(t, retry.Duplicate)
+		assert.Equal(t, "1-0", retry.MessageID)
+		mockPort.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("failed publish releases the key", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := newUsecase(mockPort, newFakeIdempotencyStore())
+		ctx := context.Background()
+		event := newEvent("evt-1", "article-42")
+
+		mockPort.On("Publish", ctx, domain.StreamKeyArticles, event).Return("", errors.New("connection refused")).Once()
+		mockPort.On("Publish", ctx, domain.StreamKeyArticles, event).Return("2-0", nil).Once()
+
+		_, err := uc.Publish(ctx, domain.StreamKeyArticles, event)
+		require.Error(t, err)
+		result, err := uc.Publish(ctx, domain.StreamKeyArticles, event)
+		require.NoError(t, err)
+		assert.Equal(t, "2-0", result.MessageID)
+		assert.False(t, result.Duplicate)
+	})
+
+	t.Run("in-flight key is rejected", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		store := newFakeIdempotencyStore()
+		store.keys[domain.StreamKeyArticles.String()+"|article-42"] = ""
+		uc := newUsecase(mockPort, store)
+
+		_, err := uc.Publish(context.Background(), domain.StreamKeyArticles, newEvent("evt-1", "article-42"))
+		require.ErrorIs(t, err, ErrIdempotencyKeyInFlight)
+		mockPort.AssertNotCalled(t, "Publish")
+	})
+
+	t.Run("keys are ignored without a ttl", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		store := newFakeIdempotencyStore()
+		uc := NewPublishUsecaseWithOptions(mockPort, &PublishUsecaseOptions{Idempotency: store})
+		ctx := context.Background()
+		event := newEvent("evt-1", "article-42")
+
+		mockPort.On("Publish", ctx, domain.StreamKeyArticles, event).Return("1-0", nil)
+
+		_, err := uc.Publish(ctx, domain.StreamKeyArticles, event)
+		require.NoError(t, err)
+		_, err = uc.Publish(ctx, domain.StreamKeyArticles, event)
+		require.NoError(t, err)
+		mockPort.AssertNumberOfCalls(t, "Publish", 2)
+		assert.Empty(t, store.keys)
+	})
+
+	t.Run("batch retry republishes only the failed events", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := newUsecase(mockPort, newFakeIdempotencyStore())
+		ctx := context.Background()
+		a, b, plain := newEvent("a", "key-a"), newEvent("b", "key-b"), newEvent("c", "")
+		events := []*domain.Event{a, b, plain}
+
+		mockPort.On("PublishBatch", ctx, domain.StreamKeyArticles, events).
+			Return([]string{"1-0", "", "1-2"}, &domain.PartialPublishError{
+				TotalEvents: 3,
+				Failures:    []domain.PublishFailure{{Index: 1, Err: errors.New("OOM")}},
+			}).Once()
+		mockPort.On("PublishBatch", ctx, domain.StreamKeyArticles, []*domain.Event{b, plain}).
+			Return([]string{"2-0", "2-1"}, nil).Once()
+
+		_, err := uc.PublishBatch(ctx, domain.StreamKeyArticles, events)
+		require.NoError(t, err)
+		retry, err := uc.PublishBatch(ctx, domain.StreamKeyArticles, events)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"1-0", "2-0", "2-1"}, retry.MessageIDs)
+		assert.Equal(t, int32(3), retry.SuccessCount)
+		mockPort.AssertExpectations(t)
+	})
+
+	t.Run("repeated key within a batch is published once", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := newUsecase(mockPort, newFakeIdempotencyStore())
+		ctx := context.Background()
+		first, again := newEvent("a", "key-a"), newEvent("a2", "key-a")
+
+		mockPort.On("PublishBatch", ctx, domain.StreamKeyArticles, []*domain.Event{first}).
+			Return([]string{"1-0"}, nil).Once()
+
+		result, err := uc.PublishBatch(ctx, domain.StreamKeyArticles, []*domain.Event{first, again})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1-0", "1-0"}, result.MessageIDs)
+		assert.Equal(t, 1, result.Results[1].Index)
+		assert.Equal(t, int32(2), result.SuccessCount)
+	})
 }
 
 func TestPublishUsecase_CreateConsumerGroup(t *testing.T) {
@@ -269,4 +491,53 @@ func TestPublishUsecase_HealthCheck(t *testing.T) {
 		assert.Equal(t, "connection refused", health.RedisStatus)
 		mockPort.AssertExpectations(t)
 	})
+
+	t.Run("includes the Redis topology", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		topo := &domain.RedisTopology{
+			Mode:    domain.RedisModeSentinel,
+			Masters: []string{"redis-b:6379"},
+			Nodes: []domain.RedisNode{
+				{Addr: "redis-b:6379", Role: domain.RedisRoleMaster, Healthy: true},
+				{Addr: "redis-a:6379", Role: domain.RedisRoleReplica, Healthy: false},
+			},
+			Failovers: 1,
+		}
+		uc := NewPublishUsecaseWithOptions(mockPort, &PublishUsecaseOptions{Topology: stubTopology{topo: topo}})
+
+		ctx := context.Background()
+		mockPort.On("Ping", ctx).Return(nil)
+
+		health := uc.HealthCheck(ctx)
+
+		assert.True(t, health.Healthy, "a failed replica does not make mq-hub unhealthy")
+		assert.Equal(t, topo, health.Topology)
+		assert.Empty(t, health.TopologyError)
+	})
+
+	t.Run("reports a topology error without failing", func(t *testing.T) {
+		mockPort := new(MockStreamPort)
+		uc := NewPublishUsecaseWithOptions(mockPort, &PublishUsecaseOptions{
+			Topology: stubTopology{err: errors.New("no sentinel reachable")},
+		})
+
+		ctx := context.Background()
+		mockPort.On("Ping", ctx).Return(nil)
+
+		health := uc.HealthCheck(ctx)
+
+		assert.True(t, health.Healthy)
+		assert.Nil(t, health.Topology)
+		assert.Equal(t, "no sentinel reachable", health.TopologyError)
+	})
+}
+
+// stubTopology implements port.TopologyPort for testing.
+type stubTopology struct {
+	topo *domain.RedisTopology
+	err  error
+}
+
+func (s stubTopology) Topology(context.Context) (*domain.RedisTopology, error) {
+	return s.topo, s.err
 }
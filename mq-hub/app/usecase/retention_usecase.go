@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"mq-hub/domain"
+	"mq-hub/metrics"
+	"mq-hub/port"
+)
+
+// TrimResult reports what one trim pass did to a stream.
+type TrimResult struct {
+	Stream        domain.StreamKey
+	TrimmedMaxLen int64
+	TrimmedMaxAge int64
+	Err           error
+}
+
+// RetentionUsecase enforces per-stream retention policies with XTRIM so Redis
+// memory stays bounded even for streams whose producers do not cap length.
+type RetentionUsecase struct {
+	trimPort port.StreamTrimPort
+	policies domain.RetentionPolicies
+	now      func() time.Time
+}
+
+// NewRetentionUsecase creates a new RetentionUsecase. Streams whose policy is
+// zero are skipped.
+func NewRetentionUsecase(trimPort port.StreamTrimPort, policies domain.RetentionPolicies) *RetentionUsecase {
+	active := make(domain.RetentionPolicies, len(policies))
+	for stream, p := range policies {
+		if !p.IsZero() {
+			active[stream] = p
+		}
+	}
+	return &RetentionUsecase{
+		trimPort: trimPort,
+		policies: active,
+		now:      time.Now,
+	}
+}
+
+// Enabled reports whether any stream has a retention policy.
+func (u *RetentionUsecase) Enabled() bool {
+	return len(u.policies) > 0
+}
+
+// Policies returns the active per-stream policies.
+func (u *RetentionUsecase) Policies() domain.RetentionPolicies {
+	return u.policies
+}
+
+// Run trims every stream once per interval until ctx is cancelled.
+func (u *RetentionUsecase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		u.TrimOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// TrimOnce applies every policy once. A failure on one stream is logged and
+// recorded but does not stop the others.
+func (u *RetentionUsecase) TrimOnce(ctx context.Context) []TrimResult {
+	results := make([]TrimResult, 0, len(u.policies))
+	for _, stream := range u.policies.Streams() {
+		res := u.trimStream(ctx, stream, u.policies[stream])
+		if res.Err != nil {
+			metrics.RecordError("trim", "redis_error")
+			slog.WarnContext(ctx, "stream trim failed", "stream", stream.String(), "error", res.Err)
+		} else if res.TrimmedMaxLen+res.TrimmedMaxAge > 0 {
+			slog.InfoContext(ctx, "stream trimmed",
+				"stream", stream.String(),
+				"trimmed_maxlen", res.TrimmedMaxLen,
+				"trimmed_maxage", res.TrimmedMaxAge,
+			)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func (u *RetentionUsecase) trimStream(ctx context.Context, stream domain.StreamKey, policy domain.RetentionPolicy) TrimResult {
+	res := TrimResult{Stream: stream}
+
+	if policy.MaxLen > 0 {
+		n, err := u.trimPort.TrimMaxLen(ctx, stream, policy.MaxLen)
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		res.TrimmedMaxLen = n
+		metrics.RecordTrim(stream.String(), "maxlen", n)
+	}
+
+	if policy.MaxAge > 0 {
+		minID := domain.StreamIDAt(u.now().Add(-policy.MaxAge))
+
+		// Never drop entries a consumer group still has to process: trimming
+		// a pending entry turns a retry into silent data loss.
+		oldest, err := u.trimPort.OldestUnackedID(ctx, stream)
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		if oldest != "" && domain.CompareStreamIDs(oldest, minID) < 0 {
+			minID = oldest
+		}
+
+		n, err := u.trimPort.TrimMinID(ctx, stream, minID)
+		if err != nil {
+			res.Err = err
+			return res
+		}
+		res.TrimmedMaxAge = n
+		metrics.RecordTrim(stream.String(), "maxage", n)
+	}
+
+	return res
+}
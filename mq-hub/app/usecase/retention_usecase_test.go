@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"mq-hub/domain"
+)
+
+// MockStreamTrimPort is a mock implementation of port.StreamTrimPort.
+type MockStreamTrimPort struct {
+	mock.Mock
+}
+
+func (m *MockStreamTrimPort) TrimMaxLen(ctx context.Context, stream domain.StreamKey, maxLen int64) (int64, error) {
+	args := m.Called(ctx, stream, maxLen)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStreamTrimPort) TrimMinID(ctx context.Context, stream domain.StreamKey, minID string) (int64, error) {
+	args := m.Called(ctx, stream, minID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStreamTrimPort) OldestUnackedID(ctx context.Context, stream domain.StreamKey) (string, error) {
+	args := m.Called(ctx, stream)
+	return args.String(0), args.Error(1)
+}
+
+func newTestRetentionUsecase(trimPort *MockStreamTrimPort, policies domain.RetentionPolicies, now time.Time) *RetentionUsecase {
+	uc := NewRetentionUsecase(trimPort, policies)
+	uc.now = func() time.Time { return now }
+	return uc
+}
+
+func TestRetentionUsecase_SkipsZeroPolicies(t *testing.T) {
+	uc := NewRetentionUsecase(new(MockStreamTrimPort), domain.RetentionPolicies{
+		domain.StreamKeyArticles: {},
+	})
+
+	assert.False(t, uc.Enabled())
+	assert.Empty(t, uc.TrimOnce(context.Background()))
+}
+
+func TestRetentionUsecase_TrimOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(1700000000000)
+
+	t.Run("applies maxlen and maxage", func(t *testing.T) {
+		trimPort := new(MockStreamTrimPort)
+		trimPort.On("TrimMaxLen", ctx, domain.StreamKeyArticles, int64(100)).Return(int64(7), nil)
+		trimPort.On("OldestUnackedID", ctx, domain.StreamKeyArticles).Return("", nil)
+		trimPort.On("TrimMinID", ctx, domain.StreamKeyArticles, "1699999000000-0").Return(int64(3), nil)
+
+		uc := newTestRetentionUsecase(trimPort, domain.RetentionPolicies{
+			domain.StreamKeyArticles: {MaxLen: 100, MaxAge: 1000 * time.Second},
+		}, now)
+
+		results := uc.TrimOnce(ctx)
+
+		require.Len(t, results, 1)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, int64(7), results[0].TrimmedMaxLen)
+		assert.Equal(t, int64(3), results[0].TrimmedMaxAge)
+		trimPort.AssertExpectations(t)
+	})
+
+	t.Run("maxage never trims past unacked entries", func(t *testing.T) {
+		trimPort := new(MockStreamTrimPort)
+		trimPort.On("OldestUnackedID", ctx, domain.StreamKeyTags).Return("1690000000000-4", nil)
+		trimPort.On("TrimMinID", ctx, domain.StreamKeyTags, "1690000000000-4").Return(int64(0), nil)
+
+		uc := newTestRetentionUsecase(trimPort, domain.RetentionPolicies{
+			domain.StreamKeyTags: {MaxAge: time.Hour},
+		}, now)
+
+		results := uc.TrimOnce(ctx)
+
+		require.Len(t, results, 1)
+		assert.NoError(t, results[0].Err)
+		trimPort.AssertNotCalled(t, "TrimMaxLen", mock.Anything, mock.Anything, mock.Anything)
+		trimPort.AssertExpectations(t)
+	})
+
+	t.Run("failure on one stream does not stop the others", func(t *testing.T) {
+		trimPort := new(MockStreamTrimPort)
+		trimPort.On("TrimMaxLen", ctx, domain.StreamKeyArticles, int64(10)).Return(int64(0), errors.New("connection refused"))
+		trimPort.On("TrimMaxLen", ctx, domain.StreamKeyTags, int64(10)).Return(int64(2), nil)
+
+		uc := newTestRetentionUsecase(trimPort, domain.RetentionPolicies{
+			domain.StreamKeyArticles: {MaxLen: 10},
+			domain.StreamKeyTags:     {MaxLen: 10},
+		}, now)
+
+		results := uc.TrimOnce(ctx)
+
+		require.Len(t, results, 2)
+		assert.Error(t, results[0].Err)
+		assert.NoError(t, results[1].Err)
+		assert.Equal(t, int64(2), results[1].TrimmedMaxLen)
+	})
+}
+
+func TestRetentionUsecase_RunStopsOnCancel(t *testing.T) {
+	called := make(chan struct{}, 1)
+	trimPort := new(MockStreamTrimPort)
+	trimPort.On("TrimMaxLen", mock.Anything, domain.StreamKeyIndex, int64(5)).Return(int64(0), nil).
+		Run(func(mock.Arguments) {
+			select {
+			case called <- struct{}{}:
+			default:
+			}
+		})
+
+	uc := NewRetentionUsecase(trimPort, domain.RetentionPolicies{
+		domain.StreamKeyIndex: {MaxLen: 5},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		uc.Run(ctx, time.Hour)
+		close(done)
+	}()
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not trim on start")
+	}
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
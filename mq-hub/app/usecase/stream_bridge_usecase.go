@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"mq-hub/domain"
+	"mq-hub/metrics"
+	"mq-hub/port"
+)
+
+const (
+	defaultBridgeSendBuffer = 256
+	defaultBridgeReadCount  = 100
+	defaultBridgeReadBlock  = 5 * time.Second
+	bridgeReadRetryDelay    = time.Second
+)
+
+// StreamBridgeOptions configures the StreamBridge. Zero values select the
+// defaults.
+type StreamBridgeOptions struct {
+	// Streams are the streams clients may subscribe to.
+	Streams []domain.StreamKey
+	// SendBuffer is how many events may queue for one client before it is
+	// considered lagging.
+	SendBuffer int
+	// ReadCount is the maximum number of entries per XREAD.
+	ReadCount int64
+	// ReadBlock is how long one XREAD waits for new entries.
+	ReadBlock time.Duration
+}
+
+// BridgeSnapshot replaces the events a lagging client missed: the head of
+// each subscribed stream and how many events were dropped. Events that follow
+// a snapshot may repeat entries at or before its heads.
+type BridgeSnapshot struct {
+	Heads   []domain.StreamHead
+	Dropped int
+}
+
+// StreamBridge fans Redis stream entries out to browser subscriptions. A
+// single reader follows every bridged stream with XREAD, so the number of
+// connected clients does not change the load on Redis.
+type StreamBridge struct {
+	reader     port.StreamReadPort
+	streams    []domain.StreamKey
+	sendBuffer int
+	readCount  int64
+	readBlock  time.Duration
+
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// NewStreamBridge creates a new StreamBridge.
+func NewStreamBridge(reader port.StreamReadPort, opts StreamBridgeOptions) *StreamBridge {
+	b := &StreamBridge{
+		reader:     reader,
+		streams:    opts.Streams,
+		sendBuffer: opts.SendBuffer,
+		readCount:  opts.ReadCount,
+		readBlock:  opts.ReadBlock,
+		subs:       make(map[*Subscription]struct{}),
+	}
+	if len(b.streams) == 0 {
+		b.streams = domain.DefaultBridgeStreams
+	}
+	if b.sendBuffer <= 0 {
+		b.sendBuffer = defaultBridgeSendBuffer
+	}
+	if b.readCount <= 0 {
+		b.readCount = defaultBridgeReadCount
+	}
+	if b.readBlock <= 0 {
+		b.readBlock = defaultBridgeReadBlock
+	}
+	return b
+}
+
+// Streams returns the streams clients may subscribe to.
+func (b *StreamBridge) Streams() []domain.StreamKey {
+	return b.streams
+}
+
+// Subscribe registers a subscription for userID. The caller must Unsubscribe
+// it when the connection closes.
+func (b *StreamBridge) Subscribe(userID string, filter domain.SubscriptionFilter) *Subscription {
+	sub := &Subscription{
+		userID: userID,
+		filter: filter,
+		events: make(chan domain.StreamMessage, b.sendBuffer),
+		lagged: make(chan struct{}, 1),
+	}
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+	return sub
+}
+
+// Unsubscribe removes a subscription. Its channels are not closed; the
+// connection stops reading them instead.
+func (b *StreamBridge) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	delete(b.subs, sub)
+	b.mu.Unlock()
+}
+
+// Run follows the bridged streams from their current heads and delivers new
+// entries until ctx is cancelled. Read errors are logged and retried.
+func (b *StreamBridge) Run(ctx context.Context) {
+	cursors, err := b.initialCursors(ctx)
+	for err != nil {
+		metrics.RecordError("bridge_read", "redis_error")
+		slog.WarnContext(ctx, "bridge failed to read stream heads", "error", err)
+		if !sleepCtx(ctx, bridgeReadRetryDelay) {
+			return
+		}
+		cursors, err = b.initialCursors(ctx)
+	}
+
+	for ctx.Err() == nil {
+		msgs, err := b.reader.ReadStreams(ctx, cursors, b.readCount, b.readBlock)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			metrics.RecordError("bridge_read", "redis_error")
+			slog.WarnContext(ctx, "bridge stream read failed", "error", err)
+			sleepCtx(ctx, bridgeReadRetryDelay)
+			continue
+		}
+		for i := range msgs {
+			cursors[msgs[i].Stream] = msgs[i].ID
+			b.dispatch(&msgs[i])
+		}
+	}
+}
+
+// Snapshot clears sub's lagging state and returns the current heads of its
+// streams. Events still queued from before the lag are discarded and counted
+// as dropped: the snapshot supersedes them.
+func (b *StreamBridge) Snapshot(ctx context.Context, sub *Subscription) (*BridgeSnapshot, error) {
+	discarded := 0
+	for drained := false; !drained; {
+		select {
+		case <-sub.events:
+			discarded++
+			metrics.RecordBridgeDrop()
+		default:
+			drained = true
+		}
+	}
+	dropped := sub.resume() + discarded
+
+	streams := sub.Filter().StreamList()
+	snap := &BridgeSnapshot{Heads: make([]domain.StreamHead, 0, len(streams)), Dropped: dropped}
+	for _, stream := range streams {
+		head, err := b.reader.StreamHead(ctx, stream)
+		if err != nil {
+			return nil, fmt.Errorf("stream head: %w", err)
+		}
+		snap.Heads = append(snap.Heads, head)
+	}
+	metrics.RecordBridgeSnapshot()
+	return snap, nil
+}
+
+func (b *StreamBridge) initialCursors(ctx context.Context) (map[domain.StreamKey]string, error) {
+	cursors := make(map[domain.StreamKey]string, len(b.streams))
+	for _, stream := range b.streams {
+		head, err := b.reader.StreamHead(ctx, stream)
+		if err != nil {
+			return nil, err
+		}
+		cursors[stream] = head.LastEntryID
+		if cursors[stream] == "" {
+			cursors[stream] = "0-0"
+		}
+	}
+	return cursors, nil
+}
+
+func (b *StreamBridge) dispatch(msg *domain.StreamMessage) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subs {
+		sub.deliver(msg)
+	}
+}
+
+// Subscription is one connection's view of the bridge. Delivery never
+// blocks the reader: when the send buffer is full the subscription is marked
+// lagging, further events are dropped, and Lagged fires so the connection
+// can replace the backlog with a snapshot.
+type Subscription struct {
+	userID string
+	events chan domain.StreamMessage
+	lagged chan struct{}
+
+	mu        sync.Mutex
+	filter    domain.SubscriptionFilter
+	isLagging bool
+	dropped   int
+}
+
+// Events returns the queued events for the connection to send.
+func (s *Subscription) Events() <-chan domain.StreamMessage {
+	return s.events
+}
+
+// Lagged fires once when the subscription starts dropping events.
+func (s *Subscription) Lagged() <-chan struct{} {
+	return s.lagged
+}
+
+// Filter returns the subscription's current filter.
+func (s *Subscription) Filter() domain.SubscriptionFilter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.filter
+}
+
+// SetFilter replaces the filter for events delivered from now on.
+func (s *Subscription) SetFilter(filter domain.SubscriptionFilter) {
+	s.mu.Lock()
+	s.filter = filter
+	s.mu.Unlock()
+}
+
+func (s *Subscription) deliver(msg *domain.StreamMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.filter.Matches(msg, s.userID) {
+		return
+	}
+	if !s.isLagging {
+		select {
+		case s.events <- *msg:
+			return
+		default:
+			s.isLagging = true
+			select {
+			case s.lagged <- struct{}{}:
+			default:
+			}
+		}
+	}
+	s.dropped++
+	metrics.RecordBridgeDrop()
+}
+
+// resume leaves lagging mode and returns how many events were dropped.
+func (s *Subscription) resume() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dropped := s.dropped
+	s.isLagging = false
+	s.dropped = 0
+	return dropped
+}
+
+// sleepCtx waits for d and reports whether ctx is still live.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mq-hub/domain"
+)
+
+// fakeStreamReader serves queued batches to ReadStreams and blocks briefly
+// once they are used up, like an XREAD that times out.
+type fakeStreamReader struct {
+	mu      sync.Mutex
+	batches [][]domain.StreamMessage
+	heads   map[domain.StreamKey]domain.StreamHead
+	cursors []map[domain.StreamKey]string
+}
+
+func (f *fakeStreamReader) ReadStreams(ctx context.Context, after map[domain.StreamKey]string, _ int64, _ time.Duration) ([]domain.StreamMessage, error) {
+	f.mu.Lock()
+	cursors := make(map[domain.StreamKey]string, len(after))
+	for k, v := range after {
+		cursors[k] = v
+	}
+	f.cursors = append(f.cursors, cursors)
+	if len(f.batches) > 0 {
+		batch := f.batches[0]
+		f.batches = f.batches[1:]
+		f.mu.Unlock()
+		return batch, nil
+	}
+	f.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(5 * time.Millisecond):
+		return nil, nil
+	}
+}
+
+func (f *fakeStreamReader) StreamHead(_ context.Context, stream domain.StreamKey) (domain.StreamHead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if head, ok := f.heads[stream]; ok {
+		return head, nil
+	}
+	return domain.StreamHead{Stream: stream}, nil
+}
+
+func bridgeMessage(stream domain.StreamKey, id string, eventType domain.EventType, userID string) domain.StreamMessage {
+	ev := &domain.Event{EventID: id, EventType: eventType, Metadata: map[string]string{}}
+	if userID != "" {
+		ev.Metadata[domain.MetadataUserID] = userID
+	}
+	return domain.StreamMessage{Stream: stream, ID: id, Event: ev}
+}
+
+func allStreamsFilter(t *testing.T) domain.SubscriptionFilter {
+	t.Helper()
+	f, err := domain.NewSubscriptionFilter(nil, nil, domain.DefaultBridgeStreams)
+	require.NoError(t, err)
+	return f
+}
+
+func TestStreamBridge_RunDeliversMatchingEventsFromHead(t *testing.T) {
+	reader := &fakeStreamReader{
+		heads: map[domain.StreamKey]domain.StreamHead{
+			domain.StreamKeyArticles: {Stream: domain.StreamKeyArticles, LastEntryID: "5-0", Length: 5},
+		},
+		batches: [][]domain.StreamMessage{{
+			bridgeMessage(domain.StreamKeyArticles, "6-0", domain.EventTypeArticleCreated, ""),
+			bridgeMessage(domain.StreamKeySummaries, "1-0", domain.EventTypeArticleSummarized, "other-user"),
+			bridgeMessage(domain.StreamKeySummaries, "2-0", domain.EventTypeArticleSummarized, "user-1"),
+		}},
+	}
+	b := NewStreamBridge(reader, StreamBridgeOptions{})
+	sub := b.Subscribe("user-1", allStreamsFilter(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		b.Run(ctx)
+		close(done)
+	}()
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case msg := <-sub.Events():
+			got = append(got, msg.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for events, got %v", got)
+		}
+	}
+	cancel()
+	<-done
+
+	assert.Equal(t, []string{"6-0", "2-0"}, got, "events for another user must be filtered out")
+	reader.mu.Lock()
+	defer reader.mu.Unlock()
+	assert.Equal(t, "5-0", reader.cursors[0][domain.StreamKeyArticles], "reading starts at the stream head")
+	assert.Equal(t, "0-0", reader.cursors[0][domain.StreamKeyTags], "an empty stream is read from the start")
+	assert.Equal(t, "6-0", reader.cursors[1][domain.StreamKeyArticles])
+	assert.Equal(t, "2-0", reader.cursors[1][domain.StreamKeySummaries])
+}
+
+func TestStreamBridge_LaggingSubscriptionFallsBackToSnapshot(t *testing.T) {
+	reader := &fakeStreamReader{heads: map[domain.StreamKey]domain.StreamHead{
+		domain.StreamKeyArticles: {Stream: domain.StreamKeyArticles, LastEntryID: "9-0", Length: 9},
+	}}
+	b := NewStreamBridge(reader, StreamBridgeOptions{SendBuffer: 2})
+	filter, err := domain.NewSubscriptionFilter([]string{"alt:events:articles"}, nil, domain.DefaultBridgeStreams)
+	require.NoError(t, err)
+	sub := b.Subscribe("user-1", filter)
+
+	for i := 1; i <= 5; i++ {
+		msg := bridgeMessage(domain.StreamKeyArticles, fmt.Sprintf("%d-0", i), domain.EventTypeArticleCreated, "")
+		b.dispatch(&msg)
+	}
+
+	select {
+	case <-sub.Lagged():
+	default:
+		t.Fatal("a full send buffer must signal lag")
+	}
+
+	snap, err := b.Snapshot(context.Background(), sub)
+	require.NoError(t, err)
+	assert.Equal(t, 5, snap.Dropped, "queued and dropped events are both superseded by the snapshot")
+	assert.Equal(t, []domain.StreamHead{{Stream: domain.StreamKeyArticles, LastEntryID: "9-0", Length: 9}}, snap.Heads)
+	assert.Empty(t, sub.Events())
+
+	// Back in live mode: the next event is queued again.
+	msg := bridgeMessage(domain.StreamKeyArticles, "10-0", domain.EventTypeArticleCreated, "")
+	b.dispatch(&msg)
+	require.Len(t, sub.Events(), 1)
+	assert.Equal(t, "10-0", (<-sub.Events()).ID)
+}
+
+func TestStreamBridge_UnsubscribeStopsDelivery(t *testing.T) {
+	b := NewStreamBridge(&fakeStreamReader{}, StreamBridgeOptions{})
+	sub := b.Subscribe("user-1", allStreamsFilter(t))
+	b.Unsubscribe(sub)
+
+	msg := bridgeMessage(domain.StreamKeyArticles, "1-0", domain.EventTypeArticleCreated, "")
+	b.dispatch(&msg)
+	assert.Empty(t, sub.Events())
+}
@@ -32,6 +32,10 @@ class SummarizeRequest(StrictFrozenModel):
     content: str = Field(min_length=1)
     stream: bool = False
     priority: str = Field(default="low", pattern="^(high|low)$")
+    # Language the summary is written in (the reader's locale).
+    target_language: str = Field(default="ja", pattern="^(ja|en)$")
+    # Detected language of the article, when the caller knows it.
+    source_language: str | None = Field(default=None, max_length=16)
 
 
 class SummarizeResponse(StrictFrozenModel):
@@ -41,6 +45,7 @@ class SummarizeResponse(StrictFrozenModel):
     article_id: str
     summary: str
     model: str
+    language: str = "ja"
     prompt_tokens: int | None = None
     completion_tokens: int | None = None
     total_duration_ms: float | None = None
@@ -83,6 +83,89 @@ Extract key facts:
 <|turn>model
 """
 
+SUMMARY_PROMPT_TEMPLATE_EN = """<|turn>user
+You are an expert multilingual journalist writing English news summaries.
+
+TASK:
+- Current Date: {current_date}
+- Read the article (in any language) and produce an English newspaper-style summary.
+- Work in two silent steps: (1) extract facts; (2) write the article. Do NOT show notes.
+
+RULES AND CONSTRAINTS:
+- Style: neutral news prose, third person, no headings, no bullet points, body text only
+- Length: 150-300 words. Adjust the length to the amount of content in the article.
+- Must include: 5W1H / at least 3 numbers (dates, amounts, counts, ratios) / proper nouns / background / impact / outlook
+- If information is missing, write "not stated". Quote at most 2 key statements of 30 words or fewer.
+- Dates as "Month D, YYYY". Keep numbers and units exactly as in the article.
+- Translate non-English proper nouns and add the original in parentheses on first mention.
+
+CRITICAL: AVOID GENERIC STATEMENTS
+- Use only concrete facts stated in the article; do not generalize or speculate.
+- Do not use hedging such as "it is thought", "may possibly", "is likely to".
+- Prefer specific numbers and names over vague words ("50 companies", not "many companies").
+
+OUTPUT STRUCTURE:
+- Paragraph 1 = lead (most important fact + 5W1H)
+- Paragraph 2 = background and timeline (with dates and numbers)
+- Paragraph 3 = details (numbers, proper nouns, quotes)
+- Paragraph 4 = impact and reactions
+- Paragraph 5 = outlook and next steps
+
+CRITICAL OUTPUT REQUIREMENTS:
+- Do not include any preamble. Complete your output; always end with a complete sentence.
+
+ARTICLE TO SUMMARIZE:
+---
+{content}
+---
+
+Write 3-5 paragraphs in English with specific facts, numbers, dates, and proper nouns. Target 150-300 words. Always end with a complete sentence.
+<turn|>
+<|turn>model
+"""
+
+CHUNK_SUMMARY_PROMPT_TEMPLATE_EN = """<|turn>user
+You are an expert editor extracting key information for a later summarization task.
+
+TASK:
+- Read the text chunk below (part of a larger article).
+- Extract and list the key facts, numbers, dates, and proper nouns.
+- Maintain the flow of events if present.
+- Output as a bulleted list in English.
+
+CONSTRAINTS:
+- Language: English
+- Format: Bullet points
+- Focus: Factual accuracy. Do not summarize abstractly; capture specifics.
+- Content: If the text is just boilerplates or irrelevant, output "NONE".
+
+TEXT CHUNK:
+---
+{content}
+---
+
+Extract key facts:
+<turn|>
+<|turn>model
+"""
+
+# Article summaries can be written in these languages (ISO 639-1). The
+# Japanese templates are the default; callers pass the reader's language.
+DEFAULT_SUMMARY_LANGUAGE = "ja"
+
+SUMMARY_PROMPT_TEMPLATES = {
+    "ja": SUMMARY_PROMPT_TEMPLATE,
+    "en": SUMMARY_PROMPT_TEMPLATE_EN,
+}
+
+CHUNK_SUMMARY_PROMPT_TEMPLATES = {
+    "ja": CHUNK_SUMMARY_PROMPT_TEMPLATE,
+    "en": CHUNK_SUMMARY_PROMPT_TEMPLATE_EN,
+}
+
+# What each chunk template outputs for a chunk with no usable facts.
+EMPTY_CHUNK_MARKERS = frozenset({"なし", "NONE"})
+
 RECAP_CLUSTER_SUMMARY_PROMPT = r"""<|turn>system
 You are an expert Japanese news editor. Generate structured Japanese recap bullets strictly following the contract below.
 Return a single JSON object and nothing else.
@@ -59,6 +59,8 @@ def create_summarize_router(summarize_usecase: SummarizeUsecase) -> APIRouter:
             "Received summarize request",
             extra={
                 "incoming_content_length": incoming_content_length,
+                "source_language": request.source_language,
+                "target_language": request.target_language,
             },
         )
 
@@ -98,6 +100,7 @@ def create_summarize_router(summarize_usecase: SummarizeUsecase) -> APIRouter:
                         article_id=request.article_id,
                         content=request.content,
                         priority=request.priority,
+                        language=request.target_language,
                     )
                     logger.info(
                         "Stream generator created, creating StreamingResponse with heartbeat",
@@ -335,6 +338,7 @@ def create_summarize_router(summarize_usecase: SummarizeUsecase) -> APIRouter:
                 article_id=request.article_id,
                 content=request.content,
                 priority=request.priority,
+                language=request.target_language,
             )
 
             return SummarizeResponse(
@@ -342,6 +346,7 @@ def create_summarize_router(summarize_usecase: SummarizeUsecase) -> APIRouter:
                 article_id=request.article_id,
                 summary=summary,
                 model=metadata.model,
+                language=request.target_language,
                 prompt_tokens=metadata.prompt_tokens,
                 completion_tokens=metadata.completion_tokens,
                 total_duration_ms=metadata.total_duration_ms,
@@ -15,8 +15,9 @@ from datetime import datetime
 from typing import Protocol
 
 from news_creator.domain.prompts import (
-    SUMMARY_PROMPT_TEMPLATE,
-    CHUNK_SUMMARY_PROMPT_TEMPLATE,
+    DEFAULT_SUMMARY_LANGUAGE,
+    SUMMARY_PROMPT_TEMPLATES,
+    CHUNK_SUMMARY_PROMPT_TEMPLATES,
     RECAP_CLUSTER_SUMMARY_PROMPT,
 )
 
@@ -35,7 +36,8 @@ class SummaryPromptBuilder:
     """Builds prompts for article summarization.
 
     Responsibilities:
-    - Format SUMMARY_PROMPT_TEMPLATE with content and date
+    - Pick the summary template for the target language
+    - Format it with content and date
     - Handle default date formatting
 
     This class extracts prompt building from SummarizeUsecase.
@@ -45,20 +47,29 @@ class SummaryPromptBuilder:
         self,
         content: str,
         current_date: str | None = None,
+        language: str = DEFAULT_SUMMARY_LANGUAGE,
     ) -> str:
         """Build a summary prompt.
 
         Args:
             content: Article content to summarize
             current_date: Optional date string (defaults to today)
+            language: Language to write the summary in ("ja" or "en")
 
         Returns:
             Formatted prompt string
+
+        Raises:
+            ValueError: If there is no template for language
         """
+        template = SUMMARY_PROMPT_TEMPLATES.get(language)
+        if template is None:
+            raise ValueError(f"unsupported summary language: {language}")
         if current_date is None:
-            current_date = datetime.now().strftime("%Y年%m月%d日")
+            date_format = "%Y年%m月%d日" if language == "ja" else "%Y-%m-%d"
+            current_date = datetime.now().strftime(date_format)
 
-        return SUMMARY_PROMPT_TEMPLATE.format(
+        return template.format(
             current_date=current_date,
             content=content,
         )
@@ -68,21 +79,28 @@ class ChunkPromptBuilder:
     """Builds prompts for chunk summarization in hierarchical processing.
 
     Responsibilities:
-    - Format CHUNK_SUMMARY_PROMPT_TEMPLATE with chunk content
+    - Format the chunk template for the target language with chunk content
 
     This class extracts prompt building from SummarizeUsecase._generate_hierarchical_summary().
     """
 
-    def build(self, content: str) -> str:
+    def build(self, content: str, language: str = DEFAULT_SUMMARY_LANGUAGE) -> str:
         """Build a chunk summary prompt.
 
         Args:
             content: Chunk content to extract facts from
+            language: Language to write the extracted facts in ("ja" or "en")
 
         Returns:
             Formatted prompt string
+
+        Raises:
+            ValueError: If there is no template for language
         """
-        return CHUNK_SUMMARY_PROMPT_TEMPLATE.format(content=content)
+        template = CHUNK_SUMMARY_PROMPT_TEMPLATES.get(language)
+        if template is None:
+            raise ValueError(f"unsupported summary language: {language}")
+        return template.format(content=content)
 
 
 class RecapPromptBuilder:
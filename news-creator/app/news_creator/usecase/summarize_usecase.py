@@ -10,8 +10,10 @@ import aiohttp
 from news_creator.domain.models import LLMGenerateResponse, SummaryMetadata
 from news_creator.config.config import NewsCreatorConfig
 from news_creator.domain.prompts import (
-    SUMMARY_PROMPT_TEMPLATE,
-    CHUNK_SUMMARY_PROMPT_TEMPLATE,
+    DEFAULT_SUMMARY_LANGUAGE,
+    EMPTY_CHUNK_MARKERS,
+    SUMMARY_PROMPT_TEMPLATES,
+    CHUNK_SUMMARY_PROMPT_TEMPLATES,
 )
 from news_creator.port.llm_provider_port import LLMProviderPort
 from news_creator.utils.repetition_detector import detect_repetition
@@ -22,7 +24,7 @@ logger = logging.getLogger(__name__)
 
 
 class SummarizeUsecase:
-    """Usecase for generating Japanese article summaries."""
+    """Usecase for generating article summaries (Japanese by default)."""
 
     def __init__(self, config: NewsCreatorConfig, llm_provider: LLMProviderPort):
         """Initialize summarize usecase."""
@@ -30,14 +32,19 @@ class SummarizeUsecase:
         self.llm_provider = llm_provider
 
     async def generate_summary(
-        self, article_id: str, content: str, priority: str = "low"
+        self,
+        article_id: str,
+        content: str,
+        priority: str = "low",
+        language: str = DEFAULT_SUMMARY_LANGUAGE,
     ) -> tuple[str, SummaryMetadata]:
         """
-        Generate a Japanese summary for an article.
+        Generate a summary for an article.
 
         Args:
             article_id: Article identifier
             content: Article content to summarize
+            language: Language to write the summary in ("ja" or "en")
 
         Returns:
             Tuple of (summary text, metadata dict)
@@ -50,6 +57,8 @@ class SummarizeUsecase:
             raise ValueError("article_id cannot be empty")
         if not content or not content.strip():
             raise ValueError("content cannot be empty")
+        if language not in SUMMARY_PROMPT_TEMPLATES:
+            raise ValueError(f"unsupported summary language: {language}")
 
         # Zero Trust: Always clean HTML from content, even if it appears to be plain text
         # This ensures we never process raw HTML, even if upstream services already extracted it
@@ -140,7 +149,9 @@ class SummarizeUsecase:
                     "threshold": self.config.hierarchical_single_article_threshold,
                 },
             )
-            return await self._generate_hierarchical_summary(article_id, content)
+            return await self._generate_hierarchical_summary(
+                article_id, content, language
+            )
 
         truncated_content = content.strip()[:MAX_CONTENT_LENGTH]
 
@@ -161,13 +172,14 @@ class SummarizeUsecase:
                 "article_id": article_id,
                 "content_length": len(truncated_content),
                 "was_truncated": original_length > MAX_CONTENT_LENGTH,
+                "language": language,
             },
         )
 
-        # Build prompt from template
+        # Build prompt from the template for the target language
         jst = timezone(timedelta(hours=9))
         current_date_str = datetime.now(jst).strftime("%Y-%m-%d")
-        prompt = SUMMARY_PROMPT_TEMPLATE.format(
+        prompt = SUMMARY_PROMPT_TEMPLATES[language].format(
             content=truncated_content, current_date=current_date_str
         )
         prompt_length = len(prompt)
@@ -270,7 +282,9 @@ class SummarizeUsecase:
                     "context_window": context_window,
                 },
             )
-            return await self._generate_hierarchical_summary(article_id, content)
+            return await self._generate_hierarchical_summary(
+                article_id, content, language
+            )
 
         # Retry loop with repetition detection
         # IMPORTANT: Use hold_slot to acquire semaphore ONCE for all retries.
@@ -563,7 +577,7 @@ class SummarizeUsecase:
         return truncated_summary, metadata
 
     async def _generate_hierarchical_summary(
-        self, article_id: str, content: str
+        self, article_id: str, content: str, language: str = DEFAULT_SUMMARY_LANGUAGE
     ) -> tuple[str, SummaryMetadata]:
         """
         Generate a summary for a large article using Hierarchical (Map-Reduce) strategy.
@@ -575,6 +589,7 @@ class SummarizeUsecase:
         Args:
             article_id: Article identifier
             content: Full article content
+            language: Language to write the summary in
 
         Returns:
             Tuple of (summary text, metadata dict)
@@ -607,7 +622,7 @@ class SummarizeUsecase:
                 extra={"article_id": article_id, "chunk_index": i},
             )
 
-            prompt = CHUNK_SUMMARY_PROMPT_TEMPLATE.format(content=chunk)
+            prompt = CHUNK_SUMMARY_PROMPT_TEMPLATES[language].format(content=chunk)
 
             # Use somewhat higher temperature for extraction to avoid rigid repetition
             llm_options = {
@@ -636,7 +651,7 @@ class SummarizeUsecase:
                     chunk_text, f"{article_id}-chunk-{i}"
                 )
 
-                if chunk_text and chunk_text != "なし":
+                if chunk_text and chunk_text not in EMPTY_CHUNK_MARKERS:
                     chunk_summaries.append(chunk_text)
 
                 if chunk_resp.prompt_eval_count:
@@ -668,7 +683,7 @@ class SummarizeUsecase:
             },
         )
 
-        prompt = SUMMARY_PROMPT_TEMPLATE.format(
+        prompt = SUMMARY_PROMPT_TEMPLATES[language].format(
             content=combined_text,
             current_date=datetime.now(timezone(timedelta(hours=9))).strftime(
                 "%Y-%m-%d"
@@ -716,14 +731,19 @@ class SummarizeUsecase:
         return truncated_summary, metadata
 
     async def generate_summary_stream(
-        self, article_id: str, content: str, priority: str = "low"
+        self,
+        article_id: str,
+        content: str,
+        priority: str = "low",
+        language: str = DEFAULT_SUMMARY_LANGUAGE,
     ) -> AsyncGenerator[str, None]:
         """
-        Generate a Japanese summary for an article as a stream of tokens.
+        Generate a summary for an article as a stream of tokens.
 
         Args:
             article_id: Article identifier
             content: Article content to summarize
+            language: Language to write the summary in ("ja" or "en")
 
         Yields:
             Summary tokens
@@ -740,6 +760,8 @@ class SummarizeUsecase:
             raise ValueError("article_id cannot be empty")
         if not content or not content.strip():
             raise ValueError("content cannot be empty")
+        if language not in SUMMARY_PROMPT_TEMPLATES:
+            raise ValueError(f"unsupported summary language: {language}")
 
         # Zero Trust: Clean HTML
         cleaned_content, _ = clean_html_content(content, article_id)
@@ -799,7 +821,7 @@ class SummarizeUsecase:
         safe_content = truncated_content[:MAX_CONTENT_LENGTH]
         jst = timezone(timedelta(hours=9))
         current_date_str = datetime.now(jst).strftime("%Y-%m-%d")
-        prompt = SUMMARY_PROMPT_TEMPLATE.format(
+        prompt = SUMMARY_PROMPT_TEMPLATES[language].format(
             content=safe_content, current_date=current_date_str
         )
         prompt_length = len(prompt)
@@ -68,6 +68,57 @@ class TestChunkPromptBuilder:
 
         assert "Bullet" in prompt or "bullet" in prompt
 
+    def test_selects_english_chunk_template(self):
+        """Should use the English chunk template for language="en"."""
+        from news_creator.usecase.prompt_builder import ChunkPromptBuilder
+
+        prompt = ChunkPromptBuilder().build(content="Content", language="en")
+
+        assert "Content" in prompt
+        assert "NONE" in prompt
+
+
+class TestSummaryPromptLanguage:
+    """Tests for locale-specific summary prompt selection."""
+
+    def test_defaults_to_japanese(self):
+        """Should keep the Japanese template when no language is given."""
+        from news_creator.domain.prompts import SUMMARY_PROMPT_TEMPLATE
+        from news_creator.usecase.prompt_builder import SummaryPromptBuilder
+
+        prompt = SummaryPromptBuilder().build(content="Content", current_date="2026年4月1日")
+
+        assert prompt == SUMMARY_PROMPT_TEMPLATE.format(
+            current_date="2026年4月1日", content="Content"
+        )
+
+    def test_selects_english_template(self):
+        """Should use the English template for language="en"."""
+        from news_creator.domain.prompts import SUMMARY_PROMPT_TEMPLATE_EN
+        from news_creator.usecase.prompt_builder import SummaryPromptBuilder
+
+        prompt = SummaryPromptBuilder().build(
+            content="Content", current_date="April 1, 2026", language="en"
+        )
+
+        assert prompt == SUMMARY_PROMPT_TEMPLATE_EN.format(
+            current_date="April 1, 2026", content="Content"
+        )
+
+    def test_rejects_unsupported_language(self):
+        """Should raise ValueError for a language without a template."""
+        import pytest
+
+        from news_creator.usecase.prompt_builder import (
+            ChunkPromptBuilder,
+            SummaryPromptBuilder,
+        )
+
+        with pytest.raises(ValueError):
+            SummaryPromptBuilder().build(content="Content", language="fr")
+        with pytest.raises(ValueError):
+            ChunkPromptBuilder().build(content="Content", language="fr")
+
 
 class TestRecapPromptBuilder:
     """Tests for RecapPromptBuilder."""
@@ -30,6 +30,7 @@ import (
 
 	"pki-agent/config"
 	"pki-agent/internal/adapter/handler"
+	"pki-agent/internal/domain"
 	"pki-agent/internal/infrastructure"
 	"pki-agent/internal/usecase"
 )
@@ -42,6 +43,9 @@ func main() {
 		}
 		return
 	}
+	if len(os.Args) > 1 && (os.Args[1] == "status" || os.Args[1] == "rotate") {
+		os.Exit(runOneShot(os.Args[1], os.Stdout, os.Stderr))
+	}
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 	slog.SetDefault(logger)
@@ -57,26 +61,7 @@ func main() {
 		"renew_fraction", cfg.RenewAtFraction, "tick_interval", cfg.TickInterval)
 
 	obs := infrastructure.NewPromObserver(cfg.Subject)
-	certFile := &infrastructure.CertFile{
-		CertPath: cfg.CertPath, KeyPath: cfg.KeyPath,
-		OwnerUID: cfg.OwnerUID, OwnerGID: cfg.OwnerGID,
-		// config.Load always resolves OwnerUID/OwnerGID to a concrete,
-		// intentional pair (default 0:0, i.e. root) — never "unset" — so
-		// the composition root always requests the chown.
-		ChownRequested: true,
-	}
-	stepCA := &infrastructure.StepCACLI{
-		CAURL: cfg.CAURL, RootFile: cfg.RootFile,
-		Provisioner: cfg.Provisioner, PasswordFile: cfg.PasswordFile,
-	}
-	rotator := &usecase.Rotator{
-		Subject: cfg.Subject, SANs: cfg.SANs,
-		RenewAtFraction: cfg.RenewAtFraction,
-		Loader:          certFile,
-		Issuer:          stepCA,
-		Writer:          certFile,
-		Observer:        obs,
-	}
+	rotator := newRotator(cfg, obs)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
@@ -178,3 +163,28 @@ func main() {
 		}
 	}
 }
+
+// newRotator wires the rotator for cfg. Shared by the agent loop and the
+// one-shot status/rotate commands so both act on the same files and CA.
+func newRotator(cfg *config.Config, obs domain.Observer) *usecase.Rotator {
+	certFile := &infrastructure.CertFile{
+		CertPath: cfg.CertPath, KeyPath: cfg.KeyPath,
+		OwnerUID: cfg.OwnerUID, OwnerGID: cfg.OwnerGID,
+		// config.Load always resolves OwnerUID/OwnerGID to a concrete,
+		// intentional pair (default 0:0, i.e. root) — never "unset" — so
+		// the composition root always requests the chown.
+		ChownRequested: true,
+	}
+	stepCA := &infrastructure.StepCACLI{
+		CAURL: cfg.CAURL, RootFile: cfg.RootFile,
+		Provisioner: cfg.Provisioner, PasswordFile: cfg.PasswordFile,
+	}
+	return &usecase.Rotator{
+		Subject: cfg.Subject, SANs: cfg.SANs,
+		RenewAtFraction: cfg.RenewAtFraction,
+		Loader:          certFile,
+		Issuer:          stepCA,
+		Writer:          certFile,
+		Observer:        obs,
+	}
+}
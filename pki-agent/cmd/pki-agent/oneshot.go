@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"pki-agent/config"
+	"pki-agent/internal/domain"
+)
+
+// oneShotTimeout bounds `pki-agent rotate`, which waits on step-ca.
+const oneShotTimeout = 60 * time.Second
+
+// runOneShot implements `pki-agent status` and `pki-agent rotate`, run
+// through `docker compose exec` against a live sidecar (altctl ssl). Both
+// read the sidecar's own environment, so they act on exactly the cert the
+// agent loop manages. Output is one JSON CertStatus on stdout; the return
+// value is the process exit code.
+func runOneShot(command string, stdout, stderr io.Writer) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(stderr, "config:", err)
+		return 2
+	}
+	rotator := newRotator(cfg, nopObserver{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), oneShotTimeout)
+	defer cancel()
+
+	if command == "rotate" {
+		if _, err := rotator.Rotate(ctx); err != nil {
+			fmt.Fprintln(stderr, "rotate:", err)
+			return 1
+		}
+	}
+
+	status, err := rotator.Status(ctx, time.Now())
+	if err != nil {
+		fmt.Fprintln(stderr, "status:", err)
+		return 1
+	}
+	if err := json.NewEncoder(stdout).Encode(status); err != nil {
+		fmt.Fprintln(stderr, "status:", err)
+		return 1
+	}
+	return 0
+}
+
+// nopObserver discards lifecycle events: a one-shot process has no
+// metrics endpoint, and the agent loop reports the new state on its next
+// tick.
+type nopObserver struct{}
+
+func (nopObserver) OnClassified(domain.CertState, time.Duration) {}
+func (nopObserver) OnReissued(string)                            {}
+func (nopObserver) OnRenewed(bool)                               {}
@@ -56,3 +56,24 @@ func ClassifyRemaining(notBefore, notAfter, now time.Time, renewAtFraction float
 	}
 	return StateFresh
 }
+
+// RenewAt returns when a cert valid over [notBefore, notAfter] crosses
+// renewAtFraction of its lifetime, i.e. the first tick that reissues it.
+func RenewAt(notBefore, notAfter time.Time, renewAtFraction float64) time.Time {
+	total := notAfter.Sub(notBefore)
+	return notBefore.Add(time.Duration(float64(total) * renewAtFraction))
+}
+
+// CertStatus is a read-only snapshot of the on-disk leaf, printed as JSON
+// by `pki-agent status`. Only State is meaningful for a missing or corrupt
+// cert.
+type CertStatus struct {
+	State     string    `json:"state"`
+	Subject   string    `json:"subject,omitempty"`
+	SANs      []string  `json:"sans,omitempty"`
+	Issuer    string    `json:"issuer,omitempty"`
+	Serial    string    `json:"serial,omitempty"`
+	NotBefore time.Time `json:"not_before,omitzero"`
+	NotAfter  time.Time `json:"not_after,omitzero"`
+	RenewAt   time.Time `json:"renew_at,omitzero"`
+}
@@ -52,6 +52,39 @@ func (r *Rotator) Tick(ctx context.Context, now time.Time) (domain.CertState, er
 	}
 }
 
+// Rotate reissues the cert regardless of its state, e.g. after a CA
+// change or a suspected key compromise. Invoked by `pki-agent rotate`; the
+// long-running agent picks the new cert up on its next tick.
+func (r *Rotator) Rotate(ctx context.Context) (domain.CertState, error) {
+	return r.issue(ctx, "forced")
+}
+
+// Status reports the on-disk cert without reissuing it. A missing or
+// unparseable cert is a state, not an error.
+func (r *Rotator) Status(ctx context.Context, now time.Time) (domain.CertStatus, error) {
+	cert, err := r.Loader.Load(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrCertNotFound) {
+			return domain.CertStatus{State: domain.StateMissing.String()}, nil
+		}
+		if errors.Is(err, domain.ErrCertParseFailed) {
+			return domain.CertStatus{State: domain.StateCorrupt.String()}, nil
+		}
+		return domain.CertStatus{}, fmt.Errorf("load cert: %w", err)
+	}
+	state := domain.ClassifyRemaining(cert.NotBefore, cert.NotAfter, now, r.RenewAtFraction)
+	return domain.CertStatus{
+		State:     state.String(),
+		Subject:   cert.Subject.CommonName,
+		SANs:      cert.DNSNames,
+		Issuer:    cert.Issuer.CommonName,
+		Serial:    cert.SerialNumber.Text(16),
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+		RenewAt:   domain.RenewAt(cert.NotBefore, cert.NotAfter, r.RenewAtFraction),
+	}, nil
+}
+
 func (r *Rotator) issue(ctx context.Context, reason string) (domain.CertState, error) {
 	r.Observer.OnReissued(reason)
 	certPEM, keyPEM, err := r.Issuer.Issue(ctx, r.Subject, r.SANs)
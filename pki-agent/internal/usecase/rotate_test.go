@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
@@ -204,3 +206,78 @@ func TestTick_Missing_IssueUpdatesObserverClassified(t *testing.T) {
 		t.Fatalf("want OnClassified(StateFresh) after issue-on-missing; got classified=%v", obs.classified)
 	}
 }
+
+func TestRotate_ReissuesFreshCert(t *testing.T) {
+	nb := time.Now().Add(-time.Hour)
+	cert := &x509.Certificate{NotBefore: nb, NotAfter: nb.Add(24 * time.Hour)}
+	loader := &fakeLoader{cert: cert}
+	issuer := &fakeIssuer{}
+	writer := &fakeWriter{}
+	obs := &fakeObs{}
+	r := newRotator(loader, issuer, writer, obs)
+
+	state, err := r.Rotate(context.Background())
+	if err != nil || state != domain.StateFresh {
+		t.Fatalf("state=%s err=%v", state, err)
+	}
+	if issuer.called != 1 || writer.wrote != 1 {
+		t.Fatalf("fresh cert must still be reissued: %+v %+v", issuer, writer)
+	}
+	if len(obs.reissued) != 1 || obs.reissued[0] != "forced" {
+		t.Fatalf("observer: %+v", obs)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	nb := time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{
+		SerialNumber: big.NewInt(0xabc),
+		Subject:      pkix.Name{CommonName: "alt-backend"},
+		Issuer:       pkix.Name{CommonName: "Alt Intermediate CA"},
+		DNSNames:     []string{"alt-backend", "localhost"},
+		NotBefore:    nb,
+		NotAfter:     nb.Add(100 * time.Hour),
+	}
+
+	t.Run("reports without reissuing", func(t *testing.T) {
+		issuer := &fakeIssuer{}
+		r := newRotator(&fakeLoader{cert: cert}, issuer, &fakeWriter{}, &fakeObs{})
+
+		status, err := r.Status(context.Background(), nb.Add(70*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if issuer.called != 0 {
+			t.Fatal("status must not issue")
+		}
+		if status.State != "near_expiry" || status.Subject != "alt-backend" || status.Serial != "abc" {
+			t.Fatalf("status: %+v", status)
+		}
+		if want := nb.Add(66 * time.Hour); !status.RenewAt.Equal(want) {
+			t.Fatalf("renew_at=%s want %s", status.RenewAt, want)
+		}
+	})
+
+	t.Run("missing and corrupt are states", func(t *testing.T) {
+		for _, tc := range []struct {
+			err  error
+			want string
+		}{
+			{domain.ErrCertNotFound, "missing"},
+			{domain.ErrCertParseFailed, "corrupt"},
+		} {
+			r := newRotator(&fakeLoader{err: tc.err}, &fakeIssuer{}, &fakeWriter{}, &fakeObs{})
+			status, err := r.Status(context.Background(), nb)
+			if err != nil || status.State != tc.want {
+				t.Fatalf("state=%s err=%v, want %s", status.State, err, tc.want)
+			}
+		}
+	})
+
+	t.Run("read error fails", func(t *testing.T) {
+		r := newRotator(&fakeLoader{err: errors.New("permission denied")}, &fakeIssuer{}, &fakeWriter{}, &fakeObs{})
+		if _, err := r.Status(context.Background(), nb); err == nil {
+			t.Fatal("want error")
+		}
+	})
+}
@@ -0,0 +1,29 @@
+-- Migration: add article_thumbnails
+-- Created: 2026-10-16
+-- Description: Lead-image thumbnail queue and results per article.
+--   Article sync enqueues the extracted lead image (og:image or first content
+--   image); the thumbnail job downloads, resizes, stores it in object storage,
+--   and records the per-size URLs so frontends stop hotlinking origin images.
+
+CREATE TABLE IF NOT EXISTS article_thumbnails (
+    article_id TEXT PRIMARY KEY,
+    source_url TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    thumbnails JSONB NOT NULL DEFAULT '{}'::jsonb,
+    error_message TEXT,
+    retry_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_thumbnails_status ON article_thumbnails(status, updated_at) WHERE status IN ('pending', 'running');
+
+COMMENT ON TABLE article_thumbnails IS 'Lead-image thumbnails per article (queue + stored object URLs)';
+COMMENT ON COLUMN article_thumbnails.article_id IS 'Article ID (TEXT) the lead image belongs to';
+COMMENT ON COLUMN article_thumbnails.source_url IS 'Origin URL of the lead image (og:image or first content image)';
+COMMENT ON COLUMN article_thumbnails.status IS 'Processing status: pending, running, completed, failed';
+COMMENT ON COLUMN article_thumbnails.thumbnails IS 'JSON object mapping thumbnail size name to stored object URL';
+COMMENT ON COLUMN article_thumbnails.error_message IS 'Last processing error (populated on retry or failure)';
+COMMENT ON COLUMN article_thumbnails.retry_count IS 'Number of failed processing attempts';
+COMMENT ON COLUMN article_thumbnails.created_at IS 'Timestamp when the lead image was enqueued';
+COMMENT ON COLUMN article_thumbnails.updated_at IS 'Timestamp of the last status change';
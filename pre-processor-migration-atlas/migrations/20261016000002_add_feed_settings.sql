@@ -0,0 +1,27 @@
+-- Migration: add feed_settings
+-- Created: 2026-10-16
+-- Description: Per-feed processing overrides. Each nullable column overrides
+--   one global setting for a single feed; NULL means the feed inherits the
+--   global value. Read by the summarize queue worker, the quality checker and
+--   the feed processor.
+
+CREATE TABLE IF NOT EXISTS feed_settings (
+    feed_id TEXT PRIMARY KEY,
+    batch_size INT CHECK (batch_size > 0),
+    summarization_enabled BOOLEAN,
+    quality_strictness VARCHAR(20) CHECK (quality_strictness IN ('lenient', 'standard', 'strict')),
+    language_override VARCHAR(8),
+    fetch_interval_seconds INT CHECK (fetch_interval_seconds >= 60),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feed_settings IS 'Per-feed processing overrides (NULL columns inherit the global setting)';
+COMMENT ON COLUMN feed_settings.feed_id IS 'alt-backend feed ID (TEXT) the overrides apply to';
+COMMENT ON COLUMN feed_settings.batch_size IS 'Maximum articles from this feed enqueued for summarization per batch';
+COMMENT ON COLUMN feed_settings.summarization_enabled IS 'Whether articles from this feed are summarized';
+COMMENT ON COLUMN feed_settings.quality_strictness IS 'Quality check strictness: lenient, standard, strict';
+COMMENT ON COLUMN feed_settings.language_override IS 'Language code used for this feed instead of per-article detection';
+COMMENT ON COLUMN feed_settings.fetch_interval_seconds IS 'Minimum seconds between fetches of this feed';
+COMMENT ON COLUMN feed_settings.created_at IS 'Timestamp when the overrides were first set';
+COMMENT ON COLUMN feed_settings.updated_at IS 'Timestamp of the last change';
@@ -0,0 +1,35 @@
+-- Migration: add inoreader_subscription_health
+-- Created: 2026-10-16
+-- Description: Per-subscription fetch failure tracking for pre-processor-sidecar.
+--   Only failures attributable to the subscription itself are counted
+--   (404 stream, unsubscribed upstream, unparsable stream contents); token,
+--   rate-limit and 5xx errors are not. After a configurable number of
+--   consecutive failures the subscription is paused and skipped by every
+--   fetch path until an operator resumes it via the Admin API. A row only
+--   exists once a subscription has failed at least once.
+
+CREATE TABLE IF NOT EXISTS inoreader_subscription_health (
+    subscription_id UUID PRIMARY KEY REFERENCES inoreader_subscriptions(id) ON DELETE CASCADE,
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    total_failures INT NOT NULL DEFAULT 0,
+    last_error_kind VARCHAR(20) CHECK (last_error_kind IN ('not_found', 'unsubscribed', 'parse_error')),
+    last_error TEXT,
+    last_failure_at TIMESTAMPTZ,
+    last_success_at TIMESTAMPTZ,
+    paused_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inoreader_subscription_health_paused_at
+    ON inoreader_subscription_health(paused_at) WHERE paused_at IS NOT NULL;
+
+COMMENT ON TABLE inoreader_subscription_health IS 'Per-subscription fetch failure tracking and auto-pause state';
+COMMENT ON COLUMN inoreader_subscription_health.subscription_id IS 'inoreader_subscriptions.id the state belongs to';
+COMMENT ON COLUMN inoreader_subscription_health.consecutive_failures IS 'Failures since the last successful fetch or resume';
+COMMENT ON COLUMN inoreader_subscription_health.total_failures IS 'Failures over the lifetime of the subscription';
+COMMENT ON COLUMN inoreader_subscription_health.last_error_kind IS 'Classified cause of the last failure: not_found, unsubscribed, parse_error';
+COMMENT ON COLUMN inoreader_subscription_health.last_error IS 'Error message of the last failure';
+COMMENT ON COLUMN inoreader_subscription_health.last_failure_at IS 'Timestamp of the last failure';
+COMMENT ON COLUMN inoreader_subscription_health.last_success_at IS 'Timestamp of the last successful fetch after a failure';
+COMMENT ON COLUMN inoreader_subscription_health.paused_at IS 'When the subscription was auto-paused; NULL while active';
+COMMENT ON COLUMN inoreader_subscription_health.updated_at IS 'Timestamp of the last change';
@@ -0,0 +1,32 @@
+-- Migration: add summary_review_queue
+-- Created: 2026-10-16
+-- Description: Human review queue for summaries picked by quality check
+--   sampling. The quality checker only judges a sample of summaries (plus
+--   every low-confidence one); those that pass the LLM judge are queued here
+--   for a reviewer to approve or reject. A rejection deletes the summary and
+--   re-enqueues the article for summarization. One row per article: a
+--   rejected article whose new summary is sampled again is reset to pending.
+
+CREATE TABLE IF NOT EXISTS summary_review_queue (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id TEXT NOT NULL UNIQUE,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('sampled', 'low_confidence')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewer TEXT,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_summary_review_queue_status_created_at
+    ON summary_review_queue(status, created_at);
+
+COMMENT ON TABLE summary_review_queue IS 'Sampled summaries awaiting or given a human quality review';
+COMMENT ON COLUMN summary_review_queue.id IS 'Review item ID used by the review API';
+COMMENT ON COLUMN summary_review_queue.article_id IS 'Article ID (TEXT) whose summary is reviewed';
+COMMENT ON COLUMN summary_review_queue.reason IS 'Why the summary was queued: sampled, low_confidence';
+COMMENT ON COLUMN summary_review_queue.status IS 'Review status: pending, approved, rejected';
+COMMENT ON COLUMN summary_review_queue.reviewer IS 'Who approved or rejected the summary';
+COMMENT ON COLUMN summary_review_queue.note IS 'Reviewer note, e.g. why the summary was rejected';
+COMMENT ON COLUMN summary_review_queue.created_at IS 'Timestamp when the summary was queued for review';
+COMMENT ON COLUMN summary_review_queue.decided_at IS 'Timestamp of the approval or rejection; NULL while pending';
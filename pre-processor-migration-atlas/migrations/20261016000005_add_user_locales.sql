@@ -0,0 +1,16 @@
+-- Migration: add user_locales
+-- Created: 2026-10-16
+-- Description: Preferred locale per user. The summarize queue worker writes
+--   a user's article summaries in this locale when news-creator has prompts
+--   for it, and falls back to Japanese otherwise.
+
+CREATE TABLE IF NOT EXISTS user_locales (
+    user_id TEXT PRIMARY KEY,
+    locale VARCHAR(16) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE user_locales IS 'Preferred locale per user, used to pick the summary language';
+COMMENT ON COLUMN user_locales.user_id IS 'alt-backend user ID (TEXT)';
+COMMENT ON COLUMN user_locales.locale IS 'BCP 47 locale, e.g. ja or en-US';
+COMMENT ON COLUMN user_locales.updated_at IS 'Timestamp of the last change';
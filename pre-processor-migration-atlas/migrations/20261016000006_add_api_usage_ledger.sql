@@ -0,0 +1,71 @@
+-- Migration: add inoreader_api_calls and inoreader_api_usage_daily
+-- Created: 2026-10-16
+-- Description: Structured ledger of every Inoreader API request made by
+--   pre-processor-sidecar. Each call records the endpoint (stream IDs
+--   stripped), its zone and cost, the HTTP status and the quota headers
+--   Inoreader returned. The daily rollup is maintained in the same statement
+--   as the insert, so the Admin API can chart usage history without scanning
+--   the ledger. Days are UTC. At ~100 calls/day the ledger is kept in full.
+
+CREATE TABLE IF NOT EXISTS inoreader_api_calls (
+    id BIGSERIAL PRIMARY KEY,
+    called_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    endpoint TEXT NOT NULL,
+    zone SMALLINT NOT NULL CHECK (zone IN (1, 2)),
+    cost INT NOT NULL DEFAULT 1 CHECK (cost >= 0),
+    status_code INT,
+    duration_ms INT NOT NULL DEFAULT 0,
+    zone1_usage INT,
+    zone1_limit INT,
+    zone2_usage INT,
+    zone2_limit INT,
+    reset_after_seconds INT,
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_inoreader_api_calls_called_at
+    ON inoreader_api_calls(called_at DESC);
+
+COMMENT ON TABLE inoreader_api_calls IS 'Ledger of every Inoreader API request made by pre-processor-sidecar';
+COMMENT ON COLUMN inoreader_api_calls.id IS 'Ledger sequence number';
+COMMENT ON COLUMN inoreader_api_calls.called_at IS 'When the request was sent';
+COMMENT ON COLUMN inoreader_api_calls.endpoint IS 'API path without query string or stream ID, e.g. /stream/contents';
+COMMENT ON COLUMN inoreader_api_calls.zone IS 'Inoreader rate limit zone: 1 (read) or 2 (write)';
+COMMENT ON COLUMN inoreader_api_calls.cost IS 'Quota units consumed; 0 when the request never reached Inoreader';
+COMMENT ON COLUMN inoreader_api_calls.status_code IS 'HTTP status; NULL when no response was received';
+COMMENT ON COLUMN inoreader_api_calls.duration_ms IS 'Request round-trip time in milliseconds';
+COMMENT ON COLUMN inoreader_api_calls.zone1_usage IS 'X-Reader-Zone1-Usage response header';
+COMMENT ON COLUMN inoreader_api_calls.zone1_limit IS 'X-Reader-Zone1-Limit response header';
+COMMENT ON COLUMN inoreader_api_calls.zone2_usage IS 'X-Reader-Zone2-Usage response header';
+COMMENT ON COLUMN inoreader_api_calls.zone2_limit IS 'X-Reader-Zone2-Limit response header';
+COMMENT ON COLUMN inoreader_api_calls.reset_after_seconds IS 'X-Reader-Limits-Reset-After response header';
+COMMENT ON COLUMN inoreader_api_calls.error IS 'Error message for failed requests';
+
+CREATE TABLE IF NOT EXISTS inoreader_api_usage_daily (
+    usage_date DATE PRIMARY KEY,
+    zone1_calls INT NOT NULL DEFAULT 0,
+    zone2_calls INT NOT NULL DEFAULT 0,
+    zone1_cost INT NOT NULL DEFAULT 0,
+    zone2_cost INT NOT NULL DEFAULT 0,
+    error_calls INT NOT NULL DEFAULT 0,
+    rate_limited_calls INT NOT NULL DEFAULT 0,
+    peak_zone1_usage INT,
+    peak_zone2_usage INT,
+    zone1_limit INT,
+    zone2_limit INT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE inoreader_api_usage_daily IS 'Per-day (UTC) rollup of inoreader_api_calls';
+COMMENT ON COLUMN inoreader_api_usage_daily.usage_date IS 'UTC day the calls were made';
+COMMENT ON COLUMN inoreader_api_usage_daily.zone1_calls IS 'Zone 1 requests made';
+COMMENT ON COLUMN inoreader_api_usage_daily.zone2_calls IS 'Zone 2 requests made';
+COMMENT ON COLUMN inoreader_api_usage_daily.zone1_cost IS 'Zone 1 quota units consumed';
+COMMENT ON COLUMN inoreader_api_usage_daily.zone2_cost IS 'Zone 2 quota units consumed';
+COMMENT ON COLUMN inoreader_api_usage_daily.error_calls IS 'Requests that failed or answered non-2xx';
+COMMENT ON COLUMN inoreader_api_usage_daily.rate_limited_calls IS 'Requests answered 429';
+COMMENT ON COLUMN inoreader_api_usage_daily.peak_zone1_usage IS 'Highest X-Reader-Zone1-Usage seen that day';
+COMMENT ON COLUMN inoreader_api_usage_daily.peak_zone2_usage IS 'Highest X-Reader-Zone2-Usage seen that day';
+COMMENT ON COLUMN inoreader_api_usage_daily.zone1_limit IS 'Last X-Reader-Zone1-Limit seen that day';
+COMMENT ON COLUMN inoreader_api_usage_daily.zone2_limit IS 'Last X-Reader-Zone2-Limit seen that day';
+COMMENT ON COLUMN inoreader_api_usage_daily.updated_at IS 'Timestamp of the last call rolled up';
@@ -0,0 +1,26 @@
+-- Migration: add article_media
+-- Created: 2026-10-16
+-- Description: Podcast and video enclosures. The sidecar keeps the
+--   enclosure list Inoreader returns for each item; article sync parses it
+--   into audio/video media per article so the frontend player has URLs,
+--   MIME types and durations, and so media-only entries (no show notes to
+--   speak of) are not sent to news-creator for summarization.
+
+ALTER TABLE inoreader_articles ADD COLUMN IF NOT EXISTS enclosures JSONB;
+
+COMMENT ON COLUMN inoreader_articles.enclosures IS 'Enclosure list from the Inoreader item (href, type, length), as returned';
+
+CREATE TABLE IF NOT EXISTS article_media (
+    article_id TEXT PRIMARY KEY,
+    media JSONB NOT NULL DEFAULT '[]'::jsonb,
+    media_only BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE article_media IS 'Audio and video enclosures per article, for the frontend player';
+COMMENT ON COLUMN article_media.article_id IS 'Article ID (TEXT) the media belongs to';
+COMMENT ON COLUMN article_media.media IS 'JSON array of {url, mime_type, kind, length_bytes, duration_seconds}';
+COMMENT ON COLUMN article_media.media_only IS 'Whether the article has too little text besides its media to summarize';
+COMMENT ON COLUMN article_media.created_at IS 'Timestamp when the media was first recorded';
+COMMENT ON COLUMN article_media.updated_at IS 'Timestamp of the last re-sync';
@@ -1,6 +1,9 @@
 # Pre-Processor DB Schema
 # Tables: inoreader_subscriptions, inoreader_articles, sync_state,
-#          api_usage_tracking, summarize_job_queue
+#          api_usage_tracking, summarize_job_queue, article_thumbnails,
+#          feed_settings, inoreader_subscription_health, summary_review_queue,
+#          user_locales, inoreader_api_calls, inoreader_api_usage_daily,
+#          article_media
 
 table "inoreader_subscriptions" {
   schema  = schema.public
@@ -131,6 +134,11 @@ table "inoreader_articles" {
     default = "html"
     comment = "Content type (html, html_rtl, text)"
   }
+  column "enclosures" {
+    null    = true
+    type    = jsonb
+    comment = "Enclosure list from the Inoreader item (href, type, length), as returned"
+  }
   primary_key {
     columns = [column.id]
   }
@@ -379,6 +387,481 @@ table "summarize_job_queue" {
   }
 }
 
+table "article_thumbnails" {
+  schema  = schema.public
+  comment = "Lead-image thumbnails per article (queue + stored object URLs)"
+  column "article_id" {
+    null    = false
+    type    = text
+    comment = "Article ID (TEXT) the lead image belongs to"
+  }
+  column "source_url" {
+    null    = false
+    type    = text
+    comment = "Origin URL of the lead image (og:image or first content image)"
+  }
+  column "status" {
+    null    = false
+    type    = character_varying(20)
+    default = "pending"
+    comment = "Processing status: pending, running, completed, failed"
+  }
+  column "thumbnails" {
+    null    = false
+    type    = jsonb
+    default = sql("'{}'::jsonb")
+    comment = "JSON object mapping thumbnail size name to stored object URL"
+  }
+  column "error_message" {
+    null    = true
+    type    = text
+    comment = "Last processing error (populated on retry or failure)"
+  }
+  column "retry_count" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Number of failed processing attempts"
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp when the lead image was enqueued"
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp of the last status change"
+  }
+  primary_key {
+    columns = [column.article_id]
+  }
+  index "idx_article_thumbnails_status" {
+    columns = [column.status, column.updated_at]
+    where   = "((status)::text = ANY (ARRAY[('pending'::character varying)::text, ('running'::character varying)::text]))"
+  }
+  check "article_thumbnails_status_check" {
+    expr = "((status)::text = ANY (ARRAY[('pending'::character varying)::text, ('running'::character varying)::text, ('completed'::character varying)::text, ('failed'::character varying)::text]))"
+  }
+}
+
+table "feed_settings" {
+  schema  = schema.public
+  comment = "Per-feed processing overrides (NULL columns inherit the global setting)"
+  column "feed_id" {
+    null    = false
+    type    = text
+    comment = "alt-backend feed ID (TEXT) the overrides apply to"
+  }
+  column "batch_size" {
+    null    = true
+    type    = integer
+    comment = "Maximum articles from this feed enqueued for summarization per batch"
+  }
+  column "summarization_enabled" {
+    null    = true
+    type    = boolean
+    comment = "Whether articles from this feed are summarized"
+  }
+  column "quality_strictness" {
+    null    = true
+    type    = character_varying(20)
+    comment = "Quality check strictness: lenient, standard, strict"
+  }
+  column "language_override" {
+    null    = true
+    type    = character_varying(8)
+    comment = "Language code used for this feed instead of per-article detection"
+  }
+  column "fetch_interval_seconds" {
+    null    = true
+    type    = integer
+    comment = "Minimum seconds between fetches of this feed"
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp when the overrides were first set"
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp of the last change"
+  }
+  primary_key {
+    columns = [column.feed_id]
+  }
+  check "feed_settings_batch_size_check" {
+    expr = "(batch_size > 0)"
+  }
+  check "feed_settings_quality_strictness_check" {
+    expr = "((quality_strictness)::text = ANY (ARRAY[('lenient'::character varying)::text, ('standard'::character varying)::text, ('strict'::character varying)::text]))"
+  }
+  check "feed_settings_fetch_interval_seconds_check" {
+    expr = "(fetch_interval_seconds >= 60)"
+  }
+}
+
+table "inoreader_subscription_health" {
+  schema  = schema.public
+  comment = "Per-subscription fetch failure tracking and auto-pause state"
+  column "subscription_id" {
+    null    = false
+    type    = uuid
+    comment = "inoreader_subscriptions.id the state belongs to"
+  }
+  column "consecutive_failures" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Failures since the last successful fetch or resume"
+  }
+  column "total_failures" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Failures over the lifetime of the subscription"
+  }
+  column "last_error_kind" {
+    null    = true
+    type    = character_varying(20)
+    comment = "Classified cause of the last failure: not_found, unsubscribed, parse_error"
+  }
+  column "last_error" {
+    null    = true
+    type    = text
+    comment = "Error message of the last failure"
+  }
+  column "last_failure_at" {
+    null    = true
+    type    = timestamptz
+    comment = "Timestamp of the last failure"
+  }
+  column "last_success_at" {
+    null    = true
+    type    = timestamptz
+    comment = "Timestamp of the last successful fetch after a failure"
+  }
+  column "paused_at" {
+    null    = true
+    type    = timestamptz
+    comment = "When the subscription was auto-paused; NULL while active"
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp of the last change"
+  }
+  primary_key {
+    columns = [column.subscription_id]
+  }
+  foreign_key "inoreader_subscription_health_subscription_id_fkey" {
+    columns     = [column.subscription_id]
+    ref_columns = [table.inoreader_subscriptions.column.id]
+    on_update   = NO_ACTION
+    on_delete   = CASCADE
+  }
+  index "idx_inoreader_subscription_health_paused_at" {
+    columns = [column.paused_at]
+    where   = "(paused_at IS NOT NULL)"
+  }
+  check "inoreader_subscription_health_last_error_kind_check" {
+    expr = "((last_error_kind)::text = ANY (ARRAY[('not_found'::character varying)::text, ('unsubscribed'::character varying)::text, ('parse_error'::character varying)::text]))"
+  }
+}
+
+table "summary_review_queue" {
+  schema  = schema.public
+  comment = "Sampled summaries awaiting or given a human quality review"
+  column "id" {
+    null    = false
+    type    = uuid
+    default = sql("gen_random_uuid()")
+    comment = "Review item ID used by the review API"
+  }
+  column "article_id" {
+    null    = false
+    type    = text
+    comment = "Article ID (TEXT) whose summary is reviewed"
+  }
+  column "reason" {
+    null    = false
+    type    = character_varying(20)
+    comment = "Why the summary was queued: sampled, low_confidence"
+  }
+  column "status" {
+    null    = false
+    type    = character_varying(20)
+    default = "pending"
+    comment = "Review status: pending, approved, rejected"
+  }
+  column "reviewer" {
+    null    = true
+    type    = text
+    comment = "Who approved or rejected the summary"
+  }
+  column "note" {
+    null    = true
+    type    = text
+    comment = "Reviewer note, e.g. why the summary was rejected"
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp when the summary was queued for review"
+  }
+  column "decided_at" {
+    null    = true
+    type    = timestamptz
+    comment = "Timestamp of the approval or rejection; NULL while pending"
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_summary_review_queue_status_created_at" {
+    columns = [column.status, column.created_at]
+  }
+  unique "summary_review_queue_article_id_key" {
+    columns = [column.article_id]
+  }
+  check "summary_review_queue_reason_check" {
+    expr = "((reason)::text = ANY (ARRAY[('sampled'::character varying)::text, ('low_confidence'::character varying)::text]))"
+  }
+  check "summary_review_queue_status_check" {
+    expr = "((status)::text = ANY (ARRAY[('pending'::character varying)::text, ('approved'::character varying)::text, ('rejected'::character varying)::text]))"
+  }
+}
+
+table "user_locales" {
+  schema  = schema.public
+  comment = "Preferred locale per user, used to pick the summary language"
+  column "user_id" {
+    null    = false
+    type    = text
+    comment = "alt-backend user ID (TEXT)"
+  }
+  column "locale" {
+    null    = false
+    type    = character_varying(16)
+    comment = "BCP 47 locale, e.g. ja or en-US"
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp of the last change"
+  }
+  primary_key {
+    columns = [column.user_id]
+  }
+}
+
+table "inoreader_api_calls" {
+  schema  = schema.public
+  comment = "Ledger of every Inoreader API request made by pre-processor-sidecar"
+  column "id" {
+    null    = false
+    type    = bigserial
+    comment = "Ledger sequence number"
+  }
+  column "called_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "When the request was sent"
+  }
+  column "endpoint" {
+    null    = false
+    type    = text
+    comment = "API path without query string or stream ID, e.g. /stream/contents"
+  }
+  column "zone" {
+    null    = false
+    type    = smallint
+    comment = "Inoreader rate limit zone: 1 (read) or 2 (write)"
+  }
+  column "cost" {
+    null    = false
+    type    = integer
+    default = 1
+    comment = "Quota units consumed; 0 when the request never reached Inoreader"
+  }
+  column "status_code" {
+    null    = true
+    type    = integer
+    comment = "HTTP status; NULL when no response was received"
+  }
+  column "duration_ms" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Request round-trip time in milliseconds"
+  }
+  column "zone1_usage" {
+    null    = true
+    type    = integer
+    comment = "X-Reader-Zone1-Usage response header"
+  }
+  column "zone1_limit" {
+    null    = true
+    type    = integer
+    comment = "X-Reader-Zone1-Limit response header"
+  }
+  column "zone2_usage" {
+    null    = true
+    type    = integer
+    comment = "X-Reader-Zone2-Usage response header"
+  }
+  column "zone2_limit" {
+    null    = true
+    type    = integer
+    comment = "X-Reader-Zone2-Limit response header"
+  }
+  column "reset_after_seconds" {
+    null    = true
+    type    = integer
+    comment = "X-Reader-Limits-Reset-After response header"
+  }
+  column "error" {
+    null    = true
+    type    = text
+    comment = "Error message for failed requests"
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "idx_inoreader_api_calls_called_at" {
+    on {
+      desc   = true
+      column = column.called_at
+    }
+  }
+  check "inoreader_api_calls_zone_check" {
+    expr = "(zone = ANY (ARRAY[1, 2]))"
+  }
+  check "inoreader_api_calls_cost_check" {
+    expr = "(cost >= 0)"
+  }
+}
+
+table "inoreader_api_usage_daily" {
+  schema  = schema.public
+  comment = "Per-day (UTC) rollup of inoreader_api_calls"
+  column "usage_date" {
+    null    = false
+    type    = date
+    comment = "UTC day the calls were made"
+  }
+  column "zone1_calls" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Zone 1 requests made"
+  }
+  column "zone2_calls" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Zone 2 requests made"
+  }
+  column "zone1_cost" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Zone 1 quota units consumed"
+  }
+  column "zone2_cost" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Zone 2 quota units consumed"
+  }
+  column "error_calls" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Requests that failed or answered non-2xx"
+  }
+  column "rate_limited_calls" {
+    null    = false
+    type    = integer
+    default = 0
+    comment = "Requests answered 429"
+  }
+  column "peak_zone1_usage" {
+    null    = true
+    type    = integer
+    comment = "Highest X-Reader-Zone1-Usage seen that day"
+  }
+  column "peak_zone2_usage" {
+    null    = true
+    type    = integer
+    comment = "Highest X-Reader-Zone2-Usage seen that day"
+  }
+  column "zone1_limit" {
+    null    = true
+    type    = integer
+    comment = "Last X-Reader-Zone1-Limit seen that day"
+  }
+  column "zone2_limit" {
+    null    = true
+    type    = integer
+    comment = "Last X-Reader-Zone2-Limit seen that day"
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp of the last call rolled up"
+  }
+  primary_key {
+    columns = [column.usage_date]
+  }
+}
+
+table "article_media" {
+  schema  = schema.public
+  comment = "Audio and video enclosures per article, for the frontend player"
+  column "article_id" {
+    null    = false
+    type    = text
+    comment = "Article ID (TEXT) the media belongs to"
+  }
+  column "media" {
+    null    = false
+    type    = jsonb
+    default = sql("'[]'::jsonb")
+    comment = "JSON array of {url, mime_type, kind, length_bytes, duration_seconds}"
+  }
+  column "media_only" {
+    null    = false
+    type    = boolean
+    default = false
+    comment = "Whether the article has too little text besides its media to summarize"
+  }
+  column "created_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp when the media was first recorded"
+  }
+  column "updated_at" {
+    null    = false
+    type    = timestamptz
+    default = sql("now()")
+    comment = "Timestamp of the last re-sync"
+  }
+  primary_key {
+    columns = [column.article_id]
+  }
+}
+
 schema "public" {
   comment = "standard public schema"
 }
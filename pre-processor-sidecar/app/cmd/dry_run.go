@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"pre-processor-sidecar/config"
+	"pre-processor-sidecar/driver"
+	"pre-processor-sidecar/repository"
+	"pre-processor-sidecar/service"
+	"pre-processor-sidecar/utils"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dryRunReport is the JSON document --dry-run prints to stdout.
+type dryRunReport struct {
+	SubscriptionSync *service.SubscriptionSyncDryRunReport `json:"subscription_sync"`
+	ArticleFetch     *service.ArticleFetchDryRunReport     `json:"article_fetch,omitempty"`
+}
+
+// performDryRun runs a subscription sync — and, when streamID is set, an
+// article fetch for that stream — against the Inoreader API, then prints
+// the writes the real run would make. Postgres is only read: the Inoreader
+// service gets no API usage repository, so even quota bookkeeping is left
+// to the scheduler.
+func performDryRun(ctx context.Context, cfg *config.Config, logger *slog.Logger, tokenProvider service.TokenProvider, streamID string) error {
+	pool, err := pgxpool.New(ctx, cfg.Database.PostgresURL())
+	if err != nil {
+		return fmt.Errorf("failed to open database connection: %w", err)
+	}
+	defer pool.Close()
+
+	if err := pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	articleRepo := repository.NewPostgreSQLArticleRepository(pool, logger)
+	syncStateRepo := repository.NewPostgreSQLSyncStateRepository(pool, logger)
+	subscriptionRepo := repository.NewPostgreSQLSubscriptionRepository(pool, logger)
+
+	oauth2Client := driver.NewOAuth2Client(cfg.OAuth2.ClientID, cfg.OAuth2.ClientSecret, cfg.OAuth2.BaseURL, logger)
+	inoreaderClient := service.NewInoreaderClient(oauth2Client, logger, utils.NewSanitizer())
+	inoreaderService := service.NewInoreaderService(inoreaderClient, nil, tokenProvider, logger)
+
+	logger.Warn("Dry-run mode: Inoreader API calls are real and count against the daily quota, but nothing is written to Postgres",
+		"stream_id", streamID)
+
+	var report dryRunReport
+
+	syncService := service.NewSubscriptionSyncService(inoreaderService, subscriptionRepo, syncStateRepo, logger)
+	report.SubscriptionSync, err = syncService.DryRunSyncSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("subscription sync dry-run failed: %w", err)
+	}
+
+	if streamID != "" {
+		fetchService := service.NewArticleFetchService(inoreaderService, articleRepo, syncStateRepo, subscriptionRepo, logger)
+		report.ArticleFetch, err = fetchService.DryRunFetchArticles(ctx, streamID)
+		if err != nil {
+			return fmt.Errorf("article fetch dry-run failed: %w", err)
+		}
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(report)
+}
@@ -70,6 +70,8 @@ func main() {
 	healthCheck := flag.Bool("health-check", false, "Perform health check and exit")
 	oauth2Init := flag.Bool("oauth2-init", false, "Initialize OAuth2 tokens and exit")
 	scheduleMode := flag.Bool("schedule-mode", false, "Enable dual schedule processing mode")
+	dryRun := flag.Bool("dry-run", false, "Run subscription sync against Inoreader, print the planned DB writes and exit without writing")
+	dryRunStream := flag.String("dry-run-stream", "", "With --dry-run, also preview an article fetch for this Inoreader stream ID")
 	flag.Parse()
 
 	// Setup structured logging
@@ -152,6 +154,14 @@ func main() {
 		service: remoteTokenService,
 	}
 
+	if *dryRun {
+		if err := performDryRun(ctx, cfg, logger, remoteTokenService, *dryRunStream); err != nil {
+			logger.Error("Dry run failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Run in continuous scheduling mode with new token system
 	if *scheduleMode {
 		logger.Info("Starting in schedule mode as requested by flag")
@@ -331,12 +341,18 @@ func runScheduleMode(ctx context.Context, cfg *config.Config, logger *slog.Logge
 	articleRepo := repository.NewPostgreSQLArticleRepository(pool, logger)
 	syncStateRepo := repository.NewPostgreSQLSyncStateRepository(pool, logger)
 	subscriptionRepo := repository.NewPostgreSQLSubscriptionRepository(pool, logger)
+	subscriptionHealthRepo := repository.NewPostgreSQLSubscriptionHealthRepository(pool, logger)
+	apiUsageLedgerRepo := repository.NewPostgreSQLAPIUsageLedgerRepository(pool, logger)
 
 	// OAuth2クライアントの作成（Enhanced Token Serviceと同じ設定）
 	clientID := cfg.OAuth2.ClientID
 	clientSecret := cfg.OAuth2.ClientSecret
 	oauth2Client := driver.NewOAuth2Client(clientID, clientSecret, cfg.OAuth2.BaseURL, logger)
 	// Note: Do NOT call SetHTTPClient here - OAuth2Client already has proxy disabled for token refresh
+	// Every authenticated API request is written to the inoreader_api_calls
+	// ledger and feeds the per-zone budgets of the rate limit manager.
+	rateLimitManager := service.NewRateLimitManager(nil, logger)
+	oauth2Client.SetUsageRecorder(driver.APICallRecorders{apiUsageLedgerRepo, rateLimitManager})
 
 	// Initialize enhanced token management service
 	tokenManagementService := service.NewTokenManagementService(tokenRepo, oauth2Client, logger)
@@ -368,7 +384,6 @@ func runScheduleMode(ctx context.Context, cfg *config.Config, logger *slog.Logge
 	inoreaderService := service.NewInoreaderService(inoreaderClient, apiUsageRepo, tokenProvider, logger)
 
 	subscriptionSyncService := service.NewSubscriptionSyncService(inoreaderService, subscriptionRepo, syncStateRepo, logger)
-	rateLimitManager := service.NewRateLimitManager(nil, logger)
 
 	// Initialize service layer with rotation support
 	articleFetchService := service.NewArticleFetchService(
@@ -378,6 +393,9 @@ func runScheduleMode(ctx context.Context, cfg *config.Config, logger *slog.Logge
 		subscriptionRepo,
 		logger,
 	)
+	// Subscriptions failing on their own (404, unsubscribed, unparsable) are
+	// paused after SUBSCRIPTION_PAUSE_THRESHOLD consecutive failures.
+	articleFetchService.SetSubscriptionHealthTracking(subscriptionHealthRepo, cfg.SubscriptionPauseThreshold)
 
 	// Initialize handler layer (keep legacy handler for subscription sync)
 	articleFetchHandler := handler.NewArticleFetchHandler(
@@ -406,6 +424,11 @@ func runScheduleMode(ctx context.Context, cfg *config.Config, logger *slog.Logge
 		logger,
 	)
 
+	// Mark-as-read sync spends the zone 2 (write) budget on its own ticker,
+	// apart from the zone 1 article fetches.
+	readStateSync := service.NewReadStateSync(oauth2Client, tokenProvider, rateLimitManager, logger)
+	inoreaderScheduler.SetReadStateSync(readStateSync)
+
 	// Add job result callback for monitoring
 	scheduleHandler.AddJobResultCallback(func(result *handler.JobResult) {
 		logger.Info("Scheduled job completed",
@@ -474,6 +497,39 @@ func runScheduleMode(ctx context.Context, cfg *config.Config, logger *slog.Logge
 		}
 	}))
 
+	// Fetch windows (quiet hours) can be read and replaced at runtime; the
+	// scheduler reschedules its next fetch as soon as a new schedule lands.
+	fetchWindowHandler := handler.NewFetchWindowHandler(inoreaderScheduler, healthRealClock{}, cfg.RateLimit.DailyLimit, logger)
+	adminMux.HandleFunc("/admin/schedule/fetch-windows", adminAPIHandler.RequireAdmin("/admin/schedule/fetch-windows", fetchWindowHandler.HandleFetchWindows))
+
+	// Dry-run variants of the triggers: same Inoreader calls, no Postgres
+	// writes, and a report of what would have been inserted/updated.
+	dryRunHandler := handler.NewDryRunHandler(articleFetchService, subscriptionSyncService, logger)
+	adminMux.HandleFunc("/admin/dry-run/article-fetch", adminAPIHandler.RequireAdmin("/admin/dry-run/article-fetch", dryRunHandler.HandleArticleFetch))
+	adminMux.HandleFunc("/admin/dry-run/subscription-sync", adminAPIHandler.RequireAdmin("/admin/dry-run/subscription-sync", dryRunHandler.HandleSubscriptionSync))
+
+	// Auto-paused subscriptions can be listed and put back into rotation.
+	subscriptionHealthHandler := handler.NewSubscriptionHealthHandler(subscriptionHealthRepo, logger)
+	adminMux.HandleFunc("/admin/subscriptions/paused", adminAPIHandler.RequireAdmin("/admin/subscriptions/paused", subscriptionHealthHandler.HandlePaused))
+	adminMux.HandleFunc("/admin/subscriptions/resume", adminAPIHandler.RequireAdmin("/admin/subscriptions/resume", subscriptionHealthHandler.HandleResume))
+
+	// Daily Inoreader API usage history from the call ledger.
+	apiUsageHandler := handler.NewAPIUsageHandler(apiUsageLedgerRepo, logger)
+	adminMux.HandleFunc("/admin/api-usage/history", adminAPIHandler.RequireAdmin("/admin/api-usage/history", apiUsageHandler.HandleHistory))
+
+	// Items to mark read in Inoreader, sent by the read-state sync.
+	readStateHandler := handler.NewReadStateHandler(readStateSync, rateLimitManager, logger)
+	adminMux.HandleFunc("/admin/read-state/mark-read", adminAPIHandler.RequireAdmin("/admin/read-state/mark-read", readStateHandler.HandleMarkRead))
+
+	// Push ingestion: Inoreader rule webhooks and WebSub hubs call /push,
+	// which authenticates by HMAC signature instead of a service account
+	// token, so it sits outside RequireAdmin.
+	if cfg.Push.Enabled {
+		pushHandler := handler.NewPushHandler(inoreaderScheduler, cfg.Push.Secret, logger)
+		adminMux.HandleFunc("/push", pushHandler.HandlePush)
+		logger.Info("Push ingestion endpoint enabled", "path", "/push")
+	}
+
 	adminMux.HandleFunc("/admin/trigger/subscription-sync", adminAPIHandler.RequireAdmin("/admin/trigger/subscription-sync", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -534,8 +590,18 @@ func runScheduleMode(ctx context.Context, cfg *config.Config, logger *slog.Logge
 		"article_fetch_interval", "30m",
 		"admin_api_address", ":8080")
 
-	// Use Default Config (16m fetch, 24h refresh)
+	// Use Default Config (16m fetch, 24h refresh); FETCH_WINDOWS, when set,
+	// replaces the fixed fetch interval with per-window intervals.
 	schedulerConfig := scheduler.DefaultConfig()
+	schedulerConfig.FetchSchedule = cfg.FetchSchedule
+	schedulerConfig.ReadStateSyncInterval = cfg.ReadStateSyncInterval
+	if cfg.Push.Enabled {
+		schedulerConfig.Push = scheduler.PushConfig{
+			PollInterval:      cfg.Push.PollInterval,
+			StaleAfter:        cfg.Push.StaleAfter,
+			MinStreamInterval: cfg.Push.MinStreamInterval,
+		}
+	}
 	inoreaderScheduler.Start(schedulerConfig)
 
 	// Register shutdown hook for scheduler
@@ -11,6 +11,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"pre-processor-sidecar/domain"
 )
 
 // Config holds all configuration for the pre-processor-sidecar service
@@ -52,6 +54,12 @@ type Config struct {
 	TokenStoragePath string
 	TokenStorageType string // "kubernetes_secret", "env_var", "file"
 
+	// Token encryption at rest for the Kubernetes Secret store: a keyring of
+	// "id:base64(32-byte key)" entries and the key ID new writes are sealed
+	// with. Empty keys disable encryption.
+	TokenEncryptionKeys         string
+	TokenEncryptionPrimaryKeyID string
+
 	// TDD Phase 3 - REFACTOR: Enhanced Configuration Management
 	// HTTP Client configuration
 	HTTPClient HTTPClientConfig
@@ -79,6 +87,33 @@ type Config struct {
 
 	// Phase 5: Content processing configuration
 	Content ContentConfig
+
+	// SubscriptionPauseThreshold is the number of consecutive 404 /
+	// unsubscribed / parse failures after which a subscription is paused
+	// (SUBSCRIPTION_PAUSE_THRESHOLD). 0 tracks failures without pausing.
+	SubscriptionPauseThreshold int
+
+	// FetchSchedule limits article fetches to daily windows (FETCH_WINDOWS,
+	// read in FETCH_WINDOWS_TZ). Empty means fetch around the clock.
+	FetchSchedule domain.FetchSchedule
+
+	// Push configures push ingestion from Inoreader webhooks / WebSub.
+	Push PushConfig
+
+	// ReadStateSyncInterval is how often items queued on
+	// /admin/read-state/mark-read are marked read in Inoreader, spending
+	// the zone 2 (write) budget (READ_STATE_SYNC_INTERVAL). 0 disables it.
+	ReadStateSyncInterval time.Duration
+}
+
+// PushConfig holds push ingestion settings. Polling keeps running as the
+// fallback; it only slows while notifications arrive.
+type PushConfig struct {
+	Enabled           bool          // Serve /push and slow polling while push is live (PUSH_ENABLED)
+	Secret            string        // HMAC key notifications are signed with (PUSH_SECRET)
+	PollInterval      time.Duration // Fetch interval while push is live (default: 2h)
+	StaleAfter        time.Duration // Push counts as unavailable this long after the last notification (default: 1h)
+	MinStreamInterval time.Duration // Least time between push-triggered fetches of one stream (default: 10m)
 }
 
 // DatabaseConfig holds database connection settings
@@ -243,6 +278,9 @@ func LoadConfig() (*Config, error) {
 		TokenStoragePath:  getEnvOrDefault("TOKEN_STORAGE_PATH", "/tmp/oauth2_token.env"),
 		TokenStorageType:  getEnvOrDefault("TOKEN_STORAGE_TYPE", "kubernetes_secret"), // Default to Kubernetes Secret
 
+		TokenEncryptionKeys:         GetSecretOrEnv("TOKEN_ENCRYPTION_KEYS_FILE", "TOKEN_ENCRYPTION_KEYS"),
+		TokenEncryptionPrimaryKeyID: getEnvOrDefault("TOKEN_ENCRYPTION_PRIMARY_KEY_ID", ""),
+
 		// TDD Phase 3 - REFACTOR: Enhanced Configuration Management
 		HTTPClient: HTTPClientConfig{
 			Timeout:               getEnvOrDefaultDuration("HTTP_CLIENT_TIMEOUT", 60*time.Second),
@@ -339,6 +377,29 @@ func LoadConfig() (*Config, error) {
 		CompressionEnabled:   getEnvOrDefaultBool("CONTENT_COMPRESSION_ENABLED", false),
 	}
 
+	cfg.SubscriptionPauseThreshold = getEnvOrDefaultInt("SUBSCRIPTION_PAUSE_THRESHOLD", 5)
+
+	// Fetch windows fail startup on a typo instead of silently spending the
+	// daily API budget at the wrong time of day.
+	fetchSchedule, err := domain.NewFetchSchedule(
+		os.Getenv("FETCH_WINDOWS"),
+		getEnvOrDefault("FETCH_WINDOWS_TZ", domain.DefaultFetchWindowTimezone),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FETCH_WINDOWS: %w", err)
+	}
+	cfg.FetchSchedule = fetchSchedule
+
+	cfg.Push = PushConfig{
+		Enabled:           getEnvOrDefaultBool("PUSH_ENABLED", false),
+		Secret:            GetSecretOrEnv("PUSH_SECRET_FILE", "PUSH_SECRET"),
+		PollInterval:      getEnvOrDefaultDuration("PUSH_POLL_INTERVAL", 2*time.Hour),
+		StaleAfter:        getEnvOrDefaultDuration("PUSH_STALE_AFTER", time.Hour),
+		MinStreamInterval: getEnvOrDefaultDuration("PUSH_MIN_STREAM_INTERVAL", 10*time.Minute),
+	}
+
+	cfg.ReadStateSyncInterval = getEnvOrDefaultDuration("READ_STATE_SYNC_INTERVAL", time.Hour)
+
 	// Validate required configuration
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
@@ -422,6 +483,29 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("RETRY_MULTIPLIER must be greater than 1.0")
 	}
 
+	if c.SubscriptionPauseThreshold < 0 {
+		return fmt.Errorf("SUBSCRIPTION_PAUSE_THRESHOLD must be non-negative")
+	}
+
+	if err := c.FetchSchedule.Validate(c.RateLimit.DailyLimit); err != nil {
+		return fmt.Errorf("FETCH_WINDOWS: %w", err)
+	}
+
+	if c.ReadStateSyncInterval < 0 {
+		return fmt.Errorf("READ_STATE_SYNC_INTERVAL must be non-negative")
+	}
+
+	if c.Push.Enabled {
+		// The secret is all that keeps a forged notification from spending
+		// the daily API budget.
+		if len(c.Push.Secret) < 16 {
+			return fmt.Errorf("PUSH_SECRET must be at least 16 characters when PUSH_ENABLED is set")
+		}
+		if c.Push.PollInterval <= 0 || c.Push.StaleAfter <= 0 || c.Push.MinStreamInterval <= 0 {
+			return fmt.Errorf("PUSH_POLL_INTERVAL, PUSH_STALE_AFTER and PUSH_MIN_STREAM_INTERVAL must be positive")
+		}
+	}
+
 	return nil
 }
 
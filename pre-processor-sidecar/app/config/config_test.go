@@ -77,6 +77,7 @@ func TestLoadConfig(t *testing.T) {
 				assert.Equal(t, 10*time.Minute, cfg.Inoreader.TokenRefreshBuffer)
 				assert.Equal(t, "https://www.inoreader.com/reader/api/0", cfg.Inoreader.BaseURL)
 				assert.Equal(t, "http://envoy-proxy.alt-apps.svc.cluster.local:8081", cfg.Proxy.HTTPSProxy)
+				assert.Equal(t, 5, cfg.SubscriptionPauseThreshold)
 			},
 		},
 		"invalid_integer_parsing": {
@@ -111,6 +112,115 @@ func TestLoadConfig(t *testing.T) {
 				assert.Equal(t, 30*time.Minute, cfg.RateLimit.SyncInterval)
 			},
 		},
+		"fetch_windows": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"FETCH_WINDOWS":                     "06:00-12:00@20m,12:00-24:00@15m",
+			},
+			expectError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.FetchSchedule.Enabled())
+				assert.Equal(t, "06:00-12:00@20m,12:00-24:00@15m", cfg.FetchSchedule.String())
+				assert.Equal(t, "Asia/Tokyo", cfg.FetchSchedule.TimezoneName())
+			},
+		},
+		"subscription_pause_threshold": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"SUBSCRIPTION_PAUSE_THRESHOLD":      "3",
+			},
+			expectError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 3, cfg.SubscriptionPauseThreshold)
+			},
+		},
+		"negative_subscription_pause_threshold": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"SUBSCRIPTION_PAUSE_THRESHOLD":      "-1",
+			},
+			expectError: true,
+		},
+		"invalid_fetch_windows_fail_fast": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"FETCH_WINDOWS":                     "06:00-24:00",
+			},
+			expectError: true,
+		},
+		"push_enabled": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"PUSH_ENABLED":                      "true",
+				"PUSH_SECRET":                       "test_push_secret_value",
+				"PUSH_POLL_INTERVAL":                "3h",
+			},
+			expectError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.Push.Enabled)
+				assert.Equal(t, 3*time.Hour, cfg.Push.PollInterval)
+				assert.Equal(t, time.Hour, cfg.Push.StaleAfter)
+				assert.Equal(t, 10*time.Minute, cfg.Push.MinStreamInterval)
+			},
+		},
+		"push_enabled_without_secret": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"PUSH_ENABLED":                      "true",
+			},
+			expectError: true,
+		},
+		"read_state_sync_interval": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"READ_STATE_SYNC_INTERVAL":          "30m",
+			},
+			expectError: false,
+			validate: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 30*time.Minute, cfg.ReadStateSyncInterval)
+			},
+		},
+		"negative_read_state_sync_interval": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"READ_STATE_SYNC_INTERVAL":          "-1m",
+			},
+			expectError: true,
+		},
+		"fetch_windows_over_budget": {
+			envVars: map[string]string{
+				"PRE_PROCESSOR_SIDECAR_DB_PASSWORD": "test_password",
+				"INOREADER_CLIENT_ID":               "test_client_id",
+				"INOREADER_CLIENT_SECRET":           "test_client_secret",
+				"INTERNAL_AUTH_TOKEN":               "test_internal_auth_token",
+				"FETCH_WINDOWS":                     "00:00-24:00@5m",
+			},
+			expectError: true,
+		},
 	}
 
 	for name, tc := range tests {
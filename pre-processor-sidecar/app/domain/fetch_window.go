@@ -0,0 +1,275 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day = 24 * time.Hour
+
+	// MinFetchWindowInterval keeps a misconfigured window from hammering the
+	// Inoreader API: one fetch a minute already exceeds the daily budget.
+	MinFetchWindowInterval = time.Minute
+
+	// DefaultFetchWindowTimezone is the zone fetch windows are read in when
+	// none is given; the readers this budget is spent for are in Japan.
+	DefaultFetchWindowTimezone = "Asia/Tokyo"
+)
+
+// FetchWindow is a daily time-of-day range during which article fetches run
+// every Interval. Start and End are offsets from local midnight; End is
+// exclusive and a window whose End is not after Start wraps past midnight.
+type FetchWindow struct {
+	Start    time.Duration
+	End      time.Duration
+	Interval time.Duration
+}
+
+// String renders the window in the FETCH_WINDOWS syntax, e.g. "06:00-24:00@16m".
+func (w FetchWindow) String() string {
+	return formatClock(w.Start) + "-" + formatClock(w.End) + "@" + formatInterval(w.Interval)
+}
+
+// Length returns how long the window is open each day.
+func (w FetchWindow) Length() time.Duration {
+	if w.End > w.Start {
+		return w.End - w.Start
+	}
+	return day - w.Start + w.End
+}
+
+// contains reports whether the time-of-day offset falls inside the window.
+func (w FetchWindow) contains(offset time.Duration) bool {
+	if w.End > w.Start {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// segments splits the window into non-wrapping [start, end) ranges.
+func (w FetchWindow) segments() [][2]time.Duration {
+	if w.End > w.Start {
+		return [][2]time.Duration{{w.Start, w.End}}
+	}
+	segs := [][2]time.Duration{{w.Start, day}}
+	if w.End > 0 {
+		segs = append(segs, [2]time.Duration{0, w.End})
+	}
+	return segs
+}
+
+// FetchSchedule restricts article fetches to a set of daily windows. Outside
+// every window (quiet hours) no fetch runs. A schedule without windows
+// places no restriction, so the scheduler falls back to its fixed interval.
+type FetchSchedule struct {
+	Windows  []FetchWindow
+	Location *time.Location
+}
+
+// NewFetchSchedule parses spec (see ParseFetchWindows) in the named IANA
+// timezone, defaulting to DefaultFetchWindowTimezone.
+func NewFetchSchedule(spec, timezone string) (FetchSchedule, error) {
+	if timezone == "" {
+		timezone = DefaultFetchWindowTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return FetchSchedule{}, fmt.Errorf("invalid fetch window timezone %q: %w", timezone, err)
+	}
+	windows, err := ParseFetchWindows(spec)
+	if err != nil {
+		return FetchSchedule{}, err
+	}
+	return FetchSchedule{Windows: windows, Location: loc}, nil
+}
+
+// ParseFetchWindows parses a comma-separated list of windows of the form
+//
+//	06:00-12:00@20m,12:00-24:00@15m
+//
+// "24:00" is accepted as an end of day ("00:00-24:00" is all day), and a
+// window such as "22:00-02:00" wraps past midnight. Overlapping windows and
+// intervals below
+// MinFetchWindowInterval are rejected so a typo fails startup instead of
+// silently spending the API budget at the wrong time. An empty spec yields
+// no windows.
+func ParseFetchWindows(spec string) ([]FetchWindow, error) {
+	var windows []FetchWindow
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		span, interval, ok := strings.Cut(entry, "@")
+		if !ok {
+			return nil, fmt.Errorf("fetch window %q: expected <start>-<end>@<interval>", entry)
+		}
+		startStr, endStr, ok := strings.Cut(span, "-")
+		if !ok {
+			return nil, fmt.Errorf("fetch window %q: expected <start>-<end>@<interval>", entry)
+		}
+
+		start, err := parseClock(startStr)
+		if err != nil || start == day {
+			return nil, fmt.Errorf("fetch window %q: invalid start %q", entry, startStr)
+		}
+		end, err := parseClock(endStr)
+		if err != nil {
+			return nil, fmt.Errorf("fetch window %q: invalid end %q", entry, endStr)
+		}
+		if start == end {
+			return nil, fmt.Errorf("fetch window %q: start and end must differ", entry)
+		}
+
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil {
+			return nil, fmt.Errorf("fetch window %q: invalid interval %q", entry, interval)
+		}
+		if d < MinFetchWindowInterval {
+			return nil, fmt.Errorf("fetch window %q: interval must be at least %s", entry, MinFetchWindowInterval)
+		}
+
+		windows = append(windows, FetchWindow{Start: start, End: end, Interval: d})
+	}
+
+	if err := checkOverlap(windows); err != nil {
+		return nil, err
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].Start < windows[j].Start })
+	return windows, nil
+}
+
+// Enabled reports whether the schedule restricts fetching at all.
+func (s FetchSchedule) Enabled() bool {
+	return len(s.Windows) > 0
+}
+
+// String renders the windows in the FETCH_WINDOWS syntax.
+func (s FetchSchedule) String() string {
+	parts := make([]string, len(s.Windows))
+	for i, w := range s.Windows {
+		parts[i] = w.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// TimezoneName returns the IANA name of the schedule's timezone.
+func (s FetchSchedule) TimezoneName() string {
+	return s.location().String()
+}
+
+// ActiveWindow returns the window containing t, if any.
+func (s FetchSchedule) ActiveWindow(t time.Time) (FetchWindow, bool) {
+	offset := s.offset(t)
+	for _, w := range s.Windows {
+		if w.contains(offset) {
+			return w, true
+		}
+	}
+	return FetchWindow{}, false
+}
+
+// NextWindowStart returns the first window opening strictly after t. It
+// returns the zero time when the schedule has no windows.
+func (s FetchSchedule) NextWindowStart(t time.Time) time.Time {
+	local := t.In(s.location())
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	var next time.Time
+	for _, w := range s.Windows {
+		candidate := midnight.Add(w.Start)
+		if !candidate.After(local) {
+			candidate = midnight.AddDate(0, 0, 1).Add(w.Start)
+		}
+		if next.IsZero() || candidate.Before(next) {
+			next = candidate
+		}
+	}
+	return next
+}
+
+// EstimatedDailyFetches returns how many fetches the schedule allows per day.
+func (s FetchSchedule) EstimatedDailyFetches() int {
+	total := 0
+	for _, w := range s.Windows {
+		total += int((w.Length() + w.Interval - 1) / w.Interval)
+	}
+	return total
+}
+
+// Validate rejects schedules that would spend more than dailyBudget fetches
+// a day. A non-positive budget disables the check.
+func (s FetchSchedule) Validate(dailyBudget int) error {
+	if dailyBudget > 0 && s.EstimatedDailyFetches() > dailyBudget {
+		return fmt.Errorf("fetch windows allow %d fetches/day, exceeding the daily budget of %d",
+			s.EstimatedDailyFetches(), dailyBudget)
+	}
+	return nil
+}
+
+func (s FetchSchedule) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
+
+func (s FetchSchedule) offset(t time.Time) time.Duration {
+	local := t.In(s.location())
+	return time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+}
+
+func checkOverlap(windows []FetchWindow) error {
+	for i := range windows {
+		for j := i + 1; j < len(windows); j++ {
+			for _, a := range windows[i].segments() {
+				for _, b := range windows[j].segments() {
+					if a[0] < b[1] && b[0] < a[1] {
+						return fmt.Errorf("fetch windows %s and %s overlap", windows[i], windows[j])
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, fmt.Errorf("expected HH:MM")
+	}
+	h, err := strconv.Atoi(hh)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.Atoi(mm)
+	if err != nil {
+		return 0, err
+	}
+	if h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("out of range")
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+func formatInterval(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
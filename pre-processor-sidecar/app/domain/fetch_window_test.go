@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func jst(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParseFetchWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    string
+		wantErr string
+	}{
+		{name: "empty spec", spec: "", want: ""},
+		{name: "single window to end of day", spec: "06:00-24:00@16m", want: "06:00-24:00@16m"},
+		{name: "sorted by start", spec: " 12:00-24:00@15m , 06:00-12:00@20m ", want: "06:00-12:00@20m,12:00-24:00@15m"},
+		{name: "wraps past midnight", spec: "22:00-02:00@1h", want: "22:00-02:00@1h"},
+		{name: "missing interval", spec: "06:00-24:00", wantErr: "expected <start>-<end>@<interval>"},
+		{name: "missing end", spec: "06:00@16m", wantErr: "expected <start>-<end>@<interval>"},
+		{name: "bad clock", spec: "6-24:00@16m", wantErr: "invalid start"},
+		{name: "minutes out of range", spec: "06:60-24:00@16m", wantErr: "invalid start"},
+		{name: "start at 24:00", spec: "24:00-06:00@16m", wantErr: "invalid start"},
+		{name: "empty window", spec: "06:00-06:00@16m", wantErr: "start and end must differ"},
+		{name: "interval too small", spec: "06:00-24:00@30s", wantErr: "interval must be at least"},
+		{name: "overlap", spec: "06:00-12:00@20m,11:00-13:00@20m", wantErr: "overlap"},
+		{name: "overlap across midnight", spec: "22:00-02:00@1h,01:00-03:00@1h", wantErr: "overlap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows, err := ParseFetchWindows(tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, FetchSchedule{Windows: windows}.String())
+		})
+	}
+}
+
+func TestNewFetchSchedule_InvalidTimezone(t *testing.T) {
+	_, err := NewFetchSchedule("06:00-24:00@16m", "Mars/Olympus")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid fetch window timezone")
+}
+
+func TestFetchSchedule_ActiveWindow(t *testing.T) {
+	loc := jst(t)
+	schedule, err := NewFetchSchedule("06:00-12:00@20m,12:00-24:00@15m", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", schedule.TimezoneName())
+
+	tests := []struct {
+		name         string
+		at           time.Time
+		wantActive   bool
+		wantInterval time.Duration
+	}{
+		{name: "quiet hours", at: time.Date(2026, 10, 16, 3, 0, 0, 0, loc), wantActive: false},
+		{name: "window start is inclusive", at: time.Date(2026, 10, 16, 6, 0, 0, 0, loc), wantActive: true, wantInterval: 20 * time.Minute},
+		{name: "window end is exclusive", at: time.Date(2026, 10, 16, 12, 0, 0, 0, loc), wantActive: true, wantInterval: 15 * time.Minute},
+		{name: "late evening", at: time.Date(2026, 10, 16, 23, 59, 0, 0, loc), wantActive: true, wantInterval: 15 * time.Minute},
+		{name: "evaluated in JST not UTC", at: time.Date(2026, 10, 15, 22, 0, 0, 0, time.UTC), wantActive: true, wantInterval: 20 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, ok := schedule.ActiveWindow(tt.at)
+			assert.Equal(t, tt.wantActive, ok)
+			assert.Equal(t, tt.wantInterval, w.Interval)
+		})
+	}
+}
+
+func TestFetchSchedule_ActiveWindow_WrapsMidnight(t *testing.T) {
+	loc := jst(t)
+	schedule, err := NewFetchSchedule("22:00-02:00@1h", "")
+	require.NoError(t, err)
+
+	_, ok := schedule.ActiveWindow(time.Date(2026, 10, 16, 23, 0, 0, 0, loc))
+	assert.True(t, ok)
+	_, ok = schedule.ActiveWindow(time.Date(2026, 10, 17, 1, 59, 0, 0, loc))
+	assert.True(t, ok)
+	_, ok = schedule.ActiveWindow(time.Date(2026, 10, 17, 2, 0, 0, 0, loc))
+	assert.False(t, ok)
+}
+
+func TestFetchSchedule_NextWindowStart(t *testing.T) {
+	loc := jst(t)
+	schedule, err := NewFetchSchedule("06:00-12:00@20m,18:00-24:00@15m", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 10, 16, 6, 0, 0, 0, loc),
+		schedule.NextWindowStart(time.Date(2026, 10, 16, 1, 0, 0, 0, loc)))
+	assert.Equal(t, time.Date(2026, 10, 16, 18, 0, 0, 0, loc),
+		schedule.NextWindowStart(time.Date(2026, 10, 16, 12, 30, 0, 0, loc)))
+	assert.Equal(t, time.Date(2026, 10, 17, 6, 0, 0, 0, loc),
+		schedule.NextWindowStart(time.Date(2026, 10, 16, 18, 0, 0, 0, loc)))
+
+	assert.True(t, FetchSchedule{}.NextWindowStart(time.Now()).IsZero())
+}
+
+func TestFetchSchedule_Validate(t *testing.T) {
+	schedule, err := NewFetchSchedule("06:00-24:00@16m", "")
+	require.NoError(t, err)
+	assert.Equal(t, 68, schedule.EstimatedDailyFetches())
+	assert.NoError(t, schedule.Validate(90))
+
+	greedy, err := NewFetchSchedule("00:00-24:00@5m", "")
+	require.NoError(t, err)
+	assert.Equal(t, 288, greedy.EstimatedDailyFetches())
+	err = greedy.Validate(90)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeding the daily budget")
+	assert.NoError(t, greedy.Validate(0))
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// MaxPushStreams caps how many streams a single push notification may
+// queue, so one notification cannot spend the whole daily API budget.
+const MaxPushStreams = 20
+
+var (
+	// ErrPushSignatureMissing is returned when a notification carries no
+	// X-Hub-Signature / X-Hub-Signature-256 header.
+	ErrPushSignatureMissing = errors.New("push notification is not signed")
+	// ErrPushSignatureInvalid is returned when the signature does not match
+	// the body or uses an unsupported algorithm.
+	ErrPushSignatureInvalid = errors.New("push notification signature is invalid")
+)
+
+// pushSignatureHashes are the HMAC algorithms WebSub hubs may sign with.
+// sha1 stays accepted because widely deployed hubs still only send it.
+var pushSignatureHashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// VerifyPushSignature checks a WebSub-style "<algo>=<hex hmac>" signature of
+// body under secret. The comparison is constant-time.
+func VerifyPushSignature(secret []byte, signature string, body []byte) error {
+	if signature == "" {
+		return ErrPushSignatureMissing
+	}
+	algo, sum, ok := strings.Cut(strings.TrimSpace(signature), "=")
+	newHash, known := pushSignatureHashes[strings.ToLower(algo)]
+	if !ok || !known {
+		return ErrPushSignatureInvalid
+	}
+	got, err := hex.DecodeString(sum)
+	if err != nil {
+		return ErrPushSignatureInvalid
+	}
+	mac := hmac.New(newHash, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrPushSignatureInvalid
+	}
+	return nil
+}
+
+// inoreaderPushPayload is the part of an Inoreader rule webhook body this
+// service reads: the origin stream of each new item.
+type inoreaderPushPayload struct {
+	Items []struct {
+		Origin struct {
+			StreamID string `json:"streamId"`
+		} `json:"origin"`
+	} `json:"items"`
+}
+
+// PushStreamIDs returns the Inoreader stream IDs a push notification reports
+// as changed, without duplicates and in first-seen order. Inoreader webhook
+// bodies name the origin stream of each item; a generic WebSub notification
+// names only its topic, which maps to the "feed/<topic URL>" stream.
+func PushStreamIDs(body []byte, topic string) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var payload inoreaderPushPayload
+	if err := json.Unmarshal(body, &payload); err == nil {
+		for _, item := range payload.Items {
+			add(item.Origin.StreamID)
+		}
+	}
+	if topic != "" {
+		add("feed/" + topic)
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("push notification names no stream")
+	}
+	if len(ids) > MaxPushStreams {
+		ids = ids[:MaxPushStreams]
+	}
+	return ids, nil
+}
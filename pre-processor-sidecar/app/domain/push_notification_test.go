@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signSHA256(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyPushSignature(t *testing.T) {
+	const secret = "push-secret"
+	body := `{"items":[]}`
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   error
+	}{
+		{name: "valid sha256", signature: signSHA256(secret, body)},
+		{name: "algorithm is case-insensitive", signature: strings.Replace(signSHA256(secret, body), "sha256", "SHA256", 1)},
+		{name: "missing", signature: "", wantErr: ErrPushSignatureMissing},
+		{name: "wrong secret", signature: signSHA256("other", body), wantErr: ErrPushSignatureInvalid},
+		{name: "unsupported algorithm", signature: "md5=00", wantErr: ErrPushSignatureInvalid},
+		{name: "not hex", signature: "sha256=zz", wantErr: ErrPushSignatureInvalid},
+		{name: "no algorithm", signature: "abcdef", wantErr: ErrPushSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyPushSignature([]byte(secret), tt.signature, []byte(body))
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, VerifyPushSignature([]byte(secret), signSHA256(secret, body), []byte(body+" ")), ErrPushSignatureInvalid)
+}
+
+func TestPushStreamIDs(t *testing.T) {
+	inoreader := `{"rule":{"name":"push"},"items":[
+		{"id":"1","origin":{"streamId":"feed/https://a.example.com/rss"}},
+		{"id":"2","origin":{"streamId":"feed/https://b.example.com/rss"}},
+		{"id":"3","origin":{"streamId":"feed/https://a.example.com/rss"}}
+	]}`
+
+	ids, err := PushStreamIDs([]byte(inoreader), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feed/https://a.example.com/rss", "feed/https://b.example.com/rss"}, ids)
+
+	ids, err = PushStreamIDs([]byte(`<feed xmlns="http://www.w3.org/2005/Atom"/>`), "https://c.example.com/atom")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feed/https://c.example.com/atom"}, ids)
+
+	_, err = PushStreamIDs([]byte(`{"items":[]}`), "")
+	assert.Error(t, err)
+
+	var items []string
+	for i := 0; i < MaxPushStreams+5; i++ {
+		items = append(items, fmt.Sprintf(`{"origin":{"streamId":"feed/https://%d.example.com"}}`, i))
+	}
+	ids, err = PushStreamIDs([]byte(`{"items":[`+strings.Join(items, ",")+`]}`), "")
+	require.NoError(t, err)
+	assert.Len(t, ids, MaxPushStreams)
+}
@@ -3,7 +3,10 @@
 
 package driver
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // StreamContentsResponse represents the complete response structure from Inoreader stream contents API
 // Based on official documentation: https://www.inoreader.com/developers/stream-contents
@@ -36,6 +39,9 @@ type InoreaderArticleItem struct {
 	Author        string                `json:"author"`
 	Origin        InoreaderOrigin       `json:"origin"`
 	Annotations   []InoreaderAnnotation `json:"annotations,omitempty"`
+	// Enclosure lists the item's podcast/video attachments (href, type,
+	// length). It is kept as returned; pre-processor parses it.
+	Enclosure json.RawMessage `json:"enclosure,omitempty"`
 	// Additional fields that might be present
 	LikingUsers []interface{} `json:"likingUsers,omitempty"`
 	Comments    []interface{} `json:"comments,omitempty"`
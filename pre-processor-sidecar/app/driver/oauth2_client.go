@@ -67,8 +67,33 @@ type OAuth2Client struct {
 	fallbackClient *http.Client // Client for fallback direct connection
 	logger         *slog.Logger
 	useFallback    bool // Whether to use fallback on failure
+	usageRecorder  APICallRecorder
 }
 
+// APICallRecorder receives one record per authenticated Inoreader API
+// request, e.g. to keep the usage ledger in Postgres.
+type APICallRecorder interface {
+	RecordCall(ctx context.Context, call *models.APICall) error
+}
+
+// APICallRecorders fans each call out to several recorders, e.g. the
+// ledger and the rate limit manager. Every recorder sees every call.
+type APICallRecorders []APICallRecorder
+
+// RecordCall implements APICallRecorder.
+func (rs APICallRecorders) RecordCall(ctx context.Context, call *models.APICall) error {
+	var errs []error
+	for _, r := range rs {
+		if err := r.RecordCall(ctx, call); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// apiCallRecordTimeout bounds how long a request waits on its ledger write.
+const apiCallRecordTimeout = 2 * time.Second
+
 // NewOAuth2Client creates a new OAuth2 client for Inoreader API without proxy
 func NewOAuth2Client(clientID, clientSecret, baseURL string, logger *slog.Logger) *OAuth2Client {
 	return newOAuth2ClientWithConfig(clientID, clientSecret, baseURL, logger, false, false)
@@ -381,7 +406,9 @@ func (c *OAuth2Client) MakeAuthenticatedRequest(ctx context.Context, accessToken
 	req.Header.Set("User-Agent", "pre-processor-sidecar/1.0")
 
 	// Execute request
+	startedAt := time.Now()
 	resp, err := c.httpClient.Do(req)
+	c.recordAPICall(ctx, endpoint, startedAt, resp, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute authenticated request: %w", err)
 	}
@@ -389,11 +416,7 @@ func (c *OAuth2Client) MakeAuthenticatedRequest(ctx context.Context, accessToken
 
 	// Check for rate limit or authentication errors
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return nil, &HTTPStatusError{
-			StatusCode: resp.StatusCode,
-			Message: fmt.Sprintf("API rate limit exceeded (Zone 1: %s/%s)",
-				resp.Header.Get("X-Reader-Zone1-Usage"), resp.Header.Get("X-Reader-Zone1-Limit")),
-		}
+		return nil, rateLimitExceededError(endpoint, resp.Header)
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
@@ -414,6 +437,51 @@ func (c *OAuth2Client) MakeAuthenticatedRequest(ctx context.Context, accessToken
 	return responseData, nil
 }
 
+// PostAuthenticatedForm sends an authenticated form POST, as Inoreader's
+// zone 2 write endpoints (/edit-tag, /mark-all-as-read) expect. Those answer
+// a plain "OK" body, so only the status is checked.
+func (c *OAuth2Client) PostAuthenticatedForm(ctx context.Context, accessToken, endpoint string, form url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiBaseURL+endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create authenticated request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "pre-processor-sidecar/1.0")
+
+	startedAt := time.Now()
+	resp, err := c.httpClient.Do(req)
+	c.recordAPICall(ctx, endpoint, startedAt, resp, err)
+	if err != nil {
+		return fmt.Errorf("failed to execute authenticated request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<10))
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusTooManyRequests:
+		return rateLimitExceededError(endpoint, resp.Header)
+	case http.StatusUnauthorized:
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Message: "authentication failed: token may be expired or invalid"}
+	default:
+		return &HTTPStatusError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("API request failed with status %d", resp.StatusCode)}
+	}
+}
+
+// rateLimitExceededError reports a 429 with the usage of the zone endpoint
+// is counted against.
+func rateLimitExceededError(endpoint string, h http.Header) *HTTPStatusError {
+	zone := models.APIZoneForEndpoint(endpoint)
+	return &HTTPStatusError{
+		StatusCode: http.StatusTooManyRequests,
+		Message: fmt.Sprintf("API rate limit exceeded (Zone %d: %s/%s)", zone,
+			h.Get(fmt.Sprintf("X-Reader-Zone%d-Usage", zone)), h.Get(fmt.Sprintf("X-Reader-Zone%d-Limit", zone))),
+	}
+}
+
 // handleRateLimitHeaders extracts and parses rate limit information from response headers
 func (c *OAuth2Client) handleRateLimitHeaders(headers map[string]string) (usage, limit, remaining int) {
 	// Default values
@@ -447,6 +515,66 @@ func (c *OAuth2Client) SetHTTPClient(client *http.Client) {
 	c.httpClient = client
 }
 
+// SetUsageRecorder makes authenticated API requests report to recorder.
+// A nil recorder disables recording.
+func (c *OAuth2Client) SetUsageRecorder(recorder APICallRecorder) {
+	c.usageRecorder = recorder
+}
+
+// recordAPICall reports an authenticated request to the usage recorder.
+// Requests that got no response are recorded without a status and at no
+// cost. Recording failures are logged and never fail the request.
+func (c *OAuth2Client) recordAPICall(ctx context.Context, endpoint string, startedAt time.Time, resp *http.Response, reqErr error) {
+	if c.usageRecorder == nil {
+		return
+	}
+
+	call := &models.APICall{
+		CalledAt:   startedAt,
+		Endpoint:   models.NormalizeAPIEndpoint(endpoint),
+		Zone:       models.APIZoneForEndpoint(endpoint),
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}
+	if reqErr != nil {
+		call.Error = reqErr.Error()
+	} else {
+		call.Cost = 1
+		call.StatusCode = resp.StatusCode
+		call.Zone1Usage = headerInt(resp.Header, "X-Reader-Zone1-Usage")
+		call.Zone1Limit = headerInt(resp.Header, "X-Reader-Zone1-Limit")
+		call.Zone2Usage = headerInt(resp.Header, "X-Reader-Zone2-Usage")
+		call.Zone2Limit = headerInt(resp.Header, "X-Reader-Zone2-Limit")
+		call.ResetAfterSeconds = headerInt(resp.Header, "X-Reader-Limits-Reset-After")
+	}
+
+	// The ledger write outlives a cancelled request: the call was still made.
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), apiCallRecordTimeout)
+	defer cancel()
+	if err := c.usageRecorder.RecordCall(recordCtx, call); err != nil {
+		c.logger.Warn("failed to record Inoreader API call",
+			"endpoint", call.Endpoint,
+			"status_code", call.StatusCode,
+			"error", err)
+	}
+}
+
+// headerInt parses an integer response header, returning nil when it is
+// absent or malformed. Fractional values are truncated.
+func headerInt(h http.Header, key string) *int {
+	raw := strings.TrimSpace(h.Get(key))
+	if raw == "" {
+		return nil
+	}
+	if v, err := strconv.Atoi(raw); err == nil {
+		return &v
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		v := int(f)
+		return &v
+	}
+	return nil
+}
+
 // SetTimeout sets the HTTP client timeout for testing purposes
 func (c *OAuth2Client) SetTimeout(timeout time.Duration) {
 	c.httpClient.Timeout = timeout
@@ -474,7 +602,9 @@ func (c *OAuth2Client) MakeAuthenticatedRequestWithHeaders(ctx context.Context,
 	req.Header.Set("User-Agent", "pre-processor-sidecar/1.0")
 
 	// Execute request
+	startedAt := time.Now()
 	resp, err := c.httpClient.Do(req)
+	c.recordAPICall(ctx, endpoint, startedAt, resp, err)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to execute authenticated request: %w", err)
 	}
@@ -490,11 +620,7 @@ func (c *OAuth2Client) MakeAuthenticatedRequestWithHeaders(ctx context.Context,
 
 	// Check for rate limit or authentication errors
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return nil, headers, &HTTPStatusError{
-			StatusCode: resp.StatusCode,
-			Message: fmt.Sprintf("API rate limit exceeded (Zone 1: %s/%s)",
-				resp.Header.Get("X-Reader-Zone1-Usage"), resp.Header.Get("X-Reader-Zone1-Limit")),
-		}
+		return nil, headers, rateLimitExceededError(endpoint, resp.Header)
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
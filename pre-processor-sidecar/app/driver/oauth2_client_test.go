@@ -349,6 +349,117 @@ func TestOAuth2Client_MakeAuthenticatedRequest(t *testing.T) {
 	}
 }
 
+type recordedCalls struct {
+	calls []*models.APICall
+}
+
+func (r *recordedCalls) RecordCall(_ context.Context, call *models.APICall) error {
+	r.calls = append(r.calls, call)
+	return nil
+}
+
+func TestOAuth2Client_RecordsAPICalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Reader-Zone1-Usage", "42")
+		w.Header().Set("X-Reader-Zone1-Limit", "100")
+		w.Header().Set("X-Reader-Limits-Reset-After", "3600")
+		if strings.HasPrefix(r.URL.Path, "/stream/contents/") {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"subscriptions": []interface{}{}})
+	}))
+	defer server.Close()
+
+	recorder := &recordedCalls{}
+	client := NewOAuth2Client("test_client_id", "test_client_secret", server.URL, slog.Default())
+	client.SetUsageRecorder(recorder)
+
+	_, err := client.MakeAuthenticatedRequest(context.Background(), "token", "/subscription/list", nil)
+	require.NoError(t, err)
+	_, _, err = client.MakeAuthenticatedRequestWithHeaders(context.Background(), "token",
+		"/stream/contents/feed%2Fhttps%3A%2F%2Fexample.com%2Frss", map[string]string{"n": "100"})
+	require.Error(t, err)
+
+	require.Len(t, recorder.calls, 2)
+
+	list := recorder.calls[0]
+	assert.Equal(t, "/subscription/list", list.Endpoint)
+	assert.Equal(t, models.APIZoneRead, list.Zone)
+	assert.Equal(t, 1, list.Cost)
+	assert.Equal(t, http.StatusOK, list.StatusCode)
+	require.NotNil(t, list.Zone1Usage)
+	assert.Equal(t, 42, *list.Zone1Usage)
+	require.NotNil(t, list.ResetAfterSeconds)
+	assert.Equal(t, 3600, *list.ResetAfterSeconds)
+	assert.Nil(t, list.Zone2Usage)
+
+	stream := recorder.calls[1]
+	assert.Equal(t, "/stream/contents", stream.Endpoint)
+	assert.True(t, stream.RateLimited())
+	assert.True(t, stream.Failed())
+}
+
+func TestOAuth2Client_RecordsTransportFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	recorder := &recordedCalls{}
+	client := NewOAuth2Client("test_client_id", "test_client_secret", server.URL, slog.Default())
+	client.SetUsageRecorder(recorder)
+
+	_, err := client.MakeAuthenticatedRequest(context.Background(), "token", "/edit-tag", nil)
+	require.Error(t, err)
+
+	require.Len(t, recorder.calls, 1)
+	assert.Equal(t, models.APIZoneWrite, recorder.calls[0].Zone)
+	assert.Equal(t, 0, recorder.calls[0].Cost)
+	assert.Equal(t, 0, recorder.calls[0].StatusCode)
+	assert.NotEmpty(t, recorder.calls[0].Error)
+}
+
+func TestOAuth2Client_PostAuthenticatedForm(t *testing.T) {
+	var got *http.Request
+	var gotForm map[string][]string
+	zone2Usage := "7"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		got, gotForm = r, r.PostForm
+		w.Header().Set("X-Reader-Zone2-Usage", zone2Usage)
+		w.Header().Set("X-Reader-Zone2-Limit", "100")
+		if zone2Usage == "100" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	ledger, budget := &recordedCalls{}, &recordedCalls{}
+	client := NewOAuth2Client("test_client_id", "test_client_secret", server.URL, slog.Default())
+	client.SetUsageRecorder(APICallRecorders{ledger, budget})
+
+	form := map[string][]string{"a": {"user/-/state/com.google/read"}, "i": {"item-1", "item-2"}}
+	require.NoError(t, client.PostAuthenticatedForm(context.Background(), "token", "/edit-tag", form))
+
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "Bearer token", got.Header.Get("Authorization"))
+	assert.Equal(t, []string{"item-1", "item-2"}, gotForm["i"], "every item is sent")
+
+	require.Len(t, ledger.calls, 1)
+	require.Len(t, budget.calls, 1, "every recorder sees the call")
+	assert.Equal(t, models.APIZoneWrite, budget.calls[0].Zone)
+	require.NotNil(t, budget.calls[0].Zone2Usage)
+	assert.Equal(t, 7, *budget.calls[0].Zone2Usage)
+
+	zone2Usage = "100"
+	err := client.PostAuthenticatedForm(context.Background(), "token", "/edit-tag", form)
+	var statusErr *HTTPStatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Contains(t, statusErr.Message, "Zone 2: 100/100")
+}
+
 func TestOAuth2Client_HandleRateLimitHeaders(t *testing.T) {
 	tests := map[string]struct {
 		headers           map[string]string
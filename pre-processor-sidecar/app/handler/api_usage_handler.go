@@ -0,0 +1,127 @@
+// ABOUTME: APIUsageHandler exposes /admin/api-usage/history so operators can chart
+// ABOUTME: daily Inoreader API usage from the call ledger's per-day rollup.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pre-processor-sidecar/models"
+)
+
+const (
+	defaultAPIUsageHistoryDays = 30
+	maxAPIUsageHistoryDays     = 90
+)
+
+// APIUsageHistoryStore is the repository surface APIUsageHandler drives.
+// repository.APIUsageLedgerRepository implements it.
+type APIUsageHistoryStore interface {
+	ListDailyUsage(ctx context.Context, from, to time.Time) ([]*models.APIUsageDay, error)
+}
+
+// APIUsageHandler serves GET /admin/api-usage/history.
+type APIUsageHandler struct {
+	store  APIUsageHistoryStore
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewAPIUsageHandler constructs an APIUsageHandler.
+func NewAPIUsageHandler(store APIUsageHistoryStore, logger *slog.Logger) *APIUsageHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &APIUsageHandler{
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+type apiUsageTotals struct {
+	Zone1Calls       int `json:"zone1_calls"`
+	Zone2Calls       int `json:"zone2_calls"`
+	Zone1Cost        int `json:"zone1_cost"`
+	Zone2Cost        int `json:"zone2_cost"`
+	ErrorCalls       int `json:"error_calls"`
+	RateLimitedCalls int `json:"rate_limited_calls"`
+}
+
+type apiUsageHistoryPayload struct {
+	From   string                `json:"from"`
+	To     string                `json:"to"`
+	Days   []*models.APIUsageDay `json:"days"`
+	Totals apiUsageTotals        `json:"totals"`
+}
+
+// HandleHistory returns one entry per UTC day for the last ?days= days
+// (default 30, at most 90), today included. Days without calls are filled
+// with zeros so the series can be charted directly.
+func (h *APIUsageHandler) HandleHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	days := defaultAPIUsageHistoryDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAPIUsageHistoryDays {
+			http.Error(w, "days must be an integer between 1 and 90", http.StatusBadRequest)
+			return
+		}
+		days = parsed
+	}
+
+	to := models.UsageDate(h.now())
+	from := to.AddDate(0, 0, -(days - 1))
+
+	recorded, err := h.store.ListDailyUsage(r.Context(), from, to)
+	if err != nil {
+		h.logger.Error("Failed to list API usage history", "error", err)
+		http.Error(w, "failed to list api usage history", http.StatusInternalServerError)
+		return
+	}
+
+	byDate := make(map[time.Time]*models.APIUsageDay, len(recorded))
+	for _, day := range recorded {
+		byDate[models.UsageDate(day.Date)] = day
+	}
+
+	payload := apiUsageHistoryPayload{
+		From: from.Format(time.DateOnly),
+		To:   to.Format(time.DateOnly),
+		Days: make([]*models.APIUsageDay, 0, days),
+	}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day, ok := byDate[date]
+		if !ok {
+			day = &models.APIUsageDay{}
+		}
+		day.Date = date
+		payload.Days = append(payload.Days, day)
+
+		payload.Totals.Zone1Calls += day.Zone1Calls
+		payload.Totals.Zone2Calls += day.Zone2Calls
+		payload.Totals.Zone1Cost += day.Zone1Cost
+		payload.Totals.Zone2Cost += day.Zone2Cost
+		payload.Totals.ErrorCalls += day.ErrorCalls
+		payload.Totals.RateLimitedCalls += day.RateLimitedCalls
+	}
+
+	h.respond(w, http.StatusOK, payload)
+}
+
+func (h *APIUsageHandler) respond(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		h.logger.Error("Failed to encode api usage response", "error", err)
+	}
+}
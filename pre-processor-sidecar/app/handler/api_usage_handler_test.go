@@ -0,0 +1,102 @@
+// ABOUTME: Tests for /admin/api-usage/history — zero-filled daily series, totals
+// ABOUTME: and validation of the days window.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pre-processor-sidecar/models"
+)
+
+type fakeAPIUsageHistoryStore struct {
+	days     []*models.APIUsageDay
+	from, to time.Time
+}
+
+func (f *fakeAPIUsageHistoryStore) ListDailyUsage(_ context.Context, from, to time.Time) ([]*models.APIUsageDay, error) {
+	f.from, f.to = from, to
+	return f.days, nil
+}
+
+func newTestAPIUsageHandler(store APIUsageHistoryStore) *APIUsageHandler {
+	h := NewAPIUsageHandler(store, newHealthTestLogger())
+	h.now = func() time.Time { return time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC) }
+	return h
+}
+
+func TestHandleHistory_ZeroFillsMissingDays(t *testing.T) {
+	limit := 100
+	store := &fakeAPIUsageHistoryStore{days: []*models.APIUsageDay{
+		{Date: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), Zone1Calls: 40, Zone1Cost: 40, ErrorCalls: 2, Zone1Limit: &limit},
+		{Date: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), Zone1Calls: 5, Zone2Calls: 1, Zone1Cost: 5, Zone2Cost: 1},
+	}}
+	h := newTestAPIUsageHandler(store)
+
+	rec := httptest.NewRecorder()
+	h.HandleHistory(rec, httptest.NewRequest(http.MethodGet, "/admin/api-usage/history?days=3", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !store.from.Equal(time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)) || !store.to.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected window %s..%s", store.from, store.to)
+	}
+
+	var body apiUsageHistoryPayload
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.From != "2026-10-14" || body.To != "2026-10-16" {
+		t.Fatalf("unexpected range %s..%s", body.From, body.To)
+	}
+	if len(body.Days) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(body.Days))
+	}
+	if body.Days[1].Zone1Calls != 0 || !body.Days[1].Date.Equal(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected zero-filled 2026-10-15, got %+v", body.Days[1])
+	}
+	if body.Totals.Zone1Calls != 45 || body.Totals.Zone2Calls != 1 || body.Totals.ErrorCalls != 2 {
+		t.Fatalf("unexpected totals %+v", body.Totals)
+	}
+}
+
+func TestHandleHistory_DefaultsToThirtyDays(t *testing.T) {
+	h := newTestAPIUsageHandler(&fakeAPIUsageHistoryStore{})
+
+	rec := httptest.NewRecorder()
+	h.HandleHistory(rec, httptest.NewRequest(http.MethodGet, "/admin/api-usage/history", nil))
+
+	var body apiUsageHistoryPayload
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Days) != 30 || body.From != "2026-09-17" {
+		t.Fatalf("expected 30 days from 2026-09-17, got %d from %s", len(body.Days), body.From)
+	}
+}
+
+func TestHandleHistory_RejectsBadRequests(t *testing.T) {
+	h := newTestAPIUsageHandler(&fakeAPIUsageHistoryStore{})
+
+	for _, tc := range []struct {
+		method, target string
+		want           int
+	}{
+		{http.MethodGet, "/admin/api-usage/history?days=0", http.StatusBadRequest},
+		{http.MethodGet, "/admin/api-usage/history?days=91", http.StatusBadRequest},
+		{http.MethodGet, "/admin/api-usage/history?days=week", http.StatusBadRequest},
+		{http.MethodPost, "/admin/api-usage/history", http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		h.HandleHistory(rec, httptest.NewRequest(tc.method, tc.target, nil))
+		if rec.Code != tc.want {
+			t.Fatalf("%s %s: expected %d, got %d", tc.method, tc.target, tc.want, rec.Code)
+		}
+	}
+}
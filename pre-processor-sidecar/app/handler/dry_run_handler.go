@@ -0,0 +1,97 @@
+// ABOUTME: DryRunHandler exposes /admin/dry-run/* so operators can validate config changes
+// ABOUTME: against the live Inoreader API and get a report of the writes instead of performing them.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"pre-processor-sidecar/service"
+)
+
+// ArticleFetchDryRunner is the ArticleFetchService surface DryRunHandler drives.
+type ArticleFetchDryRunner interface {
+	DryRunFetchArticles(ctx context.Context, streamID string) (*service.ArticleFetchDryRunReport, error)
+}
+
+// SubscriptionSyncDryRunner is the SubscriptionSyncService surface DryRunHandler drives.
+type SubscriptionSyncDryRunner interface {
+	DryRunSyncSubscriptions(ctx context.Context) (*service.SubscriptionSyncDryRunReport, error)
+}
+
+// DryRunHandler serves POST /admin/dry-run/article-fetch and
+// POST /admin/dry-run/subscription-sync. Both still spend Inoreader quota;
+// only the Postgres writes are skipped.
+type DryRunHandler struct {
+	articles      ArticleFetchDryRunner
+	subscriptions SubscriptionSyncDryRunner
+	logger        *slog.Logger
+}
+
+// NewDryRunHandler constructs a DryRunHandler.
+func NewDryRunHandler(articles ArticleFetchDryRunner, subscriptions SubscriptionSyncDryRunner, logger *slog.Logger) *DryRunHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DryRunHandler{
+		articles:      articles,
+		subscriptions: subscriptions,
+		logger:        logger,
+	}
+}
+
+// HandleArticleFetch previews a fetch of the stream named by the stream_id
+// query parameter.
+func (h *DryRunHandler) HandleArticleFetch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	streamID := strings.TrimSpace(r.URL.Query().Get("stream_id"))
+	if streamID == "" {
+		http.Error(w, "stream_id query parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("Article fetch dry-run requested via Admin API", "stream_id", streamID)
+	report, err := h.articles.DryRunFetchArticles(r.Context(), streamID)
+	if err != nil {
+		h.logger.Error("Article fetch dry-run failed", "stream_id", streamID, "error", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	h.respond(w, report)
+}
+
+// HandleSubscriptionSync previews a subscription sync.
+func (h *DryRunHandler) HandleSubscriptionSync(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.logger.Info("Subscription sync dry-run requested via Admin API")
+	report, err := h.subscriptions.DryRunSyncSubscriptions(r.Context())
+	if err != nil {
+		h.logger.Error("Subscription sync dry-run failed", "error", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	h.respond(w, report)
+}
+
+func (h *DryRunHandler) respond(w http.ResponseWriter, report any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"status":  "dry_run",
+		"report":  report,
+		"written": false,
+	}); err != nil {
+		h.logger.Error("Failed to encode dry-run response", "error", err)
+	}
+}
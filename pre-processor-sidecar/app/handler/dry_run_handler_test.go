@@ -0,0 +1,102 @@
+// ABOUTME: Tests for /admin/dry-run/* — previewing article fetches and subscription
+// ABOUTME: syncs without writing to Postgres.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pre-processor-sidecar/service"
+)
+
+type fakeDryRunner struct {
+	streamID string
+	err      error
+}
+
+func (f *fakeDryRunner) DryRunFetchArticles(_ context.Context, streamID string) (*service.ArticleFetchDryRunReport, error) {
+	f.streamID = streamID
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &service.ArticleFetchDryRunReport{StreamID: streamID, Fetched: 3, WouldInsert: 2, WouldUpdate: 1}, nil
+}
+
+func (f *fakeDryRunner) DryRunSyncSubscriptions(_ context.Context) (*service.SubscriptionSyncDryRunReport, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &service.SubscriptionSyncDryRunReport{Fetched: 4, WouldCreate: 1, Unchanged: 3}, nil
+}
+
+func TestDryRunHandler_ArticleFetch_ReportsPlannedWrites(t *testing.T) {
+	runner := &fakeDryRunner{}
+	h := NewDryRunHandler(runner, runner, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandleArticleFetch(rec, httptest.NewRequest(http.MethodPost, "/admin/dry-run/article-fetch?stream_id=feed/http://example.com/rss", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if runner.streamID != "feed/http://example.com/rss" {
+		t.Errorf("unexpected stream_id passed through: %q", runner.streamID)
+	}
+	var body struct {
+		Status  string                           `json:"status"`
+		Written bool                             `json:"written"`
+		Report  service.ArticleFetchDryRunReport `json:"report"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Status != "dry_run" || body.Written {
+		t.Errorf("unexpected envelope: %+v", body)
+	}
+	if body.Report.WouldInsert != 2 || body.Report.WouldUpdate != 1 {
+		t.Errorf("unexpected report: %+v", body.Report)
+	}
+}
+
+func TestDryRunHandler_ArticleFetch_RequiresStreamID(t *testing.T) {
+	runner := &fakeDryRunner{}
+	h := NewDryRunHandler(runner, runner, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandleArticleFetch(rec, httptest.NewRequest(http.MethodPost, "/admin/dry-run/article-fetch", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestDryRunHandler_SubscriptionSync(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		err    error
+		want   int
+	}{
+		"success":        {method: http.MethodPost, want: http.StatusOK},
+		"upstream error": {method: http.MethodPost, err: errors.New("inoreader down"), want: http.StatusBadGateway},
+		"wrong method":   {method: http.MethodGet, want: http.StatusMethodNotAllowed},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runner := &fakeDryRunner{err: tc.err}
+			h := NewDryRunHandler(runner, runner, newHealthTestLogger())
+
+			rec := httptest.NewRecorder()
+			h.HandleSubscriptionSync(rec, httptest.NewRequest(tc.method, "/admin/dry-run/subscription-sync", nil))
+
+			if rec.Code != tc.want {
+				t.Fatalf("expected %d, got %d", tc.want, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// ABOUTME: FetchWindowHandler exposes /admin/schedule/fetch-windows so operators can read
+// ABOUTME: and replace the daily fetch windows (quiet hours) without restarting the sidecar.
+
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"pre-processor-sidecar/domain"
+)
+
+// FetchScheduleController is the scheduler surface FetchWindowHandler drives.
+// scheduler.Scheduler implements it.
+type FetchScheduleController interface {
+	FetchSchedule() domain.FetchSchedule
+	SetFetchSchedule(schedule domain.FetchSchedule)
+}
+
+// FetchWindowHandler serves GET/PUT /admin/schedule/fetch-windows.
+type FetchWindowHandler struct {
+	controller  FetchScheduleController
+	clock       HealthClock
+	dailyBudget int
+	logger      *slog.Logger
+}
+
+// NewFetchWindowHandler constructs a FetchWindowHandler. dailyBudget is the
+// number of fetches a day a schedule may allow; PUTs exceeding it are rejected.
+func NewFetchWindowHandler(controller FetchScheduleController, clock HealthClock, dailyBudget int, logger *slog.Logger) *FetchWindowHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &FetchWindowHandler{
+		controller:  controller,
+		clock:       clock,
+		dailyBudget: dailyBudget,
+		logger:      logger,
+	}
+}
+
+// fetchWindowsRequest replaces the schedule. An empty Windows string
+// removes every window, restoring round-the-clock fetching.
+type fetchWindowsRequest struct {
+	Windows  string `json:"windows"`
+	Timezone string `json:"timezone"`
+}
+
+type fetchWindowsPayload struct {
+	Enabled               bool   `json:"enabled"`
+	Windows               string `json:"windows"`
+	Timezone              string `json:"timezone"`
+	ActiveWindow          string `json:"active_window,omitempty"`
+	NextWindowStart       string `json:"next_window_start,omitempty"`
+	EstimatedDailyFetches int    `json:"estimated_daily_fetches"`
+}
+
+// HandleFetchWindows answers GET with the current schedule and PUT by
+// replacing it. Invalid specs are rejected with 400 and leave the running
+// schedule untouched.
+func (h *FetchWindowHandler) HandleFetchWindows(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.respond(w, http.StatusOK, h.controller.FetchSchedule())
+	case http.MethodPut:
+		var req fetchWindowsRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 4096)).Decode(&req); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		schedule, err := domain.NewFetchSchedule(req.Windows, req.Timezone)
+		if err == nil {
+			err = schedule.Validate(h.dailyBudget)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		h.controller.SetFetchSchedule(schedule)
+		h.logger.Info("Fetch windows updated via Admin API",
+			"fetch_windows", schedule.String(),
+			"timezone", schedule.TimezoneName())
+		h.respond(w, http.StatusOK, schedule)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *FetchWindowHandler) respond(w http.ResponseWriter, status int, schedule domain.FetchSchedule) {
+	now := h.clock.Now()
+	payload := fetchWindowsPayload{
+		Enabled:               schedule.Enabled(),
+		Windows:               schedule.String(),
+		Timezone:              schedule.TimezoneName(),
+		EstimatedDailyFetches: schedule.EstimatedDailyFetches(),
+	}
+	if schedule.Enabled() {
+		if active, ok := schedule.ActiveWindow(now); ok {
+			payload.ActiveWindow = active.String()
+		} else {
+			payload.NextWindowStart = schedule.NextWindowStart(now).Format(time.RFC3339)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		h.logger.Error("Failed to encode fetch windows response", "error", err)
+	}
+}
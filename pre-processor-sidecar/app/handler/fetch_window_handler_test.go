@@ -0,0 +1,118 @@
+// ABOUTME: Tests for /admin/schedule/fetch-windows — reading and replacing the daily
+// ABOUTME: fetch windows that keep the Inoreader budget for hours when users read.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pre-processor-sidecar/domain"
+)
+
+type fakeFetchScheduleController struct {
+	schedule domain.FetchSchedule
+	sets     int
+}
+
+func (f *fakeFetchScheduleController) FetchSchedule() domain.FetchSchedule { return f.schedule }
+func (f *fakeFetchScheduleController) SetFetchSchedule(s domain.FetchSchedule) {
+	f.schedule = s
+	f.sets++
+}
+
+func TestHandleFetchWindows_Get_ReportsNextWindowDuringQuietHours(t *testing.T) {
+	schedule, err := domain.NewFetchSchedule("06:00-24:00@16m", "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("NewFetchSchedule: %v", err)
+	}
+	controller := &fakeFetchScheduleController{schedule: schedule}
+	now := time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC) // 03:00 JST
+	h := NewFetchWindowHandler(controller, &fakeHealthClock{now: now}, 90, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandleFetchWindows(rec, httptest.NewRequest(http.MethodGet, "/admin/schedule/fetch-windows", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var body fetchWindowsPayload
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !body.Enabled || body.Windows != "06:00-24:00@16m" || body.Timezone != "Asia/Tokyo" {
+		t.Errorf("unexpected schedule: %+v", body)
+	}
+	if body.ActiveWindow != "" {
+		t.Errorf("expected no active window at 03:00 JST, got %q", body.ActiveWindow)
+	}
+	if body.NextWindowStart != "2026-10-16T06:00:00+09:00" {
+		t.Errorf("unexpected next_window_start %q", body.NextWindowStart)
+	}
+}
+
+func TestHandleFetchWindows_Put_ReplacesSchedule(t *testing.T) {
+	controller := &fakeFetchScheduleController{}
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC) // 09:00 JST
+	h := NewFetchWindowHandler(controller, &fakeHealthClock{now: now}, 90, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/admin/schedule/fetch-windows",
+		strings.NewReader(`{"windows":"06:00-12:00@20m,12:00-24:00@15m"}`))
+	h.HandleFetchWindows(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if controller.sets != 1 || controller.schedule.String() != "06:00-12:00@20m,12:00-24:00@15m" {
+		t.Errorf("schedule not applied: sets=%d schedule=%q", controller.sets, controller.schedule.String())
+	}
+	var body fetchWindowsPayload
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.ActiveWindow != "06:00-12:00@20m" {
+		t.Errorf("expected morning window active, got %q", body.ActiveWindow)
+	}
+}
+
+func TestHandleFetchWindows_Put_RejectsInvalidSchedules(t *testing.T) {
+	tests := map[string]string{
+		"malformed JSON":   `{`,
+		"malformed window": `{"windows":"06:00-24:00"}`,
+		"unknown timezone": `{"windows":"06:00-24:00@16m","timezone":"Mars/Olympus"}`,
+		"over budget":      `{"windows":"00:00-24:00@5m"}`,
+	}
+
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			controller := &fakeFetchScheduleController{}
+			h := NewFetchWindowHandler(controller, &fakeHealthClock{now: time.Now()}, 90, newHealthTestLogger())
+
+			rec := httptest.NewRecorder()
+			h.HandleFetchWindows(rec, httptest.NewRequest(http.MethodPut, "/admin/schedule/fetch-windows", strings.NewReader(body)))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+			if controller.sets != 0 {
+				t.Error("invalid schedule must not be applied")
+			}
+		})
+	}
+}
+
+func TestHandleFetchWindows_RejectsOtherMethods(t *testing.T) {
+	h := NewFetchWindowHandler(&fakeFetchScheduleController{}, &fakeHealthClock{now: time.Now()}, 90, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandleFetchWindows(rec, httptest.NewRequest(http.MethodPost, "/admin/schedule/fetch-windows", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,193 @@
+// ABOUTME: PushHandler accepts Inoreader rule webhooks and WebSub notifications on /push
+// ABOUTME: and queues targeted fetches of only the streams they report as changed.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"pre-processor-sidecar/domain"
+)
+
+// maxPushBodyBytes bounds a notification body; only stream IDs are read
+// from it, but WebSub hubs send the full feed.
+const maxPushBodyBytes = 1 << 20
+
+// PushStreamNotifier is the scheduler surface PushHandler drives.
+// scheduler.Scheduler implements it.
+type PushStreamNotifier interface {
+	KnownStream(ctx context.Context, streamID string) (bool, error)
+	NotifyStreams(streamIDs []string) int
+}
+
+// PushHandler serves GET/POST /push.
+type PushHandler struct {
+	notifier PushStreamNotifier
+	secret   []byte
+	logger   *slog.Logger
+}
+
+// NewPushHandler constructs a PushHandler. secret is the HMAC key the hub or
+// Inoreader signs notifications with.
+func NewPushHandler(notifier PushStreamNotifier, secret string, logger *slog.Logger) *PushHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &PushHandler{
+		notifier: notifier,
+		secret:   []byte(secret),
+		logger:   logger,
+	}
+}
+
+type pushResponse struct {
+	Queued  int `json:"queued"`
+	Ignored int `json:"ignored"`
+}
+
+// HandlePush answers WebSub intent verification on GET and notifications on
+// POST.
+func (h *PushHandler) HandlePush(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.verifyIntent(w, r)
+	case http.MethodPost:
+		h.notify(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verifyIntent echoes hub.challenge for subscriptions to topics this service
+// is subscribed to in Inoreader. Unsubscribes are always confirmed.
+func (h *PushHandler) verifyIntent(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	mode, topic, challenge := q.Get("hub.mode"), q.Get("hub.topic"), q.Get("hub.challenge")
+	switch mode {
+	case "subscribe", "unsubscribe":
+	case "denied":
+		h.logger.Warn("Push subscription denied by hub", "topic", topic, "reason", q.Get("hub.reason"))
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		http.Error(w, "unsupported hub.mode", http.StatusBadRequest)
+		return
+	}
+	if topic == "" || challenge == "" {
+		http.Error(w, "hub.topic and hub.challenge are required", http.StatusBadRequest)
+		return
+	}
+
+	if mode == "subscribe" {
+		known, err := h.notifier.KnownStream(r.Context(), "feed/"+topic)
+		if err != nil {
+			h.logger.Error("Failed to look up push topic", "topic", topic, "error", err)
+			http.Error(w, "lookup failed", http.StatusServiceUnavailable)
+			return
+		}
+		if !known {
+			http.Error(w, "unknown topic", http.StatusNotFound)
+			return
+		}
+	}
+
+	h.logger.Info("Push subscription verified", "mode", mode, "topic", topic)
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, challenge)
+}
+
+// notify verifies the signature and queues the subscribed streams the
+// notification names. As WebSub requires, an invalid signature is
+// acknowledged with 2xx and otherwise ignored.
+func (h *PushHandler) notify(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushBodyBytes+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxPushBodyBytes {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	signature := r.Header.Get("X-Hub-Signature-256")
+	if signature == "" {
+		signature = r.Header.Get("X-Hub-Signature")
+	}
+	if err := domain.VerifyPushSignature(h.secret, signature, body); err != nil {
+		h.logger.Warn("Ignoring push notification", "error", err, "remote_addr", r.RemoteAddr)
+		h.respond(w, pushResponse{})
+		return
+	}
+
+	streamIDs, err := domain.PushStreamIDs(body, linkSelf(r.Header.Values("Link")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	known := make([]string, 0, len(streamIDs))
+	for _, id := range streamIDs {
+		ok, err := h.notifier.KnownStream(r.Context(), id)
+		if err != nil {
+			// Polling still covers the stream; ask the sender to retry.
+			h.logger.Error("Failed to look up pushed stream", "stream_id", id, "error", err)
+			http.Error(w, "lookup failed", http.StatusServiceUnavailable)
+			return
+		}
+		if ok {
+			known = append(known, id)
+		} else {
+			h.logger.Debug("Ignoring push for unsubscribed stream", "stream_id", id)
+		}
+	}
+
+	resp := pushResponse{Ignored: len(streamIDs) - len(known)}
+	if len(known) > 0 {
+		resp.Queued = h.notifier.NotifyStreams(known)
+	}
+	h.logger.Info("Push notification received",
+		"streams", len(streamIDs),
+		"queued", resp.Queued,
+		"ignored", resp.Ignored)
+	h.respond(w, resp)
+}
+
+func (h *PushHandler) respond(w http.ResponseWriter, resp pushResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error("Failed to encode push response", "error", err)
+	}
+}
+
+// linkSelf returns the rel="self" target of Link headers, which WebSub hubs
+// set to the notification's topic URL.
+func linkSelf(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, rel, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, r := range strings.Fields(strings.Trim(rel, `"`)) {
+					if r == "self" {
+						return strings.Trim(strings.TrimSpace(target), "<>")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+// ABOUTME: Tests for /push — signature checks, WebSub intent verification and which
+// ABOUTME: streams a notification queues for a targeted fetch.
+
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testPushSecret = "push-secret-for-tests"
+
+type fakePushNotifier struct {
+	known    map[string]bool
+	notified []string
+}
+
+func (f *fakePushNotifier) KnownStream(_ context.Context, streamID string) (bool, error) {
+	return f.known[streamID], nil
+}
+
+func (f *fakePushNotifier) NotifyStreams(streamIDs []string) int {
+	f.notified = append(f.notified, streamIDs...)
+	return len(streamIDs)
+}
+
+func signPush(body string) string {
+	mac := hmac.New(sha256.New, []byte(testPushSecret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func postPush(h *PushHandler, body, signature string, headers map[string]string) (*httptest.ResponseRecorder, pushResponse) {
+	req := httptest.NewRequest(http.MethodPost, "/push", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.HandlePush(rec, req)
+	var resp pushResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func TestHandlePush_InoreaderWebhookQueuesKnownStreams(t *testing.T) {
+	notifier := &fakePushNotifier{known: map[string]bool{"feed/https://a.example.com/rss": true}}
+	h := NewPushHandler(notifier, testPushSecret, newHealthTestLogger())
+	body := `{"items":[{"origin":{"streamId":"feed/https://a.example.com/rss"}},{"origin":{"streamId":"feed/https://other.example.com/rss"}}]}`
+
+	rec, resp := postPush(h, body, signPush(body), nil)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.Queued != 1 || resp.Ignored != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if len(notifier.notified) != 1 || notifier.notified[0] != "feed/https://a.example.com/rss" {
+		t.Errorf("notified %v", notifier.notified)
+	}
+}
+
+func TestHandlePush_WebSubTopicFromLinkHeader(t *testing.T) {
+	notifier := &fakePushNotifier{known: map[string]bool{"feed/https://blog.example.com/atom": true}}
+	h := NewPushHandler(notifier, testPushSecret, newHealthTestLogger())
+	body := `<feed xmlns="http://www.w3.org/2005/Atom"></feed>`
+
+	rec, resp := postPush(h, body, signPush(body), map[string]string{
+		"Link": `<https://hub.example.com/>; rel="hub", <https://blog.example.com/atom>; rel="self"`,
+	})
+
+	if rec.Code != http.StatusAccepted || resp.Queued != 1 {
+		t.Fatalf("expected one queued stream, got %d %+v", rec.Code, resp)
+	}
+}
+
+func TestHandlePush_BadSignatureIsAcknowledgedAndIgnored(t *testing.T) {
+	notifier := &fakePushNotifier{known: map[string]bool{"feed/https://a.example.com/rss": true}}
+	h := NewPushHandler(notifier, testPushSecret, newHealthTestLogger())
+	body := `{"items":[{"origin":{"streamId":"feed/https://a.example.com/rss"}}]}`
+
+	for name, signature := range map[string]string{"unsigned": "", "forged": signPush(body + "x")} {
+		rec, resp := postPush(h, body, signature, nil)
+		if rec.Code != http.StatusAccepted || resp.Queued != 0 {
+			t.Errorf("%s: expected 202 with nothing queued, got %d %+v", name, rec.Code, resp)
+		}
+	}
+	if len(notifier.notified) != 0 {
+		t.Errorf("unsigned notifications queued %v", notifier.notified)
+	}
+}
+
+func TestHandlePush_VerifyIntent(t *testing.T) {
+	notifier := &fakePushNotifier{known: map[string]bool{"feed/https://blog.example.com/atom": true}}
+	h := NewPushHandler(notifier, testPushSecret, newHealthTestLogger())
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{"known topic", "hub.mode=subscribe&hub.topic=https://blog.example.com/atom&hub.challenge=abc", http.StatusOK, "abc"},
+		{"unknown topic", "hub.mode=subscribe&hub.topic=https://evil.example.com/&hub.challenge=abc", http.StatusNotFound, ""},
+		{"unsubscribe", "hub.mode=unsubscribe&hub.topic=https://evil.example.com/&hub.challenge=xyz", http.StatusOK, "xyz"},
+		{"missing challenge", "hub.mode=subscribe&hub.topic=https://blog.example.com/atom", http.StatusBadRequest, ""},
+		{"bad mode", "hub.mode=publish", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.HandlePush(rec, httptest.NewRequest(http.MethodGet, "/push?"+tt.query, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("expected challenge %q, got %q", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// ABOUTME: ReadStateHandler exposes /admin/read-state/mark-read so read items can be queued
+// ABOUTME: for the zone 2 read-state sync and the queue and write budget can be inspected.
+
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"pre-processor-sidecar/models"
+	"pre-processor-sidecar/service"
+)
+
+// maxMarkReadItems bounds the item IDs one request may queue.
+const maxMarkReadItems = 1000
+
+// ReadStateQueue is the read-state sync surface ReadStateHandler drives.
+// service.ReadStateSync implements it.
+type ReadStateQueue interface {
+	Enqueue(itemIDs []string) int
+	Pending() int
+}
+
+// ZoneBudgetReader reports a rate limit zone's budget.
+// service.RateLimitManager implements it.
+type ZoneBudgetReader interface {
+	ZoneBudget(zone int) service.ZoneBudget
+}
+
+// ReadStateHandler serves GET/POST /admin/read-state/mark-read.
+type ReadStateHandler struct {
+	queue  ReadStateQueue
+	budget ZoneBudgetReader
+	logger *slog.Logger
+}
+
+// NewReadStateHandler constructs a ReadStateHandler.
+func NewReadStateHandler(queue ReadStateQueue, budget ZoneBudgetReader, logger *slog.Logger) *ReadStateHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ReadStateHandler{
+		queue:  queue,
+		budget: budget,
+		logger: logger,
+	}
+}
+
+// markReadRequest lists Inoreader item IDs
+// ("tag:google.com,2005:reader/item/...") to mark read.
+type markReadRequest struct {
+	ItemIDs []string `json:"item_ids"`
+}
+
+type readStatePayload struct {
+	Queued     int                `json:"queued,omitempty"`
+	Pending    int                `json:"pending"`
+	Zone2Quota service.ZoneBudget `json:"zone2_quota"`
+}
+
+// HandleMarkRead queues items on POST and reports the queue on GET. Items
+// are sent on the scheduler's read-state cadence, not during the request.
+func (h *ReadStateHandler) HandleMarkRead(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.respond(w, http.StatusOK, readStatePayload{
+			Pending:    h.queue.Pending(),
+			Zone2Quota: h.budget.ZoneBudget(models.APIZoneWrite),
+		})
+	case http.MethodPost:
+		h.enqueue(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ReadStateHandler) enqueue(w http.ResponseWriter, r *http.Request) {
+	var req markReadRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if len(req.ItemIDs) == 0 {
+		http.Error(w, "item_ids must not be empty", http.StatusBadRequest)
+		return
+	}
+	if len(req.ItemIDs) > maxMarkReadItems {
+		http.Error(w, "too many item_ids in one request", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	queued := h.queue.Enqueue(req.ItemIDs)
+	h.logger.Info("Items queued for read-state sync via Admin API",
+		"requested", len(req.ItemIDs),
+		"queued", queued)
+	h.respond(w, http.StatusAccepted, readStatePayload{
+		Queued:     queued,
+		Pending:    h.queue.Pending(),
+		Zone2Quota: h.budget.ZoneBudget(models.APIZoneWrite),
+	})
+}
+
+func (h *ReadStateHandler) respond(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		h.logger.Error("Failed to encode read-state response", "error", err)
+	}
+}
@@ -0,0 +1,105 @@
+// ABOUTME: Tests for /admin/read-state/mark-read — queueing items for the zone 2
+// ABOUTME: read-state sync and reporting the queue with the write budget.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pre-processor-sidecar/service"
+)
+
+type fakeReadStateQueue struct {
+	items []string
+}
+
+func (f *fakeReadStateQueue) Enqueue(itemIDs []string) int {
+	f.items = append(f.items, itemIDs...)
+	return len(itemIDs)
+}
+
+func (f *fakeReadStateQueue) Pending() int { return len(f.items) }
+
+type fakeZoneBudgetReader struct{}
+
+func (fakeZoneBudgetReader) ZoneBudget(zone int) service.ZoneBudget {
+	return service.ZoneBudget{Zone: zone, Usage: 12, Limit: 100, Remaining: 88}
+}
+
+func TestHandleMarkRead_QueuesItems(t *testing.T) {
+	queue := &fakeReadStateQueue{}
+	h := NewReadStateHandler(queue, fakeZoneBudgetReader{}, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandleMarkRead(rec, httptest.NewRequest(http.MethodPost, "/admin/read-state/mark-read",
+		strings.NewReader(`{"item_ids":["tag:google.com,2005:reader/item/1","tag:google.com,2005:reader/item/2"]}`)))
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body readStatePayload
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Queued != 2 || body.Pending != 2 {
+		t.Errorf("queued/pending = %d/%d, want 2/2", body.Queued, body.Pending)
+	}
+	if body.Zone2Quota.Zone != 2 || body.Zone2Quota.Remaining != 88 {
+		t.Errorf("zone2_quota = %+v", body.Zone2Quota)
+	}
+}
+
+func TestHandleMarkRead_ReportsQueue(t *testing.T) {
+	queue := &fakeReadStateQueue{items: []string{"a", "b", "c"}}
+	h := NewReadStateHandler(queue, fakeZoneBudgetReader{}, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandleMarkRead(rec, httptest.NewRequest(http.MethodGet, "/admin/read-state/mark-read", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var body readStatePayload
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Pending != 3 {
+		t.Errorf("pending = %d, want 3", body.Pending)
+	}
+}
+
+func TestHandleMarkRead_RejectsBadRequests(t *testing.T) {
+	tooMany := `{"item_ids":["x"` + strings.Repeat(`,"x"`, maxMarkReadItems) + `]}`
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"invalid JSON", http.MethodPost, `{`, http.StatusBadRequest},
+		{"no items", http.MethodPost, `{"item_ids":[]}`, http.StatusBadRequest},
+		{"too many items", http.MethodPost, tooMany, http.StatusRequestEntityTooLarge},
+		{"wrong method", http.MethodDelete, ``, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := &fakeReadStateQueue{}
+			h := NewReadStateHandler(queue, fakeZoneBudgetReader{}, newHealthTestLogger())
+
+			rec := httptest.NewRecorder()
+			h.HandleMarkRead(rec, httptest.NewRequest(tt.method, "/admin/read-state/mark-read", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if len(queue.items) != 0 {
+				t.Errorf("items queued on a rejected request: %v", queue.items)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
@@ -603,6 +604,7 @@ func (h *ScheduleHandler) processNextSubscriptionRotation(ctx context.Context, r
 
 	// Process each subscription in the batch
 	successCount := 0
+	pausedCount := 0
 	var processingErrors []string
 
 	for i, subscriptionID := range batch {
@@ -612,7 +614,9 @@ func (h *ScheduleHandler) processNextSubscriptionRotation(ctx context.Context, r
 			"batch_size", len(batch))
 
 		// Process single subscription (use existing rotation logic)
-		if _, err := h.articleFetchService.FetchSingleSubscriptionArticles(ctx, subscriptionID); err != nil {
+		if _, err := h.articleFetchService.FetchSingleSubscriptionArticles(ctx, subscriptionID); errors.Is(err, service.ErrSubscriptionPaused) {
+			pausedCount++
+		} else if err != nil {
 			errorMsg := fmt.Sprintf("Failed to process subscription %s: %v", subscriptionID, err)
 			h.logger.Error("Batch subscription processing failed",
 				"subscription_id", subscriptionID,
@@ -657,6 +661,7 @@ func (h *ScheduleHandler) processNextSubscriptionRotation(ctx context.Context, r
 		"batch_size":           BATCH_SIZE,
 		"processed_count":      len(batch),
 		"successful_count":     successCount,
+		"paused_count":         pausedCount,
 		"failed_count":         len(processingErrors),
 		"processed_today":      statsAfter.ProcessedToday,
 		"remaining_today":      statsAfter.RemainingToday,
@@ -672,6 +677,7 @@ func (h *ScheduleHandler) processNextSubscriptionRotation(ctx context.Context, r
 	h.logger.Info("Batch rotation processing completed",
 		"batch_size", len(batch),
 		"successful", successCount,
+		"paused", pausedCount,
 		"failed", len(processingErrors),
 		"processed_today", statsAfter.ProcessedToday,
 		"remaining_today", statsAfter.RemainingToday,
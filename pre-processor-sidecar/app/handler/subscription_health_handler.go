@@ -0,0 +1,114 @@
+// ABOUTME: SubscriptionHealthHandler exposes /admin/subscriptions/* so operators can list
+// ABOUTME: auto-paused subscriptions and put them back into the fetch rotation.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"pre-processor-sidecar/models"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionHealthStore is the repository surface SubscriptionHealthHandler drives.
+// repository.SubscriptionHealthRepository implements it.
+type SubscriptionHealthStore interface {
+	ListPaused(ctx context.Context) ([]*models.SubscriptionHealth, error)
+	Resume(ctx context.Context, subscriptionID uuid.UUID) (bool, error)
+}
+
+// SubscriptionHealthHandler serves GET /admin/subscriptions/paused and
+// POST /admin/subscriptions/resume.
+type SubscriptionHealthHandler struct {
+	store  SubscriptionHealthStore
+	logger *slog.Logger
+}
+
+// NewSubscriptionHealthHandler constructs a SubscriptionHealthHandler.
+func NewSubscriptionHealthHandler(store SubscriptionHealthStore, logger *slog.Logger) *SubscriptionHealthHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SubscriptionHealthHandler{
+		store:  store,
+		logger: logger,
+	}
+}
+
+type pausedSubscriptionsPayload struct {
+	Count         int                          `json:"count"`
+	Subscriptions []*models.SubscriptionHealth `json:"subscriptions"`
+}
+
+// resumeSubscriptionRequest identifies the subscription by its database UUID;
+// stream IDs contain slashes and do not fit in a path segment.
+type resumeSubscriptionRequest struct {
+	SubscriptionID string `json:"subscription_id"`
+}
+
+// HandlePaused lists paused subscriptions with their last failure.
+func (h *SubscriptionHealthHandler) HandlePaused(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	paused, err := h.store.ListPaused(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to list paused subscriptions", "error", err)
+		http.Error(w, "failed to list paused subscriptions", http.StatusInternalServerError)
+		return
+	}
+
+	h.respond(w, http.StatusOK, pausedSubscriptionsPayload{Count: len(paused), Subscriptions: paused})
+}
+
+// HandleResume clears the pause of one subscription. Subscriptions that are
+// not paused answer 404 so a typo does not look like a successful resume.
+func (h *SubscriptionHealthHandler) HandleResume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req resumeSubscriptionRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 4096)).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	subscriptionID, err := uuid.Parse(req.SubscriptionID)
+	if err != nil {
+		http.Error(w, "subscription_id must be a UUID", http.StatusBadRequest)
+		return
+	}
+
+	resumed, err := h.store.Resume(r.Context(), subscriptionID)
+	if err != nil {
+		h.logger.Error("Failed to resume subscription", "subscription_id", subscriptionID, "error", err)
+		http.Error(w, "failed to resume subscription", http.StatusInternalServerError)
+		return
+	}
+	if !resumed {
+		http.Error(w, "subscription is not paused", http.StatusNotFound)
+		return
+	}
+
+	h.logger.Info("Subscription resumed via Admin API", "subscription_id", subscriptionID)
+	h.respond(w, http.StatusOK, map[string]string{
+		"status":          "resumed",
+		"subscription_id": subscriptionID.String(),
+	})
+}
+
+func (h *SubscriptionHealthHandler) respond(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		h.logger.Error("Failed to encode subscription health response", "error", err)
+	}
+}
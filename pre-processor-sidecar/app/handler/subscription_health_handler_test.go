@@ -0,0 +1,105 @@
+// ABOUTME: Tests for /admin/subscriptions/* — listing auto-paused subscriptions and
+// ABOUTME: resuming them so they re-enter the fetch rotation.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pre-processor-sidecar/models"
+
+	"github.com/google/uuid"
+)
+
+type fakeSubscriptionHealthStore struct {
+	paused  []*models.SubscriptionHealth
+	resumed []uuid.UUID
+}
+
+func (f *fakeSubscriptionHealthStore) ListPaused(context.Context) ([]*models.SubscriptionHealth, error) {
+	return f.paused, nil
+}
+
+func (f *fakeSubscriptionHealthStore) Resume(_ context.Context, id uuid.UUID) (bool, error) {
+	for _, h := range f.paused {
+		if h.SubscriptionID == id {
+			f.resumed = append(f.resumed, id)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestHandlePaused_ListsPausedSubscriptions(t *testing.T) {
+	pausedAt := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	store := &fakeSubscriptionHealthStore{paused: []*models.SubscriptionHealth{{
+		SubscriptionID:      uuid.New(),
+		InoreaderID:         "feed/https://gone.example.com/rss",
+		ConsecutiveFailures: 5,
+		LastErrorKind:       models.SubscriptionErrorNotFound,
+		PausedAt:            &pausedAt,
+	}}}
+	h := NewSubscriptionHealthHandler(store, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandlePaused(rec, httptest.NewRequest(http.MethodGet, "/admin/subscriptions/paused", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var body pausedSubscriptionsPayload
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Count != 1 || body.Subscriptions[0].LastErrorKind != models.SubscriptionErrorNotFound {
+		t.Errorf("unexpected payload: %+v", body)
+	}
+}
+
+func TestHandleResume(t *testing.T) {
+	pausedID := uuid.New()
+	pausedAt := time.Now()
+	tests := map[string]struct {
+		body       string
+		wantStatus int
+	}{
+		"resumes paused subscription": {body: `{"subscription_id":"` + pausedID.String() + `"}`, wantStatus: http.StatusOK},
+		"not paused":                  {body: `{"subscription_id":"` + uuid.NewString() + `"}`, wantStatus: http.StatusNotFound},
+		"invalid id":                  {body: `{"subscription_id":"feed/https://example.com"}`, wantStatus: http.StatusBadRequest},
+		"malformed body":              {body: `{`, wantStatus: http.StatusBadRequest},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := &fakeSubscriptionHealthStore{paused: []*models.SubscriptionHealth{{SubscriptionID: pausedID, PausedAt: &pausedAt}}}
+			h := NewSubscriptionHealthHandler(store, newHealthTestLogger())
+
+			rec := httptest.NewRecorder()
+			h.HandleResume(rec, httptest.NewRequest(http.MethodPost, "/admin/subscriptions/resume", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d (%s)", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && (len(store.resumed) != 1 || store.resumed[0] != pausedID) {
+				t.Errorf("expected %s to be resumed, got %v", pausedID, store.resumed)
+			}
+		})
+	}
+}
+
+func TestHandleResume_RejectsGet(t *testing.T) {
+	h := NewSubscriptionHealthHandler(&fakeSubscriptionHealthStore{}, newHealthTestLogger())
+
+	rec := httptest.NewRecorder()
+	h.HandleResume(rec, httptest.NewRequest(http.MethodGet, "/admin/subscriptions/resume", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
@@ -4,6 +4,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,6 +27,10 @@ type Article struct {
 	ContentLength int    `json:"content_length" db:"content_length"`
 	ContentType   string `json:"content_type" db:"content_type"`
 
+	// Enclosures is the Inoreader item's enclosure list as returned, nil
+	// when the item has none.
+	Enclosures json.RawMessage `json:"enclosures,omitempty" db:"enclosures"`
+
 	// Internal fields for processing (not stored in database)
 	OriginStreamID string `json:"-" db:"-"` // Temporary field for UUID resolution
 }
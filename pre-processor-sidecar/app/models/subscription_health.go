@@ -0,0 +1,44 @@
+// ABOUTME: This file defines per-subscription fetch health used to auto-pause failing subscriptions
+// ABOUTME: Only failures caused by the subscription itself are tracked; quota and token errors are not
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionErrorKind classifies a fetch failure that is attributable to the
+// subscription rather than to the sidecar, its token or the Inoreader API as a whole.
+type SubscriptionErrorKind string
+
+const (
+	// SubscriptionErrorNotFound: the stream returned 404.
+	SubscriptionErrorNotFound SubscriptionErrorKind = "not_found"
+	// SubscriptionErrorUnsubscribed: the stream returned 403/410, i.e. the
+	// feed was unsubscribed or removed upstream.
+	SubscriptionErrorUnsubscribed SubscriptionErrorKind = "unsubscribed"
+	// SubscriptionErrorParse: the stream contents could not be parsed.
+	SubscriptionErrorParse SubscriptionErrorKind = "parse_error"
+)
+
+// SubscriptionHealth is the failure tracking state of one subscription.
+// Subscriptions that never failed have no health record.
+type SubscriptionHealth struct {
+	SubscriptionID      uuid.UUID             `json:"subscription_id" db:"subscription_id"`
+	InoreaderID         string                `json:"inoreader_id" db:"inoreader_id"`
+	Title               string                `json:"title" db:"title"`
+	ConsecutiveFailures int                   `json:"consecutive_failures" db:"consecutive_failures"`
+	TotalFailures       int                   `json:"total_failures" db:"total_failures"`
+	LastErrorKind       SubscriptionErrorKind `json:"last_error_kind,omitempty" db:"last_error_kind"`
+	LastError           string                `json:"last_error,omitempty" db:"last_error"`
+	LastFailureAt       *time.Time            `json:"last_failure_at,omitempty" db:"last_failure_at"`
+	LastSuccessAt       *time.Time            `json:"last_success_at,omitempty" db:"last_success_at"`
+	PausedAt            *time.Time            `json:"paused_at,omitempty" db:"paused_at"`
+}
+
+// IsPaused reports whether the subscription is excluded from fetching.
+func (h *SubscriptionHealth) IsPaused() bool {
+	return h.PausedAt != nil
+}
@@ -0,0 +1,148 @@
+// ABOUTME: PostgreSQL implementation of the Inoreader API call ledger
+// ABOUTME: Records every API request and maintains the per-day rollup used for usage history
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pre-processor-sidecar/models"
+)
+
+// APIUsageLedgerRepository records individual Inoreader API calls and serves
+// the per-day rollup. Unlike APIUsageRepository, which holds the live
+// counters the rate limiter consults, the ledger is history only.
+type APIUsageLedgerRepository interface {
+	// RecordCall appends the call to the ledger and folds it into the
+	// rollup of its UTC day.
+	RecordCall(ctx context.Context, call *models.APICall) error
+	// ListDailyUsage returns the rollups for the UTC days from through to,
+	// oldest first. Days without calls are absent.
+	ListDailyUsage(ctx context.Context, from, to time.Time) ([]*models.APIUsageDay, error)
+}
+
+// PostgreSQLAPIUsageLedgerRepository implements APIUsageLedgerRepository using PostgreSQL
+type PostgreSQLAPIUsageLedgerRepository struct {
+	pool   PgxIface
+	logger *slog.Logger
+}
+
+// NewPostgreSQLAPIUsageLedgerRepository creates a new PostgreSQL API usage ledger repository
+func NewPostgreSQLAPIUsageLedgerRepository(pool PgxIface, logger *slog.Logger) APIUsageLedgerRepository {
+	return &PostgreSQLAPIUsageLedgerRepository{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// RecordCall inserts the call and upserts the daily rollup in one statement
+// so the two can never disagree. Peak usage keeps the highest header value
+// of the day; limits keep the latest one Inoreader reported.
+func (r *PostgreSQLAPIUsageLedgerRepository) RecordCall(ctx context.Context, call *models.APICall) error {
+	query := `
+		WITH call AS (
+			INSERT INTO inoreader_api_calls (
+				called_at, endpoint, zone, cost, status_code, duration_ms,
+				zone1_usage, zone1_limit, zone2_usage, zone2_limit, reset_after_seconds, error
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		)
+		INSERT INTO inoreader_api_usage_daily AS d (
+			usage_date, zone1_calls, zone2_calls, zone1_cost, zone2_cost,
+			error_calls, rate_limited_calls,
+			peak_zone1_usage, peak_zone2_usage, zone1_limit, zone2_limit, updated_at
+		)
+		VALUES ($13, $14, $15, $16, $17, $18, $19, $7, $9, $8, $10, $1)
+		ON CONFLICT (usage_date) DO UPDATE SET
+			zone1_calls = d.zone1_calls + EXCLUDED.zone1_calls,
+			zone2_calls = d.zone2_calls + EXCLUDED.zone2_calls,
+			zone1_cost = d.zone1_cost + EXCLUDED.zone1_cost,
+			zone2_cost = d.zone2_cost + EXCLUDED.zone2_cost,
+			error_calls = d.error_calls + EXCLUDED.error_calls,
+			rate_limited_calls = d.rate_limited_calls + EXCLUDED.rate_limited_calls,
+			peak_zone1_usage = GREATEST(d.peak_zone1_usage, EXCLUDED.peak_zone1_usage),
+			peak_zone2_usage = GREATEST(d.peak_zone2_usage, EXCLUDED.peak_zone2_usage),
+			zone1_limit = COALESCE(EXCLUDED.zone1_limit, d.zone1_limit),
+			zone2_limit = COALESCE(EXCLUDED.zone2_limit, d.zone2_limit),
+			updated_at = GREATEST(d.updated_at, EXCLUDED.updated_at)`
+
+	var zone1Calls, zone2Calls, zone1Cost, zone2Cost int
+	if call.Zone == models.APIZoneWrite {
+		zone2Calls, zone2Cost = 1, call.Cost
+	} else {
+		zone1Calls, zone1Cost = 1, call.Cost
+	}
+	var errorCalls, rateLimitedCalls int
+	if call.Failed() {
+		errorCalls = 1
+	}
+	if call.RateLimited() {
+		rateLimitedCalls = 1
+	}
+
+	var statusCode *int
+	if call.StatusCode != 0 {
+		statusCode = &call.StatusCode
+	}
+	var callErr *string
+	if call.Error != "" {
+		callErr = &call.Error
+	}
+
+	if _, err := r.pool.Exec(ctx, query,
+		call.CalledAt, call.Endpoint, call.Zone, call.Cost, statusCode, call.DurationMs,
+		call.Zone1Usage, call.Zone1Limit, call.Zone2Usage, call.Zone2Limit, call.ResetAfterSeconds, callErr,
+		models.UsageDate(call.CalledAt), zone1Calls, zone2Calls, zone1Cost, zone2Cost, errorCalls, rateLimitedCalls,
+	); err != nil {
+		return fmt.Errorf("failed to record api call: %w", err)
+	}
+
+	return nil
+}
+
+// ListDailyUsage returns the rollups between from and to inclusive
+func (r *PostgreSQLAPIUsageLedgerRepository) ListDailyUsage(ctx context.Context, from, to time.Time) ([]*models.APIUsageDay, error) {
+	query := `
+		SELECT usage_date, zone1_calls, zone2_calls, zone1_cost, zone2_cost,
+		       error_calls, rate_limited_calls,
+		       peak_zone1_usage, peak_zone2_usage, zone1_limit, zone2_limit
+		FROM inoreader_api_usage_daily
+		WHERE usage_date BETWEEN $1 AND $2
+		ORDER BY usage_date`
+
+	rows, err := r.pool.Query(ctx, query, models.UsageDate(from), models.UsageDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily api usage: %w", err)
+	}
+	defer rows.Close()
+
+	days := []*models.APIUsageDay{}
+	for rows.Next() {
+		var day models.APIUsageDay
+		if err := rows.Scan(
+			&day.Date,
+			&day.Zone1Calls,
+			&day.Zone2Calls,
+			&day.Zone1Cost,
+			&day.Zone2Cost,
+			&day.ErrorCalls,
+			&day.RateLimitedCalls,
+			&day.PeakZone1Usage,
+			&day.PeakZone2Usage,
+			&day.Zone1Limit,
+			&day.Zone2Limit,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan daily api usage: %w", err)
+		}
+		days = append(days, &day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return days, nil
+}
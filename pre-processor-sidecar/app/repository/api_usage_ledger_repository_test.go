@@ -0,0 +1,129 @@
+// ABOUTME: Tests for the PostgreSQL-backed Inoreader API call ledger
+// ABOUTME: Verifies call recording feeds the daily rollup and history reads back in order
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pre-processor-sidecar/models"
+)
+
+func newTestAPIUsageLedgerRepo(t *testing.T) (APIUsageLedgerRepository, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	return NewPostgreSQLAPIUsageLedgerRepository(mock, slog.Default()), mock
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestRecordCall_Success(t *testing.T) {
+	repo, mock := newTestAPIUsageLedgerRepo(t)
+
+	calledAt := time.Date(2026, 10, 16, 23, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+	call := &models.APICall{
+		CalledAt:   calledAt,
+		Endpoint:   "/stream/contents",
+		Zone:       models.APIZoneRead,
+		Cost:       1,
+		StatusCode: 200,
+		DurationMs: 420,
+		Zone1Usage: intPtr(12),
+		Zone1Limit: intPtr(100),
+	}
+
+	mock.ExpectExec(`INSERT INTO inoreader_api_usage_daily`).
+		WithArgs(
+			calledAt, "/stream/contents", models.APIZoneRead, 1, &call.StatusCode, int64(420),
+			call.Zone1Usage, call.Zone1Limit, (*int)(nil), (*int)(nil), (*int)(nil), (*string)(nil),
+			time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), 1, 0, 1, 0, 0, 0,
+		).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.RecordCall(context.Background(), call))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordCall_RateLimitedWrite(t *testing.T) {
+	repo, mock := newTestAPIUsageLedgerRepo(t)
+
+	calledAt := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	call := &models.APICall{
+		CalledAt:   calledAt,
+		Endpoint:   "/edit-tag",
+		Zone:       models.APIZoneWrite,
+		Cost:       1,
+		StatusCode: 429,
+	}
+
+	mock.ExpectExec(`INSERT INTO inoreader_api_usage_daily`).
+		WithArgs(
+			calledAt, "/edit-tag", models.APIZoneWrite, 1, &call.StatusCode, int64(0),
+			(*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil), (*int)(nil), (*string)(nil),
+			time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), 0, 1, 0, 1, 1, 1,
+		).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.RecordCall(context.Background(), call))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordCall_DatabaseError(t *testing.T) {
+	repo, mock := newTestAPIUsageLedgerRepo(t)
+
+	anyArgs := make([]any, 19)
+	for i := range anyArgs {
+		anyArgs[i] = pgxmock.AnyArg()
+	}
+	mock.ExpectExec(`INSERT INTO inoreader_api_usage_daily`).
+		WithArgs(anyArgs...).
+		WillReturnError(errors.New("connection refused"))
+
+	err := repo.RecordCall(context.Background(), &models.APICall{
+		CalledAt: time.Now(),
+		Endpoint: "/subscription/list",
+		Zone:     models.APIZoneRead,
+		Error:    "dial tcp: timeout",
+	})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListDailyUsage(t *testing.T) {
+	repo, mock := newTestAPIUsageLedgerRepo(t)
+
+	from := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	day1 := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM inoreader_api_usage_daily`).
+		WithArgs(day1, day2).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"usage_date", "zone1_calls", "zone2_calls", "zone1_cost", "zone2_cost",
+			"error_calls", "rate_limited_calls",
+			"peak_zone1_usage", "peak_zone2_usage", "zone1_limit", "zone2_limit",
+		}).
+			AddRow(day1, 40, 2, 40, 2, 1, 0, intPtr(40), intPtr(2), intPtr(100), intPtr(100)).
+			AddRow(day2, 5, 0, 5, 0, 0, 0, intPtr(5), (*int)(nil), intPtr(100), (*int)(nil)))
+
+	days, err := repo.ListDailyUsage(context.Background(), from, to)
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	assert.Equal(t, day1, days[0].Date)
+	assert.Equal(t, 40, days[0].Zone1Calls)
+	assert.Equal(t, 100, *days[0].Zone1Limit)
+	assert.Nil(t, days[1].PeakZone2Usage)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -91,7 +91,8 @@ func (r *PostgreSQLArticleRepository) updateMetadataOnly(ctx context.Context, ar
 			title = $4,
 			author = $5,
 			published_at = $6,
-			fetched_at = $7
+			fetched_at = $7,
+			enclosures = COALESCE($8, enclosures)
 		WHERE inoreader_id = $1`
 
 	tag, err := r.pool.Exec(ctx, query,
@@ -102,6 +103,7 @@ func (r *PostgreSQLArticleRepository) updateMetadataOnly(ctx context.Context, ar
 		article.Author,
 		article.PublishedAt,
 		article.FetchedAt,
+		article.Enclosures,
 	)
 	if err != nil {
 		r.logger.Error("Failed to update article metadata",
@@ -125,8 +127,9 @@ func (r *PostgreSQLArticleRepository) updateMetadataOnly(ctx context.Context, ar
 func (r *PostgreSQLArticleRepository) upsertFull(ctx context.Context, article *models.Article) (*UpsertResult, error) {
 	query := `INSERT INTO inoreader_articles (
 			id, inoreader_id, subscription_id, article_url, title, author,
-			published_at, fetched_at, processed, content, content_length, content_type
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			published_at, fetched_at, processed, content, content_length, content_type,
+			enclosures
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		ON CONFLICT (inoreader_id)
 		DO UPDATE SET
 			subscription_id = EXCLUDED.subscription_id,
@@ -137,7 +140,8 @@ func (r *PostgreSQLArticleRepository) upsertFull(ctx context.Context, article *m
 			fetched_at = EXCLUDED.fetched_at,
 			content = EXCLUDED.content,
 			content_length = EXCLUDED.content_length,
-			content_type = EXCLUDED.content_type
+			content_type = EXCLUDED.content_type,
+			enclosures = EXCLUDED.enclosures
 		RETURNING (xmax = 0) AS was_inserted`
 
 	var wasInserted bool
@@ -154,6 +158,7 @@ func (r *PostgreSQLArticleRepository) upsertFull(ctx context.Context, article *m
 		article.Content,
 		article.ContentLength,
 		article.ContentType,
+		article.Enclosures,
 	).Scan(&wasInserted)
 
 	if err != nil {
@@ -326,7 +331,7 @@ func (r *PostgreSQLArticleRepository) FindByInoreaderID(ctx context.Context, ino
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("article not found with inoreader_id: %s", inoreaderID)
+			return nil, fmt.Errorf("inoreader_id %s: %w", inoreaderID, ErrArticleNotFound)
 		}
 		return nil, fmt.Errorf("failed to find article by inoreader_id: %w", err)
 	}
@@ -55,8 +55,9 @@ var (
 	upsertFullQuery = regexp.QuoteMeta(
 		`INSERT INTO inoreader_articles (
 			id, inoreader_id, subscription_id, article_url, title, author,
-			published_at, fetched_at, processed, content, content_length, content_type
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			published_at, fetched_at, processed, content, content_length, content_type,
+			enclosures
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		ON CONFLICT (inoreader_id)
 		DO UPDATE SET
 			subscription_id = EXCLUDED.subscription_id,
@@ -67,7 +68,8 @@ var (
 			fetched_at = EXCLUDED.fetched_at,
 			content = EXCLUDED.content,
 			content_length = EXCLUDED.content_length,
-			content_type = EXCLUDED.content_type
+			content_type = EXCLUDED.content_type,
+			enclosures = EXCLUDED.enclosures
 		RETURNING (xmax = 0) AS was_inserted`,
 	)
 	updateMetadataOnlyQuery = regexp.QuoteMeta(
@@ -77,7 +79,8 @@ var (
 			title = $4,
 			author = $5,
 			published_at = $6,
-			fetched_at = $7
+			fetched_at = $7,
+			enclosures = COALESCE($8, enclosures)
 		WHERE inoreader_id = $1`,
 	)
 )
@@ -98,6 +101,7 @@ func TestCreateWithResult_Insert_NewArticle(t *testing.T) {
 			article.ArticleURL, article.Title, article.Author,
 			article.PublishedAt, article.FetchedAt, article.Processed,
 			article.Content, article.ContentLength, article.ContentType,
+			article.Enclosures,
 		).
 		WillReturnRows(pgxmock.NewRows([]string{"was_inserted"}).AddRow(true))
 
@@ -125,6 +129,7 @@ func TestCreateWithResult_Update_LongerContent(t *testing.T) {
 			article.ArticleURL, article.Title, article.Author,
 			article.PublishedAt, article.FetchedAt, article.Processed,
 			article.Content, article.ContentLength, article.ContentType,
+			article.Enclosures,
 		).
 		WillReturnRows(pgxmock.NewRows([]string{"was_inserted"}).AddRow(false))
 
@@ -150,7 +155,7 @@ func TestCreateWithResult_Update_ShorterContent_PreservesExisting(t *testing.T)
 		WithArgs(
 			article.InoreaderID, article.SubscriptionID,
 			article.ArticleURL, article.Title, article.Author,
-			article.PublishedAt, article.FetchedAt,
+			article.PublishedAt, article.FetchedAt, article.Enclosures,
 		).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
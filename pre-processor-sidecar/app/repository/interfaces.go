@@ -20,6 +20,11 @@ import (
 // discarding the continuation token and re-fetching from scratch.
 var ErrSyncStateNotFound = errors.New("sync state not found")
 
+// ErrArticleNotFound is returned by ArticleRepository.FindByInoreaderID when
+// no row matches, so read-only callers (e.g. the dry-run planner) can tell
+// "would be inserted" apart from a real repository error.
+var ErrArticleNotFound = errors.New("article not found")
+
 // ArticleRepository interface for article database operations
 type ArticleRepository interface {
 	// Create operations
@@ -1,5 +1,6 @@
 // ABOUTME: Kubernetes Secret-based OAuth2TokenRepository implementation
 // ABOUTME: Provides persistent storage for OAuth2 tokens with rotation support
+// ABOUTME: Optionally envelope-encrypts token data so a leaked Secret dump exposes no usable tokens
 
 package repository
 
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"pre-processor-sidecar/models"
+	"pre-processor-sidecar/security"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,8 +27,16 @@ type KubernetesSecretRepository struct {
 	namespace  string
 	secretName string
 	logger     *slog.Logger
+	cipher     *security.TokenCipher
 }
 
+const (
+	// tokenDataKey holds the serialized token, sealed when a cipher is set
+	tokenDataKey = "token_data"
+	// encryptionKeyIDAnnotation records the master key the token was sealed with
+	encryptionKeyIDAnnotation = "pre-processor-sidecar/encryption-key-id"
+)
+
 // NewKubernetesSecretRepository creates a new Kubernetes Secret-based token repository
 func NewKubernetesSecretRepository(
 	namespace, secretName string,
@@ -76,6 +86,14 @@ func NewKubernetesSecretRepositoryWithClientset(
 	}
 }
 
+// WithTokenCipher enables envelope encryption of the stored token. Tokens
+// written before encryption was enabled are still read as plaintext and are
+// sealed on the next write or ReencryptToken call.
+func (r *KubernetesSecretRepository) WithTokenCipher(cipher *security.TokenCipher) *KubernetesSecretRepository {
+	r.cipher = cipher
+	return r
+}
+
 // GetCurrentToken retrieves the current OAuth2 token from Kubernetes Secret
 func (r *KubernetesSecretRepository) GetCurrentToken(ctx context.Context) (*models.OAuth2Token, error) {
 	r.logger.Debug("Retrieving OAuth2 token from Kubernetes Secret",
@@ -92,26 +110,125 @@ func (r *KubernetesSecretRepository) GetCurrentToken(ctx context.Context) (*mode
 		return nil, fmt.Errorf("failed to retrieve token secret: %w", err)
 	}
 
-	// Extract token data from secret
-	tokenDataBytes, exists := secret.Data["token_data"]
+	token, _, err := r.decodeToken(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.Info("Successfully retrieved OAuth2 token from Kubernetes Secret",
+		"expires_at", token.ExpiresAt,
+		"time_until_expiry", token.TimeUntilExpiry(),
+		"is_expired", token.IsExpired())
+
+	return token, nil
+}
+
+// decodeToken extracts the token from secret, opening it if it is sealed,
+// and returns the master key ID it was sealed with ("" for plaintext).
+func (r *KubernetesSecretRepository) decodeToken(ctx context.Context, secret *corev1.Secret) (*models.OAuth2Token, string, error) {
+	tokenDataBytes, exists := secret.Data[tokenDataKey]
 	if !exists {
 		r.logger.Error("Token data not found in secret", "secret_name", r.secretName)
-		return nil, ErrTokenNotFound
+		return nil, "", ErrTokenNotFound
+	}
+
+	var keyID string
+	if security.IsEnvelope(tokenDataBytes) {
+		if r.cipher == nil {
+			r.logger.Error("Token data is encrypted but no token cipher is configured", "secret_name", r.secretName)
+			return nil, "", fmt.Errorf("token data in secret is encrypted and no encryption key is configured")
+		}
+		plaintext, sealedWith, err := r.cipher.Open(ctx, tokenDataBytes, r.tokenAAD())
+		if err != nil {
+			r.logger.Error("Failed to decrypt token data from secret", "error", err, "key_id", sealedWith)
+			return nil, "", fmt.Errorf("failed to decrypt token data: %w", err)
+		}
+		tokenDataBytes, keyID = plaintext, sealedWith
 	}
 
-	// Parse JSON token data
 	var token models.OAuth2Token
 	if err := json.Unmarshal(tokenDataBytes, &token); err != nil {
 		r.logger.Error("Failed to parse token data from secret", "error", err)
-		return nil, fmt.Errorf("invalid token data in secret: %w", err)
+		return nil, "", fmt.Errorf("invalid token data in secret: %w", err)
 	}
+	return &token, keyID, nil
+}
 
-	r.logger.Info("Successfully retrieved OAuth2 token from Kubernetes Secret",
-		"expires_at", token.ExpiresAt,
-		"time_until_expiry", token.TimeUntilExpiry(),
-		"is_expired", token.IsExpired())
+// buildSecretData serializes token into Secret data. With a cipher the token
+// is sealed and the plaintext access_token/refresh_token keys are omitted.
+func (r *KubernetesSecretRepository) buildSecretData(ctx context.Context, token *models.OAuth2Token) (map[string][]byte, error) {
+	tokenBytes, err := json.Marshal(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize token: %w", err)
+	}
+
+	expiresAt := []byte(token.ExpiresAt.Format(time.RFC3339))
+	if r.cipher == nil {
+		return map[string][]byte{
+			tokenDataKey:    tokenBytes,
+			"access_token":  []byte(token.AccessToken),
+			"refresh_token": []byte(token.RefreshToken),
+			"expires_at":    expiresAt,
+		}, nil
+	}
+
+	sealed, err := r.cipher.Seal(ctx, tokenBytes, r.tokenAAD())
+	if err != nil {
+		r.logger.Error("Failed to encrypt token data", "error", err)
+		return nil, fmt.Errorf("failed to encrypt token: %w", err)
+	}
+	return map[string][]byte{
+		tokenDataKey: sealed,
+		"expires_at": expiresAt,
+	}, nil
+}
+
+// tokenAAD binds sealed token data to this Secret, so a ciphertext copied
+// into another Secret does not decrypt.
+func (r *KubernetesSecretRepository) tokenAAD() []byte {
+	return []byte(r.namespace + "/" + r.secretName + "/" + tokenDataKey)
+}
+
+// ReencryptToken re-seals the stored token under the cipher's primary key.
+// It seals plaintext tokens left from before encryption was enabled and
+// moves tokens off a previous master key so that key can be retired, and
+// reports whether the Secret was rewritten.
+func (r *KubernetesSecretRepository) ReencryptToken(ctx context.Context) (bool, error) {
+	if r.cipher == nil {
+		return false, fmt.Errorf("token encryption is not configured")
+	}
+
+	secret, err := r.clientset.CoreV1().Secrets(r.namespace).Get(
+		ctx, r.secretName, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve token secret: %w", err)
+	}
+
+	token, keyID, err := r.decodeToken(ctx, secret)
+	if err != nil {
+		return false, err
+	}
+	if keyID != "" && !r.cipher.NeedsRotation(keyID) {
+		return false, nil
+	}
+
+	secretData, err := r.buildSecretData(ctx, token)
+	if err != nil {
+		return false, err
+	}
+	// Keep rotation audit metadata across the rewrite
+	if rotation, ok := secret.Data["rotation_metadata"]; ok {
+		secretData["rotation_metadata"] = rotation
+	}
+	if err := r.updateSecret(ctx, secretData); err != nil {
+		return false, err
+	}
 
-	return &token, nil
+	r.logger.Info("Re-encrypted OAuth2 token secret",
+		"secret_name", r.secretName,
+		"previous_key_id", keyID,
+		"key_id", r.cipher.PrimaryKeyID())
+	return true, nil
 }
 
 // SaveToken stores a new OAuth2 token to Kubernetes Secret
@@ -124,18 +241,10 @@ func (r *KubernetesSecretRepository) SaveToken(ctx context.Context, token *model
 		"secret_name", r.secretName,
 		"expires_at", token.ExpiresAt)
 
-	// Serialize token to JSON
-	tokenBytes, err := json.Marshal(token)
-	if err != nil {
-		return fmt.Errorf("failed to serialize token: %w", err)
-	}
-
 	// Create or update secret data
-	secretData := map[string][]byte{
-		"token_data":    tokenBytes,
-		"access_token":  []byte(token.AccessToken),
-		"refresh_token": []byte(token.RefreshToken),
-		"expires_at":    []byte(token.ExpiresAt.Format(time.RFC3339)),
+	secretData, err := r.buildSecretData(ctx, token)
+	if err != nil {
+		return err
 	}
 
 	// Try to get existing secret first
@@ -190,18 +299,11 @@ func (r *KubernetesSecretRepository) UpdateWithRefreshRotation(
 	rotationBytes, _ := json.Marshal(rotationData)
 
 	// Update token with rotation tracking
-	tokenBytes, err := json.Marshal(newToken)
+	secretData, err := r.buildSecretData(ctx, newToken)
 	if err != nil {
-		return fmt.Errorf("failed to serialize token: %w", err)
-	}
-
-	secretData := map[string][]byte{
-		"token_data":        tokenBytes,
-		"access_token":      []byte(newToken.AccessToken),
-		"refresh_token":     []byte(newToken.RefreshToken),
-		"expires_at":        []byte(newToken.ExpiresAt.Format(time.RFC3339)),
-		"rotation_metadata": rotationBytes,
+		return err
 	}
+	secretData["rotation_metadata"] = rotationBytes
 
 	return r.updateSecret(ctx, secretData)
 }
@@ -233,10 +335,10 @@ func (r *KubernetesSecretRepository) createSecret(ctx context.Context, data map[
 				"app.kubernetes.io/part-of":    "alt-processing",
 				"app.kubernetes.io/managed-by": "pre-processor-sidecar",
 			},
-			Annotations: map[string]string{
+			Annotations: r.encryptionAnnotations(map[string]string{
 				"pre-processor-sidecar/last-updated":  time.Now().Format(time.RFC3339),
 				"pre-processor-sidecar/token-version": "1",
-			},
+			}),
 		},
 		Type: corev1.SecretTypeOpaque,
 		Data: data,
@@ -267,6 +369,7 @@ func (r *KubernetesSecretRepository) updateSecret(ctx context.Context, data map[
 		currentSecret.Annotations = make(map[string]string)
 	}
 	currentSecret.Annotations["pre-processor-sidecar/last-updated"] = time.Now().Format(time.RFC3339)
+	r.encryptionAnnotations(currentSecret.Annotations)
 
 	// Increment token version for tracking
 	currentVersion := currentSecret.Annotations["pre-processor-sidecar/token-version"]
@@ -288,6 +391,17 @@ func (r *KubernetesSecretRepository) updateSecret(ctx context.Context, data map[
 	return nil
 }
 
+// encryptionAnnotations sets or clears the key ID annotation so operators
+// can see which master key a Secret depends on before retiring one.
+func (r *KubernetesSecretRepository) encryptionAnnotations(annotations map[string]string) map[string]string {
+	if r.cipher != nil {
+		annotations[encryptionKeyIDAnnotation] = r.cipher.PrimaryKeyID()
+	} else {
+		delete(annotations, encryptionKeyIDAnnotation)
+	}
+	return annotations
+}
+
 // IsHealthy checks if the repository can access Kubernetes API
 func (r *KubernetesSecretRepository) IsHealthy(ctx context.Context) error {
 	// Test connectivity by trying to get the secret (or check if it doesn't exist)
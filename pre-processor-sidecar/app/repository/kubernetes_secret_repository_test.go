@@ -4,12 +4,14 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"testing"
 	"time"
 
 	"pre-processor-sidecar/models"
+	"pre-processor-sidecar/security"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -254,6 +256,146 @@ func TestKubernetesSecretRepository_SaveToken_InvalidToken(t *testing.T) {
 	}
 }
 
+func TestKubernetesSecretRepository_EncryptedRoundTrip(t *testing.T) {
+	namespace := "test-namespace"
+	secretName := "test-secret"
+
+	fakeClient := fake.NewSimpleClientset()
+	repo := NewKubernetesSecretRepositoryWithClientset(fakeClient, namespace, secretName, nil).
+		WithTokenCipher(newTestTokenCipher(t, "k1", "k1"))
+
+	token := &models.OAuth2Token{
+		AccessToken:  "test-access-token",
+		RefreshToken: "test-refresh-token",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(1 * time.Hour),
+	}
+	require.NoError(t, repo.SaveToken(context.Background(), token))
+
+	// The stored Secret must not contain usable tokens
+	secret, err := fakeClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, secret.Data, "access_token")
+	assert.NotContains(t, secret.Data, "refresh_token")
+	assert.True(t, security.IsEnvelope(secret.Data["token_data"]))
+	assert.NotContains(t, string(secret.Data["token_data"]), "test-refresh-token")
+	assert.Equal(t, "k1", secret.Annotations[encryptionKeyIDAnnotation])
+
+	retrievedToken, err := repo.GetCurrentToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token.AccessToken, retrievedToken.AccessToken)
+	assert.Equal(t, token.RefreshToken, retrievedToken.RefreshToken)
+
+	// Without the key the sealed token cannot be read
+	plainRepo := NewKubernetesSecretRepositoryWithClientset(fakeClient, namespace, secretName, nil)
+	_, err = plainRepo.GetCurrentToken(context.Background())
+	assert.Error(t, err)
+
+	// A sealed token copied into another Secret does not decrypt
+	copied := secret.DeepCopy()
+	copied.Name = "other-secret"
+	copied.ResourceVersion = ""
+	_, err = fakeClient.CoreV1().Secrets(namespace).Create(context.Background(), copied, metav1.CreateOptions{})
+	require.NoError(t, err)
+	otherRepo := NewKubernetesSecretRepositoryWithClientset(fakeClient, namespace, "other-secret", nil).
+		WithTokenCipher(newTestTokenCipher(t, "k1", "k1"))
+	_, err = otherRepo.GetCurrentToken(context.Background())
+	assert.ErrorIs(t, err, security.ErrDecryptionFailed)
+}
+
+func TestKubernetesSecretRepository_EncryptedRotationDropsPlaintext(t *testing.T) {
+	namespace := "test-namespace"
+	secretName := "test-secret"
+
+	oldToken := &models.OAuth2Token{
+		AccessToken:  "old-access-token",
+		RefreshToken: "old-refresh-token",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(1 * time.Hour),
+	}
+	fakeClient := fake.NewSimpleClientset(createTestSecret(namespace, secretName, oldToken))
+	repo := NewKubernetesSecretRepositoryWithClientset(fakeClient, namespace, secretName, nil).
+		WithTokenCipher(newTestTokenCipher(t, "k1", "k1"))
+
+	newToken := &models.OAuth2Token{
+		AccessToken:  "new-access-token",
+		RefreshToken: "new-refresh-token",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(2 * time.Hour),
+	}
+	require.NoError(t, repo.UpdateWithRefreshRotation(context.Background(), newToken, oldToken.RefreshToken))
+
+	secret, err := fakeClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, secret.Data, "refresh_token")
+	assert.Contains(t, secret.Data, "rotation_metadata")
+	assert.True(t, security.IsEnvelope(secret.Data["token_data"]))
+
+	retrievedToken, err := repo.GetCurrentToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, newToken.RefreshToken, retrievedToken.RefreshToken)
+}
+
+func TestKubernetesSecretRepository_ReencryptToken(t *testing.T) {
+	namespace := "test-namespace"
+	secretName := "test-secret"
+
+	token := &models.OAuth2Token{
+		AccessToken:  "test-access-token",
+		RefreshToken: "test-refresh-token",
+		TokenType:    "Bearer",
+		ExpiresAt:    time.Now().Add(1 * time.Hour),
+	}
+	// Starts as a legacy plaintext Secret
+	fakeClient := fake.NewSimpleClientset(createTestSecret(namespace, secretName, token))
+
+	repo := NewKubernetesSecretRepositoryWithClientset(fakeClient, namespace, secretName, nil).
+		WithTokenCipher(newTestTokenCipher(t, "k1", "k1"))
+
+	// Plaintext is read transparently, then sealed
+	retrievedToken, err := repo.GetCurrentToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token.RefreshToken, retrievedToken.RefreshToken)
+
+	rewritten, err := repo.ReencryptToken(context.Background())
+	require.NoError(t, err)
+	assert.True(t, rewritten)
+
+	rewritten, err = repo.ReencryptToken(context.Background())
+	require.NoError(t, err)
+	assert.False(t, rewritten, "already sealed with the primary key")
+
+	// Rotate the master key: k1 stays in the keyring for reads, k2 is primary
+	rotated := NewKubernetesSecretRepositoryWithClientset(fakeClient, namespace, secretName, nil).
+		WithTokenCipher(newTestTokenCipher(t, "k2", "k1", "k2"))
+	rewritten, err = rotated.ReencryptToken(context.Background())
+	require.NoError(t, err)
+	assert.True(t, rewritten)
+
+	secret, err := fakeClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "k2", secret.Annotations[encryptionKeyIDAnnotation])
+
+	// k1 can now be retired
+	retired := NewKubernetesSecretRepositoryWithClientset(fakeClient, namespace, secretName, nil).
+		WithTokenCipher(newTestTokenCipher(t, "k2", "k2"))
+	retrievedToken, err = retired.GetCurrentToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token.RefreshToken, retrievedToken.RefreshToken)
+}
+
+// newTestTokenCipher builds a cipher over deterministic test master keys
+func newTestTokenCipher(t *testing.T, primary string, keyIDs ...string) *security.TokenCipher {
+	t.Helper()
+	keyring := make(map[string][]byte, len(keyIDs))
+	for _, id := range keyIDs {
+		keyring[id] = bytes.Repeat([]byte(id[len(id)-1:]), 32)
+	}
+	wrapper, err := security.NewLocalKeyWrapper(keyring, primary)
+	require.NoError(t, err)
+	return security.NewTokenCipher(wrapper)
+}
+
 // Helper function to create test secret
 func createTestSecret(namespace, secretName string, token *models.OAuth2Token) *corev1.Secret {
 	tokenBytes, _ := json.Marshal(token)
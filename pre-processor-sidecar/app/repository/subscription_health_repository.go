@@ -0,0 +1,191 @@
+// ABOUTME: PostgreSQL implementation of per-subscription fetch failure tracking
+// ABOUTME: Counts consecutive failures, auto-pauses at a threshold and resumes on operator request
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pre-processor-sidecar/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SubscriptionHealthRepository tracks fetch failures per subscription.
+// Subscriptions are addressed by stream ID (inoreader_subscriptions.inoreader_id)
+// on the fetch path and by database UUID from the Admin API. Stream IDs
+// without a subscription row (e.g. reading-list streams) are not tracked.
+type SubscriptionHealthRepository interface {
+	// RecordFailure counts a failure and pauses the subscription once
+	// consecutive failures reach pauseThreshold (0 never pauses). It returns
+	// nil, nil when streamID does not belong to a subscription.
+	RecordFailure(ctx context.Context, streamID string, kind models.SubscriptionErrorKind, message string, pauseThreshold int) (*models.SubscriptionHealth, error)
+	// RecordSuccess resets the consecutive failure count of a subscription
+	// that has failed before. It does not resume a paused subscription.
+	RecordSuccess(ctx context.Context, streamID string) error
+	IsPaused(ctx context.Context, streamID string) (bool, error)
+	ListPaused(ctx context.Context) ([]*models.SubscriptionHealth, error)
+	// Resume clears the pause and the consecutive failure count. It reports
+	// false when the subscription was not paused.
+	Resume(ctx context.Context, subscriptionID uuid.UUID) (bool, error)
+}
+
+// PostgreSQLSubscriptionHealthRepository implements SubscriptionHealthRepository using PostgreSQL
+type PostgreSQLSubscriptionHealthRepository struct {
+	pool   PgxIface
+	logger *slog.Logger
+}
+
+// NewPostgreSQLSubscriptionHealthRepository creates a new PostgreSQL subscription health repository
+func NewPostgreSQLSubscriptionHealthRepository(pool PgxIface, logger *slog.Logger) SubscriptionHealthRepository {
+	return &PostgreSQLSubscriptionHealthRepository{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// RecordFailure upserts the failure in one statement so concurrent fetch
+// paths cannot lose an increment or pause twice.
+func (r *PostgreSQLSubscriptionHealthRepository) RecordFailure(ctx context.Context, streamID string, kind models.SubscriptionErrorKind, message string, pauseThreshold int) (*models.SubscriptionHealth, error) {
+	query := `
+		INSERT INTO inoreader_subscription_health AS h (
+			subscription_id, consecutive_failures, total_failures,
+			last_error_kind, last_error, last_failure_at, paused_at, updated_at
+		)
+		SELECT s.id, 1, 1, $2, $3, NOW(),
+			CASE WHEN $4::int > 0 AND $4::int <= 1 THEN NOW() END, NOW()
+		FROM inoreader_subscriptions s
+		WHERE s.inoreader_id = $1
+		ON CONFLICT (subscription_id) DO UPDATE SET
+			consecutive_failures = h.consecutive_failures + 1,
+			total_failures = h.total_failures + 1,
+			last_error_kind = EXCLUDED.last_error_kind,
+			last_error = EXCLUDED.last_error,
+			last_failure_at = EXCLUDED.last_failure_at,
+			paused_at = COALESCE(h.paused_at,
+				CASE WHEN $4::int > 0 AND h.consecutive_failures + 1 >= $4::int THEN NOW() END),
+			updated_at = NOW()
+		RETURNING h.subscription_id, h.consecutive_failures, h.total_failures, h.last_failure_at, h.paused_at`
+
+	health := &models.SubscriptionHealth{
+		InoreaderID:   streamID,
+		LastErrorKind: kind,
+		LastError:     message,
+	}
+	err := r.pool.QueryRow(ctx, query, streamID, string(kind), message, pauseThreshold).Scan(
+		&health.SubscriptionID,
+		&health.ConsecutiveFailures,
+		&health.TotalFailures,
+		&health.LastFailureAt,
+		&health.PausedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to record subscription failure: %w", err)
+	}
+
+	return health, nil
+}
+
+// RecordSuccess resets the failure streak of a previously failing subscription
+func (r *PostgreSQLSubscriptionHealthRepository) RecordSuccess(ctx context.Context, streamID string) error {
+	query := `
+		UPDATE inoreader_subscription_health h
+		SET consecutive_failures = 0, last_success_at = NOW(), updated_at = NOW()
+		FROM inoreader_subscriptions s
+		WHERE s.id = h.subscription_id
+		  AND s.inoreader_id = $1
+		  AND h.consecutive_failures > 0`
+
+	if _, err := r.pool.Exec(ctx, query, streamID); err != nil {
+		return fmt.Errorf("failed to record subscription success: %w", err)
+	}
+
+	return nil
+}
+
+// IsPaused reports whether the subscription behind streamID is paused
+func (r *PostgreSQLSubscriptionHealthRepository) IsPaused(ctx context.Context, streamID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM inoreader_subscription_health h
+			JOIN inoreader_subscriptions s ON s.id = h.subscription_id
+			WHERE s.inoreader_id = $1 AND h.paused_at IS NOT NULL
+		)`
+
+	var paused bool
+	if err := r.pool.QueryRow(ctx, query, streamID).Scan(&paused); err != nil {
+		return false, fmt.Errorf("failed to check subscription pause state: %w", err)
+	}
+
+	return paused, nil
+}
+
+// ListPaused returns paused subscriptions, most recently paused first
+func (r *PostgreSQLSubscriptionHealthRepository) ListPaused(ctx context.Context) ([]*models.SubscriptionHealth, error) {
+	query := `
+		SELECT h.subscription_id, s.inoreader_id, COALESCE(s.title, ''),
+		       h.consecutive_failures, h.total_failures,
+		       COALESCE(h.last_error_kind, ''), COALESCE(h.last_error, ''),
+		       h.last_failure_at, h.last_success_at, h.paused_at
+		FROM inoreader_subscription_health h
+		JOIN inoreader_subscriptions s ON s.id = h.subscription_id
+		WHERE h.paused_at IS NOT NULL
+		ORDER BY h.paused_at DESC`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query paused subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	paused := []*models.SubscriptionHealth{}
+	for rows.Next() {
+		var health models.SubscriptionHealth
+		var kind string
+		if err := rows.Scan(
+			&health.SubscriptionID,
+			&health.InoreaderID,
+			&health.Title,
+			&health.ConsecutiveFailures,
+			&health.TotalFailures,
+			&kind,
+			&health.LastError,
+			&health.LastFailureAt,
+			&health.LastSuccessAt,
+			&health.PausedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan paused subscription: %w", err)
+		}
+		health.LastErrorKind = models.SubscriptionErrorKind(kind)
+		paused = append(paused, &health)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return paused, nil
+}
+
+// Resume puts a paused subscription back into the fetch rotation
+func (r *PostgreSQLSubscriptionHealthRepository) Resume(ctx context.Context, subscriptionID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE inoreader_subscription_health
+		SET paused_at = NULL, consecutive_failures = 0, updated_at = NOW()
+		WHERE subscription_id = $1 AND paused_at IS NOT NULL`
+
+	tag, err := r.pool.Exec(ctx, query, subscriptionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to resume subscription: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
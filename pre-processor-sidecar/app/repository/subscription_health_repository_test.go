@@ -0,0 +1,84 @@
+// ABOUTME: Tests for the PostgreSQL-backed subscription health repository
+// ABOUTME: Verifies failure counting, auto-pause and resume round-trip through Postgres
+
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pre-processor-sidecar/models"
+)
+
+func newTestSubscriptionHealthRepo(t *testing.T) (SubscriptionHealthRepository, pgxmock.PgxPoolIface) {
+	t.Helper()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	return NewPostgreSQLSubscriptionHealthRepository(mock, slog.Default()), mock
+}
+
+func TestRecordFailure_PausesAtThreshold(t *testing.T) {
+	repo, mock := newTestSubscriptionHealthRepo(t)
+
+	subscriptionID := uuid.New()
+	now := time.Now()
+	streamID := "feed/https://gone.example.com/rss"
+
+	mock.ExpectQuery(`INSERT INTO inoreader_subscription_health`).
+		WithArgs(streamID, "not_found", "API request failed with status 404", 5).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"subscription_id", "consecutive_failures", "total_failures", "last_failure_at", "paused_at",
+		}).AddRow(subscriptionID, 5, 7, &now, &now))
+
+	health, err := repo.RecordFailure(context.Background(), streamID, models.SubscriptionErrorNotFound, "API request failed with status 404", 5)
+	require.NoError(t, err)
+	require.NotNil(t, health)
+	assert.Equal(t, subscriptionID, health.SubscriptionID)
+	assert.Equal(t, 5, health.ConsecutiveFailures)
+	assert.True(t, health.IsPaused())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordFailure_UnknownStream(t *testing.T) {
+	repo, mock := newTestSubscriptionHealthRepo(t)
+
+	mock.ExpectQuery(`INSERT INTO inoreader_subscription_health`).
+		WithArgs("user/-/state/com.google/reading-list", "parse_error", "bad json", 5).
+		WillReturnError(pgx.ErrNoRows)
+
+	health, err := repo.RecordFailure(context.Background(), "user/-/state/com.google/reading-list", models.SubscriptionErrorParse, "bad json", 5)
+	require.NoError(t, err)
+	assert.Nil(t, health)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResume(t *testing.T) {
+	repo, mock := newTestSubscriptionHealthRepo(t)
+	subscriptionID := uuid.New()
+
+	mock.ExpectExec(`UPDATE inoreader_subscription_health`).
+		WithArgs(subscriptionID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`UPDATE inoreader_subscription_health`).
+		WithArgs(subscriptionID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	resumed, err := repo.Resume(context.Background(), subscriptionID)
+	require.NoError(t, err)
+	assert.True(t, resumed)
+
+	resumed, err = repo.Resume(context.Background(), subscriptionID)
+	require.NoError(t, err)
+	assert.False(t, resumed, "resuming an active subscription reports false")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
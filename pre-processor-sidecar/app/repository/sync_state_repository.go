@@ -130,12 +130,21 @@ func (r *PostgreSQLSyncStateRepository) GetStaleStates(ctx context.Context, olde
 	return r.querySyncStates(ctx, query, olderThan)
 }
 
-// GetOldestOne retrieves the single most outdated sync state
+// GetOldestOne retrieves the single most outdated sync state. Streams of
+// paused subscriptions are skipped: a failing fetch never advances last_sync,
+// so without the filter the scheduler would pick the same broken stream on
+// every tick.
 func (r *PostgreSQLSyncStateRepository) GetOldestOne(ctx context.Context) (*models.SyncState, error) {
 	query := `
-		SELECT id, stream_id, continuation_token, last_sync
-		FROM sync_state
-		ORDER BY last_sync ASC
+		SELECT ss.id, ss.stream_id, ss.continuation_token, ss.last_sync
+		FROM sync_state ss
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM inoreader_subscription_health h
+			JOIN inoreader_subscriptions s ON s.id = h.subscription_id
+			WHERE s.inoreader_id = ss.stream_id AND h.paused_at IS NOT NULL
+		)
+		ORDER BY ss.last_sync ASC
 		LIMIT 1`
 
 	var syncState models.SyncState
@@ -0,0 +1,310 @@
+// ABOUTME: Envelope encryption for OAuth2 tokens stored at rest
+// ABOUTME: Per-write AES-256-GCM data keys wrapped by a rotatable master key (env keyring or KMS)
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnvelopeVersion is the format version written into every envelope.
+const EnvelopeVersion = 1
+
+// masterKeySize is the AES-256 key length required for master and data keys.
+const masterKeySize = 32
+
+var (
+	// ErrUnknownKeyID means an envelope was sealed with a master key that is
+	// no longer in the keyring.
+	ErrUnknownKeyID = errors.New("unknown master key id")
+	// ErrDecryptionFailed covers tampered ciphertext, a wrong key, or data
+	// bound to a different context.
+	ErrDecryptionFailed = errors.New("token decryption failed")
+)
+
+// KeyWrapper wraps and unwraps data keys with a master key. The env keyring
+// (LocalKeyWrapper) implements it directly; a KMS client implements it by
+// calling the KMS Encrypt/Decrypt APIs, so the master key never leaves it.
+type KeyWrapper interface {
+	// WrapKey encrypts dataKey with the current master key and returns
+	// that key's ID with the wrapped bytes.
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey decrypts a data key wrapped by the master key keyID.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+	// PrimaryKeyID is the master key new envelopes are sealed with.
+	PrimaryKeyID() string
+}
+
+// Envelope is the serialized form of an encrypted value. []byte fields are
+// base64 in JSON.
+type Envelope struct {
+	Version    int    `json:"v"`
+	KeyID      string `json:"kid"`
+	WrappedKey []byte `json:"wrapped_key"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// TokenCipher seals values with envelope encryption: every call generates a
+// fresh data key, encrypts the value with it, and stores the data key
+// wrapped by the master key alongside the ciphertext.
+type TokenCipher struct {
+	wrapper KeyWrapper
+}
+
+// NewTokenCipher creates a TokenCipher using wrapper for master keys.
+func NewTokenCipher(wrapper KeyWrapper) *TokenCipher {
+	return &TokenCipher{wrapper: wrapper}
+}
+
+// Seal encrypts plaintext. aad is authenticated but not stored; Open must
+// be given the same aad, which binds the ciphertext to where it is stored.
+func (c *TokenCipher) Seal(ctx context.Context, plaintext, aad []byte) ([]byte, error) {
+	dataKey := make([]byte, masterKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+
+	nonce, ciphertext, err := gcmSeal(dataKey, plaintext, aad)
+	if err != nil {
+		return nil, err
+	}
+	keyID, wrapped, err := c.wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrap data key: %w", err)
+	}
+
+	return json.Marshal(Envelope{
+		Version:    EnvelopeVersion,
+		KeyID:      keyID,
+		WrappedKey: wrapped,
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	})
+}
+
+// Open decrypts a value produced by Seal and reports the master key ID it
+// was sealed with.
+func (c *TokenCipher) Open(ctx context.Context, sealed, aad []byte) (plaintext []byte, keyID string, err error) {
+	env, err := ParseEnvelope(sealed)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dataKey, err := c.wrapper.UnwrapKey(ctx, env.KeyID, env.WrappedKey)
+	if err != nil {
+		return nil, env.KeyID, fmt.Errorf("unwrap data key: %w", err)
+	}
+	plaintext, err = gcmOpen(dataKey, env.Nonce, env.Ciphertext, aad)
+	if err != nil {
+		return nil, env.KeyID, err
+	}
+	return plaintext, env.KeyID, nil
+}
+
+// NeedsRotation reports whether a value sealed with keyID should be
+// re-sealed under the current primary key.
+func (c *TokenCipher) NeedsRotation(keyID string) bool {
+	return keyID != c.wrapper.PrimaryKeyID()
+}
+
+// PrimaryKeyID returns the master key ID new values are sealed with.
+func (c *TokenCipher) PrimaryKeyID() string {
+	return c.wrapper.PrimaryKeyID()
+}
+
+// IsEnvelope reports whether data looks like a sealed envelope rather than
+// plaintext written before encryption was enabled.
+func IsEnvelope(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return false
+	}
+	var probe struct {
+		Version int    `json:"v"`
+		KeyID   string `json:"kid"`
+	}
+	return json.Unmarshal(data, &probe) == nil && probe.Version > 0 && probe.KeyID != ""
+}
+
+// ParseEnvelope decodes and validates a sealed envelope.
+func ParseEnvelope(sealed []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(sealed, &env); err != nil {
+		return nil, fmt.Errorf("parse envelope: %w", err)
+	}
+	if env.Version != EnvelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version %d", env.Version)
+	}
+	if env.KeyID == "" || len(env.WrappedKey) == 0 || len(env.Nonce) == 0 {
+		return nil, errors.New("incomplete envelope")
+	}
+	return &env, nil
+}
+
+// LocalKeyWrapper wraps data keys with AES-256-GCM master keys held in
+// process memory, loaded from the environment. Keeping retired keys in the
+// keyring lets values sealed before a rotation still be opened.
+type LocalKeyWrapper struct {
+	keys    map[string][]byte
+	primary string
+}
+
+// NewLocalKeyWrapper creates a wrapper from keyring (key ID to 32-byte key)
+// sealing new values with primary.
+func NewLocalKeyWrapper(keyring map[string][]byte, primary string) (*LocalKeyWrapper, error) {
+	if len(keyring) == 0 {
+		return nil, errors.New("master keyring is empty")
+	}
+	for id, key := range keyring {
+		if id == "" {
+			return nil, errors.New("master key id cannot be empty")
+		}
+		if len(key) != masterKeySize {
+			return nil, fmt.Errorf("master key %q must be %d bytes, got %d", id, masterKeySize, len(key))
+		}
+	}
+	if _, ok := keyring[primary]; !ok {
+		return nil, fmt.Errorf("primary master key %q is not in the keyring", primary)
+	}
+	return &LocalKeyWrapper{keys: keyring, primary: primary}, nil
+}
+
+// ParseKeyring parses "id:base64key" entries separated by commas, e.g.
+// "2026-10:AbC...=,2026-04:XyZ...=".
+func ParseKeyring(spec string) (map[string][]byte, error) {
+	keyring := make(map[string][]byte)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("keyring entry must be id:base64key")
+		}
+		id = strings.TrimSpace(id)
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("master key %q: invalid base64: %w", id, err)
+		}
+		if _, dup := keyring[id]; dup {
+			return nil, fmt.Errorf("duplicate master key id %q", id)
+		}
+		keyring[id] = key
+	}
+	return keyring, nil
+}
+
+// NewEnvTokenCipher builds a TokenCipher from a keyring spec (see
+// ParseKeyring) and primary key ID, as read from the environment. It returns
+// nil without error when spec is empty, meaning encryption is disabled. When
+// primary is empty a single-key keyring uses its only key.
+func NewEnvTokenCipher(spec, primary string) (*TokenCipher, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	keyring, err := ParseKeyring(spec)
+	if err != nil {
+		return nil, err
+	}
+	if primary == "" && len(keyring) == 1 {
+		for id := range keyring {
+			primary = id
+		}
+	}
+	wrapper, err := NewLocalKeyWrapper(keyring, primary)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenCipher(wrapper), nil
+}
+
+// WrapKey implements KeyWrapper.
+func (w *LocalKeyWrapper) WrapKey(_ context.Context, dataKey []byte) (string, []byte, error) {
+	nonce, ciphertext, err := gcmSeal(w.keys[w.primary], dataKey, []byte(w.primary))
+	if err != nil {
+		return "", nil, err
+	}
+	return w.primary, append(nonce, ciphertext...), nil
+}
+
+// UnwrapKey implements KeyWrapper.
+func (w *LocalKeyWrapper) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := w.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, keyID)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < gcm.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+	return gcmOpen(key, wrapped[:gcm.NonceSize()], wrapped[gcm.NonceSize():], []byte(keyID))
+}
+
+// PrimaryKeyID implements KeyWrapper.
+func (w *LocalKeyWrapper) PrimaryKeyID() string {
+	return w.primary
+}
+
+// KeyIDs lists the keyring's key IDs, sorted.
+func (w *LocalKeyWrapper) KeyIDs() []string {
+	ids := make([]string, 0, len(w.keys))
+	for id := range w.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func gcmSeal(key, plaintext, aad []byte) (nonce, ciphertext []byte, err error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create GCM: %w", err)
+	}
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return nonce, gcm.Seal(nil, nonce, plaintext, aad), nil
+}
+
+func gcmOpen(key, nonce, ciphertext, aad []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM: %w", err)
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
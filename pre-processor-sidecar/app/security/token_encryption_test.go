@@ -0,0 +1,168 @@
+// ABOUTME: This file tests envelope encryption for OAuth2 tokens at rest
+// ABOUTME: Covers round trips, AAD binding, tamper detection and master key rotation
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMasterKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, masterKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func newTestCipher(t *testing.T, keyring map[string][]byte, primary string) *TokenCipher {
+	t.Helper()
+	wrapper, err := NewLocalKeyWrapper(keyring, primary)
+	require.NoError(t, err)
+	return NewTokenCipher(wrapper)
+}
+
+func TestTokenCipher_RoundTrip(t *testing.T) {
+	c := newTestCipher(t, map[string][]byte{"k1": testMasterKey(t)}, "k1")
+	ctx := context.Background()
+	plaintext := []byte(`{"refresh_token":"rt-secret"}`)
+	aad := []byte("ns/secret/token_data")
+
+	sealed, err := c.Seal(ctx, plaintext, aad)
+	require.NoError(t, err)
+	assert.True(t, IsEnvelope(sealed))
+	assert.False(t, bytes.Contains(sealed, []byte("rt-secret")))
+
+	opened, keyID, err := c.Open(ctx, sealed, aad)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+	assert.Equal(t, "k1", keyID)
+}
+
+func TestTokenCipher_FreshDataKeyPerSeal(t *testing.T) {
+	c := newTestCipher(t, map[string][]byte{"k1": testMasterKey(t)}, "k1")
+
+	a, err := c.Seal(context.Background(), []byte("same"), nil)
+	require.NoError(t, err)
+	b, err := c.Seal(context.Background(), []byte("same"), nil)
+	require.NoError(t, err)
+
+	envA, err := ParseEnvelope(a)
+	require.NoError(t, err)
+	envB, err := ParseEnvelope(b)
+	require.NoError(t, err)
+	assert.NotEqual(t, envA.WrappedKey, envB.WrappedKey)
+	assert.NotEqual(t, envA.Ciphertext, envB.Ciphertext)
+}
+
+func TestTokenCipher_RejectsWrongAADAndTampering(t *testing.T) {
+	c := newTestCipher(t, map[string][]byte{"k1": testMasterKey(t)}, "k1")
+	ctx := context.Background()
+
+	sealed, err := c.Seal(ctx, []byte("token"), []byte("ns/a/token_data"))
+	require.NoError(t, err)
+
+	_, _, err = c.Open(ctx, sealed, []byte("ns/b/token_data"))
+	assert.True(t, errors.Is(err, ErrDecryptionFailed))
+
+	env, err := ParseEnvelope(sealed)
+	require.NoError(t, err)
+	env.Ciphertext[0] ^= 0xff
+	tampered, err := json.Marshal(env)
+	require.NoError(t, err)
+	_, _, err = c.Open(ctx, tampered, []byte("ns/a/token_data"))
+	assert.True(t, errors.Is(err, ErrDecryptionFailed))
+}
+
+func TestTokenCipher_KeyRotation(t *testing.T) {
+	oldKey, newKey := testMasterKey(t), testMasterKey(t)
+	ctx := context.Background()
+
+	before := newTestCipher(t, map[string][]byte{"old": oldKey}, "old")
+	sealed, err := before.Seal(ctx, []byte("token"), nil)
+	require.NoError(t, err)
+
+	after := newTestCipher(t, map[string][]byte{"old": oldKey, "new": newKey}, "new")
+	opened, keyID, err := after.Open(ctx, sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("token"), opened)
+	assert.Equal(t, "old", keyID)
+	assert.True(t, after.NeedsRotation(keyID))
+
+	resealed, err := after.Seal(ctx, opened, nil)
+	require.NoError(t, err)
+	_, keyID, err = after.Open(ctx, resealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "new", keyID)
+	assert.False(t, after.NeedsRotation(keyID))
+
+	retired := newTestCipher(t, map[string][]byte{"new": newKey}, "new")
+	_, _, err = retired.Open(ctx, sealed, nil)
+	assert.True(t, errors.Is(err, ErrUnknownKeyID))
+}
+
+func TestIsEnvelope(t *testing.T) {
+	assert.False(t, IsEnvelope([]byte(`{"access_token":"at","refresh_token":"rt"}`)))
+	assert.False(t, IsEnvelope([]byte("not json")))
+	assert.False(t, IsEnvelope(nil))
+	assert.True(t, IsEnvelope([]byte(`{"v":1,"kid":"k1","wrapped_key":"AA==","nonce":"AA==","ciphertext":"AA=="}`)))
+}
+
+func TestParseKeyring(t *testing.T) {
+	k1, k2 := testMasterKey(t), testMasterKey(t)
+	spec := "k1:" + base64.StdEncoding.EncodeToString(k1) + ", k2:" + base64.StdEncoding.EncodeToString(k2)
+
+	keyring, err := ParseKeyring(spec)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"k1": k1, "k2": k2}, keyring)
+
+	_, err = ParseKeyring("k1")
+	assert.Error(t, err)
+	_, err = ParseKeyring("k1:!!!")
+	assert.Error(t, err)
+	_, err = ParseKeyring(spec + ",k1:" + base64.StdEncoding.EncodeToString(k1))
+	assert.Error(t, err)
+}
+
+func TestNewLocalKeyWrapper_Validation(t *testing.T) {
+	_, err := NewLocalKeyWrapper(nil, "k1")
+	assert.Error(t, err)
+
+	_, err = NewLocalKeyWrapper(map[string][]byte{"k1": []byte("short")}, "k1")
+	assert.Error(t, err)
+
+	_, err = NewLocalKeyWrapper(map[string][]byte{"k1": testMasterKey(t)}, "k2")
+	assert.Error(t, err)
+
+	w, err := NewLocalKeyWrapper(map[string][]byte{"b": testMasterKey(t), "a": testMasterKey(t)}, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, w.KeyIDs())
+}
+
+func TestNewEnvTokenCipher(t *testing.T) {
+	c, err := NewEnvTokenCipher("", "")
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	spec := "only:" + base64.StdEncoding.EncodeToString(testMasterKey(t))
+	c, err = NewEnvTokenCipher(spec, "")
+	require.NoError(t, err)
+	assert.Equal(t, "only", c.PrimaryKeyID())
+
+	spec += ",other:" + base64.StdEncoding.EncodeToString(testMasterKey(t))
+	_, err = NewEnvTokenCipher(spec, "")
+	assert.Error(t, err, "primary is ambiguous with several keys")
+
+	c, err = NewEnvTokenCipher(spec, "other")
+	require.NoError(t, err)
+	assert.Equal(t, "other", c.PrimaryKeyID())
+}
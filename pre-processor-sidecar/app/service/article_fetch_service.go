@@ -87,6 +87,10 @@ type ArticleFetchService struct {
 	// Phase 3: Rotation processing components
 	subscriptionRotator *SubscriptionRotator // 40 subscriptions rotation processor
 	rotationEnabled     bool                 // Enable rotation mode
+
+	// Per-subscription failure tracking and auto-pause (optional)
+	healthRepo     repository.SubscriptionHealthRepository
+	pauseThreshold int
 }
 
 // SlogAdapter adapts slog.Logger to domain.LoggerInterface
@@ -161,6 +165,11 @@ func (s *ArticleFetchService) FetchArticles(ctx context.Context, streamID string
 		Errors:   []string{},
 	}
 
+	// Step 1: Paused subscriptions are skipped before spending an API call
+	if err := s.checkSubscriptionPaused(ctx, streamID); err != nil {
+		return nil, err
+	}
+
 	// DEPRECATED: Old subscription mapping approach - now handled by Clean Architecture use case
 
 	// Step 2: Get existing sync state for continuation token. Only a genuine
@@ -189,8 +198,10 @@ func (s *ArticleFetchService) FetchArticles(ctx context.Context, streamID string
 	articles, nextToken, err := s.inoreaderService.FetchStreamContents(ctx, streamID, continuationToken)
 	if err != nil {
 		s.logger.Error("Failed to fetch articles from Inoreader API", "error", err, "stream_id", streamID)
+		s.recordSubscriptionFailure(ctx, streamID, err)
 		return nil, fmt.Errorf("failed to fetch articles from stream %s: %w", streamID, err)
 	}
+	s.recordSubscriptionSuccess(ctx, streamID)
 
 	s.logger.Info("Fetched articles from Inoreader API",
 		"stream_id", streamID,
@@ -518,6 +529,9 @@ func (s *ArticleFetchService) ProcessNextSubscriptionRotation(ctx context.Contex
 
 	// Fetch articles for this subscription (max 100 articles)
 	result, err := s.FetchArticles(ctx, streamID, 100)
+	if errors.Is(err, ErrSubscriptionPaused) {
+		return nil
+	}
 	if err != nil {
 		s.logger.Error("Failed to fetch articles for rotated subscription",
 			"subscription_id", subID,
@@ -597,8 +611,9 @@ func (s *ArticleFetchService) ProcessSubscriptionBatch(ctx context.Context, subs
 	s.logger.Info("Starting batch subscription processing",
 		"batch_size", len(subscriptionIDs))
 
-	var errors []string
+	var failures []string
 	successCount := 0
+	pausedCount := 0
 
 	for i, subscriptionID := range subscriptionIDs {
 		s.logger.Debug("Processing subscription in batch",
@@ -608,12 +623,14 @@ func (s *ArticleFetchService) ProcessSubscriptionBatch(ctx context.Context, subs
 
 		// Use existing single subscription processing logic
 		result, err := s.FetchSingleSubscriptionArticles(ctx, subscriptionID)
-		if err != nil {
+		if errors.Is(err, ErrSubscriptionPaused) {
+			pausedCount++
+		} else if err != nil {
 			errorMsg := fmt.Sprintf("Failed to process subscription %s: %v", subscriptionID, err)
 			s.logger.Error("Batch processing error",
 				"subscription_id", subscriptionID,
 				"error", err)
-			errors = append(errors, errorMsg)
+			failures = append(failures, errorMsg)
 		} else {
 			successCount++
 			s.logger.Debug("Successfully processed subscription in batch",
@@ -635,10 +652,11 @@ func (s *ArticleFetchService) ProcessSubscriptionBatch(ctx context.Context, subs
 	s.logger.Info("Batch subscription processing completed",
 		"total_processed", len(subscriptionIDs),
 		"successful", successCount,
-		"failed", len(errors))
+		"paused", pausedCount,
+		"failed", len(failures))
 
-	if len(errors) > 0 {
-		return fmt.Errorf("batch processing completed with errors: %v", errors)
+	if len(failures) > 0 {
+		return fmt.Errorf("batch processing completed with errors: %v", failures)
 	}
 
 	return nil
@@ -0,0 +1,279 @@
+// ABOUTME: Dry-run planning for article fetches and subscription syncs
+// ABOUTME: Calls the Inoreader API for real but reports the Postgres writes instead of performing them
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pre-processor-sidecar/models"
+	"pre-processor-sidecar/repository"
+)
+
+// Dry-run actions reported per article / subscription.
+const (
+	DryRunActionInsert    = "insert"
+	DryRunActionUpdate    = "update"
+	DryRunActionUnchanged = "unchanged"
+	DryRunActionSkip      = "skip"
+)
+
+// DryRunItem is one row the real run would have written (or skipped).
+type DryRunItem struct {
+	Action      string `json:"action"`
+	InoreaderID string `json:"inoreader_id"`
+	Title       string `json:"title,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// ArticleFetchDryRunReport describes what FetchArticles would have written
+// for a single stream.
+type ArticleFetchDryRunReport struct {
+	StreamID              string        `json:"stream_id"`
+	ContinuationToken     string        `json:"continuation_token,omitempty"`
+	NextContinuationToken string        `json:"next_continuation_token,omitempty"`
+	SyncStateAction       string        `json:"sync_state_action"`
+	Fetched               int           `json:"fetched"`
+	FilteredNonTier1      int           `json:"filtered_non_tier1"`
+	WouldInsert           int           `json:"would_insert"`
+	WouldUpdate           int           `json:"would_update"`
+	WouldSkip             int           `json:"would_skip"`
+	SubscriptionsToCreate []string      `json:"subscriptions_to_create,omitempty"`
+	Articles              []DryRunItem  `json:"articles"`
+	Duration              time.Duration `json:"duration"`
+}
+
+// SubscriptionSyncDryRunReport describes what SyncSubscriptions would have
+// written.
+type SubscriptionSyncDryRunReport struct {
+	Fetched            int           `json:"fetched"`
+	WouldCreate        int           `json:"would_create"`
+	WouldUpdate        int           `json:"would_update"`
+	Unchanged          int           `json:"unchanged"`
+	SyncStatesToCreate int           `json:"sync_states_to_create"`
+	Subscriptions      []DryRunItem  `json:"subscriptions"`
+	Duration           time.Duration `json:"duration"`
+}
+
+// DryRunFetchArticles runs the FetchArticles pipeline against the Inoreader
+// API — sync state lookup, stream contents, UUID resolution and Tier1
+// filtering — but only reads from Postgres. Unknown origin streams are
+// reported instead of auto-created, and the continuation token is left
+// untouched so the next real fetch starts from the same position.
+func (s *ArticleFetchService) DryRunFetchArticles(ctx context.Context, streamID string) (*ArticleFetchDryRunReport, error) {
+	startTime := time.Now()
+	report := &ArticleFetchDryRunReport{
+		StreamID:        streamID,
+		SyncStateAction: DryRunActionInsert,
+		Articles:        []DryRunItem{},
+	}
+
+	syncState, err := s.syncStateRepo.FindByStreamID(ctx, streamID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrSyncStateNotFound) {
+			return nil, fmt.Errorf("failed to load sync state for stream %s: %w", streamID, err)
+		}
+		syncState = nil
+	}
+	if syncState != nil {
+		report.ContinuationToken = syncState.ContinuationToken
+		report.SyncStateAction = DryRunActionUpdate
+	}
+
+	articles, nextToken, err := s.inoreaderService.FetchStreamContents(ctx, streamID, report.ContinuationToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch articles from stream %s: %w", streamID, err)
+	}
+	report.Fetched = len(articles)
+	report.NextContinuationToken = nextToken
+
+	subscriptions, err := s.subscriptionRepo.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subscriptions for mapping: %w", err)
+	}
+	report.SubscriptionsToCreate = resolveArticleSubscriptionsReadOnly(articles, subscriptions)
+
+	filterResult := FilterTier1Articles(articles, s.logger)
+	report.FilteredNonTier1 = filterResult.Filtered
+
+	for _, article := range filterResult.Tier1 {
+		item, err := s.planArticleWrite(ctx, article)
+		if err != nil {
+			return nil, err
+		}
+		switch item.Action {
+		case DryRunActionInsert:
+			report.WouldInsert++
+		case DryRunActionUpdate:
+			report.WouldUpdate++
+		default:
+			report.WouldSkip++
+		}
+		report.Articles = append(report.Articles, item)
+	}
+
+	report.Duration = time.Since(startTime)
+
+	s.logger.Info("Article fetch dry-run completed",
+		"stream_id", streamID,
+		"fetched", report.Fetched,
+		"would_insert", report.WouldInsert,
+		"would_update", report.WouldUpdate,
+		"would_skip", report.WouldSkip,
+		"subscriptions_to_create", len(report.SubscriptionsToCreate))
+
+	return report, nil
+}
+
+// planArticleWrite mirrors the validation and upsert decision made by
+// ArticleRepository.CreateBatch without writing anything.
+func (s *ArticleFetchService) planArticleWrite(ctx context.Context, article *models.Article) (DryRunItem, error) {
+	item := DryRunItem{InoreaderID: article.InoreaderID, Title: article.Title}
+
+	switch {
+	case article.InoreaderID == "":
+		item.Action, item.Reason = DryRunActionSkip, "empty inoreader_id"
+		return item, nil
+	case article.ArticleURL == "":
+		item.Action, item.Reason = DryRunActionSkip, "empty article_url"
+		return item, nil
+	}
+
+	existing, err := s.articleRepo.FindByInoreaderID(ctx, article.InoreaderID)
+	switch {
+	case err == nil:
+		item.Action = DryRunActionUpdate
+		if existing.ContentLength > article.ContentLength {
+			item.Reason = "existing longer content kept; metadata only"
+		}
+	case errors.Is(err, repository.ErrArticleNotFound):
+		item.Action = DryRunActionInsert
+	default:
+		return item, fmt.Errorf("failed to look up article %s: %w", article.InoreaderID, err)
+	}
+
+	return item, nil
+}
+
+// resolveArticleSubscriptionsReadOnly assigns SubscriptionID from the
+// existing subscriptions and returns the origin streams the real run would
+// auto-create, in first-seen order.
+func resolveArticleSubscriptionsReadOnly(articles []*models.Article, subscriptions []models.InoreaderSubscription) []string {
+	known := make(map[string]models.InoreaderSubscription, len(subscriptions))
+	for _, sub := range subscriptions {
+		known[sub.InoreaderID] = sub
+	}
+
+	var toCreate []string
+	seen := make(map[string]bool)
+	for _, article := range articles {
+		if article.OriginStreamID == "" {
+			continue
+		}
+		if sub, ok := known[article.OriginStreamID]; ok {
+			article.SubscriptionID = sub.DatabaseID
+			continue
+		}
+		if !seen[article.OriginStreamID] {
+			seen[article.OriginStreamID] = true
+			toCreate = append(toCreate, article.OriginStreamID)
+		}
+	}
+	return toCreate
+}
+
+// DryRunSyncSubscriptions fetches the subscription list from Inoreader and
+// diffs it against Postgres without saving subscriptions, creating sync
+// states, or touching the sync statistics and UUID cache.
+func (s *SubscriptionSyncService) DryRunSyncSubscriptions(ctx context.Context) (*SubscriptionSyncDryRunReport, error) {
+	startTime := time.Now()
+
+	incoming, err := s.inoreaderService.FetchSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subscriptions from Inoreader: %w", err)
+	}
+
+	existing, err := s.subscriptionRepo.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing subscriptions: %w", err)
+	}
+
+	missingSyncStates := 0
+	for _, sub := range incoming {
+		state, err := s.syncRepo.FindByStreamID(ctx, sub.InoreaderID)
+		if err != nil && !errors.Is(err, repository.ErrSyncStateNotFound) {
+			return nil, fmt.Errorf("failed to load sync state for stream %s: %w", sub.InoreaderID, err)
+		}
+		if state == nil {
+			missingSyncStates++
+		}
+	}
+
+	report := PlanSubscriptionSync(incoming, existing)
+	report.SyncStatesToCreate = missingSyncStates
+	report.Duration = time.Since(startTime)
+
+	s.logger.Info("Subscription sync dry-run completed",
+		"fetched", report.Fetched,
+		"would_create", report.WouldCreate,
+		"would_update", report.WouldUpdate,
+		"unchanged", report.Unchanged,
+		"sync_states_to_create", report.SyncStatesToCreate)
+
+	return report, nil
+}
+
+// PlanSubscriptionSync classifies each incoming subscription as a create,
+// update or no-op against the rows already in Postgres, using the same
+// title/URL/category comparison as SyncSubscriptions.
+func PlanSubscriptionSync(incoming []*models.Subscription, existing []models.InoreaderSubscription) *SubscriptionSyncDryRunReport {
+	existingMap := make(map[string]models.InoreaderSubscription, len(existing))
+	for _, sub := range existing {
+		existingMap[sub.InoreaderID] = sub
+	}
+
+	report := &SubscriptionSyncDryRunReport{
+		Fetched:       len(incoming),
+		Subscriptions: make([]DryRunItem, 0, len(incoming)),
+	}
+
+	for _, sub := range incoming {
+		item := DryRunItem{InoreaderID: sub.InoreaderID, Title: sub.Title}
+
+		current, ok := existingMap[sub.InoreaderID]
+		if !ok {
+			item.Action = DryRunActionInsert
+			report.WouldCreate++
+			report.Subscriptions = append(report.Subscriptions, item)
+			continue
+		}
+
+		currentCategory := ""
+		if len(current.Categories) > 0 {
+			currentCategory = current.Categories[0].Label
+		}
+
+		switch {
+		case current.Title != sub.Title:
+			item.Action, item.Reason = DryRunActionUpdate, "title changed"
+		case current.URL != sub.FeedURL:
+			item.Action, item.Reason = DryRunActionUpdate, "feed URL changed"
+		case currentCategory != sub.Category:
+			item.Action, item.Reason = DryRunActionUpdate, "category changed"
+		default:
+			item.Action = DryRunActionUnchanged
+		}
+
+		if item.Action == DryRunActionUpdate {
+			report.WouldUpdate++
+		} else {
+			report.Unchanged++
+		}
+		report.Subscriptions = append(report.Subscriptions, item)
+	}
+
+	return report
+}
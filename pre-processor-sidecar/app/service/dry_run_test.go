@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"pre-processor-sidecar/mocks"
+	"pre-processor-sidecar/models"
+	"pre-processor-sidecar/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlanSubscriptionSync(t *testing.T) {
+	existing := []models.InoreaderSubscription{
+		{
+			InoreaderID: "feed/http://same.example.com/rss",
+			URL:         "http://same.example.com/rss",
+			Title:       "Same",
+			Categories:  []models.InoreaderCategory{{Label: "Tech"}},
+		},
+		{
+			InoreaderID: "feed/http://renamed.example.com/rss",
+			URL:         "http://renamed.example.com/rss",
+			Title:       "Old Title",
+			Categories:  []models.InoreaderCategory{{Label: "Tech"}},
+		},
+	}
+	incoming := []*models.Subscription{
+		{InoreaderID: "feed/http://same.example.com/rss", FeedURL: "http://same.example.com/rss", Title: "Same", Category: "Tech"},
+		{InoreaderID: "feed/http://renamed.example.com/rss", FeedURL: "http://renamed.example.com/rss", Title: "New Title", Category: "Tech"},
+		{InoreaderID: "feed/http://new.example.com/rss", FeedURL: "http://new.example.com/rss", Title: "New", Category: "News"},
+	}
+
+	report := PlanSubscriptionSync(incoming, existing)
+
+	assert.Equal(t, 3, report.Fetched)
+	assert.Equal(t, 1, report.WouldCreate)
+	assert.Equal(t, 1, report.WouldUpdate)
+	assert.Equal(t, 1, report.Unchanged)
+	require.Len(t, report.Subscriptions, 3)
+	assert.Equal(t, DryRunActionUnchanged, report.Subscriptions[0].Action)
+	assert.Equal(t, DryRunActionUpdate, report.Subscriptions[1].Action)
+	assert.Equal(t, "title changed", report.Subscriptions[1].Reason)
+	assert.Equal(t, DryRunActionInsert, report.Subscriptions[2].Action)
+}
+
+func TestResolveArticleSubscriptionsReadOnly(t *testing.T) {
+	knownID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	subscriptions := []models.InoreaderSubscription{
+		{DatabaseID: knownID, InoreaderID: "feed/http://known.example.com/rss"},
+	}
+	articles := []*models.Article{
+		{InoreaderID: "a1", OriginStreamID: "feed/http://known.example.com/rss"},
+		{InoreaderID: "a2", OriginStreamID: "feed/http://unknown.example.com/rss"},
+		{InoreaderID: "a3", OriginStreamID: "feed/http://unknown.example.com/rss"},
+	}
+
+	toCreate := resolveArticleSubscriptionsReadOnly(articles, subscriptions)
+
+	assert.Equal(t, []string{"feed/http://unknown.example.com/rss"}, toCreate)
+	assert.Equal(t, knownID, articles[0].SubscriptionID)
+	assert.Equal(t, uuid.Nil, articles[1].SubscriptionID)
+}
+
+func TestArticleFetchService_PlanArticleWrite(t *testing.T) {
+	tests := map[string]struct {
+		article    *models.Article
+		setup      func(repo *mocks.MockArticleRepository)
+		wantAction string
+		wantReason string
+		wantErr    bool
+	}{
+		"new article is inserted": {
+			article: &models.Article{InoreaderID: "new", ArticleURL: "https://example.com/new"},
+			setup: func(repo *mocks.MockArticleRepository) {
+				repo.EXPECT().FindByInoreaderID(gomock.Any(), "new").
+					Return(nil, fmt.Errorf("inoreader_id new: %w", repository.ErrArticleNotFound))
+			},
+			wantAction: DryRunActionInsert,
+		},
+		"existing article with longer content keeps it": {
+			article: &models.Article{InoreaderID: "old", ArticleURL: "https://example.com/old", ContentLength: 10},
+			setup: func(repo *mocks.MockArticleRepository) {
+				repo.EXPECT().FindByInoreaderID(gomock.Any(), "old").
+					Return(&models.Article{InoreaderID: "old", ContentLength: 500}, nil)
+			},
+			wantAction: DryRunActionUpdate,
+			wantReason: "existing longer content kept; metadata only",
+		},
+		"missing URL is skipped without a lookup": {
+			article:    &models.Article{InoreaderID: "nourl"},
+			setup:      func(repo *mocks.MockArticleRepository) {},
+			wantAction: DryRunActionSkip,
+			wantReason: "empty article_url",
+		},
+		"repository failure aborts": {
+			article: &models.Article{InoreaderID: "boom", ArticleURL: "https://example.com/boom"},
+			setup: func(repo *mocks.MockArticleRepository) {
+				repo.EXPECT().FindByInoreaderID(gomock.Any(), "boom").
+					Return(nil, errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			articleRepo := mocks.NewMockArticleRepository(ctrl)
+			tc.setup(articleRepo)
+
+			s := &ArticleFetchService{
+				articleRepo: articleRepo,
+				logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+			}
+
+			item, err := s.planArticleWrite(context.Background(), tc.article)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantAction, item.Action)
+			assert.Equal(t, tc.wantReason, item.Reason)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -503,6 +504,7 @@ func (c *InoreaderClient) parseArticleDataFromStruct(item driver.InoreaderArticl
 		Content:       content,
 		ContentLength: contentLength,
 		ContentType:   contentType,
+		Enclosures:    enclosuresOf(item),
 	}
 
 	// Extract and parse published timestamp using structured access
@@ -514,6 +516,15 @@ func (c *InoreaderClient) parseArticleDataFromStruct(item driver.InoreaderArticl
 	return article, nil
 }
 
+// enclosuresOf returns the item's enclosure list, or nil when it has none.
+func enclosuresOf(item driver.InoreaderArticleItem) json.RawMessage {
+	trimmed := bytes.TrimSpace(item.Enclosure)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) || bytes.Equal(trimmed, []byte("[]")) {
+		return nil
+	}
+	return item.Enclosure
+}
+
 // getResponseKeys returns the keys of the response map for debugging
 func (c *InoreaderClient) getResponseKeys(response map[string]interface{}) []string {
 	keys := make([]string, 0, len(response))
@@ -190,3 +190,38 @@ func TestInoreaderClient_ErrorClassification(t *testing.T) {
 		})
 	}
 }
+
+// TEST: エンクロージャ保持テスト - ポッドキャスト/動画の添付情報をそのまま保存
+func TestInoreaderClient_ParseStreamContentsResponse_KeepsEnclosures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := NewInoreaderClient(mocks.NewMockOAuth2Driver(ctrl), slog.Default(), utils.NewSanitizer())
+
+	response := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{
+				"id":        "tag:google.com,2005:reader/item/episode",
+				"title":     "Episode 42",
+				"canonical": []interface{}{map[string]interface{}{"href": "https://example.com/ep42"}},
+				"enclosure": []interface{}{map[string]interface{}{
+					"href":   "https://cdn.example.com/ep42.mp3",
+					"type":   "audio/mpeg",
+					"length": "12345678",
+				}},
+			},
+			map[string]interface{}{
+				"id":        "tag:google.com,2005:reader/item/post",
+				"title":     "Plain post",
+				"canonical": []interface{}{map[string]interface{}{"href": "https://example.com/post"}},
+				"enclosure": []interface{}{},
+			},
+		},
+	}
+
+	articles, _, err := client.ParseStreamContentsResponse(response)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 2)
+	assert.JSONEq(t, `[{"href":"https://cdn.example.com/ep42.mp3","type":"audio/mpeg","length":"12345678"}]`, string(articles[0].Enclosures))
+	assert.Nil(t, articles[1].Enclosures)
+}
@@ -276,7 +276,7 @@ func (s *InoreaderService) FetchStreamContents(ctx context.Context, streamID, co
 		var parseErr error
 		articles, nextContinuation, parseErr = s.inoreaderClient.ParseStreamContentsResponse(response)
 		if parseErr != nil {
-			return fmt.Errorf("failed to parse stream contents: %w", parseErr)
+			return fmt.Errorf("%w: %w", ErrStreamContentsParse, parseErr)
 		}
 
 		// Resolve subscription UUIDs for articles
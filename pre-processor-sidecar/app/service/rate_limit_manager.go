@@ -1,5 +1,5 @@
 // ABOUTME: Rate limit manager for Inoreader API usage monitoring and control
-// ABOUTME: Handles API quotas, usage tracking, and safety limits to prevent API blocking
+// ABOUTME: Tracks the read (zone 1) and write (zone 2) budgets independently from response headers
 
 package service
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +17,17 @@ import (
 
 // Note: APIUsageRepository interface is defined in inoreader_service.go
 
+// Inoreader quota headers. Both zones share one reset time.
+const (
+	zone1UsageHeader     = "X-Reader-Zone1-Usage"
+	zone1LimitHeader     = "X-Reader-Zone1-Limit"
+	zone1RemainingHeader = "X-Reader-Zone1-Remaining"
+	zone2UsageHeader     = "X-Reader-Zone2-Usage"
+	zone2LimitHeader     = "X-Reader-Zone2-Limit"
+	zone2RemainingHeader = "X-Reader-Zone2-Remaining"
+	resetAfterHeader     = "X-Reader-Limits-Reset-After"
+)
+
 // RateLimitConfig represents rate limiting configuration
 type RateLimitConfig struct {
 	Zone1DailyLimit       int           `json:"zone1_daily_limit"`       // Zone 1 daily limit (read operations)
@@ -26,7 +38,21 @@ type RateLimitConfig struct {
 	AlertThresholds       []int         `json:"alert_thresholds"`        // Alert at these percentages
 }
 
-// RateLimitStatus represents current rate limiting status
+// ZoneBudget is one zone's daily budget as last reported by Inoreader.
+// Zone is models.APIZoneRead or models.APIZoneWrite.
+type ZoneBudget struct {
+	Zone          int       `json:"zone"`
+	Usage         int       `json:"usage"`
+	Limit         int       `json:"limit"`
+	Remaining     int       `json:"remaining"`
+	ResetAt       time.Time `json:"reset_at"`
+	Blocked       bool      `json:"blocked"`
+	BlockedReason string    `json:"blocked_reason,omitempty"`
+}
+
+// RateLimitStatus represents current rate limiting status. IsBlocked is set
+// when any zone is blocked; Zones says which, since a blocked zone never
+// holds back requests to the other.
 type RateLimitStatus struct {
 	Zone1Usage         int                    `json:"zone1_usage"`
 	Zone1Limit         int                    `json:"zone1_limit"`
@@ -34,6 +60,7 @@ type RateLimitStatus struct {
 	Zone2Usage         int                    `json:"zone2_usage"`
 	Zone2Limit         int                    `json:"zone2_limit"`
 	Zone2Remaining     int                    `json:"zone2_remaining"`
+	Zones              []ZoneBudget           `json:"zones"`
 	SafetyBufferActive bool                   `json:"safety_buffer_active"`
 	DailyResetTime     time.Time              `json:"daily_reset_time"`
 	LastUpdated        time.Time              `json:"last_updated"`
@@ -53,14 +80,33 @@ type RateLimitAlert struct {
 	Timestamp    time.Time `json:"timestamp"`
 }
 
-// RateLimitManager manages API rate limiting and usage monitoring
+// zoneState is the mutable budget of one zone, guarded by RateLimitManager.mu.
+type zoneState struct {
+	zone      int
+	usage     int
+	limit     int
+	remaining int
+	resetAt   time.Time
+	// rateLimitedUntil is set when Inoreader answers 429 for the zone: the
+	// zone stays blocked until its reset whatever the counters say.
+	rateLimitedUntil time.Time
+	// alerted is the highest alert threshold already reported since the
+	// last reset, so each threshold alerts once a day.
+	alerted int
+}
+
+// RateLimitManager manages API rate limiting and usage monitoring. The read
+// and write zones have separate budgets: exhausting zone 2 with mark-as-read
+// calls never stops article fetches, and vice versa.
 type RateLimitManager struct {
 	config         *RateLimitConfig
 	apiUsageRepo   APIUsageRepository
 	logger         *slog.Logger
-	currentStatus  *RateLimitStatus
-	lastUsageCheck time.Time
+	zones          map[int]*zoneState
+	headers        map[string]interface{}
+	lastUpdated    time.Time
 	alertCallbacks []func(*RateLimitAlert)
+	now            func() time.Time
 	mu             sync.RWMutex
 }
 
@@ -83,18 +129,19 @@ func NewRateLimitManager(
 		AlertThresholds:       []int{50, 75, 90}, // Alert at 50%, 75%, 90%
 	}
 
+	now := time.Now()
 	return &RateLimitManager{
 		config:       config,
 		apiUsageRepo: apiUsageRepo,
 		logger:       logger,
-		currentStatus: &RateLimitStatus{
-			Zone1Limit:     config.Zone1DailyLimit,
-			Zone2Limit:     config.Zone2DailyLimit,
-			DailyResetTime: getNextMidnight(),
-			LastUpdated:    time.Now(),
-			Headers:        make(map[string]interface{}),
+		zones: map[int]*zoneState{
+			models.APIZoneRead:  {zone: models.APIZoneRead, limit: config.Zone1DailyLimit, remaining: config.Zone1DailyLimit, resetAt: nextMidnight(now)},
+			models.APIZoneWrite: {zone: models.APIZoneWrite, limit: config.Zone2DailyLimit, remaining: config.Zone2DailyLimit, resetAt: nextMidnight(now)},
 		},
+		headers:        make(map[string]interface{}),
+		lastUpdated:    now,
 		alertCallbacks: make([]func(*RateLimitAlert), 0),
+		now:            time.Now,
 	}
 }
 
@@ -103,55 +150,28 @@ func (r *RateLimitManager) UpdateFromHeaders(ctx context.Context, headers map[st
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Parse Inoreader-specific rate limit headers
-	if zone1Usage, ok := headers["X-Reader-Zone1-Usage"]; ok {
-		if parsed, err := strconv.ParseInt(zone1Usage, 10, 32); err == nil {
-			r.currentStatus.Zone1Usage = int(parsed)
-		}
-	}
-
-	if zone1Limit, ok := headers["X-Reader-Zone1-Limit"]; ok {
-		if parsed, err := strconv.ParseInt(zone1Limit, 10, 32); err == nil {
-			r.currentStatus.Zone1Limit = int(parsed)
-		}
-	}
-
-	if zone1Remaining, ok := headers["X-Reader-Zone1-Remaining"]; ok {
-		if parsed, err := strconv.ParseInt(zone1Remaining, 10, 32); err == nil {
-			r.currentStatus.Zone1Remaining = int(parsed)
-		}
-	}
+	now := r.now()
+	r.rolloverLocked(now)
 
-	if zone2Usage, ok := headers["X-Reader-Zone2-Usage"]; ok {
-		if parsed, err := strconv.ParseInt(zone2Usage, 10, 32); err == nil {
-			r.currentStatus.Zone2Usage = int(parsed)
-		}
-	}
-
-	if zone2Limit, ok := headers["X-Reader-Zone2-Limit"]; ok {
-		if parsed, err := strconv.ParseInt(zone2Limit, 10, 32); err == nil {
-			r.currentStatus.Zone2Limit = int(parsed)
-		}
-	}
-
-	if zone2Remaining, ok := headers["X-Reader-Zone2-Remaining"]; ok {
-		if parsed, err := strconv.ParseInt(zone2Remaining, 10, 32); err == nil {
-			r.currentStatus.Zone2Remaining = int(parsed)
+	header := func(key string) *int {
+		for k, v := range headers {
+			if strings.EqualFold(k, key) {
+				return parseHeaderInt(v)
+			}
 		}
+		return nil
 	}
+	r.applyLocked(models.APIZoneRead, header(zone1UsageHeader), header(zone1LimitHeader), header(zone1RemainingHeader))
+	r.applyLocked(models.APIZoneWrite, header(zone2UsageHeader), header(zone2LimitHeader), header(zone2RemainingHeader))
+	r.applyResetAfterLocked(header(resetAfterHeader), now)
 
 	// Store all headers for debugging
-	r.currentStatus.Headers = make(map[string]interface{})
+	r.headers = make(map[string]interface{}, len(headers))
 	for key, value := range headers {
-		r.currentStatus.Headers[key] = value
+		r.headers[key] = value
 	}
+	r.lastUpdated = now
 
-	r.currentStatus.LastUpdated = time.Now()
-
-	// Update blocked status
-	r.updateBlockedStatus()
-
-	// Check for alerts
 	r.checkAndTriggerAlerts()
 
 	// Persist to database
@@ -160,66 +180,132 @@ func (r *RateLimitManager) UpdateFromHeaders(ctx context.Context, headers map[st
 	}
 
 	r.logger.Debug("Rate limit status updated from headers",
-		"zone1_usage", r.currentStatus.Zone1Usage,
-		"zone1_limit", r.currentStatus.Zone1Limit,
-		"zone2_usage", r.currentStatus.Zone2Usage,
-		"zone2_limit", r.currentStatus.Zone2Limit,
+		"zone1_usage", r.zones[models.APIZoneRead].usage,
+		"zone1_limit", r.zones[models.APIZoneRead].limit,
+		"zone2_usage", r.zones[models.APIZoneWrite].usage,
+		"zone2_limit", r.zones[models.APIZoneWrite].limit,
 		"endpoint", endpoint)
 
 	return nil
 }
 
-// CheckAllowed checks if a request is allowed based on current rate limits
-func (r *RateLimitManager) CheckAllowed(endpoint string) (allowed bool, reason string, remaining int) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+// RecordCall updates the zone budgets from one API call, making
+// RateLimitManager a driver.APICallRecorder next to the usage ledger.
+// Calls Inoreader answered without quota headers count one against their
+// zone locally; a 429 blocks the call's zone until the limits reset.
+func (r *RateLimitManager) RecordCall(_ context.Context, call *models.APICall) error {
+	if call == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	// Check if blocked
-	if r.currentStatus.IsBlocked {
-		return false, r.currentStatus.BlockedReason, 0
+	now := r.now()
+	r.rolloverLocked(now)
+
+	r.applyLocked(models.APIZoneRead, call.Zone1Usage, call.Zone1Limit, nil)
+	r.applyLocked(models.APIZoneWrite, call.Zone2Usage, call.Zone2Limit, nil)
+	r.applyResetAfterLocked(call.ResetAfterSeconds, now)
+
+	z := r.zoneLocked(call.Zone)
+	reported := call.Zone1Usage
+	if z.zone == models.APIZoneWrite {
+		reported = call.Zone2Usage
+	}
+	if reported == nil && call.Cost > 0 {
+		z.usage += call.Cost
+		z.remaining = max(z.limit-z.usage, 0)
 	}
+	if call.RateLimited() {
+		z.rateLimitedUntil = z.resetAt
+		r.logger.Warn("Inoreader rate limited zone, holding its requests until reset",
+			"zone", z.zone,
+			"endpoint", call.Endpoint,
+			"reset_at", z.resetAt)
+	}
+	r.lastUpdated = now
 
-	// Determine which zone this endpoint belongs to
-	isZone1 := r.isReadOnlyEndpoint(endpoint)
+	r.checkAndTriggerAlerts()
+	return nil
+}
 
-	var usage, limit int
-	if isZone1 {
-		usage = r.currentStatus.Zone1Usage
-		limit = r.currentStatus.Zone1Limit
-	} else {
-		usage = r.currentStatus.Zone2Usage
-		limit = r.currentStatus.Zone2Limit
+// CheckAllowed checks if a request to endpoint is allowed by the budget of
+// the zone Inoreader counts it against.
+func (r *RateLimitManager) CheckAllowed(endpoint string) (allowed bool, reason string, remaining int) {
+	return r.CheckZone(models.APIZoneForEndpoint(endpoint))
+}
+
+// CheckZone checks if one more request fits zone's budget after the safety
+// buffer. remaining is how many more requests fit.
+func (r *RateLimitManager) CheckZone(zone int) (allowed bool, reason string, remaining int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.rolloverLocked(now)
+	z := r.zoneLocked(zone)
+
+	if now.Before(z.rateLimitedUntil) {
+		return false, fmt.Sprintf("Zone %d rate limited by Inoreader until %s", z.zone, z.rateLimitedUntil.UTC().Format(time.RFC3339)), 0
 	}
 
 	// Apply safety buffer
-	safetyBuffer := (limit * r.config.SafetyBufferPercent) / 100
-	effectiveLimit := limit - safetyBuffer
-	remaining = effectiveLimit - usage
-
+	safetyBuffer := (z.limit * r.config.SafetyBufferPercent) / 100
+	remaining = z.limit - safetyBuffer - z.usage
 	if remaining <= 0 {
-		zone := "Zone 1"
-		if !isZone1 {
-			zone = "Zone 2"
-		}
-		return false, fmt.Sprintf("%s rate limit exceeded with safety buffer", zone), 0
+		return false, fmt.Sprintf("Zone %d rate limit exceeded with safety buffer", z.zone), 0
 	}
 
 	return true, "", remaining
 }
 
+// ZoneBudget returns zone's current budget.
+func (r *RateLimitManager) ZoneBudget(zone int) ZoneBudget {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.rolloverLocked(now)
+	return r.budgetLocked(r.zoneLocked(zone), now)
+}
+
 // GetStatus returns current rate limit status
 func (r *RateLimitManager) GetStatus() *RateLimitStatus {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	// Return a copy to prevent race conditions
-	statusCopy := *r.currentStatus
-	statusCopy.Headers = make(map[string]interface{})
-	for k, v := range r.currentStatus.Headers {
-		statusCopy.Headers[k] = v
+	now := r.now()
+	r.rolloverLocked(now)
+
+	read := r.budgetLocked(r.zones[models.APIZoneRead], now)
+	write := r.budgetLocked(r.zones[models.APIZoneWrite], now)
+	status := &RateLimitStatus{
+		Zone1Usage:         read.Usage,
+		Zone1Limit:         read.Limit,
+		Zone1Remaining:     read.Remaining,
+		Zone2Usage:         write.Usage,
+		Zone2Limit:         write.Limit,
+		Zone2Remaining:     write.Remaining,
+		Zones:              []ZoneBudget{read, write},
+		SafetyBufferActive: r.overSafetyThresholdLocked(r.zones[models.APIZoneRead]) || r.overSafetyThresholdLocked(r.zones[models.APIZoneWrite]),
+		DailyResetTime:     read.ResetAt,
+		LastUpdated:        r.lastUpdated,
+		Headers:            make(map[string]interface{}, len(r.headers)),
+	}
+	var reasons []string
+	for _, b := range status.Zones {
+		if b.Blocked {
+			status.IsBlocked = true
+			reasons = append(reasons, b.BlockedReason)
+		}
+	}
+	status.BlockedReason = strings.Join(reasons, "; ")
+	for k, v := range r.headers {
+		status.Headers[k] = v
 	}
 
-	return &statusCopy
+	return status
 }
 
 // GetUsagePercentage returns usage percentage for specified zone
@@ -227,20 +313,7 @@ func (r *RateLimitManager) GetUsagePercentage(zone int) float64 {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var usage, limit int
-	if zone == 1 {
-		usage = r.currentStatus.Zone1Usage
-		limit = r.currentStatus.Zone1Limit
-	} else {
-		usage = r.currentStatus.Zone2Usage
-		limit = r.currentStatus.Zone2Limit
-	}
-
-	if limit == 0 {
-		return 0.0
-	}
-
-	return (float64(usage) / float64(limit)) * 100.0
+	return usagePercentage(r.zoneLocked(zone))
 }
 
 // AddAlertCallback adds a callback function for rate limit alerts
@@ -251,60 +324,111 @@ func (r *RateLimitManager) AddAlertCallback(callback func(*RateLimitAlert)) {
 	r.alertCallbacks = append(r.alertCallbacks, callback)
 }
 
-// updateBlockedStatus updates the blocked status based on current usage
-func (r *RateLimitManager) updateBlockedStatus() {
-	// Check Zone 1
-	zone1Percentage := r.GetUsagePercentage(1)
-	zone2Percentage := r.GetUsagePercentage(2)
-
-	safetyThreshold := float64(100 - r.config.SafetyBufferPercent)
-
-	if zone1Percentage >= safetyThreshold {
-		r.currentStatus.IsBlocked = true
-		r.currentStatus.BlockedReason = fmt.Sprintf("Zone 1 usage exceeded safety threshold: %.1f%%", zone1Percentage)
-		r.currentStatus.SafetyBufferActive = true
-	} else if zone2Percentage >= safetyThreshold {
-		r.currentStatus.IsBlocked = true
-		r.currentStatus.BlockedReason = fmt.Sprintf("Zone 2 usage exceeded safety threshold: %.1f%%", zone2Percentage)
-		r.currentStatus.SafetyBufferActive = true
-	} else {
-		r.currentStatus.IsBlocked = false
-		r.currentStatus.BlockedReason = ""
-		r.currentStatus.SafetyBufferActive = false
+// zoneLocked returns the state of zone. Unknown zones are treated as the
+// write zone, the more conservative budget to spend. Callers must hold mu.
+func (r *RateLimitManager) zoneLocked(zone int) *zoneState {
+	if z, ok := r.zones[zone]; ok {
+		return z
 	}
+	return r.zones[models.APIZoneWrite]
 }
 
-// checkAndTriggerAlerts checks for threshold violations and triggers alerts
-func (r *RateLimitManager) checkAndTriggerAlerts() {
-	zone1Percentage := r.GetUsagePercentage(1)
-	zone2Percentage := r.GetUsagePercentage(2)
+// applyLocked copies the reported counters of one zone. remaining is
+// derived from usage and limit when Inoreader did not send it.
+func (r *RateLimitManager) applyLocked(zone int, usage, limit, remaining *int) {
+	z := r.zones[zone]
+	if usage != nil {
+		z.usage = *usage
+	}
+	if limit != nil {
+		z.limit = *limit
+	}
+	switch {
+	case remaining != nil:
+		z.remaining = *remaining
+	case usage != nil || limit != nil:
+		z.remaining = max(z.limit-z.usage, 0)
+	}
+}
 
-	// Check Zone 1 alerts
-	for _, threshold := range r.config.AlertThresholds {
-		if zone1Percentage >= float64(threshold) {
-			r.triggerAlert(&RateLimitAlert{
-				AlertType:    r.getAlertType(threshold),
-				Message:      fmt.Sprintf("Zone 1 API usage reached %d%% threshold", threshold),
-				Threshold:    threshold,
-				CurrentUsage: r.currentStatus.Zone1Usage,
-				DailyLimit:   r.currentStatus.Zone1Limit,
-				Zone:         1,
-				Timestamp:    time.Now(),
-			})
+// applyResetAfterLocked moves both zones' reset to seconds from now.
+func (r *RateLimitManager) applyResetAfterLocked(seconds *int, now time.Time) {
+	if seconds == nil || *seconds < 0 {
+		return
+	}
+	resetAt := now.Add(time.Duration(*seconds) * time.Second)
+	for _, z := range r.zones {
+		z.resetAt = resetAt
+	}
+}
+
+// rolloverLocked clears every zone whose reset time has passed. Until the
+// next response says otherwise the following reset is assumed a day later.
+func (r *RateLimitManager) rolloverLocked(now time.Time) {
+	for _, z := range r.zones {
+		if z.resetAt.IsZero() || now.Before(z.resetAt) {
+			continue
+		}
+		for !now.Before(z.resetAt) {
+			z.resetAt = z.resetAt.Add(24 * time.Hour)
 		}
+		z.usage = 0
+		z.remaining = z.limit
+		z.rateLimitedUntil = time.Time{}
+		z.alerted = 0
+		r.logger.Info("Zone usage reset", "zone", z.zone, "next_reset", z.resetAt)
 	}
+}
+
+func (r *RateLimitManager) budgetLocked(z *zoneState, now time.Time) ZoneBudget {
+	b := ZoneBudget{
+		Zone:      z.zone,
+		Usage:     z.usage,
+		Limit:     z.limit,
+		Remaining: z.remaining,
+		ResetAt:   z.resetAt,
+	}
+	switch {
+	case now.Before(z.rateLimitedUntil):
+		b.Blocked = true
+		b.BlockedReason = fmt.Sprintf("Zone %d rate limited by Inoreader", z.zone)
+	case r.overSafetyThresholdLocked(z):
+		b.Blocked = true
+		b.BlockedReason = fmt.Sprintf("Zone %d usage exceeded safety threshold: %.1f%%", z.zone, usagePercentage(z))
+	}
+	return b
+}
+
+func (r *RateLimitManager) overSafetyThresholdLocked(z *zoneState) bool {
+	return usagePercentage(z) >= float64(100-r.config.SafetyBufferPercent)
+}
 
-	// Check Zone 2 alerts
-	for _, threshold := range r.config.AlertThresholds {
-		if zone2Percentage >= float64(threshold) {
+func usagePercentage(z *zoneState) float64 {
+	if z.limit == 0 {
+		return 0.0
+	}
+	return (float64(z.usage) / float64(z.limit)) * 100.0
+}
+
+// checkAndTriggerAlerts reports each zone's newly crossed thresholds.
+// Callers must hold mu.
+func (r *RateLimitManager) checkAndTriggerAlerts() {
+	for _, zone := range []int{models.APIZoneRead, models.APIZoneWrite} {
+		z := r.zones[zone]
+		percentage := usagePercentage(z)
+		for _, threshold := range r.config.AlertThresholds {
+			if percentage < float64(threshold) || threshold <= z.alerted {
+				continue
+			}
+			z.alerted = threshold
 			r.triggerAlert(&RateLimitAlert{
 				AlertType:    r.getAlertType(threshold),
-				Message:      fmt.Sprintf("Zone 2 API usage reached %d%% threshold", threshold),
+				Message:      fmt.Sprintf("Zone %d API usage reached %d%% threshold", zone, threshold),
 				Threshold:    threshold,
-				CurrentUsage: r.currentStatus.Zone2Usage,
-				DailyLimit:   r.currentStatus.Zone2Limit,
-				Zone:         2,
-				Timestamp:    time.Now(),
+				CurrentUsage: z.usage,
+				DailyLimit:   z.limit,
+				Zone:         zone,
+				Timestamp:    r.now(),
 			})
 		}
 	}
@@ -333,27 +457,6 @@ func (r *RateLimitManager) getAlertType(threshold int) string {
 	return "info"
 }
 
-// isReadOnlyEndpoint determines if an endpoint is read-only (Zone 1)
-func (r *RateLimitManager) isReadOnlyEndpoint(endpoint string) bool {
-	readOnlyEndpoints := []string{
-		"/subscription/list",
-		"/stream/contents/",
-		"/stream/items/contents",
-		"/user-info",
-		"/unread-count",
-	}
-
-	for _, readOnly := range readOnlyEndpoints {
-		if endpoint == readOnly || (readOnly[len(readOnly)-1] == '/' &&
-			len(endpoint) > len(readOnly) &&
-			endpoint[:len(readOnly)] == readOnly) {
-			return true
-		}
-	}
-
-	return false
-}
-
 // persistUsage persists current usage to database
 func (r *RateLimitManager) persistUsage(ctx context.Context, endpoint string) error {
 	if r.apiUsageRepo == nil {
@@ -365,17 +468,17 @@ func (r *RateLimitManager) persistUsage(ctx context.Context, endpoint string) er
 	if err != nil {
 		// Create new usage record
 		usage = models.NewAPIUsageTracking()
-		usage.Zone1Requests = r.currentStatus.Zone1Usage
-		usage.Zone2Requests = r.currentStatus.Zone2Usage
-		usage.UpdateRateLimitHeaders(r.currentStatus.Headers)
+		usage.Zone1Requests = r.zones[models.APIZoneRead].usage
+		usage.Zone2Requests = r.zones[models.APIZoneWrite].usage
+		usage.UpdateRateLimitHeaders(r.headers)
 
 		return r.apiUsageRepo.CreateUsageRecord(ctx, usage)
 	}
 
 	// Update existing record
-	usage.Zone1Requests = r.currentStatus.Zone1Usage
-	usage.Zone2Requests = r.currentStatus.Zone2Usage
-	usage.UpdateRateLimitHeaders(r.currentStatus.Headers)
+	usage.Zone1Requests = r.zones[models.APIZoneRead].usage
+	usage.Zone2Requests = r.zones[models.APIZoneWrite].usage
+	usage.UpdateRateLimitHeaders(r.headers)
 
 	return r.apiUsageRepo.UpdateUsageRecord(ctx, usage)
 }
@@ -385,35 +488,36 @@ func (r *RateLimitManager) ResetDailyUsage() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.currentStatus.Zone1Usage = 0
-	r.currentStatus.Zone2Usage = 0
-	r.currentStatus.Zone1Remaining = r.currentStatus.Zone1Limit
-	r.currentStatus.Zone2Remaining = r.currentStatus.Zone2Limit
-	r.currentStatus.DailyResetTime = getNextMidnight()
-	r.currentStatus.IsBlocked = false
-	r.currentStatus.BlockedReason = ""
-	r.currentStatus.SafetyBufferActive = false
-	r.currentStatus.LastUpdated = time.Now()
+	now := r.now()
+	for _, z := range r.zones {
+		z.usage = 0
+		z.remaining = z.limit
+		z.resetAt = nextMidnight(now)
+		z.rateLimitedUntil = time.Time{}
+		z.alerted = 0
+	}
+	r.lastUpdated = now
 
 	r.logger.Info("Daily usage counters reset",
-		"next_reset", r.currentStatus.DailyResetTime)
+		"next_reset", nextMidnight(now))
 }
 
 // GetUsageStats returns usage statistics for monitoring
 func (r *RateLimitManager) GetUsageStats() map[string]interface{} {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	status := r.GetStatus()
 
 	return map[string]interface{}{
-		"zone1_usage_percent":  r.GetUsagePercentage(1),
-		"zone2_usage_percent":  r.GetUsagePercentage(2),
-		"safety_buffer_active": r.currentStatus.SafetyBufferActive,
-		"is_blocked":           r.currentStatus.IsBlocked,
-		"blocked_reason":       r.currentStatus.BlockedReason,
-		"daily_reset_time":     r.currentStatus.DailyResetTime,
-		"last_updated":         r.currentStatus.LastUpdated,
-		"zone1_remaining":      r.currentStatus.Zone1Remaining,
-		"zone2_remaining":      r.currentStatus.Zone2Remaining,
+		"zone1_usage_percent":  r.GetUsagePercentage(models.APIZoneRead),
+		"zone2_usage_percent":  r.GetUsagePercentage(models.APIZoneWrite),
+		"zone1_blocked":        status.Zones[0].Blocked,
+		"zone2_blocked":        status.Zones[1].Blocked,
+		"safety_buffer_active": status.SafetyBufferActive,
+		"is_blocked":           status.IsBlocked,
+		"blocked_reason":       status.BlockedReason,
+		"daily_reset_time":     status.DailyResetTime,
+		"last_updated":         status.LastUpdated,
+		"zone1_remaining":      status.Zone1Remaining,
+		"zone2_remaining":      status.Zone2Remaining,
 	}
 }
 
@@ -429,8 +533,16 @@ func (r *RateLimitManager) UpdateConfig(newConfig *RateLimitConfig) {
 		"safety_buffer", newConfig.SafetyBufferPercent)
 }
 
-// getNextMidnight returns the next midnight timestamp
-func getNextMidnight() time.Time {
-	now := time.Now()
+// parseHeaderInt parses an integer quota header, nil when malformed.
+func parseHeaderInt(raw string) *int {
+	v, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// nextMidnight returns the midnight following now
+func nextMidnight(now time.Time) time.Time {
 	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
 }
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"pre-processor-sidecar/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRateLimitManager(now *time.Time) *RateLimitManager {
+	r := NewRateLimitManager(nil, slog.Default())
+	r.now = func() time.Time { return *now }
+	r.ResetDailyUsage()
+	return r
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestRateLimitManager_ZonesAreIndependent(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	r := newTestRateLimitManager(&now)
+
+	require.NoError(t, r.RecordCall(context.Background(), &models.APICall{
+		Endpoint: "/edit-tag", Zone: models.APIZoneWrite, Cost: 1, StatusCode: 200,
+		Zone1Usage: intPtr(10), Zone1Limit: intPtr(100),
+		Zone2Usage: intPtr(95), Zone2Limit: intPtr(100),
+	}))
+
+	allowed, _, remaining := r.CheckAllowed("/stream/contents/feed%2Fhttps%3A%2F%2Fexample.com")
+	assert.True(t, allowed, "an exhausted write zone does not block reads")
+	assert.Equal(t, 80, remaining, "limit minus 10% buffer minus usage")
+
+	allowed, reason, _ := r.CheckAllowed("/edit-tag")
+	assert.False(t, allowed)
+	assert.Contains(t, reason, "Zone 2")
+
+	status := r.GetStatus()
+	assert.True(t, status.IsBlocked)
+	require.Len(t, status.Zones, 2)
+	assert.False(t, status.Zones[0].Blocked)
+	assert.True(t, status.Zones[1].Blocked)
+	assert.Equal(t, 5, status.Zone2Remaining)
+}
+
+func TestRateLimitManager_RateLimitedZoneWaitsForReset(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	r := newTestRateLimitManager(&now)
+
+	require.NoError(t, r.RecordCall(context.Background(), &models.APICall{
+		Endpoint: "/stream/contents", Zone: models.APIZoneRead, Cost: 1, StatusCode: 429,
+		Zone1Usage: intPtr(50), Zone1Limit: intPtr(100), ResetAfterSeconds: intPtr(3600),
+	}))
+
+	allowed, reason, _ := r.CheckZone(models.APIZoneRead)
+	assert.False(t, allowed, "429 blocks the zone even below the safety buffer")
+	assert.Contains(t, reason, "Zone 1 rate limited")
+	allowed, _, _ = r.CheckZone(models.APIZoneWrite)
+	assert.True(t, allowed)
+
+	now = now.Add(time.Hour)
+	allowed, _, remaining := r.CheckZone(models.APIZoneRead)
+	assert.True(t, allowed, "the reset clears the block and the usage")
+	assert.Equal(t, 90, remaining)
+	assert.Equal(t, now.Add(24*time.Hour), r.ZoneBudget(models.APIZoneRead).ResetAt)
+}
+
+func TestRateLimitManager_CountsCallsWithoutHeaders(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	r := newTestRateLimitManager(&now)
+
+	for range 3 {
+		require.NoError(t, r.RecordCall(context.Background(), &models.APICall{
+			Endpoint: "/edit-tag", Zone: models.APIZoneWrite, Cost: 1, StatusCode: 200,
+		}))
+	}
+	require.NoError(t, r.RecordCall(context.Background(), &models.APICall{
+		Endpoint: "/edit-tag", Zone: models.APIZoneWrite, Error: "connection refused",
+	}))
+
+	assert.Equal(t, 3, r.ZoneBudget(models.APIZoneWrite).Usage, "failed requests cost nothing")
+	assert.Equal(t, 0, r.ZoneBudget(models.APIZoneRead).Usage)
+}
+
+func TestRateLimitManager_UpdateFromHeaders(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	r := newTestRateLimitManager(&now)
+
+	require.NoError(t, r.UpdateFromHeaders(context.Background(), map[string]string{
+		"X-Reader-Zone1-Usage":        "91",
+		"X-Reader-Zone1-Limit":        "100",
+		"X-Reader-Zone2-Usage":        "3",
+		"X-Reader-Zone2-Limit":        "200",
+		"X-Reader-Zone2-Remaining":    "197",
+		"X-Reader-Limits-Reset-After": "600",
+	}, "/subscription/list"))
+
+	allowed, _, _ := r.CheckAllowed("/subscription/list")
+	assert.False(t, allowed)
+	allowed, _, remaining := r.CheckAllowed("/mark-all-as-read")
+	assert.True(t, allowed)
+	assert.Equal(t, 177, remaining)
+
+	write := r.ZoneBudget(models.APIZoneWrite)
+	assert.Equal(t, 197, write.Remaining)
+	assert.Equal(t, now.Add(10*time.Minute), write.ResetAt)
+	assert.InDelta(t, 91.0, r.GetUsagePercentage(models.APIZoneRead), 0.01)
+}
+
+func TestRateLimitManager_AlertsOncePerThreshold(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	r := newTestRateLimitManager(&now)
+	alerts := make(chan *RateLimitAlert, 10)
+	r.AddAlertCallback(func(a *RateLimitAlert) { alerts <- a })
+
+	for _, usage := range []int{60, 61, 62} {
+		require.NoError(t, r.RecordCall(context.Background(), &models.APICall{
+			Zone: models.APIZoneWrite, Cost: 1, StatusCode: 200,
+			Zone2Usage: intPtr(usage), Zone2Limit: intPtr(100),
+		}))
+	}
+
+	select {
+	case a := <-alerts:
+		assert.Equal(t, 2, a.Zone)
+		assert.Equal(t, 50, a.Threshold)
+	case <-time.After(time.Second):
+		t.Fatal("expected an alert")
+	}
+	select {
+	case a := <-alerts:
+		t.Fatalf("unexpected second alert: %+v", a)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
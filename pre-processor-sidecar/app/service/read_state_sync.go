@@ -0,0 +1,189 @@
+// ABOUTME: ReadStateSync queues Inoreader items to mark read and sends them in zone 2 batches
+// ABOUTME: on their own schedule, so write quota is spent apart from the zone 1 article fetches
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+
+	"pre-processor-sidecar/models"
+)
+
+const (
+	// readStateTag is the Inoreader system tag of read items.
+	readStateTag = "user/-/state/com.google/read"
+
+	// markReadBatchSize is how many items one /edit-tag call marks read.
+	markReadBatchSize = 100
+
+	// maxReadStatePending bounds the queue; items beyond it are dropped and
+	// stay unread in Inoreader.
+	maxReadStatePending = 5000
+)
+
+// AuthenticatedFormPoster sends zone 2 write requests.
+// driver.OAuth2Client implements it.
+type AuthenticatedFormPoster interface {
+	PostAuthenticatedForm(ctx context.Context, accessToken, endpoint string, form url.Values) error
+}
+
+// ZoneBudgetChecker reports whether a zone's budget allows another request.
+// RateLimitManager implements it.
+type ZoneBudgetChecker interface {
+	CheckZone(zone int) (allowed bool, reason string, remaining int)
+}
+
+// ReadStateFlushResult describes one Flush.
+type ReadStateFlushResult struct {
+	Marked  int `json:"marked"`
+	Calls   int `json:"calls"`
+	Pending int `json:"pending"`
+	// Deferred is why the flush stopped with items still queued, e.g. the
+	// zone 2 budget is spent.
+	Deferred string `json:"deferred,omitempty"`
+}
+
+// ReadStateSync marks items read in Inoreader. Items are queued by
+// Enqueue and sent by Flush, which the scheduler runs on its own zone 2
+// ticker. Each call is checked against the zone 2 budget only, so a busy
+// read day never holds back read-state sync and vice versa.
+type ReadStateSync struct {
+	poster AuthenticatedFormPoster
+	tokens TokenProvider
+	budget ZoneBudgetChecker
+	logger *slog.Logger
+
+	// flushMu keeps two flushes from sending the same batch.
+	flushMu sync.Mutex
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+}
+
+// NewReadStateSync creates a ReadStateSync.
+func NewReadStateSync(poster AuthenticatedFormPoster, tokens TokenProvider, budget ZoneBudgetChecker, logger *slog.Logger) *ReadStateSync {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ReadStateSync{
+		poster: poster,
+		tokens: tokens,
+		budget: budget,
+		logger: logger,
+		queued: make(map[string]bool),
+	}
+}
+
+// Enqueue queues Inoreader item IDs to be marked read and returns how many
+// were added. Blank, already queued and over-capacity IDs are skipped.
+func (s *ReadStateSync) Enqueue(itemIDs []string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, id := range itemIDs {
+		if id == "" || s.queued[id] {
+			continue
+		}
+		if len(s.pending) >= maxReadStatePending {
+			s.logger.Warn("Read-state queue full, dropping items",
+				"dropped", len(itemIDs)-added,
+				"max_pending", maxReadStatePending)
+			break
+		}
+		s.queued[id] = true
+		s.pending = append(s.pending, id)
+		added++
+	}
+	return added
+}
+
+// Pending returns how many items wait to be marked read.
+func (s *ReadStateSync) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// Flush sends the queue in batches while the zone 2 budget allows. A
+// failed batch goes back to the head of the queue for the next flush.
+func (s *ReadStateSync) Flush(ctx context.Context) (ReadStateFlushResult, error) {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	var result ReadStateFlushResult
+	if s.Pending() == 0 {
+		return result, nil
+	}
+
+	token, err := s.tokens.GetValidToken(ctx)
+	if err != nil {
+		result.Pending = s.Pending()
+		return result, fmt.Errorf("get token for read-state sync: %w", err)
+	}
+
+	for {
+		if allowed, reason, _ := s.budget.CheckZone(models.APIZoneWrite); !allowed {
+			result.Deferred = reason
+			break
+		}
+		batch := s.takeBatch()
+		if len(batch) == 0 {
+			break
+		}
+
+		form := url.Values{"a": {readStateTag}, "i": batch}
+		err := s.poster.PostAuthenticatedForm(ctx, token.AccessToken, "/edit-tag", form)
+		result.Calls++
+		if err != nil {
+			s.requeue(batch)
+			result.Pending = s.Pending()
+			return result, fmt.Errorf("mark %d items read: %w", len(batch), err)
+		}
+		result.Marked += len(batch)
+	}
+
+	result.Pending = s.Pending()
+	if result.Deferred != "" {
+		s.logger.Info("Read-state sync deferred",
+			"reason", result.Deferred,
+			"marked", result.Marked,
+			"pending", result.Pending)
+	}
+	return result, nil
+}
+
+// takeBatch pops up to markReadBatchSize items off the queue.
+func (s *ReadStateSync) takeBatch() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := min(len(s.pending), markReadBatchSize)
+	batch := s.pending[:n:n]
+	s.pending = s.pending[n:]
+	for _, id := range batch {
+		delete(s.queued, id)
+	}
+	return batch
+}
+
+// requeue puts a failed batch back at the head of the queue, skipping
+// items queued again meanwhile.
+func (s *ReadStateSync) requeue(batch []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := make([]string, 0, len(batch)+len(s.pending))
+	for _, id := range batch {
+		if !s.queued[id] {
+			s.queued[id] = true
+			kept = append(kept, id)
+		}
+	}
+	s.pending = append(kept, s.pending...)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"testing"
+	"time"
+
+	"pre-processor-sidecar/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFormPoster struct {
+	forms []url.Values
+	err   error
+}
+
+func (f *fakeFormPoster) PostAuthenticatedForm(_ context.Context, accessToken, endpoint string, form url.Values) error {
+	if accessToken != "access" || endpoint != "/edit-tag" {
+		return errors.New("unexpected request")
+	}
+	f.forms = append(f.forms, form)
+	return f.err
+}
+
+type fakeZoneBudget struct {
+	zones     []int
+	remaining int
+}
+
+func (f *fakeZoneBudget) CheckZone(zone int) (bool, string, int) {
+	f.zones = append(f.zones, zone)
+	if f.remaining <= 0 {
+		return false, "Zone 2 rate limit exceeded with safety buffer", 0
+	}
+	f.remaining--
+	return true, "", f.remaining + 1
+}
+
+type staticTokenProvider struct{}
+
+func (staticTokenProvider) GetValidToken(context.Context) (*models.OAuth2Token, error) {
+	return &models.OAuth2Token{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+func (p staticTokenProvider) EnsureValidToken(ctx context.Context) (*models.OAuth2Token, error) {
+	return p.GetValidToken(ctx)
+}
+
+func itemIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("tag:google.com,2005:reader/item/%016x", i)
+	}
+	return ids
+}
+
+func TestReadStateSync_EnqueueDeduplicates(t *testing.T) {
+	s := NewReadStateSync(&fakeFormPoster{}, staticTokenProvider{}, &fakeZoneBudget{}, slog.Default())
+
+	assert.Equal(t, 2, s.Enqueue([]string{"item-1", "item-2", "item-1", ""}))
+	assert.Equal(t, 1, s.Enqueue([]string{"item-2", "item-3"}))
+	assert.Equal(t, 3, s.Pending())
+}
+
+func TestReadStateSync_FlushBatchesWithinZone2Budget(t *testing.T) {
+	poster := &fakeFormPoster{}
+	budget := &fakeZoneBudget{remaining: 2}
+	s := NewReadStateSync(poster, staticTokenProvider{}, budget, slog.Default())
+	s.Enqueue(itemIDs(250))
+
+	result, err := s.Flush(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, ReadStateFlushResult{Marked: 200, Calls: 2, Pending: 50, Deferred: "Zone 2 rate limit exceeded with safety buffer"}, result)
+	require.Len(t, poster.forms, 2)
+	assert.Equal(t, readStateTag, poster.forms[0].Get("a"))
+	assert.Len(t, poster.forms[0]["i"], markReadBatchSize)
+	for _, zone := range budget.zones {
+		assert.Equal(t, models.APIZoneWrite, zone, "only the write budget is consulted")
+	}
+}
+
+func TestReadStateSync_FailedBatchIsRequeued(t *testing.T) {
+	poster := &fakeFormPoster{err: errors.New("API request failed with status 503")}
+	s := NewReadStateSync(poster, staticTokenProvider{}, &fakeZoneBudget{remaining: 10}, slog.Default())
+	s.Enqueue([]string{"item-1", "item-2"})
+
+	result, err := s.Flush(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, 2, result.Pending)
+
+	poster.err = nil
+	result, err = s.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Marked)
+	assert.Equal(t, []string{"item-1", "item-2"}, poster.forms[1]["i"])
+	assert.Zero(t, s.Pending())
+}
+
+func TestReadStateSync_EmptyQueueSpendsNothing(t *testing.T) {
+	poster := &fakeFormPoster{}
+	budget := &fakeZoneBudget{remaining: 10}
+	s := NewReadStateSync(poster, staticTokenProvider{}, budget, slog.Default())
+
+	result, err := s.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, result)
+	assert.Empty(t, budget.zones)
+	assert.Empty(t, poster.forms)
+}
@@ -2,11 +2,13 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
 	"time"
 
+	"pre-processor-sidecar/domain"
 	"pre-processor-sidecar/repository"
 	"pre-processor-sidecar/service"
 )
@@ -26,22 +28,86 @@ type Scheduler struct {
 	mu            sync.Mutex
 	refreshTicker *time.Ticker
 	fetchTicker   *time.Ticker
+	readTicker    *time.Ticker
 	stopChan      chan struct{}
 	isRunning     bool
 	wg            sync.WaitGroup
 
+	// fetchInterval is the fixed fetch cadence used when fetchSchedule has
+	// no windows. fetchSchedule is guarded by mu so the Admin API can swap
+	// it while the loop runs.
+	fetchInterval time.Duration
+	fetchSchedule domain.FetchSchedule
+	now           func() time.Time
+
 	// fetchRunMu/refreshRunMu make the ticker-driven loop and an
 	// Admin-API-triggered manual run (TriggerFetchNow/TriggerRefreshNow)
 	// mutually exclusive — without this, an admin trigger racing the ticker
 	// could double-consume the 100 req/day Inoreader quota concurrently.
 	fetchRunMu   sync.Mutex
 	refreshRunMu sync.Mutex
+
+	// Push ingestion (NotifyStreams), guarded by pushMu. pushWake is
+	// buffered so a notification never blocks on the run loop.
+	pushMu        sync.Mutex
+	push          PushConfig
+	pushPending   []string
+	pushQueued    map[string]bool
+	lastPushAt    time.Time
+	lastPushFetch map[string]time.Time
+	pushWake      chan struct{}
+
+	// readState marks items read in Inoreader on its own ticker. Its calls
+	// spend the zone 2 (write) budget, so they never share fetchRunMu or
+	// the fetch cadence.
+	readState      ReadStateFlusher
+	readStateRunMu sync.Mutex
+}
+
+// ReadStateFlusher sends queued zone 2 read-state changes.
+// service.ReadStateSync implements it.
+type ReadStateFlusher interface {
+	Pending() int
+	Flush(ctx context.Context) (service.ReadStateFlushResult, error)
+}
+
+// maxPushPending bounds the push queue; notifications beyond it are dropped
+// and the streams are left to polling.
+const maxPushPending = 100
+
+// PushConfig enables push ingestion. The zero value disables it.
+type PushConfig struct {
+	// PollInterval replaces a shorter fetch interval while push is live;
+	// polling then only has to catch streams that never push.
+	PollInterval time.Duration
+	// StaleAfter is how long push counts as live after the last
+	// notification. Past it polling returns to the normal schedule.
+	StaleAfter time.Duration
+	// MinStreamInterval is the least time between two push-triggered
+	// fetches of one stream; notifications in between are ignored.
+	MinStreamInterval time.Duration
+}
+
+// Enabled reports whether push ingestion is configured.
+func (c PushConfig) Enabled() bool {
+	return c.StaleAfter > 0
 }
 
 // Config holds scheduler configuration
 type Config struct {
 	FetchInterval   time.Duration
 	RefreshInterval time.Duration
+
+	// FetchSchedule, when it has windows, replaces FetchInterval: fetches
+	// run at each window's own interval and pause outside every window.
+	FetchSchedule domain.FetchSchedule
+
+	// Push, when enabled, slows polling while push notifications arrive.
+	Push PushConfig
+
+	// ReadStateSyncInterval is how often queued mark-as-read changes are
+	// sent. Zero disables read-state sync.
+	ReadStateSyncInterval time.Duration
 }
 
 // DefaultConfig returns the default configuration for the scheduler
@@ -66,9 +132,20 @@ func NewScheduler(
 		subService:          subService,
 		articleFetchService: articleFetchService,
 		logger:              logger,
+		now:                 time.Now,
+		pushQueued:          make(map[string]bool),
+		lastPushFetch:       make(map[string]time.Time),
+		pushWake:            make(chan struct{}, 1),
 	}
 }
 
+// SetReadStateSync enables zone 2 read-state sync. Call it before Start.
+func (s *Scheduler) SetReadStateSync(readState ReadStateFlusher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readState = readState
+}
+
 // Start starts the scheduling loops
 func (s *Scheduler) Start(cfg Config) {
 	s.mu.Lock()
@@ -79,13 +156,36 @@ func (s *Scheduler) Start(cfg Config) {
 		return
 	}
 
+	s.fetchInterval = cfg.FetchInterval
+	s.fetchSchedule = cfg.FetchSchedule
+	s.pushMu.Lock()
+	s.push = cfg.Push
+	s.pushMu.Unlock()
+
 	s.logger.Info("Starting Inoreader Scheduler",
 		"fetch_interval", cfg.FetchInterval,
 		"refresh_interval", cfg.RefreshInterval)
+	s.logFetchScheduleLocked()
+	if cfg.Push.Enabled() {
+		s.logger.Info("Push ingestion enabled, polling slows while notifications arrive",
+			"push_poll_interval", cfg.Push.PollInterval,
+			"push_stale_after", cfg.Push.StaleAfter,
+			"push_min_stream_interval", cfg.Push.MinStreamInterval)
+	}
 
 	stopChan := make(chan struct{})
 	refreshTicker := time.NewTicker(cfg.RefreshInterval)
-	fetchTicker := time.NewTicker(cfg.FetchInterval)
+	fetchTicker := time.NewTicker(s.nextFetchDelayLocked())
+
+	// A nil channel never fires, leaving read-state sync off.
+	var readTick <-chan time.Time
+	s.readTicker = nil
+	if s.readState != nil && cfg.ReadStateSyncInterval > 0 {
+		s.readTicker = time.NewTicker(cfg.ReadStateSyncInterval)
+		readTick = s.readTicker.C
+		s.logger.Info("Read-state sync enabled on the zone 2 budget",
+			"read_state_sync_interval", cfg.ReadStateSyncInterval)
+	}
 
 	s.stopChan = stopChan
 	s.refreshTicker = refreshTicker
@@ -93,7 +193,7 @@ func (s *Scheduler) Start(cfg Config) {
 	s.isRunning = true
 
 	s.wg.Add(1)
-	go s.runLoop(stopChan, refreshTicker, fetchTicker)
+	go s.runLoop(stopChan, refreshTicker, fetchTicker, readTick)
 }
 
 // Stop stops the scheduler and waits for the run loop to fully exit, so a
@@ -113,13 +213,16 @@ func (s *Scheduler) Stop() {
 	if s.fetchTicker != nil {
 		s.fetchTicker.Stop()
 	}
+	if s.readTicker != nil {
+		s.readTicker.Stop()
+	}
 	s.isRunning = false
 	s.mu.Unlock()
 
 	s.wg.Wait()
 }
 
-func (s *Scheduler) runLoop(stopChan chan struct{}, refreshTicker, fetchTicker *time.Ticker) {
+func (s *Scheduler) runLoop(stopChan chan struct{}, refreshTicker, fetchTicker *time.Ticker, readTick <-chan time.Time) {
 	defer s.wg.Done()
 	for {
 		select {
@@ -129,19 +232,258 @@ func (s *Scheduler) runLoop(stopChan chan struct{}, refreshTicker, fetchTicker *
 			s.runRefresh()
 		case <-fetchTicker.C:
 			s.runFetch()
+			s.rescheduleFetch(fetchTicker)
+		case <-s.pushWake:
+			s.runPushFetches(stopChan)
+		case <-readTick:
+			// Off the loop: a slow write must not delay the next fetch.
+			// Stop still waits for it through wg.
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.runReadStateSync()
+			}()
 		}
 	}
 }
 
+// SetFetchSchedule replaces the fetch windows (e.g. from the Admin API). A
+// running loop is rescheduled immediately so a newly opened window does not
+// wait out a quiet-hours sleep computed under the old schedule.
+func (s *Scheduler) SetFetchSchedule(schedule domain.FetchSchedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fetchSchedule = schedule
+	s.logFetchScheduleLocked()
+	if s.isRunning && s.fetchTicker != nil {
+		s.fetchTicker.Reset(s.nextFetchDelayLocked())
+	}
+}
+
+// FetchSchedule returns the fetch windows currently in effect.
+func (s *Scheduler) FetchSchedule() domain.FetchSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetchSchedule
+}
+
+// rescheduleFetch points the fetch ticker at the next fetch, which depends
+// on the window active now rather than a fixed interval.
+func (s *Scheduler) rescheduleFetch(fetchTicker *time.Ticker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isRunning && s.fetchTicker == fetchTicker {
+		fetchTicker.Reset(s.nextFetchDelayLocked())
+	}
+}
+
+// nextFetchDelayLocked returns how long to wait before the next fetch: the
+// active window's interval, or until the next window opens during quiet
+// hours. While push is live the interval stretches to the push poll
+// interval, but never past the moment push would go stale. Callers must
+// hold mu.
+func (s *Scheduler) nextFetchDelayLocked() time.Duration {
+	now := s.now()
+	interval := s.fetchInterval
+	if s.fetchSchedule.Enabled() {
+		w, ok := s.fetchSchedule.ActiveWindow(now)
+		if !ok {
+			if delay := s.fetchSchedule.NextWindowStart(now).Sub(now); delay > 0 {
+				return delay
+			}
+			return time.Second
+		}
+		interval = w.Interval
+	}
+
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+	if !s.pushLiveLocked(now) || interval >= s.push.PollInterval {
+		return interval
+	}
+	untilStale := s.lastPushAt.Add(s.push.StaleAfter).Sub(now)
+	return max(interval, min(s.push.PollInterval, untilStale))
+}
+
+// inFetchWindow reports whether a ticker-driven fetch may run now.
+func (s *Scheduler) inFetchWindow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.fetchSchedule.Enabled() {
+		return true
+	}
+	_, ok := s.fetchSchedule.ActiveWindow(s.now())
+	return ok
+}
+
+func (s *Scheduler) logFetchScheduleLocked() {
+	if !s.fetchSchedule.Enabled() {
+		s.logger.Info("Fetch windows disabled, fetching around the clock",
+			"fetch_interval", s.fetchInterval)
+		return
+	}
+	s.logger.Info("Fetch windows enabled, pausing fetches outside them",
+		"fetch_windows", s.fetchSchedule.String(),
+		"timezone", s.fetchSchedule.TimezoneName(),
+		"estimated_daily_fetches", s.fetchSchedule.EstimatedDailyFetches())
+}
+
 // runFetch runs the ticker-driven article fetch, skipping this tick if a
-// fetch (ticker-driven or via TriggerFetchNow) is already in progress.
+// fetch (ticker-driven or via TriggerFetchNow) is already in progress or
+// the tick landed in quiet hours.
 func (s *Scheduler) runFetch() {
+	if !s.inFetchWindow() {
+		s.logger.Debug("Skipping scheduled article fetch: outside fetch windows")
+		return
+	}
 	if !s.fetchRunMu.TryLock() {
 		s.logger.Warn("Skipping scheduled article fetch: a fetch is already in progress")
 		return
 	}
 	defer s.fetchRunMu.Unlock()
 	s.fetchNextStream()
+
+	// Streams pushed during quiet hours waited for this window.
+	s.pushMu.Lock()
+	pending := len(s.pushPending) > 0
+	s.pushMu.Unlock()
+	if pending {
+		s.wakePush()
+	}
+}
+
+// NotifyStreams queues push-triggered fetches of streamIDs and marks push
+// as live. Streams already queued, or fetched by push within the minimum
+// stream interval, are skipped. It returns how many streams were queued;
+// the fetches run asynchronously on the scheduler loop.
+func (s *Scheduler) NotifyStreams(streamIDs []string) int {
+	s.pushMu.Lock()
+	now := s.now()
+	s.lastPushAt = now
+	for id, at := range s.lastPushFetch {
+		if now.Sub(at) >= s.push.MinStreamInterval {
+			delete(s.lastPushFetch, id)
+		}
+	}
+
+	queued := 0
+	for _, id := range streamIDs {
+		if s.pushQueued[id] || len(s.pushPending) >= maxPushPending {
+			continue
+		}
+		if _, recent := s.lastPushFetch[id]; recent {
+			continue
+		}
+		s.pushQueued[id] = true
+		s.pushPending = append(s.pushPending, id)
+		queued++
+	}
+	s.pushMu.Unlock()
+
+	if queued > 0 {
+		s.wakePush()
+	}
+	return queued
+}
+
+// KnownStream reports whether streamID is a subscribed stream, so push
+// notifications for anything else are ignored before they spend quota.
+func (s *Scheduler) KnownStream(ctx context.Context, streamID string) (bool, error) {
+	if _, err := s.syncRepo.FindByStreamID(ctx, streamID); err != nil {
+		if errors.Is(err, repository.ErrSyncStateNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// PushLive reports whether a push notification arrived within the stale
+// window, i.e. whether polling is currently slowed.
+func (s *Scheduler) PushLive() bool {
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+	return s.pushLiveLocked(s.now())
+}
+
+// pushLiveLocked must be called with pushMu held.
+func (s *Scheduler) pushLiveLocked(now time.Time) bool {
+	return s.push.Enabled() && !s.lastPushAt.IsZero() && now.Sub(s.lastPushAt) < s.push.StaleAfter
+}
+
+func (s *Scheduler) wakePush() {
+	select {
+	case s.pushWake <- struct{}{}:
+	default:
+	}
+}
+
+// nextPushedStream pops the oldest queued stream.
+func (s *Scheduler) nextPushedStream() (string, bool) {
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+	if len(s.pushPending) == 0 {
+		return "", false
+	}
+	id := s.pushPending[0]
+	s.pushPending = s.pushPending[1:]
+	delete(s.pushQueued, id)
+	s.lastPushFetch[id] = s.now()
+	return id, true
+}
+
+// runPushFetches fetches the streams queued by NotifyStreams one at a time,
+// sharing fetchRunMu with the ticker and manual triggers. Outside fetch
+// windows the queue is kept for the next window.
+func (s *Scheduler) runPushFetches(stopChan chan struct{}) {
+	if !s.inFetchWindow() {
+		s.logger.Debug("Deferring pushed streams: outside fetch windows")
+		return
+	}
+	s.fetchRunMu.Lock()
+	defer s.fetchRunMu.Unlock()
+	for {
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+		streamID, ok := s.nextPushedStream()
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		s.logger.Info("Fetching content for pushed stream", "stream_id", streamID)
+		s.fetchStream(ctx, streamID)
+		cancel()
+	}
+}
+
+// runReadStateSync sends queued read-state changes, skipping this tick if
+// the previous sync is still running or nothing is queued.
+func (s *Scheduler) runReadStateSync() {
+	if s.readState.Pending() == 0 {
+		return
+	}
+	if !s.readStateRunMu.TryLock() {
+		s.logger.Warn("Skipping read-state sync: a sync is already in progress")
+		return
+	}
+	defer s.readStateRunMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	result, err := s.readState.Flush(ctx)
+	if err != nil {
+		s.logger.Error("Failed to sync read state", "error", err, "marked", result.Marked, "pending", result.Pending)
+		return
+	}
+	s.logger.Info("Synced read state",
+		"marked", result.Marked,
+		"calls", result.Calls,
+		"pending", result.Pending,
+		"deferred", result.Deferred)
 }
 
 // runRefresh runs the ticker-driven subscription refresh, skipping this
@@ -156,8 +498,9 @@ func (s *Scheduler) runRefresh() {
 }
 
 // TriggerFetchNow runs an out-of-band article fetch (e.g. from the Admin
-// API's manual trigger endpoint). Mutually exclusive with the ticker-driven
-// fetch via fetchRunMu, so the two paths can never run concurrently and
+// API's manual trigger endpoint). Fetch windows are not consulted: a manual
+// trigger is an explicit operator decision. Mutually exclusive with the
+// ticker-driven fetch via fetchRunMu, so the two paths can never run concurrently and
 // double-consume the Inoreader daily quota. Runs asynchronously, matching
 // the fire-and-forget semantics HTTP callers expect.
 func (s *Scheduler) TriggerFetchNow() error {
@@ -219,12 +562,17 @@ func (s *Scheduler) fetchNextStream() {
 		"stream_id", syncState.StreamID,
 		"last_sync", syncState.LastSync)
 
-	// 2. Fetch AND Save content using ArticleFetchService
+	s.fetchStream(ctx, syncState.StreamID)
+}
+
+// fetchStream fetches and saves one stream's new articles.
+func (s *Scheduler) fetchStream(ctx context.Context, streamID string) {
+	// Fetch AND Save content using ArticleFetchService
 	// This ensures articles are persisted to the database
-	result, err := s.articleFetchService.FetchArticles(ctx, syncState.StreamID, 100)
+	result, err := s.articleFetchService.FetchArticles(ctx, streamID, 100)
 	if err != nil {
 		s.logger.Error("Failed to fetch and save articles",
-			"stream_id", syncState.StreamID,
+			"stream_id", streamID,
 			"error", err)
 		return
 	}
@@ -235,7 +583,7 @@ func (s *Scheduler) fetchNextStream() {
 	}
 
 	s.logger.Info("Successfully processed stream",
-		"stream_id", syncState.StreamID,
+		"stream_id", streamID,
 		"articles_found", result.TotalProcessed,
 		"new_articles_saved", result.NewArticles,
 		"has_continuation", result.ContinuationToken != "")
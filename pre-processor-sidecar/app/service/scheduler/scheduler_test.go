@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"pre-processor-sidecar/domain"
 	"pre-processor-sidecar/models"
 	"pre-processor-sidecar/repository"
+	"pre-processor-sidecar/service"
 	// Check if we need to mock services.
 	// Since we are testing logic, we can mock repository.
 )
@@ -15,8 +17,16 @@ import (
 // MockSyncStateRepository
 type MockSyncStateRepository struct {
 	repository.SyncStateRepository
-	GetOldestOneFunc func(ctx context.Context) (*models.SyncState, error)
-	UpdateFunc       func(ctx context.Context, syncState *models.SyncState) error
+	GetOldestOneFunc   func(ctx context.Context) (*models.SyncState, error)
+	UpdateFunc         func(ctx context.Context, syncState *models.SyncState) error
+	FindByStreamIDFunc func(ctx context.Context, streamID string) (*models.SyncState, error)
+}
+
+func (m *MockSyncStateRepository) FindByStreamID(ctx context.Context, streamID string) (*models.SyncState, error) {
+	if m.FindByStreamIDFunc != nil {
+		return m.FindByStreamIDFunc(ctx, streamID)
+	}
+	return nil, repository.ErrSyncStateNotFound
 }
 
 func (m *MockSyncStateRepository) GetOldestOne(ctx context.Context) (*models.SyncState, error) {
@@ -100,3 +110,195 @@ func TestScheduler_RestartAfterStop(t *testing.T) {
 
 	s.Stop()
 }
+
+func TestScheduler_NextFetchDelay(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	schedule, err := domain.NewFetchSchedule("06:00-12:00@20m,12:00-24:00@15m", "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("NewFetchSchedule: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule domain.FetchSchedule
+		now      time.Time
+		want     time.Duration
+	}{
+		{name: "no windows uses fixed interval", now: time.Date(2026, 10, 16, 3, 0, 0, 0, loc), want: 16 * time.Minute},
+		{name: "morning window interval", schedule: schedule, now: time.Date(2026, 10, 16, 7, 0, 0, 0, loc), want: 20 * time.Minute},
+		{name: "evening window interval", schedule: schedule, now: time.Date(2026, 10, 16, 20, 0, 0, 0, loc), want: 15 * time.Minute},
+		{name: "quiet hours sleep until window opens", schedule: schedule, now: time.Date(2026, 10, 16, 2, 30, 0, 0, loc), want: 3*time.Hour + 30*time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(nil, nil, nil, slog.Default())
+			s.fetchInterval = 16 * time.Minute
+			s.fetchSchedule = tt.schedule
+			s.now = func() time.Time { return tt.now }
+
+			if got := s.nextFetchDelayLocked(); got != tt.want {
+				t.Errorf("nextFetchDelayLocked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduler_QuietHoursSkipFetch(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	schedule, err := domain.NewFetchSchedule("06:00-24:00@16m", "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("NewFetchSchedule: %v", err)
+	}
+
+	// Services are nil: reaching fetchNextStream would panic, so this also
+	// proves quiet hours never touch the Inoreader API.
+	s := NewScheduler(nil, nil, nil, slog.Default())
+	s.SetFetchSchedule(schedule)
+	s.now = func() time.Time { return time.Date(2026, 10, 16, 3, 0, 0, 0, loc) }
+
+	s.runFetch()
+
+	if got := s.FetchSchedule().String(); got != "06:00-24:00@16m" {
+		t.Errorf("FetchSchedule() = %q", got)
+	}
+}
+
+func TestScheduler_NotifyStreams(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	s := NewScheduler(nil, nil, nil, slog.Default())
+	s.push = PushConfig{PollInterval: 2 * time.Hour, StaleAfter: time.Hour, MinStreamInterval: 10 * time.Minute}
+	s.now = func() time.Time { return now }
+
+	if s.PushLive() {
+		t.Fatal("push should not be live before the first notification")
+	}
+	if got := s.NotifyStreams([]string{"feed/a", "feed/b", "feed/a"}); got != 2 {
+		t.Fatalf("NotifyStreams() = %d, want 2", got)
+	}
+	if !s.PushLive() {
+		t.Fatal("push should be live after a notification")
+	}
+	if got := s.NotifyStreams([]string{"feed/a"}); got != 0 {
+		t.Errorf("already queued stream was queued again: %d", got)
+	}
+
+	// Popping a stream records its fetch; a notification within
+	// MinStreamInterval is ignored, one after it is queued.
+	if id, ok := s.nextPushedStream(); !ok || id != "feed/a" {
+		t.Fatalf("nextPushedStream() = %q, %v", id, ok)
+	}
+	now = now.Add(5 * time.Minute)
+	if got := s.NotifyStreams([]string{"feed/a"}); got != 0 {
+		t.Errorf("recently fetched stream was queued: %d", got)
+	}
+	now = now.Add(10 * time.Minute)
+	if got := s.NotifyStreams([]string{"feed/a"}); got != 1 {
+		t.Errorf("stream past MinStreamInterval was not queued: %d", got)
+	}
+
+	now = now.Add(time.Hour)
+	if s.PushLive() {
+		t.Error("push should go stale after StaleAfter without notifications")
+	}
+}
+
+func TestScheduler_NextFetchDelayWithPush(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	s := NewScheduler(nil, nil, nil, slog.Default())
+	s.fetchInterval = 16 * time.Minute
+	s.push = PushConfig{PollInterval: 2 * time.Hour, StaleAfter: time.Hour, MinStreamInterval: time.Minute}
+	s.now = func() time.Time { return now }
+
+	if got := s.nextFetchDelayLocked(); got != 16*time.Minute {
+		t.Errorf("without notifications = %v, want the fetch interval", got)
+	}
+
+	// Polling waits until push would go stale, capped at PollInterval.
+	s.NotifyStreams(nil)
+	if got := s.nextFetchDelayLocked(); got != time.Hour {
+		t.Errorf("push live = %v, want 1h", got)
+	}
+	s.push.StaleAfter = 6 * time.Hour
+	if got := s.nextFetchDelayLocked(); got != 2*time.Hour {
+		t.Errorf("push live with long stale window = %v, want 2h", got)
+	}
+
+	now = now.Add(6 * time.Hour)
+	if got := s.nextFetchDelayLocked(); got != 16*time.Minute {
+		t.Errorf("push stale = %v, want the fetch interval", got)
+	}
+}
+
+func TestScheduler_KnownStream(t *testing.T) {
+	repo := &MockSyncStateRepository{FindByStreamIDFunc: func(_ context.Context, streamID string) (*models.SyncState, error) {
+		if streamID == "feed/known" {
+			return &models.SyncState{StreamID: streamID}, nil
+		}
+		return nil, repository.ErrSyncStateNotFound
+	}}
+	s := NewScheduler(repo, nil, nil, slog.Default())
+
+	for id, want := range map[string]bool{"feed/known": true, "feed/unknown": false} {
+		got, err := s.KnownStream(context.Background(), id)
+		if err != nil || got != want {
+			t.Errorf("KnownStream(%q) = %v, %v; want %v", id, got, err, want)
+		}
+	}
+}
+
+type fakeReadStateFlusher struct {
+	pending int
+	flushed chan struct{}
+}
+
+func (f *fakeReadStateFlusher) Pending() int { return f.pending }
+
+func (f *fakeReadStateFlusher) Flush(context.Context) (service.ReadStateFlushResult, error) {
+	select {
+	case f.flushed <- struct{}{}:
+	default:
+	}
+	return service.ReadStateFlushResult{Marked: f.pending, Calls: 1}, nil
+}
+
+func TestScheduler_ReadStateSyncRunsOnItsOwnTicker(t *testing.T) {
+	// Fetch and refresh services are nil and their tickers never fire here:
+	// read-state sync must run without waiting on either.
+	flusher := &fakeReadStateFlusher{pending: 3, flushed: make(chan struct{}, 1)}
+	s := NewScheduler(nil, nil, nil, slog.Default())
+	s.SetReadStateSync(flusher)
+
+	s.Start(Config{
+		FetchInterval:         time.Hour,
+		RefreshInterval:       time.Hour,
+		ReadStateSyncInterval: 10 * time.Millisecond,
+	})
+	defer s.Stop()
+
+	select {
+	case <-flusher.flushed:
+	case <-time.After(time.Second):
+		t.Fatal("read-state sync did not run")
+	}
+}
+
+func TestScheduler_ReadStateSyncSkipsEmptyQueue(t *testing.T) {
+	flusher := &fakeReadStateFlusher{flushed: make(chan struct{}, 1)}
+	s := NewScheduler(nil, nil, nil, slog.Default())
+	s.SetReadStateSync(flusher)
+
+	s.runReadStateSync()
+
+	select {
+	case <-flusher.flushed:
+		t.Error("Flush called with nothing queued")
+	default:
+	}
+}
@@ -0,0 +1,127 @@
+// ABOUTME: Per-subscription fetch failure classification and auto-pause bookkeeping
+// ABOUTME: Subscriptions failing repeatedly are paused so they stop consuming the daily API quota
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"pre-processor-sidecar/driver"
+	"pre-processor-sidecar/models"
+	"pre-processor-sidecar/repository"
+)
+
+// ErrStreamContentsParse wraps stream contents responses that could not be parsed.
+var ErrStreamContentsParse = errors.New("failed to parse stream contents")
+
+// ErrSubscriptionPaused is returned by FetchArticles for streams whose
+// subscription was auto-paused. No API call is made.
+var ErrSubscriptionPaused = errors.New("subscription is paused")
+
+// ClassifySubscriptionFetchError reports whether err is the subscription's own
+// fault and, if so, which kind. Token, rate-limit, circuit-breaker, 5xx and
+// transport errors return false: they say nothing about the subscription and
+// must not pause it.
+func ClassifySubscriptionFetchError(err error) (models.SubscriptionErrorKind, bool) {
+	if errors.Is(err, ErrStreamContentsParse) {
+		return models.SubscriptionErrorParse, true
+	}
+
+	var statusErr *driver.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusNotFound:
+			return models.SubscriptionErrorNotFound, true
+		case http.StatusForbidden, http.StatusGone:
+			return models.SubscriptionErrorUnsubscribed, true
+		}
+	}
+
+	return "", false
+}
+
+// SetSubscriptionHealthTracking enables per-subscription failure tracking.
+// pauseThreshold consecutive failures pause a subscription; 0 tracks
+// failures without ever pausing.
+func (s *ArticleFetchService) SetSubscriptionHealthTracking(repo repository.SubscriptionHealthRepository, pauseThreshold int) {
+	s.healthRepo = repo
+	s.pauseThreshold = pauseThreshold
+}
+
+// checkSubscriptionPaused returns ErrSubscriptionPaused for paused streams.
+// A failed lookup lets the fetch proceed: the pause only saves quota and must
+// not stop ingestion when the health table is unavailable.
+func (s *ArticleFetchService) checkSubscriptionPaused(ctx context.Context, streamID string) error {
+	if s.healthRepo == nil {
+		return nil
+	}
+
+	paused, err := s.healthRepo.IsPaused(ctx, streamID)
+	if err != nil {
+		s.logger.Warn("Failed to check subscription pause state, fetching anyway",
+			"stream_id", streamID,
+			"error", err)
+		return nil
+	}
+	if paused {
+		s.logger.Info("Skipping paused subscription", "stream_id", streamID)
+		return fmt.Errorf("%w: %s", ErrSubscriptionPaused, streamID)
+	}
+
+	return nil
+}
+
+// recordSubscriptionFailure counts fetchErr against the subscription when it
+// is the subscription's fault, pausing it at the threshold.
+func (s *ArticleFetchService) recordSubscriptionFailure(ctx context.Context, streamID string, fetchErr error) {
+	if s.healthRepo == nil {
+		return
+	}
+	kind, ok := ClassifySubscriptionFetchError(fetchErr)
+	if !ok {
+		return
+	}
+
+	health, err := s.healthRepo.RecordFailure(ctx, streamID, kind, fetchErr.Error(), s.pauseThreshold)
+	if err != nil {
+		s.logger.Error("Failed to record subscription failure",
+			"stream_id", streamID,
+			"error_kind", kind,
+			"error", err)
+		return
+	}
+	if health == nil {
+		return
+	}
+
+	if health.IsPaused() {
+		s.logger.Warn("Subscription auto-paused after repeated failures",
+			"stream_id", streamID,
+			"subscription_id", health.SubscriptionID,
+			"error_kind", kind,
+			"consecutive_failures", health.ConsecutiveFailures,
+			"pause_threshold", s.pauseThreshold)
+		return
+	}
+	s.logger.Warn("Subscription fetch failure recorded",
+		"stream_id", streamID,
+		"subscription_id", health.SubscriptionID,
+		"error_kind", kind,
+		"consecutive_failures", health.ConsecutiveFailures,
+		"pause_threshold", s.pauseThreshold)
+}
+
+// recordSubscriptionSuccess ends a failure streak.
+func (s *ArticleFetchService) recordSubscriptionSuccess(ctx context.Context, streamID string) {
+	if s.healthRepo == nil {
+		return
+	}
+	if err := s.healthRepo.RecordSuccess(ctx, streamID); err != nil {
+		s.logger.Warn("Failed to record subscription success",
+			"stream_id", streamID,
+			"error", err)
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"pre-processor-sidecar/driver"
+	"pre-processor-sidecar/models"
+	"pre-processor-sidecar/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSubscriptionHealthRepo struct {
+	paused    map[string]bool
+	failures  map[string]int
+	successes []string
+	pausedErr error
+}
+
+func newFakeSubscriptionHealthRepo() *fakeSubscriptionHealthRepo {
+	return &fakeSubscriptionHealthRepo{paused: map[string]bool{}, failures: map[string]int{}}
+}
+
+func (f *fakeSubscriptionHealthRepo) RecordFailure(_ context.Context, streamID string, kind models.SubscriptionErrorKind, message string, pauseThreshold int) (*models.SubscriptionHealth, error) {
+	f.failures[streamID]++
+	health := &models.SubscriptionHealth{
+		SubscriptionID:      uuid.New(),
+		InoreaderID:         streamID,
+		ConsecutiveFailures: f.failures[streamID],
+		LastErrorKind:       kind,
+		LastError:           message,
+	}
+	if pauseThreshold > 0 && f.failures[streamID] >= pauseThreshold {
+		now := time.Now()
+		health.PausedAt = &now
+		f.paused[streamID] = true
+	}
+	return health, nil
+}
+
+func (f *fakeSubscriptionHealthRepo) RecordSuccess(_ context.Context, streamID string) error {
+	f.failures[streamID] = 0
+	f.successes = append(f.successes, streamID)
+	return nil
+}
+
+func (f *fakeSubscriptionHealthRepo) IsPaused(_ context.Context, streamID string) (bool, error) {
+	return f.paused[streamID], f.pausedErr
+}
+
+func (f *fakeSubscriptionHealthRepo) ListPaused(context.Context) ([]*models.SubscriptionHealth, error) {
+	return nil, nil
+}
+
+func (f *fakeSubscriptionHealthRepo) Resume(context.Context, uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func TestClassifySubscriptionFetchError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		wantKind models.SubscriptionErrorKind
+		wantOK   bool
+	}{
+		"404 stream": {
+			err:      fmt.Errorf("stream contents fetch failed: %w", &driver.HTTPStatusError{StatusCode: http.StatusNotFound}),
+			wantKind: models.SubscriptionErrorNotFound, wantOK: true,
+		},
+		"unsubscribed upstream": {
+			err:      &driver.HTTPStatusError{StatusCode: http.StatusForbidden},
+			wantKind: models.SubscriptionErrorUnsubscribed, wantOK: true,
+		},
+		"gone": {
+			err:      &driver.HTTPStatusError{StatusCode: http.StatusGone},
+			wantKind: models.SubscriptionErrorUnsubscribed, wantOK: true,
+		},
+		"parse error": {
+			err:      fmt.Errorf("%w: %w", ErrStreamContentsParse, errors.New("unexpected EOF")),
+			wantKind: models.SubscriptionErrorParse, wantOK: true,
+		},
+		"rate limited":         {err: &driver.HTTPStatusError{StatusCode: http.StatusTooManyRequests}},
+		"upstream 5xx":         {err: &driver.HTTPStatusError{StatusCode: http.StatusBadGateway}},
+		"expired token":        {err: &driver.HTTPStatusError{StatusCode: http.StatusUnauthorized}},
+		"circuit breaker open": {err: fmt.Errorf("service temporarily unavailable: %w", utils.ErrCircuitBreakerOpen)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			kind, ok := ClassifySubscriptionFetchError(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantKind, kind)
+		})
+	}
+}
+
+func TestSubscriptionHealth_PausesAfterThreshold(t *testing.T) {
+	repo := newFakeSubscriptionHealthRepo()
+	s := &ArticleFetchService{logger: slog.Default()}
+	s.SetSubscriptionHealthTracking(repo, 3)
+	ctx := context.Background()
+	streamID := "feed/https://gone.example.com/rss"
+	notFound := &driver.HTTPStatusError{StatusCode: http.StatusNotFound}
+
+	for i := 0; i < 2; i++ {
+		s.recordSubscriptionFailure(ctx, streamID, notFound)
+	}
+	require.NoError(t, s.checkSubscriptionPaused(ctx, streamID))
+
+	s.recordSubscriptionFailure(ctx, streamID, notFound)
+	assert.ErrorIs(t, s.checkSubscriptionPaused(ctx, streamID), ErrSubscriptionPaused)
+}
+
+func TestSubscriptionHealth_IgnoresNonSubscriptionErrors(t *testing.T) {
+	repo := newFakeSubscriptionHealthRepo()
+	s := &ArticleFetchService{logger: slog.Default()}
+	s.SetSubscriptionHealthTracking(repo, 1)
+
+	s.recordSubscriptionFailure(context.Background(), "feed/a", &driver.HTTPStatusError{StatusCode: http.StatusTooManyRequests})
+
+	assert.Empty(t, repo.failures)
+	assert.NoError(t, s.checkSubscriptionPaused(context.Background(), "feed/a"))
+}
+
+func TestSubscriptionHealth_LookupFailureDoesNotBlockFetch(t *testing.T) {
+	repo := newFakeSubscriptionHealthRepo()
+	repo.pausedErr = errors.New("connection refused")
+	s := &ArticleFetchService{logger: slog.Default()}
+	s.SetSubscriptionHealthTracking(repo, 1)
+
+	assert.NoError(t, s.checkSubscriptionPaused(context.Background(), "feed/a"))
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	connectv2 "pre-processor/connect/v2"
+	"pre-processor/handler/openapi"
 	appmiddleware "pre-processor/middleware"
+	"pre-processor/spec"
 	"pre-processor/tlsutil"
 
 	"github.com/labstack/echo/v4"
@@ -23,6 +25,13 @@ const (
 
 // NewHTTPServer creates and configures the Echo HTTP server.
 func NewHTTPServer(deps *Dependencies, otelEnabled bool, otelServiceName string) *echo.Echo {
+	// The document is embedded, so a parse failure is a build defect that
+	// TestHTTPServer_OpenAPISpec catches, not a runtime condition.
+	openAPIValidator, err := appmiddleware.NewOpenAPIValidator(spec.OpenAPI, "/api/v1")
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded OpenAPI document: %v", err))
+	}
+
 	e := echo.New()
 	e.HideBanner = true
 
@@ -73,10 +82,31 @@ func NewHTTPServer(deps *Dependencies, otelEnabled bool, otelServiceName string)
 	// alt-backend, so 1 MiB comfortably covers real inputs while rejecting
 	// DoS payloads before they reach the summarize pipeline.
 	api.Use(middleware.BodyLimit("1M"))
-	api.POST("/summarize", deps.SummarizeHandler.HandleSummarize)
-	api.POST("/summarize/stream", deps.SummarizeHandler.HandleStreamSummarize)
-	api.POST("/summarize/queue", deps.SummarizeHandler.HandleSummarizeQueue)
-	api.GET("/summarize/status/:job_id", deps.SummarizeHandler.HandleSummarizeStatus)
+	// Operations described by spec/openapi.yaml are validated against it
+	// before they reach a handler; malformed requests get a 400
+	// VALIDATION_ERROR listing the violations.
+	api.Use(openAPIValidator.Middleware())
+	openapi.RegisterHandlers(api, deps.SummarizeHandler)
+
+	// Background job scheduler: next-run times and manual triggers.
+	api.GET("/jobs", deps.JobsHandler.HandleListJobs)
+	api.POST("/jobs/:name/run", deps.JobsHandler.HandleTriggerJob)
+
+	// Per-feed processing overrides.
+	api.GET("/feed-settings", deps.FeedSettings.HandleListFeedSettings)
+	api.GET("/feed-settings/:feed_id", deps.FeedSettings.HandleGetFeedSettings)
+	api.PUT("/feed-settings/:feed_id", deps.FeedSettings.HandlePutFeedSettings)
+	api.DELETE("/feed-settings/:feed_id", deps.FeedSettings.HandleDeleteFeedSettings)
+
+	// Per-user locale, which picks the summary language.
+	api.GET("/users/:user_id/locale", deps.UserLocales.HandleGetUserLocale)
+	api.PUT("/users/:user_id/locale", deps.UserLocales.HandlePutUserLocale)
+	api.DELETE("/users/:user_id/locale", deps.UserLocales.HandleDeleteUserLocale)
+
+	// Human review queue for sampled summaries.
+	api.GET("/summary-reviews", deps.SummaryReviews.HandleListSummaryReviews)
+	api.POST("/summary-reviews/:id/approve", deps.SummaryReviews.HandleApproveSummaryReview)
+	api.POST("/summary-reviews/:id/reject", deps.SummaryReviews.HandleRejectSummaryReview)
 
 	return e
 }
@@ -113,7 +143,7 @@ type ConnectServers struct {
 // is started alongside the plaintext listener. Any ListenAndServe failure is
 // sent on errCh so the caller can fail the whole process.
 func StartConnectServer(deps *Dependencies, errCh chan<- error) *ConnectServers {
-	connectHandler := connectv2.CreateConnectServer(deps.APIRepo, deps.SummaryRepo, deps.ArticleRepo, deps.JobRepo, deps.Logger)
+	connectHandler := connectv2.CreateConnectServer(deps.APIRepo, deps.SummaryRepo, deps.ArticleRepo, deps.JobRepo, deps.UserLocaleRepo, deps.Logger)
 
 	port := os.Getenv("CONNECT_PORT")
 	if port == "" {
@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "pre-processor/utils/errors"
+)
+
+// Every operation in spec/openapi.yaml must be served, and requests that do
+// not match it must be rejected before the handlers run.
+func TestHTTPServer_OpenAPISpec(t *testing.T) {
+	srv := NewHTTPServer(newTestHTTPServer(t, "unit-test-secret"), false, "")
+
+	routes := make(map[string]bool)
+	for _, r := range srv.Routes() {
+		routes[r.Method+" "+r.Path] = true
+	}
+	for _, want := range []string{
+		"POST /api/v1/summarize",
+		"POST /api/v1/summarize/stream",
+		"POST /api/v1/summarize/queue",
+		"GET /api/v1/summarize/status/:job_id",
+	} {
+		assert.True(t, routes[want], "route %s is not registered", want)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		want   []apperrors.FieldViolation
+	}{
+		{
+			name:   "summarize without article_id",
+			method: http.MethodPost,
+			url:    "/api/v1/summarize",
+			body:   `{"content":"text"}`,
+			want:   []apperrors.FieldViolation{{Field: "body.article_id", Reason: "required"}},
+		},
+		{
+			name:   "queue with a non-string title",
+			method: http.MethodPost,
+			url:    "/api/v1/summarize/queue",
+			body:   `{"article_id":"a1","title":42}`,
+			want:   []apperrors.FieldViolation{{Field: "body.title", Reason: "invalid_type"}},
+		},
+		{
+			name:   "status with a malformed job_id",
+			method: http.MethodGet,
+			url:    "/api/v1/summarize/status/not-a-uuid",
+			want:   []apperrors.FieldViolation{{Field: "path.job_id", Reason: "invalid_format"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+
+			srv.ServeHTTP(res, req)
+
+			require.Equal(t, http.StatusBadRequest, res.Code, res.Body.String())
+			var body apperrors.SecureHTTPResponse
+			require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, "VALIDATION_ERROR", body.Error.Code)
+			assert.Equal(t, tt.want, body.Error.Violations)
+		})
+	}
+}
@@ -17,6 +17,10 @@ func newTestHTTPServer(t *testing.T, _ string) *Dependencies {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	return &Dependencies{
 		SummarizeHandler: handler.NewSummarizeHandler(nil, nil, nil, nil, logger),
+		JobsHandler:      handler.NewJobsHandler(nil, logger),
+		FeedSettings:     handler.NewFeedSettingsHandler(nil, nil, logger),
+		UserLocales:      handler.NewUserLocaleHandler(nil, logger),
+		SummaryReviews:   handler.NewSummaryReviewHandler(nil, logger),
 		Logger:           logger,
 	}
 }
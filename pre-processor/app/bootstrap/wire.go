@@ -12,14 +12,19 @@ import (
 
 	"pre-processor/config"
 	"pre-processor/consumer"
+	"pre-processor/domain"
 	"pre-processor/driver"
 	backend_api "pre-processor/driver/backend_api"
 	"pre-processor/handler"
+	"pre-processor/orchestrator"
 	qualitychecker "pre-processor/quality-checker"
 	"pre-processor/repository"
 	"pre-processor/service"
 	"pre-processor/tlsutil"
+	"pre-processor/utils"
 	logger "pre-processor/utils/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // buildBackendHTTPClient returns an *http.Client the Connect-RPC backend
@@ -66,16 +71,21 @@ const (
 // Dependencies holds all application dependencies.
 type Dependencies struct {
 	JobHandler       handler.JobHandler
+	JobsHandler      *handler.JobsHandler
+	FeedSettings     *handler.FeedSettingsHandler
+	UserLocales      *handler.UserLocaleHandler
+	SummaryReviews   *handler.SummaryReviewHandler
 	HealthHandler    handler.HealthHandler
 	SummarizeHandler *handler.SummarizeHandler
 	RedisConsumer    *consumer.Consumer
 	Logger           *slog.Logger
 
 	// Repositories (exposed for Connect-RPC server)
-	APIRepo     repository.ExternalAPIRepository
-	SummaryRepo repository.SummaryRepository
-	ArticleRepo repository.ArticleRepository
-	JobRepo     repository.SummarizeJobRepository
+	APIRepo        repository.ExternalAPIRepository
+	SummaryRepo    repository.SummaryRepository
+	ArticleRepo    repository.ArticleRepository
+	JobRepo        repository.SummarizeJobRepository
+	UserLocaleRepo repository.UserLocaleRepository
 }
 
 // BuildDependencies constructs all application dependencies.
@@ -122,21 +132,53 @@ func BuildDependencies(ctx context.Context, log *slog.Logger, otelEnabled bool)
 	articleRepo := backend_api.NewArticleRepository(client, ppDBPool)
 	summaryRepo := backend_api.NewSummaryRepository(client)
 
+	healthCheckerService := service.NewHealthCheckerServiceWithFactory(cfg, cfg.NewsCreator.Host, log)
 	apiRepo := repository.NewExternalAPIRepository(cfg, log)
+	newsCreatorBreaker := buildNewsCreatorBreaker(cfg, healthCheckerService, log)
+	if newsCreatorBreaker != nil {
+		apiRepo = repository.NewCircuitBreakerExternalAPIRepository(apiRepo, newsCreatorBreaker)
+	}
 	jobRepo := repository.NewSummarizeJobRepository(ppDBPool, log)
+	feedSettingsRepo := repository.NewFeedSettingsRepository(ppDBPool, log)
+	summaryReviewRepo := repository.NewSummaryReviewRepository(ppDBPool, log)
+	userLocaleRepo := repository.NewUserLocaleRepository(ppDBPool, log)
+
+	// Per-feed overrides are applied over these global settings.
+	feedSettings := service.NewFeedSettingsResolver(feedSettingsRepo, articleRepo, domain.EffectiveFeedSettings{
+		BatchSize:            batchSize,
+		SummarizationEnabled: true,
+		QualityStrictness:    domain.QualityStrictnessStandard,
+	}, log)
 
 	// Initialize services
 	articleSummarizerService := service.NewArticleSummarizerService(articleRepo, summaryRepo, apiRepo, log)
-	qualityCheckerService := service.NewQualityCheckerService(summaryRepo, articleRepo, apiRepo, jobRepo, log)
-	healthCheckerService := service.NewHealthCheckerServiceWithFactory(cfg, cfg.NewsCreator.Host, log)
-	articleSyncService := service.NewArticleSyncService(articleRepo, apiRepo, log)
+	qualityCheckerService := service.NewSampledQualityCheckerService(summaryRepo, articleRepo, apiRepo, jobRepo, feedSettings, service.QualitySampling{
+		SampleRate: cfg.QualityChecker.SampleRate,
+		Reviews:    summaryReviewRepo,
+	}, log)
+	summaryReviewService := service.NewSummaryReviewService(summaryReviewRepo, summaryRepo, jobRepo, log)
+	thumbnailJob, thumbnailRepo := buildThumbnailJob(cfg, ppDBPool, log)
+	articleMediaRepo := repository.NewArticleMediaRepository(ppDBPool, log)
+	articleSyncService := service.NewArticleSyncServiceWithMedia(articleRepo, apiRepo, thumbnailRepo, articleMediaRepo, log)
 	summarizeQueueWorker := service.NewSummarizeQueueWorker(jobRepo, articleRepo, apiRepo, summaryRepo, log, batchSize)
 	summarizeQueueWorker.SetConcurrency(cfg.SummarizeQueue.Concurrency)
+	summarizeQueueWorker.SetFeedSettings(feedSettings)
+	summarizeQueueWorker.SetUserLocales(userLocaleRepo)
+	summarizeQueueWorker.SetArticleMedia(articleMediaRepo)
+	if newsCreatorBreaker != nil {
+		summarizeQueueWorker.SetCircuitBreaker(newsCreatorBreaker)
+	}
 
 	// Initialize health metrics collector
 	contextLogger := logger.NewContextLoggerWithOTel(logger.LoadLoggerConfigFromEnv(), otelEnabled)
 	metricsCollector := service.NewHealthMetricsCollector(contextLogger)
 
+	jobSchedules, err := buildJobSchedules(cfg, log)
+	if err != nil {
+		ppDBPoolCleanup()
+		return nil, nil, err
+	}
+
 	// Initialize handlers
 	jobHandler := handler.NewJobHandler(
 		ctx,
@@ -145,12 +187,19 @@ func BuildDependencies(ctx context.Context, log *slog.Logger, otelEnabled bool)
 		articleSyncService,
 		healthCheckerService,
 		summarizeQueueWorker,
+		thumbnailJob,
+		jobSchedules,
 		batchSize,
 		log,
 	)
 
+	jobsHandler := handler.NewJobsHandler(jobHandler, log)
+	feedSettingsHandler := handler.NewFeedSettingsHandler(feedSettingsRepo, feedSettings, log)
+	userLocaleHandler := handler.NewUserLocaleHandler(userLocaleRepo, log)
+	summaryReviewHandler := handler.NewSummaryReviewHandler(summaryReviewService, log)
 	healthHandler := handler.NewHealthHandler(healthCheckerService, metricsCollector, log)
 	summarizeHandler := handler.NewSummarizeHandler(apiRepo, summaryRepo, articleRepo, jobRepo, log)
+	summarizeHandler.SetUserLocales(userLocaleRepo)
 
 	// Initialize Redis Streams consumer
 	redisConsumer, err := buildRedisConsumer(ctx, jobRepo, articleRepo, summaryRepo, log)
@@ -165,6 +214,10 @@ func BuildDependencies(ctx context.Context, log *slog.Logger, otelEnabled bool)
 
 	return &Dependencies{
 		JobHandler:       jobHandler,
+		JobsHandler:      jobsHandler,
+		FeedSettings:     feedSettingsHandler,
+		UserLocales:      userLocaleHandler,
+		SummaryReviews:   summaryReviewHandler,
 		HealthHandler:    healthHandler,
 		SummarizeHandler: summarizeHandler,
 		RedisConsumer:    redisConsumer,
@@ -173,9 +226,91 @@ func BuildDependencies(ctx context.Context, log *slog.Logger, otelEnabled bool)
 		SummaryRepo:      summaryRepo,
 		ArticleRepo:      articleRepo,
 		JobRepo:          jobRepo,
+		UserLocaleRepo:   userLocaleRepo,
 	}, cleanup, nil
 }
 
+// buildNewsCreatorBreaker returns the circuit breaker guarding news-creator
+// summarize calls, recovering on the same /health check (service up and
+// model loaded) the job handler gates startup on. Returns nil when
+// NEWS_CREATOR_BREAKER_FAILURE_THRESHOLD=0.
+func buildNewsCreatorBreaker(cfg *config.Config, health service.HealthCheckerService, log *slog.Logger) *utils.CircuitBreaker {
+	if cfg.NewsCreator.BreakerFailureThreshold <= 0 {
+		log.Warn("news-creator circuit breaker disabled: summarize calls keep hitting news-creator during outages")
+		return nil
+	}
+	log.Info("news-creator circuit breaker enabled",
+		"failure_threshold", cfg.NewsCreator.BreakerFailureThreshold,
+		"probe_interval", cfg.NewsCreator.BreakerProbeInterval)
+	return utils.NewCircuitBreaker("news-creator",
+		cfg.NewsCreator.BreakerFailureThreshold,
+		cfg.NewsCreator.BreakerProbeInterval,
+		health.CheckNewsCreatorHealth,
+		log)
+}
+
+// buildThumbnailJob wires lead-image thumbnail generation when
+// THUMBNAIL_ENABLED=true. Returns nils (and logs the opt-out) otherwise.
+func buildThumbnailJob(cfg *config.Config, ppDBPool *pgxpool.Pool, log *slog.Logger) (*handler.ThumbnailJob, repository.ArticleThumbnailRepository) {
+	if !cfg.Thumbnail.Enabled {
+		log.Info("thumbnail_disabled: lead images are not downloaded; frontends keep using the image proxy")
+		return nil, nil
+	}
+
+	thumbnailRepo := repository.NewArticleThumbnailRepository(ppDBPool, log)
+	thumbnailService := service.NewArticleThumbnailService(
+		thumbnailRepo,
+		service.NewArticleFetcherService(log),
+		service.NewHTTPClientFactory(cfg, log).CreateArticleFetcherClient(),
+		driver.NewImageResizer(),
+		driver.NewFSObjectStorage(cfg.Thumbnail.StorageDir, cfg.Thumbnail.PublicBaseURL),
+		utils.NewHostRateLimiter(cfg.RateLimit.DefaultInterval),
+		cfg.Thumbnail.MaxImageBytes,
+		cfg.Thumbnail.MaxRetries,
+		log,
+	)
+	log.Info("thumbnail_enabled",
+		"storage_dir", cfg.Thumbnail.StorageDir,
+		"public_base_url", cfg.Thumbnail.PublicBaseURL,
+		"interval", cfg.Thumbnail.WorkerInterval,
+	)
+	return &handler.ThumbnailJob{
+		Service:   thumbnailService,
+		Interval:  cfg.Thumbnail.WorkerInterval,
+		BatchSize: cfg.Thumbnail.BatchSize,
+	}, thumbnailRepo
+}
+
+// buildJobSchedules parses the configured cron schedules and logs each
+// job's schedule, or that it is disabled, at startup.
+func buildJobSchedules(cfg *config.Config, log *slog.Logger) (handler.JobSchedules, error) {
+	var schedules handler.JobSchedules
+	for _, job := range []struct {
+		name string
+		cfg  config.JobScheduleConfig
+		dst  *handler.JobSchedule
+	}{
+		{"article-sync", cfg.Jobs.ArticleSync, &schedules.ArticleSync},
+		{"article-backfill", cfg.Jobs.Backfill, &schedules.Backfill},
+		{"summarization", cfg.Jobs.Summarization, &schedules.Summarization},
+		{"quality-check", cfg.Jobs.QualityCheck, &schedules.QualityCheck},
+		{"queue-worker", cfg.Jobs.QueueWorker, &schedules.QueueWorker},
+	} {
+		if !job.cfg.Enabled {
+			log.Warn("job_disabled: job will not run until re-enabled", "job", job.name)
+			*job.dst = handler.JobSchedule{Disabled: true}
+			continue
+		}
+		schedule, err := orchestrator.ParseSchedule(job.cfg.Schedule)
+		if err != nil {
+			return handler.JobSchedules{}, fmt.Errorf("job %s: %w", job.name, err)
+		}
+		log.Info("job_scheduled", "job", job.name, "schedule", schedule.String(), "jitter", job.cfg.Jitter)
+		*job.dst = handler.JobSchedule{Schedule: schedule, Jitter: job.cfg.Jitter}
+	}
+	return schedules, nil
+}
+
 // readSecret reads a secret value, supporting both direct env var and _FILE suffix
 // for Docker Secrets compatibility.
 func readSecret(key string) string {
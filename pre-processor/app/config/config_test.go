@@ -172,6 +172,61 @@ func TestValidateConfig(t *testing.T) {
 			expectError: true,
 			errorMsg:    "backoff factor must be greater than 1.0",
 		},
+		"thumbnail enabled without storage dir": {
+			config: &Config{
+				Server: ServerConfig{Port: 9200},
+				HTTP:   HTTPConfig{Timeout: 30 * time.Second},
+				Retry: RetryConfig{
+					MaxAttempts:   3,
+					BackoffFactor: 2.0,
+				},
+				RateLimit: RateLimitConfig{DefaultInterval: 5 * time.Second},
+				Metrics:   MetricsConfig{Port: 9201},
+				NewsCreator: NewsCreatorConfig{
+					Host:    "http://news-creator:11434",
+					APIPath: "/api/generate",
+					Model:   "gemma4-e4b-q4km",
+					Timeout: 60 * time.Second,
+				},
+				SummarizeQueue: SummarizeQueueConfig{
+					WorkerInterval:  10 * time.Second,
+					MaxRetries:      3,
+					PollingInterval: 5 * time.Second,
+					Concurrency:     3,
+				},
+				Thumbnail: ThumbnailConfig{
+					Enabled:        true,
+					WorkerInterval: 30 * time.Second,
+					BatchSize:      10,
+					MaxRetries:     3,
+					MaxImageBytes:  1 << 20,
+					PublicBaseURL:  "/thumbnails",
+				},
+			},
+			expectError: true,
+			errorMsg:    "thumbnail storage dir",
+		},
+		"quality sample rate above one": {
+			config: &Config{
+				Server: ServerConfig{Port: 9200},
+				HTTP:   HTTPConfig{Timeout: 30 * time.Second},
+				Retry: RetryConfig{
+					MaxAttempts:   3,
+					BackoffFactor: 2.0,
+				},
+				RateLimit: RateLimitConfig{DefaultInterval: 5 * time.Second},
+				Metrics:   MetricsConfig{Port: 9201},
+				NewsCreator: NewsCreatorConfig{
+					Host:    "http://news-creator:11434",
+					APIPath: "/api/generate",
+					Model:   "gemma4-e4b-q4km",
+					Timeout: 60 * time.Second,
+				},
+				QualityChecker: QualityCheckerConfig{SampleRate: 1.5},
+			},
+			expectError: true,
+			errorMsg:    "quality checker sample rate",
+		},
 	}
 
 	for name, tc := range tests {
@@ -349,3 +404,56 @@ func TestLoadFromEnv(t *testing.T) {
 		assert.Equal(t, 5*time.Second, config.Metrics.UpdateInterval)
 	})
 }
+
+func TestLoadJobsConfig(t *testing.T) {
+	t.Run("should default to the previous fixed intervals", func(t *testing.T) {
+		config := &Config{}
+		require.NoError(t, loadFromEnv(config))
+
+		assert.Equal(t, JobScheduleConfig{Enabled: true, Schedule: "@every 1h"}, config.Jobs.ArticleSync)
+		assert.Equal(t, JobScheduleConfig{Enabled: true, Schedule: "@every 5m"}, config.Jobs.Summarization)
+		assert.Equal(t, JobScheduleConfig{Enabled: true, Schedule: "@every 10s"}, config.Jobs.QueueWorker)
+		require.NoError(t, validateConfig(config))
+	})
+
+	t.Run("should parse per-job overrides", func(t *testing.T) {
+		t.Setenv("JOB_ARTICLE_SYNC_SCHEDULE", "0 * * * *")
+		t.Setenv("JOB_ARTICLE_SYNC_JITTER", "2m")
+		t.Setenv("JOB_QUALITY_CHECK_ENABLED", "false")
+
+		config := &Config{}
+		require.NoError(t, loadFromEnv(config))
+
+		assert.Equal(t, JobScheduleConfig{Enabled: true, Schedule: "0 * * * *", Jitter: 2 * time.Minute}, config.Jobs.ArticleSync)
+		assert.False(t, config.Jobs.QualityCheck.Enabled)
+		require.NoError(t, validateConfig(config))
+	})
+
+	t.Run("should reject an invalid schedule on an enabled job", func(t *testing.T) {
+		t.Setenv("JOB_SUMMARIZATION_SCHEDULE", "every five minutes")
+
+		config := &Config{}
+		require.NoError(t, loadFromEnv(config))
+
+		err := validateConfig(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "summarization job schedule")
+	})
+
+	t.Run("should ignore the schedule of a disabled job", func(t *testing.T) {
+		t.Setenv("JOB_SUMMARIZATION_SCHEDULE", "every five minutes")
+		t.Setenv("JOB_SUMMARIZATION_ENABLED", "false")
+
+		config := &Config{}
+		require.NoError(t, loadFromEnv(config))
+		require.NoError(t, validateConfig(config))
+	})
+
+	t.Run("should reject an invalid enabled flag", func(t *testing.T) {
+		t.Setenv("JOB_QUEUE_WORKER_ENABLED", "sometimes")
+
+		err := loadFromEnv(&Config{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "JOB_QUEUE_WORKER_ENABLED")
+	})
+}
@@ -67,6 +67,14 @@ func loadFromEnv(config *Config) error {
 		return fmt.Errorf("failed to load summarize queue config: %w", err)
 	}
 
+	if err := loadThumbnailConfig(&config.Thumbnail); err != nil {
+		return fmt.Errorf("failed to load thumbnail config: %w", err)
+	}
+
+	if err := loadJobsConfig(&config.Jobs); err != nil {
+		return fmt.Errorf("failed to load jobs config: %w", err)
+	}
+
 	return nil
 }
 
@@ -296,6 +304,14 @@ func loadNewsCreatorConfig(cfg *NewsCreatorConfig) error {
 		return err
 	}
 
+	if cfg.BreakerFailureThreshold, err = parseIntEnv("NEWS_CREATOR_BREAKER_FAILURE_THRESHOLD", cfg.BreakerFailureThreshold); err != nil {
+		return err
+	}
+
+	if cfg.BreakerProbeInterval, err = parseDurationEnv("NEWS_CREATOR_BREAKER_PROBE_INTERVAL", cfg.BreakerProbeInterval); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -322,6 +338,10 @@ func loadQualityCheckerConfig(cfg *QualityCheckerConfig) error {
 		return err
 	}
 
+	if cfg.SampleRate, err = parseFloatEnv("QUALITY_CHECKER_SAMPLE_RATE", cfg.SampleRate); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -363,6 +383,77 @@ func loadSummarizeQueueConfig(cfg *SummarizeQueueConfig) error {
 	return nil
 }
 
+// loadThumbnailConfig loads thumbnail configuration from environment variables
+func loadThumbnailConfig(cfg *ThumbnailConfig) error {
+	var err error
+
+	if cfg.Enabled, err = parseBoolEnv("THUMBNAIL_ENABLED", cfg.Enabled); err != nil {
+		return err
+	}
+
+	if cfg.WorkerInterval, err = parseDurationEnv("THUMBNAIL_WORKER_INTERVAL", cfg.WorkerInterval); err != nil {
+		return err
+	}
+
+	if cfg.BatchSize, err = parseIntEnv("THUMBNAIL_BATCH_SIZE", cfg.BatchSize); err != nil {
+		return err
+	}
+
+	if cfg.MaxRetries, err = parseIntEnv("THUMBNAIL_MAX_RETRIES", cfg.MaxRetries); err != nil {
+		return err
+	}
+
+	maxImageBytes, err := parseIntEnv("THUMBNAIL_MAX_IMAGE_BYTES", int(cfg.MaxImageBytes))
+	if err != nil {
+		return err
+	}
+	cfg.MaxImageBytes = int64(maxImageBytes)
+
+	if value := os.Getenv("THUMBNAIL_STORAGE_DIR"); value != "" {
+		cfg.StorageDir = value
+	}
+
+	if value := os.Getenv("THUMBNAIL_PUBLIC_BASE_URL"); value != "" {
+		cfg.PublicBaseURL = value
+	}
+
+	return nil
+}
+
+// loadJobsConfig loads per-job schedules from JOB_<NAME>_* environment variables
+func loadJobsConfig(cfg *JobsConfig) error {
+	for prefix, job := range map[string]*JobScheduleConfig{
+		"JOB_ARTICLE_SYNC":  &cfg.ArticleSync,
+		"JOB_BACKFILL":      &cfg.Backfill,
+		"JOB_SUMMARIZATION": &cfg.Summarization,
+		"JOB_QUALITY_CHECK": &cfg.QualityCheck,
+		"JOB_QUEUE_WORKER":  &cfg.QueueWorker,
+	} {
+		if err := loadJobScheduleConfig(prefix, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadJobScheduleConfig(prefix string, cfg *JobScheduleConfig) error {
+	var err error
+
+	if cfg.Enabled, err = parseBoolEnv(prefix+"_ENABLED", cfg.Enabled); err != nil {
+		return err
+	}
+
+	if value := os.Getenv(prefix + "_SCHEDULE"); value != "" {
+		cfg.Schedule = value
+	}
+
+	if cfg.Jitter, err = parseDurationEnv(prefix+"_JITTER", cfg.Jitter); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func splitUserAgents(value string) []string {
 	parts := strings.Split(value, ",")
 	for i := range parts {
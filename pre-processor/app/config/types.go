@@ -16,6 +16,8 @@ type Config struct {
 	NewsCreator    NewsCreatorConfig    `json:"news_creator"`
 	QualityChecker QualityCheckerConfig `json:"quality_checker"`
 	SummarizeQueue SummarizeQueueConfig `json:"summarize_queue"`
+	Thumbnail      ThumbnailConfig      `json:"thumbnail"`
+	Jobs           JobsConfig           `json:"jobs"`
 	AltService     AltServiceConfig     `json:"alt_service"`
 }
 
@@ -89,6 +91,13 @@ type NewsCreatorConfig struct {
 	APIPath string        `json:"api_path" env:"NEWS_CREATOR_API_PATH" default:"/api/v1/summarize"`
 	Model   string        `json:"model" env:"NEWS_CREATOR_MODEL" default:"gemma4-e4b-q4km"`
 	Timeout time.Duration `json:"timeout" env:"NEWS_CREATOR_TIMEOUT" default:"600s"`
+
+	// BreakerFailureThreshold is how many consecutive connection-level
+	// failures open the news-creator circuit; 0 disables the breaker.
+	BreakerFailureThreshold int `json:"breaker_failure_threshold" env:"NEWS_CREATOR_BREAKER_FAILURE_THRESHOLD" default:"5"`
+	// BreakerProbeInterval is the minimum gap between health probes while
+	// the circuit is open.
+	BreakerProbeInterval time.Duration `json:"breaker_probe_interval" env:"NEWS_CREATOR_BREAKER_PROBE_INTERVAL" default:"30s"`
 }
 
 // QualityCheckerConfig configures the LLM-based quality judge that scores
@@ -101,6 +110,10 @@ type QualityCheckerConfig struct {
 	LowScoreThreshold int           `json:"low_score_threshold" env:"QUALITY_CHECKER_LOW_SCORE_THRESHOLD" default:"7"`
 	MaxContentLength  int           `json:"max_content_length" env:"QUALITY_CHECKER_MAX_CONTENT_LENGTH" default:"20000"`
 	Timeout           time.Duration `json:"timeout" env:"QUALITY_CHECKER_TIMEOUT" default:"300s"`
+	// SampleRate is the share (0-1) of summaries the judge checks; summaries
+	// that look low-confidence are always checked. Checked summaries that
+	// pass go to the human review queue.
+	SampleRate float64 `json:"sample_rate" env:"QUALITY_CHECKER_SAMPLE_RATE" default:"0.1"`
 }
 
 type SummarizeQueueConfig struct {
@@ -110,6 +123,39 @@ type SummarizeQueueConfig struct {
 	Concurrency     int           `json:"concurrency" env:"SUMMARIZE_QUEUE_CONCURRENCY" default:"3"`
 }
 
+// ThumbnailConfig configures lead-image thumbnail generation. Thumbnails are
+// written to StorageDir and served by a static file server under PublicBaseURL.
+type ThumbnailConfig struct {
+	Enabled        bool          `json:"enabled" env:"THUMBNAIL_ENABLED" default:"false"`
+	WorkerInterval time.Duration `json:"worker_interval" env:"THUMBNAIL_WORKER_INTERVAL" default:"30s"`
+	BatchSize      int           `json:"batch_size" env:"THUMBNAIL_BATCH_SIZE" default:"10"`
+	MaxRetries     int           `json:"max_retries" env:"THUMBNAIL_MAX_RETRIES" default:"3"`
+	MaxImageBytes  int64         `json:"max_image_bytes" env:"THUMBNAIL_MAX_IMAGE_BYTES" default:"10485760"`
+	StorageDir     string        `json:"storage_dir" env:"THUMBNAIL_STORAGE_DIR" default:"/var/lib/pre-processor/thumbnails"`
+	PublicBaseURL  string        `json:"public_base_url" env:"THUMBNAIL_PUBLIC_BASE_URL" default:"/thumbnails"`
+}
+
+// JobScheduleConfig configures when one background job runs. Schedule is a
+// 5-field cron expression, a descriptor such as @hourly, or
+// "@every <duration>"; Jitter adds a random delay of up to that long to each
+// scheduled run so replicas do not hit alt-backend in lockstep.
+type JobScheduleConfig struct {
+	Enabled  bool          `json:"enabled"`
+	Schedule string        `json:"schedule"`
+	Jitter   time.Duration `json:"jitter"`
+}
+
+// JobsConfig holds the schedule of each background job. Each job reads
+// JOB_<NAME>_ENABLED, JOB_<NAME>_SCHEDULE and JOB_<NAME>_JITTER, e.g.
+// JOB_ARTICLE_SYNC_SCHEDULE="0 * * * *".
+type JobsConfig struct {
+	ArticleSync   JobScheduleConfig `json:"article_sync"`
+	Backfill      JobScheduleConfig `json:"backfill"`
+	Summarization JobScheduleConfig `json:"summarization"`
+	QualityCheck  JobScheduleConfig `json:"quality_check"`
+	QueueWorker   JobScheduleConfig `json:"queue_worker"`
+}
+
 func defaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
@@ -170,6 +216,9 @@ func defaultConfig() *Config {
 			APIPath: "/api/v1/summarize",
 			Model:   "gemma4-e4b-q4km",
 			Timeout: 600 * time.Second,
+
+			BreakerFailureThreshold: 5,
+			BreakerProbeInterval:    30 * time.Second,
 		},
 		QualityChecker: QualityCheckerConfig{
 			APIPath:           "/api/generate",
@@ -177,6 +226,7 @@ func defaultConfig() *Config {
 			LowScoreThreshold: 7,
 			MaxContentLength:  20_000,
 			Timeout:           300 * time.Second,
+			SampleRate:        0.1,
 		},
 		SummarizeQueue: SummarizeQueueConfig{
 			WorkerInterval:  10 * time.Second,
@@ -184,6 +234,22 @@ func defaultConfig() *Config {
 			PollingInterval: 5 * time.Second,
 			Concurrency:     3,
 		},
+		Thumbnail: ThumbnailConfig{
+			Enabled:        false,
+			WorkerInterval: 30 * time.Second,
+			BatchSize:      10,
+			MaxRetries:     3,
+			MaxImageBytes:  10 << 20,
+			StorageDir:     "/var/lib/pre-processor/thumbnails",
+			PublicBaseURL:  "/thumbnails",
+		},
+		Jobs: JobsConfig{
+			ArticleSync:   JobScheduleConfig{Enabled: true, Schedule: "@every 1h"},
+			Backfill:      JobScheduleConfig{Enabled: true, Schedule: "@every 1h"},
+			Summarization: JobScheduleConfig{Enabled: true, Schedule: "@every 5m"},
+			QualityCheck:  JobScheduleConfig{Enabled: true, Schedule: "@every 5m"},
+			QueueWorker:   JobScheduleConfig{Enabled: true, Schedule: "@every 10s"},
+		},
 		AltService: AltServiceConfig{
 			Host:    "http://alt-backend:9000",
 			Timeout: 10 * time.Second,
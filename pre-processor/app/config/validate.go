@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"strings"
+
+	"pre-processor/orchestrator"
 )
 
 func validateConfig(config *Config) error {
@@ -38,6 +40,18 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("news creator timeout must be positive: %v", config.NewsCreator.Timeout)
 	}
 
+	if config.NewsCreator.BreakerFailureThreshold < 0 {
+		return fmt.Errorf("news creator breaker failure threshold must be non-negative: %d", config.NewsCreator.BreakerFailureThreshold)
+	}
+
+	if config.NewsCreator.BreakerFailureThreshold > 0 && config.NewsCreator.BreakerProbeInterval <= 0 {
+		return fmt.Errorf("news creator breaker probe interval must be positive: %v", config.NewsCreator.BreakerProbeInterval)
+	}
+
+	if config.QualityChecker.SampleRate < 0 || config.QualityChecker.SampleRate > 1 {
+		return fmt.Errorf("quality checker sample rate must be between 0 and 1: %f", config.QualityChecker.SampleRate)
+	}
+
 	if config.SummarizeQueue.WorkerInterval <= 0 {
 		return fmt.Errorf("summarize queue worker interval must be positive: %v", config.SummarizeQueue.WorkerInterval)
 	}
@@ -54,6 +68,42 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("summarize queue concurrency must be positive: %d", config.SummarizeQueue.Concurrency)
 	}
 
+	if config.Thumbnail.Enabled {
+		if config.Thumbnail.WorkerInterval <= 0 {
+			return fmt.Errorf("thumbnail worker interval must be positive: %v", config.Thumbnail.WorkerInterval)
+		}
+		if config.Thumbnail.BatchSize <= 0 {
+			return fmt.Errorf("thumbnail batch size must be positive: %d", config.Thumbnail.BatchSize)
+		}
+		if config.Thumbnail.MaxRetries <= 0 {
+			return fmt.Errorf("thumbnail max retries must be positive: %d", config.Thumbnail.MaxRetries)
+		}
+		if config.Thumbnail.MaxImageBytes <= 0 {
+			return fmt.Errorf("thumbnail max image bytes must be positive: %d", config.Thumbnail.MaxImageBytes)
+		}
+		if config.Thumbnail.StorageDir == "" || config.Thumbnail.PublicBaseURL == "" {
+			return fmt.Errorf("thumbnail storage dir and public base URL are required when thumbnails are enabled")
+		}
+	}
+
+	for name, job := range map[string]JobScheduleConfig{
+		"article sync":  config.Jobs.ArticleSync,
+		"backfill":      config.Jobs.Backfill,
+		"summarization": config.Jobs.Summarization,
+		"quality check": config.Jobs.QualityCheck,
+		"queue worker":  config.Jobs.QueueWorker,
+	} {
+		if !job.Enabled {
+			continue
+		}
+		if _, err := orchestrator.ParseSchedule(job.Schedule); err != nil {
+			return fmt.Errorf("%s job schedule: %w", name, err)
+		}
+		if job.Jitter < 0 {
+			return fmt.Errorf("%s job jitter must be non-negative: %v", name, job.Jitter)
+		}
+	}
+
 	if config.HTTP.MinContentLength < 0 {
 		return fmt.Errorf("min content length must be non-negative: %d", config.HTTP.MinContentLength)
 	}
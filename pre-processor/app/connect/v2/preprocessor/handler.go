@@ -47,6 +47,16 @@ func NewHandler(
 	}
 }
 
+// SetUserLocales makes requests without a language summarize in the article
+// owner's locale.
+func (h *Handler) SetUserLocales(repo repository.UserLocaleRepository) {
+	h.onDemand.SetUserLocales(repo)
+}
+
+// summaryFallbackHeader marks a Summarize response carrying an unsaved
+// extractive summary served while news-creator is unavailable.
+const summaryFallbackHeader = "X-Alt-Summary-Fallback"
+
 // Compile-time check that Handler implements PreProcessorServiceHandler.
 var _ preprocessorv2connect.PreProcessorServiceHandler = (*Handler)(nil)
 
@@ -79,16 +89,23 @@ func (h *Handler) Summarize(
 		Content:   req.Msg.Content,
 		Title:     req.Msg.Title,
 		Priority:  "high", // UI-triggered requests
+		Language:  req.Msg.Language,
 	})
 	if err != nil {
 		return nil, mapDomainError(err, articleID)
 	}
 
-	return connect.NewResponse(&preprocessorv2.SummarizeResponse{
+	resp := connect.NewResponse(&preprocessorv2.SummarizeResponse{
 		Success:   true,
 		Summary:   result.Summary,
 		ArticleId: articleID,
-	}), nil
+		Language:  result.Language,
+	})
+	if result.Fallback {
+		// The proto has no field for this; callers that care read the header.
+		resp.Header().Set(summaryFallbackHeader, "true")
+	}
+	return resp, nil
 }
 
 // StreamSummarize performs streaming article summarization.
@@ -117,17 +134,15 @@ func (h *Handler) StreamSummarize(
 	resolved, err := h.onDemand.ResolveArticle(ctx, summarizeuc.SummarizeRequest{
 		ArticleID: articleID,
 		Content:   req.Msg.Content,
+		Language:  req.Msg.Language,
 	})
 	if err != nil {
 		return mapDomainError(err, articleID)
 	}
 
-	h.logger.InfoContext(ctx, "processing streaming summarization request", "article_id", articleID, "content_length", len(resolved.Content))
+	h.logger.InfoContext(ctx, "processing streaming summarization request", "article_id", articleID, "content_length", len(resolved.Content), "summary_language", resolved.Language)
 
-	article := &domain.Article{
-		ID:      articleID,
-		Content: resolved.Content,
-	}
+	article := resolved.Article()
 
 	// Call streaming service with HIGH priority for UI-triggered requests
 	ioStream, err := h.apiRepo.StreamSummarizeArticle(ctx, article, "high")
@@ -178,6 +193,8 @@ func mapDomainError(err error, articleID string) error {
 		return connect.NewError(connect.CodeNotFound, fmt.Errorf("article not found"))
 	case errors.Is(err, domain.ErrArticleContentEmpty), errors.Is(err, domain.ErrEmptyContent):
 		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("article content is empty"))
+	case errors.Is(err, domain.ErrUnsupportedSummaryLanguage):
+		return connect.NewError(connect.CodeInvalidArgument, err)
 	case errors.Is(err, domain.ErrContentTooShort):
 		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("content too short"))
 	case errors.Is(err, domain.ErrContentTooLong):
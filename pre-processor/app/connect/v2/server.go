@@ -19,6 +19,7 @@ func CreateConnectServer(
 	summaryRepo repository.SummaryRepository,
 	articleRepo repository.ArticleRepository,
 	jobRepo repository.SummarizeJobRepository,
+	userLocaleRepo repository.UserLocaleRepository,
 	logger *slog.Logger,
 ) http.Handler {
 	mux := http.NewServeMux()
@@ -32,6 +33,9 @@ func CreateConnectServer(
 
 	// Authentication is established at the TLS transport layer (mTLS on :9443).
 	handler := preprocessor.NewHandler(apiRepo, summaryRepo, articleRepo, jobRepo, logger)
+	if userLocaleRepo != nil {
+		handler.SetUserLocales(userLocaleRepo)
+	}
 	path, serviceHandler := preprocessorv2connect.NewPreProcessorServiceHandler(handler)
 	mux.Handle(path, serviceHandler)
 	logger.Info("Registered Connect-RPC PreProcessorService", "path", path)
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -17,6 +18,12 @@ type Article struct {
 	PublishedAt time.Time `db:"published_at"`
 	InoreaderID string    `db:"inoreader_id"` // Transient field
 	FeedURL     string    `db:"-"`            // Transient field for sync
+	// SummaryLanguage is the language to summarize in ("ja", "en"); empty
+	// means DefaultSummaryLanguage. Transient, set by the summarizing caller.
+	SummaryLanguage string `db:"-"`
+	// Enclosures is the raw Inoreader enclosure list, nil when the item has
+	// none. Transient, read by article sync to record the article's media.
+	Enclosures json.RawMessage `db:"-"`
 }
 
 // ArticleSummary represents a summary of an article.
@@ -27,6 +34,9 @@ type ArticleSummary struct {
 	UserID          string    `db:"user_id"`
 	ArticleTitle    string    `db:"article_title"`
 	SummaryJapanese string    `db:"summary_japanese"`
+	// SummaryLanguage is the language the summary is written in. Despite
+	// the field name, SummaryJapanese holds the summary in this language.
+	SummaryLanguage string `db:"summary_language"`
 }
 
 // ArticleWithSummary represents an article with its summary for quality checking.
@@ -44,4 +54,5 @@ type ArticleWithSummary struct {
 type SummarizedContent struct {
 	ArticleID       string `json:"article_id"`
 	SummaryJapanese string `json:"summary_japanese"`
+	Language        string `json:"language"`
 }
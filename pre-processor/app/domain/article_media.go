@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+)
+
+// MediaKind is the kind of player an enclosure needs.
+type MediaKind string
+
+const (
+	MediaKindAudio MediaKind = "audio"
+	MediaKindVideo MediaKind = "video"
+)
+
+// MediaOnlyMaxTextChars is the longest extracted text an article with media
+// can have and still count as media-only. Podcast and video feeds typically
+// carry a one-line blurb or show notes links, which are not worth summarizing.
+const MediaOnlyMaxTextChars = 200
+
+// MediaEnclosure is one playable podcast or video attachment of an article.
+type MediaEnclosure struct {
+	URL             string    `json:"url"`
+	MimeType        string    `json:"mime_type"`
+	Kind            MediaKind `json:"kind"`
+	LengthBytes     int64     `json:"length_bytes,omitempty"`
+	DurationSeconds int       `json:"duration_seconds,omitempty"`
+}
+
+// ArticleMedia is the media recorded for an article, stored for the
+// frontend player.
+type ArticleMedia struct {
+	ArticleID string           `db:"article_id"`
+	Media     []MediaEnclosure `db:"media"`
+	// MediaOnly marks articles whose only substance is their media, which
+	// are not summarized.
+	MediaOnly bool `db:"media_only"`
+}
+
+// IsMediaOnly reports whether an article with media and the given extracted
+// text should be treated as media-only.
+func IsMediaOnly(media []MediaEnclosure, text string) bool {
+	return len(media) > 0 && len([]rune(strings.TrimSpace(text))) <= MediaOnlyMaxTextChars
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMediaOnly(t *testing.T) {
+	media := []MediaEnclosure{{URL: "https://cdn.example.com/ep.mp3", MimeType: "audio/mpeg", Kind: MediaKindAudio}}
+
+	assert.True(t, IsMediaOnly(media, ""))
+	assert.True(t, IsMediaOnly(media, "  今週のエピソードです。  "))
+	assert.True(t, IsMediaOnly(media, strings.Repeat("あ", MediaOnlyMaxTextChars)))
+	assert.False(t, IsMediaOnly(media, strings.Repeat("あ", MediaOnlyMaxTextChars+1)))
+	assert.False(t, IsMediaOnly(nil, ""))
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+)
+
+// ArticleThumbnailStatus represents the processing status of an article's lead-image thumbnails.
+type ArticleThumbnailStatus string
+
+const (
+	ArticleThumbnailStatusPending   ArticleThumbnailStatus = "pending"
+	ArticleThumbnailStatusRunning   ArticleThumbnailStatus = "running"
+	ArticleThumbnailStatusCompleted ArticleThumbnailStatus = "completed"
+	ArticleThumbnailStatusFailed    ArticleThumbnailStatus = "failed"
+)
+
+// ThumbnailSize is a standard thumbnail width. Height follows the source aspect ratio.
+type ThumbnailSize struct {
+	Name  string
+	Width int
+}
+
+// DefaultThumbnailSizes are the sizes generated for every lead image:
+// list cards, detail headers, and high-DPI detail headers.
+var DefaultThumbnailSizes = []ThumbnailSize{
+	{Name: "small", Width: 320},
+	{Name: "medium", Width: 640},
+	{Name: "large", Width: 1280},
+}
+
+// ArticleThumbnail represents an article's lead image and its stored thumbnails.
+type ArticleThumbnail struct {
+	ArticleID    string                 `db:"article_id"`
+	SourceURL    string                 `db:"source_url"`
+	Status       ArticleThumbnailStatus `db:"status"`
+	Thumbnails   map[string]string      `db:"thumbnails"` // size name -> object URL
+	ErrorMessage *string                `db:"error_message"`
+	RetryCount   int                    `db:"retry_count"`
+	CreatedAt    time.Time              `db:"created_at"`
+	UpdatedAt    time.Time              `db:"updated_at"`
+}
@@ -43,6 +43,10 @@ var (
 
 	// ErrEmptyContent indicates content field is required but empty
 	ErrEmptyContent = errors.New("content cannot be empty")
+
+	// ErrUnsupportedSummaryLanguage indicates a requested summary language
+	// has no news-creator prompts
+	ErrUnsupportedSummaryLanguage = errors.New("unsupported summary language")
 )
 
 // External service errors
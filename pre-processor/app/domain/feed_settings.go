@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// QualityStrictness selects how strictly the quality checker judges the
+// summaries of a feed's articles.
+type QualityStrictness string
+
+const (
+	QualityStrictnessLenient  QualityStrictness = "lenient"
+	QualityStrictnessStandard QualityStrictness = "standard"
+	QualityStrictnessStrict   QualityStrictness = "strict"
+)
+
+// MinFeedFetchInterval is the shortest fetch interval a feed may be given,
+// so an override cannot turn into a request storm against the origin.
+const MinFeedFetchInterval = time.Minute
+
+// ErrInvalidFeedSettings indicates a feed settings override is out of range.
+var ErrInvalidFeedSettings = errors.New("invalid feed settings")
+
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z]{2,4})?$`)
+
+// Valid reports whether q is a known strictness level.
+func (q QualityStrictness) Valid() bool {
+	switch q {
+	case QualityStrictnessLenient, QualityStrictnessStandard, QualityStrictnessStrict:
+		return true
+	}
+	return false
+}
+
+// Threshold returns the lowest acceptable quality score for q given the
+// global threshold: lenient accepts two points lower, strict demands one
+// point higher. The result stays within the 1-10 score range.
+func (q QualityStrictness) Threshold(base int) int {
+	switch q {
+	case QualityStrictnessLenient:
+		return max(base-2, 1)
+	case QualityStrictnessStrict:
+		return min(base+1, 10)
+	default:
+		return base
+	}
+}
+
+// FeedSettings holds the processing overrides for one feed. A nil field
+// inherits the global setting.
+type FeedSettings struct {
+	FeedID               string             `db:"feed_id"`
+	BatchSize            *int               `db:"batch_size"`
+	SummarizationEnabled *bool              `db:"summarization_enabled"`
+	QualityStrictness    *QualityStrictness `db:"quality_strictness"`
+	LanguageOverride     *string            `db:"language_override"`
+	FetchInterval        *time.Duration     `db:"fetch_interval_seconds"`
+	CreatedAt            time.Time          `db:"created_at"`
+	UpdatedAt            time.Time          `db:"updated_at"`
+}
+
+// Validate checks the overrides that are set. Errors wrap
+// ErrInvalidFeedSettings.
+func (s *FeedSettings) Validate() error {
+	if s.FeedID == "" {
+		return fmt.Errorf("%w: feed ID is required", ErrInvalidFeedSettings)
+	}
+	if s.BatchSize != nil && *s.BatchSize <= 0 {
+		return fmt.Errorf("%w: batch_size must be positive", ErrInvalidFeedSettings)
+	}
+	if s.QualityStrictness != nil && !s.QualityStrictness.Valid() {
+		return fmt.Errorf("%w: quality_strictness must be lenient, standard or strict", ErrInvalidFeedSettings)
+	}
+	if s.LanguageOverride != nil && !languageCodePattern.MatchString(*s.LanguageOverride) {
+		return fmt.Errorf("%w: language_override must be a language code such as \"en\" or \"ja\"", ErrInvalidFeedSettings)
+	}
+	if s.FetchInterval != nil && *s.FetchInterval < MinFeedFetchInterval {
+		return fmt.Errorf("%w: fetch_interval must be at least %s", ErrInvalidFeedSettings, MinFeedFetchInterval)
+	}
+	return nil
+}
+
+// EffectiveFeedSettings is the configuration a feed is processed with once
+// its overrides are applied over the global defaults.
+type EffectiveFeedSettings struct {
+	FeedID               string
+	BatchSize            int
+	SummarizationEnabled bool
+	QualityStrictness    QualityStrictness
+	// Language is empty unless overridden; the article's own detected
+	// language applies then.
+	Language      string
+	FetchInterval time.Duration
+}
+
+// Resolve applies s over defaults. A nil s yields defaults unchanged.
+func (s *FeedSettings) Resolve(defaults EffectiveFeedSettings) EffectiveFeedSettings {
+	eff := defaults
+	if s == nil {
+		return eff
+	}
+	eff.FeedID = s.FeedID
+	if s.BatchSize != nil {
+		eff.BatchSize = *s.BatchSize
+	}
+	if s.SummarizationEnabled != nil {
+		eff.SummarizationEnabled = *s.SummarizationEnabled
+	}
+	if s.QualityStrictness != nil {
+		eff.QualityStrictness = *s.QualityStrictness
+	}
+	if s.LanguageOverride != nil {
+		eff.Language = *s.LanguageOverride
+	}
+	if s.FetchInterval != nil {
+		eff.FetchInterval = *s.FetchInterval
+	}
+	return eff
+}
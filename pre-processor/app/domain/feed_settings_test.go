@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQualityStrictness_Threshold(t *testing.T) {
+	assert.Equal(t, 5, QualityStrictnessLenient.Threshold(7))
+	assert.Equal(t, 7, QualityStrictnessStandard.Threshold(7))
+	assert.Equal(t, 8, QualityStrictnessStrict.Threshold(7))
+
+	t.Run("should stay within the score range", func(t *testing.T) {
+		assert.Equal(t, 1, QualityStrictnessLenient.Threshold(2))
+		assert.Equal(t, 10, QualityStrictnessStrict.Threshold(10))
+	})
+}
+
+func TestFeedSettings_Validate(t *testing.T) {
+	batch := func(n int) *int { return &n }
+	strictness := func(q QualityStrictness) *QualityStrictness { return &q }
+	lang := func(s string) *string { return &s }
+	interval := func(d time.Duration) *time.Duration { return &d }
+
+	tests := []struct {
+		name     string
+		settings FeedSettings
+		wantErr  bool
+	}{
+		{"no overrides", FeedSettings{FeedID: "feed-1"}, false},
+		{"all overrides", FeedSettings{
+			FeedID:            "feed-1",
+			BatchSize:         batch(5),
+			QualityStrictness: strictness(QualityStrictnessStrict),
+			LanguageOverride:  lang("pt-BR"),
+			FetchInterval:     interval(30 * time.Minute),
+		}, false},
+		{"missing feed ID", FeedSettings{}, true},
+		{"zero batch size", FeedSettings{FeedID: "feed-1", BatchSize: batch(0)}, true},
+		{"unknown strictness", FeedSettings{FeedID: "feed-1", QualityStrictness: strictness("harsh")}, true},
+		{"bad language code", FeedSettings{FeedID: "feed-1", LanguageOverride: lang("Japanese")}, true},
+		{"fetch interval too short", FeedSettings{FeedID: "feed-1", FetchInterval: interval(10 * time.Second)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidFeedSettings)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeedSettings_Resolve(t *testing.T) {
+	defaults := EffectiveFeedSettings{
+		BatchSize:            10,
+		SummarizationEnabled: true,
+		QualityStrictness:    QualityStrictnessStandard,
+		FetchInterval:        time.Hour,
+	}
+
+	t.Run("should return defaults for a feed without overrides", func(t *testing.T) {
+		var s *FeedSettings
+		assert.Equal(t, defaults, s.Resolve(defaults))
+	})
+
+	t.Run("should apply only the fields that are set", func(t *testing.T) {
+		disabled := false
+		lang := "ja"
+		s := &FeedSettings{FeedID: "feed-1", SummarizationEnabled: &disabled, LanguageOverride: &lang}
+
+		got := s.Resolve(defaults)
+
+		assert.Equal(t, "feed-1", got.FeedID)
+		assert.False(t, got.SummarizationEnabled)
+		assert.Equal(t, "ja", got.Language)
+		assert.Equal(t, 10, got.BatchSize)
+		assert.Equal(t, QualityStrictnessStandard, got.QualityStrictness)
+		assert.Equal(t, time.Hour, got.FetchInterval)
+	})
+}
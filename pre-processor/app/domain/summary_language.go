@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Summary languages news-creator has prompts for.
+const (
+	SummaryLanguageJapanese = "ja"
+	SummaryLanguageEnglish  = "en"
+
+	// DefaultSummaryLanguage is used when neither the request nor the
+	// owning user's locale names a supported language.
+	DefaultSummaryLanguage = SummaryLanguageJapanese
+)
+
+// ErrInvalidUserLocale indicates a user locale is not a language tag.
+var ErrInvalidUserLocale = errors.New("invalid user locale")
+
+// NormalizeSummaryLanguage maps a language tag or locale ("en-US", "ja_JP",
+// "EN") to a summary language. It reports false when news-creator has no
+// prompts for the language.
+func NormalizeSummaryLanguage(locale string) (string, bool) {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case SummaryLanguageJapanese, SummaryLanguageEnglish:
+		return lang, true
+	}
+	return "", false
+}
+
+// ResolveSummaryLanguage picks the language to write a summary in: the
+// explicitly requested language, else the owning user's locale, else
+// DefaultSummaryLanguage. Unsupported values are skipped.
+func ResolveSummaryLanguage(requested, userLocale string) string {
+	if lang, ok := NormalizeSummaryLanguage(requested); ok {
+		return lang
+	}
+	if lang, ok := NormalizeSummaryLanguage(userLocale); ok {
+		return lang
+	}
+	return DefaultSummaryLanguage
+}
+
+// UserLocale is a user's preferred locale. Any language tag may be stored;
+// summaries fall back to DefaultSummaryLanguage for unsupported ones.
+type UserLocale struct {
+	UserID    string    `db:"user_id"`
+	Locale    string    `db:"locale"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate checks that the locale is a language tag such as "ja" or "en-US".
+func (l *UserLocale) Validate() error {
+	if l.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidUserLocale)
+	}
+	if !languageCodePattern.MatchString(strings.ReplaceAll(l.Locale, "_", "-")) {
+		return fmt.Errorf("%w: locale %q is not a language tag", ErrInvalidUserLocale, l.Locale)
+	}
+	return nil
+}
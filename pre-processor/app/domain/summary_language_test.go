@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSummaryLanguage(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"ja", "ja", true},
+		{"en-US", "en", true},
+		{"ja_JP", "ja", true},
+		{" EN ", "en", true},
+		{"fr", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeSummaryLanguage(tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+	}
+}
+
+func TestResolveSummaryLanguage(t *testing.T) {
+	assert.Equal(t, "en", ResolveSummaryLanguage("en", "ja-JP"), "request wins")
+	assert.Equal(t, "en", ResolveSummaryLanguage("", "en-GB"), "user locale")
+	assert.Equal(t, "en", ResolveSummaryLanguage("fr", "en"), "unsupported request is skipped")
+	assert.Equal(t, DefaultSummaryLanguage, ResolveSummaryLanguage("", "de-DE"))
+	assert.Equal(t, DefaultSummaryLanguage, ResolveSummaryLanguage("", ""))
+}
+
+func TestUserLocale_Validate(t *testing.T) {
+	assert.NoError(t, (&UserLocale{UserID: "u1", Locale: "en-US"}).Validate())
+	assert.NoError(t, (&UserLocale{UserID: "u1", Locale: "ja_JP"}).Validate())
+	assert.ErrorIs(t, (&UserLocale{Locale: "en"}).Validate(), ErrInvalidUserLocale)
+	assert.ErrorIs(t, (&UserLocale{UserID: "u1", Locale: "english please"}).Validate(), ErrInvalidUserLocale)
+	assert.ErrorIs(t, (&UserLocale{UserID: "u1"}).Validate(), ErrInvalidUserLocale)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"hash/fnv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SummaryReviewStatus is the state of a summary in the human review queue.
+type SummaryReviewStatus string
+
+const (
+	SummaryReviewPending  SummaryReviewStatus = "pending"
+	SummaryReviewApproved SummaryReviewStatus = "approved"
+	SummaryReviewRejected SummaryReviewStatus = "rejected"
+)
+
+// Valid reports whether s is a known review status.
+func (s SummaryReviewStatus) Valid() bool {
+	switch s {
+	case SummaryReviewPending, SummaryReviewApproved, SummaryReviewRejected:
+		return true
+	}
+	return false
+}
+
+// SummaryReviewReason records why the quality checker queued a summary.
+type SummaryReviewReason string
+
+const (
+	// SummaryReviewSampled summaries were picked by the sample rate.
+	SummaryReviewSampled SummaryReviewReason = "sampled"
+	// SummaryReviewLowConfidence summaries tripped a low-confidence
+	// heuristic and are always checked.
+	SummaryReviewLowConfidence SummaryReviewReason = "low_confidence"
+)
+
+var (
+	// ErrSummaryReviewNotFound indicates the review item does not exist.
+	ErrSummaryReviewNotFound = errors.New("summary review not found")
+	// ErrSummaryReviewDecided indicates the review item is no longer pending.
+	ErrSummaryReviewDecided = errors.New("summary review already decided")
+)
+
+// SummaryReview is one entry of the human review queue.
+type SummaryReview struct {
+	ID        string              `db:"id"`
+	ArticleID string              `db:"article_id"`
+	Reason    SummaryReviewReason `db:"reason"`
+	Status    SummaryReviewStatus `db:"status"`
+	Reviewer  *string             `db:"reviewer"`
+	Note      *string             `db:"note"`
+	CreatedAt time.Time           `db:"created_at"`
+	DecidedAt *time.Time          `db:"decided_at"`
+}
+
+// Thresholds for IsLowConfidenceSummary.
+const (
+	lowConfidenceMinRunes        = 80
+	lowConfidenceMinJapaneseRate = 0.5
+	lowConfidenceMinSentence     = 10
+)
+
+// SampledForReview reports whether articleID falls within rate (0-1) of
+// all articles. The choice is a hash of the ID rather than a random draw, so
+// an article keeps its place in or out of the sample every time the quality
+// checker pages past it.
+func SampledForReview(articleID string, rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(articleID))
+	return float64(h.Sum32()%10_000) < rate*10_000
+}
+
+// IsLowConfidenceSummary flags summaries whose shape suggests the model
+// went wrong, without an LLM call: too short, mostly not Japanese, or
+// repeating a sentence.
+func IsLowConfidenceSummary(summary string) bool {
+	summary = strings.TrimSpace(summary)
+	if utf8.RuneCountInString(summary) < lowConfidenceMinRunes {
+		return true
+	}
+
+	letters, japanese := 0, 0
+	for _, r := range summary {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+			japanese++
+		}
+	}
+	if letters == 0 || float64(japanese)/float64(letters) < lowConfidenceMinJapaneseRate {
+		return true
+	}
+
+	seen := make(map[string]bool)
+	for _, sentence := range strings.FieldsFunc(summary, func(r rune) bool { return r == '。' || r == '\n' }) {
+		sentence = strings.TrimSpace(sentence)
+		if utf8.RuneCountInString(sentence) < lowConfidenceMinSentence {
+			continue
+		}
+		if seen[sentence] {
+			return true
+		}
+		seen[sentence] = true
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampledForReview(t *testing.T) {
+	assert.False(t, SampledForReview("article-1", 0))
+	assert.True(t, SampledForReview("article-1", 1))
+	assert.Equal(t, SampledForReview("article-1", 0.1), SampledForReview("article-1", 0.1), "sampling should be deterministic")
+
+	t.Run("should sample roughly the configured share", func(t *testing.T) {
+		sampled := 0
+		for i := 0; i < 10_000; i++ {
+			if SampledForReview(fmt.Sprintf("article-%d", i), 0.1) {
+				sampled++
+			}
+		}
+		assert.InDelta(t, 1_000, sampled, 150)
+	})
+}
+
+func TestIsLowConfidenceSummary(t *testing.T) {
+	confident := "この記事は新しい技術の導入について説明している。企業は段階的な移行計画を立て、既存システムとの互換性を検証した。" +
+		"導入後は処理時間が大幅に短縮され、運用コストも削減された。一方で人材育成と保守体制の整備が課題として残っている。"
+	repeated := strings.Repeat("この記事は新しい技術の導入について説明している。", 4)
+
+	tests := []struct {
+		name    string
+		summary string
+		want    bool
+	}{
+		{"confident summary", confident, false},
+		{"confident summary with English terms", confident + "OpenAIのGPTも言及された。", false},
+		{"too short", "短い要約。", true},
+		{"mostly English", strings.Repeat("The article describes a new technology rollout. ", 4), true},
+		{"repeated sentence", repeated, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsLowConfidenceSummary(tt.summary))
+		})
+	}
+}
@@ -206,6 +206,19 @@ func (r *ArticleRepository) FindByID(ctx context.Context, articleID string) (*do
 	}, nil
 }
 
+// FindFeedID returns the ID of the feed an article belongs to, or "" if the
+// article has no feed.
+func (r *ArticleRepository) FindFeedID(ctx context.Context, articleID string) (string, error) {
+	req := connect.NewRequest(&backendv1.GetArticleByIDRequest{ArticleId: articleID})
+	r.client.addAuth(req)
+
+	resp, err := r.client.client.GetArticleByID(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("GetArticleByID: %w", err)
+	}
+	return resp.Msg.GetArticle().GetFeedId(), nil
+}
+
 // FetchInoreaderArticles fetches articles from the pre-processor's own inoreader_articles table.
 // This requires direct DB access since the data lives in the sidecar DB, not the backend API.
 func (r *ArticleRepository) FetchInoreaderArticles(ctx context.Context, since time.Time) ([]*domain.Article, error) {
@@ -23,6 +23,7 @@ type mockBackendClient struct {
 	listUnsummarizedFunc func(ctx context.Context, req *connect.Request[backendv1.ListUnsummarizedArticlesRequest]) (*connect.Response[backendv1.ListUnsummarizedArticlesResponse], error)
 	hasUnsummarizedFunc  func(ctx context.Context, req *connect.Request[backendv1.HasUnsummarizedArticlesRequest]) (*connect.Response[backendv1.HasUnsummarizedArticlesResponse], error)
 	getEmptyFeedIDFunc   func(ctx context.Context, req *connect.Request[backendv1.GetEmptyFeedIDRequest]) (*connect.Response[backendv1.GetEmptyFeedIDResponse], error)
+	getArticleByIDFunc   func(ctx context.Context, req *connect.Request[backendv1.GetArticleByIDRequest]) (*connect.Response[backendv1.GetArticleByIDResponse], error)
 }
 
 func (m *mockBackendClient) GetFeedID(ctx context.Context, req *connect.Request[backendv1.GetFeedIDRequest]) (*connect.Response[backendv1.GetFeedIDResponse], error) {
@@ -60,6 +61,13 @@ func (m *mockBackendClient) GetEmptyFeedID(ctx context.Context, req *connect.Req
 	return connect.NewResponse(&backendv1.GetEmptyFeedIDResponse{}), nil
 }
 
+func (m *mockBackendClient) GetArticleByID(ctx context.Context, req *connect.Request[backendv1.GetArticleByIDRequest]) (*connect.Response[backendv1.GetArticleByIDResponse], error) {
+	if m.getArticleByIDFunc != nil {
+		return m.getArticleByIDFunc(ctx, req)
+	}
+	return connect.NewResponse(&backendv1.GetArticleByIDResponse{}), nil
+}
+
 func newTestRepo(mock *mockBackendClient) *ArticleRepository {
 	client := &Client{client: mock}
 	return NewArticleRepository(client, nil)
@@ -486,3 +494,29 @@ func TestGetEmptyFeedID_Error(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestFindFeedID(t *testing.T) {
+	mock := &mockBackendClient{
+		getArticleByIDFunc: func(_ context.Context, req *connect.Request[backendv1.GetArticleByIDRequest]) (*connect.Response[backendv1.GetArticleByIDResponse], error) {
+			if req.Msg.ArticleId != "article-1" {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("article not found"))
+			}
+			return connect.NewResponse(&backendv1.GetArticleByIDResponse{
+				Article: &backendv1.ArticleWithTags{Id: "article-1", FeedId: "feed-1"},
+			}), nil
+		},
+	}
+	repo := newTestRepo(mock)
+
+	feedID, err := repo.FindFeedID(context.Background(), "article-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if feedID != "feed-1" {
+		t.Errorf("got feed ID %q, want feed-1", feedID)
+	}
+
+	if _, err := repo.FindFeedID(context.Background(), "missing"); err == nil {
+		t.Error("expected an error for a missing article")
+	}
+}
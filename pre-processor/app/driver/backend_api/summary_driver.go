@@ -32,10 +32,14 @@ func (r *SummaryRepository) Create(ctx context.Context, summary *domain.ArticleS
 		return fmt.Errorf("article ID cannot be empty")
 	}
 
+	language := summary.SummaryLanguage
+	if language == "" {
+		language = domain.DefaultSummaryLanguage
+	}
 	protoReq := &backendv1.SaveArticleSummaryRequest{
 		ArticleId: summary.ArticleID,
 		Summary:   summary.SummaryJapanese,
-		Language:  "ja",
+		Language:  language,
 		UserId:    summary.UserID,
 	}
 
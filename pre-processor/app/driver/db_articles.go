@@ -26,7 +26,8 @@ func GetInoreaderArticles(ctx context.Context, db *pgxpool.Pool, since time.Time
 			a.content,
 			a.published_at,
 			COALESCE(s.feed_url, '') AS feed_url,
-			a.fetched_at
+			a.fetched_at,
+			a.enclosures
 		FROM inoreader_articles a
 		LEFT JOIN inoreader_subscriptions s ON a.subscription_id = s.id
 		WHERE a.fetched_at > $1
@@ -54,6 +55,7 @@ func GetInoreaderArticles(ctx context.Context, db *pgxpool.Pool, since time.Time
 			&publishedAt,
 			&feedURL,
 			&fetchedAt,
+			&a.Enclosures,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan inoreader article: %w", err)
@@ -89,7 +91,8 @@ func GetInoreaderArticlesForBackfill(ctx context.Context, db *pgxpool.Pool, fetc
 			ia.content,
 			ia.published_at,
 			COALESCE(isub.feed_url, '') AS feed_url,
-			ia.fetched_at
+			ia.fetched_at,
+			ia.enclosures
 		FROM inoreader_articles ia
 		INNER JOIN inoreader_subscriptions isub ON ia.subscription_id = isub.id
 		WHERE ia.content IS NOT NULL
@@ -120,6 +123,7 @@ func GetInoreaderArticlesForBackfill(ctx context.Context, db *pgxpool.Pool, fetc
 			&publishedAt,
 			&feedURL,
 			&fetchedAt,
+			&a.Enclosures,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan inoreader article for backfill: %w", err)
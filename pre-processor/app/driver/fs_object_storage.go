@@ -0,0 +1,71 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FSObjectStorage stores objects on a filesystem volume that a static file
+// server exposes under publicBaseURL. It is the Compose-native
+// object store; an S3-compatible backend can replace it behind the same
+// ObjectStorage port.
+type FSObjectStorage struct {
+	rootDir       string
+	publicBaseURL string
+}
+
+// NewFSObjectStorage creates a filesystem-backed object storage.
+func NewFSObjectStorage(rootDir, publicBaseURL string) *FSObjectStorage {
+	return &FSObjectStorage{
+		rootDir:       rootDir,
+		publicBaseURL: strings.TrimRight(publicBaseURL, "/"),
+	}
+}
+
+// Put writes data under key atomically (temp file + rename) and returns the
+// public URL. contentType is implied by the key's extension for a static
+// file server, so it is not persisted.
+func (s *FSObjectStorage) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid object key: %q", key)
+	}
+	dest := filepath.Join(s.rootDir, filepath.FromSlash(clean))
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", fmt.Errorf("create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp object: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("close object: %w", err)
+	}
+	if err := os.Chmod(tmpName, 0o644); err != nil {
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("chmod object: %w", err)
+	}
+	if err := os.Rename(tmpName, dest); err != nil {
+		_ = os.Remove(tmpName)
+		return "", fmt.Errorf("commit object: %w", err)
+	}
+
+	return s.publicBaseURL + clean, nil
+}
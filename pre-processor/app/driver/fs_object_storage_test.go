@@ -0,0 +1,30 @@
+package driver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSObjectStorage_PutWritesFileAndReturnsPublicURL(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFSObjectStorage(dir, "https://alt.example.com/thumbnails/")
+
+	url, err := s.Put(context.Background(), "articles/abc/small.jpg", []byte("data"), "image/jpeg")
+	require.NoError(t, err)
+	assert.Equal(t, "https://alt.example.com/thumbnails/articles/abc/small.jpg", url)
+
+	got, err := os.ReadFile(filepath.Join(dir, "articles", "abc", "small.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(got))
+}
+
+func TestFSObjectStorage_PutRejectsTraversal(t *testing.T) {
+	s := NewFSObjectStorage(t.TempDir(), "/thumbnails")
+	_, err := s.Put(context.Background(), "../etc/passwd", []byte("x"), "text/plain")
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+
+	// Register decoders for the formats article images ship in.
+	_ "image/gif"
+	_ "image/png"
+)
+
+// maxSourcePixels bounds decoded source images so a small, highly
+// compressed file cannot expand past the container's memory limit
+// (decode + RGBA copy is roughly 8 bytes per pixel).
+const maxSourcePixels = 8_000_000
+
+// thumbnailJPEGQuality balances thumbnail size against visible artifacts.
+const thumbnailJPEGQuality = 82
+
+// ErrUnsupportedImage is returned for formats the resizer cannot decode
+// (e.g. WebP, AVIF, SVG) or images exceeding maxSourcePixels.
+var ErrUnsupportedImage = errors.New("unsupported image")
+
+// ImageResizer downsizes JPEG/PNG/GIF images into JPEG thumbnails using
+// only the standard library (box-filter area averaging).
+type ImageResizer struct{}
+
+// NewImageResizer creates a new ImageResizer.
+func NewImageResizer() *ImageResizer {
+	return &ImageResizer{}
+}
+
+// Resize scales src to the given width, preserving aspect ratio, and encodes
+// it as JPEG. Images narrower than width are re-encoded without upscaling.
+func (r *ImageResizer) Resize(src []byte, width int) ([]byte, string, error) {
+	if width <= 0 {
+		return nil, "", fmt.Errorf("width must be positive: %d", width)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(src))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, "", fmt.Errorf("%w: dimensions %dx%d", ErrUnsupportedImage, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupportedImage, err)
+	}
+
+	out := flattenOnWhite(img)
+	if b := out.Bounds(); b.Dx() > width {
+		height := b.Dy() * width / b.Dx()
+		if height < 1 {
+			height = 1
+		}
+		out = boxResize(out, width, height)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, out, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, "", fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// flattenOnWhite converts img to RGBA composited over a white background,
+// since JPEG has no alpha channel.
+func flattenOnWhite(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return dst
+}
+
+// boxResize downsamples src to w x h by averaging the source pixels each
+// destination pixel covers. Only used for downscaling.
+func boxResize(src *image.RGBA, w, h int) *image.RGBA {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for dy := 0; dy < h; dy++ {
+		y0 := dy * sh / h
+		y1 := (dy + 1) * sh / h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for dx := 0; dx < w; dx++ {
+			x0 := dx * sw / w
+			x1 := (dx + 1) * sw / w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var rSum, gSum, bSum, aSum, n uint64
+			for sy := y0; sy < y1; sy++ {
+				off := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					rSum += uint64(src.Pix[off])
+					gSum += uint64(src.Pix[off+1])
+					bSum += uint64(src.Pix[off+2])
+					aSum += uint64(src.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			d := dy*dst.Stride + dx*4
+			dst.Pix[d] = uint8(rSum / n)
+			dst.Pix[d+1] = uint8(gSum / n)
+			dst.Pix[d+2] = uint8(bSum / n)
+			dst.Pix[d+3] = uint8(aSum / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,61 @@
+package driver
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeTestPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestImageResizer_DownscalesPreservingAspectRatio(t *testing.T) {
+	r := NewImageResizer()
+	out, contentType, err := r.Resize(encodeTestPNG(t, 400, 200), 100)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(out))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 100, cfg.Width)
+	assert.Equal(t, 50, cfg.Height)
+}
+
+func TestImageResizer_DoesNotUpscale(t *testing.T) {
+	r := NewImageResizer()
+	out, _, err := r.Resize(encodeTestPNG(t, 80, 60), 320)
+	require.NoError(t, err)
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(out))
+	require.NoError(t, err)
+	assert.Equal(t, 80, cfg.Width)
+	assert.Equal(t, 60, cfg.Height)
+}
+
+func TestImageResizer_RejectsUndecodableInput(t *testing.T) {
+	r := NewImageResizer()
+	_, _, err := r.Resize([]byte("<svg></svg>"), 320)
+	assert.ErrorIs(t, err, ErrUnsupportedImage)
+}
+
+func TestImageResizer_RejectsNonPositiveWidth(t *testing.T) {
+	r := NewImageResizer()
+	_, _, err := r.Resize(encodeTestPNG(t, 10, 10), 0)
+	assert.Error(t, err)
+}
@@ -1,5 +1,7 @@
 package driver
 
+import "pre-processor/domain"
+
 // ArticleWithSummary represents an article with its summary for quality checking.
 type ArticleWithSummary struct {
 	ArticleID       string `db:"article_id"`
@@ -7,4 +9,7 @@ type ArticleWithSummary struct {
 	Content         string `db:"content"`
 	SummaryJapanese string `db:"summary_japanese"`
 	SummaryID       string `db:"summary_id"`
+	// QualityStrictness is the article's feed override; empty judges with
+	// the global threshold.
+	QualityStrictness domain.QualityStrictness `db:"-"`
 }
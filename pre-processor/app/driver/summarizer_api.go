@@ -40,6 +40,7 @@ func isTransportBusyError(err error) bool {
 type SummarizedContent struct {
 	ArticleID       string `json:"article_id"`
 	SummaryJapanese string `json:"summary_japanese"`
+	Language        string `json:"language"`
 }
 
 // SummarizeRequest represents the request to news-creator /api/v1/summarize endpoint
@@ -48,6 +49,10 @@ type SummarizeRequest struct {
 	Content   string `json:"content"`
 	Stream    bool   `json:"stream"`
 	Priority  string `json:"priority,omitempty"`
+	// SourceLanguage is the article's detected language, if known.
+	SourceLanguage string `json:"source_language,omitempty"`
+	// TargetLanguage selects the summary prompts; news-creator defaults to "ja".
+	TargetLanguage string `json:"target_language,omitempty"`
 }
 
 // SummarizeResponse represents the response from news-creator /api/v1/summarize endpoint
@@ -56,6 +61,7 @@ type SummarizeResponse struct {
 	ArticleID        string   `json:"article_id"`
 	Summary          string   `json:"summary"`
 	Model            string   `json:"model"`
+	Language         string   `json:"language,omitempty"`
 	PromptTokens     *int     `json:"prompt_tokens,omitempty"`
 	CompletionTokens *int     `json:"completion_tokens,omitempty"`
 	TotalDurationMs  *float64 `json:"total_duration_ms,omitempty"`
@@ -81,6 +87,15 @@ const (
 	maxContentRunes = 100_000
 )
 
+// summaryLanguage is the target language sent to news-creator for article.
+func summaryLanguage(article *domain.Article) string {
+	lang, ok := domain.NormalizeSummaryLanguage(article.SummaryLanguage)
+	if !ok {
+		return domain.DefaultSummaryLanguage
+	}
+	return lang
+}
+
 // prepareSummarizeContent extracts plain text from raw article HTML (Zero
 // Trust: news-creator must never receive raw HTML, even if it was already
 // extracted upstream) and validates its length. logContext names the calling
@@ -203,9 +218,11 @@ func ArticleSummarizerAPIClient(ctx context.Context, article *domain.Article, cf
 	// Prepare request payload for /api/v1/summarize endpoint
 	// Use extracted content, not original
 	payload := SummarizeRequest{
-		ArticleID: article.ID,
-		Content:   extractedContent,
-		Priority:  priority,
+		ArticleID:      article.ID,
+		Content:        extractedContent,
+		Priority:       priority,
+		SourceLanguage: article.Language,
+		TargetLanguage: summaryLanguage(article),
 	}
 
 	jsonData, err := json.Marshal(payload)
@@ -296,9 +313,15 @@ func ArticleSummarizerAPIClient(ctx context.Context, article *domain.Article, cf
 	}
 
 	// Summary is already cleaned by news-creator, no need for additional cleaning
+	language := apiResponse.Language
+	if language == "" {
+		// Older news-creator builds do not echo the language back.
+		language = payload.TargetLanguage
+	}
 	summarizedContent := &SummarizedContent{
 		ArticleID:       apiResponse.ArticleID,
 		SummaryJapanese: apiResponse.Summary,
+		Language:        language,
 	}
 
 	logger.InfoContext(ctx, "Summary generated successfully",
@@ -325,10 +348,12 @@ func StreamArticleSummarizerAPIClient(ctx context.Context, article *domain.Artic
 	}
 
 	payload := SummarizeRequest{
-		ArticleID: article.ID,
-		Content:   extractedContent,
-		Stream:    true,
-		Priority:  priority,
+		ArticleID:      article.ID,
+		Content:        extractedContent,
+		Stream:         true,
+		Priority:       priority,
+		SourceLanguage: article.Language,
+		TargetLanguage: summaryLanguage(article),
 	}
 
 	jsonData, err := json.Marshal(payload)
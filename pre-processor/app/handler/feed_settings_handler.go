@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+	"pre-processor/service"
+	apperrors "pre-processor/utils/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// FeedSettingsHandler exposes per-feed processing overrides over REST.
+type FeedSettingsHandler struct {
+	repo     repository.FeedSettingsRepository
+	resolver *service.FeedSettingsResolver
+	logger   *slog.Logger
+}
+
+// NewFeedSettingsHandler creates a new feed settings handler. resolver is
+// the one the workers use; it is invalidated after every change.
+func NewFeedSettingsHandler(repo repository.FeedSettingsRepository, resolver *service.FeedSettingsResolver, logger *slog.Logger) *FeedSettingsHandler {
+	return &FeedSettingsHandler{repo: repo, resolver: resolver, logger: logger}
+}
+
+// FeedSettingsOverrides lists a feed's overrides. Omitted (null) fields
+// inherit the global setting.
+type FeedSettingsOverrides struct {
+	BatchSize            *int    `json:"batch_size"`
+	SummarizationEnabled *bool   `json:"summarization_enabled"`
+	QualityStrictness    *string `json:"quality_strictness"`
+	LanguageOverride     *string `json:"language_override"`
+	FetchIntervalSeconds *int    `json:"fetch_interval_seconds"`
+}
+
+// EffectiveFeedSettingsResponse is what a feed is processed with.
+type EffectiveFeedSettingsResponse struct {
+	BatchSize            int    `json:"batch_size"`
+	SummarizationEnabled bool   `json:"summarization_enabled"`
+	QualityStrictness    string `json:"quality_strictness"`
+	Language             string `json:"language,omitempty"`
+	FetchIntervalSeconds int    `json:"fetch_interval_seconds,omitempty"`
+}
+
+// FeedSettingsResponse is the body of GET/PUT /api/v1/feed-settings/{feed_id}.
+type FeedSettingsResponse struct {
+	FeedID    string                        `json:"feed_id"`
+	Overrides FeedSettingsOverrides         `json:"overrides"`
+	Effective EffectiveFeedSettingsResponse `json:"effective"`
+	UpdatedAt *time.Time                    `json:"updated_at,omitempty"`
+}
+
+// FeedSettingsListResponse is the body of GET /api/v1/feed-settings.
+type FeedSettingsListResponse struct {
+	Feeds []FeedSettingsResponse `json:"feeds"`
+}
+
+// HandleListFeedSettings handles GET /api/v1/feed-settings requests. Only
+// feeds with overrides are listed.
+func (h *FeedSettingsHandler) HandleListFeedSettings(c echo.Context) error {
+	items, err := h.repo.List(c.Request().Context())
+	if err != nil {
+		return apperrors.NewDatabaseContextError(
+			"failed to list feed settings",
+			"handler", "FeedSettingsHandler", "HandleListFeedSettings",
+			err, nil,
+		)
+	}
+
+	resp := FeedSettingsListResponse{Feeds: make([]FeedSettingsResponse, 0, len(items))}
+	for _, s := range items {
+		resp.Feeds = append(resp.Feeds, h.toResponse(s.FeedID, s))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandleGetFeedSettings handles GET /api/v1/feed-settings/{feed_id}
+// requests. A feed without overrides returns the global settings.
+func (h *FeedSettingsHandler) HandleGetFeedSettings(c echo.Context) error {
+	feedID := c.Param("feed_id")
+	settings, err := h.repo.Get(c.Request().Context(), feedID)
+	if err != nil {
+		return apperrors.NewDatabaseContextError(
+			"failed to get feed settings",
+			"handler", "FeedSettingsHandler", "HandleGetFeedSettings",
+			err, map[string]interface{}{"feed_id": feedID},
+		)
+	}
+	return c.JSON(http.StatusOK, h.toResponse(feedID, settings))
+}
+
+// HandlePutFeedSettings handles PUT /api/v1/feed-settings/{feed_id}
+// requests. The body replaces all of the feed's overrides.
+func (h *FeedSettingsHandler) HandlePutFeedSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+	feedID := c.Param("feed_id")
+
+	var req FeedSettingsOverrides
+	if err := c.Bind(&req); err != nil {
+		return apperrors.NewValidationContextError(
+			"invalid request format",
+			"handler", "FeedSettingsHandler", "HandlePutFeedSettings",
+			map[string]interface{}{"bind_error": err.Error()},
+		)
+	}
+
+	settings := &domain.FeedSettings{
+		FeedID:               feedID,
+		BatchSize:            req.BatchSize,
+		SummarizationEnabled: req.SummarizationEnabled,
+		LanguageOverride:     req.LanguageOverride,
+	}
+	if req.QualityStrictness != nil {
+		q := domain.QualityStrictness(*req.QualityStrictness)
+		settings.QualityStrictness = &q
+	}
+	if req.FetchIntervalSeconds != nil {
+		d := time.Duration(*req.FetchIntervalSeconds) * time.Second
+		settings.FetchInterval = &d
+	}
+	if err := settings.Validate(); err != nil {
+		return apperrors.NewValidationContextError(
+			err.Error(),
+			"handler", "FeedSettingsHandler", "HandlePutFeedSettings",
+			map[string]interface{}{"feed_id": feedID},
+		)
+	}
+
+	stored, err := h.repo.Upsert(ctx, settings)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFeedSettings) {
+			return apperrors.NewValidationContextError(
+				err.Error(),
+				"handler", "FeedSettingsHandler", "HandlePutFeedSettings",
+				map[string]interface{}{"feed_id": feedID},
+			)
+		}
+		return apperrors.NewDatabaseContextError(
+			"failed to save feed settings",
+			"handler", "FeedSettingsHandler", "HandlePutFeedSettings",
+			err, map[string]interface{}{"feed_id": feedID},
+		)
+	}
+	h.resolver.Invalidate()
+
+	h.logger.InfoContext(ctx, "feed settings updated", "feed_id", feedID, "remote_addr", c.RealIP())
+	return c.JSON(http.StatusOK, h.toResponse(feedID, stored))
+}
+
+// HandleDeleteFeedSettings handles DELETE /api/v1/feed-settings/{feed_id}
+// requests, returning the feed to the global settings.
+func (h *FeedSettingsHandler) HandleDeleteFeedSettings(c echo.Context) error {
+	ctx := c.Request().Context()
+	feedID := c.Param("feed_id")
+
+	deleted, err := h.repo.Delete(ctx, feedID)
+	if err != nil {
+		return apperrors.NewDatabaseContextError(
+			"failed to delete feed settings",
+			"handler", "FeedSettingsHandler", "HandleDeleteFeedSettings",
+			err, map[string]interface{}{"feed_id": feedID},
+		)
+	}
+	if !deleted {
+		return apperrors.NewNotFoundContextError(
+			"feed has no settings overrides",
+			"handler", "FeedSettingsHandler", "HandleDeleteFeedSettings",
+			map[string]interface{}{"feed_id": feedID},
+		)
+	}
+	h.resolver.Invalidate()
+
+	h.logger.InfoContext(ctx, "feed settings deleted", "feed_id", feedID, "remote_addr", c.RealIP())
+	return c.NoContent(http.StatusNoContent)
+}
+
+// toResponse renders a feed's overrides (nil for none) and the settings
+// they resolve to.
+func (h *FeedSettingsHandler) toResponse(feedID string, s *domain.FeedSettings) FeedSettingsResponse {
+	eff := s.Resolve(h.resolver.Defaults())
+	resp := FeedSettingsResponse{
+		FeedID: feedID,
+		Effective: EffectiveFeedSettingsResponse{
+			BatchSize:            eff.BatchSize,
+			SummarizationEnabled: eff.SummarizationEnabled,
+			QualityStrictness:    string(eff.QualityStrictness),
+			Language:             eff.Language,
+			FetchIntervalSeconds: int(eff.FetchInterval.Seconds()),
+		},
+	}
+	if s == nil {
+		return resp
+	}
+
+	resp.Overrides = FeedSettingsOverrides{
+		BatchSize:            s.BatchSize,
+		SummarizationEnabled: s.SummarizationEnabled,
+		LanguageOverride:     s.LanguageOverride,
+	}
+	if s.QualityStrictness != nil {
+		q := string(*s.QualityStrictness)
+		resp.Overrides.QualityStrictness = &q
+	}
+	if s.FetchInterval != nil {
+		secs := int(s.FetchInterval.Seconds())
+		resp.Overrides.FetchIntervalSeconds = &secs
+	}
+	resp.UpdatedAt = optionalTime(s.UpdatedAt)
+	return resp
+}
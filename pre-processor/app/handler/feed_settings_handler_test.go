@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pre-processor/domain"
+	"pre-processor/handler"
+	"pre-processor/middleware"
+	"pre-processor/repository"
+	"pre-processor/service"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memFeedSettingsRepo is an in-memory FeedSettingsRepository.
+type memFeedSettingsRepo struct {
+	repository.FeedSettingsRepository
+	items map[string]*domain.FeedSettings
+}
+
+func (m *memFeedSettingsRepo) Get(_ context.Context, feedID string) (*domain.FeedSettings, error) {
+	return m.items[feedID], nil
+}
+
+func (m *memFeedSettingsRepo) List(_ context.Context) ([]*domain.FeedSettings, error) {
+	var out []*domain.FeedSettings
+	for _, s := range m.items {
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func (m *memFeedSettingsRepo) Upsert(_ context.Context, s *domain.FeedSettings) (*domain.FeedSettings, error) {
+	m.items[s.FeedID] = s
+	return s, nil
+}
+
+func (m *memFeedSettingsRepo) Delete(_ context.Context, feedID string) (bool, error) {
+	_, ok := m.items[feedID]
+	delete(m.items, feedID)
+	return ok, nil
+}
+
+func newFeedSettingsTestServer(repo *memFeedSettingsRepo) *echo.Echo {
+	resolver := service.NewFeedSettingsResolver(repo, nil, domain.EffectiveFeedSettings{
+		BatchSize:            10,
+		SummarizationEnabled: true,
+		QualityStrictness:    domain.QualityStrictnessStandard,
+	}, testLoggerSummarize())
+	h := handler.NewFeedSettingsHandler(repo, resolver, testLoggerSummarize())
+
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.CustomHTTPErrorHandler(testLoggerSummarize())
+	e.GET("/api/v1/feed-settings", h.HandleListFeedSettings)
+	e.GET("/api/v1/feed-settings/:feed_id", h.HandleGetFeedSettings)
+	e.PUT("/api/v1/feed-settings/:feed_id", h.HandlePutFeedSettings)
+	e.DELETE("/api/v1/feed-settings/:feed_id", h.HandleDeleteFeedSettings)
+	return e
+}
+
+func TestFeedSettingsHandler_GetWithoutOverrides(t *testing.T) {
+	e := newFeedSettingsTestServer(&memFeedSettingsRepo{items: map[string]*domain.FeedSettings{}})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/feed-settings/feed-1", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp handler.FeedSettingsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "feed-1", resp.FeedID)
+	assert.Nil(t, resp.Overrides.BatchSize)
+	assert.Equal(t, 10, resp.Effective.BatchSize)
+	assert.True(t, resp.Effective.SummarizationEnabled)
+}
+
+func TestFeedSettingsHandler_Put(t *testing.T) {
+	repo := &memFeedSettingsRepo{items: map[string]*domain.FeedSettings{}}
+	e := newFeedSettingsTestServer(repo)
+
+	body := `{"batch_size": 3, "summarization_enabled": false, "quality_strictness": "strict", "fetch_interval_seconds": 1800}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/feed-settings/feed-1", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp handler.FeedSettingsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Effective.BatchSize)
+	assert.False(t, resp.Effective.SummarizationEnabled)
+	assert.Equal(t, "strict", resp.Effective.QualityStrictness)
+	assert.Equal(t, 1800, resp.Effective.FetchIntervalSeconds)
+	assert.Nil(t, resp.Overrides.LanguageOverride)
+	assert.Contains(t, repo.items, "feed-1")
+}
+
+func TestFeedSettingsHandler_PutRejectsInvalidOverrides(t *testing.T) {
+	repo := &memFeedSettingsRepo{items: map[string]*domain.FeedSettings{}}
+	e := newFeedSettingsTestServer(repo)
+
+	for _, body := range []string{
+		`{"batch_size": 0}`,
+		`{"quality_strictness": "harsh"}`,
+		`{"fetch_interval_seconds": 5}`,
+		`{"language_override": "Japanese"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/feed-settings/feed-1", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	assert.Empty(t, repo.items)
+}
+
+func TestFeedSettingsHandler_Delete(t *testing.T) {
+	repo := &memFeedSettingsRepo{items: map[string]*domain.FeedSettings{"feed-1": {FeedID: "feed-1"}}}
+	e := newFeedSettingsTestServer(repo)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/feed-settings/feed-1", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/feed-settings/feed-1", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -2,6 +2,8 @@ package handler
 
 import (
 	"context"
+
+	"pre-processor/orchestrator"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=../test/mocks/handler_mocks.go -package=mocks
@@ -13,6 +15,9 @@ type JobHandler interface {
 	StartArticleSyncJob(ctx context.Context) error
 	StartBackfillJob(ctx context.Context) error
 	StartSummarizeQueueWorker(ctx context.Context) error
+	StartThumbnailJob(ctx context.Context) error
+	JobStatuses() []orchestrator.JobStatus
+	TriggerJob(name string) error
 	Stop() error
 }
 
@@ -17,6 +17,8 @@ type jobHandler struct {
 	articleSync             service.ArticleSyncService
 	healthChecker           service.HealthCheckerService
 	queueWorker             *service.SummarizeQueueWorker
+	thumbnailJob            *ThumbnailJob
+	schedules               JobSchedules
 	logger                  *slog.Logger
 	jobGroup                *orchestrator.JobGroup
 	batchSize               int
@@ -24,7 +26,40 @@ type jobHandler struct {
 	batchSweepForceInterval time.Duration
 }
 
-// NewJobHandler creates a new job handler.
+// ThumbnailJob configures the optional lead-image thumbnail job.
+type ThumbnailJob struct {
+	Service   *service.ArticleThumbnailService
+	Interval  time.Duration
+	BatchSize int
+}
+
+// JobSchedule overrides when one job runs. The zero value keeps the job
+// enabled on its built-in interval.
+type JobSchedule struct {
+	Disabled bool
+	Schedule orchestrator.Schedule
+	Jitter   time.Duration
+}
+
+// JobSchedules holds the schedule of each cron-driven job.
+type JobSchedules struct {
+	ArticleSync   JobSchedule
+	Backfill      JobSchedule
+	Summarization JobSchedule
+	QualityCheck  JobSchedule
+	QueueWorker   JobSchedule
+}
+
+// apply copies the schedule into a runner config.
+func (s JobSchedule) apply(cfg orchestrator.JobConfig) orchestrator.JobConfig {
+	cfg.Schedule = s.Schedule
+	cfg.Jitter = s.Jitter
+	cfg.Disabled = s.Disabled
+	return cfg
+}
+
+// NewJobHandler creates a new job handler. thumbnailJob may be nil when
+// thumbnail generation is disabled.
 func NewJobHandler(
 	ctx context.Context,
 	articleSummarizer service.ArticleSummarizerService,
@@ -32,6 +67,8 @@ func NewJobHandler(
 	articleSync service.ArticleSyncService,
 	healthChecker service.HealthCheckerService,
 	queueWorker *service.SummarizeQueueWorker,
+	thumbnailJob *ThumbnailJob,
+	schedules JobSchedules,
 	batchSize int,
 	logger *slog.Logger,
 ) JobHandler {
@@ -41,6 +78,8 @@ func NewJobHandler(
 		articleSync:             articleSync,
 		healthChecker:           healthChecker,
 		queueWorker:             queueWorker,
+		thumbnailJob:            thumbnailJob,
+		schedules:               schedules,
 		logger:                  logger,
 		jobGroup:                orchestrator.NewJobGroup(ctx, logger),
 		batchSize:               batchSize,
@@ -53,11 +92,11 @@ func NewJobHandler(
 func (h *jobHandler) StartArticleSyncJob(ctx context.Context) error {
 	h.logger.InfoContext(ctx, "starting article sync job")
 
-	h.jobGroup.Add(orchestrator.NewJobRunner(orchestrator.JobConfig{
+	h.jobGroup.Add(orchestrator.NewJobRunner(h.schedules.ArticleSync.apply(orchestrator.JobConfig{
 		Name:           "article-sync",
 		Interval:       1 * time.Hour,
 		RunImmediately: true,
-	}, func(ctx context.Context) error {
+	}), func(ctx context.Context) error {
 		return h.articleSync.SyncArticles(ctx)
 	}, h.logger))
 
@@ -68,11 +107,11 @@ func (h *jobHandler) StartArticleSyncJob(ctx context.Context) error {
 func (h *jobHandler) StartBackfillJob(ctx context.Context) error {
 	h.logger.InfoContext(ctx, "starting article backfill job")
 
-	h.jobGroup.Add(orchestrator.NewJobRunner(orchestrator.JobConfig{
+	h.jobGroup.Add(orchestrator.NewJobRunner(h.schedules.Backfill.apply(orchestrator.JobConfig{
 		Name:           "article-backfill",
 		Interval:       1 * time.Hour,
 		RunImmediately: true,
-	}, func(ctx context.Context) error {
+	}), func(ctx context.Context) error {
 		return h.articleSync.BackfillEmptyFeeds(ctx)
 	}, h.logger))
 
@@ -88,8 +127,14 @@ const healthWaitPerAttemptTimeout = 5 * time.Minute
 // own goroutine and only then registers the job with the job group. Running
 // the wait out-of-line — instead of blocking the synchronous startJobs()
 // call chain — keeps the HTTP/Connect-RPC servers and the shutdown signal
-// handler coming up even while news-creator is still unavailable.
-func (h *jobHandler) startHealthGatedJob(ctx context.Context, name string, register func()) {
+// handler coming up even while news-creator is still unavailable. A disabled
+// job skips the wait and is registered straight away so it still shows up
+// in the jobs API.
+func (h *jobHandler) startHealthGatedJob(ctx context.Context, name string, schedule JobSchedule, register func()) {
+	if schedule.Disabled {
+		register()
+		return
+	}
 	go func() {
 		for {
 			waitCtx, cancel := context.WithTimeout(ctx, healthWaitPerAttemptTimeout)
@@ -112,11 +157,11 @@ func (h *jobHandler) startHealthGatedJob(ctx context.Context, name string, regis
 func (h *jobHandler) StartSummarizationJob(ctx context.Context) error {
 	h.logger.InfoContext(ctx, "starting summarization job")
 
-	h.startHealthGatedJob(ctx, "summarization", func() {
-		h.jobGroup.Add(orchestrator.NewJobRunner(orchestrator.JobConfig{
+	h.startHealthGatedJob(ctx, "summarization", h.schedules.Summarization, func() {
+		h.jobGroup.Add(orchestrator.NewJobRunner(h.schedules.Summarization.apply(orchestrator.JobConfig{
 			Name:     "summarization",
 			Interval: 5 * time.Minute, // Fallback safety net; primary path is event-driven via ArticleCreated events
-		}, func(ctx context.Context) error {
+		}), func(ctx context.Context) error {
 			return h.processSummarizationBatch(ctx)
 		}, h.logger))
 	})
@@ -128,11 +173,11 @@ func (h *jobHandler) StartSummarizationJob(ctx context.Context) error {
 func (h *jobHandler) StartQualityCheckJob(ctx context.Context) error {
 	h.logger.InfoContext(ctx, "starting quality check job")
 
-	h.startHealthGatedJob(ctx, "quality-check", func() {
-		h.jobGroup.Add(orchestrator.NewJobRunner(orchestrator.JobConfig{
+	h.startHealthGatedJob(ctx, "quality-check", h.schedules.QualityCheck, func() {
+		h.jobGroup.Add(orchestrator.NewJobRunner(h.schedules.QualityCheck.apply(orchestrator.JobConfig{
 			Name:     "quality-check",
 			Interval: 5 * time.Minute,
-		}, func(ctx context.Context) error {
+		}), func(ctx context.Context) error {
 			return h.processQualityCheckBatch(ctx)
 		}, h.logger))
 	})
@@ -149,14 +194,14 @@ func (h *jobHandler) StartSummarizeQueueWorker(ctx context.Context) error {
 
 	h.logger.InfoContext(ctx, "starting summarize queue worker")
 
-	h.startHealthGatedJob(ctx, "queue-worker", func() {
-		h.jobGroup.Add(orchestrator.NewJobRunner(orchestrator.JobConfig{
+	h.startHealthGatedJob(ctx, "queue-worker", h.schedules.QueueWorker, func() {
+		h.jobGroup.Add(orchestrator.NewJobRunner(h.schedules.QueueWorker.apply(orchestrator.JobConfig{
 			Name:            "queue-worker",
 			Interval:        10 * time.Second,
 			InitialBackoff:  15 * time.Second,
 			MaxBackoff:      5 * time.Minute,
 			BackoffOnErrors: []error{domain.ErrServiceOverloaded, domain.ErrUpstreamBusy},
-		}, func(ctx context.Context) error {
+		}), func(ctx context.Context) error {
 			return h.queueWorker.ProcessQueue(ctx)
 		}, h.logger))
 	})
@@ -164,6 +209,39 @@ func (h *jobHandler) StartSummarizeQueueWorker(ctx context.Context) error {
 	return nil
 }
 
+// StartThumbnailJob starts the lead-image thumbnail job.
+func (h *jobHandler) StartThumbnailJob(ctx context.Context) error {
+	if h.thumbnailJob == nil || h.thumbnailJob.Service == nil {
+		h.logger.InfoContext(ctx, "thumbnail job disabled, skipping start")
+		return nil
+	}
+
+	h.logger.InfoContext(ctx, "starting thumbnail job",
+		"interval", h.thumbnailJob.Interval,
+		"batch_size", h.thumbnailJob.BatchSize)
+
+	h.jobGroup.Add(orchestrator.NewJobRunner(orchestrator.JobConfig{
+		Name:     "thumbnail",
+		Interval: h.thumbnailJob.Interval,
+	}, func(ctx context.Context) error {
+		_, err := h.thumbnailJob.Service.ProcessPending(ctx, h.thumbnailJob.BatchSize)
+		return err
+	}, h.logger))
+
+	return nil
+}
+
+// JobStatuses reports every registered job. Health-gated jobs appear once
+// news-creator has become healthy and they have been started.
+func (h *jobHandler) JobStatuses() []orchestrator.JobStatus {
+	return h.jobGroup.Status()
+}
+
+// TriggerJob queues a manual run of the named job.
+func (h *jobHandler) TriggerJob(name string) error {
+	return h.jobGroup.Trigger(name)
+}
+
 // Stop stops all jobs.
 func (h *jobHandler) Stop() error {
 	h.logger.Info("stopping all jobs")
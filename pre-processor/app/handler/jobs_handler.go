@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"pre-processor/orchestrator"
+	apperrors "pre-processor/utils/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// JobsHandler exposes the background job scheduler over REST: next-run
+// times for every job and manual triggers.
+type JobsHandler struct {
+	jobs   JobHandler
+	logger *slog.Logger
+}
+
+// NewJobsHandler creates a new jobs handler.
+func NewJobsHandler(jobs JobHandler, logger *slog.Logger) *JobsHandler {
+	return &JobsHandler{jobs: jobs, logger: logger}
+}
+
+// JobStatusResponse describes one scheduled job.
+type JobStatusResponse struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	Enabled        bool       `json:"enabled"`
+	Running        bool       `json:"running"`
+	NextRun        *time.Time `json:"next_run,omitempty"`
+	LastRun        *time.Time `json:"last_run,omitempty"`
+	LastDurationMs int64      `json:"last_duration_ms,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// JobListResponse is the body of GET /api/v1/jobs.
+type JobListResponse struct {
+	Jobs []JobStatusResponse `json:"jobs"`
+}
+
+// JobTriggerResponse is the body of POST /api/v1/jobs/{name}/run.
+type JobTriggerResponse struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// HandleListJobs handles GET /api/v1/jobs requests.
+func (h *JobsHandler) HandleListJobs(c echo.Context) error {
+	statuses := h.jobs.JobStatuses()
+	resp := JobListResponse{Jobs: make([]JobStatusResponse, 0, len(statuses))}
+	for _, st := range statuses {
+		resp.Jobs = append(resp.Jobs, JobStatusResponse{
+			Name:           st.Name,
+			Schedule:       st.Schedule,
+			Enabled:        st.Enabled,
+			Running:        st.Running,
+			NextRun:        optionalTime(st.NextRun),
+			LastRun:        optionalTime(st.LastRun),
+			LastDurationMs: st.LastDuration.Milliseconds(),
+			LastError:      st.LastError,
+		})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandleTriggerJob handles POST /api/v1/jobs/{name}/run requests. The run
+// is queued and starts as soon as the job is idle.
+func (h *JobsHandler) HandleTriggerJob(c echo.Context) error {
+	ctx := c.Request().Context()
+	name := c.Param("name")
+
+	if err := h.jobs.TriggerJob(name); err != nil {
+		switch {
+		case errors.Is(err, orchestrator.ErrJobNotFound):
+			return apperrors.NewNotFoundContextError(
+				err.Error(),
+				"handler", "JobsHandler", "HandleTriggerJob",
+				map[string]interface{}{"job": name},
+			)
+		case errors.Is(err, orchestrator.ErrJobDisabled),
+			errors.Is(err, orchestrator.ErrJobTriggerQueued):
+			return apperrors.NewConflictContextError(
+				err.Error(),
+				"handler", "JobsHandler", "HandleTriggerJob",
+				map[string]interface{}{"job": name},
+			)
+		default:
+			return apperrors.NewInternalContextError(
+				"failed to trigger job",
+				"handler", "JobsHandler", "HandleTriggerJob",
+				err,
+				map[string]interface{}{"job": name},
+			)
+		}
+	}
+
+	h.logger.InfoContext(ctx, "manual job run queued", "job", name, "remote_addr", c.RealIP())
+	return c.JSON(http.StatusAccepted, JobTriggerResponse{Name: name, Status: "queued"})
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
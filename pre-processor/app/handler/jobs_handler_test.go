@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pre-processor/handler"
+	"pre-processor/middleware"
+	"pre-processor/orchestrator"
+	"pre-processor/test/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newJobsTestServer(jobs handler.JobHandler) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.CustomHTTPErrorHandler(testLoggerSummarize())
+	h := handler.NewJobsHandler(jobs, testLoggerSummarize())
+	e.GET("/api/v1/jobs", h.HandleListJobs)
+	e.POST("/api/v1/jobs/:name/run", h.HandleTriggerJob)
+	return e
+}
+
+func TestJobsHandler_HandleListJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	jobs := mocks.NewMockJobHandler(ctrl)
+
+	next := time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC)
+	last := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	jobs.EXPECT().JobStatuses().Return([]orchestrator.JobStatus{
+		{Name: "article-sync", Schedule: "0 * * * *", Enabled: true, NextRun: next, LastRun: last, LastDuration: 1500 * time.Millisecond},
+		{Name: "quality-check", Schedule: "@every 5m0s", Enabled: false},
+	})
+
+	rec := httptest.NewRecorder()
+	newJobsTestServer(jobs).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp handler.JobListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Jobs, 2)
+
+	assert.Equal(t, "article-sync", resp.Jobs[0].Name)
+	require.NotNil(t, resp.Jobs[0].NextRun)
+	assert.True(t, next.Equal(*resp.Jobs[0].NextRun))
+	assert.Equal(t, int64(1500), resp.Jobs[0].LastDurationMs)
+
+	assert.False(t, resp.Jobs[1].Enabled)
+	assert.Nil(t, resp.Jobs[1].NextRun)
+	assert.Nil(t, resp.Jobs[1].LastRun)
+}
+
+func TestJobsHandler_HandleTriggerJob(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		wantCode int
+	}{
+		"queued":         {err: nil, wantCode: http.StatusAccepted},
+		"unknown job":    {err: fmt.Errorf("%w: nope", orchestrator.ErrJobNotFound), wantCode: http.StatusNotFound},
+		"disabled job":   {err: fmt.Errorf("%w: nope", orchestrator.ErrJobDisabled), wantCode: http.StatusConflict},
+		"already queued": {err: fmt.Errorf("%w: nope", orchestrator.ErrJobTriggerQueued), wantCode: http.StatusConflict},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			jobs := mocks.NewMockJobHandler(ctrl)
+			jobs.EXPECT().TriggerJob("article-sync").Return(tc.err)
+
+			rec := httptest.NewRecorder()
+			newJobsTestServer(jobs).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/jobs/article-sync/run", nil))
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+		})
+	}
+}
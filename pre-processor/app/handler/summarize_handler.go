@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"pre-processor/domain"
+	"pre-processor/handler/openapi"
 	"pre-processor/repository"
 	"pre-processor/service"
 	summarizeuc "pre-processor/usecase/summarize"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // SummarizeRequest represents the request body for article summarization
@@ -21,6 +23,9 @@ type SummarizeRequest struct {
 	Content   string `json:"content"`
 	ArticleID string `json:"article_id" validate:"required"`
 	Title     string `json:"title"`
+	// Language requests a summary language ("ja", "en"); empty uses the
+	// article owner's locale.
+	Language string `json:"language,omitempty"`
 }
 
 // SummarizeResponse represents the response for article summarization
@@ -28,6 +33,11 @@ type SummarizeResponse struct {
 	Success   bool   `json:"success"`
 	Summary   string `json:"summary"`
 	ArticleID string `json:"article_id"`
+	// Language is the language Summary is written in.
+	Language string `json:"language,omitempty"`
+	// Fallback marks an extractive summary served while news-creator is
+	// unavailable; it is not saved.
+	Fallback bool `json:"fallback,omitempty"`
 }
 
 // SummarizeHandler handles on-demand article summarization requests
@@ -52,6 +62,12 @@ func NewSummarizeHandler(apiRepo repository.ExternalAPIRepository, summaryRepo r
 	}
 }
 
+// SetUserLocales makes requests without a language summarize in the article
+// owner's locale.
+func (h *SummarizeHandler) SetUserLocales(repo repository.UserLocaleRepository) {
+	h.onDemand.SetUserLocales(repo)
+}
+
 // HandleSummarize handles POST /api/v1/summarize requests
 func (h *SummarizeHandler) HandleSummarize(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -93,6 +109,7 @@ func (h *SummarizeHandler) HandleSummarize(c echo.Context) error {
 		Content:   req.Content,
 		Title:     req.Title,
 		Priority:  "high", // UI-triggered requests
+		Language:  req.Language,
 	})
 	if err != nil {
 		return mapDomainErrorToHTTP(err, req.ArticleID)
@@ -102,6 +119,8 @@ func (h *SummarizeHandler) HandleSummarize(c echo.Context) error {
 		Success:   true,
 		Summary:   result.Summary,
 		ArticleID: req.ArticleID,
+		Language:  result.Language,
+		Fallback:  result.Fallback,
 	})
 }
 
@@ -126,6 +145,12 @@ func mapDomainErrorToHTTP(err error, articleID string) error {
 			"handler", "SummarizeHandler", "HandleSummarize",
 			map[string]interface{}{"article_id": articleID},
 		)
+	case errors.Is(err, domain.ErrUnsupportedSummaryLanguage):
+		return apperrors.NewValidationContextError(
+			err.Error(),
+			"handler", "SummarizeHandler", "HandleSummarize",
+			map[string]interface{}{"article_id": articleID},
+		)
 	case errors.Is(err, domain.ErrContentTooShort):
 		return apperrors.NewValidationContextError(
 			domain.ErrContentTooShort.Error(),
@@ -195,17 +220,15 @@ func (h *SummarizeHandler) HandleStreamSummarize(c echo.Context) error {
 		ArticleID: req.ArticleID,
 		Content:   req.Content,
 		Title:     req.Title,
+		Language:  req.Language,
 	})
 	if err != nil {
 		return mapDomainErrorToHTTP(err, req.ArticleID)
 	}
 
-	h.logger.InfoContext(ctx, "processing streaming summarization request", "article_id", req.ArticleID, "content_length", len(resolved.Content))
+	h.logger.InfoContext(ctx, "processing streaming summarization request", "article_id", req.ArticleID, "content_length", len(resolved.Content), "summary_language", resolved.Language)
 
-	article := &domain.Article{
-		ID:      req.ArticleID,
-		Content: resolved.Content,
-	}
+	article := resolved.Article()
 
 	// Call streaming service with HIGH priority for UI-triggered requests
 	stream, err := h.apiRepo.StreamSummarizeArticle(ctx, article, "high")
@@ -397,3 +420,29 @@ func (h *SummarizeHandler) HandleSummarizeStatus(c echo.Context) error {
 	h.logger.DebugContext(ctx, "summarization job status retrieved", "job_id", jobID, "status", job.Status)
 	return c.JSON(http.StatusOK, response)
 }
+
+// SummarizeHandler serves the summarize operations of spec/openapi.yaml;
+// requests reach it after the OpenAPI validator has checked them.
+var _ openapi.ServerInterface = (*SummarizeHandler)(nil)
+
+// Summarize implements openapi.ServerInterface (POST /summarize).
+func (h *SummarizeHandler) Summarize(c echo.Context) error {
+	return h.HandleSummarize(c)
+}
+
+// StreamSummarize implements openapi.ServerInterface (POST /summarize/stream).
+func (h *SummarizeHandler) StreamSummarize(c echo.Context) error {
+	return h.HandleStreamSummarize(c)
+}
+
+// QueueSummarize implements openapi.ServerInterface (POST /summarize/queue).
+func (h *SummarizeHandler) QueueSummarize(c echo.Context) error {
+	return h.HandleSummarizeQueue(c)
+}
+
+// GetSummarizeStatus implements openapi.ServerInterface
+// (GET /summarize/status/{job_id}). The job ID is read from the route as
+// HandleSummarizeStatus always has; the wrapper has already parsed it.
+func (h *SummarizeHandler) GetSummarizeStatus(c echo.Context, _ openapi_types.UUID) error {
+	return h.HandleSummarizeStatus(c)
+}
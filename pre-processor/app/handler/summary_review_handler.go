@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pre-processor/domain"
+	"pre-processor/service"
+	apperrors "pre-processor/utils/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultSummaryReviewListLimit = 50
+	maxSummaryReviewListLimit     = 500
+)
+
+// SummaryReviewHandler exposes the human summary review queue over REST.
+type SummaryReviewHandler struct {
+	reviews *service.SummaryReviewService
+	logger  *slog.Logger
+}
+
+// NewSummaryReviewHandler creates a new summary review handler.
+func NewSummaryReviewHandler(reviews *service.SummaryReviewService, logger *slog.Logger) *SummaryReviewHandler {
+	return &SummaryReviewHandler{reviews: reviews, logger: logger}
+}
+
+// SummaryReviewResponse is one review queue item.
+type SummaryReviewResponse struct {
+	ID        string     `json:"id"`
+	ArticleID string     `json:"article_id"`
+	Reason    string     `json:"reason"`
+	Status    string     `json:"status"`
+	Reviewer  *string    `json:"reviewer,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+}
+
+// SummaryReviewListResponse is the body of GET /api/v1/summary-reviews.
+type SummaryReviewListResponse struct {
+	Reviews []SummaryReviewResponse `json:"reviews"`
+}
+
+// SummaryReviewDecisionRequest is the optional body of the approve and
+// reject endpoints.
+type SummaryReviewDecisionRequest struct {
+	Reviewer *string `json:"reviewer"`
+	Note     *string `json:"note"`
+}
+
+// HandleListSummaryReviews handles GET /api/v1/summary-reviews requests.
+// status defaults to pending and limit to 50 (at most 500).
+func (h *SummaryReviewHandler) HandleListSummaryReviews(c echo.Context) error {
+	status := domain.SummaryReviewStatus(c.QueryParam("status"))
+	if status == "" {
+		status = domain.SummaryReviewPending
+	}
+	if !status.Valid() {
+		return apperrors.NewValidationContextError(
+			"status must be pending, approved or rejected",
+			"handler", "SummaryReviewHandler", "HandleListSummaryReviews",
+			map[string]interface{}{"status": string(status)},
+		)
+	}
+
+	limit := defaultSummaryReviewListLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return apperrors.NewValidationContextError(
+				"limit must be a positive integer",
+				"handler", "SummaryReviewHandler", "HandleListSummaryReviews",
+				map[string]interface{}{"limit": raw},
+			)
+		}
+		limit = min(n, maxSummaryReviewListLimit)
+	}
+
+	items, err := h.reviews.List(c.Request().Context(), status, limit)
+	if err != nil {
+		return apperrors.NewDatabaseContextError(
+			"failed to list summary reviews",
+			"handler", "SummaryReviewHandler", "HandleListSummaryReviews",
+			err, map[string]interface{}{"status": string(status)},
+		)
+	}
+
+	resp := SummaryReviewListResponse{Reviews: make([]SummaryReviewResponse, 0, len(items))}
+	for _, item := range items {
+		resp.Reviews = append(resp.Reviews, toSummaryReviewResponse(item))
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// HandleApproveSummaryReview handles POST
+// /api/v1/summary-reviews/{id}/approve requests.
+func (h *SummaryReviewHandler) HandleApproveSummaryReview(c echo.Context) error {
+	return h.decide(c, "HandleApproveSummaryReview", h.reviews.Approve)
+}
+
+// HandleRejectSummaryReview handles POST /api/v1/summary-reviews/{id}/reject
+// requests. The summary is deleted and the article re-enqueued for
+// summarization.
+func (h *SummaryReviewHandler) HandleRejectSummaryReview(c echo.Context) error {
+	return h.decide(c, "HandleRejectSummaryReview", h.reviews.Reject)
+}
+
+type summaryReviewDecision func(ctx context.Context, id string, reviewer, note *string) (*domain.SummaryReview, error)
+
+func (h *SummaryReviewHandler) decide(c echo.Context, operation string, apply summaryReviewDecision) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	var req SummaryReviewDecisionRequest
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			return apperrors.NewValidationContextError(
+				"invalid request format",
+				"handler", "SummaryReviewHandler", operation,
+				map[string]interface{}{"bind_error": err.Error()},
+			)
+		}
+	}
+
+	review, err := apply(ctx, id, req.Reviewer, req.Note)
+	switch {
+	case errors.Is(err, domain.ErrSummaryReviewNotFound):
+		return apperrors.NewNotFoundContextError(
+			"summary review not found",
+			"handler", "SummaryReviewHandler", operation,
+			map[string]interface{}{"review_id": id},
+		)
+	case errors.Is(err, domain.ErrSummaryReviewDecided):
+		return apperrors.NewConflictContextError(
+			"summary review already decided",
+			"handler", "SummaryReviewHandler", operation,
+			map[string]interface{}{"review_id": id},
+		)
+	case err != nil:
+		return apperrors.NewInternalContextError(
+			"failed to apply summary review decision",
+			"handler", "SummaryReviewHandler", operation,
+			err, map[string]interface{}{"review_id": id},
+		)
+	}
+
+	h.logger.InfoContext(ctx, "summary review decided", "review_id", id, "status", review.Status, "remote_addr", c.RealIP())
+	return c.JSON(http.StatusOK, toSummaryReviewResponse(review))
+}
+
+func toSummaryReviewResponse(r *domain.SummaryReview) SummaryReviewResponse {
+	return SummaryReviewResponse{
+		ID:        r.ID,
+		ArticleID: r.ArticleID,
+		Reason:    string(r.Reason),
+		Status:    string(r.Status),
+		Reviewer:  r.Reviewer,
+		Note:      r.Note,
+		CreatedAt: r.CreatedAt,
+		DecidedAt: r.DecidedAt,
+	}
+}
@@ -0,0 +1,155 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pre-processor/domain"
+	"pre-processor/handler"
+	"pre-processor/middleware"
+	"pre-processor/repository"
+	"pre-processor/service"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memSummaryReviewRepo is an in-memory SummaryReviewRepository.
+type memSummaryReviewRepo struct {
+	repository.SummaryReviewRepository
+	items map[string]*domain.SummaryReview
+}
+
+func (m *memSummaryReviewRepo) Get(_ context.Context, id string) (*domain.SummaryReview, error) {
+	return m.items[id], nil
+}
+
+func (m *memSummaryReviewRepo) List(_ context.Context, status domain.SummaryReviewStatus, _ int) ([]*domain.SummaryReview, error) {
+	var out []*domain.SummaryReview
+	for _, r := range m.items {
+		if r.Status == status {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *memSummaryReviewRepo) Decide(_ context.Context, id string, status domain.SummaryReviewStatus, reviewer, note *string) (*domain.SummaryReview, error) {
+	r, ok := m.items[id]
+	if !ok {
+		return nil, domain.ErrSummaryReviewNotFound
+	}
+	if r.Status != domain.SummaryReviewPending {
+		return nil, domain.ErrSummaryReviewDecided
+	}
+	r.Status, r.Reviewer, r.Note = status, reviewer, note
+	return r, nil
+}
+
+// reviewSummaryRepo records deleted summaries.
+type reviewSummaryRepo struct {
+	repository.SummaryRepository
+	deleted []string
+}
+
+func (m *reviewSummaryRepo) Exists(_ context.Context, _ string) (bool, error) { return true, nil }
+
+func (m *reviewSummaryRepo) Delete(_ context.Context, articleID string) error {
+	m.deleted = append(m.deleted, articleID)
+	return nil
+}
+
+// reviewJobRepo records re-enqueued articles.
+type reviewJobRepo struct {
+	repository.SummarizeJobRepository
+	created []string
+}
+
+func (m *reviewJobRepo) InvalidateCompletedJobSummary(_ context.Context, _ string) error { return nil }
+
+func (m *reviewJobRepo) CreateJob(_ context.Context, articleID string) (string, error) {
+	m.created = append(m.created, articleID)
+	return "job-1", nil
+}
+
+func newSummaryReviewTestServer(repo *memSummaryReviewRepo, summaries *reviewSummaryRepo, jobs *reviewJobRepo) *echo.Echo {
+	svc := service.NewSummaryReviewService(repo, summaries, jobs, testLoggerSummarize())
+	h := handler.NewSummaryReviewHandler(svc, testLoggerSummarize())
+
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.CustomHTTPErrorHandler(testLoggerSummarize())
+	e.GET("/api/v1/summary-reviews", h.HandleListSummaryReviews)
+	e.POST("/api/v1/summary-reviews/:id/approve", h.HandleApproveSummaryReview)
+	e.POST("/api/v1/summary-reviews/:id/reject", h.HandleRejectSummaryReview)
+	return e
+}
+
+func pendingSummaryReviews() *memSummaryReviewRepo {
+	return &memSummaryReviewRepo{items: map[string]*domain.SummaryReview{
+		"r1": {ID: "r1", ArticleID: "article-1", Reason: domain.SummaryReviewSampled, Status: domain.SummaryReviewPending},
+	}}
+}
+
+func TestSummaryReviewHandler_ListPending(t *testing.T) {
+	e := newSummaryReviewTestServer(pendingSummaryReviews(), &reviewSummaryRepo{}, &reviewJobRepo{})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/summary-reviews", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp handler.SummaryReviewListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Reviews, 1)
+	assert.Equal(t, "article-1", resp.Reviews[0].ArticleID)
+	assert.Equal(t, "sampled", resp.Reviews[0].Reason)
+}
+
+func TestSummaryReviewHandler_ListRejectsUnknownStatus(t *testing.T) {
+	e := newSummaryReviewTestServer(pendingSummaryReviews(), &reviewSummaryRepo{}, &reviewJobRepo{})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/summary-reviews?status=done", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSummaryReviewHandler_RejectResummarizes(t *testing.T) {
+	summaries, jobs := &reviewSummaryRepo{}, &reviewJobRepo{}
+	e := newSummaryReviewTestServer(pendingSummaryReviews(), summaries, jobs)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/summary-reviews/r1/reject",
+		strings.NewReader(`{"reviewer":"ops","note":"misses the main point"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp handler.SummaryReviewResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "rejected", resp.Status)
+	require.NotNil(t, resp.Reviewer)
+	assert.Equal(t, "ops", *resp.Reviewer)
+	assert.Equal(t, []string{"article-1"}, summaries.deleted)
+	assert.Equal(t, []string{"article-1"}, jobs.created)
+}
+
+func TestSummaryReviewHandler_DecisionErrors(t *testing.T) {
+	e := newSummaryReviewTestServer(pendingSummaryReviews(), &reviewSummaryRepo{}, &reviewJobRepo{})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/summary-reviews/r1/approve", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/summary-reviews/r1/reject", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/summary-reviews/missing/approve", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+	apperrors "pre-processor/utils/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UserLocaleHandler exposes users' preferred locales over REST. The locale
+// picks the language of the user's article summaries.
+type UserLocaleHandler struct {
+	repo   repository.UserLocaleRepository
+	logger *slog.Logger
+}
+
+// NewUserLocaleHandler creates a new user locale handler.
+func NewUserLocaleHandler(repo repository.UserLocaleRepository, logger *slog.Logger) *UserLocaleHandler {
+	return &UserLocaleHandler{repo: repo, logger: logger}
+}
+
+// PutUserLocaleRequest is the body of PUT /api/v1/users/{user_id}/locale.
+type PutUserLocaleRequest struct {
+	Locale string `json:"locale"`
+}
+
+// UserLocaleResponse is the body of GET/PUT /api/v1/users/{user_id}/locale.
+type UserLocaleResponse struct {
+	UserID string `json:"user_id"`
+	// Locale is empty when the user has none stored.
+	Locale string `json:"locale"`
+	// SummaryLanguage is the language the user's summaries are written in.
+	SummaryLanguage string     `json:"summary_language"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// HandleGetUserLocale handles GET /api/v1/users/{user_id}/locale requests.
+// A user without a stored locale gets the default summary language.
+func (h *UserLocaleHandler) HandleGetUserLocale(c echo.Context) error {
+	userID := c.Param("user_id")
+	locale, err := h.repo.Get(c.Request().Context(), userID)
+	if err != nil {
+		return apperrors.NewDatabaseContextError(
+			"failed to get user locale",
+			"handler", "UserLocaleHandler", "HandleGetUserLocale",
+			err, map[string]interface{}{"user_id": userID},
+		)
+	}
+	return c.JSON(http.StatusOK, toUserLocaleResponse(userID, locale))
+}
+
+// HandlePutUserLocale handles PUT /api/v1/users/{user_id}/locale requests.
+func (h *UserLocaleHandler) HandlePutUserLocale(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Param("user_id")
+
+	var req PutUserLocaleRequest
+	if err := c.Bind(&req); err != nil {
+		return apperrors.NewValidationContextError(
+			"invalid request format",
+			"handler", "UserLocaleHandler", "HandlePutUserLocale",
+			map[string]interface{}{"bind_error": err.Error()},
+		)
+	}
+
+	locale := &domain.UserLocale{UserID: userID, Locale: req.Locale}
+	if err := locale.Validate(); err != nil {
+		return apperrors.NewValidationContextError(
+			err.Error(),
+			"handler", "UserLocaleHandler", "HandlePutUserLocale",
+			map[string]interface{}{"user_id": userID},
+		)
+	}
+
+	stored, err := h.repo.Upsert(ctx, locale)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidUserLocale) {
+			return apperrors.NewValidationContextError(
+				err.Error(),
+				"handler", "UserLocaleHandler", "HandlePutUserLocale",
+				map[string]interface{}{"user_id": userID},
+			)
+		}
+		return apperrors.NewDatabaseContextError(
+			"failed to save user locale",
+			"handler", "UserLocaleHandler", "HandlePutUserLocale",
+			err, map[string]interface{}{"user_id": userID},
+		)
+	}
+
+	h.logger.InfoContext(ctx, "user locale updated", "user_id", userID, "locale", stored.Locale)
+	return c.JSON(http.StatusOK, toUserLocaleResponse(userID, stored))
+}
+
+// HandleDeleteUserLocale handles DELETE /api/v1/users/{user_id}/locale
+// requests, returning the user to the default summary language.
+func (h *UserLocaleHandler) HandleDeleteUserLocale(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Param("user_id")
+
+	deleted, err := h.repo.Delete(ctx, userID)
+	if err != nil {
+		return apperrors.NewDatabaseContextError(
+			"failed to delete user locale",
+			"handler", "UserLocaleHandler", "HandleDeleteUserLocale",
+			err, map[string]interface{}{"user_id": userID},
+		)
+	}
+	if !deleted {
+		return apperrors.NewNotFoundContextError(
+			"user has no locale",
+			"handler", "UserLocaleHandler", "HandleDeleteUserLocale",
+			map[string]interface{}{"user_id": userID},
+		)
+	}
+
+	h.logger.InfoContext(ctx, "user locale deleted", "user_id", userID)
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toUserLocaleResponse(userID string, l *domain.UserLocale) UserLocaleResponse {
+	resp := UserLocaleResponse{
+		UserID:          userID,
+		SummaryLanguage: domain.DefaultSummaryLanguage,
+	}
+	if l == nil {
+		return resp
+	}
+	resp.Locale = l.Locale
+	resp.SummaryLanguage = domain.ResolveSummaryLanguage("", l.Locale)
+	resp.UpdatedAt = optionalTime(l.UpdatedAt)
+	return resp
+}
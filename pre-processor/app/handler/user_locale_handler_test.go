@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pre-processor/domain"
+	"pre-processor/handler"
+	"pre-processor/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memUserLocaleRepo is an in-memory UserLocaleRepository.
+type memUserLocaleRepo struct {
+	items map[string]*domain.UserLocale
+}
+
+func (m *memUserLocaleRepo) Get(_ context.Context, userID string) (*domain.UserLocale, error) {
+	return m.items[userID], nil
+}
+
+func (m *memUserLocaleRepo) Upsert(_ context.Context, l *domain.UserLocale) (*domain.UserLocale, error) {
+	m.items[l.UserID] = l
+	return l, nil
+}
+
+func (m *memUserLocaleRepo) Delete(_ context.Context, userID string) (bool, error) {
+	_, ok := m.items[userID]
+	delete(m.items, userID)
+	return ok, nil
+}
+
+func newUserLocaleTestServer(repo *memUserLocaleRepo) *echo.Echo {
+	h := handler.NewUserLocaleHandler(repo, testLoggerSummarize())
+
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.CustomHTTPErrorHandler(testLoggerSummarize())
+	e.GET("/api/v1/users/:user_id/locale", h.HandleGetUserLocale)
+	e.PUT("/api/v1/users/:user_id/locale", h.HandlePutUserLocale)
+	e.DELETE("/api/v1/users/:user_id/locale", h.HandleDeleteUserLocale)
+	return e
+}
+
+func TestUserLocaleHandler_GetWithoutLocale(t *testing.T) {
+	e := newUserLocaleTestServer(&memUserLocaleRepo{items: map[string]*domain.UserLocale{}})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/users/user-1/locale", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp handler.UserLocaleResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Empty(t, resp.Locale)
+	assert.Equal(t, domain.DefaultSummaryLanguage, resp.SummaryLanguage)
+}
+
+func TestUserLocaleHandler_Put(t *testing.T) {
+	repo := &memUserLocaleRepo{items: map[string]*domain.UserLocale{}}
+	e := newUserLocaleTestServer(repo)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/user-1/locale", strings.NewReader(`{"locale": "en-US"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp handler.UserLocaleResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "en-US", resp.Locale)
+	assert.Equal(t, "en", resp.SummaryLanguage)
+	assert.Contains(t, repo.items, "user-1")
+}
+
+func TestUserLocaleHandler_PutRejectsInvalidLocale(t *testing.T) {
+	repo := &memUserLocaleRepo{items: map[string]*domain.UserLocale{}}
+	e := newUserLocaleTestServer(repo)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/user-1/locale", strings.NewReader(`{"locale": "English"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, repo.items)
+}
+
+func TestUserLocaleHandler_Delete(t *testing.T) {
+	repo := &memUserLocaleRepo{items: map[string]*domain.UserLocale{"user-1": {UserID: "user-1", Locale: "en"}}}
+	e := newUserLocaleTestServer(repo)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/users/user-1/locale", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/users/user-1/locale", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
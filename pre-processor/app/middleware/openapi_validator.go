@@ -0,0 +1,368 @@
+// ABOUTME: Validates requests against the OpenAPI document in spec/openapi.yaml
+// ABOUTME: Rejects mismatches with a 400 VALIDATION_ERROR that lists every violation
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"gopkg.in/yaml.v3"
+
+	apperrors "pre-processor/utils/errors"
+)
+
+// Violation reasons, as enumerated by the Violation schema.
+const (
+	reasonRequired      = "required"
+	reasonInvalidJSON   = "invalid_json"
+	reasonInvalidType   = "invalid_type"
+	reasonInvalidFormat = "invalid_format"
+	reasonNotInEnum     = "not_in_enum"
+	reasonTooShort      = "too_short"
+	reasonTooLong       = "too_long"
+)
+
+// openAPIDoc is the part of an OpenAPI 3.0 document the validator reads.
+type openAPIDoc struct {
+	Paths      map[string]map[string]*openAPIOperation `yaml:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+type openAPIOperation struct {
+	Parameters  []openAPIParameter `yaml:"parameters"`
+	RequestBody *struct {
+		Required bool `yaml:"required"`
+		Content  map[string]struct {
+			Schema *openAPISchema `yaml:"schema"`
+		} `yaml:"content"`
+	} `yaml:"requestBody"`
+}
+
+type openAPIParameter struct {
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required"`
+	Schema   *openAPISchema `yaml:"schema"`
+}
+
+// openAPISchema supports the schema keywords the spec uses; others are
+// ignored rather than rejected.
+type openAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Format     string                    `yaml:"format"`
+	Required   []string                  `yaml:"required"`
+	Properties map[string]*openAPISchema `yaml:"properties"`
+	Items      *openAPISchema            `yaml:"items"`
+	Enum       []string                  `yaml:"enum"`
+	MinLength  *int                      `yaml:"minLength"`
+	MaxLength  *int                      `yaml:"maxLength"`
+}
+
+// validatedOperation is an operation with its $refs resolved.
+type validatedOperation struct {
+	name         string
+	params       []openAPIParameter
+	body         *openAPISchema
+	bodyRequired bool
+}
+
+// OpenAPIValidator checks requests to the operations of an OpenAPI document
+// before they reach the handlers, so every malformed request gets the same
+// 400 with machine-readable violations. Routes the document does not
+// describe pass through unchecked.
+type OpenAPIValidator struct {
+	operations map[string]*validatedOperation // "METHOD /echo/route/:param"
+}
+
+// NewOpenAPIValidator parses spec and maps its operations to Echo routes
+// under basePath, e.g. "/api/v1".
+func NewOpenAPIValidator(spec []byte, basePath string) (*OpenAPIValidator, error) {
+	var doc openAPIDoc
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("parse OpenAPI document: %w", err)
+	}
+
+	v := &OpenAPIValidator{operations: make(map[string]*validatedOperation)}
+	for path, methods := range doc.Paths {
+		route := basePath + echoRoute(path)
+		for method, op := range methods {
+			name := strings.ToUpper(method) + " " + route
+			vop := &validatedOperation{name: name}
+			for _, p := range op.Parameters {
+				schema, err := doc.resolve(p.Schema)
+				if err != nil {
+					return nil, fmt.Errorf("%s parameter %s: %w", name, p.Name, err)
+				}
+				p.Schema = schema
+				vop.params = append(vop.params, p)
+			}
+			if op.RequestBody != nil {
+				if media, ok := op.RequestBody.Content[echo.MIMEApplicationJSON]; ok {
+					schema, err := doc.resolve(media.Schema)
+					if err != nil {
+						return nil, fmt.Errorf("%s request body: %w", name, err)
+					}
+					vop.body = schema
+					vop.bodyRequired = op.RequestBody.Required
+				}
+			}
+			v.operations[name] = vop
+		}
+	}
+	return v, nil
+}
+
+// echoRoute turns an OpenAPI path template into an Echo route:
+// /summarize/status/{job_id} becomes /summarize/status/:job_id.
+func echoRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			segments[i] = ":" + s[1:len(s)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// resolve returns s with every $ref replaced by its component schema.
+func (d *openAPIDoc) resolve(s *openAPISchema) (*openAPISchema, error) {
+	return d.resolveDepth(s, 0)
+}
+
+func (d *openAPIDoc) resolveDepth(s *openAPISchema, depth int) (*openAPISchema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if depth > 32 {
+		return nil, fmt.Errorf("schema nesting too deep (recursive $ref?)")
+	}
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		target := d.Components.Schemas[name]
+		if !ok || target == nil {
+			return nil, fmt.Errorf("unresolvable $ref %q", s.Ref)
+		}
+		return d.resolveDepth(target, depth+1)
+	}
+
+	resolved := *s
+	if s.Properties != nil {
+		resolved.Properties = make(map[string]*openAPISchema, len(s.Properties))
+		for name, prop := range s.Properties {
+			p, err := d.resolveDepth(prop, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			resolved.Properties[name] = p
+		}
+	}
+	items, err := d.resolveDepth(s.Items, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Items = items
+	return &resolved, nil
+}
+
+// Middleware returns an Echo middleware that validates the request's path
+// and query parameters and JSON body. It must run after routing, e.g. on a
+// group, so c.Path() names the matched route.
+func (v *OpenAPIValidator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			op, ok := v.operations[c.Request().Method+" "+c.Path()]
+			if !ok {
+				return next(c)
+			}
+
+			violations, err := op.validate(c)
+			if err != nil {
+				return err
+			}
+			if len(violations) > 0 {
+				return apperrors.NewSchemaValidationContextError(violations, "middleware", "OpenAPIValidator", op.name)
+			}
+			return next(c)
+		}
+	}
+}
+
+// validate returns the request's violations. The body is read and then
+// restored for the handler; a read error, such as BodyLimit's 413, is
+// returned as is.
+func (op *validatedOperation) validate(c echo.Context) ([]apperrors.FieldViolation, error) {
+	var violations []apperrors.FieldViolation
+	add := func(field, reason string) {
+		violations = append(violations, apperrors.FieldViolation{Field: field, Reason: reason})
+	}
+
+	for _, p := range op.params {
+		var value string
+		switch p.In {
+		case "path":
+			value = c.Param(p.Name)
+		case "query":
+			value = c.QueryParam(p.Name)
+		default:
+			continue
+		}
+		field := p.In + "." + p.Name
+		if value == "" {
+			if p.Required {
+				add(field, reasonRequired)
+			}
+			continue
+		}
+		validateParam(value, p.Schema, field, add)
+	}
+
+	if op.body == nil {
+		return violations, nil
+	}
+	req := c.Request()
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.bodyRequired {
+			add("body", reasonRequired)
+		}
+		return violations, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		add("body", reasonInvalidJSON)
+		return violations, nil
+	}
+	validateValue(value, op.body, "body", add)
+	return violations, nil
+}
+
+// validateParam checks a parameter's raw string value. Parameters are
+// strings unless their schema says integer, number or boolean.
+func validateParam(raw string, s *openAPISchema, field string, add func(field, reason string)) {
+	if s == nil {
+		return
+	}
+	switch s.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			add(field, reasonInvalidType)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			add(field, reasonInvalidType)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(raw); err != nil {
+			add(field, reasonInvalidType)
+		}
+	default:
+		validateString(raw, s, field, add)
+	}
+}
+
+// validateValue checks a decoded JSON value against s.
+func validateValue(value any, s *openAPISchema, field string, add func(field, reason string)) {
+	if s == nil {
+		return
+	}
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			add(field, reasonInvalidType)
+			return
+		}
+		for _, name := range s.Required {
+			if _, present := obj[name]; !present {
+				add(field+"."+name, reasonRequired)
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			if v, present := obj[name]; present {
+				validateValue(v, s.Properties[name], field+"."+name, add)
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			add(field, reasonInvalidType)
+			return
+		}
+		for i, item := range arr {
+			validateValue(item, s.Items, field+"["+strconv.Itoa(i)+"]", add)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			add(field, reasonInvalidType)
+			return
+		}
+		validateString(str, s, field, add)
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			add(field, reasonInvalidType)
+			return
+		}
+		if _, err := n.Int64(); err != nil {
+			add(field, reasonInvalidType)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			add(field, reasonInvalidType)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			add(field, reasonInvalidType)
+		}
+	}
+}
+
+// validateString applies the string keywords: length, format and enum.
+func validateString(str string, s *openAPISchema, field string, add func(field, reason string)) {
+	n := utf8.RuneCountInString(str)
+	if s.MinLength != nil && n < *s.MinLength {
+		add(field, reasonTooShort)
+		return
+	}
+	if s.MaxLength != nil && n > *s.MaxLength {
+		add(field, reasonTooLong)
+		return
+	}
+	if s.Format == "uuid" {
+		if _, err := uuid.Parse(str); err != nil {
+			add(field, reasonInvalidFormat)
+			return
+		}
+	}
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+		add(field, reasonNotInEnum)
+	}
+}
@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pre-processor/spec"
+	apperrors "pre-processor/utils/errors"
+)
+
+const testOpenAPISpec = `
+openapi: 3.0.3
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Item"
+  /items/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: limit
+          in: query
+          schema:
+            type: integer
+components:
+  schemas:
+    Item:
+      type: object
+      required: [name, kind]
+      properties:
+        name:
+          type: string
+          minLength: 1
+          maxLength: 5
+        kind:
+          $ref: "#/components/schemas/Kind"
+        count:
+          type: integer
+        tags:
+          type: array
+          items:
+            type: string
+    Kind:
+      type: string
+      enum: [a, b]
+`
+
+// newValidatedEcho serves the test spec's routes behind the validator; the
+// handlers echo the body they receive.
+func newValidatedEcho(t *testing.T) *echo.Echo {
+	t.Helper()
+	v, err := NewOpenAPIValidator([]byte(testOpenAPISpec), "/api/v1")
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.HTTPErrorHandler = CustomHTTPErrorHandler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	api := e.Group("/api/v1", v.Middleware())
+	api.POST("/items", func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, body)
+	})
+	api.GET("/items/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	api.GET("/unlisted", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	return e
+}
+
+func violationsOf(t *testing.T, rec *httptest.ResponseRecorder) []apperrors.FieldViolation {
+	t.Helper()
+	var resp apperrors.SecureHTTPResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+	assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+	return resp.Error.Violations
+}
+
+func TestOpenAPIValidator_Body(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []apperrors.FieldViolation
+	}{
+		{
+			name: "missing body",
+			body: "",
+			want: []apperrors.FieldViolation{{Field: "body", Reason: "required"}},
+		},
+		{
+			name: "malformed JSON",
+			body: `{"name":`,
+			want: []apperrors.FieldViolation{{Field: "body", Reason: "invalid_json"}},
+		},
+		{
+			name: "not an object",
+			body: `["x"]`,
+			want: []apperrors.FieldViolation{{Field: "body", Reason: "invalid_type"}},
+		},
+		{
+			name: "every violation is listed",
+			body: `{"name":"","count":1.5,"tags":["ok",3]}`,
+			want: []apperrors.FieldViolation{
+				{Field: "body.kind", Reason: "required"},
+				{Field: "body.count", Reason: "invalid_type"},
+				{Field: "body.name", Reason: "too_short"},
+				{Field: "body.tags[1]", Reason: "invalid_type"},
+			},
+		},
+		{
+			name: "enum and max length",
+			body: `{"name":"toolong","kind":"c"}`,
+			want: []apperrors.FieldViolation{
+				{Field: "body.kind", Reason: "not_in_enum"},
+				{Field: "body.name", Reason: "too_long"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newValidatedEcho(t)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/items", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, tt.want, violationsOf(t, rec))
+		})
+	}
+}
+
+func TestOpenAPIValidator_ValidBodyReachesHandler(t *testing.T) {
+	e := newValidatedEcho(t)
+	body := `{"name":"日本語です","kind":"a","count":2,"tags":["x"],"extra":true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/items", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "lengths count characters; unknown fields are allowed")
+	assert.Equal(t, body, rec.Body.String(), "the handler gets the body the validator read")
+}
+
+func TestOpenAPIValidator_Parameters(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want []apperrors.FieldViolation
+	}{
+		{name: "valid", url: "/api/v1/items/7f8e2c1a-3b4d-4e5f-8a9b-0c1d2e3f4a5b?limit=10"},
+		{
+			name: "bad path and query",
+			url:  "/api/v1/items/not-a-uuid?limit=ten",
+			want: []apperrors.FieldViolation{
+				{Field: "path.id", Reason: "invalid_format"},
+				{Field: "query.limit", Reason: "invalid_type"},
+			},
+		},
+		{name: "undocumented route is not checked", url: "/api/v1/unlisted?limit=ten"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newValidatedEcho(t)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if tt.want == nil {
+				assert.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, tt.want, violationsOf(t, rec))
+		})
+	}
+}
+
+func TestNewOpenAPIValidator_RejectsBrokenDocuments(t *testing.T) {
+	_, err := NewOpenAPIValidator([]byte("paths: ["), "")
+	assert.Error(t, err)
+
+	_, err = NewOpenAPIValidator([]byte(`
+paths:
+  /x:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Missing"
+`), "")
+	assert.ErrorContains(t, err, "Missing")
+}
+
+func TestNewOpenAPIValidator_EmbeddedSpec(t *testing.T) {
+	v, err := NewOpenAPIValidator(spec.OpenAPI, "/api/v1")
+	require.NoError(t, err)
+
+	assert.Contains(t, v.operations, "POST /api/v1/summarize")
+	assert.Contains(t, v.operations, "GET /api/v1/summarize/status/:job_id")
+}
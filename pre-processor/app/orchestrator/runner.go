@@ -5,35 +5,74 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"time"
 )
 
+// Errors returned by JobGroup.Trigger.
+var (
+	ErrJobNotFound      = errors.New("job not found")
+	ErrJobDisabled      = errors.New("job is disabled")
+	ErrJobTriggerQueued = errors.New("job already has a manual run queued")
+)
+
 // JobConfig configures a job runner.
 type JobConfig struct {
 	Name            string
-	Interval        time.Duration
+	Interval        time.Duration // Used when Schedule is nil
+	Schedule        Schedule      // Cron schedule; overrides Interval when set
+	Jitter          time.Duration // Random delay in [0, Jitter) added to each scheduled run
+	Disabled        bool          // Registered for status/trigger reporting but never started
 	InitialBackoff  time.Duration
 	MaxBackoff      time.Duration
 	BackoffOnErrors []error // Errors that trigger backoff instead of logging
-	RunImmediately  bool    // Run once immediately before starting the ticker
+	RunImmediately  bool    // Run once immediately before waiting for the schedule
+}
+
+// JobStatus is a point-in-time view of one job for the jobs API.
+type JobStatus struct {
+	Name         string
+	Schedule     string
+	Enabled      bool
+	Running      bool
+	NextRun      time.Time // Zero when disabled or not yet scheduled
+	LastRun      time.Time // Zero before the first run
+	LastDuration time.Duration
+	LastError    string
 }
 
 // JobRunner manages the lifecycle of a single background job.
 type JobRunner struct {
-	config JobConfig
-	fn     func(ctx context.Context) error
-	logger *slog.Logger
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	config   JobConfig
+	schedule Schedule
+	fn       func(ctx context.Context) error
+	logger   *slog.Logger
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	trigger  chan struct{}
+
+	mu           sync.Mutex
+	running      bool
+	nextRun      time.Time
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastErr      error
 }
 
 // NewJobRunner creates a new job runner.
 func NewJobRunner(config JobConfig, fn func(ctx context.Context) error, logger *slog.Logger) *JobRunner {
+	schedule := config.Schedule
+	if schedule == nil {
+		schedule = Every(config.Interval)
+	}
 	return &JobRunner{
-		config: config,
-		fn:     fn,
-		logger: logger,
+		config:   config,
+		schedule: schedule,
+		fn:       fn,
+		logger:   logger,
+		trigger:  make(chan struct{}, 1),
 	}
 }
 
@@ -57,55 +96,153 @@ func (r *JobRunner) Stop() {
 	r.wg.Wait()
 }
 
+// Trigger queues a manual run that starts as soon as the job is idle. Runs
+// never overlap: a trigger during a run waits for it to finish, and only
+// one manual run can be queued at a time.
+func (r *JobRunner) Trigger() error {
+	if r.config.Disabled {
+		return fmt.Errorf("%w: %s", ErrJobDisabled, r.config.Name)
+	}
+	select {
+	case r.trigger <- struct{}{}:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrJobTriggerQueued, r.config.Name)
+	}
+}
+
+// Status reports the runner's schedule and last run.
+func (r *JobRunner) Status() JobStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st := JobStatus{
+		Name:         r.config.Name,
+		Schedule:     r.schedule.String(),
+		Enabled:      !r.config.Disabled,
+		Running:      r.running,
+		NextRun:      r.nextRun,
+		LastRun:      r.lastRun,
+		LastDuration: r.lastDuration,
+	}
+	if r.lastErr != nil {
+		st.LastError = r.lastErr.Error()
+	}
+	return st
+}
+
 // run is the main loop of the job runner. Each iteration recovers its own
 // panic via invoke — a top-level recover here would only catch the first
-// panic and then return, killing the ticker loop and silently stopping the
+// panic and then return, killing the loop and silently stopping the
 // periodic job for good.
 func (r *JobRunner) run(ctx context.Context) {
+	backoff := time.Duration(0)
+
 	// Run immediately if configured
 	if r.config.RunImmediately {
-		if err := r.invoke(ctx); err != nil {
-			r.logger.ErrorContext(ctx, "initial job run failed", "job", r.config.Name, "error", err)
-		}
+		backoff = r.handleResult(ctx, r.execute(ctx), backoff)
 	}
 
-	ticker := time.NewTicker(r.config.Interval)
-	defer ticker.Stop()
-
-	backoff := time.Duration(0)
-
 	for {
+		timer, next := r.nextTimer(backoff)
+		r.setNextRun(next)
+
 		select {
 		case <-ctx.Done():
+			stopTimer(timer)
 			r.logger.InfoContext(ctx, "job stopped", "job", r.config.Name)
 			return
-		case <-ticker.C:
-			if err := r.invoke(ctx); err != nil {
-				if r.shouldBackoff(err) {
-					backoff = r.nextBackoff(backoff)
-					r.logger.WarnContext(ctx, "job backing off",
-						"job", r.config.Name, "backoff", backoff, "error", err)
-					ticker.Reset(backoff)
-					continue
-				}
-				r.logger.ErrorContext(ctx, "job failed", "job", r.config.Name, "error", err)
-				// Non-backoff failure must not leave the ticker stuck on a
-				// prior backoff interval — re-evaluate to the configured cadence.
-				if backoff > 0 {
-					r.logger.InfoContext(ctx, "backoff cleared after non-backoff error, resuming normal interval",
-						"job", r.config.Name)
-					backoff = 0
-					ticker.Reset(r.config.Interval)
-				}
-			} else if backoff > 0 {
-				// Success: reset backoff if active
-				r.logger.InfoContext(ctx, "backoff cleared, resuming normal interval",
-					"job", r.config.Name)
-				backoff = 0
-				ticker.Reset(r.config.Interval)
-			}
+		case <-timerC(timer):
+		case <-r.trigger:
+			stopTimer(timer)
+			r.logger.InfoContext(ctx, "manual job run triggered", "job", r.config.Name)
+		}
+
+		backoff = r.handleResult(ctx, r.execute(ctx), backoff)
+	}
+}
+
+// nextTimer arms a timer for the next activation: the backoff delay while
+// backing off, otherwise the schedule's next time plus jitter. It returns a
+// nil timer when the schedule never fires again, leaving only manual
+// triggers.
+func (r *JobRunner) nextTimer(backoff time.Duration) (*time.Timer, time.Time) {
+	now := time.Now()
+	if backoff > 0 {
+		return time.NewTimer(backoff), now.Add(backoff)
+	}
+	next := r.schedule.Next(now)
+	if next.IsZero() {
+		return nil, time.Time{}
+	}
+	if r.config.Jitter > 0 {
+		next = next.Add(rand.N(r.config.Jitter))
+	}
+	return time.NewTimer(next.Sub(now)), next
+}
+
+func timerC(t *time.Timer) <-chan time.Time {
+	if t == nil {
+		return nil
+	}
+	return t.C
+}
+
+func stopTimer(t *time.Timer) {
+	if t != nil {
+		t.Stop()
+	}
+}
+
+// execute runs the job once and records its outcome for Status.
+func (r *JobRunner) execute(ctx context.Context) error {
+	start := time.Now()
+	r.mu.Lock()
+	r.running = true
+	r.nextRun = time.Time{}
+	r.mu.Unlock()
+
+	err := r.invoke(ctx)
+
+	r.mu.Lock()
+	r.running = false
+	r.lastRun = start
+	r.lastDuration = time.Since(start)
+	r.lastErr = err
+	r.mu.Unlock()
+	return err
+}
+
+// handleResult logs a run's outcome and returns the backoff to apply
+// before the next run (zero to follow the schedule).
+func (r *JobRunner) handleResult(ctx context.Context, err error, backoff time.Duration) time.Duration {
+	if err != nil {
+		if r.shouldBackoff(err) {
+			backoff = r.nextBackoff(backoff)
+			r.logger.WarnContext(ctx, "job backing off",
+				"job", r.config.Name, "backoff", backoff, "error", err)
+			return backoff
 		}
+		r.logger.ErrorContext(ctx, "job failed", "job", r.config.Name, "error", err)
+		// Non-backoff failure must not leave the job stuck on a prior
+		// backoff delay — return to the configured schedule.
+		if backoff > 0 {
+			r.logger.InfoContext(ctx, "backoff cleared after non-backoff error, resuming schedule",
+				"job", r.config.Name)
+		}
+		return 0
+	}
+	if backoff > 0 {
+		r.logger.InfoContext(ctx, "backoff cleared, resuming schedule",
+			"job", r.config.Name)
 	}
+	return 0
+}
+
+func (r *JobRunner) setNextRun(t time.Time) {
+	r.mu.Lock()
+	r.nextRun = t
+	r.mu.Unlock()
 }
 
 // invoke calls r.fn, recovering any panic so a single bad iteration reports
@@ -167,15 +304,53 @@ func NewJobGroup(ctx context.Context, logger *slog.Logger) *JobGroup {
 	return &JobGroup{ctx: ctx, logger: logger}
 }
 
-// Add adds a job runner to the group and starts it immediately.
+// Add adds a job runner to the group and starts it immediately. A runner
+// configured as Disabled is registered, so it shows up in Status, but is
+// not started.
 func (g *JobGroup) Add(runner *JobRunner) {
 	g.mu.Lock()
 	g.runners = append(g.runners, runner)
 	g.mu.Unlock()
-	g.logger.InfoContext(g.ctx, "starting job", "job", runner.config.Name)
+	if runner.config.Disabled {
+		g.logger.WarnContext(g.ctx, "job disabled by configuration, not starting", "job", runner.config.Name)
+		return
+	}
+	g.logger.InfoContext(g.ctx, "starting job",
+		"job", runner.config.Name,
+		"schedule", runner.schedule.String(),
+		"jitter", runner.config.Jitter)
 	runner.Start(g.ctx)
 }
 
+// Status returns the status of every registered job in registration order.
+func (g *JobGroup) Status() []JobStatus {
+	g.mu.Lock()
+	runners := slices.Clone(g.runners)
+	g.mu.Unlock()
+
+	statuses := make([]JobStatus, len(runners))
+	for i, r := range runners {
+		statuses[i] = r.Status()
+	}
+	return statuses
+}
+
+// Trigger queues a manual run of the named job.
+func (g *JobGroup) Trigger(name string) error {
+	g.mu.Lock()
+	idx := slices.IndexFunc(g.runners, func(r *JobRunner) bool { return r.config.Name == name })
+	var runner *JobRunner
+	if idx >= 0 {
+		runner = g.runners[idx]
+	}
+	g.mu.Unlock()
+
+	if runner == nil {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return runner.Trigger()
+}
+
 // StopAll stops all jobs in the group and waits for them to finish.
 func (g *JobGroup) StopAll() {
 	g.mu.Lock()
@@ -229,3 +229,95 @@ func TestJobGroup(t *testing.T) {
 		require.Greater(t, count2.Load(), int32(0))
 	})
 }
+
+func TestJobRunner_Schedule(t *testing.T) {
+	t.Run("should follow the schedule instead of the interval", func(t *testing.T) {
+		var callCount atomic.Int32
+		runner := NewJobRunner(JobConfig{
+			Name:     "scheduled-job",
+			Interval: 1 * time.Hour,
+			Schedule: Every(10 * time.Millisecond),
+		}, func(ctx context.Context) error {
+			callCount.Add(1)
+			return nil
+		}, testLogger())
+
+		runner.Start(context.Background())
+		time.Sleep(50 * time.Millisecond)
+		runner.Stop()
+
+		assert.Greater(t, callCount.Load(), int32(1))
+	})
+}
+
+func TestJobRunner_TriggerAndStatus(t *testing.T) {
+	t.Run("should run on manual trigger and report the run", func(t *testing.T) {
+		ran := make(chan struct{}, 1)
+		runner := NewJobRunner(JobConfig{
+			Name:     "manual-job",
+			Interval: 1 * time.Hour,
+			Jitter:   time.Minute,
+		}, func(ctx context.Context) error {
+			ran <- struct{}{}
+			return errors.New("boom")
+		}, testLogger())
+
+		runner.Start(context.Background())
+		defer runner.Stop()
+
+		require.Eventually(t, func() bool { return !runner.Status().NextRun.IsZero() },
+			time.Second, 5*time.Millisecond)
+		st := runner.Status()
+		assert.True(t, st.Enabled)
+		assert.Equal(t, "@every 1h0m0s", st.Schedule)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), st.NextRun, time.Minute+time.Second)
+		assert.True(t, st.LastRun.IsZero())
+
+		require.NoError(t, runner.Trigger())
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("manual trigger did not run the job")
+		}
+
+		require.Eventually(t, func() bool { return !runner.Status().LastRun.IsZero() && !runner.Status().Running },
+			time.Second, 5*time.Millisecond)
+		assert.Equal(t, "boom", runner.Status().LastError)
+	})
+
+	t.Run("should reject a second queued trigger", func(t *testing.T) {
+		runner := NewJobRunner(JobConfig{Name: "queued-job", Interval: time.Hour},
+			func(ctx context.Context) error { return nil }, testLogger())
+
+		// Not started: the first trigger stays queued.
+		require.NoError(t, runner.Trigger())
+		assert.ErrorIs(t, runner.Trigger(), ErrJobTriggerQueued)
+	})
+}
+
+func TestJobGroup_DisabledAndTrigger(t *testing.T) {
+	var enabledCalls, disabledCalls atomic.Int32
+
+	group := NewJobGroup(context.Background(), testLogger())
+	group.Add(NewJobRunner(JobConfig{Name: "enabled", Interval: time.Hour},
+		func(ctx context.Context) error { enabledCalls.Add(1); return nil }, testLogger()))
+	group.Add(NewJobRunner(JobConfig{Name: "disabled", Interval: 10 * time.Millisecond, Disabled: true, RunImmediately: true},
+		func(ctx context.Context) error { disabledCalls.Add(1); return nil }, testLogger()))
+	defer group.StopAll()
+
+	require.NoError(t, group.Trigger("enabled"))
+	assert.ErrorIs(t, group.Trigger("disabled"), ErrJobDisabled)
+	assert.ErrorIs(t, group.Trigger("missing"), ErrJobNotFound)
+
+	require.Eventually(t, func() bool { return enabledCalls.Load() == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	assert.Zero(t, disabledCalls.Load(), "disabled jobs never run")
+
+	statuses := group.Status()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "enabled", statuses[0].Name)
+	assert.True(t, statuses[0].Enabled)
+	assert.Equal(t, "disabled", statuses[1].Name)
+	assert.False(t, statuses[1].Enabled)
+	assert.True(t, statuses[1].NextRun.IsZero())
+}
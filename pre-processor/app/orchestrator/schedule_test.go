@@ -0,0 +1,74 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// Friday 2026-01-02 10:07:30 UTC
+	base := time.Date(2026, 1, 2, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 2, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 2, 10, 15, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC)},
+		{"30 3 * * *", time.Date(2026, 1, 3, 3, 30, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2026, 1, 2, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * *", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 3 *", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"5,10 10 * * *", time.Date(2026, 1, 2, 10, 10, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches (Monday 5th beats the 15th).
+		{"0 0 15 * 1", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"@every 10s", base.Add(10 * time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(base))
+			assert.Equal(t, tt.expr, s.String())
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@fortnightly",
+		"@every",
+		"@every -5s",
+		"@every soon",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseSchedule(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseSchedule_NeverMatches(t *testing.T) {
+	s, err := ParseSchedule("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+}
@@ -40,11 +40,13 @@ var (
 	rateLimitHost     = "http://news-creator:11434"
 	rateLimitInterval = time.Duration(0)
 
-	// knownPlaceholders are placeholder summaries saved when content is too short/long.
+	// knownPlaceholders are placeholder summaries saved when content is too short/long
+	// or the article is a media-only (podcast/video) entry.
 	// These must NOT be quality-checked: deleting them causes an infinite summarize→delete loop.
 	knownPlaceholders = []string{
 		"本文が短すぎるため要約できませんでした。",
 		"本文が長すぎるため要約できませんでした。",
+		"音声・動画のみの記事のため要約していません。",
 	}
 )
 
@@ -81,11 +83,11 @@ func Configure(cfg *config.Config) {
 	}
 }
 
-// isPlaceholderSummary returns true if the summary is a known placeholder message.
+// IsPlaceholderSummary returns true if the summary is a known placeholder message.
 // Placeholder summaries are generated when article content is too short or too long
 // for summarization. Quality-checking these would always yield low scores, causing
 // deletion and re-summarization in an infinite loop.
-func isPlaceholderSummary(summary string) bool {
+func IsPlaceholderSummary(summary string) bool {
 	for _, placeholder := range knownPlaceholders {
 		if summary == placeholder {
 			return true
@@ -392,7 +394,7 @@ func RemoveLowScoreSummary(ctx context.Context, summaryRepo repository.SummaryRe
 	logger.Logger.InfoContext(ctx, "Removing low quality summary",
 		"articleID", articleWithSummary.ArticleID,
 		"score", score.Overall,
-		"threshold", lowScoreThreshold)
+		"threshold", articleWithSummary.QualityStrictness.Threshold(lowScoreThreshold))
 
 	// Remove the summary via repository (works with both DB and API modes)
 	err := summaryRepo.Delete(ctx, articleWithSummary.ArticleID)
@@ -472,13 +474,14 @@ func scoreSummaryWithRetry(ctx context.Context, prompt string, maxRetries int) (
 }
 
 // JudgeArticleQuality judges the quality of an article's summary and takes action if the score is low.
+// The acceptable score is lowScoreThreshold adjusted by the article's feed strictness.
 func JudgeArticleQuality(ctx context.Context, summaryRepo repository.SummaryRepository, articleRepo repository.ArticleRepository, articleWithSummary *driver.ArticleWithSummary) error {
 	if articleWithSummary == nil || articleWithSummary.ArticleID == "" {
 		return errors.New("article with summary is invalid")
 	}
 
 	// プレースホルダーサマリーはLLM評価不要 - 削除すると無限ループになる
-	if isPlaceholderSummary(articleWithSummary.SummaryJapanese) {
+	if IsPlaceholderSummary(articleWithSummary.SummaryJapanese) {
 		logger.Logger.InfoContext(ctx, "Skipping quality check: placeholder summary",
 			"articleID", articleWithSummary.ArticleID)
 		return nil
@@ -517,7 +520,7 @@ func JudgeArticleQuality(ctx context.Context, summaryRepo repository.SummaryRepo
 	}
 
 	// If score is too low, remove the summary (but keep the article)
-	if score.Overall < lowScoreThreshold {
+	if score.Overall < articleWithSummary.QualityStrictness.Threshold(lowScoreThreshold) {
 		return RemoveLowScoreSummary(ctx, summaryRepo, articleRepo, articleWithSummary, score)
 	}
 
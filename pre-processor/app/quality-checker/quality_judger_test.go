@@ -915,7 +915,7 @@ func TestMaxQualityCheckContentLengthConstant(t *testing.T) {
 	assert.Greater(t, maxQualityCheckContentLength, 0, "maxQualityCheckContentLength should be positive")
 }
 
-// TestIsPlaceholderSummary tests the isPlaceholderSummary function
+// TestIsPlaceholderSummary tests the IsPlaceholderSummary function
 func TestIsPlaceholderSummary(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -932,6 +932,11 @@ func TestIsPlaceholderSummary(t *testing.T) {
 			summary:  "本文が長すぎるため要約できませんでした。",
 			expected: true,
 		},
+		{
+			name:     "placeholder_media_only",
+			summary:  "音声・動画のみの記事のため要約していません。",
+			expected: true,
+		},
 		{
 			name:     "normal_summary",
 			summary:  "この記事はAIの最新動向について述べている。",
@@ -956,7 +961,7 @@ func TestIsPlaceholderSummary(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := isPlaceholderSummary(tc.summary)
+			result := IsPlaceholderSummary(tc.summary)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
@@ -1027,6 +1032,36 @@ func TestJudgeArticleQualityLowScoreStillDeletes(t *testing.T) {
 	assert.NotContains(t, err.Error(), "failed to connect to news-creator service", "Error should not be about connection")
 }
 
+// TestJudgeArticleQualityHonoursFeedStrictness checks that a score of 6 is
+// kept for a lenient feed and deleted for a standard one.
+func TestJudgeArticleQualityHonoursFeedStrictness(t *testing.T) {
+	withMockTransport(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(ollamaResponse{Response: "<score>6</score>", Done: true})
+	}))
+
+	originalURL := qualityCheckerAPIURL
+	qualityCheckerAPIURL = testQualityCheckerURL
+	t.Cleanup(func() { qualityCheckerAPIURL = originalURL })
+
+	for strictness, wantDelete := range map[domain.QualityStrictness]bool{
+		domain.QualityStrictnessLenient:  false,
+		domain.QualityStrictnessStandard: true,
+		"":                               true,
+	} {
+		repo := &deleteTrackingSummaryRepo{}
+		article := &driver.ArticleWithSummary{
+			ArticleID:         "test-article-strictness",
+			Content:           "Test content",
+			SummaryJapanese:   "テスト要約",
+			QualityStrictness: strictness,
+		}
+
+		require.NoError(t, JudgeArticleQuality(context.Background(), repo, nil, article))
+		assert.Equal(t, wantDelete, repo.deleteCalled, "strictness %q", strictness)
+	}
+}
+
 // deleteTrackingSummaryRepo is a minimal repository.SummaryRepository stub
 // that records whether Delete was invoked, without needing a real database.
 type deleteTrackingSummaryRepo struct {
@@ -0,0 +1,98 @@
+// Package repository: article_media_repository.go stores the podcast and
+// video attachments of articles in pre-processor-db. Article sync records
+// them; the summarize worker reads the media-only flag to skip articles
+// whose only substance is their media.
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pre-processor/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ArticleMediaRepository handles article_media persistence.
+type ArticleMediaRepository interface {
+	// Save records an article's media, replacing what a previous sync stored.
+	Save(ctx context.Context, media *domain.ArticleMedia) error
+	// IsMediaOnly reports whether the article was recorded as media-only.
+	// Articles without a row are not.
+	IsMediaOnly(ctx context.Context, articleID string) (bool, error)
+}
+
+type articleMediaRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewArticleMediaRepository creates a new article media repository.
+func NewArticleMediaRepository(db *pgxpool.Pool, logger *slog.Logger) ArticleMediaRepository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &articleMediaRepository{db: db, logger: logger}
+}
+
+const saveArticleMediaQuery = `
+		INSERT INTO article_media (article_id, media, media_only)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (article_id) DO UPDATE
+		SET media = EXCLUDED.media, media_only = EXCLUDED.media_only, updated_at = NOW()
+	`
+
+const isMediaOnlyArticleQuery = `
+		SELECT media_only FROM article_media WHERE article_id = $1
+	`
+
+// Save records an article's media.
+func (r *articleMediaRepository) Save(ctx context.Context, media *domain.ArticleMedia) error {
+	if media == nil || media.ArticleID == "" {
+		return fmt.Errorf("article ID cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return fmt.Errorf("database connection is nil")
+	}
+
+	items := media.Media
+	if items == nil {
+		items = []domain.MediaEnclosure{}
+	}
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to encode article media: %w", err)
+	}
+	if _, err := r.db.Exec(ctx, saveArticleMediaQuery, media.ArticleID, encoded, media.MediaOnly); err != nil {
+		r.logger.ErrorContext(ctx, "failed to save article media", "article_id", media.ArticleID, "error", err)
+		return fmt.Errorf("failed to save article media: %w", err)
+	}
+	return nil
+}
+
+// IsMediaOnly reports whether the article was recorded as media-only.
+func (r *articleMediaRepository) IsMediaOnly(ctx context.Context, articleID string) (bool, error) {
+	if articleID == "" {
+		return false, fmt.Errorf("article ID cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return false, fmt.Errorf("database connection is nil")
+	}
+
+	var mediaOnly bool
+	err := r.db.QueryRow(ctx, isMediaOnlyArticleQuery, articleID).Scan(&mediaOnly)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to read article media", "article_id", articleID, "error", err)
+		return false, fmt.Errorf("failed to read article media: %w", err)
+	}
+	return mediaOnly, nil
+}
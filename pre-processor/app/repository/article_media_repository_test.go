@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"pre-processor/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleMediaRepository_InterfaceCompliance(t *testing.T) {
+	repo := NewArticleMediaRepository(nil, testArticleThumbnailLogger())
+	assert.NotNil(t, repo)
+}
+
+func TestArticleMediaRepository_RejectsEmptyArticleID(t *testing.T) {
+	repo := NewArticleMediaRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	assert.Error(t, repo.Save(ctx, nil))
+	assert.Error(t, repo.Save(ctx, &domain.ArticleMedia{}))
+	_, err := repo.IsMediaOnly(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestArticleMediaRepository_RejectsNilPool(t *testing.T) {
+	repo := NewArticleMediaRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	assert.Error(t, repo.Save(ctx, &domain.ArticleMedia{ArticleID: "article-1", MediaOnly: true}))
+	_, err := repo.IsMediaOnly(ctx, "article-1")
+	assert.Error(t, err)
+}
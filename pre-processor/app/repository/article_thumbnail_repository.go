@@ -0,0 +1,174 @@
+// Package repository: article_thumbnail_repository.go implements the
+// pre-processor-db queue for lead-image thumbnails. Article sync enqueues one
+// row per article; the thumbnail job dequeues pending rows (and rows whose
+// worker crashed mid-run), then records the stored object URLs or the error.
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pre-processor/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ArticleThumbnailRepository handles article_thumbnails persistence.
+type ArticleThumbnailRepository interface {
+	// Enqueue records the lead image for an article. Existing rows are left
+	// untouched so re-synced articles are not reprocessed.
+	Enqueue(ctx context.Context, articleID, sourceURL string) error
+	// DequeuePending atomically claims pending rows (and running rows idle
+	// longer than staleAfter) by transitioning them to running.
+	DequeuePending(ctx context.Context, limit int, staleAfter time.Duration) ([]*domain.ArticleThumbnail, error)
+	// MarkCompleted stores the per-size object URLs.
+	MarkCompleted(ctx context.Context, articleID string, thumbnails map[string]string) error
+	// MarkFailed records a failed attempt. The row returns to pending until
+	// maxRetries attempts have failed, then becomes failed.
+	MarkFailed(ctx context.Context, articleID string, errorMessage string, maxRetries int) error
+}
+
+type articleThumbnailRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewArticleThumbnailRepository creates a new article thumbnail repository.
+func NewArticleThumbnailRepository(db *pgxpool.Pool, logger *slog.Logger) ArticleThumbnailRepository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &articleThumbnailRepository{db: db, logger: logger}
+}
+
+const enqueueArticleThumbnailQuery = `
+		INSERT INTO article_thumbnails (article_id, source_url)
+		VALUES ($1, $2)
+		ON CONFLICT (article_id) DO NOTHING
+	`
+
+const dequeueArticleThumbnailsQuery = `
+		UPDATE article_thumbnails
+		SET status = 'running', updated_at = NOW()
+		WHERE article_id IN (
+			SELECT article_id FROM article_thumbnails
+			WHERE status = 'pending'
+			   OR (status = 'running' AND updated_at < $2)
+			ORDER BY updated_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING article_id, source_url, status, thumbnails, error_message,
+		          retry_count, created_at, updated_at
+	`
+
+const completeArticleThumbnailQuery = `
+		UPDATE article_thumbnails
+		SET status = 'completed', thumbnails = $2, error_message = NULL, updated_at = NOW()
+		WHERE article_id = $1
+	`
+
+const failArticleThumbnailQuery = `
+		UPDATE article_thumbnails
+		SET retry_count = retry_count + 1,
+		    status = CASE WHEN retry_count + 1 >= $3 THEN 'failed' ELSE 'pending' END,
+		    error_message = $2,
+		    updated_at = NOW()
+		WHERE article_id = $1
+	`
+
+// Enqueue records the lead image for an article.
+func (r *articleThumbnailRepository) Enqueue(ctx context.Context, articleID, sourceURL string) error {
+	if articleID == "" || sourceURL == "" {
+		return fmt.Errorf("article ID and source URL cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return fmt.Errorf("database connection is nil")
+	}
+
+	if _, err := r.db.Exec(ctx, enqueueArticleThumbnailQuery, articleID, sourceURL); err != nil {
+		r.logger.ErrorContext(ctx, "failed to enqueue article thumbnail", "article_id", articleID, "error", err)
+		return fmt.Errorf("failed to enqueue article thumbnail: %w", err)
+	}
+	return nil
+}
+
+// DequeuePending claims up to limit rows for processing.
+func (r *articleThumbnailRepository) DequeuePending(ctx context.Context, limit int, staleAfter time.Duration) ([]*domain.ArticleThumbnail, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	rows, err := r.db.Query(ctx, dequeueArticleThumbnailsQuery, limit, time.Now().Add(-staleAfter))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to dequeue article thumbnails", "error", err)
+		return nil, fmt.Errorf("failed to dequeue article thumbnails: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*domain.ArticleThumbnail, 0, limit)
+	for rows.Next() {
+		var item domain.ArticleThumbnail
+		var thumbnails []byte
+		if err := rows.Scan(
+			&item.ArticleID,
+			&item.SourceURL,
+			&item.Status,
+			&thumbnails,
+			&item.ErrorMessage,
+			&item.RetryCount,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan article thumbnail row: %w", err)
+		}
+		if err := json.Unmarshal(thumbnails, &item.Thumbnails); err != nil {
+			return nil, fmt.Errorf("failed to decode thumbnails for %s: %w", item.ArticleID, err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate article thumbnail rows: %w", err)
+	}
+	return items, nil
+}
+
+// MarkCompleted stores the per-size object URLs.
+func (r *articleThumbnailRepository) MarkCompleted(ctx context.Context, articleID string, thumbnails map[string]string) error {
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return fmt.Errorf("database connection is nil")
+	}
+
+	encoded, err := json.Marshal(thumbnails)
+	if err != nil {
+		return fmt.Errorf("failed to encode thumbnails: %w", err)
+	}
+	if _, err := r.db.Exec(ctx, completeArticleThumbnailQuery, articleID, encoded); err != nil {
+		r.logger.ErrorContext(ctx, "failed to mark article thumbnail completed", "article_id", articleID, "error", err)
+		return fmt.Errorf("failed to mark article thumbnail completed: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt.
+func (r *articleThumbnailRepository) MarkFailed(ctx context.Context, articleID string, errorMessage string, maxRetries int) error {
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return fmt.Errorf("database connection is nil")
+	}
+
+	if _, err := r.db.Exec(ctx, failArticleThumbnailQuery, articleID, errorMessage, maxRetries); err != nil {
+		r.logger.ErrorContext(ctx, "failed to mark article thumbnail failed", "article_id", articleID, "error", err)
+		return fmt.Errorf("failed to mark article thumbnail failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testArticleThumbnailLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+}
+
+func TestArticleThumbnailRepository_InterfaceCompliance(t *testing.T) {
+	repo := NewArticleThumbnailRepository(nil, testArticleThumbnailLogger())
+	assert.NotNil(t, repo)
+}
+
+func TestArticleThumbnailRepository_Enqueue_RejectsEmptyInput(t *testing.T) {
+	repo := NewArticleThumbnailRepository(nil, testArticleThumbnailLogger())
+	assert.Error(t, repo.Enqueue(context.Background(), "", "https://example.com/a.jpg"))
+	assert.Error(t, repo.Enqueue(context.Background(), "article-1", ""))
+}
+
+func TestArticleThumbnailRepository_RejectsNilPool(t *testing.T) {
+	repo := NewArticleThumbnailRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	assert.Error(t, repo.Enqueue(ctx, "article-1", "https://example.com/a.jpg"))
+	_, err := repo.DequeuePending(ctx, 10, time.Minute)
+	assert.Error(t, err)
+	assert.Error(t, repo.MarkCompleted(ctx, "article-1", map[string]string{"small": "/thumbnails/a.jpg"}))
+	assert.Error(t, repo.MarkFailed(ctx, "article-1", "boom", 3))
+}
+
+func TestArticleThumbnailRepository_DequeuePending_RejectsNonPositiveLimit(t *testing.T) {
+	repo := NewArticleThumbnailRepository(nil, testArticleThumbnailLogger())
+	_, err := repo.DequeuePending(context.Background(), 0, time.Minute)
+	assert.Error(t, err)
+}
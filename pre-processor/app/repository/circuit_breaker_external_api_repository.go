@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"pre-processor/domain"
+	"pre-processor/utils"
+)
+
+// circuitBreakerExternalAPIRepository guards the news-creator calls of an
+// ExternalAPIRepository with a circuit breaker. While the circuit is open,
+// summarize calls fail fast with domain.ErrNewsCreatorUnavailable instead
+// of each waiting out NEWS_CREATOR_TIMEOUT against a dead service. Calls
+// that do not go to news-creator pass straight through.
+type circuitBreakerExternalAPIRepository struct {
+	ExternalAPIRepository
+	breaker *utils.CircuitBreaker
+}
+
+// NewCircuitBreakerExternalAPIRepository wraps inner so SummarizeArticle and
+// StreamSummarizeArticle go through breaker.
+func NewCircuitBreakerExternalAPIRepository(inner ExternalAPIRepository, breaker *utils.CircuitBreaker) ExternalAPIRepository {
+	return &circuitBreakerExternalAPIRepository{ExternalAPIRepository: inner, breaker: breaker}
+}
+
+// SummarizeArticle summarizes an article unless the circuit is open.
+func (r *circuitBreakerExternalAPIRepository) SummarizeArticle(ctx context.Context, article *domain.Article, priority string) (*domain.SummarizedContent, error) {
+	if err := r.breaker.Allow(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrNewsCreatorUnavailable, err)
+	}
+	summary, err := r.ExternalAPIRepository.SummarizeArticle(ctx, article, priority)
+	r.record(ctx, err)
+	return summary, err
+}
+
+// StreamSummarizeArticle starts a summary stream unless the circuit is open.
+// Only establishing the stream is counted; errors mid-stream are not.
+func (r *circuitBreakerExternalAPIRepository) StreamSummarizeArticle(ctx context.Context, article *domain.Article, priority string) (io.ReadCloser, error) {
+	if err := r.breaker.Allow(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrNewsCreatorUnavailable, err)
+	}
+	body, err := r.ExternalAPIRepository.StreamSummarizeArticle(ctx, article, priority)
+	r.record(ctx, err)
+	return body, err
+}
+
+// record classifies a call's outcome. Content rejections, backpressure and
+// caller cancellation prove news-creator answered (or say nothing about
+// it), so they never trip the circuit.
+func (r *circuitBreakerExternalAPIRepository) record(ctx context.Context, err error) {
+	switch {
+	case err == nil:
+		r.breaker.RecordSuccess()
+	case errors.Is(err, domain.ErrContentTooShort),
+		errors.Is(err, domain.ErrContentTooLong),
+		errors.Is(err, domain.ErrContentNotProcessable),
+		errors.Is(err, domain.ErrServiceOverloaded),
+		errors.Is(err, domain.ErrUpstreamBusy),
+		ctx.Err() != nil:
+		r.breaker.RecordNeutral()
+	default:
+		r.breaker.RecordFailure()
+	}
+}
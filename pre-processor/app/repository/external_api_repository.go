@@ -86,6 +86,7 @@ func (r *externalAPIRepository) SummarizeArticle(ctx context.Context, article *d
 	summarizedContent := &domain.SummarizedContent{
 		ArticleID:       driverSummary.ArticleID,
 		SummaryJapanese: driverSummary.SummaryJapanese,
+		Language:        driverSummary.Language,
 	}
 
 	r.logger.InfoContext(ctx, "article summarized successfully", "article_id", article.ID)
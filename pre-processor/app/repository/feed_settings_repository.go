@@ -0,0 +1,210 @@
+// Package repository: feed_settings_repository.go implements the
+// pre-processor-db store for per-feed processing overrides. The table is
+// small (one row per customized feed), so callers load it whole and resolve
+// settings in memory.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pre-processor/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FeedSettingsRepository handles feed_settings persistence.
+type FeedSettingsRepository interface {
+	// Get returns the overrides for a feed, or nil if it has none.
+	Get(ctx context.Context, feedID string) (*domain.FeedSettings, error)
+	// List returns the overrides of every customized feed.
+	List(ctx context.Context) ([]*domain.FeedSettings, error)
+	// Upsert replaces the overrides for a feed and returns the stored row.
+	Upsert(ctx context.Context, settings *domain.FeedSettings) (*domain.FeedSettings, error)
+	// Delete removes a feed's overrides. It reports false if there were none.
+	Delete(ctx context.Context, feedID string) (bool, error)
+}
+
+type feedSettingsRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewFeedSettingsRepository creates a new feed settings repository.
+func NewFeedSettingsRepository(db *pgxpool.Pool, logger *slog.Logger) FeedSettingsRepository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &feedSettingsRepository{db: db, logger: logger}
+}
+
+const feedSettingsColumns = `feed_id, batch_size, summarization_enabled, quality_strictness,
+		       language_override, fetch_interval_seconds, created_at, updated_at`
+
+const getFeedSettingsQuery = `
+		SELECT ` + feedSettingsColumns + `
+		FROM feed_settings
+		WHERE feed_id = $1
+	`
+
+const listFeedSettingsQuery = `
+		SELECT ` + feedSettingsColumns + `
+		FROM feed_settings
+		ORDER BY feed_id
+	`
+
+const upsertFeedSettingsQuery = `
+		INSERT INTO feed_settings (feed_id, batch_size, summarization_enabled, quality_strictness,
+		                           language_override, fetch_interval_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (feed_id) DO UPDATE
+		SET batch_size = EXCLUDED.batch_size,
+		    summarization_enabled = EXCLUDED.summarization_enabled,
+		    quality_strictness = EXCLUDED.quality_strictness,
+		    language_override = EXCLUDED.language_override,
+		    fetch_interval_seconds = EXCLUDED.fetch_interval_seconds,
+		    updated_at = NOW()
+		RETURNING ` + feedSettingsColumns
+
+const deleteFeedSettingsQuery = `
+		DELETE FROM feed_settings WHERE feed_id = $1
+	`
+
+// Get returns the overrides for a feed, or nil if it has none.
+func (r *feedSettingsRepository) Get(ctx context.Context, feedID string) (*domain.FeedSettings, error) {
+	if feedID == "" {
+		return nil, fmt.Errorf("feed ID cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	settings, err := scanFeedSettings(r.db.QueryRow(ctx, getFeedSettingsQuery, feedID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to get feed settings", "feed_id", feedID, "error", err)
+		return nil, fmt.Errorf("failed to get feed settings: %w", err)
+	}
+	return settings, nil
+}
+
+// List returns the overrides of every customized feed.
+func (r *feedSettingsRepository) List(ctx context.Context) ([]*domain.FeedSettings, error) {
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	rows, err := r.db.Query(ctx, listFeedSettingsQuery)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to list feed settings", "error", err)
+		return nil, fmt.Errorf("failed to list feed settings: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.FeedSettings
+	for rows.Next() {
+		settings, err := scanFeedSettings(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed settings row: %w", err)
+		}
+		items = append(items, settings)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feed settings rows: %w", err)
+	}
+	return items, nil
+}
+
+// Upsert replaces the overrides for a feed.
+func (r *feedSettingsRepository) Upsert(ctx context.Context, settings *domain.FeedSettings) (*domain.FeedSettings, error) {
+	if settings == nil {
+		return nil, fmt.Errorf("feed settings cannot be nil")
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	var strictness *string
+	if settings.QualityStrictness != nil {
+		s := string(*settings.QualityStrictness)
+		strictness = &s
+	}
+	var intervalSeconds *int
+	if settings.FetchInterval != nil {
+		secs := int(settings.FetchInterval.Seconds())
+		intervalSeconds = &secs
+	}
+
+	stored, err := scanFeedSettings(r.db.QueryRow(ctx, upsertFeedSettingsQuery,
+		settings.FeedID,
+		settings.BatchSize,
+		settings.SummarizationEnabled,
+		strictness,
+		settings.LanguageOverride,
+		intervalSeconds,
+	))
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to upsert feed settings", "feed_id", settings.FeedID, "error", err)
+		return nil, fmt.Errorf("failed to upsert feed settings: %w", err)
+	}
+	return stored, nil
+}
+
+// Delete removes a feed's overrides.
+func (r *feedSettingsRepository) Delete(ctx context.Context, feedID string) (bool, error) {
+	if feedID == "" {
+		return false, fmt.Errorf("feed ID cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return false, fmt.Errorf("database connection is nil")
+	}
+
+	tag, err := r.db.Exec(ctx, deleteFeedSettingsQuery, feedID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to delete feed settings", "feed_id", feedID, "error", err)
+		return false, fmt.Errorf("failed to delete feed settings: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanFeedSettings(row pgx.Row) (*domain.FeedSettings, error) {
+	var (
+		s               domain.FeedSettings
+		strictness      *string
+		intervalSeconds *int
+	)
+	if err := row.Scan(
+		&s.FeedID,
+		&s.BatchSize,
+		&s.SummarizationEnabled,
+		&strictness,
+		&s.LanguageOverride,
+		&intervalSeconds,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if strictness != nil {
+		q := domain.QualityStrictness(*strictness)
+		s.QualityStrictness = &q
+	}
+	if intervalSeconds != nil {
+		d := time.Duration(*intervalSeconds) * time.Second
+		s.FetchInterval = &d
+	}
+	return &s, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"pre-processor/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedSettingsRepository_InterfaceCompliance(t *testing.T) {
+	repo := NewFeedSettingsRepository(nil, testArticleThumbnailLogger())
+	assert.NotNil(t, repo)
+}
+
+func TestFeedSettingsRepository_RejectsEmptyFeedID(t *testing.T) {
+	repo := NewFeedSettingsRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, "")
+	assert.Error(t, err)
+	_, err = repo.Delete(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestFeedSettingsRepository_Upsert_ValidatesBeforeWriting(t *testing.T) {
+	repo := NewFeedSettingsRepository(nil, testArticleThumbnailLogger())
+	zero := 0
+
+	_, err := repo.Upsert(context.Background(), &domain.FeedSettings{FeedID: "feed-1", BatchSize: &zero})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidFeedSettings)
+}
+
+func TestFeedSettingsRepository_RejectsNilPool(t *testing.T) {
+	repo := NewFeedSettingsRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, "feed-1")
+	assert.Error(t, err)
+	_, err = repo.List(ctx)
+	assert.Error(t, err)
+	_, err = repo.Upsert(ctx, &domain.FeedSettings{FeedID: "feed-1"})
+	assert.Error(t, err)
+	_, err = repo.Delete(ctx, "feed-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+// Package repository: summary_review_repository.go implements the
+// pre-processor-db store for the human summary review queue.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pre-processor/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SummaryReviewRepository handles summary_review_queue persistence.
+type SummaryReviewRepository interface {
+	// Enqueue queues an article's summary for review. An article that is
+	// already pending or approved is left as is; a rejected one is reset to
+	// pending, since its summary has been regenerated since.
+	Enqueue(ctx context.Context, articleID string, reason domain.SummaryReviewReason) error
+	// Get returns a review item, or nil if it does not exist.
+	Get(ctx context.Context, id string) (*domain.SummaryReview, error)
+	// List returns up to limit items with the given status, oldest first.
+	List(ctx context.Context, status domain.SummaryReviewStatus, limit int) ([]*domain.SummaryReview, error)
+	// Decide moves a pending item to status. It returns
+	// ErrSummaryReviewNotFound or ErrSummaryReviewDecided when the item is
+	// missing or no longer pending.
+	Decide(ctx context.Context, id string, status domain.SummaryReviewStatus, reviewer, note *string) (*domain.SummaryReview, error)
+}
+
+type summaryReviewRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewSummaryReviewRepository creates a new summary review repository.
+func NewSummaryReviewRepository(db *pgxpool.Pool, logger *slog.Logger) SummaryReviewRepository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &summaryReviewRepository{db: db, logger: logger}
+}
+
+const summaryReviewColumns = `id, article_id, reason, status, reviewer, note, created_at, decided_at`
+
+const enqueueSummaryReviewQuery = `
+		INSERT INTO summary_review_queue (article_id, reason)
+		VALUES ($1, $2)
+		ON CONFLICT (article_id) DO UPDATE
+		SET reason = EXCLUDED.reason,
+		    status = 'pending',
+		    reviewer = NULL,
+		    note = NULL,
+		    created_at = NOW(),
+		    decided_at = NULL
+		WHERE summary_review_queue.status = 'rejected'
+	`
+
+const getSummaryReviewQuery = `
+		SELECT ` + summaryReviewColumns + `
+		FROM summary_review_queue
+		WHERE id = $1
+	`
+
+const listSummaryReviewsQuery = `
+		SELECT ` + summaryReviewColumns + `
+		FROM summary_review_queue
+		WHERE status = $1
+		ORDER BY created_at
+		LIMIT $2
+	`
+
+const decideSummaryReviewQuery = `
+		UPDATE summary_review_queue
+		SET status = $2, reviewer = $3, note = $4, decided_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + summaryReviewColumns
+
+// Enqueue queues an article's summary for review.
+func (r *summaryReviewRepository) Enqueue(ctx context.Context, articleID string, reason domain.SummaryReviewReason) error {
+	if articleID == "" {
+		return fmt.Errorf("article ID cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return fmt.Errorf("database connection is nil")
+	}
+
+	if _, err := r.db.Exec(ctx, enqueueSummaryReviewQuery, articleID, string(reason)); err != nil {
+		r.logger.ErrorContext(ctx, "failed to enqueue summary review", "article_id", articleID, "error", err)
+		return fmt.Errorf("failed to enqueue summary review: %w", err)
+	}
+	return nil
+}
+
+// Get returns a review item, or nil if it does not exist.
+func (r *summaryReviewRepository) Get(ctx context.Context, id string) (*domain.SummaryReview, error) {
+	if id == "" {
+		return nil, fmt.Errorf("review ID cannot be empty")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		// Review IDs are UUIDs; anything else cannot exist.
+		return nil, nil
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	review, err := scanSummaryReview(r.db.QueryRow(ctx, getSummaryReviewQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to get summary review", "review_id", id, "error", err)
+		return nil, fmt.Errorf("failed to get summary review: %w", err)
+	}
+	return review, nil
+}
+
+// List returns up to limit items with the given status, oldest first.
+func (r *summaryReviewRepository) List(ctx context.Context, status domain.SummaryReviewStatus, limit int) ([]*domain.SummaryReview, error) {
+	if !status.Valid() {
+		return nil, fmt.Errorf("invalid review status: %q", status)
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	rows, err := r.db.Query(ctx, listSummaryReviewsQuery, string(status), limit)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to list summary reviews", "status", status, "error", err)
+		return nil, fmt.Errorf("failed to list summary reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.SummaryReview
+	for rows.Next() {
+		review, err := scanSummaryReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan summary review row: %w", err)
+		}
+		items = append(items, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate summary review rows: %w", err)
+	}
+	return items, nil
+}
+
+// Decide moves a pending item to status.
+func (r *summaryReviewRepository) Decide(ctx context.Context, id string, status domain.SummaryReviewStatus, reviewer, note *string) (*domain.SummaryReview, error) {
+	if id == "" {
+		return nil, fmt.Errorf("review ID cannot be empty")
+	}
+	if status != domain.SummaryReviewApproved && status != domain.SummaryReviewRejected {
+		return nil, fmt.Errorf("invalid review decision: %q", status)
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrSummaryReviewNotFound
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	review, err := scanSummaryReview(r.db.QueryRow(ctx, decideSummaryReviewQuery, id, string(status), reviewer, note))
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Distinguish a missing item from one decided concurrently.
+		existing, getErr := r.Get(ctx, id)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if existing == nil {
+			return nil, domain.ErrSummaryReviewNotFound
+		}
+		return nil, domain.ErrSummaryReviewDecided
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to decide summary review", "review_id", id, "status", status, "error", err)
+		return nil, fmt.Errorf("failed to decide summary review: %w", err)
+	}
+	return review, nil
+}
+
+func scanSummaryReview(row pgx.Row) (*domain.SummaryReview, error) {
+	var (
+		s      domain.SummaryReview
+		reason string
+		status string
+	)
+	if err := row.Scan(
+		&s.ID,
+		&s.ArticleID,
+		&reason,
+		&status,
+		&s.Reviewer,
+		&s.Note,
+		&s.CreatedAt,
+		&s.DecidedAt,
+	); err != nil {
+		return nil, err
+	}
+	s.Reason = domain.SummaryReviewReason(reason)
+	s.Status = domain.SummaryReviewStatus(status)
+	return &s, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"pre-processor/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryReviewRepository_InterfaceCompliance(t *testing.T) {
+	repo := NewSummaryReviewRepository(nil, testArticleThumbnailLogger())
+	assert.NotNil(t, repo)
+}
+
+func TestSummaryReviewRepository_RejectsInvalidInput(t *testing.T) {
+	repo := NewSummaryReviewRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	assert.Error(t, repo.Enqueue(ctx, "", domain.SummaryReviewSampled))
+	_, err := repo.Get(ctx, "")
+	assert.Error(t, err)
+	_, err = repo.List(ctx, "unknown", 10)
+	assert.Error(t, err)
+	_, err = repo.Decide(ctx, "", domain.SummaryReviewApproved, nil, nil)
+	assert.Error(t, err)
+	_, err = repo.Decide(ctx, "3f0c3c8e-6d0b-4d1c-9a43-3b2f7f0f5b11", domain.SummaryReviewPending, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestSummaryReviewRepository_MalformedIDIsNotFound(t *testing.T) {
+	repo := NewSummaryReviewRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	review, err := repo.Get(ctx, "not-a-uuid")
+	assert.NoError(t, err)
+	assert.Nil(t, review)
+
+	_, err = repo.Decide(ctx, "not-a-uuid", domain.SummaryReviewRejected, nil, nil)
+	assert.ErrorIs(t, err, domain.ErrSummaryReviewNotFound)
+}
+
+func TestSummaryReviewRepository_RejectsNilPool(t *testing.T) {
+	repo := NewSummaryReviewRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+	id := "3f0c3c8e-6d0b-4d1c-9a43-3b2f7f0f5b11"
+
+	assert.Error(t, repo.Enqueue(ctx, "article-1", domain.SummaryReviewSampled))
+	_, err := repo.Get(ctx, id)
+	assert.Error(t, err)
+	_, err = repo.List(ctx, domain.SummaryReviewPending, 10)
+	assert.Error(t, err)
+	_, err = repo.Decide(ctx, id, domain.SummaryReviewApproved, nil, nil)
+	assert.Error(t, err)
+}
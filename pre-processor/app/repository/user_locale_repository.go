@@ -0,0 +1,121 @@
+// Package repository: user_locale_repository.go implements the
+// pre-processor-db store for users' preferred locales, which decide the
+// language their article summaries are written in.
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"pre-processor/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UserLocaleRepository handles user_locales persistence.
+type UserLocaleRepository interface {
+	// Get returns a user's locale, or nil if none is stored.
+	Get(ctx context.Context, userID string) (*domain.UserLocale, error)
+	// Upsert stores a user's locale and returns the stored row.
+	Upsert(ctx context.Context, locale *domain.UserLocale) (*domain.UserLocale, error)
+	// Delete removes a user's locale. It reports false if there was none.
+	Delete(ctx context.Context, userID string) (bool, error)
+}
+
+type userLocaleRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewUserLocaleRepository creates a new user locale repository.
+func NewUserLocaleRepository(db *pgxpool.Pool, logger *slog.Logger) UserLocaleRepository {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &userLocaleRepository{db: db, logger: logger}
+}
+
+const getUserLocaleQuery = `
+		SELECT user_id, locale, updated_at
+		FROM user_locales
+		WHERE user_id = $1
+	`
+
+const upsertUserLocaleQuery = `
+		INSERT INTO user_locales (user_id, locale)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET locale = EXCLUDED.locale,
+		    updated_at = NOW()
+		RETURNING user_id, locale, updated_at
+	`
+
+const deleteUserLocaleQuery = `
+		DELETE FROM user_locales WHERE user_id = $1
+	`
+
+// Get returns a user's locale, or nil if none is stored.
+func (r *userLocaleRepository) Get(ctx context.Context, userID string) (*domain.UserLocale, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	var l domain.UserLocale
+	err := r.db.QueryRow(ctx, getUserLocaleQuery, userID).Scan(&l.UserID, &l.Locale, &l.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to get user locale", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get user locale: %w", err)
+	}
+	return &l, nil
+}
+
+// Upsert stores a user's locale.
+func (r *userLocaleRepository) Upsert(ctx context.Context, locale *domain.UserLocale) (*domain.UserLocale, error) {
+	if locale == nil {
+		return nil, fmt.Errorf("user locale cannot be nil")
+	}
+	if err := locale.Validate(); err != nil {
+		return nil, err
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	var stored domain.UserLocale
+	err := r.db.QueryRow(ctx, upsertUserLocaleQuery, locale.UserID, locale.Locale).
+		Scan(&stored.UserID, &stored.Locale, &stored.UpdatedAt)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to upsert user locale", "user_id", locale.UserID, "error", err)
+		return nil, fmt.Errorf("failed to upsert user locale: %w", err)
+	}
+	return &stored, nil
+}
+
+// Delete removes a user's locale.
+func (r *userLocaleRepository) Delete(ctx context.Context, userID string) (bool, error) {
+	if userID == "" {
+		return false, fmt.Errorf("user ID cannot be empty")
+	}
+	if r.db == nil {
+		r.logger.ErrorContext(ctx, "database connection is nil")
+		return false, fmt.Errorf("database connection is nil")
+	}
+
+	tag, err := r.db.Exec(ctx, deleteUserLocaleQuery, userID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to delete user locale", "user_id", userID, "error", err)
+		return false, fmt.Errorf("failed to delete user locale: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
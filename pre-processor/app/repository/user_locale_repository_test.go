@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"pre-processor/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserLocaleRepository_RejectsEmptyUserID(t *testing.T) {
+	repo := NewUserLocaleRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, "")
+	assert.Error(t, err)
+	_, err = repo.Delete(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestUserLocaleRepository_Upsert_ValidatesBeforeWriting(t *testing.T) {
+	repo := NewUserLocaleRepository(nil, testArticleThumbnailLogger())
+
+	_, err := repo.Upsert(context.Background(), &domain.UserLocale{UserID: "user-1", Locale: "not a locale"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidUserLocale)
+}
+
+func TestUserLocaleRepository_RejectsNilPool(t *testing.T) {
+	repo := NewUserLocaleRepository(nil, testArticleThumbnailLogger())
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, "user-1")
+	assert.Error(t, err)
+	_, err = repo.Upsert(ctx, &domain.UserLocale{UserID: "user-1", Locale: "en-US"})
+	assert.Error(t, err)
+	_, err = repo.Delete(ctx, "user-1")
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -212,3 +213,49 @@ func TestBackfillEmptyFeeds_PreservesFeedID(t *testing.T) {
 		assert.Equal(t, "pre-resolved-feed-uuid", articleRepo.upsertedArticles[0].FeedID)
 	})
 }
+
+// stubArticleMediaSaver captures saved article media by article ID.
+type stubArticleMediaSaver struct {
+	repository.ArticleMediaRepository
+	saved map[string]*domain.ArticleMedia
+}
+
+func (m *stubArticleMediaSaver) Save(_ context.Context, media *domain.ArticleMedia) error {
+	if m.saved == nil {
+		m.saved = make(map[string]*domain.ArticleMedia)
+	}
+	m.saved[media.ArticleID] = media
+	return nil
+}
+
+func TestBackfillEmptyFeeds_SavesMedia(t *testing.T) {
+	t.Run("should keep media-only entries and record their enclosures", func(t *testing.T) {
+		episode := []byte(`[{"href":"https://cdn.example.com/ep42.mp3","type":"audio/mpeg","length":"1000"}]`)
+		articleRepo := &stubBackfillArticleRepo{
+			emptyFeedArticles: []*domain.Article{
+				{URL: "https://example.com/ep42", Title: "Episode 42", Content: "", Enclosures: episode, FeedID: "feed-1"},
+				{URL: "https://example.com/ep43", Title: "Episode 43", Content: "<p>" + strings.Repeat("Detailed show notes. ", 20) + "</p>", Enclosures: episode, FeedID: "feed-1"},
+				{URL: "https://example.com/post", Title: "Post", Content: "<p>Plain text post.</p>", FeedID: "feed-1"},
+				{URL: "https://example.com/empty", Title: "Empty", Content: "", FeedID: "feed-1"},
+			},
+		}
+		mediaRepo := &stubArticleMediaSaver{}
+		svc := NewArticleSyncServiceWithMedia(
+			&assigningArticleRepo{stubBackfillArticleRepo: articleRepo},
+			&stubBackfillExternalAPI{userID: "system-user-id"},
+			nil,
+			mediaRepo,
+			testLoggerBackfill(),
+		)
+
+		assert.NoError(t, svc.BackfillEmptyFeeds(context.Background()))
+
+		assert.Len(t, articleRepo.upsertedArticles, 3, "empty entry without media is still skipped")
+		assert.Len(t, mediaRepo.saved, 2)
+		assert.True(t, mediaRepo.saved["article-0"].MediaOnly)
+		assert.False(t, mediaRepo.saved["article-1"].MediaOnly)
+		assert.Equal(t, []domain.MediaEnclosure{{
+			URL: "https://cdn.example.com/ep42.mp3", MimeType: "audio/mpeg", Kind: domain.MediaKindAudio, LengthBytes: 1000,
+		}}, mediaRepo.saved["article-0"].Media)
+	})
+}
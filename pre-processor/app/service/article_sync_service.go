@@ -10,6 +10,7 @@ import (
 	"pre-processor/domain"
 	"pre-processor/repository"
 	"pre-processor/utils"
+	"pre-processor/utils/html_parser"
 )
 
 // ArticleSyncService implementation.
@@ -19,6 +20,13 @@ type articleSyncService struct {
 	sanitizer       *utils.Sanitizer
 	logger          *slog.Logger
 
+	// thumbnailRepo is optional; when set, the lead image of every upserted
+	// article is enqueued for thumbnail generation.
+	thumbnailRepo repository.ArticleThumbnailRepository
+	// mediaRepo is optional; when set, the podcast and video enclosures of
+	// every upserted article are recorded for the player and summarization.
+	mediaRepo repository.ArticleMediaRepository
+
 	// mu guards userID and lastBackfillFetchedAt, which are read and written
 	// by SyncArticles and BackfillEmptyFeeds — two independent JobRunner
 	// goroutines (article-sync, article-backfill) sharing this instance.
@@ -81,6 +89,43 @@ func NewArticleSyncService(
 	}
 }
 
+// NewArticleSyncServiceWithThumbnails creates an article sync service that
+// also enqueues the lead image of every upserted article for thumbnail generation.
+func NewArticleSyncServiceWithThumbnails(
+	articleRepo repository.ArticleRepository,
+	externalAPIRepo repository.ExternalAPIRepository,
+	thumbnailRepo repository.ArticleThumbnailRepository,
+	logger *slog.Logger,
+) ArticleSyncService {
+	return &articleSyncService{
+		articleRepo:     articleRepo,
+		externalAPIRepo: externalAPIRepo,
+		thumbnailRepo:   thumbnailRepo,
+		sanitizer:       utils.NewSanitizer(),
+		logger:          logger,
+	}
+}
+
+// NewArticleSyncServiceWithMedia creates an article sync service that also
+// records the media enclosures of every upserted article. thumbnailRepo may
+// be nil to skip thumbnail generation.
+func NewArticleSyncServiceWithMedia(
+	articleRepo repository.ArticleRepository,
+	externalAPIRepo repository.ExternalAPIRepository,
+	thumbnailRepo repository.ArticleThumbnailRepository,
+	mediaRepo repository.ArticleMediaRepository,
+	logger *slog.Logger,
+) ArticleSyncService {
+	return &articleSyncService{
+		articleRepo:     articleRepo,
+		externalAPIRepo: externalAPIRepo,
+		thumbnailRepo:   thumbnailRepo,
+		mediaRepo:       mediaRepo,
+		sanitizer:       utils.NewSanitizer(),
+		logger:          logger,
+	}
+}
+
 // SyncArticles synchronizes articles from Inoreader source to articles table.
 func (s *articleSyncService) SyncArticles(ctx context.Context) error {
 	s.logger.InfoContext(ctx, "starting article synchronization")
@@ -111,12 +156,19 @@ func (s *articleSyncService) SyncArticles(ctx context.Context) error {
 	s.logger.InfoContext(ctx, "processing articles for sync", "count", len(articles))
 
 	var validArticles []*domain.Article
+	leadImages := make(map[*domain.Article]string)
+	media := make(map[*domain.Article][]domain.MediaEnclosure)
 	for _, article := range articles {
+		// Extract the lead image before sanitization strips <meta> tags.
+		leadImage := html_parser.ExtractLeadImage(article.Content, article.URL)
+		enclosures := html_parser.ExtractMedia(article.Enclosures)
+
 		// 1. Sanitize content (Zero-Trust)
 		sanitizedContent := s.sanitizer.SanitizeHTMLAndTrim(article.Content)
 
-		// 2. Check validation (Empty content safeguard)
-		if sanitizedContent == "" {
+		// 2. Check validation (Empty content safeguard). Podcast and video
+		// entries often have no text at all; their media is the content.
+		if sanitizedContent == "" && len(enclosures) == 0 {
 			s.logger.WarnContext(ctx, "skipping article with empty content after sanitization", "url", article.URL)
 			continue
 		}
@@ -132,6 +184,8 @@ func (s *articleSyncService) SyncArticles(ctx context.Context) error {
 		}
 
 		validArticles = append(validArticles, article)
+		leadImages[article] = leadImage
+		media[article] = enclosures
 	}
 
 	// 3. Upsert
@@ -141,6 +195,8 @@ func (s *articleSyncService) SyncArticles(ctx context.Context) error {
 			return fmt.Errorf("failed to upsert articles: %w", err)
 		}
 		s.logger.InfoContext(ctx, "successfully synced articles", "count", len(validArticles))
+		s.enqueueLeadImages(ctx, validArticles, leadImages)
+		s.saveMedia(ctx, validArticles, media)
 	} else {
 		s.logger.InfoContext(ctx, "no valid articles to upsert after validation")
 	}
@@ -175,10 +231,15 @@ func (s *articleSyncService) BackfillEmptyFeeds(ctx context.Context) error {
 	s.logger.InfoContext(ctx, "processing articles for backfill", "count", len(articles))
 
 	var validArticles []*domain.Article
+	leadImages := make(map[*domain.Article]string)
+	media := make(map[*domain.Article][]domain.MediaEnclosure)
 	for _, article := range articles {
+		leadImage := html_parser.ExtractLeadImage(article.Content, article.URL)
+		enclosures := html_parser.ExtractMedia(article.Enclosures)
+
 		// Sanitize content (Zero-Trust)
 		sanitizedContent := s.sanitizer.SanitizeHTMLAndTrim(article.Content)
-		if sanitizedContent == "" {
+		if sanitizedContent == "" && len(enclosures) == 0 {
 			s.logger.WarnContext(ctx, "skipping article with empty content after sanitization", "url", article.URL)
 			continue
 		}
@@ -191,6 +252,8 @@ func (s *articleSyncService) BackfillEmptyFeeds(ctx context.Context) error {
 		}
 
 		validArticles = append(validArticles, article)
+		leadImages[article] = leadImage
+		media[article] = enclosures
 	}
 
 	if len(validArticles) > 0 {
@@ -199,6 +262,8 @@ func (s *articleSyncService) BackfillEmptyFeeds(ctx context.Context) error {
 			return fmt.Errorf("failed to upsert backfill articles: %w", err)
 		}
 		s.logger.InfoContext(ctx, "backfill completed", "count", len(validArticles))
+		s.enqueueLeadImages(ctx, validArticles, leadImages)
+		s.saveMedia(ctx, validArticles, media)
 	} else {
 		s.logger.InfoContext(ctx, "no valid articles to backfill after validation")
 	}
@@ -214,3 +279,62 @@ func (s *articleSyncService) BackfillEmptyFeeds(ctx context.Context) error {
 
 	return nil
 }
+
+// enqueueLeadImages records the lead image of each persisted article for
+// thumbnail generation. Failures are logged and never fail the sync: the
+// frontend falls back to the image proxy when no thumbnail exists.
+func (s *articleSyncService) enqueueLeadImages(ctx context.Context, articles []*domain.Article, leadImages map[*domain.Article]string) {
+	if s.thumbnailRepo == nil {
+		return
+	}
+	enqueued := 0
+	for _, article := range articles {
+		leadImage := leadImages[article]
+		// Articles skipped by the upsert (unknown feed, already existing) have no ID.
+		if article.ID == "" || leadImage == "" {
+			continue
+		}
+		if err := s.thumbnailRepo.Enqueue(ctx, article.ID, leadImage); err != nil {
+			s.logger.WarnContext(ctx, "failed to enqueue lead image", "article_id", article.ID, "error", err)
+			continue
+		}
+		enqueued++
+	}
+	if enqueued > 0 {
+		s.logger.InfoContext(ctx, "enqueued lead images for thumbnails", "count", enqueued)
+	}
+}
+
+// saveMedia records the media enclosures of each persisted article, flagging
+// those with little text besides as media-only so they are not summarized.
+// Failures are logged and never fail the sync: such an article is summarized
+// like any other.
+func (s *articleSyncService) saveMedia(ctx context.Context, articles []*domain.Article, media map[*domain.Article][]domain.MediaEnclosure) {
+	if s.mediaRepo == nil {
+		return
+	}
+	saved, mediaOnly := 0, 0
+	for _, article := range articles {
+		enclosures := media[article]
+		// Articles skipped by the upsert (unknown feed, already existing) have no ID.
+		if article.ID == "" || len(enclosures) == 0 {
+			continue
+		}
+		record := &domain.ArticleMedia{
+			ArticleID: article.ID,
+			Media:     enclosures,
+			MediaOnly: domain.IsMediaOnly(enclosures, html_parser.ExtractArticleText(article.Content)),
+		}
+		if err := s.mediaRepo.Save(ctx, record); err != nil {
+			s.logger.WarnContext(ctx, "failed to save article media", "article_id", article.ID, "error", err)
+			continue
+		}
+		saved++
+		if record.MediaOnly {
+			mediaOnly++
+		}
+	}
+	if saved > 0 {
+		s.logger.InfoContext(ctx, "saved article media", "count", saved, "media_only", mediaOnly)
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+	"pre-processor/utils"
+)
+
+// ImageProcessor resizes a source image into a thumbnail of the given width.
+type ImageProcessor interface {
+	Resize(src []byte, width int) ([]byte, string, error)
+}
+
+// ObjectStorage stores an object under key and returns its public URL.
+type ObjectStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+}
+
+// thumbnailStaleAfter is how long a row may stay running before another
+// worker reclaims it (crashed or killed mid-run).
+const thumbnailStaleAfter = 10 * time.Minute
+
+// ArticleThumbnailService downloads article lead images, resizes them to the
+// standard thumbnail sizes, and stores them in object storage.
+type ArticleThumbnailService struct {
+	repo          repository.ArticleThumbnailRepository
+	urlValidator  ArticleFetcherService
+	httpClient    HTTPClient
+	processor     ImageProcessor
+	storage       ObjectStorage
+	hostLimiter   *utils.HostRateLimiter
+	sizes         []domain.ThumbnailSize
+	maxImageBytes int64
+	maxRetries    int
+	logger        *slog.Logger
+}
+
+// NewArticleThumbnailService creates a new article thumbnail service.
+// urlValidator provides the SSRF checks applied to every image URL, and
+// hostLimiter enforces the per-host interval for origin downloads.
+func NewArticleThumbnailService(
+	repo repository.ArticleThumbnailRepository,
+	urlValidator ArticleFetcherService,
+	httpClient HTTPClient,
+	processor ImageProcessor,
+	storage ObjectStorage,
+	hostLimiter *utils.HostRateLimiter,
+	maxImageBytes int64,
+	maxRetries int,
+	logger *slog.Logger,
+) *ArticleThumbnailService {
+	return &ArticleThumbnailService{
+		repo:          repo,
+		urlValidator:  urlValidator,
+		httpClient:    httpClient,
+		processor:     processor,
+		storage:       storage,
+		hostLimiter:   hostLimiter,
+		sizes:         domain.DefaultThumbnailSizes,
+		maxImageBytes: maxImageBytes,
+		maxRetries:    maxRetries,
+		logger:        logger,
+	}
+}
+
+// ProcessPending claims up to batchSize queued lead images and generates
+// their thumbnails. Per-image failures are recorded on the row and do not
+// abort the batch. Returns the number of completed rows.
+func (s *ArticleThumbnailService) ProcessPending(ctx context.Context, batchSize int) (int, error) {
+	items, err := s.repo.DequeuePending(ctx, batchSize, thumbnailStaleAfter)
+	if err != nil {
+		return 0, fmt.Errorf("dequeue article thumbnails: %w", err)
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	completed := 0
+	for _, item := range items {
+		if ctx.Err() != nil {
+			// Unprocessed rows stay running and are reclaimed after thumbnailStaleAfter.
+			return completed, ctx.Err()
+		}
+
+		thumbnails, err := s.processOne(ctx, item)
+		if err != nil {
+			s.logger.WarnContext(ctx, "thumbnail generation failed",
+				"article_id", item.ArticleID,
+				"retry_count", item.RetryCount,
+				"error", err)
+			if markErr := s.repo.MarkFailed(ctx, item.ArticleID, err.Error(), s.maxRetries); markErr != nil {
+				return completed, fmt.Errorf("mark thumbnail failed: %w", markErr)
+			}
+			continue
+		}
+
+		if err := s.repo.MarkCompleted(ctx, item.ArticleID, thumbnails); err != nil {
+			return completed, fmt.Errorf("mark thumbnail completed: %w", err)
+		}
+		completed++
+	}
+
+	s.logger.InfoContext(ctx, "thumbnail batch processed",
+		"claimed", len(items),
+		"completed", completed)
+	return completed, nil
+}
+
+// processOne downloads the lead image once and stores every thumbnail size.
+func (s *ArticleThumbnailService) processOne(ctx context.Context, item *domain.ArticleThumbnail) (map[string]string, error) {
+	src, err := s.download(ctx, item.SourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails := make(map[string]string, len(s.sizes))
+	for _, size := range s.sizes {
+		data, contentType, err := s.processor.Resize(src, size.Width)
+		if err != nil {
+			return nil, fmt.Errorf("resize %s: %w", size.Name, err)
+		}
+		key := fmt.Sprintf("articles/%s/%s.jpg", item.ArticleID, size.Name)
+		objectURL, err := s.storage.Put(ctx, key, data, contentType)
+		if err != nil {
+			return nil, fmt.Errorf("store %s: %w", size.Name, err)
+		}
+		thumbnails[size.Name] = objectURL
+	}
+	return thumbnails, nil
+}
+
+// download fetches the image with SSRF validation, per-host rate limiting,
+// a content-type check, and a size cap.
+func (s *ArticleThumbnailService) download(ctx context.Context, imageURL string) ([]byte, error) {
+	if err := s.urlValidator.ValidateURL(imageURL); err != nil {
+		return nil, fmt.Errorf("invalid image URL: %w", err)
+	}
+	parsed, err := url.Parse(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse image URL: %w", err)
+	}
+	if err := s.hostLimiter.Wait(ctx, parsed.Host); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Get(ctx, imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("download image: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download image: status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "image/") {
+		return nil, fmt.Errorf("download image: unexpected content type %q", ct)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s.maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	if int64(len(data)) > s.maxImageBytes {
+		return nil, errors.New("image exceeds maximum size")
+	}
+	return data, nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+	"pre-processor/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Stubs ---
+
+type stubThumbnailRepo struct {
+	pending   []*domain.ArticleThumbnail
+	enqueued  map[string]string
+	completed map[string]map[string]string
+	failed    map[string]string
+}
+
+func newStubThumbnailRepo(pending ...*domain.ArticleThumbnail) *stubThumbnailRepo {
+	return &stubThumbnailRepo{
+		pending:   pending,
+		enqueued:  make(map[string]string),
+		completed: make(map[string]map[string]string),
+		failed:    make(map[string]string),
+	}
+}
+
+func (s *stubThumbnailRepo) Enqueue(_ context.Context, articleID, sourceURL string) error {
+	s.enqueued[articleID] = sourceURL
+	return nil
+}
+
+func (s *stubThumbnailRepo) DequeuePending(_ context.Context, _ int, _ time.Duration) ([]*domain.ArticleThumbnail, error) {
+	items := s.pending
+	s.pending = nil
+	return items, nil
+}
+
+func (s *stubThumbnailRepo) MarkCompleted(_ context.Context, articleID string, thumbnails map[string]string) error {
+	s.completed[articleID] = thumbnails
+	return nil
+}
+
+func (s *stubThumbnailRepo) MarkFailed(_ context.Context, articleID string, errorMessage string, _ int) error {
+	s.failed[articleID] = errorMessage
+	return nil
+}
+
+type stubURLValidator struct {
+	ArticleFetcherService
+	err error
+}
+
+func (s *stubURLValidator) ValidateURL(_ string) error {
+	return s.err
+}
+
+type stubImageHTTPClient struct {
+	status      int
+	contentType string
+	body        string
+	calls       int
+}
+
+func (s *stubImageHTTPClient) Get(_ context.Context, _ string) (*http.Response, error) {
+	s.calls++
+	header := make(http.Header)
+	header.Set("Content-Type", s.contentType)
+	return &http.Response{
+		StatusCode: s.status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(s.body)),
+	}, nil
+}
+
+type stubImageProcessor struct {
+	err error
+}
+
+func (s *stubImageProcessor) Resize(src []byte, _ int) ([]byte, string, error) {
+	if s.err != nil {
+		return nil, "", s.err
+	}
+	return src, "image/jpeg", nil
+}
+
+type stubObjectStorage struct {
+	keys []string
+}
+
+func (s *stubObjectStorage) Put(_ context.Context, key string, _ []byte, _ string) (string, error) {
+	s.keys = append(s.keys, key)
+	return "/thumbnails/" + key, nil
+}
+
+func newTestThumbnailService(repo repository.ArticleThumbnailRepository, client HTTPClient, processor ImageProcessor, storage ObjectStorage, validator ArticleFetcherService) *ArticleThumbnailService {
+	return NewArticleThumbnailService(repo, validator, client, processor, storage,
+		utils.NewHostRateLimiter(0), 1024, 3, testLoggerBackfill())
+}
+
+// --- Tests ---
+
+func TestArticleThumbnailService_ProcessPending_StoresAllSizes(t *testing.T) {
+	repo := newStubThumbnailRepo(&domain.ArticleThumbnail{ArticleID: "a1", SourceURL: "https://example.com/lead.jpg"})
+	client := &stubImageHTTPClient{status: http.StatusOK, contentType: "image/jpeg", body: "jpegdata"}
+	storage := &stubObjectStorage{}
+
+	svc := newTestThumbnailService(repo, client, &stubImageProcessor{}, storage, &stubURLValidator{})
+	completed, err := svc.ProcessPending(context.Background(), 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, 1, client.calls, "source image is downloaded once for all sizes")
+	assert.Equal(t, map[string]string{
+		"small":  "/thumbnails/articles/a1/small.jpg",
+		"medium": "/thumbnails/articles/a1/medium.jpg",
+		"large":  "/thumbnails/articles/a1/large.jpg",
+	}, repo.completed["a1"])
+}
+
+func TestArticleThumbnailService_ProcessPending_RecordsFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    *stubImageHTTPClient
+		processor *stubImageProcessor
+		validator *stubURLValidator
+	}{
+		{
+			name:      "rejected by SSRF validation",
+			client:    &stubImageHTTPClient{status: http.StatusOK, contentType: "image/png", body: "x"},
+			processor: &stubImageProcessor{},
+			validator: &stubURLValidator{err: errors.New("private host")},
+		},
+		{
+			name:      "non-image content type",
+			client:    &stubImageHTTPClient{status: http.StatusOK, contentType: "text/html", body: "<html>"},
+			processor: &stubImageProcessor{},
+			validator: &stubURLValidator{},
+		},
+		{
+			name:      "origin error status",
+			client:    &stubImageHTTPClient{status: http.StatusNotFound, contentType: "image/png"},
+			processor: &stubImageProcessor{},
+			validator: &stubURLValidator{},
+		},
+		{
+			name:      "image over size cap",
+			client:    &stubImageHTTPClient{status: http.StatusOK, contentType: "image/png", body: strings.Repeat("x", 2048)},
+			processor: &stubImageProcessor{},
+			validator: &stubURLValidator{},
+		},
+		{
+			name:      "undecodable image",
+			client:    &stubImageHTTPClient{status: http.StatusOK, contentType: "image/webp", body: "webp"},
+			processor: &stubImageProcessor{err: errors.New("unsupported image")},
+			validator: &stubURLValidator{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubThumbnailRepo(&domain.ArticleThumbnail{ArticleID: "a1", SourceURL: "https://example.com/lead"})
+			svc := newTestThumbnailService(repo, tt.client, tt.processor, &stubObjectStorage{}, tt.validator)
+
+			completed, err := svc.ProcessPending(context.Background(), 10)
+
+			require.NoError(t, err, "per-image failures must not abort the batch")
+			assert.Equal(t, 0, completed)
+			assert.Contains(t, repo.failed, "a1")
+			assert.Empty(t, repo.completed)
+		})
+	}
+}
+
+func TestArticleSyncService_EnqueuesLeadImages(t *testing.T) {
+	articleRepo := &stubBackfillArticleRepo{
+		emptyFeedArticles: []*domain.Article{
+			{
+				URL:     "https://example.com/article1",
+				Title:   "With image",
+				Content: `<p>Body text long enough.</p><img src="/img/lead.jpg">`,
+				FeedID:  "feed-uuid-1",
+			},
+			{
+				URL:     "https://example.com/article2",
+				Title:   "Without image",
+				Content: "<p>Body text long enough.</p>",
+				FeedID:  "feed-uuid-1",
+			},
+		},
+	}
+	thumbnailRepo := newStubThumbnailRepo()
+	svc := NewArticleSyncServiceWithThumbnails(
+		&assigningArticleRepo{stubBackfillArticleRepo: articleRepo},
+		&stubBackfillExternalAPI{userID: "system-user-id"},
+		thumbnailRepo,
+		testLoggerBackfill(),
+	)
+
+	require.NoError(t, svc.BackfillEmptyFeeds(context.Background()))
+	assert.Equal(t, map[string]string{
+		"article-0": "https://example.com/img/lead.jpg",
+	}, thumbnailRepo.enqueued)
+}
+
+// assigningArticleRepo assigns backend article IDs on upsert, as the
+// backend API driver does on CreateArticle.
+type assigningArticleRepo struct {
+	*stubBackfillArticleRepo
+}
+
+func (r *assigningArticleRepo) UpsertArticlesWithFeedID(ctx context.Context, articles []*domain.Article) error {
+	for i, a := range articles {
+		a.ID = fmt.Sprintf("article-%d", i)
+	}
+	return r.stubBackfillArticleRepo.UpsertArticlesWithFeedID(ctx, articles)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+)
+
+// defaultFeedSettingsTTL bounds how long an override change made outside
+// this instance can take to reach the workers.
+const defaultFeedSettingsTTL = time.Minute
+
+// ArticleFeedLookup maps an article to the feed it belongs to. The backend
+// API does not include feed IDs in the article listings the workers use.
+type ArticleFeedLookup interface {
+	FindFeedID(ctx context.Context, articleID string) (string, error)
+}
+
+// FeedSettingsResolver resolves the effective processing settings of a feed
+// by applying its overrides over the global defaults. Overrides are loaded
+// in full and cached, since the workers resolve settings per article.
+type FeedSettingsResolver struct {
+	repo     repository.FeedSettingsRepository
+	articles ArticleFeedLookup
+	defaults domain.EffectiveFeedSettings
+	ttl      time.Duration
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu       sync.Mutex
+	byFeed   map[string]*domain.FeedSettings
+	loadedAt time.Time
+}
+
+// NewFeedSettingsResolver creates a resolver over repo. defaults are the
+// global settings a feed without overrides is processed with; articles
+// looks up the feed of an article that arrives without one.
+func NewFeedSettingsResolver(repo repository.FeedSettingsRepository, articles ArticleFeedLookup, defaults domain.EffectiveFeedSettings, logger *slog.Logger) *FeedSettingsResolver {
+	return &FeedSettingsResolver{
+		repo:     repo,
+		articles: articles,
+		defaults: defaults,
+		ttl:      defaultFeedSettingsTTL,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Defaults returns the global settings.
+func (r *FeedSettingsResolver) Defaults() domain.EffectiveFeedSettings {
+	return r.defaults
+}
+
+// Resolve returns the effective settings for feedID. If the overrides
+// cannot be loaded, the last loaded overrides (or the defaults) are used so
+// a pre-processor-db blip does not stall summarization.
+func (r *FeedSettingsResolver) Resolve(ctx context.Context, feedID string) domain.EffectiveFeedSettings {
+	overrides := r.load(ctx)
+	eff := overrides[feedID].Resolve(r.defaults)
+	eff.FeedID = feedID
+	return eff
+}
+
+// ResolveArticle returns the effective settings for an article. feedID may
+// be empty, in which case the article's feed is looked up, but only while
+// some feed has overrides, so the common no-overrides case costs nothing.
+func (r *FeedSettingsResolver) ResolveArticle(ctx context.Context, articleID, feedID string) domain.EffectiveFeedSettings {
+	overrides := r.load(ctx)
+	if feedID == "" && len(overrides) > 0 && r.articles != nil {
+		id, err := r.articles.FindFeedID(ctx, articleID)
+		if err != nil {
+			r.logger.WarnContext(ctx, "failed to look up article feed, using default settings",
+				"article_id", articleID, "error", err)
+		}
+		feedID = id
+	}
+	eff := overrides[feedID].Resolve(r.defaults)
+	eff.FeedID = feedID
+	return eff
+}
+
+// Invalidate drops the cached overrides so the next Resolve reloads them.
+func (r *FeedSettingsResolver) Invalidate() {
+	r.mu.Lock()
+	r.loadedAt = time.Time{}
+	r.mu.Unlock()
+}
+
+func (r *FeedSettingsResolver) load(ctx context.Context) map[string]*domain.FeedSettings {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byFeed != nil && r.now().Sub(r.loadedAt) < r.ttl {
+		return r.byFeed
+	}
+
+	items, err := r.repo.List(ctx)
+	if err != nil {
+		// Keep serving the previous overrides and retry after the TTL rather
+		// than hitting the database again for every article.
+		r.logger.WarnContext(ctx, "failed to load feed settings, using previous overrides", "error", err)
+		if r.byFeed == nil {
+			r.byFeed = map[string]*domain.FeedSettings{}
+		}
+		r.loadedAt = r.now()
+		return r.byFeed
+	}
+
+	byFeed := make(map[string]*domain.FeedSettings, len(items))
+	for _, s := range items {
+		byFeed[s.FeedID] = s
+	}
+	r.byFeed = byFeed
+	r.loadedAt = r.now()
+	return byFeed
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubFeedSettingsRepo serves a fixed set of overrides.
+type stubFeedSettingsRepo struct {
+	repository.FeedSettingsRepository
+	items     []*domain.FeedSettings
+	listErr   error
+	listCalls int
+}
+
+func (m *stubFeedSettingsRepo) List(_ context.Context) ([]*domain.FeedSettings, error) {
+	m.listCalls++
+	return m.items, m.listErr
+}
+
+// stubArticleFeedLookup maps article IDs to feed IDs.
+type stubArticleFeedLookup struct {
+	feeds map[string]string
+	calls int
+}
+
+func (m *stubArticleFeedLookup) FindFeedID(_ context.Context, articleID string) (string, error) {
+	m.calls++
+	return m.feeds[articleID], nil
+}
+
+func testFeedSettingsDefaults() domain.EffectiveFeedSettings {
+	return domain.EffectiveFeedSettings{
+		BatchSize:            10,
+		SummarizationEnabled: true,
+		QualityStrictness:    domain.QualityStrictnessStandard,
+	}
+}
+
+func disabledFeed(feedID string) *domain.FeedSettings {
+	off := false
+	return &domain.FeedSettings{FeedID: feedID, SummarizationEnabled: &off}
+}
+
+func TestFeedSettingsResolver_Resolve(t *testing.T) {
+	repo := &stubFeedSettingsRepo{items: []*domain.FeedSettings{disabledFeed("feed-off")}}
+	resolver := NewFeedSettingsResolver(repo, nil, testFeedSettingsDefaults(), testLogger())
+	ctx := context.Background()
+
+	assert.False(t, resolver.Resolve(ctx, "feed-off").SummarizationEnabled)
+	assert.True(t, resolver.Resolve(ctx, "feed-other").SummarizationEnabled)
+	assert.Equal(t, 10, resolver.Resolve(ctx, "feed-off").BatchSize)
+	assert.Equal(t, 1, repo.listCalls, "overrides should be cached between resolves")
+}
+
+func TestFeedSettingsResolver_ReloadsAfterTTLAndInvalidate(t *testing.T) {
+	repo := &stubFeedSettingsRepo{}
+	resolver := NewFeedSettingsResolver(repo, nil, testFeedSettingsDefaults(), testLogger())
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	resolver.Resolve(ctx, "feed-1")
+	now = now.Add(defaultFeedSettingsTTL)
+	resolver.Resolve(ctx, "feed-1")
+	assert.Equal(t, 2, repo.listCalls)
+
+	resolver.Invalidate()
+	resolver.Resolve(ctx, "feed-1")
+	assert.Equal(t, 3, repo.listCalls)
+}
+
+func TestFeedSettingsResolver_KeepsPreviousOverridesOnError(t *testing.T) {
+	repo := &stubFeedSettingsRepo{items: []*domain.FeedSettings{disabledFeed("feed-off")}}
+	resolver := NewFeedSettingsResolver(repo, nil, testFeedSettingsDefaults(), testLogger())
+	ctx := context.Background()
+
+	assert.False(t, resolver.Resolve(ctx, "feed-off").SummarizationEnabled)
+
+	repo.listErr = errors.New("connection refused")
+	resolver.Invalidate()
+	assert.False(t, resolver.Resolve(ctx, "feed-off").SummarizationEnabled)
+}
+
+func TestFeedSettingsResolver_ResolveArticle(t *testing.T) {
+	t.Run("looks up the feed when overrides exist", func(t *testing.T) {
+		repo := &stubFeedSettingsRepo{items: []*domain.FeedSettings{disabledFeed("feed-off")}}
+		lookup := &stubArticleFeedLookup{feeds: map[string]string{"article-1": "feed-off"}}
+		resolver := NewFeedSettingsResolver(repo, lookup, testFeedSettingsDefaults(), testLogger())
+
+		got := resolver.ResolveArticle(context.Background(), "article-1", "")
+
+		assert.Equal(t, "feed-off", got.FeedID)
+		assert.False(t, got.SummarizationEnabled)
+		assert.Equal(t, 1, lookup.calls)
+	})
+
+	t.Run("skips the lookup when no feed has overrides", func(t *testing.T) {
+		lookup := &stubArticleFeedLookup{}
+		resolver := NewFeedSettingsResolver(&stubFeedSettingsRepo{}, lookup, testFeedSettingsDefaults(), testLogger())
+
+		got := resolver.ResolveArticle(context.Background(), "article-1", "")
+
+		assert.True(t, got.SummarizationEnabled)
+		assert.Equal(t, 0, lookup.calls)
+	})
+
+	t.Run("skips the lookup when the feed is known", func(t *testing.T) {
+		repo := &stubFeedSettingsRepo{items: []*domain.FeedSettings{disabledFeed("feed-off")}}
+		lookup := &stubArticleFeedLookup{}
+		resolver := NewFeedSettingsResolver(repo, lookup, testFeedSettingsDefaults(), testLogger())
+
+		got := resolver.ResolveArticle(context.Background(), "article-1", "feed-off")
+
+		assert.False(t, got.SummarizationEnabled)
+		assert.Equal(t, 0, lookup.calls)
+	})
+}
@@ -73,7 +73,12 @@ type QualityResult struct {
 	ErrorCount     int
 	RemovedCount   int
 	RetainedCount  int
-	HasMore        bool
+	// SkippedCount is the number of summaries left out by sampling.
+	SkippedCount int
+	// QueuedForReviewCount is the number of judged summaries queued for
+	// human review.
+	QueuedForReviewCount int
+	HasMore              bool
 }
 
 // ProcessingStats represents processing statistics.
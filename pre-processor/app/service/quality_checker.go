@@ -19,6 +19,20 @@ type qualityCheckerService struct {
 	jobRepo     repository.SummarizeJobRepository
 	logger      *slog.Logger
 	cursor      *domain.Cursor
+	// feedSettings, when set, supplies per-feed quality strictness.
+	feedSettings *FeedSettingsResolver
+	// sampling, when set, limits the LLM judge to a sample of summaries and
+	// queues those that pass for human review.
+	sampling *QualitySampling
+}
+
+// QualitySampling selects which summaries the quality checker judges.
+// Summaries flagged by domain.IsLowConfidenceSummary are always judged;
+// the rest are judged at SampleRate (0-1). Judged summaries that pass are
+// queued in Reviews for a human to approve or reject.
+type QualitySampling struct {
+	SampleRate float64
+	Reviews    repository.SummaryReviewRepository
 }
 
 // NewQualityCheckerService creates a new quality checker service.
@@ -39,6 +53,50 @@ func NewQualityCheckerService(
 	}
 }
 
+// NewQualityCheckerServiceWithFeedSettings creates a quality checker that
+// judges each summary with the strictness of its article's feed.
+func NewQualityCheckerServiceWithFeedSettings(
+	summaryRepo repository.SummaryRepository,
+	articleRepo repository.ArticleRepository,
+	apiRepo repository.ExternalAPIRepository,
+	jobRepo repository.SummarizeJobRepository,
+	feedSettings *FeedSettingsResolver,
+	logger *slog.Logger,
+) QualityCheckerService {
+	return &qualityCheckerService{
+		summaryRepo:  summaryRepo,
+		articleRepo:  articleRepo,
+		apiRepo:      apiRepo,
+		jobRepo:      jobRepo,
+		logger:       logger,
+		cursor:       &domain.Cursor{},
+		feedSettings: feedSettings,
+	}
+}
+
+// NewSampledQualityCheckerService creates a quality checker that judges
+// only the summaries selected by sampling and queues them for human review.
+func NewSampledQualityCheckerService(
+	summaryRepo repository.SummaryRepository,
+	articleRepo repository.ArticleRepository,
+	apiRepo repository.ExternalAPIRepository,
+	jobRepo repository.SummarizeJobRepository,
+	feedSettings *FeedSettingsResolver,
+	sampling QualitySampling,
+	logger *slog.Logger,
+) QualityCheckerService {
+	return &qualityCheckerService{
+		summaryRepo:  summaryRepo,
+		articleRepo:  articleRepo,
+		apiRepo:      apiRepo,
+		jobRepo:      jobRepo,
+		logger:       logger,
+		cursor:       &domain.Cursor{},
+		feedSettings: feedSettings,
+		sampling:     &sampling,
+	}
+}
+
 // CheckQuality processes a batch of articles for quality checking.
 func (s *qualityCheckerService) CheckQuality(ctx context.Context, batchSize int) (*QualityResult, error) {
 	s.logger.InfoContext(ctx, "starting quality check", "batch_size", batchSize)
@@ -61,10 +119,17 @@ func (s *qualityCheckerService) CheckQuality(ctx context.Context, batchSize int)
 	}
 
 	// Process each article with summary for quality check using LLM scoring
-	for i, articleWithSummary := range articlesWithSummaries {
+	judged := 0
+	for _, articleWithSummary := range articlesWithSummaries {
+		reason, selected := s.selectForReview(articleWithSummary)
+		if !selected {
+			result.SkippedCount++
+			continue
+		}
+
 		// Yield between items so other BE requests (e.g. summarize queue worker)
 		// can acquire the semaphore slot
-		if i > 0 {
+		if judged > 0 {
 			select {
 			case <-ctx.Done():
 				return result, ctx.Err()
@@ -72,10 +137,15 @@ func (s *qualityCheckerService) CheckQuality(ctx context.Context, batchSize int)
 			}
 		}
 
-		s.logger.InfoContext(ctx, "processing article for quality check", "article_id", articleWithSummary.ArticleID)
+		judged++
+
+		s.logger.InfoContext(ctx, "processing article for quality check", "article_id", articleWithSummary.ArticleID, "review_reason", reason)
 
 		// Convert domain.ArticleWithSummary to driver.ArticleWithSummary for quality checker
 		driverArticle := s.convertToDriverArticle(articleWithSummary)
+		if s.feedSettings != nil {
+			driverArticle.QualityStrictness = s.feedSettings.ResolveArticle(ctx, articleWithSummary.ArticleID, "").QualityStrictness
+		}
 
 		// Use the actual LLM-based quality scoring from quality_judger.go
 		// JudgeArticleQuality handles scoring and removal of low-quality summaries
@@ -104,6 +174,15 @@ func (s *qualityCheckerService) CheckQuality(ctx context.Context, batchSize int)
 			result.RetainedCount++
 
 			s.logger.DebugContext(ctx, "article quality acceptable - summary retained", "article_id", articleWithSummary.ArticleID)
+
+			if reason != "" && s.sampling.Reviews != nil {
+				if err := s.sampling.Reviews.Enqueue(ctx, articleWithSummary.ArticleID, reason); err != nil {
+					s.logger.ErrorContext(ctx, "failed to queue summary for review (best-effort)",
+						"article_id", articleWithSummary.ArticleID, "error", err)
+				} else {
+					result.QueuedForReviewCount++
+				}
+			}
 		} else {
 			result.RemovedCount++
 
@@ -131,11 +210,33 @@ func (s *qualityCheckerService) CheckQuality(ctx context.Context, batchSize int)
 		"errors", result.ErrorCount,
 		"removed", result.RemovedCount,
 		"retained", result.RetainedCount,
+		"skipped", result.SkippedCount,
+		"queued_for_review", result.QueuedForReviewCount,
 		"has_more", result.HasMore)
 
 	return result, nil
 }
 
+// selectForReview reports whether a summary is judged and, with sampling
+// enabled, why it will be queued for human review if it passes. Without
+// sampling every summary is judged and none is queued. Placeholder
+// summaries are never judged, so they are not sampled either.
+func (s *qualityCheckerService) selectForReview(article *domain.ArticleWithSummary) (domain.SummaryReviewReason, bool) {
+	if s.sampling == nil {
+		return "", true
+	}
+	if qualitychecker.IsPlaceholderSummary(article.SummaryJapanese) {
+		return "", false
+	}
+	if domain.IsLowConfidenceSummary(article.SummaryJapanese) {
+		return domain.SummaryReviewLowConfidence, true
+	}
+	if domain.SampledForReview(article.ArticleID, s.sampling.SampleRate) {
+		return domain.SummaryReviewSampled, true
+	}
+	return "", false
+}
+
 // convertToDriverArticle converts domain.ArticleWithSummary to driver.ArticleWithSummary.
 func (s *qualityCheckerService) convertToDriverArticle(article *domain.ArticleWithSummary) *driver.ArticleWithSummary {
 	return &driver.ArticleWithSummary{
@@ -12,6 +12,7 @@ import (
 
 	"pre-processor/domain"
 	"pre-processor/repository"
+	"pre-processor/utils"
 	"pre-processor/utils/html_parser"
 )
 
@@ -21,6 +22,9 @@ import (
 const (
 	placeholderTooShort = "本文が短すぎるため要約できませんでした。"
 	placeholderTooLong  = "本文が長すぎるため要約できませんでした。"
+	// placeholderMediaOnly marks podcast/video entries whose only content is
+	// their media; there is no text worth summarizing.
+	placeholderMediaOnly = "音声・動画のみの記事のため要約していません。"
 )
 
 // EnqueueResult represents the result of enqueuing unsummarized articles.
@@ -41,6 +45,21 @@ type SummarizeQueueWorker struct {
 	logger      *slog.Logger
 	batchSize   int
 	concurrency int
+	// breaker, when set, is the news-creator circuit breaker also wrapping
+	// apiRepo. While it is open the worker leaves jobs pending instead of
+	// dequeuing them only to fail.
+	breaker *utils.CircuitBreaker
+	// feedSettings, when set, supplies per-feed overrides: feeds with
+	// summarization disabled are neither enqueued nor summarized, and a
+	// feed's batch size caps how many of its articles one enqueue pass adds.
+	feedSettings *FeedSettingsResolver
+	// userLocales, when set, supplies the article owner's locale, which
+	// picks the summary language. Without it summaries use
+	// domain.DefaultSummaryLanguage.
+	userLocales repository.UserLocaleRepository
+	// articleMedia, when set, identifies media-only articles, which get a
+	// placeholder summary instead of being sent to news-creator.
+	articleMedia repository.ArticleMediaRepository
 
 	// mu guards lastRecoveryRun and enqueueCursor: ProcessQueue (queue-worker
 	// job, 10s ticker) and EnqueueUnsummarizedBatch/ResetEnqueueCursor
@@ -80,6 +99,69 @@ func (w *SummarizeQueueWorker) SetConcurrency(concurrency int) {
 	w.concurrency = concurrency
 }
 
+// SetCircuitBreaker makes the worker defer jobs while breaker is open. It
+// must be the breaker guarding the worker's ExternalAPIRepository.
+func (w *SummarizeQueueWorker) SetCircuitBreaker(breaker *utils.CircuitBreaker) {
+	w.breaker = breaker
+}
+
+// SetFeedSettings makes the worker honour per-feed processing overrides.
+func (w *SummarizeQueueWorker) SetFeedSettings(resolver *FeedSettingsResolver) {
+	w.feedSettings = resolver
+}
+
+// SetUserLocales makes the worker summarize in each article owner's locale.
+func (w *SummarizeQueueWorker) SetUserLocales(repo repository.UserLocaleRepository) {
+	w.userLocales = repo
+}
+
+// SetArticleMedia makes the worker skip summarizing media-only articles.
+func (w *SummarizeQueueWorker) SetArticleMedia(repo repository.ArticleMediaRepository) {
+	w.articleMedia = repo
+}
+
+// isMediaOnly reports whether an article is a media-only entry. A failed
+// lookup is treated as not media-only so the article is still summarized.
+func (w *SummarizeQueueWorker) isMediaOnly(ctx context.Context, articleID string) bool {
+	if w.articleMedia == nil {
+		return false
+	}
+	mediaOnly, err := w.articleMedia.IsMediaOnly(ctx, articleID)
+	if err != nil {
+		w.logger.WarnContext(ctx, "failed to look up article media, summarizing as text",
+			"article_id", articleID, "error", err)
+		return false
+	}
+	return mediaOnly
+}
+
+// summaryLanguageFor returns the language to summarize a user's article in.
+// A failed lookup falls back to the default rather than failing the job.
+func (w *SummarizeQueueWorker) summaryLanguageFor(ctx context.Context, userID string) string {
+	if w.userLocales == nil || userID == "" {
+		return domain.DefaultSummaryLanguage
+	}
+	locale, err := w.userLocales.Get(ctx, userID)
+	if err != nil {
+		w.logger.WarnContext(ctx, "failed to look up user locale, using default summary language",
+			"user_id", userID, "error", err)
+		return domain.DefaultSummaryLanguage
+	}
+	if locale == nil {
+		return domain.DefaultSummaryLanguage
+	}
+	return domain.ResolveSummaryLanguage("", locale.Locale)
+}
+
+// settingsFor returns the effective settings for an article's feed.
+// Without a resolver every feed is summarized with no per-feed cap.
+func (w *SummarizeQueueWorker) settingsFor(ctx context.Context, articleID, feedID string) domain.EffectiveFeedSettings {
+	if w.feedSettings == nil {
+		return domain.EffectiveFeedSettings{FeedID: feedID, SummarizationEnabled: true}
+	}
+	return w.feedSettings.ResolveArticle(ctx, articleID, feedID)
+}
+
 // HasPendingJobs checks if there are any pending summarization jobs in the queue.
 func (w *SummarizeQueueWorker) HasPendingJobs(ctx context.Context) (bool, error) {
 	jobs, err := w.jobRepo.GetPendingJobs(ctx, 1)
@@ -114,6 +196,14 @@ func (w *SummarizeQueueWorker) ProcessQueue(ctx context.Context) error {
 	// Recover stuck running jobs (throttled to once per 5 minutes)
 	w.RecoverStuckJobs(ctx)
 
+	// While news-creator is known to be down, leave jobs pending: dequeuing
+	// them would only burn a retry each. Ready probes news-creator once the
+	// probe interval has passed, so recovery is picked up by a later tick.
+	if w.breaker != nil && !w.breaker.Ready(ctx) {
+		w.logger.DebugContext(ctx, "news-creator circuit open, leaving summarization jobs pending")
+		return nil
+	}
+
 	// Atomically dequeue pending jobs → running in a single transaction
 	jobs, err := w.jobRepo.DequeueJobs(ctx, w.batchSize)
 	if err != nil {
@@ -140,15 +230,28 @@ func (w *SummarizeQueueWorker) ProcessQueue(ctx context.Context) error {
 	var wg sync.WaitGroup
 	var overloadOnce sync.Once
 	var overloaded atomic.Bool
+	var circuitOpen atomic.Bool
 
 	workerFn := func() {
 		defer wg.Done()
 		for job := range jobCh {
+			if circuitOpen.Load() {
+				w.deferJob(ctx, job)
+				continue
+			}
 			if ctx.Err() != nil || overloaded.Load() {
 				continue
 			}
 
 			if err := w.processJob(ctx, job); err != nil {
+				if errors.Is(err, domain.ErrNewsCreatorUnavailable) {
+					if !circuitOpen.Swap(true) {
+						w.logger.WarnContext(ctx, "news-creator circuit opened, deferring remaining queued jobs",
+							"job_id", job.JobID,
+							"article_id", job.ArticleID)
+					}
+					continue
+				}
 				if errors.Is(err, domain.ErrServiceOverloaded) || errors.Is(err, domain.ErrUpstreamBusy) {
 					overloadOnce.Do(func() {
 						overloaded.Store(true)
@@ -181,6 +284,12 @@ func (w *SummarizeQueueWorker) ProcessQueue(ctx context.Context) error {
 				"remaining", len(jobs)-i)
 			break
 		}
+		if circuitOpen.Load() {
+			for _, remaining := range jobs[i:] {
+				w.deferJob(ctx, remaining)
+			}
+			break
+		}
 		jobCh <- job
 	}
 
@@ -223,6 +332,35 @@ func (w *SummarizeQueueWorker) processJob(ctx context.Context, job *domain.Summa
 		return fmt.Errorf("article not found: %s", job.ArticleID)
 	}
 
+	settings := w.settingsFor(ctx, job.ArticleID, article.FeedID)
+	if !settings.SummarizationEnabled {
+		// Jobs queued before the feed was switched off (or via the API) are
+		// closed without a placeholder, so re-enabling the feed lets the
+		// batch enqueue pick the article up again.
+		w.logger.InfoContext(ctx, "skipping article from feed with summarization disabled",
+			"job_id", job.JobID,
+			"article_id", job.ArticleID,
+			"feed_id", settings.FeedID)
+		if updateErr := w.jobRepo.UpdateJobStatus(ctx, job.JobID.String(), domain.SummarizeJobStatusCompleted, "", "skipped: summarization disabled for feed"); updateErr != nil {
+			w.logger.ErrorContext(ctx, "failed to update job status", "error", updateErr, "job_id", job.JobID)
+		}
+		return nil
+	}
+
+	if w.isMediaOnly(ctx, job.ArticleID) {
+		// Podcast and video entries carry at most a short blurb. Save a
+		// placeholder so they leave the Unsummarized count and are not
+		// re-enqueued.
+		w.logger.InfoContext(ctx, "skipping media-only article",
+			"job_id", job.JobID,
+			"article_id", job.ArticleID)
+		w.savePlaceholderSummary(ctx, job.ArticleID, article.UserID, article.Title, placeholderMediaOnly)
+		if updateErr := w.jobRepo.UpdateJobStatus(ctx, job.JobID.String(), domain.SummarizeJobStatusCompleted, "", "skipped: media-only entry"); updateErr != nil {
+			w.logger.ErrorContext(ctx, "failed to update job status", "error", updateErr, "job_id", job.JobID)
+		}
+		return nil
+	}
+
 	if article.Content == "" {
 		errorMsg := "Article content is empty"
 		w.logger.WarnContext(ctx, "article content is empty", "article_id", job.ArticleID)
@@ -246,9 +384,15 @@ func (w *SummarizeQueueWorker) processJob(ctx context.Context, job *domain.Summa
 	}
 
 	// Create article model for summarization
+	language := article.Language
+	if settings.Language != "" {
+		language = settings.Language
+	}
 	articleModel := &domain.Article{
-		ID:      job.ArticleID,
-		Content: content,
+		ID:              job.ArticleID,
+		Content:         content,
+		Language:        language,
+		SummaryLanguage: w.summaryLanguageFor(ctx, article.UserID),
 	}
 
 	// Call summarization service with LOW priority (queue worker is a background job)
@@ -286,6 +430,12 @@ func (w *SummarizeQueueWorker) processJob(ctx context.Context, job *domain.Summa
 			}
 			return nil
 		}
+		if errors.Is(err, domain.ErrNewsCreatorUnavailable) {
+			// The circuit breaker rejected the call before it was made, so
+			// this attempt does not count against the job's retries.
+			w.deferJob(ctx, job)
+			return err
+		}
 		if errors.Is(err, domain.ErrContentNotProcessable) {
 			w.logger.WarnContext(ctx, "non-retryable summarization error, moving to dead_letter immediately",
 				"job_id", job.JobID,
@@ -369,6 +519,10 @@ func (w *SummarizeQueueWorker) processJob(ctx context.Context, job *domain.Summa
 		UserID:          article.UserID,
 		ArticleTitle:    articleTitle,
 		SummaryJapanese: summarized.SummaryJapanese,
+		SummaryLanguage: summarized.Language,
+	}
+	if articleSummary.SummaryLanguage == "" {
+		articleSummary.SummaryLanguage = articleModel.SummaryLanguage
 	}
 
 	saveSummaryStartTime := time.Now()
@@ -405,6 +559,18 @@ func (w *SummarizeQueueWorker) processJob(ctx context.Context, job *domain.Summa
 	return nil
 }
 
+// deferJob returns a dequeued job to pending without touching its retry
+// count, for jobs that were never attempted because news-creator is down.
+func (w *SummarizeQueueWorker) deferJob(ctx context.Context, job *domain.SummarizeJob) {
+	if err := w.jobRepo.UpdateJobStatus(ctx, job.JobID.String(), domain.SummarizeJobStatusPending, "", ""); err != nil {
+		w.logger.ErrorContext(ctx, "failed to defer job back to pending", "error", err, "job_id", job.JobID)
+		return
+	}
+	w.logger.InfoContext(ctx, "summarization job deferred until news-creator recovers",
+		"job_id", job.JobID,
+		"article_id", job.ArticleID)
+}
+
 // savePlaceholderSummary saves a placeholder summary for articles that cannot be
 // summarized (content too short or too long). This prevents the article from being
 // re-enqueued indefinitely and removes it from the Unsummarized count in Stats.
@@ -448,11 +614,27 @@ func (w *SummarizeQueueWorker) EnqueueUnsummarizedBatch(ctx context.Context, bat
 		HasMore: newCursor != nil,
 	}
 
+	enqueuedPerFeed := make(map[string]int)
 	for _, article := range articles {
 		if ctx.Err() != nil {
 			break
 		}
 
+		settings := w.settingsFor(ctx, article.ID, article.FeedID)
+		if !settings.SummarizationEnabled {
+			w.logger.DebugContext(ctx, "batch enqueue skipped: summarization disabled for feed",
+				"article_id", article.ID, "feed_id", settings.FeedID)
+			result.Skipped++
+			continue
+		}
+		if settings.FeedID != "" && settings.BatchSize > 0 && enqueuedPerFeed[settings.FeedID] >= settings.BatchSize {
+			// Left for a later pass once the cursor wraps around.
+			w.logger.DebugContext(ctx, "batch enqueue skipped: feed batch size reached",
+				"article_id", article.ID, "feed_id", settings.FeedID, "batch_size", settings.BatchSize)
+			result.Skipped++
+			continue
+		}
+
 		shouldQueue, reason, guardErr := ShouldQueueSummarizeJob(ctx, article.ID, w.summaryRepo, w.jobRepo, w.logger)
 		if guardErr != nil {
 			w.logger.ErrorContext(ctx, "guard check failed", "article_id", article.ID, "error", guardErr)
@@ -478,6 +660,7 @@ func (w *SummarizeQueueWorker) EnqueueUnsummarizedBatch(ctx context.Context, bat
 			continue
 		}
 
+		enqueuedPerFeed[settings.FeedID]++
 		result.Enqueued++
 	}
 
@@ -10,6 +10,7 @@ import (
 
 	"pre-processor/domain"
 	"pre-processor/repository"
+	"pre-processor/utils"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -1117,3 +1118,216 @@ func TestSummarizeQueueWorker_ProcessQueue_UsesConfiguredConcurrency(t *testing.
 			"expected worker to utilize configured concurrency")
 	})
 }
+
+// stubAPIRepoUnavailable rejects SummarizeArticle the way the circuit
+// breaker decorator does while news-creator is down.
+type stubAPIRepoUnavailable struct {
+	repository.ExternalAPIRepository
+	summarizeCalls int
+}
+
+func (m *stubAPIRepoUnavailable) SummarizeArticle(_ context.Context, _ *domain.Article, _ string) (*domain.SummarizedContent, error) {
+	m.summarizeCalls++
+	return nil, fmt.Errorf("%w: %w", domain.ErrNewsCreatorUnavailable, utils.ErrCircuitOpen)
+}
+
+func TestSummarizeQueueWorker_ProcessQueue_CircuitOpen(t *testing.T) {
+	t.Run("should not dequeue while the circuit is open", func(t *testing.T) {
+		ctx := context.Background()
+		jobRepo := &stubJobRepo{jobs: []*domain.SummarizeJob{
+			{JobID: uuid.New(), ArticleID: "article-1", MaxRetries: 3},
+		}}
+		apiRepo := &stubAPIRepoForWorker{}
+
+		breaker := utils.NewCircuitBreaker("news-creator", 1, time.Hour,
+			func(context.Context) error { return errors.New("down") }, testLogger())
+		breaker.RecordFailure()
+
+		worker := NewSummarizeQueueWorker(jobRepo, &stubArticleRepoForWorker{}, apiRepo, &stubSummaryRepoForWorker{}, testLogger(), 10)
+		worker.SetCircuitBreaker(breaker)
+
+		err := worker.ProcessQueue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, jobRepo.dequeueCalls, "jobs must stay pending while news-creator is down")
+		assert.Equal(t, 0, apiRepo.summarizeCalls)
+	})
+
+	t.Run("should defer jobs without burning retries when rejected", func(t *testing.T) {
+		ctx := context.Background()
+		jobs := []*domain.SummarizeJob{
+			{JobID: uuid.New(), ArticleID: "article-1", MaxRetries: 3},
+			{JobID: uuid.New(), ArticleID: "article-2", MaxRetries: 3},
+			{JobID: uuid.New(), ArticleID: "article-3", MaxRetries: 3},
+		}
+		jobRepo := &stubJobRepoTracking{jobs: jobs}
+		apiRepo := &stubAPIRepoUnavailable{}
+
+		worker := NewSummarizeQueueWorker(jobRepo, &stubArticleRepoForWorker{}, apiRepo, &stubSummaryRepoForWorker{}, testLogger(), 10)
+
+		err := worker.ProcessQueue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, apiRepo.summarizeCalls, "remaining jobs should be deferred without calling news-creator")
+		pending := 0
+		for _, call := range jobRepo.updateCalls {
+			if call.status == domain.SummarizeJobStatusRunning {
+				continue
+			}
+			assert.Equal(t, domain.SummarizeJobStatusPending, call.status, "job %s", call.jobID)
+			assert.Empty(t, call.errorMsg)
+			pending++
+		}
+		assert.Equal(t, len(jobs), pending)
+	})
+}
+
+// stubAPIRepoCapturing records the article passed to SummarizeArticle.
+type stubAPIRepoCapturing struct {
+	repository.ExternalAPIRepository
+	articles []*domain.Article
+}
+
+func (m *stubAPIRepoCapturing) SummarizeArticle(_ context.Context, article *domain.Article, _ string) (*domain.SummarizedContent, error) {
+	m.articles = append(m.articles, article)
+	return &domain.SummarizedContent{SummaryJapanese: "テスト要約"}, nil
+}
+
+func TestSummarizeQueueWorker_ProcessQueue_FeedSettings(t *testing.T) {
+	t.Run("closes jobs of feeds with summarization disabled without summarizing", func(t *testing.T) {
+		ctx := context.Background()
+		jobRepo := &stubJobRepoTracking{jobs: []*domain.SummarizeJob{
+			{JobID: uuid.New(), ArticleID: "article-1", MaxRetries: 3},
+		}}
+		apiRepo := &stubAPIRepoForWorker{}
+		summaryRepo := &stubSummaryRepoForWorker{}
+		resolver := NewFeedSettingsResolver(
+			&stubFeedSettingsRepo{items: []*domain.FeedSettings{disabledFeed("feed-off")}},
+			&stubArticleFeedLookup{feeds: map[string]string{"article-1": "feed-off"}},
+			testFeedSettingsDefaults(), testLogger())
+
+		worker := NewSummarizeQueueWorker(jobRepo, &stubArticleRepoForWorker{}, apiRepo, summaryRepo, testLogger(), 10)
+		worker.SetFeedSettings(resolver)
+
+		err := worker.ProcessQueue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, apiRepo.summarizeCalls)
+		assert.Equal(t, 0, summaryRepo.createCalls, "no placeholder, so re-enabling the feed resumes summarization")
+		if assert.Len(t, jobRepo.updateCalls, 1) {
+			assert.Equal(t, domain.SummarizeJobStatusCompleted, jobRepo.updateCalls[0].status)
+			assert.Contains(t, jobRepo.updateCalls[0].errorMsg, "summarization disabled for feed")
+		}
+	})
+
+	t.Run("passes the feed language override to the summarizer", func(t *testing.T) {
+		ctx := context.Background()
+		lang := "ja"
+		jobRepo := &stubJobRepoTracking{jobs: []*domain.SummarizeJob{
+			{JobID: uuid.New(), ArticleID: "article-1", MaxRetries: 3},
+		}}
+		apiRepo := &stubAPIRepoCapturing{}
+		resolver := NewFeedSettingsResolver(
+			&stubFeedSettingsRepo{items: []*domain.FeedSettings{{FeedID: "feed-ja", LanguageOverride: &lang}}},
+			&stubArticleFeedLookup{feeds: map[string]string{"article-1": "feed-ja"}},
+			testFeedSettingsDefaults(), testLogger())
+
+		worker := NewSummarizeQueueWorker(jobRepo, &stubArticleRepoForWorker{}, apiRepo, &stubSummaryRepoForWorker{}, testLogger(), 10)
+		worker.SetFeedSettings(resolver)
+
+		assert.NoError(t, worker.ProcessQueue(ctx))
+		if assert.Len(t, apiRepo.articles, 1) {
+			assert.Equal(t, "ja", apiRepo.articles[0].Language)
+		}
+	})
+}
+
+func TestEnqueueUnsummarizedBatch_FeedSettings(t *testing.T) {
+	two := 2
+	articles := []*domain.Article{
+		{ID: "article-1", FeedID: "feed-capped"},
+		{ID: "article-2", FeedID: "feed-capped"},
+		{ID: "article-3", FeedID: "feed-capped"},
+		{ID: "article-4", FeedID: "feed-off"},
+		{ID: "article-5", FeedID: "feed-default"},
+	}
+	resolver := NewFeedSettingsResolver(
+		&stubFeedSettingsRepo{items: []*domain.FeedSettings{
+			{FeedID: "feed-capped", BatchSize: &two},
+			disabledFeed("feed-off"),
+		}},
+		nil, testFeedSettingsDefaults(), testLogger())
+
+	jobRepo := &stubJobRepoWithEnqueue{recentSuccessMap: map[string]bool{}}
+	worker := NewSummarizeQueueWorker(jobRepo, &stubArticleRepoWithFind{articles: articles},
+		nil, &stubSummaryRepoWithExists{existsMap: map[string]bool{}}, testLogger(), 10)
+	worker.SetFeedSettings(resolver)
+
+	result, err := worker.EnqueueUnsummarizedBatch(context.Background(), 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Enqueued)
+	assert.Equal(t, 2, result.Skipped, "one over the feed batch size, one from a disabled feed")
+	assert.Equal(t, []string{"article-1", "article-2", "article-5"}, jobRepo.createJobCalls)
+}
+
+// stubArticleMediaRepo reports the articles in mediaOnly as media-only.
+type stubArticleMediaRepo struct {
+	repository.ArticleMediaRepository
+	mediaOnly map[string]bool
+	err       error
+}
+
+func (m *stubArticleMediaRepo) IsMediaOnly(_ context.Context, articleID string) (bool, error) {
+	return m.mediaOnly[articleID], m.err
+}
+
+func TestSummarizeQueueWorker_ProcessQueue_MediaOnly_SavesPlaceholder(t *testing.T) {
+	t.Run("should skip summarization for media-only articles", func(t *testing.T) {
+		ctx := context.Background()
+
+		jobs := []*domain.SummarizeJob{
+			{JobID: uuid.New(), ArticleID: "article-podcast", MaxRetries: 3},
+			{JobID: uuid.New(), ArticleID: "article-text", MaxRetries: 3},
+		}
+
+		jobRepo := &stubJobRepoTracking{jobs: jobs}
+		articleRepo := &stubArticleRepoForWorker{}
+		apiRepo := &stubAPIRepoForWorker{}
+		summaryRepo := &stubSummaryRepoTracking{}
+
+		worker := NewSummarizeQueueWorker(jobRepo, articleRepo, apiRepo, summaryRepo, testLogger(), 10)
+		worker.SetArticleMedia(&stubArticleMediaRepo{mediaOnly: map[string]bool{"article-podcast": true}})
+
+		err := worker.ProcessQueue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, apiRepo.summarizeCalls, "should only summarize the text article")
+		assert.Equal(t, 2, summaryRepo.createCalls, "should save placeholder + real summary")
+		assert.Equal(t, 2, len(jobRepo.updateCalls))
+		assert.Equal(t, domain.SummarizeJobStatusCompleted, jobRepo.updateCalls[0].status)
+		assert.Contains(t, jobRepo.updateCalls[0].errorMsg, "media-only")
+	})
+
+	t.Run("should summarize when the media lookup fails", func(t *testing.T) {
+		ctx := context.Background()
+
+		jobs := []*domain.SummarizeJob{
+			{JobID: uuid.New(), ArticleID: "article-podcast", MaxRetries: 3},
+		}
+
+		jobRepo := &stubJobRepoTracking{jobs: jobs}
+		articleRepo := &stubArticleRepoForWorker{}
+		apiRepo := &stubAPIRepoForWorker{}
+		summaryRepo := &stubSummaryRepoTracking{}
+
+		worker := NewSummarizeQueueWorker(jobRepo, articleRepo, apiRepo, summaryRepo, testLogger(), 10)
+		worker.SetArticleMedia(&stubArticleMediaRepo{err: fmt.Errorf("db error")})
+
+		err := worker.ProcessQueue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, apiRepo.summarizeCalls)
+		assert.Equal(t, "テスト要約", summaryRepo.lastSummary.SummaryJapanese)
+	})
+}
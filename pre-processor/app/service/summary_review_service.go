@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+)
+
+// SummaryReviewService applies human review decisions to sampled summaries.
+// Approving only records the decision; rejecting also removes the summary
+// and re-enqueues the article, the same feedback path a low LLM score takes.
+type SummaryReviewService struct {
+	reviews     repository.SummaryReviewRepository
+	summaryRepo repository.SummaryRepository
+	jobRepo     repository.SummarizeJobRepository
+	logger      *slog.Logger
+}
+
+// NewSummaryReviewService creates a new summary review service.
+func NewSummaryReviewService(
+	reviews repository.SummaryReviewRepository,
+	summaryRepo repository.SummaryRepository,
+	jobRepo repository.SummarizeJobRepository,
+	logger *slog.Logger,
+) *SummaryReviewService {
+	return &SummaryReviewService{
+		reviews:     reviews,
+		summaryRepo: summaryRepo,
+		jobRepo:     jobRepo,
+		logger:      logger,
+	}
+}
+
+// List returns up to limit review items with the given status, oldest first.
+func (s *SummaryReviewService) List(ctx context.Context, status domain.SummaryReviewStatus, limit int) ([]*domain.SummaryReview, error) {
+	return s.reviews.List(ctx, status, limit)
+}
+
+// Approve marks a pending summary as approved.
+func (s *SummaryReviewService) Approve(ctx context.Context, id string, reviewer, note *string) (*domain.SummaryReview, error) {
+	review, err := s.reviews.Decide(ctx, id, domain.SummaryReviewApproved, reviewer, note)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "summary review approved", "review_id", id, "article_id", review.ArticleID)
+	return review, nil
+}
+
+// Reject deletes a pending summary and queues its article for
+// re-summarization before recording the decision, so a failure part way
+// leaves the item pending for the reviewer to retry.
+func (s *SummaryReviewService) Reject(ctx context.Context, id string, reviewer, note *string) (*domain.SummaryReview, error) {
+	pending, err := s.reviews.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, domain.ErrSummaryReviewNotFound
+	}
+	if pending.Status != domain.SummaryReviewPending {
+		return nil, domain.ErrSummaryReviewDecided
+	}
+	articleID := pending.ArticleID
+
+	exists, err := s.summaryRepo.Exists(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check summary: %w", err)
+	}
+	if exists {
+		if err := s.summaryRepo.Delete(ctx, articleID); err != nil {
+			return nil, fmt.Errorf("failed to delete rejected summary: %w", err)
+		}
+	}
+
+	// Compensating transaction: invalidate completed job summary
+	// so the recent_success guard allows re-enqueue.
+	if err := s.jobRepo.InvalidateCompletedJobSummary(ctx, articleID); err != nil {
+		s.logger.ErrorContext(ctx, "failed to invalidate completed job summary (best-effort)",
+			"article_id", articleID, "error", err)
+	}
+	jobID, err := s.jobRepo.CreateJob(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue re-summarization: %w", err)
+	}
+
+	review, err := s.reviews.Decide(ctx, id, domain.SummaryReviewRejected, reviewer, note)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "summary review rejected, re-summarization queued",
+		"review_id", id, "article_id", articleID, "job_id", jobID)
+	return review, nil
+}
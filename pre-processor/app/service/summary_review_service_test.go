@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pre-processor/domain"
+	"pre-processor/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSummaryReviewRepo keeps review items in memory.
+type stubSummaryReviewRepo struct {
+	repository.SummaryReviewRepository
+	items    map[string]*domain.SummaryReview
+	enqueued map[string]domain.SummaryReviewReason
+}
+
+func newStubSummaryReviewRepo(items ...*domain.SummaryReview) *stubSummaryReviewRepo {
+	m := &stubSummaryReviewRepo{items: map[string]*domain.SummaryReview{}, enqueued: map[string]domain.SummaryReviewReason{}}
+	for _, item := range items {
+		m.items[item.ID] = item
+	}
+	return m
+}
+
+func (m *stubSummaryReviewRepo) Enqueue(_ context.Context, articleID string, reason domain.SummaryReviewReason) error {
+	m.enqueued[articleID] = reason
+	return nil
+}
+
+func (m *stubSummaryReviewRepo) Get(_ context.Context, id string) (*domain.SummaryReview, error) {
+	return m.items[id], nil
+}
+
+func (m *stubSummaryReviewRepo) Decide(_ context.Context, id string, status domain.SummaryReviewStatus, reviewer, note *string) (*domain.SummaryReview, error) {
+	item, ok := m.items[id]
+	if !ok {
+		return nil, domain.ErrSummaryReviewNotFound
+	}
+	if item.Status != domain.SummaryReviewPending {
+		return nil, domain.ErrSummaryReviewDecided
+	}
+	item.Status, item.Reviewer, item.Note = status, reviewer, note
+	return item, nil
+}
+
+// stubReviewSummaryRepo tracks summary deletions.
+type stubReviewSummaryRepo struct {
+	repository.SummaryRepository
+	exists    bool
+	deleted   []string
+	deleteErr error
+	items     []*domain.ArticleWithSummary
+}
+
+func (m *stubReviewSummaryRepo) Exists(_ context.Context, _ string) (bool, error) {
+	return m.exists, nil
+}
+
+func (m *stubReviewSummaryRepo) Delete(_ context.Context, articleID string) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deleted = append(m.deleted, articleID)
+	return nil
+}
+
+func (m *stubReviewSummaryRepo) FindArticlesWithSummaries(_ context.Context, _ *domain.Cursor, _ int) ([]*domain.ArticleWithSummary, *domain.Cursor, error) {
+	return m.items, nil, nil
+}
+
+// stubReviewJobRepo tracks re-summarization requests.
+type stubReviewJobRepo struct {
+	repository.SummarizeJobRepository
+	invalidated []string
+	created     []string
+}
+
+func (m *stubReviewJobRepo) InvalidateCompletedJobSummary(_ context.Context, articleID string) error {
+	m.invalidated = append(m.invalidated, articleID)
+	return nil
+}
+
+func (m *stubReviewJobRepo) CreateJob(_ context.Context, articleID string) (string, error) {
+	m.created = append(m.created, articleID)
+	return "job-1", nil
+}
+
+func pendingReview(id, articleID string) *domain.SummaryReview {
+	return &domain.SummaryReview{ID: id, ArticleID: articleID, Reason: domain.SummaryReviewSampled, Status: domain.SummaryReviewPending}
+}
+
+func TestSummaryReviewService_Reject(t *testing.T) {
+	t.Run("should delete the summary and re-enqueue the article", func(t *testing.T) {
+		reviews := newStubSummaryReviewRepo(pendingReview("r1", "article-1"))
+		summaries := &stubReviewSummaryRepo{exists: true}
+		jobs := &stubReviewJobRepo{}
+		svc := NewSummaryReviewService(reviews, summaries, jobs, testLogger())
+		note := "hallucinated figures"
+
+		review, err := svc.Reject(context.Background(), "r1", nil, &note)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.SummaryReviewRejected, review.Status)
+		assert.Equal(t, &note, review.Note)
+		assert.Equal(t, []string{"article-1"}, summaries.deleted)
+		assert.Equal(t, []string{"article-1"}, jobs.invalidated)
+		assert.Equal(t, []string{"article-1"}, jobs.created)
+	})
+
+	t.Run("should leave the item pending when the summary cannot be deleted", func(t *testing.T) {
+		reviews := newStubSummaryReviewRepo(pendingReview("r1", "article-1"))
+		summaries := &stubReviewSummaryRepo{exists: true, deleteErr: errors.New("backend down")}
+		jobs := &stubReviewJobRepo{}
+		svc := NewSummaryReviewService(reviews, summaries, jobs, testLogger())
+
+		_, err := svc.Reject(context.Background(), "r1", nil, nil)
+
+		require.Error(t, err)
+		assert.Equal(t, domain.SummaryReviewPending, reviews.items["r1"].Status)
+		assert.Empty(t, jobs.created)
+	})
+
+	t.Run("should not touch summaries of decided or missing items", func(t *testing.T) {
+		decided := pendingReview("r1", "article-1")
+		decided.Status = domain.SummaryReviewApproved
+		reviews := newStubSummaryReviewRepo(decided)
+		summaries := &stubReviewSummaryRepo{exists: true}
+		svc := NewSummaryReviewService(reviews, summaries, &stubReviewJobRepo{}, testLogger())
+
+		_, err := svc.Reject(context.Background(), "r1", nil, nil)
+		assert.ErrorIs(t, err, domain.ErrSummaryReviewDecided)
+		_, err = svc.Reject(context.Background(), "missing", nil, nil)
+		assert.ErrorIs(t, err, domain.ErrSummaryReviewNotFound)
+		assert.Empty(t, summaries.deleted)
+	})
+}
+
+func TestSummaryReviewService_Approve(t *testing.T) {
+	reviews := newStubSummaryReviewRepo(pendingReview("r1", "article-1"))
+	summaries := &stubReviewSummaryRepo{exists: true}
+	jobs := &stubReviewJobRepo{}
+	svc := NewSummaryReviewService(reviews, summaries, jobs, testLogger())
+
+	review, err := svc.Approve(context.Background(), "r1", nil, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.SummaryReviewApproved, review.Status)
+	assert.Empty(t, summaries.deleted)
+	assert.Empty(t, jobs.created)
+}
+
+func TestQualityChecker_SamplingSkipsUnselectedSummaries(t *testing.T) {
+	confident := "この記事は新しい技術の導入について説明している。企業は段階的な移行計画を立て、既存システムとの互換性を検証した。" +
+		"導入後は処理時間が大幅に短縮され、運用コストも削減された。一方で人材育成と保守体制の整備が課題として残っている。"
+	summaries := &stubReviewSummaryRepo{items: []*domain.ArticleWithSummary{
+		{ArticleID: "article-1", SummaryJapanese: confident + "一"},
+		{ArticleID: "article-2", SummaryJapanese: confident + "二"},
+	}}
+	reviews := newStubSummaryReviewRepo()
+	svc := NewSampledQualityCheckerService(summaries, nil, nil, &stubReviewJobRepo{}, nil,
+		QualitySampling{SampleRate: 0, Reviews: reviews}, testLogger())
+
+	result, err := svc.CheckQuality(context.Background(), 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ProcessedCount)
+	assert.Equal(t, 2, result.SkippedCount)
+	assert.Zero(t, result.SuccessCount)
+	assert.Empty(t, reviews.enqueued)
+}
@@ -0,0 +1,10 @@
+// Package spec embeds the pre-processor OpenAPI document, the source of the
+// handler/openapi server code and of request validation.
+package spec
+
+import _ "embed"
+
+// OpenAPI is openapi.yaml.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
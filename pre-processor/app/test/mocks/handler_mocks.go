@@ -11,6 +11,7 @@ package mocks
 
 import (
 	context "context"
+	orchestrator "pre-processor/orchestrator"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,20 @@ func (m *MockJobHandler) EXPECT() *MockJobHandlerMockRecorder {
 	return m.recorder
 }
 
+// JobStatuses mocks base method.
+func (m *MockJobHandler) JobStatuses() []orchestrator.JobStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JobStatuses")
+	ret0, _ := ret[0].([]orchestrator.JobStatus)
+	return ret0
+}
+
+// JobStatuses indicates an expected call of JobStatuses.
+func (mr *MockJobHandlerMockRecorder) JobStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobStatuses", reflect.TypeOf((*MockJobHandler)(nil).JobStatuses))
+}
+
 // StartArticleSyncJob mocks base method.
 func (m *MockJobHandler) StartArticleSyncJob(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -110,6 +125,20 @@ func (mr *MockJobHandlerMockRecorder) StartSummarizeQueueWorker(ctx any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSummarizeQueueWorker", reflect.TypeOf((*MockJobHandler)(nil).StartSummarizeQueueWorker), ctx)
 }
 
+// StartThumbnailJob mocks base method.
+func (m *MockJobHandler) StartThumbnailJob(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartThumbnailJob", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartThumbnailJob indicates an expected call of StartThumbnailJob.
+func (mr *MockJobHandlerMockRecorder) StartThumbnailJob(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartThumbnailJob", reflect.TypeOf((*MockJobHandler)(nil).StartThumbnailJob), ctx)
+}
+
 // Stop mocks base method.
 func (m *MockJobHandler) Stop() error {
 	m.ctrl.T.Helper()
@@ -124,6 +153,20 @@ func (mr *MockJobHandlerMockRecorder) Stop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockJobHandler)(nil).Stop))
 }
 
+// TriggerJob mocks base method.
+func (m *MockJobHandler) TriggerJob(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TriggerJob", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TriggerJob indicates an expected call of TriggerJob.
+func (mr *MockJobHandlerMockRecorder) TriggerJob(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TriggerJob", reflect.TypeOf((*MockJobHandler)(nil).TriggerJob), name)
+}
+
 // MockHealthHandler is a mock of HealthHandler interface.
 type MockHealthHandler struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,171 @@
+package summarize
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// extractiveMaxSentences and extractiveMaxRunes bound the fallback
+	// summary to roughly what a timeline card shows.
+	extractiveMaxSentences = 3
+	extractiveMaxRunes     = 400
+	// extractiveMinSentenceRunes drops captions, bylines and other
+	// fragments that are never a useful summary line.
+	extractiveMinSentenceRunes = 15
+)
+
+// ExtractiveSummary picks the most representative sentences of content, in
+// their original order. It is the fallback while news-creator is
+// unavailable: no model, no translation, so the result is in the article's
+// own language. Sentences are scored by the average document frequency of
+// their terms (words for space-delimited scripts, character bigrams for
+// CJK) with a bonus for appearing early, since news leads carry the gist.
+func ExtractiveSummary(content string) string {
+	sentences := splitSentences(content)
+	if len(sentences) == 0 {
+		return ""
+	}
+
+	freq := make(map[string]int)
+	terms := make([][]string, len(sentences))
+	for i, s := range sentences {
+		terms[i] = sentenceTerms(s)
+		for _, t := range terms[i] {
+			freq[t]++
+		}
+	}
+
+	type scored struct {
+		index int
+		score float64
+	}
+	ranked := make([]scored, 0, len(sentences))
+	for i := range sentences {
+		if len(terms[i]) == 0 {
+			continue
+		}
+		total := 0
+		for _, t := range terms[i] {
+			total += freq[t]
+		}
+		position := 1.0 / float64(i+1)
+		ranked = append(ranked, scored{index: i, score: float64(total)/float64(len(terms[i])) + 2*position})
+	}
+	slices.SortStableFunc(ranked, func(a, b scored) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var picked []int
+	runes := 0
+	for _, r := range ranked {
+		if len(picked) == extractiveMaxSentences {
+			break
+		}
+		n := utf8.RuneCountInString(sentences[r.index])
+		if len(picked) > 0 && runes+n > extractiveMaxRunes {
+			continue
+		}
+		picked = append(picked, r.index)
+		runes += n
+	}
+	slices.Sort(picked)
+
+	parts := make([]string, len(picked))
+	for i, idx := range picked {
+		parts[i] = sentences[idx]
+	}
+	return truncateRunes(strings.Join(parts, " "), extractiveMaxRunes)
+}
+
+// splitSentences splits on Latin and CJK sentence terminators and line
+// breaks, dropping fragments shorter than extractiveMinSentenceRunes. If
+// nothing survives, the whole trimmed text is one sentence.
+func splitSentences(content string) []string {
+	var sentences []string
+	var b strings.Builder
+	flush := func() {
+		s := strings.Join(strings.Fields(b.String()), " ")
+		b.Reset()
+		if utf8.RuneCountInString(s) >= extractiveMinSentenceRunes {
+			sentences = append(sentences, s)
+		}
+	}
+
+	runes := []rune(content)
+	for i, r := range runes {
+		if r == '\n' {
+			flush()
+			continue
+		}
+		b.WriteRune(r)
+		switch r {
+		case '。', '！', '？':
+			flush()
+		case '.', '!', '?':
+			// Only a terminator when followed by whitespace or the end, so
+			// "3.5" and "e.g." mid-sentence stay intact more often than not.
+			if i+1 == len(runes) || unicode.IsSpace(runes[i+1]) {
+				flush()
+			}
+		}
+	}
+	flush()
+
+	if len(sentences) == 0 {
+		if s := strings.Join(strings.Fields(content), " "); s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+	return sentences
+}
+
+// sentenceTerms returns lowercase words of three or more letters and
+// bigrams of consecutive CJK characters.
+func sentenceTerms(sentence string) []string {
+	var terms []string
+	var word []rune
+	var prevCJK rune
+	flushWord := func() {
+		if len(word) >= 3 {
+			terms = append(terms, strings.ToLower(string(word)))
+		}
+		word = word[:0]
+	}
+	for _, r := range sentence {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flushWord()
+			if prevCJK != 0 {
+				terms = append(terms, string([]rune{prevCJK, r}))
+			}
+			prevCJK = r
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			prevCJK = 0
+			word = append(word, r)
+		default:
+			prevCJK = 0
+			flushWord()
+		}
+	}
+	flushWord()
+	return terms
+}
+
+// truncateRunes cuts s to at most n runes, marking the cut with an ellipsis.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
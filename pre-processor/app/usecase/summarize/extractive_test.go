@@ -0,0 +1,51 @@
+package summarize
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractiveSummary(t *testing.T) {
+	t.Run("should keep representative sentences in original order", func(t *testing.T) {
+		content := "The city council approved the new transit budget on Monday. " +
+			"Photo: staff. " +
+			"The transit budget adds two bus lines and extends late-night service. " +
+			"Weather tomorrow is expected to be mild and sunny across the region. " +
+			"Council members said the transit budget will be reviewed next year. " +
+			"A local bakery celebrated its tenth anniversary with free pastries."
+
+		summary := ExtractiveSummary(content)
+
+		assert.True(t, strings.HasPrefix(summary, "The city council approved"))
+		assert.Contains(t, summary, "two bus lines")
+		assert.NotContains(t, summary, "Photo: staff")
+		assert.NotContains(t, summary, "bakery")
+		assert.Less(t, strings.Index(summary, "two bus lines"), strings.Index(summary, "reviewed next year"))
+	})
+
+	t.Run("should split Japanese sentences", func(t *testing.T) {
+		content := "政府は新しいエネルギー政策を発表しました。この政策は再生可能エネルギーの導入を加速させます。" +
+			"明日の天気は晴れの予報で気温も上がりそうです。専門家はエネルギー政策の効果に期待を示しました。"
+
+		summary := ExtractiveSummary(content)
+
+		assert.True(t, strings.HasPrefix(summary, "政府は新しいエネルギー政策を発表しました。"))
+		assert.LessOrEqual(t, utf8.RuneCountInString(summary), extractiveMaxRunes)
+	})
+
+	t.Run("should bound the summary length", func(t *testing.T) {
+		content := strings.Repeat("This extremely long sentence keeps going without any terminator at all ", 20)
+
+		summary := ExtractiveSummary(content)
+
+		assert.Equal(t, extractiveMaxRunes, utf8.RuneCountInString(summary))
+		assert.True(t, strings.HasSuffix(summary, "…"))
+	})
+
+	t.Run("should return empty for blank content", func(t *testing.T) {
+		assert.Empty(t, ExtractiveSummary("  \n\t "))
+	})
+}
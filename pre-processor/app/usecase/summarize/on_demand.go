@@ -2,6 +2,7 @@ package summarize
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -66,6 +67,9 @@ type OnDemandService struct {
 	articleRepo repository.ArticleRepository
 	summaryRepo repository.SummaryRepository
 	apiRepo     repository.ExternalAPIRepository
+	// userLocales, when set, supplies the owner's locale for requests that
+	// do not name a summary language.
+	userLocales repository.UserLocaleRepository
 	logger      *slog.Logger
 }
 
@@ -84,18 +88,33 @@ func NewOnDemandService(
 	}
 }
 
+// SetUserLocales makes requests without a language summarize in the
+// article owner's locale instead of domain.DefaultSummaryLanguage.
+func (s *OnDemandService) SetUserLocales(repo repository.UserLocaleRepository) {
+	s.userLocales = repo
+}
+
 // SummarizeRequest represents a request to summarize an article.
 type SummarizeRequest struct {
 	ArticleID string
 	Content   string // If empty, fetched from DB
 	Title     string
 	Priority  string // "high" (UI) / "low" (batch)
+	// Language requests a summary language ("ja", "en"), e.g. to
+	// re-summarize in another language. Empty uses the owner's locale.
+	Language string
 }
 
 // SummarizeResult represents the result of summarization.
 type SummarizeResult struct {
 	Summary   string
 	ArticleID string
+	// Language is the language Summary is written in.
+	Language string
+	// Fallback is true when news-creator was unavailable and Summary is an
+	// unsaved extractive summary in the article's own language. Asking again
+	// once news-creator is back yields and saves the real summary.
+	Fallback bool
 }
 
 // ResolvedArticle is the result of resolving an article's content from the request or DB.
@@ -104,11 +123,21 @@ type ResolvedArticle struct {
 	Content   string
 	Title     string
 	UserID    string
+	// SourceLanguage is the article's detected language, if known.
+	SourceLanguage string
+	// Language is the language to summarize in.
+	Language string
 }
 
 // ResolveArticle fetches the article from DB and resolves content, applying Zero Trust text extraction.
 // Returns the resolved article data needed for summarization.
 func (s *OnDemandService) ResolveArticle(ctx context.Context, req SummarizeRequest) (*ResolvedArticle, error) {
+	if req.Language != "" {
+		if _, ok := domain.NormalizeSummaryLanguage(req.Language); !ok {
+			return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedSummaryLanguage, req.Language)
+		}
+	}
+
 	// Fetch article from DB
 	article, err := s.articleRepo.FindByID(ctx, req.ArticleID)
 	if err != nil {
@@ -142,13 +171,32 @@ func (s *OnDemandService) ResolveArticle(ctx context.Context, req SummarizeReque
 	}
 
 	return &ResolvedArticle{
-		ArticleID: req.ArticleID,
-		Content:   content,
-		Title:     title,
-		UserID:    article.UserID,
+		ArticleID:      req.ArticleID,
+		Content:        content,
+		Title:          title,
+		UserID:         article.UserID,
+		SourceLanguage: article.Language,
+		Language:       domain.ResolveSummaryLanguage(req.Language, s.userLocale(ctx, article.UserID)),
 	}, nil
 }
 
+// userLocale returns the stored locale of userID, or "" if there is none or
+// the lookup fails.
+func (s *OnDemandService) userLocale(ctx context.Context, userID string) string {
+	if s.userLocales == nil || userID == "" {
+		return ""
+	}
+	locale, err := s.userLocales.Get(ctx, userID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to look up user locale", "user_id", userID, "error", err)
+		return ""
+	}
+	if locale == nil {
+		return ""
+	}
+	return locale.Locale
+}
+
 // Summarize performs the full summarization flow: resolve article, call API, save result.
 func (s *OnDemandService) Summarize(ctx context.Context, req SummarizeRequest) (*SummarizeResult, error) {
 	resolved, err := s.ResolveArticle(ctx, req)
@@ -157,13 +205,24 @@ func (s *OnDemandService) Summarize(ctx context.Context, req SummarizeRequest) (
 	}
 
 	// Call summarization API
-	article := &domain.Article{
-		ID:      resolved.ArticleID,
-		Content: resolved.Content,
-	}
+	article := resolved.Article()
 
 	summarized, err := s.apiRepo.SummarizeArticle(ctx, article, req.Priority)
 	if err != nil {
+		if errors.Is(err, domain.ErrNewsCreatorUnavailable) {
+			if fallback := ExtractiveSummary(resolved.Content); fallback != "" {
+				// Not persisted: a saved fallback would count as summarized
+				// and the article would never get its real summary.
+				s.logger.WarnContext(ctx, "news-creator unavailable, returning extractive fallback summary",
+					"article_id", resolved.ArticleID, "error", err)
+				return &SummarizeResult{
+					Summary:   fallback,
+					ArticleID: resolved.ArticleID,
+					Language:  resolved.SourceLanguage,
+					Fallback:  true,
+				}, nil
+			}
+		}
 		return nil, fmt.Errorf("failed to generate summary: %w", err)
 	}
 
@@ -180,6 +239,10 @@ func (s *OnDemandService) Summarize(ctx context.Context, req SummarizeRequest) (
 		UserID:          resolved.UserID,
 		ArticleTitle:    articleTitle,
 		SummaryJapanese: summarized.SummaryJapanese,
+		SummaryLanguage: summarized.Language,
+	}
+	if articleSummary.SummaryLanguage == "" {
+		articleSummary.SummaryLanguage = resolved.Language
 	}
 
 	// A save failure must fail the request — matching the queue-worker path
@@ -196,9 +259,20 @@ func (s *OnDemandService) Summarize(ctx context.Context, req SummarizeRequest) (
 	return &SummarizeResult{
 		Summary:   summarized.SummaryJapanese,
 		ArticleID: resolved.ArticleID,
+		Language:  articleSummary.SummaryLanguage,
 	}, nil
 }
 
+// Article returns the article to send to news-creator.
+func (r *ResolvedArticle) Article() *domain.Article {
+	return &domain.Article{
+		ID:              r.ArticleID,
+		Content:         r.Content,
+		Language:        r.SourceLanguage,
+		SummaryLanguage: r.Language,
+	}
+}
+
 // extractText applies Zero Trust text extraction from potentially HTML content.
 func extractText(content string, logger *slog.Logger, ctx context.Context, articleID string) string {
 	// First extraction pass
@@ -3,6 +3,7 @@ package summarize
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
@@ -242,6 +243,29 @@ func TestOnDemandService_Summarize(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to generate summary")
 	})
+
+	t.Run("should return unsaved extractive fallback when news-creator is unavailable", func(t *testing.T) {
+		articleRepo := &stubArticleRepo{
+			findByIDResult: &domain.Article{
+				ID:      "art-1",
+				Content: "The city council approved the new transit budget on Monday. The budget funds two new bus lines. Critics said the transit budget ignores cyclists.",
+				UserID:  "user-1",
+			},
+		}
+		summaryRepo := &stubSummaryRepo{}
+		apiRepo := &stubAPIRepo{summarizeErr: fmt.Errorf("%w: circuit breaker is open", domain.ErrNewsCreatorUnavailable)}
+		svc := NewOnDemandService(articleRepo, summaryRepo, apiRepo, testLogger())
+
+		result, err := svc.Summarize(context.Background(), SummarizeRequest{
+			ArticleID: "art-1",
+			Priority:  "high",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.Fallback)
+		assert.Contains(t, result.Summary, "transit budget")
+		assert.False(t, summaryRepo.createCalled, "fallback summaries must not be saved")
+	})
 }
 
 func TestExtractText(t *testing.T) {
@@ -258,3 +282,82 @@ func TestExtractText(t *testing.T) {
 		assert.Equal(t, content, result)
 	})
 }
+
+// stubUserLocaleRepo implements repository.UserLocaleRepository for testing
+type stubUserLocaleRepo struct {
+	locales map[string]string
+}
+
+func (s *stubUserLocaleRepo) Get(_ context.Context, userID string) (*domain.UserLocale, error) {
+	locale, ok := s.locales[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.UserLocale{UserID: userID, Locale: locale}, nil
+}
+func (s *stubUserLocaleRepo) Upsert(_ context.Context, l *domain.UserLocale) (*domain.UserLocale, error) {
+	return l, nil
+}
+func (s *stubUserLocaleRepo) Delete(_ context.Context, _ string) (bool, error) { return false, nil }
+
+func TestOnDemandService_SummaryLanguage(t *testing.T) {
+	article := &domain.Article{
+		ID:       "art-1",
+		Content:  "This is a long enough article content for testing.",
+		UserID:   "user-1",
+		Language: "en",
+	}
+
+	t.Run("should default to Japanese without a user locale", func(t *testing.T) {
+		svc := NewOnDemandService(&stubArticleRepo{findByIDResult: article}, nil, nil, testLogger())
+
+		resolved, err := svc.ResolveArticle(context.Background(), SummarizeRequest{ArticleID: "art-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultSummaryLanguage, resolved.Language)
+		assert.Equal(t, "en", resolved.Article().Language)
+	})
+
+	t.Run("should use the owner's locale", func(t *testing.T) {
+		svc := NewOnDemandService(&stubArticleRepo{findByIDResult: article}, nil, nil, testLogger())
+		svc.SetUserLocales(&stubUserLocaleRepo{locales: map[string]string{"user-1": "en-US"}})
+
+		resolved, err := svc.ResolveArticle(context.Background(), SummarizeRequest{ArticleID: "art-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "en", resolved.Article().SummaryLanguage)
+	})
+
+	t.Run("should prefer the requested language and save it", func(t *testing.T) {
+		summaryRepo := &recordingSummaryRepo{}
+		apiRepo := &stubAPIRepo{summarizeResult: &domain.SummarizedContent{ArticleID: "art-1", SummaryJapanese: "要約"}}
+		svc := NewOnDemandService(&stubArticleRepo{findByIDResult: article}, summaryRepo, apiRepo, testLogger())
+		svc.SetUserLocales(&stubUserLocaleRepo{locales: map[string]string{"user-1": "en-US"}})
+
+		result, err := svc.Summarize(context.Background(), SummarizeRequest{ArticleID: "art-1", Language: "ja"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ja", result.Language)
+		require.NotNil(t, summaryRepo.saved)
+		assert.Equal(t, "ja", summaryRepo.saved.SummaryLanguage)
+	})
+
+	t.Run("should reject an unsupported language", func(t *testing.T) {
+		svc := NewOnDemandService(&stubArticleRepo{findByIDResult: article}, nil, nil, testLogger())
+
+		_, err := svc.ResolveArticle(context.Background(), SummarizeRequest{ArticleID: "art-1", Language: "fr"})
+
+		assert.ErrorIs(t, err, domain.ErrUnsupportedSummaryLanguage)
+	})
+}
+
+// recordingSummaryRepo records the summary passed to Create.
+type recordingSummaryRepo struct {
+	stubSummaryRepo
+	saved *domain.ArticleSummary
+}
+
+func (r *recordingSummaryRepo) Create(_ context.Context, s *domain.ArticleSummary) error {
+	r.saved = s
+	return nil
+}
@@ -0,0 +1,216 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Allow while calls are being
+// short-circuited.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every call until a health probe succeeds.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial call through after a successful
+	// probe; its outcome closes or re-opens the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker trips after a run of consecutive failures and recovers on
+// a health probe rather than on a timer, so a dependency that is still
+// down is never sent real work just because a timeout elapsed.
+//
+// Probing is lazy: while open, the first Allow or Ready call after
+// probeInterval runs the probe inline. No goroutine is started, and an idle
+// service does not probe at all.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	probeInterval    time.Duration
+	probeTimeout     time.Duration
+	probe            func(ctx context.Context) error
+	logger           *slog.Logger
+	now              func() time.Time
+
+	mu              sync.Mutex
+	state           CircuitState
+	failures        int
+	nextProbe       time.Time
+	probing         bool
+	trialInFlight   bool
+	lastStateChange time.Time
+}
+
+// NewCircuitBreaker returns a closed breaker that opens after
+// failureThreshold consecutive failures and, once open, calls probe at most
+// every probeInterval.
+func NewCircuitBreaker(name string, failureThreshold int, probeInterval time.Duration, probe func(ctx context.Context) error, logger *slog.Logger) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		probeInterval:    probeInterval,
+		probeTimeout:     10 * time.Second,
+		probe:            probe,
+		logger:           logger,
+		now:              time.Now,
+	}
+}
+
+// State returns the current state without probing.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// Ready reports whether a call would currently be attempted, probing the
+// dependency first if the circuit is open and a probe is due. Unlike Allow
+// it does not claim the half-open trial slot, so callers can use it to skip
+// work (e.g. not dequeue jobs) without affecting the breaker.
+func (cb *CircuitBreaker) Ready(ctx context.Context) bool {
+	cb.maybeProbe(ctx)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state == CircuitClosed || (cb.state == CircuitHalfOpen && !cb.trialInFlight)
+}
+
+// Allow returns nil if the call may proceed, ErrCircuitOpen otherwise. A
+// nil return must be followed by exactly one of RecordSuccess,
+// RecordFailure or RecordNeutral.
+func (cb *CircuitBreaker) Allow(ctx context.Context) error {
+	cb.maybeProbe(ctx)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case CircuitClosed:
+		return nil
+	case CircuitHalfOpen:
+		if cb.trialInFlight {
+			return ErrCircuitOpen
+		}
+		cb.trialInFlight = true
+		return nil
+	default:
+		return ErrCircuitOpen
+	}
+}
+
+// RecordSuccess closes a half-open circuit and resets the failure run.
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures = 0
+	if cb.state == CircuitHalfOpen {
+		cb.trialInFlight = false
+		cb.transition(CircuitClosed)
+	}
+}
+
+// RecordFailure counts a failure that indicates the dependency is down. It
+// opens a closed circuit at the threshold and re-opens a half-open one
+// immediately.
+func (cb *CircuitBreaker) RecordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures++
+	switch cb.state {
+	case CircuitHalfOpen:
+		cb.trialInFlight = false
+		cb.open()
+	case CircuitClosed:
+		if cb.failures >= cb.failureThreshold {
+			cb.open()
+		}
+	}
+}
+
+// RecordNeutral ends a call whose outcome says nothing about the
+// dependency's health (bad input, caller cancellation, backpressure). It
+// only frees the half-open trial slot.
+func (cb *CircuitBreaker) RecordNeutral() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitHalfOpen {
+		cb.trialInFlight = false
+	}
+}
+
+// open must be called with mu held.
+func (cb *CircuitBreaker) open() {
+	cb.nextProbe = cb.now().Add(cb.probeInterval)
+	cb.transition(CircuitOpen)
+}
+
+// transition must be called with mu held.
+func (cb *CircuitBreaker) transition(to CircuitState) {
+	if cb.state == to {
+		return
+	}
+	from := cb.state
+	now := cb.now()
+	cb.logger.Warn("circuit breaker state changed",
+		"breaker", cb.name,
+		"from", from.String(),
+		"to", to.String(),
+		"consecutive_failures", cb.failures,
+		"previous_state_duration_ms", now.Sub(cb.lastStateChange).Milliseconds())
+	cb.state = to
+	cb.lastStateChange = now
+}
+
+// maybeProbe runs the health probe if the circuit is open, a probe is due
+// and no other caller is already probing. A successful probe half-opens
+// the circuit.
+func (cb *CircuitBreaker) maybeProbe(ctx context.Context) {
+	cb.mu.Lock()
+	if cb.state != CircuitOpen || cb.probing || cb.now().Before(cb.nextProbe) {
+		cb.mu.Unlock()
+		return
+	}
+	cb.probing = true
+	cb.mu.Unlock()
+
+	probeCtx, cancel := context.WithTimeout(ctx, cb.probeTimeout)
+	err := cb.probe(probeCtx)
+	cancel()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probing = false
+	if cb.state != CircuitOpen {
+		return
+	}
+	if err != nil {
+		cb.nextProbe = cb.now().Add(cb.probeInterval)
+		cb.logger.Info("circuit breaker probe failed, staying open",
+			"breaker", cb.name,
+			"next_probe_in_ms", cb.probeInterval.Milliseconds(),
+			"error", err)
+		return
+	}
+	cb.transition(CircuitHalfOpen)
+}
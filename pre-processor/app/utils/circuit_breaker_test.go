@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(threshold int, probeErr *error, probes *int) (*CircuitBreaker, *time.Time) {
+	now := time.Unix(0, 0)
+	cb := NewCircuitBreaker("test", threshold, 30*time.Second, func(context.Context) error {
+		*probes++
+		return *probeErr
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	cb.now = func() time.Time { return now }
+	return cb, &now
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	var probeErr error
+	probes := 0
+	cb, _ := newTestBreaker(3, &probeErr, &probes)
+	ctx := context.Background()
+
+	cb.RecordFailure()
+	cb.RecordFailure()
+	cb.RecordSuccess() // resets the run
+	cb.RecordFailure()
+	cb.RecordFailure()
+	if cb.State() != CircuitClosed {
+		t.Fatalf("state = %s, want closed before threshold", cb.State())
+	}
+
+	cb.RecordFailure()
+	if cb.State() != CircuitOpen {
+		t.Fatalf("state = %s, want open at threshold", cb.State())
+	}
+	if err := cb.Allow(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() = %v, want ErrCircuitOpen", err)
+	}
+	if probes != 0 {
+		t.Fatalf("probed %d times before the probe interval elapsed", probes)
+	}
+}
+
+func TestCircuitBreaker_RecoversOnlyAfterSuccessfulProbe(t *testing.T) {
+	probeErr := errors.New("model not loaded")
+	probes := 0
+	cb, now := newTestBreaker(1, &probeErr, &probes)
+	ctx := context.Background()
+
+	cb.RecordFailure()
+	*now = now.Add(31 * time.Second)
+
+	if cb.Ready(ctx) {
+		t.Fatal("Ready() = true with a failing probe")
+	}
+	if probes != 1 {
+		t.Fatalf("probes = %d, want 1", probes)
+	}
+	// The failed probe pushes the next one out by a full interval.
+	cb.Ready(ctx)
+	if probes != 1 {
+		t.Fatalf("probes = %d, want no re-probe before the interval", probes)
+	}
+
+	probeErr = nil
+	*now = now.Add(31 * time.Second)
+	if !cb.Ready(ctx) {
+		t.Fatal("Ready() = false after a successful probe")
+	}
+	if cb.State() != CircuitHalfOpen {
+		t.Fatalf("state = %s, want half_open", cb.State())
+	}
+
+	// Half-open admits exactly one trial call.
+	if err := cb.Allow(ctx); err != nil {
+		t.Fatalf("first Allow() in half-open = %v", err)
+	}
+	if err := cb.Allow(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second Allow() in half-open = %v, want ErrCircuitOpen", err)
+	}
+	cb.RecordSuccess()
+	if cb.State() != CircuitClosed {
+		t.Fatalf("state = %s, want closed after successful trial", cb.State())
+	}
+}
+
+func TestCircuitBreaker_FailedTrialReopens(t *testing.T) {
+	var probeErr error
+	probes := 0
+	cb, now := newTestBreaker(5, &probeErr, &probes)
+	ctx := context.Background()
+
+	for range 5 {
+		cb.RecordFailure()
+	}
+	*now = now.Add(31 * time.Second)
+	if err := cb.Allow(ctx); err != nil {
+		t.Fatalf("Allow() after successful probe = %v", err)
+	}
+	cb.RecordFailure()
+	if cb.State() != CircuitOpen {
+		t.Fatalf("state = %s, want open after a failed trial", cb.State())
+	}
+}
+
+func TestCircuitBreaker_NeutralFreesTrialSlot(t *testing.T) {
+	var probeErr error
+	probes := 0
+	cb, now := newTestBreaker(1, &probeErr, &probes)
+	ctx := context.Background()
+
+	cb.RecordFailure()
+	*now = now.Add(31 * time.Second)
+	if err := cb.Allow(ctx); err != nil {
+		t.Fatalf("Allow() = %v", err)
+	}
+	cb.RecordNeutral()
+	if cb.State() != CircuitHalfOpen {
+		t.Fatalf("state = %s, want half_open after a neutral outcome", cb.State())
+	}
+	if err := cb.Allow(ctx); err != nil {
+		t.Fatalf("Allow() after neutral outcome = %v, want the trial slot back", err)
+	}
+}
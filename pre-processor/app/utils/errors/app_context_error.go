@@ -20,6 +20,16 @@ type AppContextError struct {
 	Cause     error                  `json:"-"`                   // Underlying error (not serialized)
 	Context   map[string]interface{} `json:"context,omitempty"`   // Additional context information
 	ErrorID   string                 `json:"-"`                   // Unique ID for log correlation (not serialized to client)
+	// Violations lists what a VALIDATION_ERROR request got wrong. They name
+	// fields and rules, never values, so they are safe to send to clients.
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+// FieldViolation is one way a request breaks the API schema, e.g.
+// {Field: "body.article_id", Reason: "required"}.
+type FieldViolation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
 }
 
 // Error implements the error interface
@@ -114,6 +124,8 @@ type SecureErrorDetail struct {
 	Message   string `json:"message"`
 	ErrorID   string `json:"error_id,omitempty"`
 	Retryable bool   `json:"retryable,omitempty"`
+	// Violations is set for VALIDATION_ERROR responses from schema validation.
+	Violations []FieldViolation `json:"violations,omitempty"`
 }
 
 // ToSecureHTTPResponse converts an AppContextError to a secure HTTP response
@@ -121,10 +133,11 @@ type SecureErrorDetail struct {
 func (e *AppContextError) ToSecureHTTPResponse() SecureHTTPResponse {
 	return SecureHTTPResponse{
 		Error: SecureErrorDetail{
-			Code:      e.Code,
-			Message:   e.SafeMessage(),
-			ErrorID:   e.ErrorID,
-			Retryable: e.IsRetryable(),
+			Code:       e.Code,
+			Message:    e.SafeMessage(),
+			ErrorID:    e.ErrorID,
+			Retryable:  e.IsRetryable(),
+			Violations: e.Violations,
 		},
 	}
 }
@@ -172,6 +185,14 @@ func NewValidationContextError(message, layer, component, operation string, cont
 	return NewAppContextError("VALIDATION_ERROR", message, layer, component, operation, nil, context)
 }
 
+// NewSchemaValidationContextError creates a validation error for a request
+// that does not match the API schema, listing every violation
+func NewSchemaValidationContextError(violations []FieldViolation, layer, component, operation string) *AppContextError {
+	err := NewValidationContextError("request does not match the API schema", layer, component, operation, nil)
+	err.Violations = violations
+	return err
+}
+
 // NewNotFoundContextError creates a not found error with context
 func NewNotFoundContextError(message, layer, component, operation string, context map[string]interface{}) *AppContextError {
 	if context == nil {
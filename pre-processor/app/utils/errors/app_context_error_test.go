@@ -279,6 +279,29 @@ func TestNewValidationContextError(t *testing.T) {
 	}
 }
 
+func TestNewSchemaValidationContextError(t *testing.T) {
+	violations := []FieldViolation{
+		{Field: "body.article_id", Reason: "required"},
+		{Field: "path.job_id", Reason: "invalid_format"},
+	}
+	err := NewSchemaValidationContextError(violations, "middleware", "OpenAPIValidator", "POST /api/v1/summarize")
+
+	if err.Code != "VALIDATION_ERROR" {
+		t.Errorf("Code = %q, want VALIDATION_ERROR", err.Code)
+	}
+	if err.HTTPStatusCode() != http.StatusBadRequest {
+		t.Errorf("HTTPStatusCode() = %d, want %d", err.HTTPStatusCode(), http.StatusBadRequest)
+	}
+
+	resp := err.ToSecureHTTPResponse()
+	if len(resp.Error.Violations) != 2 || resp.Error.Violations[1] != violations[1] {
+		t.Errorf("Violations = %v, want %v", resp.Error.Violations, violations)
+	}
+	if resp.Error.Message != "request does not match the API schema" {
+		t.Errorf("Message = %q", resp.Error.Message)
+	}
+}
+
 func TestNewNotFoundContextError(t *testing.T) {
 	err := NewNotFoundContextError(
 		"article not found",
@@ -0,0 +1,84 @@
+package html_parser
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ExtractLeadImage returns the absolute URL of the article's lead image.
+// Priority order: og:image meta tag, twitter:image meta tag, first content <img>.
+// Relative URLs are resolved against pageURL. Data URIs and 1x1 tracking
+// pixels are skipped. Returns empty string if no usable image is found.
+func ExtractLeadImage(raw string, pageURL string) string {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return ""
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(trimmed))
+	if err != nil {
+		return ""
+	}
+
+	base, _ := url.Parse(pageURL)
+
+	for _, selector := range []string{
+		"meta[property='og:image']",
+		"meta[property='og:image:url']",
+		"meta[name='twitter:image']",
+	} {
+		if content, ok := doc.Find(selector).First().Attr("content"); ok {
+			if resolved := resolveImageURL(content, base); resolved != "" {
+				return resolved
+			}
+		}
+	}
+
+	var lead string
+	doc.Find("img").EachWithBreak(func(_ int, img *goquery.Selection) bool {
+		if isTrackingPixel(img) {
+			return true
+		}
+		src, _ := img.Attr("src")
+		if resolved := resolveImageURL(src, base); resolved != "" {
+			lead = resolved
+			return false
+		}
+		return true
+	})
+	return lead
+}
+
+// resolveImageURL resolves ref against base and returns it only if the
+// result is an absolute http(s) URL.
+func resolveImageURL(ref string, base *url.URL) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "data:") {
+		return ""
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
+
+// isTrackingPixel reports whether an <img> declares a 1x1 (or smaller) size.
+func isTrackingPixel(img *goquery.Selection) bool {
+	w, _ := img.Attr("width")
+	h, _ := img.Attr("height")
+	return isTinyDimension(w) || isTinyDimension(h)
+}
+
+func isTinyDimension(v string) bool {
+	v = strings.TrimSuffix(strings.TrimSpace(v), "px")
+	return v == "0" || v == "1"
+}
@@ -0,0 +1,62 @@
+package html_parser
+
+import "testing"
+
+func TestExtractLeadImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		pageURL string
+		want    string
+	}{
+		{
+			name:    "og:image wins over content image",
+			html:    `<html><head><meta property="og:image" content="https://cdn.example.com/og.jpg"></head><body><img src="/inline.png"></body></html>`,
+			pageURL: "https://example.com/post",
+			want:    "https://cdn.example.com/og.jpg",
+		},
+		{
+			name:    "relative og:image is resolved",
+			html:    `<meta property="og:image" content="/images/lead.jpg">`,
+			pageURL: "https://example.com/blog/post",
+			want:    "https://example.com/images/lead.jpg",
+		},
+		{
+			name:    "first content image when no meta",
+			html:    `<p>Intro</p><img src="img/a.png"><img src="img/b.png">`,
+			pageURL: "https://example.com/blog/post",
+			want:    "https://example.com/blog/img/a.png",
+		},
+		{
+			name:    "skips tracking pixels and data URIs",
+			html:    `<img src="https://t.example.com/p.gif" width="1" height="1"><img src="data:image/png;base64,AAAA"><img src="https://example.com/real.jpg">`,
+			pageURL: "https://example.com/post",
+			want:    "https://example.com/real.jpg",
+		},
+		{
+			name:    "non-http scheme is rejected",
+			html:    `<img src="javascript:alert(1)">`,
+			pageURL: "https://example.com/post",
+			want:    "",
+		},
+		{
+			name:    "no image",
+			html:    `<p>Text only</p>`,
+			pageURL: "https://example.com/post",
+			want:    "",
+		},
+		{
+			name: "empty input",
+			html: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractLeadImage(tt.html, tt.pageURL); got != tt.want {
+				t.Errorf("ExtractLeadImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
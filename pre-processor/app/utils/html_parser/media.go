@@ -0,0 +1,157 @@
+package html_parser
+
+import (
+	"encoding/json"
+	"mime"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"pre-processor/domain"
+)
+
+// enclosure is one entry of an Inoreader item's enclosure list. Length and
+// duration arrive as strings or numbers depending on the feed.
+type enclosure struct {
+	Href     string          `json:"href"`
+	URL      string          `json:"url"`
+	Type     string          `json:"type"`
+	Length   json.RawMessage `json:"length"`
+	Duration json.RawMessage `json:"duration"`
+}
+
+// mimeTypesByExtension covers the podcast and video formats feeds commonly
+// attach without declaring a type.
+var mimeTypesByExtension = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".opus": "audio/ogg",
+	".wav":  "audio/wav",
+	".mp4":  "video/mp4",
+	".m4v":  "video/mp4",
+	".webm": "video/webm",
+	".mov":  "video/quicktime",
+}
+
+// ExtractMedia returns the playable audio and video attachments in a raw
+// enclosure list. Images and other attachments, non-http(s) URLs and
+// malformed entries are skipped. Returns nil if nothing is playable.
+func ExtractMedia(raw json.RawMessage) []domain.MediaEnclosure {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var entries []enclosure
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		// Some feeds carry a single enclosure object instead of a list.
+		var single enclosure
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil
+		}
+		entries = []enclosure{single}
+	}
+
+	var media []domain.MediaEnclosure
+	for _, e := range entries {
+		ref := e.Href
+		if ref == "" {
+			ref = e.URL
+		}
+		u, err := url.Parse(strings.TrimSpace(ref))
+		if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			continue
+		}
+
+		mimeType := mediaType(e.Type)
+		if mimeType == "" {
+			mimeType = mimeTypesByExtension[strings.ToLower(path.Ext(u.Path))]
+		}
+		kind, ok := mediaKind(mimeType)
+		if !ok {
+			continue
+		}
+
+		media = append(media, domain.MediaEnclosure{
+			URL:             u.String(),
+			MimeType:        mimeType,
+			Kind:            kind,
+			LengthBytes:     parseLength(e.Length),
+			DurationSeconds: ParseDuration(jsonScalar(e.Duration)),
+		})
+	}
+	return media
+}
+
+// mediaType returns the lower-cased media type of a declared MIME type
+// without parameters, or "" if it is missing or unparsable.
+func mediaType(declared string) string {
+	declared = strings.TrimSpace(declared)
+	if declared == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+func mediaKind(mimeType string) (domain.MediaKind, bool) {
+	switch {
+	case strings.HasPrefix(mimeType, "audio/"):
+		return domain.MediaKindAudio, true
+	case strings.HasPrefix(mimeType, "video/"):
+		return domain.MediaKindVideo, true
+	default:
+		return "", false
+	}
+}
+
+// parseLength returns the declared size in bytes, or 0 when it is missing
+// or not a positive integer (feeds often send "0" or "-1").
+func parseLength(raw json.RawMessage) int64 {
+	n, err := strconv.ParseInt(jsonScalar(raw), 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// ParseDuration parses an itunes:duration value: seconds ("3600"),
+// "MM:SS" or "HH:MM:SS". Returns 0 when the value is missing or malformed.
+func ParseDuration(v string) int {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	parts := strings.Split(v, ":")
+	if len(parts) > 3 {
+		return 0
+	}
+	total := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0
+		}
+		total = total*60 + n
+	}
+	return total
+}
+
+// jsonScalar returns a JSON string or number as text, or "" for anything else.
+func jsonScalar(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+	return ""
+}
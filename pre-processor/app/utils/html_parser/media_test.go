@@ -0,0 +1,87 @@
+package html_parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"pre-processor/domain"
+)
+
+func TestExtractMedia(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []domain.MediaEnclosure
+	}{
+		{
+			name: "podcast episode with string length and duration",
+			raw:  `[{"href":"https://cdn.example.com/ep42.mp3","type":"audio/mpeg","length":"12345678","duration":"1:02:03"}]`,
+			want: []domain.MediaEnclosure{{
+				URL: "https://cdn.example.com/ep42.mp3", MimeType: "audio/mpeg", Kind: domain.MediaKindAudio,
+				LengthBytes: 12345678, DurationSeconds: 3723,
+			}},
+		},
+		{
+			name: "video with numeric length and type parameters",
+			raw:  `[{"url":"https://cdn.example.com/talk.mp4","type":"Video/MP4; codecs=avc1","length":2048}]`,
+			want: []domain.MediaEnclosure{{
+				URL: "https://cdn.example.com/talk.mp4", MimeType: "video/mp4", Kind: domain.MediaKindVideo, LengthBytes: 2048,
+			}},
+		},
+		{
+			name: "missing type is guessed from the extension",
+			raw:  `[{"href":"https://cdn.example.com/ep.M4A?token=x","length":"-1"}]`,
+			want: []domain.MediaEnclosure{{
+				URL: "https://cdn.example.com/ep.M4A?token=x", MimeType: "audio/mp4", Kind: domain.MediaKindAudio,
+			}},
+		},
+		{
+			name: "single enclosure object",
+			raw:  `{"href":"https://cdn.example.com/ep.ogg","type":"audio/ogg"}`,
+			want: []domain.MediaEnclosure{{
+				URL: "https://cdn.example.com/ep.ogg", MimeType: "audio/ogg", Kind: domain.MediaKindAudio,
+			}},
+		},
+		{
+			name: "images, unknown types and non-http URLs are skipped",
+			raw:  `[{"href":"https://example.com/cover.jpg","type":"image/jpeg"},{"href":"https://example.com/file.bin"},{"href":"ftp://example.com/ep.mp3","type":"audio/mpeg"}]`,
+			want: nil,
+		},
+		{
+			name: "malformed",
+			raw:  `"not a list"`,
+			want: nil,
+		},
+		{
+			name: "empty",
+			raw:  ``,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractMedia(json.RawMessage(tt.raw)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractMedia() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]int{
+		"3600":     3600,
+		"45:30":    2730,
+		"01:00:05": 3605,
+		"":         0,
+		"1:2:3:4":  0,
+		"ten":      0,
+		"-5":       0,
+	}
+	for in, want := range tests {
+		if got := ParseDuration(in); got != want {
+			t.Errorf("ParseDuration(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+-- rag_answers: write-once snapshot of each generated answer, keyed by the
+-- retrieval set ID returned in the answer's debug block. chunks holds the
+-- context the prompt was built from and citations the chunks the answer
+-- cited, so feedback can be replayed as an eval case after the index moves on.
+CREATE TABLE rag_answers (
+    id UUID PRIMARY KEY,
+    query TEXT NOT NULL,
+    answer TEXT NOT NULL,
+    prompt_version TEXT NOT NULL DEFAULT '',
+    strategy_used TEXT NOT NULL DEFAULT '',
+    intent_type TEXT NOT NULL DEFAULT '',
+    chunks JSONB NOT NULL DEFAULT '[]'::jsonb,
+    citations JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_rag_answers_created ON rag_answers (created_at);
+
+-- rag_answer_feedback: append-only reader verdicts. Several rows per answer
+-- are allowed; the export keeps them all.
+CREATE TABLE rag_answer_feedback (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    answer_id UUID NOT NULL REFERENCES rag_answers(id) ON DELETE CASCADE,
+    rating TEXT NOT NULL CHECK (rating IN ('up', 'down')),
+    comment TEXT NOT NULL DEFAULT '',
+    helpful_chunk_ids JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_rag_answer_feedback_created ON rag_answer_feedback (created_at, id);
+CREATE INDEX idx_rag_answer_feedback_answer ON rag_answer_feedback (answer_id);
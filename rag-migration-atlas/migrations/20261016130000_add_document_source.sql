@@ -0,0 +1,13 @@
+-- rag_documents.source_type: where a document came from. Existing rows are
+-- alt feed articles. Non-article documents keep "<source_type>:<external id>"
+-- in article_id so the unique key stays collision free across connectors.
+ALTER TABLE rag_documents
+    ADD COLUMN source_type TEXT NOT NULL DEFAULT 'article'
+        CHECK (source_type IN ('article', 'bookmark', 'note', 'upload')),
+    ADD COLUMN source_metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Retrieval filters by source; the partial form keeps the index small
+-- because articles (the common, unfiltered case) are excluded.
+CREATE INDEX idx_rag_documents_source_type
+ON rag_documents(source_type, current_version_id)
+WHERE source_type <> 'article';
@@ -0,0 +1,6 @@
+-- Orphaned-chunk reconciliation detaches chunk events from the chunks it
+-- deletes (the FK has no ON DELETE action). Without this index every batch
+-- scans rag_chunk_events.
+CREATE INDEX IF NOT EXISTS idx_rag_chunk_events_chunk_id
+ON rag_chunk_events(chunk_id)
+WHERE chunk_id IS NOT NULL;
@@ -0,0 +1,28 @@
+-- rag_reindex_runs: one row per partial reindex (POST /internal/rag/reindex).
+-- The scan of alt-backend's articles is tracked here; the per-article work
+-- is ordinary rag_jobs rows tagged with the run ID in their payload, so the
+-- completed/failed counts are read from rag_jobs rather than stored.
+CREATE TABLE rag_reindex_runs (
+    id UUID PRIMARY KEY,
+    filter JSONB NOT NULL DEFAULT '{}'::jsonb,
+    status TEXT NOT NULL CHECK (status IN ('running', 'completed', 'failed')),
+    matched INTEGER NOT NULL DEFAULT 0,
+    enqueued INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- At most one run scans at a time.
+CREATE UNIQUE INDEX idx_rag_reindex_runs_single_running
+ON rag_reindex_runs ((true))
+WHERE status = 'running';
+
+CREATE INDEX idx_rag_reindex_runs_started ON rag_reindex_runs (started_at DESC);
+
+-- Progress lookups count a run's jobs by status.
+CREATE INDEX idx_rag_jobs_reindex_run
+ON rag_jobs ((payload->>'reindex_run_id'), status)
+WHERE payload ? 'reindex_run_id';
@@ -0,0 +1,21 @@
+-- rag_request_usage: one row per answer request with the generator and
+-- retrieval work it cost. Written after the answer is delivered; summaries
+-- for GET /internal/rag/usage and the daily budget checks group it by
+-- user and UTC day.
+CREATE TABLE rag_request_usage (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id TEXT NOT NULL DEFAULT '',
+    mode TEXT NOT NULL CHECK (mode IN ('answer', 'stream')),
+    model TEXT NOT NULL DEFAULT '',
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    generator_calls INTEGER NOT NULL DEFAULT 0,
+    embedding_calls INTEGER NOT NULL DEFAULT 0,
+    retrieval_ms INTEGER NOT NULL DEFAULT 0,
+    total_ms INTEGER NOT NULL DEFAULT 0,
+    failed BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_rag_request_usage_created ON rag_request_usage (created_at);
+CREATE INDEX idx_rag_request_usage_user_created ON rag_request_usage (user_id, created_at);
@@ -0,0 +1,18 @@
+-- Article metadata that retrieval filters (date, feed, tag) match on.
+-- Written by the index usecase from article events, reindex runs and
+-- upserts; documents indexed before this migration stay NULL / empty until
+-- they are indexed again and are excluded by any filter on these columns.
+ALTER TABLE rag_documents
+    ADD COLUMN published_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN feed_id TEXT,
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_rag_documents_published_at
+ON rag_documents(published_at, current_version_id)
+WHERE published_at IS NOT NULL;
+
+CREATE INDEX idx_rag_documents_feed_id
+ON rag_documents(feed_id, current_version_id)
+WHERE feed_id IS NOT NULL;
+
+CREATE INDEX idx_rag_documents_tags ON rag_documents USING GIN (tags);
@@ -37,6 +37,18 @@ func main() {
 		log.Fatalf("failed to load golden cases: %v", err)
 	}
 
+	// EVAL_FEEDBACK_PATH points at a saved /internal/rag/feedback/export
+	// response; its reader verdicts are replayed as extra regression cases.
+	if feedbackPath := os.Getenv("EVAL_FEEDBACK_PATH"); feedbackPath != "" {
+		export, err := eval.LoadFeedbackExport(feedbackPath)
+		if err != nil {
+			log.Fatalf("failed to load feedback export: %v", err)
+		}
+		feedbackCases := eval.GoldenCasesFromFeedback(export.Items)
+		cases = append(cases, feedbackCases...)
+		fmt.Printf("Loaded %d feedback cases from %s\n", len(feedbackCases), feedbackPath)
+	}
+
 	client := augurv2connect.NewAugurServiceClient(
 		&http.Client{Timeout: 130 * time.Second},
 		augurAddr,
@@ -101,6 +101,22 @@ func main() {
 		log.Info("Stopping worker...")
 		app.Worker.Stop()
 	}()
+	if app.SourceSyncWorker != nil {
+		app.SourceSyncWorker.Start()
+		defer app.SourceSyncWorker.Stop()
+	}
+	if app.OrphanReconcileWorker != nil {
+		app.OrphanReconcileWorker.Start()
+		defer app.OrphanReconcileWorker.Stop()
+	}
+	if app.ArticleDeletionSyncWorker != nil {
+		app.ArticleDeletionSyncWorker.Start()
+		defer app.ArticleDeletionSyncWorker.Stop()
+	}
+	if app.ArticleEventConsumer != nil {
+		app.ArticleEventConsumer.Start()
+		defer app.ArticleEventConsumer.Stop()
+	}
 
 	// 7. Initialize Echo
 	e := echo.New()
@@ -116,10 +132,29 @@ func main() {
 		app.MorningLetterUsecase,
 		log,
 		rag_http.WithEmbedderOverride(app.EmbedderFactory, app.IndexUsecaseFactory, app.EmbeddingModel, app.EmbedderTimeout, cfg.Embedder.AllowedOverrideOrigins),
+		rag_http.WithAnswerFeedback(app.FeedbackUsecase),
+		rag_http.WithSourceIndexing(app.IndexSourceUsecase, app.TextExtractor, app.MaxUploadBytes),
+		rag_http.WithCorpusStats(app.CorpusStatsUsecase),
+		rag_http.WithReindex(app.ReindexUsecase),
+		rag_http.WithGuardrails(app.Guardrails),
+		rag_http.WithUsage(app.UsageUsecase),
+		rag_http.WithOpenAICompat(app.OpenAIModels, cfg.OpenAICompat.APIKey),
 	)
 	openapi.RegisterHandlers(e, handler)
 	e.POST("/internal/rag/backfill", handler.Backfill)
 	e.POST("/v1/rag/morning-letter", handler.MorningLetter)
+	e.POST("/v1/rag/answers/:id/feedback", handler.SubmitAnswerFeedback)
+	e.GET("/internal/rag/feedback/export", handler.ExportFeedback)
+	e.POST("/internal/rag/sources/index", handler.IndexSource)
+	e.GET("/internal/rag/stats", handler.CorpusStats)
+	e.POST("/internal/rag/stats/reconcile", handler.ReconcileOrphans)
+	e.POST("/internal/rag/reindex", handler.StartReindex)
+	e.GET("/internal/rag/reindex/:id", handler.GetReindex)
+	e.GET("/internal/rag/guardrails", handler.Guardrails)
+	e.PUT("/internal/rag/guardrails", handler.SetGuardrailMode)
+	e.GET("/internal/rag/usage", handler.Usage)
+	e.POST("/v1/chat/completions", handler.ChatCompletions)
+	e.GET("/v1/models", handler.ListModels)
 
 	// 9. Health Checks
 	e.GET("/healthz", func(c echo.Context) error {
@@ -0,0 +1,108 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FeedbackExport is the body of GET /internal/rag/feedback/export.
+type FeedbackExport struct {
+	Items      []FeedbackItem `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// FeedbackItem is one reader verdict together with the answer snapshot it rates.
+type FeedbackItem struct {
+	FeedbackID      string          `json:"feedback_id"`
+	Rating          string          `json:"rating"` // "up" or "down"
+	Comment         string          `json:"comment,omitempty"`
+	HelpfulChunkIDs []string        `json:"helpful_chunk_ids"`
+	AnswerID        string          `json:"answer_id"`
+	Query           string          `json:"query"`
+	Answer          string          `json:"answer"`
+	PromptVersion   string          `json:"prompt_version"`
+	IntentType      string          `json:"intent_type,omitempty"`
+	Chunks          []FeedbackChunk `json:"chunks"`
+	Citations       []FeedbackChunk `json:"citations"`
+}
+
+// FeedbackChunk is a chunk snapshot stored with the answer.
+type FeedbackChunk struct {
+	ChunkID string `json:"chunk_id"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+}
+
+// LoadFeedbackExport reads a saved export response from disk.
+func LoadFeedbackExport(path string) (FeedbackExport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FeedbackExport{}, fmt.Errorf("read feedback export: %w", err)
+	}
+	var export FeedbackExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return FeedbackExport{}, fmt.Errorf("parse feedback export: %w", err)
+	}
+	return export, nil
+}
+
+// GoldenCasesFromFeedback turns reader feedback into regression cases.
+//
+// Thumbs-up answers become "keep doing this": the query must still retrieve
+// at least as many relevant chunks as the reader marked helpful, and must
+// cite them. Thumbs-down answers become "stop doing this": titles that were
+// cited but not marked helpful must not come back.
+func GoldenCasesFromFeedback(items []FeedbackItem) []GoldenCase {
+	cases := make([]GoldenCase, 0, len(items))
+	for _, it := range items {
+		if it.Query == "" {
+			continue
+		}
+		helpful := make(map[string]struct{}, len(it.HelpfulChunkIDs))
+		for _, id := range it.HelpfulChunkIDs {
+			helpful[id] = struct{}{}
+		}
+
+		gc := GoldenCase{
+			ID:    "feedback-" + shortID(it.FeedbackID),
+			Query: it.Query,
+			Expected: ExpectedBehavior{
+				RetrievalScope: "global",
+				ExpectedIntent: it.IntentType,
+			},
+			Tags: []string{"feedback"},
+		}
+
+		switch it.Rating {
+		case "up":
+			gc.Tags = append(gc.Tags, "thumbs-up")
+			gc.Expected.MinRelevantContexts = len(helpful)
+			gc.Expected.RequiresCitations = len(it.Citations) > 0
+		case "down":
+			gc.Tags = append(gc.Tags, "thumbs-down")
+			seen := make(map[string]struct{})
+			for _, c := range it.Citations {
+				if _, ok := helpful[c.ChunkID]; ok || c.Title == "" {
+					continue
+				}
+				if _, dup := seen[c.Title]; dup {
+					continue
+				}
+				seen[c.Title] = struct{}{}
+				gc.Expected.IrrelevantTitles = append(gc.Expected.IrrelevantTitles, c.Title)
+			}
+		default:
+			continue
+		}
+		cases = append(cases, gc)
+	}
+	return cases
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,67 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoldenCasesFromFeedback(t *testing.T) {
+	items := []FeedbackItem{
+		{
+			FeedbackID:      "11111111-aaaa-bbbb-cccc-000000000001",
+			Rating:          "up",
+			HelpfulChunkIDs: []string{"c1", "c2"},
+			Query:           "Rust 1.80 の変更点は？",
+			IntentType:      "general",
+			Citations:       []FeedbackChunk{{ChunkID: "c1", Title: "Rust 1.80"}},
+		},
+		{
+			FeedbackID:      "22222222-aaaa-bbbb-cccc-000000000002",
+			Rating:          "down",
+			HelpfulChunkIDs: []string{"c3"},
+			Query:           "イランの石油危機はなぜ起きた？",
+			Citations: []FeedbackChunk{
+				{ChunkID: "c3", Title: "Oil supply shock"},
+				{ChunkID: "c4", Title: "Football results"},
+				{ChunkID: "c5", Title: "Football results"},
+			},
+		},
+		{FeedbackID: "33333333", Rating: "meh", Query: "ignored"},
+		{FeedbackID: "44444444", Rating: "up"},
+	}
+
+	cases := GoldenCasesFromFeedback(items)
+	require.Len(t, cases, 2)
+
+	up := cases[0]
+	assert.Equal(t, "feedback-11111111", up.ID)
+	assert.Equal(t, []string{"feedback", "thumbs-up"}, up.Tags)
+	assert.Equal(t, 2, up.Expected.MinRelevantContexts)
+	assert.True(t, up.Expected.RequiresCitations)
+	assert.Equal(t, "general", up.Expected.ExpectedIntent)
+
+	down := cases[1]
+	assert.Equal(t, []string{"feedback", "thumbs-down"}, down.Tags)
+	assert.Equal(t, []string{"Football results"}, down.Expected.IrrelevantTitles)
+	assert.False(t, down.Expected.RequiresCitations)
+}
+
+func TestLoadFeedbackExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feedback.json")
+	body := `{"items":[{"feedback_id":"f1","rating":"up","helpful_chunk_ids":["c1"],"answer_id":"a1","query":"q","answer":"a","prompt_version":"alpha-v2","chunks":[],"citations":[{"chunk_id":"c1","title":"T","url":"https://example.com"}]}],"next_cursor":"1_x"}`
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+
+	export, err := LoadFeedbackExport(path)
+	require.NoError(t, err)
+	require.Len(t, export.Items, 1)
+	assert.Equal(t, "alpha-v2", export.Items[0].PromptVersion)
+	assert.Equal(t, "T", export.Items[0].Citations[0].Title)
+	assert.Equal(t, "1_x", export.NextCursor)
+
+	_, err = LoadFeedbackExport(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package altdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/infra/httpclient"
+	"strconv"
+	"time"
+)
+
+// HTTPArticleDeletionClient implements domain.ArticleDeletionSource on top
+// of alt-backend's GET /v1/internal/articles/deletions.
+// Authentication is established at the TLS transport layer (mTLS).
+type HTTPArticleDeletionClient struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewHTTPArticleDeletionClient creates a new HTTP-based deletion source.
+func NewHTTPArticleDeletionClient(baseURL string, timeout time.Duration, logger *slog.Logger) *HTTPArticleDeletionClient {
+	return &HTTPArticleDeletionClient{
+		baseURL:    baseURL,
+		httpClient: httpclient.NewPooledClient(timeout),
+		logger:     logger,
+	}
+}
+
+type articleDeletionEventsResponse struct {
+	Events []articleDeletionEventDTO `json:"events"`
+	Count  int                       `json:"count"`
+}
+
+type articleDeletionEventDTO struct {
+	ID         string    `json:"id"`
+	EntityID   string    `json:"entity_id"`
+	Action     string    `json:"action"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ListArticleDeletions fetches one page of the article soft-delete audit
+// trail.
+func (c *HTTPArticleDeletionClient) ListArticleDeletions(ctx context.Context, cursor domain.ArticleDeletionCursor, limit int) ([]domain.ArticleDeletionEvent, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+	if !cursor.Since.IsZero() {
+		params.Set("since", cursor.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if cursor.AfterID != "" {
+		params.Set("after_id", cursor.AfterID)
+	}
+	endpoint := c.baseURL + "/v1/internal/articles/deletions?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch article deletions: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var response articleDeletionEventsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	events := make([]domain.ArticleDeletionEvent, 0, len(response.Events))
+	for _, dto := range response.Events {
+		events = append(events, domain.ArticleDeletionEvent{
+			ID:         dto.ID,
+			ArticleID:  dto.EntityID,
+			Action:     domain.ArticleDeletionAction(dto.Action),
+			OccurredAt: dto.OccurredAt,
+		})
+	}
+	c.logger.Debug("fetched article deletions", slog.Int("count", len(events)))
+	return events, nil
+}
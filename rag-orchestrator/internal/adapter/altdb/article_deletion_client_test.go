@@ -0,0 +1,43 @@
+package altdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPArticleDeletionClient_ListArticleDeletions(t *testing.T) {
+	var query map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/internal/articles/deletions", r.URL.Path)
+		query = map[string]string{
+			"since":    r.URL.Query().Get("since"),
+			"after_id": r.URL.Query().Get("after_id"),
+			"limit":    r.URL.Query().Get("limit"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"events":[{"id":"e2","entity":"article","entity_id":"a1","action":"purged","occurred_at":"2026-10-16T12:00:01.123456Z"}],"count":1}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPArticleDeletionClient(srv.URL, 5*time.Second, testLogger())
+	since := time.Date(2026, 10, 16, 12, 0, 0, 500, time.UTC)
+	events, err := client.ListArticleDeletions(context.Background(), domain.ArticleDeletionCursor{Since: since, AfterID: "e1"}, 100)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"since": "2026-10-16T12:00:00.0000005Z", "after_id": "e1", "limit": "100"}, query)
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.ArticleDeletionEvent{
+		ID:         "e2",
+		ArticleID:  "a1",
+		Action:     domain.ArticlePurged,
+		OccurredAt: time.Date(2026, 10, 16, 12, 0, 1, 123456000, time.UTC),
+	}, events[0])
+}
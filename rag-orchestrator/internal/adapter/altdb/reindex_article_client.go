@@ -0,0 +1,73 @@
+package altdb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	backendv1 "alt/gen/proto/services/backend/v1"
+	"alt/gen/proto/services/backend/v1/backendv1connect"
+
+	"rag-orchestrator/internal/domain"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// InternalReindexArticleClient implements domain.ReindexArticleSource using
+// BackendInternalService.ListArticlesForReindex.
+type InternalReindexArticleClient struct {
+	client backendv1connect.BackendInternalServiceClient
+	logger *slog.Logger
+}
+
+// NewInternalReindexArticleClient creates a reindex article source using the BackendInternalService.
+func NewInternalReindexArticleClient(client backendv1connect.BackendInternalServiceClient, logger *slog.Logger) *InternalReindexArticleClient {
+	return &InternalReindexArticleClient{client: client, logger: logger}
+}
+
+func (c *InternalReindexArticleClient) ListArticlesForReindex(ctx context.Context, filter domain.ReindexFilter, cursor domain.ReindexCursor, limit int) ([]domain.ReindexArticle, *domain.ReindexCursor, error) {
+	msg := &backendv1.ListArticlesForReindexRequest{
+		FeedId: filter.FeedID,
+		UserId: filter.UserID,
+		LastId: cursor.ID,
+		Limit:  int32(min(limit, math.MaxInt32)), //nolint:gosec // value clamped to MaxInt32
+	}
+	if filter.From != nil {
+		msg.CreatedFrom = timestamppb.New(*filter.From)
+	}
+	if filter.To != nil {
+		msg.CreatedTo = timestamppb.New(*filter.To)
+	}
+	if !cursor.CreatedAt.IsZero() {
+		msg.LastCreatedAt = timestamppb.New(cursor.CreatedAt)
+	}
+
+	resp, err := c.client.ListArticlesForReindex(ctx, connect.NewRequest(msg))
+	if err != nil {
+		return nil, nil, fmt.Errorf("ListArticlesForReindex RPC failed: %w", err)
+	}
+
+	articles := make([]domain.ReindexArticle, 0, len(resp.Msg.Articles))
+	for _, a := range resp.Msg.Articles {
+		articles = append(articles, domain.ReindexArticle{
+			ID:        a.GetId(),
+			Title:     a.GetTitle(),
+			Body:      a.GetContent(),
+			URL:       a.GetUrl(),
+			FeedID:    a.GetFeedId(),
+			UserID:    a.GetUserId(),
+			CreatedAt: a.GetCreatedAt().AsTime(),
+		})
+	}
+
+	// A short page is the last one; the server still returns its cursor.
+	var next *domain.ReindexCursor
+	if len(articles) == limit && resp.Msg.NextCreatedAt != nil {
+		next = &domain.ReindexCursor{CreatedAt: resp.Msg.NextCreatedAt.AsTime(), ID: resp.Msg.GetNextId()}
+	}
+
+	c.logger.Debug("reindex_articles_fetched", slog.Int("count", len(articles)))
+	return articles, next, nil
+}
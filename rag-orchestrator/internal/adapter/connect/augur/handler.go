@@ -168,6 +168,15 @@ func (h *Handler) StreamChat(
 		return connect.NewError(connect.CodeInvalidArgument, errors.New("no user message found"))
 	}
 
+	answerLength, err := usecase.ParseAnswerLength(req.Msg.AnswerLength)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	readingLevel, err := usecase.ParseReadingLevel(req.Msg.ReadingLevel)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
 	// Build conversation history (all messages except the last user message)
 	// Limit to last 6 messages (3 turns) for efficiency
 	allMsgs := req.Msg.Messages
@@ -227,13 +236,17 @@ func (h *Handler) StreamChat(
 	// persisted conversation id — it feeds RAG context continuity, not history).
 	threadID := deriveThreadID(userID, req.Msg.Messages)
 
-	// Build input for AnswerWithRAGUsecase
+	// Build input for AnswerWithRAGUsecase. UserID is the real caller so
+	// request usage is accounted per user; the thread keys the store.
 	locale := detectLocale(query)
 	input := usecase.AnswerWithRAGInput{
 		Query:               query,
-		UserID:              threadID,
+		UserID:              userID.String(),
+		ConversationID:      threadID,
 		Locale:              locale,
 		ConversationHistory: conversationHistory,
+		AnswerLength:        answerLength,
+		ReadingLevel:        readingLevel,
 	}
 
 	// Stream answer using AnswerWithRAGUsecase
@@ -606,3 +606,56 @@ func TestStreamChat_IncrementsEmitterFailureMetric_OnEmitError(t *testing.T) {
 	after := emitterFailureCount(t, "augur.conversation_linked.v1")
 	assert.Equal(t, before+1, after, "IncEmitterFailure must fire on the warn-and-continue path")
 }
+
+func TestStreamChat_MapsAnswerLengthAndReadingLevel(t *testing.T) {
+	mockAnswer := new(MockAnswerWithRAGUsecase)
+	mockConv := new(MockAugurConversationUsecase)
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	userID := uuid.New()
+	conv := &domain.AugurConversation{ID: uuid.New(), UserID: userID, Title: "test", CreatedAt: time.Now().UTC()}
+	mockConv.On("EnsureConversation", mock.Anything, userID, uuid.Nil, mock.AnythingOfType("string")).Return(conv, nil)
+	mockConv.On("AppendUserTurn", mock.Anything, conv.ID, "test query").Return(nil)
+	mockConv.On("AppendAssistantTurn", mock.Anything, conv.ID, mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return(nil)
+
+	var got usecase.AnswerWithRAGInput
+	events := make(chan usecase.StreamEvent)
+	close(events)
+	mockAnswer.On("Stream", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { got = args.Get(1).(usecase.AnswerWithRAGInput) }).
+		Return((<-chan usecase.StreamEvent)(events))
+
+	mux := http.NewServeMux()
+	path, connectHandler := augurv2connect.NewAugurServiceHandler(augur.NewHandler(mockAnswer, new(MockRetrieveContextUsecase), mockConv, nil, logger))
+	mux.Handle(path, connectHandler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := augurv2connect.NewAugurServiceClient(server.Client(), server.URL)
+
+	send := func(length, level string) error {
+		req := connect.NewRequest(&augurv2.StreamChatRequest{
+			Messages:     []*augurv2.ChatMessage{{Role: "user", Content: "test query"}},
+			AnswerLength: length,
+			ReadingLevel: level,
+		})
+		req.Header().Set("X-Alt-User-Id", userID.String())
+		stream, err := client.StreamChat(context.Background(), req)
+		require.NoError(t, err)
+		for stream.Receive() {
+		}
+		return stream.Err()
+	}
+
+	require.NoError(t, send("medium", "basic"))
+	assert.Equal(t, usecase.AnswerLengthMedium, got.AnswerLength)
+	assert.Equal(t, usecase.ReadingLevelBasic, got.ReadingLevel)
+
+	err := send("tiny", "")
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	err = send("", "toddler")
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	mockAnswer.AssertNumberOfCalls(t, "Stream", 1)
+}
@@ -0,0 +1,79 @@
+package eventstream
+
+import (
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// handledTotal counts article events by type and outcome.
+//
+// Cardinality: event_type is folded to the three article types plus
+// "other", outcome is one of usecase.ArticleEventOutcome or failed,
+// dead_lettered.
+var handledTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "article_events",
+		Name:      "handled_total",
+		Help:      "Article events handled by event type and outcome (enqueued, unchanged, deleted, skipped, failed, dead_lettered).",
+	},
+	[]string{"event_type", "outcome"},
+)
+
+// eventAge is how old events are when handled: the end-to-end delay from
+// alt-backend publishing to the index job being queued.
+var eventAge = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "article_events",
+		Name:      "age_seconds",
+		Help:      "Time from an article event being published to rag-orchestrator handling it.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900, 3600},
+	},
+)
+
+var groupLag = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "article_events",
+		Name:      "consumer_lag",
+		Help:      "Article stream entries not yet delivered to the consumer group; -1 when Redis cannot tell.",
+	},
+)
+
+var groupPending = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "article_events",
+		Name:      "consumer_pending",
+		Help:      "Article stream entries delivered to the consumer group but not yet acknowledged.",
+	},
+)
+
+// Recorder implements worker.ArticleEventRecorder.
+type Recorder struct{}
+
+// RecordArticleEvent increments the handled counter.
+func (Recorder) RecordArticleEvent(eventType, outcome string) {
+	switch eventType {
+	case domain.ArticleEventCreated, domain.ArticleEventUpdated, domain.ArticleEventDeleted:
+	default:
+		eventType = "other"
+	}
+	handledTotal.WithLabelValues(eventType, outcome).Inc()
+}
+
+// RecordArticleEventAge observes the age of a handled event.
+func (Recorder) RecordArticleEventAge(age time.Duration) {
+	eventAge.Observe(age.Seconds())
+}
+
+// RecordArticleEventLag sets the lag gauges.
+func (Recorder) RecordArticleEventLag(lag domain.ArticleEventLag) {
+	groupLag.Set(float64(lag.Lag))
+	groupPending.Set(float64(lag.Pending))
+}
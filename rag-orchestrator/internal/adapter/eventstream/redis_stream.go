@@ -0,0 +1,222 @@
+// Package eventstream reads alt-backend's article events from the Redis
+// Stream mq-hub publishes them to, through a rag-orchestrator consumer
+// group. It implements domain.ArticleEventStream.
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrUnreachable marks a Verify that failed because Redis did not answer,
+// as opposed to answering that the group is missing.
+var ErrUnreachable = errors.New("event stream unreachable")
+
+// Config selects the stream, the consumer group and how messages are read.
+type Config struct {
+	Stream    string
+	Group     string
+	Consumer  string
+	DLQStream string
+	BatchSize int64
+	// Block is how long Read waits for new messages.
+	Block time.Duration
+	// ClaimIdle is how long a message stays pending, unacknowledged, before
+	// Read hands it out again.
+	ClaimIdle time.Duration
+}
+
+// RedisStream implements domain.ArticleEventStream with XREADGROUP, and
+// retries pending messages with XAUTOCLAIM.
+type RedisStream struct {
+	client *redis.Client
+	cfg    Config
+
+	// claimCursor is where the next XAUTOCLAIM sweep of the pending list
+	// continues, so a long list is walked rather than rescanned from the
+	// start on every Read.
+	claimCursor string
+}
+
+// NewRedisStream connects to the Redis at redisURL. It does not dial;
+// call Verify to check the group.
+func NewRedisStream(redisURL string, cfg Config) (*RedisStream, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	return &RedisStream{client: redis.NewClient(opts), cfg: cfg, claimCursor: "0-0"}, nil
+}
+
+// Close closes the Redis client.
+func (s *RedisStream) Close() error {
+	return s.client.Close()
+}
+
+// Verify checks that the consumer group exists. Groups are provisioned
+// with mq-hub CreateConsumerGroup or XGROUP CREATE, never created here, so
+// a missing one fails loudly at startup instead of at the first read.
+func (s *RedisStream) Verify(ctx context.Context) error {
+	groups, err := s.client.XInfoGroups(ctx, s.cfg.Stream).Result()
+	var redisErr redis.Error
+	if err != nil && !errors.As(err, &redisErr) {
+		return fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+	if err != nil {
+		return fmt.Errorf("consumer group %q not found: stream %q is missing: %w", s.cfg.Group, s.cfg.Stream, err)
+	}
+	for _, g := range groups {
+		if g.Name == s.cfg.Group {
+			return nil
+		}
+	}
+	return fmt.Errorf("consumer group %q not found on stream %q", s.cfg.Group, s.cfg.Stream)
+}
+
+func (s *RedisStream) Read(ctx context.Context) ([]domain.ArticleEvent, error) {
+	events, err := s.claimIdle(ctx)
+	if err != nil || len(events) > 0 {
+		return events, err
+	}
+
+	streams, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    s.cfg.Group,
+		Consumer: s.cfg.Consumer,
+		Streams:  []string{s.cfg.Stream, ">"},
+		Count:    s.cfg.BatchSize,
+		Block:    s.cfg.Block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read stream %s: %w", s.cfg.Stream, err)
+	}
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			events = append(events, toArticleEvent(message, 1))
+		}
+	}
+	return events, nil
+}
+
+// claimIdle takes over the next batch of messages pending longer than
+// ClaimIdle: ones whose handling failed, and ones a stopped consumer never
+// acknowledged. Claiming counts as a delivery.
+func (s *RedisStream) claimIdle(ctx context.Context) ([]domain.ArticleEvent, error) {
+	messages, next, err := s.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   s.cfg.Stream,
+		Group:    s.cfg.Group,
+		Consumer: s.cfg.Consumer,
+		MinIdle:  s.cfg.ClaimIdle,
+		Start:    s.claimCursor,
+		Count:    s.cfg.BatchSize,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("claim pending on %s: %w", s.cfg.Stream, err)
+	}
+	s.claimCursor = next
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	deliveries, err := s.deliveries(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]domain.ArticleEvent, 0, len(messages))
+	for _, message := range messages {
+		events = append(events, toArticleEvent(message, deliveries[message.ID]))
+	}
+	return events, nil
+}
+
+// deliveries looks up the delivery counters of just-claimed messages.
+func (s *RedisStream) deliveries(ctx context.Context, messages []redis.XMessage) (map[string]int64, error) {
+	pending, err := s.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   s.cfg.Stream,
+		Group:    s.cfg.Group,
+		Consumer: s.cfg.Consumer,
+		Start:    messages[0].ID,
+		End:      messages[len(messages)-1].ID,
+		Count:    int64(len(messages)),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("pending deliveries on %s: %w", s.cfg.Stream, err)
+	}
+	counts := make(map[string]int64, len(pending))
+	for _, p := range pending {
+		counts[p.ID] = p.RetryCount
+	}
+	return counts, nil
+}
+
+func (s *RedisStream) Ack(ctx context.Context, messageIDs ...string) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	return s.client.XAck(ctx, s.cfg.Stream, s.cfg.Group, messageIDs...).Err()
+}
+
+// DeadLetter adds the message's fields to the dead-letter stream together
+// with why and after how many deliveries it was given up on, then
+// acknowledges the original. If the acknowledgement fails the message is
+// retried and may be dead-lettered twice.
+func (s *RedisStream) DeadLetter(ctx context.Context, event domain.ArticleEvent, reason string) error {
+	values := map[string]any{
+		"event_id":           event.EventID,
+		"event_type":         event.EventType,
+		"payload":            string(event.Payload),
+		"dlq_reason":         reason,
+		"dlq_delivery_count": event.Deliveries,
+		"dlq_original_id":    event.MessageID,
+		"dlq_consumer_group": s.cfg.Group,
+		"dlq_at":             time.Now().UTC().Format(time.RFC3339),
+	}
+	if !event.CreatedAt.IsZero() {
+		values["created_at"] = event.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if err := s.client.XAdd(ctx, &redis.XAddArgs{Stream: s.cfg.DLQStream, Values: values}).Err(); err != nil {
+		return fmt.Errorf("write dead letter for %s: %w", event.MessageID, err)
+	}
+	if err := s.Ack(ctx, event.MessageID); err != nil {
+		return fmt.Errorf("ack dead-lettered %s: %w", event.MessageID, err)
+	}
+	return nil
+}
+
+// Lag reads the group's lag and pending count from XINFO GROUPS. Lag is
+// -1 when Redis cannot tell, e.g. after entries were deleted from the
+// middle of the stream.
+func (s *RedisStream) Lag(ctx context.Context) (domain.ArticleEventLag, error) {
+	groups, err := s.client.XInfoGroups(ctx, s.cfg.Stream).Result()
+	if err != nil {
+		return domain.ArticleEventLag{}, fmt.Errorf("stream info %s: %w", s.cfg.Stream, err)
+	}
+	for _, g := range groups {
+		if g.Name == s.cfg.Group {
+			return domain.ArticleEventLag{Lag: g.Lag, Pending: g.Pending}, nil
+		}
+	}
+	return domain.ArticleEventLag{}, fmt.Errorf("consumer group %q not found on stream %q", s.cfg.Group, s.cfg.Stream)
+}
+
+// toArticleEvent maps the fields mq-hub writes for every event.
+func toArticleEvent(message redis.XMessage, deliveries int64) domain.ArticleEvent {
+	event := domain.ArticleEvent{MessageID: message.ID, Deliveries: deliveries}
+	event.EventID, _ = message.Values["event_id"].(string)
+	event.EventType, _ = message.Values["event_type"].(string)
+	if payload, ok := message.Values["payload"].(string); ok {
+		event.Payload = []byte(payload)
+	}
+	if createdAt, ok := message.Values["created_at"].(string); ok {
+		event.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	}
+	return event
+}
@@ -0,0 +1,132 @@
+package eventstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStream = "alt:events:articles"
+	testGroup  = "rag-orchestrator-group"
+	testDLQ    = "alt:events:articles:rag-orchestrator:dlq"
+)
+
+func newTestStream(t *testing.T) (*RedisStream, *miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	stream, err := NewRedisStream("redis://"+mr.Addr(), Config{
+		Stream:    testStream,
+		Group:     testGroup,
+		Consumer:  "rag-orchestrator-1",
+		DLQStream: testDLQ,
+		BatchSize: 10,
+		Block:     10 * time.Millisecond,
+		ClaimIdle: time.Minute,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = stream.Close() })
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return stream, mr, client
+}
+
+func publish(t *testing.T, client *redis.Client, eventType, payload string) string {
+	t.Helper()
+	id, err := client.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: testStream,
+		Values: map[string]any{
+			"event_id":   "ev-" + eventType,
+			"event_type": eventType,
+			"source":     "alt-backend",
+			"created_at": "2026-10-16T12:00:00.000Z",
+			"payload":    payload,
+		},
+	}).Result()
+	require.NoError(t, err)
+	return id
+}
+
+func TestVerify_RequiresProvisionedGroup(t *testing.T) {
+	stream, mr, client := newTestStream(t)
+	ctx := context.Background()
+
+	err := stream.Verify(ctx)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrUnreachable)
+
+	require.NoError(t, client.XGroupCreateMkStream(ctx, testStream, testGroup, "0").Err())
+	require.NoError(t, stream.Verify(ctx))
+
+	mr.Close()
+	assert.ErrorIs(t, stream.Verify(ctx), ErrUnreachable)
+}
+
+func TestRead_ReclaimsIdleMessagesWithDeliveryCount(t *testing.T) {
+	stream, mr, client := newTestStream(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	mr.SetTime(now)
+	require.NoError(t, client.XGroupCreateMkStream(ctx, testStream, testGroup, "0").Err())
+	id := publish(t, client, domain.ArticleEventCreated, `{"article_id":"a1"}`)
+
+	events, err := stream.Read(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.ArticleEvent{
+		MessageID:  id,
+		EventID:    "ev-ArticleCreated",
+		EventType:  domain.ArticleEventCreated,
+		Payload:    []byte(`{"article_id":"a1"}`),
+		CreatedAt:  time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		Deliveries: 1,
+	}, events[0])
+
+	// Not acknowledged and not yet idle: nothing to read.
+	events, err = stream.Read(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	mr.SetTime(now.Add(2 * time.Minute))
+	events, err = stream.Read(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, id, events[0].MessageID)
+	assert.Equal(t, int64(2), events[0].Deliveries)
+
+	require.NoError(t, stream.Ack(ctx, id))
+	lag, err := stream.Lag(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), lag.Pending)
+}
+
+func TestDeadLetter_CopiesAndAcks(t *testing.T) {
+	stream, _, client := newTestStream(t)
+	ctx := context.Background()
+	require.NoError(t, client.XGroupCreateMkStream(ctx, testStream, testGroup, "0").Err())
+	publish(t, client, domain.ArticleEventUpdated, `not json`)
+
+	events, err := stream.Read(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.NoError(t, stream.DeadLetter(ctx, events[0], "invalid article event"))
+
+	dead, err := client.XRange(ctx, testDLQ, "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "not json", dead[0].Values["payload"])
+	assert.Equal(t, "invalid article event", dead[0].Values["dlq_reason"])
+	assert.Equal(t, events[0].MessageID, dead[0].Values["dlq_original_id"])
+
+	lag, err := stream.Lag(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), lag.Pending)
+}
@@ -0,0 +1,31 @@
+// Package guardrail exports answer guardrail outcomes as Prometheus metrics.
+package guardrail
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// outcomeTotal counts guardrail filter verdicts on generated answers.
+//
+// Cardinality: filter is bounded by the filters wired in di (pii_redaction,
+// injection_echo, toxicity), action by usecase.GuardrailAction and mode by
+// enforce/observe. Observe-mode counts show what enforcing would change
+// before it is switched on.
+var outcomeTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "answer_guardrail",
+		Name:      "outcome_total",
+		Help:      "Answer guardrail filter verdicts by filter, action (pass, redact, block, error) and mode.",
+	},
+	[]string{"filter", "action", "mode"},
+)
+
+// Recorder implements usecase.GuardrailRecorder.
+type Recorder struct{}
+
+// RecordGuardrailOutcome increments the outcome counter.
+func (Recorder) RecordGuardrailOutcome(filter, action, mode string) {
+	outcomeTotal.WithLabelValues(filter, action, mode).Inc()
+}
@@ -0,0 +1,74 @@
+// Package modelroute exports per-route generator latency, token and cost
+// metrics as Prometheus metrics.
+package modelroute
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"rag-orchestrator/internal/usecase"
+)
+
+// Cardinality: route is bounded by usecase.ModelRoute plus "unrouted",
+// model by the RAG_MODEL_ROUTES table plus the Augur model, method by the
+// five domain.LLMClient calls and outcome by success/error.
+var (
+	callsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "rag_orchestrator",
+			Subsystem: "model_route",
+			Name:      "calls_total",
+			Help:      "Generator calls by model route, serving model, method and outcome (success, error).",
+		},
+		[]string{"route", "model", "method", "outcome"},
+	)
+
+	latencySeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "rag_orchestrator",
+			Subsystem: "model_route",
+			Name:      "latency_seconds",
+			Help:      "Generator call latency by model route and serving model. Streams are measured to their last chunk.",
+			Buckets:   []float64{0.5, 1, 2.5, 5, 10, 20, 40, 80, 160, 300},
+		},
+		[]string{"route", "model", "method"},
+	)
+
+	tokensTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "rag_orchestrator",
+			Subsystem: "model_route",
+			Name:      "tokens_total",
+			Help:      "Tokens reported by the generator by model route, serving model and kind (prompt, completion).",
+		},
+		[]string{"route", "model", "kind"},
+	)
+
+	costTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "rag_orchestrator",
+			Subsystem: "model_route",
+			Name:      "cost_total",
+			Help:      "Tokens weighted by the route's configured cost per 1k tokens, by model route and serving model.",
+		},
+		[]string{"route", "model"},
+	)
+)
+
+// Recorder implements usecase.ModelRouteRecorder.
+type Recorder struct{}
+
+// RecordModelRouteCall updates the per-route metrics for one call.
+func (Recorder) RecordModelRouteCall(obs usecase.ModelRouteObservation) {
+	callsTotal.WithLabelValues(obs.Route, obs.Model, obs.Method, obs.Outcome).Inc()
+	latencySeconds.WithLabelValues(obs.Route, obs.Model, obs.Method).Observe(obs.Latency.Seconds())
+	if obs.PromptTokens > 0 {
+		tokensTotal.WithLabelValues(obs.Route, obs.Model, "prompt").Add(float64(obs.PromptTokens))
+	}
+	if obs.CompletionTokens > 0 {
+		tokensTotal.WithLabelValues(obs.Route, obs.Model, "completion").Add(float64(obs.CompletionTokens))
+	}
+	if obs.Cost > 0 {
+		costTotal.WithLabelValues(obs.Route, obs.Model).Add(obs.Cost)
+	}
+}
@@ -0,0 +1,121 @@
+package rag_augur
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// embeddingCacheLookups counts query embedding cache lookups by result
+// (hit, miss). Hit rate is hit / (hit + miss).
+var embeddingCacheLookups = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "embedding_cache",
+		Name:      "lookups_total",
+		Help:      "Query embedding cache lookups by result (hit, miss).",
+	},
+	[]string{"result"},
+)
+
+// embeddingCacheEntries tracks how many query embeddings are cached.
+var embeddingCacheEntries = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "embedding_cache",
+		Name:      "entries",
+		Help:      "Number of query embeddings currently cached.",
+	},
+)
+
+// CachedEmbedder wraps a VectorEncoder with an LRU of query embeddings so
+// repeated or near-identical queries skip the embedder call. Texts are
+// keyed by model and normalised form (trimmed, whitespace collapsed,
+// lower-cased); only the texts missing from the cache are sent, in one
+// batch.
+//
+// It is meant for query-time retrieval. Indexing embeds each chunk once,
+// so caching it would only evict queries. Cached vectors are shared
+// between callers and must not be modified.
+type CachedEmbedder struct {
+	inner   domain.VectorEncoder
+	entries *expirable.LRU[string, []float32]
+}
+
+// NewCachedEmbedder caches up to size embeddings from inner for ttl each.
+func NewCachedEmbedder(inner domain.VectorEncoder, size int, ttl time.Duration) *CachedEmbedder {
+	return &CachedEmbedder{
+		inner: inner,
+		entries: expirable.NewLRU[string, []float32](size, func(string, []float32) {
+			embeddingCacheEntries.Dec()
+		}, ttl),
+	}
+}
+
+// Encode returns one embedding per text, serving cached ones and embedding
+// the rest.
+func (c *CachedEmbedder) Encode(ctx context.Context, texts []string) ([][]float32, error) {
+	version := c.inner.Version()
+	out := make([][]float32, len(texts))
+	keys := make([]string, len(texts))
+
+	// Misses are embedded once per key, even if a batch repeats a query.
+	var missTexts []string
+	missIndex := map[string]int{}
+	for i, text := range texts {
+		keys[i] = version + "\x00" + normalizeQueryText(text)
+		if vec, ok := c.entries.Get(keys[i]); ok {
+			embeddingCacheLookups.WithLabelValues("hit").Inc()
+			out[i] = vec
+			continue
+		}
+		embeddingCacheLookups.WithLabelValues("miss").Inc()
+		if _, seen := missIndex[keys[i]]; !seen {
+			missIndex[keys[i]] = len(missTexts)
+			missTexts = append(missTexts, text)
+		}
+	}
+	if len(missTexts) == 0 {
+		return out, nil
+	}
+
+	embedded, err := c.inner.Encode(ctx, missTexts)
+	if err != nil {
+		return nil, err
+	}
+	if len(embedded) != len(missTexts) {
+		return nil, fmt.Errorf("embedder returned %d embeddings for %d texts", len(embedded), len(missTexts))
+	}
+	for key, j := range missIndex {
+		if !c.entries.Contains(key) {
+			embeddingCacheEntries.Inc()
+		}
+		c.entries.Add(key, embedded[j])
+	}
+	for i := range out {
+		if out[i] == nil {
+			out[i] = embedded[missIndex[keys[i]]]
+		}
+	}
+	return out, nil
+}
+
+// Version returns the wrapped encoder's version.
+func (c *CachedEmbedder) Version() string {
+	return c.inner.Version()
+}
+
+// normalizeQueryText folds queries that differ only in case or spacing
+// onto one cache key.
+func normalizeQueryText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+var _ domain.VectorEncoder = (*CachedEmbedder)(nil)
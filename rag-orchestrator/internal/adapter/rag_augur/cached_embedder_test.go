@@ -0,0 +1,85 @@
+package rag_augur
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingEncoder struct {
+	calls [][]string
+	err   error
+}
+
+func (e *countingEncoder) Encode(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls = append(e.calls, texts)
+	if e.err != nil {
+		return nil, e.err
+	}
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		out[i] = []float32{float32(len(text))}
+	}
+	return out, nil
+}
+
+func (e *countingEncoder) Version() string { return "test-model" }
+
+func TestCachedEmbedder_ServesRepeatedQueriesFromCache(t *testing.T) {
+	inner := &countingEncoder{}
+	cache := NewCachedEmbedder(inner, 16, time.Hour)
+
+	first, err := cache.Encode(context.Background(), []string{"Go generics"})
+	require.NoError(t, err)
+	second, err := cache.Encode(context.Background(), []string{"  go   GENERICS "})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Len(t, inner.calls, 1)
+}
+
+func TestCachedEmbedder_EmbedsOnlyMisses(t *testing.T) {
+	inner := &countingEncoder{}
+	cache := NewCachedEmbedder(inner, 16, time.Hour)
+
+	_, err := cache.Encode(context.Background(), []string{"cached"})
+	require.NoError(t, err)
+
+	got, err := cache.Encode(context.Background(), []string{"new one", "cached", "New  one"})
+	require.NoError(t, err)
+
+	require.Len(t, inner.calls, 2)
+	assert.Equal(t, []string{"new one"}, inner.calls[1])
+	assert.Equal(t, [][]float32{{7}, {6}, {7}}, got)
+}
+
+func TestCachedEmbedder_DoesNotCacheErrors(t *testing.T) {
+	inner := &countingEncoder{err: errors.New("embedder down")}
+	cache := NewCachedEmbedder(inner, 16, time.Hour)
+
+	_, err := cache.Encode(context.Background(), []string{"query"})
+	require.Error(t, err)
+
+	inner.err = nil
+	got, err := cache.Encode(context.Background(), []string{"query"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{5}}, got)
+	assert.Len(t, inner.calls, 2)
+}
+
+func TestCachedEmbedder_ExpiresEntries(t *testing.T) {
+	inner := &countingEncoder{}
+	cache := NewCachedEmbedder(inner, 16, 10*time.Millisecond)
+
+	_, err := cache.Encode(context.Background(), []string{"query"})
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	_, err = cache.Encode(context.Background(), []string{"query"})
+	require.NoError(t, err)
+
+	assert.Len(t, inner.calls, 2)
+}
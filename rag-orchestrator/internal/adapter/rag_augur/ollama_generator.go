@@ -70,7 +70,9 @@ type chatResponse struct {
 		Thinking  string            `json:"thinking"`
 		ToolCalls []domain.ToolCall `json:"tool_calls"`
 	} `json:"message"`
-	Done bool `json:"done"`
+	Done            bool `json:"done"`
+	PromptEvalCount int  `json:"prompt_eval_count"`
+	EvalCount       int  `json:"eval_count"`
 }
 
 // OllamaGenerator sends prompts to Ollama's chat endpoint and returns structured text.
@@ -526,9 +528,11 @@ func (g *OllamaGenerator) Chat(ctx context.Context, messages []domain.Message, m
 		slog.Bool("done", chatResp.Done))
 
 	return &domain.LLMResponse{
-		Text:      chatResp.Message.Content,
-		ToolCalls: chatResp.Message.ToolCalls,
-		Done:      chatResp.Done,
+		Text:             chatResp.Message.Content,
+		ToolCalls:        chatResp.Message.ToolCalls,
+		Done:             chatResp.Done,
+		PromptTokens:     chatResp.PromptEvalCount,
+		CompletionTokens: chatResp.EvalCount,
 	}, nil
 }
 
@@ -603,9 +607,11 @@ func (g *OllamaGenerator) ChatWithTools(ctx context.Context, messages []domain.M
 		slog.Bool("done", chatResp.Done))
 
 	return &domain.LLMResponse{
-		Text:      chatResp.Message.Content,
-		ToolCalls: chatResp.Message.ToolCalls,
-		Done:      chatResp.Done,
+		Text:             chatResp.Message.Content,
+		ToolCalls:        chatResp.Message.ToolCalls,
+		Done:             chatResp.Done,
+		PromptTokens:     chatResp.PromptEvalCount,
+		CompletionTokens: chatResp.EvalCount,
 	}, nil
 }
 
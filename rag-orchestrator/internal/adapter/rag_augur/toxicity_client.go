@@ -0,0 +1,76 @@
+package rag_augur
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+)
+
+// ToxicityRequest is the request payload for the toxicity classifier.
+type ToxicityRequest struct {
+	Text string `json:"text"`
+}
+
+// ToxicityResponse is the classifier's response.
+type ToxicityResponse struct {
+	Score  float64  `json:"score"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// ToxicityClient implements domain.ToxicityClassifier against any endpoint
+// that accepts {"text": ...} and answers {"score": 0..1, "labels": [...]}.
+type ToxicityClient struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewToxicityClient constructs a ToxicityClient. url is the full classify
+// endpoint URL. If client is nil, a default http.Client with timeout is used.
+func NewToxicityClient(url string, timeout time.Duration, client ...*http.Client) *ToxicityClient {
+	var c *http.Client
+	if len(client) > 0 && client[0] != nil {
+		c = client[0]
+	} else {
+		c = &http.Client{Timeout: timeout}
+	}
+	return &ToxicityClient{URL: url, Client: c}
+}
+
+// ClassifyToxicity scores text with the classifier endpoint.
+func (c *ToxicityClient) ClassifyToxicity(ctx context.Context, text string) (*domain.ToxicityResult, error) {
+	payload, err := json.Marshal(ToxicityRequest{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal toxicity request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create toxicity request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call toxicity endpoint: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("toxicity endpoint returned %d: %s", resp.StatusCode, truncateString(string(body), 200))
+	}
+
+	var out ToxicityResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode toxicity response: %w", err)
+	}
+	if out.Score < 0 || out.Score > 1 {
+		return nil, fmt.Errorf("toxicity score %v out of range [0,1]", out.Score)
+	}
+	return &domain.ToxicityResult{Score: out.Score, Labels: out.Labels}, nil
+}
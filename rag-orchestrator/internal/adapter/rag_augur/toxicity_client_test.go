@@ -0,0 +1,49 @@
+package rag_augur
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToxicityClient_ClassifyToxicity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var req ToxicityRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "generated answer", req.Text)
+		_ = json.NewEncoder(w).Encode(ToxicityResponse{Score: 0.42, Labels: []string{"insult"}})
+	}))
+	defer server.Close()
+
+	res, err := NewToxicityClient(server.URL, 5*time.Second).ClassifyToxicity(context.Background(), "generated answer")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.42, res.Score, 1e-9)
+	assert.Equal(t, []string{"insult"}, res.Labels)
+}
+
+func TestToxicityClient_Errors(t *testing.T) {
+	t.Run("non-200", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "model loading", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		_, err := NewToxicityClient(server.URL, 5*time.Second).ClassifyToxicity(context.Background(), "x")
+		assert.ErrorContains(t, err, "503")
+	})
+
+	t.Run("score out of range", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"score": 7}`))
+		}))
+		defer server.Close()
+		_, err := NewToxicityClient(server.URL, 5*time.Second).ClassifyToxicity(context.Background(), "x")
+		assert.ErrorContains(t, err, "out of range")
+	})
+}
@@ -0,0 +1,76 @@
+package rag_http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	corpusStatsTimeout     = 30 * time.Second
+	reconcileOrphanTimeout = 5 * time.Minute
+)
+
+// WithCorpusStats enables GET /internal/rag/stats and
+// POST /internal/rag/stats/reconcile.
+func WithCorpusStats(stats usecase.CorpusStatsUsecase) HandlerOption {
+	return func(h *Handler) {
+		h.corpusStats = stats
+	}
+}
+
+// corpusStatsResponse adds the configured embedding model to the stored
+// statistics so a dimension or version mismatch is visible at a glance.
+type corpusStatsResponse struct {
+	*domain.CorpusStats
+	EmbeddingModel string `json:"embedding_model"`
+}
+
+// CorpusStats reports what the index holds and how healthy it is.
+// (GET /internal/rag/stats)
+func (h *Handler) CorpusStats(ctx echo.Context) error {
+	if h.corpusStats == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "corpus stats are disabled"})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Request().Context(), corpusStatsTimeout)
+	defer cancel()
+
+	stats, err := h.corpusStats.Stats(timeoutCtx)
+	if err != nil {
+		h.logger.Error("failed to compute corpus stats", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to compute corpus stats"})
+	}
+	return ctx.JSON(http.StatusOK, corpusStatsResponse{CorpusStats: stats, EmbeddingModel: h.embeddingModel})
+}
+
+// ReconcileOrphans deletes chunks of superseded versions now instead of
+// waiting for the periodic run. (POST /internal/rag/stats/reconcile)
+func (h *Handler) ReconcileOrphans(ctx echo.Context) error {
+	if h.corpusStats == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "corpus stats are disabled"})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Request().Context(), reconcileOrphanTimeout)
+	defer cancel()
+
+	result, err := h.corpusStats.ReconcileOrphans(timeoutCtx)
+	if err != nil {
+		// A partial run still committed its batches; report them.
+		var deleted int64
+		if result != nil {
+			deleted = result.DeletedChunks
+		}
+		h.logger.Error("orphaned chunk reconcile failed", "deleted_chunks", deleted, "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]any{
+			"error":          "reconcile failed",
+			"deleted_chunks": deleted,
+		})
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,123 @@
+package rag_http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCorpusStatsUsecase struct {
+	stats        *domain.CorpusStats
+	statsErr     error
+	reconcile    *usecase.ReconcileResult
+	reconcileErr error
+}
+
+func (s *stubCorpusStatsUsecase) Stats(context.Context) (*domain.CorpusStats, error) {
+	return s.stats, s.statsErr
+}
+
+func (s *stubCorpusStatsUsecase) ReconcileOrphans(context.Context) (*usecase.ReconcileResult, error) {
+	return s.reconcile, s.reconcileErr
+}
+
+func newCorpusStatsHandler(uc usecase.CorpusStatsUsecase) *rag_http.Handler {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	return rag_http.NewHandler(nil, nil, nil, nil, nil, logger, rag_http.WithCorpusStats(uc))
+}
+
+func TestHandler_CorpusStats(t *testing.T) {
+	t.Run("returns stats", func(t *testing.T) {
+		oldest := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		uc := &stubCorpusStatsUsecase{stats: &domain.CorpusStats{
+			Documents: 12,
+			DocumentsBySource: []domain.SourceDocumentCount{
+				{SourceType: domain.SourceArticle, Documents: 10},
+				{SourceType: domain.SourceNote, UserID: "u1", Documents: 2},
+			},
+			OrphanedChunks:         40,
+			EmbeddingDimension:     768,
+			OldestIndexedArticleAt: &oldest,
+			JobBacklog:             domain.JobBacklog{New: 5, Failed: 1},
+		}}
+
+		req := httptest.NewRequest(http.MethodGet, "/internal/rag/stats", nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, newCorpusStatsHandler(uc).CorpusStats(echo.New().NewContext(req, rec)))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.EqualValues(t, 12, body["documents"])
+		assert.EqualValues(t, 40, body["orphaned_chunks"])
+		assert.EqualValues(t, 768, body["embedding_dimension"])
+		assert.Equal(t, "2026-01-02T03:04:05Z", body["oldest_indexed_article_at"])
+		assert.Len(t, body["documents_by_source"], 2)
+		assert.EqualValues(t, 5, body["job_backlog"].(map[string]any)["new"])
+		assert.Contains(t, body, "embedding_model")
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		uc := &stubCorpusStatsUsecase{statsErr: errors.New("db down")}
+		req := httptest.NewRequest(http.MethodGet, "/internal/rag/stats", nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, newCorpusStatsHandler(uc).CorpusStats(echo.New().NewContext(req, rec)))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		h := rag_http.NewHandler(nil, nil, nil, nil, nil, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+		req := httptest.NewRequest(http.MethodGet, "/internal/rag/stats", nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.CorpusStats(echo.New().NewContext(req, rec)))
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+}
+
+func TestHandler_ReconcileOrphans(t *testing.T) {
+	tests := []struct {
+		name        string
+		uc          *stubCorpusStatsUsecase
+		wantStatus  int
+		wantDeleted float64
+	}{
+		{
+			name:        "completed",
+			uc:          &stubCorpusStatsUsecase{reconcile: &usecase.ReconcileResult{DeletedChunks: 30, Batches: 1, Complete: true}},
+			wantStatus:  http.StatusOK,
+			wantDeleted: 30,
+		},
+		{
+			name:        "partial failure reports committed batches",
+			uc:          &stubCorpusStatsUsecase{reconcile: &usecase.ReconcileResult{DeletedChunks: 2000}, reconcileErr: errors.New("timeout")},
+			wantStatus:  http.StatusInternalServerError,
+			wantDeleted: 2000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/internal/rag/stats/reconcile", nil)
+			rec := httptest.NewRecorder()
+			require.NoError(t, newCorpusStatsHandler(tt.uc).ReconcileOrphans(echo.New().NewContext(req, rec)))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantDeleted, body["deleted_chunks"])
+		})
+	}
+}
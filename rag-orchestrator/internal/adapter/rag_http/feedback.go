@@ -0,0 +1,208 @@
+package rag_http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// recordAnswerTimeout bounds the answer snapshot insert. The snapshot is
+// detached from the request context so a client hanging up right after the
+// done event does not lose it.
+const recordAnswerTimeout = 5 * time.Second
+
+// WithAnswerFeedback enables answer snapshots and the feedback endpoints.
+func WithAnswerFeedback(feedbackUsecase usecase.AnswerFeedbackUsecase) HandlerOption {
+	return func(h *Handler) {
+		h.feedbackUsecase = feedbackUsecase
+	}
+}
+
+// recordAnswer snapshots output for later feedback. Failures are logged and
+// never fail the answer itself: feedback is best-effort.
+func (h *Handler) recordAnswer(ctx context.Context, input usecase.AnswerWithRAGInput, output *usecase.AnswerWithRAGOutput) {
+	if h.feedbackUsecase == nil {
+		return
+	}
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordAnswerTimeout)
+	defer cancel()
+	if err := h.feedbackUsecase.RecordAnswer(recordCtx, input, output); err != nil {
+		h.logger.Warn("failed to record answer for feedback",
+			"retrieval_set_id", output.Debug.RetrievalSetID,
+			"error", err)
+	}
+}
+
+// answerFeedbackRequest is the body of POST /v1/rag/answers/:id/feedback.
+type answerFeedbackRequest struct {
+	Rating          string   `json:"rating"`
+	Comment         string   `json:"comment"`
+	HelpfulChunkIDs []string `json:"helpful_chunk_ids"`
+}
+
+type answerFeedbackResponse struct {
+	ID        string    `json:"id"`
+	AnswerID  string    `json:"answer_id"`
+	Rating    string    `json:"rating"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SubmitAnswerFeedback records a reader's verdict on an answer. The answer
+// ID is the retrieval_set_id returned in the answer's debug block.
+// (POST /v1/rag/answers/:id/feedback)
+func (h *Handler) SubmitAnswerFeedback(ctx echo.Context) error {
+	if h.feedbackUsecase == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "answer feedback is disabled"})
+	}
+
+	answerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid answer id"})
+	}
+	var req answerFeedbackRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	feedback, err := h.feedbackUsecase.SubmitFeedback(ctx.Request().Context(), usecase.SubmitFeedbackInput{
+		AnswerID:        answerID,
+		Rating:          domain.FeedbackRating(req.Rating),
+		Comment:         req.Comment,
+		HelpfulChunkIDs: req.HelpfulChunkIDs,
+	})
+	switch {
+	case errors.Is(err, usecase.ErrInvalidFeedback):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAnswerNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"error": "answer not found"})
+	case err != nil:
+		h.logger.Error("failed to submit answer feedback", "answer_id", answerID.String(), "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to record feedback"})
+	}
+
+	return ctx.JSON(http.StatusCreated, answerFeedbackResponse{
+		ID:        feedback.ID.String(),
+		AnswerID:  feedback.AnswerID.String(),
+		Rating:    string(feedback.Rating),
+		CreatedAt: feedback.CreatedAt,
+	})
+}
+
+// feedbackExportItem is one exported feedback row with the answer snapshot
+// it rates. The shape is consumed by eval.LoadFeedbackExport.
+type feedbackExportItem struct {
+	FeedbackID      string               `json:"feedback_id"`
+	Rating          string               `json:"rating"`
+	Comment         string               `json:"comment,omitempty"`
+	HelpfulChunkIDs []string             `json:"helpful_chunk_ids"`
+	CreatedAt       time.Time            `json:"created_at"`
+	AnswerID        string               `json:"answer_id"`
+	Query           string               `json:"query"`
+	Answer          string               `json:"answer"`
+	PromptVersion   string               `json:"prompt_version"`
+	StrategyUsed    string               `json:"strategy_used,omitempty"`
+	IntentType      string               `json:"intent_type,omitempty"`
+	Chunks          []domain.AnswerChunk `json:"chunks"`
+	Citations       []domain.AnswerChunk `json:"citations"`
+}
+
+type feedbackExportResponse struct {
+	Items      []feedbackExportItem `json:"items"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// ExportFeedback pages through feedback for the eval harness.
+// Query params: since (RFC3339, default epoch), limit (default 500, max
+// 1000), cursor (opaque, from next_cursor).
+// (GET /internal/rag/feedback/export)
+func (h *Handler) ExportFeedback(ctx echo.Context) error {
+	if h.feedbackUsecase == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "answer feedback is disabled"})
+	}
+
+	var since time.Time
+	if raw := ctx.QueryParam("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "since must be RFC3339"})
+		}
+		since = parsed
+	}
+	limit := 500
+	if raw := ctx.QueryParam("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 || n > 1000 {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "limit must be between 1 and 1000"})
+		}
+		limit = n
+	}
+	var afterCreatedAt *time.Time
+	var afterID *uuid.UUID
+	if raw := ctx.QueryParam("cursor"); raw != "" {
+		ts, id, err := decodeFeedbackCursor(raw)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid cursor"})
+		}
+		afterCreatedAt, afterID = &ts, &id
+	}
+
+	items, err := h.feedbackUsecase.ExportFeedback(ctx.Request().Context(), since, afterCreatedAt, afterID, limit)
+	if err != nil {
+		h.logger.Error("failed to export answer feedback", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to export feedback"})
+	}
+
+	resp := feedbackExportResponse{Items: make([]feedbackExportItem, 0, len(items))}
+	for _, it := range items {
+		resp.Items = append(resp.Items, feedbackExportItem{
+			FeedbackID:      it.Feedback.ID.String(),
+			Rating:          string(it.Feedback.Rating),
+			Comment:         it.Feedback.Comment,
+			HelpfulChunkIDs: it.Feedback.HelpfulChunkIDs,
+			CreatedAt:       it.Feedback.CreatedAt,
+			AnswerID:        it.Answer.ID.String(),
+			Query:           it.Answer.Query,
+			Answer:          it.Answer.Answer,
+			PromptVersion:   it.Answer.PromptVersion,
+			StrategyUsed:    it.Answer.StrategyUsed,
+			IntentType:      it.Answer.IntentType,
+			Chunks:          it.Answer.Chunks,
+			Citations:       it.Answer.Citations,
+		})
+	}
+	if len(items) == limit {
+		last := items[len(items)-1].Feedback
+		resp.NextCursor = encodeFeedbackCursor(last.CreatedAt, last.ID)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// The cursor is "<unix nanos>_<feedback id>"; opaque to callers.
+func encodeFeedbackCursor(createdAt time.Time, id uuid.UUID) string {
+	return strconv.FormatInt(createdAt.UnixNano(), 10) + "_" + id.String()
+}
+
+func decodeFeedbackCursor(raw string) (time.Time, uuid.UUID, error) {
+	nanosPart, idPart, ok := strings.Cut(raw, "_")
+	if !ok {
+		return time.Time{}, uuid.Nil, errors.New("malformed cursor")
+	}
+	nanos, err := strconv.ParseInt(nanosPart, 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	return time.Unix(0, nanos).UTC(), id, nil
+}
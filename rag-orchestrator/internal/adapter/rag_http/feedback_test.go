@@ -0,0 +1,167 @@
+package rag_http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFeedbackUsecase struct {
+	submitted   []usecase.SubmitFeedbackInput
+	submitErr   error
+	exportItems []domain.AnswerFeedbackExport
+	exportLimit int
+	afterID     *uuid.UUID
+}
+
+func (s *stubFeedbackUsecase) RecordAnswer(ctx context.Context, input usecase.AnswerWithRAGInput, output *usecase.AnswerWithRAGOutput) error {
+	return nil
+}
+
+func (s *stubFeedbackUsecase) SubmitFeedback(ctx context.Context, input usecase.SubmitFeedbackInput) (*domain.AnswerFeedback, error) {
+	s.submitted = append(s.submitted, input)
+	if s.submitErr != nil {
+		return nil, s.submitErr
+	}
+	return &domain.AnswerFeedback{
+		ID:        uuid.New(),
+		AnswerID:  input.AnswerID,
+		Rating:    input.Rating,
+		CreatedAt: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+	}, nil
+}
+
+func (s *stubFeedbackUsecase) ExportFeedback(ctx context.Context, since time.Time, afterCreatedAt *time.Time, afterID *uuid.UUID, limit int) ([]domain.AnswerFeedbackExport, error) {
+	s.exportLimit = limit
+	s.afterID = afterID
+	return s.exportItems, nil
+}
+
+func newFeedbackHandler(uc usecase.AnswerFeedbackUsecase) *rag_http.Handler {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	return rag_http.NewHandler(nil, nil, nil, nil, nil, logger, rag_http.WithAnswerFeedback(uc))
+}
+
+func postFeedback(t *testing.T, h *rag_http.Handler, answerID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/v1/rag/answers/"+answerID+"/feedback", bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(answerID)
+	require.NoError(t, h.SubmitAnswerFeedback(c))
+	return rec
+}
+
+func TestHandler_SubmitAnswerFeedback(t *testing.T) {
+	answerID := uuid.New()
+
+	tests := []struct {
+		name       string
+		answerID   string
+		body       string
+		submitErr  error
+		wantStatus int
+	}{
+		{name: "created", answerID: answerID.String(), body: `{"rating":"up","helpful_chunk_ids":["c1"]}`, wantStatus: http.StatusCreated},
+		{name: "invalid answer id", answerID: "not-a-uuid", body: `{"rating":"up"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid feedback", answerID: answerID.String(), body: `{"rating":"meh"}`, submitErr: usecase.ErrInvalidFeedback, wantStatus: http.StatusBadRequest},
+		{name: "unknown answer", answerID: answerID.String(), body: `{"rating":"down"}`, submitErr: usecase.ErrAnswerNotFound, wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &stubFeedbackUsecase{submitErr: tt.submitErr}
+			rec := postFeedback(t, newFeedbackHandler(uc), tt.answerID, tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+
+	t.Run("passes body through", func(t *testing.T) {
+		uc := &stubFeedbackUsecase{}
+		postFeedback(t, newFeedbackHandler(uc), answerID.String(), `{"rating":"up","comment":"great","helpful_chunk_ids":["c1","c2"]}`)
+		require.Len(t, uc.submitted, 1)
+		assert.Equal(t, answerID, uc.submitted[0].AnswerID)
+		assert.Equal(t, domain.FeedbackRatingUp, uc.submitted[0].Rating)
+		assert.Equal(t, "great", uc.submitted[0].Comment)
+		assert.Equal(t, []string{"c1", "c2"}, uc.submitted[0].HelpfulChunkIDs)
+	})
+}
+
+func TestHandler_SubmitAnswerFeedback_Disabled(t *testing.T) {
+	h := rag_http.NewHandler(nil, nil, nil, nil, nil, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+	rec := postFeedback(t, h, uuid.NewString(), `{"rating":"up"}`)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
+
+func TestHandler_ExportFeedback_Pagination(t *testing.T) {
+	createdAt := time.Date(2026, 10, 16, 9, 0, 0, 123456000, time.UTC)
+	lastID := uuid.New()
+	uc := &stubFeedbackUsecase{exportItems: []domain.AnswerFeedbackExport{
+		{
+			Feedback: domain.AnswerFeedback{ID: uuid.New(), Rating: domain.FeedbackRatingUp, CreatedAt: createdAt},
+			Answer:   domain.AnswerRecord{ID: uuid.New(), Query: "q1", PromptVersion: "alpha-v2"},
+		},
+		{
+			Feedback: domain.AnswerFeedback{ID: lastID, Rating: domain.FeedbackRatingDown, CreatedAt: createdAt},
+			Answer:   domain.AnswerRecord{ID: uuid.New(), Query: "q2", PromptVersion: "alpha-v2"},
+		},
+	}}
+	h := newFeedbackHandler(uc)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/rag/feedback/export?limit=2", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.ExportFeedback(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, uc.exportLimit)
+
+	var resp struct {
+		Items []struct {
+			FeedbackID string `json:"feedback_id"`
+			Rating     string `json:"rating"`
+			Query      string `json:"query"`
+		} `json:"items"`
+		NextCursor string `json:"next_cursor"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, "q2", resp.Items[1].Query)
+	require.NotEmpty(t, resp.NextCursor)
+
+	// Feeding the cursor back resumes after the last row.
+	req = httptest.NewRequest(http.MethodGet, "/internal/rag/feedback/export?limit=2&cursor="+resp.NextCursor, nil)
+	rec = httptest.NewRecorder()
+	require.NoError(t, h.ExportFeedback(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, uc.afterID)
+	assert.Equal(t, lastID, *uc.afterID)
+}
+
+func TestHandler_ExportFeedback_BadParams(t *testing.T) {
+	h := newFeedbackHandler(&stubFeedbackUsecase{})
+	e := echo.New()
+
+	for _, query := range []string{"since=yesterday", "limit=0", "limit=5000", "cursor=garbage"} {
+		req := httptest.NewRequest(http.MethodGet, "/internal/rag/feedback/export?"+query, nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.ExportFeedback(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
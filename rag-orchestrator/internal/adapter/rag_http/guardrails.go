@@ -0,0 +1,56 @@
+package rag_http
+
+import (
+	"net/http"
+
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithGuardrails enables GET and PUT /internal/rag/guardrails.
+func WithGuardrails(chain *usecase.GuardrailChain) HandlerOption {
+	return func(h *Handler) {
+		h.guardrails = chain
+	}
+}
+
+type guardrailsResponse struct {
+	Mode    usecase.GuardrailMode `json:"mode"`
+	Filters []string              `json:"filters"`
+}
+
+type guardrailsRequest struct {
+	Mode string `json:"mode"`
+}
+
+// Guardrails reports the answer guardrail mode and filters.
+// (GET /internal/rag/guardrails)
+func (h *Handler) Guardrails(ctx echo.Context) error {
+	if h.guardrails == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "answer guardrails are disabled"})
+	}
+	return ctx.JSON(http.StatusOK, guardrailsResponse{Mode: h.guardrails.Mode(), Filters: h.guardrails.FilterNames()})
+}
+
+// SetGuardrailMode switches the answer guardrails between "enforce" and
+// "observe" without a restart. The change is not persisted; a restart goes
+// back to RAG_GUARDRAILS_MODE. (PUT /internal/rag/guardrails)
+func (h *Handler) SetGuardrailMode(ctx echo.Context) error {
+	if h.guardrails == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "answer guardrails are disabled"})
+	}
+	var req guardrailsRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	mode, err := usecase.ParseGuardrailMode(req.Mode)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	h.guardrails.SetMode(mode)
+	h.logger.Info("guardrail_mode_set_via_admin",
+		"mode", string(mode),
+		"remote_addr", ctx.RealIP())
+	return ctx.JSON(http.StatusOK, guardrailsResponse{Mode: mode, Filters: h.guardrails.FilterNames()})
+}
@@ -0,0 +1,61 @@
+package rag_http_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGuardrailsHandler(chain *usecase.GuardrailChain) *rag_http.Handler {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	return rag_http.NewHandler(nil, nil, nil, nil, nil, logger, rag_http.WithGuardrails(chain))
+}
+
+func TestHandler_Guardrails(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	chain := usecase.NewGuardrailChain(usecase.GuardrailModeObserve, nil, logger, usecase.NewPIIRedactionFilter())
+	h := newGuardrailsHandler(chain)
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/rag/guardrails", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.Guardrails(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"mode":"observe","filters":["pii_redaction"]}`, rec.Body.String())
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/internal/rag/guardrails", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.SetGuardrailMode(echo.New().NewContext(req, rec)))
+		return rec
+	}
+
+	rec = put(`{"mode":"enforce"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, usecase.GuardrailModeEnforce, chain.Mode())
+
+	rec = put(`{"mode":"off"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Contains(t, body["error"], "unknown guardrail mode")
+	assert.Equal(t, usecase.GuardrailModeEnforce, chain.Mode())
+}
+
+func TestHandler_Guardrails_Disabled(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/internal/rag/guardrails", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, newGuardrailsHandler(nil).Guardrails(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
@@ -3,6 +3,7 @@ package rag_http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -43,9 +44,36 @@ type Handler struct {
 	// nil/empty means the override is effectively disabled even if the
 	// factories are set — see isAllowedEmbedderOverride.
 	allowedEmbedderOverrideOrigins map[string]struct{}
+
+	// feedbackUsecase snapshots answers for later reader feedback. nil
+	// disables both the snapshots and the feedback endpoints.
+	feedbackUsecase usecase.AnswerFeedbackUsecase
+
+	// sourceIndexer backs POST /internal/rag/sources/index. nil disables it.
+	sourceIndexer  usecase.IndexSourceUsecase
+	textExtractor  domain.TextExtractor
+	maxUploadBytes int64
+
+	// corpusStats backs the /internal/rag/stats endpoints. nil disables them.
+	corpusStats usecase.CorpusStatsUsecase
+
+	// reindex backs the /internal/rag/reindex endpoints. nil disables them.
+	reindex usecase.ReindexUsecase
+
+	// guardrails backs the /internal/rag/guardrails admin switch. nil
+	// disables it.
+	guardrails *usecase.GuardrailChain
+
+	// usage backs GET /internal/rag/usage. nil disables it.
+	usage usecase.UsageAccountingUsecase
+
+	// openAIModels backs the OpenAI-compatible /v1 endpoints. nil
+	// disables them.
+	openAIModels []OpenAIModel
+	openAIAPIKey string
 }
 
-func mapAnswerRequestToInput(req openapi.AnswerRequest) usecase.AnswerWithRAGInput {
+func mapAnswerRequestToInput(req openapi.AnswerRequest) (usecase.AnswerWithRAGInput, error) {
 	input := usecase.AnswerWithRAGInput{
 		Query: req.Query,
 	}
@@ -64,7 +92,81 @@ func mapAnswerRequestToInput(req openapi.AnswerRequest) usecase.AnswerWithRAGInp
 	if req.MaxTokens != nil {
 		input.MaxTokens = int(*req.MaxTokens)
 	}
-	return input
+	sourceTypes, err := parseSourceTypes(req.SourceTypes)
+	if err != nil {
+		return usecase.AnswerWithRAGInput{}, err
+	}
+	input.SourceTypes = sourceTypes
+	filter, err := parseRetrievalFilters(req.Filters)
+	if err != nil {
+		return usecase.AnswerWithRAGInput{}, err
+	}
+	input.Filter = filter
+	if err := applyAnswerStyle(&input, req.Style); err != nil {
+		return usecase.AnswerWithRAGInput{}, err
+	}
+	return input, nil
+}
+
+// applyAnswerStyle validates the request's style controls and maps them onto
+// the answer input. A nil style keeps every prompt default.
+func applyAnswerStyle(input *usecase.AnswerWithRAGInput, style *openapi.AnswerStyle) error {
+	if style == nil {
+		return nil
+	}
+	if style.Verbosity != nil && style.AnswerLength != nil {
+		return errors.New("set verbosity or answer_length, not both")
+	}
+	if style.Verbosity != nil {
+		length, err := usecase.ParseAnswerVerbosity(string(*style.Verbosity))
+		if err != nil {
+			return err
+		}
+		input.AnswerLength = length
+	}
+	if style.AnswerLength != nil {
+		length, err := usecase.ParseAnswerLength(string(*style.AnswerLength))
+		if err != nil {
+			return err
+		}
+		input.AnswerLength = length
+	}
+	if style.ReadingLevel != nil {
+		level, err := usecase.ParseReadingLevel(string(*style.ReadingLevel))
+		if err != nil {
+			return err
+		}
+		input.ReadingLevel = level
+	}
+	if style.Format != nil {
+		format, err := usecase.ParseAnswerFormat(string(*style.Format))
+		if err != nil {
+			return err
+		}
+		input.AnswerFormat = format
+	}
+	if style.Locale != nil {
+		// style.locale is the request's locale, validated. Both may be set
+		// as long as they name the same language.
+		locale, err := usecase.ParseAnswerLocale(*style.Locale)
+		if err != nil {
+			return err
+		}
+		if input.Locale != "" {
+			if requested, err := usecase.ParseAnswerLocale(input.Locale); err != nil || requested != locale {
+				return fmt.Errorf("style.locale %q does not match locale %q", *style.Locale, input.Locale)
+			}
+		}
+		input.Locale = locale
+	}
+	if style.MaxSentences != nil {
+		n := int(*style.MaxSentences)
+		if err := usecase.ValidateMaxSentences(n); err != nil {
+			return err
+		}
+		input.MaxSentences = n
+	}
+	return nil
 }
 
 // HandlerOption configures the Handler.
@@ -197,12 +299,13 @@ func (h *Handler) UpsertIndex(ctx echo.Context) error {
 	}
 
 	// Index the article with all required fields from the request
-	if err := indexUsecase.Upsert(
+	if err := indexUsecase.UpsertWithMetadata(
 		timeoutCtx,
 		req.ArticleId,
 		req.Title,
 		req.Url, // URL is a required field per OpenAPI spec
 		req.Body,
+		upsertRetrievalMetadata(req),
 	); err != nil {
 		h.logger.Error("failed to upsert index", "error", err)
 		if isDuplicateKeyError(err) {
@@ -231,13 +334,17 @@ func (h *Handler) AnswerWithRAG(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "query is required"})
 	}
 
-	input := mapAnswerRequestToInput(req)
+	input, err := mapAnswerRequestToInput(req)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 
 	output, err := h.answerUsecase.Execute(ctx.Request().Context(), input)
 	if err != nil {
 		h.logger.Error("failed to answer with RAG", "error", err)
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to generate answer"})
 	}
+	h.recordAnswer(ctx.Request().Context(), input, output)
 
 	contexts := make([]openapi.Context, 0, len(output.Contexts))
 	for _, c := range output.Contexts {
@@ -263,6 +370,7 @@ func (h *Handler) AnswerWithRAG(ctx echo.Context) error {
 			Score:           &score,
 			DocumentVersion: &docVer,
 			ChunkId:         &chunkID,
+			SourceType:      contextSourceType(c.SourceType),
 		})
 	}
 
@@ -334,7 +442,10 @@ func (h *Handler) AnswerWithRAGStream(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "query is required"})
 	}
 
-	input := mapAnswerRequestToInput(req)
+	input, err := mapAnswerRequestToInput(req)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	events := h.answerUsecase.Stream(ctx.Request().Context(), input)
 
 	res := ctx.Response()
@@ -363,6 +474,11 @@ func (h *Handler) AnswerWithRAGStream(ctx echo.Context) error {
 				return err
 			}
 			flusher.Flush()
+			// Snapshot after the client already has the answer so the
+			// insert never delays the done event.
+			if output, ok := event.Payload.(*usecase.AnswerWithRAGOutput); ok && event.Kind == usecase.StreamEventKindDone {
+				h.recordAnswer(ctx.Request().Context(), input, output)
+			}
 			if event.Kind == usecase.StreamEventKindDone || event.Kind == usecase.StreamEventKindFallback {
 				return nil
 			}
@@ -448,6 +564,16 @@ func (h *Handler) RetrieveContext(ctx echo.Context) error {
 	if req.CandidateArticleIds != nil {
 		input.CandidateArticleIDs = *req.CandidateArticleIds
 	}
+	sourceTypes, err := parseSourceTypes(req.SourceTypes)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	input.SourceTypes = sourceTypes
+	filter, err := parseRetrievalFilters(req.Filters)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	input.Filter = filter
 
 	output, err := h.retrieveUsecase.Execute(ctx.Request().Context(), input)
 	if err != nil {
@@ -474,6 +600,7 @@ func (h *Handler) RetrieveContext(ctx echo.Context) error {
 			PublishedAt:     pubAt,
 			Score:           &score,
 			DocumentVersion: &docVer,
+			SourceType:      contextSourceType(c.SourceType),
 		})
 	}
 
@@ -180,18 +180,101 @@ func TestHandler_AnswerWithRAGStream(t *testing.T) {
 	}
 }
 
+func TestHandler_AnswerWithRAG_Style(t *testing.T) {
+	tests := []struct {
+		name       string
+		locale     string
+		style      string
+		wantStatus int
+		wantInput  usecase.AnswerWithRAGInput
+	}{
+		{
+			name:       "all controls",
+			style:      `{"verbosity":"concise","format":"bullet","locale":"EN-us","max_sentences":3}`,
+			wantStatus: http.StatusOK,
+			wantInput: usecase.AnswerWithRAGInput{
+				Query:        "TPU",
+				AnswerLength: usecase.AnswerLengthShort,
+				AnswerFormat: usecase.AnswerFormatBullet,
+				Locale:       "en-US",
+				MaxSentences: 3,
+			},
+		},
+		{
+			name:       "answer length and reading level",
+			style:      `{"answer_length":"medium","reading_level":"basic"}`,
+			wantStatus: http.StatusOK,
+			wantInput: usecase.AnswerWithRAGInput{
+				Query:        "TPU",
+				AnswerLength: usecase.AnswerLengthMedium,
+				ReadingLevel: usecase.ReadingLevelBasic,
+			},
+		},
+		{
+			name:       "locale matches style locale",
+			locale:     "ja-jp",
+			style:      `{"locale":"ja-JP"}`,
+			wantStatus: http.StatusOK,
+			wantInput:  usecase.AnswerWithRAGInput{Query: "TPU", Locale: "ja-JP"},
+		},
+		{
+			name:       "empty style keeps defaults",
+			style:      `{}`,
+			wantStatus: http.StatusOK,
+			wantInput:  usecase.AnswerWithRAGInput{Query: "TPU"},
+		},
+		{name: "unknown verbosity", style: `{"verbosity":"terse"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown answer length", style: `{"answer_length":"tiny"}`, wantStatus: http.StatusBadRequest},
+		{name: "verbosity with answer length", style: `{"verbosity":"concise","answer_length":"medium"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown reading level", style: `{"reading_level":"toddler"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown format", style: `{"format":"table"}`, wantStatus: http.StatusBadRequest},
+		{name: "malformed locale", style: `{"locale":"not a locale"}`, wantStatus: http.StatusBadRequest},
+		{name: "locale mismatch", locale: "ja", style: `{"locale":"en"}`, wantStatus: http.StatusBadRequest},
+		{name: "zero sentences", style: `{"max_sentences":0}`, wantStatus: http.StatusBadRequest},
+		{name: "too many sentences", style: `{"max_sentences":31}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer := &stubChatAnswerUsecase{output: &usecase.AnswerWithRAGOutput{Answer: "ok"}}
+			handler := rag_http.NewHandler(nil, answer, nil, nil, nil, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+
+			locale := ""
+			if tt.locale != "" {
+				locale = `"locale":"` + tt.locale + `",`
+			}
+			body := bytes.NewBufferString(`{"query":"TPU",` + locale + `"style":` + tt.style + `}`)
+			req := httptest.NewRequest(http.MethodPost, "/v1/rag/answer", body)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			if assert.NoError(t, handler.AnswerWithRAG(echo.New().NewContext(req, rec))) {
+				assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+				if tt.wantStatus == http.StatusOK {
+					assert.Equal(t, tt.wantInput, answer.input)
+				}
+			}
+		})
+	}
+}
+
 // dummyIndexUsecase captures the parameters passed to Upsert
 type dummyIndexUsecase struct {
 	capturedURL   string
 	capturedTitle string
 	capturedCtx   context.Context
+	capturedMeta  domain.RetrievalMetadata
 	returnError   error
 }
 
 func (d *dummyIndexUsecase) Upsert(ctx context.Context, articleID, title, url, body string) error {
+	return d.UpsertWithMetadata(ctx, articleID, title, url, body, domain.RetrievalMetadata{})
+}
+
+func (d *dummyIndexUsecase) UpsertWithMetadata(ctx context.Context, articleID, title, url, body string, meta domain.RetrievalMetadata) error {
 	d.capturedURL = url
 	d.capturedTitle = title
 	d.capturedCtx = ctx
+	d.capturedMeta = meta
 	return d.returnError
 }
 
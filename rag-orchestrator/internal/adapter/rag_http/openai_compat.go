@@ -0,0 +1,426 @@
+package rag_http
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// openAIFallbackAnswer is the assistant message sent when the RAG pipeline
+// falls back instead of answering. OpenAI clients have no fallback field, so
+// the reason code is appended for whoever reads the transcript.
+const openAIFallbackAnswer = "I could not find enough grounded context in the knowledge base to answer this."
+
+// OpenAIModel maps a client-facing model name to the answer style it selects.
+type OpenAIModel struct {
+	Name         string
+	AnswerLength usecase.AnswerLength
+	ReadingLevel usecase.ReadingLevel
+}
+
+// WithOpenAICompat enables the OpenAI-compatible POST /v1/chat/completions
+// and GET /v1/models endpoints. A non-empty apiKey must be sent as a bearer
+// token; nil models leaves the endpoints disabled.
+func WithOpenAICompat(models []OpenAIModel, apiKey string) HandlerOption {
+	return func(h *Handler) {
+		h.openAIModels = models
+		h.openAIAPIKey = apiKey
+	}
+}
+
+type chatCompletionRequest struct {
+	Model               string             `json:"model"`
+	Messages            []chatMessage      `json:"messages"`
+	Stream              bool               `json:"stream"`
+	StreamOptions       *chatStreamOptions `json:"stream_options"`
+	MaxTokens           *int               `json:"max_tokens"`
+	MaxCompletionTokens *int               `json:"max_completion_tokens"`
+	User                string             `json:"user"`
+}
+
+type chatStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatMessage struct {
+	Role    string      `json:"role"`
+	Content chatContent `json:"content"`
+}
+
+// chatContent accepts both content shapes OpenAI clients send: a plain
+// string, or an array of parts of which only "text" parts are supported.
+type chatContent string
+
+func (c *chatContent) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*c = ""
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*c = chatContent(text)
+		return nil
+	}
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return errors.New("message content must be a string or an array of content parts")
+	}
+	texts := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p.Type != "text" {
+			return fmt.Errorf("unsupported content part type %q (only text is supported)", p.Type)
+		}
+		texts = append(texts, p.Text)
+	}
+	*c = chatContent(strings.Join(texts, "\n"))
+	return nil
+}
+
+type chatCompletionResponse struct {
+	ID      string       `json:"id"`
+	Object  string       `json:"object"`
+	Created int64        `json:"created"`
+	Model   string       `json:"model"`
+	Choices []chatChoice `json:"choices"`
+	Usage   *chatUsage   `json:"usage,omitempty"`
+}
+
+type chatChoice struct {
+	Index        int               `json:"index"`
+	Message      *chatReplyMessage `json:"message,omitempty"`
+	Delta        *chatReplyMessage `json:"delta,omitempty"`
+	FinishReason *string           `json:"finish_reason"`
+}
+
+type chatReplyMessage struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content"`
+}
+
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type modelListResponse struct {
+	Object string        `json:"object"`
+	Data   []modelObject `json:"data"`
+}
+
+type modelObject struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+type openAIErrorResponse struct {
+	Error openAIErrorBody `json:"error"`
+}
+
+type openAIErrorBody struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Code    string `json:"code,omitempty"`
+}
+
+func openAIError(ctx echo.Context, status int, errType, code, message string) error {
+	return ctx.JSON(status, openAIErrorResponse{Error: openAIErrorBody{Message: message, Type: errType, Code: code}})
+}
+
+// authorizeOpenAI reports whether the request may use the OpenAI facade,
+// writing the error response when it may not.
+func (h *Handler) authorizeOpenAI(ctx echo.Context) (bool, error) {
+	if h.openAIModels == nil {
+		return false, openAIError(ctx, http.StatusNotImplemented, "invalid_request_error", "", "OpenAI-compatible API is disabled")
+	}
+	if h.openAIAPIKey == "" {
+		return true, nil
+	}
+	token, ok := strings.CutPrefix(ctx.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.openAIAPIKey)) != 1 {
+		return false, openAIError(ctx, http.StatusUnauthorized, "invalid_request_error", "invalid_api_key", "invalid API key")
+	}
+	return true, nil
+}
+
+func (h *Handler) lookupOpenAIModel(name string) (OpenAIModel, bool) {
+	for _, m := range h.openAIModels {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return OpenAIModel{}, false
+}
+
+// ListModels lists the model names ChatCompletions accepts.
+// (GET /v1/models)
+func (h *Handler) ListModels(ctx echo.Context) error {
+	if ok, err := h.authorizeOpenAI(ctx); !ok {
+		return err
+	}
+	resp := modelListResponse{Object: "list", Data: make([]modelObject, 0, len(h.openAIModels))}
+	for _, m := range h.openAIModels {
+		resp.Data = append(resp.Data, modelObject{ID: m.Name, Object: "model", OwnedBy: "rag-orchestrator"})
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// mapChatCompletionRequest turns an OpenAI chat request into a RAG answer
+// input. The last message must be the user's question; earlier user and
+// assistant turns become conversation history. System, developer and tool
+// messages are dropped: the orchestrator owns its grounded prompt.
+func mapChatCompletionRequest(req chatCompletionRequest, model OpenAIModel) (usecase.AnswerWithRAGInput, error) {
+	var turns []domain.Message
+	for _, m := range req.Messages {
+		switch m.Role {
+		case "user", "assistant":
+			turns = append(turns, domain.Message{Role: m.Role, Content: string(m.Content)})
+		case "system", "developer", "tool":
+		default:
+			return usecase.AnswerWithRAGInput{}, fmt.Errorf("unsupported message role %q", m.Role)
+		}
+	}
+	if len(turns) == 0 || turns[len(turns)-1].Role != "user" || strings.TrimSpace(turns[len(turns)-1].Content) == "" {
+		return usecase.AnswerWithRAGInput{}, errors.New("the last message must be a non-empty user message")
+	}
+
+	input := usecase.AnswerWithRAGInput{
+		Query:               turns[len(turns)-1].Content,
+		ConversationHistory: turns[:len(turns)-1],
+		UserID:              req.User,
+		AnswerLength:        model.AnswerLength,
+		ReadingLevel:        model.ReadingLevel,
+	}
+	maxTokens := req.MaxCompletionTokens
+	if maxTokens == nil {
+		maxTokens = req.MaxTokens
+	}
+	if maxTokens != nil {
+		if *maxTokens <= 0 {
+			return usecase.AnswerWithRAGInput{}, errors.New("max_tokens must be positive")
+		}
+		input.MaxTokens = *maxTokens
+	}
+	return input, nil
+}
+
+// estimateChatUsage approximates token usage with the same runes/3 estimate
+// the prompt budget uses. Prompt tokens count the conversation and the
+// retrieved context the answer was grounded on.
+func estimateChatUsage(input usecase.AnswerWithRAGInput, contexts []usecase.ContextItem, answer string) *chatUsage {
+	prompt := usecase.EstimateTokens(input.Query)
+	for _, m := range input.ConversationHistory {
+		prompt += usecase.EstimateTokens(m.Content)
+	}
+	for _, c := range contexts {
+		prompt += usecase.EstimateTokens(c.ChunkText)
+	}
+	completion := usecase.EstimateTokens(answer)
+	return &chatUsage{PromptTokens: prompt, CompletionTokens: completion, TotalTokens: prompt + completion}
+}
+
+func openAIFallbackContent(reason string) string {
+	if reason == "" {
+		return openAIFallbackAnswer
+	}
+	return fmt.Sprintf("%s (reason: %s)", openAIFallbackAnswer, reason)
+}
+
+// ChatCompletions answers an OpenAI-style chat completion request with the
+// RAG pipeline, streaming chat.completion.chunk events when stream is set.
+// (POST /v1/chat/completions)
+func (h *Handler) ChatCompletions(ctx echo.Context) error {
+	if ok, err := h.authorizeOpenAI(ctx); !ok {
+		return err
+	}
+
+	var req chatCompletionRequest
+	if err := ctx.Bind(&req); err != nil {
+		return openAIError(ctx, http.StatusBadRequest, "invalid_request_error", "", "invalid request body")
+	}
+	model, ok := h.lookupOpenAIModel(req.Model)
+	if !ok {
+		return openAIError(ctx, http.StatusNotFound, "invalid_request_error", "model_not_found",
+			fmt.Sprintf("the model %q does not exist", req.Model))
+	}
+	input, err := mapChatCompletionRequest(req, model)
+	if err != nil {
+		return openAIError(ctx, http.StatusBadRequest, "invalid_request_error", "", err.Error())
+	}
+
+	id := "chatcmpl-" + uuid.NewString()
+	created := time.Now().Unix()
+	if req.Stream {
+		includeUsage := req.StreamOptions != nil && req.StreamOptions.IncludeUsage
+		return h.streamChatCompletion(ctx, input, id, created, model.Name, includeUsage)
+	}
+
+	output, err := h.answerUsecase.Execute(ctx.Request().Context(), input)
+	if err != nil {
+		h.logger.Error("failed to answer chat completion", "model", model.Name, "error", err)
+		return openAIError(ctx, http.StatusInternalServerError, "server_error", "", "failed to generate answer")
+	}
+	h.recordAnswer(ctx.Request().Context(), input, output)
+
+	content := output.Answer
+	if output.Fallback || content == "" {
+		content = openAIFallbackContent(output.Reason)
+	}
+	stop := "stop"
+	return ctx.JSON(http.StatusOK, chatCompletionResponse{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   model.Name,
+		Choices: []chatChoice{{Message: &chatReplyMessage{Role: "assistant", Content: content}, FinishReason: &stop}},
+		Usage:   estimateChatUsage(input, output.Contexts, content),
+	})
+}
+
+func (h *Handler) streamChatCompletion(ctx echo.Context, input usecase.AnswerWithRAGInput, id string, created int64, model string, includeUsage bool) error {
+	events := h.answerUsecase.Stream(ctx.Request().Context(), input)
+
+	res := ctx.Response()
+	res.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	res.Header().Set("Cache-Control", "no-cache, no-transform")
+	res.Header().Set("Connection", "keep-alive")
+
+	flusher, ok := res.Writer.(http.Flusher)
+	if !ok {
+		return openAIError(ctx, http.StatusInternalServerError, "server_error", "", "streaming not supported")
+	}
+
+	chunk := func(delta *chatReplyMessage, finishReason *string) chatCompletionResponse {
+		return chatCompletionResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []chatChoice{{Delta: delta, FinishReason: finishReason}},
+		}
+	}
+	if err := writeOpenAIData(res.Writer, chunk(&chatReplyMessage{Role: "assistant"}, nil)); err != nil {
+		return err
+	}
+	flusher.Flush()
+
+	var (
+		answer   strings.Builder
+		contexts []usecase.ContextItem
+	)
+	// finish sends the closing chunk, the optional usage chunk and the
+	// [DONE] sentinel, after the fallback text when nothing was streamed.
+	finish := func(fallbackReason string, fallback bool) error {
+		if fallback && answer.Len() == 0 {
+			text := openAIFallbackContent(fallbackReason)
+			answer.WriteString(text)
+			if err := writeOpenAIData(res.Writer, chunk(&chatReplyMessage{Content: text}, nil)); err != nil {
+				return err
+			}
+		}
+		stop := "stop"
+		if err := writeOpenAIData(res.Writer, chunk(&chatReplyMessage{}, &stop)); err != nil {
+			return err
+		}
+		if includeUsage {
+			usageChunk := chunk(nil, nil)
+			usageChunk.Choices = []chatChoice{}
+			usageChunk.Usage = estimateChatUsage(input, contexts, answer.String())
+			if err := writeOpenAIData(res.Writer, usageChunk); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(res.Writer, "data: [DONE]\n\n"); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return finish("", answer.Len() == 0)
+			}
+			switch event.Kind {
+			case usecase.StreamEventKindMeta:
+				if meta, ok := event.Payload.(usecase.StreamMeta); ok {
+					contexts = meta.Contexts
+				}
+			case usecase.StreamEventKindDelta:
+				text, _ := event.Payload.(string)
+				if text == "" {
+					continue
+				}
+				answer.WriteString(text)
+				if err := writeOpenAIData(res.Writer, chunk(&chatReplyMessage{Content: text}, nil)); err != nil {
+					return err
+				}
+				flusher.Flush()
+			case usecase.StreamEventKindDone:
+				output, _ := event.Payload.(*usecase.AnswerWithRAGOutput)
+				if output == nil {
+					return finish("", answer.Len() == 0)
+				}
+				if len(output.Contexts) > 0 {
+					contexts = output.Contexts
+				}
+				if err := finish(output.Reason, output.Fallback || answer.Len() == 0); err != nil {
+					return err
+				}
+				h.recordAnswer(ctx.Request().Context(), input, output)
+				return nil
+			case usecase.StreamEventKindFallback:
+				reason, _ := event.Payload.(string)
+				return finish(reason, true)
+			case usecase.StreamEventKindError:
+				message, _ := event.Payload.(string)
+				h.logger.Error("chat completion stream failed", "model", model, "error", message)
+				if err := writeOpenAIData(res.Writer, openAIErrorResponse{Error: openAIErrorBody{Message: "failed to generate answer", Type: "server_error"}}); err != nil {
+					return err
+				}
+				flusher.Flush()
+				return nil
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(res.Writer, ":\n\n"); err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeOpenAIData writes one unnamed SSE data event, the framing OpenAI
+// streaming clients expect.
+func writeOpenAIData(w io.Writer, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
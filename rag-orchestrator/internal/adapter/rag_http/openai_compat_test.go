@@ -0,0 +1,244 @@
+package rag_http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChatAnswerUsecase struct {
+	output *usecase.AnswerWithRAGOutput
+	events []usecase.StreamEvent
+	input  usecase.AnswerWithRAGInput
+}
+
+func (s *stubChatAnswerUsecase) Execute(_ context.Context, input usecase.AnswerWithRAGInput) (*usecase.AnswerWithRAGOutput, error) {
+	s.input = input
+	return s.output, nil
+}
+
+func (s *stubChatAnswerUsecase) Stream(_ context.Context, input usecase.AnswerWithRAGInput) <-chan usecase.StreamEvent {
+	s.input = input
+	ch := make(chan usecase.StreamEvent, len(s.events))
+	for _, ev := range s.events {
+		ch <- ev
+	}
+	close(ch)
+	return ch
+}
+
+var testOpenAIModels = []rag_http.OpenAIModel{
+	{Name: "alt-rag"},
+	{Name: "alt-rag-short", AnswerLength: usecase.AnswerLengthShort, ReadingLevel: usecase.ReadingLevelBasic},
+}
+
+func newChatHandler(answer usecase.AnswerWithRAGUsecase, apiKey string) *rag_http.Handler {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	return rag_http.NewHandler(nil, answer, nil, nil, nil, logger, rag_http.WithOpenAICompat(testOpenAIModels, apiKey))
+}
+
+func postChat(t *testing.T, h *rag_http.Handler, body, auth string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if auth != "" {
+		req.Header.Set(echo.HeaderAuthorization, auth)
+	}
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.ChatCompletions(echo.New().NewContext(req, rec)))
+	return rec
+}
+
+func TestHandler_ChatCompletions(t *testing.T) {
+	answer := &stubChatAnswerUsecase{output: &usecase.AnswerWithRAGOutput{
+		Answer:   "TPUs accelerate matrix multiplies.",
+		Contexts: []usecase.ContextItem{{ChunkText: "TPU provides high throughput for matrix multiplies."}},
+	}}
+	h := newChatHandler(answer, "")
+
+	rec := postChat(t, h, `{
+		"model": "alt-rag-short",
+		"user": "user-1",
+		"max_tokens": 256,
+		"messages": [
+			{"role": "system", "content": "You are a pirate."},
+			{"role": "user", "content": "What is a TPU?"},
+			{"role": "assistant", "content": "A tensor processing unit."},
+			{"role": "user", "content": [{"type": "text", "text": "Why is it fast?"}]}
+		]
+	}`, "")
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "Why is it fast?", answer.input.Query)
+	assert.Equal(t, []domain.Message{
+		{Role: "user", Content: "What is a TPU?"},
+		{Role: "assistant", Content: "A tensor processing unit."},
+	}, answer.input.ConversationHistory)
+	assert.Equal(t, "user-1", answer.input.UserID)
+	assert.Equal(t, 256, answer.input.MaxTokens)
+	assert.Equal(t, usecase.AnswerLengthShort, answer.input.AnswerLength)
+	assert.Equal(t, usecase.ReadingLevelBasic, answer.input.ReadingLevel)
+
+	var resp struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"message"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(t, strings.HasPrefix(resp.ID, "chatcmpl-"))
+	assert.Equal(t, "chat.completion", resp.Object)
+	assert.Equal(t, "alt-rag-short", resp.Model)
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, "assistant", resp.Choices[0].Message.Role)
+	assert.Equal(t, "TPUs accelerate matrix multiplies.", resp.Choices[0].Message.Content)
+	assert.Equal(t, "stop", resp.Choices[0].FinishReason)
+	assert.Equal(t, usecase.EstimateTokens("TPUs accelerate matrix multiplies."), resp.Usage.CompletionTokens)
+	assert.Positive(t, resp.Usage.PromptTokens)
+	assert.Equal(t, resp.Usage.PromptTokens+resp.Usage.CompletionTokens, resp.Usage.TotalTokens)
+}
+
+func TestHandler_ChatCompletions_Fallback(t *testing.T) {
+	h := newChatHandler(&stubChatAnswerUsecase{output: &usecase.AnswerWithRAGOutput{Fallback: true, Reason: "insufficient_context"}}, "")
+
+	rec := postChat(t, h, `{"model":"alt-rag","messages":[{"role":"user","content":"?"}]}`, "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "reason: insufficient_context")
+}
+
+func TestHandler_ChatCompletions_RejectsInvalidRequests(t *testing.T) {
+	h := newChatHandler(&stubChatAnswerUsecase{}, "secret")
+	const auth = "Bearer secret"
+
+	tests := []struct {
+		name   string
+		body   string
+		auth   string
+		status int
+		code   string
+	}{
+		{"missing key", `{"model":"alt-rag","messages":[{"role":"user","content":"hi"}]}`, "", http.StatusUnauthorized, "invalid_api_key"},
+		{"wrong key", `{"model":"alt-rag","messages":[{"role":"user","content":"hi"}]}`, "Bearer nope", http.StatusUnauthorized, "invalid_api_key"},
+		{"unknown model", `{"model":"gpt-4o","messages":[{"role":"user","content":"hi"}]}`, auth, http.StatusNotFound, "model_not_found"},
+		{"last turn not user", `{"model":"alt-rag","messages":[{"role":"user","content":"hi"},{"role":"assistant","content":"hello"}]}`, auth, http.StatusBadRequest, ""},
+		{"image part", `{"model":"alt-rag","messages":[{"role":"user","content":[{"type":"image_url"}]}]}`, auth, http.StatusBadRequest, ""},
+		{"unknown role", `{"model":"alt-rag","messages":[{"role":"narrator","content":"hi"}]}`, auth, http.StatusBadRequest, ""},
+		{"non-positive max_tokens", `{"model":"alt-rag","max_tokens":0,"messages":[{"role":"user","content":"hi"}]}`, auth, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postChat(t, h, tt.body, tt.auth)
+			assert.Equal(t, tt.status, rec.Code)
+			var body struct {
+				Error struct {
+					Message string `json:"message"`
+					Code    string `json:"code"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.NotEmpty(t, body.Error.Message)
+			assert.Equal(t, tt.code, body.Error.Code)
+		})
+	}
+}
+
+func TestHandler_ChatCompletions_Stream(t *testing.T) {
+	answer := &stubChatAnswerUsecase{events: []usecase.StreamEvent{
+		{Kind: usecase.StreamEventKindMeta, Payload: usecase.StreamMeta{Contexts: []usecase.ContextItem{{ChunkText: "context chunk"}}}},
+		{Kind: usecase.StreamEventKindProgress, Payload: "retrieving"},
+		{Kind: usecase.StreamEventKindDelta, Payload: "Hello"},
+		{Kind: usecase.StreamEventKindDelta, Payload: " world"},
+		{Kind: usecase.StreamEventKindDone, Payload: &usecase.AnswerWithRAGOutput{Answer: "Hello world"}},
+	}}
+	h := newChatHandler(answer, "")
+
+	rec := postChat(t, h, `{"model":"alt-rag","stream":true,"stream_options":{"include_usage":true},"messages":[{"role":"user","content":"hi"}]}`, "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/event-stream")
+
+	var (
+		content      strings.Builder
+		finishReason string
+		usage        map[string]int
+		lines        []string
+	)
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			lines = append(lines, data)
+		}
+	}
+	require.NotEmpty(t, lines)
+	assert.Equal(t, "[DONE]", lines[len(lines)-1])
+	for _, data := range lines[:len(lines)-1] {
+		var chunk struct {
+			Object  string `json:"object"`
+			Choices []struct {
+				Delta struct {
+					Role    string `json:"role"`
+					Content string `json:"content"`
+				} `json:"delta"`
+				FinishReason *string `json:"finish_reason"`
+			} `json:"choices"`
+			Usage map[string]int `json:"usage"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(data), &chunk))
+		assert.Equal(t, "chat.completion.chunk", chunk.Object)
+		for _, c := range chunk.Choices {
+			content.WriteString(c.Delta.Content)
+			if c.FinishReason != nil {
+				finishReason = *c.FinishReason
+			}
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+	assert.Equal(t, "Hello world", content.String())
+	assert.Equal(t, "stop", finishReason)
+	require.NotNil(t, usage)
+	assert.Equal(t, usecase.EstimateTokens("Hello world"), usage["completion_tokens"])
+	assert.Equal(t, usecase.EstimateTokens("hi")+usecase.EstimateTokens("context chunk"), usage["prompt_tokens"])
+}
+
+func TestHandler_ListModels(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, newChatHandler(nil, "").ListModels(echo.New().NewContext(req, rec)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":"alt-rag-short"`)
+}
+
+func TestHandler_ChatCompletions_Disabled(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	h := rag_http.NewHandler(nil, nil, nil, nil, nil, logger)
+
+	rec := postChat(t, h, `{"model":"alt-rag","messages":[{"role":"user","content":"hi"}]}`, "")
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
@@ -9,6 +9,40 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Defines values for AnswerStyleAnswerLength.
+const (
+	AnswerStyleAnswerLengthLong   AnswerStyleAnswerLength = "long"
+	AnswerStyleAnswerLengthMedium AnswerStyleAnswerLength = "medium"
+	AnswerStyleAnswerLengthShort  AnswerStyleAnswerLength = "short"
+)
+
+// Defines values for AnswerStyleFormat.
+const (
+	AnswerStyleFormatBullet AnswerStyleFormat = "bullet"
+	AnswerStyleFormatProse  AnswerStyleFormat = "prose"
+)
+
+// Defines values for AnswerStyleReadingLevel.
+const (
+	AnswerStyleReadingLevelBasic   AnswerStyleReadingLevel = "basic"
+	AnswerStyleReadingLevelExpert  AnswerStyleReadingLevel = "expert"
+	AnswerStyleReadingLevelGeneral AnswerStyleReadingLevel = "general"
+)
+
+// Defines values for AnswerStyleVerbosity.
+const (
+	AnswerStyleVerbosityConcise  AnswerStyleVerbosity = "concise"
+	AnswerStyleVerbosityDetailed AnswerStyleVerbosity = "detailed"
+)
+
+// Defines values for SourceType.
+const (
+	SourceTypeArticle  SourceType = "article"
+	SourceTypeBookmark SourceType = "bookmark"
+	SourceTypeNote     SourceType = "note"
+	SourceTypeUpload   SourceType = "upload"
+)
+
 // AnswerCitation defines model for AnswerCitation.
 type AnswerCitation struct {
 	ChunkId         *string  `json:"chunk_id,omitempty"`
@@ -48,11 +82,20 @@ type AnswerDebug struct {
 // AnswerRequest defines model for AnswerRequest.
 type AnswerRequest struct {
 	CandidateArticleIds *[]string `json:"candidate_article_ids,omitempty"`
-	Locale              *string   `json:"locale,omitempty"`
-	MaxChunks           *int32    `json:"max_chunks,omitempty"`
-	MaxTokens           *int32    `json:"max_tokens,omitempty"`
-	Query               string    `json:"query"`
-	UserId              *string   `json:"user_id,omitempty"`
+
+	// Filters Optional document metadata filters applied before vector search; every set field must match
+	Filters   *RetrievalFilters `json:"filters,omitempty"`
+	Locale    *string           `json:"locale,omitempty"`
+	MaxChunks *int32            `json:"max_chunks,omitempty"`
+	MaxTokens *int32            `json:"max_tokens,omitempty"`
+	Query     string            `json:"query"`
+
+	// SourceTypes Optional list of document sources to restrict search to
+	SourceTypes *[]SourceType `json:"source_types,omitempty"`
+
+	// Style Optional answer style controls; omitted fields keep the prompt defaults
+	Style  *AnswerStyle `json:"style,omitempty"`
+	UserId *string      `json:"user_id,omitempty"`
 }
 
 // AnswerResponse defines model for AnswerResponse.
@@ -65,6 +108,35 @@ type AnswerResponse struct {
 	Reason    *string           `json:"reason,omitempty"`
 }
 
+// AnswerStyle Optional answer style controls; omitted fields keep the prompt defaults
+type AnswerStyle struct {
+	// AnswerLength Target answer length; finer-grained than verbosity and not combinable with it
+	AnswerLength *AnswerStyleAnswerLength `json:"answer_length,omitempty"`
+	Format       *AnswerStyleFormat       `json:"format,omitempty"`
+
+	// Locale BCP 47 language tag the answer is written in (e.g. en, ja); sets locale, which must name the same language if also given
+	Locale       *string `json:"locale,omitempty"`
+	MaxSentences *int32  `json:"max_sentences,omitempty"`
+
+	// ReadingLevel basic = plain language with jargon explained, expert = domain terminology without explanation
+	ReadingLevel *AnswerStyleReadingLevel `json:"reading_level,omitempty"`
+
+	// Verbosity concise = a few sentences, detailed = a structured long-form answer
+	Verbosity *AnswerStyleVerbosity `json:"verbosity,omitempty"`
+}
+
+// AnswerStyleAnswerLength Target answer length; finer-grained than verbosity and not combinable with it
+type AnswerStyleAnswerLength string
+
+// AnswerStyleFormat defines model for AnswerStyle.Format.
+type AnswerStyleFormat string
+
+// AnswerStyleReadingLevel basic = plain language with jargon explained, expert = domain terminology without explanation
+type AnswerStyleReadingLevel string
+
+// AnswerStyleVerbosity concise = a few sentences, detailed = a structured long-form answer
+type AnswerStyleVerbosity string
+
 // Context defines model for Context.
 type Context struct {
 	ChunkId   *string `json:"chunk_id,omitempty"`
@@ -75,9 +147,10 @@ type Context struct {
 	PublishedAt     *time.Time `json:"published_at,omitempty"`
 
 	// Score Similarity score
-	Score *float32 `json:"score,omitempty"`
-	Title *string  `json:"title,omitempty"`
-	Url   *string  `json:"url,omitempty"`
+	Score      *float32    `json:"score,omitempty"`
+	SourceType *SourceType `json:"source_type,omitempty"`
+	Title      *string     `json:"title,omitempty"`
+	Url        *string     `json:"url,omitempty"`
 }
 
 // DeleteIndexRequest defines model for DeleteIndexRequest.
@@ -86,11 +159,32 @@ type DeleteIndexRequest struct {
 	UserId    string `json:"user_id"`
 }
 
+// RetrievalFilters Optional document metadata filters applied before vector search; every set field must match
+type RetrievalFilters struct {
+	// FeedIds Keep documents from any of these feeds
+	FeedIds *[]string `json:"feed_ids,omitempty"`
+
+	// PublishedAfter Keep documents published at or after this time
+	PublishedAfter *time.Time `json:"published_after,omitempty"`
+
+	// PublishedBefore Keep documents published before this time
+	PublishedBefore *time.Time `json:"published_before,omitempty"`
+
+	// Tags Keep documents carrying any of these tags
+	Tags *[]string `json:"tags,omitempty"`
+}
+
 // RetrieveRequest defines model for RetrieveRequest.
 type RetrieveRequest struct {
 	// CandidateArticleIds Optional list of article IDs to restrict search to
 	CandidateArticleIds *[]string `json:"candidate_article_ids,omitempty"`
-	Query               string    `json:"query"`
+
+	// Filters Optional document metadata filters applied before vector search; every set field must match
+	Filters *RetrievalFilters `json:"filters,omitempty"`
+	Query   string            `json:"query"`
+
+	// SourceTypes Optional list of document sources to restrict search to
+	SourceTypes *[]SourceType `json:"source_types,omitempty"`
 }
 
 // RetrieveResponse defines model for RetrieveResponse.
@@ -98,17 +192,26 @@ type RetrieveResponse struct {
 	Contexts *[]Context `json:"contexts,omitempty"`
 }
 
+// SourceType defines model for SourceType.
+type SourceType string
+
 // UpsertIndexRequest defines model for UpsertIndexRequest.
 type UpsertIndexRequest struct {
 	// ArticleId Unique identifier of the article (UUID or string)
 	ArticleId string `json:"article_id"`
 
 	// Body Full text content of the article
-	Body        string     `json:"body"`
-	PublishedAt time.Time  `json:"published_at"`
-	Title       string     `json:"title"`
-	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
-	Url         string     `json:"url"`
+	Body string `json:"body"`
+
+	// FeedId Feed the article belongs to, matched by retrieval feed filters
+	FeedId      *string   `json:"feed_id,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+
+	// Tags Article tags, matched by retrieval tag filters
+	Tags      *[]string  `json:"tags,omitempty"`
+	Title     string     `json:"title"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Url       string     `json:"url"`
 
 	// UserId User ID owning the article
 	UserId string `json:"user_id"`
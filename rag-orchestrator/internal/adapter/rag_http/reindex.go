@@ -0,0 +1,84 @@
+package rag_http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// WithReindex enables POST /internal/rag/reindex and
+// GET /internal/rag/reindex/:id.
+func WithReindex(reindex usecase.ReindexUsecase) HandlerOption {
+	return func(h *Handler) {
+		h.reindex = reindex
+	}
+}
+
+// reindexRequest is the body of POST /internal/rag/reindex. from is
+// inclusive and to exclusive, both on the article's created_at.
+type reindexRequest struct {
+	From   *time.Time `json:"from"`
+	To     *time.Time `json:"to"`
+	FeedID string     `json:"feed_id"`
+	UserID string     `json:"user_id"`
+}
+
+// StartReindex re-enqueues the articles matching a date range, feed or user
+// through the job worker. The scan runs in the background; poll the
+// returned run for progress. (POST /internal/rag/reindex)
+func (h *Handler) StartReindex(ctx echo.Context) error {
+	if h.reindex == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "reindex is disabled"})
+	}
+
+	var req reindexRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	run, err := h.reindex.Start(ctx.Request().Context(), domain.ReindexFilter{
+		From:   req.From,
+		To:     req.To,
+		FeedID: req.FeedID,
+		UserID: req.UserID,
+	})
+	switch {
+	case errors.Is(err, usecase.ErrInvalidReindexFilter):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, domain.ErrReindexRunActive):
+		return ctx.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case err != nil:
+		h.logger.Error("failed to start reindex", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to start reindex"})
+	}
+	return ctx.JSON(http.StatusAccepted, run)
+}
+
+// GetReindex reports a reindex run: the scan counters and how many of its
+// jobs the worker has finished. (GET /internal/rag/reindex/:id)
+func (h *Handler) GetReindex(ctx echo.Context) error {
+	if h.reindex == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "reindex is disabled"})
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid run id"})
+	}
+
+	run, err := h.reindex.Get(ctx.Request().Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get reindex run", "run_id", id, "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to get reindex run"})
+	}
+	if run == nil {
+		return ctx.JSON(http.StatusNotFound, map[string]string{"error": "reindex run not found"})
+	}
+	return ctx.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,138 @@
+package rag_http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubReindexUsecase struct {
+	gotFilter domain.ReindexFilter
+	run       *domain.ReindexRun
+	err       error
+}
+
+func (s *stubReindexUsecase) Start(_ context.Context, filter domain.ReindexFilter) (*domain.ReindexRun, error) {
+	s.gotFilter = filter
+	return s.run, s.err
+}
+
+func (s *stubReindexUsecase) Get(context.Context, uuid.UUID) (*domain.ReindexRun, error) {
+	return s.run, s.err
+}
+
+func newReindexHandler(uc usecase.ReindexUsecase) *rag_http.Handler {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	return rag_http.NewHandler(nil, nil, nil, nil, nil, logger, rag_http.WithReindex(uc))
+}
+
+func postReindex(t *testing.T, h *rag_http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/internal/rag/reindex", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.StartReindex(echo.New().NewContext(req, rec)))
+	return rec
+}
+
+func TestHandler_StartReindex(t *testing.T) {
+	t.Run("starts a run", func(t *testing.T) {
+		feedID := uuid.NewString()
+		uc := &stubReindexUsecase{run: &domain.ReindexRun{ID: uuid.New(), Status: domain.ReindexRunning}}
+
+		rec := postReindex(t, newReindexHandler(uc), fmt.Sprintf(`{"from":"2026-10-01T00:00:00Z","feed_id":%q}`, feedID))
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		require.NotNil(t, uc.gotFilter.From)
+		assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), uc.gotFilter.From.UTC())
+		assert.Nil(t, uc.gotFilter.To)
+		assert.Equal(t, feedID, uc.gotFilter.FeedID)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, uc.run.ID.String(), body["id"])
+		assert.Equal(t, "running", body["status"])
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		uc := &stubReindexUsecase{err: fmt.Errorf("%w: from must be before to", usecase.ErrInvalidReindexFilter)}
+		rec := postReindex(t, newReindexHandler(uc), `{"from":"2026-10-02T00:00:00Z","to":"2026-10-01T00:00:00Z"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "from must be before to")
+	})
+
+	t.Run("malformed date", func(t *testing.T) {
+		rec := postReindex(t, newReindexHandler(&stubReindexUsecase{}), `{"from":"yesterday"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("run already active", func(t *testing.T) {
+		uc := &stubReindexUsecase{err: domain.ErrReindexRunActive}
+		rec := postReindex(t, newReindexHandler(uc), `{"user_id":"`+uuid.NewString()+`"}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		rec := postReindex(t, newReindexHandler(nil), `{}`)
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+}
+
+func TestHandler_GetReindex(t *testing.T) {
+	get := func(t *testing.T, uc usecase.ReindexUsecase, id string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/internal/rag/reindex/"+id, nil)
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		require.NoError(t, newReindexHandler(uc).GetReindex(c))
+		return rec
+	}
+
+	t.Run("returns progress", func(t *testing.T) {
+		uc := &stubReindexUsecase{run: &domain.ReindexRun{
+			ID:       uuid.New(),
+			Status:   domain.ReindexCompleted,
+			Matched:  10,
+			Enqueued: 7,
+			Skipped:  3,
+			Jobs:     domain.ReindexJobCounts{Pending: 2, Completed: 5},
+		}}
+
+		rec := get(t, uc, uc.run.ID.String())
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.EqualValues(t, 7, body["enqueued"])
+		assert.EqualValues(t, 3, body["skipped"])
+		assert.EqualValues(t, 5, body["jobs"].(map[string]any)["completed"])
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rec := get(t, &stubReindexUsecase{}, uuid.NewString())
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rec := get(t, &stubReindexUsecase{}, "latest")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,45 @@
+package rag_http
+
+import (
+	"rag-orchestrator/internal/adapter/rag_http/openapi"
+	"rag-orchestrator/internal/domain"
+)
+
+// parseRetrievalFilters converts and validates the optional filters
+// request field. Errors wrap domain.ErrInvalidRetrievalFilter.
+func parseRetrievalFilters(raw *openapi.RetrievalFilters) (domain.RetrievalFilter, error) {
+	if raw == nil {
+		return domain.RetrievalFilter{}, nil
+	}
+	filter := domain.RetrievalFilter{
+		PublishedAfter:  raw.PublishedAfter,
+		PublishedBefore: raw.PublishedBefore,
+	}
+	if raw.FeedIds != nil {
+		filter.FeedIDs = *raw.FeedIds
+	}
+	if raw.Tags != nil {
+		filter.Tags = *raw.Tags
+	}
+	if err := filter.Validate(); err != nil {
+		return domain.RetrievalFilter{}, err
+	}
+	return filter, nil
+}
+
+// upsertRetrievalMetadata collects the article metadata retrieval filters
+// match on from an upsert request.
+func upsertRetrievalMetadata(req openapi.UpsertIndexRequest) domain.RetrievalMetadata {
+	meta := domain.RetrievalMetadata{PublishedAt: req.PublishedAt}
+	if req.FeedId != nil {
+		meta.FeedID = *req.FeedId
+	}
+	if req.Tags != nil {
+		for _, tag := range *req.Tags {
+			if tag != "" {
+				meta.Tags = append(meta.Tags, tag)
+			}
+		}
+	}
+	return meta
+}
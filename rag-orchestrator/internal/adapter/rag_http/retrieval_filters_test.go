@@ -0,0 +1,100 @@
+package rag_http_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturingRetrieveUsecase struct {
+	input *usecase.RetrieveContextInput
+}
+
+func (c *capturingRetrieveUsecase) Execute(_ context.Context, input usecase.RetrieveContextInput) (*usecase.RetrieveContextOutput, error) {
+	c.input = &input
+	return &usecase.RetrieveContextOutput{}, nil
+}
+
+func postJSON(t *testing.T, handle func(echo.Context) error, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handle(echo.New().NewContext(req, rec)))
+	return rec
+}
+
+func TestHandler_RetrieveContext_Filters(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	t.Run("filters reach the usecase", func(t *testing.T) {
+		retrieve := &capturingRetrieveUsecase{}
+		h := rag_http.NewHandler(retrieve, nil, nil, nil, nil, logger)
+
+		rec := postJSON(t, h.RetrieveContext, "/v1/rag/retrieve",
+			`{"query":"this week","filters":{"published_after":"2026-10-09T00:00:00Z","feed_ids":["feed-1"],"tags":["go"]}}`)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		after := time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)
+		filter := retrieve.input.Filter
+		require.NotNil(t, filter.PublishedAfter)
+		assert.True(t, filter.PublishedAfter.Equal(after))
+		assert.Nil(t, filter.PublishedBefore)
+		assert.Equal(t, []string{"feed-1"}, filter.FeedIDs)
+		assert.Equal(t, []string{"go"}, filter.Tags)
+	})
+
+	for name, filters := range map[string]string{
+		"inverted window": `{"published_after":"2026-10-16T00:00:00Z","published_before":"2026-10-09T00:00:00Z"}`,
+		"blank tag":       `{"tags":[""]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			retrieve := &capturingRetrieveUsecase{}
+			h := rag_http.NewHandler(retrieve, nil, nil, nil, nil, logger)
+
+			rec := postJSON(t, h.RetrieveContext, "/v1/rag/retrieve", `{"query":"q","filters":`+filters+`}`)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Nil(t, retrieve.input, "usecase must not run for an invalid filter")
+		})
+	}
+}
+
+func TestHandler_AnswerWithRAG_Filters(t *testing.T) {
+	answer := &stubChatAnswerUsecase{output: &usecase.AnswerWithRAGOutput{Answer: "ok"}}
+	h := rag_http.NewHandler(nil, answer, nil, nil, nil, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+
+	rec := postJSON(t, h.AnswerWithRAG, "/v1/rag/answer", `{"query":"q","filters":{"tags":["ai","go"]}}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, domain.RetrievalFilter{Tags: []string{"ai", "go"}}, answer.input.Filter)
+
+	rec = postJSON(t, h.AnswerWithRAG, "/v1/rag/answer", `{"query":"q","filters":{"feed_ids":["feed-1",""]}}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUpsertIndex_PassesRetrievalMetadata(t *testing.T) {
+	dummy := &dummyIndexUsecase{}
+	h := rag_http.NewHandler(nil, nil, dummy, nil, nil, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+
+	rec := postJSON(t, h.UpsertIndex, "/v1/rag/index/upsert",
+		`{"article_id":"a1","user_id":"u1","title":"T","url":"https://example.com/a1","body":"B",`+
+			`"published_at":"2026-10-14T08:30:00Z","feed_id":"feed-1","tags":["go",""]}`)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.True(t, dummy.capturedMeta.PublishedAt.Equal(time.Date(2026, 10, 14, 8, 30, 0, 0, time.UTC)))
+	assert.Equal(t, "feed-1", dummy.capturedMeta.FeedID)
+	assert.Equal(t, []string{"go"}, dummy.capturedMeta.Tags)
+}
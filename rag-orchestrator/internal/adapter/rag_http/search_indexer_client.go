@@ -15,15 +15,18 @@ import (
 type SearchIndexerClient struct {
 	BaseURL string
 	Client  *http.Client
+	APIKey  string
 }
 
 // NewSearchIndexerClient constructs a client for search-indexer's REST API.
-// Authentication is established at the TLS transport layer (mTLS). The third
-// argument is retained for DI-signature compatibility and ignored.
-func NewSearchIndexerClient(baseURL string, timeout int, _ string) *SearchIndexerClient {
+// apiKey is sent as X-API-Key, which search-indexer's plaintext listener
+// requires; it may be empty when calling over mTLS, where the client
+// certificate identifies the caller.
+func NewSearchIndexerClient(baseURL string, timeout int, apiKey string) *SearchIndexerClient {
 	return &SearchIndexerClient{
 		BaseURL: baseURL,
 		Client:  httpclient.NewPooledClient(time.Duration(timeout) * time.Second),
+		APIKey:  apiKey,
 	}
 }
 
@@ -56,6 +59,9 @@ func (c *SearchIndexerClient) Search(ctx context.Context, query string) ([]domai
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if c.APIKey != "" {
+		req.Header.Set("X-API-Key", c.APIKey)
+	}
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("search request failed: %w", err)
@@ -0,0 +1,33 @@
+package rag_http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchIndexerClient_SendsAPIKey(t *testing.T) {
+	for name, apiKey := range map[string]string{"with key": "k-rag", "without key": ""} {
+		t.Run(name, func(t *testing.T) {
+			var gotKey string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotKey = r.Header.Get("X-API-Key")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"query":"go","hits":[{"id":"a1","title":"Go","content":"c","tags":[]}]}`))
+			}))
+			defer srv.Close()
+
+			client := rag_http.NewSearchIndexerClient(srv.URL, 5, apiKey)
+			hits, err := client.Search(context.Background(), "go")
+			require.NoError(t, err)
+			require.Len(t, hits, 1)
+			assert.Equal(t, apiKey, gotKey)
+		})
+	}
+}
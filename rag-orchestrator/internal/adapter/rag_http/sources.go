@@ -0,0 +1,165 @@
+package rag_http
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"rag-orchestrator/internal/adapter/rag_http/openapi"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithSourceIndexing enables POST /internal/rag/sources/index. Uploads
+// larger than maxUploadBytes (after base64 decoding) are rejected.
+func WithSourceIndexing(indexer usecase.IndexSourceUsecase, extractor domain.TextExtractor, maxUploadBytes int64) HandlerOption {
+	return func(h *Handler) {
+		h.sourceIndexer = indexer
+		h.textExtractor = extractor
+		h.maxUploadBytes = maxUploadBytes
+	}
+}
+
+// sourceIndexRequest is the body of POST /internal/rag/sources/index.
+// Exactly one of Body (plain text) and ContentBase64 (a file, with
+// ContentType) is set unless Deleted is true.
+type sourceIndexRequest struct {
+	SourceType    string            `json:"source_type"`
+	ExternalID    string            `json:"external_id"`
+	Title         string            `json:"title"`
+	URL           string            `json:"url"`
+	Body          string            `json:"body"`
+	ContentBase64 string            `json:"content_base64"`
+	ContentType   string            `json:"content_type"`
+	Metadata      map[string]string `json:"metadata"`
+	Deleted       bool              `json:"deleted"`
+}
+
+// IndexSource indexes (or tombstones) one bookmark, note or uploaded file.
+// (POST /internal/rag/sources/index)
+func (h *Handler) IndexSource(ctx echo.Context) error {
+	if h.sourceIndexer == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "source indexing is disabled"})
+	}
+
+	// base64 inflates the upload by 4/3; leave headroom for the JSON fields.
+	limit := h.maxUploadBytes*4/3 + 64<<10
+	ctx.Request().Body = http.MaxBytesReader(ctx.Response(), ctx.Request().Body, limit)
+
+	var req sourceIndexRequest
+	if err := ctx.Bind(&req); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) || strings.Contains(err.Error(), "request body too large") {
+			return ctx.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "upload too large"})
+		}
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+
+	sourceType, err := domain.ParseSourceType(req.SourceType)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Request().Context(), upsertTimeout)
+	defer cancel()
+
+	doc := domain.SourceDocument{
+		SourceType: sourceType,
+		ExternalID: req.ExternalID,
+		Title:      req.Title,
+		URL:        req.URL,
+		Body:       req.Body,
+		Metadata:   maps.Clone(req.Metadata),
+		Deleted:    req.Deleted,
+	}
+	if !req.Deleted && req.ContentBase64 != "" {
+		if req.Body != "" {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "set either body or content_base64, not both"})
+		}
+		status, msg := h.extractUpload(timeoutCtx, &doc, req.ContentBase64, req.ContentType)
+		if status != 0 {
+			return ctx.JSON(status, map[string]string{"error": msg})
+		}
+	}
+
+	if err := h.sourceIndexer.Index(timeoutCtx, doc); err != nil {
+		if errors.Is(err, usecase.ErrInvalidSourceDocument) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		h.logger.Error("failed to index source document",
+			"source_type", string(sourceType),
+			"external_id", req.ExternalID,
+			"error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to index document"})
+	}
+
+	status := "indexed"
+	if req.Deleted {
+		status = "deleted"
+	}
+	return ctx.JSON(http.StatusOK, map[string]string{"status": status})
+}
+
+// extractUpload decodes and extracts an uploaded file into doc.Body. It
+// returns a non-zero HTTP status when the upload is rejected.
+func (h *Handler) extractUpload(ctx context.Context, doc *domain.SourceDocument, contentBase64, contentType string) (int, string) {
+	if h.textExtractor == nil || !h.textExtractor.Supports(contentType) {
+		return http.StatusUnsupportedMediaType, fmt.Sprintf("unsupported content_type %q", contentType)
+	}
+	data, err := base64.StdEncoding.DecodeString(contentBase64)
+	if err != nil {
+		return http.StatusBadRequest, "content_base64 is not valid base64"
+	}
+	if int64(len(data)) > h.maxUploadBytes {
+		return http.StatusRequestEntityTooLarge, "upload too large"
+	}
+
+	text, err := h.textExtractor.Extract(ctx, contentType, data)
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedContentType):
+		return http.StatusUnsupportedMediaType, err.Error()
+	case errors.Is(err, domain.ErrNoExtractableText):
+		return http.StatusUnprocessableEntity, "no extractable text in upload"
+	case err != nil:
+		return http.StatusUnprocessableEntity, "failed to extract text from upload"
+	}
+
+	doc.Body = text
+	if doc.Metadata == nil {
+		doc.Metadata = make(map[string]string, 1)
+	}
+	doc.Metadata["content_type"] = contentType
+	return 0, ""
+}
+
+// parseSourceTypes converts the optional source_types request field.
+func parseSourceTypes(raw *[]openapi.SourceType) ([]domain.SourceType, error) {
+	if raw == nil || len(*raw) == 0 {
+		return nil, nil
+	}
+	out := make([]domain.SourceType, 0, len(*raw))
+	for _, s := range *raw {
+		t, err := domain.ParseSourceType(string(s))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// contextSourceType maps a context's source for the response. Documents
+// indexed before source tracking carry no type and are reported as articles.
+func contextSourceType(t domain.SourceType) *openapi.SourceType {
+	if t == "" {
+		t = domain.SourceArticle
+	}
+	st := openapi.SourceType(t)
+	return &st
+}
@@ -0,0 +1,156 @@
+package rag_http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/adapter/source"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubSourceIndexer struct {
+	indexed  []domain.SourceDocument
+	indexErr error
+}
+
+func (s *stubSourceIndexer) Index(ctx context.Context, doc domain.SourceDocument) error {
+	s.indexed = append(s.indexed, doc)
+	return s.indexErr
+}
+
+func (s *stubSourceIndexer) Sync(ctx context.Context, connector domain.SourceConnector, since time.Time) (usecase.SourceSyncResult, error) {
+	return usecase.SourceSyncResult{Cursor: since}, nil
+}
+
+func postSource(t *testing.T, h *rag_http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/internal/rag/sources/index", bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.IndexSource(e.NewContext(req, rec)))
+	return rec
+}
+
+func TestHandler_IndexSource(t *testing.T) {
+	markdown := base64.StdEncoding.EncodeToString([]byte("# Trip notes\n\n**Kyoto** in spring."))
+	png := base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'})
+	oversize := base64.StdEncoding.EncodeToString(make([]byte, 2048))
+
+	tests := []struct {
+		name       string
+		body       string
+		indexErr   error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "plain text note",
+			body:       `{"source_type":"note","external_id":"n1","title":"Todo","body":"Buy milk"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   "Buy milk",
+		},
+		{
+			name:       "markdown upload is extracted",
+			body:       fmt.Sprintf(`{"source_type":"upload","external_id":"trip.md","content_base64":%q,"content_type":"text/markdown"}`, markdown),
+			wantStatus: http.StatusOK,
+			wantBody:   "Trip notes\n\nKyoto in spring.",
+		},
+		{
+			name:       "tombstone",
+			body:       `{"source_type":"bookmark","external_id":"b1","deleted":true}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown source type",
+			body:       `{"source_type":"email","external_id":"e1","body":"hi"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "body and upload together",
+			body:       fmt.Sprintf(`{"source_type":"upload","external_id":"x","body":"hi","content_base64":%q,"content_type":"text/markdown"}`, markdown),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unsupported content type",
+			body:       fmt.Sprintf(`{"source_type":"upload","external_id":"p.png","content_base64":%q,"content_type":"image/png"}`, png),
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "upload too large",
+			body:       fmt.Sprintf(`{"source_type":"upload","external_id":"big.txt","content_base64":%q,"content_type":"text/plain"}`, oversize),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "invalid document",
+			body:       `{"source_type":"note","external_id":"n2","body":"x"}`,
+			indexErr:   fmt.Errorf("%w: title is required", usecase.ErrInvalidSourceDocument),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "indexer failure",
+			body:       `{"source_type":"note","external_id":"n3","body":"x"}`,
+			indexErr:   assert.AnError,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := &stubSourceIndexer{indexErr: tt.indexErr}
+			logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+			h := rag_http.NewHandler(nil, nil, nil, nil, nil, logger,
+				rag_http.WithSourceIndexing(indexer, source.NewTextExtractor(), 1024))
+
+			rec := postSource(t, h, tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+
+			if tt.wantBody != "" {
+				require.Len(t, indexer.indexed, 1)
+				assert.Equal(t, tt.wantBody, indexer.indexed[0].Body)
+			}
+		})
+	}
+}
+
+func TestHandler_IndexSource_UploadRecordsContentType(t *testing.T) {
+	indexer := &stubSourceIndexer{}
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	h := rag_http.NewHandler(nil, nil, nil, nil, nil, logger,
+		rag_http.WithSourceIndexing(indexer, source.NewTextExtractor(), 1024))
+
+	content := base64.StdEncoding.EncodeToString([]byte("hello"))
+	body := fmt.Sprintf(`{"source_type":"upload","external_id":"a.txt","title":"A","content_base64":%q,"content_type":"text/plain","metadata":{"owner":"me"}}`, content)
+	rec := postSource(t, h, body)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "indexed", resp["status"])
+
+	require.Len(t, indexer.indexed, 1)
+	assert.Equal(t, map[string]string{"owner": "me", "content_type": "text/plain"}, indexer.indexed[0].Metadata)
+}
+
+func TestHandler_IndexSource_Disabled(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	h := rag_http.NewHandler(nil, nil, nil, nil, nil, logger)
+
+	rec := postSource(t, h, `{"source_type":"note","external_id":"n1","body":"x"}`)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
@@ -0,0 +1,69 @@
+package rag_http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	usageReportTimeout = 30 * time.Second
+	// usageReportDefaultDays is the window reported when from is omitted.
+	usageReportDefaultDays = 7
+	// usageReportMaxDays bounds one report so it stays a single cheap scan.
+	usageReportMaxDays = 93
+)
+
+// WithUsage enables GET /internal/rag/usage.
+func WithUsage(usage usecase.UsageAccountingUsecase) HandlerOption {
+	return func(h *Handler) {
+		h.usage = usage
+	}
+}
+
+// Usage summarizes per-request usage grouped by user and UTC day.
+// Query params: from and to (YYYY-MM-DD, inclusive; default the last seven
+// days through today), user_id (default every user).
+// (GET /internal/rag/usage)
+func (h *Handler) Usage(ctx echo.Context) error {
+	if h.usage == nil {
+		return ctx.JSON(http.StatusNotImplemented, map[string]string{"error": "usage accounting is disabled"})
+	}
+
+	to := time.Now().UTC()
+	if raw := ctx.QueryParam("to"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "to must be YYYY-MM-DD"})
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -(usageReportDefaultDays - 1))
+	if raw := ctx.QueryParam("from"); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "from must be YYYY-MM-DD"})
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "from must not be after to"})
+	}
+	if to.Sub(from) >= usageReportMaxDays*24*time.Hour {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "range must not exceed 93 days"})
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx.Request().Context(), usageReportTimeout)
+	defer cancel()
+
+	report, err := h.usage.Report(timeoutCtx, from, to, ctx.QueryParam("user_id"))
+	if err != nil {
+		h.logger.Error("failed to build usage report", "error", err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to build usage report"})
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
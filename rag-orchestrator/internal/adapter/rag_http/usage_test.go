@@ -0,0 +1,115 @@
+package rag_http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/adapter/rag_http"
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubUsageUsecase struct {
+	report   *usecase.UsageReport
+	err      error
+	gotFrom  time.Time
+	gotTo    time.Time
+	gotUser  string
+	reported bool
+}
+
+func (s *stubUsageUsecase) Record(context.Context, *domain.RequestUsage) error { return nil }
+
+func (s *stubUsageUsecase) Report(_ context.Context, from, to time.Time, userID string) (*usecase.UsageReport, error) {
+	s.reported = true
+	s.gotFrom, s.gotTo, s.gotUser = from, to, userID
+	return s.report, s.err
+}
+
+func serveUsage(t *testing.T, uc usecase.UsageAccountingUsecase, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	var opts []rag_http.HandlerOption
+	if uc != nil {
+		opts = append(opts, rag_http.WithUsage(uc))
+	}
+	h := rag_http.NewHandler(nil, nil, nil, nil, nil, logger, opts...)
+	req := httptest.NewRequest(http.MethodGet, "/internal/rag/usage"+query, nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.Usage(echo.New().NewContext(req, rec)))
+	return rec
+}
+
+func TestHandler_Usage(t *testing.T) {
+	t.Run("returns the report", func(t *testing.T) {
+		uc := &stubUsageUsecase{report: &usecase.UsageReport{
+			From:   "2026-10-01",
+			To:     "2026-10-02",
+			Budget: usecase.UsageBudget{DailyTokens: 1000},
+			Days: []usecase.UsageDayReport{{
+				UsageDaySummary: domain.UsageDaySummary{UserID: "u1", Day: "2026-10-01", Requests: 3, PromptTokens: 900, CompletionTokens: 200, Models: []string{"gemma"}},
+				TotalTokens:     1100,
+				OverBudget:      []string{usecase.UsageLimitDailyTokens},
+			}},
+		}}
+		rec := serveUsage(t, uc, "?from=2026-10-01&to=2026-10-02&user_id=u1")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), uc.gotFrom)
+		assert.Equal(t, time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), uc.gotTo)
+		assert.Equal(t, "u1", uc.gotUser)
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.EqualValues(t, 1000, body["budget"].(map[string]any)["daily_tokens"])
+		days := body["days"].([]any)
+		require.Len(t, days, 1)
+		day := days[0].(map[string]any)
+		assert.Equal(t, "u1", day["user_id"])
+		assert.EqualValues(t, 1100, day["total_tokens"])
+		assert.Equal(t, []any{"daily_tokens"}, day["over_budget"])
+	})
+
+	t.Run("defaults to the last seven days", func(t *testing.T) {
+		uc := &stubUsageUsecase{report: &usecase.UsageReport{}}
+		rec := serveUsage(t, uc, "?to=2026-10-16")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), uc.gotFrom)
+		assert.Empty(t, uc.gotUser)
+	})
+
+	t.Run("rejects bad ranges", func(t *testing.T) {
+		for _, query := range []string{
+			"?from=yesterday",
+			"?to=2026/10/16",
+			"?from=2026-10-16&to=2026-10-15",
+			"?from=2026-01-01&to=2026-10-16",
+		} {
+			uc := &stubUsageUsecase{}
+			rec := serveUsage(t, uc, query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+			assert.False(t, uc.reported, query)
+		}
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		rec := serveUsage(t, &stubUsageUsecase{err: errors.New("db down")}, "")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		rec := serveUsage(t, nil, "")
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+}
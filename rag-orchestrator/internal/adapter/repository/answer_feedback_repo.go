@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type answerFeedbackRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAnswerFeedbackRepository returns a postgres-backed repository for answer
+// snapshots and reader feedback. Both tables are INSERT-only.
+func NewAnswerFeedbackRepository(pool *pgxpool.Pool) domain.AnswerFeedbackRepository {
+	return &answerFeedbackRepository{pool: pool}
+}
+
+func (r *answerFeedbackRepository) SaveAnswer(ctx context.Context, answer *domain.AnswerRecord) error {
+	if answer == nil {
+		return errors.New("answer feedback repo: nil answer")
+	}
+	chunksPayload, err := marshalAnswerChunks(answer.Chunks)
+	if err != nil {
+		return fmt.Errorf("marshal chunks: %w", err)
+	}
+	citationsPayload, err := marshalAnswerChunks(answer.Citations)
+	if err != nil {
+		return fmt.Errorf("marshal citations: %w", err)
+	}
+	const q = `
+		INSERT INTO rag_answers (id, query, answer, prompt_version, strategy_used, intent_type, chunks, citations, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8::jsonb, $9)
+		ON CONFLICT (id) DO NOTHING
+	`
+	_, err = r.pool.Exec(
+		ctx,
+		q,
+		answer.ID, answer.Query, answer.Answer,
+		answer.PromptVersion, answer.StrategyUsed, answer.IntentType,
+		chunksPayload, citationsPayload,
+		answer.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert rag_answer: %w", err)
+	}
+	return nil
+}
+
+func (r *answerFeedbackRepository) GetAnswer(ctx context.Context, id uuid.UUID) (*domain.AnswerRecord, error) {
+	const q = `
+		SELECT id, query, answer, prompt_version, strategy_used, intent_type, chunks, citations, created_at
+		FROM rag_answers
+		WHERE id = $1
+	`
+	var a domain.AnswerRecord
+	var chunksRaw, citationsRaw []byte
+	err := r.pool.QueryRow(ctx, q, id).Scan(
+		&a.ID, &a.Query, &a.Answer,
+		&a.PromptVersion, &a.StrategyUsed, &a.IntentType,
+		&chunksRaw, &citationsRaw, &a.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select rag_answer: %w", err)
+	}
+	if err := unmarshalAnswerChunks(chunksRaw, citationsRaw, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *answerFeedbackRepository) AppendFeedback(ctx context.Context, feedback *domain.AnswerFeedback) error {
+	if feedback == nil {
+		return errors.New("answer feedback repo: nil feedback")
+	}
+	helpful := feedback.HelpfulChunkIDs
+	if helpful == nil {
+		helpful = []string{}
+	}
+	helpfulPayload, err := json.Marshal(helpful)
+	if err != nil {
+		return fmt.Errorf("marshal helpful chunk ids: %w", err)
+	}
+	const q = `
+		INSERT INTO rag_answer_feedback (id, answer_id, rating, comment, helpful_chunk_ids, created_at)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6)
+	`
+	_, err = r.pool.Exec(
+		ctx,
+		q,
+		feedback.ID, feedback.AnswerID, string(feedback.Rating), feedback.Comment,
+		string(helpfulPayload), feedback.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert rag_answer_feedback: %w", err)
+	}
+	return nil
+}
+
+func (r *answerFeedbackRepository) ListFeedback(
+	ctx context.Context,
+	since time.Time,
+	afterCreatedAt *time.Time,
+	afterID *uuid.UUID,
+	limit int,
+) ([]domain.AnswerFeedbackExport, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 500
+	}
+
+	const base = `
+		SELECT f.id, f.answer_id, f.rating, f.comment, f.helpful_chunk_ids, f.created_at,
+		       a.id, a.query, a.answer, a.prompt_version, a.strategy_used, a.intent_type,
+		       a.chunks, a.citations, a.created_at
+		FROM rag_answer_feedback f
+		JOIN rag_answers a ON a.id = f.answer_id
+		WHERE f.created_at >= $1
+	`
+	args := []interface{}{since}
+	q := base
+	if afterCreatedAt != nil && afterID != nil {
+		q += ` AND (f.created_at, f.id) > ($2, $3)`
+		args = append(args, *afterCreatedAt, *afterID)
+	}
+	q += ` ORDER BY f.created_at ASC, f.id ASC LIMIT $` + fmt.Sprint(len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query rag_answer_feedback: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.AnswerFeedbackExport
+	for rows.Next() {
+		var e domain.AnswerFeedbackExport
+		var rating string
+		var helpfulRaw, chunksRaw, citationsRaw []byte
+		if err := rows.Scan(
+			&e.Feedback.ID, &e.Feedback.AnswerID, &rating, &e.Feedback.Comment, &helpfulRaw, &e.Feedback.CreatedAt,
+			&e.Answer.ID, &e.Answer.Query, &e.Answer.Answer,
+			&e.Answer.PromptVersion, &e.Answer.StrategyUsed, &e.Answer.IntentType,
+			&chunksRaw, &citationsRaw, &e.Answer.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan rag_answer_feedback: %w", err)
+		}
+		e.Feedback.Rating = domain.FeedbackRating(rating)
+		if len(helpfulRaw) > 0 {
+			if err := json.Unmarshal(helpfulRaw, &e.Feedback.HelpfulChunkIDs); err != nil {
+				return nil, fmt.Errorf("unmarshal helpful chunk ids: %w", err)
+			}
+		}
+		if err := unmarshalAnswerChunks(chunksRaw, citationsRaw, &e.Answer); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iter rag_answer_feedback: %w", err)
+	}
+	return out, nil
+}
+
+func marshalAnswerChunks(chunks []domain.AnswerChunk) (string, error) {
+	if chunks == nil {
+		chunks = []domain.AnswerChunk{}
+	}
+	payload, err := json.Marshal(chunks)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+func unmarshalAnswerChunks(chunksRaw, citationsRaw []byte, a *domain.AnswerRecord) error {
+	if len(chunksRaw) > 0 {
+		if err := json.Unmarshal(chunksRaw, &a.Chunks); err != nil {
+			return fmt.Errorf("unmarshal chunks: %w", err)
+		}
+	}
+	if len(citationsRaw) > 0 {
+		if err := json.Unmarshal(citationsRaw, &a.Citations); err != nil {
+			return fmt.Errorf("unmarshal citations: %w", err)
+		}
+	}
+	return nil
+}
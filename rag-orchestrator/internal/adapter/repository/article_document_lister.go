@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type articleDocumentLister struct {
+	pool *pgxpool.Pool
+}
+
+// NewArticleDocumentLister returns a postgres-backed ArticleDocumentLister.
+func NewArticleDocumentLister(pool *pgxpool.Pool) domain.ArticleDocumentLister {
+	return &articleDocumentLister{pool: pool}
+}
+
+const listArticleDocumentsQuery = `
+	SELECT d.article_id, v.chunker_version = 'tombstone'
+	FROM rag_documents d
+	JOIN rag_document_versions v ON v.id = d.current_version_id
+	WHERE d.source_type = 'article' AND d.article_id > $1
+	ORDER BY d.article_id
+	LIMIT $2
+`
+
+func (r *articleDocumentLister) ListArticleDocuments(ctx context.Context, afterArticleID string, limit int) ([]domain.ArticleDocumentState, error) {
+	rows, err := r.pool.Query(ctx, listArticleDocumentsQuery, afterArticleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list article documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []domain.ArticleDocumentState
+	for rows.Next() {
+		var doc domain.ArticleDocumentState
+		if err := rows.Scan(&doc.ArticleID, &doc.Tombstoned); err != nil {
+			return nil, fmt.Errorf("scan article document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate article documents: %w", err)
+	}
+	return docs, nil
+}
@@ -11,3 +11,7 @@ import (
 func TestNewAugurConversationRepository_ImplementsInterface(t *testing.T) {
 	var _ domain.AugurConversationRepository = NewAugurConversationRepository(nil)
 }
+
+func TestNewAnswerFeedbackRepository_ImplementsInterface(t *testing.T) {
+	var _ domain.AnswerFeedbackRepository = NewAnswerFeedbackRepository(nil)
+}
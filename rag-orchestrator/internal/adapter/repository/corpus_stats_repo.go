@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type corpusStatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCorpusStatsRepository returns a postgres-backed CorpusStatsRepository.
+func NewCorpusStatsRepository(pool *pgxpool.Pool) domain.CorpusStatsRepository {
+	return &corpusStatsRepository{pool: pool}
+}
+
+// A document is tombstoned when its current version is a tombstone version
+// (see indexArticleUsecase.delete).
+const (
+	corpusDocumentTotalsQuery = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE v.chunker_version = 'tombstone'),
+			COUNT(*) FILTER (WHERE d.current_version_id IS NULL)
+		FROM rag_documents d
+		LEFT JOIN rag_document_versions v ON v.id = d.current_version_id
+	`
+
+	corpusDocumentsBySourceQuery = `
+		SELECT
+			d.source_type,
+			COALESCE(d.source_metadata->>'user_id', '') AS user_id,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE v.chunker_version = 'tombstone')
+		FROM rag_documents d
+		LEFT JOIN rag_document_versions v ON v.id = d.current_version_id
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`
+
+	corpusChunkTotalsQuery = `
+		SELECT
+			COUNT(*) FILTER (WHERE d.id IS NOT NULL),
+			COUNT(*) FILTER (WHERE d.id IS NOT NULL AND c.embedding IS NULL),
+			COUNT(*) FILTER (WHERE d.id IS NULL)
+		FROM rag_chunks c
+		LEFT JOIN rag_documents d ON d.current_version_id = c.version_id
+	`
+
+	corpusEmbeddingDimensionQuery = `
+		SELECT vector_dims(embedding)
+		FROM rag_chunks
+		WHERE embedding IS NOT NULL
+		LIMIT 1
+	`
+
+	corpusEmbedderVersionsQuery = `
+		SELECT v.embedder_version, v.chunker_version, COUNT(*)
+		FROM rag_documents d
+		JOIN rag_document_versions v ON v.id = d.current_version_id
+		WHERE v.chunker_version <> 'tombstone'
+		GROUP BY 1, 2
+		ORDER BY 3 DESC, 1, 2
+	`
+
+	corpusArticleRangeQuery = `
+		SELECT MIN(v.created_at), MAX(v.created_at)
+		FROM rag_documents d
+		JOIN rag_document_versions v ON v.id = d.current_version_id
+		WHERE d.source_type = 'article' AND v.chunker_version <> 'tombstone'
+	`
+
+	corpusJobBacklogQuery = `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'new'),
+			COUNT(*) FILTER (WHERE status = 'processing'),
+			COUNT(*) FILTER (WHERE status = 'failed'),
+			MIN(created_at) FILTER (WHERE status = 'new')
+		FROM rag_jobs
+		WHERE status <> 'completed'
+	`
+
+	selectOrphanedChunksQuery = `
+		SELECT c.id
+		FROM rag_chunks c
+		WHERE NOT EXISTS (
+			SELECT 1 FROM rag_documents d WHERE d.current_version_id = c.version_id
+		)
+		LIMIT $1
+		FOR UPDATE OF c SKIP LOCKED
+	`
+)
+
+func (r *corpusStatsRepository) GetCorpusStats(ctx context.Context) (*domain.CorpusStats, error) {
+	stats := &domain.CorpusStats{
+		DocumentsBySource: []domain.SourceDocumentCount{},
+		EmbedderVersions:  []domain.EmbedderVersionCount{},
+	}
+
+	if err := r.pool.QueryRow(ctx, corpusDocumentTotalsQuery).Scan(
+		&stats.Documents, &stats.TombstonedDocuments, &stats.DocumentsWithoutVersion,
+	); err != nil {
+		return nil, fmt.Errorf("count documents: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, corpusDocumentsBySourceQuery)
+	if err != nil {
+		return nil, fmt.Errorf("count documents by source: %w", err)
+	}
+	for rows.Next() {
+		var (
+			c          domain.SourceDocumentCount
+			sourceType string
+		)
+		if err := rows.Scan(&sourceType, &c.UserID, &c.Documents, &c.Tombstoned); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan documents by source: %w", err)
+		}
+		c.SourceType = domain.SourceType(sourceType)
+		stats.DocumentsBySource = append(stats.DocumentsBySource, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate documents by source: %w", err)
+	}
+
+	if err := r.pool.QueryRow(ctx, corpusChunkTotalsQuery).Scan(
+		&stats.Chunks, &stats.ChunksWithoutEmbedding, &stats.OrphanedChunks,
+	); err != nil {
+		return nil, fmt.Errorf("count chunks: %w", err)
+	}
+
+	err = r.pool.QueryRow(ctx, corpusEmbeddingDimensionQuery).Scan(&stats.EmbeddingDimension)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("read embedding dimension: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, corpusEmbedderVersionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("count embedder versions: %w", err)
+	}
+	for rows.Next() {
+		var c domain.EmbedderVersionCount
+		if err := rows.Scan(&c.EmbedderVersion, &c.ChunkerVersion, &c.Documents); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan embedder versions: %w", err)
+		}
+		stats.EmbedderVersions = append(stats.EmbedderVersions, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate embedder versions: %w", err)
+	}
+
+	if err := r.pool.QueryRow(ctx, corpusArticleRangeQuery).Scan(
+		&stats.OldestIndexedArticleAt, &stats.NewestIndexedArticleAt,
+	); err != nil {
+		return nil, fmt.Errorf("read indexed article range: %w", err)
+	}
+
+	backlog := &stats.JobBacklog
+	if err := r.pool.QueryRow(ctx, corpusJobBacklogQuery).Scan(
+		&backlog.New, &backlog.Processing, &backlog.Failed, &backlog.OldestPendingAt,
+	); err != nil {
+		return nil, fmt.Errorf("count job backlog: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *corpusStatsRepository) DeleteOrphanedChunks(ctx context.Context, limit int) (deleted int64, err error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, selectOrphanedChunksQuery, limit)
+	if err != nil {
+		return 0, fmt.Errorf("select orphaned chunks: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, fmt.Errorf("scan orphaned chunks: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, tx.Commit(ctx)
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE rag_chunk_events SET chunk_id = NULL WHERE chunk_id = ANY($1)`, ids); err != nil {
+		return 0, fmt.Errorf("detach chunk events: %w", err)
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM rag_chunks WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("delete orphaned chunks: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -55,6 +55,11 @@ func NewHybridSearchRepository(pool *pgxpool.Pool, rrfK int) domain.HybridSearch
 // 2. text_matches: tsvector full-text search with ts_rank_cd
 // 3. RRF fusion: 1/(rank + k) summed across both search methods
 // 4. Metadata enrichment via JOIN
+//
+// With a source or retrieval filter on ctx both arms read from a
+// scoped_chunks CTE that keeps only current-version chunks of matching
+// documents, so the filter narrows the candidates instead of emptying the
+// fused list.
 func (r *hybridSearchRepository) HybridSearch(ctx context.Context, queryVector []float32, queryText string, limit int) ([]domain.SearchResult, error) {
 	if limit <= 0 {
 		limit = 20
@@ -66,17 +71,38 @@ func (r *hybridSearchRepository) HybridSearch(ctx context.Context, queryVector [
 		candidateLimit = 100
 	}
 
+	args := []any{
+		pgvector.NewVector(queryVector),
+		queryText,
+		candidateLimit,
+		limit,
+	}
+	scope, chunkSource := "", "rag_chunks"
+	if predicates, scopeArgs := documentScope(ctx, 5); predicates != "" {
+		scope = `
+		scoped_chunks AS (
+			SELECT c.id, c.embedding, c.tsv
+			FROM rag_chunks c
+			JOIN rag_document_versions v ON c.version_id = v.id
+			JOIN rag_documents d ON v.document_id = d.id
+			WHERE d.current_version_id = v.id` + predicates + `
+		),`
+		chunkSource = "scoped_chunks"
+		args = append(args, scopeArgs...)
+	}
+
 	tsConfig := tsqueryConfig(queryText)
 	query := fmt.Sprintf(`
-		WITH vector_matches AS (
+		WITH %s
+		vector_matches AS (
 			SELECT id, rank() OVER (ORDER BY embedding <=> $1) AS rank
-			FROM rag_chunks
+			FROM %s
 			ORDER BY embedding <=> $1
 			LIMIT $3
 		),
 		text_matches AS (
 			SELECT id, rank() OVER (ORDER BY ts_rank_cd(tsv, plainto_tsquery('%s', $2)) DESC) AS rank
-			FROM rag_chunks
+			FROM %s
 			WHERE tsv @@ plainto_tsquery('%s', $2)
 			ORDER BY rank
 			LIMIT $3
@@ -96,6 +122,8 @@ func (r *hybridSearchRepository) HybridSearch(ctx context.Context, queryVector [
 			r.score,
 			c.id, c.version_id, c.ordinal, c.content, c.created_at,
 			d.article_id,
+			d.source_type,
+			d.source_metadata,
 			v.version_number,
 			v.title,
 			v.url
@@ -105,14 +133,9 @@ func (r *hybridSearchRepository) HybridSearch(ctx context.Context, queryVector [
 		JOIN rag_documents d ON v.document_id = d.id
 		WHERE d.current_version_id = v.id
 		ORDER BY r.score DESC
-	`, tsConfig, tsConfig, r.rrfK)
+	`, scope, chunkSource, tsConfig, chunkSource, tsConfig, r.rrfK)
 
-	rows, err := r.pool.Query(ctx, query,
-		pgvector.NewVector(queryVector),
-		queryText,
-		candidateLimit,
-		limit,
-	)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("hybrid search failed: %w", err)
 	}
@@ -122,7 +145,8 @@ func (r *hybridSearchRepository) HybridSearch(ctx context.Context, queryVector [
 	for rows.Next() {
 		var score float32
 		var chunk domain.RagChunk
-		var articleID string
+		var articleID, sourceType string
+		var sourceMetadata map[string]string
 		var versionNumber int
 		var title, url sql.NullString
 
@@ -130,6 +154,8 @@ func (r *hybridSearchRepository) HybridSearch(ctx context.Context, queryVector [
 			&score,
 			&chunk.ID, &chunk.VersionID, &chunk.Ordinal, &chunk.Content, &chunk.CreatedAt,
 			&articleID,
+			&sourceType,
+			&sourceMetadata,
 			&versionNumber,
 			&title,
 			&url,
@@ -144,6 +170,8 @@ func (r *hybridSearchRepository) HybridSearch(ctx context.Context, queryVector [
 			Title:           title.String,
 			URL:             url.String,
 			DocumentVersion: versionNumber,
+			SourceType:      domain.SourceType(sourceType),
+			SourceMetadata:  sourceMetadata,
 		})
 	}
 	if err := rows.Err(); err != nil {
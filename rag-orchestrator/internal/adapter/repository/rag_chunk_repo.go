@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"rag-orchestrator/internal/domain"
 	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -128,6 +129,13 @@ func (r *ragChunkRepository) InsertEvents(ctx context.Context, events []domain.R
 // Search performs a vector search across all chunks (Augur use case).
 // Uses Two-Stage Search for HNSW index efficiency.
 func (r *ragChunkRepository) Search(ctx context.Context, queryVector []float32, limit int) ([]domain.SearchResult, error) {
+	// A source or retrieval filter usually keeps a small slice of the
+	// corpus, so the HNSW top-k of Stage 1 would mostly be discarded in
+	// Stage 2. Filter first instead, like SearchWithinArticles.
+	if scope, scopeArgs := documentScope(ctx, 3); scope != "" {
+		return r.searchScoped(ctx, queryVector, limit, scope, scopeArgs)
+	}
+
 	// Two-Stage Search for HNSW Index Efficiency
 	//
 	// Stage 1: Pure vector search on rag_chunks (uses HNSW index efficiently)
@@ -194,6 +202,8 @@ func (r *ragChunkRepository) Search(ctx context.Context, queryVector []float32,
 		SELECT
 			c.id, c.version_id, c.ordinal, c.content, c.embedding, c.created_at,
 			d.article_id,
+			d.source_type,
+			d.source_metadata,
 			v.version_number,
 			v.title,
 			v.url
@@ -213,10 +223,11 @@ func (r *ragChunkRepository) Search(ctx context.Context, queryVector []float32,
 	var results []domain.SearchResult
 	for stage2Rows.Next() {
 		var c domain.RagChunk
-		var articleID string
+		var articleID, sourceType string
+		var sourceMetadata map[string]string
 		var versionNumber int
 		var title, url sql.NullString
-		if err := stage2Rows.Scan(&c.ID, &c.VersionID, &c.Ordinal, &c.Content, &c.Embedding, &c.CreatedAt, &articleID, &versionNumber, &title, &url); err != nil {
+		if err := stage2Rows.Scan(&c.ID, &c.VersionID, &c.Ordinal, &c.Content, &c.Embedding, &c.CreatedAt, &articleID, &sourceType, &sourceMetadata, &versionNumber, &title, &url); err != nil {
 			return nil, fmt.Errorf("failed to scan stage 2 result: %w", err)
 		}
 
@@ -228,6 +239,8 @@ func (r *ragChunkRepository) Search(ctx context.Context, queryVector []float32,
 			Title:           title.String,
 			URL:             url.String,
 			DocumentVersion: versionNumber,
+			SourceType:      domain.SourceType(sourceType),
+			SourceMetadata:  sourceMetadata,
 		})
 	}
 	if err := stage2Rows.Err(); err != nil {
@@ -260,6 +273,8 @@ func (r *ragChunkRepository) SearchWithinArticles(ctx context.Context, queryVect
 		SELECT
 			c.id, c.version_id, c.ordinal, c.content, c.embedding, c.created_at,
 			d.article_id,
+			d.source_type,
+			d.source_metadata,
 			v.version_number,
 			v.title,
 			v.url,
@@ -268,25 +283,65 @@ func (r *ragChunkRepository) SearchWithinArticles(ctx context.Context, queryVect
 		JOIN rag_document_versions v ON c.version_id = v.id
 		JOIN rag_documents d ON v.document_id = d.id
 		WHERE d.article_id = ANY($2)
-		  AND d.current_version_id = v.id
+		  AND d.current_version_id = v.id%s
 		ORDER BY distance ASC
 		LIMIT $3
 	`
+	scope, scopeArgs := documentScope(ctx, 4)
+	args := append([]any{pgvector.NewVector(queryVector), articleIDs, limit}, scopeArgs...)
 
-	rows, err := r.getExecutor(ctx).Query(ctx, query, pgvector.NewVector(queryVector), articleIDs, limit)
+	rows, err := r.getExecutor(ctx).Query(ctx, fmt.Sprintf(query, scope), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search within articles: %w", err)
 	}
+	return scanDistanceResults(rows)
+}
+
+// searchScoped is the single-pass variant of Search used when a source or
+// retrieval filter is set: documents are filtered before the distance sort,
+// so the HNSW index is bypassed in exchange for never coming back short.
+// scope comes from documentScope numbered from $3.
+func (r *ragChunkRepository) searchScoped(ctx context.Context, queryVector []float32, limit int, scope string, scopeArgs []any) ([]domain.SearchResult, error) {
+	query := `
+		SELECT
+			c.id, c.version_id, c.ordinal, c.content, c.embedding, c.created_at,
+			d.article_id,
+			d.source_type,
+			d.source_metadata,
+			v.version_number,
+			v.title,
+			v.url,
+			(c.embedding <=> $1) as distance
+		FROM rag_chunks c
+		JOIN rag_document_versions v ON c.version_id = v.id
+		JOIN rag_documents d ON v.document_id = d.id
+		WHERE d.current_version_id = v.id%s
+		ORDER BY distance ASC
+		LIMIT $2
+	`
+	args := append([]any{pgvector.NewVector(queryVector), limit}, scopeArgs...)
+
+	rows, err := r.getExecutor(ctx).Query(ctx, fmt.Sprintf(query, scope), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search scoped chunks: %w", err)
+	}
+	return scanDistanceResults(rows)
+}
+
+// scanDistanceResults reads rows shaped like the SearchWithinArticles
+// query and closes them.
+func scanDistanceResults(rows pgx.Rows) ([]domain.SearchResult, error) {
 	defer rows.Close()
 
 	var results []domain.SearchResult
 	for rows.Next() {
 		var c domain.RagChunk
-		var articleID string
+		var articleID, sourceType string
+		var sourceMetadata map[string]string
 		var versionNumber int
 		var title, url sql.NullString
 		var distance float32
-		if err := rows.Scan(&c.ID, &c.VersionID, &c.Ordinal, &c.Content, &c.Embedding, &c.CreatedAt, &articleID, &versionNumber, &title, &url, &distance); err != nil {
+		if err := rows.Scan(&c.ID, &c.VersionID, &c.Ordinal, &c.Content, &c.Embedding, &c.CreatedAt, &articleID, &sourceType, &sourceMetadata, &versionNumber, &title, &url, &distance); err != nil {
 			return nil, fmt.Errorf("failed to scan search result: %w", err)
 		}
 
@@ -297,6 +352,8 @@ func (r *ragChunkRepository) SearchWithinArticles(ctx context.Context, queryVect
 			Title:           title.String,
 			URL:             url.String,
 			DocumentVersion: versionNumber,
+			SourceType:      domain.SourceType(sourceType),
+			SourceMetadata:  sourceMetadata,
 		})
 	}
 	if err := rows.Err(); err != nil {
@@ -306,6 +363,51 @@ func (r *ragChunkRepository) SearchWithinArticles(ctx context.Context, queryVect
 	return results, nil
 }
 
+// sourceFilterArg converts the context's source filter to a text[]
+// parameter; nil encodes as SQL NULL, meaning "no filter".
+func sourceFilterArg(ctx context.Context) []string {
+	types := domain.SourceFilterFromContext(ctx)
+	if len(types) == 0 {
+		return nil
+	}
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
+
+// documentScope renders the context's source and retrieval filters as
+// predicates on rag_documents d, each prefixed with AND, numbering
+// placeholders from $next. Without filters it returns "" and no args.
+func documentScope(ctx context.Context, next int) (string, []any) {
+	var b strings.Builder
+	var args []any
+	add := func(predicate string, arg any) {
+		b.WriteString("\n\t\t  AND ")
+		fmt.Fprintf(&b, predicate, next+len(args))
+		args = append(args, arg)
+	}
+
+	if sources := sourceFilterArg(ctx); sources != nil {
+		add("d.source_type = ANY($%d)", sources)
+	}
+	filter := domain.RetrievalFilterFromContext(ctx)
+	if filter.PublishedAfter != nil {
+		add("d.published_at >= $%d", *filter.PublishedAfter)
+	}
+	if filter.PublishedBefore != nil {
+		add("d.published_at < $%d", *filter.PublishedBefore)
+	}
+	if len(filter.FeedIDs) > 0 {
+		add("d.feed_id = ANY($%d)", filter.FeedIDs)
+	}
+	if len(filter.Tags) > 0 {
+		add("d.tags && $%d::text[]", filter.Tags)
+	}
+	return b.String(), args
+}
+
 // sortByDistance sorts search results by score in descending order (higher score = more similar)
 func sortByDistance(results []domain.SearchResult) {
 	sort.Slice(results, func(i, j int) bool {
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentScope_NoFilters(t *testing.T) {
+	scope, args := documentScope(context.Background(), 3)
+	assert.Empty(t, scope)
+	assert.Empty(t, args)
+}
+
+func TestDocumentScope_NumbersPlaceholdersFromNext(t *testing.T) {
+	after := time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)
+	ctx := domain.WithSourceFilter(context.Background(), []domain.SourceType{domain.SourceArticle})
+	ctx = domain.WithRetrievalFilter(ctx, domain.RetrievalFilter{
+		PublishedAfter: &after,
+		FeedIDs:        []string{"feed-1"},
+		Tags:           []string{"go", "rust"},
+	})
+
+	scope, args := documentScope(ctx, 5)
+
+	assert.Contains(t, scope, "AND d.source_type = ANY($5)")
+	assert.Contains(t, scope, "AND d.published_at >= $6")
+	assert.Contains(t, scope, "AND d.feed_id = ANY($7)")
+	assert.Contains(t, scope, "AND d.tags && $8::text[]")
+	assert.NotContains(t, scope, "d.published_at <")
+	assert.Equal(t, []any{[]string{"article"}, after, []string{"feed-1"}, []string{"go", "rust"}}, args)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"rag-orchestrator/internal/domain"
@@ -35,29 +36,46 @@ func (r *ragDocumentRepository) getExecutor(ctx context.Context) interface {
 
 func (r *ragDocumentRepository) GetByArticleID(ctx context.Context, articleID string) (*domain.RagDocument, error) {
 	query := `
-		SELECT id, article_id, current_version_id, created_at, updated_at
+		SELECT id, article_id, current_version_id, source_type, source_metadata, published_at, feed_id, tags, created_at, updated_at
 		FROM rag_documents
 		WHERE article_id = $1
 	`
 	row := r.getExecutor(ctx).QueryRow(ctx, query, articleID)
 
 	var doc domain.RagDocument
-	err := row.Scan(&doc.ID, &doc.ArticleID, &doc.CurrentVersionID, &doc.CreatedAt, &doc.UpdatedAt)
+	var sourceType string
+	var publishedAt pgtype.Timestamptz
+	var feedID pgtype.Text
+	err := row.Scan(&doc.ID, &doc.ArticleID, &doc.CurrentVersionID, &sourceType, &doc.SourceMetadata, &publishedAt, &feedID, &doc.Retrieval.Tags, &doc.CreatedAt, &doc.UpdatedAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan document: %w", err)
 	}
+	doc.SourceType = domain.SourceType(sourceType)
+	if publishedAt.Valid {
+		doc.Retrieval.PublishedAt = publishedAt.Time
+	}
+	doc.Retrieval.FeedID = feedID.String
 	return &doc, nil
 }
 
 func (r *ragDocumentRepository) CreateDocument(ctx context.Context, doc *domain.RagDocument) error {
 	query := `
-		INSERT INTO rag_documents (id, article_id, current_version_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO rag_documents (id, article_id, current_version_id, source_type, source_metadata, published_at, feed_id, tags, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7, $8, $9, $10)
 	`
-	_, err := r.getExecutor(ctx).Exec(ctx, query, doc.ID, doc.ArticleID, doc.CurrentVersionID, doc.CreatedAt, doc.UpdatedAt)
+	sourceType := doc.SourceType
+	if sourceType == "" {
+		sourceType = domain.SourceArticle
+	}
+	metadata, err := marshalSourceMetadata(doc.SourceMetadata)
+	if err != nil {
+		return err
+	}
+	publishedAt, feedID, tags := retrievalMetadataArgs(doc.Retrieval)
+	_, err = r.getExecutor(ctx).Exec(ctx, query, doc.ID, doc.ArticleID, doc.CurrentVersionID, string(sourceType), metadata, publishedAt, feedID, tags, doc.CreatedAt, doc.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to insert document: %w", err)
 	}
@@ -138,3 +156,57 @@ func (r *ragDocumentRepository) CreateVersion(ctx context.Context, version *doma
 	}
 	return nil
 }
+
+func (r *ragDocumentRepository) UpdateSourceMetadata(ctx context.Context, docID uuid.UUID, metadata map[string]string) error {
+	query := `
+		UPDATE rag_documents
+		SET source_metadata = $1::jsonb, updated_at = NOW()
+		WHERE id = $2
+	`
+	payload, err := marshalSourceMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	if _, err := r.getExecutor(ctx).Exec(ctx, query, payload, docID); err != nil {
+		return fmt.Errorf("failed to update source metadata: %w", err)
+	}
+	return nil
+}
+
+func (r *ragDocumentRepository) UpdateRetrievalMetadata(ctx context.Context, docID uuid.UUID, metadata domain.RetrievalMetadata) error {
+	query := `
+		UPDATE rag_documents
+		SET published_at = $1, feed_id = $2, tags = $3, updated_at = NOW()
+		WHERE id = $4
+	`
+	publishedAt, feedID, tags := retrievalMetadataArgs(metadata)
+	if _, err := r.getExecutor(ctx).Exec(ctx, query, publishedAt, feedID, tags, docID); err != nil {
+		return fmt.Errorf("failed to update retrieval metadata: %w", err)
+	}
+	return nil
+}
+
+// retrievalMetadataArgs encodes metadata for the published_at, feed_id and
+// tags columns: unknown date and feed become NULL, no tags an empty array.
+func retrievalMetadataArgs(metadata domain.RetrievalMetadata) (pgtype.Timestamptz, pgtype.Text, []string) {
+	publishedAt := pgtype.Timestamptz{Time: metadata.PublishedAt, Valid: !metadata.PublishedAt.IsZero()}
+	feedID := pgtype.Text{String: metadata.FeedID, Valid: metadata.FeedID != ""}
+	tags := metadata.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return publishedAt, feedID, tags
+}
+
+// marshalSourceMetadata encodes metadata for the jsonb column; nil becomes
+// an empty object to match the column default.
+func marshalSourceMetadata(metadata map[string]string) ([]byte, error) {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal source metadata: %w", err)
+	}
+	return payload, nil
+}
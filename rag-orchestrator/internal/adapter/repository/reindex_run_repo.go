@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uniqueViolation is the SQLSTATE idx_rag_reindex_runs_single_running
+// raises when a second run is inserted while one is running.
+const uniqueViolation = "23505"
+
+type reindexRunRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReindexRunRepository returns a postgres-backed ReindexRunRepository.
+func NewReindexRunRepository(pool *pgxpool.Pool) domain.ReindexRunRepository {
+	return &reindexRunRepository{pool: pool}
+}
+
+const (
+	// A run whose scanner died (pod restart mid-scan) never finishes on its
+	// own; release the single-running slot once it stops reporting progress.
+	abandonStaleReindexRunsQuery = `
+		UPDATE rag_reindex_runs
+		SET status = 'failed', error_message = 'abandoned: no progress reported', finished_at = $1, updated_at = $1
+		WHERE status = 'running' AND updated_at < $2
+	`
+
+	insertReindexRunQuery = `
+		INSERT INTO rag_reindex_runs (id, filter, status, started_at, updated_at)
+		VALUES ($1, $2::jsonb, $3, $4, $4)
+	`
+
+	selectReindexRunQuery = `
+		SELECT id, filter, status, matched, enqueued, skipped, error_message, started_at, updated_at, finished_at
+		FROM rag_reindex_runs
+		WHERE id = $1
+	`
+
+	// The payload ? 'reindex_run_id' predicate lets the planner use the
+	// partial idx_rag_jobs_reindex_run index.
+	countReindexJobsQuery = `
+		SELECT status, COUNT(*)
+		FROM rag_jobs
+		WHERE payload ? 'reindex_run_id' AND payload->>'reindex_run_id' = $1
+		GROUP BY status
+	`
+)
+
+func (r *reindexRunRepository) CreateRun(ctx context.Context, run *domain.ReindexRun, staleAfter time.Duration) (err error) {
+	filter, err := json.Marshal(run.Filter)
+	if err != nil {
+		return fmt.Errorf("marshal reindex filter: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	now := time.Now()
+	if _, err = tx.Exec(ctx, abandonStaleReindexRunsQuery, now, now.Add(-staleAfter)); err != nil {
+		return fmt.Errorf("abandon stale reindex runs: %w", err)
+	}
+	if _, err = tx.Exec(ctx, insertReindexRunQuery, run.ID, filter, run.Status, run.StartedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return domain.ErrReindexRunActive
+		}
+		return fmt.Errorf("insert reindex run: %w", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *reindexRunRepository) UpdateProgress(ctx context.Context, id uuid.UUID, matched, enqueued, skipped int) error {
+	const q = `
+		UPDATE rag_reindex_runs
+		SET matched = $2, enqueued = $3, skipped = $4, updated_at = $5
+		WHERE id = $1
+	`
+	if _, err := r.pool.Exec(ctx, q, id, matched, enqueued, skipped, time.Now()); err != nil {
+		return fmt.Errorf("update reindex progress: %w", err)
+	}
+	return nil
+}
+
+func (r *reindexRunRepository) FinishRun(ctx context.Context, id uuid.UUID, status domain.ReindexRunStatus, errorMessage *string) error {
+	const q = `
+		UPDATE rag_reindex_runs
+		SET status = $2, error_message = $3, finished_at = $4, updated_at = $4
+		WHERE id = $1
+	`
+	if _, err := r.pool.Exec(ctx, q, id, status, errorMessage, time.Now()); err != nil {
+		return fmt.Errorf("finish reindex run: %w", err)
+	}
+	return nil
+}
+
+func (r *reindexRunRepository) GetRun(ctx context.Context, id uuid.UUID) (*domain.ReindexRun, error) {
+	var run domain.ReindexRun
+	var filter []byte
+	var status string
+	err := r.pool.QueryRow(ctx, selectReindexRunQuery, id).Scan(
+		&run.ID, &filter, &status,
+		&run.Matched, &run.Enqueued, &run.Skipped,
+		&run.ErrorMessage, &run.StartedAt, &run.UpdatedAt, &run.FinishedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get reindex run: %w", err)
+	}
+	run.Status = domain.ReindexRunStatus(status)
+	if err := json.Unmarshal(filter, &run.Filter); err != nil {
+		return nil, fmt.Errorf("unmarshal reindex filter: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, countReindexJobsQuery, id.String())
+	if err != nil {
+		return nil, fmt.Errorf("count reindex jobs: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var jobStatus string
+		var n int
+		if err := rows.Scan(&jobStatus, &n); err != nil {
+			return nil, fmt.Errorf("scan reindex job count: %w", err)
+		}
+		switch jobStatus {
+		case "new":
+			run.Jobs.Pending = n
+		case "processing":
+			run.Jobs.Processing = n
+		case "completed":
+			run.Jobs.Completed = n
+		case "failed":
+			run.Jobs.Failed = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reindex job counts: %w", err)
+	}
+	return &run, nil
+}
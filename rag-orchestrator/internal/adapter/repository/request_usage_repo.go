@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type requestUsageRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRequestUsageRepository returns a postgres-backed repository for
+// per-request usage rows. The table is INSERT-only.
+func NewRequestUsageRepository(pool *pgxpool.Pool) domain.RequestUsageRepository {
+	return &requestUsageRepository{pool: pool}
+}
+
+func (r *requestUsageRepository) Append(ctx context.Context, usage *domain.RequestUsage) error {
+	if usage == nil {
+		return errors.New("request usage repo: nil usage")
+	}
+	const q = `
+		INSERT INTO rag_request_usage (
+			id, user_id, mode, model, prompt_tokens, completion_tokens,
+			generator_calls, embedding_calls, retrieval_ms, total_ms, failed, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.pool.Exec(
+		ctx,
+		q,
+		usage.ID, usage.UserID, string(usage.Mode), usage.Model,
+		usage.PromptTokens, usage.CompletionTokens,
+		usage.GeneratorCalls, usage.EmbeddingCalls,
+		usage.RetrievalLatency.Milliseconds(), usage.TotalLatency.Milliseconds(),
+		usage.Failed, usage.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert rag_request_usage: %w", err)
+	}
+	return nil
+}
+
+func (r *requestUsageRepository) SummarizeByUserDay(ctx context.Context, from, to time.Time, userID string) ([]domain.UsageDaySummary, error) {
+	const q = `
+		SELECT user_id,
+		       to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day,
+		       count(*),
+		       count(*) FILTER (WHERE failed),
+		       COALESCE(sum(prompt_tokens), 0),
+		       COALESCE(sum(completion_tokens), 0),
+		       COALESCE(sum(embedding_calls), 0),
+		       COALESCE(avg(retrieval_ms), 0)::float8,
+		       COALESCE(max(retrieval_ms), 0),
+		       COALESCE(avg(total_ms), 0)::float8,
+		       array_remove(array_agg(DISTINCT model ORDER BY model), '')
+		FROM rag_request_usage
+		WHERE created_at >= $1 AND created_at < $2
+		  AND ($3::text = '' OR user_id = $3)
+		GROUP BY user_id, day
+		ORDER BY day ASC, user_id ASC
+	`
+	rows, err := r.pool.Query(ctx, q, from, to, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query rag_request_usage: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.UsageDaySummary
+	for rows.Next() {
+		var s domain.UsageDaySummary
+		if err := rows.Scan(
+			&s.UserID, &s.Day, &s.Requests, &s.FailedRequests,
+			&s.PromptTokens, &s.CompletionTokens, &s.EmbeddingCalls,
+			&s.AvgRetrievalLatencyMS, &s.MaxRetrievalLatencyMS, &s.AvgTotalLatencyMS,
+			&s.Models,
+		); err != nil {
+			return nil, fmt.Errorf("scan rag_request_usage: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iter rag_request_usage: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+)
+
+// DirectoryConnector indexes the PDF, Markdown and text files under a
+// directory as upload documents. The external ID is the slash-separated
+// path relative to the root, so moving a file re-indexes it under a new ID.
+//
+// Deletions are not detected: a walk only sees files that exist. Removing
+// an upload from the index goes through the sources API with deleted=true.
+type DirectoryConnector struct {
+	root      string
+	extractor domain.TextExtractor
+	maxBytes  int64
+}
+
+var _ domain.SourceConnector = (*DirectoryConnector)(nil)
+
+// NewDirectoryConnector creates a connector over root. Files larger than
+// maxBytes are skipped.
+func NewDirectoryConnector(root string, extractor domain.TextExtractor, maxBytes int64) *DirectoryConnector {
+	return &DirectoryConnector{root: root, extractor: extractor, maxBytes: maxBytes}
+}
+
+// Type implements domain.SourceConnector.
+func (c *DirectoryConnector) Type() domain.SourceType { return domain.SourceUpload }
+
+// Fetch returns every supported file modified after since. Files that fail
+// extraction are skipped rather than failing the whole listing; a scanned
+// PDF should not block the rest of the directory.
+func (c *DirectoryConnector) Fetch(ctx context.Context, since time.Time) ([]domain.SourceDocument, error) {
+	var docs []domain.SourceDocument
+	err := filepath.WalkDir(c.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != c.root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		contentType := ContentTypeForFile(d.Name())
+		if contentType == "" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.ModTime().After(since) || info.Size() > c.maxBytes {
+			return nil
+		}
+
+		doc, err := c.readFile(ctx, path, contentType, info)
+		if errors.Is(err, domain.ErrNoExtractableText) || errors.Is(err, domain.ErrUnsupportedContentType) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk upload directory: %w", err)
+	}
+	return docs, nil
+}
+
+func (c *DirectoryConnector) readFile(ctx context.Context, path, contentType string, info fs.FileInfo) (domain.SourceDocument, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path comes from walking the configured upload root
+	if err != nil {
+		return domain.SourceDocument{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	text, err := c.extractor.Extract(ctx, contentType, data)
+	if err != nil {
+		return domain.SourceDocument{}, fmt.Errorf("failed to extract %s: %w", path, err)
+	}
+
+	rel, err := filepath.Rel(c.root, path)
+	if err != nil {
+		return domain.SourceDocument{}, err
+	}
+	rel = filepath.ToSlash(rel)
+
+	return domain.SourceDocument{
+		SourceType: domain.SourceUpload,
+		ExternalID: rel,
+		Title:      documentTitle(text, info.Name()),
+		Body:       text,
+		Metadata: map[string]string{
+			"file_name":    info.Name(),
+			"path":         rel,
+			"content_type": contentType,
+			"size_bytes":   strconv.FormatInt(info.Size(), 10),
+		},
+		UpdatedAt: info.ModTime(),
+	}, nil
+}
+
+// documentTitle uses the first line of the extracted text when it is short
+// enough to be a heading, and the file name otherwise.
+func documentTitle(text, fileName string) string {
+	first, _, _ := strings.Cut(text, "\n")
+	first = strings.TrimSpace(first)
+	if first != "" && len([]rune(first)) <= 120 {
+		return first
+	}
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName))
+}
@@ -0,0 +1,67 @@
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, data []byte, mtime time.Time) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+}
+
+func TestDirectoryConnector_Fetch(t *testing.T) {
+	root := t.TempDir()
+	old := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	writeFile(t, filepath.Join(root, "notes", "plan.md"), []byte("# Plan\n\nShip it."), recent)
+	writeFile(t, filepath.Join(root, "paper.pdf"), buildPDF([]byte("BT (Abstract) Tj ET"), true), recent)
+	writeFile(t, filepath.Join(root, "old.md"), []byte("# Old"), old)
+	writeFile(t, filepath.Join(root, "photo.png"), []byte{0x89, 'P', 'N', 'G'}, recent)
+	writeFile(t, filepath.Join(root, "scan.pdf"), buildPDF([]byte("q /Im1 Do Q"), true), recent)
+	writeFile(t, filepath.Join(root, ".trash", "gone.md"), []byte("# Gone"), recent)
+	writeFile(t, filepath.Join(root, "huge.txt"), make([]byte, 2048), recent)
+
+	c := NewDirectoryConnector(root, NewTextExtractor(), 1024)
+	assert.Equal(t, domain.SourceUpload, c.Type())
+
+	docs, err := c.Fetch(context.Background(), old)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	byID := map[string]domain.SourceDocument{}
+	for _, d := range docs {
+		byID[d.ExternalID] = d
+	}
+
+	plan := byID["notes/plan.md"]
+	assert.Equal(t, "Plan", plan.Title)
+	assert.Equal(t, "Plan\n\nShip it.", plan.Body)
+	assert.Equal(t, domain.SourceUpload, plan.SourceType)
+	assert.Equal(t, ContentTypeMarkdown, plan.Metadata["content_type"])
+	assert.True(t, plan.UpdatedAt.Equal(recent))
+
+	paper := byID["paper.pdf"]
+	assert.Equal(t, "Abstract", paper.Body)
+	assert.Equal(t, "paper.pdf", paper.Metadata["file_name"])
+
+	all, err := c.Fetch(context.Background(), time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, all, 3, "zero since lists old files too")
+}
+
+func TestDocumentTitle(t *testing.T) {
+	assert.Equal(t, "Heading", documentTitle("Heading\nbody", "file.md"))
+	assert.Equal(t, "file", documentTitle(string(make([]rune, 200)), "file.md"))
+}
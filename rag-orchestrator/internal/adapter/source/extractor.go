@@ -0,0 +1,107 @@
+// Package source holds the non-article document connectors and the text
+// extractors that turn uploaded files into indexable plain text.
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"rag-orchestrator/internal/domain"
+)
+
+const (
+	ContentTypeMarkdown = "text/markdown"
+	ContentTypePlain    = "text/plain"
+	ContentTypePDF      = "application/pdf"
+)
+
+// ContentTypeForFile guesses the content type of an upload from its file
+// extension. It returns "" for extensions no extractor supports.
+func ContentTypeForFile(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown":
+		return ContentTypeMarkdown
+	case ".txt":
+		return ContentTypePlain
+	case ".pdf":
+		return ContentTypePDF
+	default:
+		return ""
+	}
+}
+
+// TextExtractor dispatches on content type to the Markdown, PDF and plain
+// text extractors.
+type TextExtractor struct{}
+
+var _ domain.TextExtractor = (*TextExtractor)(nil)
+
+// NewTextExtractor creates a TextExtractor.
+func NewTextExtractor() *TextExtractor {
+	return &TextExtractor{}
+}
+
+// Supports reports whether contentType (parameters allowed) is handled.
+func (e *TextExtractor) Supports(contentType string) bool {
+	switch normalizeContentType(contentType) {
+	case ContentTypeMarkdown, "text/x-markdown", ContentTypePlain, ContentTypePDF:
+		return true
+	default:
+		return false
+	}
+}
+
+// Extract returns the plain text of data.
+func (e *TextExtractor) Extract(ctx context.Context, contentType string, data []byte) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var (
+		text string
+		err  error
+	)
+	switch ct := normalizeContentType(contentType); ct {
+	case ContentTypeMarkdown, "text/x-markdown":
+		text, err = decodeText(data)
+		if err == nil {
+			text = MarkdownToText(text)
+		}
+	case ContentTypePlain:
+		text, err = decodeText(data)
+	case ContentTypePDF:
+		text, err = PDFToText(data)
+	default:
+		return "", fmt.Errorf("%w: %q", domain.ErrUnsupportedContentType, contentType)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", domain.ErrNoExtractableText
+	}
+	return text, nil
+}
+
+func normalizeContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// decodeText accepts UTF-8 text, dropping a leading byte order mark.
+func decodeText(data []byte) (string, error) {
+	if !utf8.Valid(data) {
+		return "", errors.New("text is not valid UTF-8")
+	}
+	return strings.TrimPrefix(string(data), "\ufeff"), nil
+}
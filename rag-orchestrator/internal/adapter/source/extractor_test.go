@@ -0,0 +1,43 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentTypeForFile(t *testing.T) {
+	assert.Equal(t, ContentTypeMarkdown, ContentTypeForFile("notes/README.MD"))
+	assert.Equal(t, ContentTypePDF, ContentTypeForFile("paper.pdf"))
+	assert.Equal(t, ContentTypePlain, ContentTypeForFile("a.txt"))
+	assert.Empty(t, ContentTypeForFile("image.png"))
+}
+
+func TestTextExtractor_Extract(t *testing.T) {
+	e := NewTextExtractor()
+	ctx := context.Background()
+
+	assert.True(t, e.Supports("text/markdown; charset=utf-8"))
+	assert.False(t, e.Supports("image/png"))
+
+	got, err := e.Extract(ctx, "text/markdown; charset=utf-8", []byte("\ufeff# Title\n\nBody"))
+	require.NoError(t, err)
+	assert.Equal(t, "Title\n\nBody", got)
+
+	got, err = e.Extract(ctx, ContentTypePDF, buildPDF([]byte("BT (pdf text) Tj ET"), true))
+	require.NoError(t, err)
+	assert.Equal(t, "pdf text", got)
+
+	_, err = e.Extract(ctx, "image/png", []byte{0x89})
+	assert.ErrorIs(t, err, domain.ErrUnsupportedContentType)
+
+	_, err = e.Extract(ctx, ContentTypePlain, []byte("  \n "))
+	assert.ErrorIs(t, err, domain.ErrNoExtractableText)
+
+	_, err = e.Extract(ctx, ContentTypePlain, []byte{0xff, 0xfe})
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package source
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mdImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink       = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	mdRefLink    = regexp.MustCompile(`\[([^\]]+)\]\[[^\]]*\]`)
+	mdLinkDef    = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s+\S+`)
+	mdHeading    = regexp.MustCompile(`^\s{0,3}#{1,6}\s+`)
+	mdHeadingEnd = regexp.MustCompile(`\s+#+\s*$`)
+	mdListItem   = regexp.MustCompile(`^(\s*)(?:[-*+]|\d{1,9}[.)])\s+(?:\[[ xX]\]\s+)?`)
+	mdQuote      = regexp.MustCompile(`^\s{0,3}(?:>\s?)+`)
+	mdRule       = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	mdSetext     = regexp.MustCompile(`^\s{0,3}(?:=+|-+)\s*$`)
+	mdStrong     = regexp.MustCompile(`\*\*([^*\n]+)\*\*|~~([^~\n]+)~~`)
+	mdEmStar     = regexp.MustCompile(`\*([^*\s](?:[^*\n]*[^*\s])?)\*`)
+	// Underscore emphasis only applies at word boundaries so snake_case
+	// identifiers survive.
+	mdEmUnderscore = regexp.MustCompile(`(^|[^\w])__?([^_\s](?:[^_\n]*[^_\s])?)__?([^\w]|$)`)
+	mdInlineCode   = regexp.MustCompile("`+([^`]+)`+")
+	mdHTMLTag      = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+	mdTableSep     = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// MarkdownToText strips Markdown syntax, keeping the prose, link texts,
+// image alt texts and code block contents. Paragraph breaks are preserved
+// so the chunker still sees paragraph boundaries.
+func MarkdownToText(md string) string {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	lines := strings.Split(stripFrontMatter(md), "\n")
+
+	out := make([]string, 0, len(lines))
+	inFence := false
+	fence := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if inFence {
+			if strings.HasPrefix(trimmed, fence) {
+				inFence = false
+				continue
+			}
+			out = append(out, line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence, fence = true, trimmed[:3]
+			continue
+		}
+
+		if mdLinkDef.MatchString(line) || mdRule.MatchString(line) ||
+			mdSetext.MatchString(line) || mdTableSep.MatchString(line) {
+			continue
+		}
+
+		line = mdQuote.ReplaceAllString(line, "")
+		if mdHeading.MatchString(line) {
+			line = mdHeadingEnd.ReplaceAllString(mdHeading.ReplaceAllString(line, ""), "")
+		}
+		line = mdListItem.ReplaceAllString(line, "$1")
+		if strings.Contains(line, "|") && strings.HasPrefix(strings.TrimSpace(line), "|") {
+			cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			line = strings.Join(cells, " ")
+		}
+		out = append(out, stripInlineMarkdown(line))
+	}
+
+	return collapseBlankLines(out)
+}
+
+func stripInlineMarkdown(line string) string {
+	line = mdImage.ReplaceAllString(line, "$1")
+	line = mdLink.ReplaceAllString(line, "$1")
+	line = mdRefLink.ReplaceAllString(line, "$1")
+	line = mdInlineCode.ReplaceAllString(line, "$1")
+	line = mdHTMLTag.ReplaceAllString(line, "")
+	line = mdStrong.ReplaceAllString(line, "$1$2")
+	line = mdEmStar.ReplaceAllString(line, "$1")
+	line = mdEmUnderscore.ReplaceAllString(line, "$1$2$3")
+	return strings.TrimRight(line, " \t")
+}
+
+// stripFrontMatter drops a leading YAML (---) or TOML (+++) block.
+func stripFrontMatter(md string) string {
+	for _, delim := range []string{"---", "+++"} {
+		if !strings.HasPrefix(md, delim+"\n") {
+			continue
+		}
+		rest := md[len(delim)+1:]
+		if end := strings.Index(rest, "\n"+delim+"\n"); end >= 0 {
+			return rest[end+len(delim)+2:]
+		}
+		if strings.HasSuffix(rest, "\n"+delim) {
+			return ""
+		}
+	}
+	return md
+}
+
+// collapseBlankLines joins lines, squeezing runs of blank lines into a
+// single paragraph break.
+func collapseBlankLines(lines []string) string {
+	var b strings.Builder
+	blank := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if !blank {
+				b.WriteString("\n")
+				blank = true
+			}
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+		blank = false
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,38 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToText(t *testing.T) {
+	md := "---\ntitle: Notes\ntags: [go]\n---\n" +
+		"# Reading **list** #\n\n" +
+		"Some *emphasis*, `code` and a [link](https://example.com).\n" +
+		"![diagram](img.png)\n\n" +
+		"> quoted text\n\n" +
+		"- [x] done item\n" +
+		"1. first\n\n" +
+		"---\n\n" +
+		"| a | b |\n|---|:-:|\n| 1 | 2 |\n\n" +
+		"```go\nfmt.Println(\"hi\")\n```\n\n\n" +
+		"[ref]: https://example.com\n" +
+		"Trailing <b>html</b>."
+
+	want := "Reading list\n\n" +
+		"Some emphasis, code and a link.\n" +
+		"diagram\n\n" +
+		"quoted text\n\n" +
+		"done item\n" +
+		"first\n\n" +
+		"a b\n" +
+		"1 2\n\n" +
+		"fmt.Println(\"hi\")\n\n" +
+		"Trailing html."
+	assert.Equal(t, want, MarkdownToText(md))
+}
+
+func TestMarkdownToText_KeepsUnderscoresInWords(t *testing.T) {
+	assert.Equal(t, "snake_case_name stays", MarkdownToText("snake_case_name stays"))
+}
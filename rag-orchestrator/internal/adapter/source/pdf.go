@@ -0,0 +1,482 @@
+package source
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"rag-orchestrator/internal/domain"
+)
+
+// maxPDFDecodedBytes bounds the total decompressed size of all streams in
+// one PDF so a compression bomb cannot exhaust memory.
+const maxPDFDecodedBytes = 64 << 20
+
+// PDFToText extracts the text layer of a PDF.
+//
+// It is a deliberately small reader, not a full PDF implementation: it
+// decodes uncompressed and FlateDecode streams, keeps those that look like
+// page content (contain BT/ET text blocks), and collects the strings shown by
+// the Tj, TJ, ' and " operators. Text in fonts with simple (Latin) encodings
+// and UTF-16 strings is recovered; CID-keyed fonts without a ToUnicode
+// mapping, encrypted files and scanned images yield domain.ErrNoExtractableText.
+// Page order follows stream order in the file, which matches reading order
+// for the PDFs typical writers produce.
+func PDFToText(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\r\n "), []byte("%PDF-")) {
+		return "", errors.New("pdf: missing %PDF header")
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return "", fmt.Errorf("pdf: encrypted documents are not supported: %w", domain.ErrNoExtractableText)
+	}
+
+	var (
+		out     strings.Builder
+		budget  = int64(maxPDFDecodedBytes)
+		rest    = data
+		scanned int
+	)
+	for {
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			break
+		}
+		// "endstream" also contains "stream"; skip it.
+		if start >= 3 && bytes.Equal(rest[start-3:start], []byte("end")) {
+			rest = rest[start+len("stream"):]
+			continue
+		}
+		dict := streamDict(rest[:start])
+		body := rest[start+len("stream"):]
+		body = bytes.TrimPrefix(body, []byte("\r"))
+		body = bytes.TrimPrefix(body, []byte("\n"))
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		raw := body[:end]
+		rest = body[end+len("endstream"):]
+		scanned++
+
+		content, err := decodeStream(dict, raw, &budget)
+		if err != nil {
+			if errors.Is(err, errPDFBudget) {
+				return "", err
+			}
+			continue // images, fonts and unsupported filters
+		}
+		if !looksLikeContentStream(content) {
+			continue
+		}
+		if text := contentStreamText(content); strings.TrimSpace(text) != "" {
+			out.WriteString(text)
+			out.WriteString("\n\n")
+		}
+	}
+
+	text := normalizePDFText(out.String())
+	if text == "" {
+		return "", fmt.Errorf("pdf: %d streams scanned: %w", scanned, domain.ErrNoExtractableText)
+	}
+	return text, nil
+}
+
+// pdfOtherFilters are the standard filters besides FlateDecode.
+var pdfOtherFilters = []string{
+	"/ASCIIHexDecode", "/ASCII85Decode", "/LZWDecode", "/RunLengthDecode",
+	"/CCITTFaxDecode", "/JBIG2Decode", "/DCTDecode", "/JPXDecode", "/Crypt",
+}
+
+var errPDFBudget = errors.New("pdf: decompressed content exceeds size limit")
+
+// streamDict returns the dictionary of the object a stream belongs to: the
+// text between the last "obj" keyword and the stream keyword.
+func streamDict(before []byte) []byte {
+	if i := bytes.LastIndex(before, []byte("obj")); i >= 0 {
+		return before[i:]
+	}
+	return before
+}
+
+func decodeStream(dict, raw []byte, budget *int64) ([]byte, error) {
+	switch {
+	case bytes.Contains(dict, []byte("/Subtype/Image")), bytes.Contains(dict, []byte("/Subtype /Image")),
+		bytes.Contains(dict, []byte("/Type/XRef")), bytes.Contains(dict, []byte("/Type /XRef")):
+		return nil, errors.New("pdf: not a content stream")
+	case bytes.Contains(dict, []byte("/FlateDecode")):
+		// Filter chains (e.g. [/ASCII85Decode /FlateDecode]) are not
+		// supported; plain Flate covers what common writers emit.
+		for _, other := range pdfOtherFilters {
+			if bytes.Contains(dict, []byte(other)) {
+				return nil, errors.New("pdf: unsupported filter chain")
+			}
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		decoded, err := io.ReadAll(io.LimitReader(zr, *budget+1))
+		if int64(len(decoded)) > *budget {
+			return nil, errPDFBudget
+		}
+		*budget -= int64(len(decoded))
+		// Truncated streams are common; keep what decoded cleanly.
+		if err != nil && len(decoded) == 0 {
+			return nil, err
+		}
+		return decoded, nil
+	case bytes.Contains(dict, []byte("/Filter")):
+		return nil, errors.New("pdf: unsupported filter")
+	default:
+		return raw, nil
+	}
+}
+
+func looksLikeContentStream(b []byte) bool {
+	return bytes.Contains(b, []byte("BT")) && bytes.Contains(b, []byte("ET"))
+}
+
+// contentStreamText interprets the text operators of a content stream.
+func contentStreamText(content []byte) string {
+	var (
+		out      strings.Builder
+		operands []pdfToken
+		lex      = pdfLexer{data: content}
+		inText   bool
+	)
+	newline := func() {
+		if s := out.String(); s != "" && !strings.HasSuffix(s, "\n") {
+			out.WriteByte('\n')
+		}
+	}
+	space := func() {
+		if s := out.String(); s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+			out.WriteByte(' ')
+		}
+	}
+
+	for {
+		tok, ok := lex.next()
+		if !ok {
+			break
+		}
+		if tok.kind != pdfOperator {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch tok.text {
+		case "BT":
+			inText = true
+		case "ET":
+			inText = false
+			newline()
+		case "T*":
+			newline()
+		case "Td", "TD":
+			if len(operands) >= 2 && operands[len(operands)-1].num != 0 {
+				newline()
+			} else {
+				space()
+			}
+		case "Tm":
+			newline()
+		case "Tj":
+			if inText && len(operands) > 0 {
+				out.WriteString(operands[len(operands)-1].str)
+			}
+		case "'", "\"":
+			newline()
+			if inText && len(operands) > 0 {
+				out.WriteString(operands[len(operands)-1].str)
+			}
+		case "TJ":
+			if inText && len(operands) > 0 {
+				for _, el := range operands[len(operands)-1].array {
+					switch el.kind {
+					case pdfString:
+						out.WriteString(el.str)
+					case pdfNumber:
+						// Large negative kerning is how most writers encode
+						// the gap between words.
+						if el.num < -200 {
+							space()
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	return out.String()
+}
+
+// normalizePDFText drops control characters and collapses the whitespace
+// left over from positioning operators.
+func normalizePDFText(s string) string {
+	var lines []string
+	blank := 0
+	for line := range strings.SplitSeq(s, "\n") {
+		line = strings.Join(strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || (unicode.IsControl(r) && r != '\n')
+		}), " ")
+		if line == "" {
+			blank++
+			if blank == 1 && len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			continue
+		}
+		blank = 0
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+type pdfTokenKind int
+
+const (
+	pdfNumber pdfTokenKind = iota
+	pdfString
+	pdfName
+	pdfArray
+	pdfOperator
+	pdfOther
+)
+
+type pdfToken struct {
+	kind  pdfTokenKind
+	text  string
+	str   string
+	num   float64
+	array []pdfToken
+}
+
+// pdfLexer tokenizes a content stream. Dictionaries and inline images are
+// skipped; only what the text operators need is decoded.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+func (l *pdfLexer) next() (pdfToken, bool) {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		case c == '(':
+			l.pos++
+			return pdfToken{kind: pdfString, str: decodePDFString(l.literal())}, true
+		case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+			l.skipDict()
+			return pdfToken{kind: pdfOther}, true
+		case c == '<':
+			l.pos++
+			return pdfToken{kind: pdfString, str: decodePDFString(l.hex())}, true
+		case c == '[':
+			l.pos++
+			var items []pdfToken
+			for {
+				if l.skipSpace(); l.pos >= len(l.data) {
+					break
+				}
+				if l.data[l.pos] == ']' {
+					l.pos++
+					break
+				}
+				tok, ok := l.next()
+				if !ok {
+					break
+				}
+				items = append(items, tok)
+			}
+			return pdfToken{kind: pdfArray, array: items}, true
+		case c == '/':
+			l.pos++
+			return pdfToken{kind: pdfName, text: l.word()}, true
+		case c == ']' || c == '>' || c == ')' || c == '{' || c == '}':
+			l.pos++
+		default:
+			w := l.word()
+			if w == "" {
+				l.pos++
+				continue
+			}
+			if n, err := strconv.ParseFloat(w, 64); err == nil {
+				return pdfToken{kind: pdfNumber, num: n, text: w}, true
+			}
+			if w == "BI" {
+				l.skipInlineImage()
+				continue
+			}
+			return pdfToken{kind: pdfOperator, text: w}, true
+		}
+	}
+	return pdfToken{}, false
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) && isPDFSpace(l.data[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *pdfLexer) word() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// literal reads a (...) string body; the opening parenthesis is consumed.
+func (l *pdfLexer) literal() []byte {
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// hex reads a <...> string body; the opening bracket is consumed.
+func (l *pdfLexer) hex() []byte {
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++ // '>'
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, 0, len(digits)/2)
+	for i := 0; i+1 < len(digits); i += 2 {
+		v, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		if err != nil {
+			return nil
+		}
+		out = append(out, byte(v))
+	}
+	return out
+}
+
+func (l *pdfLexer) skipDict() {
+	depth := 0
+	for l.pos+1 < len(l.data) {
+		switch {
+		case l.data[l.pos] == '<' && l.data[l.pos+1] == '<':
+			depth++
+			l.pos += 2
+		case l.data[l.pos] == '>' && l.data[l.pos+1] == '>':
+			depth--
+			l.pos += 2
+			if depth == 0 {
+				return
+			}
+		case l.data[l.pos] == '(':
+			l.pos++
+			l.literal()
+		default:
+			l.pos++
+		}
+	}
+	l.pos = len(l.data)
+}
+
+// skipInlineImage jumps past the binary data of a BI ... ID ... EI block.
+func (l *pdfLexer) skipInlineImage() {
+	if i := bytes.Index(l.data[l.pos:], []byte("EI")); i >= 0 {
+		l.pos += i + 2
+		return
+	}
+	l.pos = len(l.data)
+}
+
+// decodePDFString converts a PDF string to UTF-8: UTF-16BE when it carries
+// a byte order mark, Latin-1 (a close superset of PDFDocEncoding for text)
+// otherwise.
+func decodePDFString(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xFE && b[1] == 0xFF {
+		units := make([]uint16, 0, (len(b)-2)/2)
+		for i := 2; i+1 < len(b); i += 2 {
+			units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	runes := make([]rune, 0, len(b))
+	for _, c := range b {
+		runes = append(runes, rune(c))
+	}
+	return string(runes)
+}
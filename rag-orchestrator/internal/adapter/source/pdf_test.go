@@ -0,0 +1,77 @@
+package source
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildPDF assembles a minimal single-page PDF around content. It has no
+// valid xref table, which the extractor does not need.
+func buildPDF(content []byte, flate bool) []byte {
+	stream := content
+	filter := ""
+	if flate {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		_, _ = zw.Write(content)
+		_ = zw.Close()
+		stream = buf.Bytes()
+		filter = " /Filter /FlateDecode"
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	b.WriteString("1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n")
+	b.WriteString("2 0 obj << /Type /Pages /Kids [3 0 R] /Count 1 >> endobj\n")
+	b.WriteString("3 0 obj << /Type /Page /Parent 2 0 R /Contents 4 0 R >> endobj\n")
+	fmt.Fprintf(&b, "4 0 obj << /Length %d%s >>\nstream\n", len(stream), filter)
+	b.Write(stream)
+	b.WriteString("\nendstream\nendobj\n%%EOF\n")
+	return b.Bytes()
+}
+
+const samplePageContent = `BT
+/F1 12 Tf
+72 720 Td
+(Hello, PDF) Tj
+0 -14 Td
+[(Kerned) -250 (words) 30 (join)] TJ
+T*
+(Escaped \(parens\) and \101 ) Tj
+<FEFF00E9007400E9> Tj
+ET`
+
+func TestPDFToText(t *testing.T) {
+	want := "Hello, PDF\nKerned wordsjoin\nEscaped (parens) and A été"
+
+	for _, flate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("flate=%v", flate), func(t *testing.T) {
+			got, err := PDFToText(buildPDF([]byte(samplePageContent), flate))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestPDFToText_NoTextLayer(t *testing.T) {
+	_, err := PDFToText(buildPDF([]byte("q 100 0 0 100 0 0 cm /Im1 Do Q"), true))
+	assert.ErrorIs(t, err, domain.ErrNoExtractableText)
+}
+
+func TestPDFToText_RejectsNonPDF(t *testing.T) {
+	_, err := PDFToText([]byte("hello"))
+	assert.Error(t, err)
+}
+
+func TestPDFToText_Encrypted(t *testing.T) {
+	data := append(buildPDF([]byte(samplePageContent), false), []byte("trailer << /Encrypt 9 0 R >>")...)
+	_, err := PDFToText(data)
+	assert.ErrorIs(t, err, domain.ErrNoExtractableText)
+}
@@ -0,0 +1,30 @@
+// Package usagebudget exports per-user usage budget alarms as Prometheus
+// metrics.
+package usagebudget
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"rag-orchestrator/internal/usecase"
+)
+
+// Cardinality: limit is bounded by the two usecase.UsageLimit* names. The
+// user is deliberately not a label; the alarm log line carries it.
+var exceededTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "rag_orchestrator",
+		Subsystem: "usage_budget",
+		Name:      "exceeded_total",
+		Help:      "Users going over a daily usage limit, by limit (daily_tokens, daily_requests).",
+	},
+	[]string{"limit"},
+)
+
+// Recorder implements usecase.UsageBudgetRecorder.
+type Recorder struct{}
+
+// RecordUsageBudgetExceeded counts one budget alarm.
+func (Recorder) RecordUsageBudgetExceeded(alarm usecase.UsageBudgetAlarm) {
+	exceededTotal.WithLabelValues(alarm.Limit).Inc()
+}
@@ -0,0 +1,169 @@
+package backfill
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+)
+
+// DefaultAuditBatchSize is how many documents the deletion audit checks
+// against alt-db per query.
+const DefaultAuditBatchSize = 500
+
+// ArticleLiveness reports which articles are still live in alt-db.
+type ArticleLiveness interface {
+	// LiveArticleIDs returns the subset of ids that exist and are not
+	// soft-deleted.
+	LiveArticleIDs(ctx context.Context, ids []string) (map[string]bool, error)
+}
+
+type altDBArticleLiveness struct {
+	db *sql.DB
+}
+
+// NewAltDBArticleLiveness checks liveness against the articles table.
+func NewAltDBArticleLiveness(db *sql.DB) ArticleLiveness {
+	return &altDBArticleLiveness{db: db}
+}
+
+func (l *altDBArticleLiveness) LiveArticleIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	rows, err := l.db.QueryContext(ctx, `
+		SELECT id::text
+		FROM articles
+		WHERE id = ANY($1::uuid[])
+		  AND deleted_at IS NULL
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query live articles: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	live := make(map[string]bool, len(ids))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan article id: %w", err)
+		}
+		live[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+	return live, nil
+}
+
+// DeletionAuditResult is what a deletion audit found.
+type DeletionAuditResult struct {
+	// Checked counts article documents compared with alt-db.
+	Checked int
+	// Leaked are retrievable documents whose article is soft-deleted or
+	// gone from alt-db.
+	Leaked []string
+	// Tombstoned counts leaked documents tombstoned by the audit.
+	Tombstoned int
+	// Restored are tombstoned documents whose article is live again; they
+	// need re-indexing to come back.
+	Restored []string
+	// Skipped counts documents whose article ID is not a UUID and so
+	// cannot be looked up in alt-db.
+	Skipped int
+}
+
+// DeletionAuditor compares the article documents in rag-db with alt-db.
+// It catches what the deletion sync worker missed: deletions older than its
+// lookback, and articles that disappeared without an audit entry (a purged
+// feed takes its articles with it).
+type DeletionAuditor struct {
+	docs      domain.ArticleDocumentLister
+	articles  ArticleLiveness
+	deleter   usecase.IndexArticleUsecase
+	batchSize int
+	logger    *slog.Logger
+}
+
+// NewDeletionAuditor creates an auditor. deleter may be nil to only report;
+// otherwise leaked documents are tombstoned through it.
+func NewDeletionAuditor(docs domain.ArticleDocumentLister, articles ArticleLiveness, deleter usecase.IndexArticleUsecase, batchSize int, logger *slog.Logger) *DeletionAuditor {
+	if batchSize <= 0 {
+		batchSize = DefaultAuditBatchSize
+	}
+	return &DeletionAuditor{
+		docs:      docs,
+		articles:  articles,
+		deleter:   deleter,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// Run walks every article document in article ID order.
+func (a *DeletionAuditor) Run(ctx context.Context) (*DeletionAuditResult, error) {
+	result := &DeletionAuditResult{}
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		docs, err := a.docs.ListArticleDocuments(ctx, after, a.batchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(docs) == 0 {
+			return result, nil
+		}
+		if err := a.auditBatch(ctx, docs, result); err != nil {
+			return result, err
+		}
+		after = docs[len(docs)-1].ArticleID
+
+		a.logger.Debug("audit progress",
+			slog.Int("checked", result.Checked),
+			slog.Int("leaked", len(result.Leaked)),
+			slog.String("after", after),
+		)
+		if len(docs) < a.batchSize {
+			return result, nil
+		}
+	}
+}
+
+func (a *DeletionAuditor) auditBatch(ctx context.Context, docs []domain.ArticleDocumentState, result *DeletionAuditResult) error {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if _, err := uuid.Parse(doc.ArticleID); err == nil {
+			ids = append(ids, doc.ArticleID)
+		}
+	}
+	live, err := a.articles.LiveArticleIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if _, err := uuid.Parse(doc.ArticleID); err != nil {
+			result.Skipped++
+			continue
+		}
+		result.Checked++
+		switch {
+		case doc.Tombstoned && live[doc.ArticleID]:
+			result.Restored = append(result.Restored, doc.ArticleID)
+		case !doc.Tombstoned && !live[doc.ArticleID]:
+			result.Leaked = append(result.Leaked, doc.ArticleID)
+			if a.deleter == nil {
+				continue
+			}
+			if err := a.deleter.Delete(ctx, doc.ArticleID); err != nil {
+				return fmt.Errorf("tombstone article %s: %w", doc.ArticleID, err)
+			}
+			result.Tombstoned++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package backfill
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDocumentLister struct {
+	docs   []domain.ArticleDocumentState
+	afters []string
+}
+
+func (f *fakeDocumentLister) ListArticleDocuments(_ context.Context, after string, limit int) ([]domain.ArticleDocumentState, error) {
+	f.afters = append(f.afters, after)
+	i := sort.Search(len(f.docs), func(i int) bool { return f.docs[i].ArticleID > after })
+	return f.docs[i:min(i+limit, len(f.docs))], nil
+}
+
+type fakeLiveness map[string]bool
+
+func (f fakeLiveness) LiveArticleIDs(_ context.Context, ids []string) (map[string]bool, error) {
+	live := map[string]bool{}
+	for _, id := range ids {
+		if f[id] {
+			live[id] = true
+		}
+	}
+	return live, nil
+}
+
+type recordingDeleter struct{ deleted []string }
+
+func (r *recordingDeleter) Upsert(context.Context, string, string, string, string) error { return nil }
+
+func (r *recordingDeleter) UpsertWithMetadata(context.Context, string, string, string, string, domain.RetrievalMetadata) error {
+	return nil
+}
+
+func (r *recordingDeleter) Delete(_ context.Context, articleID string) error {
+	r.deleted = append(r.deleted, articleID)
+	return nil
+}
+
+const (
+	liveID     = "00000000-0000-0000-0000-000000000001"
+	deletedID  = "00000000-0000-0000-0000-000000000002"
+	restoredID = "00000000-0000-0000-0000-000000000003"
+	goneID     = "00000000-0000-0000-0000-000000000004"
+)
+
+func auditFixture() (*fakeDocumentLister, fakeLiveness) {
+	docs := &fakeDocumentLister{docs: []domain.ArticleDocumentState{
+		{ArticleID: liveID},
+		{ArticleID: deletedID},
+		{ArticleID: restoredID, Tombstoned: true},
+		{ArticleID: goneID},
+		{ArticleID: "legacy-key"},
+	}}
+	return docs, fakeLiveness{liveID: true, restoredID: true}
+}
+
+func TestDeletionAuditor_ReportsWithoutFix(t *testing.T) {
+	docs, live := auditFixture()
+
+	result, err := NewDeletionAuditor(docs, live, nil, 2, slog.New(slog.NewTextHandler(io.Discard, nil))).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.Checked)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, []string{deletedID, goneID}, result.Leaked)
+	assert.Equal(t, []string{restoredID}, result.Restored)
+	assert.Zero(t, result.Tombstoned)
+	assert.Equal(t, []string{"", deletedID, goneID}, docs.afters)
+}
+
+func TestDeletionAuditor_FixTombstonesLeakedDocuments(t *testing.T) {
+	docs, live := auditFixture()
+	deleter := &recordingDeleter{}
+
+	result, err := NewDeletionAuditor(docs, live, deleter, 0, slog.New(slog.NewTextHandler(io.Discard, nil))).Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{deletedID, goneID}, deleter.deleted)
+	assert.Equal(t, 2, result.Tombstoned)
+}
@@ -0,0 +1,217 @@
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+const LedgerVersion = 1
+
+// ErrorClass groups article failures by likely cause, so a run can be
+// summarized and retried selectively.
+type ErrorClass string
+
+const (
+	ErrorClassTimeout    ErrorClass = "timeout"
+	ErrorClassConnection ErrorClass = "connection"
+	ErrorClassClient     ErrorClass = "http_4xx"
+	ErrorClassServer     ErrorClass = "http_5xx"
+	ErrorClassOther      ErrorClass = "other"
+)
+
+// ErrorClasses lists every ErrorClass.
+var ErrorClasses = []ErrorClass{
+	ErrorClassTimeout, ErrorClassConnection, ErrorClassClient, ErrorClassServer, ErrorClassOther,
+}
+
+// StatusError is returned by the HTTP indexer for non-success responses.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+// ClassifyError maps an indexing error to its ErrorClass.
+func ClassifyError(err error) ErrorClass {
+	var statusErr *StatusError
+	var netErr net.Error
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &statusErr) && statusErr.StatusCode >= 500:
+		return ErrorClassServer
+	case errors.As(err, &statusErr):
+		return ErrorClassClient
+	case errors.Is(err, context.DeadlineExceeded), os.IsTimeout(err),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.As(err, &opErr):
+		return ErrorClassConnection
+	default:
+		return ErrorClassOther
+	}
+}
+
+// Failure is one article that failed to index, with its most recent error.
+type Failure struct {
+	ArticleID     string     `json:"article_id"`
+	ErrorClass    ErrorClass `json:"error_class"`
+	LastError     string     `json:"last_error"`
+	Attempts      int        `json:"attempts"`
+	FirstFailedAt time.Time  `json:"first_failed_at"`
+	LastFailedAt  time.Time  `json:"last_failed_at"`
+}
+
+// Ledger is the set of articles that failed to index and have not
+// succeeded since. It is safe for concurrent use.
+type Ledger struct {
+	mu       sync.Mutex
+	failures map[string]*Failure
+	now      func() time.Time
+}
+
+// NewLedger returns an empty ledger.
+func NewLedger() *Ledger {
+	return &Ledger{failures: make(map[string]*Failure), now: time.Now}
+}
+
+// RecordFailure adds an attempt for articleID, replacing its last error.
+func (l *Ledger) RecordFailure(articleID string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	f, ok := l.failures[articleID]
+	if !ok {
+		f = &Failure{ArticleID: articleID, FirstFailedAt: now}
+		l.failures[articleID] = f
+	}
+	f.Attempts++
+	f.ErrorClass = ClassifyError(err)
+	f.LastError = truncate(err.Error(), 500)
+	f.LastFailedAt = now
+}
+
+// Resolve removes articleID after it has been indexed or is no longer
+// eligible for indexing.
+func (l *Ledger) Resolve(articleID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.failures, articleID)
+}
+
+// Failures returns the failures whose class is in classes (all when
+// classes is empty), sorted by article ID.
+func (l *Ledger) Failures(classes ...ErrorClass) []Failure {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make([]Failure, 0, len(l.failures))
+	for _, f := range l.failures {
+		if len(classes) > 0 && !slices.Contains(classes, f.ErrorClass) {
+			continue
+		}
+		out = append(out, *f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ArticleID < out[j].ArticleID })
+	return out
+}
+
+// Summary counts failures per error class.
+func (l *Ledger) Summary() map[ErrorClass]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	summary := make(map[ErrorClass]int)
+	for _, f := range l.failures {
+		summary[f.ErrorClass]++
+	}
+	return summary
+}
+
+// Len returns the number of failed articles.
+func (l *Ledger) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.failures)
+}
+
+type ledgerFile struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Failures  []Failure `json:"failures"`
+}
+
+// LedgerManager persists a Ledger next to the cursor. It relies on the
+// cursor lock for exclusion.
+type LedgerManager struct {
+	filePath string
+}
+
+// NewLedgerManager creates a new LedgerManager for the given file path.
+func NewLedgerManager(filePath string) *LedgerManager {
+	return &LedgerManager{filePath: filePath}
+}
+
+// Load reads the ledger from disk.
+// Returns an empty ledger if the file doesn't exist or is empty.
+func (m *LedgerManager) Load() (*Ledger, error) {
+	ledger := NewLedger()
+	data, err := os.ReadFile(m.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ledger, nil
+		}
+		return nil, fmt.Errorf("read ledger file: %w", err)
+	}
+	if len(data) == 0 {
+		return ledger, nil
+	}
+
+	var file ledgerFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse ledger file: %w", err)
+	}
+	for i := range file.Failures {
+		f := file.Failures[i]
+		ledger.failures[f.ArticleID] = &f
+	}
+	return ledger, nil
+}
+
+// Save writes the ledger to disk atomically.
+func (m *LedgerManager) Save(ledger *Ledger) error {
+	data, err := json.MarshalIndent(ledgerFile{
+		Version:   LedgerVersion,
+		UpdatedAt: time.Now(),
+		Failures:  ledger.Failures(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal ledger: %w", err)
+	}
+
+	tmpPath := m.filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil { //nolint:gosec // G306: ledger holds article IDs and error text only
+		return fmt.Errorf("write temp ledger file: %w", err)
+	}
+	if err := os.Rename(tmpPath, m.filePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename ledger file: %w", err)
+	}
+	return nil
+}
+
+// FilePath returns the ledger file path.
+func (m *LedgerManager) FilePath() string {
+	return m.filePath
+}
@@ -0,0 +1,82 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorClass
+	}{
+		{"server error", &StatusError{StatusCode: 503, Body: "busy"}, ErrorClassServer},
+		{"client error", fmt.Errorf("wrapped: %w", &StatusError{StatusCode: 422}), ErrorClassClient},
+		{"deadline", fmt.Errorf("timeout: %w", context.DeadlineExceeded), ErrorClassTimeout},
+		{"connection refused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, ErrorClassConnection},
+		{"embedder failure", errors.New("embed: model not loaded"), ErrorClassOther},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestLedger_RecordAndResolve(t *testing.T) {
+	ledger := NewLedger()
+	first := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	now := first
+	ledger.now = func() time.Time { return now }
+
+	ledger.RecordFailure("a", &StatusError{StatusCode: 500})
+	now = first.Add(time.Minute)
+	ledger.RecordFailure("a", context.DeadlineExceeded)
+	ledger.RecordFailure("b", &StatusError{StatusCode: 400})
+	ledger.RecordFailure("c", context.DeadlineExceeded)
+	ledger.Resolve("c")
+
+	failures := ledger.Failures()
+	require.Len(t, failures, 2)
+	assert.Equal(t, "a", failures[0].ArticleID)
+	assert.Equal(t, 2, failures[0].Attempts)
+	assert.Equal(t, ErrorClassTimeout, failures[0].ErrorClass, "class follows the latest error")
+	assert.Equal(t, first, failures[0].FirstFailedAt)
+	assert.Equal(t, now, failures[0].LastFailedAt)
+
+	assert.Equal(t, map[ErrorClass]int{ErrorClassTimeout: 1, ErrorClassClient: 1}, ledger.Summary())
+
+	selected := ledger.Failures(ErrorClassClient)
+	require.Len(t, selected, 1)
+	assert.Equal(t, "b", selected[0].ArticleID)
+}
+
+func TestLedgerManager_LoadSave(t *testing.T) {
+	manager := NewLedgerManager(filepath.Join(t.TempDir(), "failures.json"))
+
+	empty, err := manager.Load()
+	require.NoError(t, err)
+	assert.Zero(t, empty.Len())
+
+	ledger := NewLedger()
+	ledger.RecordFailure("a", &StatusError{StatusCode: 502, Body: "bad gateway"})
+	ledger.RecordFailure("a", &StatusError{StatusCode: 502, Body: "bad gateway"})
+	require.NoError(t, manager.Save(ledger))
+
+	loaded, err := manager.Load()
+	require.NoError(t, err)
+	failures := loaded.Failures()
+	require.Len(t, failures, 1)
+	assert.Equal(t, ErrorClassServer, failures[0].ErrorClass)
+	assert.Equal(t, 2, failures[0].Attempts)
+	assert.Equal(t, "status 502: bad gateway", failures[0].LastError)
+}
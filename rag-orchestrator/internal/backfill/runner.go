@@ -34,6 +34,7 @@ type Config struct {
 	DatabaseURL         string
 	OrchestratorURL     string
 	CursorFile          string
+	LedgerFile          string
 	FromDate            time.Time
 	ToDate              time.Time
 	Concurrency         int
@@ -52,6 +53,7 @@ func DefaultConfig() Config {
 	return Config{
 		OrchestratorURL: "http://localhost:9010",
 		CursorFile:      "cursor.json",
+		LedgerFile:      "failures.json",
 		Concurrency:     8,
 		BatchSize:       40,
 		RequestTimeout:  100 * time.Second,
@@ -81,6 +83,8 @@ type Runner struct {
 	db            *sql.DB
 	client        *http.Client
 	cursorManager *CursorManager
+	ledgerManager *LedgerManager
+	ledger        *Ledger
 	logger        *slog.Logger
 	stats         *Stats
 	limiter       *rate.Limiter
@@ -120,6 +124,8 @@ func NewRunner(cfg Config, logger *slog.Logger) (*Runner, error) {
 		db:            db,
 		client:        client,
 		cursorManager: NewCursorManager(cfg.CursorFile),
+		ledgerManager: NewLedgerManager(cfg.LedgerFile),
+		ledger:        NewLedger(),
 		logger:        logger,
 		stats:         &Stats{StartTime: time.Now()},
 		limiter:       limiter,
@@ -139,6 +145,11 @@ func (r *Runner) Run(ctx context.Context) error {
 	}
 	defer func() { _ = r.cursorManager.Unlock() }()
 
+	if err := r.loadLedger(); err != nil {
+		return err
+	}
+	defer r.finishLedger()
+
 	// Load cursor
 	cursor, err := r.cursorManager.Load()
 	if err != nil {
@@ -289,7 +300,6 @@ func (r *Runner) processBatches(ctx context.Context, query string, args []interf
 	defer func() { _ = rows.Close() }()
 
 	totalCount := 0
-	sem := make(chan struct{}, r.cfg.Concurrency)
 
 	for {
 		batch := make([]Article, 0, r.cfg.BatchSize)
@@ -308,47 +318,9 @@ func (r *Runner) processBatches(ctx context.Context, query string, args []interf
 			break
 		}
 
-		// Process batch concurrently
-		var wg sync.WaitGroup
-		for _, a := range batch {
-			select {
-			case <-ctx.Done():
-				return totalCount, ctx.Err()
-			default:
-			}
-
-			// Rate limit
-			if err := r.limiter.Wait(ctx); err != nil {
-				return totalCount, err
-			}
-
-			wg.Add(1)
-			sem <- struct{}{}
-			go func(article Article) {
-				defer wg.Done()
-				defer func() { <-sem }()
-
-				if r.cfg.DryRun {
-					r.logger.Info("dry-run: would process",
-						slog.String("id", article.ID),
-						slog.String("title", truncate(article.Title, 50)),
-					)
-					atomic.AddInt64(&r.stats.Skipped, 1)
-					return
-				}
-
-				if err := r.indexArticle(ctx, article); err != nil {
-					r.logger.Warn("failed to send article",
-						slog.String("id", article.ID),
-						slog.String("error", err.Error()),
-					)
-					atomic.AddInt64(&r.stats.Failed, 1)
-				} else {
-					atomic.AddInt64(&r.stats.Processed, 1)
-				}
-			}(a)
+		if err := r.processBatch(ctx, batch); err != nil {
+			return totalCount, err
 		}
-		wg.Wait()
 
 		// Update cursor
 		lastArticle := batch[len(batch)-1]
@@ -360,6 +332,7 @@ func (r *Runner) processBatches(ctx context.Context, query string, args []interf
 			if err := r.cursorManager.Save(*cursor); err != nil {
 				r.logger.Warn("failed to save cursor", slog.String("error", err.Error()))
 			}
+			r.saveLedger()
 		}
 
 		totalCount += len(batch)
@@ -372,6 +345,61 @@ func (r *Runner) processBatches(ctx context.Context, query string, args []interf
 	return totalCount, nil
 }
 
+// processBatch indexes a batch concurrently. Failures go to the ledger,
+// except those caused by the run being interrupted; successes clear any
+// earlier failure of the same article.
+func (r *Runner) processBatch(ctx context.Context, batch []Article) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	sem := make(chan struct{}, r.cfg.Concurrency)
+	for _, a := range batch {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// Rate limit
+		if err := r.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(article Article) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if r.cfg.DryRun {
+				r.logger.Info("dry-run: would process",
+					slog.String("id", article.ID),
+					slog.String("title", truncate(article.Title, 50)),
+				)
+				atomic.AddInt64(&r.stats.Skipped, 1)
+				return
+			}
+
+			if err := r.indexArticle(ctx, article); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				r.logger.Warn("failed to send article",
+					slog.String("id", article.ID),
+					slog.String("error_class", string(ClassifyError(err))),
+					slog.String("error", err.Error()),
+				)
+				r.ledger.RecordFailure(article.ID, err)
+				atomic.AddInt64(&r.stats.Failed, 1)
+			} else {
+				r.ledger.Resolve(article.ID)
+				atomic.AddInt64(&r.stats.Processed, 1)
+			}
+		}(a)
+	}
+	return nil
+}
+
 // indexArticle dispatches to direct indexer or HTTP depending on mode.
 func (r *Runner) indexArticle(ctx context.Context, a Article) error {
 	if r.cfg.Direct && r.cfg.DirectIndexer != nil {
@@ -430,7 +458,7 @@ func (r *Runner) sendArticle(ctx context.Context, a Article) error {
 	}
 
 	bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-	return fmt.Errorf("status %d: %s", resp.StatusCode, string(bodyBytes))
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
 }
 
 // reportProgress logs progress periodically.
@@ -453,6 +481,143 @@ func (r *Runner) reportProgress(ctx context.Context) {
 	}
 }
 
+// RetryFailed reprocesses only the articles in the failure ledger, or
+// those whose last failure is in classes when classes is non-empty. The
+// cursor is left untouched. Articles that were deleted or lost their
+// content since they failed are dropped from the ledger.
+func (r *Runner) RetryFailed(ctx context.Context, classes []ErrorClass) error {
+	if err := r.cursorManager.Lock(); err != nil {
+		return fmt.Errorf("acquire cursor lock: %w", err)
+	}
+	defer func() { _ = r.cursorManager.Unlock() }()
+
+	if err := r.loadLedger(); err != nil {
+		return err
+	}
+	defer r.finishLedger()
+
+	failures := r.ledger.Failures(classes...)
+	r.logger.Info("retrying failed articles",
+		slog.Int("selected", len(failures)),
+		slog.Int("in_ledger", r.ledger.Len()),
+	)
+
+	progressCtx, cancelProgress := context.WithCancel(ctx)
+	defer cancelProgress()
+	go r.reportProgress(progressCtx)
+
+	for start := 0; start < len(failures); start += r.cfg.BatchSize {
+		end := min(start+r.cfg.BatchSize, len(failures))
+		ids := make([]string, 0, end-start)
+		for _, f := range failures[start:end] {
+			ids = append(ids, f.ArticleID)
+		}
+
+		batch, err := r.fetchArticles(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if !r.cfg.DryRun {
+			found := make(map[string]bool, len(batch))
+			for _, a := range batch {
+				found[a.ID] = true
+			}
+			for _, id := range ids {
+				if !found[id] {
+					r.logger.Info("dropping article no longer eligible for indexing", slog.String("id", id))
+					r.ledger.Resolve(id)
+				}
+			}
+		}
+
+		if err := r.processBatch(ctx, batch); err != nil {
+			return err
+		}
+		if !r.cfg.DryRun {
+			r.saveLedger()
+		}
+	}
+
+	r.logger.Info("retry completed",
+		slog.Int64("total_processed", atomic.LoadInt64(&r.stats.Processed)),
+		slog.Int64("total_failed", atomic.LoadInt64(&r.stats.Failed)),
+	)
+	return nil
+}
+
+// fetchArticles loads the given articles if they are still eligible for
+// indexing.
+func (r *Runner) fetchArticles(ctx context.Context, ids []string) ([]Article, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, title, content, url, user_id, created_at
+		FROM articles
+		WHERE id = ANY($1::uuid[])
+		  AND content IS NOT NULL AND content != ''
+		  AND deleted_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query failed articles: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var articles []Article
+	for rows.Next() {
+		var a Article
+		if err := rows.Scan(&a.ID, &a.Title, &a.Body, &a.URL, &a.UserID, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan article: %w", err)
+		}
+		articles = append(articles, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+	return articles, nil
+}
+
+func (r *Runner) loadLedger() error {
+	ledger, err := r.ledgerManager.Load()
+	if err != nil {
+		return fmt.Errorf("load failure ledger: %w", err)
+	}
+	r.ledger = ledger
+	return nil
+}
+
+func (r *Runner) saveLedger() {
+	if err := r.ledgerManager.Save(r.ledger); err != nil {
+		r.logger.Warn("failed to save failure ledger", slog.String("error", err.Error()))
+	}
+}
+
+// finishLedger saves the ledger and summarizes what is still failing, by
+// error class, whether the run completed or was interrupted.
+func (r *Runner) finishLedger() {
+	if r.cfg.DryRun {
+		return
+	}
+	r.saveLedger()
+
+	summary := r.ledger.Summary()
+	if len(summary) == 0 {
+		r.logger.Info("no failed articles in ledger", slog.String("ledger_file", r.ledgerManager.FilePath()))
+		return
+	}
+	attrs := []any{
+		slog.Int("failed_articles", r.ledger.Len()),
+		slog.String("ledger_file", r.ledgerManager.FilePath()),
+	}
+	for class, count := range summary {
+		attrs = append(attrs, slog.Int(string(class), count))
+	}
+	r.logger.Warn("failure summary by error class, rerun with 'backfill retry-failed'", attrs...)
+}
+
+// GetLedger returns the persisted failure ledger.
+func (r *Runner) GetLedger() (*Ledger, error) {
+	return r.ledgerManager.Load()
+}
+
 // ResetCursor clears the cursor file.
 func (r *Runner) ResetCursor() error {
 	return r.cursorManager.Reset()
@@ -2,8 +2,10 @@ package di
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"time"
 
@@ -11,12 +13,17 @@ import (
 
 	"rag-orchestrator/internal/adapter/altdb"
 	"rag-orchestrator/internal/adapter/eino"
+	"rag-orchestrator/internal/adapter/eventstream"
+	"rag-orchestrator/internal/adapter/guardrail"
+	"rag-orchestrator/internal/adapter/modelroute"
 	"rag-orchestrator/internal/adapter/rag_augur"
 	rag_http "rag-orchestrator/internal/adapter/rag_http"
 	"rag-orchestrator/internal/adapter/recap_worker"
 	"rag-orchestrator/internal/adapter/repository"
+	"rag-orchestrator/internal/adapter/source"
 	"rag-orchestrator/internal/adapter/sovereign_client"
 	"rag-orchestrator/internal/adapter/tools"
+	"rag-orchestrator/internal/adapter/usagebudget"
 	"rag-orchestrator/internal/domain"
 	"rag-orchestrator/internal/infra/config"
 	"rag-orchestrator/internal/infra/httpclient"
@@ -44,9 +51,36 @@ type ApplicationComponents struct {
 	AnswerUsecase        usecase.AnswerWithRAGUsecase
 	MorningLetterUsecase usecase.MorningLetterUsecase
 	ConversationUsecase  usecase.AugurConversationUsecase
+	FeedbackUsecase      usecase.AnswerFeedbackUsecase
+	IndexSourceUsecase   usecase.IndexSourceUsecase
+	CorpusStatsUsecase   usecase.CorpusStatsUsecase
+	ReindexUsecase       usecase.ReindexUsecase
+	UsageUsecase         usecase.UsageAccountingUsecase
+
+	// Guardrails filters generated answers; nil when RAG_GUARDRAILS_ENABLED
+	// is false. Exposed so the admin endpoint can switch its mode.
+	Guardrails *usecase.GuardrailChain
+
+	// OpenAIModels are the model names served by /v1/chat/completions; nil
+	// when OPENAI_COMPAT_ENABLED is false.
+	OpenAIModels []rag_http.OpenAIModel
 
 	// Worker
 	Worker *worker.JobWorker
+	// SourceSyncWorker is nil unless RAG_SOURCE_UPLOAD_DIR is set.
+	SourceSyncWorker *worker.SourceSyncWorker
+	// OrphanReconcileWorker is nil when
+	// RAG_ORPHAN_RECONCILE_INTERVAL_MINUTES is 0.
+	OrphanReconcileWorker *worker.OrphanReconcileWorker
+	// ArticleDeletionSyncWorker is nil when
+	// RAG_DELETION_SYNC_INTERVAL_MINUTES is 0.
+	ArticleDeletionSyncWorker *worker.ArticleDeletionSyncWorker
+	// ArticleEventConsumer is nil unless RAG_EVENTS_ENABLED is true.
+	ArticleEventConsumer *worker.ArticleEventConsumer
+
+	// TextExtractor turns uploaded PDF/Markdown/text files into plain text.
+	TextExtractor  domain.TextExtractor
+	MaxUploadBytes int64
 
 	// Factories (for hyper-boost support)
 	EmbedderFactory     rag_http.EmbedderFactory
@@ -73,6 +107,8 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 	docRepo := repository.NewRagDocumentRepository(pool)
 	jobRepo := repository.NewRagJobRepository(pool)
 	augurConvRepo := repository.NewAugurConversationRepository(pool)
+	answerFeedbackRepo := repository.NewAnswerFeedbackRepository(pool)
+	requestUsageRepo := repository.NewRequestUsageRepository(pool)
 	txManager := repository.NewPostgresTransactionManager(pool)
 
 	// Preflight mTLS cert loading so any cert/key/CA misconfiguration surfaces
@@ -90,7 +126,7 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 
 	// External clients
 	embedder := rag_augur.NewOllamaEmbedder(cfg.Embedder.URL, cfg.Embedder.Model, cfg.Embedder.Timeout, log, embedderHTTP)
-	searchClient := rag_http.NewSearchIndexerClient(cfg.Search.IndexerURL, cfg.Search.Timeout, "")
+	searchClient := rag_http.NewSearchIndexerClient(cfg.Search.IndexerURL, cfg.Search.Timeout, cfg.Search.APIKey)
 	queryExpander := rag_augur.NewQueryExpanderClient(cfg.QueryExpansion.URL, cfg.QueryExpansion.Timeout, log, queryExpanderHTTP)
 
 	var generator ragLLMClient
@@ -110,6 +146,29 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 		generator = rag_augur.NewOllamaGenerator(cfg.Augur.URL, cfg.Augur.Model, cfg.Augur.Timeout, log, augurHTTP)
 		log.Info("llm_backend_selected", slog.String("backend", "ollama"))
 	}
+	// Per-request usage accounting reads token counts off the innermost
+	// generators, so a routed call is attributed to the model that served it.
+	generator = usecase.NewMeteredLLMClient(generator).(ragLLMClient)
+
+	// Per-query-type model routing. Routes that name the Augur model share
+	// the generator above; other models get a generator of the same backend.
+	if cfg.ModelRouting.Enabled {
+		routes := make(map[usecase.ModelRoute]usecase.ModelRouteTarget, len(cfg.ModelRouting.Routes))
+		for _, rc := range cfg.ModelRouting.Routes {
+			route, err := usecase.ParseModelRoute(rc.Route)
+			if err != nil {
+				panic(fmt.Errorf("model routing: %w", err))
+			}
+			var client domain.LLMClient = generator
+			if rc.Model != cfg.Augur.Model {
+				client = usecase.NewMeteredLLMClient(newRouteGenerator(cfg, rc.Model, log, augurHTTP))
+			}
+			routes[route] = usecase.ModelRouteTarget{Client: client, CostPer1KTokens: rc.CostPer1KTokens}
+		}
+		router := usecase.NewModelRouter(generator, routes, modelroute.Recorder{}, log)
+		generator = router
+		log.Info("model_routing_enabled", slog.Any("routes", router.Routes()))
+	}
 
 	// Domain services
 	hasher := domain.NewSourceHashPolicy()
@@ -137,6 +196,16 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 		LanguageAllocation: usecase.LanguageAllocationConfig{
 			Enabled: cfg.RAG.DynamicLanguageAllocationEnabled,
 		},
+		QueryDecomposition: usecase.QueryDecompositionConfig{
+			Enabled:       cfg.RAG.QueryDecompositionEnabled,
+			MaxSubQueries: cfg.RAG.QueryDecompositionMaxSubQueries,
+		},
+	}
+	if cfg.RAG.QueryDecompositionEnabled {
+		log.Info("query_decomposition_enabled",
+			slog.Int("max_sub_queries", cfg.RAG.QueryDecompositionMaxSubQueries))
+	} else {
+		log.Info("query_decomposition_disabled")
 	}
 
 	// Optional components
@@ -177,9 +246,20 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 		}
 	}
 
+	// Query embeddings are cached; indexing keeps the uncached embedder.
+	// Only cache misses count as embedding calls in request usage.
+	queryEmbedder := usecase.NewMeteredVectorEncoder(embedder)
+	if cfg.Embedder.QueryCacheEnabled {
+		queryEmbedder = rag_augur.NewCachedEmbedder(queryEmbedder, cfg.Embedder.QueryCacheSize,
+			time.Duration(cfg.Embedder.QueryCacheTTL)*time.Minute)
+		log.Info("embedding_cache_enabled",
+			slog.Int("size", cfg.Embedder.QueryCacheSize),
+			slog.Int("ttl_minutes", cfg.Embedder.QueryCacheTTL))
+	}
+
 	// Retrieve usecase
 	retrieveUsecase := usecase.NewRetrieveContextUsecase(
-		chunkRepo, docRepo, embedder, generator, searchClient, queryExpander,
+		chunkRepo, docRepo, queryEmbedder, generator, searchClient, queryExpander,
 		retrievalConfig, log, opts...,
 	)
 
@@ -278,6 +358,47 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 	answerOpts = append(answerOpts, usecase.WithNeighborSearcher(neighborSearcher))
 	log.Info("neighbor_searcher_enabled")
 
+	// Post-generation answer guardrails.
+	var guardrails *usecase.GuardrailChain
+	if cfg.Guardrails.Enabled {
+		var filters []usecase.AnswerFilter
+		if cfg.Guardrails.PIIRedaction {
+			filters = append(filters, usecase.NewPIIRedactionFilter())
+		}
+		if cfg.Guardrails.InjectionEcho {
+			filters = append(filters, usecase.NewInjectionEchoFilter())
+		}
+		if cfg.Guardrails.ToxicityURL != "" {
+			toxicityClient := rag_augur.NewToxicityClient(
+				cfg.Guardrails.ToxicityURL,
+				time.Duration(cfg.Guardrails.ToxicityTimeout)*time.Second,
+				httpclient.NewPooledClient(time.Duration(cfg.Guardrails.ToxicityTimeout)*time.Second),
+			)
+			filters = append(filters, usecase.NewToxicityFilter(toxicityClient, cfg.Guardrails.ToxicityThreshold))
+		}
+		guardrails = usecase.NewGuardrailChain(usecase.GuardrailMode(cfg.Guardrails.Mode), guardrail.Recorder{}, log, filters...)
+		answerOpts = append(answerOpts, usecase.WithGuardrails(guardrails))
+		log.Info("answer_guardrails_enabled",
+			slog.String("mode", cfg.Guardrails.Mode),
+			slog.Any("filters", guardrails.FilterNames()))
+	} else {
+		log.Info("answer_guardrails_disabled")
+	}
+
+	var openAIModels []rag_http.OpenAIModel
+	if cfg.OpenAICompat.Enabled {
+		for _, m := range cfg.OpenAICompat.Models {
+			openAIModels = append(openAIModels, rag_http.OpenAIModel{
+				Name:         m.Name,
+				AnswerLength: usecase.AnswerLength(m.AnswerLength),
+				ReadingLevel: usecase.ReadingLevel(m.ReadingLevel),
+			})
+		}
+		log.Info("openai_compat_enabled",
+			slog.Int("models", len(openAIModels)),
+			slog.Bool("api_key_required", cfg.OpenAICompat.APIKey != ""))
+	}
+
 	answerUsecase := usecase.NewAnswerWithRAGUsecase(
 		retrieveUsecase, promptBuilder, generator, usecase.NewOutputValidator(cfg.RAG.MinAnswerLength),
 		cfg.RAG.MaxChunks, cfg.RAG.MaxTokens, cfg.RAG.MaxPromptTokens,
@@ -285,6 +406,13 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 		answerOpts...,
 	)
 
+	// Per-request usage accounting and daily budget alarms (append-only rows in rag-db)
+	usageUsecase := usecase.NewUsageAccountingUsecase(requestUsageRepo, usecase.UsageBudget{
+		DailyTokens:   int64(cfg.Usage.DailyTokenLimit),
+		DailyRequests: int64(cfg.Usage.DailyRequestLimit),
+	}, usagebudget.Recorder{}, log, nil)
+	answerUsecase = usecase.NewUsageAccountingAnswerUsecase(answerUsecase, usageUsecase, log)
+
 	// Morning letter usecase
 	articleClient := altdb.NewHTTPArticleClient(
 		cfg.Backend.URL,
@@ -306,6 +434,9 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 	// Ask Augur chat persistence (append-first rows in rag-db)
 	conversationUsecase := usecase.NewAugurConversationUsecase(augurConvRepo, nil)
 
+	// Answer snapshots + reader feedback (append-only rows in rag-db)
+	feedbackUsecase := usecase.NewAnswerFeedbackUsecase(answerFeedbackRepo, nil)
+
 	// Morning letter fetcher for chat grounding (recap-worker REST)
 	recapWorkerURL := cfg.Backend.RecapWorkerURL
 	if recapWorkerURL == "" {
@@ -324,6 +455,71 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 	// Worker
 	jobWorker := worker.NewJobWorker(jobRepo, indexUsecase, log)
 
+	// Non-article sources (bookmarks, notes, uploads)
+	indexSourceUsecase := usecase.NewIndexSourceUsecase(docRepo, chunkRepo, txManager, hasher, chunker, embedder, log)
+	textExtractor := source.NewTextExtractor()
+	maxUploadBytes := int64(cfg.Sources.MaxUploadMB) << 20
+	var sourceSyncWorker *worker.SourceSyncWorker
+	if cfg.Sources.UploadDir != "" {
+		connectors := []domain.SourceConnector{
+			source.NewDirectoryConnector(cfg.Sources.UploadDir, textExtractor, maxUploadBytes),
+		}
+		sourceSyncWorker = worker.NewSourceSyncWorker(indexSourceUsecase, connectors,
+			time.Duration(cfg.Sources.SyncIntervalMinutes)*time.Minute, log)
+		log.Info("upload directory sync enabled",
+			"dir", cfg.Sources.UploadDir,
+			"interval_minutes", cfg.Sources.SyncIntervalMinutes)
+	} else {
+		log.Info("upload directory sync disabled",
+			"reason", "RAG_SOURCE_UPLOAD_DIR is not set")
+	}
+
+	// Index health
+	corpusStatsUsecase := usecase.NewCorpusStatsUsecase(repository.NewCorpusStatsRepository(pool), nil)
+	var orphanReconcileWorker *worker.OrphanReconcileWorker
+	if cfg.Maintenance.OrphanReconcileIntervalMinutes > 0 {
+		orphanReconcileWorker = worker.NewOrphanReconcileWorker(corpusStatsUsecase,
+			time.Duration(cfg.Maintenance.OrphanReconcileIntervalMinutes)*time.Minute, log)
+	} else {
+		log.Info("orphaned chunk reconcile disabled",
+			"reason", "RAG_ORPHAN_RECONCILE_INTERVAL_MINUTES is 0")
+	}
+
+	// Partial reindex: re-enqueue alt-backend articles by date range, feed or user
+	reindexUsecase := usecase.NewReindexUsecase(
+		altdb.NewInternalReindexArticleClient(backendInternalClient, log),
+		repository.NewReindexRunRepository(pool),
+		jobRepo, docRepo, hasher, chunker.Version(), log,
+	)
+
+	// Deletion propagation: tombstone articles deleted in alt-backend
+	var articleDeletionSyncWorker *worker.ArticleDeletionSyncWorker
+	if cfg.Maintenance.DeletionSyncIntervalMinutes > 0 {
+		deletionSource := altdb.NewHTTPArticleDeletionClient(
+			cfg.Backend.URL,
+			time.Duration(cfg.Backend.Timeout)*time.Second,
+			log,
+		)
+		articleDeletionSyncWorker = worker.NewArticleDeletionSyncWorker(
+			usecase.NewArticleDeletionSyncUsecase(deletionSource, indexUsecase, log),
+			time.Duration(cfg.Maintenance.DeletionSyncIntervalMinutes)*time.Minute,
+			time.Duration(cfg.Maintenance.DeletionSyncLookbackHours)*time.Hour,
+			log)
+	} else {
+		log.Info("article deletion sync disabled",
+			"reason", "RAG_DELETION_SYNC_INTERVAL_MINUTES is 0")
+	}
+
+	// Event-driven indexing: follow alt-backend's article events
+	var articleEventConsumer *worker.ArticleEventConsumer
+	if cfg.Events.Enabled {
+		articleEventConsumer = newArticleEventConsumer(cfg.Events,
+			usecase.NewArticleEventUsecase(jobRepo, indexUsecase, docRepo, hasher, chunker.Version()), log)
+	} else {
+		log.Info("article event consumer disabled",
+			"reason", "RAG_EVENTS_ENABLED is not true")
+	}
+
 	// EventEmitter — wire the real sovereign client when
 	// RAG_ORCHESTRATOR_KNOWLEDGE_EVENT_EMIT=true, which also requires
 	// RAG_ORCHESTRATOR_KNOWLEDGE_SOVEREIGN_URL. Emit left unset (false)
@@ -348,21 +544,87 @@ func NewApplicationComponents(cfg *config.Config, pool *pgxpool.Pool, log *slog.
 	}
 
 	return &ApplicationComponents{
-		ChunkRepo:            chunkRepo,
-		DocRepo:              docRepo,
-		JobRepo:              jobRepo,
-		IndexUsecase:         indexUsecase,
-		RetrieveUsecase:      retrieveUsecase,
-		AnswerUsecase:        answerUsecase,
-		MorningLetterUsecase: morningLetterUsecase,
-		ConversationUsecase:  conversationUsecase,
-		EventEmitter:         eventEmitter,
-		Worker:               jobWorker,
-		EmbedderFactory:      embedderFactory,
-		IndexUsecaseFactory:  indexUsecaseFactory,
-		ArticleClient:        articleClient,
-		LetterFetcher:        letterFetcher,
-		EmbeddingModel:       cfg.Embedder.Model,
-		EmbedderTimeout:      cfg.Embedder.Timeout,
+		ChunkRepo:                 chunkRepo,
+		DocRepo:                   docRepo,
+		JobRepo:                   jobRepo,
+		IndexUsecase:              indexUsecase,
+		RetrieveUsecase:           retrieveUsecase,
+		AnswerUsecase:             answerUsecase,
+		MorningLetterUsecase:      morningLetterUsecase,
+		ConversationUsecase:       conversationUsecase,
+		FeedbackUsecase:           feedbackUsecase,
+		IndexSourceUsecase:        indexSourceUsecase,
+		CorpusStatsUsecase:        corpusStatsUsecase,
+		ReindexUsecase:            reindexUsecase,
+		UsageUsecase:              usageUsecase,
+		Guardrails:                guardrails,
+		OpenAIModels:              openAIModels,
+		EventEmitter:              eventEmitter,
+		Worker:                    jobWorker,
+		SourceSyncWorker:          sourceSyncWorker,
+		OrphanReconcileWorker:     orphanReconcileWorker,
+		ArticleDeletionSyncWorker: articleDeletionSyncWorker,
+		ArticleEventConsumer:      articleEventConsumer,
+		TextExtractor:             textExtractor,
+		MaxUploadBytes:            maxUploadBytes,
+		EmbedderFactory:           embedderFactory,
+		IndexUsecaseFactory:       indexUsecaseFactory,
+		ArticleClient:             articleClient,
+		LetterFetcher:             letterFetcher,
+		EmbeddingModel:            cfg.Embedder.Model,
+		EmbedderTimeout:           cfg.Embedder.Timeout,
 	}
 }
+
+// newRouteGenerator builds a generator for a routed model on the configured
+// LLM backend, sharing the Augur endpoint and HTTP client.
+func newRouteGenerator(cfg *config.Config, model string, log *slog.Logger, client *http.Client) domain.LLMClient {
+	if cfg.LLMBackend == "eino" {
+		g, err := eino.NewChatModelAdapter(context.Background(), cfg.Augur.URL, model, log)
+		if err != nil {
+			panic(fmt.Errorf("model routing: eino generator for %s: %w", model, err))
+		}
+		return g
+	}
+	return rag_augur.NewOllamaGenerator(cfg.Augur.URL, model, cfg.Augur.Timeout, log, client)
+}
+
+// newArticleEventConsumer connects the article event consumer. A missing
+// consumer group is a setup error and fails startup; Redis being down is
+// not, and the consumer keeps retrying until it answers.
+func newArticleEventConsumer(cfg config.EventsConfig, events usecase.ArticleEventUsecase, log *slog.Logger) *worker.ArticleEventConsumer {
+	stream, err := eventstream.NewRedisStream(cfg.RedisURL, eventstream.Config{
+		Stream:    cfg.Stream,
+		Group:     cfg.Group,
+		Consumer:  cfg.Consumer,
+		DLQStream: cfg.DLQStream,
+		BatchSize: int64(cfg.BatchSize),
+		// Short enough that Stop does not wait long on an idle stream.
+		Block:     2 * time.Second,
+		ClaimIdle: time.Duration(cfg.ClaimIdleSeconds) * time.Second,
+	})
+	if err != nil {
+		panic(fmt.Errorf("article event stream: %w", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := stream.Verify(ctx); err != nil {
+		if !errors.Is(err, eventstream.ErrUnreachable) {
+			panic(fmt.Errorf("article event stream: %w", err))
+		}
+		log.Warn("article event stream unreachable at startup, consumer will keep retrying",
+			"stream", cfg.Stream,
+			"error", err)
+	}
+
+	log.Info("article event consumer enabled",
+		"stream", cfg.Stream,
+		"group", cfg.Group,
+		"consumer", cfg.Consumer,
+		"dlq_stream", cfg.DLQStream)
+	return worker.NewArticleEventConsumer(stream, events, eventstream.Recorder{},
+		int64(cfg.MaxDeliveries),
+		time.Duration(cfg.LagIntervalSeconds)*time.Second,
+		log)
+}
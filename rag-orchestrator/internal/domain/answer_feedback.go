@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedbackRating is the thumbs up/down verdict a reader gives an answer.
+type FeedbackRating string
+
+const (
+	FeedbackRatingUp   FeedbackRating = "up"
+	FeedbackRatingDown FeedbackRating = "down"
+)
+
+// Valid reports whether r is a known rating.
+func (r FeedbackRating) Valid() bool {
+	return r == FeedbackRatingUp || r == FeedbackRatingDown
+}
+
+// AnswerRecord is the write-once snapshot of a generated answer, keyed by
+// the answer's retrieval set ID. It keeps the query, prompt version, and
+// exact chunks the prompt was built from so feedback can later be replayed
+// as an eval case even after the index has moved on.
+type AnswerRecord struct {
+	ID            uuid.UUID
+	Query         string
+	Answer        string
+	PromptVersion string
+	StrategyUsed  string
+	IntentType    string
+	Chunks        []AnswerChunk
+	Citations     []AnswerChunk
+	CreatedAt     time.Time
+}
+
+// HasChunk reports whether chunkID was part of the answer's context or
+// citations.
+func (a *AnswerRecord) HasChunk(chunkID string) bool {
+	for _, c := range a.Chunks {
+		if c.ChunkID == chunkID {
+			return true
+		}
+	}
+	for _, c := range a.Citations {
+		if c.ChunkID == chunkID {
+			return true
+		}
+	}
+	return false
+}
+
+// AnswerChunk is a chunk snapshot persisted inside rag_answers (JSONB).
+type AnswerChunk struct {
+	ChunkID         string  `json:"chunk_id"`
+	ArticleID       string  `json:"article_id,omitempty"`
+	Title           string  `json:"title"`
+	URL             string  `json:"url"`
+	Text            string  `json:"text"`
+	Score           float32 `json:"score"`
+	DocumentVersion int     `json:"document_version"`
+}
+
+// AnswerFeedback is a single append-only feedback submission for an answer.
+// A reader may submit several; the export keeps them all.
+type AnswerFeedback struct {
+	ID              uuid.UUID
+	AnswerID        uuid.UUID
+	Rating          FeedbackRating
+	Comment         string
+	HelpfulChunkIDs []string
+	CreatedAt       time.Time
+}
+
+// AnswerFeedbackExport pairs a feedback row with the answer it rates.
+type AnswerFeedbackExport struct {
+	Feedback AnswerFeedback
+	Answer   AnswerRecord
+}
+
+// AnswerFeedbackRepository manages rag_answers and rag_answer_feedback. Both
+// tables are append-only.
+type AnswerFeedbackRepository interface {
+	// SaveAnswer inserts an answer snapshot. Saving the same ID twice is a
+	// no-op so a retried request cannot fail on its own snapshot.
+	SaveAnswer(ctx context.Context, answer *AnswerRecord) error
+
+	// GetAnswer loads an answer snapshot. Returns nil, nil if not found.
+	GetAnswer(ctx context.Context, id uuid.UUID) (*AnswerRecord, error)
+
+	// AppendFeedback inserts a feedback row. Caller sets ID and CreatedAt.
+	AppendFeedback(ctx context.Context, feedback *AnswerFeedback) error
+
+	// ListFeedback returns feedback created at or after since, joined with
+	// its answer, ordered by (created_at, id) ascending. Keyset pagination
+	// continues after (afterCreatedAt, afterID) when both are set.
+	ListFeedback(ctx context.Context, since time.Time, afterCreatedAt *time.Time, afterID *uuid.UUID, limit int) ([]AnswerFeedbackExport, error)
+}
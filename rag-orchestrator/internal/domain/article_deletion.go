@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ArticleDeletionAction is what happened to an article in alt-backend's
+// soft-delete audit trail.
+type ArticleDeletionAction string
+
+const (
+	// ArticleDeleted: the owner moved the article to the trash. It can still
+	// be restored for alt-backend's restore window.
+	ArticleDeleted ArticleDeletionAction = "deleted"
+	// ArticleRestored: the article came back out of the trash.
+	ArticleRestored ArticleDeletionAction = "restored"
+	// ArticlePurged: the article was removed permanently.
+	ArticlePurged ArticleDeletionAction = "purged"
+)
+
+// ArticleDeletionEvent is one entry of the article soft-delete audit trail.
+type ArticleDeletionEvent struct {
+	ID         string
+	ArticleID  string
+	Action     ArticleDeletionAction
+	OccurredAt time.Time
+}
+
+// ArticleDeletionCursor is a position in the audit trail: events are
+// ordered by (OccurredAt, ID), and a page holds the events after the
+// cursor. The zero value starts at the beginning.
+type ArticleDeletionCursor struct {
+	Since   time.Time
+	AfterID string
+}
+
+// After returns the cursor positioned just past e.
+func (e ArticleDeletionEvent) After() ArticleDeletionCursor {
+	return ArticleDeletionCursor{Since: e.OccurredAt, AfterID: e.ID}
+}
+
+// ArticleDeletionSource reads alt-backend's article soft-delete audit trail.
+type ArticleDeletionSource interface {
+	// ListArticleDeletions returns up to limit events after cursor, oldest
+	// first.
+	ListArticleDeletions(ctx context.Context, cursor ArticleDeletionCursor, limit int) ([]ArticleDeletionEvent, error)
+}
+
+// ArticleDocumentState is whether an article's document is still
+// retrievable.
+type ArticleDocumentState struct {
+	ArticleID  string
+	Tombstoned bool
+}
+
+// ArticleDocumentLister pages through the indexed article documents for the
+// deletion audit.
+type ArticleDocumentLister interface {
+	// ListArticleDocuments returns up to limit article documents with an
+	// article ID greater than afterArticleID, in article ID order.
+	// Documents whose first version never committed are skipped.
+	ListArticleDocuments(ctx context.Context, afterArticleID string, limit int) ([]ArticleDocumentState, error)
+}
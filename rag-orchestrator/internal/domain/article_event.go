@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Article event types published by alt-backend through mq-hub.
+const (
+	ArticleEventCreated = "ArticleCreated"
+	ArticleEventUpdated = "ArticleUpdated"
+	ArticleEventDeleted = "ArticleDeleted"
+)
+
+// ErrInvalidArticleEvent marks an event that can never be handled, such as
+// a payload that is not JSON or has no article ID. Retrying it is pointless,
+// so the consumer dead-letters it at once.
+var ErrInvalidArticleEvent = errors.New("invalid article event")
+
+// ArticleEvent is one message of the article event stream.
+type ArticleEvent struct {
+	MessageID string
+	EventID   string
+	EventType string
+	Payload   []byte
+	CreatedAt time.Time
+	// Deliveries is how many times the stream has handed the message out,
+	// this delivery included.
+	Deliveries int64
+}
+
+// ArticleEventLag is how far the consumer group trails the stream.
+type ArticleEventLag struct {
+	// Lag is the number of entries not yet delivered to the group.
+	Lag int64
+	// Pending is the number of entries delivered but not acknowledged.
+	Pending int64
+}
+
+// ArticleEventStream is the consumer group's view of the article event
+// stream. Messages stay pending until acknowledged or dead-lettered.
+type ArticleEventStream interface {
+	// Read returns the next batch: pending messages left idle long enough to
+	// be retried first, then new ones, blocking briefly when there are none.
+	Read(ctx context.Context) ([]ArticleEvent, error)
+	// Ack acknowledges handled messages.
+	Ack(ctx context.Context, messageIDs ...string) error
+	// DeadLetter copies the message to the dead-letter stream with reason
+	// and acknowledges it.
+	DeadLetter(ctx context.Context, event ArticleEvent, reason string) error
+	// Lag reports the group's backlog.
+	Lag(ctx context.Context) (ArticleEventLag, error)
+}
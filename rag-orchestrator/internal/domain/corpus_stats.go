@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SourceDocumentCount is how many documents one owner has indexed from one
+// source. Alt feed articles are a shared corpus and carry no owner, so they
+// report an empty UserID; connector documents are attributed through the
+// "user_id" key of their source metadata when the connector sets it.
+type SourceDocumentCount struct {
+	SourceType SourceType `json:"source_type"`
+	UserID     string     `json:"user_id"`
+	Documents  int64      `json:"documents"`
+	Tombstoned int64      `json:"tombstoned"`
+}
+
+// EmbedderVersionCount is how many live documents were embedded with one
+// embedder/chunker pair. More than one entry means a re-embed is pending or
+// stalled.
+type EmbedderVersionCount struct {
+	EmbedderVersion string `json:"embedder_version"`
+	ChunkerVersion  string `json:"chunker_version"`
+	Documents       int64  `json:"documents"`
+}
+
+// JobBacklog is the depth of the rag_jobs queue by status.
+type JobBacklog struct {
+	New        int64 `json:"new"`
+	Processing int64 `json:"processing"`
+	Failed     int64 `json:"failed"`
+	// OldestPendingAt is the created_at of the oldest job still in "new".
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty"`
+}
+
+// CorpusStats is a point-in-time view of what the index holds and how
+// healthy it is.
+type CorpusStats struct {
+	Documents           int64 `json:"documents"`
+	TombstonedDocuments int64 `json:"tombstoned_documents"`
+	// DocumentsWithoutVersion counts documents whose first version never
+	// committed; retrieval cannot see them.
+	DocumentsWithoutVersion int64                 `json:"documents_without_version"`
+	DocumentsBySource       []SourceDocumentCount `json:"documents_by_source"`
+
+	// Chunks counts chunks of current versions, the ones retrieval searches.
+	Chunks int64 `json:"chunks"`
+	// ChunksWithoutEmbedding are current chunks vector search cannot match.
+	ChunksWithoutEmbedding int64 `json:"chunks_without_embedding"`
+	// OrphanedChunks belong to superseded versions. They are never
+	// retrieved and only cost storage and HNSW maintenance.
+	OrphanedChunks int64 `json:"orphaned_chunks"`
+
+	EmbeddingDimension int                    `json:"embedding_dimension"`
+	EmbedderVersions   []EmbedderVersionCount `json:"embedder_versions"`
+
+	// OldestIndexedArticleAt and NewestIndexedArticleAt bound the creation
+	// time of the live article versions.
+	OldestIndexedArticleAt *time.Time `json:"oldest_indexed_article_at,omitempty"`
+	NewestIndexedArticleAt *time.Time `json:"newest_indexed_article_at,omitempty"`
+
+	JobBacklog JobBacklog `json:"job_backlog"`
+	ComputedAt time.Time  `json:"computed_at"`
+}
+
+// CorpusStatsRepository reads index statistics and removes orphaned chunks.
+type CorpusStatsRepository interface {
+	// GetCorpusStats computes CorpusStats. ComputedAt is left to the caller.
+	GetCorpusStats(ctx context.Context) (*CorpusStats, error)
+
+	// DeleteOrphanedChunks deletes up to limit chunks that do not belong to
+	// their document's current version, detaching any chunk events that
+	// reference them first. It returns how many chunks were deleted.
+	DeleteOrphanedChunks(ctx context.Context, limit int) (int64, error)
+}
@@ -30,6 +30,10 @@ type LLMResponse struct {
 	Text      string
 	ToolCalls []ToolCall
 	Done      bool
+	// PromptTokens and CompletionTokens are the backend's token counts, zero
+	// when it does not report them.
+	PromptTokens     int
+	CompletionTokens int
 }
 
 // LLMStreamChunk represents a single streaming response chunk returned by the LLM.
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrReindexRunActive is returned when a reindex is requested while another
+// run is still scanning.
+var ErrReindexRunActive = errors.New("a reindex run is already active")
+
+// ReindexFilter selects the Alt articles a partial reindex covers. From is
+// inclusive and To exclusive; empty fields do not filter.
+type ReindexFilter struct {
+	From   *time.Time `json:"from,omitempty"`
+	To     *time.Time `json:"to,omitempty"`
+	FeedID string     `json:"feed_id,omitempty"`
+	UserID string     `json:"user_id,omitempty"`
+}
+
+// IsEmpty reports whether the filter would match every article.
+func (f ReindexFilter) IsEmpty() bool {
+	return f.From == nil && f.To == nil && f.FeedID == "" && f.UserID == ""
+}
+
+// ReindexArticle is an Alt article as listed for reindexing.
+type ReindexArticle struct {
+	ID        string
+	Title     string
+	Body      string
+	URL       string
+	FeedID    string
+	UserID    string
+	CreatedAt time.Time
+}
+
+// ReindexCursor is a position in the newest-first article listing. The
+// zero value starts at the newest article.
+type ReindexCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// ReindexArticleSource lists Alt articles matching a ReindexFilter.
+type ReindexArticleSource interface {
+	// ListArticlesForReindex returns up to limit articles after cursor,
+	// newest first, and the cursor for the next page (nil when exhausted).
+	ListArticlesForReindex(ctx context.Context, filter ReindexFilter, cursor ReindexCursor, limit int) ([]ReindexArticle, *ReindexCursor, error)
+}
+
+// ReindexRunStatus is the state of the article scan of a run. The jobs it
+// enqueued are tracked separately in ReindexJobCounts.
+type ReindexRunStatus string
+
+const (
+	ReindexRunning   ReindexRunStatus = "running"
+	ReindexCompleted ReindexRunStatus = "completed"
+	ReindexFailed    ReindexRunStatus = "failed"
+)
+
+// ReindexJobCounts is how far the worker got through a run's jobs.
+type ReindexJobCounts struct {
+	Pending    int `json:"pending"`
+	Processing int `json:"processing"`
+	Completed  int `json:"completed"`
+	Failed     int `json:"failed"`
+}
+
+// ReindexRun is one partial reindex. Matched counts the articles the filter
+// selected; each was either Enqueued as a backfill job or Skipped because
+// its current version already has the same source hash.
+type ReindexRun struct {
+	ID           uuid.UUID        `json:"id"`
+	Filter       ReindexFilter    `json:"filter"`
+	Status       ReindexRunStatus `json:"status"`
+	Matched      int              `json:"matched"`
+	Enqueued     int              `json:"enqueued"`
+	Skipped      int              `json:"skipped"`
+	Jobs         ReindexJobCounts `json:"jobs"`
+	ErrorMessage *string          `json:"error_message,omitempty"`
+	StartedAt    time.Time        `json:"started_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	FinishedAt   *time.Time       `json:"finished_at,omitempty"`
+}
+
+// ReindexRunRepository persists reindex runs.
+type ReindexRunRepository interface {
+	// CreateRun inserts run in the running state. It returns
+	// ErrReindexRunActive when another run is running and has reported
+	// progress within staleAfter; an older running run is marked failed
+	// and replaced.
+	CreateRun(ctx context.Context, run *ReindexRun, staleAfter time.Duration) error
+
+	// UpdateProgress stores the scan counters of a running run.
+	UpdateProgress(ctx context.Context, id uuid.UUID, matched, enqueued, skipped int) error
+
+	// FinishRun moves a run to its final status.
+	FinishRun(ctx context.Context, id uuid.UUID, status ReindexRunStatus, errorMessage *string) error
+
+	// GetRun returns the run with its job counts, or nil, nil if not found.
+	GetRun(ctx context.Context, id uuid.UUID) (*ReindexRun, error)
+}
@@ -11,8 +11,11 @@ import (
 // RagDocument represents a document in the system.
 type RagDocument struct {
 	ID               uuid.UUID
-	ArticleID        string
+	ArticleID        string     // SourceDocumentKey for non-article sources
 	CurrentVersionID *uuid.UUID // Can be nil if no version exists yet
+	SourceType       SourceType
+	SourceMetadata   map[string]string
+	Retrieval        RetrievalMetadata
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
@@ -73,6 +76,12 @@ type RagDocumentRepository interface {
 
 	// CreateVersion creates a new document version.
 	CreateVersion(ctx context.Context, version *RagDocumentVersion) error
+
+	// UpdateSourceMetadata replaces the connector metadata of a document.
+	UpdateSourceMetadata(ctx context.Context, docID uuid.UUID, metadata map[string]string) error
+
+	// UpdateRetrievalMetadata replaces the retrieval metadata of a document.
+	UpdateRetrievalMetadata(ctx context.Context, docID uuid.UUID, metadata RetrievalMetadata) error
 }
 
 // RagChunkRepository defines the operations for managing chunks and events.
@@ -88,6 +97,8 @@ type RagChunkRepository interface {
 
 	// Search performs a vector search across all chunks (Augur use case).
 	// Uses Two-Stage Search for HNSW index efficiency.
+	// Search and SearchWithinArticles honour the source and retrieval
+	// filters set with WithSourceFilter and WithRetrievalFilter.
 	Search(ctx context.Context, queryVector []float32, limit int) ([]SearchResult, error)
 
 	// SearchWithinArticles performs a vector search within specific articles (Morning Letter use case).
@@ -104,12 +115,16 @@ type SearchResult struct {
 	Title           string
 	URL             string
 	DocumentVersion int
+	SourceType      SourceType
+	SourceMetadata  map[string]string
 }
 
 // HybridSearcher performs in-database hybrid search (dense vector + sparse tsvector)
 // with Reciprocal Rank Fusion (RRF). Replaces application-level BM25 + vector fusion.
 type HybridSearcher interface {
 	// HybridSearch performs a combined vector + full-text search with RRF fusion.
+	// It honours the source and retrieval filters set with WithSourceFilter
+	// and WithRetrievalFilter.
 	HybridSearch(ctx context.Context, queryVector []float32, queryText string, limit int) ([]SearchResult, error)
 
 	// SearchNeighbors finds articles semantically and lexically near a seed set
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageMode is how an answer was delivered.
+type UsageMode string
+
+const (
+	UsageModeAnswer UsageMode = "answer"
+	UsageModeStream UsageMode = "stream"
+)
+
+// RequestUsage is the generator and retrieval work one answer request cost.
+// Token counts are the backend's own; a backend that does not report them
+// records zero.
+type RequestUsage struct {
+	ID uuid.UUID
+	// UserID is empty for callers that do not identify a user, e.g. the
+	// morning letter.
+	UserID string
+	Mode   UsageMode
+	// Model is the generator that served the request's last call, i.e. the
+	// one that wrote the answer.
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	GeneratorCalls   int
+	// EmbeddingCalls counts calls that reached the embedder; query cache
+	// hits are free and not counted.
+	EmbeddingCalls int
+	// RetrievalLatency is the time spent retrieving context, summed over
+	// every retrieval the request ran.
+	RetrievalLatency time.Duration
+	TotalLatency     time.Duration
+	Failed           bool
+	CreatedAt        time.Time
+}
+
+// TotalTokens is prompt plus completion tokens.
+func (u *RequestUsage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// UsageDaySummary totals one user's requests over one UTC day.
+type UsageDaySummary struct {
+	UserID string `json:"user_id"`
+	// Day is formatted as YYYY-MM-DD.
+	Day                   string   `json:"day"`
+	Requests              int64    `json:"requests"`
+	FailedRequests        int64    `json:"failed_requests"`
+	PromptTokens          int64    `json:"prompt_tokens"`
+	CompletionTokens      int64    `json:"completion_tokens"`
+	EmbeddingCalls        int64    `json:"embedding_calls"`
+	AvgRetrievalLatencyMS float64  `json:"avg_retrieval_latency_ms"`
+	MaxRetrievalLatencyMS int64    `json:"max_retrieval_latency_ms"`
+	AvgTotalLatencyMS     float64  `json:"avg_total_latency_ms"`
+	Models                []string `json:"models"`
+}
+
+// TotalTokens is prompt plus completion tokens.
+func (s *UsageDaySummary) TotalTokens() int64 {
+	return s.PromptTokens + s.CompletionTokens
+}
+
+// RequestUsageRepository manages rag_request_usage. Rows are append-only.
+type RequestUsageRepository interface {
+	// Append inserts a usage row. Caller sets ID and CreatedAt.
+	Append(ctx context.Context, usage *RequestUsage) error
+
+	// SummarizeByUserDay totals rows created in [from, to) per user and UTC
+	// day, ordered by day then user. An empty userID includes every user.
+	SummarizeByUserDay(ctx context.Context, from, to time.Time, userID string) ([]UsageDaySummary, error)
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxRetrievalFilterFeeds bounds the feed IDs one request may filter on.
+	MaxRetrievalFilterFeeds = 100
+	// MaxRetrievalFilterTags bounds the tags one request may filter on.
+	MaxRetrievalFilterTags = 20
+)
+
+// ErrInvalidRetrievalFilter is returned when a retrieval filter cannot be
+// applied, e.g. an empty date window.
+var ErrInvalidRetrievalFilter = errors.New("invalid retrieval filter")
+
+// RetrievalMetadata is the article metadata stored on a document for
+// retrieval filters to match on. Documents indexed without it have no
+// publication date, feed or tags and are excluded by any filter on them.
+type RetrievalMetadata struct {
+	PublishedAt time.Time
+	FeedID      string
+	Tags        []string
+}
+
+// Merge returns stored with the fields set in m applied on top. Fields m
+// leaves zero keep their stored value, so an event without tags never
+// clears tags an earlier event set.
+func (m RetrievalMetadata) Merge(stored RetrievalMetadata) RetrievalMetadata {
+	merged := stored
+	if !m.PublishedAt.IsZero() {
+		merged.PublishedAt = m.PublishedAt
+	}
+	if m.FeedID != "" {
+		merged.FeedID = m.FeedID
+	}
+	if len(m.Tags) > 0 {
+		merged.Tags = m.Tags
+	}
+	return merged
+}
+
+// Equal reports whether m and other hold the same metadata.
+func (m RetrievalMetadata) Equal(other RetrievalMetadata) bool {
+	return m.PublishedAt.Equal(other.PublishedAt) &&
+		m.FeedID == other.FeedID &&
+		slices.Equal(m.Tags, other.Tags)
+}
+
+// RetrievalFilter scopes retrieval by document metadata. Every set field
+// must match; within FeedIDs and Tags any one value is enough. The zero
+// value filters nothing.
+type RetrievalFilter struct {
+	// PublishedAfter keeps documents published at or after this time.
+	PublishedAfter *time.Time
+	// PublishedBefore keeps documents published before this time.
+	PublishedBefore *time.Time
+	// FeedIDs keeps documents from any of these feeds.
+	FeedIDs []string
+	// Tags keeps documents carrying any of these tags.
+	Tags []string
+}
+
+// IsEmpty reports whether f filters nothing.
+func (f RetrievalFilter) IsEmpty() bool {
+	return f.PublishedAfter == nil && f.PublishedBefore == nil && len(f.FeedIDs) == 0 && len(f.Tags) == 0
+}
+
+// Validate checks the date window and list sizes.
+func (f RetrievalFilter) Validate() error {
+	if f.PublishedAfter != nil && f.PublishedBefore != nil && !f.PublishedAfter.Before(*f.PublishedBefore) {
+		return fmt.Errorf("%w: published_after must be before published_before", ErrInvalidRetrievalFilter)
+	}
+	if len(f.FeedIDs) > MaxRetrievalFilterFeeds {
+		return fmt.Errorf("%w: at most %d feed_ids, got %d", ErrInvalidRetrievalFilter, MaxRetrievalFilterFeeds, len(f.FeedIDs))
+	}
+	if len(f.Tags) > MaxRetrievalFilterTags {
+		return fmt.Errorf("%w: at most %d tags, got %d", ErrInvalidRetrievalFilter, MaxRetrievalFilterTags, len(f.Tags))
+	}
+	if slices.Contains(f.FeedIDs, "") || slices.Contains(f.Tags, "") {
+		return fmt.Errorf("%w: feed_ids and tags must not contain empty values", ErrInvalidRetrievalFilter)
+	}
+	return nil
+}
+
+// CacheKey renders f for answer cache keys: equal filters give equal keys
+// regardless of list order, and the empty filter gives "".
+func (f RetrievalFilter) CacheKey() string {
+	if f.IsEmpty() {
+		return ""
+	}
+	var b strings.Builder
+	if f.PublishedAfter != nil {
+		b.WriteString("after=" + f.PublishedAfter.UTC().Format(time.RFC3339))
+	}
+	if f.PublishedBefore != nil {
+		b.WriteString(";before=" + f.PublishedBefore.UTC().Format(time.RFC3339))
+	}
+	feeds := slices.Sorted(slices.Values(f.FeedIDs))
+	tags := slices.Sorted(slices.Values(f.Tags))
+	fmt.Fprintf(&b, ";feeds=%v;tags=%v", feeds, tags)
+	return b.String()
+}
+
+type retrievalFilterKey struct{}
+
+// WithRetrievalFilter restricts every chunk search made with the returned
+// context to documents matching f, like WithSourceFilter. An empty filter
+// leaves ctx unchanged.
+func WithRetrievalFilter(ctx context.Context, f RetrievalFilter) context.Context {
+	if f.IsEmpty() {
+		return ctx
+	}
+	f.FeedIDs = slices.Clone(f.FeedIDs)
+	f.Tags = slices.Clone(f.Tags)
+	return context.WithValue(ctx, retrievalFilterKey{}, f)
+}
+
+// RetrievalFilterFromContext returns the filter set by WithRetrievalFilter,
+// or the empty filter.
+func RetrievalFilterFromContext(ctx context.Context) RetrievalFilter {
+	f, _ := ctx.Value(retrievalFilterKey{}).(RetrievalFilter)
+	return f
+}
@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrievalFilter_Validate(t *testing.T) {
+	after := time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)
+	before := after.Add(7 * 24 * time.Hour)
+	tooManyTags := strings.Split(strings.Repeat("t,", domain.MaxRetrievalFilterTags)+"t", ",")
+
+	tests := map[string]struct {
+		filter  domain.RetrievalFilter
+		wantErr bool
+	}{
+		"empty":              {filter: domain.RetrievalFilter{}},
+		"week window":        {filter: domain.RetrievalFilter{PublishedAfter: &after, PublishedBefore: &before}},
+		"open ended":         {filter: domain.RetrievalFilter{PublishedAfter: &after, Tags: []string{"go"}}},
+		"inverted window":    {filter: domain.RetrievalFilter{PublishedAfter: &before, PublishedBefore: &after}, wantErr: true},
+		"empty window":       {filter: domain.RetrievalFilter{PublishedAfter: &after, PublishedBefore: &after}, wantErr: true},
+		"too many tags":      {filter: domain.RetrievalFilter{Tags: tooManyTags}, wantErr: true},
+		"blank feed id":      {filter: domain.RetrievalFilter{FeedIDs: []string{"feed-1", ""}}, wantErr: true},
+		"blank tag":          {filter: domain.RetrievalFilter{Tags: []string{""}}, wantErr: true},
+		"feeds within bound": {filter: domain.RetrievalFilter{FeedIDs: []string{"feed-1", "feed-2"}}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidRetrievalFilter)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRetrievalFilter_CacheKeyIgnoresOrder(t *testing.T) {
+	after := time.Date(2026, 10, 9, 9, 0, 0, 0, time.FixedZone("JST", 9*3600))
+	a := domain.RetrievalFilter{PublishedAfter: &after, FeedIDs: []string{"b", "a"}, Tags: []string{"go", "ai"}}
+	utc := after.UTC()
+	b := domain.RetrievalFilter{PublishedAfter: &utc, FeedIDs: []string{"a", "b"}, Tags: []string{"ai", "go"}}
+
+	assert.Equal(t, a.CacheKey(), b.CacheKey())
+	assert.NotEqual(t, a.CacheKey(), domain.RetrievalFilter{FeedIDs: []string{"a", "b"}}.CacheKey())
+	assert.Empty(t, domain.RetrievalFilter{}.CacheKey())
+}
+
+func TestRetrievalFilterContext(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, domain.RetrievalFilterFromContext(ctx).IsEmpty())
+	assert.Equal(t, ctx, domain.WithRetrievalFilter(ctx, domain.RetrievalFilter{}))
+
+	tags := []string{"go"}
+	ctx = domain.WithRetrievalFilter(ctx, domain.RetrievalFilter{Tags: tags})
+	tags[0] = "mutated"
+	require.False(t, domain.RetrievalFilterFromContext(ctx).IsEmpty())
+	assert.Equal(t, []string{"go"}, domain.RetrievalFilterFromContext(ctx).Tags)
+}
+
+func TestRetrievalMetadata_MergeKeepsStoredFields(t *testing.T) {
+	published := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	stored := domain.RetrievalMetadata{PublishedAt: published, FeedID: "feed-1", Tags: []string{"go"}}
+
+	merged := domain.RetrievalMetadata{FeedID: "feed-2"}.Merge(stored)
+
+	assert.Equal(t, domain.RetrievalMetadata{PublishedAt: published, FeedID: "feed-2", Tags: []string{"go"}}, merged)
+	assert.True(t, domain.RetrievalMetadata{}.Merge(stored).Equal(stored))
+}
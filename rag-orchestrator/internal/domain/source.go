@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SourceType identifies where an indexed document came from.
+type SourceType string
+
+const (
+	// SourceArticle is an alt feed article. It is the default for documents
+	// indexed before connectors existed.
+	SourceArticle SourceType = "article"
+	// SourceBookmark is a page the user bookmarked outside of their feeds.
+	SourceBookmark SourceType = "bookmark"
+	// SourceNote is a free-form note written by the user.
+	SourceNote SourceType = "note"
+	// SourceUpload is an uploaded file (PDF or Markdown).
+	SourceUpload SourceType = "upload"
+)
+
+// ErrUnknownSourceType is returned when a source type string is not one of
+// the known SourceType values.
+var ErrUnknownSourceType = errors.New("unknown source type")
+
+// ParseSourceType validates a source type string.
+func ParseSourceType(s string) (SourceType, error) {
+	switch t := SourceType(strings.ToLower(strings.TrimSpace(s))); t {
+	case SourceArticle, SourceBookmark, SourceNote, SourceUpload:
+		return t, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownSourceType, s)
+	}
+}
+
+// SourceDocumentKey returns the rag_documents.article_id value for a
+// document. Articles keep their bare alt-db ID so existing rows and
+// candidate-article scoping keep working; other sources are prefixed with
+// their type so IDs from different connectors cannot collide.
+func SourceDocumentKey(sourceType SourceType, externalID string) string {
+	if sourceType == "" || sourceType == SourceArticle {
+		return externalID
+	}
+	return string(sourceType) + ":" + externalID
+}
+
+// SourceDocument is a document produced by a SourceConnector, ready to be
+// chunked and embedded. Body is plain text; binary formats are converted by
+// a TextExtractor before they reach the indexer.
+type SourceDocument struct {
+	SourceType SourceType
+	ExternalID string
+	Title      string
+	URL        string
+	Body       string
+	// Metadata is stored on the document as-is and returned with search
+	// results (e.g. file name, content type, bookmark folder).
+	Metadata map[string]string
+	// Retrieval is the article metadata retrieval filters match on. Zero
+	// fields keep the document's stored values.
+	Retrieval RetrievalMetadata
+	UpdatedAt time.Time
+	// Deleted marks a document the connector saw removed upstream; it is
+	// tombstoned instead of indexed.
+	Deleted bool
+}
+
+// Validate checks the fields the indexer depends on.
+func (d SourceDocument) Validate() error {
+	if _, err := ParseSourceType(string(d.SourceType)); err != nil {
+		return err
+	}
+	if strings.TrimSpace(d.ExternalID) == "" {
+		return errors.New("source document: external id is required")
+	}
+	if !d.Deleted && strings.TrimSpace(d.Body) == "" {
+		return errors.New("source document: body is required")
+	}
+	return nil
+}
+
+// SourceConnector pulls documents from one non-article source.
+type SourceConnector interface {
+	// Type returns the source type every document from this connector has.
+	Type() SourceType
+	// Fetch returns documents created, updated or deleted after since.
+	// A zero since requests a full listing.
+	Fetch(ctx context.Context, since time.Time) ([]SourceDocument, error)
+}
+
+var (
+	// ErrUnsupportedContentType is returned by a TextExtractor for content
+	// types it does not handle.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	// ErrNoExtractableText is returned when a file parses but yields no
+	// text, e.g. a scanned PDF without a text layer.
+	ErrNoExtractableText = errors.New("no extractable text")
+)
+
+// TextExtractor converts an uploaded file to plain text for chunking.
+type TextExtractor interface {
+	// Supports reports whether contentType can be extracted.
+	Supports(contentType string) bool
+	// Extract returns the text content of data.
+	Extract(ctx context.Context, contentType string, data []byte) (string, error)
+}
+
+type sourceFilterKey struct{}
+
+// WithSourceFilter restricts every chunk search made with the returned
+// context to documents of the given source types. It is carried on the
+// context so the retrieval pipeline does not need to thread it through
+// every stage. An empty list leaves ctx unchanged (no filtering).
+func WithSourceFilter(ctx context.Context, types []SourceType) context.Context {
+	if len(types) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, sourceFilterKey{}, slices.Clone(types))
+}
+
+// SourceFilterFromContext returns the source types set by WithSourceFilter,
+// or nil when searches are unrestricted.
+func SourceFilterFromContext(ctx context.Context) []SourceType {
+	types, _ := ctx.Value(sourceFilterKey{}).([]SourceType)
+	return types
+}
+
+// SourceFilterAllows reports whether documents of sourceType may be returned
+// under ctx's source filter.
+func SourceFilterAllows(ctx context.Context, sourceType SourceType) bool {
+	types := SourceFilterFromContext(ctx)
+	return len(types) == 0 || slices.Contains(types, sourceType)
+}
@@ -0,0 +1,55 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSourceType(t *testing.T) {
+	got, err := domain.ParseSourceType(" Note ")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SourceNote, got)
+
+	_, err = domain.ParseSourceType("email")
+	assert.ErrorIs(t, err, domain.ErrUnknownSourceType)
+}
+
+func TestSourceDocumentKey(t *testing.T) {
+	assert.Equal(t, "art-1", domain.SourceDocumentKey(domain.SourceArticle, "art-1"))
+	assert.Equal(t, "art-1", domain.SourceDocumentKey("", "art-1"))
+	assert.Equal(t, "note:42", domain.SourceDocumentKey(domain.SourceNote, "42"))
+}
+
+func TestSourceDocument_Validate(t *testing.T) {
+	valid := domain.SourceDocument{SourceType: domain.SourceUpload, ExternalID: "a.pdf", Body: "text"}
+	assert.NoError(t, valid.Validate())
+
+	missingBody := valid
+	missingBody.Body = " "
+	assert.Error(t, missingBody.Validate())
+
+	deleted := missingBody
+	deleted.Deleted = true
+	assert.NoError(t, deleted.Validate(), "deletions carry no body")
+
+	missingID := valid
+	missingID.ExternalID = ""
+	assert.Error(t, missingID.Validate())
+}
+
+func TestSourceFilter(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, domain.SourceFilterFromContext(ctx))
+	assert.True(t, domain.SourceFilterAllows(ctx, domain.SourceArticle))
+	assert.Equal(t, ctx, domain.WithSourceFilter(ctx, nil))
+
+	filtered := domain.WithSourceFilter(ctx, []domain.SourceType{domain.SourceNote, domain.SourceUpload})
+	assert.Equal(t, []domain.SourceType{domain.SourceNote, domain.SourceUpload}, domain.SourceFilterFromContext(filtered))
+	assert.True(t, domain.SourceFilterAllows(filtered, domain.SourceUpload))
+	assert.False(t, domain.SourceFilterAllows(filtered, domain.SourceArticle))
+}
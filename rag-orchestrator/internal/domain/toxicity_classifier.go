@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// ToxicityResult is a classifier's verdict on a piece of generated text.
+type ToxicityResult struct {
+	// Score is the probability (0.0 to 1.0) that the text is toxic.
+	Score float64
+	// Labels lists the categories the classifier flagged (e.g. "insult",
+	// "hate"), if it reports them.
+	Labels []string
+}
+
+// ToxicityClassifier scores generated answers before they reach the user.
+// Implementations call an external classifier endpoint; callers treat an
+// error as "unknown" and let the answer through.
+type ToxicityClassifier interface {
+	ClassifyToxicity(ctx context.Context, text string) (*ToxicityResult, error)
+}
@@ -102,6 +102,9 @@ const (
 	// BackendInternalServiceFetchArticlesByTagProcedure is the fully-qualified name of the
 	// BackendInternalService's FetchArticlesByTag RPC.
 	BackendInternalServiceFetchArticlesByTagProcedure = "/services.backend.v1.BackendInternalService/FetchArticlesByTag"
+	// BackendInternalServiceListArticlesForReindexProcedure is the fully-qualified name of the
+	// BackendInternalService's ListArticlesForReindex RPC.
+	BackendInternalServiceListArticlesForReindexProcedure = "/services.backend.v1.BackendInternalService/ListArticlesForReindex"
 	// BackendInternalServiceListRecapArticlesProcedure is the fully-qualified name of the
 	// BackendInternalService's ListRecapArticles RPC.
 	BackendInternalServiceListRecapArticlesProcedure = "/services.backend.v1.BackendInternalService/ListRecapArticles"
@@ -162,6 +165,9 @@ type BackendInternalServiceClient interface {
 	FetchTagCloud(context.Context, *connect.Request[v1.BackendInternalServiceFetchTagCloudRequest]) (*connect.Response[v1.BackendInternalServiceFetchTagCloudResponse], error)
 	// FetchArticlesByTag returns articles filtered by tag name.
 	FetchArticlesByTag(context.Context, *connect.Request[v1.BackendInternalServiceFetchArticlesByTagRequest]) (*connect.Response[v1.BackendInternalServiceFetchArticlesByTagResponse], error)
+	// ListArticlesForReindex returns articles matching date/feed/user filters
+	// using backward keyset pagination. Used by rag-orchestrator partial reindex.
+	ListArticlesForReindex(context.Context, *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error)
 	// ListRecapArticles returns paginated articles within a time window.
 	// Service-to-service only; auth is established at the mTLS transport layer.
 	ListRecapArticles(context.Context, *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error)
@@ -316,6 +322,12 @@ func NewBackendInternalServiceClient(httpClient connect.HTTPClient, baseURL stri
 			connect.WithSchema(backendInternalServiceMethods.ByName("FetchArticlesByTag")),
 			connect.WithClientOptions(opts...),
 		),
+		listArticlesForReindex: connect.NewClient[v1.ListArticlesForReindexRequest, v1.ListArticlesForReindexResponse](
+			httpClient,
+			baseURL+BackendInternalServiceListArticlesForReindexProcedure,
+			connect.WithSchema(backendInternalServiceMethods.ByName("ListArticlesForReindex")),
+			connect.WithClientOptions(opts...),
+		),
 		listRecapArticles: connect.NewClient[v1.ListRecapArticlesRequest, v1.ListRecapArticlesResponse](
 			httpClient,
 			baseURL+BackendInternalServiceListRecapArticlesProcedure,
@@ -350,6 +362,7 @@ type backendInternalServiceClient struct {
 	getEmptyFeedID              *connect.Client[v1.GetEmptyFeedIDRequest, v1.GetEmptyFeedIDResponse]
 	fetchTagCloud               *connect.Client[v1.BackendInternalServiceFetchTagCloudRequest, v1.BackendInternalServiceFetchTagCloudResponse]
 	fetchArticlesByTag          *connect.Client[v1.BackendInternalServiceFetchArticlesByTagRequest, v1.BackendInternalServiceFetchArticlesByTagResponse]
+	listArticlesForReindex      *connect.Client[v1.ListArticlesForReindexRequest, v1.ListArticlesForReindexResponse]
 	listRecapArticles           *connect.Client[v1.ListRecapArticlesRequest, v1.ListRecapArticlesResponse]
 }
 
@@ -474,6 +487,11 @@ func (c *backendInternalServiceClient) FetchArticlesByTag(ctx context.Context, r
 	return c.fetchArticlesByTag.CallUnary(ctx, req)
 }
 
+// ListArticlesForReindex calls services.backend.v1.BackendInternalService.ListArticlesForReindex.
+func (c *backendInternalServiceClient) ListArticlesForReindex(ctx context.Context, req *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error) {
+	return c.listArticlesForReindex.CallUnary(ctx, req)
+}
+
 // ListRecapArticles calls services.backend.v1.BackendInternalService.ListRecapArticles.
 func (c *backendInternalServiceClient) ListRecapArticles(ctx context.Context, req *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error) {
 	return c.listRecapArticles.CallUnary(ctx, req)
@@ -534,6 +552,9 @@ type BackendInternalServiceHandler interface {
 	FetchTagCloud(context.Context, *connect.Request[v1.BackendInternalServiceFetchTagCloudRequest]) (*connect.Response[v1.BackendInternalServiceFetchTagCloudResponse], error)
 	// FetchArticlesByTag returns articles filtered by tag name.
 	FetchArticlesByTag(context.Context, *connect.Request[v1.BackendInternalServiceFetchArticlesByTagRequest]) (*connect.Response[v1.BackendInternalServiceFetchArticlesByTagResponse], error)
+	// ListArticlesForReindex returns articles matching date/feed/user filters
+	// using backward keyset pagination. Used by rag-orchestrator partial reindex.
+	ListArticlesForReindex(context.Context, *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error)
 	// ListRecapArticles returns paginated articles within a time window.
 	// Service-to-service only; auth is established at the mTLS transport layer.
 	ListRecapArticles(context.Context, *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error)
@@ -684,6 +705,12 @@ func NewBackendInternalServiceHandler(svc BackendInternalServiceHandler, opts ..
 		connect.WithSchema(backendInternalServiceMethods.ByName("FetchArticlesByTag")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backendInternalServiceListArticlesForReindexHandler := connect.NewUnaryHandler(
+		BackendInternalServiceListArticlesForReindexProcedure,
+		svc.ListArticlesForReindex,
+		connect.WithSchema(backendInternalServiceMethods.ByName("ListArticlesForReindex")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backendInternalServiceListRecapArticlesHandler := connect.NewUnaryHandler(
 		BackendInternalServiceListRecapArticlesProcedure,
 		svc.ListRecapArticles,
@@ -738,6 +765,8 @@ func NewBackendInternalServiceHandler(svc BackendInternalServiceHandler, opts ..
 			backendInternalServiceFetchTagCloudHandler.ServeHTTP(w, r)
 		case BackendInternalServiceFetchArticlesByTagProcedure:
 			backendInternalServiceFetchArticlesByTagHandler.ServeHTTP(w, r)
+		case BackendInternalServiceListArticlesForReindexProcedure:
+			backendInternalServiceListArticlesForReindexHandler.ServeHTTP(w, r)
 		case BackendInternalServiceListRecapArticlesProcedure:
 			backendInternalServiceListRecapArticlesHandler.ServeHTTP(w, r)
 		default:
@@ -841,6 +870,10 @@ func (UnimplementedBackendInternalServiceHandler) FetchArticlesByTag(context.Con
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.FetchArticlesByTag is not implemented"))
 }
 
+func (UnimplementedBackendInternalServiceHandler) ListArticlesForReindex(context.Context, *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.ListArticlesForReindex is not implemented"))
+}
+
 func (UnimplementedBackendInternalServiceHandler) ListRecapArticles(context.Context, *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.ListRecapArticles is not implemented"))
 }
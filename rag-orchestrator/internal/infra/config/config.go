@@ -54,6 +54,13 @@ const (
 	defaultDynamicLanguageAllocationEnabled = true // Dynamic score-based allocation by default
 )
 
+// Query decomposition defaults.
+// Opt-in: each request pays one extra generator call when enabled.
+const (
+	defaultQueryDecompositionEnabled       = false
+	defaultQueryDecompositionMaxSubQueries = 3
+)
+
 // DB pool defaults.
 const (
 	defaultDBMaxConns int32 = 20
@@ -71,6 +78,60 @@ const (
 	defaultCacheTTL  = 10 // minutes
 )
 
+// Query embedding cache defaults. Embeddings are deterministic per model,
+// so the TTL only bounds how long rarely repeated queries hold memory.
+const (
+	defaultEmbeddingCacheEnabled = true
+	defaultEmbeddingCacheSize    = 1024
+	defaultEmbeddingCacheTTL     = 60 // minutes
+)
+
+// Source connector defaults.
+const (
+	defaultSourceSyncIntervalMinutes = 15
+	defaultSourceMaxUploadMB         = 20
+)
+
+// Answer guardrail defaults. Observe-only by default so filters can be
+// tuned on live traffic before they are allowed to change answers.
+const (
+	defaultGuardrailsEnabled   = true
+	defaultGuardrailsMode      = "observe"
+	defaultToxicityThreshold   = 0.8
+	defaultToxicityTimeoutSecs = 3
+)
+
+// OpenAI-compatible facade defaults. Each model entry is
+// "name[:answer_length[:reading_level]]"; empty fields keep the
+// intent-driven default.
+var defaultOpenAICompatModels = []string{"alt-rag", "alt-rag-short:short", "alt-rag-basic::basic"}
+
+// Model routing defaults. Off by default: every query uses the Augur
+// model until a routing table is configured.
+const defaultModelRoutingEnabled = false
+
+// Index maintenance defaults.
+const (
+	defaultOrphanReconcileIntervalMinutes = 360
+	defaultDeletionSyncIntervalMinutes    = 5
+	defaultDeletionSyncLookbackHours      = 72
+)
+
+// Article event consumer defaults. Off by default: the consumer group has
+// to be provisioned on the stream before it is switched on.
+const (
+	defaultEventsEnabled            = false
+	defaultEventsRedisURL           = "redis://redis-streams:6379"
+	defaultEventsStream             = "alt:events:articles"
+	defaultEventsGroup              = "rag-orchestrator-group"
+	defaultEventsConsumer           = "rag-orchestrator-1"
+	defaultEventsDLQStream          = "alt:events:articles:rag-orchestrator:dlq"
+	defaultEventsBatchSize          = 20
+	defaultEventsMaxDeliveries      = 5
+	defaultEventsClaimIdleSeconds   = 60
+	defaultEventsLagIntervalSeconds = 30
+)
+
 // ServerConfig holds server-related settings.
 type ServerConfig struct {
 	Port        string
@@ -107,6 +168,12 @@ type EmbedderConfig struct {
 	// rejected — see internal/adapter/rag_http/handler.go. Defaults to the
 	// hyper-boost container's fixed origin (internal/backfill/hyperboost.go).
 	AllowedOverrideOrigins []string
+
+	// QueryCacheEnabled caches query-time embeddings so repeated queries
+	// skip the embedder call. Indexing is never cached.
+	QueryCacheEnabled bool
+	QueryCacheSize    int // Max entries
+	QueryCacheTTL     int // Minutes
 }
 
 // AugurConfig holds Knowledge Augur (LLM generator) settings.
@@ -120,6 +187,9 @@ type AugurConfig struct {
 type SearchConfig struct {
 	IndexerURL string
 	Timeout    int // Seconds
+	// APIKey is sent as X-API-Key on REST search calls. Required by
+	// search-indexer's plaintext listener outside development.
+	APIKey string
 }
 
 // QueryExpansionConfig holds query expansion settings.
@@ -143,6 +213,8 @@ type RAGConfig struct {
 	PromptVersion                    string
 	DynamicLanguageAllocationEnabled bool
 	MinAnswerLength                  int
+	QueryDecompositionEnabled        bool
+	QueryDecompositionMaxSubQueries  int
 }
 
 // QualityGateConfig holds retrieval quality gate settings.
@@ -162,6 +234,145 @@ type RerankConfig struct {
 	Timeout int // Seconds
 }
 
+// GuardrailsConfig holds the post-generation answer filter settings.
+type GuardrailsConfig struct {
+	Enabled       bool
+	Mode          string // "enforce" or "observe"; switchable at runtime via /internal/rag/guardrails
+	PIIRedaction  bool
+	InjectionEcho bool
+	// ToxicityURL is the classifier endpoint; empty disables the toxicity filter.
+	ToxicityURL       string
+	ToxicityThreshold float64
+	ToxicityTimeout   int // Seconds
+}
+
+func loadGuardrails() GuardrailsConfig {
+	cfg := GuardrailsConfig{
+		Enabled:           getEnvBool("RAG_GUARDRAILS_ENABLED", defaultGuardrailsEnabled),
+		Mode:              strings.ToLower(getEnv("RAG_GUARDRAILS_MODE", defaultGuardrailsMode)),
+		PIIRedaction:      getEnvBool("RAG_GUARDRAIL_PII_REDACTION", true),
+		InjectionEcho:     getEnvBool("RAG_GUARDRAIL_INJECTION_ECHO", true),
+		ToxicityURL:       getEnv("RAG_GUARDRAIL_TOXICITY_URL", ""),
+		ToxicityThreshold: getEnvFloat64("RAG_GUARDRAIL_TOXICITY_THRESHOLD", defaultToxicityThreshold),
+		ToxicityTimeout:   getEnvInt("RAG_GUARDRAIL_TOXICITY_TIMEOUT", defaultToxicityTimeoutSecs),
+	}
+	if cfg.Mode != "enforce" && cfg.Mode != "observe" {
+		panic(fmt.Sprintf("config: invalid RAG_GUARDRAILS_MODE %q (want \"enforce\" or \"observe\")", cfg.Mode))
+	}
+	if cfg.ToxicityThreshold <= 0 || cfg.ToxicityThreshold > 1 {
+		panic(fmt.Sprintf("config: RAG_GUARDRAIL_TOXICITY_THRESHOLD must be in (0,1], got %v", cfg.ToxicityThreshold))
+	}
+	return cfg
+}
+
+// OpenAICompatConfig holds the OpenAI-compatible /v1/chat/completions
+// facade settings.
+type OpenAICompatConfig struct {
+	Enabled bool
+	// APIKey, when set, must be sent as "Authorization: Bearer <key>".
+	APIKey string
+	// Models are the model names clients may request, in listing order.
+	Models []OpenAIModelConfig
+}
+
+// OpenAIModelConfig maps a client-facing model name to an answer style.
+type OpenAIModelConfig struct {
+	Name         string
+	AnswerLength string // "", "short", "medium" or "long"
+	ReadingLevel string // "", "basic", "general" or "expert"
+}
+
+func loadOpenAICompat() OpenAICompatConfig {
+	cfg := OpenAICompatConfig{
+		Enabled: getEnvBool("OPENAI_COMPAT_ENABLED", false),
+		APIKey:  optionalSecret("OPENAI_COMPAT_API_KEY", "OPENAI_COMPAT_API_KEY_FILE"),
+	}
+	seen := make(map[string]bool)
+	for _, entry := range getEnvCSV("OPENAI_COMPAT_MODELS", defaultOpenAICompatModels) {
+		parts := strings.Split(entry, ":")
+		if len(parts) > 3 {
+			panic(fmt.Sprintf("config: invalid OPENAI_COMPAT_MODELS entry %q (want name[:answer_length[:reading_level]])", entry))
+		}
+		parts = append(parts, "", "")
+		model := OpenAIModelConfig{
+			Name:         strings.TrimSpace(parts[0]),
+			AnswerLength: strings.ToLower(strings.TrimSpace(parts[1])),
+			ReadingLevel: strings.ToLower(strings.TrimSpace(parts[2])),
+		}
+		if model.Name == "" || seen[model.Name] {
+			panic(fmt.Sprintf("config: OPENAI_COMPAT_MODELS entry %q has an empty or duplicate name", entry))
+		}
+		switch model.AnswerLength {
+		case "", "short", "medium", "long":
+		default:
+			panic(fmt.Sprintf("config: invalid answer length %q in OPENAI_COMPAT_MODELS (want short, medium or long)", model.AnswerLength))
+		}
+		switch model.ReadingLevel {
+		case "", "basic", "general", "expert":
+		default:
+			panic(fmt.Sprintf("config: invalid reading level %q in OPENAI_COMPAT_MODELS (want basic, general or expert)", model.ReadingLevel))
+		}
+		seen[model.Name] = true
+		cfg.Models = append(cfg.Models, model)
+	}
+	if cfg.Enabled && len(cfg.Models) == 0 {
+		panic("config: OPENAI_COMPAT_ENABLED requires at least one OPENAI_COMPAT_MODELS entry")
+	}
+	return cfg
+}
+
+// ModelRoutingConfig holds the per-query-type generator routing table.
+type ModelRoutingConfig struct {
+	Enabled bool
+	// Routes maps query classes to models. Classes without an entry use
+	// the Augur model.
+	Routes []ModelRouteConfig
+}
+
+// ModelRouteConfig is one RAG_MODEL_ROUTES entry.
+type ModelRouteConfig struct {
+	Route           string // "factual", "summarization" or "open_ended"
+	Model           string
+	CostPer1KTokens float64
+}
+
+// loadModelRouting parses RAG_MODEL_ROUTES entries of the form
+// "route=model[@cost_per_1k_tokens]", e.g.
+// "factual=gemma3:4b-it-qat@0.2,open_ended=gemma4-e4b-12k@1".
+func loadModelRouting() ModelRoutingConfig {
+	cfg := ModelRoutingConfig{
+		Enabled: getEnvBool("RAG_MODEL_ROUTING_ENABLED", defaultModelRoutingEnabled),
+	}
+	seen := make(map[string]bool)
+	for _, entry := range getEnvCSV("RAG_MODEL_ROUTES", nil) {
+		route, target, ok := strings.Cut(entry, "=")
+		if !ok {
+			panic(fmt.Sprintf("config: invalid RAG_MODEL_ROUTES entry %q (want route=model[@cost_per_1k_tokens])", entry))
+		}
+		rc := ModelRouteConfig{Route: strings.ToLower(strings.TrimSpace(route))}
+		model, cost, hasCost := strings.Cut(target, "@")
+		rc.Model = strings.TrimSpace(model)
+		if hasCost {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(cost), 64)
+			if err != nil || parsed < 0 {
+				panic(fmt.Sprintf("config: invalid cost %q in RAG_MODEL_ROUTES entry %q", cost, entry))
+			}
+			rc.CostPer1KTokens = parsed
+		}
+		switch rc.Route {
+		case "factual", "summarization", "open_ended":
+		default:
+			panic(fmt.Sprintf("config: invalid route %q in RAG_MODEL_ROUTES (want factual, summarization or open_ended)", rc.Route))
+		}
+		if rc.Model == "" || seen[rc.Route] {
+			panic(fmt.Sprintf("config: RAG_MODEL_ROUTES entry %q has an empty model or duplicate route", entry))
+		}
+		seen[rc.Route] = true
+		cfg.Routes = append(cfg.Routes, rc)
+	}
+	return cfg
+}
+
 // HybridConfig holds hybrid search (BM25+vector) settings.
 type HybridConfig struct {
 	Enabled    bool
@@ -191,6 +402,62 @@ type CacheConfig struct {
 	TTL  int // Minutes
 }
 
+// SourcesConfig configures indexing of non-article sources.
+type SourcesConfig struct {
+	// UploadDir, when set, is scanned for PDF/Markdown/text files that are
+	// indexed as upload documents. Empty disables the directory sync.
+	UploadDir           string
+	SyncIntervalMinutes int
+	// MaxUploadMB bounds both API uploads and files picked up from UploadDir.
+	MaxUploadMB int
+}
+
+// MaintenanceConfig configures background index maintenance.
+type MaintenanceConfig struct {
+	// OrphanReconcileIntervalMinutes is how often chunks of superseded
+	// versions are deleted. 0 disables the periodic run; the reconcile
+	// endpoint still works.
+	OrphanReconcileIntervalMinutes int
+	// DeletionSyncIntervalMinutes is how often articles deleted in
+	// alt-backend are tombstoned. 0 disables the sync.
+	DeletionSyncIntervalMinutes int
+	// DeletionSyncLookbackHours is how far back the deletion sync replays
+	// the audit trail after a restart.
+	DeletionSyncLookbackHours int
+}
+
+// UsageConfig configures the per-user daily budget alarms on request usage.
+// Usage is always recorded; a zero limit only disables its alarm.
+type UsageConfig struct {
+	// DailyTokenLimit is the prompt plus completion tokens a user may use
+	// per UTC day before an alarm is raised.
+	DailyTokenLimit int
+	// DailyRequestLimit is the answer requests a user may make per UTC day
+	// before an alarm is raised.
+	DailyRequestLimit int
+}
+
+// EventsConfig configures indexing from alt-backend's article events,
+// read from the Redis Stream mq-hub publishes them to.
+type EventsConfig struct {
+	Enabled  bool
+	RedisURL string
+	Stream   string
+	// Group must already exist on Stream; it is provisioned with mq-hub
+	// CreateConsumerGroup or XGROUP CREATE, never by the consumer.
+	Group     string
+	Consumer  string
+	DLQStream string
+	BatchSize int
+	// MaxDeliveries is how often a failing event is tried before it is
+	// dead-lettered. 0 retries forever.
+	MaxDeliveries int
+	// ClaimIdleSeconds is how long a failed or abandoned event stays
+	// pending before it is retried.
+	ClaimIdleSeconds   int
+	LagIntervalSeconds int
+}
+
 // PeerIdentityMode selects how the Connect-RPC listener authenticates its
 // callers. It is a required setting: "disabled" must be an explicit operator
 // choice, never inferred from an unset variable (CLAUDE.md rules 8/9).
@@ -259,10 +526,17 @@ type Config struct {
 	RAG            RAGConfig
 	QualityGate    QualityGateConfig
 	Rerank         RerankConfig
+	Guardrails     GuardrailsConfig
+	OpenAICompat   OpenAICompatConfig
+	ModelRouting   ModelRoutingConfig
 	Hybrid         HybridConfig
 	Temporal       TemporalConfig
 	Backend        BackendConfig
 	Cache          CacheConfig
+	Sources        SourcesConfig
+	Maintenance    MaintenanceConfig
+	Usage          UsageConfig
+	Events         EventsConfig
 	PeerIdentity   PeerIdentityConfig
 }
 
@@ -289,6 +563,9 @@ func Load() *Config {
 			Model:                  getEnv("EMBEDDING_MODEL", "embeddinggemma"),
 			Timeout:                getEnvInt("EMBEDDER_TIMEOUT", 30),
 			AllowedOverrideOrigins: getEnvCSV("RAG_EMBEDDER_ALLOWED_OVERRIDE_URLS", []string{"http://backfill-hyperboost:11434"}),
+			QueryCacheEnabled:      getEnvBool("RAG_EMBEDDING_CACHE_ENABLED", defaultEmbeddingCacheEnabled),
+			QueryCacheSize:         getEnvInt("RAG_EMBEDDING_CACHE_SIZE", defaultEmbeddingCacheSize),
+			QueryCacheTTL:          getEnvInt("RAG_EMBEDDING_CACHE_TTL_MINUTES", defaultEmbeddingCacheTTL),
 		},
 		Augur: AugurConfig{
 			URL:     getEnvWithAlt("AUGUR_EXTERNAL", "AUGUR_EXTERNAL_URL", "http://news-creator-backend:11435"),
@@ -298,6 +575,7 @@ func Load() *Config {
 		Search: SearchConfig{
 			IndexerURL: getEnv("SEARCH_INDEXER_URL", "http://search-indexer:8080"),
 			Timeout:    getEnvInt("SEARCH_INDEXER_TIMEOUT", 10),
+			APIKey:     optionalSecret("SEARCH_INDEXER_API_KEY", "SEARCH_INDEXER_API_KEY_FILE"),
 		},
 		QueryExpansion: QueryExpansionConfig{
 			URL:            getEnv("QUERY_EXPANSION_URL", "http://news-creator:11434"),
@@ -317,6 +595,8 @@ func Load() *Config {
 			PromptVersion:                    getEnv("RAG_PROMPT_VERSION", "alpha-v1"),
 			DynamicLanguageAllocationEnabled: getEnvBool("RAG_DYNAMIC_LANGUAGE_ALLOCATION", defaultDynamicLanguageAllocationEnabled),
 			MinAnswerLength:                  getEnvInt("RAG_MIN_ANSWER_LENGTH", defaultRAGMinAnswerLength),
+			QueryDecompositionEnabled:        getEnvBool("RAG_QUERY_DECOMPOSITION_ENABLED", defaultQueryDecompositionEnabled),
+			QueryDecompositionMaxSubQueries:  getEnvInt("RAG_QUERY_DECOMPOSITION_MAX_SUBQUERIES", defaultQueryDecompositionMaxSubQueries),
 		},
 		QualityGate: QualityGateConfig{
 			Enabled:           getEnvBool("RAG_QUALITY_GATE_ENABLED", true),
@@ -331,6 +611,9 @@ func Load() *Config {
 			TopK:    getEnvInt("RERANK_TOP_K", defaultRerankTopK),
 			Timeout: getEnvInt("RERANK_TIMEOUT", defaultRerankTimeout),
 		},
+		Guardrails:   loadGuardrails(),
+		OpenAICompat: loadOpenAICompat(),
+		ModelRouting: loadModelRouting(),
 		Hybrid: HybridConfig{
 			Enabled:    getEnvBool("HYBRID_SEARCH_ENABLED", defaultHybridSearchEnabled),
 			Alpha:      getEnvFloat64("HYBRID_ALPHA", defaultHybridAlpha),
@@ -351,6 +634,32 @@ func Load() *Config {
 			Size: getEnvInt("RAG_CACHE_SIZE", defaultCacheSize),
 			TTL:  getEnvInt("RAG_CACHE_TTL_MINUTES", defaultCacheTTL),
 		},
+		Sources: SourcesConfig{
+			UploadDir:           getEnv("RAG_SOURCE_UPLOAD_DIR", ""),
+			SyncIntervalMinutes: getEnvInt("RAG_SOURCE_SYNC_INTERVAL_MINUTES", defaultSourceSyncIntervalMinutes),
+			MaxUploadMB:         getEnvInt("RAG_SOURCE_MAX_UPLOAD_MB", defaultSourceMaxUploadMB),
+		},
+		Maintenance: MaintenanceConfig{
+			OrphanReconcileIntervalMinutes: getEnvInt("RAG_ORPHAN_RECONCILE_INTERVAL_MINUTES", defaultOrphanReconcileIntervalMinutes),
+			DeletionSyncIntervalMinutes:    getEnvInt("RAG_DELETION_SYNC_INTERVAL_MINUTES", defaultDeletionSyncIntervalMinutes),
+			DeletionSyncLookbackHours:      getEnvInt("RAG_DELETION_SYNC_LOOKBACK_HOURS", defaultDeletionSyncLookbackHours),
+		},
+		Usage: UsageConfig{
+			DailyTokenLimit:   getEnvInt("RAG_USAGE_DAILY_TOKEN_LIMIT", 0),
+			DailyRequestLimit: getEnvInt("RAG_USAGE_DAILY_REQUEST_LIMIT", 0),
+		},
+		Events: EventsConfig{
+			Enabled:            getEnvBool("RAG_EVENTS_ENABLED", defaultEventsEnabled),
+			RedisURL:           getEnv("RAG_EVENTS_REDIS_URL", defaultEventsRedisURL),
+			Stream:             getEnv("RAG_EVENTS_STREAM", defaultEventsStream),
+			Group:              getEnv("RAG_EVENTS_GROUP", defaultEventsGroup),
+			Consumer:           getEnv("RAG_EVENTS_CONSUMER", defaultEventsConsumer),
+			DLQStream:          getEnv("RAG_EVENTS_DLQ_STREAM", defaultEventsDLQStream),
+			BatchSize:          getEnvInt("RAG_EVENTS_BATCH_SIZE", defaultEventsBatchSize),
+			MaxDeliveries:      getEnvInt("RAG_EVENTS_MAX_DELIVERIES", defaultEventsMaxDeliveries),
+			ClaimIdleSeconds:   getEnvInt("RAG_EVENTS_CLAIM_IDLE_SECONDS", defaultEventsClaimIdleSeconds),
+			LagIntervalSeconds: getEnvInt("RAG_EVENTS_LAG_INTERVAL_SECONDS", defaultEventsLagIntervalSeconds),
+		},
 		PeerIdentity: loadPeerIdentity(),
 	}
 }
@@ -380,6 +689,21 @@ func requireSecret(envKey, fileEnvKey string) string {
 	panic(fmt.Sprintf("config: %s or %s must be set (no fallback permitted)", envKey, fileEnvKey))
 }
 
+// optionalSecret is requireSecret for secrets a deployment may omit: it
+// returns "" instead of panicking when neither variable is set.
+func optionalSecret(envKey, fileEnvKey string) string {
+	if value, ok := os.LookupEnv(envKey); ok {
+		return value
+	}
+	if filePath, ok := os.LookupEnv(fileEnvKey); ok {
+		content, err := os.ReadFile(filePath) //nolint:gosec // G304: path from trusted env var
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+	}
+	return ""
+}
+
 func getEnvWithAlt(key, altKey, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
@@ -134,6 +134,48 @@ func TestLoad_TemporalBoostParameters_FromEnv(t *testing.T) {
 	assert.Equal(t, float32(1.1), cfg.Temporal.Boost18h)
 }
 
+func TestLoad_Sources(t *testing.T) {
+	_ = os.Unsetenv("RAG_SOURCE_UPLOAD_DIR")
+	_ = os.Unsetenv("RAG_SOURCE_SYNC_INTERVAL_MINUTES")
+	_ = os.Unsetenv("RAG_SOURCE_MAX_UPLOAD_MB")
+
+	cfg := Load()
+	assert.Empty(t, cfg.Sources.UploadDir)
+	assert.Equal(t, 15, cfg.Sources.SyncIntervalMinutes)
+	assert.Equal(t, 20, cfg.Sources.MaxUploadMB)
+
+	t.Setenv("RAG_SOURCE_UPLOAD_DIR", "/data/uploads")
+	t.Setenv("RAG_SOURCE_SYNC_INTERVAL_MINUTES", "5")
+	t.Setenv("RAG_SOURCE_MAX_UPLOAD_MB", "50")
+
+	cfg = Load()
+	assert.Equal(t, "/data/uploads", cfg.Sources.UploadDir)
+	assert.Equal(t, 5, cfg.Sources.SyncIntervalMinutes)
+	assert.Equal(t, 50, cfg.Sources.MaxUploadMB)
+}
+
+func TestLoad_Events(t *testing.T) {
+	_ = os.Unsetenv("RAG_EVENTS_ENABLED")
+	_ = os.Unsetenv("RAG_EVENTS_GROUP")
+	_ = os.Unsetenv("RAG_EVENTS_MAX_DELIVERIES")
+
+	cfg := Load()
+	assert.False(t, cfg.Events.Enabled)
+	assert.Equal(t, "alt:events:articles", cfg.Events.Stream)
+	assert.Equal(t, "rag-orchestrator-group", cfg.Events.Group)
+	assert.Equal(t, "alt:events:articles:rag-orchestrator:dlq", cfg.Events.DLQStream)
+	assert.Equal(t, 5, cfg.Events.MaxDeliveries)
+
+	t.Setenv("RAG_EVENTS_ENABLED", "true")
+	t.Setenv("RAG_EVENTS_GROUP", "rag-orchestrator-staging")
+	t.Setenv("RAG_EVENTS_MAX_DELIVERIES", "0")
+
+	cfg = Load()
+	assert.True(t, cfg.Events.Enabled)
+	assert.Equal(t, "rag-orchestrator-staging", cfg.Events.Group)
+	assert.Equal(t, 0, cfg.Events.MaxDeliveries)
+}
+
 func TestGetEnvFloat64(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -322,6 +364,20 @@ func TestLoad_CacheConfig_Defaults(t *testing.T) {
 	assert.Equal(t, 10, cfg.Cache.TTL)
 }
 
+func TestLoad_EmbeddingCacheConfig(t *testing.T) {
+	_ = os.Unsetenv("RAG_EMBEDDING_CACHE_ENABLED")
+	_ = os.Unsetenv("RAG_EMBEDDING_CACHE_SIZE")
+	_ = os.Unsetenv("RAG_EMBEDDING_CACHE_TTL_MINUTES")
+
+	cfg := Load()
+	assert.True(t, cfg.Embedder.QueryCacheEnabled)
+	assert.Equal(t, 1024, cfg.Embedder.QueryCacheSize)
+	assert.Equal(t, 60, cfg.Embedder.QueryCacheTTL)
+
+	t.Setenv("RAG_EMBEDDING_CACHE_ENABLED", "false")
+	assert.False(t, Load().Embedder.QueryCacheEnabled)
+}
+
 func TestLoad_LLMBackend_DefaultAndFromEnv(t *testing.T) {
 	_ = os.Unsetenv("LLM_BACKEND")
 
@@ -332,3 +388,82 @@ func TestLoad_LLMBackend_DefaultAndFromEnv(t *testing.T) {
 	cfg = Load()
 	assert.Equal(t, "eino", cfg.LLMBackend)
 }
+
+func TestLoad_Guardrails_DefaultsToObserve(t *testing.T) {
+	unsetEnv(t, "RAG_GUARDRAILS_MODE")
+	unsetEnv(t, "RAG_GUARDRAIL_TOXICITY_URL")
+
+	cfg := Load()
+
+	assert.True(t, cfg.Guardrails.Enabled)
+	assert.Equal(t, "observe", cfg.Guardrails.Mode)
+	assert.True(t, cfg.Guardrails.PIIRedaction)
+	assert.Empty(t, cfg.Guardrails.ToxicityURL, "toxicity filter is off until a classifier is configured")
+}
+
+func TestLoad_Guardrails_InvalidValuesPanic(t *testing.T) {
+	t.Setenv("RAG_GUARDRAILS_MODE", "block-everything")
+	assert.Panics(t, func() { Load() })
+
+	t.Setenv("RAG_GUARDRAILS_MODE", "Enforce")
+	t.Setenv("RAG_GUARDRAIL_TOXICITY_THRESHOLD", "1.5")
+	assert.Panics(t, func() { Load() })
+
+	t.Setenv("RAG_GUARDRAIL_TOXICITY_THRESHOLD", "0.7")
+	assert.Equal(t, "enforce", Load().Guardrails.Mode)
+}
+
+func TestLoad_OpenAICompat_DefaultsAndModelMapping(t *testing.T) {
+	unsetEnv(t, "OPENAI_COMPAT_ENABLED")
+	unsetEnv(t, "OPENAI_COMPAT_MODELS")
+
+	cfg := Load()
+	assert.False(t, cfg.OpenAICompat.Enabled)
+	assert.Equal(t, []OpenAIModelConfig{
+		{Name: "alt-rag"},
+		{Name: "alt-rag-short", AnswerLength: "short"},
+		{Name: "alt-rag-basic", ReadingLevel: "basic"},
+	}, cfg.OpenAICompat.Models)
+
+	t.Setenv("OPENAI_COMPAT_MODELS", "gpt-4o:long:Expert, mini:short")
+	assert.Equal(t, []OpenAIModelConfig{
+		{Name: "gpt-4o", AnswerLength: "long", ReadingLevel: "expert"},
+		{Name: "mini", AnswerLength: "short"},
+	}, Load().OpenAICompat.Models)
+}
+
+func TestLoad_OpenAICompat_InvalidModelsPanic(t *testing.T) {
+	for _, models := range []string{"alt-rag:huge", "alt-rag::child", "a:short:basic:x", ":short", "a,a"} {
+		t.Setenv("OPENAI_COMPAT_MODELS", models)
+		assert.Panics(t, func() { Load() }, models)
+	}
+
+	t.Setenv("OPENAI_COMPAT_ENABLED", "true")
+	t.Setenv("OPENAI_COMPAT_MODELS", "")
+	assert.Panics(t, func() { Load() })
+}
+
+func TestLoad_ModelRouting(t *testing.T) {
+	unsetEnv(t, "RAG_MODEL_ROUTING_ENABLED")
+	unsetEnv(t, "RAG_MODEL_ROUTES")
+
+	cfg := Load()
+	assert.False(t, cfg.ModelRouting.Enabled)
+	assert.Empty(t, cfg.ModelRouting.Routes)
+
+	t.Setenv("RAG_MODEL_ROUTING_ENABLED", "true")
+	t.Setenv("RAG_MODEL_ROUTES", "factual=gemma3:4b-it-qat@0.2, Open_Ended=gemma4-e4b-12k")
+	cfg = Load()
+	assert.True(t, cfg.ModelRouting.Enabled)
+	assert.Equal(t, []ModelRouteConfig{
+		{Route: "factual", Model: "gemma3:4b-it-qat", CostPer1KTokens: 0.2},
+		{Route: "open_ended", Model: "gemma4-e4b-12k"},
+	}, cfg.ModelRouting.Routes)
+}
+
+func TestLoad_ModelRouting_InvalidRoutesPanic(t *testing.T) {
+	for _, routes := range []string{"factual", "chitchat=m", "factual=", "factual=m@free", "factual=m@-1", "factual=a,factual=b"} {
+		t.Setenv("RAG_MODEL_ROUTES", routes)
+		assert.Panics(t, func() { Load() }, routes)
+	}
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// maxFeedbackCommentRunes bounds free-text comments so the export stays a
+// reviewable size.
+const maxFeedbackCommentRunes = 2000
+
+var (
+	// ErrAnswerNotFound is returned when feedback targets an answer that was
+	// never recorded (or has been purged).
+	ErrAnswerNotFound = errors.New("answer not found")
+	// ErrInvalidFeedback is returned for malformed feedback submissions.
+	ErrInvalidFeedback = errors.New("invalid feedback")
+)
+
+// SubmitFeedbackInput is a reader's verdict on one answer.
+type SubmitFeedbackInput struct {
+	AnswerID        uuid.UUID
+	Rating          domain.FeedbackRating
+	Comment         string
+	HelpfulChunkIDs []string
+}
+
+// AnswerFeedbackUsecase records answer snapshots and the feedback readers
+// leave on them, and exports both for the eval harness.
+type AnswerFeedbackUsecase interface {
+	// RecordAnswer snapshots a generated answer so feedback can reference it.
+	// Fallbacks and empty answers are skipped: there is nothing to rate.
+	RecordAnswer(ctx context.Context, input AnswerWithRAGInput, output *AnswerWithRAGOutput) error
+
+	SubmitFeedback(ctx context.Context, input SubmitFeedbackInput) (*domain.AnswerFeedback, error)
+
+	// ExportFeedback pages through feedback created at or after since.
+	ExportFeedback(ctx context.Context, since time.Time, afterCreatedAt *time.Time, afterID *uuid.UUID, limit int) ([]domain.AnswerFeedbackExport, error)
+}
+
+type answerFeedbackUsecase struct {
+	repo  domain.AnswerFeedbackRepository
+	clock func() time.Time
+}
+
+// NewAnswerFeedbackUsecase wires a repository into the usecase. clock may be
+// nil to use time.Now.
+func NewAnswerFeedbackUsecase(repo domain.AnswerFeedbackRepository, clock func() time.Time) AnswerFeedbackUsecase {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &answerFeedbackUsecase{repo: repo, clock: clock}
+}
+
+func (u *answerFeedbackUsecase) RecordAnswer(ctx context.Context, input AnswerWithRAGInput, output *AnswerWithRAGOutput) error {
+	if output == nil || output.Fallback || strings.TrimSpace(output.Answer) == "" {
+		return nil
+	}
+	id, err := uuid.Parse(output.Debug.RetrievalSetID)
+	if err != nil {
+		return fmt.Errorf("record answer: retrieval set id %q is not a uuid: %w", output.Debug.RetrievalSetID, err)
+	}
+
+	record := &domain.AnswerRecord{
+		ID:            id,
+		Query:         input.Query,
+		Answer:        output.Answer,
+		PromptVersion: output.Debug.PromptVersion,
+		StrategyUsed:  output.Debug.StrategyUsed,
+		IntentType:    output.Debug.IntentType,
+		Chunks:        make([]domain.AnswerChunk, 0, len(output.Contexts)),
+		Citations:     make([]domain.AnswerChunk, 0, len(output.Citations)),
+		CreatedAt:     u.clock().UTC(),
+	}
+	for _, c := range output.Contexts {
+		record.Chunks = append(record.Chunks, domain.AnswerChunk{
+			ChunkID:         c.ChunkID.String(),
+			ArticleID:       c.ArticleID,
+			Title:           c.Title,
+			URL:             c.URL,
+			Text:            c.ChunkText,
+			Score:           c.Score,
+			DocumentVersion: c.DocumentVersion,
+		})
+	}
+	for _, c := range output.Citations {
+		record.Citations = append(record.Citations, domain.AnswerChunk{
+			ChunkID:         c.ChunkID,
+			ArticleID:       c.ArticleID,
+			Title:           c.Title,
+			URL:             c.URL,
+			Text:            c.ChunkText,
+			Score:           c.Score,
+			DocumentVersion: c.DocumentVersion,
+		})
+	}
+
+	if err := u.repo.SaveAnswer(ctx, record); err != nil {
+		return fmt.Errorf("record answer: %w", err)
+	}
+	return nil
+}
+
+func (u *answerFeedbackUsecase) SubmitFeedback(ctx context.Context, input SubmitFeedbackInput) (*domain.AnswerFeedback, error) {
+	if !input.Rating.Valid() {
+		return nil, fmt.Errorf("%w: rating must be %q or %q", ErrInvalidFeedback, domain.FeedbackRatingUp, domain.FeedbackRatingDown)
+	}
+	comment := strings.TrimSpace(input.Comment)
+	if utf8.RuneCountInString(comment) > maxFeedbackCommentRunes {
+		return nil, fmt.Errorf("%w: comment exceeds %d characters", ErrInvalidFeedback, maxFeedbackCommentRunes)
+	}
+
+	answer, err := u.repo.GetAnswer(ctx, input.AnswerID)
+	if err != nil {
+		return nil, fmt.Errorf("submit feedback: %w", err)
+	}
+	if answer == nil {
+		return nil, ErrAnswerNotFound
+	}
+
+	// Only chunks the answer actually saw can be "helpful"; anything else
+	// would poison the regression cases built from this feedback.
+	helpful := make([]string, 0, len(input.HelpfulChunkIDs))
+	seen := make(map[string]struct{}, len(input.HelpfulChunkIDs))
+	for _, id := range input.HelpfulChunkIDs {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		if !answer.HasChunk(id) {
+			return nil, fmt.Errorf("%w: chunk %q was not part of the answer", ErrInvalidFeedback, id)
+		}
+		seen[id] = struct{}{}
+		helpful = append(helpful, id)
+	}
+
+	feedback := &domain.AnswerFeedback{
+		ID:              uuid.New(),
+		AnswerID:        answer.ID,
+		Rating:          input.Rating,
+		Comment:         comment,
+		HelpfulChunkIDs: helpful,
+		CreatedAt:       u.clock().UTC(),
+	}
+	if err := u.repo.AppendFeedback(ctx, feedback); err != nil {
+		return nil, fmt.Errorf("submit feedback: %w", err)
+	}
+	return feedback, nil
+}
+
+func (u *answerFeedbackUsecase) ExportFeedback(ctx context.Context, since time.Time, afterCreatedAt *time.Time, afterID *uuid.UUID, limit int) ([]domain.AnswerFeedbackExport, error) {
+	items, err := u.repo.ListFeedback(ctx, since, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("export feedback: %w", err)
+	}
+	return items, nil
+}
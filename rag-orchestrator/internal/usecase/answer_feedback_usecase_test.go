@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAnswerFeedbackRepo mirrors the postgres contract: answers are
+// write-once (duplicate IDs ignored), feedback is append-only.
+type fakeAnswerFeedbackRepo struct {
+	mu       sync.Mutex
+	answers  map[uuid.UUID]domain.AnswerRecord
+	feedback []domain.AnswerFeedback
+}
+
+func newFakeAnswerFeedbackRepo() *fakeAnswerFeedbackRepo {
+	return &fakeAnswerFeedbackRepo{answers: map[uuid.UUID]domain.AnswerRecord{}}
+}
+
+func (f *fakeAnswerFeedbackRepo) SaveAnswer(_ context.Context, a *domain.AnswerRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, exists := f.answers[a.ID]; !exists {
+		f.answers[a.ID] = *a
+	}
+	return nil
+}
+
+func (f *fakeAnswerFeedbackRepo) GetAnswer(_ context.Context, id uuid.UUID) (*domain.AnswerRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	a, ok := f.answers[id]
+	if !ok {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+func (f *fakeAnswerFeedbackRepo) AppendFeedback(_ context.Context, fb *domain.AnswerFeedback) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.feedback = append(f.feedback, *fb)
+	return nil
+}
+
+func (f *fakeAnswerFeedbackRepo) ListFeedback(_ context.Context, since time.Time, _ *time.Time, _ *uuid.UUID, _ int) ([]domain.AnswerFeedbackExport, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []domain.AnswerFeedbackExport
+	for _, fb := range f.feedback {
+		if fb.CreatedAt.Before(since) {
+			continue
+		}
+		out = append(out, domain.AnswerFeedbackExport{Feedback: fb, Answer: f.answers[fb.AnswerID]})
+	}
+	return out, nil
+}
+
+func sampleAnswerOutput(retrievalSetID string, chunkID uuid.UUID) *AnswerWithRAGOutput {
+	return &AnswerWithRAGOutput{
+		Answer: "Rust 1.80 stabilised LazyCell.",
+		Contexts: []ContextItem{
+			{ChunkID: chunkID, ChunkText: "LazyCell is now stable", Title: "Rust 1.80", URL: "https://blog.rust-lang.org", Score: 0.9, ArticleID: "art-1"},
+		},
+		Citations: []Citation{
+			{ChunkID: chunkID.String(), ChunkText: "LazyCell is now stable", Title: "Rust 1.80", URL: "https://blog.rust-lang.org", Score: 0.9, ArticleID: "art-1"},
+		},
+		Debug: AnswerDebug{RetrievalSetID: retrievalSetID, PromptVersion: "alpha-v2", StrategyUsed: "general"},
+	}
+}
+
+func TestAnswerFeedbackUsecase_RecordAnswer(t *testing.T) {
+	repo := newFakeAnswerFeedbackRepo()
+	fixed := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	uc := NewAnswerFeedbackUsecase(repo, func() time.Time { return fixed })
+
+	answerID := uuid.New()
+	chunkID := uuid.New()
+	err := uc.RecordAnswer(context.Background(), AnswerWithRAGInput{Query: "what changed in rust 1.80"}, sampleAnswerOutput(answerID.String(), chunkID))
+	require.NoError(t, err)
+
+	rec, ok := repo.answers[answerID]
+	require.True(t, ok)
+	assert.Equal(t, "what changed in rust 1.80", rec.Query)
+	assert.Equal(t, "alpha-v2", rec.PromptVersion)
+	assert.Equal(t, fixed, rec.CreatedAt)
+	require.Len(t, rec.Chunks, 1)
+	assert.Equal(t, chunkID.String(), rec.Chunks[0].ChunkID)
+	assert.Equal(t, "LazyCell is now stable", rec.Chunks[0].Text)
+	require.Len(t, rec.Citations, 1)
+}
+
+func TestAnswerFeedbackUsecase_RecordAnswer_SkipsFallback(t *testing.T) {
+	repo := newFakeAnswerFeedbackRepo()
+	uc := NewAnswerFeedbackUsecase(repo, nil)
+
+	out := sampleAnswerOutput(uuid.NewString(), uuid.New())
+	out.Fallback = true
+	require.NoError(t, uc.RecordAnswer(context.Background(), AnswerWithRAGInput{Query: "q"}, out))
+	require.NoError(t, uc.RecordAnswer(context.Background(), AnswerWithRAGInput{Query: "q"}, nil))
+
+	assert.Empty(t, repo.answers)
+}
+
+func TestAnswerFeedbackUsecase_RecordAnswer_RejectsNonUUIDRetrievalSet(t *testing.T) {
+	uc := NewAnswerFeedbackUsecase(newFakeAnswerFeedbackRepo(), nil)
+
+	err := uc.RecordAnswer(context.Background(), AnswerWithRAGInput{Query: "q"}, sampleAnswerOutput("not-a-uuid", uuid.New()))
+	assert.Error(t, err)
+}
+
+func TestAnswerFeedbackUsecase_SubmitFeedback(t *testing.T) {
+	repo := newFakeAnswerFeedbackRepo()
+	uc := NewAnswerFeedbackUsecase(repo, nil)
+	answerID := uuid.New()
+	chunkID := uuid.New()
+	require.NoError(t, uc.RecordAnswer(context.Background(), AnswerWithRAGInput{Query: "q"}, sampleAnswerOutput(answerID.String(), chunkID)))
+
+	fb, err := uc.SubmitFeedback(context.Background(), SubmitFeedbackInput{
+		AnswerID:        answerID,
+		Rating:          domain.FeedbackRatingUp,
+		Comment:         "  spot on  ",
+		HelpfulChunkIDs: []string{chunkID.String(), chunkID.String()},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "spot on", fb.Comment)
+	assert.Equal(t, []string{chunkID.String()}, fb.HelpfulChunkIDs)
+	require.Len(t, repo.feedback, 1)
+
+	items, err := uc.ExportFeedback(context.Background(), time.Time{}, nil, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "q", items[0].Answer.Query)
+}
+
+func TestAnswerFeedbackUsecase_SubmitFeedback_Errors(t *testing.T) {
+	repo := newFakeAnswerFeedbackRepo()
+	uc := NewAnswerFeedbackUsecase(repo, nil)
+	answerID := uuid.New()
+	require.NoError(t, uc.RecordAnswer(context.Background(), AnswerWithRAGInput{Query: "q"}, sampleAnswerOutput(answerID.String(), uuid.New())))
+
+	tests := []struct {
+		name    string
+		input   SubmitFeedbackInput
+		wantErr error
+	}{
+		{name: "unknown rating", input: SubmitFeedbackInput{AnswerID: answerID, Rating: "meh"}, wantErr: ErrInvalidFeedback},
+		{name: "unknown answer", input: SubmitFeedbackInput{AnswerID: uuid.New(), Rating: domain.FeedbackRatingDown}, wantErr: ErrAnswerNotFound},
+		{name: "foreign chunk", input: SubmitFeedbackInput{AnswerID: answerID, Rating: domain.FeedbackRatingUp, HelpfulChunkIDs: []string{uuid.NewString()}}, wantErr: ErrInvalidFeedback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.SubmitFeedback(context.Background(), tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+	assert.Empty(t, repo.feedback)
+}
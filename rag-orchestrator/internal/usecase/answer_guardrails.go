@@ -0,0 +1,327 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"rag-orchestrator/internal/domain"
+)
+
+// GuardrailMode controls whether the answer guardrails change answers.
+type GuardrailMode string
+
+const (
+	// GuardrailModeEnforce applies redactions and replaces blocked answers
+	// with a fallback.
+	GuardrailModeEnforce GuardrailMode = "enforce"
+	// GuardrailModeObserve runs every filter and records what it would have
+	// done, but returns the answer untouched. Used to tune filters on live
+	// traffic before enforcing them.
+	GuardrailModeObserve GuardrailMode = "observe"
+)
+
+// ParseGuardrailMode validates a mode string from config or the admin API.
+func ParseGuardrailMode(s string) (GuardrailMode, error) {
+	switch mode := GuardrailMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case GuardrailModeEnforce, GuardrailModeObserve:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown guardrail mode %q (want enforce or observe)", s)
+	}
+}
+
+// GuardrailAction is what a single filter decided about an answer.
+type GuardrailAction string
+
+const (
+	GuardrailActionPass   GuardrailAction = "pass"
+	GuardrailActionRedact GuardrailAction = "redact"
+	GuardrailActionBlock  GuardrailAction = "block"
+	// GuardrailActionError means the filter could not reach a verdict (e.g.
+	// the classifier was down). The answer is let through.
+	GuardrailActionError GuardrailAction = "error"
+)
+
+// FallbackGuardrailBlocked is the fallback category for answers a
+// guardrail filter blocked in enforce mode.
+const FallbackGuardrailBlocked FallbackCategory = "guardrail_blocked"
+
+// AnswerFilterResult is one filter's verdict.
+type AnswerFilterResult struct {
+	Action GuardrailAction
+	// Answer is the rewritten answer when Action is GuardrailActionRedact.
+	Answer string
+	// Detail says what matched, without echoing the matched text itself.
+	Detail string
+}
+
+// AnswerFilter inspects a generated answer after validation.
+type AnswerFilter interface {
+	Name() string
+	Filter(ctx context.Context, answer string) AnswerFilterResult
+}
+
+// GuardrailOutcome records one filter's verdict on one answer.
+type GuardrailOutcome struct {
+	Filter string
+	Action GuardrailAction
+	Detail string
+	// Enforced is false when the chain ran in observe mode, so the action
+	// was recorded but not applied.
+	Enforced bool
+}
+
+// GuardrailRecorder receives every filter outcome, e.g. for Prometheus.
+type GuardrailRecorder interface {
+	RecordGuardrailOutcome(filter, action, mode string)
+}
+
+// GuardrailResult is the chain's verdict on an answer.
+type GuardrailResult struct {
+	// Answer is the text to return: redacted in enforce mode, the input
+	// unchanged in observe mode.
+	Answer   string
+	Blocked  bool
+	Reason   string
+	Outcomes []GuardrailOutcome
+}
+
+// GuardrailChain runs the configured filters over every generated answer.
+// The mode can be switched at runtime through the admin API.
+type GuardrailChain struct {
+	filters  []AnswerFilter
+	mode     atomic.Value // GuardrailMode
+	recorder GuardrailRecorder
+	logger   *slog.Logger
+}
+
+// NewGuardrailChain builds a chain that runs filters in order. recorder may
+// be nil.
+func NewGuardrailChain(mode GuardrailMode, recorder GuardrailRecorder, logger *slog.Logger, filters ...AnswerFilter) *GuardrailChain {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	c := &GuardrailChain{filters: filters, recorder: recorder, logger: logger}
+	c.mode.Store(mode)
+	return c
+}
+
+// Mode returns the current mode.
+func (c *GuardrailChain) Mode() GuardrailMode {
+	return c.mode.Load().(GuardrailMode)
+}
+
+// SetMode switches between enforce and observe for subsequent answers.
+func (c *GuardrailChain) SetMode(mode GuardrailMode) {
+	previous := c.Mode()
+	c.mode.Store(mode)
+	if previous != mode {
+		c.logger.Info("guardrail_mode_changed",
+			slog.String("from", string(previous)),
+			slog.String("to", string(mode)))
+	}
+}
+
+// FilterNames lists the configured filters in the order they run.
+func (c *GuardrailChain) FilterNames() []string {
+	names := make([]string, len(c.filters))
+	for i, f := range c.filters {
+		names[i] = f.Name()
+	}
+	return names
+}
+
+// Apply runs every filter over answer. Each filter sees the previous
+// filters' redactions, so observe mode records the same outcomes enforce
+// mode would produce. In enforce mode the chain stops at the first block.
+func (c *GuardrailChain) Apply(ctx context.Context, answer, requestID string) GuardrailResult {
+	mode := c.Mode()
+	enforce := mode == GuardrailModeEnforce
+	result := GuardrailResult{Answer: answer}
+	current := answer
+
+	for _, f := range c.filters {
+		verdict := f.Filter(ctx, current)
+		outcome := GuardrailOutcome{Filter: f.Name(), Action: verdict.Action, Detail: verdict.Detail, Enforced: enforce}
+		result.Outcomes = append(result.Outcomes, outcome)
+		if c.recorder != nil {
+			c.recorder.RecordGuardrailOutcome(outcome.Filter, string(outcome.Action), string(mode))
+		}
+		if verdict.Action != GuardrailActionPass {
+			c.logger.Info("answer_guardrail_outcome",
+				slog.String("request_id", requestID),
+				slog.String("filter", outcome.Filter),
+				slog.String("action", string(outcome.Action)),
+				slog.String("detail", outcome.Detail),
+				slog.String("mode", string(mode)))
+		}
+
+		switch verdict.Action {
+		case GuardrailActionRedact:
+			current = verdict.Answer
+		case GuardrailActionBlock:
+			if enforce {
+				result.Blocked = true
+				result.Reason = fmt.Sprintf("answer blocked by %s guardrail", f.Name())
+				result.Answer = ""
+				return result
+			}
+		}
+	}
+
+	if enforce {
+		result.Answer = current
+	}
+	return result
+}
+
+// applyGuardrails runs the chain when one is configured. It returns the
+// (possibly redacted) answer, the recorded outcomes, and the fallback reason
+// when the answer was blocked.
+func (u *answerWithRAGUsecase) applyGuardrails(ctx context.Context, answer, requestID string) (string, []GuardrailOutcome, string) {
+	if u.guardrails == nil {
+		return answer, nil, ""
+	}
+	result := u.guardrails.Apply(ctx, answer, requestID)
+	return result.Answer, result.Outcomes, result.Reason
+}
+
+// --- PII redaction ---------------------------------------------------------
+
+var (
+	piiEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	// Japanese domestic numbers (03-1234-5678, 090-1234-5678) and
+	// international numbers with an explicit + prefix.
+	piiPhonePattern = regexp.MustCompile(`(?:\+\d{1,3}[ \-]?\(?\d{1,4}\)?[ \-]?\d{2,4}[ \-]?\d{3,4}|\b0\d{1,4}-\d{1,4}-\d{3,4}\b)`)
+	piiCardPattern  = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+)
+
+type piiRedactionFilter struct{}
+
+// NewPIIRedactionFilter masks email addresses, phone numbers and payment
+// card numbers (Luhn-checked, so ordinary long numbers survive).
+func NewPIIRedactionFilter() AnswerFilter {
+	return piiRedactionFilter{}
+}
+
+func (piiRedactionFilter) Name() string { return "pii_redaction" }
+
+func (piiRedactionFilter) Filter(_ context.Context, answer string) AnswerFilterResult {
+	counts := map[string]int{}
+	redacted := piiCardPattern.ReplaceAllStringFunc(answer, func(m string) string {
+		if !luhnValid(m) {
+			return m
+		}
+		counts["card"]++
+		return "[redacted card]"
+	})
+	redacted = piiEmailPattern.ReplaceAllStringFunc(redacted, func(string) string {
+		counts["email"]++
+		return "[redacted email]"
+	})
+	redacted = piiPhonePattern.ReplaceAllStringFunc(redacted, func(string) string {
+		counts["phone"]++
+		return "[redacted phone]"
+	})
+	if len(counts) == 0 {
+		return AnswerFilterResult{Action: GuardrailActionPass}
+	}
+
+	var parts []string
+	for _, kind := range []string{"card", "email", "phone"} {
+		if n := counts[kind]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", kind, n))
+		}
+	}
+	return AnswerFilterResult{Action: GuardrailActionRedact, Answer: redacted, Detail: strings.Join(parts, ",")}
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		ch := s[i]
+		if ch < '0' || ch > '9' {
+			continue
+		}
+		d := int(ch - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
+
+// --- Prompt-injection echo detection ---------------------------------------
+
+// injectionEchoPatterns match text an answer should never contain: an
+// instruction aimed at a model, typically planted in a retrieved article and
+// repeated by the generator, or our own prompt scaffolding leaking out.
+var injectionEchoPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"ignore_instructions", regexp.MustCompile(`(?i)\b(ignore|disregard|forget)\s+(all\s+|any\s+)?(the\s+)?(previous|prior|above|earlier)\s+(instructions|prompts|rules|directions)`)},
+	{"ignore_instructions_ja", regexp.MustCompile(`(以前|前|上記|これまで)の(指示|命令|プロンプト)を(すべて|全て)?(無視|忘れ)`)},
+	{"role_override", regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(a|an|in)\b|\b(developer|jailbreak|DAN)\s+mode\b`)},
+	{"prompt_scaffolding", regexp.MustCompile(`(?i)(<\|(system|im_start|im_end)\|>|<(start|end)_of_turn>|\[/?INST\])`)},
+}
+
+type injectionEchoFilter struct{}
+
+// NewInjectionEchoFilter blocks answers that repeat prompt-injection
+// instructions or leak prompt scaffolding.
+func NewInjectionEchoFilter() AnswerFilter {
+	return injectionEchoFilter{}
+}
+
+func (injectionEchoFilter) Name() string { return "injection_echo" }
+
+func (injectionEchoFilter) Filter(_ context.Context, answer string) AnswerFilterResult {
+	for _, p := range injectionEchoPatterns {
+		if p.pattern.MatchString(answer) {
+			return AnswerFilterResult{Action: GuardrailActionBlock, Detail: p.name}
+		}
+	}
+	return AnswerFilterResult{Action: GuardrailActionPass}
+}
+
+// --- Toxicity ---------------------------------------------------------------
+
+type toxicityFilter struct {
+	classifier domain.ToxicityClassifier
+	threshold  float64
+}
+
+// NewToxicityFilter blocks answers the classifier scores at or above
+// threshold. Classifier errors are recorded and the answer is let through,
+// so an outage of the classifier never takes Augur down with it.
+func NewToxicityFilter(classifier domain.ToxicityClassifier, threshold float64) AnswerFilter {
+	return toxicityFilter{classifier: classifier, threshold: threshold}
+}
+
+func (toxicityFilter) Name() string { return "toxicity" }
+
+func (f toxicityFilter) Filter(ctx context.Context, answer string) AnswerFilterResult {
+	res, err := f.classifier.ClassifyToxicity(ctx, answer)
+	if err != nil {
+		return AnswerFilterResult{Action: GuardrailActionError, Detail: err.Error()}
+	}
+	detail := fmt.Sprintf("score=%.2f", res.Score)
+	if len(res.Labels) > 0 {
+		detail += " labels=" + strings.Join(res.Labels, ",")
+	}
+	if res.Score >= f.threshold {
+		return AnswerFilterResult{Action: GuardrailActionBlock, Detail: detail}
+	}
+	return AnswerFilterResult{Action: GuardrailActionPass, Detail: detail}
+}
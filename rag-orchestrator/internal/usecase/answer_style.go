@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"rag-orchestrator/internal/domain"
+
+	"golang.org/x/text/language"
+)
+
+// AnswerLength is the caller-requested target length of a generated answer.
+// The zero value keeps the intent-driven default (long-form for most intents).
+type AnswerLength string
+
+const (
+	AnswerLengthDefault AnswerLength = ""
+	AnswerLengthShort   AnswerLength = "short"  // mobile: a few sentences
+	AnswerLengthMedium  AnswerLength = "medium" // a few paragraphs
+	AnswerLengthLong    AnswerLength = "long"   // desktop: full structured report
+)
+
+// ReadingLevel is the caller-requested vocabulary/explanation register.
+// The zero value keeps the default analyst register.
+type ReadingLevel string
+
+const (
+	ReadingLevelDefault ReadingLevel = ""
+	ReadingLevelBasic   ReadingLevel = "basic" // plain language, jargon explained
+	ReadingLevelGeneral ReadingLevel = "general"
+	ReadingLevelExpert  ReadingLevel = "expert" // domain terminology without explanation
+)
+
+// AnswerFormat is the caller-requested layout of the answer body.
+// The zero value keeps the intent template's own structure.
+type AnswerFormat string
+
+const (
+	AnswerFormatDefault AnswerFormat = ""
+	AnswerFormatBullet  AnswerFormat = "bullet" // bullet list, no headings
+	AnswerFormatProse   AnswerFormat = "prose"  // paragraphs, no lists or headings
+)
+
+// maxAnswerSentences bounds the caller-requested sentence limit; anything
+// larger is a long-form answer and better expressed as AnswerLengthLong.
+const maxAnswerSentences = 30
+
+// ParseAnswerLength converts an API value into an AnswerLength.
+// Empty and "default" map to AnswerLengthDefault.
+func ParseAnswerLength(s string) (AnswerLength, error) {
+	switch AnswerLength(strings.ToLower(strings.TrimSpace(s))) {
+	case AnswerLengthDefault, "default":
+		return AnswerLengthDefault, nil
+	case AnswerLengthShort:
+		return AnswerLengthShort, nil
+	case AnswerLengthMedium:
+		return AnswerLengthMedium, nil
+	case AnswerLengthLong:
+		return AnswerLengthLong, nil
+	}
+	return AnswerLengthDefault, fmt.Errorf("invalid answer length %q (want short, medium, or long)", s)
+}
+
+// ParseReadingLevel converts an API value into a ReadingLevel.
+// Empty and "default" map to ReadingLevelDefault.
+func ParseReadingLevel(s string) (ReadingLevel, error) {
+	switch ReadingLevel(strings.ToLower(strings.TrimSpace(s))) {
+	case ReadingLevelDefault, "default":
+		return ReadingLevelDefault, nil
+	case ReadingLevelBasic:
+		return ReadingLevelBasic, nil
+	case ReadingLevelGeneral:
+		return ReadingLevelGeneral, nil
+	case ReadingLevelExpert:
+		return ReadingLevelExpert, nil
+	}
+	return ReadingLevelDefault, fmt.Errorf("invalid reading level %q (want basic, general, or expert)", s)
+}
+
+// ParseAnswerVerbosity converts the API's concise/detailed switch into an
+// AnswerLength: concise is a short answer, detailed a long one.
+// Empty and "default" map to AnswerLengthDefault.
+func ParseAnswerVerbosity(s string) (AnswerLength, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "default":
+		return AnswerLengthDefault, nil
+	case "concise":
+		return AnswerLengthShort, nil
+	case "detailed":
+		return AnswerLengthLong, nil
+	}
+	return AnswerLengthDefault, fmt.Errorf("invalid verbosity %q (want concise or detailed)", s)
+}
+
+// ParseAnswerFormat converts an API value into an AnswerFormat.
+// Empty and "default" map to AnswerFormatDefault.
+func ParseAnswerFormat(s string) (AnswerFormat, error) {
+	switch AnswerFormat(strings.ToLower(strings.TrimSpace(s))) {
+	case AnswerFormatDefault, "default":
+		return AnswerFormatDefault, nil
+	case AnswerFormatBullet:
+		return AnswerFormatBullet, nil
+	case AnswerFormatProse:
+		return AnswerFormatProse, nil
+	}
+	return AnswerFormatDefault, fmt.Errorf("invalid answer format %q (want bullet or prose)", s)
+}
+
+// ParseAnswerLocale validates a BCP 47 language tag for the answer language
+// and returns its canonical form ("EN-us" becomes "en-US"). Empty keeps the
+// prompt's default language.
+func ParseAnswerLocale(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid answer locale %q (want a BCP 47 tag such as en or ja)", s)
+	}
+	return tag.String(), nil
+}
+
+// ValidateMaxSentences checks a caller-requested sentence limit. Callers
+// that want no limit omit it rather than sending zero.
+func ValidateMaxSentences(n int) error {
+	if n < 1 || n > maxAnswerSentences {
+		return fmt.Errorf("max_sentences must be between 1 and %d", maxAnswerSentences)
+	}
+	return nil
+}
+
+// answerLanguageNames names the common answer languages in the prompt's own
+// language; other tags are written as-is, which the model understands too.
+var answerLanguageNames = map[string]string{
+	"ja": "日本語",
+	"en": "英語",
+	"zh": "中国語",
+	"ko": "韓国語",
+	"fr": "フランス語",
+	"de": "ドイツ語",
+	"es": "スペイン語",
+}
+
+// answerLanguageName returns the prompt wording for an answer locale.
+func answerLanguageName(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return locale
+	}
+	base, _ := tag.Base()
+	if name, ok := answerLanguageNames[base.String()]; ok {
+		if tag.String() == base.String() {
+			return name
+		}
+		return fmt.Sprintf("%s（%s）", name, tag)
+	}
+	return locale
+}
+
+// isJapaneseLocale reports whether locale is Japanese, the prompts' own
+// language. Empty means the default, Japanese.
+func isJapaneseLocale(locale string) bool {
+	if locale == "" {
+		return true
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return false
+	}
+	base, _ := tag.Base()
+	return base.String() == "ja"
+}
+
+// runeRange returns the target answer length in runes (Japanese characters).
+// max == 0 means unbounded. The bounds only hold for Japanese answers.
+func (l AnswerLength) runeRange() (minRunes, maxRunes int) {
+	switch l {
+	case AnswerLengthShort:
+		return 100, 300
+	case AnswerLengthMedium:
+		return 300, 800
+	case AnswerLengthLong:
+		return 800, 0
+	}
+	return 0, 0
+}
+
+// maxTokenCap bounds generation tokens for short/medium answers so the model
+// cannot wander into a long report. Zero means "no cap".
+func (l AnswerLength) maxTokenCap() int {
+	switch l {
+	case AnswerLengthShort:
+		return 768
+	case AnswerLengthMedium:
+		return 1536
+	}
+	return 0
+}
+
+// Answer length violations reported by OutputValidator.CheckAnswerLength.
+const (
+	lengthViolationTooShort = "too_short"
+	lengthViolationTooLong  = "too_long"
+)
+
+// grossLengthFactor is how far outside the target range an answer must land
+// before it counts as a violation. Small overshoots are normal for LLMs and
+// not worth a second generation call.
+const grossLengthFactor = 2
+
+// hasAnswerStyle reports whether the caller requested any answer style. A
+// non-Japanese locale counts: the prompts would otherwise ask for Japanese.
+func (in PromptInput) hasAnswerStyle() bool {
+	return in.AnswerLength != AnswerLengthDefault || in.ReadingLevel != ReadingLevelDefault ||
+		in.AnswerFormat != AnswerFormatDefault || !isJapaneseLocale(in.Locale) || in.MaxSentences > 0
+}
+
+// writeAnswerStyle renders the caller-requested style section of the system
+// prompt. Writes nothing when every style option is default.
+func writeAnswerStyle(sb *strings.Builder, input PromptInput) {
+	if !input.hasAnswerStyle() {
+		return
+	}
+	sb.WriteString("\n## 回答スタイル（他の指示より優先）\n")
+	japanese := isJapaneseLocale(input.Locale)
+	// Character counts only make sense for Japanese; other languages get
+	// the same targets in sentences and paragraphs.
+	switch {
+	case input.AnswerLength == AnswerLengthShort && japanese:
+		sb.WriteString("- 回答は100〜300文字程度の簡潔な要点にまとめること。見出しは使わないこと\n")
+	case input.AnswerLength == AnswerLengthShort:
+		sb.WriteString("- 回答は3〜5文程度の簡潔な要点にまとめること。見出しは使わないこと\n")
+	case input.AnswerLength == AnswerLengthMedium && japanese:
+		sb.WriteString("- 回答は300〜800文字程度、2〜4段落でまとめること\n")
+	case input.AnswerLength == AnswerLengthMedium:
+		sb.WriteString("- 回答は2〜4段落でまとめること\n")
+	case input.AnswerLength == AnswerLengthLong && japanese:
+		sb.WriteString("- 回答は800文字以上で、見出しを使って詳細に構造化すること\n")
+	case input.AnswerLength == AnswerLengthLong:
+		sb.WriteString("- 回答は見出しを使って詳細に構造化すること\n")
+	}
+	switch input.ReadingLevel {
+	case ReadingLevelBasic:
+		sb.WriteString("- 専門用語は避けるか、使う場合は平易な言葉で説明すること。短い文で書くこと\n")
+	case ReadingLevelGeneral:
+		sb.WriteString("- 一般的なニュース読者が理解できる表現で書くこと\n")
+	case ReadingLevelExpert:
+		sb.WriteString("- 専門家向けに、専門用語は説明なしで使い、技術的な正確さを優先すること\n")
+	}
+	switch input.AnswerFormat {
+	case AnswerFormatBullet:
+		sb.WriteString("- 回答本文は見出しを使わず、「- 」で始まる箇条書きで書くこと\n")
+	case AnswerFormatProse:
+		sb.WriteString("- 回答本文は箇条書きや見出しを使わず、段落の文章で書くこと\n")
+	}
+	if input.MaxSentences > 0 {
+		sb.WriteString(fmt.Sprintf("- 回答本文は%d文以内に収めること（箇条書きは1項目を1文と数える）\n", input.MaxSentences))
+	}
+	if !japanese {
+		sb.WriteString(fmt.Sprintf("- 回答は%sで書くこと。日本語で回答する指示よりこちらを優先すること。引用番号とJSONのキーはそのままにすること\n",
+			answerLanguageName(input.Locale)))
+	}
+}
+
+// appendAnswerStyle appends the answer style section to the system message.
+// Both prompt builders route through this so the instruction is identical
+// regardless of prompt version.
+func appendAnswerStyle(messages []domain.Message, input PromptInput) []domain.Message {
+	if !input.hasAnswerStyle() {
+		return messages
+	}
+	for i := range messages {
+		if messages[i].Role == "system" {
+			var sb strings.Builder
+			sb.WriteString(messages[i].Content)
+			writeAnswerStyle(&sb, input)
+			messages[i].Content = sb.String()
+			break
+		}
+	}
+	return messages
+}
+
+// CheckAnswerLength flags answers in locale that grossly miss the requested
+// length. It sets LengthViolation on the answer and leaves fallback answers
+// untouched. The bounds are Japanese character counts, so answers in other
+// languages are never flagged.
+func (v OutputValidator) CheckAnswerLength(answer *LLMAnswer, length AnswerLength, locale string) {
+	if answer == nil || answer.Fallback {
+		return
+	}
+	// A short/medium request supersedes the global minimum-length soft check;
+	// otherwise every such answer would be flagged and retried for length.
+	if length == AnswerLengthShort || length == AnswerLengthMedium {
+		answer.ShortAnswer = false
+	}
+	answer.LengthViolation = ""
+	if !isJapaneseLocale(locale) {
+		return
+	}
+	minRunes, maxRunes := length.runeRange()
+	n := utf8.RuneCountInString(answer.Answer)
+	switch {
+	case minRunes > 0 && n*grossLengthFactor < minRunes:
+		answer.LengthViolation = lengthViolationTooShort
+	case maxRunes > 0 && n > maxRunes*grossLengthFactor:
+		answer.LengthViolation = lengthViolationTooLong
+	}
+}
+
+// lengthCorrectionHint returns the corrective instruction appended to the
+// query when regenerating after a length violation.
+func lengthCorrectionHint(violation string, length AnswerLength) string {
+	minRunes, maxRunes := length.runeRange()
+	switch violation {
+	case lengthViolationTooLong:
+		return fmt.Sprintf("前回の回答は長すぎました。%d文字以内に要点だけをまとめてください。", maxRunes)
+	case lengthViolationTooShort:
+		return fmt.Sprintf("前回の回答は短すぎました。%d文字以上で具体的な事実と引用を交えて説明してください。", minRunes)
+	}
+	return ""
+}
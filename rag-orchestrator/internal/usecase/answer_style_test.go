@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnswerLength(t *testing.T) {
+	for in, want := range map[string]AnswerLength{
+		"":        AnswerLengthDefault,
+		"default": AnswerLengthDefault,
+		"short":   AnswerLengthShort,
+		" Medium": AnswerLengthMedium,
+		"LONG":    AnswerLengthLong,
+	} {
+		got, err := ParseAnswerLength(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseAnswerLength("tiny")
+	assert.Error(t, err)
+}
+
+func TestParseReadingLevel(t *testing.T) {
+	got, err := ParseReadingLevel("basic")
+	require.NoError(t, err)
+	assert.Equal(t, ReadingLevelBasic, got)
+
+	_, err = ParseReadingLevel("child")
+	assert.Error(t, err)
+}
+
+func TestParseAnswerVerbosity(t *testing.T) {
+	for in, want := range map[string]AnswerLength{
+		"":         AnswerLengthDefault,
+		"concise":  AnswerLengthShort,
+		"Detailed": AnswerLengthLong,
+	} {
+		got, err := ParseAnswerVerbosity(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseAnswerVerbosity("short")
+	assert.Error(t, err)
+}
+
+func TestParseAnswerFormat(t *testing.T) {
+	got, err := ParseAnswerFormat(" Bullet")
+	require.NoError(t, err)
+	assert.Equal(t, AnswerFormatBullet, got)
+
+	_, err = ParseAnswerFormat("table")
+	assert.Error(t, err)
+}
+
+func TestParseAnswerLocale(t *testing.T) {
+	for in, want := range map[string]string{
+		"":      "",
+		"en":    "en",
+		"ja-jp": "ja-JP",
+		"zh-TW": "zh-TW",
+	} {
+		got, err := ParseAnswerLocale(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseAnswerLocale("english please")
+	assert.Error(t, err)
+}
+
+func TestValidateMaxSentences(t *testing.T) {
+	assert.NoError(t, ValidateMaxSentences(1))
+	assert.NoError(t, ValidateMaxSentences(maxAnswerSentences))
+	assert.Error(t, ValidateMaxSentences(0))
+	assert.Error(t, ValidateMaxSentences(maxAnswerSentences+1))
+}
+
+func TestCheckAnswerLength(t *testing.T) {
+	v := NewOutputValidator(800)
+	tests := []struct {
+		name      string
+		length    AnswerLength
+		locale    string
+		runes     int
+		violation string
+	}{
+		{"short within range", AnswerLengthShort, "ja", 200, ""},
+		{"short mild overshoot tolerated", AnswerLengthShort, "ja", 500, ""},
+		{"short gross overshoot", AnswerLengthShort, "ja", 700, lengthViolationTooLong},
+		{"short gross undershoot", AnswerLengthShort, "ja", 30, lengthViolationTooShort},
+		{"long has no upper bound", AnswerLengthLong, "ja", 5000, ""},
+		{"long gross undershoot", AnswerLengthLong, "ja", 300, lengthViolationTooShort},
+		{"default never violates", AnswerLengthDefault, "ja", 10, ""},
+		{"empty locale is japanese", AnswerLengthShort, "", 700, lengthViolationTooLong},
+		{"regional japanese", AnswerLengthShort, "ja-JP", 700, lengthViolationTooLong},
+		{"english not bounded by japanese counts", AnswerLengthShort, "en", 700, ""},
+		{"english long not undershot", AnswerLengthLong, "en-US", 300, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer := &LLMAnswer{Answer: strings.Repeat("あ", tt.runes)}
+			v.CheckAnswerLength(answer, tt.length, tt.locale)
+			assert.Equal(t, tt.violation, answer.LengthViolation)
+		})
+	}
+}
+
+func TestCheckAnswerLength_ShortRequestClearsShortAnswerFlag(t *testing.T) {
+	v := NewOutputValidator(800)
+	answer, err := v.Validate(`{"answer":"`+strings.Repeat("あ", 200)+`","citations":[],"fallback":false,"reason":""}`, nil)
+	require.NoError(t, err)
+	require.True(t, answer.ShortAnswer)
+
+	v.CheckAnswerLength(answer, AnswerLengthShort, "ja")
+	assert.False(t, answer.ShortAnswer)
+	assert.Empty(t, answer.LengthViolation)
+}
+
+func TestPromptBuilder_AppendsAnswerStyle(t *testing.T) {
+	builders := map[string]string{"alpha-v1": "alpha-v1", "alpha-v2": "alpha-v2"}
+	for name, version := range builders {
+		t.Run(name, func(t *testing.T) {
+			b := NewXMLPromptBuilder()
+			msgs, err := b.Build(PromptInput{
+				Query:         "量子コンピュータの現状は？",
+				PromptVersion: version,
+				Contexts:      []PromptContext{{Title: "t", ChunkText: "c"}},
+				AnswerLength:  AnswerLengthShort,
+				ReadingLevel:  ReadingLevelBasic,
+			})
+			require.NoError(t, err)
+			system := systemContent(msgs)
+			assert.Contains(t, system, "100〜300文字")
+			assert.Contains(t, system, "専門用語は避ける")
+			assert.NotContains(t, system, "800文字以上")
+		})
+	}
+}
+
+func TestPromptBuilder_AppendsFormatLocaleAndSentenceLimit(t *testing.T) {
+	for _, version := range []string{"alpha-v1", "alpha-v2"} {
+		t.Run(version, func(t *testing.T) {
+			b := NewXMLPromptBuilder()
+			msgs, err := b.Build(PromptInput{
+				Query:         "量子コンピュータの現状は？",
+				PromptVersion: version,
+				Contexts:      []PromptContext{{Title: "t", ChunkText: "c"}},
+				AnswerFormat:  AnswerFormatBullet,
+				Locale:        "en",
+				MaxSentences:  4,
+			})
+			require.NoError(t, err)
+			system := systemContent(msgs)
+			assert.Contains(t, system, "回答スタイル")
+			assert.Contains(t, system, "箇条書きで書くこと")
+			assert.Contains(t, system, "4文以内")
+			assert.Contains(t, system, "回答は英語で書くこと")
+			assert.NotContains(t, system, "800文字以上")
+		})
+	}
+}
+
+func TestPromptBuilder_NonJapaneseLengthInSentences(t *testing.T) {
+	b := NewXMLPromptBuilder()
+	msgs, err := b.Build(PromptInput{
+		Query:         "What is the state of quantum computing?",
+		Locale:        "en",
+		PromptVersion: "alpha-v1",
+		Contexts:      []PromptContext{{Title: "t", ChunkText: "c"}},
+		AnswerLength:  AnswerLengthShort,
+	})
+	require.NoError(t, err)
+	system := systemContent(msgs)
+	assert.Contains(t, system, "3〜5文程度")
+	assert.NotContains(t, system, "100〜300文字")
+}
+
+func TestAnswerLanguageName(t *testing.T) {
+	assert.Equal(t, "英語", answerLanguageName("en"))
+	assert.Equal(t, "中国語（zh-TW）", answerLanguageName("zh-TW"))
+	assert.Equal(t, "pt-BR", answerLanguageName("pt-BR"))
+}
+
+func TestPromptBuilder_DefaultStyleUnchanged(t *testing.T) {
+	b := NewXMLPromptBuilder()
+	msgs, err := b.Build(PromptInput{
+		Query:         "量子コンピュータの現状は？",
+		Locale:        "ja",
+		PromptVersion: "alpha-v1",
+		Contexts:      []PromptContext{{Title: "t", ChunkText: "c"}},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, systemContent(msgs), "回答スタイル")
+}
+
+func systemContent(msgs []domain.Message) string {
+	for _, m := range msgs {
+		if m.Role == "system" {
+			return m.Content
+		}
+	}
+	return ""
+}
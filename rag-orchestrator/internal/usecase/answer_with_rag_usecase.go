@@ -51,6 +51,9 @@ type answerWithRAGUsecase struct {
 	// neighborLimit caps how many related citations the FE may render. Default
 	// 3 matches the rail's compact pencil-margin design.
 	neighborLimit int
+	// guardrails filters generated answers before they are returned or
+	// cached. Optional: nil returns answers as generated.
+	guardrails *GuardrailChain
 }
 
 // NewAnswerWithRAGUsecase wires together the components needed to generate a RAG answer.
@@ -114,6 +117,7 @@ func NewAnswerWithRAGUsecase(
 		templateRegistry:  tmplRegistry,
 		neighborSearcher:  cfg.neighborSearcher,
 		neighborLimit:     neighborLimit,
+		guardrails:        cfg.guardrails,
 	}
 }
 
@@ -135,6 +139,7 @@ type answerUsecaseConfig struct {
 	relevanceGate     *RelevanceGate
 	neighborSearcher  domain.HybridSearcher
 	neighborLimit     int
+	guardrails        *GuardrailChain
 }
 
 // WithCacheConfig sets the cache size and TTL.
@@ -226,11 +231,21 @@ func WithNeighborLimit(limit int) AnswerUsecaseOption {
 	}
 }
 
+// WithGuardrails runs chain over every generated answer (PII redaction,
+// prompt-injection echo, toxicity) before it is returned or cached.
+func WithGuardrails(chain *GuardrailChain) AnswerUsecaseOption {
+	return func(cfg *answerUsecaseConfig) {
+		cfg.guardrails = chain
+	}
+}
+
 // Execute performs the Single-Phase RAG generation with caching.
 func (u *answerWithRAGUsecase) Execute(ctx context.Context, input AnswerWithRAGInput) (*AnswerWithRAGOutput, error) {
 	if strings.TrimSpace(input.Query) == "" {
 		return nil, fmt.Errorf("query is required")
 	}
+	ctx = domain.WithSourceFilter(ctx, input.SourceTypes)
+	ctx = domain.WithRetrievalFilter(ctx, input.Filter)
 
 	executionStart := time.Now()
 	requestID := uuid.NewString()
@@ -351,6 +366,25 @@ func (u *answerWithRAGUsecase) Execute(ctx context.Context, input AnswerWithRAGI
 		)
 	}
 
+	answerText, guardrailOutcomes, blockedReason := u.applyGuardrails(ctx, strings.TrimSpace(parsedAnswer.Answer), requestID)
+	if blockedReason != "" {
+		u.logger.Warn("answer_fallback_triggered",
+			slog.String("request_id", requestID),
+			slog.String("retrieval_set_id", finalPromptData.retrievalSetID),
+			slog.String("reason", blockedReason),
+			slog.Int("contexts_available", len(finalPromptData.contexts)))
+		output, err := u.prepareFallback(
+			finalPromptData.contexts,
+			finalPromptData.retrievalSetID,
+			blockedReason,
+			FallbackGuardrailBlocked,
+			finalPromptData.strategyUsed,
+			finalPromptData.expandedQueries,
+		)
+		output.Debug.GuardrailOutcomes = guardrailOutcomes
+		return output, err
+	}
+
 	// Build Citations (Hydration)
 	finalCitations := u.buildCitations(finalPromptData.contexts, parsedAnswer.Citations)
 
@@ -375,6 +409,7 @@ func (u *answerWithRAGUsecase) Execute(ctx context.Context, input AnswerWithRAGI
 		RetrievalPolicy:       finalPromptData.retrievalPolicy,
 		GeneralRetrievalGated: finalPromptData.generalGated,
 		BM25HitCount:          finalPromptData.bm25HitCount,
+		GuardrailOutcomes:     guardrailOutcomes,
 	}
 	if finalPromptData.plannerOutput != nil {
 		debug.PlannerOperation = string(finalPromptData.plannerOutput.Operation)
@@ -384,7 +419,7 @@ func (u *answerWithRAGUsecase) Execute(ctx context.Context, input AnswerWithRAGI
 
 	relatedCitations := u.buildRelatedCitations(ctx, finalCitations, input.Query)
 	output := &AnswerWithRAGOutput{
-		Answer:           strings.TrimSpace(parsedAnswer.Answer),
+		Answer:           answerText,
 		Citations:        finalCitations,
 		RelatedCitations: relatedCitations,
 		Contexts:         finalPromptData.contexts,
@@ -428,6 +463,11 @@ func deriveAcceptanceProfile(input AnswerWithRAGInput, promptData *promptBuildRe
 	if promptData == nil {
 		return profile
 	}
+	// An explicit short/medium request overrides the intent-driven long-form
+	// profiles; their minimum-length acceptance would reject every answer.
+	if input.AnswerLength == AnswerLengthShort || input.AnswerLength == AnswerLengthMedium {
+		return profile
+	}
 
 	isDetailed := queryRequestsDetailedAnswer(input.Query) ||
 		promptData.intentType == IntentTopicDeepDive ||
@@ -500,9 +540,10 @@ func (u *answerWithRAGUsecase) generateAnswerWithRetries(
 			slog.String("request_id", requestID),
 			slog.String("retrieval_set_id", currentPromptData.retrievalSetID),
 			slog.String("profile", profile.name),
+			slog.String("model_route", string(currentPromptData.modelRoute)),
 			slog.Int("attempt", retryCount+1))
 
-		resp, err := u.llmClient.Chat(ctx, currentPromptData.messages, currentPromptData.maxTokens)
+		resp, err := u.llmClient.Chat(WithModelRoute(ctx, currentPromptData.modelRoute), currentPromptData.messages, currentPromptData.maxTokens)
 		if err != nil {
 			return currentPromptData, nil, currentFlags, retryCount, false, fmt.Errorf("generation failed: %w", err)
 		}
@@ -531,6 +572,13 @@ func (u *answerWithRAGUsecase) generateAnswerWithRetries(
 			return currentPromptData, parsedAnswer, currentFlags, retryCount, false, nil
 		}
 
+		if input.AnswerLength != AnswerLengthDefault {
+			u.validator.CheckAnswerLength(parsedAnswer, input.AnswerLength, currentPromptData.locale)
+			if parsedAnswer.LengthViolation != "" {
+				parsedAnswer, retryCount = u.regenerateForLength(ctx, input, currentPromptData, parsedAnswer, requestID, retryCount)
+			}
+		}
+
 		qualityFlags := AssessAnswerQuality(
 			parsedAnswer.Answer, input.Query, parsedAnswer.Citations, currentPromptData.intentType, currentPromptData.expandedQueries,
 		)
@@ -599,7 +647,7 @@ func (u *answerWithRAGUsecase) retryValidatedAnswer(
 			return currentPromptData, parsedAnswer, qualityFlags, retryCount, false, fmt.Errorf("build corrective retry prompt: %w", err)
 		}
 
-		retryResp, err := u.llmClient.Chat(ctx, retryPromptData.messages, retryPromptData.maxTokens)
+		retryResp, err := u.llmClient.Chat(WithModelRoute(ctx, retryPromptData.modelRoute), retryPromptData.messages, retryPromptData.maxTokens)
 		if err != nil {
 			return currentPromptData, parsedAnswer, qualityFlags, retryCount, false, fmt.Errorf("corrective retry generation failed: %w", err)
 		}
@@ -608,6 +656,9 @@ func (u *answerWithRAGUsecase) retryValidatedAnswer(
 		if err != nil {
 			return currentPromptData, parsedAnswer, qualityFlags, retryCount, false, fmt.Errorf("corrective retry validation failed: %w", err)
 		}
+		if input.AnswerLength != AnswerLengthDefault {
+			u.validator.CheckAnswerLength(retryParsed, input.AnswerLength, retryPromptData.locale)
+		}
 
 		retryFlags := AssessAnswerQuality(
 			retryParsed.Answer, input.Query, retryParsed.Citations, retryPromptData.intentType, retryPromptData.expandedQueries,
@@ -632,6 +683,60 @@ func (u *answerWithRAGUsecase) retryValidatedAnswer(
 	}
 }
 
+// regenerateForLength re-runs generation once when the answer grossly misses
+// the requested AnswerLength. The retrieved contexts are reused as-is — only a
+// corrective hint is appended to the final user message — so no retrieval is
+// repeated. The regenerated answer replaces the original only when it parses
+// and lands within bounds (or at least stops violating in the same direction);
+// otherwise the original is kept, since a mis-sized answer beats a fallback.
+func (u *answerWithRAGUsecase) regenerateForLength(
+	ctx context.Context,
+	input AnswerWithRAGInput,
+	promptData *promptBuildResult,
+	parsedAnswer *LLMAnswer,
+	requestID string,
+	retryCount int,
+) (*LLMAnswer, int) {
+	u.logger.Warn("answer_length_violation",
+		slog.String("request_id", requestID),
+		slog.String("retrieval_set_id", promptData.retrievalSetID),
+		slog.String("answer_length", string(input.AnswerLength)),
+		slog.String("violation", parsedAnswer.LengthViolation),
+		slog.Int("answer_rune_length", utf8.RuneCountInString(parsedAnswer.Answer)))
+
+	messages := make([]domain.Message, len(promptData.messages))
+	copy(messages, promptData.messages)
+	if n := len(messages); n > 0 && messages[n-1].Role == "user" {
+		messages[n-1].Content += "\n\n" + lengthCorrectionHint(parsedAnswer.LengthViolation, input.AnswerLength)
+	}
+
+	resp, err := u.llmClient.Chat(WithModelRoute(ctx, promptData.modelRoute), messages, promptData.maxTokens)
+	retryCount++
+	if err != nil {
+		u.logger.Warn("answer_length_regeneration_failed",
+			slog.String("request_id", requestID),
+			slog.String("error", err.Error()))
+		return parsedAnswer, retryCount
+	}
+	regenerated, err := u.validator.Validate(resp.Text, promptData.contexts)
+	if err != nil || regenerated.Fallback {
+		u.logger.Warn("answer_length_regeneration_discarded",
+			slog.String("request_id", requestID),
+			slog.Bool("validation_failed", err != nil))
+		return parsedAnswer, retryCount
+	}
+	u.validator.CheckAnswerLength(regenerated, input.AnswerLength, promptData.locale)
+	if regenerated.LengthViolation == parsedAnswer.LengthViolation {
+		return parsedAnswer, retryCount
+	}
+
+	u.logger.Info("answer_length_regenerated",
+		slog.String("request_id", requestID),
+		slog.String("remaining_violation", regenerated.LengthViolation),
+		slog.Int("answer_rune_length", utf8.RuneCountInString(regenerated.Answer)))
+	return regenerated, retryCount
+}
+
 func (u *answerWithRAGUsecase) shouldRetryGeneratedAnswer(
 	query string,
 	parsedAnswer *LLMAnswer,
@@ -968,6 +1073,7 @@ type promptBuildResult struct {
 	strategyUsed     string
 	intentType       IntentType
 	subIntentType    SubIntentType
+	modelRoute       ModelRoute
 	toolsUsed        []string
 	articleContext   *ArticleContext
 	retrievalQuality QualityVerdict
@@ -977,7 +1083,8 @@ type promptBuildResult struct {
 	plannerOutput    *domain.PlannerOutput // Conversation planner result
 	parsedIntent     QueryIntent           // Resolved intent for state derivation
 	bm25HitCount     int
-	lowConfidence    bool // Insufficient quality but generating with disclaimer
+	lowConfidence    bool   // Insufficient quality but generating with disclaimer
+	locale           string // Answer language the prompt asked for
 }
 
 func (u *answerWithRAGUsecase) buildPrompt(ctx context.Context, input AnswerWithRAGInput) (*promptBuildResult, error) {
@@ -989,6 +1096,9 @@ func (u *answerWithRAGUsecase) buildPrompt(ctx context.Context, input AnswerWith
 	if maxTokens <= 0 {
 		maxTokens = u.maxTokens
 	}
+	if tokenCap := input.AnswerLength.maxTokenCap(); tokenCap > 0 && maxTokens > tokenCap {
+		maxTokens = tokenCap
+	}
 
 	result := &promptBuildResult{
 		retrievalSetID: uuid.NewString(),
@@ -1025,11 +1135,13 @@ func (u *answerWithRAGUsecase) buildPrompt(ctx context.Context, input AnswerWith
 	result.strategyUsed = strategy.Name()
 	result.intentType = intent.IntentType
 	result.subIntentType = intent.SubIntentType
+	result.modelRoute = ClassifyModelRoute(intent.IntentType, intent.SubIntentType, intent.UserQuestion)
 	result.parsedIntent = intent
 
 	u.logger.Info("query_intent_parsed",
 		slog.String("intent_type", string(intent.IntentType)),
 		slog.String("sub_intent_type", string(intent.SubIntentType)),
+		slog.String("model_route", string(result.modelRoute)),
 		slog.String("article_id", intent.ArticleID),
 		slog.String("strategy", strategy.Name()))
 
@@ -1228,6 +1340,7 @@ func (u *answerWithRAGUsecase) buildPrompt(ctx context.Context, input AnswerWith
 	if locale == "" {
 		locale = u.defaultLocale
 	}
+	result.locale = locale
 
 	// Phase 3: Tool dispatch (intent-driven, no LLM)
 	var supplementary []string
@@ -1260,6 +1373,10 @@ func (u *answerWithRAGUsecase) buildPrompt(ctx context.Context, input AnswerWith
 		SubIntentType:       intent.SubIntentType,
 		SupplementaryInfo:   supplementary,
 		LowConfidence:       result.lowConfidence,
+		AnswerLength:        input.AnswerLength,
+		ReadingLevel:        input.ReadingLevel,
+		AnswerFormat:        input.AnswerFormat,
+		MaxSentences:        input.MaxSentences,
 	}
 
 	messages, err := u.promptBuilder.Build(promptInput)
@@ -1499,6 +1616,7 @@ func (u *answerWithRAGUsecase) buildPromptWithQueryPlanner(
 	if strings.TrimSpace(qPlan.ResolvedQuery) != "" {
 		plannerIntent.UserQuestion = qPlan.ResolvedQuery
 	}
+	result.modelRoute = ClassifyModelRoute(result.intentType, SubIntentNone, plannerIntent.UserQuestion)
 
 	// Map to PlannerOutput for compatibility with stream clarification
 	plannerOut := &domain.PlannerOutput{
@@ -1593,6 +1711,7 @@ func (u *answerWithRAGUsecase) buildPromptWithQueryPlanner(
 	if locale == "" {
 		locale = u.defaultLocale
 	}
+	result.locale = locale
 
 	// Tool dispatch (supplementary info)
 	var supplementary []string
@@ -1619,6 +1738,10 @@ func (u *answerWithRAGUsecase) buildPromptWithQueryPlanner(
 		IntentType:          result.intentType,
 		SupplementaryInfo:   supplementary,
 		PlannerOutput:       plannerOut,
+		AnswerLength:        input.AnswerLength,
+		ReadingLevel:        input.ReadingLevel,
+		AnswerFormat:        input.AnswerFormat,
+		MaxSentences:        input.MaxSentences,
 	}
 
 	messages, err := u.promptBuilder.Build(promptInput)
@@ -1656,11 +1779,28 @@ func (u *answerWithRAGUsecase) generateCacheKey(input AnswerWithRAGInput) string
 	// follow-ups ("tell me more") never reuse an unrelated earlier
 	// conversation's cached answer, and so the cache isn't shared across
 	// users. MaxChunks/MaxTokens are included because they change the shape
-	// of the generated answer for an otherwise-identical query.
-	return fmt.Sprintf("%s|%v|%s|user=%s|hist=%s|chunks=%d|tokens=%d",
+	// of the generated answer for an otherwise-identical query; the same
+	// holds for the answer style (length, reading level, format, locale and
+	// sentence limit).
+	// SourceTypes and Filter narrow the corpus, so a filtered answer must
+	// never be served for an unfiltered query or vice versa. The guardrail mode is
+	// included so switching to enforce never serves an answer cached while
+	// observing.
+	guard := ""
+	if u.guardrails != nil {
+		guard = string(u.guardrails.Mode())
+	}
+	sources := make([]string, len(input.SourceTypes))
+	for i, t := range input.SourceTypes {
+		sources[i] = string(t)
+	}
+	sort.Strings(sources)
+
+	return fmt.Sprintf("%s|%v|%s|user=%s|hist=%s|chunks=%d|tokens=%d|len=%s|level=%s|fmt=%s|sent=%d|src=%v|filter=%s|guard=%s",
 		input.Query, ids, input.Locale, input.UserID,
 		hashConversationHistory(input.ConversationHistory),
-		input.MaxChunks, input.MaxTokens)
+		input.MaxChunks, input.MaxTokens, input.AnswerLength, input.ReadingLevel,
+		input.AnswerFormat, input.MaxSentences, sources, input.Filter.CacheKey(), guard)
 }
 
 // hashConversationHistory returns a short deterministic hash of the most
@@ -1740,6 +1880,9 @@ func cloneAnswerOutput(src *AnswerWithRAGOutput) *AnswerWithRAGOutput {
 	if src.Debug.AgentSteps != nil {
 		dst.Debug.AgentSteps = append([]AgentStep(nil), src.Debug.AgentSteps...)
 	}
+	if src.Debug.GuardrailOutcomes != nil {
+		dst.Debug.GuardrailOutcomes = append([]GuardrailOutcome(nil), src.Debug.GuardrailOutcomes...)
+	}
 	return &dst
 }
 
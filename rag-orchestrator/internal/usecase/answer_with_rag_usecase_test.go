@@ -2129,3 +2129,40 @@ func TestExecute_CacheKey_DiffersByUserID(t *testing.T) {
 	mockRetrieve.AssertNumberOfCalls(t, "Execute", 2)
 	mockLLM.AssertNumberOfCalls(t, "Chat", 2)
 }
+
+// TestExecute_RetrievalFilter_ScopesRetrievalAndCache checks the filter
+// reaches retrieval through the context and is part of the cache key, so a
+// "this week" answer is never replayed for an unfiltered question.
+func TestExecute_RetrievalFilter_ScopesRetrievalAndCache(t *testing.T) {
+	ctx := context.Background()
+	mockRetrieve := new(mockRetrieveContextUsecase)
+	mockLLM := new(mockLLMClient)
+	builder := usecase.NewXMLPromptBuilder()
+	testLogger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	uc := usecase.NewAnswerWithRAGUsecase(mockRetrieve, builder, mockLLM, usecase.NewOutputValidator(0), 10, 512, 6000, "alpha-v1", "ja", testLogger)
+
+	chunkID := uuid.New()
+	var filters []domain.RetrievalFilter
+	mockRetrieve.On("Execute", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		filters = append(filters, domain.RetrievalFilterFromContext(args.Get(0).(context.Context)))
+	}).Return(&usecase.RetrieveContextOutput{
+		Contexts: []usecase.ContextItem{{ChunkID: chunkID, ChunkText: "Weekly chunk", Title: "Weekly", Score: 0.9}},
+	}, nil)
+
+	llmResponse := `{"answer":"Scoped answer","citations":[{"chunk_id":"` + chunkID.String() + `","reason":"r"}],"fallback":false,"reason":""}`
+	mockLLM.On("Chat", mock.Anything, mock.Anything, mock.Anything).Return(&domain.LLMResponse{Text: llmResponse, Done: true}, nil)
+
+	after := time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)
+	filter := domain.RetrievalFilter{PublishedAfter: &after, FeedIDs: []string{"feed-1"}}
+
+	_, err := uc.Execute(ctx, usecase.AnswerWithRAGInput{Query: "what happened", UserID: "user-1", Filter: filter})
+	require.NoError(t, err)
+	_, err = uc.Execute(ctx, usecase.AnswerWithRAGInput{Query: "what happened", UserID: "user-1"})
+	require.NoError(t, err)
+
+	mockRetrieve.AssertNumberOfCalls(t, "Execute", 2)
+	require.Len(t, filters, 2)
+	assert.Equal(t, []string{"feed-1"}, filters[0].FeedIDs)
+	assert.True(t, filters[1].IsEmpty(), "unfiltered request must not inherit the filter")
+}
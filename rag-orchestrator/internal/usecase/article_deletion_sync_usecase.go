@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"rag-orchestrator/internal/domain"
+)
+
+// articleDeletionPageSize is how many audit events one request fetches.
+const articleDeletionPageSize = 500
+
+// ArticleDeletionSyncResult summarises one pass over the article
+// soft-delete audit trail.
+type ArticleDeletionSyncResult struct {
+	Events int
+	// Deleted counts articles whose latest event was a delete or purge and
+	// whose document is now tombstoned (or was already).
+	Deleted int
+	// Restored counts articles whose latest event was a restore. Their
+	// documents are left as they are: a tombstoned document comes back only
+	// through a backfill run.
+	Restored int
+	// Cursor is the position after the last page that was applied; pass it
+	// to the next Sync. A failed page leaves it at the start of that page.
+	Cursor domain.ArticleDeletionCursor
+}
+
+// ArticleDeletionSyncUsecase tombstones the documents of articles deleted in
+// alt-backend so they stop being retrieved. The chunks of tombstoned
+// versions are removed later by the orphan reconcile.
+type ArticleDeletionSyncUsecase interface {
+	Sync(ctx context.Context, cursor domain.ArticleDeletionCursor) (ArticleDeletionSyncResult, error)
+}
+
+type articleDeletionSyncUsecase struct {
+	source   domain.ArticleDeletionSource
+	indexer  IndexArticleUsecase
+	pageSize int
+	logger   *slog.Logger
+}
+
+// NewArticleDeletionSyncUsecase creates a sync reading from source and
+// tombstoning through indexer.
+func NewArticleDeletionSyncUsecase(source domain.ArticleDeletionSource, indexer IndexArticleUsecase, logger *slog.Logger) ArticleDeletionSyncUsecase {
+	return &articleDeletionSyncUsecase{
+		source:   source,
+		indexer:  indexer,
+		pageSize: articleDeletionPageSize,
+		logger:   logger,
+	}
+}
+
+func (u *articleDeletionSyncUsecase) Sync(ctx context.Context, cursor domain.ArticleDeletionCursor) (ArticleDeletionSyncResult, error) {
+	result := ArticleDeletionSyncResult{Cursor: cursor}
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		events, err := u.source.ListArticleDeletions(ctx, result.Cursor, u.pageSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list article deletions: %w", err)
+		}
+		if len(events) == 0 {
+			return result, nil
+		}
+		if err := u.applyPage(ctx, events, &result); err != nil {
+			return result, err
+		}
+		result.Events += len(events)
+		result.Cursor = events[len(events)-1].After()
+		if len(events) < u.pageSize {
+			return result, nil
+		}
+	}
+}
+
+// applyPage applies the latest event of every article in the page, so a
+// delete followed by a restore leaves the document alone.
+func (u *articleDeletionSyncUsecase) applyPage(ctx context.Context, events []domain.ArticleDeletionEvent, result *ArticleDeletionSyncResult) error {
+	latest := make(map[string]domain.ArticleDeletionAction, len(events))
+	var order []string
+	for _, e := range events {
+		if _, seen := latest[e.ArticleID]; !seen {
+			order = append(order, e.ArticleID)
+		}
+		latest[e.ArticleID] = e.Action
+	}
+
+	for _, articleID := range order {
+		switch latest[articleID] {
+		case domain.ArticleDeleted, domain.ArticlePurged:
+			if err := u.indexer.Delete(ctx, articleID); err != nil {
+				return fmt.Errorf("failed to tombstone article %s: %w", articleID, err)
+			}
+			result.Deleted++
+		case domain.ArticleRestored:
+			u.logger.Info("article restored in alt-backend; its document is not re-indexed automatically",
+				"article_id", articleID)
+			result.Restored++
+		default:
+			u.logger.Warn("unknown article deletion action, skipping",
+				"article_id", articleID,
+				"action", latest[articleID])
+		}
+	}
+	return nil
+}
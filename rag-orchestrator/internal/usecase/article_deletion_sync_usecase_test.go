@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeletionSource serves a fixed audit trail page by page.
+type fakeDeletionSource struct {
+	events  []domain.ArticleDeletionEvent
+	cursors []domain.ArticleDeletionCursor
+}
+
+func (f *fakeDeletionSource) ListArticleDeletions(_ context.Context, cursor domain.ArticleDeletionCursor, limit int) ([]domain.ArticleDeletionEvent, error) {
+	f.cursors = append(f.cursors, cursor)
+	var page []domain.ArticleDeletionEvent
+	for _, e := range f.events {
+		if e.OccurredAt.Before(cursor.Since) || (e.OccurredAt.Equal(cursor.Since) && e.ID <= cursor.AfterID) {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, e)
+	}
+	return page, nil
+}
+
+// fakeDeleter records tombstoned articles and fails for failIDs.
+type fakeDeleter struct {
+	deleted []string
+	failIDs map[string]bool
+}
+
+func (f *fakeDeleter) Upsert(context.Context, string, string, string, string) error { return nil }
+
+func (f *fakeDeleter) UpsertWithMetadata(context.Context, string, string, string, string, domain.RetrievalMetadata) error {
+	return nil
+}
+
+func (f *fakeDeleter) Delete(_ context.Context, articleID string) error {
+	if f.failIDs[articleID] {
+		return errors.New("tx aborted")
+	}
+	f.deleted = append(f.deleted, articleID)
+	return nil
+}
+
+func deletionEvent(id, articleID string, action domain.ArticleDeletionAction, at time.Time) domain.ArticleDeletionEvent {
+	return domain.ArticleDeletionEvent{ID: id, ArticleID: articleID, Action: action, OccurredAt: at}
+}
+
+func newTestDeletionSync(source domain.ArticleDeletionSource, indexer IndexArticleUsecase, pageSize int) *articleDeletionSyncUsecase {
+	uc := NewArticleDeletionSyncUsecase(source, indexer, slog.New(slog.NewTextHandler(io.Discard, nil))).(*articleDeletionSyncUsecase)
+	uc.pageSize = pageSize
+	return uc
+}
+
+func TestArticleDeletionSync_AppliesLatestActionPerArticle(t *testing.T) {
+	t0 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	source := &fakeDeletionSource{events: []domain.ArticleDeletionEvent{
+		deletionEvent("e1", "a1", domain.ArticleDeleted, t0),
+		deletionEvent("e2", "a2", domain.ArticleDeleted, t0.Add(time.Second)),
+		deletionEvent("e3", "a2", domain.ArticleRestored, t0.Add(2*time.Second)),
+		deletionEvent("e4", "a3", domain.ArticlePurged, t0.Add(3*time.Second)),
+	}}
+	deleter := &fakeDeleter{}
+
+	result, err := newTestDeletionSync(source, deleter, 10).Sync(context.Background(), domain.ArticleDeletionCursor{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a1", "a3"}, deleter.deleted)
+	assert.Equal(t, 4, result.Events)
+	assert.Equal(t, 2, result.Deleted)
+	assert.Equal(t, 1, result.Restored)
+	assert.Equal(t, domain.ArticleDeletionCursor{Since: t0.Add(3 * time.Second), AfterID: "e4"}, result.Cursor)
+}
+
+func TestArticleDeletionSync_PagesAndStopsAtFailedPage(t *testing.T) {
+	t0 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	source := &fakeDeletionSource{events: []domain.ArticleDeletionEvent{
+		deletionEvent("e1", "a1", domain.ArticleDeleted, t0),
+		deletionEvent("e2", "a2", domain.ArticleDeleted, t0),
+		deletionEvent("e3", "a3", domain.ArticleDeleted, t0),
+		deletionEvent("e4", "a4", domain.ArticleDeleted, t0),
+	}}
+	deleter := &fakeDeleter{failIDs: map[string]bool{"a3": true}}
+
+	result, err := newTestDeletionSync(source, deleter, 2).Sync(context.Background(), domain.ArticleDeletionCursor{})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"a1", "a2"}, deleter.deleted)
+	assert.Equal(t, 2, result.Events)
+	assert.Equal(t, domain.ArticleDeletionCursor{Since: t0, AfterID: "e2"}, result.Cursor,
+		"the failed page is retried from its start")
+}
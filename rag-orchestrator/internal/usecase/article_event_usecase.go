@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ArticleEventOutcome is what handling one article event amounted to.
+type ArticleEventOutcome string
+
+const (
+	// ArticleEventEnqueued: a backfill job was queued for the article.
+	ArticleEventEnqueued ArticleEventOutcome = "enqueued"
+	// ArticleEventUnchanged: the latest indexed version already has the
+	// event's content, so no job was queued.
+	ArticleEventUnchanged ArticleEventOutcome = "unchanged"
+	// ArticleEventDeleted: the article's document was tombstoned.
+	ArticleEventDeleted ArticleEventOutcome = "deleted"
+	// ArticleEventSkipped: the event carried no content, or is of a type
+	// the index does not follow. Content-less articles are picked up by the
+	// next reindex or backfill instead.
+	ArticleEventSkipped ArticleEventOutcome = "skipped"
+)
+
+// articleEventPayload is the part of alt-backend's ArticleCreated,
+// ArticleUpdated and ArticleDeleted payloads the index needs.
+type articleEventPayload struct {
+	ArticleID   string    `json:"article_id"`
+	FeedID      string    `json:"feed_id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Content     string    `json:"content"`
+	Tags        []string  `json:"tags"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// retrieval is the metadata retrieval filters match on. A missing
+// published_at decodes to the zero time and keeps the stored value.
+func (p articleEventPayload) retrieval() domain.RetrievalMetadata {
+	return domain.RetrievalMetadata{PublishedAt: p.PublishedAt, FeedID: p.FeedID, Tags: p.Tags}
+}
+
+// ArticleEventUsecase keeps the index in step with alt-backend's article
+// events.
+type ArticleEventUsecase interface {
+	// Handle applies one event. An error wrapping
+	// domain.ErrInvalidArticleEvent means the event can never succeed; any
+	// other error is worth a retry.
+	Handle(ctx context.Context, event domain.ArticleEvent) (ArticleEventOutcome, error)
+}
+
+type articleEventUsecase struct {
+	jobs    domain.RagJobRepository
+	indexer IndexArticleUsecase
+	indexed indexedCheck
+	clock   func() time.Time
+}
+
+// NewArticleEventUsecase creates the usecase. Created and updated articles
+// are indexed through backfill jobs, like a reindex, so the job worker stays
+// the only writer of new versions; deletions are applied directly.
+func NewArticleEventUsecase(
+	jobs domain.RagJobRepository,
+	indexer IndexArticleUsecase,
+	docRepo domain.RagDocumentRepository,
+	hasher domain.SourceHashPolicy,
+	chunkerVersion domain.ChunkerVersion,
+) ArticleEventUsecase {
+	return &articleEventUsecase{
+		jobs:    jobs,
+		indexer: indexer,
+		indexed: indexedCheck{docRepo: docRepo, hasher: hasher, chunkerVersion: chunkerVersion},
+		clock:   time.Now,
+	}
+}
+
+func (u *articleEventUsecase) Handle(ctx context.Context, event domain.ArticleEvent) (ArticleEventOutcome, error) {
+	switch event.EventType {
+	case domain.ArticleEventCreated, domain.ArticleEventUpdated, domain.ArticleEventDeleted:
+	default:
+		return ArticleEventSkipped, nil
+	}
+
+	var payload articleEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return "", fmt.Errorf("%w: %s payload: %w", domain.ErrInvalidArticleEvent, event.EventType, err)
+	}
+	if payload.ArticleID == "" {
+		return "", fmt.Errorf("%w: %s payload has no article_id", domain.ErrInvalidArticleEvent, event.EventType)
+	}
+
+	if event.EventType == domain.ArticleEventDeleted {
+		if err := u.indexer.Delete(ctx, payload.ArticleID); err != nil {
+			return "", fmt.Errorf("delete article %s: %w", payload.ArticleID, err)
+		}
+		return ArticleEventDeleted, nil
+	}
+
+	if payload.Content == "" {
+		return ArticleEventSkipped, nil
+	}
+	current, err := u.indexed.current(ctx, payload.ArticleID, payload.Title, payload.URL, payload.Content, payload.retrieval())
+	if err != nil {
+		return "", fmt.Errorf("check article %s: %w", payload.ArticleID, err)
+	}
+	if current {
+		return ArticleEventUnchanged, nil
+	}
+	if err := u.enqueue(ctx, event, payload); err != nil {
+		return "", fmt.Errorf("enqueue article %s: %w", payload.ArticleID, err)
+	}
+	return ArticleEventEnqueued, nil
+}
+
+func (u *articleEventUsecase) enqueue(ctx context.Context, event domain.ArticleEvent, payload articleEventPayload) error {
+	now := u.clock()
+	jobPayload := map[string]interface{}{
+		"article_id": payload.ArticleID,
+		"title":      payload.Title,
+		"body":       payload.Content,
+		"url":        payload.URL,
+		"event_id":   event.EventID,
+	}
+	if payload.FeedID != "" {
+		jobPayload["feed_id"] = payload.FeedID
+	}
+	if len(payload.Tags) > 0 {
+		jobPayload["tags"] = payload.Tags
+	}
+	if !payload.PublishedAt.IsZero() {
+		jobPayload["published_at"] = payload.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	return u.jobs.Enqueue(ctx, &domain.RagJob{
+		ID:        uuid.New(),
+		JobType:   "backfill_article",
+		Payload:   jobPayload,
+		Status:    "new",
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEventIndexer records the articles deleted through it.
+type fakeEventIndexer struct {
+	deleted []string
+}
+
+func (f *fakeEventIndexer) Upsert(context.Context, string, string, string, string) error { return nil }
+
+func (f *fakeEventIndexer) UpsertWithMetadata(context.Context, string, string, string, string, domain.RetrievalMetadata) error {
+	return nil
+}
+
+func (f *fakeEventIndexer) Delete(_ context.Context, articleID string) error {
+	f.deleted = append(f.deleted, articleID)
+	return nil
+}
+
+func newTestArticleEventUsecase(jobs domain.RagJobRepository, indexer usecase.IndexArticleUsecase, docRepo domain.RagDocumentRepository) usecase.ArticleEventUsecase {
+	return usecase.NewArticleEventUsecase(jobs, indexer, docRepo, domain.NewSourceHashPolicy(), domain.ChunkerVersionV9)
+}
+
+func articleEvent(eventType, payload string) domain.ArticleEvent {
+	return domain.ArticleEvent{MessageID: "1-0", EventID: "ev-1", EventType: eventType, Payload: []byte(payload), Deliveries: 1}
+}
+
+func TestArticleEvent_EnqueuesOnlyChangedContent(t *testing.T) {
+	hasher := domain.NewSourceHashPolicy()
+	versionID := uuid.New()
+	doc := &domain.RagDocument{ID: uuid.New(), CurrentVersionID: &versionID}
+	docRepo := new(MockRagDocumentRepository)
+	docRepo.On("GetByArticleID", mock.Anything, "a1").Return(doc, nil)
+	docRepo.On("GetLatestVersion", mock.Anything, doc.ID).Return(&domain.RagDocumentVersion{
+		Title: "Title", URL: "https://example.com/a1", SourceHash: hasher.Compute("Title", "body"), ChunkerVersion: string(domain.ChunkerVersionV9),
+	}, nil)
+	docRepo.On("GetByArticleID", mock.Anything, "a2").Return(nil, nil)
+	jobs := &fakeJobRepo{}
+	uc := newTestArticleEventUsecase(jobs, &fakeEventIndexer{}, docRepo)
+	ctx := context.Background()
+
+	outcome, err := uc.Handle(ctx, articleEvent(domain.ArticleEventUpdated,
+		`{"article_id":"a1","title":"Title","url":"https://example.com/a1","content":"body"}`))
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ArticleEventUnchanged, outcome)
+
+	outcome, err = uc.Handle(ctx, articleEvent(domain.ArticleEventCreated,
+		`{"article_id":"a2","title":"New","url":"https://example.com/a2","content":"fresh body"}`))
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ArticleEventEnqueued, outcome)
+
+	require.Len(t, jobs.jobs, 1)
+	assert.Equal(t, "backfill_article", jobs.jobs[0].JobType)
+	assert.Equal(t, map[string]interface{}{
+		"article_id": "a2",
+		"title":      "New",
+		"body":       "fresh body",
+		"url":        "https://example.com/a2",
+		"event_id":   "ev-1",
+	}, jobs.jobs[0].Payload)
+}
+
+func TestArticleEvent_EnqueuesMetadataOnlyChange(t *testing.T) {
+	hasher := domain.NewSourceHashPolicy()
+	versionID := uuid.New()
+	doc := &domain.RagDocument{ID: uuid.New(), CurrentVersionID: &versionID}
+	docRepo := new(MockRagDocumentRepository)
+	docRepo.On("GetByArticleID", mock.Anything, "a1").Return(doc, nil)
+	docRepo.On("GetLatestVersion", mock.Anything, doc.ID).Return(&domain.RagDocumentVersion{
+		Title: "Title", URL: "https://example.com/a1", SourceHash: hasher.Compute("Title", "body"), ChunkerVersion: string(domain.ChunkerVersionV9),
+	}, nil)
+	jobs := &fakeJobRepo{}
+	uc := newTestArticleEventUsecase(jobs, &fakeEventIndexer{}, docRepo)
+
+	outcome, err := uc.Handle(context.Background(), articleEvent(domain.ArticleEventUpdated,
+		`{"article_id":"a1","feed_id":"feed-1","title":"Title","url":"https://example.com/a1","content":"body","tags":["go"],"published_at":"2026-10-14T08:30:00+09:00"}`))
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ArticleEventEnqueued, outcome, "unchanged content with new metadata is re-indexed")
+
+	require.Len(t, jobs.jobs, 1)
+	payload := jobs.jobs[0].Payload
+	assert.Equal(t, "feed-1", payload["feed_id"])
+	assert.Equal(t, []string{"go"}, payload["tags"])
+	assert.Equal(t, "2026-10-13T23:30:00Z", payload["published_at"])
+}
+
+func TestArticleEvent_DeletesAndSkips(t *testing.T) {
+	jobs := &fakeJobRepo{}
+	indexer := &fakeEventIndexer{}
+	uc := newTestArticleEventUsecase(jobs, indexer, new(MockRagDocumentRepository))
+	ctx := context.Background()
+
+	outcome, err := uc.Handle(ctx, articleEvent(domain.ArticleEventDeleted, `{"article_id":"a1","user_id":"u1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ArticleEventDeleted, outcome)
+	assert.Equal(t, []string{"a1"}, indexer.deleted)
+
+	// A thin event has nothing to hash; the next reindex picks it up.
+	outcome, err = uc.Handle(ctx, articleEvent(domain.ArticleEventCreated, `{"article_id":"a2","title":"Thin"}`))
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ArticleEventSkipped, outcome)
+
+	outcome, err = uc.Handle(ctx, articleEvent("ArticleSummarized", `not json`))
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ArticleEventSkipped, outcome)
+
+	assert.Empty(t, jobs.jobs)
+}
+
+func TestArticleEvent_InvalidPayloadIsPermanent(t *testing.T) {
+	uc := newTestArticleEventUsecase(&fakeJobRepo{}, &fakeEventIndexer{}, new(MockRagDocumentRepository))
+	ctx := context.Background()
+
+	_, err := uc.Handle(ctx, articleEvent(domain.ArticleEventCreated, `{"article_id":`))
+	assert.ErrorIs(t, err, domain.ErrInvalidArticleEvent)
+
+	_, err = uc.Handle(ctx, articleEvent(domain.ArticleEventDeleted, `{"user_id":"u1"}`))
+	assert.ErrorIs(t, err, domain.ErrInvalidArticleEvent)
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+)
+
+const (
+	// orphanReconcileBatchSize bounds one delete transaction so the HNSW
+	// index is not rewritten under a long-held lock.
+	orphanReconcileBatchSize = 1000
+	// orphanReconcileMaxBatches caps one reconcile run; whatever is left is
+	// picked up by the next run.
+	orphanReconcileMaxBatches = 100
+)
+
+// ReconcileResult reports one orphaned-chunk reconcile run.
+type ReconcileResult struct {
+	DeletedChunks int64 `json:"deleted_chunks"`
+	Batches       int   `json:"batches"`
+	// Complete is false when the run stopped at its batch cap with orphans
+	// possibly left over.
+	Complete   bool          `json:"complete"`
+	Duration   time.Duration `json:"-"`
+	DurationMS int64         `json:"duration_ms"`
+}
+
+// CorpusStatsUsecase reports index health and removes orphaned chunks.
+type CorpusStatsUsecase interface {
+	Stats(ctx context.Context) (*domain.CorpusStats, error)
+
+	// ReconcileOrphans deletes chunks of superseded versions in batches.
+	ReconcileOrphans(ctx context.Context) (*ReconcileResult, error)
+}
+
+type corpusStatsUsecase struct {
+	repo      domain.CorpusStatsRepository
+	batchSize int
+	clock     func() time.Time
+}
+
+// NewCorpusStatsUsecase wires a repository into the usecase. clock may be
+// nil to use time.Now.
+func NewCorpusStatsUsecase(repo domain.CorpusStatsRepository, clock func() time.Time) CorpusStatsUsecase {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &corpusStatsUsecase{repo: repo, batchSize: orphanReconcileBatchSize, clock: clock}
+}
+
+func (u *corpusStatsUsecase) Stats(ctx context.Context) (*domain.CorpusStats, error) {
+	stats, err := u.repo.GetCorpusStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("corpus stats: %w", err)
+	}
+	stats.ComputedAt = u.clock().UTC()
+	return stats, nil
+}
+
+func (u *corpusStatsUsecase) ReconcileOrphans(ctx context.Context) (*ReconcileResult, error) {
+	start := u.clock()
+	result := &ReconcileResult{}
+	defer func() {
+		result.Duration = u.clock().Sub(start)
+		result.DurationMS = result.Duration.Milliseconds()
+	}()
+
+	for result.Batches < orphanReconcileMaxBatches {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		deleted, err := u.repo.DeleteOrphanedChunks(ctx, u.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("reconcile orphaned chunks: %w", err)
+		}
+		result.Batches++
+		result.DeletedChunks += deleted
+		if deleted < int64(u.batchSize) {
+			result.Complete = true
+			return result, nil
+		}
+	}
+	return result, nil
+}
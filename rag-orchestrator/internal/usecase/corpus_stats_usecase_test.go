@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCorpusStatsRepo hands out orphans batch by batch from a fixed pool.
+type fakeCorpusStatsRepo struct {
+	orphans   int64
+	failAfter int // fail on this call (1-based); 0 never fails
+	calls     int
+	limits    []int
+}
+
+func (f *fakeCorpusStatsRepo) GetCorpusStats(context.Context) (*domain.CorpusStats, error) {
+	return &domain.CorpusStats{Documents: 3, OrphanedChunks: f.orphans}, nil
+}
+
+func (f *fakeCorpusStatsRepo) DeleteOrphanedChunks(_ context.Context, limit int) (int64, error) {
+	f.calls++
+	f.limits = append(f.limits, limit)
+	if f.failAfter > 0 && f.calls >= f.failAfter {
+		return 0, errors.New("connection reset")
+	}
+	n := min(int64(limit), f.orphans)
+	f.orphans -= n
+	return n, nil
+}
+
+func TestCorpusStatsUsecase_Stats(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	uc := NewCorpusStatsUsecase(&fakeCorpusStatsRepo{orphans: 7}, func() time.Time { return now })
+
+	stats, err := uc.Stats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stats.Documents)
+	assert.Equal(t, int64(7), stats.OrphanedChunks)
+	assert.Equal(t, now.UTC(), stats.ComputedAt)
+}
+
+func TestCorpusStatsUsecase_ReconcileOrphans(t *testing.T) {
+	t.Run("deletes in batches until a short batch", func(t *testing.T) {
+		repo := &fakeCorpusStatsRepo{orphans: 25}
+		uc := &corpusStatsUsecase{repo: repo, batchSize: 10, clock: time.Now}
+
+		result, err := uc.ReconcileOrphans(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(25), result.DeletedChunks)
+		assert.Equal(t, 3, result.Batches)
+		assert.True(t, result.Complete)
+		assert.Equal(t, []int{10, 10, 10}, repo.limits)
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		uc := &corpusStatsUsecase{repo: &fakeCorpusStatsRepo{}, batchSize: 10, clock: time.Now}
+
+		result, err := uc.ReconcileOrphans(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, result.DeletedChunks)
+		assert.Equal(t, 1, result.Batches)
+		assert.True(t, result.Complete)
+	})
+
+	t.Run("stops at the batch cap", func(t *testing.T) {
+		repo := &fakeCorpusStatsRepo{orphans: int64(orphanReconcileMaxBatches+5) * 2}
+		uc := &corpusStatsUsecase{repo: repo, batchSize: 2, clock: time.Now}
+
+		result, err := uc.ReconcileOrphans(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, orphanReconcileMaxBatches, result.Batches)
+		assert.False(t, result.Complete)
+		assert.Equal(t, int64(10), repo.orphans)
+	})
+
+	t.Run("error keeps the committed count", func(t *testing.T) {
+		repo := &fakeCorpusStatsRepo{orphans: 100, failAfter: 3}
+		uc := &corpusStatsUsecase{repo: repo, batchSize: 10, clock: time.Now}
+
+		result, err := uc.ReconcileOrphans(context.Background())
+		require.Error(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, int64(20), result.DeletedChunks)
+		assert.False(t, result.Complete)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"maps"
 	"rag-orchestrator/internal/domain"
 	"time"
 
@@ -15,6 +16,9 @@ import (
 type IndexArticleUsecase interface {
 	// Upsert indexes an article. It is idempotent.
 	Upsert(ctx context.Context, articleID, title, url, body string) error
+	// UpsertWithMetadata is Upsert that also records the article metadata
+	// retrieval filters match on. Zero fields keep the stored values.
+	UpsertWithMetadata(ctx context.Context, articleID, title, url, body string, meta domain.RetrievalMetadata) error
 	// Delete removes an article (soft delete logic).
 	Delete(ctx context.Context, articleID string) error
 }
@@ -47,16 +51,53 @@ func NewIndexArticleUsecase(
 }
 
 func (u *indexArticleUsecase) Upsert(ctx context.Context, articleID, title, url, body string) error {
+	return u.UpsertWithMetadata(ctx, articleID, title, url, body, domain.RetrievalMetadata{})
+}
+
+func (u *indexArticleUsecase) UpsertWithMetadata(ctx context.Context, articleID, title, url, body string, meta domain.RetrievalMetadata) error {
+	return u.upsert(ctx, domain.SourceDocument{
+		SourceType: domain.SourceArticle,
+		ExternalID: articleID,
+		Title:      title,
+		URL:        url,
+		Body:       body,
+		Retrieval:  meta,
+	})
+}
+
+// upsert indexes a document from any source. Articles and connector
+// documents share the versioning, diffing and idempotency logic; only the
+// document key and source columns differ.
+func (u *indexArticleUsecase) upsert(ctx context.Context, src domain.SourceDocument) error {
+	documentKey := domain.SourceDocumentKey(src.SourceType, src.ExternalID)
+	title, url, body := src.Title, src.URL, src.Body
+
 	// 1. Source Hash Calculation
 	sourceHash := u.hasher.Compute(title, body)
 
 	return u.txManager.RunInTx(ctx, func(ctx context.Context) error {
 		// 2. Check existence
-		doc, err := u.docRepo.GetByArticleID(ctx, articleID)
+		doc, err := u.docRepo.GetByArticleID(ctx, documentKey)
 		if err != nil {
 			return fmt.Errorf("failed to get document: %w", err)
 		}
 
+		// Connector metadata lives on the document, not the version, so it
+		// is refreshed even when the content is unchanged.
+		if doc != nil && src.Metadata != nil && !maps.Equal(doc.SourceMetadata, src.Metadata) {
+			if err := u.docRepo.UpdateSourceMetadata(ctx, doc.ID, src.Metadata); err != nil {
+				return fmt.Errorf("failed to update source metadata: %w", err)
+			}
+		}
+		if doc != nil {
+			if merged := src.Retrieval.Merge(doc.Retrieval); !merged.Equal(doc.Retrieval) {
+				if err := u.docRepo.UpdateRetrievalMetadata(ctx, doc.ID, merged); err != nil {
+					return fmt.Errorf("failed to update retrieval metadata: %w", err)
+				}
+				doc.Retrieval = merged
+			}
+		}
+
 		var latestVer *domain.RagDocumentVersion
 		if doc != nil && doc.CurrentVersionID != nil {
 			latestVer, err = u.docRepo.GetLatestVersion(ctx, doc.ID)
@@ -121,10 +162,13 @@ func (u *indexArticleUsecase) Upsert(ctx context.Context, articleID, title, url,
 		// Insert Document if new
 		if doc == nil {
 			doc = &domain.RagDocument{
-				ID:        uuid.New(),
-				ArticleID: articleID,
-				CreatedAt: now,
-				UpdatedAt: now,
+				ID:             uuid.New(),
+				ArticleID:      documentKey,
+				SourceType:     src.SourceType,
+				SourceMetadata: src.Metadata,
+				Retrieval:      src.Retrieval,
+				CreatedAt:      now,
+				UpdatedAt:      now,
 			}
 			if err := u.docRepo.CreateDocument(ctx, doc); err != nil {
 				return fmt.Errorf("failed to create document: %w", err)
@@ -243,8 +287,13 @@ func (u *indexArticleUsecase) Upsert(ctx context.Context, articleID, title, url,
 }
 
 func (u *indexArticleUsecase) Delete(ctx context.Context, articleID string) error {
+	return u.delete(ctx, articleID)
+}
+
+// delete tombstones the document stored under documentKey.
+func (u *indexArticleUsecase) delete(ctx context.Context, documentKey string) error {
 	return u.txManager.RunInTx(ctx, func(ctx context.Context) error {
-		doc, err := u.docRepo.GetByArticleID(ctx, articleID)
+		doc, err := u.docRepo.GetByArticleID(ctx, documentKey)
 		if err != nil {
 			return fmt.Errorf("failed to get document: %w", err)
 		}
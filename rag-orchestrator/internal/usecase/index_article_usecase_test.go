@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"rag-orchestrator/internal/domain"
 	"rag-orchestrator/internal/usecase"
@@ -57,6 +58,16 @@ func (m *MockRagDocumentRepository) CreateVersion(ctx context.Context, version *
 	return args.Error(0)
 }
 
+func (m *MockRagDocumentRepository) UpdateSourceMetadata(ctx context.Context, docID uuid.UUID, metadata map[string]string) error {
+	args := m.Called(ctx, docID, metadata)
+	return args.Error(0)
+}
+
+func (m *MockRagDocumentRepository) UpdateRetrievalMetadata(ctx context.Context, docID uuid.UUID, metadata domain.RetrievalMetadata) error {
+	args := m.Called(ctx, docID, metadata)
+	return args.Error(0)
+}
+
 type MockRagChunkRepository struct {
 	mock.Mock
 }
@@ -153,6 +164,49 @@ func TestIndexArticle_Upsert_Idempotency(t *testing.T) {
 	mockChunkRepo.AssertExpectations(t) // Should not be called
 }
 
+func TestIndexArticle_UpsertWithMetadata_RefreshesUnchangedArticle(t *testing.T) {
+	mockDocRepo := new(MockRagDocumentRepository)
+	mockChunkRepo := new(MockRagChunkRepository)
+	hasher := domain.NewSourceHashPolicy()
+	chunker := domain.NewChunker()
+
+	uc := usecase.NewIndexArticleUsecase(
+		mockDocRepo, mockChunkRepo, new(MockTransactionManager), hasher, chunker, nil,
+	)
+
+	ctx := context.Background()
+	articleID := "article-123"
+	docID := uuid.New()
+	verID := uuid.New()
+	publishedAt := time.Date(2026, 10, 14, 8, 30, 0, 0, time.UTC)
+
+	mockDocRepo.On("GetByArticleID", ctx, articleID).Return(&domain.RagDocument{
+		ID:               docID,
+		ArticleID:        articleID,
+		CurrentVersionID: &verID,
+		Retrieval:        domain.RetrievalMetadata{Tags: []string{"go"}},
+	}, nil)
+	mockDocRepo.On("GetLatestVersion", ctx, docID).Return(&domain.RagDocumentVersion{
+		ID:             verID,
+		DocumentID:     docID,
+		SourceHash:     hasher.Compute("Title", "Body"),
+		Title:          "Title",
+		ChunkerVersion: string(domain.ChunkerVersionV9),
+	}, nil)
+	// Stored tags survive metadata that does not carry any.
+	mockDocRepo.On("UpdateRetrievalMetadata", ctx, docID, domain.RetrievalMetadata{
+		PublishedAt: publishedAt,
+		FeedID:      "feed-1",
+		Tags:        []string{"go"},
+	}).Return(nil)
+
+	err := uc.UpsertWithMetadata(ctx, articleID, "Title", "", "Body", domain.RetrievalMetadata{PublishedAt: publishedAt, FeedID: "feed-1"})
+
+	assert.NoError(t, err)
+	mockDocRepo.AssertExpectations(t)
+	mockChunkRepo.AssertExpectations(t)
+}
+
 func TestIndexArticle_Upsert_NewArticle(t *testing.T) {
 	mockDocRepo := new(MockRagDocumentRepository)
 	mockChunkRepo := new(MockRagChunkRepository)
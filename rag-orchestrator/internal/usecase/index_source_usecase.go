@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"rag-orchestrator/internal/domain"
+	"time"
+)
+
+// ErrInvalidSourceDocument is returned for source documents that fail
+// validation or claim the article source, which is reserved for the alt
+// article pipeline (IndexArticleUsecase).
+var ErrInvalidSourceDocument = errors.New("invalid source document")
+
+// SourceSyncResult summarises one connector sync.
+type SourceSyncResult struct {
+	Indexed int
+	Deleted int
+	Failed  int
+	// Cursor is the newest UpdatedAt indexed; pass it as since on the next
+	// sync. It stays at the input since when any document failed, so the
+	// next sync retries the whole window (unchanged documents are skipped
+	// by the source-hash check).
+	Cursor time.Time
+}
+
+// IndexSourceUsecase indexes documents from non-article sources (bookmarks,
+// notes, uploads). Documents go through the same chunk, embed and version
+// pipeline as articles and are tagged with their source type so retrieval
+// can filter on it.
+type IndexSourceUsecase interface {
+	// Index upserts doc, or tombstones it when doc.Deleted is set.
+	Index(ctx context.Context, doc domain.SourceDocument) error
+	// Sync indexes everything connector reports changed since the given
+	// time. Per-document failures are logged and counted; only a failed
+	// fetch is returned as an error.
+	Sync(ctx context.Context, connector domain.SourceConnector, since time.Time) (SourceSyncResult, error)
+}
+
+type indexSourceUsecase struct {
+	indexer *indexArticleUsecase
+	logger  *slog.Logger
+}
+
+// NewIndexSourceUsecase creates an IndexSourceUsecase sharing the article
+// indexer's repositories and policies.
+func NewIndexSourceUsecase(
+	docRepo domain.RagDocumentRepository,
+	chunkRepo domain.RagChunkRepository,
+	txManager domain.TransactionManager,
+	hasher domain.SourceHashPolicy,
+	chunker domain.Chunker,
+	encoder domain.VectorEncoder,
+	logger *slog.Logger,
+) IndexSourceUsecase {
+	return &indexSourceUsecase{
+		indexer: &indexArticleUsecase{
+			docRepo:   docRepo,
+			chunkRepo: chunkRepo,
+			txManager: txManager,
+			hasher:    hasher,
+			chunker:   chunker,
+			encoder:   encoder,
+		},
+		logger: logger,
+	}
+}
+
+func (u *indexSourceUsecase) Index(ctx context.Context, doc domain.SourceDocument) error {
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSourceDocument, err)
+	}
+	if doc.SourceType == domain.SourceArticle {
+		return fmt.Errorf("%w: articles are indexed through the article pipeline", ErrInvalidSourceDocument)
+	}
+
+	if doc.Deleted {
+		return u.indexer.delete(ctx, domain.SourceDocumentKey(doc.SourceType, doc.ExternalID))
+	}
+	return u.indexer.upsert(ctx, doc)
+}
+
+func (u *indexSourceUsecase) Sync(ctx context.Context, connector domain.SourceConnector, since time.Time) (SourceSyncResult, error) {
+	result := SourceSyncResult{Cursor: since}
+	sourceType := connector.Type()
+
+	docs, err := connector.Fetch(ctx, since)
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch %s documents: %w", sourceType, err)
+	}
+
+	for _, doc := range docs {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if doc.SourceType == "" {
+			doc.SourceType = sourceType
+		}
+		if doc.SourceType != sourceType {
+			result.Failed++
+			u.logger.Warn("source_sync_type_mismatch",
+				slog.String("source_type", string(sourceType)),
+				slog.String("document_source_type", string(doc.SourceType)),
+				slog.String("external_id", doc.ExternalID))
+			continue
+		}
+
+		if err := u.Index(ctx, doc); err != nil {
+			result.Failed++
+			u.logger.Warn("source_sync_document_failed",
+				slog.String("source_type", string(sourceType)),
+				slog.String("external_id", doc.ExternalID),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if doc.Deleted {
+			result.Deleted++
+		} else {
+			result.Indexed++
+		}
+		if doc.UpdatedAt.After(result.Cursor) {
+			result.Cursor = doc.UpdatedAt
+		}
+	}
+
+	if result.Failed > 0 {
+		result.Cursor = since
+	}
+
+	u.logger.Info("source_sync_completed",
+		slog.String("source_type", string(sourceType)),
+		slog.Int("fetched", len(docs)),
+		slog.Int("indexed", result.Indexed),
+		slog.Int("deleted", result.Deleted),
+		slog.Int("failed", result.Failed))
+	return result, nil
+}
@@ -0,0 +1,392 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"rag-orchestrator/internal/domain"
+)
+
+// ModelRoute is the query class that picks a generator configuration.
+type ModelRoute string
+
+const (
+	// ModelRouteFactual is a short lookup ("who", "when", "is it true")
+	// that a small, fast model answers as well as a large one.
+	ModelRouteFactual ModelRoute = "factual"
+	// ModelRouteSummarization condenses retrieved articles (digests,
+	// recaps, morning letters).
+	ModelRouteSummarization ModelRoute = "summarization"
+	// ModelRouteOpenEnded needs reasoning across sources (comparisons,
+	// causes, deep dives) and goes to the largest configured model.
+	ModelRouteOpenEnded ModelRoute = "open_ended"
+)
+
+// modelRouteUnrouted labels calls made without a route in the context,
+// e.g. tool planning.
+const modelRouteUnrouted = "unrouted"
+
+// factualQueryMaxRunes bounds what counts as a factual lookup. Longer
+// questions tend to carry several sub-questions and go open-ended.
+const factualQueryMaxRunes = 60
+
+// ParseModelRoute validates a route name from config.
+func ParseModelRoute(s string) (ModelRoute, error) {
+	switch route := ModelRoute(strings.ToLower(strings.TrimSpace(s))); route {
+	case ModelRouteFactual, ModelRouteSummarization, ModelRouteOpenEnded:
+		return route, nil
+	default:
+		return "", fmt.Errorf("unknown model route %q (want factual, summarization or open_ended)", s)
+	}
+}
+
+// ClassifyModelRoute maps a classified query to a model route. It reuses
+// the intent the retrieval pipeline already computed and only looks at the
+// query text to separate lookups and summaries from general questions.
+// Pure function — does not use LLM or context.
+func ClassifyModelRoute(intent IntentType, subIntent SubIntentType, query string) ModelRoute {
+	switch subIntent {
+	case SubIntentSummaryRefresh:
+		return ModelRouteSummarization
+	case SubIntentRelatedArticles:
+		return ModelRouteFactual
+	case SubIntentCritique, SubIntentOpinion, SubIntentImplication, SubIntentDetail, SubIntentEvidence:
+		return ModelRouteOpenEnded
+	}
+
+	switch intent {
+	case IntentComparison, IntentCausalExplanation, IntentTopicDeepDive, IntentSynthesis:
+		return ModelRouteOpenEnded
+	case IntentFactCheck:
+		return ModelRouteFactual
+	case IntentTemporal:
+		return ModelRouteSummarization
+	}
+
+	lower := strings.ToLower(query)
+	if matchesSummarization(query, lower) {
+		return ModelRouteSummarization
+	}
+	if matchesFactualLookup(query, lower) {
+		return ModelRouteFactual
+	}
+	return ModelRouteOpenEnded
+}
+
+func matchesSummarization(query, lower string) bool {
+	jpKeywords := []string{"要約", "まとめ", "要点", "概要", "ざっくり"}
+	for _, kw := range jpKeywords {
+		if strings.Contains(query, kw) {
+			return true
+		}
+	}
+	enKeywords := []string{"summarize", "summarise", "summary", "tl;dr", "tldr", "key points", "recap"}
+	for _, kw := range enKeywords {
+		if strings.Contains(lower, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesFactualLookup(query, lower string) bool {
+	if utf8.RuneCountInString(strings.TrimSpace(query)) > factualQueryMaxRunes {
+		return false
+	}
+	jpKeywords := []string{"いつ", "誰", "どこで", "どこの", "何人", "何年", "何日", "いくら", "何歳", "名前は"}
+	for _, kw := range jpKeywords {
+		if strings.Contains(query, kw) {
+			return true
+		}
+	}
+	enPrefixes := []string{"who ", "when ", "where ", "which ", "how many ", "how much ", "what year ", "what date "}
+	trimmed := strings.TrimSpace(lower)
+	for _, p := range enPrefixes {
+		if strings.HasPrefix(trimmed, p) {
+			return true
+		}
+	}
+	return false
+}
+
+type modelRouteKey struct{}
+
+// WithModelRoute tags ctx so a ModelRouter sends the calls made with it to
+// the route's generator.
+func WithModelRoute(ctx context.Context, route ModelRoute) context.Context {
+	if route == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, modelRouteKey{}, route)
+}
+
+// ModelRouteFromContext returns the route set by WithModelRoute.
+func ModelRouteFromContext(ctx context.Context) (ModelRoute, bool) {
+	route, ok := ctx.Value(modelRouteKey{}).(ModelRoute)
+	return route, ok && route != ""
+}
+
+// ModelRouteTarget is the generator configuration of one route.
+type ModelRouteTarget struct {
+	Client domain.LLMClient
+	// CostPer1KTokens weights prompt plus completion tokens into the
+	// per-route cost metric. Zero records tokens without a cost.
+	CostPer1KTokens float64
+}
+
+// ModelRouteObservation describes one generator call made by a ModelRouter.
+type ModelRouteObservation struct {
+	// Route is the routed class, or "unrouted" when the context had none.
+	Route string
+	// Model is the Version() of the client that served the call.
+	Model string
+	// Method is chat, chat_stream, chat_with_tools, generate or
+	// generate_stream.
+	Method string
+	// Outcome is "success" or "error".
+	Outcome          string
+	Latency          time.Duration
+	PromptTokens     int
+	CompletionTokens int
+	Cost             float64
+}
+
+// ModelRouteRecorder receives every routed call, e.g. for Prometheus.
+type ModelRouteRecorder interface {
+	RecordModelRouteCall(obs ModelRouteObservation)
+}
+
+// ModelRouter is a domain.LLMClient that dispatches each call to the
+// generator configured for the route in its context. Routes without a
+// configured generator, and calls without a route, use the fallback.
+type ModelRouter struct {
+	fallback domain.LLMClient
+	routes   map[ModelRoute]ModelRouteTarget
+	recorder ModelRouteRecorder
+	logger   *slog.Logger
+}
+
+var (
+	_ domain.LLMClient            = (*ModelRouter)(nil)
+	_ domain.ToolCallingLLMClient = (*ModelRouter)(nil)
+)
+
+// NewModelRouter builds a router over fallback. recorder may be nil.
+func NewModelRouter(fallback domain.LLMClient, routes map[ModelRoute]ModelRouteTarget, recorder ModelRouteRecorder, logger *slog.Logger) *ModelRouter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ModelRouter{fallback: fallback, routes: routes, recorder: recorder, logger: logger}
+}
+
+// Routes returns the model each route is served by, for startup logging.
+func (r *ModelRouter) Routes() map[string]string {
+	out := make(map[string]string, 3)
+	for _, route := range []ModelRoute{ModelRouteFactual, ModelRouteSummarization, ModelRouteOpenEnded} {
+		target, _ := r.target(route)
+		out[string(route)] = target.Client.Version()
+	}
+	return out
+}
+
+// Version returns the fallback generator's version, which serves every
+// call that is not routed elsewhere.
+func (r *ModelRouter) Version() string {
+	return r.fallback.Version()
+}
+
+// Generate routes a single-prompt generation.
+func (r *ModelRouter) Generate(ctx context.Context, prompt string, maxTokens int) (*domain.LLMResponse, error) {
+	label, target := r.resolve(ctx)
+	start := time.Now()
+	resp, err := target.Client.Generate(ctx, prompt, maxTokens)
+	r.recordResponse(label, target, "generate", start, resp, err)
+	return resp, err
+}
+
+// Chat routes a chat completion.
+func (r *ModelRouter) Chat(ctx context.Context, messages []domain.Message, maxTokens int) (*domain.LLMResponse, error) {
+	label, target := r.resolve(ctx)
+	start := time.Now()
+	resp, err := target.Client.Chat(ctx, messages, maxTokens)
+	r.recordResponse(label, target, "chat", start, resp, err)
+	return resp, err
+}
+
+// ChatWithTools routes a tool-calling chat. A route whose generator cannot
+// call tools falls back to the fallback generator.
+func (r *ModelRouter) ChatWithTools(ctx context.Context, messages []domain.Message, tools []domain.ToolDefinition, maxTokens int) (*domain.LLMResponse, error) {
+	label, target := r.resolve(ctx)
+	toolClient, ok := target.Client.(domain.ToolCallingLLMClient)
+	if !ok {
+		target = ModelRouteTarget{Client: r.fallback}
+		if toolClient, ok = r.fallback.(domain.ToolCallingLLMClient); !ok {
+			return nil, fmt.Errorf("model router: generator %s does not support tool calling", r.fallback.Version())
+		}
+	}
+	start := time.Now()
+	resp, err := toolClient.ChatWithTools(ctx, messages, tools, maxTokens)
+	r.recordResponse(label, target, "chat_with_tools", start, resp, err)
+	return resp, err
+}
+
+// GenerateStream routes a streaming generation. The call is recorded when
+// the stream ends.
+func (r *ModelRouter) GenerateStream(ctx context.Context, prompt string, maxTokens int) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	label, target := r.resolve(ctx)
+	start := time.Now()
+	chunks, errs, err := target.Client.GenerateStream(ctx, prompt, maxTokens)
+	if err != nil {
+		r.recordResponse(label, target, "generate_stream", start, nil, err)
+		return nil, nil, err
+	}
+	return r.observeStream(ctx, label, target, "generate_stream", start, chunks, errs)
+}
+
+// ChatStream routes a streaming chat. The call is recorded when the stream
+// ends.
+func (r *ModelRouter) ChatStream(ctx context.Context, messages []domain.Message, maxTokens int) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	label, target := r.resolve(ctx)
+	start := time.Now()
+	chunks, errs, err := target.Client.ChatStream(ctx, messages, maxTokens)
+	if err != nil {
+		r.recordResponse(label, target, "chat_stream", start, nil, err)
+		return nil, nil, err
+	}
+	return r.observeStream(ctx, label, target, "chat_stream", start, chunks, errs)
+}
+
+func (r *ModelRouter) target(route ModelRoute) (ModelRouteTarget, bool) {
+	if target, ok := r.routes[route]; ok && target.Client != nil {
+		return target, true
+	}
+	return ModelRouteTarget{Client: r.fallback}, false
+}
+
+func (r *ModelRouter) resolve(ctx context.Context) (string, ModelRouteTarget) {
+	route, ok := ModelRouteFromContext(ctx)
+	if !ok {
+		return modelRouteUnrouted, ModelRouteTarget{Client: r.fallback}
+	}
+	target, _ := r.target(route)
+	return string(route), target
+}
+
+// observeStream forwards a stream unchanged and records the call once both
+// channels are closed.
+func (r *ModelRouter) observeStream(
+	ctx context.Context,
+	label string,
+	target ModelRouteTarget,
+	method string,
+	start time.Time,
+	chunks <-chan domain.LLMStreamChunk,
+	errs <-chan error,
+) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	outChunks, outErrs := observeLLMStream(ctx, chunks, errs, func(promptTokens, completionTokens int, err error) {
+		r.record(label, target, method, start, promptTokens, completionTokens, err)
+	})
+	return outChunks, outErrs, nil
+}
+
+// observeLLMStream forwards a stream unchanged and calls done once both
+// channels are closed, with the token counts taken from the chunks that
+// carry them and the first stream error. done runs before the forwarded
+// channels close, so a consumer that drains them sees its effects.
+func observeLLMStream(
+	ctx context.Context,
+	chunks <-chan domain.LLMStreamChunk,
+	errs <-chan error,
+	done func(promptTokens, completionTokens int, err error),
+) (<-chan domain.LLMStreamChunk, <-chan error) {
+	outChunks := make(chan domain.LLMStreamChunk)
+	outErrs := make(chan error, 1)
+
+	go func() {
+		defer close(outErrs)
+		defer close(outChunks)
+
+		var (
+			promptTokens, completionTokens int
+			streamErr                      error
+		)
+		defer func() {
+			done(promptTokens, completionTokens, streamErr)
+		}()
+
+		for chunks != nil || errs != nil {
+			select {
+			case chunk, ok := <-chunks:
+				if !ok {
+					chunks = nil
+					continue
+				}
+				if chunk.PromptEvalCount != nil {
+					promptTokens = *chunk.PromptEvalCount
+				}
+				if chunk.EvalCount != nil {
+					completionTokens = *chunk.EvalCount
+				}
+				select {
+				case outChunks <- chunk:
+				case <-ctx.Done():
+					streamErr = ctx.Err()
+					drainLLMStream(chunks, errs)
+					return
+				}
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				if err != nil && streamErr == nil {
+					streamErr = err
+					outErrs <- err
+				}
+			}
+		}
+	}()
+
+	return outChunks, outErrs
+}
+
+func (r *ModelRouter) recordResponse(label string, target ModelRouteTarget, method string, start time.Time, resp *domain.LLMResponse, err error) {
+	var promptTokens, completionTokens int
+	if resp != nil {
+		promptTokens, completionTokens = resp.PromptTokens, resp.CompletionTokens
+	}
+	r.record(label, target, method, start, promptTokens, completionTokens, err)
+}
+
+func (r *ModelRouter) record(label string, target ModelRouteTarget, method string, start time.Time, promptTokens, completionTokens int, err error) {
+	obs := ModelRouteObservation{
+		Route:            label,
+		Model:            target.Client.Version(),
+		Method:           method,
+		Outcome:          "success",
+		Latency:          time.Since(start),
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		Cost:             float64(promptTokens+completionTokens) / 1000 * target.CostPer1KTokens,
+	}
+	if err != nil {
+		obs.Outcome = "error"
+	}
+
+	r.logger.Debug("model_route_call",
+		slog.String("route", obs.Route),
+		slog.String("model", obs.Model),
+		slog.String("method", obs.Method),
+		slog.String("outcome", obs.Outcome),
+		slog.Int64("latency_ms", obs.Latency.Milliseconds()),
+		slog.Int("prompt_tokens", obs.PromptTokens),
+		slog.Int("completion_tokens", obs.CompletionTokens))
+
+	if r.recorder != nil {
+		r.recorder.RecordModelRouteCall(obs)
+	}
+}
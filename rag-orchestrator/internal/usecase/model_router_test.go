@@ -0,0 +1,202 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type routeStubLLM struct {
+	model string
+	resp  *domain.LLMResponse
+	err   error
+	calls int
+}
+
+func (c *routeStubLLM) Generate(context.Context, string, int) (*domain.LLMResponse, error) {
+	c.calls++
+	return c.resp, c.err
+}
+
+func (c *routeStubLLM) GenerateStream(context.Context, string, int) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	panic("unused")
+}
+
+func (c *routeStubLLM) Chat(context.Context, []domain.Message, int) (*domain.LLMResponse, error) {
+	c.calls++
+	return c.resp, c.err
+}
+
+func (c *routeStubLLM) ChatStream(context.Context, []domain.Message, int) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	c.calls++
+	prompt, eval := 120, 80
+	chunkCh := make(chan domain.LLMStreamChunk, 2)
+	errCh := make(chan error)
+	chunkCh <- domain.LLMStreamChunk{Response: "hello"}
+	chunkCh <- domain.LLMStreamChunk{Done: true, PromptEvalCount: &prompt, EvalCount: &eval}
+	close(chunkCh)
+	close(errCh)
+	return chunkCh, errCh, nil
+}
+
+func (c *routeStubLLM) Version() string { return c.model }
+
+type fakeModelRouteRecorder struct {
+	mu  sync.Mutex
+	obs []usecase.ModelRouteObservation
+}
+
+func (r *fakeModelRouteRecorder) RecordModelRouteCall(obs usecase.ModelRouteObservation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.obs = append(r.obs, obs)
+}
+
+func (r *fakeModelRouteRecorder) observations() []usecase.ModelRouteObservation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]usecase.ModelRouteObservation(nil), r.obs...)
+}
+
+func TestClassifyModelRoute(t *testing.T) {
+	tests := []struct {
+		name      string
+		intent    usecase.IntentType
+		subIntent usecase.SubIntentType
+		query     string
+		want      usecase.ModelRoute
+	}{
+		{"fact check", usecase.IntentFactCheck, "", "AppleがVision Proを出したのは本当？", usecase.ModelRouteFactual},
+		{"jp lookup", usecase.IntentGeneral, "", "OpenAIのCEOは誰？", usecase.ModelRouteFactual},
+		{"en lookup", usecase.IntentGeneral, "", "When was Go 1.22 released?", usecase.ModelRouteFactual},
+		{"related articles", usecase.IntentArticleScoped, usecase.SubIntentRelatedArticles, "関連記事は？", usecase.ModelRouteFactual},
+		{"summary keyword", usecase.IntentGeneral, "", "半導体規制のニュースを要約して", usecase.ModelRouteSummarization},
+		{"en summary keyword", usecase.IntentGeneral, "", "Give me a summary of the Rust 2024 edition", usecase.ModelRouteSummarization},
+		{"temporal digest", usecase.IntentTemporal, "", "最近のAIニュースは？", usecase.ModelRouteSummarization},
+		{"summary refresh", usecase.IntentArticleScoped, usecase.SubIntentSummaryRefresh, "結論だけ", usecase.ModelRouteSummarization},
+		{"comparison", usecase.IntentComparison, "", "RustとGoの違いは？", usecase.ModelRouteOpenEnded},
+		{"causal", usecase.IntentCausalExplanation, "", "円安の原因は？", usecase.ModelRouteOpenEnded},
+		{"article critique", usecase.IntentArticleScoped, usecase.SubIntentCritique, "この記事の弱点は？", usecase.ModelRouteOpenEnded},
+		{"general question", usecase.IntentGeneral, "", "生成AIは教育をどう変えていくと考えられる？", usecase.ModelRouteOpenEnded},
+		{"long lookup", usecase.IntentGeneral, "", "Who are the main contributors to the Linux kernel scheduler rework and what did each of them change over the last few releases?", usecase.ModelRouteOpenEnded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, usecase.ClassifyModelRoute(tt.intent, tt.subIntent, tt.query))
+		})
+	}
+}
+
+func TestParseModelRoute(t *testing.T) {
+	route, err := usecase.ParseModelRoute(" Open_Ended ")
+	require.NoError(t, err)
+	assert.Equal(t, usecase.ModelRouteOpenEnded, route)
+
+	_, err = usecase.ParseModelRoute("chitchat")
+	assert.Error(t, err)
+}
+
+func newTestModelRouter(recorder usecase.ModelRouteRecorder) (*usecase.ModelRouter, *routeStubLLM, *routeStubLLM) {
+	large := &routeStubLLM{model: "large", resp: &domain.LLMResponse{Text: "large", PromptTokens: 1500, CompletionTokens: 500}}
+	small := &routeStubLLM{model: "small", resp: &domain.LLMResponse{Text: "small", PromptTokens: 300, CompletionTokens: 200}}
+	router := usecase.NewModelRouter(large, map[usecase.ModelRoute]usecase.ModelRouteTarget{
+		usecase.ModelRouteFactual:   {Client: small, CostPer1KTokens: 0.5},
+		usecase.ModelRouteOpenEnded: {Client: large, CostPer1KTokens: 2},
+	}, recorder, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return router, large, small
+}
+
+func TestModelRouter_DispatchesByContextRoute(t *testing.T) {
+	recorder := &fakeModelRouteRecorder{}
+	router, large, small := newTestModelRouter(recorder)
+	ctx := context.Background()
+
+	resp, err := router.Chat(usecase.WithModelRoute(ctx, usecase.ModelRouteFactual), nil, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "small", resp.Text)
+
+	// Summarization has no entry and falls back to the default generator.
+	resp, err = router.Chat(usecase.WithModelRoute(ctx, usecase.ModelRouteSummarization), nil, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "large", resp.Text)
+
+	resp, err = router.Generate(ctx, "plan", 100)
+	require.NoError(t, err)
+	assert.Equal(t, "large", resp.Text)
+
+	assert.Equal(t, 1, small.calls)
+	assert.Equal(t, 2, large.calls)
+
+	obs := recorder.observations()
+	require.Len(t, obs, 3)
+	assert.Equal(t, "factual", obs[0].Route)
+	assert.Equal(t, "small", obs[0].Model)
+	assert.Equal(t, "chat", obs[0].Method)
+	assert.Equal(t, "success", obs[0].Outcome)
+	assert.InDelta(t, 0.25, obs[0].Cost, 1e-9)
+	assert.Equal(t, "summarization", obs[1].Route)
+	assert.Equal(t, "large", obs[1].Model)
+	assert.Zero(t, obs[1].Cost, "unconfigured routes record tokens without a cost")
+	assert.Equal(t, "unrouted", obs[2].Route)
+	assert.Equal(t, "generate", obs[2].Method)
+}
+
+func TestModelRouter_RecordsErrors(t *testing.T) {
+	recorder := &fakeModelRouteRecorder{}
+	router, _, small := newTestModelRouter(recorder)
+	small.resp, small.err = nil, errors.New("boom")
+
+	_, err := router.Chat(usecase.WithModelRoute(context.Background(), usecase.ModelRouteFactual), nil, 100)
+	require.Error(t, err)
+
+	obs := recorder.observations()
+	require.Len(t, obs, 1)
+	assert.Equal(t, "error", obs[0].Outcome)
+	assert.Zero(t, obs[0].PromptTokens)
+}
+
+func TestModelRouter_StreamRecordsTokensAtEnd(t *testing.T) {
+	recorder := &fakeModelRouteRecorder{}
+	router, large, _ := newTestModelRouter(recorder)
+
+	chunkCh, errCh, err := router.ChatStream(usecase.WithModelRoute(context.Background(), usecase.ModelRouteOpenEnded), nil, 100)
+	require.NoError(t, err)
+
+	var text string
+	for chunk := range chunkCh {
+		text += chunk.Response
+	}
+	for streamErr := range errCh {
+		require.NoError(t, streamErr)
+	}
+	assert.Equal(t, "hello", text)
+	assert.Equal(t, 1, large.calls)
+
+	require.Eventually(t, func() bool { return len(recorder.observations()) == 1 }, time.Second, 10*time.Millisecond)
+	obs := recorder.observations()[0]
+	assert.Equal(t, "open_ended", obs.Route)
+	assert.Equal(t, "chat_stream", obs.Method)
+	assert.Equal(t, 120, obs.PromptTokens)
+	assert.Equal(t, 80, obs.CompletionTokens)
+	assert.InDelta(t, 0.4, obs.Cost, 1e-9)
+}
+
+func TestModelRouter_VersionAndRoutes(t *testing.T) {
+	router, _, _ := newTestModelRouter(nil)
+	assert.Equal(t, "large", router.Version())
+	assert.Equal(t, map[string]string{
+		"factual":       "small",
+		"summarization": "large",
+		"open_ended":    "large",
+	}, router.Routes())
+}
@@ -212,7 +212,7 @@ func (u *morningLetterUsecase) Execute(ctx context.Context, input MorningLetterI
 	}
 
 	// 7. Generate topics via LLM
-	response, err := u.llmClient.Chat(ctx, messages, u.maxTokens)
+	response, err := u.llmClient.Chat(WithModelRoute(ctx, ModelRouteSummarization), messages, u.maxTokens)
 	if err != nil {
 		u.logger.Error("LLM generation failed", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("LLM generation failed: %w", err)
@@ -107,7 +107,7 @@ func TestMorningLetterUsecase_Execute_Success(t *testing.T) {
 	}, nil)
 
 	// Mock LLM Chat
-	mockLLM.On("Chat", ctx, mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
+	mockLLM.On("Chat", usecase.WithModelRoute(ctx, usecase.ModelRouteSummarization), mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
 		Text: `{
 			"topics": [
 				{
@@ -338,7 +338,7 @@ func TestMorningLetterUsecase_Execute_MaxTokensPassedToLLM(t *testing.T) {
 	}, nil)
 
 	// Verify customMaxTokens is passed through to LLM
-	mockLLM.On("Chat", ctx, mock.AnythingOfType("[]domain.Message"), customMaxTokens).Return(&domain.LLMResponse{
+	mockLLM.On("Chat", usecase.WithModelRoute(ctx, usecase.ModelRouteSummarization), mock.AnythingOfType("[]domain.Message"), customMaxTokens).Return(&domain.LLMResponse{
 		Text: `{"topics": [{"topic": "Tech", "headline": "News", "summary": "Summary", "importance": 0.9, "article_refs": [], "keywords": ["tech"]}], "meta": {"topics_found": 1, "coverage_assessment": "ok"}}`,
 		Done: true,
 	}, nil)
@@ -412,7 +412,7 @@ func TestMorningLetterUsecase_Execute_ContextTokenLimiting(t *testing.T) {
 		{Role: "user", Content: "analyze"},
 	}, nil)
 
-	mockLLM.On("Chat", ctx, mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
+	mockLLM.On("Chat", usecase.WithModelRoute(ctx, usecase.ModelRouteSummarization), mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
 		Text: `{"topics": [{"topic": "Tech", "headline": "News", "summary": "Summary", "importance": 0.9, "article_refs": [], "keywords": ["tech"]}], "meta": {"topics_found": 1, "coverage_assessment": "ok"}}`,
 		Done: true,
 	}, nil)
@@ -469,7 +469,7 @@ func TestMorningLetterUsecase_MaxTokensDefaultsWhenZero(t *testing.T) {
 	}, nil)
 
 	// Should use default 4096
-	mockLLM.On("Chat", ctx, mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
+	mockLLM.On("Chat", usecase.WithModelRoute(ctx, usecase.ModelRouteSummarization), mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
 		Text: `{"topics": [], "meta": {"topics_found": 0, "coverage_assessment": "none"}}`,
 		Done: true,
 	}, nil)
@@ -551,7 +551,7 @@ func TestMorningLetterUsecase_Execute_EnrichesArticleRefsFromPositionalIndices(t
 	}, nil)
 
 	// LLM output follows the prompt's own documented contract: integer indices.
-	mockLLM.On("Chat", ctx, mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
+	mockLLM.On("Chat", usecase.WithModelRoute(ctx, usecase.ModelRouteSummarization), mock.AnythingOfType("[]domain.Message"), 4096).Return(&domain.LLMResponse{
 		Text: `{
 			"topics": [
 				{
@@ -266,6 +266,9 @@ type LLMAnswer struct {
 	Fallback    bool          `json:"fallback"`
 	Reason      string        `json:"reason"`
 	ShortAnswer bool          // Internal flag: true when answer is below minAnswerLength (rune count)
+	// LengthViolation is set by CheckAnswerLength when the answer grossly
+	// misses the caller-requested AnswerLength ("too_short" / "too_long").
+	LengthViolation string
 }
 
 // LLMCitation declares the chunks referenced in the final answer.
@@ -32,6 +32,10 @@ type PromptInput struct {
 	SupplementaryInfo   []string              // Tool results injected as supplementary context (Phase 3)
 	PlannerOutput       *domain.PlannerOutput // Planner-driven prompt shaping (nil = legacy mode)
 	LowConfidence       bool                  // Retrieval quality insufficient — add disclaimer to prompt
+	AnswerLength        AnswerLength          // Caller-requested answer length (empty = default)
+	ReadingLevel        ReadingLevel          // Caller-requested reading level (empty = default)
+	AnswerFormat        AnswerFormat          // Caller-requested bullet/prose layout (empty = default)
+	MaxSentences        int                   // Caller-requested sentence limit (0 = none)
 }
 
 // PromptBuilder builds the chat messages sent to the LLM.
@@ -87,10 +91,17 @@ func (b *XMLPromptBuilder) Build(input PromptInput) ([]domain.Message, error) {
 		return b.v2Registry.Build(input)
 	}
 
+	var messages []domain.Message
+	var err error
 	if len(input.ConversationHistory) > 0 {
-		return b.buildMultiTurn(input)
+		messages, err = b.buildMultiTurn(input)
+	} else {
+		messages, err = b.buildSingleTurn(input)
 	}
-	return b.buildSingleTurn(input)
+	if err != nil {
+		return nil, err
+	}
+	return appendAnswerStyle(messages, input), nil
 }
 
 // buildSingleTurn creates a system message with instructions and a user message with context + query.
@@ -209,7 +220,7 @@ func (b *XMLPromptBuilder) writeFullInstructions(sb *strings.Builder, input Prom
 	sb.WriteString("## 回答の品質基準\n")
 	sb.WriteString("- **必ず日本語で回答すること**。ソース記事が英語であっても、回答は日本語で記述すること\n")
 	sb.WriteString("- 結論を最初に述べ、その後で根拠と詳細を説明すること\n")
-	if input.SubIntentType != SubIntentNone {
+	if input.SubIntentType != SubIntentNone || input.AnswerLength != AnswerLengthDefault || input.MaxSentences > 0 {
 		sb.WriteString("- 回答は具体的な事実・データ・事例を含むこと\n")
 	} else {
 		sb.WriteString("- 回答は800文字以上で、具体的な事実・データ・事例を含むこと\n")
@@ -223,7 +234,10 @@ func (b *XMLPromptBuilder) writeFullInstructions(sb *strings.Builder, input Prom
 	// Generic summary structure only for queries without a specific SubIntent.
 	// SubIntents have their own task-specific guidance below, and mixing in
 	// 概要/詳細/まとめ sends the model conflicting signals about answer shape.
-	if input.SubIntentType == SubIntentNone && input.IntentType != IntentCausalExplanation && input.IntentType != IntentFactCheck && input.IntentType != IntentSynthesis {
+	// Short/medium answers skip it too: the three-section skeleton alone
+	// pushes the model past the requested length.
+	if input.SubIntentType == SubIntentNone && input.IntentType != IntentCausalExplanation && input.IntentType != IntentFactCheck && input.IntentType != IntentSynthesis &&
+		input.AnswerLength != AnswerLengthShort && input.AnswerLength != AnswerLengthMedium && input.MaxSentences == 0 {
 		sb.WriteString("## 回答構造\n")
 		sb.WriteString("1. **概要**: 結論と全体像を2-3文で説明（最重要ポイントを冒頭に）\n")
 		sb.WriteString("2. **詳細**: 具体的な事実・データ・事例を含む本文（最も重要なセクション）\n")
@@ -51,10 +51,17 @@ func (r *TemplateRegistry) Build(input PromptInput) ([]domain.Message, error) {
 
 	tmpl := r.resolve(input.IntentType)
 
+	var messages []domain.Message
+	var err error
 	if len(input.ConversationHistory) > 0 {
-		return r.buildMultiTurn(tmpl, input)
+		messages, err = r.buildMultiTurn(tmpl, input)
+	} else {
+		messages, err = r.buildSingleTurn(tmpl, input)
 	}
-	return r.buildSingleTurn(tmpl, input)
+	if err != nil {
+		return nil, err
+	}
+	return appendAnswerStyle(messages, input), nil
 }
 
 // EstimateSystemTokens returns the estimated system prompt token count.
@@ -175,3 +182,9 @@ func buildUserMessage(input PromptInput) string {
 func estimateTokens(text string) int {
 	return len([]rune(text)) / 3
 }
+
+// EstimateTokens exposes estimateTokens for adapters that report token
+// usage without access to the model's tokenizer.
+func EstimateTokens(text string) int {
+	return estimateTokens(text)
+}
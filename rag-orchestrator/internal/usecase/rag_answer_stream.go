@@ -24,6 +24,8 @@ const doneSendTimeout = 5 * time.Second
 // handler side keys off Done.Answer != "" — empty answers signal "nothing
 // worth keeping" (clarification, hard-fail before any LLM output).
 func (u *answerWithRAGUsecase) Stream(ctx context.Context, input AnswerWithRAGInput) <-chan StreamEvent {
+	ctx = domain.WithSourceFilter(ctx, input.SourceTypes)
+	ctx = domain.WithRetrievalFilter(ctx, input.Filter)
 	events := make(chan StreamEvent, 4)
 	go func() {
 		defer close(events)
@@ -215,7 +217,7 @@ func (u *answerWithRAGUsecase) Stream(ctx context.Context, input AnswerWithRAGIn
 		}
 		chatStreamCh := make(chan chatStreamResult, 1)
 		go func() {
-			ch, ech, setupErr := u.llmClient.ChatStream(ctx, messages, promptData.maxTokens)
+			ch, ech, setupErr := u.llmClient.ChatStream(WithModelRoute(ctx, promptData.modelRoute), messages, promptData.maxTokens)
 			chatStreamCh <- chatStreamResult{chunkCh: ch, errCh: ech, err: setupErr}
 		}()
 
@@ -378,6 +380,18 @@ func (u *answerWithRAGUsecase) Stream(ctx context.Context, input AnswerWithRAGIn
 			slog.Int("answer_length", len(parsedAnswer.Answer)),
 			slog.Int("citations_count", len(parsedAnswer.Citations)))
 
+		// Deltas are already on the wire, so a length violation cannot be
+		// regenerated here; surface it for observability only.
+		if input.AnswerLength != AnswerLengthDefault {
+			u.validator.CheckAnswerLength(parsedAnswer, input.AnswerLength, promptData.locale)
+			if parsedAnswer.LengthViolation != "" {
+				u.logger.Warn("stream_answer_length_violation",
+					slog.String("retrieval_set_id", promptData.retrievalSetID),
+					slog.String("answer_length", string(input.AnswerLength)),
+					slog.String("violation", parsedAnswer.LengthViolation))
+			}
+		}
+
 		qualityFlags := AssessAnswerQuality(
 			parsedAnswer.Answer, input.Query, parsedAnswer.Citations, promptData.intentType, promptData.expandedQueries,
 		)
@@ -441,6 +455,22 @@ func (u *answerWithRAGUsecase) Stream(ctx context.Context, input AnswerWithRAGIn
 		qualityFlags = finalFlags
 		finalAnswerText = strings.TrimSpace(parsedAnswer.Answer)
 
+		// Deltas are already on the wire, so guardrails can only correct the
+		// final answer: a redaction replaces it in the closing delta and Done,
+		// a block turns the turn into a fallback the UI renders instead.
+		finalAnswerText, guardrailOutcomes, blockedReason := u.applyGuardrails(ctx, finalAnswerText, promptData.retrievalSetID)
+		if blockedReason != "" {
+			u.sendStreamEvent(ctx, events, StreamEvent{
+				Kind:    StreamEventKindFallback,
+				Payload: blockedReason,
+			})
+			finalOutput.Fallback = true
+			finalOutput.Reason = blockedReason
+			finalOutput.FallbackCategory = FallbackGuardrailBlocked
+			finalOutput.Debug.GuardrailOutcomes = guardrailOutcomes
+			return
+		}
+
 		// Build Final Output (Hydration)
 		finalCitations := u.buildCitations(promptData.contexts, parsedAnswer.Citations)
 		relatedCitations := u.buildRelatedCitations(ctx, finalCitations, input.Query)
@@ -464,6 +494,7 @@ func (u *answerWithRAGUsecase) Stream(ctx context.Context, input AnswerWithRAGIn
 				ToolsUsed:             promptData.toolsUsed,
 				RetrievalPolicy:       promptData.retrievalPolicy,
 				GeneralRetrievalGated: promptData.generalGated,
+				GuardrailOutcomes:     guardrailOutcomes,
 			},
 		}
 
@@ -21,6 +21,16 @@ type AnswerWithRAGInput struct {
 	Locale              string
 	ConversationHistory []domain.Message // Recent chat turns for multi-turn context
 	LetterContext       string           // Morning Letter body for document-grounded follow-up
+	AnswerLength        AnswerLength     // Target answer length (empty = intent-driven default)
+	ReadingLevel        ReadingLevel     // Target reading level (empty = analyst register)
+	AnswerFormat        AnswerFormat     // Bullet or prose layout (empty = template structure)
+	MaxSentences        int              // Sentence limit for the answer body (0 = none)
+	// SourceTypes restricts retrieval to documents from these sources
+	// (empty = all sources).
+	SourceTypes []domain.SourceType
+	// Filter restricts retrieval to documents matching its date window,
+	// feeds and tags (zero value = no restriction).
+	Filter domain.RetrievalFilter
 }
 
 // conversationThreadKey resolves the ConversationStore key for a request.
@@ -75,20 +85,21 @@ type AnswerDebug struct {
 	PromptVersion         string
 	ExpandedQueries       []string
 	StrategyUsed          string
-	IntentType            string      // Phase 2: classified intent type
-	SubIntentType         string      // Analytical sub-intent (critique, opinion, implication, detail, related_articles, evidence, summary_refresh)
-	RetrievalQuality      string      // Phase 1: "good", "marginal", "insufficient"
-	RetryCount            int         // Phase 1: number of retrieval retries (0 = no retry)
-	ToolsUsed             []string    // Phase 3: tool names executed
-	QualityFlags          []string    // Phase 4: answer quality check failures
-	AgentSteps            []AgentStep // Phase 5: full agentic step trace
-	TotalAgentStepsMs     int64       // Phase 5: sum of all step durations
-	RetrievalPolicy       string      // article_only, tool_delegated, article+general_analytical, article+general
-	GeneralRetrievalGated bool        // true when general re-retrieval was suppressed by subintent
-	PlannerOperation      string      // Conversation planner operation (detail, evidence, clarify, etc.)
-	PlannerConfidence     float64     // Planner confidence in the chosen operation
-	NeedsClarification    bool        // true when planner determined clarification is needed
-	BM25HitCount          int         // Number of BM25 keyword search results
+	IntentType            string             // Phase 2: classified intent type
+	SubIntentType         string             // Analytical sub-intent (critique, opinion, implication, detail, related_articles, evidence, summary_refresh)
+	RetrievalQuality      string             // Phase 1: "good", "marginal", "insufficient"
+	RetryCount            int                // Phase 1: number of retrieval retries (0 = no retry)
+	ToolsUsed             []string           // Phase 3: tool names executed
+	QualityFlags          []string           // Phase 4: answer quality check failures
+	AgentSteps            []AgentStep        // Phase 5: full agentic step trace
+	TotalAgentStepsMs     int64              // Phase 5: sum of all step durations
+	RetrievalPolicy       string             // article_only, tool_delegated, article+general_analytical, article+general
+	GeneralRetrievalGated bool               // true when general re-retrieval was suppressed by subintent
+	PlannerOperation      string             // Conversation planner operation (detail, evidence, clarify, etc.)
+	PlannerConfidence     float64            // Planner confidence in the chosen operation
+	NeedsClarification    bool               // true when planner determined clarification is needed
+	BM25HitCount          int                // Number of BM25 keyword search results
+	GuardrailOutcomes     []GuardrailOutcome // Per-filter verdicts from the answer guardrail chain
 }
 
 // AnswerWithRAGUsecase defines the contract for generating grounded answers.
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// reindexPageSize stays under alt-backend's 500-article page cap.
+	reindexPageSize = 200
+	// reindexPageTimeout bounds one page: the listing call plus the source
+	// hash lookups and enqueues of its articles.
+	reindexPageTimeout = 2 * time.Minute
+	// reindexStaleAfter is how long a running run may go without reporting
+	// progress before a new run may replace it. Progress is written after
+	// every page, so this is comfortably above reindexPageTimeout.
+	reindexStaleAfter = 10 * time.Minute
+)
+
+// ErrInvalidReindexFilter is returned by ReindexUsecase.Start for a filter
+// it refuses to run.
+var ErrInvalidReindexFilter = errors.New("invalid reindex filter")
+
+// ReindexUsecase re-enqueues the Alt articles matching a filter through the
+// job worker.
+type ReindexUsecase interface {
+	// Start records a new run and scans the matching articles in the
+	// background. Only one run scans at a time; Start returns
+	// domain.ErrReindexRunActive while another is running.
+	Start(ctx context.Context, filter domain.ReindexFilter) (*domain.ReindexRun, error)
+
+	// Get returns a run with its job progress, or nil, nil if not found.
+	Get(ctx context.Context, id uuid.UUID) (*domain.ReindexRun, error)
+}
+
+type reindexUsecase struct {
+	source  domain.ReindexArticleSource
+	runs    domain.ReindexRunRepository
+	jobs    domain.RagJobRepository
+	indexed indexedCheck
+	logger  *slog.Logger
+	clock   func() time.Time
+
+	// wg tracks background scans so tests can wait for them.
+	wg sync.WaitGroup
+}
+
+// NewReindexUsecase wires the reindex dependencies. chunkerVersion is the
+// version the index usecase writes; an article whose current version was
+// chunked with another version is never skipped.
+func NewReindexUsecase(
+	source domain.ReindexArticleSource,
+	runs domain.ReindexRunRepository,
+	jobs domain.RagJobRepository,
+	docRepo domain.RagDocumentRepository,
+	hasher domain.SourceHashPolicy,
+	chunkerVersion domain.ChunkerVersion,
+	logger *slog.Logger,
+) ReindexUsecase {
+	return &reindexUsecase{
+		source:  source,
+		runs:    runs,
+		jobs:    jobs,
+		indexed: indexedCheck{docRepo: docRepo, hasher: hasher, chunkerVersion: chunkerVersion},
+		logger:  logger,
+		clock:   time.Now,
+	}
+}
+
+func (u *reindexUsecase) Start(ctx context.Context, filter domain.ReindexFilter) (*domain.ReindexRun, error) {
+	if err := validateReindexFilter(filter); err != nil {
+		return nil, err
+	}
+
+	run := &domain.ReindexRun{
+		ID:        uuid.New(),
+		Filter:    filter,
+		Status:    domain.ReindexRunning,
+		StartedAt: u.clock(),
+	}
+	run.UpdatedAt = run.StartedAt
+	if err := u.runs.CreateRun(ctx, run, reindexStaleAfter); err != nil {
+		if errors.Is(err, domain.ErrReindexRunActive) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("create reindex run: %w", err)
+	}
+
+	u.logger.Info("reindex_run_started", "run_id", run.ID, "filter", filter)
+
+	// The scan outlives the request that started it.
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+		u.scan(context.WithoutCancel(ctx), run)
+	}()
+	return run, nil
+}
+
+func (u *reindexUsecase) Get(ctx context.Context, id uuid.UUID) (*domain.ReindexRun, error) {
+	run, err := u.runs.GetRun(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get reindex run: %w", err)
+	}
+	return run, nil
+}
+
+// validateReindexFilter rejects filters that would match everything (the
+// backfill CLI covers full reindexes) and malformed bounds or IDs, which
+// alt-backend would otherwise reject mid-run.
+func validateReindexFilter(f domain.ReindexFilter) error {
+	if f.IsEmpty() {
+		return fmt.Errorf("%w: at least one of from, to, feed_id or user_id is required", ErrInvalidReindexFilter)
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidReindexFilter)
+	}
+	if f.FeedID != "" {
+		if _, err := uuid.Parse(f.FeedID); err != nil {
+			return fmt.Errorf("%w: feed_id must be a UUID", ErrInvalidReindexFilter)
+		}
+	}
+	if f.UserID != "" {
+		if _, err := uuid.Parse(f.UserID); err != nil {
+			return fmt.Errorf("%w: user_id must be a UUID", ErrInvalidReindexFilter)
+		}
+	}
+	return nil
+}
+
+// scan pages through the matching articles, enqueueing a backfill job for
+// every article whose index is out of date, and records the outcome on run.
+func (u *reindexUsecase) scan(ctx context.Context, run *domain.ReindexRun) {
+	err := u.scanPages(ctx, run)
+
+	status := domain.ReindexCompleted
+	var errMsg *string
+	if err != nil {
+		status = domain.ReindexFailed
+		msg := err.Error()
+		errMsg = &msg
+		u.logger.Error("reindex_run_failed", "run_id", run.ID, "matched", run.Matched, "enqueued", run.Enqueued, "error", err)
+	} else {
+		u.logger.Info("reindex_run_completed", "run_id", run.ID, "matched", run.Matched, "enqueued", run.Enqueued, "skipped", run.Skipped)
+	}
+
+	finishCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := u.runs.FinishRun(finishCtx, run.ID, status, errMsg); err != nil {
+		u.logger.Error("failed to finish reindex run", "run_id", run.ID, "error", err)
+	}
+}
+
+func (u *reindexUsecase) scanPages(ctx context.Context, run *domain.ReindexRun) error {
+	var cursor domain.ReindexCursor
+	for {
+		next, err := u.scanPage(ctx, run, cursor)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+		cursor = *next
+	}
+}
+
+func (u *reindexUsecase) scanPage(ctx context.Context, run *domain.ReindexRun, cursor domain.ReindexCursor) (*domain.ReindexCursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, reindexPageTimeout)
+	defer cancel()
+
+	articles, next, err := u.source.ListArticlesForReindex(ctx, run.Filter, cursor, reindexPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list articles: %w", err)
+	}
+
+	for _, article := range articles {
+		run.Matched++
+		current, err := u.indexed.current(ctx, article.ID, article.Title, article.URL, article.Body, reindexRetrievalMetadata(article))
+		if err != nil {
+			return nil, fmt.Errorf("check article %s: %w", article.ID, err)
+		}
+		if current {
+			run.Skipped++
+			continue
+		}
+		if err := u.enqueue(ctx, run.ID, article); err != nil {
+			return nil, fmt.Errorf("enqueue article %s: %w", article.ID, err)
+		}
+		run.Enqueued++
+	}
+
+	if err := u.runs.UpdateProgress(ctx, run.ID, run.Matched, run.Enqueued, run.Skipped); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// indexedCheck compares an article with its latest indexed version,
+// mirroring the idempotency check the index usecase applies before
+// re-chunking. Skipping on a match only saves the worker a no-op job.
+type indexedCheck struct {
+	docRepo        domain.RagDocumentRepository
+	hasher         domain.SourceHashPolicy
+	chunkerVersion domain.ChunkerVersion
+}
+
+// current reports whether the article's latest version already has this
+// content, URL and title, chunked with the current chunker, and the
+// document already carries meta.
+func (c indexedCheck) current(ctx context.Context, articleID, title, url, body string, meta domain.RetrievalMetadata) (bool, error) {
+	doc, err := c.docRepo.GetByArticleID(ctx, domain.SourceDocumentKey(domain.SourceArticle, articleID))
+	if err != nil {
+		return false, err
+	}
+	if doc == nil || doc.CurrentVersionID == nil {
+		return false, nil
+	}
+	latest, err := c.docRepo.GetLatestVersion(ctx, doc.ID)
+	if err != nil {
+		return false, err
+	}
+	return latest != nil &&
+		meta.Merge(doc.Retrieval).Equal(doc.Retrieval) &&
+		latest.SourceHash == c.hasher.Compute(title, body) &&
+		latest.URL == url &&
+		latest.Title == title &&
+		latest.ChunkerVersion == string(c.chunkerVersion), nil
+}
+
+func (u *reindexUsecase) enqueue(ctx context.Context, runID uuid.UUID, article domain.ReindexArticle) error {
+	now := u.clock()
+	payload := map[string]interface{}{
+		"article_id":     article.ID,
+		"title":          article.Title,
+		"body":           article.Body,
+		"url":            article.URL,
+		"reindex_run_id": runID.String(),
+	}
+	if article.FeedID != "" {
+		payload["feed_id"] = article.FeedID
+	}
+	return u.jobs.Enqueue(ctx, &domain.RagJob{
+		ID:        uuid.New(),
+		JobType:   "backfill_article",
+		Payload:   payload,
+		Status:    "new",
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// reindexRetrievalMetadata is the retrieval metadata a reindex listing can
+// vouch for. The listing has no publication date or tags; those come from
+// article events and are kept as stored.
+func reindexRetrievalMetadata(article domain.ReindexArticle) domain.RetrievalMetadata {
+	return domain.RetrievalMetadata{FeedID: article.FeedID}
+}
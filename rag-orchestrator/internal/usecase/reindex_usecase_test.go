@@ -0,0 +1,222 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReindexSource struct {
+	pages   [][]domain.ReindexArticle
+	err     error
+	cursors []domain.ReindexCursor
+}
+
+func (s *fakeReindexSource) ListArticlesForReindex(_ context.Context, _ domain.ReindexFilter, cursor domain.ReindexCursor, _ int) ([]domain.ReindexArticle, *domain.ReindexCursor, error) {
+	page := len(s.cursors)
+	s.cursors = append(s.cursors, cursor)
+	if s.err != nil && page == len(s.pages) {
+		return nil, nil, s.err
+	}
+	articles := s.pages[page]
+	if page == len(s.pages)-1 && s.err == nil {
+		return articles, nil, nil
+	}
+	last := articles[len(articles)-1]
+	return articles, &domain.ReindexCursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+}
+
+type fakeReindexRunRepo struct {
+	createErr error
+	created   *domain.ReindexRun
+	progress  [][3]int
+	status    domain.ReindexRunStatus
+	errMsg    *string
+	finished  chan struct{}
+}
+
+func newFakeReindexRunRepo() *fakeReindexRunRepo {
+	return &fakeReindexRunRepo{finished: make(chan struct{})}
+}
+
+func (r *fakeReindexRunRepo) CreateRun(_ context.Context, run *domain.ReindexRun, _ time.Duration) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.created = run
+	return nil
+}
+
+func (r *fakeReindexRunRepo) UpdateProgress(_ context.Context, _ uuid.UUID, matched, enqueued, skipped int) error {
+	r.progress = append(r.progress, [3]int{matched, enqueued, skipped})
+	return nil
+}
+
+func (r *fakeReindexRunRepo) FinishRun(_ context.Context, _ uuid.UUID, status domain.ReindexRunStatus, errorMessage *string) error {
+	r.status = status
+	r.errMsg = errorMessage
+	close(r.finished)
+	return nil
+}
+
+func (r *fakeReindexRunRepo) GetRun(_ context.Context, _ uuid.UUID) (*domain.ReindexRun, error) {
+	return r.created, nil
+}
+
+func (r *fakeReindexRunRepo) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.finished:
+	case <-time.After(2 * time.Second):
+		t.Fatal("reindex run did not finish")
+	}
+}
+
+type fakeJobRepo struct {
+	mu   sync.Mutex
+	jobs []*domain.RagJob
+}
+
+func (r *fakeJobRepo) Enqueue(_ context.Context, job *domain.RagJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs = append(r.jobs, job)
+	return nil
+}
+
+func (r *fakeJobRepo) AcquireNextJob(context.Context) (*domain.RagJob, error) { return nil, nil }
+
+func (r *fakeJobRepo) UpdateStatus(context.Context, uuid.UUID, string, *string) error { return nil }
+
+func newTestReindexUsecase(source domain.ReindexArticleSource, runs domain.ReindexRunRepository, jobs domain.RagJobRepository, docRepo domain.RagDocumentRepository) usecase.ReindexUsecase {
+	return usecase.NewReindexUsecase(source, runs, jobs, docRepo, domain.NewSourceHashPolicy(),
+		domain.ChunkerVersionV9, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestReindex_EnqueuesOnlyOutOfDateArticles(t *testing.T) {
+	created := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	current := domain.ReindexArticle{ID: "a1", Title: "Same", Body: "unchanged body", URL: "https://example.com/a1", CreatedAt: created}
+	edited := domain.ReindexArticle{ID: "a2", Title: "Edited", Body: "new body", URL: "https://example.com/a2", CreatedAt: created}
+	fresh := domain.ReindexArticle{ID: "a3", Title: "New", Body: "never indexed", URL: "https://example.com/a3", CreatedAt: created.Add(-time.Hour)}
+	source := &fakeReindexSource{pages: [][]domain.ReindexArticle{{current, edited}, {fresh}}}
+
+	hasher := domain.NewSourceHashPolicy()
+	ver1, ver2 := uuid.New(), uuid.New()
+	doc1 := &domain.RagDocument{ID: uuid.New(), CurrentVersionID: &ver1}
+	doc2 := &domain.RagDocument{ID: uuid.New(), CurrentVersionID: &ver2}
+	docRepo := new(MockRagDocumentRepository)
+	docRepo.On("GetByArticleID", mock.Anything, "a1").Return(doc1, nil)
+	docRepo.On("GetLatestVersion", mock.Anything, doc1.ID).Return(&domain.RagDocumentVersion{
+		Title: current.Title, URL: current.URL, SourceHash: hasher.Compute(current.Title, current.Body), ChunkerVersion: string(domain.ChunkerVersionV9),
+	}, nil)
+	docRepo.On("GetByArticleID", mock.Anything, "a2").Return(doc2, nil)
+	docRepo.On("GetLatestVersion", mock.Anything, doc2.ID).Return(&domain.RagDocumentVersion{
+		Title: edited.Title, URL: edited.URL, SourceHash: hasher.Compute(edited.Title, "old body"), ChunkerVersion: string(domain.ChunkerVersionV9),
+	}, nil)
+	docRepo.On("GetByArticleID", mock.Anything, "a3").Return(nil, nil)
+
+	runs := newFakeReindexRunRepo()
+	jobs := &fakeJobRepo{}
+	uc := newTestReindexUsecase(source, runs, jobs, docRepo)
+
+	from := created.AddDate(0, 0, -7)
+	run, err := uc.Start(context.Background(), domain.ReindexFilter{From: &from, FeedID: uuid.NewString()})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReindexRunning, run.Status)
+	runs.wait(t)
+
+	assert.Equal(t, domain.ReindexCompleted, runs.status)
+	assert.Nil(t, runs.errMsg)
+	assert.Equal(t, [][3]int{{2, 1, 1}, {3, 2, 1}}, runs.progress)
+	assert.Equal(t, []domain.ReindexCursor{{}, {CreatedAt: created, ID: "a2"}}, source.cursors)
+
+	require.Len(t, jobs.jobs, 2)
+	assert.Equal(t, "backfill_article", jobs.jobs[0].JobType)
+	assert.Equal(t, "a2", jobs.jobs[0].Payload["article_id"])
+	assert.Equal(t, "https://example.com/a2", jobs.jobs[0].Payload["url"])
+	assert.Equal(t, run.ID.String(), jobs.jobs[0].Payload["reindex_run_id"])
+	assert.Equal(t, "a3", jobs.jobs[1].Payload["article_id"])
+}
+
+func TestReindex_ChunkerUpgradeIsNotSkipped(t *testing.T) {
+	article := domain.ReindexArticle{ID: "a1", Title: "T", Body: "B", URL: "u", CreatedAt: time.Now()}
+	verID := uuid.New()
+	doc := &domain.RagDocument{ID: uuid.New(), CurrentVersionID: &verID}
+	docRepo := new(MockRagDocumentRepository)
+	docRepo.On("GetByArticleID", mock.Anything, "a1").Return(doc, nil)
+	docRepo.On("GetLatestVersion", mock.Anything, doc.ID).Return(&domain.RagDocumentVersion{
+		Title: "T", URL: "u", SourceHash: domain.NewSourceHashPolicy().Compute("T", "B"), ChunkerVersion: string(domain.ChunkerVersionV8),
+	}, nil)
+
+	runs := newFakeReindexRunRepo()
+	jobs := &fakeJobRepo{}
+	uc := newTestReindexUsecase(&fakeReindexSource{pages: [][]domain.ReindexArticle{{article}}}, runs, jobs, docRepo)
+
+	_, err := uc.Start(context.Background(), domain.ReindexFilter{UserID: uuid.NewString()})
+	require.NoError(t, err)
+	runs.wait(t)
+
+	assert.Len(t, jobs.jobs, 1)
+}
+
+func TestReindex_SourceErrorFailsRun(t *testing.T) {
+	article := domain.ReindexArticle{ID: "a1", Title: "T", Body: "B", CreatedAt: time.Now()}
+	docRepo := new(MockRagDocumentRepository)
+	docRepo.On("GetByArticleID", mock.Anything, "a1").Return(nil, nil)
+	source := &fakeReindexSource{pages: [][]domain.ReindexArticle{{article}}, err: errors.New("alt-backend unavailable")}
+
+	runs := newFakeReindexRunRepo()
+	jobs := &fakeJobRepo{}
+	uc := newTestReindexUsecase(source, runs, jobs, docRepo)
+
+	to := time.Now()
+	_, err := uc.Start(context.Background(), domain.ReindexFilter{To: &to})
+	require.NoError(t, err)
+	runs.wait(t)
+
+	assert.Equal(t, domain.ReindexFailed, runs.status)
+	require.NotNil(t, runs.errMsg)
+	assert.Contains(t, *runs.errMsg, "alt-backend unavailable")
+	// The first page was enqueued and recorded before the failure.
+	assert.Len(t, jobs.jobs, 1)
+	assert.Equal(t, [][3]int{{1, 1, 0}}, runs.progress)
+}
+
+func TestReindex_RejectsInvalidFilters(t *testing.T) {
+	uc := newTestReindexUsecase(&fakeReindexSource{}, newFakeReindexRunRepo(), &fakeJobRepo{}, new(MockRagDocumentRepository))
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	for name, filter := range map[string]domain.ReindexFilter{
+		"empty":          {},
+		"inverted range": {From: &now, To: &earlier},
+		"bad feed id":    {FeedID: "tech-news"},
+		"bad user id":    {UserID: "alice"},
+	} {
+		_, err := uc.Start(context.Background(), filter)
+		assert.ErrorIs(t, err, usecase.ErrInvalidReindexFilter, name)
+	}
+}
+
+func TestReindex_RejectsConcurrentRun(t *testing.T) {
+	runs := newFakeReindexRunRepo()
+	runs.createErr = domain.ErrReindexRunActive
+	source := &fakeReindexSource{}
+	uc := newTestReindexUsecase(source, runs, &fakeJobRepo{}, new(MockRagDocumentRepository))
+
+	_, err := uc.Start(context.Background(), domain.ReindexFilter{FeedID: uuid.NewString()})
+	assert.ErrorIs(t, err, domain.ErrReindexRunActive)
+	assert.Empty(t, source.cursors, "no scan may start when the slot is taken")
+}
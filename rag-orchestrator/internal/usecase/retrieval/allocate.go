@@ -67,6 +67,7 @@ func SelectContextsDynamic(hitsOriginal []domain.SearchResult, hitsExpanded []Co
 			DocumentVersion: res.DocumentVersion,
 			ChunkID:         res.Chunk.ID,
 			ArticleID:       res.ArticleID,
+			SourceType:      res.SourceType,
 		}
 		if rerankApplied {
 			item.RerankScore = res.Score
@@ -114,6 +115,7 @@ func allocateLegacy(hitsOriginal []domain.SearchResult, hitsExpanded []ContextIt
 				DocumentVersion: res.DocumentVersion,
 				ChunkID:         res.Chunk.ID,
 				ArticleID:       res.ArticleID,
+				SourceType:      res.SourceType,
 			})
 			seen[res.Chunk.ID] = true
 			countOriginal++
@@ -0,0 +1,148 @@
+package retrieval
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// llmTokensQueryDecompose is the token budget for the decomposition call.
+// Sub-queries are short single lines, so this stays close to the expand budget.
+const llmTokensQueryDecompose = 160
+
+// DecomposeQuery asks the generator to split a complex question into
+// self-contained sub-queries that can be retrieved independently.
+//
+// The result is filtered with the same leak/garbage rules as expanded queries
+// and capped at maxSubQueries. A simple question is expected to come back as a
+// single line; callers should treat fewer than two sub-queries as "do not fan out".
+func DecomposeQuery(ctx context.Context, query string, history []domain.Message, llmClient domain.LLMClient, maxSubQueries int) ([]string, error) {
+	if llmClient == nil {
+		return nil, fmt.Errorf("decompose query: llm client is nil")
+	}
+	if maxSubQueries <= 0 {
+		return nil, nil
+	}
+
+	resp, err := llmClient.Generate(ctx, buildDecomposePrompt(query, history, maxSubQueries), llmTokensQueryDecompose)
+	if err != nil {
+		return nil, fmt.Errorf("decompose query: %w", err)
+	}
+
+	return parseSubQueries(resp.Text, query, maxSubQueries), nil
+}
+
+func buildDecomposePrompt(query string, history []domain.Message, maxSubQueries int) string {
+	var sb strings.Builder
+	sb.WriteString("You split a user's question into independent search sub-queries.\n\n")
+	if len(history) > 0 {
+		maxTurns := 6
+		start := 0
+		if len(history) > maxTurns {
+			start = len(history) - maxTurns
+		}
+		sb.WriteString("Resolve coreferences using the conversation before splitting.\n\nConversation:\n")
+		for _, msg := range history[start:] {
+			fmt.Fprintf(&sb, "%s: %s\n", msg.Role, runeTruncate(msg.Content, 200))
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, `If the question asks about several entities, aspects, or time periods, output up to %d self-contained sub-queries, each answerable on its own.
+If the question is already simple, output it unchanged on a single line.
+Keep the language of the question.
+Output ONLY sub-queries, one per line. No numbering, bullets, or explanations.
+
+Question: %s`, maxSubQueries, query)
+	return sb.String()
+}
+
+// subQueryListMarker matches leading bullets/numbering ("- ", "1. ", "2）").
+// Digits are only stripped when followed by a list delimiter so queries such
+// as "2024年の..." survive intact.
+var subQueryListMarker = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)）])\s*`)
+
+// parseSubQueries turns raw generator output into a filtered sub-query list.
+// Numbering and bullet prefixes are stripped because small models add them
+// despite the instruction; sub-queries identical to the original are dropped
+// since the original query is always retrieved on its own.
+func parseSubQueries(text, original string, maxSubQueries int) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(subQueryListMarker.ReplaceAllString(line, ""))
+		if line == "" || strings.EqualFold(line, strings.TrimSpace(original)) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	filtered := filterExpandedQueries(lines)
+	if len(filtered) > maxSubQueries {
+		filtered = filtered[:maxSubQueries]
+	}
+	return filtered
+}
+
+// MergeSubQueryContexts fuses per-sub-query context lists into one ranked list.
+//
+// Each list is treated as an independent ranking and combined with Reciprocal
+// Rank Fusion, so chunks that several sub-queries agree on rise to the top while
+// the first-ranked hit of every sub-query still gets a fair slot. Duplicates are
+// collapsed by chunk ID (or URL+text when the chunk ID is unknown, e.g. BM25-only
+// hits); the highest original Score is kept because downstream quality gates
+// threshold on it. limit <= 0 returns every fused item.
+func MergeSubQueryContexts(lists [][]ContextItem, rrfK float64, limit int) []ContextItem {
+	type fused struct {
+		item     ContextItem
+		rrfScore float64
+		order    int
+	}
+	byKey := make(map[string]*fused)
+	order := 0
+	for _, list := range lists {
+		for rank, item := range list {
+			key := contextDedupKey(item)
+			f, ok := byKey[key]
+			if !ok {
+				f = &fused{item: item, order: order}
+				byKey[key] = f
+				order++
+			} else if item.Score > f.item.Score {
+				f.item.Score = item.Score
+			}
+			f.rrfScore += 1.0 / (rrfK + float64(rank+1))
+		}
+	}
+
+	merged := make([]*fused, 0, len(byKey))
+	for _, f := range byKey {
+		merged = append(merged, f)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].rrfScore != merged[j].rrfScore {
+			return merged[i].rrfScore > merged[j].rrfScore
+		}
+		return merged[i].order < merged[j].order
+	})
+
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	result := make([]ContextItem, len(merged))
+	for i, f := range merged {
+		result[i] = f.item
+	}
+	return result
+}
+
+func contextDedupKey(item ContextItem) string {
+	if item.ChunkID != uuid.Nil {
+		return item.ChunkID.String()
+	}
+	return item.URL + "\x00" + item.ChunkText
+}
@@ -0,0 +1,101 @@
+package retrieval_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase/retrieval"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecomposeQuery_SplitsAndStripsListMarkers(t *testing.T) {
+	llm := new(mockLLMClient)
+	llm.On("Generate", mock.Anything, mock.Anything, mock.Anything).Return(&domain.LLMResponse{
+		Text: "1. EUの半導体補助金の規模\n2) 米国CHIPS法の補助金額\n- 2024年の日本の半導体政策\n",
+	}, nil)
+
+	got, err := retrieval.DecomposeQuery(context.Background(), "EU・米国・日本の半導体補助金を比較して", nil, llm, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"EUの半導体補助金の規模",
+		"米国CHIPS法の補助金額",
+		"2024年の日本の半導体政策",
+	}, got)
+}
+
+func TestDecomposeQuery_SimpleQuestionReturnsNoSubQueries(t *testing.T) {
+	llm := new(mockLLMClient)
+	llm.On("Generate", mock.Anything, mock.Anything, mock.Anything).Return(&domain.LLMResponse{
+		Text: "What is RAG?",
+	}, nil)
+
+	got, err := retrieval.DecomposeQuery(context.Background(), "What is RAG?", nil, llm, 3)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestDecomposeQuery_CapsAtMaxSubQueries(t *testing.T) {
+	llm := new(mockLLMClient)
+	llm.On("Generate", mock.Anything, mock.Anything, mock.Anything).Return(&domain.LLMResponse{
+		Text: "query alpha\nquery beta\nquery gamma\nquery delta",
+	}, nil)
+
+	got, err := retrieval.DecomposeQuery(context.Background(), "complex question", nil, llm, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query alpha", "query beta"}, got)
+}
+
+func TestDecomposeQuery_GeneratorError(t *testing.T) {
+	llm := new(mockLLMClient)
+	llm.On("Generate", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+	_, err := retrieval.DecomposeQuery(context.Background(), "complex question", nil, llm, 3)
+	assert.Error(t, err)
+}
+
+func TestMergeSubQueryContexts_DedupAndFusion(t *testing.T) {
+	shared := uuid.New()
+	onlyA := uuid.New()
+	onlyB := uuid.New()
+
+	listA := []retrieval.ContextItem{
+		{ChunkID: onlyA, ChunkText: "a", Score: 0.9},
+		{ChunkID: shared, ChunkText: "shared", Score: 0.6},
+	}
+	listB := []retrieval.ContextItem{
+		{ChunkID: shared, ChunkText: "shared", Score: 0.8},
+		{ChunkID: onlyB, ChunkText: "b", Score: 0.7},
+	}
+
+	got := retrieval.MergeSubQueryContexts([][]retrieval.ContextItem{listA, listB}, 60, 0)
+	require.Len(t, got, 3)
+	// The chunk both sub-queries retrieved wins the fusion.
+	assert.Equal(t, shared, got[0].ChunkID)
+	// The highest original score is retained for quality gating.
+	assert.Equal(t, float32(0.8), got[0].Score)
+	// Remaining hits follow their per-list rank.
+	assert.Equal(t, onlyA, got[1].ChunkID)
+	assert.Equal(t, onlyB, got[2].ChunkID)
+}
+
+func TestMergeSubQueryContexts_NilChunkIDFallsBackToURLAndText(t *testing.T) {
+	listA := []retrieval.ContextItem{{URL: "https://example.com/1", ChunkText: "x"}}
+	listB := []retrieval.ContextItem{{URL: "https://example.com/1", ChunkText: "x"}}
+
+	got := retrieval.MergeSubQueryContexts([][]retrieval.ContextItem{listA, listB}, 60, 0)
+	assert.Len(t, got, 1)
+}
+
+func TestMergeSubQueryContexts_AppliesLimit(t *testing.T) {
+	list := []retrieval.ContextItem{
+		{ChunkID: uuid.New()}, {ChunkID: uuid.New()}, {ChunkID: uuid.New()},
+	}
+	got := retrieval.MergeSubQueryContexts([][]retrieval.ContextItem{list}, 60, 2)
+	assert.Len(t, got, 2)
+}
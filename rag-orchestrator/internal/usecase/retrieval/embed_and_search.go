@@ -58,7 +58,12 @@ func EmbedAndSearch(
 	// goroutine E: BM25 Search (original + expanded queries for cross-language matching)
 	// Skipped when useHybridSearcher: the in-DB hybrid search below already
 	// fuses lexical + vector signals, so a separate BM25 arm would be redundant.
-	if !useHybridSearcher && hybridEnabled && bm25Searcher != nil {
+	// Also skipped when a source filter excludes articles: the BM25 index
+	// (search-indexer) only holds alt articles. And skipped under a retrieval
+	// filter, which the BM25 index cannot apply.
+	if !useHybridSearcher && hybridEnabled && bm25Searcher != nil &&
+		domain.SourceFilterAllows(ctx, domain.SourceArticle) &&
+		domain.RetrievalFilterFromContext(ctx).IsEmpty() {
 		g.Go(func() error {
 			bm25Start := time.Now()
 
@@ -284,3 +284,59 @@ func TestEmbedAndSearch_HybridSearcher_SkippedWhenCandidateArticlesScoped(t *tes
 	assert.Equal(t, "scoped result", sc.OriginalResults[0].Chunk.Content)
 	mockHybrid.AssertNotCalled(t, "HybridSearch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
+
+func TestEmbedAndSearch_SourceFilterWithoutArticles_SkipsBM25(t *testing.T) {
+	// The BM25 index only holds alt articles, so a notes-only retrieval must
+	// not pull article hits in through the lexical arm.
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	mockEncoder := new(MockVectorEncoder)
+	mockBM25 := new(MockBM25Searcher)
+	mockChunkRepo := new(MockRagChunkRepository)
+
+	queryVec := []float32{0.1, 0.2, 0.3}
+	sc := &retrieval.StageContext{
+		RetrievalID:       "test-source-filter",
+		Query:             "test query",
+		OriginalEmbedding: queryVec,
+		SearchLimit:       50,
+	}
+
+	ctx := domain.WithSourceFilter(context.Background(), []domain.SourceType{domain.SourceNote})
+	mockChunkRepo.On("Search", mock.Anything, queryVec, 50).Return([]domain.SearchResult{
+		{Chunk: domain.RagChunk{Content: "note"}, Score: 0.8, SourceType: domain.SourceNote},
+	}, nil)
+
+	err := retrieval.EmbedAndSearch(ctx, sc, mockEncoder, mockBM25, nil, mockChunkRepo, true, 50, logger)
+	require.NoError(t, err)
+
+	assert.Len(t, sc.OriginalResults, 1)
+	mockBM25.AssertNotCalled(t, "SearchBM25", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEmbedAndSearch_RetrievalFilter_SkipsBM25(t *testing.T) {
+	// The BM25 index has no publication date, feed or tag filter, so a
+	// filtered retrieval must not widen its results through the lexical arm.
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	mockEncoder := new(MockVectorEncoder)
+	mockBM25 := new(MockBM25Searcher)
+	mockChunkRepo := new(MockRagChunkRepository)
+
+	queryVec := []float32{0.1, 0.2, 0.3}
+	sc := &retrieval.StageContext{
+		RetrievalID:       "test-retrieval-filter",
+		Query:             "test query",
+		OriginalEmbedding: queryVec,
+		SearchLimit:       50,
+	}
+
+	ctx := domain.WithRetrievalFilter(context.Background(), domain.RetrievalFilter{Tags: []string{"go"}})
+	mockChunkRepo.On("Search", mock.Anything, queryVec, 50).Return([]domain.SearchResult{
+		{Chunk: domain.RagChunk{Content: "tagged"}, Score: 0.8, SourceType: domain.SourceArticle},
+	}, nil)
+
+	err := retrieval.EmbedAndSearch(ctx, sc, mockEncoder, mockBM25, nil, mockChunkRepo, true, 50, logger)
+	require.NoError(t, err)
+
+	assert.Len(t, sc.OriginalResults, 1)
+	mockBM25.AssertNotCalled(t, "SearchBM25", mock.Anything, mock.Anything, mock.Anything)
+}
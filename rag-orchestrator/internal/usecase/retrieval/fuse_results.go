@@ -110,6 +110,7 @@ func FuseResults(
 							ChunkID:         res.Chunk.ID,
 							Score:           res.Score,
 							ArticleID:       res.ArticleID,
+							SourceType:      res.SourceType,
 						},
 						RRFScore: 0,
 					}
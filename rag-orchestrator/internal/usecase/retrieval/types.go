@@ -55,4 +55,6 @@ type ContextItem struct {
 	// document. Carried through the pipeline so Augur can build kind=ARTICLE
 	// citations without falling back to a UUID-in-URL guess.
 	ArticleID string
+	// SourceType is the connector the owning document came from.
+	SourceType domain.SourceType
 }
@@ -95,6 +95,33 @@ func DefaultLanguageAllocationConfig() LanguageAllocationConfig {
 	}
 }
 
+// QueryDecompositionConfig holds settings for multi-query retrieval.
+// When enabled, the generator splits a complex question into sub-queries,
+// retrieval runs for each in parallel, and the results are fused with RRF.
+type QueryDecompositionConfig struct {
+	// Enabled controls whether the decomposition step runs before retrieval.
+	Enabled bool
+	// MaxSubQueries caps how many sub-queries are retrieved in parallel.
+	MaxSubQueries int
+}
+
+// DefaultQueryDecompositionConfig returns the default config.
+// Disabled by default: decomposition adds one LLM call per request.
+func DefaultQueryDecompositionConfig() QueryDecompositionConfig {
+	return QueryDecompositionConfig{
+		Enabled:       false,
+		MaxSubQueries: 3,
+	}
+}
+
+// Validate checks if the decomposition configuration is valid.
+func (c QueryDecompositionConfig) Validate() error {
+	if c.Enabled && (c.MaxSubQueries < 2 || c.MaxSubQueries > 5) {
+		return fmt.Errorf("query decomposition maxSubQueries must be in [2, 5], got %d", c.MaxSubQueries)
+	}
+	return nil
+}
+
 // RetrievalConfig holds tunable parameters for RAG retrieval.
 // Default values are based on research findings:
 // - EMNLP 2024: "Searching for Best Practices in RAG"
@@ -125,6 +152,9 @@ type RetrievalConfig struct {
 
 	// LanguageAllocation holds settings for dynamic JA/EN language allocation.
 	LanguageAllocation LanguageAllocationConfig
+
+	// QueryDecomposition holds settings for multi-query retrieval.
+	QueryDecomposition QueryDecompositionConfig
 }
 
 // DefaultRetrievalConfig returns research-backed defaults.
@@ -140,6 +170,7 @@ func DefaultRetrievalConfig() RetrievalConfig {
 		Reranking:          DefaultRerankingConfig(),          // Cross-encoder reranking
 		HybridSearch:       DefaultHybridSearchConfig(),       // BM25+vector fusion
 		LanguageAllocation: DefaultLanguageAllocationConfig(), // Dynamic JA/EN allocation
+		QueryDecomposition: DefaultQueryDecompositionConfig(), // Multi-query retrieval (opt-in)
 	}
 }
 
@@ -168,6 +199,9 @@ func applyRetrievalConfigDefaults(cfg RetrievalConfig) RetrievalConfig {
 	if cfg.HybridSearch.BM25Limit == 0 {
 		cfg.HybridSearch.BM25Limit = def.HybridSearch.BM25Limit
 	}
+	if cfg.QueryDecomposition.MaxSubQueries == 0 {
+		cfg.QueryDecomposition.MaxSubQueries = def.QueryDecomposition.MaxSubQueries
+	}
 	return cfg
 }
 
@@ -196,5 +230,8 @@ func (c RetrievalConfig) Validate() error {
 	if err := c.HybridSearch.Validate(); err != nil {
 		return fmt.Errorf("hybrid search config invalid: %w", err)
 	}
+	if err := c.QueryDecomposition.Validate(); err != nil {
+		return fmt.Errorf("query decomposition config invalid: %w", err)
+	}
 	return nil
 }
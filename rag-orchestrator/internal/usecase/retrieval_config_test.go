@@ -285,3 +285,11 @@ func TestLanguageAllocationConfig_Default(t *testing.T) {
 	cfg := DefaultLanguageAllocationConfig()
 	assert.True(t, cfg.Enabled, "dynamic language allocation should be enabled by default")
 }
+
+func TestQueryDecompositionConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultQueryDecompositionConfig().Validate(), "default (disabled) config should be valid")
+	assert.False(t, DefaultQueryDecompositionConfig().Enabled, "query decomposition should be opt-in")
+	assert.NoError(t, QueryDecompositionConfig{Enabled: true, MaxSubQueries: 3}.Validate())
+	assert.Error(t, QueryDecompositionConfig{Enabled: true, MaxSubQueries: 1}.Validate(), "a single sub-query is not a decomposition")
+	assert.Error(t, QueryDecompositionConfig{Enabled: true, MaxSubQueries: 6}.Validate())
+}
@@ -3,19 +3,23 @@ package usecase
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"rag-orchestrator/internal/domain"
 	"rag-orchestrator/internal/usecase/retrieval"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 )
 
 // RetrieveContextInput defines the input parameters for RetrieveContext.
 type RetrieveContextInput struct {
 	Query               string
 	CandidateArticleIDs []string
-	ConversationHistory []domain.Message // Recent turns for query rewriting
-	SearchQueries       []string         // Pre-filtered queries from query planner (bypass expand-query)
+	ConversationHistory []domain.Message       // Recent turns for query rewriting
+	SearchQueries       []string               // Pre-filtered queries from query planner (bypass expand-query)
+	SourceTypes         []domain.SourceType    // Restrict search to these document sources (empty = all)
+	Filter              domain.RetrievalFilter // Restrict search by publication date, feed and tag (zero = all)
 }
 
 // RetrieveContextOutput defines the output for RetrieveContext.
@@ -42,6 +46,9 @@ type ContextItem struct {
 	// document. Required downstream by Augur to emit kind=ARTICLE citations
 	// instead of falling back to UNSPECIFIED / disabled links.
 	ArticleID string
+	// SourceType is the connector the owning document came from; empty is
+	// treated as an article.
+	SourceType domain.SourceType
 }
 
 // RetrieveContextUsecase defines the interface for retrieving context.
@@ -150,6 +157,17 @@ func NewRetrieveContextUsecase(
 }
 
 func (u *retrieveContextUsecase) Execute(ctx context.Context, input RetrieveContextInput) (*RetrieveContextOutput, error) {
+	start := time.Now()
+	defer func() { UsageMeterFromContext(ctx).AddRetrieval(time.Since(start)) }()
+
+	// The filters ride on the context so every search in the graph,
+	// including the per-sub-query runs of executeMultiQuery, applies them.
+	ctx = domain.WithSourceFilter(ctx, input.SourceTypes)
+	ctx = domain.WithRetrievalFilter(ctx, input.Filter)
+	if subQueries := u.decompose(ctx, input); len(subQueries) >= 2 {
+		return u.executeMultiQuery(ctx, input, subQueries)
+	}
+
 	out, err := u.graph.Execute(ctx, retrieval.GraphInput{
 		Query:               input.Query,
 		CandidateArticleIDs: input.CandidateArticleIDs,
@@ -167,6 +185,100 @@ func (u *retrieveContextUsecase) Execute(ctx context.Context, input RetrieveCont
 	}, nil
 }
 
+// decompose runs the optional query decomposition step. It returns nil when
+// decomposition is disabled, skipped, or fails — the caller then falls back to
+// single-query retrieval, so a decomposition outage never fails the request.
+func (u *retrieveContextUsecase) decompose(ctx context.Context, input RetrieveContextInput) []string {
+	if !u.config.QueryDecomposition.Enabled || input.Query == "" {
+		return nil
+	}
+	// Planner queries are already a fan-out of the resolved question; running
+	// every sub-query with the same planner list would just repeat the work.
+	if len(input.SearchQueries) > 0 {
+		u.logger.Info("query_decomposition_skipped",
+			slog.String("reason", "planner_queries_available"))
+		return nil
+	}
+
+	start := time.Now()
+	subQueries, err := retrieval.DecomposeQuery(ctx, input.Query, input.ConversationHistory,
+		u.llmClient, u.config.QueryDecomposition.MaxSubQueries)
+	if err != nil {
+		u.logger.Warn("query_decomposition_failed",
+			slog.String("error", err.Error()),
+			slog.String("fallback", "single_query"))
+		return nil
+	}
+	u.logger.Info("query_decomposition_completed",
+		slog.Int("sub_query_count", len(subQueries)),
+		slog.Any("sub_queries", subQueries),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+	return subQueries
+}
+
+// executeMultiQuery runs the retrieval graph for the original query and every
+// sub-query in parallel, then fuses the per-query contexts with RRF.
+// The original query run is mandatory (its error fails the request, matching
+// single-query behaviour); sub-query failures are logged and dropped.
+func (u *retrieveContextUsecase) executeMultiQuery(ctx context.Context, input RetrieveContextInput, subQueries []string) (*RetrieveContextOutput, error) {
+	queries := append([]string{input.Query}, subQueries...)
+	outputs := make([]*retrieval.GraphOutput, len(queries))
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, q := range queries {
+		g.Go(func() error {
+			graphInput := retrieval.GraphInput{
+				Query:               q,
+				CandidateArticleIDs: input.CandidateArticleIDs,
+			}
+			// Only the original query needs history for coreference resolution;
+			// sub-queries were already resolved by the decomposition prompt.
+			if i == 0 {
+				graphInput.ConversationHistory = input.ConversationHistory
+			}
+			out, err := u.graph.Execute(gctx, graphInput)
+			if err != nil {
+				if i == 0 {
+					return err
+				}
+				u.logger.Warn("sub_query_retrieval_failed",
+					slog.Int("sub_query_index", i),
+					slog.String("error", err.Error()))
+				return nil
+			}
+			outputs[i] = out
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	lists := make([][]retrieval.ContextItem, 0, len(outputs))
+	expanded := append([]string(nil), subQueries...)
+	bm25Hits := 0
+	for _, out := range outputs {
+		if out == nil {
+			continue
+		}
+		lists = append(lists, out.Contexts)
+		expanded = append(expanded, out.ExpandedQueries...)
+		bm25Hits += out.BM25HitCount
+	}
+
+	merged := retrieval.MergeSubQueryContexts(lists, u.config.RRFK, u.config.TotalQuota())
+	u.logger.Info("multi_query_retrieval_completed",
+		slog.Int("query_count", len(queries)),
+		slog.Int("successful_runs", len(lists)),
+		slog.Int("contexts_returned", len(merged)))
+
+	return &RetrieveContextOutput{
+		Contexts:        convertContextItems(merged),
+		ExpandedQueries: expanded,
+		BM25HitCount:    bm25Hits,
+	}, nil
+}
+
 // SelectContextsDynamic is a pass-through to retrieval.SelectContextsDynamic for backward compatibility.
 func SelectContextsDynamic(hitsOriginal []domain.SearchResult, hitsExpanded []ContextItem, totalQuota int) []ContextItem {
 	// Convert usecase.ContextItem to retrieval.ContextItem
@@ -181,6 +293,7 @@ func SelectContextsDynamic(hitsOriginal []domain.SearchResult, hitsExpanded []Co
 			DocumentVersion: item.DocumentVersion,
 			ChunkID:         item.ChunkID,
 			ArticleID:       item.ArticleID,
+			SourceType:      item.SourceType,
 		}
 	}
 
@@ -202,6 +315,7 @@ func convertContextItems(items []retrieval.ContextItem) []ContextItem {
 			DocumentVersion: item.DocumentVersion,
 			ChunkID:         item.ChunkID,
 			ArticleID:       item.ArticleID,
+			SourceType:      item.SourceType,
 		}
 	}
 	return result
@@ -56,7 +56,7 @@ func (u *answerWithRAGUsecase) streamHybridLongForm(
 	}
 	chatStreamCh := make(chan chatStreamResult, 1)
 	go func() {
-		ch, ech, setupErr := u.llmClient.ChatStream(ctx, messages, promptData.maxTokens)
+		ch, ech, setupErr := u.llmClient.ChatStream(WithModelRoute(ctx, promptData.modelRoute), messages, promptData.maxTokens)
 		chatStreamCh <- chatStreamResult{chunkCh: ch, errCh: ech, err: setupErr}
 	}()
 
@@ -286,6 +286,19 @@ waitHybridChatStream:
 	qualityFlags = finalFlags
 	finalAnswerText = strings.TrimSpace(parsedAnswer.Answer)
 
+	finalAnswerText, guardrailOutcomes, blockedReason := u.applyGuardrails(ctx, finalAnswerText, promptData.retrievalSetID)
+	if blockedReason != "" {
+		u.sendStreamEvent(ctx, events, StreamEvent{
+			Kind:    StreamEventKindFallback,
+			Payload: blockedReason,
+		})
+		out.Fallback = true
+		out.Reason = blockedReason
+		out.FallbackCategory = FallbackGuardrailBlocked
+		out.Debug.GuardrailOutcomes = guardrailOutcomes
+		return out
+	}
+
 	// Build final output
 	finalCitations := u.buildCitations(promptData.contexts, parsedAnswer.Citations)
 	relatedCitations := u.buildRelatedCitations(ctx, finalCitations, input.Query)
@@ -308,6 +321,7 @@ waitHybridChatStream:
 			ToolsUsed:             promptData.toolsUsed,
 			RetrievalPolicy:       promptData.retrievalPolicy,
 			GeneralRetrievalGated: promptData.generalGated,
+			GuardrailOutcomes:     guardrailOutcomes,
 		},
 	}
 	if promptData.plannerOutput != nil {
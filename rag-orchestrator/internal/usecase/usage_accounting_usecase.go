@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// recordUsageTimeout bounds the usage insert and budget check. Both run
+// detached from the request context so a client hanging up right after the
+// done event does not lose the row.
+const recordUsageTimeout = 5 * time.Second
+
+// Budget limit names, used as the alarm's Limit and metric label.
+const (
+	UsageLimitDailyTokens   = "daily_tokens"
+	UsageLimitDailyRequests = "daily_requests"
+)
+
+// UsageBudget is the per-user daily allowance. Zero fields are unlimited.
+type UsageBudget struct {
+	DailyTokens   int64 `json:"daily_tokens"`
+	DailyRequests int64 `json:"daily_requests"`
+}
+
+// exceeded reports which limits day is over.
+func (b UsageBudget) exceeded(day *domain.UsageDaySummary) []string {
+	var limits []string
+	if b.DailyTokens > 0 && day.TotalTokens() > b.DailyTokens {
+		limits = append(limits, UsageLimitDailyTokens)
+	}
+	if b.DailyRequests > 0 && day.Requests > b.DailyRequests {
+		limits = append(limits, UsageLimitDailyRequests)
+	}
+	return limits
+}
+
+// UsageBudgetAlarm reports a user going over a daily limit.
+type UsageBudgetAlarm struct {
+	UserID string
+	Day    string
+	// Limit is UsageLimitDailyTokens or UsageLimitDailyRequests.
+	Limit  string
+	Used   int64
+	Budget int64
+}
+
+// UsageBudgetRecorder receives every budget alarm, e.g. for Prometheus.
+type UsageBudgetRecorder interface {
+	RecordUsageBudgetExceeded(alarm UsageBudgetAlarm)
+}
+
+// UsageDayReport is one user-day of the usage report.
+type UsageDayReport struct {
+	domain.UsageDaySummary
+	TotalTokens int64 `json:"total_tokens"`
+	// OverBudget lists the limits the day went over.
+	OverBudget []string `json:"over_budget"`
+}
+
+// UsageReport summarizes usage per user and UTC day.
+type UsageReport struct {
+	From   string           `json:"from"`
+	To     string           `json:"to"`
+	Budget UsageBudget      `json:"budget"`
+	Days   []UsageDayReport `json:"days"`
+}
+
+// UsageAccountingUsecase records what each answer request cost and checks
+// users against their daily budget.
+type UsageAccountingUsecase interface {
+	// Record stores usage and raises an alarm for every limit this request
+	// pushed its user over. Requests without a user are stored but never
+	// budgeted.
+	Record(ctx context.Context, usage *domain.RequestUsage) error
+
+	// Report summarizes the UTC days from through to, inclusive. An empty
+	// userID reports every user.
+	Report(ctx context.Context, from, to time.Time, userID string) (*UsageReport, error)
+}
+
+type usageAccountingUsecase struct {
+	repo     domain.RequestUsageRepository
+	budget   UsageBudget
+	recorder UsageBudgetRecorder
+	logger   *slog.Logger
+	clock    func() time.Time
+}
+
+// NewUsageAccountingUsecase wires a repository into the usecase. recorder
+// may be nil; clock may be nil to use time.Now.
+func NewUsageAccountingUsecase(
+	repo domain.RequestUsageRepository,
+	budget UsageBudget,
+	recorder UsageBudgetRecorder,
+	logger *slog.Logger,
+	clock func() time.Time,
+) UsageAccountingUsecase {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if clock == nil {
+		clock = time.Now
+	}
+	return &usageAccountingUsecase{repo: repo, budget: budget, recorder: recorder, logger: logger, clock: clock}
+}
+
+func (u *usageAccountingUsecase) Record(ctx context.Context, usage *domain.RequestUsage) error {
+	if usage.ID == uuid.Nil {
+		usage.ID = uuid.New()
+	}
+	if usage.CreatedAt.IsZero() {
+		usage.CreatedAt = u.clock()
+	}
+	if err := u.repo.Append(ctx, usage); err != nil {
+		return fmt.Errorf("record usage: %w", err)
+	}
+	if usage.UserID == "" || (u.budget.DailyTokens <= 0 && u.budget.DailyRequests <= 0) {
+		return nil
+	}
+
+	dayStart := utcDay(usage.CreatedAt)
+	days, err := u.repo.SummarizeByUserDay(ctx, dayStart, dayStart.AddDate(0, 0, 1), usage.UserID)
+	if err != nil {
+		return fmt.Errorf("check usage budget: %w", err)
+	}
+	if len(days) == 0 {
+		return nil
+	}
+	after := days[0]
+	// Only the request that crosses a limit alarms, so a user over budget
+	// raises one alarm per limit and day instead of one per request.
+	before := after
+	before.Requests--
+	before.PromptTokens -= int64(usage.PromptTokens)
+	before.CompletionTokens -= int64(usage.CompletionTokens)
+	crossedBefore := make(map[string]bool)
+	for _, limit := range u.budget.exceeded(&before) {
+		crossedBefore[limit] = true
+	}
+	for _, limit := range u.budget.exceeded(&after) {
+		if crossedBefore[limit] {
+			continue
+		}
+		u.alarm(usageBudgetAlarm(after, limit, u.budget))
+	}
+	return nil
+}
+
+func (u *usageAccountingUsecase) alarm(alarm UsageBudgetAlarm) {
+	u.logger.Warn("usage_budget_exceeded",
+		slog.String("user_id", alarm.UserID),
+		slog.String("day", alarm.Day),
+		slog.String("limit", alarm.Limit),
+		slog.Int64("used", alarm.Used),
+		slog.Int64("budget", alarm.Budget))
+	if u.recorder != nil {
+		u.recorder.RecordUsageBudgetExceeded(alarm)
+	}
+}
+
+func usageBudgetAlarm(day domain.UsageDaySummary, limit string, budget UsageBudget) UsageBudgetAlarm {
+	alarm := UsageBudgetAlarm{UserID: day.UserID, Day: day.Day, Limit: limit}
+	switch limit {
+	case UsageLimitDailyTokens:
+		alarm.Used, alarm.Budget = day.TotalTokens(), budget.DailyTokens
+	case UsageLimitDailyRequests:
+		alarm.Used, alarm.Budget = day.Requests, budget.DailyRequests
+	}
+	return alarm
+}
+
+func (u *usageAccountingUsecase) Report(ctx context.Context, from, to time.Time, userID string) (*UsageReport, error) {
+	from, to = utcDay(from), utcDay(to)
+	days, err := u.repo.SummarizeByUserDay(ctx, from, to.AddDate(0, 0, 1), userID)
+	if err != nil {
+		return nil, fmt.Errorf("usage report: %w", err)
+	}
+	report := &UsageReport{
+		From:   from.Format(time.DateOnly),
+		To:     to.Format(time.DateOnly),
+		Budget: u.budget,
+		Days:   make([]UsageDayReport, 0, len(days)),
+	}
+	for _, day := range days {
+		if day.Models == nil {
+			day.Models = []string{}
+		}
+		over := u.budget.exceeded(&day)
+		if over == nil {
+			over = []string{}
+		}
+		report.Days = append(report.Days, UsageDayReport{
+			UsageDaySummary: day,
+			TotalTokens:     day.TotalTokens(),
+			OverBudget:      over,
+		})
+	}
+	return report, nil
+}
+
+// utcDay truncates t to the start of its UTC day.
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// usageAccountingAnswerUsecase meters every answer and records it once the
+// answer is delivered.
+type usageAccountingAnswerUsecase struct {
+	inner      AnswerWithRAGUsecase
+	accounting UsageAccountingUsecase
+	logger     *slog.Logger
+}
+
+// NewUsageAccountingAnswerUsecase wraps inner so every Execute and Stream
+// call runs under a UsageMeter and is recorded through accounting. Recording
+// failures are logged and never fail the answer.
+func NewUsageAccountingAnswerUsecase(inner AnswerWithRAGUsecase, accounting UsageAccountingUsecase, logger *slog.Logger) AnswerWithRAGUsecase {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &usageAccountingAnswerUsecase{inner: inner, accounting: accounting, logger: logger}
+}
+
+func (u *usageAccountingAnswerUsecase) Execute(ctx context.Context, input AnswerWithRAGInput) (*AnswerWithRAGOutput, error) {
+	ctx, meter := WithUsageMeter(ctx)
+	start := time.Now()
+	output, err := u.inner.Execute(ctx, input)
+	u.record(ctx, input, domain.UsageModeAnswer, meter, start, err != nil)
+	return output, err
+}
+
+func (u *usageAccountingAnswerUsecase) Stream(ctx context.Context, input AnswerWithRAGInput) <-chan StreamEvent {
+	ctx, meter := WithUsageMeter(ctx)
+	start := time.Now()
+	events := u.inner.Stream(ctx, input)
+	out := make(chan StreamEvent)
+
+	go func() {
+		failed := false
+		// Close first: the insert must not hold up a caller draining out.
+		defer func() {
+			u.record(ctx, input, domain.UsageModeStream, meter, start, failed)
+		}()
+		defer close(out)
+		for event := range events {
+			if event.Kind == StreamEventKindError {
+				failed = true
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				// The caller is gone; let inner finish its terminal
+				// event so the meter holds everything it spent.
+				for range events {
+				}
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+func (u *usageAccountingAnswerUsecase) record(ctx context.Context, input AnswerWithRAGInput, mode domain.UsageMode, meter *UsageMeter, start time.Time, failed bool) {
+	usage := &domain.RequestUsage{
+		UserID:       input.UserID,
+		Mode:         mode,
+		TotalLatency: time.Since(start),
+		Failed:       failed,
+	}
+	meter.fill(usage)
+
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordUsageTimeout)
+	defer cancel()
+	if err := u.accounting.Record(recordCtx, usage); err != nil {
+		u.logger.Warn("failed to record request usage",
+			slog.String("mode", string(mode)),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,235 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRequestUsageRepo keeps rows in memory and summarizes them the way the
+// postgres query does.
+type fakeRequestUsageRepo struct {
+	mu        sync.Mutex
+	rows      []domain.RequestUsage
+	appendErr error
+}
+
+func newFakeRequestUsageRepo() *fakeRequestUsageRepo {
+	return &fakeRequestUsageRepo{}
+}
+
+func (f *fakeRequestUsageRepo) Append(_ context.Context, usage *domain.RequestUsage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.appendErr != nil {
+		return f.appendErr
+	}
+	f.rows = append(f.rows, *usage)
+	return nil
+}
+
+func (f *fakeRequestUsageRepo) SummarizeByUserDay(_ context.Context, from, to time.Time, userID string) ([]domain.UsageDaySummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	type key struct{ user, day string }
+	byKey := map[key]*domain.UsageDaySummary{}
+	for _, r := range f.rows {
+		if r.CreatedAt.Before(from) || !r.CreatedAt.Before(to) || (userID != "" && r.UserID != userID) {
+			continue
+		}
+		k := key{r.UserID, r.CreatedAt.UTC().Format(time.DateOnly)}
+		s, ok := byKey[k]
+		if !ok {
+			s = &domain.UsageDaySummary{UserID: k.user, Day: k.day}
+			byKey[k] = s
+		}
+		s.Requests++
+		s.PromptTokens += int64(r.PromptTokens)
+		s.CompletionTokens += int64(r.CompletionTokens)
+		s.EmbeddingCalls += int64(r.EmbeddingCalls)
+		if r.Model != "" {
+			s.Models = append(s.Models, r.Model)
+		}
+	}
+	out := make([]domain.UsageDaySummary, 0, len(byKey))
+	for _, s := range byKey {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Day != out[j].Day {
+			return out[i].Day < out[j].Day
+		}
+		return out[i].UserID < out[j].UserID
+	})
+	return out, nil
+}
+
+type fakeUsageBudgetRecorder struct {
+	mu     sync.Mutex
+	alarms []usecase.UsageBudgetAlarm
+}
+
+func (r *fakeUsageBudgetRecorder) RecordUsageBudgetExceeded(alarm usecase.UsageBudgetAlarm) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alarms = append(r.alarms, alarm)
+}
+
+// funcAnswerUsecase runs fn as the answer pipeline and streams a single
+// done event.
+type funcAnswerUsecase func(ctx context.Context)
+
+func (f funcAnswerUsecase) Execute(ctx context.Context, _ usecase.AnswerWithRAGInput) (*usecase.AnswerWithRAGOutput, error) {
+	f(ctx)
+	return &usecase.AnswerWithRAGOutput{Answer: "ok"}, nil
+}
+
+func (f funcAnswerUsecase) Stream(ctx context.Context, _ usecase.AnswerWithRAGInput) <-chan usecase.StreamEvent {
+	events := make(chan usecase.StreamEvent, 1)
+	go func() {
+		defer close(events)
+		f(ctx)
+		events <- usecase.StreamEvent{Kind: usecase.StreamEventKindDone, Payload: &usecase.AnswerWithRAGOutput{Answer: "ok"}}
+	}()
+	return events
+}
+
+func TestUsageAccounting_AlarmsOnlyWhenCrossingLimit(t *testing.T) {
+	repo := newFakeRequestUsageRepo()
+	recorder := &fakeUsageBudgetRecorder{}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	uc := usecase.NewUsageAccountingUsecase(repo, usecase.UsageBudget{DailyTokens: 1000, DailyRequests: 3}, recorder, nil,
+		func() time.Time { return now })
+
+	record := func(user string, tokens int) {
+		require.NoError(t, uc.Record(context.Background(), &domain.RequestUsage{
+			UserID: user, Mode: domain.UsageModeAnswer, PromptTokens: tokens,
+		}))
+	}
+
+	record("u1", 600)
+	record("u1", 300)
+	assert.Empty(t, recorder.alarms)
+
+	record("u1", 200) // 1100 tokens: crosses the token limit
+	require.Len(t, recorder.alarms, 1)
+	assert.Equal(t, usecase.UsageBudgetAlarm{
+		UserID: "u1", Day: "2026-10-16", Limit: usecase.UsageLimitDailyTokens, Used: 1100, Budget: 1000,
+	}, recorder.alarms[0])
+
+	record("u1", 10) // 4th request: crosses the request limit only
+	require.Len(t, recorder.alarms, 2)
+	assert.Equal(t, usecase.UsageLimitDailyRequests, recorder.alarms[1].Limit)
+
+	record("u1", 10)
+	assert.Len(t, recorder.alarms, 2, "an over-budget user alarms once per limit and day")
+
+	record("u2", 5000)
+	require.Len(t, recorder.alarms, 3)
+	assert.Equal(t, "u2", recorder.alarms[2].UserID)
+
+	now = now.Add(24 * time.Hour)
+	record("u1", 10)
+	assert.Len(t, recorder.alarms, 3, "a new day starts a new budget")
+}
+
+func TestUsageAccounting_AnonymousRequestsAreNotBudgeted(t *testing.T) {
+	repo := newFakeRequestUsageRepo()
+	recorder := &fakeUsageBudgetRecorder{}
+	uc := usecase.NewUsageAccountingUsecase(repo, usecase.UsageBudget{DailyTokens: 1}, recorder, nil, nil)
+
+	require.NoError(t, uc.Record(context.Background(), &domain.RequestUsage{Mode: domain.UsageModeStream, PromptTokens: 50}))
+	assert.Len(t, repo.rows, 1)
+	assert.NotEqual(t, uuid.Nil, repo.rows[0].ID)
+	assert.False(t, repo.rows[0].CreatedAt.IsZero())
+	assert.Empty(t, recorder.alarms)
+}
+
+func TestUsageAccounting_Report(t *testing.T) {
+	repo := newFakeRequestUsageRepo()
+	day1 := time.Date(2026, 10, 15, 23, 30, 0, 0, time.UTC)
+	day2 := time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC)
+	repo.rows = []domain.RequestUsage{
+		{UserID: "u1", PromptTokens: 800, CompletionTokens: 300, Model: "gemma", CreatedAt: day1},
+		{UserID: "u1", PromptTokens: 100, CreatedAt: day2},
+		{UserID: "u2", PromptTokens: 100, CreatedAt: day2},
+		{UserID: "u1", PromptTokens: 100, CreatedAt: day2.AddDate(0, 0, 1)},
+	}
+	uc := usecase.NewUsageAccountingUsecase(repo, usecase.UsageBudget{DailyTokens: 1000}, nil, nil, nil)
+
+	report, err := uc.Report(context.Background(), day1, day2, "")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-15", report.From)
+	assert.Equal(t, "2026-10-16", report.To)
+	assert.EqualValues(t, 1000, report.Budget.DailyTokens)
+	require.Len(t, report.Days, 3, "to is inclusive, the day after is not")
+
+	assert.Equal(t, "u1", report.Days[0].UserID)
+	assert.EqualValues(t, 1100, report.Days[0].TotalTokens)
+	assert.Equal(t, []string{usecase.UsageLimitDailyTokens}, report.Days[0].OverBudget)
+	assert.Equal(t, []string{"gemma"}, report.Days[0].Models)
+	assert.Equal(t, []string{}, report.Days[1].OverBudget)
+	assert.Equal(t, []string{}, report.Days[1].Models)
+
+	report, err = uc.Report(context.Background(), day1, day2, "u2")
+	require.NoError(t, err)
+	require.Len(t, report.Days, 1)
+	assert.Equal(t, "u2", report.Days[0].UserID)
+}
+
+func TestUsageAccountingAnswerUsecase_RecordsStream(t *testing.T) {
+	repo := newFakeRequestUsageRepo()
+	accounting := usecase.NewUsageAccountingUsecase(repo, usecase.UsageBudget{}, nil, nil, nil)
+	llm := usecase.NewMeteredLLMClient(&routeStubLLM{model: "gemma"})
+	inner := funcAnswerUsecase(func(ctx context.Context) {
+		usecase.UsageMeterFromContext(ctx).AddRetrieval(40 * time.Millisecond)
+		chunks, errs, err := llm.ChatStream(ctx, nil, 100)
+		require.NoError(t, err)
+		for range chunks {
+		}
+		for range errs {
+		}
+	})
+	answer := usecase.NewUsageAccountingAnswerUsecase(inner, accounting, nil)
+
+	var kinds []usecase.StreamEventKind
+	for event := range answer.Stream(context.Background(), usecase.AnswerWithRAGInput{UserID: "u1"}) {
+		kinds = append(kinds, event.Kind)
+	}
+	assert.Equal(t, []usecase.StreamEventKind{usecase.StreamEventKindDone}, kinds)
+
+	// The row is written after the stream closes.
+	require.Eventually(t, func() bool {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		return len(repo.rows) == 1
+	}, time.Second, 5*time.Millisecond)
+	row := repo.rows[0]
+	assert.Equal(t, "u1", row.UserID)
+	assert.Equal(t, domain.UsageModeStream, row.Mode)
+	assert.Equal(t, "gemma", row.Model)
+	assert.Equal(t, 200, row.TotalTokens())
+	assert.Equal(t, 40*time.Millisecond, row.RetrievalLatency)
+	assert.False(t, row.Failed)
+}
+
+func TestUsageAccountingAnswerUsecase_RecordFailureDoesNotFailAnswer(t *testing.T) {
+	repo := newFakeRequestUsageRepo()
+	repo.appendErr = errors.New("db down")
+	accounting := usecase.NewUsageAccountingUsecase(repo, usecase.UsageBudget{}, nil, nil, nil)
+	answer := usecase.NewUsageAccountingAnswerUsecase(funcAnswerUsecase(func(context.Context) {}), accounting, nil)
+
+	output, err := answer.Execute(context.Background(), usecase.AnswerWithRAGInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", output.Answer)
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+)
+
+// UsageMeter accumulates the generator and retrieval work done on behalf of
+// one request. The metered generator and embedder and the retrieve usecase
+// add to the meter found in their context; calls made without one are not
+// metered. Safe for concurrent use, since retrieval fans out.
+type UsageMeter struct {
+	mu               sync.Mutex
+	model            string
+	promptTokens     int
+	completionTokens int
+	generatorCalls   int
+	embeddingCalls   int
+	retrieval        time.Duration
+}
+
+type usageMeterKey struct{}
+
+// WithUsageMeter attaches a fresh meter to ctx.
+func WithUsageMeter(ctx context.Context) (context.Context, *UsageMeter) {
+	meter := &UsageMeter{}
+	return context.WithValue(ctx, usageMeterKey{}, meter), meter
+}
+
+// UsageMeterFromContext returns the meter attached by WithUsageMeter, or nil.
+// All UsageMeter methods are no-ops on a nil meter.
+func UsageMeterFromContext(ctx context.Context) *UsageMeter {
+	meter, _ := ctx.Value(usageMeterKey{}).(*UsageMeter)
+	return meter
+}
+
+// AddGeneration records one generator call served by model.
+func (m *UsageMeter) AddGeneration(model string, promptTokens, completionTokens int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.generatorCalls++
+	m.promptTokens += promptTokens
+	m.completionTokens += completionTokens
+	if model != "" {
+		m.model = model
+	}
+}
+
+// AddEmbeddingCall records one call to the embedder.
+func (m *UsageMeter) AddEmbeddingCall() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.embeddingCalls++
+}
+
+// AddRetrieval records time spent retrieving context.
+func (m *UsageMeter) AddRetrieval(d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retrieval += d
+}
+
+// fill copies the accumulated counts into usage.
+func (m *UsageMeter) fill(usage *domain.RequestUsage) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	usage.Model = m.model
+	usage.PromptTokens = m.promptTokens
+	usage.CompletionTokens = m.completionTokens
+	usage.GeneratorCalls = m.generatorCalls
+	usage.EmbeddingCalls = m.embeddingCalls
+	usage.RetrievalLatency = m.retrieval
+}
+
+// NewMeteredLLMClient wraps client so every call adds its token counts to
+// the UsageMeter in the call's context, attributed to client.Version(). Wrap
+// the innermost generators (below a ModelRouter) so the meter sees the model
+// that actually served each call. The result implements
+// domain.ToolCallingLLMClient exactly when client does.
+func NewMeteredLLMClient(client domain.LLMClient) domain.LLMClient {
+	metered := &meteredLLMClient{inner: client}
+	if tools, ok := client.(domain.ToolCallingLLMClient); ok {
+		return &meteredToolCallingLLMClient{meteredLLMClient: metered, tools: tools}
+	}
+	return metered
+}
+
+type meteredLLMClient struct {
+	inner domain.LLMClient
+}
+
+func (c *meteredLLMClient) Version() string {
+	return c.inner.Version()
+}
+
+func (c *meteredLLMClient) Generate(ctx context.Context, prompt string, maxTokens int) (*domain.LLMResponse, error) {
+	resp, err := c.inner.Generate(ctx, prompt, maxTokens)
+	c.meterResponse(ctx, resp)
+	return resp, err
+}
+
+func (c *meteredLLMClient) Chat(ctx context.Context, messages []domain.Message, maxTokens int) (*domain.LLMResponse, error) {
+	resp, err := c.inner.Chat(ctx, messages, maxTokens)
+	c.meterResponse(ctx, resp)
+	return resp, err
+}
+
+func (c *meteredLLMClient) GenerateStream(ctx context.Context, prompt string, maxTokens int) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	chunks, errs, err := c.inner.GenerateStream(ctx, prompt, maxTokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.meterStream(ctx, chunks, errs)
+}
+
+func (c *meteredLLMClient) ChatStream(ctx context.Context, messages []domain.Message, maxTokens int) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	chunks, errs, err := c.inner.ChatStream(ctx, messages, maxTokens)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.meterStream(ctx, chunks, errs)
+}
+
+func (c *meteredLLMClient) meterResponse(ctx context.Context, resp *domain.LLMResponse) {
+	meter := UsageMeterFromContext(ctx)
+	if meter == nil || resp == nil {
+		return
+	}
+	meter.AddGeneration(c.inner.Version(), resp.PromptTokens, resp.CompletionTokens)
+}
+
+func (c *meteredLLMClient) meterStream(ctx context.Context, chunks <-chan domain.LLMStreamChunk, errs <-chan error) (<-chan domain.LLMStreamChunk, <-chan error, error) {
+	meter := UsageMeterFromContext(ctx)
+	if meter == nil {
+		return chunks, errs, nil
+	}
+	outChunks, outErrs := observeLLMStream(ctx, chunks, errs, func(promptTokens, completionTokens int, _ error) {
+		meter.AddGeneration(c.inner.Version(), promptTokens, completionTokens)
+	})
+	return outChunks, outErrs, nil
+}
+
+type meteredToolCallingLLMClient struct {
+	*meteredLLMClient
+	tools domain.ToolCallingLLMClient
+}
+
+func (c *meteredToolCallingLLMClient) ChatWithTools(ctx context.Context, messages []domain.Message, tools []domain.ToolDefinition, maxTokens int) (*domain.LLMResponse, error) {
+	resp, err := c.tools.ChatWithTools(ctx, messages, tools, maxTokens)
+	c.meterResponse(ctx, resp)
+	return resp, err
+}
+
+// NewMeteredVectorEncoder wraps encoder so every Encode call counts as one
+// embedding call on the UsageMeter in its context. Wrap it below any query
+// cache so cache hits stay free.
+func NewMeteredVectorEncoder(encoder domain.VectorEncoder) domain.VectorEncoder {
+	return &meteredVectorEncoder{inner: encoder}
+}
+
+type meteredVectorEncoder struct {
+	inner domain.VectorEncoder
+}
+
+func (e *meteredVectorEncoder) Encode(ctx context.Context, texts []string) ([][]float32, error) {
+	UsageMeterFromContext(ctx).AddEmbeddingCall()
+	return e.inner.Encode(ctx, texts)
+}
+
+func (e *meteredVectorEncoder) Version() string {
+	return e.inner.Version()
+}
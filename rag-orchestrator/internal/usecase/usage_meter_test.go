@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type toolStubLLM struct {
+	routeStubLLM
+}
+
+func (c *toolStubLLM) ChatWithTools(context.Context, []domain.Message, []domain.ToolDefinition, int) (*domain.LLMResponse, error) {
+	c.calls++
+	return c.resp, c.err
+}
+
+type stubEncoder struct{ calls int }
+
+func (e *stubEncoder) Encode(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+	return make([][]float32, len(texts)), nil
+}
+
+func (e *stubEncoder) Version() string { return "embedder" }
+
+// meteredCounts runs fn under a fresh meter and reports what it recorded by
+// pushing it through the accounting decorator's repository.
+func meteredCounts(t *testing.T, fn func(ctx context.Context)) domain.RequestUsage {
+	t.Helper()
+	repo := newFakeRequestUsageRepo()
+	accounting := usecase.NewUsageAccountingUsecase(repo, usecase.UsageBudget{}, nil, nil, nil)
+	answer := usecase.NewUsageAccountingAnswerUsecase(funcAnswerUsecase(func(ctx context.Context) {
+		fn(ctx)
+	}), accounting, nil)
+	_, err := answer.Execute(context.Background(), usecase.AnswerWithRAGInput{UserID: "u1"})
+	require.NoError(t, err)
+	require.Len(t, repo.rows, 1)
+	return repo.rows[0]
+}
+
+func TestMeteredLLMClient_CountsCallsUnderMeter(t *testing.T) {
+	small := &routeStubLLM{model: "gemma-small", resp: &domain.LLMResponse{PromptTokens: 10, CompletionTokens: 4}}
+	large := &routeStubLLM{model: "gemma-large"}
+	meteredSmall := usecase.NewMeteredLLMClient(small)
+	meteredLarge := usecase.NewMeteredLLMClient(large)
+
+	usage := meteredCounts(t, func(ctx context.Context) {
+		_, err := meteredSmall.Chat(ctx, nil, 100)
+		require.NoError(t, err)
+
+		chunks, errs, err := meteredLarge.ChatStream(ctx, nil, 100)
+		require.NoError(t, err)
+		for range chunks {
+		}
+		for range errs {
+		}
+	})
+
+	assert.Equal(t, 2, usage.GeneratorCalls)
+	assert.Equal(t, 130, usage.PromptTokens)
+	assert.Equal(t, 84, usage.CompletionTokens)
+	assert.Equal(t, "gemma-large", usage.Model, "the last call's model wrote the answer")
+}
+
+func TestMeteredLLMClient_WithoutMeterPassesThrough(t *testing.T) {
+	inner := &routeStubLLM{model: "m", resp: &domain.LLMResponse{Text: "ok", PromptTokens: 1}}
+	resp, err := usecase.NewMeteredLLMClient(inner).Chat(context.Background(), nil, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp.Text)
+	assert.Equal(t, 1, inner.calls)
+}
+
+func TestMeteredLLMClient_KeepsToolCallingCapability(t *testing.T) {
+	_, ok := usecase.NewMeteredLLMClient(&routeStubLLM{model: "m"}).(domain.ToolCallingLLMClient)
+	assert.False(t, ok, "must not claim tool calling the inner client lacks")
+
+	inner := &toolStubLLM{routeStubLLM{model: "m", resp: &domain.LLMResponse{PromptTokens: 7, CompletionTokens: 3}}}
+	metered, ok := usecase.NewMeteredLLMClient(inner).(domain.ToolCallingLLMClient)
+	require.True(t, ok)
+
+	usage := meteredCounts(t, func(ctx context.Context) {
+		_, err := metered.ChatWithTools(ctx, nil, nil, 10)
+		require.NoError(t, err)
+	})
+	assert.Equal(t, 10, usage.TotalTokens())
+}
+
+func TestMeteredVectorEncoder_CountsEncodeCalls(t *testing.T) {
+	inner := &stubEncoder{}
+	encoder := usecase.NewMeteredVectorEncoder(inner)
+
+	usage := meteredCounts(t, func(ctx context.Context) {
+		_, err := encoder.Encode(ctx, []string{"a", "b"})
+		require.NoError(t, err)
+		_, err = encoder.Encode(ctx, []string{"c"})
+		require.NoError(t, err)
+	})
+	assert.Equal(t, 2, usage.EmbeddingCalls)
+	assert.Equal(t, 2, inner.calls)
+	assert.Equal(t, "embedder", encoder.Version())
+}
@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+)
+
+const (
+	// articleDeletionSyncTimeout bounds one pass over the audit trail.
+	articleDeletionSyncTimeout = 10 * time.Minute
+	// articleDeletionSyncOverlap rewinds every pass a little, so an audit
+	// entry that committed after a later one was already read is still
+	// picked up. Re-applying a delete is a no-op.
+	articleDeletionSyncOverlap = 2 * time.Minute
+)
+
+// ArticleDeletionSyncWorker periodically tombstones the documents of
+// articles deleted in alt-backend. The cursor is kept in memory: after a
+// restart the worker replays the last lookback of the audit trail, and
+// anything older is left to `backfill audit-deletions`.
+type ArticleDeletionSyncWorker struct {
+	deletions usecase.ArticleDeletionSyncUsecase
+	interval  time.Duration
+	logger    *slog.Logger
+
+	cursor   domain.ArticleDeletionCursor
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewArticleDeletionSyncWorker creates a worker syncing every interval,
+// starting lookback before now.
+func NewArticleDeletionSyncWorker(
+	deletions usecase.ArticleDeletionSyncUsecase,
+	interval, lookback time.Duration,
+	logger *slog.Logger,
+) *ArticleDeletionSyncWorker {
+	return &ArticleDeletionSyncWorker{
+		deletions: deletions,
+		interval:  interval,
+		logger:    logger,
+		cursor:    domain.ArticleDeletionCursor{Since: time.Now().Add(-lookback)},
+		stopChan:  make(chan struct{}),
+	}
+}
+
+func (w *ArticleDeletionSyncWorker) Start() {
+	w.logger.Info("Starting ArticleDeletionSyncWorker",
+		"interval", w.interval.String(),
+		"since", w.cursor.Since)
+	w.wg.Go(w.run)
+}
+
+func (w *ArticleDeletionSyncWorker) Stop() {
+	w.logger.Info("Stopping ArticleDeletionSyncWorker")
+	close(w.stopChan)
+	w.wg.Wait()
+}
+
+func (w *ArticleDeletionSyncWorker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.stopChan
+		cancel()
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Catch up right away: deletions made while the service was down
+	// should not stay retrievable for another interval.
+	w.syncOnce(ctx)
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ticker.C:
+			w.syncOnce(ctx)
+		}
+	}
+}
+
+func (w *ArticleDeletionSyncWorker) syncOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, articleDeletionSyncTimeout)
+	defer cancel()
+
+	from := domain.ArticleDeletionCursor{Since: w.cursor.Since.Add(-articleDeletionSyncOverlap)}
+	result, err := w.deletions.Sync(ctx, from)
+	// Keep whatever progress was made, but never move backwards.
+	if result.Cursor.Since.After(w.cursor.Since) {
+		w.cursor = result.Cursor
+	}
+	if err != nil {
+		w.logger.Error("Article deletion sync failed",
+			"events", result.Events,
+			"deleted", result.Deleted,
+			"error", err)
+		return
+	}
+	if result.Events > 0 {
+		w.logger.Info("Article deletion sync finished",
+			"events", result.Events,
+			"deleted", result.Deleted,
+			"restored", result.Restored)
+	}
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubDeletionSync struct {
+	froms   []domain.ArticleDeletionCursor
+	results []usecase.ArticleDeletionSyncResult
+	errs    []error
+}
+
+func (s *stubDeletionSync) Sync(ctx context.Context, cursor domain.ArticleDeletionCursor) (usecase.ArticleDeletionSyncResult, error) {
+	i := len(s.froms)
+	s.froms = append(s.froms, cursor)
+	return s.results[i], s.errs[i]
+}
+
+func TestArticleDeletionSyncWorker_RewindsAndNeverMovesBack(t *testing.T) {
+	t1 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	deletions := &stubDeletionSync{
+		results: []usecase.ArticleDeletionSyncResult{
+			{Cursor: domain.ArticleDeletionCursor{Since: t1, AfterID: "e1"}},
+			{Cursor: domain.ArticleDeletionCursor{Since: t1.Add(-articleDeletionSyncOverlap)}},
+			{Cursor: domain.ArticleDeletionCursor{Since: t2, AfterID: "e9"}},
+		},
+		errs: []error{nil, errors.New("alt-backend unavailable"), nil},
+	}
+	w := NewArticleDeletionSyncWorker(deletions, time.Minute, 72*time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.cursor = domain.ArticleDeletionCursor{Since: t1.Add(-time.Hour)}
+
+	ctx := context.Background()
+	w.syncOnce(ctx)
+	w.syncOnce(ctx)
+	w.syncOnce(ctx)
+
+	rewound := t1.Add(-articleDeletionSyncOverlap)
+	assert.Equal(t, []domain.ArticleDeletionCursor{
+		{Since: t1.Add(-time.Hour - articleDeletionSyncOverlap)},
+		{Since: rewound},
+		{Since: rewound},
+	}, deletions.froms)
+	assert.Equal(t, domain.ArticleDeletionCursor{Since: t2, AfterID: "e9"}, w.cursor)
+}
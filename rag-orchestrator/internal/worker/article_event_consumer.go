@@ -0,0 +1,226 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+)
+
+const (
+	// articleEventTimeout bounds handling one event: a source hash lookup
+	// and an enqueue, or a tombstone.
+	articleEventTimeout = 30 * time.Second
+	// articleEventRetryDelay is how long the consumer waits after the
+	// stream could not be read.
+	articleEventRetryDelay = 5 * time.Second
+)
+
+// Metric outcomes beyond usecase.ArticleEventOutcome.
+const (
+	articleEventFailed       = "failed"
+	articleEventDeadLettered = "dead_lettered"
+)
+
+// ArticleEventRecorder records what the consumer does.
+type ArticleEventRecorder interface {
+	RecordArticleEvent(eventType, outcome string)
+	RecordArticleEventAge(age time.Duration)
+	RecordArticleEventLag(lag domain.ArticleEventLag)
+}
+
+// ArticleEventConsumer indexes articles as alt-backend publishes them,
+// instead of waiting for a backfill or reindex to find them.
+//
+// A handled event is acknowledged. One that failed stays pending and is
+// read again once it has been idle for the stream's claim timeout; after
+// maxDeliveries deliveries, or at once if it can never succeed, it goes to
+// the dead-letter stream so it cannot block the group.
+type ArticleEventConsumer struct {
+	stream        domain.ArticleEventStream
+	events        usecase.ArticleEventUsecase
+	recorder      ArticleEventRecorder
+	maxDeliveries int64
+	lagInterval   time.Duration
+	logger        *slog.Logger
+	clock         func() time.Time
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewArticleEventConsumer creates a consumer reporting the group's lag
+// every lagInterval. maxDeliveries 0 never dead-letters a failing event.
+func NewArticleEventConsumer(
+	stream domain.ArticleEventStream,
+	events usecase.ArticleEventUsecase,
+	recorder ArticleEventRecorder,
+	maxDeliveries int64,
+	lagInterval time.Duration,
+	logger *slog.Logger,
+) *ArticleEventConsumer {
+	return &ArticleEventConsumer{
+		stream:        stream,
+		events:        events,
+		recorder:      recorder,
+		maxDeliveries: maxDeliveries,
+		lagInterval:   lagInterval,
+		logger:        logger,
+		clock:         time.Now,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+func (c *ArticleEventConsumer) Start() {
+	c.logger.Info("Starting ArticleEventConsumer",
+		"max_deliveries", c.maxDeliveries,
+		"lag_interval", c.lagInterval.String())
+	c.wg.Go(c.consume)
+	c.wg.Go(c.reportLag)
+}
+
+func (c *ArticleEventConsumer) Stop() {
+	c.logger.Info("Stopping ArticleEventConsumer")
+	close(c.stopChan)
+	c.wg.Wait()
+}
+
+// contextUntilStop returns a context cancelled when Stop is called.
+func (c *ArticleEventConsumer) contextUntilStop() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-c.stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+func (c *ArticleEventConsumer) consume() {
+	ctx, cancel := c.contextUntilStop()
+	defer cancel()
+
+	for {
+		select {
+		case <-c.stopChan:
+			return
+		default:
+		}
+
+		if err := c.consumeOnce(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Error("Article event stream read failed", "error", err)
+			select {
+			case <-c.stopChan:
+				return
+			case <-time.After(articleEventRetryDelay):
+			}
+		}
+	}
+}
+
+// consumeOnce reads one batch and handles its events in stream order.
+func (c *ArticleEventConsumer) consumeOnce(ctx context.Context) error {
+	events, err := c.stream.Read(ctx)
+	if err != nil {
+		return err
+	}
+	var handled []string
+	for _, event := range events {
+		if c.handle(ctx, event) {
+			handled = append(handled, event.MessageID)
+		}
+	}
+	if err := c.stream.Ack(ctx, handled...); err != nil {
+		// The events are handled again once reclaimed; enqueueing is
+		// deduplicated by source hash and deleting twice is a no-op.
+		return fmt.Errorf("ack %d article events: %w", len(handled), err)
+	}
+	return nil
+}
+
+// handle applies one event and reports whether it should be acknowledged.
+// Dead-lettered events are acknowledged by DeadLetter itself.
+func (c *ArticleEventConsumer) handle(ctx context.Context, event domain.ArticleEvent) bool {
+	handleCtx, cancel := context.WithTimeout(ctx, articleEventTimeout)
+	outcome, err := c.events.Handle(handleCtx, event)
+	cancel()
+	if err == nil {
+		c.recorder.RecordArticleEvent(event.EventType, string(outcome))
+		if !event.CreatedAt.IsZero() {
+			c.recorder.RecordArticleEventAge(c.clock().Sub(event.CreatedAt))
+		}
+		return true
+	}
+	if ctx.Err() != nil {
+		// Stopping: leave the event pending for the next start.
+		return false
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrInvalidArticleEvent):
+		c.deadLetter(ctx, event, err.Error())
+	case c.maxDeliveries > 0 && event.Deliveries >= c.maxDeliveries:
+		c.deadLetter(ctx, event, fmt.Sprintf("failed after %d deliveries: %v", event.Deliveries, err))
+	default:
+		c.recorder.RecordArticleEvent(event.EventType, articleEventFailed)
+		c.logger.Warn("Article event failed, will retry",
+			"message_id", event.MessageID,
+			"event_type", event.EventType,
+			"deliveries", event.Deliveries,
+			"error", err)
+	}
+	return false
+}
+
+func (c *ArticleEventConsumer) deadLetter(ctx context.Context, event domain.ArticleEvent, reason string) {
+	if err := c.stream.DeadLetter(ctx, event, reason); err != nil {
+		c.recorder.RecordArticleEvent(event.EventType, articleEventFailed)
+		c.logger.Error("Failed to dead-letter article event",
+			"message_id", event.MessageID,
+			"event_type", event.EventType,
+			"error", err)
+		return
+	}
+	c.recorder.RecordArticleEvent(event.EventType, articleEventDeadLettered)
+	c.logger.Warn("Article event dead-lettered",
+		"message_id", event.MessageID,
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"deliveries", event.Deliveries,
+		"reason", reason)
+}
+
+func (c *ArticleEventConsumer) reportLag() {
+	ctx, cancel := c.contextUntilStop()
+	defer cancel()
+
+	ticker := time.NewTicker(c.lagInterval)
+	defer ticker.Stop()
+
+	for {
+		c.recordLag(ctx)
+		select {
+		case <-c.stopChan:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *ArticleEventConsumer) recordLag(ctx context.Context) {
+	lag, err := c.stream.Lag(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.logger.Warn("Failed to read article event lag", "error", err)
+		}
+		return
+	}
+	c.recorder.RecordArticleEventLag(lag)
+}
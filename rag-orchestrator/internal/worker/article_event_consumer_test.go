@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubEventStream struct {
+	batch        []domain.ArticleEvent
+	acked        []string
+	deadLettered []string
+}
+
+func (s *stubEventStream) Read(context.Context) ([]domain.ArticleEvent, error) {
+	return s.batch, nil
+}
+
+func (s *stubEventStream) Ack(_ context.Context, ids ...string) error {
+	s.acked = append(s.acked, ids...)
+	return nil
+}
+
+func (s *stubEventStream) DeadLetter(_ context.Context, event domain.ArticleEvent, _ string) error {
+	s.deadLettered = append(s.deadLettered, event.MessageID)
+	return nil
+}
+
+func (s *stubEventStream) Lag(context.Context) (domain.ArticleEventLag, error) {
+	return domain.ArticleEventLag{Lag: 3, Pending: 1}, nil
+}
+
+// stubArticleEvents answers by message ID.
+type stubArticleEvents struct {
+	errs map[string]error
+}
+
+func (s *stubArticleEvents) Handle(_ context.Context, event domain.ArticleEvent) (usecase.ArticleEventOutcome, error) {
+	if err := s.errs[event.MessageID]; err != nil {
+		return "", err
+	}
+	return usecase.ArticleEventEnqueued, nil
+}
+
+type stubEventRecorder struct {
+	outcomes []string
+	ages     []time.Duration
+	lag      domain.ArticleEventLag
+}
+
+func (r *stubEventRecorder) RecordArticleEvent(eventType, outcome string) {
+	r.outcomes = append(r.outcomes, eventType+"/"+outcome)
+}
+
+func (r *stubEventRecorder) RecordArticleEventAge(age time.Duration) { r.ages = append(r.ages, age) }
+
+func (r *stubEventRecorder) RecordArticleEventLag(lag domain.ArticleEventLag) { r.lag = lag }
+
+func TestArticleEventConsumer_AcksRetriesAndDeadLetters(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	transient := errors.New("rag-db unavailable")
+	stream := &stubEventStream{batch: []domain.ArticleEvent{
+		{MessageID: "1-0", EventType: domain.ArticleEventCreated, CreatedAt: now.Add(-2 * time.Second), Deliveries: 1},
+		{MessageID: "2-0", EventType: domain.ArticleEventUpdated, Deliveries: 2},
+		{MessageID: "3-0", EventType: domain.ArticleEventUpdated, Deliveries: 5},
+		{MessageID: "4-0", EventType: domain.ArticleEventDeleted, Deliveries: 1},
+	}}
+	events := &stubArticleEvents{errs: map[string]error{
+		"2-0": transient,
+		"3-0": transient,
+		"4-0": fmt.Errorf("%w: no article_id", domain.ErrInvalidArticleEvent),
+	}}
+	recorder := &stubEventRecorder{}
+	c := NewArticleEventConsumer(stream, events, recorder, 5, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	c.clock = func() time.Time { return now }
+
+	require.NoError(t, c.consumeOnce(context.Background()))
+
+	assert.Equal(t, []string{"1-0"}, stream.acked)
+	assert.Equal(t, []string{"3-0", "4-0"}, stream.deadLettered)
+	assert.Equal(t, []string{
+		"ArticleCreated/enqueued",
+		"ArticleUpdated/failed",
+		"ArticleUpdated/dead_lettered",
+		"ArticleDeleted/dead_lettered",
+	}, recorder.outcomes)
+	assert.Equal(t, []time.Duration{2 * time.Second}, recorder.ages)
+}
+
+func TestArticleEventConsumer_RecordsLag(t *testing.T) {
+	recorder := &stubEventRecorder{}
+	c := NewArticleEventConsumer(&stubEventStream{}, &stubArticleEvents{}, recorder, 5, time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	c.recordLag(context.Background())
+
+	assert.Equal(t, domain.ArticleEventLag{Lag: 3, Pending: 1}, recorder.lag)
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"rag-orchestrator/internal/usecase"
+)
+
+// orphanReconcileTimeout bounds one reconcile run.
+const orphanReconcileTimeout = 15 * time.Minute
+
+// OrphanReconcileWorker periodically deletes chunks left behind by
+// superseded and tombstoned versions. Retrieval already ignores them; the
+// worker only keeps rag_chunks and its HNSW index from growing with every
+// re-index.
+type OrphanReconcileWorker struct {
+	stats    usecase.CorpusStatsUsecase
+	interval time.Duration
+	logger   *slog.Logger
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewOrphanReconcileWorker creates a worker reconciling every interval.
+func NewOrphanReconcileWorker(stats usecase.CorpusStatsUsecase, interval time.Duration, logger *slog.Logger) *OrphanReconcileWorker {
+	return &OrphanReconcileWorker{
+		stats:    stats,
+		interval: interval,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+func (w *OrphanReconcileWorker) Start() {
+	w.logger.Info("Starting OrphanReconcileWorker", "interval", w.interval.String())
+	w.wg.Go(w.run)
+}
+
+func (w *OrphanReconcileWorker) Stop() {
+	w.logger.Info("Stopping OrphanReconcileWorker")
+	close(w.stopChan)
+	w.wg.Wait()
+}
+
+func (w *OrphanReconcileWorker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.stopChan
+		cancel()
+	}()
+
+	// No run at startup: a rolling restart should not stack reconcile
+	// passes on a database that is already busy re-warming.
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ticker.C:
+			w.reconcile(ctx)
+		}
+	}
+}
+
+func (w *OrphanReconcileWorker) reconcile(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, orphanReconcileTimeout)
+	defer cancel()
+
+	result, err := w.stats.ReconcileOrphans(ctx)
+	if err != nil {
+		w.logger.Error("Orphaned chunk reconcile failed",
+			"deleted_chunks", result.DeletedChunks,
+			"error", err)
+		return
+	}
+	w.logger.Info("Orphaned chunk reconcile finished",
+		"deleted_chunks", result.DeletedChunks,
+		"batches", result.Batches,
+		"complete", result.Complete,
+		"duration_ms", result.DurationMS)
+}
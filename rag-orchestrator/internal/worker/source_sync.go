@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+)
+
+// sourceSyncTimeout bounds one pass over a connector. Embedding a large
+// upload directory from scratch is the slow case.
+const sourceSyncTimeout = 30 * time.Minute
+
+// SourceSyncWorker periodically pulls every registered SourceConnector into
+// the index. Cursors are kept in memory, so the first pass after a restart
+// is a full listing; unchanged documents are skipped by the source-hash
+// check, which keeps that pass cheap apart from extraction.
+type SourceSyncWorker struct {
+	indexer    usecase.IndexSourceUsecase
+	connectors []domain.SourceConnector
+	interval   time.Duration
+	logger     *slog.Logger
+
+	cursors  map[domain.SourceType]time.Time
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewSourceSyncWorker creates a worker syncing connectors every interval.
+func NewSourceSyncWorker(
+	indexer usecase.IndexSourceUsecase,
+	connectors []domain.SourceConnector,
+	interval time.Duration,
+	logger *slog.Logger,
+) *SourceSyncWorker {
+	return &SourceSyncWorker{
+		indexer:    indexer,
+		connectors: connectors,
+		interval:   interval,
+		logger:     logger,
+		cursors:    make(map[domain.SourceType]time.Time),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+func (w *SourceSyncWorker) Start() {
+	w.logger.Info("Starting SourceSyncWorker",
+		"connectors", len(w.connectors),
+		"interval", w.interval.String())
+	w.wg.Go(w.run)
+}
+
+func (w *SourceSyncWorker) Stop() {
+	w.logger.Info("Stopping SourceSyncWorker")
+	close(w.stopChan)
+	w.wg.Wait()
+}
+
+func (w *SourceSyncWorker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.stopChan
+		cancel()
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.syncAll(ctx)
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		case <-ticker.C:
+			w.syncAll(ctx)
+		}
+	}
+}
+
+func (w *SourceSyncWorker) syncAll(ctx context.Context) {
+	for _, c := range w.connectors {
+		if ctx.Err() != nil {
+			return
+		}
+		w.syncOne(ctx, c)
+	}
+}
+
+func (w *SourceSyncWorker) syncOne(ctx context.Context, c domain.SourceConnector) {
+	ctx, cancel := context.WithTimeout(ctx, sourceSyncTimeout)
+	defer cancel()
+
+	sourceType := c.Type()
+	result, err := w.indexer.Sync(ctx, c, w.cursors[sourceType])
+	if err != nil {
+		w.logger.Error("Source sync failed", "source_type", sourceType, "error", err)
+		return
+	}
+	w.cursors[sourceType] = result.Cursor
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"rag-orchestrator/internal/domain"
+	"rag-orchestrator/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubConnector struct{ sourceType domain.SourceType }
+
+func (c stubConnector) Type() domain.SourceType { return c.sourceType }
+
+func (c stubConnector) Fetch(ctx context.Context, since time.Time) ([]domain.SourceDocument, error) {
+	return nil, nil
+}
+
+type stubSourceIndexer struct {
+	mu      sync.Mutex
+	sinces  []time.Time
+	results []usecase.SourceSyncResult
+	errs    []error
+}
+
+func (s *stubSourceIndexer) Index(ctx context.Context, doc domain.SourceDocument) error { return nil }
+
+func (s *stubSourceIndexer) Sync(ctx context.Context, c domain.SourceConnector, since time.Time) (usecase.SourceSyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := len(s.sinces)
+	s.sinces = append(s.sinces, since)
+	return s.results[i], s.errs[i]
+}
+
+func TestSourceSyncWorker_AdvancesCursorOnlyOnSuccess(t *testing.T) {
+	t1 := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	indexer := &stubSourceIndexer{
+		results: []usecase.SourceSyncResult{{Cursor: t1}, {}, {Cursor: t2}},
+		errs:    []error{nil, errors.New("walk failed"), nil},
+	}
+	w := NewSourceSyncWorker(indexer, []domain.SourceConnector{stubConnector{domain.SourceUpload}},
+		time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx := context.Background()
+	w.syncAll(ctx)
+	w.syncAll(ctx)
+	w.syncAll(ctx)
+
+	assert.Equal(t, []time.Time{{}, t1, t1}, indexer.sinces)
+	assert.Equal(t, t2, w.cursors[domain.SourceUpload])
+}
+
+func TestSourceSyncWorker_StartStop(t *testing.T) {
+	indexer := &stubSourceIndexer{
+		results: []usecase.SourceSyncResult{{}},
+		errs:    []error{nil},
+	}
+	w := NewSourceSyncWorker(indexer, []domain.SourceConnector{stubConnector{domain.SourceUpload}},
+		time.Hour, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	w.Start()
+	assert.Eventually(t, func() bool {
+		indexer.mu.Lock()
+		defer indexer.mu.Unlock()
+		return len(indexer.sinces) == 1
+	}, time.Second, 10*time.Millisecond, "first sync runs immediately on start")
+	w.Stop()
+}
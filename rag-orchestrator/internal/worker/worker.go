@@ -156,5 +156,35 @@ func (w *JobWorker) processBackfillArticle(ctx context.Context, job *domain.RagJ
 	// Throttling could be implemented here (e.g., token bucket or simple sleep)
 	// For now, let's keep it simple as relying on the poll interval acts as a basic rate limiter (1 job/sec/worker)
 
-	return w.indexUsecase.Upsert(ctx, articleID, title, url, body)
+	return w.indexUsecase.UpsertWithMetadata(ctx, articleID, title, url, body, retrievalMetadataFromPayload(payload))
+}
+
+// retrievalMetadataFromPayload reads the optional published_at (RFC 3339),
+// feed_id and tags keys. Jobs enqueued before they existed, or with a
+// malformed value, leave the field zero so the stored value is kept.
+func retrievalMetadataFromPayload(payload map[string]interface{}) domain.RetrievalMetadata {
+	var meta domain.RetrievalMetadata
+	if raw, ok := payload["published_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			meta.PublishedAt = t
+		}
+	}
+	meta.FeedID, _ = payload["feed_id"].(string)
+	// Payloads read back from jsonb hold []interface{}; ones built in
+	// process still hold []string.
+	switch tags := payload["tags"].(type) {
+	case []interface{}:
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok && s != "" {
+				meta.Tags = append(meta.Tags, s)
+			}
+		}
+	case []string:
+		for _, tag := range tags {
+			if tag != "" {
+				meta.Tags = append(meta.Tags, tag)
+			}
+		}
+	}
+	return meta
 }
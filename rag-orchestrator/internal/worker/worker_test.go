@@ -44,15 +44,21 @@ func (s *stubJobRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status str
 }
 
 type stubIndexUsecase struct {
-	mu          sync.Mutex
-	capturedCtx context.Context
-	returnErr   error
+	mu           sync.Mutex
+	capturedCtx  context.Context
+	capturedMeta domain.RetrievalMetadata
+	returnErr    error
 }
 
 func (s *stubIndexUsecase) Upsert(ctx context.Context, articleID, title, url, body string) error {
+	return s.UpsertWithMetadata(ctx, articleID, title, url, body, domain.RetrievalMetadata{})
+}
+
+func (s *stubIndexUsecase) UpsertWithMetadata(ctx context.Context, articleID, title, url, body string, meta domain.RetrievalMetadata) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.capturedCtx = ctx
+	s.capturedMeta = meta
 	return s.returnErr
 }
 
@@ -96,6 +102,32 @@ func TestProcessNextJob_ContextHasTimeout(t *testing.T) {
 	assert.WithinDuration(t, time.Now().Add(jobTimeout), deadline, 5*time.Second)
 }
 
+func TestProcessNextJob_PassesRetrievalMetadata(t *testing.T) {
+	job := makeJob()
+	job.Payload["published_at"] = "2026-10-14T08:30:00Z"
+	job.Payload["feed_id"] = "feed-1"
+	job.Payload["tags"] = []interface{}{"go", "", "release"}
+	uc := &stubIndexUsecase{}
+	repo := &stubJobRepo{jobs: []*domain.RagJob{job, makeJob()}}
+
+	w := NewJobWorker(repo, uc, testLogger())
+	w.processNextJob()
+
+	uc.mu.Lock()
+	assert.Equal(t, domain.RetrievalMetadata{
+		PublishedAt: time.Date(2026, 10, 14, 8, 30, 0, 0, time.UTC),
+		FeedID:      "feed-1",
+		Tags:        []string{"go", "release"},
+	}, uc.capturedMeta)
+	uc.mu.Unlock()
+
+	// Jobs enqueued without the keys index with zero metadata.
+	w.processNextJob()
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	assert.Equal(t, domain.RetrievalMetadata{}, uc.capturedMeta)
+}
+
 func TestJobWorker_BacksOffOnConsecutiveFailures(t *testing.T) {
 	repo := &stubJobRepo{
 		jobs: []*domain.RagJob{makeJob(), makeJob(), makeJob()},
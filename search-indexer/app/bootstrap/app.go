@@ -6,14 +6,18 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"search-indexer/config"
 	"search-indexer/consumer"
 	"search-indexer/driver"
+	"search-indexer/driver/clickhouse"
 	"search-indexer/driver/recap_api"
 	"search-indexer/gateway"
 	"search-indexer/logger"
+	"search-indexer/rest"
 	"search-indexer/tlsutil"
 	"search-indexer/tokenize"
 	"search-indexer/usecase"
@@ -29,13 +33,17 @@ type App struct {
 	httpServer    *http.Server
 	connectServer *http.Server
 	mtlsServer    *http.Server
+	health        *rest.HealthHandler
+	loops         *sync.WaitGroup
 	redisConsumer *consumer.Consumer
 	eventHandler  *consumer.IndexEventHandler
+	searchEvents  *gateway.SearchEventSinkGateway
 	otelShutdown  appOtel.ShutdownFunc
 }
 
 // Run initializes all components and starts the service.
-// It blocks until ctx is cancelled, then performs graceful shutdown.
+// It blocks until ctx is cancelled (SIGINT/SIGTERM), then performs graceful
+// shutdown.
 func Run(ctx context.Context) error {
 	// ── OpenTelemetry ──
 	otelCfg := appOtel.ConfigFromEnv()
@@ -91,7 +99,8 @@ func Run(ctx context.Context) error {
 			Embedder:      config.MeiliHybridEmbedder,
 			SemanticRatio: config.MeiliHybridSemanticRatio,
 		}).
-		WithCache(config.MeiliSearchCacheSize, config.MeiliSearchCacheTTL)
+		WithCache(config.MeiliSearchCacheSize, config.MeiliSearchCacheTTL).
+		WithSearchableAttributes(strings.Split(config.IndexSearchableFields, ","))
 
 	// ── Gateways (anti-corruption layer) ──
 	articleRepo := gateway.NewArticleRepositoryGateway(articleDriver)
@@ -102,14 +111,6 @@ func Run(ctx context.Context) error {
 		return err
 	}
 
-	// Periodically probe Search so Meilisearch keeps the qwen3 embedding model
-	// in Ollama's resident set. A single startup-only probe is not enough:
-	// gemma4 (chat/RAG) and qwen3-embedding were observed to exclusively swap
-	// GPU residency, so the embedder goes cold again within minutes regardless
-	// of OLLAMA_KEEP_ALIVE. Goroutine so a stalled embedder cannot delay
-	// service start or block the loop.
-	go runWarmupLoop(ctx, searchEngine, config.WarmupInterval)
-
 	// Periodically prune finished Meilisearch tasks so registerBatchSynonyms's
 	// full-replace settings PUTs never again fill the task database and wedge
 	// all writes (2026-07-22 incident: search-indexer/CLAUDE.md known failure
@@ -139,10 +140,49 @@ func Run(ctx context.Context) error {
 	}
 
 	// ── Use cases (application layer) ──
-	indexUsecase := usecase.NewIndexArticlesUsecase(articleRepo, searchEngine, tokenizer)
+	// Feed popularity (indexed articles per feed) backs the
+	// feed_popularity:desc custom ranking rule set in EnsureIndex.
+	// Document limits keep one huge article from stalling a batch: bodies
+	// are optionally cleaned, then truncated, and oversized documents are
+	// rejected outright (counted in GET /v1/index/stats).
+	indexUsecase := usecase.NewIndexArticlesUsecase(articleRepo, searchEngine, tokenizer).
+		WithFeedPopularity(gateway.NewFeedPopularityGateway(searchDriver)).
+		WithDocumentLimits(usecase.DocumentLimits{
+			MaxDocumentBytes: config.IndexMaxDocumentBytes,
+			MaxContentBytes:  config.IndexMaxContentBytes,
+			CleanContent:     config.IndexCleanContent,
+		})
 	searchByUserUsecase := usecase.NewSearchByUserUsecase(searchEngine)
 	searchArticlesUsecase := usecase.NewSearchArticlesUsecase(searchEngine)
 
+	// ── Search analytics ──
+	// Served searches are written to ClickHouse through a buffered writer
+	// that drops events rather than ever blocking a search.
+	var searchEvents *gateway.SearchEventSinkGateway
+	if analytics := appCfg.SearchAnalytics; analytics.Enabled() {
+		chClient := clickhouse.NewClient(clickhouse.Config{
+			URL:      analytics.ClickHouseURL,
+			Database: analytics.Database,
+			Table:    analytics.Table,
+			User:     analytics.User,
+			Password: analytics.Password,
+		}, nil)
+		searchEvents = gateway.NewSearchEventSinkGateway(chClient, gateway.SearchEventSinkConfig{
+			BufferSize:    analytics.BufferSize,
+			BatchSize:     analytics.BatchSize,
+			FlushInterval: analytics.FlushInterval,
+		}, logger.Logger)
+		searchEvents.Start()
+		searchByUserUsecase.WithEventSink(searchEvents)
+		searchArticlesUsecase.WithEventSink(searchEvents)
+		logger.Logger.Info("Search analytics enabled",
+			"clickhouse_url", analytics.ClickHouseURL,
+			"table", analytics.Database+"."+analytics.Table,
+		)
+	} else {
+		logger.Logger.Info("Search analytics disabled (SEARCH_ANALYTICS_CLICKHOUSE_URL not set)")
+	}
+
 	// ── Redis Streams Consumer ──
 	var redisConsumer *consumer.Consumer
 	var eventHandler *consumer.IndexEventHandler
@@ -155,9 +195,11 @@ func Run(ctx context.Context) error {
 			return fmt.Errorf("create redis streams consumer: %w", err)
 		}
 		// CONSUMER_ENABLED=true is an explicit request for event-driven
-		// indexing; if it can't start, fail startup instead of silently
-		// falling back to the 5-minute polling loop (a quiet search-latency
-		// regression that healthchecks would not catch, CLAUDE.md rule 8).
+		// indexing; if it can't start (bad URL, unprovisioned group), fail
+		// startup instead of silently falling back to the polling loop (a
+		// quiet search-latency regression that healthchecks would not
+		// catch, CLAUDE.md rule 8). Redis merely being down is not fatal:
+		// the consumer retries and polling covers indexing meanwhile.
 		if err := redisConsumer.Start(ctx); err != nil {
 			logger.Logger.Error("Failed to start Redis Streams consumer", "err", err)
 			return fmt.Errorf("start redis streams consumer: %w", err)
@@ -171,7 +213,15 @@ func Run(ctx context.Context) error {
 	}
 
 	// ── Batch indexer (polling fallback) ──
-	go runIndexLoop(ctx, indexUsecase)
+	// Index loops are tracked so shutdown can wait for an in-flight batch to
+	// finish writing to Meilisearch instead of abandoning it mid-request.
+	// While the consumer is reading the stream, polling only reconciles.
+	loops := &sync.WaitGroup{}
+	var stream eventStream
+	if redisConsumer != nil {
+		stream = redisConsumer
+	}
+	loops.Go(func() { runIndexLoop(ctx, loops, indexUsecase, stream) })
 
 	// Periodically PUT the accumulated synonyms union instead of once per
 	// indexed batch, bounding how often Meilisearch's task history grows
@@ -180,15 +230,53 @@ func Run(ctx context.Context) error {
 
 	// ── Recap indexer ──
 	if indexRecapsUsecase != nil {
-		go runRecapIndexLoop(ctx, indexRecapsUsecase)
+		loops.Go(func() { runRecapIndexLoop(ctx, indexRecapsUsecase) })
 	}
 
+	// ── Health ──
+	// Readiness reflects the two dependencies every search and index batch
+	// needs: Meilisearch itself and the article database behind alt-backend.
+	health := rest.NewHealthHandler(config.ReadinessCheckTimeout,
+		rest.ReadinessCheck{Name: "meilisearch", Check: func(ctx context.Context) error {
+			_, err := msClient.HealthWithContext(ctx)
+			return err
+		}},
+		rest.ReadinessCheck{Name: "article_db", Check: func(ctx context.Context) error {
+			_, err := articleDriver.GetLatestCreatedAt(ctx)
+			return err
+		}},
+	)
+
+	indexStats := rest.NewIndexStatsHandler(indexUsecase, searchDriver.SearchableAttributes())
+
+	// ── Warm-up ──
+	// Run the popular-query set and prefetch index stats before /ready turns
+	// green, then periodically probe Search so Meilisearch keeps the qwen3
+	// embedding model in Ollama's resident set. A single startup-only probe
+	// is not enough: gemma4 (chat/RAG) and qwen3-embedding were observed to
+	// exclusively swap GPU residency, so the embedder goes cold again within
+	// minutes regardless of OLLAMA_KEEP_ALIVE. Goroutine so a stalled
+	// embedder cannot delay the listeners (only readiness, and only up to
+	// MEILI_WARMUP_STARTUP_TIMEOUT).
+	warmupQueries := parseWarmupQueries(config.WarmupQueries)
+	health.StartWarmup(len(warmupQueries))
+	go func() {
+		// Warm-up probes use their own usecase so they stay out of search
+		// analytics.
+		runStartupWarmup(ctx, usecase.NewSearchArticlesUsecase(searchEngine), searchDriver, health,
+			warmupQueries, config.WarmupQueryLimit, config.WarmupStartupTimeout)
+		runWarmupLoop(ctx, searchEngine, config.WarmupInterval)
+	}()
+
 	// ── Servers ──
 	app := &App{
-		httpServer:    newHTTPServer(searchByUserUsecase, searchArticlesUsecase, otelCfg, appCfg.RateLimit),
+		httpServer:    newHTTPServer(searchByUserUsecase, searchArticlesUsecase, health, indexStats, otelCfg, appCfg.RateLimit, appCfg.SearchAuth),
 		connectServer: newConnectServer(searchByUserUsecase, searchRecapsUsecase, appCfg.RateLimit),
+		health:        health,
+		loops:         loops,
 		redisConsumer: redisConsumer,
 		eventHandler:  eventHandler,
+		searchEvents:  searchEvents,
 		otelShutdown:  otelShutdown,
 	}
 
@@ -228,8 +316,11 @@ func Run(ctx context.Context) error {
 				searchByUserUsecase,
 				searchArticlesUsecase,
 				app.connectServer.Handler,
+				health,
+				indexStats,
 				otelCfg,
 				appCfg.RateLimit,
+				appCfg.SearchAuth,
 			)
 			app.mtlsServer = tlsutil.NewMTLSHTTPServer(":"+mtlsPort, tlsCfg, mtlsHandler)
 			go func() {
@@ -249,6 +340,12 @@ func Run(ctx context.Context) error {
 
 // shutdown performs graceful shutdown of all components.
 //
+// Readiness flips to "draining" first and the listeners keep serving for
+// config.ShutdownDrainDelay, so load balancers stop sending new searches
+// before connections are refused. Server.Shutdown then lets in-flight
+// requests complete, and the index loops are given the same deadline to
+// finish their current batch.
+//
 // The Redis consumer's intake (XREADGROUP/XAUTOCLAIM loops) is halted
 // before the event handler flushes, and the handler flushes (and ACKs)
 // before the consumer's Redis client is closed. Reversing this order was
@@ -256,6 +353,10 @@ func Run(ctx context.Context) error {
 // to ~2s of buffered, already-ACKed events were silently dropped on every
 // restart. See .claude/rules/event-stream-consumer.md shutdown ordering.
 func (a *App) shutdown() {
+	a.health.SetDraining()
+	logger.Logger.Info("draining before shutdown", "delay", config.ShutdownDrainDelay)
+	time.Sleep(config.ShutdownDrainDelay)
+
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -271,6 +372,10 @@ func (a *App) shutdown() {
 		}
 	}
 
+	if err := waitGroupWithContext(shutdownCtx, a.loops); err != nil {
+		logger.Logger.Warn("index loops did not finish their batch before the shutdown deadline", "err", err)
+	}
+
 	if a.redisConsumer != nil {
 		a.redisConsumer.StopIntake()
 	}
@@ -281,6 +386,12 @@ func (a *App) shutdown() {
 		a.redisConsumer.Close()
 	}
 
+	// Listeners are closed, so no more searches can be recorded: flush what
+	// is buffered.
+	if a.searchEvents != nil {
+		a.searchEvents.Stop(shutdownCtx)
+	}
+
 	otelCtx, otelCancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer otelCancel()
 	if err := a.otelShutdown(otelCtx); err != nil {
@@ -288,6 +399,21 @@ func (a *App) shutdown() {
 	}
 }
 
+// waitGroupWithContext waits for wg or returns ctx.Err() once ctx is done.
+func waitGroupWithContext(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // newRetryBackoff creates an exponential backoff policy for index loop retries.
 func newRetryBackoff() *backoff.ExponentialBackOff {
 	bo := backoff.NewExponentialBackOff()
@@ -332,7 +458,9 @@ func safeExecuteIncremental(ctx context.Context, uc *usecase.IndexArticlesUsecas
 
 // runIndexLoop runs the dual-phase indexing loop using clean architecture.
 // Phase 1 (Backfill): Index all existing articles from latest to oldest.
-// Phase 2 (Incremental): Poll for new articles and sync deletions.
+// Phase 2 (Incremental): Poll for new articles and sync deletions. While
+// the Redis Streams consumer is reading, events do the indexing and this
+// phase only reconciles every IndexReconcileInterval (see waitIncremental).
 //
 // Per-batch panics inside either phase are recovered close to the source by
 // safeExecuteBackfill/safeExecuteIncremental and folded into the existing
@@ -340,8 +468,13 @@ func safeExecuteIncremental(ctx context.Context, uc *usecase.IndexArticlesUsecas
 // intact. The recover below is a last-resort safety net for anything else:
 // it restarts the loop instead of letting the goroutine exit, because the
 // previous behavior -- log and return -- silently and permanently halted
-// indexing while the service kept reporting healthy.
-func runIndexLoop(ctx context.Context, indexUsecase *usecase.IndexArticlesUsecase) {
+// indexing while the service kept reporting healthy. The restarted loop
+// joins loops so shutdown still waits for it.
+//
+// ctx only stops the loop between batches: each batch runs on a context
+// detached from cancellation so SIGTERM lets it finish (and advance the
+// Meilisearch index consistently) rather than aborting it half-written.
+func runIndexLoop(ctx context.Context, loops *sync.WaitGroup, indexUsecase *usecase.IndexArticlesUsecase, stream eventStream) {
 	defer func() {
 		if r := recover(); r != nil {
 			logPanic(ctx, "index loop panic, restarting", r)
@@ -350,7 +483,7 @@ func runIndexLoop(ctx context.Context, indexUsecase *usecase.IndexArticlesUsecas
 				return
 			case <-time.After(indexLoopRestartDelay):
 			}
-			go runIndexLoop(ctx, indexUsecase)
+			loops.Go(func() { runIndexLoop(ctx, loops, indexUsecase, stream) })
 		}
 	}()
 
@@ -385,7 +518,7 @@ func runIndexLoop(ctx context.Context, indexUsecase *usecase.IndexArticlesUsecas
 		}
 
 		start := time.Now()
-		result, err := safeExecuteBackfill(ctx, indexUsecase, lastCreatedAt, lastID, config.IndexBatchSize)
+		result, err := safeExecuteBackfill(context.WithoutCancel(ctx), indexUsecase, lastCreatedAt, lastID, config.IndexBatchSize)
 		if err != nil {
 			recordError(ctx, "backfill")
 			delay := bo.NextBackOff()
@@ -400,12 +533,12 @@ func runIndexLoop(ctx context.Context, indexUsecase *usecase.IndexArticlesUsecas
 		bo.Reset()
 		recordBatch(ctx, "backfill", result.IndexedCount, result.DeletedCount, time.Since(start))
 
-		if result.IndexedCount == 0 {
+		if result.BackfillDone {
 			logger.Logger.Info("Phase 1 complete: backfill done")
 			break
 		}
 
-		logger.Logger.Info("backfill indexed", "count", result.IndexedCount)
+		logger.Logger.Info("backfill indexed", "count", result.IndexedCount, "rejected", result.RejectedCount)
 		lastCreatedAt = result.LastCreatedAt
 		lastID = result.LastID
 	}
@@ -426,7 +559,7 @@ func runIndexLoop(ctx context.Context, indexUsecase *usecase.IndexArticlesUsecas
 		}
 
 		start := time.Now()
-		result, err := safeExecuteIncremental(ctx, indexUsecase, incrementalMark, lastCreatedAt, lastID, lastDeletedAt, config.IndexBatchSize)
+		result, err := safeExecuteIncremental(context.WithoutCancel(ctx), indexUsecase, incrementalMark, lastCreatedAt, lastID, lastDeletedAt, config.IndexBatchSize)
 		if err != nil {
 			recordError(ctx, "incremental")
 			delay := bo.NextBackOff()
@@ -441,8 +574,10 @@ func runIndexLoop(ctx context.Context, indexUsecase *usecase.IndexArticlesUsecas
 		bo.Reset()
 		recordBatch(ctx, "incremental", result.IndexedCount, result.DeletedCount, time.Since(start))
 
-		if result.IndexedCount > 0 {
-			logger.Logger.Info("incremental indexed", "count", result.IndexedCount)
+		// A batch whose documents were all rejected as oversized still
+		// moves the cursor, or the loop would re-read it forever.
+		if result.IndexedCount > 0 || result.RejectedCount > 0 {
+			logger.Logger.Info("incremental indexed", "count", result.IndexedCount, "rejected", result.RejectedCount)
 			lastCreatedAt = result.LastCreatedAt
 			lastID = result.LastID
 		}
@@ -456,9 +591,7 @@ func runIndexLoop(ctx context.Context, indexUsecase *usecase.IndexArticlesUsecas
 			logger.Logger.Info("no new articles or deletions")
 		}
 
-		select {
-		case <-time.After(config.IndexInterval):
-		case <-ctx.Done():
+		if !waitIncremental(ctx, stream, time.Now(), config.IndexInterval, config.IndexReconcileInterval) {
 			return
 		}
 	}
@@ -487,7 +620,7 @@ func runRecapIndexLoop(ctx context.Context, indexRecapsUsecase *usecase.IndexRec
 		}
 
 		start := time.Now()
-		result, err := indexRecapsUsecase.ExecuteBackfill(ctx, lastSince, config.RecapIndexBatchSize)
+		result, err := indexRecapsUsecase.ExecuteBackfill(context.WithoutCancel(ctx), lastSince, config.RecapIndexBatchSize)
 		if err != nil {
 			recordError(ctx, "recap_backfill")
 			delay := bo.NextBackOff()
@@ -526,7 +659,7 @@ func runRecapIndexLoop(ctx context.Context, indexRecapsUsecase *usecase.IndexRec
 		}
 
 		start := time.Now()
-		result, err := indexRecapsUsecase.ExecuteIncremental(ctx, lastSince, config.RecapIndexBatchSize)
+		result, err := indexRecapsUsecase.ExecuteIncremental(context.WithoutCancel(ctx), lastSince, config.RecapIndexBatchSize)
 		if err != nil {
 			recordError(ctx, "recap_incremental")
 			delay := bo.NextBackOff()
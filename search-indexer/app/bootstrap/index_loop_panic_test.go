@@ -63,6 +63,9 @@ func (noopSearchEngine) SearchWithFilters(ctx context.Context, query string, fil
 func (noopSearchEngine) SearchWithDateFilter(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, limit int) ([]domain.SearchDocument, error) {
 	return nil, nil
 }
+func (noopSearchEngine) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	return nil, 0, nil
+}
 func (noopSearchEngine) EnsureIndex(ctx context.Context) error { return nil }
 func (noopSearchEngine) SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]domain.SearchDocument, error) {
 	return nil, nil
@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+)
+
+// eventStream reports whether event-driven indexing is live;
+// *consumer.Consumer implements it.
+type eventStream interface {
+	Available() bool
+}
+
+// waitIncremental blocks until the next incremental pass is due and returns
+// false once ctx is done. The stream is checked every interval: while it is
+// available the pass is held back until reconcile has elapsed since
+// lastPass, and as soon as it is not the pass runs, so polling takes over
+// within one interval of the stream going away. A nil stream means polling
+// is the only indexing path.
+func waitIncremental(ctx context.Context, stream eventStream, lastPass time.Time, interval, reconcile time.Duration) bool {
+	for {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return false
+		}
+		if stream == nil || !stream.Available() || time.Since(lastPass) >= reconcile {
+			return true
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeEventStream struct {
+	available atomic.Bool
+}
+
+func (f *fakeEventStream) Available() bool { return f.available.Load() }
+
+func waitIncrementalAsync(ctx context.Context, stream eventStream, reconcile time.Duration) <-chan bool {
+	done := make(chan bool, 1)
+	go func() { done <- waitIncremental(ctx, stream, time.Now(), 10*time.Millisecond, reconcile) }()
+	return done
+}
+
+// TestWaitIncremental_PollsEveryIntervalWithoutStream keeps the polling
+// cadence unchanged when the consumer is disabled.
+func TestWaitIncremental_PollsEveryIntervalWithoutStream(t *testing.T) {
+	select {
+	case ok := <-waitIncrementalAsync(context.Background(), nil, time.Hour):
+		if !ok {
+			t.Fatal("waitIncremental() = false, want true")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("waitIncremental() did not return after one interval")
+	}
+}
+
+// TestWaitIncremental_FallsBackWhenStreamGoesAway holds the pass back while
+// the stream is live and releases it within an interval of it going away.
+func TestWaitIncremental_FallsBackWhenStreamGoesAway(t *testing.T) {
+	stream := &fakeEventStream{}
+	stream.available.Store(true)
+
+	done := waitIncrementalAsync(context.Background(), stream, time.Hour)
+	select {
+	case <-done:
+		t.Fatal("waitIncremental() returned while the stream was live and reconcile had not elapsed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	stream.available.Store(false)
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Fatal("waitIncremental() = false, want true")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("waitIncremental() did not return after the stream became unavailable")
+	}
+}
+
+// TestWaitIncremental_ReconcilesWhileStreamLive still runs a pass once the
+// reconcile interval has elapsed, so events the stream missed get indexed.
+func TestWaitIncremental_ReconcilesWhileStreamLive(t *testing.T) {
+	stream := &fakeEventStream{}
+	stream.available.Store(true)
+
+	select {
+	case ok := <-waitIncrementalAsync(context.Background(), stream, 50*time.Millisecond):
+		if !ok {
+			t.Fatal("waitIncremental() = false, want true")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("waitIncremental() did not return after the reconcile interval")
+	}
+}
+
+func TestWaitIncremental_StopsOnContextCancel(t *testing.T) {
+	stream := &fakeEventStream{}
+	stream.available.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := waitIncrementalAsync(ctx, stream, time.Hour)
+	cancel()
+	select {
+	case ok := <-done:
+		if ok {
+			t.Fatal("waitIncremental() = true after cancel, want false")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("waitIncremental() did not return after cancel")
+	}
+}
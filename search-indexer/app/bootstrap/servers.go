@@ -1,7 +1,6 @@
 package bootstrap
 
 import (
-	"io"
 	"net/http"
 	"os"
 	"strings"
@@ -11,42 +10,87 @@ import (
 
 	"search-indexer/config"
 	connectv2 "search-indexer/connect/v2"
+	"search-indexer/logger"
 	"search-indexer/middleware"
 	"search-indexer/rest"
 	"search-indexer/usecase"
 	appOtel "search-indexer/utils/otel"
 )
 
-// newHTTPServer creates the REST HTTP server.
-func newHTTPServer(searchByUserUsecase *usecase.SearchByUserUsecase, searchArticlesUsecase *usecase.SearchArticlesUsecase, otelCfg appOtel.Config, rlCfg config.RateLimitConfig) *http.Server {
+// newHTTPServer creates the REST HTTP server. Routes are registered with
+// method patterns so anything other than GET answers 405, and every request
+// passes through the shared stack: request log -> panic recovery -> CORS ->
+// per-request timeout.
+func newHTTPServer(searchByUserUsecase *usecase.SearchByUserUsecase, searchArticlesUsecase *usecase.SearchArticlesUsecase, health *rest.HealthHandler, indexStats *rest.IndexStatsHandler, otelCfg appOtel.Config, rlCfg config.RateLimitConfig, authCfg config.SearchAuthConfig) *http.Server {
 	restHandler := rest.NewHandler(searchByUserUsecase, searchArticlesUsecase)
 
 	mux := http.NewServeMux()
 
-	healthHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = io.WriteString(w, `{"status":"ok"}`)
-	})
-
-	// /v1/search is gated at the transport layer (mTLS peer-identity on the
-	// :9443 listener, see newMTLSMuxHandler). The plaintext :9300 path here
-	// serves only rate-limited handlers; auth has been removed pending
-	// retirement of the listener itself.
+	// /v1/search on the plaintext :9300 listener requires an API key
+	// (anonymous only in development), then passes the global and the
+	// per-caller rate limits and is audit-logged with the caller's name.
 	rateLimiter := middleware.NewRateLimiter(rate.Limit(rlCfg.RequestsPerSecond), rlCfg.Burst)
-	searchHandler := rateLimiter.Middleware(http.HandlerFunc(restHandler.SearchArticles))
+	apiKeyAuth := middleware.NewAPIKeyAuth(authCfg.APIKeys, authCfg.AllowAnonymous)
+	callerLimiter := middleware.NewCallerRateLimiter(rate.Limit(authCfg.PerCallerRequestsPerSecond), authCfg.PerCallerBurst)
+	searchHandler := middleware.Chain(http.HandlerFunc(restHandler.SearchArticles),
+		rateLimiter.Middleware,
+		apiKeyAuth.Require,
+		callerLimiter.Middleware,
+		middleware.SearchAudit,
+	)
+	// /v1/search/export sits behind the same checks. It streams for longer
+	// than HTTPRequestTimeout allows, so it is routed around the Timeout
+	// middleware below and bounds itself instead.
+	exportHandler := middleware.Chain(http.HandlerFunc(restHandler.ExportArticles),
+		rateLimiter.Middleware,
+		apiKeyAuth.Require,
+		callerLimiter.Middleware,
+		middleware.SearchAudit,
+	)
+	if len(authCfg.APIKeys) == 0 && !authCfg.AllowAnonymous {
+		logger.Logger.Warn("REST /v1/search has no API keys configured (SEARCH_API_KEYS); plaintext callers will be rejected")
+	}
+	liveHandler := http.HandlerFunc(health.Live)
+	readyHandler := http.HandlerFunc(health.Ready)
+	statsHandler := http.HandlerFunc(indexStats.Stats)
 
 	if otelCfg.Enabled {
-		mux.Handle("/v1/search", middleware.OTelStatusHandler(searchHandler, "GET /v1/search"))
-		mux.Handle("/health", middleware.OTelStatusHandlerFunc(healthHandler, "GET /health"))
+		exportHandler = middleware.OTelStatusHandler(exportHandler, "GET /v1/search/export")
+		mux.Handle("GET /v1/search", middleware.OTelStatusHandler(searchHandler, "GET /v1/search"))
+		mux.Handle("GET /v1/index/stats", middleware.OTelStatusHandlerFunc(statsHandler, "GET /v1/index/stats"))
+		mux.Handle("GET /health", middleware.OTelStatusHandlerFunc(liveHandler, "GET /health"))
+		mux.Handle("GET /ready", middleware.OTelStatusHandlerFunc(readyHandler, "GET /ready"))
 	} else {
-		mux.Handle("/v1/search", searchHandler)
-		mux.Handle("/health", healthHandler)
+		mux.Handle("GET /v1/search", searchHandler)
+		mux.Handle("GET /v1/index/stats", statsHandler)
+		mux.Handle("GET /health", liveHandler)
+		mux.Handle("GET /ready", readyHandler)
 	}
 
+	corsOrigins := parseAllowedPeers(config.HTTPCORSAllowedOrigins)
+	if len(corsOrigins) > 0 {
+		logger.Logger.Info("REST CORS enabled", "allowed_origins", corsOrigins)
+	} else {
+		logger.Logger.Info("REST CORS disabled (HTTP_CORS_ALLOWED_ORIGINS not set)")
+	}
+
+	handler := middleware.Chain(mux,
+		middleware.RequestLogger,
+		middleware.Recover,
+		middleware.CORS(corsOrigins),
+		middleware.Timeout(config.HTTPRequestTimeout),
+	)
+
+	root := http.NewServeMux()
+	root.Handle("GET /v1/search/export", middleware.Chain(exportHandler,
+		middleware.RequestLogger,
+		middleware.Recover,
+	))
+	root.Handle("/", handler)
+
 	return &http.Server{
 		Addr:              config.HTTPAddr,
-		Handler:           mux,
+		Handler:           root,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       30 * time.Second,
 		WriteTimeout:      30 * time.Second,
@@ -71,7 +115,8 @@ func newConnectServer(searchByUserUsecase *usecase.SearchByUserUsecase, searchRe
 }
 
 // newMTLSMuxHandler builds the combined handler served on the :9443 mTLS
-// listener: REST under /v1/* + Connect-RPC under /services.* + /health.
+// listener: REST under /v1/* + Connect-RPC under /services.* + /health and
+// /ready.
 // All REST endpoints are gated by peer_identity (TLS client-cert CN
 // allowlist). Connect-RPC already runs through the Connect interceptor stack
 // with its own auth chain; the same peer_identity check is applied at the
@@ -83,8 +128,11 @@ func newMTLSMuxHandler(
 	searchByUserUsecase *usecase.SearchByUserUsecase,
 	searchArticlesUsecase *usecase.SearchArticlesUsecase,
 	connectServerHandler http.Handler,
+	health *rest.HealthHandler,
+	indexStats *rest.IndexStatsHandler,
 	otelCfg appOtel.Config,
 	rlCfg config.RateLimitConfig,
+	authCfg config.SearchAuthConfig,
 ) http.Handler {
 	restHandler := rest.NewHandler(searchByUserUsecase, searchArticlesUsecase)
 
@@ -92,26 +140,43 @@ func newMTLSMuxHandler(
 	peer := middleware.NewPeerIdentityMiddleware(allowed)
 	rateLimiter := middleware.NewRateLimiter(rate.Limit(rlCfg.RequestsPerSecond), rlCfg.Burst)
 
-	// REST /v1/search guarded by peer identity + rate limit.
-	search := rateLimiter.Middleware(peer.Require(http.HandlerFunc(restHandler.SearchArticles)))
+	// REST /v1/search guarded by peer identity + global and per-peer rate
+	// limits, and audit-logged under the peer's CN.
+	callerLimiter := middleware.NewCallerRateLimiter(rate.Limit(authCfg.PerCallerRequestsPerSecond), authCfg.PerCallerBurst)
+	search := middleware.Chain(http.HandlerFunc(restHandler.SearchArticles),
+		rateLimiter.Middleware,
+		peer.Require,
+		callerLimiter.Middleware,
+		middleware.SearchAudit,
+	)
+	export := middleware.Chain(http.HandlerFunc(restHandler.ExportArticles),
+		rateLimiter.Middleware,
+		peer.Require,
+		callerLimiter.Middleware,
+		middleware.SearchAudit,
+	)
+	stats := peer.Require(http.HandlerFunc(indexStats.Stats))
 	// Connect-RPC is also gated by peer identity at the mux layer — inside,
 	// the existing ServiceAuthInterceptor remains during the migration window.
 	connect := peer.Require(connectServerHandler)
 
 	mux := http.NewServeMux()
 
-	health := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = io.WriteString(w, `{"status":"ok"}`)
-	})
+	live := http.HandlerFunc(health.Live)
+	ready := http.HandlerFunc(health.Ready)
 
 	if otelCfg.Enabled {
 		mux.Handle("/v1/search", middleware.OTelStatusHandler(search, "GET /v1/search"))
-		mux.Handle("/health", middleware.OTelStatusHandlerFunc(health, "GET /health"))
+		mux.Handle("/v1/search/export", middleware.OTelStatusHandler(export, "GET /v1/search/export"))
+		mux.Handle("/v1/index/stats", middleware.OTelStatusHandler(stats, "GET /v1/index/stats"))
+		mux.Handle("/health", middleware.OTelStatusHandlerFunc(live, "GET /health"))
+		mux.Handle("/ready", middleware.OTelStatusHandlerFunc(ready, "GET /ready"))
 	} else {
 		mux.Handle("/v1/search", search)
-		mux.Handle("/health", health)
+		mux.Handle("/v1/search/export", export)
+		mux.Handle("/v1/index/stats", stats)
+		mux.Handle("/health", live)
+		mux.Handle("/ready", ready)
 	}
 	// Connect-RPC service paths: /services.search.v2.SearchService/*
 	mux.Handle("/services.search.v2.SearchService/", connect)
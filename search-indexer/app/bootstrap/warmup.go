@@ -2,10 +2,14 @@ package bootstrap
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"search-indexer/domain"
+	"search-indexer/driver"
 	"search-indexer/logger"
+	"search-indexer/rest"
+	"search-indexer/usecase"
 )
 
 // warmupTimeout caps how long the warmup probe is allowed to block. Set to
@@ -75,3 +79,83 @@ func runWarmupLoop(ctx context.Context, eng warmupSearcher, interval time.Durati
 		}
 	}
 }
+
+// warmupQueryRunner is the search entry point warm-up queries go through.
+// SearchArticlesUsecase.Execute applies the same sanitization as the REST
+// global search path, so the cache keys it fills are the ones real queries hit.
+type warmupQueryRunner interface {
+	Execute(ctx context.Context, query string, limit int) (*usecase.SearchResult, error)
+}
+
+// warmupStatsFetcher prefetches the article index stats.
+type warmupStatsFetcher interface {
+	IndexStats(ctx context.Context) (*driver.IndexStatsDriver, error)
+}
+
+// warmupReadiness receives the warm-up result; rest.HealthHandler implements
+// it. The caller marks the warm-up started before the listeners come up so
+// /ready can never answer "ready" ahead of it.
+type warmupReadiness interface {
+	FinishWarmup(report rest.WarmupReport)
+}
+
+// parseWarmupQueries splits the MEILI_WARMUP_QUERIES list, dropping blanks
+// and duplicates.
+func parseWarmupQueries(spec string) []string {
+	seen := make(map[string]struct{})
+	var queries []string
+	for _, q := range strings.Split(spec, ",") {
+		q = strings.TrimSpace(q)
+		if q == "" {
+			continue
+		}
+		if _, dup := seen[q]; dup {
+			continue
+		}
+		seen[q] = struct{}{}
+		queries = append(queries, q)
+	}
+	return queries
+}
+
+// runStartupWarmup runs the configured popular queries and prefetches index
+// stats before releasing readiness, so the first user queries after a deploy
+// hit a filled cache instead of paying cold-cache latency. Queries run one
+// at a time to avoid stampeding the embedder right after start. The whole
+// run is bounded by timeout; failures are logged and counted but never keep
+// the service unready.
+func runStartupWarmup(ctx context.Context, runner warmupQueryRunner, stats warmupStatsFetcher, ready warmupReadiness, queries []string, limit int, timeout time.Duration) {
+	wctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	report := rest.WarmupReport{Queries: len(queries)}
+	for _, q := range queries {
+		if wctx.Err() != nil {
+			report.QueriesFailed += len(queries) - report.QueriesOK - report.QueriesFailed
+			break
+		}
+		if _, err := runner.Execute(wctx, q, limit); err != nil {
+			report.QueriesFailed++
+			logger.Logger.WarnContext(ctx, "warmup query failed", "query", q, "err", err)
+			continue
+		}
+		report.QueriesOK++
+	}
+
+	if idx, err := stats.IndexStats(wctx); err != nil {
+		logger.Logger.WarnContext(ctx, "warmup index stats prefetch failed", "err", err)
+	} else {
+		report.IndexDocuments = &idx.NumberOfDocuments
+		report.IndexIsIndexing = idx.IsIndexing
+	}
+
+	report.ElapsedMs = time.Since(start).Milliseconds()
+	ready.FinishWarmup(report)
+	logger.Logger.InfoContext(ctx, "startup warmup finished",
+		"queries", report.Queries,
+		"queries_ok", report.QueriesOK,
+		"queries_failed", report.QueriesFailed,
+		"elapsed_ms", report.ElapsedMs,
+	)
+}
@@ -8,7 +8,10 @@ import (
 	"time"
 
 	"search-indexer/domain"
+	"search-indexer/driver"
 	"search-indexer/logger"
+	"search-indexer/rest"
+	"search-indexer/usecase"
 )
 
 type fakeWarmupEngine struct {
@@ -148,3 +151,80 @@ func TestRunWarmupLoop_RespectsCancellation(t *testing.T) {
 		t.Fatal("runWarmupLoop did not honor a pre-cancelled context")
 	}
 }
+
+type fakeWarmupRunner struct {
+	queries []string
+	fail    map[string]bool
+}
+
+func (f *fakeWarmupRunner) Execute(_ context.Context, query string, limit int) (*usecase.SearchResult, error) {
+	f.queries = append(f.queries, query)
+	if f.fail[query] {
+		return nil, errors.New("embedder unreachable")
+	}
+	return &usecase.SearchResult{Query: query}, nil
+}
+
+type fakeWarmupStats struct{ err error }
+
+func (f *fakeWarmupStats) IndexStats(context.Context) (*driver.IndexStatsDriver, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &driver.IndexStatsDriver{NumberOfDocuments: 1200, IsIndexing: true}, nil
+}
+
+type fakeWarmupReadiness struct{ report *rest.WarmupReport }
+
+func (f *fakeWarmupReadiness) FinishWarmup(report rest.WarmupReport) { f.report = &report }
+
+func TestParseWarmupQueries(t *testing.T) {
+	got := parseWarmupQueries(" AI , 半導体,,AI, rust ")
+	want := []string{"AI", "半導体", "rust"}
+	if len(got) != len(want) {
+		t.Fatalf("parseWarmupQueries = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("parseWarmupQueries = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestRunStartupWarmup_ReportsResults checks that every configured query is
+// run, failures are counted instead of aborting the set, and the index stats
+// reach the readiness report.
+func TestRunStartupWarmup_ReportsResults(t *testing.T) {
+	logger.Init()
+	runner := &fakeWarmupRunner{fail: map[string]bool{"半導体": true}}
+	ready := &fakeWarmupReadiness{}
+
+	runStartupWarmup(context.Background(), runner, &fakeWarmupStats{}, ready, []string{"AI", "半導体", "rust"}, 50, time.Second)
+
+	if len(runner.queries) != 3 {
+		t.Fatalf("ran %v, want all 3 queries", runner.queries)
+	}
+	r := ready.report
+	if r == nil {
+		t.Fatal("FinishWarmup was not called")
+	}
+	if r.Queries != 3 || r.QueriesOK != 2 || r.QueriesFailed != 1 {
+		t.Fatalf("unexpected counts %+v", r)
+	}
+	if r.IndexDocuments == nil || *r.IndexDocuments != 1200 || !r.IndexIsIndexing {
+		t.Fatalf("index stats missing from report %+v", r)
+	}
+}
+
+// TestRunStartupWarmup_StatsFailureStillFinishes guards readiness: a failed
+// stats prefetch must still release /ready.
+func TestRunStartupWarmup_StatsFailureStillFinishes(t *testing.T) {
+	logger.Init()
+	ready := &fakeWarmupReadiness{}
+
+	runStartupWarmup(context.Background(), &fakeWarmupRunner{}, &fakeWarmupStats{err: errors.New("forbidden")}, ready, nil, 50, time.Second)
+
+	if ready.report == nil || ready.report.IndexDocuments != nil {
+		t.Fatalf("unexpected report %+v", ready.report)
+	}
+}
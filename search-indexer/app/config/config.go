@@ -34,15 +34,49 @@ func parseIntEnv(key string, defaultValue int) int {
 	return n
 }
 
+func parseDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	v := getEnvOrDefault(key, "")
+	if v == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
+
 type Config struct {
-	Meilisearch MeilisearchConfig
-	BackendAPI  BackendAPIConfig
-	RateLimit   RateLimitConfig
+	Meilisearch     MeilisearchConfig
+	BackendAPI      BackendAPIConfig
+	RateLimit       RateLimitConfig
+	SearchAuth      SearchAuthConfig
+	SearchAnalytics SearchAnalyticsConfig
+}
+
+// SearchAnalyticsConfig points the search event sink at ClickHouse. An
+// empty ClickHouseURL disables search analytics.
+type SearchAnalyticsConfig struct {
+	// ClickHouseURL is the ClickHouse HTTP interface, e.g. http://clickhouse:8123.
+	ClickHouseURL string
+	Database      string
+	Table         string
+	User          string
+	Password      string
+	// BufferSize, BatchSize and FlushInterval size the buffered writer; see
+	// gateway.SearchEventSinkConfig.
+	BufferSize    int
+	BatchSize     int
+	FlushInterval time.Duration
 }
 
-// RateLimitConfig bounds incoming REST and Connect-RPC request throughput.
-// Global bucket because callers are already authenticated via TLS peer identity;
-// per-caller isolation is deferred until caller identity is propagated.
+// Enabled reports whether search events are written to ClickHouse.
+func (c SearchAnalyticsConfig) Enabled() bool {
+	return c.ClickHouseURL != ""
+}
+
+// RateLimitConfig bounds total incoming REST and Connect-RPC request
+// throughput. Per-caller buckets for /v1/search live in SearchAuthConfig.
 type RateLimitConfig struct {
 	// RequestsPerSecond is the sustained refill rate for the token bucket.
 	RequestsPerSecond float64
@@ -50,6 +84,22 @@ type RateLimitConfig struct {
 	Burst int
 }
 
+// SearchAuthConfig controls who may call GET /v1/search. Callers on the
+// mTLS listener are identified by their client certificate; callers on the
+// plaintext listener must present one of APIKeys. Every identified caller
+// gets its own token bucket on top of the global RateLimitConfig one.
+type SearchAuthConfig struct {
+	// APIKeys maps a caller name (used in logs and as the rate-limit key)
+	// to its API key.
+	APIKeys map[string]string
+	// AllowAnonymous lets requests without a key through under a shared
+	// "anonymous" identity. Load refuses it outside APP_ENV=development.
+	AllowAnonymous bool
+	// PerCallerRequestsPerSecond and PerCallerBurst size each caller's bucket.
+	PerCallerRequestsPerSecond float64
+	PerCallerBurst             int
+}
+
 // BackendAPIConfig holds configuration for connecting to alt-backend's internal API.
 type BackendAPIConfig struct {
 	// URL is the Connect-RPC URL for alt-backend's internal API.
@@ -83,6 +133,32 @@ func Load() (*Config, error) {
 			RequestsPerSecond: parseFloatEnv("SEARCH_RATE_LIMIT_RPS", 100),
 			Burst:             parseIntEnv("SEARCH_RATE_LIMIT_BURST", 200),
 		},
+		SearchAuth: SearchAuthConfig{
+			AllowAnonymous:             getEnvOrDefault("SEARCH_ALLOW_ANONYMOUS", "") == "true",
+			PerCallerRequestsPerSecond: parseFloatEnv("SEARCH_CALLER_RATE_LIMIT_RPS", 20),
+			PerCallerBurst:             parseIntEnv("SEARCH_CALLER_RATE_LIMIT_BURST", 40),
+		},
+		SearchAnalytics: SearchAnalyticsConfig{
+			ClickHouseURL: getEnvOrDefault("SEARCH_ANALYTICS_CLICKHOUSE_URL", ""),
+			Database:      getEnvOrDefault("SEARCH_ANALYTICS_CLICKHOUSE_DATABASE", "rask_logs"),
+			Table:         getEnvOrDefault("SEARCH_ANALYTICS_CLICKHOUSE_TABLE", "search_events"),
+			User:          getEnvOrDefault("SEARCH_ANALYTICS_CLICKHOUSE_USER", ""),
+			Password:      getEnvOrDefault("SEARCH_ANALYTICS_CLICKHOUSE_PASSWORD", ""),
+			BufferSize:    parseIntEnv("SEARCH_ANALYTICS_BUFFER_SIZE", 1000),
+			BatchSize:     parseIntEnv("SEARCH_ANALYTICS_BATCH_SIZE", 100),
+			FlushInterval: parseDurationEnv("SEARCH_ANALYTICS_FLUSH_INTERVAL", 5*time.Second),
+		},
+	}
+
+	apiKeys, err := parseAPIKeys(getEnvOrDefault("SEARCH_API_KEYS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("search auth configuration error: SEARCH_API_KEYS: %w", err)
+	}
+	cfg.SearchAuth.APIKeys = apiKeys
+
+	appEnv := getEnvOrDefault("APP_ENV", "production")
+	if cfg.SearchAuth.AllowAnonymous && appEnv != "development" {
+		return nil, fmt.Errorf("search auth configuration error: SEARCH_ALLOW_ANONYMOUS is only permitted with APP_ENV=development (got %q)", appEnv)
 	}
 
 	// Validate Meilisearch config (always required)
@@ -93,6 +169,9 @@ func Load() (*Config, error) {
 	slog.InfoContext(ctx, "configuration loaded",
 		"backend_api_url", backendAPIURL,
 		"meilisearch_host", cfg.Meilisearch.Host,
+		"search_api_keys", len(cfg.SearchAuth.APIKeys),
+		"search_allow_anonymous", cfg.SearchAuth.AllowAnonymous,
+		"search_analytics_enabled", cfg.SearchAnalytics.Enabled(),
 	)
 
 	return cfg, nil
@@ -112,3 +191,31 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// parseAPIKeys parses a comma-separated "name:key" list. Names and keys must
+// both be unique so a key always maps back to exactly one caller.
+func parseAPIKeys(spec string) (map[string]string, error) {
+	keys := make(map[string]string)
+	seen := make(map[string]struct{})
+	for i, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, key, ok := strings.Cut(entry, ":")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			// The entry itself is not echoed: it may be a bare key.
+			return nil, fmt.Errorf("entry %d is not in name:key form", i+1)
+		}
+		if _, dup := keys[name]; dup {
+			return nil, fmt.Errorf("caller %q is listed twice", name)
+		}
+		if _, dup := seen[key]; dup {
+			return nil, fmt.Errorf("caller %q reuses another caller's key", name)
+		}
+		keys[name] = key
+		seen[key] = struct{}{}
+	}
+	return keys, nil
+}
@@ -61,3 +61,91 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_SearchAuth(t *testing.T) {
+	base := func(t *testing.T) {
+		t.Setenv("BACKEND_API_URL", "http://alt-backend:9101")
+		t.Setenv("MEILISEARCH_HOST", "http://localhost:7700")
+	}
+
+	t.Run("parses api keys", func(t *testing.T) {
+		base(t)
+		t.Setenv("SEARCH_API_KEYS", "rag-orchestrator:k1, acolyte:k2")
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		want := map[string]string{"rag-orchestrator": "k1", "acolyte": "k2"}
+		if len(cfg.SearchAuth.APIKeys) != len(want) {
+			t.Fatalf("APIKeys = %v, want %v", cfg.SearchAuth.APIKeys, want)
+		}
+		for name, key := range want {
+			if cfg.SearchAuth.APIKeys[name] != key {
+				t.Errorf("APIKeys[%q] = %q, want %q", name, cfg.SearchAuth.APIKeys[name], key)
+			}
+		}
+		if cfg.SearchAuth.PerCallerRequestsPerSecond != 20 || cfg.SearchAuth.PerCallerBurst != 40 {
+			t.Errorf("per-caller limit = %v/%v, want 20/40",
+				cfg.SearchAuth.PerCallerRequestsPerSecond, cfg.SearchAuth.PerCallerBurst)
+		}
+	})
+
+	for name, spec := range map[string]string{
+		"missing key":    "rag-orchestrator",
+		"duplicate name": "a:k1,a:k2",
+		"duplicate key":  "a:k1,b:k1",
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			base(t)
+			t.Setenv("SEARCH_API_KEYS", spec)
+			if _, err := Load(); err == nil {
+				t.Fatal("Load() error = nil, want error")
+			}
+		})
+	}
+
+	t.Run("anonymous requires development", func(t *testing.T) {
+		base(t)
+		t.Setenv("SEARCH_ALLOW_ANONYMOUS", "true")
+		if _, err := Load(); err == nil {
+			t.Fatal("Load() error = nil, want error without APP_ENV")
+		}
+
+		t.Setenv("APP_ENV", "development")
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if !cfg.SearchAuth.AllowAnonymous {
+			t.Error("AllowAnonymous = false, want true")
+		}
+	})
+}
+
+func TestLoad_SearchAnalytics(t *testing.T) {
+	t.Setenv("BACKEND_API_URL", "http://alt-backend:9101")
+	t.Setenv("MEILISEARCH_HOST", "http://localhost:7700")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.SearchAnalytics.Enabled() {
+		t.Error("search analytics enabled without SEARCH_ANALYTICS_CLICKHOUSE_URL")
+	}
+
+	t.Setenv("SEARCH_ANALYTICS_CLICKHOUSE_URL", "http://clickhouse:8123")
+	t.Setenv("SEARCH_ANALYTICS_FLUSH_INTERVAL", "2s")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	a := cfg.SearchAnalytics
+	if !a.Enabled() || a.Database != "rask_logs" || a.Table != "search_events" {
+		t.Errorf("SearchAnalytics = %+v, want enabled with default table", a)
+	}
+	if a.BatchSize != 100 || a.BufferSize != 1000 || a.FlushInterval.Seconds() != 2 {
+		t.Errorf("writer sizing = %d/%d/%s", a.BatchSize, a.BufferSize, a.FlushInterval)
+	}
+}
@@ -18,6 +18,11 @@ var (
 	RecapWorkerURL      = stringEnv("RECAP_WORKER_URL", "")
 	RecapIndexInterval  = durationEnv("RECAP_INDEX_INTERVAL", 5*time.Minute)
 	RecapIndexBatchSize = intEnv("RECAP_INDEX_BATCH_SIZE", 200)
+	// IndexReconcileInterval is how often the incremental polling pass runs
+	// while the Redis Streams consumer is live. Events then carry indexing
+	// within seconds and the poll only catches what they missed; when the
+	// stream is unavailable the pass runs every IndexInterval again.
+	IndexReconcileInterval = durationEnv("INDEX_RECONCILE_INTERVAL", 30*time.Minute)
 	// MeiliHybridEmbedder names the embedder Meilisearch uses for hybrid search.
 	// Empty disables hybrid mode (BM25 only). When set, the driver attaches
 	// the embedder name + semantic ratio to every SearchRequest.
@@ -55,6 +60,19 @@ var (
 	// MeiliSearchCacheTTL's cadence so a query is either a cache hit or
 	// the embedder is already warm.
 	WarmupInterval = durationEnv("MEILI_WARMUP_INTERVAL", 5*time.Minute)
+	// WarmupQueries is a comma-separated list of popular queries run through
+	// the global search path at startup so their results are already in the
+	// LRU cache (and the embedder is resident) when traffic arrives. /ready
+	// reports 503 "warming_up" until they finish. Empty skips the query set;
+	// index stats are still prefetched.
+	WarmupQueries = stringEnv("MEILI_WARMUP_QUERIES", "")
+	// WarmupQueryLimit is the limit each warm-up query is run with. It must
+	// match the REST handler's default global-search limit (50) for the
+	// pre-cached entries to be hit by real queries.
+	WarmupQueryLimit = intEnv("MEILI_WARMUP_QUERY_LIMIT", 50)
+	// WarmupStartupTimeout bounds the whole startup warm-up so a stalled
+	// embedder delays readiness by at most this long.
+	WarmupStartupTimeout = durationEnv("MEILI_WARMUP_STARTUP_TIMEOUT", 60*time.Second)
 	// SynonymsFlushInterval controls how often the accumulated synonyms union
 	// is PUT to Meilisearch. See bootstrap.runSynonymsFlushLoop (PM-2026-047
 	// action item #2): Meilisearch's synonyms setting has no incremental/patch
@@ -64,8 +82,46 @@ var (
 	// payload; flushing on a fixed interval instead bounds task creation
 	// regardless of indexing throughput.
 	SynonymsFlushInterval = durationEnv("MEILI_SYNONYMS_FLUSH_INTERVAL", 1*time.Minute)
+	// HTTPRequestTimeout bounds each REST request on the :9300 listener. It
+	// stays below the server's 30s WriteTimeout so a slow search answers 503
+	// instead of having its connection reset mid-response.
+	HTTPRequestTimeout = durationEnv("HTTP_REQUEST_TIMEOUT", 25*time.Second)
+	// HTTPCORSAllowedOrigins is a comma-separated origin allowlist for the
+	// REST listener. Empty (the default) disables CORS: every caller today is
+	// a backend service, not a browser.
+	HTTPCORSAllowedOrigins = stringEnv("HTTP_CORS_ALLOWED_ORIGINS", "")
+	// ReadinessCheckTimeout bounds each dependency probe behind GET /ready.
+	ReadinessCheckTimeout = durationEnv("READINESS_CHECK_TIMEOUT", 2*time.Second)
+	// ShutdownDrainDelay is how long /ready reports "draining" after SIGTERM
+	// before the listeners stop accepting connections, giving load balancers
+	// time to stop routing new requests here.
+	ShutdownDrainDelay = durationEnv("SHUTDOWN_DRAIN_DELAY", 5*time.Second)
+	// IndexMaxDocumentBytes rejects (and logs) any article whose title,
+	// content and tags together exceed it; such articles are not indexed at
+	// all. Zero disables the check.
+	IndexMaxDocumentBytes = intEnv("INDEX_MAX_DOCUMENT_BYTES", 1<<20)
+	// IndexMaxContentBytes truncates the indexed content field. Search hits
+	// only ever return a cropped snippet, so the tail of a very long body
+	// mostly costs indexing time. Zero disables truncation.
+	IndexMaxContentBytes = intEnv("INDEX_MAX_CONTENT_BYTES", 32<<10)
+	// IndexCleanContent indexes a boilerplate-stripped body (markup, bare
+	// URLs and share/cookie/copyright lines removed) instead of the raw one.
+	IndexCleanContent = boolEnv("INDEX_CLEAN_CONTENT", false)
+	// IndexSearchableFields is the comma-separated subset of title, content
+	// and tags Meilisearch searches. Changing it makes Meilisearch rebuild
+	// the index on the next EnsureIndex.
+	IndexSearchableFields = stringEnv("INDEX_SEARCHABLE_FIELDS", "title,content,tags")
 )
 
+func boolEnv(key string, defaultVal bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return defaultVal
+}
+
 func floatEnv(key string, defaultVal float64) float64 {
 	if v := os.Getenv(key); v != "" {
 		if f, err := strconv.ParseFloat(v, 64); err == nil {
@@ -42,6 +42,9 @@ func (m *mockSearchEngine) SearchWithFilters(ctx context.Context, query string,
 func (m *mockSearchEngine) SearchWithDateFilter(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, limit int) ([]domain.SearchDocument, error) {
 	return m.docs, m.err
 }
+func (m *mockSearchEngine) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	return m.docs, int64(len(m.docs)), m.err
+}
 func (m *mockSearchEngine) EnsureIndex(ctx context.Context) error { return m.err }
 func (m *mockSearchEngine) SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]domain.SearchDocument, error) {
 	return m.docs, m.err
@@ -42,6 +42,9 @@ func (stubSearchEngine) SearchWithFilters(context.Context, string, []string, int
 func (stubSearchEngine) SearchWithDateFilter(context.Context, string, *time.Time, *time.Time, int) ([]domain.SearchDocument, error) {
 	return nil, nil
 }
+func (stubSearchEngine) SearchWithPagination(context.Context, string, *time.Time, *time.Time, int64, int64) ([]domain.SearchDocument, int64, error) {
+	return nil, 0, nil
+}
 func (stubSearchEngine) EnsureIndex(context.Context) error { return nil }
 func (stubSearchEngine) SearchByUserID(context.Context, string, string, int) ([]domain.SearchDocument, error) {
 	return nil, nil
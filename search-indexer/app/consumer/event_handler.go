@@ -55,6 +55,12 @@ type IndexArticlePayload struct {
 	FeedID    string `json:"feed_id"`
 }
 
+// ArticleDeletedPayload represents the payload for ArticleDeleted event.
+type ArticleDeletedPayload struct {
+	ArticleID string `json:"article_id"`
+	UserID    string `json:"user_id"`
+}
+
 // IndexEventHandler processes indexing events from the stream.
 // It buffers article IDs and flushes them in batches to reduce
 // per-event Meilisearch round-trips. For fat events with content,
@@ -144,6 +150,8 @@ func (h *IndexEventHandler) HandleEvent(ctx context.Context, event Event) error
 		return h.handleArticleCreated(ctx, event)
 	case "IndexArticle":
 		return h.handleIndexArticle(ctx, event)
+	case "ArticleDeleted":
+		return h.handleArticleDeleted(ctx, event)
 	default:
 		h.logger.Warn("unknown event type, skipping",
 			"event_type", event.EventType,
@@ -179,6 +187,7 @@ func (h *IndexEventHandler) handleArticleCreated(ctx context.Context, event Even
 			Content: payload.Content,
 			Tags:    payload.Tags,
 			UserID:  payload.UserID,
+			FeedID:  payload.FeedID,
 		}
 		if payload.PublishedAt != "" {
 			if publishedAt, err := time.Parse(time.RFC3339, payload.PublishedAt); err == nil {
@@ -223,6 +232,79 @@ func (h *IndexEventHandler) handleIndexArticle(ctx context.Context, event Event)
 	return nil
 }
 
+// handleArticleDeleted removes the article from the index right away.
+// Deletes are rare, so they are not batched; what matters is that an
+// upsert still sitting in a buffer cannot re-add the article after the
+// delete, so buffered events for the same article are dropped first and
+// ACKed together with the delete once it succeeds. On failure nothing is
+// ACKed and the reclaim loop redelivers the delete and the dropped events.
+func (h *IndexEventHandler) handleArticleDeleted(ctx context.Context, event Event) error {
+	var payload ArticleDeletedPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		h.logger.Error("failed to unmarshal ArticleDeleted payload",
+			"event_id", event.EventID,
+			"error", err,
+		)
+		return err
+	}
+
+	messageIDs := h.discardBuffered(payload.ArticleID)
+	if _, err := h.indexUsecase.DeleteArticles(ctx, []string{payload.ArticleID}); err != nil {
+		h.logger.Error("failed to delete article from index",
+			"article_id", payload.ArticleID,
+			"error", err,
+		)
+		return err
+	}
+
+	h.logger.Info("deleted article from index",
+		"article_id", payload.ArticleID,
+		"dropped_buffered", len(messageIDs),
+	)
+
+	if event.MessageID != "" {
+		messageIDs = append(messageIDs, event.MessageID)
+	}
+	h.ack(ctx, messageIDs)
+	return nil
+}
+
+// discardBuffered removes every buffered thin and fat event for articleID
+// and returns their message IDs.
+func (h *IndexEventHandler) discardBuffered(articleID string) []string {
+	var messageIDs []string
+
+	h.mu.Lock()
+	kept := h.buffer[:0]
+	for _, item := range h.buffer {
+		if item.articleID == articleID {
+			if item.messageID != "" {
+				messageIDs = append(messageIDs, item.messageID)
+			}
+			continue
+		}
+		kept = append(kept, item)
+	}
+	h.buffer = kept
+	h.mu.Unlock()
+
+	h.fatMu.Lock()
+	keptFat := h.fatBuffer[:0]
+	for _, item := range h.fatBuffer {
+		if item.doc.ID == articleID {
+			if item.messageID != "" {
+				messageIDs = append(messageIDs, item.messageID)
+			}
+			continue
+		}
+		keptFat = append(keptFat, item)
+	}
+	h.fatBuffer = keptFat
+	h.fatMu.Unlock()
+
+	return messageIDs
+}
+
 // enqueue adds an article ID to the buffer and triggers a flush if the
 // batch size threshold is reached. A timer is started on the first enqueue
 // to ensure timely flushing even when events arrive slowly.
@@ -50,6 +50,7 @@ func (m *mockArticleRepo) GetArticleByID(ctx context.Context, articleID string)
 // mockSearchEngine implements port.SearchEngine for testing.
 type mockSearchEngine struct {
 	indexedDocs []domain.SearchDocument
+	deletedIDs  []string
 	err         error
 }
 
@@ -61,7 +62,13 @@ func (m *mockSearchEngine) IndexDocuments(ctx context.Context, docs []domain.Sea
 	return nil
 }
 
-func (m *mockSearchEngine) DeleteDocuments(ctx context.Context, ids []string) error { return m.err }
+func (m *mockSearchEngine) DeleteDocuments(ctx context.Context, ids []string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.deletedIDs = append(m.deletedIDs, ids...)
+	return nil
+}
 func (m *mockSearchEngine) Search(ctx context.Context, query string, limit int) ([]domain.SearchDocument, error) {
 	return nil, m.err
 }
@@ -71,6 +78,9 @@ func (m *mockSearchEngine) SearchWithFilters(ctx context.Context, query string,
 func (m *mockSearchEngine) SearchWithDateFilter(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, limit int) ([]domain.SearchDocument, error) {
 	return nil, m.err
 }
+func (m *mockSearchEngine) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	return nil, 0, m.err
+}
 func (m *mockSearchEngine) EnsureIndex(ctx context.Context) error { return m.err }
 func (m *mockSearchEngine) SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]domain.SearchDocument, error) {
 	return nil, m.err
@@ -424,3 +434,66 @@ func TestIndexEventHandler_HandleEvent_UnknownType_AcksImmediately(t *testing.T)
 		t.Fatalf("acked IDs = %v, want [\"3-0\"] immediately for an unknown event type", got)
 	}
 }
+
+// TestIndexEventHandler_HandleEvent_ArticleDeleted_DropsBufferedUpsert
+// verifies a delete is applied at once and that a still-buffered upsert of
+// the same article is dropped rather than re-adding it on the next flush.
+// Both messages are ACKed with the delete.
+func TestIndexEventHandler_HandleEvent_ArticleDeleted_DropsBufferedUpsert(t *testing.T) {
+	se := &mockSearchEngine{}
+	uc := usecase.NewIndexArticlesUsecase(&mockArticleRepo{articles: map[string]*domain.Article{}}, se, (*tokenizer.Tokenizer)(nil))
+	handler := NewIndexEventHandler(uc, slog.Default())
+	defer handler.Stop()
+
+	acker := &fakeAcker{}
+	handler.SetAcker(acker)
+
+	created, _ := json.Marshal(ArticleCreatedPayload{ArticleID: "art-del-1", Title: "T", Content: "C"})
+	if err := handler.HandleEvent(context.Background(), Event{
+		EventType: "ArticleCreated", EventID: "evt-c", MessageID: "4-0", Payload: created,
+	}); err != nil {
+		t.Fatalf("HandleEvent(ArticleCreated) error = %v", err)
+	}
+
+	deleted, _ := json.Marshal(ArticleDeletedPayload{ArticleID: "art-del-1"})
+	if err := handler.HandleEvent(context.Background(), Event{
+		EventType: "ArticleDeleted", EventID: "evt-d", MessageID: "5-0", Payload: deleted,
+	}); err != nil {
+		t.Fatalf("HandleEvent(ArticleDeleted) error = %v", err)
+	}
+
+	if len(se.deletedIDs) != 1 || se.deletedIDs[0] != "art-del-1" {
+		t.Fatalf("deleted IDs = %v, want [art-del-1]", se.deletedIDs)
+	}
+	if got := acker.ackedIDs(); len(got) != 2 || got[0] != "4-0" || got[1] != "5-0" {
+		t.Fatalf("acked IDs = %v, want [4-0 5-0]", got)
+	}
+
+	handler.Stop()
+	if len(se.indexedDocs) != 0 {
+		t.Fatalf("buffered upsert re-added the deleted article: %+v", se.indexedDocs)
+	}
+}
+
+// TestIndexEventHandler_HandleEvent_ArticleDeleted_DoesNotAckOnFailure
+// leaves a failed delete in the PEL for the reclaim loop to retry.
+func TestIndexEventHandler_HandleEvent_ArticleDeleted_DoesNotAckOnFailure(t *testing.T) {
+	se := &mockSearchEngine{err: errors.New("meilisearch unavailable")}
+	uc := usecase.NewIndexArticlesUsecase(&mockArticleRepo{articles: map[string]*domain.Article{}}, se, (*tokenizer.Tokenizer)(nil))
+	handler := NewIndexEventHandler(uc, slog.Default())
+	defer handler.Stop()
+
+	acker := &fakeAcker{}
+	handler.SetAcker(acker)
+
+	deleted, _ := json.Marshal(ArticleDeletedPayload{ArticleID: "art-del-2"})
+	err := handler.HandleEvent(context.Background(), Event{
+		EventType: "ArticleDeleted", EventID: "evt-d2", MessageID: "6-0", Payload: deleted,
+	})
+	if err == nil {
+		t.Fatal("HandleEvent() error = nil, want the delete failure")
+	}
+	if got := acker.ackedIDs(); len(got) != 0 {
+		t.Fatalf("message ACKed despite delete failure: %v", got)
+	}
+}
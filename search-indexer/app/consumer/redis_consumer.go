@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -54,6 +55,10 @@ type AckSetter interface {
 	SetAcker(Acknowledger)
 }
 
+// errStreamUnreachable marks a Start-time check that failed because Redis
+// did not answer, as opposed to answering that the group is missing.
+var errStreamUnreachable = errors.New("stream unreachable")
+
 // Consumer consumes events from Redis Streams.
 type Consumer struct {
 	client       *redis.Client
@@ -63,6 +68,8 @@ type Consumer struct {
 	shutdownChan chan struct{}
 	shutdownOnce sync.Once
 	wg           sync.WaitGroup
+	// available is whether the last XREADGROUP succeeded; see Available.
+	available atomic.Bool
 }
 
 // NewConsumer creates a new Redis Streams consumer.
@@ -116,8 +123,16 @@ func (c *Consumer) Start(ctx context.Context) error {
 	// Consumer groups are provisioned by infrastructure / setup scripts
 	// (DECREE §8), not created ad hoc here. Verify the group exists so a
 	// missing-setup failure is loud at Start rather than silent at first read.
+	// Redis being down is not a setup failure: start anyway and let the
+	// consume loop retry, with Available reporting false until it connects.
 	if err := c.verifyConsumerGroup(ctx); err != nil {
-		return err
+		if !errors.Is(err, errStreamUnreachable) {
+			return err
+		}
+		c.logger.Warn("stream unreachable at start, consumer will keep retrying",
+			"stream", c.config.StreamKey,
+			"error", err,
+		)
 	}
 
 	c.logger.Info("starting consumer",
@@ -178,6 +193,26 @@ func (c *Consumer) Stop() {
 	c.Close()
 }
 
+// Available reports whether the consumer is currently reading the stream,
+// i.e. its last XREADGROUP succeeded. The polling index loop uses this to
+// decide whether it is only reconciling or has to carry indexing itself.
+func (c *Consumer) Available() bool {
+	return c.available.Load()
+}
+
+// setAvailable records the outcome of a read and logs transitions, so a
+// switch to or from polling fallback shows up once rather than per read.
+func (c *Consumer) setAvailable(ok bool, err error) {
+	if c.available.Swap(ok) == ok {
+		return
+	}
+	if ok {
+		c.logger.Info("stream available, event-driven indexing active", "stream", c.config.StreamKey)
+		return
+	}
+	c.logger.Warn("stream unavailable, falling back to polling", "stream", c.config.StreamKey, "error", err)
+}
+
 // IsEnabled returns true if the consumer is enabled.
 func (c *Consumer) IsEnabled() bool {
 	return c.config.Enabled
@@ -202,9 +237,13 @@ func ProvisionConsumerGroup(ctx context.Context, client *redis.Client, streamKey
 // / search-indexer/scripts/provision-consumer-group.sh before Start.
 func (c *Consumer) verifyConsumerGroup(ctx context.Context) error {
 	groups, err := c.client.XInfoGroups(ctx, c.config.StreamKey).Result()
+	var redisErr redis.Error
+	if err != nil && !errors.As(err, &redisErr) {
+		return fmt.Errorf("%w: %w", errStreamUnreachable, err)
+	}
 	if err != nil {
 		return fmt.Errorf(
-			"consumer group %q not found on stream %q (stream missing or unreachable); provision via search-indexer provision-consumer-group or mq-hub CreateConsumerGroup: %w",
+			"consumer group %q not found on stream %q (stream missing); provision via search-indexer provision-consumer-group or mq-hub CreateConsumerGroup: %w",
 			c.config.GroupName, c.config.StreamKey, err,
 		)
 	}
@@ -260,11 +299,17 @@ func (c *Consumer) readAndProcess(ctx context.Context) error {
 
 	if errors.Is(err, redis.Nil) {
 		// No messages available
+		c.setAvailable(true, nil)
 		return nil
 	}
 	if err != nil {
+		// A read cut short by shutdown says nothing about the stream.
+		if ctx.Err() == nil {
+			c.setAvailable(false, err)
+		}
 		return err
 	}
+	c.setAvailable(true, nil)
 
 	for _, stream := range streams {
 		c.processMessages(ctx, stream.Messages)
@@ -0,0 +1,101 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func availabilityTestConfig(addr string) Config {
+	return Config{
+		RedisURL:       fmt.Sprintf("redis://%s", addr),
+		GroupName:      reclaimTestGroup,
+		ConsumerName:   "consumer-a",
+		StreamKey:      reclaimTestStream,
+		BatchSize:      10,
+		BlockTimeout:   20 * time.Millisecond,
+		ReaperInterval: time.Hour,
+		Enabled:        true,
+	}
+}
+
+func waitAvailable(t *testing.T, c *Consumer, want bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Available() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Available() = %v, want %v", !want, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestConsumer_Available_TracksStream covers the switch between
+// event-driven indexing and polling fallback: Available turns true once
+// reads succeed, false while Redis is down, and true again on recovery.
+func TestConsumer_Available_TracksStream(t *testing.T) {
+	srv := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	if err := rdb.XGroupCreateMkStream(context.Background(), reclaimTestStream, reclaimTestGroup, "0").Err(); err != nil {
+		t.Fatalf("seed XGroupCreateMkStream: %v", err)
+	}
+
+	c, err := NewConsumer(availabilityTestConfig(srv.Addr()), &recordingHandler{}, newQuietLogger())
+	if err != nil {
+		t.Fatalf("NewConsumer: %v", err)
+	}
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer c.Stop()
+
+	waitAvailable(t, c, true)
+
+	srv.SetError("LOADING Redis is loading the dataset in memory")
+	waitAvailable(t, c, false)
+
+	srv.SetError("")
+	waitAvailable(t, c, true)
+}
+
+// TestConsumer_Start_ToleratesUnreachableRedis starts in polling fallback
+// instead of failing when Redis does not answer.
+func TestConsumer_Start_ToleratesUnreachableRedis(t *testing.T) {
+	srv := miniredis.RunT(t)
+	addr := srv.Addr()
+	srv.Close()
+
+	c, err := NewConsumer(availabilityTestConfig(addr), &recordingHandler{}, newQuietLogger())
+	if err != nil {
+		t.Fatalf("NewConsumer: %v", err)
+	}
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v, want nil while Redis is unreachable", err)
+	}
+	defer c.Stop()
+
+	if c.Available() {
+		t.Fatal("Available() = true with Redis unreachable")
+	}
+}
+
+// TestConsumer_Start_FailsOnMissingGroup keeps an unprovisioned group a
+// startup error: Redis answered, so this is a setup problem.
+func TestConsumer_Start_FailsOnMissingGroup(t *testing.T) {
+	srv := miniredis.RunT(t)
+
+	c, err := NewConsumer(availabilityTestConfig(srv.Addr()), &recordingHandler{}, newQuietLogger())
+	if err != nil {
+		t.Fatalf("NewConsumer: %v", err)
+	}
+	defer c.Stop()
+
+	if err := c.Start(context.Background()); err == nil {
+		t.Fatal("Start() error = nil, want missing consumer group error")
+	}
+}
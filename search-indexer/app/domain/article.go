@@ -14,6 +14,9 @@ type Article struct {
 	userID      string
 	language    string
 	publishedAt time.Time
+	feedID      string
+	feedTitle   string
+	author      string
 }
 
 func NewArticle(id, title, content string, tags []string, createdAt time.Time, userID string) (*Article, error) {
@@ -93,6 +96,40 @@ func (a *Article) SetPublishedAt(t time.Time) {
 	a.publishedAt = t
 }
 
+// FeedID returns the ID of the feed the article was fetched from, or ""
+// when the upstream did not report one.
+func (a *Article) FeedID() string {
+	return a.feedID
+}
+
+// SetFeedID records the source feed so search documents can carry
+// feed-level ranking signals.
+func (a *Article) SetFeedID(feedID string) {
+	a.feedID = feedID
+}
+
+// FeedTitle returns the title of the source feed, or "" when unknown.
+func (a *Article) FeedTitle() string {
+	return a.feedTitle
+}
+
+// SetFeedTitle records the source feed's title so search can filter and
+// facet on it.
+func (a *Article) SetFeedTitle(feedTitle string) {
+	a.feedTitle = feedTitle
+}
+
+// Author returns the article's author, or "" when the source reported none.
+func (a *Article) Author() string {
+	return a.author
+}
+
+// SetAuthor records the article's author so search can filter and facet
+// on it.
+func (a *Article) SetAuthor(author string) {
+	a.author = author
+}
+
 func (a *Article) HasTag(tag string) bool {
 	if tag == "" {
 		return false
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxSearchOffset is the deepest result position /v1/search pages reach. It
+// matches Meilisearch's default pagination.maxTotalHits: past it the engine
+// returns no hits at all, so deeper pages would silently come back empty.
+// Callers that need every match use the export endpoint instead.
+const MaxSearchOffset = 1000
+
+// searchCursorVersion prefixes every encoded cursor so the format can change
+// without misreading cursors handed out by an older deploy.
+const searchCursorVersion = "v1"
+
+var (
+	// ErrInvalidSearchCursor is returned for a cursor that does not decode
+	// or was issued for a different query.
+	ErrInvalidSearchCursor = errors.New("invalid search cursor")
+	// ErrSearchPageTooDeep is returned when a page would start at or past
+	// MaxSearchOffset.
+	ErrSearchPageTooDeep = fmt.Errorf("search page too deep: results past position %d are not served; narrow the query or date window", MaxSearchOffset)
+)
+
+// SearchCursor is the decoded form of the opaque next_cursor /v1/search
+// hands out: the offset of the next page and a hash of the query it belongs
+// to.
+type SearchCursor struct {
+	Offset    int64
+	QueryHash string
+}
+
+// SearchQueryHash identifies a search for cursor binding. It covers every
+// parameter that changes which documents match, so a cursor cannot be
+// replayed against a different query; limit and boost lists are left out
+// because they only change page size and in-page order.
+func SearchQueryHash(query, userID, publishedAfter, publishedBefore string) string {
+	h := sha256.New()
+	for _, part := range []string{query, userID, publishedAfter, publishedBefore} {
+		// Length-prefix each part so ("ab", "c") and ("a", "bc") differ.
+		h.Write([]byte(strconv.Itoa(len(part)) + ":" + part))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Encode returns the opaque, URL-safe form of c.
+func (c SearchCursor) Encode() string {
+	raw := searchCursorVersion + "." + strconv.FormatInt(c.Offset, 10) + "." + c.QueryHash
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeSearchCursor parses a cursor produced by SearchCursor.Encode and
+// checks that it was issued for queryHash and points inside the served
+// result window.
+func DecodeSearchCursor(encoded, queryHash string) (SearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return SearchCursor{}, ErrInvalidSearchCursor
+	}
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 3 || parts[0] != searchCursorVersion {
+		return SearchCursor{}, ErrInvalidSearchCursor
+	}
+	offset, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || offset < 0 {
+		return SearchCursor{}, ErrInvalidSearchCursor
+	}
+	if parts[2] != queryHash {
+		return SearchCursor{}, fmt.Errorf("%w: cursor belongs to a different query", ErrInvalidSearchCursor)
+	}
+	if offset >= MaxSearchOffset {
+		return SearchCursor{}, ErrSearchPageTooDeep
+	}
+	return SearchCursor{Offset: offset, QueryHash: queryHash}, nil
+}
+
+// NextSearchOffset returns the offset of the page after one that started at
+// offset and returned returned hits out of an estimated total, and false when
+// that page was the last one served.
+func NextSearchOffset(offset int64, returned int, estimatedTotal int64) (int64, bool) {
+	next := offset + int64(returned)
+	if returned == 0 || next >= estimatedTotal || next >= MaxSearchOffset {
+		return 0, false
+	}
+	return next, true
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestSearchCursor_RoundTrip(t *testing.T) {
+	hash := SearchQueryHash("iran oil", "", "2026-04-12T00:00:00Z", "")
+	encoded := SearchCursor{Offset: 40, QueryHash: hash}.Encode()
+
+	got, err := DecodeSearchCursor(encoded, hash)
+	if err != nil {
+		t.Fatalf("DecodeSearchCursor: %v", err)
+	}
+	if got.Offset != 40 {
+		t.Errorf("Offset = %d, want 40", got.Offset)
+	}
+}
+
+func TestDecodeSearchCursor_Rejects(t *testing.T) {
+	hash := SearchQueryHash("iran", "", "", "")
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name    string
+		cursor  string
+		wantErr error
+	}{
+		{"not base64", "%%%", ErrInvalidSearchCursor},
+		{"wrong version", encode("v0.20." + hash), ErrInvalidSearchCursor},
+		{"negative offset", encode("v1.-20." + hash), ErrInvalidSearchCursor},
+		{"other query", SearchCursor{Offset: 20, QueryHash: SearchQueryHash("oil", "", "", "")}.Encode(), ErrInvalidSearchCursor},
+		{"other user", SearchCursor{Offset: 20, QueryHash: SearchQueryHash("iran", "u1", "", "")}.Encode(), ErrInvalidSearchCursor},
+		{"too deep", SearchCursor{Offset: MaxSearchOffset, QueryHash: hash}.Encode(), ErrSearchPageTooDeep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeSearchCursor(tt.cursor, hash); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchQueryHash_SeparatesParts(t *testing.T) {
+	if SearchQueryHash("ab", "c", "", "") == SearchQueryHash("a", "bc", "", "") {
+		t.Error("hash must not depend only on the concatenated parts")
+	}
+}
+
+func TestNextSearchOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   int64
+		returned int
+		total    int64
+		want     int64
+		wantOK   bool
+	}{
+		{"more results", 0, 20, 100, 20, true},
+		{"last page", 80, 20, 100, 0, false},
+		{"empty page", 20, 0, 100, 0, false},
+		{"reaches the cap", MaxSearchOffset - 20, 20, 5000, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NextSearchOffset(tt.offset, tt.returned, tt.total)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NextSearchOffset = (%d, %v), want (%d, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -2,15 +2,24 @@ package domain
 
 import "time"
 
+// SearchDocument is the indexed representation of an article. FeedID and
+// FeedPopularity back the personalization boost: FeedID lets callers boost
+// their subscribed feeds, and FeedPopularity is a custom ranking attribute
+// stamped at index time (see port.FeedPopularity). FeedTitle and Author are
+// feed and source metadata joined in at index time for filtering and facets.
 type SearchDocument struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Content     string    `json:"content"`
-	Tags        []string  `json:"tags"`
-	UserID      string    `json:"user_id"`
-	Language    string    `json:"language"`
-	Score       float64   `json:"score"`
-	PublishedAt time.Time `json:"published_at"`
+	ID             string    `json:"id"`
+	Title          string    `json:"title"`
+	Content        string    `json:"content"`
+	Tags           []string  `json:"tags"`
+	UserID         string    `json:"user_id"`
+	FeedID         string    `json:"feed_id"`
+	FeedTitle      string    `json:"feed_title"`
+	Author         string    `json:"author"`
+	FeedPopularity int64     `json:"feed_popularity"`
+	Language       string    `json:"language"`
+	Score          float64   `json:"score"`
+	PublishedAt    time.Time `json:"published_at"`
 }
 
 func NewSearchDocument(article *Article) SearchDocument {
@@ -20,6 +29,9 @@ func NewSearchDocument(article *Article) SearchDocument {
 		Content:     article.Content(),
 		Tags:        article.Tags(),
 		UserID:      article.UserID(),
+		FeedID:      article.FeedID(),
+		FeedTitle:   article.FeedTitle(),
+		Author:      article.Author(),
 		Language:    article.Language(),
 		PublishedAt: article.PublishedAt(),
 	}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// SearchEventKind names the search entry point that produced a SearchEvent.
+type SearchEventKind string
+
+const (
+	SearchEventArticles       SearchEventKind = "articles"
+	SearchEventArticlesByDate SearchEventKind = "articles_date_filter"
+	SearchEventArticlesPage   SearchEventKind = "articles_page"
+	SearchEventByUser         SearchEventKind = "by_user"
+	SearchEventByUserPage     SearchEventKind = "by_user_page"
+)
+
+// SearchEvent is one executed search, recorded for analytics. Query is the
+// sanitized query that reached the engine. Failed searches are recorded
+// too, with Error set and ResultCount zero; searches rejected by input
+// validation are not.
+type SearchEvent struct {
+	OccurredAt         time.Time
+	Kind               SearchEventKind
+	Query              string
+	UserID             string
+	PublishedAfter     *time.Time
+	PublishedBefore    *time.Time
+	Offset             int64
+	Limit              int
+	ResultCount        int
+	EstimatedTotalHits int64
+	Latency            time.Duration
+	Error              string
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxPersonalizationFeedIDs bounds the subscribed-feed list a caller may
+	// pass; it covers the largest subscription lists seen in alt-db.
+	MaxPersonalizationFeedIDs = 500
+	// MaxPersonalizationTags bounds the recently-read topic list.
+	MaxPersonalizationTags = 50
+)
+
+// SearchPersonalization is optional per-request user context used to boost
+// results: FeedIDs are the feeds the user subscribes to, Tags are topics from
+// the user's recent reading. The zero value means "no personalization".
+type SearchPersonalization struct {
+	FeedIDs []string
+	Tags    []string
+}
+
+// IsEmpty reports whether the personalization carries no signal.
+func (p SearchPersonalization) IsEmpty() bool {
+	return len(p.FeedIDs) == 0 && len(p.Tags) == 0
+}
+
+// Validate bounds the list sizes so a caller cannot turn one search into an
+// unbounded amount of reranking work.
+func (p SearchPersonalization) Validate() error {
+	if len(p.FeedIDs) > MaxPersonalizationFeedIDs {
+		return fmt.Errorf("too many boost feed IDs: maximum %d allowed, got %d", MaxPersonalizationFeedIDs, len(p.FeedIDs))
+	}
+	if len(p.Tags) > MaxPersonalizationTags {
+		return fmt.Errorf("too many boost tags: maximum %d allowed, got %d", MaxPersonalizationTags, len(p.Tags))
+	}
+	for _, tag := range p.Tags {
+		if len(tag) > 100 {
+			return fmt.Errorf("boost tag too long: maximum 100 characters, got %d", len(tag))
+		}
+	}
+	return nil
+}
+
+// ParsePersonalizationList splits a comma-separated query parameter,
+// trimming blanks and dropping empty and duplicate entries.
+func ParsePersonalizationList(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if _, ok := seen[part]; ok {
+			continue
+		}
+		seen[part] = struct{}{}
+		out = append(out, part)
+	}
+	return out
+}
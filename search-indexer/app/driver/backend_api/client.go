@@ -222,6 +222,9 @@ func toDriverArticle(p *backendv1.ArticleWithTags) *driver.ArticleWithTags {
 		Tags:        tags,
 		CreatedAt:   p.CreatedAt.AsTime(),
 		UserID:      p.UserId,
+		FeedID:      p.FeedId,
+		FeedTitle:   p.FeedTitle,
+		Author:      p.Author,
 		Language:    p.Language,
 		PublishedAt: p.CreatedAt.AsTime(),
 	}
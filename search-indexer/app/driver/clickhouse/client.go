@@ -0,0 +1,118 @@
+// Package clickhouse writes search analytics rows to ClickHouse over its
+// HTTP interface (port 8123), the same interface rask-log-aggregator and
+// the Grafana datasource use.
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"search-indexer/driver"
+)
+
+// maxErrorBodyBytes bounds how much of a ClickHouse error response is kept
+// in the returned error.
+const maxErrorBodyBytes = 1024
+
+// Config locates the ClickHouse table search events are written to.
+type Config struct {
+	// URL is the HTTP interface base URL, e.g. http://clickhouse:8123.
+	URL      string
+	Database string
+	Table    string
+	User     string
+	Password string
+}
+
+// Client inserts rows with INSERT ... FORMAT JSONEachRow.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// DefaultHTTPClient constructs a dedicated *http.Client for ClickHouse
+// inserts. Batches are small, so a slow server is cut off quickly rather
+// than holding the writer goroutine.
+func DefaultHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   3 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ResponseHeaderTimeout: 10 * time.Second,
+			IdleConnTimeout:       90 * time.Second,
+			MaxIdleConns:          4,
+			MaxIdleConnsPerHost:   2,
+		},
+	}
+}
+
+// NewClient creates a ClickHouse client. When httpClient is nil
+// DefaultHTTPClient is used.
+func NewClient(cfg Config, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = DefaultHTTPClient()
+	}
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	return &Client{cfg: cfg, httpClient: httpClient}
+}
+
+// InsertSearchEvents writes rows in one INSERT. ClickHouse applies an
+// INSERT atomically, so a failed call wrote nothing and can be retried.
+func (c *Client) InsertSearchEvents(ctx context.Context, rows []driver.SearchEventRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("encode search event row: %w", err)
+		}
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s.%s FORMAT JSONEachRow", quoteIdentifier(c.cfg.Database), quoteIdentifier(c.cfg.Table))
+	reqURL := c.cfg.URL + "/?" + url.Values{"query": {query}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, &body)
+	if err != nil {
+		return fmt.Errorf("create clickhouse insert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if c.cfg.User != "" {
+		req.Header.Set("X-ClickHouse-User", c.cfg.User)
+		req.Header.Set("X-ClickHouse-Key", c.cfg.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("clickhouse insert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("clickhouse insert failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	// Drain so the connection is reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// quoteIdentifier backquotes a database or table name for the INSERT,
+// backslash-escaping as ClickHouse quoted identifiers require.
+func quoteIdentifier(name string) string {
+	name = strings.ReplaceAll(name, `\`, `\\`)
+	return "`" + strings.ReplaceAll(name, "`", "\\`") + "`"
+}
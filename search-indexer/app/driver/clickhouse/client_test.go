@@ -0,0 +1,88 @@
+package clickhouse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"search-indexer/driver"
+)
+
+func TestClient_InsertSearchEvents(t *testing.T) {
+	t.Parallel()
+
+	var gotQuery, gotUser, gotKey string
+	var gotRows []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("query")
+		gotUser = r.Header.Get("X-ClickHouse-User")
+		gotKey = r.Header.Get("X-ClickHouse-Key")
+		sc := bufio.NewScanner(r.Body)
+		for sc.Scan() {
+			var row map[string]any
+			if err := json.Unmarshal(sc.Bytes(), &row); err != nil {
+				t.Errorf("row is not JSON: %v", err)
+			}
+			gotRows = append(gotRows, row)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(Config{URL: srv.URL + "/", Database: "rask_logs", Table: "search_events", User: "writer", Password: "secret"}, nil)
+	err := c.InsertSearchEvents(context.Background(), []driver.SearchEventRow{
+		{Timestamp: "2026-10-16 00:30:15.123", Kind: "articles", Query: "go", ResultCount: 3},
+		{Timestamp: "2026-10-16 00:30:16.000", Kind: "by_user", Query: "rust", UserID: "u1"},
+	})
+	if err != nil {
+		t.Fatalf("InsertSearchEvents: %v", err)
+	}
+
+	if gotQuery != "INSERT INTO `rask_logs`.`search_events` FORMAT JSONEachRow" {
+		t.Errorf("query = %q", gotQuery)
+	}
+	if gotUser != "writer" || gotKey != "secret" {
+		t.Errorf("credentials not sent: user=%q key=%q", gotUser, gotKey)
+	}
+	if len(gotRows) != 2 || gotRows[0]["query"] != "go" || gotRows[1]["user_id"] != "u1" {
+		t.Errorf("unexpected rows %v", gotRows)
+	}
+	if _, ok := gotRows[0]["published_after"]; !ok {
+		t.Error("published_after must be sent as null, not omitted")
+	}
+}
+
+func TestClient_InsertSearchEvents_ServerError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Code: 60. DB::Exception: Table rask_logs.search_events does not exist.", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient(Config{URL: srv.URL, Database: "rask_logs", Table: "search_events"}, nil)
+	err := c.InsertSearchEvents(context.Background(), []driver.SearchEventRow{{Kind: "articles"}})
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected ClickHouse error message in %v", err)
+	}
+}
+
+func TestClient_InsertSearchEvents_EmptyBatch(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(Config{URL: "http://127.0.0.1:1"}, nil)
+	if err := c.InsertSearchEvents(context.Background(), nil); err != nil {
+		t.Fatalf("empty batch should not touch the server: %v", err)
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	t.Parallel()
+
+	if got := quoteIdentifier("a`b"); got != "`a\\`b`" {
+		t.Errorf("quoteIdentifier = %q", got)
+	}
+}
@@ -31,3 +31,16 @@ func makeSecureSearchFilter(tags []string) string {
 func BuildUserFilter(userID string) string {
 	return fmt.Sprintf("user_id = \"%s\"", escapeMeilisearchValue(userID))
 }
+
+// BuildFeedFilter creates a secure Meilisearch filter matching any of the
+// given feed IDs.
+func BuildFeedFilter(feedIDs []string) string {
+	if len(feedIDs) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(feedIDs))
+	for i, id := range feedIDs {
+		quoted[i] = fmt.Sprintf("\"%s\"", escapeMeilisearchValue(id))
+	}
+	return "feed_id IN [" + strings.Join(quoted, ", ") + "]"
+}
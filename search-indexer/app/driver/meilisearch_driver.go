@@ -49,6 +49,10 @@ type MeilisearchDriver struct {
 	cache       *searchCache
 	sf          singleflight.Group
 
+	// searchableAttrs is what EnsureIndex sets as searchable attributes;
+	// nil means defaultSearchableAttributes.
+	searchableAttrs []string
+
 	// taskWaitTimeout/taskPollInterval back waitForTask. Exposed as fields
 	// (rather than the package constants directly) so tests can shrink the
 	// timeout instead of waiting out the full 15s default.
@@ -96,6 +100,46 @@ func (d *MeilisearchDriver) waitForTask(ctx context.Context, taskUID int64) (*me
 	return d.index.WaitForTaskWithContext(waitCtx, taskUID, d.taskPollInterval)
 }
 
+// defaultSearchableAttributes are the document fields Meilisearch searches
+// unless WithSearchableAttributes narrows them. Order is attribute rank.
+var defaultSearchableAttributes = []string{"title", "content", "tags"}
+
+// WithSearchableAttributes restricts which fields EnsureIndex makes
+// searchable. Unknown names are logged and dropped; an empty result keeps
+// the default set.
+func (d *MeilisearchDriver) WithSearchableAttributes(attrs []string) *MeilisearchDriver {
+	known := make(map[string]bool, len(defaultSearchableAttributes))
+	for _, a := range defaultSearchableAttributes {
+		known[a] = true
+	}
+	selected := make([]string, 0, len(attrs))
+	for _, a := range attrs {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+		if !known[a] {
+			logger.Logger.Warn("ignoring unknown searchable attribute", "attribute", a, "allowed", defaultSearchableAttributes)
+			continue
+		}
+		selected = append(selected, a)
+	}
+	if len(selected) == 0 {
+		d.searchableAttrs = nil
+		return d
+	}
+	d.searchableAttrs = selected
+	return d
+}
+
+// SearchableAttributes returns the attributes EnsureIndex makes searchable.
+func (d *MeilisearchDriver) SearchableAttributes() []string {
+	if d.searchableAttrs == nil {
+		return append([]string(nil), defaultSearchableAttributes...)
+	}
+	return append([]string(nil), d.searchableAttrs...)
+}
+
 // WithHybrid installs a hybrid-search configuration on the driver. Pass nil
 // or a HybridConfig with an empty Embedder to disable hybrid mode.
 func (d *MeilisearchDriver) WithHybrid(cfg *HybridConfig) *MeilisearchDriver {
@@ -148,7 +192,7 @@ func (d *MeilisearchDriver) newBaseSearchRequest(query string, limit int) *meili
 		Limit:                int64(limit),
 		ShowRankingScore:     true,
 		Hybrid:               d.hybrid.toSDK(),
-		AttributesToRetrieve: []string{"id", "title", "tags", "user_id", "language", "published_at"},
+		AttributesToRetrieve: []string{"id", "title", "tags", "user_id", "feed_id", "feed_title", "author", "feed_popularity", "language", "published_at"},
 		AttributesToCrop:     []string{"content"},
 		CropLength:           120,
 	}
@@ -300,14 +344,7 @@ func (d *MeilisearchDriver) SearchWithDateFilter(ctx context.Context, query stri
 		return d.Search(ctx, query, limit)
 	}
 
-	filterClauses := make([]string, 0, 2)
-	if publishedAfter != nil {
-		filterClauses = append(filterClauses, "published_at >= "+strconv.FormatInt(publishedAfter.Unix(), 10))
-	}
-	if publishedBefore != nil {
-		filterClauses = append(filterClauses, "published_at <= "+strconv.FormatInt(publishedBefore.Unix(), 10))
-	}
-	filter := strings.Join(filterClauses, " AND ")
+	filter := publishedAtFilter(publishedAfter, publishedBefore)
 
 	emb, ratio := d.hybridSnapshot()
 	key := cacheKey{
@@ -341,6 +378,65 @@ func (d *MeilisearchDriver) SearchWithDateFilter(ctx context.Context, query stri
 	return entry.Docs, nil
 }
 
+// SearchWithPagination is Search with an offset, an optional published_at
+// window and the engine's estimated total. Unlike the user-scoped variant it
+// keeps the caller's limit (the usecase bounds it), since export pages are
+// larger than interactive ones.
+func (d *MeilisearchDriver) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]SearchDocumentDriver, int64, error) {
+	filter := publishedAtFilter(publishedAfter, publishedBefore)
+
+	emb, ratio := d.hybridSnapshot()
+	key := cacheKey{
+		Query:         normalizeCacheKeyQuery(query),
+		Filter:        filter,
+		Offset:        offset,
+		Limit:         limit,
+		Embedder:      emb,
+		SemanticRatio: ratio,
+	}
+	if e, ok := d.cache.get(key); ok {
+		appotel.RecordMeilisearchProcessing(ctx, "SearchWithPagination.cacheHit", e.ProcessingMs)
+		return e.Docs, e.EstimatedTotal, nil
+	}
+
+	entry, err := d.singleflightSearch(ctx, key.String(), func() (cacheEntry, error) {
+		searchRequest := d.newBaseSearchRequest(query, int(limit))
+		searchRequest.Offset = offset
+		if filter != "" {
+			searchRequest.Filter = filter
+		}
+		result, err := d.searchIndex.SearchWithContext(ctx, query, searchRequest)
+		if err != nil {
+			return cacheEntry{}, err
+		}
+		d.recordProcessing(ctx, "SearchWithPagination", result)
+		e := cacheEntry{
+			Docs:           d.hitsToDocs(result.Hits),
+			EstimatedTotal: result.EstimatedTotalHits,
+			ProcessingMs:   result.ProcessingTimeMs,
+		}
+		d.cache.put(key, e)
+		return e, nil
+	})
+	if err != nil {
+		return nil, 0, &DriverError{Op: "SearchWithPagination", Err: err}
+	}
+	return entry.Docs, entry.EstimatedTotal, nil
+}
+
+// publishedAtFilter builds the inclusive published_at window filter; it is
+// empty when both bounds are nil.
+func publishedAtFilter(publishedAfter, publishedBefore *time.Time) string {
+	filterClauses := make([]string, 0, 2)
+	if publishedAfter != nil {
+		filterClauses = append(filterClauses, "published_at >= "+strconv.FormatInt(publishedAfter.Unix(), 10))
+	}
+	if publishedBefore != nil {
+		filterClauses = append(filterClauses, "published_at <= "+strconv.FormatInt(publishedBefore.Unix(), 10))
+	}
+	return strings.Join(filterClauses, " AND ")
+}
+
 // hitsToDocs flattens a Meilisearch result slice into SearchDocumentDriver
 // values, preserving the language and published_at attributes that used to
 // be dropped silently at this boundary. Content is sourced from the cropped
@@ -350,14 +446,18 @@ func (d *MeilisearchDriver) hitsToDocs(hits []meilisearch.Hit) []SearchDocumentD
 	docs := make([]SearchDocumentDriver, 0, len(hits))
 	for _, hit := range hits {
 		docs = append(docs, SearchDocumentDriver{
-			ID:          d.getString(hit, "id"),
-			Title:       d.getString(hit, "title"),
-			Content:     d.getCropped(hit, "content"),
-			Tags:        d.getStringSlice(hit, "tags"),
-			UserID:      d.getString(hit, "user_id"),
-			Language:    d.getString(hit, "language"),
-			Score:       d.getFloat64(hit, "_rankingScore"),
-			PublishedAt: d.getInt64(hit, "published_at"),
+			ID:             d.getString(hit, "id"),
+			Title:          d.getString(hit, "title"),
+			Content:        d.getCropped(hit, "content"),
+			Tags:           d.getStringSlice(hit, "tags"),
+			UserID:         d.getString(hit, "user_id"),
+			FeedID:         d.getString(hit, "feed_id"),
+			FeedTitle:      d.getString(hit, "feed_title"),
+			Author:         d.getString(hit, "author"),
+			FeedPopularity: d.getInt64(hit, "feed_popularity"),
+			Language:       d.getString(hit, "language"),
+			Score:          d.getFloat64(hit, "_rankingScore"),
+			PublishedAt:    d.getInt64(hit, "published_at"),
 		})
 	}
 	return docs
@@ -393,7 +493,7 @@ func (d *MeilisearchDriver) EnsureIndex(ctx context.Context) error {
 	// Configure index settings (best practice: set before indexing) and wait
 	// for each async task so subsequent indexing sees the applied settings.
 
-	searchableAttrs := []string{"title", "content", "tags"}
+	searchableAttrs := d.SearchableAttributes()
 	searchableTask, err := d.index.UpdateSearchableAttributesWithContext(ctx, &searchableAttrs)
 	if err != nil {
 		return &DriverError{
@@ -413,7 +513,13 @@ func (d *MeilisearchDriver) EnsureIndex(ctx context.Context) error {
 	// supports ``published_at >= X AND published_at <= Y`` windows;
 	// ``language`` pairs with the acolyte language_quota rebalancing so
 	// cross-lingual recall can be scoped when the caller opts in.
-	filterableAttrs := []interface{}{"tags", "user_id", "published_at", "language"}
+	// ``feed_id`` is faceted by FeedDocumentCounts to derive per-feed
+	// popularity at index time. ``feed_title`` and ``author`` are joined in
+	// by alt-backend's indexing query so search can filter and facet on
+	// them. Settings are re-applied on every boot and the Phase 1 backfill
+	// then rewrites every document, so documents indexed before an
+	// attribute existed pick it up on the next restart.
+	filterableAttrs := []interface{}{"tags", "user_id", "published_at", "language", "feed_id", "feed_title", "author"}
 	filterableTask, err := d.index.UpdateFilterableAttributesWithContext(ctx, &filterableAttrs)
 	if err != nil {
 		return &DriverError{
@@ -435,6 +541,10 @@ func (d *MeilisearchDriver) EnsureIndex(ctx context.Context) error {
 		"attribute",
 		"sort",
 		"exactness",
+		// Custom ranking attribute: among otherwise equally relevant hits,
+		// prefer articles from feeds with more indexed articles. Placed
+		// last so it only breaks ties and never outranks text relevance.
+		"feed_popularity:desc",
 	}
 	rankingTask, err := d.index.UpdateRankingRulesWithContext(ctx, &rankingRules)
 	if err != nil {
@@ -739,6 +849,29 @@ func (d *MeilisearchDriver) PruneTaskHistory(ctx context.Context, olderThan time
 	return nil
 }
 
+// IndexStatsDriver is the subset of Meilisearch index stats search-indexer reports.
+type IndexStatsDriver struct {
+	NumberOfDocuments int64
+	IsIndexing        bool
+}
+
+// IndexStats fetches the article index stats. Reading them at startup also
+// pulls the index metadata into Meilisearch's page cache ahead of user
+// queries. Uses the admin index: search-only keys lack the stats.get action.
+func (d *MeilisearchDriver) IndexStats(ctx context.Context) (*IndexStatsDriver, error) {
+	stats, err := d.index.GetStatsWithContext(ctx, nil)
+	if err != nil {
+		return nil, &DriverError{
+			Op:  "IndexStats",
+			Err: fmt.Errorf("failed to get index stats: %w", err),
+		}
+	}
+	return &IndexStatsDriver{
+		NumberOfDocuments: stats.NumberOfDocuments,
+		IsIndexing:        stats.IsIndexing,
+	}, nil
+}
+
 // buildSecureFilter creates a secure filter from tag filters
 func (d *MeilisearchDriver) buildSecureFilter(filters []string) string {
 	return makeSecureSearchFilter(filters)
@@ -163,3 +163,24 @@ func BenchmarkMeilisearchDriver_BuildSecureFilter(b *testing.B) {
 		driver.buildSecureFilter(filters)
 	}
 }
+
+func TestBuildFeedFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		feedIDs  []string
+		expected string
+	}{
+		{name: "empty", feedIDs: nil, expected: ""},
+		{name: "single", feedIDs: []string{"f1"}, expected: `feed_id IN ["f1"]`},
+		{name: "multiple", feedIDs: []string{"f1", "f2"}, expected: `feed_id IN ["f1", "f2"]`},
+		{name: "escapes quotes", feedIDs: []string{`f1" OR user_id = "x`}, expected: `feed_id IN ["f1\" OR user_id = \"x"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildFeedFilter(tt.feedIDs); got != tt.expected {
+				t.Errorf("BuildFeedFilter(%v) = %q, want %q", tt.feedIDs, got, tt.expected)
+			}
+		})
+	}
+}
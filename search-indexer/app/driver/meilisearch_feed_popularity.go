@@ -0,0 +1,45 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+// FeedDocumentCounts returns how many indexed documents each of feedIDs has,
+// using a zero-hit faceted search over feed_id. Feeds with no documents are
+// absent from the result. It deliberately skips the search cache: counts
+// change with every indexing batch and are only read on the write path.
+func (d *MeilisearchDriver) FeedDocumentCounts(ctx context.Context, feedIDs []string) (map[string]int64, error) {
+	if len(feedIDs) == 0 {
+		return map[string]int64{}, nil
+	}
+
+	req := &meilisearch.SearchRequest{
+		Limit:  0,
+		Filter: BuildFeedFilter(feedIDs),
+		Facets: []string{"feed_id"},
+	}
+	result, err := d.searchIndex.SearchWithContext(ctx, "", req)
+	if err != nil {
+		return nil, &DriverError{Op: "FeedDocumentCounts", Err: err}
+	}
+
+	var distribution map[string]map[string]int64
+	if len(result.FacetDistribution) > 0 {
+		if err := json.Unmarshal(result.FacetDistribution, &distribution); err != nil {
+			return nil, &DriverError{
+				Op:  "FeedDocumentCounts",
+				Err: fmt.Errorf("decode facet distribution: %w", err),
+			}
+		}
+	}
+
+	counts := make(map[string]int64, len(feedIDs))
+	for id, n := range distribution["feed_id"] {
+		counts[id] = n
+	}
+	return counts, nil
+}
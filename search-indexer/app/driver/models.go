@@ -10,6 +10,9 @@ type ArticleWithTags struct {
 	Tags        []TagModel
 	CreatedAt   time.Time
 	UserID      string
+	FeedID      string
+	FeedTitle   string
+	Author      string
 	Language    string
 	PublishedAt time.Time
 }
@@ -22,15 +25,22 @@ type TagModel struct {
 // SearchDocumentDriver represents a search document in the search engine.
 // PublishedAt is encoded as Unix seconds so Meilisearch can treat it as a
 // numeric filterable attribute (“published_at >= X AND published_at <= Y“).
+// FeedPopularity is always written (no omitempty) because it backs the
+// “feed_popularity:desc“ custom ranking rule, which expects a number on
+// every document.
 type SearchDocumentDriver struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Content     string   `json:"content"`
-	Tags        []string `json:"tags"`
-	UserID      string   `json:"user_id"`
-	Language    string   `json:"language,omitempty"`
-	Score       float64  `json:"score"`
-	PublishedAt int64    `json:"published_at,omitempty"`
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Content        string   `json:"content"`
+	Tags           []string `json:"tags"`
+	UserID         string   `json:"user_id"`
+	FeedID         string   `json:"feed_id,omitempty"`
+	FeedTitle      string   `json:"feed_title,omitempty"`
+	Author         string   `json:"author,omitempty"`
+	FeedPopularity int64    `json:"feed_popularity"`
+	Language       string   `json:"language,omitempty"`
+	Score          float64  `json:"score"`
+	PublishedAt    int64    `json:"published_at,omitempty"`
 }
 
 // DeletedArticle represents a deleted article from the database
@@ -54,3 +64,22 @@ func (e *DriverError) Error() string {
 func (e *DriverError) Unwrap() error {
 	return e.Err
 }
+
+// SearchEventRow is one row of the ClickHouse search_events table, encoded
+// for INSERT ... FORMAT JSONEachRow. Times are UTC text ("2006-01-02
+// 15:04:05.000") so they parse the same whatever the server's time zone;
+// absent date filters are written as null.
+type SearchEventRow struct {
+	Timestamp          string  `json:"timestamp"`
+	Kind               string  `json:"kind"`
+	Query              string  `json:"query"`
+	UserID             string  `json:"user_id"`
+	PublishedAfter     *string `json:"published_after"`
+	PublishedBefore    *string `json:"published_before"`
+	Offset             int64   `json:"offset"`
+	Limit              int     `json:"limit"`
+	ResultCount        int     `json:"result_count"`
+	EstimatedTotalHits int64   `json:"estimated_total_hits"`
+	LatencyMs          float64 `json:"latency_ms"`
+	Error              string  `json:"error"`
+}
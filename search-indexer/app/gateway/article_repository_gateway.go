@@ -73,6 +73,9 @@ func (g *ArticleRepositoryGateway) convertToDomain(driverArticle *driver.Article
 		return nil, err
 	}
 	article.SetLanguage(driverArticle.Language)
+	article.SetFeedID(driverArticle.FeedID)
+	article.SetFeedTitle(driverArticle.FeedTitle)
+	article.SetAuthor(driverArticle.Author)
 	return article, nil
 }
 
@@ -226,6 +226,25 @@ func TestArticleRepositoryGateway_ConvertToDomain(t *testing.T) {
 					article.HasTag("tag2")
 			},
 		},
+		{
+			name: "feed metadata is carried",
+			driverData: &driver.ArticleWithTags{
+				ID:        "test-id",
+				Title:     "Test Title",
+				Content:   "Test Content",
+				CreatedAt: now,
+				FeedID:    "feed-1",
+				FeedTitle: "Go Blog",
+				Author:    "Rob",
+			},
+			wantErr: false,
+			validateRes: func(article *domain.Article) bool {
+				doc := domain.NewSearchDocument(article)
+				return doc.FeedID == "feed-1" &&
+					doc.FeedTitle == "Go Blog" &&
+					doc.Author == "Rob"
+			},
+		},
 		{
 			name: "empty ID should fail",
 			driverData: &driver.ArticleWithTags{
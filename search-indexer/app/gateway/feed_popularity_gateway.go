@@ -0,0 +1,28 @@
+package gateway
+
+import (
+	"context"
+	"search-indexer/domain"
+)
+
+type FeedCountDriver interface {
+	FeedDocumentCounts(ctx context.Context, feedIDs []string) (map[string]int64, error)
+}
+
+// FeedPopularityGateway derives feed popularity from how many documents each
+// feed already has in the search index.
+type FeedPopularityGateway struct {
+	driver FeedCountDriver
+}
+
+func NewFeedPopularityGateway(driver FeedCountDriver) *FeedPopularityGateway {
+	return &FeedPopularityGateway{driver: driver}
+}
+
+func (g *FeedPopularityGateway) FeedPopularity(ctx context.Context, feedIDs []string) (map[string]int64, error) {
+	counts, err := g.driver.FeedDocumentCounts(ctx, feedIDs)
+	if err != nil {
+		return nil, &domain.SearchEngineError{Op: "FeedPopularity", Err: err}
+	}
+	return counts, nil
+}
@@ -13,6 +13,7 @@ type SearchDriver interface {
 	Search(ctx context.Context, query string, limit int) ([]driver.SearchDocumentDriver, error)
 	SearchWithFilters(ctx context.Context, query string, filters []string, limit int) ([]driver.SearchDocumentDriver, error)
 	SearchWithDateFilter(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, limit int) ([]driver.SearchDocumentDriver, error)
+	SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]driver.SearchDocumentDriver, int64, error)
 	SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]driver.SearchDocumentDriver, error)
 	SearchByUserIDWithPagination(ctx context.Context, query string, userID string, offset, limit int64) ([]driver.SearchDocumentDriver, int64, error)
 	EnsureIndex(ctx context.Context) error
@@ -38,13 +39,17 @@ func (g *SearchEngineGateway) IndexDocuments(ctx context.Context, docs []domain.
 	driverDocs := make([]driver.SearchDocumentDriver, len(docs))
 	for i, domainDoc := range docs {
 		driverDocs[i] = driver.SearchDocumentDriver{
-			ID:          domainDoc.ID,
-			Title:       domainDoc.Title,
-			Content:     domainDoc.Content,
-			Tags:        domainDoc.Tags,
-			UserID:      domainDoc.UserID,
-			Language:    domainDoc.Language,
-			PublishedAt: publishedAtUnix(domainDoc.PublishedAt),
+			ID:             domainDoc.ID,
+			Title:          domainDoc.Title,
+			Content:        domainDoc.Content,
+			Tags:           domainDoc.Tags,
+			UserID:         domainDoc.UserID,
+			FeedID:         domainDoc.FeedID,
+			FeedTitle:      domainDoc.FeedTitle,
+			Author:         domainDoc.Author,
+			FeedPopularity: domainDoc.FeedPopularity,
+			Language:       domainDoc.Language,
+			PublishedAt:    publishedAtUnix(domainDoc.PublishedAt),
 		}
 	}
 
@@ -110,6 +115,14 @@ func (g *SearchEngineGateway) SearchWithFilters(ctx context.Context, query strin
 	return g.convertDocs(driverResults), nil
 }
 
+func (g *SearchEngineGateway) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	driverResults, total, err := g.driver.SearchWithPagination(ctx, query, publishedAfter, publishedBefore, offset, limit)
+	if err != nil {
+		return nil, 0, &domain.SearchEngineError{Op: "SearchWithPagination", Err: err}
+	}
+	return g.convertDocs(driverResults), total, nil
+}
+
 func (g *SearchEngineGateway) SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]domain.SearchDocument, error) {
 	driverResults, err := g.driver.SearchByUserID(ctx, query, userID, limit)
 	if err != nil {
@@ -130,14 +143,18 @@ func (g *SearchEngineGateway) convertDocs(driverResults []driver.SearchDocumentD
 	domainResults := make([]domain.SearchDocument, len(driverResults))
 	for i, d := range driverResults {
 		domainResults[i] = domain.SearchDocument{
-			ID:          d.ID,
-			Title:       d.Title,
-			Content:     d.Content,
-			Tags:        d.Tags,
-			UserID:      d.UserID,
-			Language:    d.Language,
-			Score:       d.Score,
-			PublishedAt: publishedAtFromUnix(d.PublishedAt),
+			ID:             d.ID,
+			Title:          d.Title,
+			Content:        d.Content,
+			Tags:           d.Tags,
+			UserID:         d.UserID,
+			FeedID:         d.FeedID,
+			FeedTitle:      d.FeedTitle,
+			Author:         d.Author,
+			FeedPopularity: d.FeedPopularity,
+			Language:       d.Language,
+			Score:          d.Score,
+			PublishedAt:    publishedAtFromUnix(d.PublishedAt),
 		}
 	}
 	return domainResults
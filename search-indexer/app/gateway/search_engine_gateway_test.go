@@ -76,6 +76,13 @@ func (m *mockSearchDriver) SearchWithDateFilter(ctx context.Context, query strin
 	return m.searchResults, nil
 }
 
+func (m *mockSearchDriver) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]driver.SearchDocumentDriver, int64, error) {
+	if m.searchErr != nil {
+		return nil, 0, m.searchErr
+	}
+	return m.searchResults, int64(len(m.searchResults)), nil
+}
+
 func (m *mockSearchDriver) SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]driver.SearchDocumentDriver, error) {
 	if m.searchErr != nil {
 		return nil, m.searchErr
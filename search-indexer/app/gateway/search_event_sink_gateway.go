@@ -0,0 +1,219 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"search-indexer/domain"
+	"search-indexer/driver"
+)
+
+const (
+	searchEventTimeLayout = "2006-01-02 15:04:05.000"
+	searchEventDateLayout = "2006-01-02 15:04:05"
+)
+
+// SearchEventWriter persists a batch of search event rows in one write.
+type SearchEventWriter interface {
+	InsertSearchEvents(ctx context.Context, rows []driver.SearchEventRow) error
+}
+
+// SearchEventSinkConfig sizes the buffer between the search path and the
+// writer.
+type SearchEventSinkConfig struct {
+	// BufferSize is how many events may wait for a flush; events recorded
+	// while the buffer is full are dropped.
+	BufferSize int
+	// BatchSize flushes as soon as this many events are pending.
+	BatchSize int
+	// FlushInterval flushes whatever is pending at least this often.
+	FlushInterval time.Duration
+	// MaxAttempts bounds writes per batch; a batch that still fails is
+	// dropped.
+	MaxAttempts int
+	// RetryDelay is the wait before the first retry, doubled per attempt.
+	RetryDelay time.Duration
+}
+
+// SearchEventSinkGateway implements port.SearchEventSink with a buffered,
+// batching writer. Record never blocks the search that produced the event:
+// analytics are best-effort, so when the writer falls behind or ClickHouse
+// is down, events are dropped and counted rather than slowing searches.
+type SearchEventSinkGateway struct {
+	writer SearchEventWriter
+	cfg    SearchEventSinkConfig
+	logger *slog.Logger
+
+	events  chan domain.SearchEvent
+	dropped atomic.Int64
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewSearchEventSinkGateway creates the sink. Call Start to begin flushing
+// and Stop to flush what is pending on shutdown.
+func NewSearchEventSinkGateway(writer SearchEventWriter, cfg SearchEventSinkConfig, logger *slog.Logger) *SearchEventSinkGateway {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1000
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = time.Second
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SearchEventSinkGateway{
+		writer: writer,
+		cfg:    cfg,
+		logger: logger,
+		events: make(chan domain.SearchEvent, cfg.BufferSize),
+		ctx:    ctx,
+		cancel: cancel,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Record buffers the event, dropping it if the buffer is full.
+func (g *SearchEventSinkGateway) Record(event domain.SearchEvent) {
+	select {
+	case g.events <- event:
+	default:
+		g.dropped.Add(1)
+	}
+}
+
+// Start runs the flush loop in the background.
+func (g *SearchEventSinkGateway) Start() {
+	go g.run()
+}
+
+// Stop flushes the buffered events and waits for the flush loop to exit.
+// Writes still in flight when ctx expires are cancelled.
+func (g *SearchEventSinkGateway) Stop(ctx context.Context) {
+	g.stopOnce.Do(func() { close(g.stop) })
+	select {
+	case <-g.done:
+	case <-ctx.Done():
+		g.cancel()
+		<-g.done
+	}
+	g.cancel()
+}
+
+func (g *SearchEventSinkGateway) run() {
+	defer close(g.done)
+
+	ticker := time.NewTicker(g.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.SearchEvent, 0, g.cfg.BatchSize)
+	flush := func() {
+		g.flush(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case event := <-g.events:
+			batch = append(batch, event)
+			if len(batch) >= g.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-g.stop:
+			for {
+				select {
+				case event := <-g.events:
+					batch = append(batch, event)
+					if len(batch) >= g.cfg.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes batch, retrying with exponential backoff up to MaxAttempts.
+func (g *SearchEventSinkGateway) flush(batch []domain.SearchEvent) {
+	if dropped := g.dropped.Swap(0); dropped > 0 {
+		g.logger.Warn("search events dropped: analytics buffer full", "dropped", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	rows := make([]driver.SearchEventRow, len(batch))
+	for i, event := range batch {
+		rows[i] = toSearchEventRow(event)
+	}
+
+	delay := g.cfg.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := g.writer.InsertSearchEvents(g.ctx, rows)
+		if err == nil {
+			return
+		}
+		if attempt >= g.cfg.MaxAttempts || g.ctx.Err() != nil {
+			g.logger.Warn("search events dropped: analytics write failed",
+				"events", len(rows),
+				"attempts", attempt,
+				"err", err,
+			)
+			return
+		}
+		g.logger.Debug("retrying search event write", "attempt", attempt, "err", err)
+		select {
+		case <-time.After(delay):
+		case <-g.ctx.Done():
+		}
+		delay *= 2
+	}
+}
+
+func toSearchEventRow(event domain.SearchEvent) driver.SearchEventRow {
+	return driver.SearchEventRow{
+		Timestamp:          event.OccurredAt.UTC().Format(searchEventTimeLayout),
+		Kind:               string(event.Kind),
+		Query:              event.Query,
+		UserID:             event.UserID,
+		PublishedAfter:     formatSearchEventDate(event.PublishedAfter),
+		PublishedBefore:    formatSearchEventDate(event.PublishedBefore),
+		Offset:             event.Offset,
+		Limit:              event.Limit,
+		ResultCount:        event.ResultCount,
+		EstimatedTotalHits: event.EstimatedTotalHits,
+		LatencyMs:          float64(event.Latency.Microseconds()) / 1000,
+		Error:              event.Error,
+	}
+}
+
+func formatSearchEventDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.UTC().Format(searchEventDateLayout)
+	return &s
+}
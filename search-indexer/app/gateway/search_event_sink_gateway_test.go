@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"search-indexer/domain"
+	"search-indexer/driver"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockSearchEventWriter struct {
+	mu       sync.Mutex
+	batches  [][]driver.SearchEventRow
+	calls    int
+	failures int
+}
+
+func (m *mockSearchEventWriter) InsertSearchEvents(ctx context.Context, rows []driver.SearchEventRow) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("clickhouse unavailable")
+	}
+	m.batches = append(m.batches, append([]driver.SearchEventRow(nil), rows...))
+	return nil
+}
+
+func (m *mockSearchEventWriter) snapshot() ([][]driver.SearchEventRow, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.batches, m.calls
+}
+
+func newTestSearchEventSink(writer SearchEventWriter, cfg SearchEventSinkConfig) *SearchEventSinkGateway {
+	return NewSearchEventSinkGateway(writer, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestSearchEventSink_FlushesFullBatch(t *testing.T) {
+	writer := &mockSearchEventWriter{}
+	sink := newTestSearchEventSink(writer, SearchEventSinkConfig{BatchSize: 2, FlushInterval: time.Hour})
+	sink.Start()
+	defer sink.Stop(context.Background())
+
+	after := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	sink.Record(domain.SearchEvent{
+		OccurredAt:     time.Date(2026, 10, 16, 9, 30, 15, 123_000_000, time.FixedZone("JST", 9*3600)),
+		Kind:           domain.SearchEventArticlesByDate,
+		Query:          "go generics",
+		PublishedAfter: &after,
+		Limit:          50,
+		ResultCount:    7,
+		Latency:        12500 * time.Microsecond,
+	})
+	sink.Record(domain.SearchEvent{OccurredAt: time.Now(), Kind: domain.SearchEventByUser, Query: "rust", UserID: "u1"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		batches, _ := writer.snapshot()
+		if len(batches) == 1 {
+			row := batches[0][0]
+			if len(batches[0]) != 2 {
+				t.Fatalf("expected a batch of 2, got %d", len(batches[0]))
+			}
+			if row.Timestamp != "2026-10-16 00:30:15.123" {
+				t.Errorf("timestamp = %q, want UTC with milliseconds", row.Timestamp)
+			}
+			if row.Kind != "articles_date_filter" || row.ResultCount != 7 || row.LatencyMs != 12.5 {
+				t.Errorf("unexpected row %+v", row)
+			}
+			if row.PublishedAfter == nil || *row.PublishedAfter != "2026-10-01 00:00:00" || row.PublishedBefore != nil {
+				t.Errorf("unexpected date filters %v %v", row.PublishedAfter, row.PublishedBefore)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("batch was not flushed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSearchEventSink_RetriesFailedWrite(t *testing.T) {
+	writer := &mockSearchEventWriter{failures: 2}
+	sink := newTestSearchEventSink(writer, SearchEventSinkConfig{
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		MaxAttempts:   3,
+		RetryDelay:    time.Millisecond,
+	})
+	sink.Start()
+
+	sink.Record(domain.SearchEvent{Kind: domain.SearchEventArticles, Query: "q"})
+	sink.Stop(context.Background())
+
+	batches, calls := writer.snapshot()
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	if len(batches) != 1 || len(batches[0]) != 1 {
+		t.Errorf("expected the batch to land on the third attempt, got %v", batches)
+	}
+}
+
+func TestSearchEventSink_GivesUpAfterMaxAttempts(t *testing.T) {
+	writer := &mockSearchEventWriter{failures: 10}
+	sink := newTestSearchEventSink(writer, SearchEventSinkConfig{MaxAttempts: 2, RetryDelay: time.Millisecond})
+	sink.Start()
+
+	sink.Record(domain.SearchEvent{Kind: domain.SearchEventArticles, Query: "q"})
+	sink.Stop(context.Background())
+
+	if batches, calls := writer.snapshot(); calls != 2 || len(batches) != 0 {
+		t.Errorf("expected 2 failed attempts and no batch, got %d calls, %d batches", calls, len(batches))
+	}
+}
+
+func TestSearchEventSink_DropsWhenBufferFull(t *testing.T) {
+	writer := &mockSearchEventWriter{}
+	sink := newTestSearchEventSink(writer, SearchEventSinkConfig{BufferSize: 2, BatchSize: 10, FlushInterval: time.Hour})
+
+	// Not started: nothing drains the buffer, so the third event is dropped
+	// instead of blocking the caller.
+	for range 3 {
+		sink.Record(domain.SearchEvent{Kind: domain.SearchEventArticles, Query: "q"})
+	}
+	if got := sink.dropped.Load(); got != 1 {
+		t.Fatalf("expected 1 dropped event, got %d", got)
+	}
+
+	sink.Start()
+	sink.Stop(context.Background())
+
+	batches, _ := writer.snapshot()
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Errorf("expected the 2 buffered events flushed on Stop, got %v", batches)
+	}
+}
@@ -75,6 +75,9 @@ const (
 	// BackendInternalServiceListUntaggedArticlesProcedure is the fully-qualified name of the
 	// BackendInternalService's ListUntaggedArticles RPC.
 	BackendInternalServiceListUntaggedArticlesProcedure = "/services.backend.v1.BackendInternalService/ListUntaggedArticles"
+	// BackendInternalServiceBatchGetTagsByArticleIDsProcedure is the fully-qualified name of the
+	// BackendInternalService's BatchGetTagsByArticleIDs RPC.
+	BackendInternalServiceBatchGetTagsByArticleIDsProcedure = "/services.backend.v1.BackendInternalService/BatchGetTagsByArticleIDs"
 	// BackendInternalServiceDeleteArticleSummaryProcedure is the fully-qualified name of the
 	// BackendInternalService's DeleteArticleSummary RPC.
 	BackendInternalServiceDeleteArticleSummaryProcedure = "/services.backend.v1.BackendInternalService/DeleteArticleSummary"
@@ -99,6 +102,9 @@ const (
 	// BackendInternalServiceFetchArticlesByTagProcedure is the fully-qualified name of the
 	// BackendInternalService's FetchArticlesByTag RPC.
 	BackendInternalServiceFetchArticlesByTagProcedure = "/services.backend.v1.BackendInternalService/FetchArticlesByTag"
+	// BackendInternalServiceListArticlesForReindexProcedure is the fully-qualified name of the
+	// BackendInternalService's ListArticlesForReindex RPC.
+	BackendInternalServiceListArticlesForReindexProcedure = "/services.backend.v1.BackendInternalService/ListArticlesForReindex"
 	// BackendInternalServiceListRecapArticlesProcedure is the fully-qualified name of the
 	// BackendInternalService's ListRecapArticles RPC.
 	BackendInternalServiceListRecapArticlesProcedure = "/services.backend.v1.BackendInternalService/ListRecapArticles"
@@ -137,6 +143,11 @@ type BackendInternalServiceClient interface {
 	BatchUpsertArticleTags(context.Context, *connect.Request[v1.BatchUpsertArticleTagsRequest]) (*connect.Response[v1.BatchUpsertArticleTagsResponse], error)
 	// ListUntaggedArticles returns articles without tags.
 	ListUntaggedArticles(context.Context, *connect.Request[v1.ListUntaggedArticlesRequest]) (*connect.Response[v1.ListUntaggedArticlesResponse], error)
+	// BatchGetTagsByArticleIDs returns tags for a batch of article ids.
+	// Replaces tag-generator /api/v1/tags/batch (ADR-000241 / ADR-000397)
+	// so recap-worker fetches tags directly from the data owner instead
+	// of reading alt-backend tables through tag-generator.
+	BatchGetTagsByArticleIDs(context.Context, *connect.Request[v1.BatchGetTagsByArticleIDsRequest]) (*connect.Response[v1.BatchGetTagsByArticleIDsResponse], error)
 	// DeleteArticleSummary deletes an article summary by article ID.
 	DeleteArticleSummary(context.Context, *connect.Request[v1.DeleteArticleSummaryRequest]) (*connect.Response[v1.DeleteArticleSummaryResponse], error)
 	// CheckArticleSummaryExists checks if an article summary exists.
@@ -154,6 +165,9 @@ type BackendInternalServiceClient interface {
 	FetchTagCloud(context.Context, *connect.Request[v1.BackendInternalServiceFetchTagCloudRequest]) (*connect.Response[v1.BackendInternalServiceFetchTagCloudResponse], error)
 	// FetchArticlesByTag returns articles filtered by tag name.
 	FetchArticlesByTag(context.Context, *connect.Request[v1.BackendInternalServiceFetchArticlesByTagRequest]) (*connect.Response[v1.BackendInternalServiceFetchArticlesByTagResponse], error)
+	// ListArticlesForReindex returns articles matching date/feed/user filters
+	// using backward keyset pagination. Used by rag-orchestrator partial reindex.
+	ListArticlesForReindex(context.Context, *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error)
 	// ListRecapArticles returns paginated articles within a time window.
 	// Service-to-service only; auth is established at the mTLS transport layer.
 	ListRecapArticles(context.Context, *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error)
@@ -254,6 +268,12 @@ func NewBackendInternalServiceClient(httpClient connect.HTTPClient, baseURL stri
 			connect.WithSchema(backendInternalServiceMethods.ByName("ListUntaggedArticles")),
 			connect.WithClientOptions(opts...),
 		),
+		batchGetTagsByArticleIDs: connect.NewClient[v1.BatchGetTagsByArticleIDsRequest, v1.BatchGetTagsByArticleIDsResponse](
+			httpClient,
+			baseURL+BackendInternalServiceBatchGetTagsByArticleIDsProcedure,
+			connect.WithSchema(backendInternalServiceMethods.ByName("BatchGetTagsByArticleIDs")),
+			connect.WithClientOptions(opts...),
+		),
 		deleteArticleSummary: connect.NewClient[v1.DeleteArticleSummaryRequest, v1.DeleteArticleSummaryResponse](
 			httpClient,
 			baseURL+BackendInternalServiceDeleteArticleSummaryProcedure,
@@ -302,6 +322,12 @@ func NewBackendInternalServiceClient(httpClient connect.HTTPClient, baseURL stri
 			connect.WithSchema(backendInternalServiceMethods.ByName("FetchArticlesByTag")),
 			connect.WithClientOptions(opts...),
 		),
+		listArticlesForReindex: connect.NewClient[v1.ListArticlesForReindexRequest, v1.ListArticlesForReindexResponse](
+			httpClient,
+			baseURL+BackendInternalServiceListArticlesForReindexProcedure,
+			connect.WithSchema(backendInternalServiceMethods.ByName("ListArticlesForReindex")),
+			connect.WithClientOptions(opts...),
+		),
 		listRecapArticles: connect.NewClient[v1.ListRecapArticlesRequest, v1.ListRecapArticlesResponse](
 			httpClient,
 			baseURL+BackendInternalServiceListRecapArticlesProcedure,
@@ -327,6 +353,7 @@ type backendInternalServiceClient struct {
 	upsertArticleTags           *connect.Client[v1.UpsertArticleTagsRequest, v1.UpsertArticleTagsResponse]
 	batchUpsertArticleTags      *connect.Client[v1.BatchUpsertArticleTagsRequest, v1.BatchUpsertArticleTagsResponse]
 	listUntaggedArticles        *connect.Client[v1.ListUntaggedArticlesRequest, v1.ListUntaggedArticlesResponse]
+	batchGetTagsByArticleIDs    *connect.Client[v1.BatchGetTagsByArticleIDsRequest, v1.BatchGetTagsByArticleIDsResponse]
 	deleteArticleSummary        *connect.Client[v1.DeleteArticleSummaryRequest, v1.DeleteArticleSummaryResponse]
 	checkArticleSummaryExists   *connect.Client[v1.CheckArticleSummaryExistsRequest, v1.CheckArticleSummaryExistsResponse]
 	findArticlesWithSummaries   *connect.Client[v1.FindArticlesWithSummariesRequest, v1.FindArticlesWithSummariesResponse]
@@ -335,6 +362,7 @@ type backendInternalServiceClient struct {
 	getEmptyFeedID              *connect.Client[v1.GetEmptyFeedIDRequest, v1.GetEmptyFeedIDResponse]
 	fetchTagCloud               *connect.Client[v1.BackendInternalServiceFetchTagCloudRequest, v1.BackendInternalServiceFetchTagCloudResponse]
 	fetchArticlesByTag          *connect.Client[v1.BackendInternalServiceFetchArticlesByTagRequest, v1.BackendInternalServiceFetchArticlesByTagResponse]
+	listArticlesForReindex      *connect.Client[v1.ListArticlesForReindexRequest, v1.ListArticlesForReindexResponse]
 	listRecapArticles           *connect.Client[v1.ListRecapArticlesRequest, v1.ListRecapArticlesResponse]
 }
 
@@ -410,6 +438,12 @@ func (c *backendInternalServiceClient) ListUntaggedArticles(ctx context.Context,
 	return c.listUntaggedArticles.CallUnary(ctx, req)
 }
 
+// BatchGetTagsByArticleIDs calls
+// services.backend.v1.BackendInternalService.BatchGetTagsByArticleIDs.
+func (c *backendInternalServiceClient) BatchGetTagsByArticleIDs(ctx context.Context, req *connect.Request[v1.BatchGetTagsByArticleIDsRequest]) (*connect.Response[v1.BatchGetTagsByArticleIDsResponse], error) {
+	return c.batchGetTagsByArticleIDs.CallUnary(ctx, req)
+}
+
 // DeleteArticleSummary calls services.backend.v1.BackendInternalService.DeleteArticleSummary.
 func (c *backendInternalServiceClient) DeleteArticleSummary(ctx context.Context, req *connect.Request[v1.DeleteArticleSummaryRequest]) (*connect.Response[v1.DeleteArticleSummaryResponse], error) {
 	return c.deleteArticleSummary.CallUnary(ctx, req)
@@ -453,6 +487,11 @@ func (c *backendInternalServiceClient) FetchArticlesByTag(ctx context.Context, r
 	return c.fetchArticlesByTag.CallUnary(ctx, req)
 }
 
+// ListArticlesForReindex calls services.backend.v1.BackendInternalService.ListArticlesForReindex.
+func (c *backendInternalServiceClient) ListArticlesForReindex(ctx context.Context, req *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error) {
+	return c.listArticlesForReindex.CallUnary(ctx, req)
+}
+
 // ListRecapArticles calls services.backend.v1.BackendInternalService.ListRecapArticles.
 func (c *backendInternalServiceClient) ListRecapArticles(ctx context.Context, req *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error) {
 	return c.listRecapArticles.CallUnary(ctx, req)
@@ -491,6 +530,11 @@ type BackendInternalServiceHandler interface {
 	BatchUpsertArticleTags(context.Context, *connect.Request[v1.BatchUpsertArticleTagsRequest]) (*connect.Response[v1.BatchUpsertArticleTagsResponse], error)
 	// ListUntaggedArticles returns articles without tags.
 	ListUntaggedArticles(context.Context, *connect.Request[v1.ListUntaggedArticlesRequest]) (*connect.Response[v1.ListUntaggedArticlesResponse], error)
+	// BatchGetTagsByArticleIDs returns tags for a batch of article ids.
+	// Replaces tag-generator /api/v1/tags/batch (ADR-000241 / ADR-000397)
+	// so recap-worker fetches tags directly from the data owner instead
+	// of reading alt-backend tables through tag-generator.
+	BatchGetTagsByArticleIDs(context.Context, *connect.Request[v1.BatchGetTagsByArticleIDsRequest]) (*connect.Response[v1.BatchGetTagsByArticleIDsResponse], error)
 	// DeleteArticleSummary deletes an article summary by article ID.
 	DeleteArticleSummary(context.Context, *connect.Request[v1.DeleteArticleSummaryRequest]) (*connect.Response[v1.DeleteArticleSummaryResponse], error)
 	// CheckArticleSummaryExists checks if an article summary exists.
@@ -508,6 +552,9 @@ type BackendInternalServiceHandler interface {
 	FetchTagCloud(context.Context, *connect.Request[v1.BackendInternalServiceFetchTagCloudRequest]) (*connect.Response[v1.BackendInternalServiceFetchTagCloudResponse], error)
 	// FetchArticlesByTag returns articles filtered by tag name.
 	FetchArticlesByTag(context.Context, *connect.Request[v1.BackendInternalServiceFetchArticlesByTagRequest]) (*connect.Response[v1.BackendInternalServiceFetchArticlesByTagResponse], error)
+	// ListArticlesForReindex returns articles matching date/feed/user filters
+	// using backward keyset pagination. Used by rag-orchestrator partial reindex.
+	ListArticlesForReindex(context.Context, *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error)
 	// ListRecapArticles returns paginated articles within a time window.
 	// Service-to-service only; auth is established at the mTLS transport layer.
 	ListRecapArticles(context.Context, *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error)
@@ -604,6 +651,12 @@ func NewBackendInternalServiceHandler(svc BackendInternalServiceHandler, opts ..
 		connect.WithSchema(backendInternalServiceMethods.ByName("ListUntaggedArticles")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backendInternalServiceBatchGetTagsByArticleIDsHandler := connect.NewUnaryHandler(
+		BackendInternalServiceBatchGetTagsByArticleIDsProcedure,
+		svc.BatchGetTagsByArticleIDs,
+		connect.WithSchema(backendInternalServiceMethods.ByName("BatchGetTagsByArticleIDs")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backendInternalServiceDeleteArticleSummaryHandler := connect.NewUnaryHandler(
 		BackendInternalServiceDeleteArticleSummaryProcedure,
 		svc.DeleteArticleSummary,
@@ -652,6 +705,12 @@ func NewBackendInternalServiceHandler(svc BackendInternalServiceHandler, opts ..
 		connect.WithSchema(backendInternalServiceMethods.ByName("FetchArticlesByTag")),
 		connect.WithHandlerOptions(opts...),
 	)
+	backendInternalServiceListArticlesForReindexHandler := connect.NewUnaryHandler(
+		BackendInternalServiceListArticlesForReindexProcedure,
+		svc.ListArticlesForReindex,
+		connect.WithSchema(backendInternalServiceMethods.ByName("ListArticlesForReindex")),
+		connect.WithHandlerOptions(opts...),
+	)
 	backendInternalServiceListRecapArticlesHandler := connect.NewUnaryHandler(
 		BackendInternalServiceListRecapArticlesProcedure,
 		svc.ListRecapArticles,
@@ -688,6 +747,8 @@ func NewBackendInternalServiceHandler(svc BackendInternalServiceHandler, opts ..
 			backendInternalServiceBatchUpsertArticleTagsHandler.ServeHTTP(w, r)
 		case BackendInternalServiceListUntaggedArticlesProcedure:
 			backendInternalServiceListUntaggedArticlesHandler.ServeHTTP(w, r)
+		case BackendInternalServiceBatchGetTagsByArticleIDsProcedure:
+			backendInternalServiceBatchGetTagsByArticleIDsHandler.ServeHTTP(w, r)
 		case BackendInternalServiceDeleteArticleSummaryProcedure:
 			backendInternalServiceDeleteArticleSummaryHandler.ServeHTTP(w, r)
 		case BackendInternalServiceCheckArticleSummaryExistsProcedure:
@@ -704,6 +765,8 @@ func NewBackendInternalServiceHandler(svc BackendInternalServiceHandler, opts ..
 			backendInternalServiceFetchTagCloudHandler.ServeHTTP(w, r)
 		case BackendInternalServiceFetchArticlesByTagProcedure:
 			backendInternalServiceFetchArticlesByTagHandler.ServeHTTP(w, r)
+		case BackendInternalServiceListArticlesForReindexProcedure:
+			backendInternalServiceListArticlesForReindexHandler.ServeHTTP(w, r)
 		case BackendInternalServiceListRecapArticlesProcedure:
 			backendInternalServiceListRecapArticlesHandler.ServeHTTP(w, r)
 		default:
@@ -771,6 +834,10 @@ func (UnimplementedBackendInternalServiceHandler) ListUntaggedArticles(context.C
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.ListUntaggedArticles is not implemented"))
 }
 
+func (UnimplementedBackendInternalServiceHandler) BatchGetTagsByArticleIDs(context.Context, *connect.Request[v1.BatchGetTagsByArticleIDsRequest]) (*connect.Response[v1.BatchGetTagsByArticleIDsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.BatchGetTagsByArticleIDs is not implemented"))
+}
+
 func (UnimplementedBackendInternalServiceHandler) DeleteArticleSummary(context.Context, *connect.Request[v1.DeleteArticleSummaryRequest]) (*connect.Response[v1.DeleteArticleSummaryResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.DeleteArticleSummary is not implemented"))
 }
@@ -803,6 +870,10 @@ func (UnimplementedBackendInternalServiceHandler) FetchArticlesByTag(context.Con
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.FetchArticlesByTag is not implemented"))
 }
 
+func (UnimplementedBackendInternalServiceHandler) ListArticlesForReindex(context.Context, *connect.Request[v1.ListArticlesForReindexRequest]) (*connect.Response[v1.ListArticlesForReindexResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.ListArticlesForReindex is not implemented"))
+}
+
 func (UnimplementedBackendInternalServiceHandler) ListRecapArticles(context.Context, *connect.Request[v1.ListRecapArticlesRequest]) (*connect.Response[v1.ListRecapArticlesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("services.backend.v1.BackendInternalService.ListRecapArticles is not implemented"))
 }
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"search-indexer/logger"
+)
+
+// APIKeyHeader is the header plaintext-listener callers put their key in.
+// "Authorization: Bearer <key>" is accepted as well.
+const APIKeyHeader = "X-API-Key"
+
+// Caller kinds recorded on a Caller.
+const (
+	CallerKindAPIKey    = "api_key"
+	CallerKindPeer      = "peer"
+	CallerKindAnonymous = "anonymous"
+)
+
+// Caller is the authenticated identity behind a request: the API key's
+// name, the mTLS client cert CN, or "anonymous".
+type Caller struct {
+	ID   string
+	Kind string
+}
+
+type callerContextKey struct{}
+
+// WithCaller returns a copy of ctx carrying c.
+func WithCaller(ctx context.Context, c Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, c)
+}
+
+// CallerFromContext returns the caller set by APIKeyAuth or
+// PeerIdentityMiddleware, if any.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	c, ok := ctx.Value(callerContextKey{}).(Caller)
+	return c, ok
+}
+
+// APIKeyAuth authenticates plaintext-listener requests by API key. Keys are
+// compared as SHA-256 digests in constant time so neither the key length
+// nor a matching prefix leaks through response timing.
+//
+// Requests without a key get 401 unless anonymous access is allowed, in
+// which case they proceed as the shared "anonymous" caller; config.Load
+// only permits that in development. A wrong key is always 401, even when
+// anonymous access is allowed, so a misconfigured client fails loudly.
+type APIKeyAuth struct {
+	keys           []apiKey
+	allowAnonymous bool
+}
+
+type apiKey struct {
+	name   string
+	digest [sha256.Size]byte
+}
+
+// NewAPIKeyAuth builds the middleware from a caller-name -> key map. An
+// empty map with allowAnonymous false rejects every request.
+func NewAPIKeyAuth(keys map[string]string, allowAnonymous bool) *APIKeyAuth {
+	a := &APIKeyAuth{allowAnonymous: allowAnonymous, keys: make([]apiKey, 0, len(keys))}
+	for name, key := range keys {
+		a.keys = append(a.keys, apiKey{name: name, digest: sha256.Sum256([]byte(key))})
+	}
+	return a
+}
+
+// Require wraps next so that only authenticated callers reach it. The
+// caller is stored on the request context for the rate limiter and audit
+// log downstream.
+func (a *APIKeyAuth) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented := presentedAPIKey(r)
+		if presented == "" {
+			if !a.allowAnonymous {
+				logger.Logger.LogAttrs(r.Context(), slog.LevelWarn,
+					"api_key_auth: missing api key",
+					slog.String("path", r.URL.Path),
+					slog.String("remote_addr", r.RemoteAddr),
+				)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="search-indexer"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			caller := Caller{ID: CallerKindAnonymous, Kind: CallerKindAnonymous}
+			next.ServeHTTP(w, r.WithContext(WithCaller(r.Context(), caller)))
+			return
+		}
+
+		name, ok := a.lookup(presented)
+		if !ok {
+			logger.Logger.LogAttrs(r.Context(), slog.LevelWarn,
+				"api_key_auth: unknown api key",
+				slog.String("path", r.URL.Path),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="search-indexer", error="invalid_token"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		caller := Caller{ID: name, Kind: CallerKindAPIKey}
+		next.ServeHTTP(w, r.WithContext(WithCaller(r.Context(), caller)))
+	})
+}
+
+// lookup checks the presented key against every configured key without
+// returning early, so the time taken does not depend on which one matched.
+func (a *APIKeyAuth) lookup(presented string) (string, bool) {
+	digest := sha256.Sum256([]byte(presented))
+	var name string
+	found := false
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare(digest[:], k.digest[:]) == 1 {
+			name, found = k.name, true
+		}
+	}
+	return name, found
+}
+
+func presentedAPIKey(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get(APIKeyHeader)); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func callerEchoHandler(t *testing.T, want Caller) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := CallerFromContext(r.Context())
+		if !ok || got != want {
+			t.Errorf("caller: got %+v (present=%v), want %+v", got, ok, want)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestAPIKeyAuth_AcceptsConfiguredKey(t *testing.T) {
+	auth := NewAPIKeyAuth(map[string]string{"rag-orchestrator": "k-rag", "acolyte": "k-acolyte"}, false)
+	h := auth.Require(callerEchoHandler(t, Caller{ID: "acolyte", Kind: CallerKindAPIKey}))
+
+	for name, set := range map[string]func(*http.Request){
+		"x-api-key header": func(r *http.Request) { r.Header.Set(APIKeyHeader, "k-acolyte") },
+		"bearer token":     func(r *http.Request) { r.Header.Set("Authorization", "Bearer k-acolyte") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/search?q=go", nil)
+			set(req)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status: got %d, want 200", rec.Code)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_RejectsMissingAndUnknownKeys(t *testing.T) {
+	auth := NewAPIKeyAuth(map[string]string{"rag-orchestrator": "k-rag"}, false)
+	h := auth.Require(newTestHandler())
+
+	for name, key := range map[string]string{"missing": "", "unknown": "k-rag-but-longer"} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/search?q=go", nil)
+			if key != "" {
+				req.Header.Set(APIKeyHeader, key)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("status: got %d, want 401", rec.Code)
+			}
+			if rec.Header().Get("WWW-Authenticate") == "" {
+				t.Fatal("WWW-Authenticate header missing on 401")
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_AnonymousWhenAllowed(t *testing.T) {
+	auth := NewAPIKeyAuth(nil, true)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=go", nil)
+	rec := httptest.NewRecorder()
+	auth.Require(callerEchoHandler(t, Caller{ID: CallerKindAnonymous, Kind: CallerKindAnonymous})).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("anonymous status: got %d, want 200", rec.Code)
+	}
+
+	// A wrong key still fails instead of silently falling back to anonymous.
+	req = httptest.NewRequest(http.MethodGet, "/v1/search?q=go", nil)
+	req.Header.Set(APIKeyHeader, "stale")
+	rec = httptest.NewRecorder()
+	auth.Require(newTestHandler()).ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong key status: got %d, want 401", rec.Code)
+	}
+}
+
+func TestPeerIdentity_SetsCaller(t *testing.T) {
+	m := NewPeerIdentityMiddleware([]string{"alt-backend"})
+	h := m.Require(callerEchoHandler(t, Caller{ID: "alt-backend", Kind: CallerKindPeer}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search", nil)
+	req.TLS = tlsStateWithCN("alt-backend")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want 200", rec.Code)
+	}
+}
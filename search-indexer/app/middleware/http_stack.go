@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"search-indexer/logger"
+)
+
+// Middleware wraps an http.Handler. The REST listener composes its stack
+// from these with Chain.
+type Middleware func(http.Handler) http.Handler
+
+// Chain applies mws to h so that the first middleware is the outermost:
+// Chain(h, A, B) serves a request as A -> B -> h.
+func Chain(h http.Handler, mws ...Middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// RequestLogger logs one line per request with method, path, status and
+// latency. Query strings are not logged: /v1/search carries user queries
+// and user IDs.
+func RequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := newStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		level := logger.Logger.InfoContext
+		if rec.status >= http.StatusInternalServerError {
+			level = logger.Logger.ErrorContext
+		}
+		level(r.Context(), "http request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"remote_addr", r.RemoteAddr,
+		)
+	})
+}
+
+// Recover turns a handler panic into a 500 response and an error log with
+// the stack, so one bad request cannot take down the listener goroutine's
+// connection without a trace. http.ErrAbortHandler is re-panicked because
+// net/http uses it to abort a response deliberately.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := newStatusRecorder(w)
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(v)
+			}
+			logger.Logger.ErrorContext(r.Context(), "http handler panic",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"panic", v,
+				"stack", string(debug.Stack()),
+			)
+			if !rec.wroteHeader {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"internal server error"}`))
+			}
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// Timeout bounds each request to d using http.TimeoutHandler, which cancels
+// the request context and answers 503 once the budget is spent. d should sit
+// below the server's WriteTimeout so callers get a response instead of a
+// reset connection. A non-positive d disables the bound.
+func Timeout(d time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+		return http.TimeoutHandler(next, d, `{"error":"request timed out"}`)
+	}
+}
+
+// CORS answers browser preflight requests and sets Access-Control-Allow-Origin
+// for origins in allowedOrigins. An empty list disables CORS entirely: no
+// headers are added and OPTIONS requests reach the router as usual. "*"
+// allows any origin.
+func CORS(allowedOrigins []string) Middleware {
+	allowAny := slices.Contains(allowedOrigins, "*")
+	return func(next http.Handler) http.Handler {
+		if len(allowedOrigins) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || !(allowAny || slices.Contains(allowedOrigins, origin)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			if allowAny {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Set("Access-Control-Allow-Methods", strings.Join([]string{http.MethodGet, http.MethodOptions}, ", "))
+				h.Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+				h.Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChain_OrderIsOutermostFirst(t *testing.T) {
+	t.Parallel()
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	h := Chain(newTestHandler(), mark("a"), mark("b"))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if strings.Join(order, ",") != "a,b" {
+		t.Fatalf("got order %v, want [a b]", order)
+	}
+}
+
+func TestRecover_PanicBecomes500(t *testing.T) {
+	t.Parallel()
+	h := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/search", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("got %d, want 500", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "internal server error") {
+		t.Fatalf("unexpected body %q", rec.Body.String())
+	}
+}
+
+func TestRecover_ReraisesErrAbortHandler(t *testing.T) {
+	t.Parallel()
+	h := Recover(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Fatalf("got recover() = %v, want http.ErrAbortHandler", r)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestTimeout_SlowHandlerGets503(t *testing.T) {
+	t.Parallel()
+	h := Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/search", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want 503", rec.Code)
+	}
+}
+
+func TestCORS_DisabledWhenNoOrigins(t *testing.T) {
+	t.Parallel()
+	h := CORS(nil)(newTestHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("got Access-Control-Allow-Origin %q, want none", got)
+	}
+}
+
+func TestCORS_AllowedOriginAndPreflight(t *testing.T) {
+	t.Parallel()
+	h := CORS([]string{"https://alt.example"})(newTestHandler())
+
+	req := httptest.NewRequest(http.MethodOptions, "/v1/search", nil)
+	req.Header.Set("Origin", "https://alt.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("preflight: got %d, want 204", rec.Code)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://alt.example" {
+		t.Fatalf("got Access-Control-Allow-Origin %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/search", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("disallowed origin got Access-Control-Allow-Origin %q", got)
+	}
+}
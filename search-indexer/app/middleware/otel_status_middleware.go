@@ -31,6 +31,12 @@ func (r *statusRecorder) WriteHeader(code int) {
 	r.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController, so
+// streaming handlers can flush and extend write deadlines through the stack.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // Write is intentionally not overridden: body bytes pass through the embedded
 // ResponseWriter so this middleware never sits on the XSS sink. Status defaults
 // to 200 when handlers Write without WriteHeader.
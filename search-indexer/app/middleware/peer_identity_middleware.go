@@ -17,8 +17,9 @@ const PeerIdentityHeader = "X-Alt-Peer-Identity"
 //
 // On mismatch the handler returns 403 Forbidden and the raw CN is written
 // to the structured log so auditors can see the offending peer. On match
-// the CN is propagated downstream via the X-Alt-Peer-Identity header so
-// handler code can log / partition / authorize on the authenticated caller.
+// the CN is propagated downstream via the X-Alt-Peer-Identity header and as
+// the request's Caller so handler code can log / partition / authorize on
+// the authenticated caller.
 //
 // Plaintext requests (r.TLS == nil) are refused with 401 — this middleware
 // is intended to be wired only on the :9443 mTLS listener, never on the
@@ -71,6 +72,6 @@ func (m *PeerIdentityMiddleware) Require(next http.Handler) http.Handler {
 			slog.String("peer", cn),
 			slog.String("path", r.URL.Path),
 		)
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(WithCaller(r.Context(), Caller{ID: cn, Kind: CallerKindPeer})))
 	})
 }
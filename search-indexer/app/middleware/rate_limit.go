@@ -4,6 +4,7 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"sync"
 
 	"golang.org/x/time/rate"
 
@@ -12,10 +13,8 @@ import (
 
 // RateLimiter wraps a single golang.org/x/time/rate Limiter to defend
 // search-indexer against request floods from trusted-but-misbehaving
-// internal callers. Since every caller is already authenticated with
-// TLS peer identity, a global token bucket is sufficient — per-caller
-// identification would require propagating caller identity, which
-// ADR-000717 defers.
+// internal callers. It caps total throughput; CallerRateLimiter below
+// splits that budget fairly between authenticated callers.
 type RateLimiter struct {
 	limiter *rate.Limiter
 }
@@ -53,3 +52,50 @@ func retryAfterSeconds(lim rate.Limit) int {
 	}
 	return int(math.Ceil(sec))
 }
+
+// CallerRateLimiter gives every authenticated caller its own token bucket,
+// so one API key or peer exhausting its budget does not starve the others.
+// It must sit behind APIKeyAuth or PeerIdentityMiddleware; requests without
+// a Caller on the context share a single bucket. Callers come from a fixed
+// key list or peer allowlist, so the bucket map stays small.
+type CallerRateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewCallerRateLimiter constructs a limiter whose per-caller buckets refill
+// r tokens/second and allow bursts up to burst tokens.
+func NewCallerRateLimiter(r rate.Limit, burst int) *CallerRateLimiter {
+	return &CallerRateLimiter{limit: r, burst: burst, limiters: make(map[string]*rate.Limiter)}
+}
+
+// Middleware returns an http.Handler wrapper that rejects requests once the
+// caller's bucket is empty.
+func (rl *CallerRateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, _ := CallerFromContext(r.Context())
+		if !rl.limiterFor(caller).Allow() {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(rl.limit)))
+			logger.Logger.WarnContext(r.Context(), "caller rate limit exceeded",
+				"path", r.URL.Path, "caller", caller.ID, "caller_kind", caller.Kind)
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (rl *CallerRateLimiter) limiterFor(c Caller) *rate.Limiter {
+	key := c.Kind + ":" + c.ID
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	lim, ok := rl.limiters[key]
+	if !ok {
+		lim = rate.NewLimiter(rl.limit, rl.burst)
+		rl.limiters[key] = lim
+	}
+	return lim
+}
@@ -74,3 +74,30 @@ func TestRateLimit_SetsRetryAfterHeader(t *testing.T) {
 
 // Make go vet happy about unused imports on non-test builds.
 var _ = time.Second
+
+func TestCallerRateLimit_SeparateBucketPerCaller(t *testing.T) {
+	t.Parallel()
+	rl := NewCallerRateLimiter(rate.Limit(1), 1)
+	handler := rl.Middleware(newTestHandler())
+
+	serve := func(c Caller) int {
+		req := httptest.NewRequest(http.MethodGet, "/v1/search", nil)
+		req = req.WithContext(WithCaller(req.Context(), c))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	rag := Caller{ID: "rag-orchestrator", Kind: CallerKindAPIKey}
+	acolyte := Caller{ID: "acolyte", Kind: CallerKindAPIKey}
+
+	if got := serve(rag); got != http.StatusOK {
+		t.Fatalf("first rag request: got %d, want 200", got)
+	}
+	if got := serve(rag); got != http.StatusTooManyRequests {
+		t.Fatalf("second rag request: got %d, want 429", got)
+	}
+	if got := serve(acolyte); got != http.StatusOK {
+		t.Fatalf("acolyte request after rag exhausted its bucket: got %d, want 200", got)
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"search-indexer/logger"
+)
+
+// SearchAudit writes one "search audit" record per /v1/search request with
+// the authenticated caller, the query and its filters, the response status
+// and latency. Unlike RequestLogger it deliberately records the query
+// string: the audit trail has to show who searched for what. It must sit
+// behind APIKeyAuth or PeerIdentityMiddleware so the caller is known.
+func SearchAudit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := newStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		caller, _ := CallerFromContext(r.Context())
+		q := r.URL.Query()
+		logger.Logger.InfoContext(r.Context(), "search audit",
+			"caller", caller.ID,
+			"caller_kind", caller.Kind,
+			"query", q.Get("q"),
+			"user_id", q.Get("user_id"),
+			"limit", q.Get("limit"),
+			"published_after", q.Get("published_after"),
+			"published_before", q.Get("published_before"),
+			"status", rec.status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"remote_addr", r.RemoteAddr,
+		)
+	})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search-indexer/logger"
+)
+
+func TestSearchAudit_LogsCallerAndQuery(t *testing.T) {
+	var buf bytes.Buffer
+	prev := logger.Logger
+	logger.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	t.Cleanup(func() { logger.Logger = prev })
+
+	h := SearchAudit(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=golang+generics&user_id=u-1&limit=5", nil)
+	req = req.WithContext(WithCaller(req.Context(), Caller{ID: "rag-orchestrator", Kind: CallerKindAPIKey}))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decode audit log %q: %v", buf.String(), err)
+	}
+	for key, want := range map[string]any{
+		"msg":         "search audit",
+		"caller":      "rag-orchestrator",
+		"caller_kind": CallerKindAPIKey,
+		"query":       "golang generics",
+		"user_id":     "u-1",
+		"limit":       "5",
+		"status":      float64(http.StatusTeapot),
+	} {
+		if entry[key] != want {
+			t.Errorf("%s: got %v, want %v", key, entry[key], want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// FeedPopularity supplies the per-feed popularity signal stamped onto search
+// documents at index time and used by the "feed_popularity:desc" custom
+// ranking rule. Feeds missing from the returned map have popularity 0.
+type FeedPopularity interface {
+	FeedPopularity(ctx context.Context, feedIDs []string) (map[string]int64, error)
+}
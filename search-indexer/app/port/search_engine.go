@@ -15,6 +15,11 @@ type SearchEngine interface {
 	// ``published_at`` falls inside the supplied window. Either bound may
 	// be nil; when both are nil the engine should behave like Search.
 	SearchWithDateFilter(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, limit int) ([]domain.SearchDocument, error)
+	// SearchWithPagination is the unfiltered search with an offset and the
+	// engine's estimated total, optionally restricted to a published_at
+	// window like SearchWithDateFilter. It backs cursor pagination and
+	// export on /v1/search.
+	SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error)
 	SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]domain.SearchDocument, error)
 	SearchByUserIDWithPagination(ctx context.Context, query string, userID string, offset, limit int64) ([]domain.SearchDocument, int64, error)
 	EnsureIndex(ctx context.Context) error
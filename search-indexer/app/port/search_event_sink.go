@@ -0,0 +1,12 @@
+package port
+
+import (
+	"search-indexer/domain"
+)
+
+// SearchEventSink receives executed searches for analytics. Record is on
+// the search hot path, so implementations must not block: they buffer the
+// event and write it asynchronously, dropping it when they cannot keep up.
+type SearchEventSink interface {
+	Record(event domain.SearchEvent)
+}
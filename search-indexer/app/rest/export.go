@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"search-indexer/domain"
+	"search-indexer/logger"
+	appOtel "search-indexer/utils/otel"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// exportMaxDuration bounds a whole export run.
+	exportMaxDuration = 10 * time.Minute
+	// exportWriteWindow is how long each streamed page may take to reach
+	// the caller; the deadline moves forward with every page so the
+	// server's WriteTimeout does not cut a long export short.
+	exportWriteWindow = 30 * time.Second
+)
+
+// ExportSummary is the last line of an export stream. A stream that ends
+// without it was cut short.
+type ExportSummary struct {
+	Done      bool `json:"done"`
+	Exported  int  `json:"exported"`
+	Truncated bool `json:"truncated"`
+}
+
+// ExportArticles handles GET /v1/search/export, the exhaustive mode of
+// /v1/search for internal callers. It takes the same q and
+// “published_after“ / “published_before“ parameters, has no user_id
+// scope and no result cap, and streams every match as newline-delimited
+// JSON: one SearchArticlesHit per line, then an ExportSummary. Errors after
+// the first line can no longer change the status, so they end the stream
+// without a summary.
+func (h *Handler) ExportArticles(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "query parameter required", http.StatusBadRequest)
+		return
+	}
+	if r.URL.Query().Get("user_id") != "" {
+		http.Error(w, "user_id is not supported by export", http.StatusBadRequest)
+		return
+	}
+	publishedAfter, err := parseOptionalRFC3339(r.URL.Query().Get("published_after"))
+	if err != nil {
+		http.Error(w, "invalid published_after (expected RFC3339)", http.StatusBadRequest)
+		return
+	}
+	publishedBefore, err := parseOptionalRFC3339(r.URL.Query().Get("published_before"))
+	if err != nil {
+		http.Error(w, "invalid published_before (expected RFC3339)", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), exportMaxDuration)
+	defer cancel()
+	start := time.Now()
+
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	started := false
+	emit := func(docs []domain.SearchDocument) error {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		_ = rc.SetWriteDeadline(time.Now().Add(exportWriteWindow))
+		for _, doc := range docs {
+			if err := enc.Encode(toSearchArticlesHit(doc)); err != nil {
+				return err
+			}
+		}
+		return rc.Flush()
+	}
+
+	result, err := h.searchArticlesUsecase.ExportArticles(ctx, query, publishedAfter, publishedBefore, emit)
+	if err != nil {
+		logger.Logger.ErrorContext(ctx, "search export failed", "err", err, "query_hash", logger.HashQuery(query), "started", started)
+		if m := appOtel.Metrics; m != nil {
+			m.ErrorsTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "search_export")))
+		}
+		if !started {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if !started {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = rc.SetWriteDeadline(time.Now().Add(exportWriteWindow))
+	if err := enc.Encode(ExportSummary{Done: true, Exported: result.Exported, Truncated: result.Truncated}); err != nil {
+		logger.Logger.ErrorContext(ctx, "encode failed", "err", err)
+	}
+
+	logger.Logger.InfoContext(ctx, "search export ok",
+		"query_hash", logger.HashQuery(query),
+		"exported", result.Exported,
+		"truncated", result.Truncated,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -37,12 +38,20 @@ type SearchArticlesHit struct {
 	Score       float64  `json:"score"`
 	Language    string   `json:"language,omitempty"`
 	PublishedAt string   `json:"published_at,omitempty"`
+	FeedTitle   string   `json:"feed_title,omitempty"`
+	Author      string   `json:"author,omitempty"`
 }
 
 type SearchArticlesResponse struct {
 	Query string              `json:"query"`
 	Hits  []SearchArticlesHit `json:"hits"`
 	Total int                 `json:"total"`
+	// EstimatedTotalHits is the engine's estimate of all matches, not just
+	// this page.
+	EstimatedTotalHits int64 `json:"estimated_total_hits"`
+	// NextCursor fetches the following page when passed back as cursor;
+	// it is omitted on the last page served.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // SearchArticles handles GET /v1/search requests.
@@ -51,6 +60,12 @@ type SearchArticlesResponse struct {
 // Optional “published_after“ / “published_before“ RFC3339 parameters
 // restrict results to a date window. Both bounds apply to the “published_at“
 // attribute on indexed documents.
+// Optional “boost_feed_ids“ (the caller's subscribed feeds) and
+// “boost_tags“ (recently read topics), both comma-separated, rerank the
+// returned hits in the caller's favor; see usecase.Personalize.
+// Responses carry an opaque next_cursor; passing it back as “cursor“ with
+// the same q, user_id and date window returns the next page. Pages stop at
+// domain.MaxSearchOffset; a cursor past it is rejected with 400.
 func (h *Handler) SearchArticles(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	start := time.Now()
@@ -75,8 +90,29 @@ func (h *Handler) SearchArticles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	queryHash := domain.SearchQueryHash(query, userID, r.URL.Query().Get("published_after"), r.URL.Query().Get("published_before"))
+	var offset int64
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		cursor, cursorErr := domain.DecodeSearchCursor(raw, queryHash)
+		if cursorErr != nil {
+			http.Error(w, cursorErr.Error(), http.StatusBadRequest)
+			return
+		}
+		offset = cursor.Offset
+	}
+
+	personalization := domain.SearchPersonalization{
+		FeedIDs: domain.ParsePersonalizationList(r.URL.Query().Get("boost_feed_ids")),
+		Tags:    domain.ParsePersonalizationList(r.URL.Query().Get("boost_tags")),
+	}
+	if err := personalization.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var docs []domain.SearchDocument
 	var searchQuery string
+	var total int64
 
 	if userID == "" {
 		// Unfiltered search for internal RAG/BM25 callers
@@ -86,22 +122,13 @@ func (h *Handler) SearchArticles(w http.ResponseWriter, r *http.Request) {
 				limit = l
 			}
 		}
-		if publishedAfter != nil || publishedBefore != nil {
-			result, dfErr := h.searchArticlesUsecase.ExecuteWithDateFilter(ctx, query, publishedAfter, publishedBefore, limit)
-			if dfErr != nil {
-				err = dfErr
-			} else {
-				docs = result.Documents
-				searchQuery = result.Query
-			}
+		result, execErr := h.searchArticlesUsecase.ExecutePage(ctx, query, publishedAfter, publishedBefore, offset, limit)
+		if execErr != nil {
+			err = execErr
 		} else {
-			result, execErr := h.searchArticlesUsecase.Execute(ctx, query, limit)
-			if execErr != nil {
-				err = execErr
-			} else {
-				docs = result.Documents
-				searchQuery = result.Query
-			}
+			docs = result.Documents
+			searchQuery = result.Query
+			total = result.EstimatedTotalHits
 		}
 	} else {
 		// User-scoped search. published_after/published_before have no
@@ -119,15 +146,21 @@ func (h *Handler) SearchArticles(w http.ResponseWriter, r *http.Request) {
 				limit = l
 			}
 		}
-		result, execErr := h.searchByUserUsecase.ExecuteWithPagination(ctx, query, userID, 0, limit)
+		limit = min(limit, domain.MaxSearchOffset-offset)
+		result, execErr := h.searchByUserUsecase.ExecuteWithPagination(ctx, query, userID, offset, limit)
 		if execErr != nil {
 			err = execErr
 		} else {
 			docs = result.Hits
 			searchQuery = result.Query
+			total = result.EstimatedTotalHits
 		}
 	}
 
+	if errors.Is(err, domain.ErrSearchPageTooDeep) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		logger.Logger.ErrorContext(ctx, "search failed", "err", err, "user_id", userID, "query_hash", logger.HashQuery(query))
 		if m := appOtel.Metrics; m != nil {
@@ -137,37 +170,27 @@ func (h *Handler) SearchArticles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	docs = usecase.Personalize(docs, personalization)
+
 	if m := appOtel.Metrics; m != nil {
 		m.SearchDuration.Record(ctx, time.Since(start).Seconds())
 	}
 
 	resp := SearchArticlesResponse{
-		Query: searchQuery,
-		Hits:  make([]SearchArticlesHit, 0, len(docs)),
-		Total: len(docs),
+		Query:              searchQuery,
+		Hits:               make([]SearchArticlesHit, 0, len(docs)),
+		Total:              len(docs),
+		EstimatedTotalHits: total,
+	}
+	if next, ok := domain.NextSearchOffset(offset, len(docs), total); ok {
+		resp.NextCursor = domain.SearchCursor{Offset: next, QueryHash: queryHash}.Encode()
 	}
 
 	for _, doc := range docs {
-		tags := doc.Tags
-		if tags == nil {
-			tags = []string{}
-		}
-		publishedAt := ""
-		if !doc.PublishedAt.IsZero() {
-			publishedAt = doc.PublishedAt.UTC().Format(time.RFC3339)
-		}
-		resp.Hits = append(resp.Hits, SearchArticlesHit{
-			ID:          doc.ID,
-			Title:       doc.Title,
-			Content:     doc.Content,
-			Tags:        tags,
-			Score:       doc.Score,
-			Language:    doc.Language,
-			PublishedAt: publishedAt,
-		})
+		resp.Hits = append(resp.Hits, toSearchArticlesHit(doc))
 	}
 
-	logger.Logger.InfoContext(ctx, "search ok", "query_hash", logger.HashQuery(query), "user_id", userID, "count", len(resp.Hits))
+	logger.Logger.InfoContext(ctx, "search ok", "query_hash", logger.HashQuery(query), "user_id", userID, "count", len(resp.Hits), "personalized", !personalization.IsEmpty())
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
@@ -176,6 +199,30 @@ func (h *Handler) SearchArticles(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// toSearchArticlesHit renders a document the way /v1/search and the export
+// stream return it.
+func toSearchArticlesHit(doc domain.SearchDocument) SearchArticlesHit {
+	tags := doc.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	publishedAt := ""
+	if !doc.PublishedAt.IsZero() {
+		publishedAt = doc.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	return SearchArticlesHit{
+		ID:          doc.ID,
+		Title:       doc.Title,
+		Content:     doc.Content,
+		Tags:        tags,
+		Score:       doc.Score,
+		Language:    doc.Language,
+		PublishedAt: publishedAt,
+		FeedTitle:   doc.FeedTitle,
+		Author:      doc.Author,
+	}
+}
+
 // parseOptionalRFC3339 returns a *time.Time when the raw value is non-empty,
 // a parse error when it is invalid, and (nil, nil) when the caller omitted it.
 func parseOptionalRFC3339(raw string) (*time.Time, error) {
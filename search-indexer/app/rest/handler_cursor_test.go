@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"search-indexer/domain"
+	"search-indexer/usecase"
+)
+
+// pagedSearchEngine serves a fixed number of matches page by page and
+// records the offsets it was asked for.
+type pagedSearchEngine struct {
+	mockSearchEngine
+	total   int
+	offsets []int64
+}
+
+func (m *pagedSearchEngine) page(offset, limit int64) []domain.SearchDocument {
+	var docs []domain.SearchDocument
+	for i := offset; i < offset+limit && i < int64(m.total) && i < domain.MaxSearchOffset; i++ {
+		docs = append(docs, domain.SearchDocument{ID: fmt.Sprintf("doc-%d", i), Tags: []string{}})
+	}
+	return docs
+}
+
+func (m *pagedSearchEngine) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	m.offsets = append(m.offsets, offset)
+	return m.page(offset, limit), int64(m.total), nil
+}
+
+func (m *pagedSearchEngine) SearchByUserIDWithPagination(ctx context.Context, query string, userID string, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	m.offsets = append(m.offsets, offset)
+	return m.page(offset, limit), int64(m.total), nil
+}
+
+func searchPage(t *testing.T, handler *Handler, params url.Values) (*httptest.ResponseRecorder, SearchArticlesResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.SearchArticles(rec, httptest.NewRequest(http.MethodGet, "/v1/search?"+params.Encode(), nil))
+	var resp SearchArticlesResponse
+	if rec.Code == http.StatusOK {
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return rec, resp
+}
+
+func TestHandler_SearchArticles_CursorWalksAllPages(t *testing.T) {
+	for _, userID := range []string{"", "u1"} {
+		t.Run("user_id="+userID, func(t *testing.T) {
+			engine := &pagedSearchEngine{total: 45}
+			handler := NewHandler(usecase.NewSearchByUserUsecase(engine), usecase.NewSearchArticlesUsecase(engine))
+
+			params := url.Values{"q": {"iran"}, "limit": {"20"}}
+			if userID != "" {
+				params.Set("user_id", userID)
+			}
+			var ids []string
+			for page := 0; ; page++ {
+				rec, resp := searchPage(t, handler, params)
+				if rec.Code != http.StatusOK {
+					t.Fatalf("page %d: status = %d, body = %s", page, rec.Code, rec.Body.String())
+				}
+				if resp.EstimatedTotalHits != 45 {
+					t.Errorf("EstimatedTotalHits = %d, want 45", resp.EstimatedTotalHits)
+				}
+				for _, hit := range resp.Hits {
+					ids = append(ids, hit.ID)
+				}
+				if resp.NextCursor == "" {
+					break
+				}
+				params.Set("cursor", resp.NextCursor)
+			}
+
+			if len(ids) != 45 || ids[44] != "doc-44" {
+				t.Fatalf("walked %d hits: %v", len(ids), ids)
+			}
+			if fmt.Sprint(engine.offsets) != "[0 20 40]" {
+				t.Errorf("offsets = %v, want [0 20 40]", engine.offsets)
+			}
+		})
+	}
+}
+
+func TestHandler_SearchArticles_CursorStopsAtMaxOffset(t *testing.T) {
+	engine := &pagedSearchEngine{total: 5000}
+	handler := NewHandler(usecase.NewSearchByUserUsecase(engine), usecase.NewSearchArticlesUsecase(engine))
+	hash := domain.SearchQueryHash("iran", "", "", "")
+
+	last := domain.SearchCursor{Offset: domain.MaxSearchOffset - 10, QueryHash: hash}.Encode()
+	rec, resp := searchPage(t, handler, url.Values{"q": {"iran"}, "limit": {"50"}, "cursor": {last}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if len(resp.Hits) != 10 || resp.NextCursor != "" {
+		t.Errorf("hits = %d, next_cursor = %q; want the 10 hits before the cap and no cursor", len(resp.Hits), resp.NextCursor)
+	}
+
+	deep := domain.SearchCursor{Offset: domain.MaxSearchOffset, QueryHash: hash}.Encode()
+	rec, _ = searchPage(t, handler, url.Values{"q": {"iran"}, "cursor": {deep}})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("deep cursor: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandler_SearchArticles_RejectsCursorFromAnotherQuery(t *testing.T) {
+	engine := &pagedSearchEngine{total: 100}
+	handler := NewHandler(usecase.NewSearchByUserUsecase(engine), usecase.NewSearchArticlesUsecase(engine))
+
+	_, first := searchPage(t, handler, url.Values{"q": {"iran"}})
+	if first.NextCursor == "" {
+		t.Fatal("expected a next_cursor on the first page")
+	}
+
+	for name, params := range map[string]url.Values{
+		"other query":  {"q": {"oil"}, "cursor": {first.NextCursor}},
+		"other window": {"q": {"iran"}, "published_after": {"2026-04-12T00:00:00Z"}, "cursor": {first.NextCursor}},
+		"garbage":      {"q": {"iran"}, "cursor": {"not-a-cursor"}},
+	} {
+		if rec, _ := searchPage(t, handler, params); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", name, rec.Code)
+		}
+	}
+}
+
+func TestHandler_ExportArticles_StreamsNDJSON(t *testing.T) {
+	engine := &pagedSearchEngine{total: 620}
+	handler := NewHandler(usecase.NewSearchByUserUsecase(engine), usecase.NewSearchArticlesUsecase(engine))
+
+	rec := httptest.NewRecorder()
+	handler.ExportArticles(rec, httptest.NewRequest(http.MethodGet, "/v1/search/export?q=iran", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	scanner := bufio.NewScanner(rec.Body)
+	var hits int
+	var summary ExportSummary
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("bad line %q: %v", scanner.Text(), err)
+		}
+		if _, ok := line["done"]; ok {
+			_ = json.Unmarshal(scanner.Bytes(), &summary)
+			continue
+		}
+		hits++
+	}
+	if hits != 620 || !summary.Done || summary.Exported != 620 || summary.Truncated {
+		t.Errorf("hits = %d, summary = %+v", hits, summary)
+	}
+}
+
+func TestHandler_ExportArticles_RejectsUserScope(t *testing.T) {
+	handler := NewHandler(usecase.NewSearchByUserUsecase(&mockSearchEngine{}), usecase.NewSearchArticlesUsecase(&mockSearchEngine{}))
+
+	for _, target := range []string{"/v1/search/export", "/v1/search/export?q=iran&user_id=u1"} {
+		rec := httptest.NewRecorder()
+		handler.ExportArticles(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", target, rec.Code)
+		}
+	}
+}
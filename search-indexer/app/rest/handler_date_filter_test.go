@@ -22,18 +22,19 @@ type mockDateFilterEngine struct {
 	dateFilterErr      error
 }
 
-func (m *mockDateFilterEngine) SearchWithDateFilter(
+func (m *mockDateFilterEngine) SearchWithPagination(
 	ctx context.Context,
 	query string,
 	publishedAfter, publishedBefore *time.Time,
-	limit int,
-) ([]domain.SearchDocument, error) {
+	offset, limit int64,
+) ([]domain.SearchDocument, int64, error) {
 	_ = ctx
 	_ = query
+	_ = offset
 	_ = limit
 	m.gotPublishedAfter = publishedAfter
 	m.gotPublishedBefore = publishedBefore
-	return m.dateFilterResults, m.dateFilterErr
+	return m.dateFilterResults, int64(len(m.dateFilterResults)), m.dateFilterErr
 }
 
 func TestHandler_SearchArticles_ForwardsPublishedAfterParam(t *testing.T) {
@@ -58,7 +59,7 @@ func TestHandler_SearchArticles_ForwardsPublishedAfterParam(t *testing.T) {
 		t.Fatalf("status = %d, want 200; body=%s", rec.Code, rec.Body.String())
 	}
 	if engine.gotPublishedAfter == nil {
-		t.Fatalf("expected SearchWithDateFilter to be called with published_after")
+		t.Fatalf("expected the date window to reach the engine with published_after")
 	}
 	want := time.Date(2026, 4, 12, 0, 0, 0, 0, time.UTC)
 	if !engine.gotPublishedAfter.Equal(want) {
@@ -84,7 +85,7 @@ func TestHandler_SearchArticles_ForwardsPublishedBeforeParam(t *testing.T) {
 		t.Fatalf("status = %d, want 200; body=%s", rec.Code, rec.Body.String())
 	}
 	if engine.gotPublishedBefore == nil {
-		t.Fatalf("expected SearchWithDateFilter to be called with published_before")
+		t.Fatalf("expected the date window to reach the engine with published_before")
 	}
 }
 
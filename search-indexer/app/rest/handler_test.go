@@ -10,6 +10,8 @@ import (
 	"search-indexer/domain"
 	"search-indexer/logger"
 	"search-indexer/usecase"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -40,6 +42,9 @@ func (m *mockSearchEngine) SearchWithFilters(ctx context.Context, query string,
 func (m *mockSearchEngine) SearchWithDateFilter(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, limit int) ([]domain.SearchDocument, error) {
 	return nil, nil
 }
+func (m *mockSearchEngine) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	return m.searchResult, int64(len(m.searchResult)), m.searchErr
+}
 func (m *mockSearchEngine) SearchByUserID(ctx context.Context, query string, userID string, limit int) ([]domain.SearchDocument, error) {
 	return m.searchByUserIDResult, m.searchByUserIDErr
 }
@@ -207,3 +212,50 @@ func TestHandler_SearchArticles_ResponseHasTotal(t *testing.T) {
 		t.Fatalf("Total = %d, want %d", resp.Total, len(results))
 	}
 }
+
+func TestHandler_SearchArticles_Personalization(t *testing.T) {
+	results := []domain.SearchDocument{
+		{ID: "1", Title: "a", FeedID: "feed-x", Tags: []string{}, Score: 0.8},
+		{ID: "2", Title: "b", FeedID: "feed-sub", Tags: []string{}, Score: 0.75},
+	}
+	mock := &mockSearchEngine{searchByUserIDResult: results}
+	handler := NewHandler(
+		usecase.NewSearchByUserUsecase(mock),
+		usecase.NewSearchArticlesUsecase(mock),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=test&user_id=u1&boost_feed_ids=feed-sub", nil)
+	rec := httptest.NewRecorder()
+	handler.SearchArticles(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var resp SearchArticlesResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Hits) != 2 || resp.Hits[0].ID != "2" {
+		t.Errorf("hits = %+v, want subscribed-feed hit first", resp.Hits)
+	}
+}
+
+func TestHandler_SearchArticles_PersonalizationTooManyFeeds(t *testing.T) {
+	mock := &mockSearchEngine{}
+	handler := NewHandler(
+		usecase.NewSearchByUserUsecase(mock),
+		usecase.NewSearchArticlesUsecase(mock),
+	)
+
+	feeds := make([]string, domain.MaxPersonalizationFeedIDs+1)
+	for i := range feeds {
+		feeds[i] = "f" + strconv.Itoa(i)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=test&user_id=u1&boost_feed_ids="+strings.Join(feeds, ","), nil)
+	rec := httptest.NewRecorder()
+	handler.SearchArticles(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
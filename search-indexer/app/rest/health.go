@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"search-indexer/logger"
+)
+
+// ReadinessCheck probes one dependency the service needs to do useful work.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthHandler serves liveness and readiness. Liveness (/health) only says
+// the process is serving HTTP, so the container healthcheck does not restart
+// search-indexer while Meilisearch is briefly down. Readiness (/ready) runs
+// every check and reports 503 when any fails or once shutdown has started
+// draining, so load balancers stop routing new searches here first. While a
+// startup warm-up is running, /ready reports 503 "warming_up" so the first
+// user queries after a deploy land on an instance with hot caches.
+type HealthHandler struct {
+	checks   []ReadinessCheck
+	timeout  time.Duration
+	draining atomic.Bool
+	warmup   atomic.Pointer[WarmupReport]
+}
+
+// NewHealthHandler creates a HealthHandler. Each readiness check gets at
+// most timeout to respond.
+func NewHealthHandler(timeout time.Duration, checks ...ReadinessCheck) *HealthHandler {
+	return &HealthHandler{checks: checks, timeout: timeout}
+}
+
+// SetDraining flips readiness to 503 for the rest of the process lifetime.
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
+
+// WarmupStatusRunning and WarmupStatusDone are the WarmupReport.Status values.
+const (
+	WarmupStatusRunning = "running"
+	WarmupStatusDone    = "done"
+)
+
+// WarmupReport summarizes the startup warm-up: the configured queries run to
+// pre-fill the search cache and the index stats prefetched afterwards.
+type WarmupReport struct {
+	Status        string `json:"status"`
+	Queries       int    `json:"queries"`
+	QueriesOK     int    `json:"queries_ok"`
+	QueriesFailed int    `json:"queries_failed"`
+	// IndexDocuments is nil when the index stats prefetch failed.
+	IndexDocuments  *int64 `json:"index_documents,omitempty"`
+	IndexIsIndexing bool   `json:"index_is_indexing"`
+	ElapsedMs       int64  `json:"elapsed_ms"`
+}
+
+// StartWarmup holds readiness at 503 until FinishWarmup is called.
+func (h *HealthHandler) StartWarmup(queries int) {
+	h.warmup.Store(&WarmupReport{Status: WarmupStatusRunning, Queries: queries})
+}
+
+// FinishWarmup records the warm-up result and releases readiness. A warm-up
+// with failed queries still releases it: warm-up only saves latency and must
+// never keep the service out of rotation.
+func (h *HealthHandler) FinishWarmup(report WarmupReport) {
+	report.Status = WarmupStatusDone
+	h.warmup.Store(&report)
+}
+
+// ReadinessResponse is the body of GET /ready.
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+	Warmup *WarmupReport     `json:"warmup,omitempty"`
+}
+
+// Live handles GET /health.
+func (h *HealthHandler) Live(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Ready handles GET /ready.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, ReadinessResponse{Status: "draining"})
+		return
+	}
+	warmup := h.warmup.Load()
+	if warmup != nil && warmup.Status == WarmupStatusRunning {
+		writeJSON(w, http.StatusServiceUnavailable, ReadinessResponse{Status: "warming_up", Warmup: warmup})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	results := make([]error, len(h.checks))
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.Check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	resp := ReadinessResponse{Status: "ready", Checks: make(map[string]string, len(h.checks)), Warmup: warmup}
+	code := http.StatusOK
+	for i, c := range h.checks {
+		if err := results[i]; err != nil {
+			logger.Logger.WarnContext(r.Context(), "readiness check failed", "check", c.Name, "err", err)
+			resp.Checks[c.Name] = "unavailable"
+			resp.Status = "not_ready"
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[c.Name] = "ok"
+	}
+	writeJSON(w, code, resp)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
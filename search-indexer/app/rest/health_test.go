@@ -0,0 +1,145 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func okCheck(name string) ReadinessCheck {
+	return ReadinessCheck{Name: name, Check: func(context.Context) error { return nil }}
+}
+
+func decodeReadiness(t *testing.T, rec *httptest.ResponseRecorder) ReadinessResponse {
+	t.Helper()
+	var resp ReadinessResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode readiness body: %v", err)
+	}
+	return resp
+}
+
+func TestReady_AllChecksPass(t *testing.T) {
+	h := NewHealthHandler(time.Second, okCheck("meilisearch"), okCheck("article_db"))
+
+	rec := httptest.NewRecorder()
+	h.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", rec.Code)
+	}
+	resp := decodeReadiness(t, rec)
+	if resp.Status != "ready" || resp.Checks["meilisearch"] != "ok" || resp.Checks["article_db"] != "ok" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestReady_FailingCheckReturns503(t *testing.T) {
+	h := NewHealthHandler(time.Second,
+		okCheck("meilisearch"),
+		ReadinessCheck{Name: "article_db", Check: func(context.Context) error { return errors.New("connection refused") }},
+	)
+
+	rec := httptest.NewRecorder()
+	h.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want 503", rec.Code)
+	}
+	resp := decodeReadiness(t, rec)
+	if resp.Status != "not_ready" || resp.Checks["article_db"] != "unavailable" || resp.Checks["meilisearch"] != "ok" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestReady_SlowCheckIsBoundedByTimeout(t *testing.T) {
+	h := NewHealthHandler(20*time.Millisecond, ReadinessCheck{Name: "meilisearch", Check: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	h.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want 503", rec.Code)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("readiness took %v, want it bounded by the check timeout", elapsed)
+	}
+}
+
+func TestReady_DrainingSkipsChecks(t *testing.T) {
+	called := false
+	h := NewHealthHandler(time.Second, ReadinessCheck{Name: "meilisearch", Check: func(context.Context) error {
+		called = true
+		return nil
+	}})
+	h.SetDraining()
+
+	rec := httptest.NewRecorder()
+	h.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want 503", rec.Code)
+	}
+	if resp := decodeReadiness(t, rec); resp.Status != "draining" {
+		t.Fatalf("got status %q, want draining", resp.Status)
+	}
+	if called {
+		t.Fatal("readiness checks ran while draining")
+	}
+}
+
+func TestLive_IgnoresDependencies(t *testing.T) {
+	h := NewHealthHandler(time.Second, ReadinessCheck{Name: "meilisearch", Check: func(context.Context) error {
+		return errors.New("down")
+	}})
+	h.SetDraining()
+
+	rec := httptest.NewRecorder()
+	h.Live(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", rec.Code)
+	}
+}
+
+func TestReady_WarmingUpReturns503(t *testing.T) {
+	h := NewHealthHandler(time.Second, okCheck("meilisearch"))
+	h.StartWarmup(3)
+
+	rec := httptest.NewRecorder()
+	h.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d, want 503", rec.Code)
+	}
+	resp := decodeReadiness(t, rec)
+	if resp.Status != "warming_up" || resp.Warmup == nil || resp.Warmup.Queries != 3 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestReady_ReportsFinishedWarmup(t *testing.T) {
+	h := NewHealthHandler(time.Second, okCheck("meilisearch"))
+	h.StartWarmup(2)
+	docs := int64(1200)
+	h.FinishWarmup(WarmupReport{Queries: 2, QueriesOK: 1, QueriesFailed: 1, IndexDocuments: &docs})
+
+	rec := httptest.NewRecorder()
+	h.Ready(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200 even with a failed warm-up query", rec.Code)
+	}
+	resp := decodeReadiness(t, rec)
+	if resp.Warmup == nil || resp.Warmup.Status != WarmupStatusDone || resp.Warmup.QueriesFailed != 1 || *resp.Warmup.IndexDocuments != 1200 {
+		t.Fatalf("unexpected warmup report %+v", resp.Warmup)
+	}
+}
@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"net/http"
+
+	"search-indexer/usecase"
+)
+
+// DocumentSizeStatsProvider reports what the per-document size limits have
+// done since startup.
+type DocumentSizeStatsProvider interface {
+	DocumentSizeStats() usecase.DocumentSizeStats
+}
+
+// IndexStatsHandler serves GET /v1/index/stats.
+type IndexStatsHandler struct {
+	documents        DocumentSizeStatsProvider
+	searchableFields []string
+}
+
+// NewIndexStatsHandler creates an IndexStatsHandler. searchableFields is the
+// effective searchable attribute list EnsureIndex applied.
+func NewIndexStatsHandler(documents DocumentSizeStatsProvider, searchableFields []string) *IndexStatsHandler {
+	return &IndexStatsHandler{documents: documents, searchableFields: searchableFields}
+}
+
+// IndexStatsResponse is the body of GET /v1/index/stats.
+type IndexStatsResponse struct {
+	SearchableFields []string                  `json:"searchable_fields"`
+	Documents        usecase.DocumentSizeStats `json:"documents"`
+}
+
+// Stats handles GET /v1/index/stats. Counters are per process and reset on
+// restart.
+func (h *IndexStatsHandler) Stats(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, IndexStatsResponse{
+		SearchableFields: h.searchableFields,
+		Documents:        h.documents.DocumentSizeStats(),
+	})
+}
@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search-indexer/usecase"
+)
+
+type fakeDocumentStats usecase.DocumentSizeStats
+
+func (f fakeDocumentStats) DocumentSizeStats() usecase.DocumentSizeStats {
+	return usecase.DocumentSizeStats(f)
+}
+
+func TestIndexStats_ReportsLimitsAndCounters(t *testing.T) {
+	h := NewIndexStatsHandler(fakeDocumentStats{MaxContentBytes: 32768, Indexed: 10, Rejected: 2}, []string{"title", "tags"})
+
+	rec := httptest.NewRecorder()
+	h.Stats(rec, httptest.NewRequest(http.MethodGet, "/v1/index/stats", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200", rec.Code)
+	}
+	var resp IndexStatsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !slices.Equal(resp.SearchableFields, []string{"title", "tags"}) {
+		t.Errorf("searchable_fields = %v", resp.SearchableFields)
+	}
+	if resp.Documents.MaxContentBytes != 32768 || resp.Documents.Indexed != 10 || resp.Documents.Rejected != 2 {
+		t.Errorf("documents = %+v", resp.Documents)
+	}
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"search-indexer/domain"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// DocumentLimits bounds what a single article contributes to the index.
+// The zero value disables every limit, which keeps NewIndexArticlesUsecase
+// behaving as it always has; bootstrap installs the env-driven values.
+type DocumentLimits struct {
+	// MaxDocumentBytes rejects a document whose title, content and tags
+	// together exceed it, measured before cleaning or truncation. 0 = off.
+	MaxDocumentBytes int
+	// MaxContentBytes truncates content (at a rune boundary) to at most
+	// this many bytes. 0 = off.
+	MaxContentBytes int
+	// CleanContent replaces content with its boilerplate-stripped form
+	// before truncation, so the byte budget is spent on the article body
+	// rather than share buttons and cookie notices.
+	CleanContent bool
+}
+
+// DocumentSizeStats is a snapshot of what the limits did since startup.
+type DocumentSizeStats struct {
+	MaxDocumentBytes int  `json:"max_document_bytes"`
+	MaxContentBytes  int  `json:"max_content_bytes"`
+	CleanContent     bool `json:"clean_content"`
+
+	Processed int64 `json:"processed"`
+	Indexed   int64 `json:"indexed"`
+	Truncated int64 `json:"truncated"`
+	Rejected  int64 `json:"rejected"`
+	// BytesIn and BytesOut are content bytes before and after cleaning and
+	// truncation, for indexed documents only.
+	BytesIn  int64 `json:"content_bytes_in"`
+	BytesOut int64 `json:"content_bytes_out"`
+	// LargestRejectedBytes is the biggest document rejected so far.
+	LargestRejectedBytes int64 `json:"largest_rejected_bytes"`
+}
+
+// documentSizeCounters are updated from the index loop and the stream
+// consumer concurrently.
+type documentSizeCounters struct {
+	processed       atomic.Int64
+	indexed         atomic.Int64
+	truncated       atomic.Int64
+	rejected        atomic.Int64
+	bytesIn         atomic.Int64
+	bytesOut        atomic.Int64
+	largestRejected atomic.Int64
+}
+
+// WithDocumentLimits installs the per-document size limits applied before
+// every write to the search engine.
+func (u *IndexArticlesUsecase) WithDocumentLimits(l DocumentLimits) *IndexArticlesUsecase {
+	u.limits = l
+	return u
+}
+
+// DocumentSizeStats returns the configured limits and how often they have
+// fired since startup.
+func (u *IndexArticlesUsecase) DocumentSizeStats() DocumentSizeStats {
+	c := &u.sizeCounters
+	return DocumentSizeStats{
+		MaxDocumentBytes:     u.limits.MaxDocumentBytes,
+		MaxContentBytes:      u.limits.MaxContentBytes,
+		CleanContent:         u.limits.CleanContent,
+		Processed:            c.processed.Load(),
+		Indexed:              c.indexed.Load(),
+		Truncated:            c.truncated.Load(),
+		Rejected:             c.rejected.Load(),
+		BytesIn:              c.bytesIn.Load(),
+		BytesOut:             c.bytesOut.Load(),
+		LargestRejectedBytes: c.largestRejected.Load(),
+	}
+}
+
+// applyDocumentLimits drops oversized documents and returns the rest with
+// their content cleaned and truncated. Rejections are logged by ID and size;
+// the article stays in the database and is simply not searchable.
+func (u *IndexArticlesUsecase) applyDocumentLimits(ctx context.Context, docs []domain.SearchDocument) []domain.SearchDocument {
+	c := &u.sizeCounters
+	kept := make([]domain.SearchDocument, 0, len(docs))
+	for _, doc := range docs {
+		c.processed.Add(1)
+
+		size := documentBytes(doc)
+		if u.limits.MaxDocumentBytes > 0 && size > u.limits.MaxDocumentBytes {
+			c.rejected.Add(1)
+			for {
+				prev := c.largestRejected.Load()
+				if int64(size) <= prev || c.largestRejected.CompareAndSwap(prev, int64(size)) {
+					break
+				}
+			}
+			slog.WarnContext(ctx, "rejecting oversized document",
+				"article_id", doc.ID,
+				"size_bytes", size,
+				"max_document_bytes", u.limits.MaxDocumentBytes,
+			)
+			continue
+		}
+
+		in := len(doc.Content)
+		if u.limits.CleanContent {
+			doc.Content = CleanContent(doc.Content)
+		}
+		if u.limits.MaxContentBytes > 0 && len(doc.Content) > u.limits.MaxContentBytes {
+			doc.Content = truncateUTF8(doc.Content, u.limits.MaxContentBytes)
+			c.truncated.Add(1)
+		}
+		c.indexed.Add(1)
+		c.bytesIn.Add(int64(in))
+		c.bytesOut.Add(int64(len(doc.Content)))
+		kept = append(kept, doc)
+	}
+	return kept
+}
+
+func documentBytes(doc domain.SearchDocument) int {
+	n := len(doc.Title) + len(doc.Content)
+	for _, t := range doc.Tags {
+		n += len(t)
+	}
+	return n
+}
+
+// truncateUTF8 cuts s to at most max bytes without splitting a rune.
+func truncateUTF8(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+var (
+	htmlTagPattern     = regexp.MustCompile(`(?s)<[^>]*>`)
+	urlPattern         = regexp.MustCompile(`https?://\S+`)
+	inlineSpacePattern = regexp.MustCompile(`[ \t\x{3000}]+`)
+
+	// boilerplatePrefixPattern matches short lines that are site chrome
+	// rather than article text. Only lines under boilerplateMaxLineRunes
+	// are dropped so a sentence that merely mentions "subscribe" survives.
+	boilerplatePrefixPattern = regexp.MustCompile(`(?i)^(share (this|on)|follow us|subscribe to|sign up for|read more|related (articles|posts)|we use cookies|all rights reserved|copyright|©|この記事をシェア|関連記事|続きを読む)`)
+	// boilerplateLinePattern matches labels that are only chrome when they
+	// stand alone on a line.
+	boilerplateLinePattern = regexp.MustCompile(`(?i)^(share|tweet|advertisement|sponsored|newsletter|広告|pr|スポンサー)[:：]?$`)
+)
+
+const boilerplateMaxLineRunes = 80
+
+// CleanContent strips markup, bare URLs and boilerplate lines from an
+// article body and collapses whitespace, keeping paragraph breaks.
+func CleanContent(s string) string {
+	s = htmlTagPattern.ReplaceAllString(s, " ")
+	s = urlPattern.ReplaceAllString(s, " ")
+
+	lines := strings.Split(s, "\n")
+	kept := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimSpace(inlineSpacePattern.ReplaceAllString(line, " "))
+		if line == "" {
+			blank = len(kept) > 0
+			continue
+		}
+		if boilerplateLinePattern.MatchString(line) ||
+			(utf8.RuneCountInString(line) <= boilerplateMaxLineRunes && boilerplatePrefixPattern.MatchString(line)) {
+			continue
+		}
+		if blank {
+			kept = append(kept, "")
+			blank = false
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"search-indexer/domain"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestIndexDocumentsDirectly_AppliesDocumentLimits(t *testing.T) {
+	engine := &mockSearchEngineForIndexing{}
+	u := NewIndexArticlesUsecase(&mockArticleRepo{}, engine, nil).
+		WithDocumentLimits(DocumentLimits{MaxDocumentBytes: 1000, MaxContentBytes: 10})
+
+	docs := []domain.SearchDocument{
+		{ID: "small", Title: "t", Content: "short"},
+		{ID: "long", Title: "t", Content: strings.Repeat("a", 50)},
+		{ID: "huge", Title: "t", Content: strings.Repeat("b", 2000)},
+	}
+
+	result, err := u.IndexDocumentsDirectly(context.Background(), docs)
+	if err != nil {
+		t.Fatalf("IndexDocumentsDirectly: %v", err)
+	}
+	if result.IndexedCount != 2 || result.RejectedCount != 1 {
+		t.Fatalf("result = %+v, want 2 indexed and 1 rejected", result)
+	}
+	if len(engine.indexedDocs) != 2 {
+		t.Fatalf("engine received %d docs, want 2", len(engine.indexedDocs))
+	}
+	if got := engine.indexedDocs[1].Content; len(got) != 10 {
+		t.Errorf("long content not truncated to 10 bytes: %q", got)
+	}
+	if docs[1].Content != strings.Repeat("a", 50) {
+		t.Error("caller's document was modified")
+	}
+
+	stats := u.DocumentSizeStats()
+	if stats.Processed != 3 || stats.Indexed != 2 || stats.Truncated != 1 || stats.Rejected != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.LargestRejectedBytes != 2001 {
+		t.Errorf("LargestRejectedBytes = %d, want 2001", stats.LargestRejectedBytes)
+	}
+}
+
+func TestIndexDocumentsDirectly_AllRejectedSkipsEngine(t *testing.T) {
+	engine := &mockSearchEngineForIndexing{}
+	u := NewIndexArticlesUsecase(&mockArticleRepo{}, engine, nil).
+		WithDocumentLimits(DocumentLimits{MaxDocumentBytes: 5})
+
+	result, err := u.IndexDocumentsDirectly(context.Background(), []domain.SearchDocument{{ID: "1", Title: "too long"}})
+	if err != nil {
+		t.Fatalf("IndexDocumentsDirectly: %v", err)
+	}
+	if result.IndexedCount != 0 || result.RejectedCount != 1 {
+		t.Fatalf("result = %+v, want 0 indexed and 1 rejected", result)
+	}
+	if len(engine.indexedDocs) != 0 {
+		t.Errorf("engine received %d docs, want none", len(engine.indexedDocs))
+	}
+}
+
+func TestExecuteBackfill_RejectedBatchIsNotDone(t *testing.T) {
+	now := time.Now()
+	a1, _ := domain.NewArticle("1", "T1", strings.Repeat("x", 100), nil, now, "u")
+	engine := &mockSearchEngineForIndexing{}
+	u := NewIndexArticlesUsecase(&mockArticleRepo{articles: []*domain.Article{a1}}, engine, nil).
+		WithDocumentLimits(DocumentLimits{MaxDocumentBytes: 10})
+
+	result, err := u.ExecuteBackfill(context.Background(), nil, "", 10)
+	if err != nil {
+		t.Fatalf("ExecuteBackfill: %v", err)
+	}
+	if result.BackfillDone {
+		t.Error("a fully rejected batch must not end the backfill")
+	}
+	if result.RejectedCount != 1 || result.LastID != "1" {
+		t.Errorf("result = %+v, want 1 rejected and cursor advanced to 1", result)
+	}
+}
+
+func TestTruncateUTF8_KeepsRunesWhole(t *testing.T) {
+	got := truncateUTF8("日本語テキスト", 7)
+	if got != "日本" {
+		t.Errorf("truncateUTF8 = %q, want %q", got, "日本")
+	}
+	if !utf8.ValidString(got) {
+		t.Error("truncated string is not valid UTF-8")
+	}
+}
+
+func TestCleanContent_StripsBoilerplate(t *testing.T) {
+	in := "<p>Go 1.26 ships   a new GC.</p>\n" +
+		"Share this article\n" +
+		"広告\n" +
+		"\n\n" +
+		"Details at https://go.dev/blog and more.\n" +
+		"We use cookies to improve your experience.\n" +
+		"Readers who subscribe to updates get it first.\n" +
+		"© 2026 Example Media. All rights reserved."
+
+	want := "Go 1.26 ships a new GC.\n" +
+		"\n" +
+		"Details at and more.\n" +
+		"Readers who subscribe to updates get it first."
+
+	if got := CleanContent(in); got != want {
+		t.Errorf("CleanContent =\n%q\nwant\n%q", got, want)
+	}
+}
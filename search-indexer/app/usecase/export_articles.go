@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"search-indexer/domain"
+)
+
+// exportPageSize is the page size ExportArticles requests from the engine.
+const exportPageSize = 500
+
+// ExportResult summarizes an ExportArticles run.
+type ExportResult struct {
+	Query    string
+	Exported int
+	// Truncated is set when a one-second published_at window still held
+	// more matches than the engine serves; the excess was skipped.
+	Truncated bool
+}
+
+// exportWindow is an inclusive published_at range in Unix seconds.
+type exportWindow struct {
+	from, to int64
+}
+
+// ExportArticles streams every match of query to emit, page by page. Offset
+// pagination alone stops at domain.MaxSearchOffset, so when a query has more
+// matches than that the published_at range is bisected until each window
+// fits, and the windows are paged through one after another. Windows do not
+// overlap, so no document is emitted twice unless it is re-indexed with a
+// different published_at mid-export. Order follows windows, newest first,
+// and relevance within a window.
+func (u *SearchArticlesUsecase) ExportArticles(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, emit func([]domain.SearchDocument) error) (*ExportResult, error) {
+	sanitizedQuery, err := validateAndSanitizeQuery(query, exportPageSize)
+	if err != nil {
+		return nil, err
+	}
+	result := &ExportResult{Query: sanitizedQuery}
+
+	// Try the requested range as is first: most queries fit in one window,
+	// and an unbounded search also reaches documents without published_at.
+	total, err := u.exportWindow(ctx, sanitizedQuery, publishedAfter, publishedBefore, result, emit, false)
+	if err != nil || total < domain.MaxSearchOffset {
+		return result, err
+	}
+
+	window := exportWindow{from: 0, to: time.Now().Unix()}
+	if publishedAfter != nil {
+		window.from = publishedAfter.Unix()
+	}
+	if publishedBefore != nil {
+		window.to = publishedBefore.Unix()
+	}
+	var pending []exportWindow
+	switch {
+	case window.from < window.to:
+		pending = splitExportWindow(window)
+	case window.from == window.to:
+		pending = []exportWindow{window}
+	}
+	for len(pending) > 0 {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		w := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		after, before := time.Unix(w.from, 0), time.Unix(w.to, 0)
+		canSplit := w.to > w.from
+		total, err := u.exportWindow(ctx, sanitizedQuery, &after, &before, result, emit, !canSplit)
+		if err != nil {
+			return result, err
+		}
+		if total >= domain.MaxSearchOffset && canSplit {
+			pending = append(pending, splitExportWindow(w)...)
+		}
+	}
+	return result, nil
+}
+
+// exportWindow pages through one published_at window and returns the
+// engine's estimated total for it. When the window holds more than the
+// engine serves and force is false nothing is emitted, so the caller can
+// split it; with force the reachable part is emitted and the export marked
+// truncated.
+func (u *SearchArticlesUsecase) exportWindow(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, result *ExportResult, emit func([]domain.SearchDocument) error, force bool) (int64, error) {
+	var offset int64
+	for {
+		docs, total, err := u.searchEngine.SearchWithPagination(ctx, query, publishedAfter, publishedBefore, offset, exportPageSize)
+		if err != nil {
+			return 0, err
+		}
+		if offset == 0 && total >= domain.MaxSearchOffset {
+			if !force {
+				return total, nil
+			}
+			result.Truncated = true
+			slog.WarnContext(ctx, "export window exceeds the engine's result cap; skipping the excess",
+				"published_after", publishedAfter, "estimated_total", total)
+		}
+		if len(docs) > 0 {
+			if err := emit(docs); err != nil {
+				return total, err
+			}
+			result.Exported += len(docs)
+		}
+		next, ok := domain.NextSearchOffset(offset, len(docs), total)
+		if !ok || len(docs) < exportPageSize {
+			return total, nil
+		}
+		offset = next
+	}
+}
+
+// splitExportWindow halves w. The older half comes first so that, popped
+// from the end of the pending stack, newer windows are exported first.
+func splitExportWindow(w exportWindow) []exportWindow {
+	mid := w.from + (w.to-w.from)/2
+	return []exportWindow{{from: w.from, to: mid}, {from: mid + 1, to: w.to}}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"search-indexer/domain"
+)
+
+// windowedSearchEngine serves SearchWithPagination from an in-memory corpus
+// and, like Meilisearch, returns nothing past domain.MaxSearchOffset.
+type windowedSearchEngine struct {
+	mockSearchEngine
+	docs  []domain.SearchDocument
+	calls int
+}
+
+func (m *windowedSearchEngine) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	m.calls++
+	var matches []domain.SearchDocument
+	for _, doc := range m.docs {
+		if publishedAfter != nil && doc.PublishedAt.Before(*publishedAfter) {
+			continue
+		}
+		if publishedBefore != nil && doc.PublishedAt.After(*publishedBefore) {
+			continue
+		}
+		matches = append(matches, doc)
+	}
+	reachable := matches[:min(len(matches), domain.MaxSearchOffset)]
+	start := min(int(offset), len(reachable))
+	end := min(start+int(limit), len(reachable))
+	return reachable[start:end], int64(len(matches)), nil
+}
+
+func corpus(n int, start time.Time, step time.Duration) []domain.SearchDocument {
+	docs := make([]domain.SearchDocument, n)
+	for i := range docs {
+		docs[i] = domain.SearchDocument{ID: fmt.Sprintf("doc-%d", i), PublishedAt: start.Add(time.Duration(i) * step)}
+	}
+	return docs
+}
+
+func exportAll(t *testing.T, engine *windowedSearchEngine, after, before *time.Time) (*ExportResult, map[string]int) {
+	t.Helper()
+	seen := make(map[string]int)
+	result, err := NewSearchArticlesUsecase(engine).ExportArticles(context.Background(), "iran", after, before, func(docs []domain.SearchDocument) error {
+		for _, doc := range docs {
+			seen[doc.ID]++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExportArticles: %v", err)
+	}
+	return result, seen
+}
+
+func TestExportArticles_SmallResultSetIsOnePass(t *testing.T) {
+	engine := &windowedSearchEngine{docs: corpus(700, time.Unix(1_700_000_000, 0), time.Minute)}
+
+	result, seen := exportAll(t, engine, nil, nil)
+
+	if result.Exported != 700 || len(seen) != 700 || result.Truncated {
+		t.Fatalf("result = %+v, distinct = %d", result, len(seen))
+	}
+	if engine.calls != 2 {
+		t.Errorf("engine calls = %d, want 2 pages", engine.calls)
+	}
+}
+
+func TestExportArticles_SplitsWindowsPastTheCap(t *testing.T) {
+	engine := &windowedSearchEngine{docs: corpus(3500, time.Unix(1_700_000_000, 0), time.Minute)}
+
+	result, seen := exportAll(t, engine, nil, nil)
+
+	if result.Exported != 3500 || len(seen) != 3500 || result.Truncated {
+		t.Fatalf("result = %+v, distinct = %d", result, len(seen))
+	}
+	for id, n := range seen {
+		if n != 1 {
+			t.Fatalf("%s exported %d times", id, n)
+		}
+	}
+}
+
+func TestExportArticles_MarksUnsplittableWindowTruncated(t *testing.T) {
+	// Every document shares one timestamp, so bisecting cannot help.
+	engine := &windowedSearchEngine{docs: corpus(1200, time.Unix(1_700_000_000, 0), 0)}
+	after := time.Unix(1_600_000_000, 0)
+	before := time.Unix(1_800_000_000, 0)
+
+	result, seen := exportAll(t, engine, &after, &before)
+
+	if !result.Truncated {
+		t.Error("expected export to be marked truncated")
+	}
+	if len(seen) != domain.MaxSearchOffset {
+		t.Errorf("distinct = %d, want %d", len(seen), domain.MaxSearchOffset)
+	}
+}
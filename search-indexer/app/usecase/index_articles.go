@@ -27,6 +27,15 @@ type IndexArticlesUsecase struct {
 	searchEngine port.SearchEngine
 	tokenizer    *tokenizer.Tokenizer
 
+	// feedPopularity is optional; when nil every document is indexed with
+	// feed_popularity 0 and the custom ranking rule is a no-op.
+	feedPopularity port.FeedPopularity
+
+	// limits and sizeCounters back WithDocumentLimits; see
+	// applyDocumentLimits.
+	limits       DocumentLimits
+	sizeCounters documentSizeCounters
+
 	// synonymsMu guards synonyms and synonymsDirty. synonyms is the
 	// process-wide union of every synonym map registered so far. Meilisearch's
 	// synonyms PUT is a full replace, not a merge (there is no incremental/
@@ -48,6 +57,7 @@ type IndexArticlesUsecase struct {
 
 type IndexResult struct {
 	IndexedCount    int
+	RejectedCount   int
 	DeletedCount    int
 	LastCreatedAt   *time.Time
 	LastID          string
@@ -65,6 +75,13 @@ func NewIndexArticlesUsecase(articleRepo port.ArticleRepository, searchEngine po
 	}
 }
 
+// WithFeedPopularity installs the source used to stamp feed_popularity onto
+// documents before they are indexed.
+func (u *IndexArticlesUsecase) WithFeedPopularity(p port.FeedPopularity) *IndexArticlesUsecase {
+	u.feedPopularity = p
+	return u
+}
+
 // ExecuteBackfill executes Phase 1: Backfill (past direction)
 func (u *IndexArticlesUsecase) ExecuteBackfill(ctx context.Context, lastCreatedAt *time.Time, lastID string, batchSize int) (*IndexResult, error) {
 	articles, newLastCreatedAt, newLastID, err := u.articleRepo.GetArticlesWithTags(ctx, lastCreatedAt, lastID, batchSize)
@@ -87,14 +104,16 @@ func (u *IndexArticlesUsecase) ExecuteBackfill(ctx context.Context, lastCreatedA
 		docs = append(docs, domain.NewSearchDocument(article))
 	}
 
-	if err := u.searchEngine.IndexDocuments(ctx, docs); err != nil {
+	indexed, err := u.indexDocuments(ctx, docs)
+	if err != nil {
 		return nil, err
 	}
 
-	u.registerBatchSynonyms(ctx, docs)
+	u.registerBatchSynonyms(ctx, indexed)
 
 	return &IndexResult{
-		IndexedCount:  len(docs),
+		IndexedCount:  len(indexed),
+		RejectedCount: len(docs) - len(indexed),
 		LastCreatedAt: newLastCreatedAt,
 		LastID:        newLastID,
 		Phase:         PhaseBackfill,
@@ -124,13 +143,15 @@ func (u *IndexArticlesUsecase) ExecuteIncremental(ctx context.Context, increment
 			docs = append(docs, domain.NewSearchDocument(article))
 		}
 
-		if err := u.searchEngine.IndexDocuments(ctx, docs); err != nil {
+		indexed, err := u.indexDocuments(ctx, docs)
+		if err != nil {
 			return nil, err
 		}
 
-		u.registerBatchSynonyms(ctx, docs)
+		u.registerBatchSynonyms(ctx, indexed)
 
-		result.IndexedCount = len(docs)
+		result.IndexedCount = len(indexed)
+		result.RejectedCount = len(docs) - len(indexed)
 		result.LastCreatedAt = newLastCreatedAt
 		result.LastID = newLastID
 	}
@@ -169,15 +190,16 @@ func (u *IndexArticlesUsecase) ExecuteSingleArticle(ctx context.Context, article
 		return nil, err
 	}
 
-	doc := domain.NewSearchDocument(article)
-	if err := u.searchEngine.IndexDocuments(ctx, []domain.SearchDocument{doc}); err != nil {
+	indexed, err := u.indexDocuments(ctx, []domain.SearchDocument{domain.NewSearchDocument(article)})
+	if err != nil {
 		return nil, err
 	}
 
-	u.registerBatchSynonyms(ctx, []domain.SearchDocument{doc})
+	u.registerBatchSynonyms(ctx, indexed)
 
 	return &IndexResult{
-		IndexedCount: 1,
+		IndexedCount:  len(indexed),
+		RejectedCount: 1 - len(indexed),
 	}, nil
 }
 
@@ -188,13 +210,14 @@ func (u *IndexArticlesUsecase) IndexDocumentsDirectly(ctx context.Context, docs
 		return &IndexResult{IndexedCount: 0}, nil
 	}
 
-	if err := u.searchEngine.IndexDocuments(ctx, docs); err != nil {
+	indexed, err := u.indexDocuments(ctx, docs)
+	if err != nil {
 		return nil, err
 	}
 
-	u.registerBatchSynonyms(ctx, docs)
+	u.registerBatchSynonyms(ctx, indexed)
 
-	return &IndexResult{IndexedCount: len(docs)}, nil
+	return &IndexResult{IndexedCount: len(indexed), RejectedCount: len(docs) - len(indexed)}, nil
 }
 
 // ExecuteBatchArticles indexes multiple articles by their IDs in a single batch.
@@ -224,13 +247,72 @@ func (u *IndexArticlesUsecase) ExecuteBatchArticles(ctx context.Context, article
 		return &IndexResult{IndexedCount: 0}, nil
 	}
 
-	if err := u.searchEngine.IndexDocuments(ctx, docs); err != nil {
+	indexed, err := u.indexDocuments(ctx, docs)
+	if err != nil {
 		return nil, err
 	}
 
-	u.registerBatchSynonyms(ctx, docs)
+	u.registerBatchSynonyms(ctx, indexed)
 
-	return &IndexResult{IndexedCount: len(docs)}, nil
+	return &IndexResult{IndexedCount: len(indexed), RejectedCount: len(docs) - len(indexed)}, nil
+}
+
+// DeleteArticles removes articles from the index by ID (for event-driven
+// deletion). Deleting an ID that is not indexed is not an error.
+func (u *IndexArticlesUsecase) DeleteArticles(ctx context.Context, articleIDs []string) (*IndexResult, error) {
+	if len(articleIDs) == 0 {
+		return &IndexResult{}, nil
+	}
+	if err := u.searchEngine.DeleteDocuments(ctx, articleIDs); err != nil {
+		return nil, err
+	}
+	return &IndexResult{DeletedCount: len(articleIDs)}, nil
+}
+
+// indexDocuments applies the document limits, stamps feed popularity onto
+// what is left and indexes it, returning the documents actually written.
+// Every write path goes through here because Meilisearch replaces whole
+// documents, so a path that skipped the stamp would reset feed_popularity
+// to 0.
+func (u *IndexArticlesUsecase) indexDocuments(ctx context.Context, docs []domain.SearchDocument) ([]domain.SearchDocument, error) {
+	docs = u.applyDocumentLimits(ctx, docs)
+	if len(docs) == 0 {
+		return docs, nil
+	}
+	u.stampFeedPopularity(ctx, docs)
+	return docs, u.searchEngine.IndexDocuments(ctx, docs)
+}
+
+// stampFeedPopularity fills FeedPopularity for every doc with a FeedID. A
+// lookup failure is logged and leaves the batch at 0: the signal only breaks
+// ties, so it must never block indexing.
+func (u *IndexArticlesUsecase) stampFeedPopularity(ctx context.Context, docs []domain.SearchDocument) {
+	if u.feedPopularity == nil {
+		return
+	}
+	seen := make(map[string]struct{})
+	feedIDs := make([]string, 0)
+	for _, doc := range docs {
+		if doc.FeedID == "" {
+			continue
+		}
+		if _, ok := seen[doc.FeedID]; !ok {
+			seen[doc.FeedID] = struct{}{}
+			feedIDs = append(feedIDs, doc.FeedID)
+		}
+	}
+	if len(feedIDs) == 0 {
+		return
+	}
+
+	counts, err := u.feedPopularity.FeedPopularity(ctx, feedIDs)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load feed popularity, indexing without it", "error", err, "feed_count", len(feedIDs))
+		return
+	}
+	for i := range docs {
+		docs[i].FeedPopularity = counts[docs[i].FeedID]
+	}
 }
 
 // registerBatchSynonyms merges synonyms for every doc in the batch into the
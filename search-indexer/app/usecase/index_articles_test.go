@@ -141,6 +141,12 @@ func (m *mockSearchEngineForIndexing) SearchWithDateFilter(ctx context.Context,
 	}
 	return m.indexedDocs, nil
 }
+func (m *mockSearchEngineForIndexing) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return m.indexedDocs, int64(len(m.indexedDocs)), nil
+}
 
 func (m *mockSearchEngineForIndexing) EnsureIndex(ctx context.Context) error {
 	return nil
@@ -412,6 +418,28 @@ func TestExecuteBatchArticles_PropagatesNonNotFoundError(t *testing.T) {
 	}
 }
 
+// TestDeleteArticles_RemovesFromIndex covers the event-driven deletion path.
+func TestDeleteArticles_RemovesFromIndex(t *testing.T) {
+	now := time.Now()
+	keep, _ := domain.NewArticle("keep-1", "T1", "C1", []string{}, now, "u")
+	gone, _ := domain.NewArticle("gone-1", "T2", "C2", []string{}, now, "u")
+	engine := &mockSearchEngineForIndexing{indexedDocs: []domain.SearchDocument{
+		domain.NewSearchDocument(keep), domain.NewSearchDocument(gone),
+	}}
+	u := NewIndexArticlesUsecase(&mockArticleRepo{}, engine, nil)
+
+	result, err := u.DeleteArticles(context.Background(), []string{"gone-1"})
+	if err != nil {
+		t.Fatalf("DeleteArticles() error = %v", err)
+	}
+	if result.DeletedCount != 1 {
+		t.Fatalf("DeletedCount = %d, want 1", result.DeletedCount)
+	}
+	if len(engine.indexedDocs) != 1 || engine.indexedDocs[0].ID != "keep-1" {
+		t.Fatalf("indexedDocs = %+v, want exactly [keep-1]", engine.indexedDocs)
+	}
+}
+
 // TestExecuteSingleArticle_NotFound preserves the "0 indexed, no error"
 // contract for a single not-found article now that not-found is signalled
 // via an error rather than a nil article + nil error pair.
@@ -606,3 +634,60 @@ func TestFlushSynonyms_IndexingAloneDoesNotPUT(t *testing.T) {
 		t.Fatalf("RegisterSynonyms call count = %d after 2nd flush with nothing new, want 1", engine.synonymsCallCount)
 	}
 }
+
+type stubFeedPopularity struct {
+	counts map[string]int64
+	err    error
+	gotIDs []string
+}
+
+func (s *stubFeedPopularity) FeedPopularity(ctx context.Context, feedIDs []string) (map[string]int64, error) {
+	s.gotIDs = feedIDs
+	return s.counts, s.err
+}
+
+func TestIndexDocumentsDirectly_StampsFeedPopularity(t *testing.T) {
+	docs := []domain.SearchDocument{
+		{ID: "1", Title: "a", FeedID: "feed-a"},
+		{ID: "2", Title: "b", FeedID: "feed-b"},
+		{ID: "3", Title: "c", FeedID: "feed-a"},
+		{ID: "4", Title: "d"},
+	}
+
+	tests := []struct {
+		name string
+		pop  *stubFeedPopularity
+		want []int64
+	}{
+		{
+			name: "counts applied per feed",
+			pop:  &stubFeedPopularity{counts: map[string]int64{"feed-a": 42}},
+			want: []int64{42, 0, 42, 0},
+		},
+		{
+			name: "lookup failure indexes without popularity",
+			pop:  &stubFeedPopularity{err: errors.New("meilisearch down")},
+			want: []int64{0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := &mockSearchEngineForIndexing{}
+			u := NewIndexArticlesUsecase(&mockArticleRepo{}, engine, nil).WithFeedPopularity(tt.pop)
+
+			batch := append([]domain.SearchDocument(nil), docs...)
+			if _, err := u.IndexDocumentsDirectly(context.Background(), batch); err != nil {
+				t.Fatalf("IndexDocumentsDirectly: %v", err)
+			}
+			if len(tt.pop.gotIDs) != 2 {
+				t.Errorf("FeedPopularity called with %v, want the 2 distinct feed IDs", tt.pop.gotIDs)
+			}
+			for i, want := range tt.want {
+				if got := engine.indexedDocs[i].FeedPopularity; got != want {
+					t.Errorf("doc %s FeedPopularity = %d, want %d", engine.indexedDocs[i].ID, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"search-indexer/domain"
+	"slices"
+	"strings"
+)
+
+// Personalization boosts are added to Meilisearch's _rankingScore (0..1).
+// They are small enough that a clearly more relevant hit still wins, but
+// reorder near-ties in favor of the user's own feeds and recent topics.
+const (
+	subscribedFeedBoost = 0.15
+	recentTopicBoost    = 0.05
+	maxTopicBoost       = 0.10
+)
+
+// Personalize reranks hits using the caller's subscribed feeds and recently
+// read topics. Boosted scores are written back to Score so the response
+// order and scores stay consistent. Ties keep the engine's order. Hits are
+// reranked within the page Meilisearch returned; personalization never
+// pulls in documents the query did not already match.
+func Personalize(docs []domain.SearchDocument, p domain.SearchPersonalization) []domain.SearchDocument {
+	if p.IsEmpty() || len(docs) == 0 {
+		return docs
+	}
+
+	feeds := make(map[string]struct{}, len(p.FeedIDs))
+	for _, id := range p.FeedIDs {
+		feeds[id] = struct{}{}
+	}
+	topics := make(map[string]struct{}, len(p.Tags))
+	for _, tag := range p.Tags {
+		topics[strings.ToLower(tag)] = struct{}{}
+	}
+
+	out := slices.Clone(docs)
+	for i := range out {
+		boost := 0.0
+		if _, ok := feeds[out[i].FeedID]; ok && out[i].FeedID != "" {
+			boost += subscribedFeedBoost
+		}
+		topicBoost := 0.0
+		for _, tag := range out[i].Tags {
+			if _, ok := topics[strings.ToLower(tag)]; ok {
+				topicBoost += recentTopicBoost
+			}
+		}
+		boost += min(topicBoost, maxTopicBoost)
+		out[i].Score += boost
+	}
+
+	slices.SortStableFunc(out, func(a, b domain.SearchDocument) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return out
+}
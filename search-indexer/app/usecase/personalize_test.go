@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"search-indexer/domain"
+	"testing"
+)
+
+func TestPersonalize(t *testing.T) {
+	docs := []domain.SearchDocument{
+		{ID: "generic", FeedID: "f-other", Tags: []string{"misc"}, Score: 0.80},
+		{ID: "subscribed", FeedID: "f-sub", Tags: []string{"misc"}, Score: 0.70},
+		{ID: "topic", FeedID: "f-other", Tags: []string{"Go", "rust", "misc"}, Score: 0.72},
+		{ID: "irrelevant-sub", FeedID: "f-sub", Score: 0.20},
+	}
+
+	tests := []struct {
+		name string
+		p    domain.SearchPersonalization
+		want []string
+	}{
+		{
+			name: "empty personalization keeps engine order",
+			p:    domain.SearchPersonalization{},
+			want: []string{"generic", "subscribed", "topic", "irrelevant-sub"},
+		},
+		{
+			name: "subscribed feed wins a near tie",
+			p:    domain.SearchPersonalization{FeedIDs: []string{"f-sub"}},
+			want: []string{"subscribed", "generic", "topic", "irrelevant-sub"},
+		},
+		{
+			name: "topic boost is case-insensitive and capped",
+			p:    domain.SearchPersonalization{Tags: []string{"go", "RUST", "misc"}},
+			want: []string{"generic", "topic", "subscribed", "irrelevant-sub"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Personalize(docs, tt.p)
+			if len(got) != len(tt.want) {
+				t.Fatalf("len = %d, want %d", len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("position %d = %q, want %q (order %v)", i, got[i].ID, id, docIDs(got))
+				}
+			}
+		})
+	}
+
+	if docs[0].ID != "generic" || docs[1].Score != 0.70 {
+		t.Error("Personalize must not mutate the caller's slice")
+	}
+}
+
+func docIDs(docs []domain.SearchDocument) []string {
+	out := make([]string, len(docs))
+	for i, d := range docs {
+		out[i] = d.ID
+	}
+	return out
+}
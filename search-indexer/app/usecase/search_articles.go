@@ -16,12 +16,16 @@ import (
 
 type SearchArticlesUsecase struct {
 	searchEngine port.SearchEngine
+	eventSink    port.SearchEventSink
 }
 
 type SearchResult struct {
 	Query     string
 	Documents []domain.SearchDocument
 	Total     int
+	// Offset and EstimatedTotalHits are set by ExecutePage only.
+	Offset             int64
+	EstimatedTotalHits int64
 }
 
 func NewSearchArticlesUsecase(searchEngine port.SearchEngine) *SearchArticlesUsecase {
@@ -30,6 +34,12 @@ func NewSearchArticlesUsecase(searchEngine port.SearchEngine) *SearchArticlesUse
 	}
 }
 
+// WithEventSink records every executed search to sink for analytics.
+func (u *SearchArticlesUsecase) WithEventSink(sink port.SearchEventSink) *SearchArticlesUsecase {
+	u.eventSink = sink
+	return u
+}
+
 // Structural validation patterns. Meilisearch does not execute SQL, shell
 // commands, or render HTML, so SQLi/XSS/cmd denylists only generate false
 // positives against legitimate searches. We keep validation limited to
@@ -116,7 +126,14 @@ func (u *SearchArticlesUsecase) Execute(ctx context.Context, query string, limit
 		return nil, err
 	}
 
+	start := time.Now()
 	documents, err := u.searchEngine.Search(ctx, sanitizedQuery, limit)
+	recordSearchEvent(u.eventSink, domain.SearchEvent{
+		Kind:        domain.SearchEventArticles,
+		Query:       sanitizedQuery,
+		Limit:       limit,
+		ResultCount: len(documents),
+	}, start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +156,16 @@ func (u *SearchArticlesUsecase) ExecuteWithDateFilter(ctx context.Context, query
 		return nil, err
 	}
 
+	start := time.Now()
 	documents, err := u.searchEngine.SearchWithDateFilter(ctx, sanitizedQuery, publishedAfter, publishedBefore, limit)
+	recordSearchEvent(u.eventSink, domain.SearchEvent{
+		Kind:            domain.SearchEventArticlesByDate,
+		Query:           sanitizedQuery,
+		PublishedAfter:  publishedAfter,
+		PublishedBefore: publishedBefore,
+		Limit:           limit,
+		ResultCount:     len(documents),
+	}, start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -150,3 +176,46 @@ func (u *SearchArticlesUsecase) ExecuteWithDateFilter(ctx context.Context, query
 		Total:     len(documents),
 	}, nil
 }
+
+// ExecutePage is the paginated form of ExecuteWithDateFilter: it returns the
+// page starting at offset together with the engine's estimated total. Pages
+// never reach past domain.MaxSearchOffset; a page that would start there
+// fails with domain.ErrSearchPageTooDeep and one that straddles it is cut
+// short.
+func (u *SearchArticlesUsecase) ExecutePage(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset int64, limit int) (*SearchResult, error) {
+	sanitizedQuery, err := validateAndSanitizeQuery(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	if offset >= domain.MaxSearchOffset {
+		return nil, domain.ErrSearchPageTooDeep
+	}
+	pageLimit := min(int64(limit), domain.MaxSearchOffset-offset)
+
+	start := time.Now()
+	documents, total, err := u.searchEngine.SearchWithPagination(ctx, sanitizedQuery, publishedAfter, publishedBefore, offset, pageLimit)
+	recordSearchEvent(u.eventSink, domain.SearchEvent{
+		Kind:               domain.SearchEventArticlesPage,
+		Query:              sanitizedQuery,
+		PublishedAfter:     publishedAfter,
+		PublishedBefore:    publishedBefore,
+		Offset:             offset,
+		Limit:              int(pageLimit),
+		ResultCount:        len(documents),
+		EstimatedTotalHits: total,
+	}, start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchResult{
+		Query:              sanitizedQuery,
+		Documents:          documents,
+		Total:              len(documents),
+		Offset:             offset,
+		EstimatedTotalHits: total,
+	}, nil
+}
@@ -59,6 +59,13 @@ func (m *mockSearchEngine) SearchWithDateFilter(ctx context.Context, query strin
 	return m.indexedDocs, nil
 }
 
+func (m *mockSearchEngine) SearchWithPagination(ctx context.Context, query string, publishedAfter, publishedBefore *time.Time, offset, limit int64) ([]domain.SearchDocument, int64, error) {
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return m.indexedDocs, int64(len(m.indexedDocs)), nil
+}
+
 func (m *mockSearchEngine) EnsureIndex(ctx context.Context) error {
 	return m.err
 }
@@ -5,17 +5,25 @@ import (
 	"errors"
 	"search-indexer/domain"
 	"search-indexer/port"
+	"time"
 )
 
 // SearchByUserUsecase handles user-scoped search operations.
 type SearchByUserUsecase struct {
 	searchEngine port.SearchEngine
+	eventSink    port.SearchEventSink
 }
 
 func NewSearchByUserUsecase(searchEngine port.SearchEngine) *SearchByUserUsecase {
 	return &SearchByUserUsecase{searchEngine: searchEngine}
 }
 
+// WithEventSink records every executed search to sink for analytics.
+func (u *SearchByUserUsecase) WithEventSink(sink port.SearchEventSink) *SearchByUserUsecase {
+	u.eventSink = sink
+	return u
+}
+
 // SearchByUserResult holds the result of a user-scoped search.
 type SearchByUserResult struct {
 	Query              string
@@ -38,7 +46,15 @@ func (u *SearchByUserUsecase) Execute(ctx context.Context, query, userID string)
 		return nil, err
 	}
 
+	start := time.Now()
 	docs, err := u.searchEngine.SearchByUserID(ctx, sanitizedQuery, userID, 20)
+	recordSearchEvent(u.eventSink, domain.SearchEvent{
+		Kind:        domain.SearchEventByUser,
+		Query:       sanitizedQuery,
+		UserID:      userID,
+		Limit:       20,
+		ResultCount: len(docs),
+	}, start, err)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +83,17 @@ func (u *SearchByUserUsecase) ExecuteWithPagination(ctx context.Context, query,
 		return nil, err
 	}
 
+	start := time.Now()
 	docs, total, err := u.searchEngine.SearchByUserIDWithPagination(ctx, sanitizedQuery, userID, offset, limit)
+	recordSearchEvent(u.eventSink, domain.SearchEvent{
+		Kind:               domain.SearchEventByUserPage,
+		Query:              sanitizedQuery,
+		UserID:             userID,
+		Offset:             offset,
+		Limit:              int(effectiveLimit),
+		ResultCount:        len(docs),
+		EstimatedTotalHits: total,
+	}, start, err)
 	if err != nil {
 		return nil, err
 	}
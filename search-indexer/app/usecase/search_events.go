@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"search-indexer/domain"
+	"search-indexer/port"
+	"time"
+)
+
+// recordSearchEvent stamps event with the search's start time, latency and
+// error and hands it to sink. A nil sink (analytics disabled) is a no-op.
+func recordSearchEvent(sink port.SearchEventSink, event domain.SearchEvent, start time.Time, err error) {
+	if sink == nil {
+		return
+	}
+	event.OccurredAt = start
+	event.Latency = time.Since(start)
+	if err != nil {
+		event.Error = err.Error()
+		event.ResultCount = 0
+	}
+	sink.Record(event)
+}
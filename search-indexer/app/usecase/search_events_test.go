@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"search-indexer/domain"
+	"testing"
+	"time"
+)
+
+type recordingEventSink struct {
+	events []domain.SearchEvent
+}
+
+func (r *recordingEventSink) Record(event domain.SearchEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestSearchArticlesUsecase_RecordsSearchEvents(t *testing.T) {
+	engine := &mockSearchEngine{indexedDocs: []domain.SearchDocument{{ID: "1"}, {ID: "2"}}}
+	sink := &recordingEventSink{}
+	uc := NewSearchArticlesUsecase(engine).WithEventSink(sink)
+
+	after := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := uc.Execute(context.Background(), "  go   generics ", 10); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if _, err := uc.ExecutePage(context.Background(), "go", &after, nil, 20, 10); err != nil {
+		t.Fatalf("ExecutePage: %v", err)
+	}
+	// Rejected by validation: never reaches the engine, so not recorded.
+	if _, err := uc.Execute(context.Background(), "", 10); err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	if len(sink.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(sink.events))
+	}
+	plain := sink.events[0]
+	if plain.Kind != domain.SearchEventArticles || plain.Query != "go generics" || plain.Limit != 10 || plain.ResultCount != 2 {
+		t.Errorf("unexpected event %+v", plain)
+	}
+	if plain.OccurredAt.IsZero() {
+		t.Error("OccurredAt not set")
+	}
+	page := sink.events[1]
+	if page.Kind != domain.SearchEventArticlesPage || page.Offset != 20 || page.EstimatedTotalHits != 2 || page.PublishedAfter != &after {
+		t.Errorf("unexpected page event %+v", page)
+	}
+}
+
+func TestSearchByUserUsecase_RecordsFailedSearch(t *testing.T) {
+	engine := &mockSearchEngine{err: errors.New("meilisearch down")}
+	sink := &recordingEventSink{}
+	uc := NewSearchByUserUsecase(engine).WithEventSink(sink)
+
+	if _, err := uc.Execute(context.Background(), "rust", "user-1"); err == nil {
+		t.Fatal("expected engine error")
+	}
+
+	if len(sink.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(sink.events))
+	}
+	ev := sink.events[0]
+	if ev.Kind != domain.SearchEventByUser || ev.UserID != "user-1" || ev.Error != "meilisearch down" || ev.ResultCount != 0 {
+		t.Errorf("unexpected event %+v", ev)
+	}
+}
+
+func TestSearchArticlesUsecase_NoSinkIsNoop(t *testing.T) {
+	uc := NewSearchArticlesUsecase(&mockSearchEngine{})
+	if _, err := uc.Execute(context.Background(), "go", 10); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+}